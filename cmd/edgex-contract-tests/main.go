@@ -0,0 +1,16 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+
+	"github.com/edgexfoundry/edgex-go/internal/system/contract"
+)
+
+func main() {
+	os.Exit(contract.Main(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system/keyspace"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// passwordEnv holds the Redis password, which is kept off the command line
+const passwordEnv = "REDIS_PASSWORD"
+
+func main() {
+	host := flag.String("host", "localhost", "Redis host")
+	port := flag.Int("port", 6379, "Redis port")
+	timeout := flag.Duration("timeout", 5*time.Second, "Timeout of the Redis commands")
+	memory := flag.Bool("memory", true, "Report the memory used by the keys, which requires Redis 4 or later")
+	top := flag.Int("top", 10, "Number of devices listed by reading volume")
+	samples := flag.Int("samples", 5, "Number of keys listed for each kind of orphaned keys")
+	jsonOutput := flag.Bool("json", false, "Write the report as JSON")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "Reports the keys and memory used by the EdgeX collections and indexes stored in Redis, the orphaned keys\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "and the devices with the most readings. The Redis password, if any, is read from %s.\n\n", passwordEnv)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	conn, err := redis.Dial("tcp", fmt.Sprintf("%s:%d", *host, *port),
+		redis.DialPassword(os.Getenv(passwordEnv)),
+		redis.DialConnectTimeout(*timeout),
+		redis.DialReadTimeout(*timeout),
+		redis.DialWriteTimeout(*timeout))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to Redis: %s\n", err.Error())
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	analyzer := keyspace.NewAnalyzer(conn, keyspace.Options{Memory: *memory, Top: *top, Samples: *samples})
+	report, err := analyzer.Analyze()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		conn.Close()
+		os.Exit(1)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	} else {
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write the report: %s\n", err.Error())
+		conn.Close()
+		os.Exit(1)
+	}
+}
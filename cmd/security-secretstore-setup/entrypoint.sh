@@ -35,6 +35,18 @@ fi
 echo "$(date) Changing ownership of secrets to ${EDGEX_USER}:${EDGEX_GROUP}"
 chown -Rh ${EDGEX_USER}:${EDGEX_GROUP} /tmp/edgex/secrets
 
+# Optionally keep watching Vault so that it is re-unsealed if it restarts sealed
+if [ "${SECRETSTORE_WATCHDOG}" = "true" ]; then
+  echo "$(date) Starting secret store watchdog"
+  /security-secretstore-setup --watchdog=true &
+fi
+
+# Optionally back up Vault's raft storage to encrypted snapshots
+if [ "${SECRETSTORE_SNAPSHOTS}" = "true" ]; then
+  echo "$(date) Starting secret store snapshots"
+  /security-secretstore-setup snapshot &
+fi
+
 # Signal tokens ready port for other services waiting on
 /edgex-init/security-bootstrapper --confdir=/edgex-init/res listenTcp \
   --port="${STAGEGATE_SECRETSTORESETUP_TOKENS_READYPORT}" --host="${STAGEGATE_SECRETSTORESETUP_HOST}"
@@ -5,6 +5,7 @@ require (
 	github.com/BurntSushi/toml v0.3.1
 	github.com/OneOfOne/xxhash v1.2.8
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
+	github.com/eclipse/paho.mqtt.golang v1.3.0
 	github.com/edgexfoundry/go-mod-bootstrap/v2 v2.0.0-dev.7
 	github.com/edgexfoundry/go-mod-configuration/v2 v2.0.0-dev.1
 	github.com/edgexfoundry/go-mod-core-contracts/v2 v2.0.0-dev.33
@@ -13,14 +14,18 @@ require (
 	github.com/edgexfoundry/go-mod-secrets/v2 v2.0.0-dev.3
 	github.com/fxamacker/cbor/v2 v2.2.0
 	github.com/gomodule/redigo v2.0.0+incompatible
+	github.com/google/go-tpm v0.3.2
 	github.com/google/uuid v1.2.0
 	github.com/gorilla/mux v1.8.0
+	github.com/graphql-go/graphql v0.8.1
 	github.com/imdario/mergo v0.3.11
 	github.com/lib/pq v1.9.0
+	github.com/pion/dtls/v2 v2.0.8
 	github.com/pkg/errors v0.8.1
 	github.com/robfig/cron v0.0.0-20180505203441-b41be1df6967
+	github.com/segmentio/kafka-go v0.4.17
 	github.com/stretchr/testify v1.7.0
-	golang.org/x/crypto v0.0.0-20190308221718-c2843e01d9a2
+	golang.org/x/crypto v0.0.0-20201221181555-eec23a3978ad
 	gopkg.in/eapache/queue.v1 v1.1.0
 	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,79 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package analytics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Route variables of the V1 and V2 routes issuing commands
+var (
+	deviceVars  = []string{"name", "id"}
+	commandVars = []string{"commandname", "commandid", "command"}
+)
+
+// statusWriter remembers the status code written by the handler
+type statusWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// NewMiddleware returns a middleware that records the latency and outcome of the requests issuing a command
+// in tracker. Devices are identified by the name or id given in the route. It should be used after the
+// authentication middlewares so that rejected requests are not counted as command failures.
+func NewMiddleware(tracker *Tracker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			vars := mux.Vars(r)
+			commandName := firstVar(vars, commandVars)
+			if commandName == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sw := &statusWriter{ResponseWriter: w}
+			start := time.Now()
+			next.ServeHTTP(sw, r)
+			if sw.statusCode == 0 {
+				sw.statusCode = http.StatusOK
+			}
+			tracker.Record(firstVar(vars, deviceVars), commandName, time.Since(start), sw.statusCode)
+		})
+	}
+}
+
+func firstVar(vars map[string]string, keys []string) string {
+	for _, key := range keys {
+		if vars[key] != "" {
+			return vars[key]
+		}
+	}
+	return ""
+}
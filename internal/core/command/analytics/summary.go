@@ -0,0 +1,111 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+)
+
+const defaultSummaryTopN = 10
+
+// AnalyticsInfo configures the periodic publication of the command usage summary
+type AnalyticsInfo struct {
+	// SummaryInterval is how often the summary is published to the message bus, e.g. "5m". No summary is
+	// published when empty.
+	SummaryInterval string
+	// SummaryTopic is the message bus topic the summary is published to
+	SummaryTopic string
+	// SummaryTopN is the number of commands, those with the most errors first, included in the summary
+	SummaryTopN int
+}
+
+// Summary is the message published every AnalyticsInfo.SummaryInterval
+type Summary struct {
+	// Timestamp is when the summary was made, in milliseconds since the epoch
+	Timestamp int64 `json:"timestamp"`
+	// Since is when the counting started, in milliseconds since the epoch
+	Since    int64          `json:"since"`
+	Commands []CommandStats `json:"commands"`
+}
+
+// Publisher publishes messages to the message bus, e.g. a messaging.MessageClient
+type Publisher interface {
+	Publish(message msgTypes.MessageEnvelope, topic string) error
+}
+
+// NewSummary returns the summary of the topN commands with the most errors
+func NewSummary(tracker *Tracker, topN int) Summary {
+	if topN <= 0 {
+		topN = defaultSummaryTopN
+	}
+	commands, _ := tracker.Query("", SortByErrors)
+	if len(commands) > topN {
+		commands = commands[:topN]
+	}
+	return Summary{
+		Timestamp: toMillis(tracker.now()),
+		Since:     tracker.Since(),
+		Commands:  commands,
+	}
+}
+
+// PublishSummary publishes the summary of tracker to the configured topic
+func PublishSummary(tracker *Tracker, publisher Publisher, info AnalyticsInfo) error {
+	data, err := json.Marshal(NewSummary(tracker, info.SummaryTopN))
+	if err != nil {
+		return err
+	}
+	ctx := correlation.NewContext(context.WithValue(context.Background(), clients.ContentType, clients.ContentTypeJSON))
+	return publisher.Publish(msgTypes.NewMessageEnvelope(data, ctx), info.SummaryTopic)
+}
+
+// RunSummary publishes the summary of tracker every info.SummaryInterval until ctx is done
+func RunSummary(ctx context.Context, wg *sync.WaitGroup, lc logger.LoggingClient, tracker *Tracker, publisher Publisher, info AnalyticsInfo) error {
+	interval, err := time.ParseDuration(info.SummaryInterval)
+	if err != nil {
+		return fmt.Errorf("invalid Analytics.SummaryInterval '%s': %s", info.SummaryInterval, err.Error())
+	}
+	if interval <= 0 {
+		return fmt.Errorf("Analytics.SummaryInterval must be positive, got '%s'", info.SummaryInterval)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := PublishSummary(tracker, publisher, info); err != nil {
+					lc.Error(fmt.Sprintf("failed to publish the command usage summary: %s", err.Error()))
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,179 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package analytics tracks how often each command of each device is issued through core-command, how long the
+// device services take to respond and how often they fail, so that the devices causing the most actuation
+// errors can be found.
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Orders accepted by Tracker.Query
+const (
+	SortByErrors    = "errors"
+	SortByErrorRate = "errorRate"
+	SortByCount     = "count"
+	SortByLatency   = "latency"
+)
+
+// CommandStats are the usage statistics of one command of one device
+type CommandStats struct {
+	DeviceName       string  `json:"deviceName"`
+	CommandName      string  `json:"commandName"`
+	Count            uint64  `json:"count"`
+	Errors           uint64  `json:"errors"`
+	ErrorRate        float64 `json:"errorRate"`
+	AverageLatencyMs float64 `json:"averageLatencyMs"`
+	MaxLatencyMs     float64 `json:"maxLatencyMs"`
+	// LastErrorStatus is the HTTP status code of the last failed invocation
+	LastErrorStatus int `json:"lastErrorStatus,omitempty"`
+	// LastErrorTimestamp is the time of the last failed invocation, in milliseconds since the epoch
+	LastErrorTimestamp int64 `json:"lastErrorTimestamp,omitempty"`
+}
+
+type commandKey struct {
+	deviceName  string
+	commandName string
+}
+
+type commandCounters struct {
+	count           uint64
+	errors          uint64
+	totalLatency    time.Duration
+	maxLatency      time.Duration
+	lastErrorStatus int
+	lastError       time.Time
+}
+
+// Tracker accumulates the usage statistics of the commands since the service started
+type Tracker struct {
+	mutex    sync.Mutex
+	now      func() time.Time
+	since    time.Time
+	counters map[commandKey]*commandCounters
+}
+
+// NewTracker creates an empty Tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		now:      time.Now,
+		since:    time.Now(),
+		counters: make(map[commandKey]*commandCounters),
+	}
+}
+
+// Record adds an invocation of the command of the device that took latency and responded statusCode
+func (t *Tracker) Record(deviceName string, commandName string, latency time.Duration, statusCode int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := commandKey{deviceName: deviceName, commandName: commandName}
+	counters, ok := t.counters[key]
+	if !ok {
+		counters = &commandCounters{}
+		t.counters[key] = counters
+	}
+
+	counters.count++
+	counters.totalLatency += latency
+	if latency > counters.maxLatency {
+		counters.maxLatency = latency
+	}
+	if statusCode >= 400 {
+		counters.errors++
+		counters.lastErrorStatus = statusCode
+		counters.lastError = t.now()
+	}
+}
+
+// Since returns when the tracking started, in milliseconds since the epoch
+func (t *Tracker) Since() int64 {
+	return toMillis(t.since)
+}
+
+// Query returns the statistics of the commands of deviceName, or of all devices when it is empty, ordered by
+// sortBy, highest first
+func (t *Tracker) Query(deviceName string, sortBy string) ([]CommandStats, error) {
+	var less func(a, b CommandStats) bool
+	switch sortBy {
+	case SortByErrors, "":
+		less = func(a, b CommandStats) bool { return a.Errors > b.Errors }
+	case SortByErrorRate:
+		less = func(a, b CommandStats) bool { return a.ErrorRate > b.ErrorRate }
+	case SortByCount:
+		less = func(a, b CommandStats) bool { return a.Count > b.Count }
+	case SortByLatency:
+		less = func(a, b CommandStats) bool { return a.AverageLatencyMs > b.AverageLatencyMs }
+	default:
+		return nil, fmt.Errorf("unsupported sort order '%s', expected one of %s, %s, %s or %s",
+			sortBy, SortByErrors, SortByErrorRate, SortByCount, SortByLatency)
+	}
+
+	t.mutex.Lock()
+	stats := make([]CommandStats, 0, len(t.counters))
+	for key, counters := range t.counters {
+		if deviceName != "" && key.deviceName != deviceName {
+			continue
+		}
+		stats = append(stats, counters.stats(key))
+	}
+	t.mutex.Unlock()
+
+	// Ties are ordered by device and command name so that the order, and thus paging, is stable
+	sort.Slice(stats, func(i, j int) bool {
+		if less(stats[i], stats[j]) {
+			return true
+		}
+		if less(stats[j], stats[i]) {
+			return false
+		}
+		if stats[i].DeviceName != stats[j].DeviceName {
+			return stats[i].DeviceName < stats[j].DeviceName
+		}
+		return stats[i].CommandName < stats[j].CommandName
+	})
+	return stats, nil
+}
+
+func (c *commandCounters) stats(key commandKey) CommandStats {
+	stats := CommandStats{
+		DeviceName:   key.deviceName,
+		CommandName:  key.commandName,
+		Count:        c.count,
+		Errors:       c.errors,
+		MaxLatencyMs: durationToMillis(c.maxLatency),
+	}
+	if c.count > 0 {
+		stats.ErrorRate = float64(c.errors) / float64(c.count)
+		stats.AverageLatencyMs = durationToMillis(c.totalLatency) / float64(c.count)
+	}
+	if c.errors > 0 {
+		stats.LastErrorStatus = c.lastErrorStatus
+		stats.LastErrorTimestamp = toMillis(c.lastError)
+	}
+	return stats
+}
+
+func durationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
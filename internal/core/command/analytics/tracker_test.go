@@ -0,0 +1,150 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package analytics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTracker() *Tracker {
+	tracker := NewTracker()
+	tracker.Record("device1", "reset", 10*time.Millisecond, http.StatusOK)
+	tracker.Record("device1", "reset", 30*time.Millisecond, http.StatusInternalServerError)
+	tracker.Record("device1", "status", time.Millisecond, http.StatusOK)
+	tracker.Record("device1", "status", time.Millisecond, http.StatusOK)
+	tracker.Record("device1", "status", time.Millisecond, http.StatusOK)
+	tracker.Record("device2", "reset", 5*time.Millisecond, http.StatusServiceUnavailable)
+	return tracker
+}
+
+func commandsOf(stats []CommandStats) []string {
+	commands := make([]string, len(stats))
+	for i, s := range stats {
+		commands[i] = s.DeviceName + "/" + s.CommandName
+	}
+	return commands
+}
+
+func TestTrackerStats(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return now }
+	tracker.Record("device1", "reset", 10*time.Millisecond, http.StatusOK)
+	tracker.Record("device1", "reset", 30*time.Millisecond, http.StatusBadGateway)
+
+	stats, err := tracker.Query("", "")
+
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, CommandStats{
+		DeviceName:         "device1",
+		CommandName:        "reset",
+		Count:              2,
+		Errors:             1,
+		ErrorRate:          0.5,
+		AverageLatencyMs:   20,
+		MaxLatencyMs:       30,
+		LastErrorStatus:    http.StatusBadGateway,
+		LastErrorTimestamp: 1000000,
+	}, stats[0])
+}
+
+func TestTrackerQuery(t *testing.T) {
+	tracker := newTestTracker()
+
+	tests := []struct {
+		name     string
+		device   string
+		sortBy   string
+		expected []string
+	}{
+		{"by errors", "", SortByErrors, []string{"device1/reset", "device2/reset", "device1/status"}},
+		{"by error rate", "", SortByErrorRate, []string{"device2/reset", "device1/reset", "device1/status"}},
+		{"by count", "", SortByCount, []string{"device1/status", "device1/reset", "device2/reset"}},
+		{"by latency", "", SortByLatency, []string{"device1/reset", "device2/reset", "device1/status"}},
+		{"one device", "device1", SortByCount, []string{"device1/status", "device1/reset"}},
+		{"unknown device", "device3", SortByErrors, []string{}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			stats, err := tracker.Query(testCase.device, testCase.sortBy)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, commandsOf(stats))
+		})
+	}
+
+	_, err := tracker.Query("", "unknown")
+	assert.Error(t, err)
+}
+
+func TestMiddleware(t *testing.T) {
+	tracker := NewTracker()
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/device/{id}/command/{commandid}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	router.HandleFunc("/api/v2/device/name/{name}/{command}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	})
+	router.HandleFunc("/api/v2/device/name/{name}", func(w http.ResponseWriter, r *http.Request) {})
+	router.Use(NewMiddleware(tracker))
+
+	for _, path := range []string{"/api/v1/device/id1/command/cmd1", "/api/v2/device/name/device1/reset", "/api/v2/device/name/device1"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	}
+
+	stats, err := tracker.Query("", SortByErrors)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id1/cmd1", "device1/reset"}, commandsOf(stats))
+	assert.Equal(t, uint64(1), stats[0].Errors)
+	assert.Equal(t, uint64(0), stats[1].Errors)
+}
+
+type capturingPublisher struct {
+	messages []msgTypes.MessageEnvelope
+	topics   []string
+}
+
+func (p *capturingPublisher) Publish(message msgTypes.MessageEnvelope, topic string) error {
+	p.messages = append(p.messages, message)
+	p.topics = append(p.topics, topic)
+	return nil
+}
+
+func TestPublishSummary(t *testing.T) {
+	tracker := newTestTracker()
+	publisher := &capturingPublisher{}
+
+	err := PublishSummary(tracker, publisher, AnalyticsInfo{SummaryTopic: "edgex/commandanalytics", SummaryTopN: 2})
+
+	require.NoError(t, err)
+	require.Len(t, publisher.messages, 1)
+	assert.Equal(t, "edgex/commandanalytics", publisher.topics[0])
+	var summary Summary
+	require.NoError(t, json.Unmarshal(publisher.messages[0].Payload, &summary))
+	assert.Equal(t, tracker.Since(), summary.Since)
+	assert.NotEmpty(t, publisher.messages[0].CorrelationID, "the summary must carry a correlation id")
+	assert.Equal(t, []string{"device1/reset", "device2/reset"}, commandsOf(summary.Commands))
+}
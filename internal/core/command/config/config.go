@@ -15,23 +15,80 @@
 package config
 
 import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/analytics"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/offline"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/remote"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/profiling"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/readonly"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/registrywatch"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
+
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 )
 
 // ConfigurationStruct contains the configuration properties for the core-command service.
 type ConfigurationStruct struct {
-	Writable    WritableInfo
-	Clients     map[string]bootstrapConfig.ClientInfo
-	Databases   map[string]bootstrapConfig.Database
-	Registry    bootstrapConfig.RegistryInfo
-	Service     bootstrapConfig.ServiceInfo
-	SecretStore bootstrapConfig.SecretStoreInfo
+	Writable      WritableInfo
+	Clients       map[string]bootstrapConfig.ClientInfo
+	Databases     map[string]bootstrapConfig.Database
+	Registry      bootstrapConfig.RegistryInfo
+	Service       bootstrapConfig.ServiceInfo
+	SecretStore   bootstrapConfig.SecretStoreInfo
+	JWTAuth       jwtauth.JWTAuthInfo
+	Audit         audit.AuditInfo
+	Analytics     analytics.AnalyticsInfo
+	MessageQueue  MessageQueueInfo
+	OutboundHTTP  httpclient.OutboundHTTPInfo
+	SecretCache   secretcache.SecretCacheInfo
+	RegistryWatch registrywatch.RegistryWatchInfo
+	OfflineQueue  offline.OfflineQueueInfo
+	// RemoteCommands executes the commands requested through an MQTT broker, verified with the keys of JWTAuth
+	RemoteCommands remote.RemoteCommandsInfo
 }
 
 // WritableInfo contains configuration properties that can be updated and applied without restarting the service.
 type WritableInfo struct {
 	LogLevel        string
+	ShutdownTimeout string
+	RequestLimits   requestlimits.RequestLimitsInfo
+	ProblemDetails  problem.ProblemDetailsInfo
+	CORS            cors.CORSInfo
+	ReadOnly        readonly.ReadOnlyInfo
+	Profiling       profiling.ProfilingInfo
 	InsecureSecrets bootstrapConfig.InsecureSecrets
+	// TransformSetParameters applies the inverse of the scale, offset, base, shift and mask transformations the
+	// device profiles declare to the PUT command parameters, so that clients send engineering-unit values
+	TransformSetParameters bool
+}
+
+// MessageQueueInfo provides parameters related to connecting to the message bus the command usage summary is
+// published to
+type MessageQueueInfo struct {
+	// Host is the hostname or IP address of the broker, if applicable.
+	Host string
+	// Port defines the port on which to access the message queue.
+	Port int
+	// Protocol indicates the protocol to use when accessing the message queue.
+	Protocol string
+	// Indicates the message queue platform being used.
+	Type string
+	// Provides additional configuration properties which do not fit within the existing field.
+	// Typically the key is the name of the configuration property and the value is a string representation of the
+	// desired value for the configuration property.
+	Optional map[string]string
+}
+
+// URL constructs a URL from the protocol, host and port and returns that as a string.
+func (m MessageQueueInfo) URL() string {
+	return fmt.Sprintf("%s://%s:%v", m.Protocol, m.Host, m.Port)
 }
 
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
@@ -92,6 +149,46 @@ func (c *ConfigurationStruct) GetDatabaseInfo() map[string]bootstrapConfig.Datab
 	return c.Databases
 }
 
+// GetRequestLimits returns the limits applied to incoming requests.
+func (c *ConfigurationStruct) GetRequestLimits() requestlimits.RequestLimitsInfo {
+	return c.Writable.RequestLimits
+}
+
+// GetSecretCache returns the configuration of the cache of the secrets read from the secret store.
+func (c *ConfigurationStruct) GetSecretCache() secretcache.SecretCacheInfo {
+	return c.SecretCache
+}
+
+// GetRegistryWatch returns the configuration of the watch of the registration of the service with the registry.
+func (c *ConfigurationStruct) GetRegistryWatch() registrywatch.RegistryWatchInfo {
+	return c.RegistryWatch
+}
+
+// GetProblemDetails returns whether the failed requests are responded to with problem details.
+func (c *ConfigurationStruct) GetProblemDetails() problem.ProblemDetailsInfo {
+	return c.Writable.ProblemDetails
+}
+
+// GetReadOnly returns the read-only mode applied to incoming requests.
+func (c *ConfigurationStruct) GetReadOnly() readonly.ReadOnlyInfo {
+	return c.Writable.ReadOnly
+}
+
+// GetProfiling returns the configuration of the profiling endpoints.
+func (c *ConfigurationStruct) GetProfiling() profiling.ProfilingInfo {
+	return c.Writable.Profiling
+}
+
+// GetCORS returns the CORS policy applied to incoming requests.
+func (c *ConfigurationStruct) GetCORS() cors.CORSInfo {
+	return c.Writable.CORS
+}
+
+// GetShutdownTimeout returns how long in-flight requests are given to complete when the service is stopped.
+func (c *ConfigurationStruct) GetShutdownTimeout() string {
+	return c.Writable.ShutdownTimeout
+}
+
 // GetInsecureSecrets returns the service's InsecureSecrets.
 func (c *ConfigurationStruct) GetInsecureSecrets() bootstrapConfig.InsecureSecrets {
 	return c.Writable.InsecureSecrets
@@ -0,0 +1,29 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/command/analytics"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// CommandTrackerName contains the name of the command usage analytics Tracker instance in the DIC.
+var CommandTrackerName = di.TypeInstanceToName(analytics.Tracker{})
+
+// CommandTrackerFrom helper function queries the DIC and returns the command usage analytics Tracker instance.
+func CommandTrackerFrom(get di.Get) *analytics.Tracker {
+	return get(CommandTrackerName).(*analytics.Tracker)
+}
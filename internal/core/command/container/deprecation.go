@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// DeprecationsClientName contains the name of the deprecations.Client implementation in the DIC.
+var DeprecationsClientName = di.TypeInstanceToName((*deprecations.Client)(nil))
+
+// DeprecationsClientFrom helper function queries the DIC and returns the deprecations.Client implementation, or nil
+// when none is registered.
+func DeprecationsClientFrom(get di.Get) deprecations.Client {
+	client, ok := get(DeprecationsClientName).(deprecations.Client)
+	if !ok {
+		return nil
+	}
+	return client
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/command/offline"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// OfflineQueueName contains the name of the offline.Queue instance in the DIC.
+var OfflineQueueName = di.TypeInstanceToName(offline.Queue{})
+
+// OfflineQueueFrom helper function queries the DIC and returns the offline.Queue, or nil if the commands of the
+// unreachable devices aren't queued.
+func OfflineQueueFrom(get di.Get) *offline.Queue {
+	queue, ok := get(OfflineQueueName).(*offline.Queue)
+	if !ok {
+		return nil
+	}
+	return queue
+}
@@ -24,7 +24,9 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/errors"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/offline"
 
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/metadata"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
@@ -38,7 +40,9 @@ func executeCommandByDeviceID(
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
 	deviceClient metadata.DeviceClient,
-	httpCaller internal.HttpCaller) (deviceServiceResponse *http.Response, theResponseBody string, failure error) {
+	httpCaller internal.HttpCaller,
+	transformParameters bool,
+	queue *offline.Queue) (deviceServiceResponse *http.Response, theResponseBody string, failure error) {
 
 	if originalRequest == nil {
 		return nil, "", errors.NewErrExtractingInfoFromRequest()
@@ -77,7 +81,7 @@ func executeCommandByDeviceID(
 		return nil, "", errors.NewErrCommandNotAssociatedWithDevice(commandID, deviceID)
 	}
 
-	return executeCommandByDevice(ctx, d, c, body, lc, originalRequest, httpCaller)
+	return executeCommandByDevice(ctx, d, c, body, lc, originalRequest, httpCaller, transformParameters, queue)
 }
 
 // extractDeviceIdAndCommandIdFromRequest extracts deviceID and commandID from r, which
@@ -104,7 +108,9 @@ func executeCommandByName(
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
 	deviceClient metadata.DeviceClient,
-	httpCaller internal.HttpCaller) (deviceServiceResponse *http.Response, theResponseBody string, failure error) {
+	httpCaller internal.HttpCaller,
+	transformParameters bool,
+	queue *offline.Queue) (deviceServiceResponse *http.Response, theResponseBody string, failure error) {
 
 	d, err := deviceClient.DeviceForName(ctx, dn)
 	if err != nil {
@@ -120,7 +126,7 @@ func executeCommandByName(
 		return nil, "", err
 	}
 
-	return executeCommandByDevice(ctx, d, command, body, lc, originalRequest, httpCaller)
+	return executeCommandByDevice(ctx, d, command, body, lc, originalRequest, httpCaller, transformParameters, queue)
 }
 
 func executeCommandByDevice(
@@ -130,7 +136,9 @@ func executeCommandByDevice(
 	body string,
 	lc logger.LoggingClient,
 	originalRequest *http.Request,
-	httpCaller internal.HttpCaller) (deviceServiceResponse *http.Response, theResponseBody string, failure error) {
+	httpCaller internal.HttpCaller,
+	transformParameters bool,
+	queue *offline.Queue) (deviceServiceResponse *http.Response, theResponseBody string, failure error) {
 
 	var method string
 	var ex Executor
@@ -142,6 +150,12 @@ func executeCommandByDevice(
 
 	switch originalRequest.Method {
 	case http.MethodPut:
+		// CBOR bodies carry binary values, which are sent as is
+		if transformParameters && originalRequest.Header.Get(clients.ContentType) != clients.ContentTypeCBOR {
+			if body, err = transformPutParameters(device, body); err != nil {
+				return nil, "", err
+			}
+		}
 		ex, err = NewPutCommand(device, command, body, ctx, httpCaller, lc, originalRequest)
 	case http.MethodGet:
 		ex, err = NewGetCommand(device, command, ctx, httpCaller, lc, originalRequest)
@@ -157,6 +171,10 @@ func executeCommandByDevice(
 	if err != nil {
 		return nil, "", err
 	}
+	// the device services answer 423 Locked for the devices they can't reach
+	if queue != nil && originalRequest.Method == http.MethodPut && deviceServiceResponse.StatusCode == http.StatusLocked {
+		deviceServiceResponse = queueCommand(queue, device, command, ex, body, originalRequest, deviceServiceResponse, lc)
+	}
 
 	responseBody := new(bytes.Buffer)
 	_, readErr := responseBody.ReadFrom(deviceServiceResponse.Body)
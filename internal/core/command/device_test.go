@@ -151,7 +151,9 @@ func TestExecuteGETCommandByDeviceIDAndCommandID(t *testing.T) {
 				logger.NewMockClient(),
 				newMockDBClient(),
 				newMockDeviceClient(),
-				httpCaller)
+				httpCaller,
+				false,
+				nil)
 			if actualErr == nil {
 				t.Fatal("expected error")
 			}
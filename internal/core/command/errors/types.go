@@ -27,6 +27,22 @@ func NewErrCommandNotAssociatedWithDevice(commandID string, deviceID string) err
 	return ErrCommandNotAssociatedWithDevice{commandID, deviceID}
 }
 
+// ErrInvalidParameters is a struct that serves as the value receiver for Error as defined for NewErrInvalidParameters
+type ErrInvalidParameters struct {
+	reason string
+}
+
+// Error returns a meaningful string message describing error details.
+func (e ErrInvalidParameters) Error() string {
+	return fmt.Sprintf("invalid command parameters: %s", e.reason)
+}
+
+// NewErrInvalidParameters returns the error of command parameters which can't be transformed into the device-native
+// values of their device resources.
+func NewErrInvalidParameters(reason string) error {
+	return ErrInvalidParameters{reason: reason}
+}
+
 // ErrExtractingInfoFromRequest is a struct that serves as the value
 // receiver for Error as defined for NewErrExtractingInfoFromRequest
 type ErrExtractingInfoFromRequest struct {
@@ -18,13 +18,29 @@ package command
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"sync"
 
+	"github.com/edgexfoundry/edgex-go/internal/core/command/analytics"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/offline"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/v2"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
 	errorContainer "github.com/edgexfoundry/edgex-go/internal/pkg/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/errorconcept"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/profiling"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/readonly"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/secret"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	V2Container "github.com/edgexfoundry/go-mod-bootstrap/v2/v2/bootstrap/container"
@@ -33,6 +49,8 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/urlclient/local"
 	V2Routes "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
 	V2Clients "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/clients/http"
+	"github.com/edgexfoundry/go-mod-messaging/v2/messaging"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
 	"github.com/gorilla/mux"
 )
 
@@ -49,9 +67,33 @@ func NewBootstrap(router *mux.Router) *Bootstrap {
 }
 
 // BootstrapHandler fulfills the BootstrapHandler contract and performs initialization needed by the command service.
-func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	// the optional subsystems of the service, reported at features.ApiCapabilitiesRoute
+	registry := features.NewRegistry(bootstrapContainer.LoggingClientFrom(dic.Get))
+	registry.Add(features.RemoteCommands, container.ConfigurationFrom(dic.Get).RemoteCommands.Enabled)
+	dic.Update(di.ServiceConstructorMap{
+		pkgContainer.FeaturesName: func(get di.Get) interface{} {
+			return registry
+		},
+	})
+
 	loadRestRoutes(b.router, dic)
 	v2.LoadRestRoutes(b.router, dic)
+	profiling.LoadRoutes(b.router, bootstrapContainer.LoggingClientFrom(dic.Get), container.ConfigurationFrom(dic.Get), bootstrapContainer.SecretProviderFrom(dic.Get))
+	b.router.Use(problem.NewMiddleware(clients.CoreCommandServiceKey, container.ConfigurationFrom(dic.Get)))
+	cors.UseMiddleware(b.router, container.ConfigurationFrom(dic.Get))
+	b.router.Use(requestlimits.NewMiddleware(bootstrapContainer.LoggingClientFrom(dic.Get), container.ConfigurationFrom(dic.Get)))
+	b.router.Use(readonly.NewMiddleware(bootstrapContainer.LoggingClientFrom(dic.Get), container.ConfigurationFrom(dic.Get)))
+	if err := jwtauth.UseMiddleware(b.router, dic, container.ConfigurationFrom(dic.Get).JWTAuth); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("failed to enable JWT verification: " + err.Error())
+		return false
+	}
+	audit.UseMiddleware(ctx, wg, b.router, dic, container.ConfigurationFrom(dic.Get).Audit, clients.CoreCommandServiceKey,
+		container.ConfigurationFrom(dic.Get).Clients["CoreData"].Url(), audit.ClassifyCommand)
+	b.router.Use(analytics.NewMiddleware(container.CommandTrackerFrom(dic.Get)))
+	if container.ConfigurationFrom(dic.Get).Analytics.SummaryInterval != "" && !startAnalyticsSummary(ctx, wg, startupTimer, dic) {
+		return false
+	}
 
 	// TODO: there is an outstanding known issue (https://github.com/edgexfoundry/edgex-go/issues/2462)
 	// 		that could be seemingly be solved by moving from JIT initialization of these external clients to static
@@ -63,8 +105,18 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _
 	configuration := container.ConfigurationFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	// the client of the V1 commands sent to the device services
+	httpClient, err := httpclient.NewClient(lc, configuration.OutboundHTTP, &http.Client{})
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid outbound HTTP configuration: %s", err.Error()))
+		return false
+	}
+
 	// initialize clients required by the service
 	dic.Update(di.ServiceConstructorMap{
+		pkgContainer.HTTPClientName: func(get di.Get) interface{} {
+			return httpClient
+		},
 		container.MetadataDeviceClientName: func(get di.Get) interface{} {
 			return metadata.NewDeviceClient(local.New(configuration.Clients["Metadata"].Url() + clients.ApiDeviceRoute))
 		},
@@ -83,7 +135,100 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _
 		V2Container.DeviceServiceCommandClientName: func(get di.Get) interface{} { // add v2 API DeviceServiceCommandClient
 			return V2Clients.NewDeviceServiceCommandClient()
 		},
+		container.DeprecationsClientName: func(get di.Get) interface{} {
+			return deprecations.NewClient(configuration.Clients["Metadata"].Url())
+		},
 	})
 
+	// the PUT commands of the unreachable devices, delivered once the devices are reachable again
+	queue, err := offline.NewQueue(configuration.OfflineQueue)
+	if err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+	if queue != nil {
+		dic.Update(di.ServiceConstructorMap{
+			container.OfflineQueueName: func(get di.Get) interface{} {
+				return queue
+			},
+		})
+		queue.Run(ctx, wg, queuedCommandSender(container.MetadataDeviceClientFrom(dic.Get), httpClient), lc)
+	}
+
+	// the commands requested through the MQTT broker are executed with the REST API, once fully set up above
+	if features.RemoteCommandsCompiled && configuration.RemoteCommands.Enabled && !startRemoteCommands(ctx, wg, dic, b.router) {
+		return false
+	}
+
+	return true
+}
+
+// startAnalyticsSummary connects to the message bus and starts publishing the command usage summary
+func startAnalyticsSummary(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	configuration := container.ConfigurationFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	// For Redis Streams MessageBus, we reuse the Redis instance running for the DB, which may have a password,
+	// so we need to get and use the DB credentials for the MessageBus connection.
+	if configuration.MessageQueue.Type == "redisstreams" {
+		secretProvider := bootstrapContainer.SecretProviderFrom(dic.Get)
+		credentials, err := secretProvider.GetSecrets(configuration.Databases["Primary"].Type)
+		if err != nil {
+			lc.Error(fmt.Sprintf("Error getting DB creds for RedisStreams: %s", err.Error()))
+			return false
+		}
+		configuration.MessageQueue.Optional["Password"] = credentials[secret.PasswordKey]
+	}
+
+	msgClient, err := messaging.NewMessageClient(
+		msgTypes.MessageBusConfig{
+			PublishHost: msgTypes.HostInfo{
+				Host:     configuration.MessageQueue.Host,
+				Port:     configuration.MessageQueue.Port,
+				Protocol: configuration.MessageQueue.Protocol,
+			},
+			Type:     configuration.MessageQueue.Type,
+			Optional: configuration.MessageQueue.Optional,
+		})
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to create messaging client: %s", err.Error()))
+		return false
+	}
+
+	for startupTimer.HasNotElapsed() {
+		err = msgClient.Connect()
+		if err == nil {
+			break
+		}
+
+		lc.Warn(fmt.Sprintf("couldn't connect to message bus: %s", err.Error()))
+		startupTimer.SleepForInterval()
+	}
+	if err != nil {
+		lc.Error("failed to connect to message bus in allotted time")
+		return false
+	}
+
+	err = analytics.RunSummary(ctx, wg, lc, container.CommandTrackerFrom(dic.Get), msgClient, configuration.Analytics)
+	if err != nil {
+		lc.Error(err.Error())
+		_ = msgClient.Disconnect()
+		return false
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if err := msgClient.Disconnect(); err != nil {
+			lc.Error("failed to disconnect from the Message Bus")
+			return
+		}
+		lc.Info("Message Bus disconnected")
+	}()
+
+	lc.Info(fmt.Sprintf("publishing the command usage summary every %s on '%s' topic of %s Message Bus @ %s",
+		configuration.Analytics.SummaryInterval, configuration.Analytics.SummaryTopic, configuration.MessageQueue.Type,
+		configuration.MessageQueue.URL()))
 	return true
 }
@@ -16,13 +16,21 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/edgexfoundry/edgex-go"
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/analytics"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configflags"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configprovider"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/database"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/httpserver"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/registry"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/chaos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/telemetry"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap"
@@ -47,31 +55,52 @@ func Main(ctx context.Context, cancel context.CancelFunc, router *mux.Router, re
 	//      ....
 	//      flags.Parse(os.Args[1:])
 	//
-	f := flags.New()
+	var inMemory bool
+	var chaosPath string
+	var configFlags configflags.Flags
+	f := flags.NewWithUsage(database.InMemoryUsage + chaos.Usage + configflags.Usage)
+	f.FlagSet.BoolVar(&inMemory, database.InMemoryFlag, false, "")
+	f.FlagSet.StringVar(&chaosPath, chaos.Flag, "", "")
+	configFlags.Register(f.FlagSet)
 	f.Parse(os.Args[1:])
+	if err := configFlags.Apply(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	providerFlags := configprovider.NewFlags(f)
 
 	configuration := &config.ConfigurationStruct{}
+	tracker := analytics.NewTracker()
 	dic := di.NewContainer(di.ServiceConstructorMap{
 		container.ConfigurationName: func(get di.Get) interface{} {
 			return configuration
 		},
+		container.CommandTrackerName: func(get di.Get) interface{} {
+			return tracker
+		},
 	})
 
-	httpServer := handlers.NewHttpServer(router, true)
+	httpServer := httpserver.NewHttpServer(router, configuration, true)
 
 	bootstrap.Run(
 		ctx,
 		cancel,
-		f,
+		providerFlags,
 		clients.CoreCommandServiceKey,
 		internal.ConfigStemCore+internal.ConfigMajorVersion,
 		configuration,
 		startupTimer,
 		dic,
 		[]interfaces.BootstrapHandler{
+			configprovider.NewHandler(providerFlags, clients.CoreCommandServiceKey, internal.ConfigStemCore+internal.ConfigMajorVersion, configuration).BootstrapHandler,
+			configflags.NewHandler(&configFlags, configuration).BootstrapHandler,
 			handlers.SecureProviderBootstrapHandler,
+			secretcache.NewHandler(configuration).BootstrapHandler,
+			database.NewInMemory(configuration, inMemory).BootstrapHandler,
 			database.NewDatabase(httpServer, configuration).BootstrapHandler,
 			NewBootstrap(router).BootstrapHandler,
+			chaos.NewHandler(router, chaosPath).BootstrapHandler,
+			registry.NewHandler(configuration, clients.CoreCommandServiceKey).BootstrapHandler,
 			telemetry.BootstrapHandler,
 			httpServer.BootstrapHandler,
 			handlers.NewStartMessage(clients.CoreCommandServiceKey, edgex.Version).BootstrapHandler,
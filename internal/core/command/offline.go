@@ -0,0 +1,89 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/offline"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/metadata"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/types"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// queueCommand queues the PUT command the device service couldn't deliver to the device and returns the response
+// accepting it, or returns the device service response when the command can't be queued
+func queueCommand(
+	queue *offline.Queue,
+	device contract.Device,
+	command contract.Command,
+	ex Executor,
+	body string,
+	originalRequest *http.Request,
+	deviceServiceResponse *http.Response,
+	lc logger.LoggingClient) *http.Response {
+
+	sc, ok := ex.(serviceCommand)
+	if !ok {
+		return deviceServiceResponse
+	}
+	c, err := queue.Enqueue(device.Name, command.Name, sc.Request, body, originalRequest.Header)
+	if err != nil {
+		lc.Warn(fmt.Sprintf("device %s unreachable, %s command not queued: %s", device.Name, command.Name, err.Error()))
+		return deviceServiceResponse
+	}
+	_, _ = io.Copy(ioutil.Discard, deviceServiceResponse.Body)
+	_ = deviceServiceResponse.Body.Close()
+	lc.Info(fmt.Sprintf("device %s unreachable, %s command queued with id %s", device.Name, command.Name, c.Id))
+	return offline.AcceptedResponse(c)
+}
+
+// commandStatusCode returns the status code of the response to a command: 200 whatever the status of the device
+// service response, or 202 with the id of the command queued until its device is reachable again
+func commandStatusCode(w http.ResponseWriter, deviceServiceResponse *http.Response) int {
+	id := deviceServiceResponse.Header.Get(offline.QueueIdHeader)
+	if id == "" {
+		return http.StatusOK
+	}
+	w.Header().Set(offline.QueueIdHeader, id)
+	return http.StatusAccepted
+}
+
+// queuedCommandSender delivers the queued commands to the device services. The commands of the devices locked since
+// they were queued are kept queued, and those of the devices deleted are dropped.
+func queuedCommandSender(deviceClient metadata.DeviceClient, httpCaller internal.HttpCaller) offline.Sender {
+	return func(ctx context.Context, c offline.Command) (int, error) {
+		d, err := deviceClient.DeviceForName(ctx, c.DeviceName)
+		if err != nil {
+			if e, ok := err.(types.ErrServiceClient); ok && e.StatusCode == http.StatusNotFound {
+				return http.StatusNotFound, nil
+			}
+			return 0, err
+		}
+		if d.AdminState == contract.Locked {
+			return http.StatusLocked, nil
+		}
+
+		req, err := c.Request(ctx)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := httpCaller.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+}
@@ -0,0 +1,365 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package offline queues the PUT commands of the devices their device service reports unreachable, and delivers them
+// once the devices are reachable again, so that the clients don't have to retry the commands themselves.
+package offline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PriorityHeader is the request header giving the priority of a command if it is queued, an integer, the highest
+	// delivered first. The priority defaults to 0.
+	PriorityHeader = "X-Command-Priority"
+	// TTLHeader is the request header giving how long a command is kept queued, e.g. "10m", before being dropped. The
+	// duration defaults to OfflineQueueInfo.DefaultTTL.
+	TTLHeader = "X-Command-TTL"
+	// QueueIdHeader is the response header giving the id of a queued command
+	QueueIdHeader = "X-Command-Queue-Id"
+
+	defaultMaxCommandsPerDevice = 10
+	defaultTTL                  = time.Hour
+	defaultRetryInterval        = 30 * time.Second
+)
+
+// ErrQueueFull is returned when the queue of a device holds OfflineQueueInfo.MaxCommandsPerDevice commands of higher
+// or equal priority
+var ErrQueueFull = errors.New("the command queue of the device is full")
+
+// OfflineQueueInfo configures the queueing of the PUT commands of the devices which can't be reached
+type OfflineQueueInfo struct {
+	// Enabled queues the PUT commands the device services answer 423 Locked, which they do for the devices they
+	// can't reach, instead of returning the error to the clients
+	Enabled bool
+	// MaxCommandsPerDevice bounds the commands queued for each device. A command of higher priority replaces the
+	// lowest priority one of a full queue; other commands are rejected.
+	MaxCommandsPerDevice int
+	// DefaultTTL is how long a command is kept queued when the request doesn't have a TTLHeader, e.g. "1h"
+	DefaultTTL string
+	// RetryInterval is how often the delivery of the queued commands is tried, e.g. "30s"
+	RetryInterval string
+}
+
+// Command is a PUT command waiting for its device to be reachable again
+type Command struct {
+	Id          string `json:"id"`
+	DeviceName  string `json:"deviceName"`
+	CommandName string `json:"commandName"`
+	Priority    int    `json:"priority"`
+	// Created is when the command was queued, in milliseconds since the epoch
+	Created int64 `json:"created"`
+	// Expires is when the command is dropped if still queued, in milliseconds since the epoch
+	Expires int64 `json:"expires"`
+	// Attempts is the number of deliveries tried since the command was queued
+	Attempts int `json:"attempts"`
+
+	url    string
+	header http.Header
+	body   string
+}
+
+// Request returns the request delivering the command to its device service
+func (c Command) Request(ctx context.Context) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.url, bytes.NewReader([]byte(c.body)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = c.header.Clone()
+	return req, nil
+}
+
+// Sender delivers a queued command, returning the status code of the device service response. The command is kept
+// queued when the status is 423 Locked or an error is returned.
+type Sender func(ctx context.Context, c Command) (int, error)
+
+// Queue holds the queued commands of each device, the highest priority first and then the oldest first. A nil Queue
+// queues nothing.
+type Queue struct {
+	mutex                sync.Mutex
+	now                  func() time.Time
+	maxCommandsPerDevice int
+	defaultTTL           time.Duration
+	retryInterval        time.Duration
+	commands             map[string][]*Command
+}
+
+// NewQueue creates the queue configured by info, or returns nil when the queueing isn't enabled
+func NewQueue(info OfflineQueueInfo) (*Queue, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+	q := &Queue{
+		now:                  time.Now,
+		maxCommandsPerDevice: info.MaxCommandsPerDevice,
+		defaultTTL:           defaultTTL,
+		retryInterval:        defaultRetryInterval,
+		commands:             make(map[string][]*Command),
+	}
+	if q.maxCommandsPerDevice <= 0 {
+		q.maxCommandsPerDevice = defaultMaxCommandsPerDevice
+	}
+	var err error
+	if info.DefaultTTL != "" {
+		if q.defaultTTL, err = parsePositiveDuration(info.DefaultTTL); err != nil {
+			return nil, fmt.Errorf("invalid OfflineQueue DefaultTTL: %s", err.Error())
+		}
+	}
+	if info.RetryInterval != "" {
+		if q.retryInterval, err = parsePositiveDuration(info.RetryInterval); err != nil {
+			return nil, fmt.Errorf("invalid OfflineQueue RetryInterval: %s", err.Error())
+		}
+	}
+	return q, nil
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s is not positive", value)
+	}
+	return d, nil
+}
+
+// Enqueue queues the PUT command of the device, to be sent as req with body, with the priority and TTL of the headers
+// of the original request
+func (q *Queue) Enqueue(deviceName string, commandName string, req *http.Request, body string, original http.Header) (Command, error) {
+	priority := 0
+	if value := original.Get(PriorityHeader); value != "" {
+		var err error
+		if priority, err = strconv.Atoi(value); err != nil {
+			return Command{}, fmt.Errorf("invalid %s header: %s", PriorityHeader, value)
+		}
+	}
+	ttl := q.defaultTTL
+	if value := original.Get(TTLHeader); value != "" {
+		var err error
+		if ttl, err = parsePositiveDuration(value); err != nil {
+			return Command{}, fmt.Errorf("invalid %s header: %s", TTLHeader, err.Error())
+		}
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := q.now()
+	c := &Command{
+		Id:          uuid.New().String(),
+		DeviceName:  deviceName,
+		CommandName: commandName,
+		Priority:    priority,
+		Created:     toMillis(now),
+		Expires:     toMillis(now.Add(ttl)),
+		url:         req.URL.String(),
+		header:      req.Header.Clone(),
+		body:        body,
+	}
+	commands := q.unexpired(deviceName, now)
+	if len(commands) >= q.maxCommandsPerDevice {
+		if commands[len(commands)-1].Priority >= priority {
+			return Command{}, ErrQueueFull
+		}
+		commands = commands[:len(commands)-1]
+	}
+	// the new command goes after the queued commands of the same priority
+	i := sort.Search(len(commands), func(i int) bool { return commands[i].Priority < priority })
+	commands = append(commands, nil)
+	copy(commands[i+1:], commands[i:])
+	commands[i] = c
+	q.commands[deviceName] = commands
+	return *c, nil
+}
+
+// unexpired drops the expired commands of the device and returns the others
+func (q *Queue) unexpired(deviceName string, now time.Time) []*Command {
+	commands := q.commands[deviceName][:0]
+	for _, c := range q.commands[deviceName] {
+		if c.Expires > toMillis(now) {
+			commands = append(commands, c)
+		}
+	}
+	if len(commands) == 0 {
+		delete(q.commands, deviceName)
+		return nil
+	}
+	q.commands[deviceName] = commands
+	return commands
+}
+
+// Commands returns the queued commands of the device, or of all devices when deviceName is empty, in delivery order
+func (q *Queue) Commands(deviceName string) []Command {
+	result := []Command{}
+	if q == nil {
+		return result
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := q.now()
+	var deviceNames []string
+	if deviceName != "" {
+		deviceNames = []string{deviceName}
+	} else {
+		for name := range q.commands {
+			deviceNames = append(deviceNames, name)
+		}
+		sort.Strings(deviceNames)
+	}
+	for _, name := range deviceNames {
+		for _, c := range q.unexpired(name, now) {
+			result = append(result, *c)
+		}
+	}
+	return result
+}
+
+// Cancel removes the queued command id, returning whether it was queued
+func (q *Queue) Cancel(id string) bool {
+	if q == nil {
+		return false
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for deviceName, commands := range q.commands {
+		for i, c := range commands {
+			if c.Id == id {
+				q.remove(deviceName, i)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CancelDevice removes the queued commands of the device, returning how many were queued
+func (q *Queue) CancelDevice(deviceName string) int {
+	if q == nil {
+		return 0
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	count := len(q.unexpired(deviceName, q.now()))
+	delete(q.commands, deviceName)
+	return count
+}
+
+func (q *Queue) remove(deviceName string, i int) {
+	commands := append(q.commands[deviceName][:i], q.commands[deviceName][i+1:]...)
+	if len(commands) == 0 {
+		delete(q.commands, deviceName)
+		return
+	}
+	q.commands[deviceName] = commands
+}
+
+// next returns the command of the device to deliver next
+func (q *Queue) next(deviceName string) (Command, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	commands := q.unexpired(deviceName, q.now())
+	if len(commands) == 0 {
+		return Command{}, false
+	}
+	commands[0].Attempts++
+	return *commands[0], true
+}
+
+// Deliver tries once to deliver the queued commands of each device in order, until the device is found unreachable.
+// The delivered commands are removed whether the device service accepted them or not.
+func (q *Queue) Deliver(ctx context.Context, send Sender, lc logger.LoggingClient) {
+	q.mutex.Lock()
+	var deviceNames []string
+	for name := range q.commands {
+		deviceNames = append(deviceNames, name)
+	}
+	q.mutex.Unlock()
+	sort.Strings(deviceNames)
+
+	for _, deviceName := range deviceNames {
+		for {
+			c, ok := q.next(deviceName)
+			if !ok {
+				break
+			}
+			status, err := send(ctx, c)
+			if err != nil {
+				lc.Warn(fmt.Sprintf("failed to deliver the queued command %s of device %s: %s", c.CommandName, deviceName, err.Error()))
+				break
+			}
+			if status == http.StatusLocked {
+				lc.Debug(fmt.Sprintf("device %s still unreachable, %s command kept queued", deviceName, c.CommandName))
+				break
+			}
+			// the command may have been cancelled while it was delivered
+			q.Cancel(c.Id)
+			if status >= http.StatusBadRequest {
+				lc.Error(fmt.Sprintf("queued command %s of device %s failed with status %d", c.CommandName, deviceName, status))
+			} else {
+				lc.Info(fmt.Sprintf("queued command %s of device %s delivered after %d attempts", c.CommandName, deviceName, c.Attempts))
+			}
+		}
+	}
+}
+
+// Run delivers the queued commands every RetryInterval until ctx is done
+func (q *Queue) Run(ctx context.Context, wg *sync.WaitGroup, send Sender, lc logger.LoggingClient) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(q.retryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				q.Deliver(ctx, send, lc)
+			}
+		}
+	}()
+}
+
+// AcceptedResponse returns the response of a queued command, in place of the response of the device service
+func AcceptedResponse(c Command) *http.Response {
+	body, _ := json.Marshal(c)
+	header := make(http.Header)
+	header.Set(clients.ContentType, clients.ContentTypeJSON)
+	header.Set(QueueIdHeader, c.Id)
+	return &http.Response{
+		StatusCode: http.StatusAccepted,
+		Header:     header,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,164 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package offline
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQueue(t *testing.T, maxCommandsPerDevice int) (*Queue, *time.Time) {
+	q, err := NewQueue(OfflineQueueInfo{Enabled: true, MaxCommandsPerDevice: maxCommandsPerDevice, DefaultTTL: "1h"})
+	require.NoError(t, err)
+	now := time.Now()
+	q.now = func() time.Time { return now }
+	return q, &now
+}
+
+func enqueue(t *testing.T, q *Queue, deviceName string, commandName string, priority string, ttl string) (Command, error) {
+	req, err := http.NewRequest(http.MethodPut, "http://localhost:49990/api/v1/device/name/"+deviceName+"/"+commandName, nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	original := make(http.Header)
+	if priority != "" {
+		original.Set(PriorityHeader, priority)
+	}
+	if ttl != "" {
+		original.Set(TTLHeader, ttl)
+	}
+	return q.Enqueue(deviceName, commandName, req, `{"speed":"10"}`, original)
+}
+
+func commandNames(commands []Command) []string {
+	var names []string
+	for _, c := range commands {
+		names = append(names, c.CommandName)
+	}
+	return names
+}
+
+func TestNewQueue(t *testing.T) {
+	q, err := NewQueue(OfflineQueueInfo{})
+	require.NoError(t, err)
+	assert.Nil(t, q, "the queue should be disabled")
+	assert.Empty(t, q.Commands(""))
+	assert.False(t, q.Cancel("id"))
+
+	_, err = NewQueue(OfflineQueueInfo{Enabled: true, DefaultTTL: "-1m"})
+	assert.Error(t, err)
+	_, err = NewQueue(OfflineQueueInfo{Enabled: true, RetryInterval: "often"})
+	assert.Error(t, err)
+}
+
+func TestQueue_Enqueue(t *testing.T) {
+	q, now := newTestQueue(t, 3)
+
+	low, err := enqueue(t, q, "fan", "low", "-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour).UnixNano()/int64(time.Millisecond), low.Expires)
+	_, err = enqueue(t, q, "fan", "first", "", "10m")
+	require.NoError(t, err)
+	_, err = enqueue(t, q, "fan", "second", "0", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "low"}, commandNames(q.Commands("fan")))
+
+	_, err = enqueue(t, q, "fan", "rejected", "-1", "")
+	assert.Equal(t, ErrQueueFull, err, "the full queue should reject a command of lower or equal priority")
+	_, err = enqueue(t, q, "fan", "urgent", "5", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"urgent", "first", "second"}, commandNames(q.Commands("fan")), "the lowest priority command should be replaced")
+
+	_, err = enqueue(t, q, "pump", "start", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"urgent", "first", "second", "start"}, commandNames(q.Commands("")))
+
+	*now = now.Add(15 * time.Minute)
+	assert.Equal(t, []string{"urgent", "second"}, commandNames(q.Commands("fan")), "the expired command should be dropped")
+
+	_, err = enqueue(t, q, "fan", "invalid", "high", "")
+	assert.Error(t, err)
+	_, err = enqueue(t, q, "fan", "invalid", "", "0s")
+	assert.Error(t, err)
+}
+
+func TestQueue_Cancel(t *testing.T) {
+	q, _ := newTestQueue(t, 10)
+	c, err := enqueue(t, q, "fan", "start", "", "")
+	require.NoError(t, err)
+	_, err = enqueue(t, q, "fan", "stop", "", "")
+	require.NoError(t, err)
+	_, err = enqueue(t, q, "pump", "start", "", "")
+	require.NoError(t, err)
+
+	assert.True(t, q.Cancel(c.Id))
+	assert.False(t, q.Cancel(c.Id))
+	assert.Equal(t, []string{"stop"}, commandNames(q.Commands("fan")))
+	assert.Equal(t, 1, q.CancelDevice("pump"))
+	assert.Equal(t, 0, q.CancelDevice("pump"))
+	assert.Len(t, q.Commands(""), 1)
+}
+
+func TestQueue_Deliver(t *testing.T) {
+	q, _ := newTestQueue(t, 10)
+	for _, name := range []string{"start", "speed", "stop"} {
+		_, err := enqueue(t, q, "fan", name, "", "")
+		require.NoError(t, err)
+	}
+	_, err := enqueue(t, q, "pump", "start", "", "")
+	require.NoError(t, err)
+
+	var sent []string
+	responses := map[string]int{"fan": http.StatusOK, "pump": http.StatusLocked}
+	send := func(ctx context.Context, c Command) (int, error) {
+		req, err := c.Request(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"speed":"10"}`, string(body))
+		sent = append(sent, c.DeviceName+"/"+c.CommandName)
+		if c.CommandName == "speed" {
+			return 0, errors.New("connection refused")
+		}
+		return responses[c.DeviceName], nil
+	}
+
+	q.Deliver(context.Background(), send, logger.NewMockClient())
+	assert.Equal(t, []string{"fan/start", "fan/speed", "pump/start"}, sent, "the delivery should stop at the first failure")
+	assert.Equal(t, []string{"speed", "stop", "start"}, commandNames(q.Commands("")))
+	assert.Equal(t, 1, q.Commands("pump")[0].Attempts)
+
+	sent = nil
+	responses["pump"] = http.StatusInternalServerError
+	q.Cancel(q.Commands("fan")[0].Id)
+	q.Deliver(context.Background(), send, logger.NewMockClient())
+	assert.Equal(t, []string{"fan/stop", "pump/start"}, sent)
+	assert.Empty(t, q.Commands(""), "the failed command should not be retried")
+}
+
+func TestAcceptedResponse(t *testing.T) {
+	q, _ := newTestQueue(t, 10)
+	c, err := enqueue(t, q, "fan", "start", "", "")
+	require.NoError(t, err)
+
+	response := AcceptedResponse(c)
+	assert.Equal(t, http.StatusAccepted, response.StatusCode)
+	assert.Equal(t, c.Id, response.Header.Get(QueueIdHeader))
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"deviceName":"fan"`)
+	assert.NotContains(t, string(body), "speed", "the command parameters should not be disclosed")
+}
@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/offline"
+	"github.com/edgexfoundry/edgex-go/internal/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/errorconcept"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// createMockHttpCallerWithStatus returns an HttpCaller whose device service responds statusCode
+func createMockHttpCallerWithStatus(statusCode int) internal.HttpCaller {
+	caller := &mocks.HttpCaller{}
+	caller.On("Do", mock.Anything).Return(func(*http.Request) *http.Response {
+		return &http.Response{StatusCode: statusCode, Body: ioutil.NopCloser(strings.NewReader("device is down"))}
+	}, nil)
+	return caller
+}
+
+func createMockNamedDeviceClient(adminState models.AdminState) *mocks.DeviceClient {
+	device := unlockedDevice
+	device.Name = knownDeviceName
+	device.AdminState = adminState
+	client := &mocks.DeviceClient{}
+	client.On("DeviceForName", mock.Anything, knownDeviceName).Return(device, nil)
+	return client
+}
+
+func TestRestPutDeviceCommandByNames_Offline(t *testing.T) {
+	queue, err := offline.NewQueue(offline.OfflineQueueInfo{Enabled: true})
+	require.NoError(t, err)
+	lc := logger.NewMockClient()
+	dbClient := createMockWithOutlines([]mockOutline{
+		{"GetCommandByNameAndDeviceId", []interface{}{exampleCommand.Name, deviceId}, []interface{}{exampleCommand, nil}},
+	})
+
+	tests := []struct {
+		name           string
+		queue          *offline.Queue
+		expectedStatus int
+		expectedQueued int
+	}{
+		{"queueing disabled", nil, http.StatusOK, 0},
+		{"command queued", queue, http.StatusAccepted, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := createRequestWithPathParameters(
+				http.MethodPut,
+				clients.ContentTypeJSON,
+				map[string]string{NAME: knownDeviceName, COMMANDNAME: exampleCommand.Name},
+				unlockedDevice,
+				exampleCommand)
+			req.Header.Set(offline.PriorityHeader, "2")
+
+			rr := httptest.NewRecorder()
+			restPutDeviceCommandByNames(
+				rr,
+				req,
+				lc,
+				dbClient,
+				createMockNamedDeviceClient(models.Unlocked),
+				errorconcept.NewErrorHandler(lc),
+				createMockHttpCallerWithStatus(http.StatusLocked),
+				false,
+				tt.queue)
+
+			response := rr.Result()
+			require.Equal(t, tt.expectedStatus, response.StatusCode)
+			commands := queue.Commands(knownDeviceName)
+			require.Len(t, commands, tt.expectedQueued)
+			if tt.expectedQueued > 0 {
+				assert.Equal(t, commands[0].Id, response.Header.Get(offline.QueueIdHeader))
+				assert.Equal(t, exampleCommand.Name, commands[0].CommandName)
+				assert.Equal(t, 2, commands[0].Priority)
+			}
+		})
+	}
+
+	// the command is kept queued while the device is locked, and delivered once unlocked
+	queue.Deliver(context.Background(), queuedCommandSender(createMockNamedDeviceClient(models.Locked), createMockHttpCallerWithStatus(http.StatusOK)), lc)
+	require.Len(t, queue.Commands(knownDeviceName), 1)
+	queue.Deliver(context.Background(), queuedCommandSender(createMockNamedDeviceClient(models.Unlocked), createMockHttpCallerWithStatus(http.StatusLocked)), lc)
+	require.Len(t, queue.Commands(knownDeviceName), 1)
+	assert.Equal(t, 2, queue.Commands(knownDeviceName)[0].Attempts)
+	queue.Deliver(context.Background(), queuedCommandSender(createMockNamedDeviceClient(models.Unlocked), createMockHttpCallerWithStatus(http.StatusOK)), lc)
+	assert.Empty(t, queue.Commands(knownDeviceName))
+}
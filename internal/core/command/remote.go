@@ -0,0 +1,59 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/remote"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/gorilla/mux"
+)
+
+// startRemoteCommands connects to the MQTT broker and executes the command requests received with router, verifying
+// their token with the keys of the JWTAuth key source
+func startRemoteCommands(ctx context.Context, wg *sync.WaitGroup, dic *di.Container, router *mux.Router) bool {
+	configuration := container.ConfigurationFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	info := configuration.RemoteCommands
+
+	refreshInterval, err := time.ParseDuration(configuration.JWTAuth.RefreshInterval)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid JWTAuth.RefreshInterval '%s': %s", configuration.JWTAuth.RefreshInterval, err.Error()))
+		return false
+	}
+	source, err := jwtauth.NewConfiguredKeySource(dic, configuration.JWTAuth)
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to read the keys verifying the remote command requests: %s", err.Error()))
+		return false
+	}
+	executor, err := remote.NewExecutor(info, jwtauth.NewVerifier(source, refreshInterval), router, lc)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid RemoteCommands configuration: %s", err.Error()))
+		return false
+	}
+
+	connection, err := remote.Connect(info, bootstrapContainer.SecretProviderFrom(dic.Get), lc)
+	if err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+	if err := executor.Serve(ctx, wg, connection); err != nil {
+		lc.Error(err.Error())
+		connection.Close()
+		return false
+	}
+
+	lc.Info(fmt.Sprintf("executing the command requests of topic %s of %s", info.RequestTopic, info.BrokerURL()))
+	return true
+}
@@ -0,0 +1,159 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	connectTimeout = 30 * time.Second
+	quiesce        = 250
+)
+
+// SecretProvider reads the secrets from the service's secret store, e.g. a bootstrap interfaces.SecretProvider
+type SecretProvider interface {
+	GetSecrets(path string, keys ...string) (map[string]string, error)
+}
+
+type subscription struct {
+	qos     byte
+	handler func(payload []byte)
+}
+
+// connection is the Connection of a paho MQTT client, which subscribes again to the topics when the connection is
+// re-established
+type connection struct {
+	client mqtt.Client
+
+	mutex         sync.Mutex
+	subscriptions map[string]subscription
+}
+
+// Connect connects to the MQTT broker with the credentials of the secret at info.SecretPath, if any. The connection is
+// re-established when lost.
+func Connect(info RemoteCommandsInfo, secretProvider SecretProvider, lc logger.LoggingClient) (Connection, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	secrets := map[string]string{}
+	if info.SecretPath != "" {
+		var err error
+		if secrets, err = secretProvider.GetSecrets(info.SecretPath); err != nil {
+			return nil, fmt.Errorf("failed to read the credentials from secret %s: %s", info.SecretPath, err.Error())
+		}
+	}
+
+	c := &connection{subscriptions: make(map[string]subscription)}
+	options := mqtt.NewClientOptions().
+		AddBroker(info.BrokerURL()).
+		SetClientID(info.ClientId).
+		SetCleanSession(true).
+		SetAutoReconnect(true).
+		SetConnectTimeout(connectTimeout).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			lc.Warn(fmt.Sprintf("connection to %s lost: %s", info.BrokerURL(), err.Error()))
+		}).
+		SetOnConnectHandler(func(_ mqtt.Client) {
+			lc.Info(fmt.Sprintf("Connected to %s as %s", info.BrokerURL(), info.ClientId))
+			if err := c.resubscribe(); err != nil {
+				lc.Error(err.Error())
+			}
+		})
+	if !info.Insecure {
+		config, err := tlsConfig(info, secrets)
+		if err != nil {
+			return nil, err
+		}
+		options.SetTLSConfig(config)
+	}
+	if secrets[UsernameKey] != "" {
+		options.SetUsername(secrets[UsernameKey])
+		options.SetPassword(secrets[PasswordKey])
+	}
+
+	c.client = mqtt.NewClient(options)
+	token := c.client.Connect()
+	if !token.WaitTimeout(connectTimeout) {
+		return nil, fmt.Errorf("timed out connecting to %s", info.BrokerURL())
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %s", info.BrokerURL(), err.Error())
+	}
+	return c, nil
+}
+
+func tlsConfig(info RemoteCommandsInfo, secrets map[string]string) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: info.Endpoint,
+		MinVersion: tls.VersionTLS12,
+	}
+	if secrets[CertKey] != "" {
+		certificate, err := tls.X509KeyPair([]byte(secrets[CertKey]), []byte(secrets[KeyKey]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid X.509 credentials in secret %s: %s", info.SecretPath, err.Error())
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	if ca := secrets[CAKey]; ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("invalid CA certificate in secret %s", info.SecretPath)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+func (c *connection) Publish(topic string, qos byte, payload []byte) error {
+	return wait(c.client.Publish(topic, qos, false, payload))
+}
+
+func (c *connection) Subscribe(topic string, qos byte, handler func(payload []byte)) error {
+	c.mutex.Lock()
+	c.subscriptions[topic] = subscription{qos: qos, handler: handler}
+	c.mutex.Unlock()
+	return c.subscribe(topic, qos, handler)
+}
+
+func (c *connection) subscribe(topic string, qos byte, handler func(payload []byte)) error {
+	return wait(c.client.Subscribe(topic, qos, func(_ mqtt.Client, m mqtt.Message) {
+		handler(m.Payload())
+	}))
+}
+
+// resubscribe subscribes again to the topics, the subscriptions of the clean sessions being lost with the connection
+func (c *connection) resubscribe() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for topic, s := range c.subscriptions {
+		if err := c.subscribe(topic, s.qos, s.handler); err != nil {
+			return fmt.Errorf("failed to subscribe again to %s: %s", topic, err.Error())
+		}
+	}
+	return nil
+}
+
+func wait(token mqtt.Token) error {
+	if !token.WaitTimeout(connectTimeout) {
+		return errors.New("timed out")
+	}
+	return token.Error()
+}
+
+func (c *connection) Close() {
+	// waits for the in-flight messages for up to quiesce milliseconds
+	c.client.Disconnect(quiesce)
+}
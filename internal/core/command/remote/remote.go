@@ -0,0 +1,442 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package remote executes the device commands requested through a topic of an MQTT broker, e.g. of the cloud, and
+// publishes their responses, so that the devices can be actuated remotely without the REST API of the gateway being
+// reachable from outside. Each request carries a JWT verified like the tokens of the API gateway, and only the
+// allow-listed commands are executed.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// Keys of the secret at RemoteCommandsInfo.SecretPath
+const (
+	// CertKey and KeyKey are the PEM-encoded X.509 certificate and private key core-command authenticates with, if any
+	CertKey = "cert"
+	KeyKey  = "key"
+	// CAKey is the PEM-encoded CA certificate the broker's certificate must be signed by. The system roots are used
+	// when not set.
+	CAKey = "ca"
+	// UsernameKey and PasswordKey are the credentials core-command authenticates with, if any
+	UsernameKey = "username"
+	PasswordKey = "password"
+)
+
+const (
+	defaultPort           = 8883
+	defaultInsecurePort   = 1883
+	defaultConcurrency    = 4
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// RemoteCommandsInfo configures the execution of the commands requested through an MQTT broker
+type RemoteCommandsInfo struct {
+	// Enabled subscribes to the RequestTopic
+	Enabled bool
+	// Endpoint is the host of the MQTT broker
+	Endpoint string
+	// Port is the MQTT port, 8883 by default, or 1883 when Insecure
+	Port int
+	// Insecure connects to the broker without TLS, for the tests only
+	Insecure bool
+	// ClientId is the MQTT client id core-command connects as
+	ClientId string
+	// SecretPath is the secret holding the credentials of the connection, with the keys CertKey, KeyKey, CAKey,
+	// UsernameKey and PasswordKey, all optional
+	SecretPath string
+	// RequestTopic is the topic of the command requests, which may contain wildcards
+	RequestTopic string
+	// ResponseTopic is the topic the responses are published to
+	ResponseTopic string
+	// QoS is the MQTT quality of service of the subscription and of the responses, 0 or 1
+	QoS byte
+	// Concurrency bounds the commands executed at the same time, 4 by default
+	Concurrency int
+	// RequestTimeout bounds the execution of each command, e.g. "30s"
+	RequestTimeout string
+	// Allowed lists the commands which can be requested. No command can be requested when empty.
+	Allowed []AllowedCommandInfo
+}
+
+// AllowedCommandInfo allows the Commands of the devices matching DeviceName to be requested with the Methods by the
+// tokens carrying one of the Roles
+type AllowedCommandInfo struct {
+	// DeviceName is matched with path.Match, e.g. "thermostat-*"
+	DeviceName string
+	// Commands are the allowed command names, "*" allowing all the commands of the devices
+	Commands []string
+	// Methods are GET and PUT, both when empty
+	Methods []string
+	// Roles are the roles a token must carry one of, any valid token being allowed when empty
+	Roles []string
+}
+
+// Validate checks the configuration is usable when enabled
+func (c RemoteCommandsInfo) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Endpoint == "" {
+		return errors.New("Endpoint is required")
+	}
+	if c.ClientId == "" {
+		return errors.New("ClientId is required")
+	}
+	if c.RequestTopic == "" {
+		return errors.New("RequestTopic is required")
+	}
+	if c.ResponseTopic == "" || strings.ContainsAny(c.ResponseTopic, "+#") {
+		return fmt.Errorf("ResponseTopic '%s' must be set and have no wildcard", c.ResponseTopic)
+	}
+	if c.QoS > 1 {
+		return fmt.Errorf("QoS %d is not supported, expected 0 or 1", c.QoS)
+	}
+	if c.Concurrency < 0 {
+		return fmt.Errorf("Concurrency %d is negative", c.Concurrency)
+	}
+	if _, err := c.Timeout(); err != nil {
+		return err
+	}
+	for _, allowed := range c.Allowed {
+		if _, err := path.Match(allowed.DeviceName, ""); err != nil || allowed.DeviceName == "" {
+			return fmt.Errorf("invalid Allowed DeviceName '%s'", allowed.DeviceName)
+		}
+		if len(allowed.Commands) == 0 {
+			return fmt.Errorf("no Allowed Commands for the devices '%s'", allowed.DeviceName)
+		}
+		for _, method := range allowed.Methods {
+			if method != http.MethodGet && method != http.MethodPut {
+				return fmt.Errorf("invalid Allowed Method '%s', expected GET or PUT", method)
+			}
+		}
+	}
+	return nil
+}
+
+// BrokerURL returns the URL of the MQTT broker
+func (c RemoteCommandsInfo) BrokerURL() string {
+	scheme, port := "ssl", defaultPort
+	if c.Insecure {
+		scheme, port = "tcp", defaultInsecurePort
+	}
+	if c.Port != 0 {
+		port = c.Port
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, c.Endpoint, port)
+}
+
+// Timeout returns the RequestTimeout
+func (c RemoteCommandsInfo) Timeout() (time.Duration, error) {
+	if c.RequestTimeout == "" {
+		return defaultRequestTimeout, nil
+	}
+	d, err := time.ParseDuration(c.RequestTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid RequestTimeout: %s", err.Error())
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("RequestTimeout %s is not positive", c.RequestTimeout)
+	}
+	return d, nil
+}
+
+func (a AllowedCommandInfo) allows(r Request, roles []string) bool {
+	if matched, _ := path.Match(a.DeviceName, r.DeviceName); !matched {
+		return false
+	}
+	if !contains(a.Commands, r.CommandName) && !contains(a.Commands, "*") {
+		return false
+	}
+	if len(a.Methods) > 0 && !contains(a.Methods, r.Method) {
+		return false
+	}
+	if len(a.Roles) == 0 {
+		return true
+	}
+	for _, role := range roles {
+		if contains(a.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Request is the payload of a command request
+type Request struct {
+	// RequestId identifies the request in its response, and can't be used again while the token is valid
+	RequestId string `json:"requestId"`
+	// Token is the JWT of the requester, which must expire
+	Token       string `json:"token"`
+	DeviceName  string `json:"deviceName"`
+	CommandName string `json:"commandName"`
+	// Method is GET to read the device, PUT to actuate it
+	Method string `json:"method"`
+	// Parameters is the body of a PUT command
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+// Response is the payload of the response to a command request
+type Response struct {
+	RequestId     string `json:"requestId,omitempty"`
+	CorrelationId string `json:"correlationId,omitempty"`
+	// StatusCode is the status of the command, as responded by the REST API, or of the rejection of the request
+	StatusCode int    `json:"statusCode"`
+	Body       string `json:"body,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Verifier verifies the token of a request, e.g. a jwtauth.Verifier
+type Verifier interface {
+	Verify(token string) (*jwtauth.Claims, error)
+}
+
+// Connection subscribes to the requests and publishes the responses through the MQTT broker
+type Connection interface {
+	Publish(topic string, qos byte, payload []byte) error
+	// Subscribe calls handler with the payload of each message published to the topic
+	Subscribe(topic string, qos byte, handler func(payload []byte)) error
+	Close()
+}
+
+// Executor executes the command requests with the REST API of core-command, so that the requests go through the same
+// verifications, e.g. the JWT policies and the read-only mode, and are audited and queued like the REST requests
+type Executor struct {
+	info     RemoteCommandsInfo
+	verifier Verifier
+	handler  http.Handler
+	lc       logger.LoggingClient
+	timeout  time.Duration
+	now      func() time.Time
+
+	mutex sync.Mutex
+	// received is the expiry of the token of each request received, to reject the replayed requests
+	received map[string]time.Time
+}
+
+// NewExecutor creates the executor of the requests verified by verifier, executing them with handler, the router of
+// the REST API
+func NewExecutor(info RemoteCommandsInfo, verifier Verifier, handler http.Handler, lc logger.LoggingClient) (*Executor, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	// validated above
+	timeout, _ := info.Timeout()
+	return &Executor{
+		info:     info,
+		verifier: verifier,
+		handler:  handler,
+		lc:       lc,
+		timeout:  timeout,
+		now:      time.Now,
+		received: make(map[string]time.Time),
+	}, nil
+}
+
+// Execute executes the command of the request payload, if allowed, and returns its response
+func (e *Executor) Execute(ctx context.Context, payload []byte) Response {
+	var r Request
+	if err := json.Unmarshal(payload, &r); err != nil {
+		return e.reject(r, http.StatusBadRequest, fmt.Sprintf("invalid request: %s", err.Error()))
+	}
+	r.Method = strings.ToUpper(r.Method)
+	switch {
+	case r.RequestId == "":
+		return e.reject(r, http.StatusBadRequest, "requestId is required")
+	case r.DeviceName == "" || r.CommandName == "":
+		return e.reject(r, http.StatusBadRequest, "deviceName and commandName are required")
+	case r.Method != http.MethodGet && r.Method != http.MethodPut:
+		return e.reject(r, http.StatusBadRequest, fmt.Sprintf("unsupported method '%s', expected GET or PUT", r.Method))
+	}
+
+	claims, err := e.verifier.Verify(r.Token)
+	if err != nil {
+		return e.reject(r, http.StatusUnauthorized, fmt.Sprintf("invalid token: %s", err.Error()))
+	}
+	if claims.ExpiresAt == 0 {
+		return e.reject(r, http.StatusUnauthorized, "invalid token: the token must expire")
+	}
+	if !e.allowed(r, claims.Roles) {
+		return e.reject(r, http.StatusForbidden, fmt.Sprintf("%s %s command of device %s not allowed for %s",
+			r.Method, r.CommandName, r.DeviceName, claims.Actor()))
+	}
+	if !e.firstReceived(r.RequestId, time.Unix(claims.ExpiresAt, 0)) {
+		return e.reject(r, http.StatusConflict, fmt.Sprintf("request %s already received", r.RequestId))
+	}
+
+	response, err := e.execute(ctx, r)
+	if err != nil {
+		return e.reject(r, http.StatusInternalServerError, err.Error())
+	}
+	e.lc.Info(fmt.Sprintf("remote %s %s command of device %s requested by %s executed with status %d",
+		r.Method, r.CommandName, r.DeviceName, claims.Actor(), response.StatusCode), clients.CorrelationHeader, response.CorrelationId)
+	return response
+}
+
+func (e *Executor) reject(r Request, statusCode int, reason string) Response {
+	e.lc.Warn(fmt.Sprintf("remote command request %s rejected: %s", r.RequestId, reason))
+	return Response{RequestId: r.RequestId, StatusCode: statusCode, Error: reason}
+}
+
+func (e *Executor) allowed(r Request, roles []string) bool {
+	for _, allowed := range e.info.Allowed {
+		if allowed.allows(r, roles) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstReceived records the request id until the expiry of its token, returning false if it was already recorded
+func (e *Executor) firstReceived(requestId string, expiry time.Time) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	now := e.now()
+	for id, expires := range e.received {
+		if !expires.After(now) {
+			delete(e.received, id)
+		}
+	}
+	if _, ok := e.received[requestId]; ok {
+		return false
+	}
+	e.received[requestId] = expiry
+	return true
+}
+
+// execute sends the request to the REST API of the command, with the token of the request and its id as correlation
+// id
+func (e *Executor) execute(ctx context.Context, r Request) (Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	route := fmt.Sprintf("%s/device/name/%s/command/%s", clients.ApiBase, url.PathEscape(r.DeviceName), url.PathEscape(r.CommandName))
+	req, err := http.NewRequestWithContext(ctx, r.Method, route, strings.NewReader(string(r.Parameters)))
+	if err != nil {
+		return Response{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.Token)
+	req.Header.Set(clients.CorrelationHeader, r.RequestId)
+	if r.Method == http.MethodPut {
+		req.Header.Set(clients.ContentType, clients.ContentTypeJSON)
+	}
+
+	recorder := &responseRecorder{header: make(http.Header), statusCode: http.StatusOK}
+	e.handler.ServeHTTP(recorder, req)
+	response := Response{
+		RequestId:     r.RequestId,
+		CorrelationId: recorder.header.Get(clients.CorrelationHeader),
+		StatusCode:    recorder.statusCode,
+		Body:          recorder.body.String(),
+	}
+	if recorder.statusCode >= http.StatusBadRequest {
+		response.Error = strings.TrimSpace(response.Body)
+	}
+	return response, nil
+}
+
+// responseRecorder is the http.ResponseWriter keeping the response of the REST API
+type responseRecorder struct {
+	header      http.Header
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode, r.wroteHeader = statusCode, true
+	}
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.body.Write(b)
+}
+
+// Serve subscribes to the requests with connection, and publishes the response of each, until ctx is done. The
+// connection is closed once the requests being executed are responded to, within their RequestTimeout.
+func (e *Executor) Serve(ctx context.Context, wg *sync.WaitGroup, connection Connection) error {
+	concurrency := e.info.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultConcurrency
+	}
+	slots := make(chan struct{}, concurrency)
+	var executing sync.WaitGroup
+	var closing bool
+	var closingMutex sync.RWMutex
+
+	err := connection.Subscribe(e.info.RequestTopic, e.info.QoS, func(payload []byte) {
+		closingMutex.RLock()
+		defer closingMutex.RUnlock()
+		if closing {
+			return
+		}
+		executing.Add(1)
+		// the next requests wait while Concurrency commands are executed
+		slots <- struct{}{}
+		go func() {
+			defer executing.Done()
+			defer func() { <-slots }()
+			e.respond(connection, e.Execute(context.Background(), payload))
+		}()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %s", e.info.RequestTopic, err.Error())
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		closingMutex.Lock()
+		closing = true
+		closingMutex.Unlock()
+		executing.Wait()
+		connection.Close()
+	}()
+	return nil
+}
+
+func (e *Executor) respond(connection Connection, response Response) {
+	payload, err := json.Marshal(response)
+	if err != nil {
+		e.lc.Error(fmt.Sprintf("failed to encode the response to request %s: %s", response.RequestId, err.Error()))
+		return
+	}
+	if err := connection.Publish(e.info.ResponseTopic, e.info.QoS, payload); err != nil {
+		e.lc.Error(fmt.Sprintf("failed to publish the response to request %s: %s", response.RequestId, err.Error()))
+	}
+}
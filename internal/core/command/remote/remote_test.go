@@ -0,0 +1,249 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifierStub accepts the tokens it knows
+type verifierStub map[string]*jwtauth.Claims
+
+func (v verifierStub) Verify(token string) (*jwtauth.Claims, error) {
+	claims, ok := v[token]
+	if !ok {
+		return nil, errors.New("signature is invalid")
+	}
+	return claims, nil
+}
+
+func claims(subject string, expiresAt int64, roles ...string) *jwtauth.Claims {
+	return &jwtauth.Claims{StandardClaims: jwt.StandardClaims{Subject: subject, ExpiresAt: expiresAt}, Roles: roles}
+}
+
+type received struct {
+	method        string
+	path          string
+	authorization string
+	body          string
+}
+
+// handlerStub is the REST API of the commands
+type handlerStub struct {
+	mutex    sync.Mutex
+	received []received
+}
+
+func (h *handlerStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	h.mutex.Lock()
+	h.received = append(h.received, received{method: r.Method, path: r.URL.Path, authorization: r.Header.Get("Authorization"), body: string(body)})
+	h.mutex.Unlock()
+	w.Header().Set(clients.CorrelationHeader, r.Header.Get(clients.CorrelationHeader))
+	if r.URL.Path == "/api/v1/device/name/thermostat-2/command/setpoint" {
+		w.WriteHeader(http.StatusLocked)
+		_, _ = w.Write([]byte("device is unreachable\n"))
+		return
+	}
+	_, _ = w.Write([]byte(`{"device":"thermostat-1"}`))
+}
+
+type connectionStub struct {
+	mutex     sync.Mutex
+	topic     string
+	handler   func(payload []byte)
+	published [][]byte
+	closed    bool
+}
+
+func (c *connectionStub) Publish(topic string, qos byte, payload []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.published = append(c.published, payload)
+	return nil
+}
+
+func (c *connectionStub) Subscribe(topic string, qos byte, handler func(payload []byte)) error {
+	c.topic, c.handler = topic, handler
+	return nil
+}
+
+func (c *connectionStub) Close() {
+	c.closed = true
+}
+
+var testInfo = RemoteCommandsInfo{
+	Enabled:       true,
+	Endpoint:      "broker",
+	ClientId:      "core-command",
+	RequestTopic:  "edgex/commands/request",
+	ResponseTopic: "edgex/commands/response",
+	Allowed: []AllowedCommandInfo{
+		{DeviceName: "thermostat-*", Commands: []string{"setpoint"}, Roles: []string{"operator"}},
+		{DeviceName: "thermostat-*", Commands: []string{"*"}, Methods: []string{http.MethodGet}},
+	},
+}
+
+func newTestExecutor(t *testing.T) (*Executor, *handlerStub) {
+	expires := time.Now().Add(time.Hour).Unix()
+	verifier := verifierStub{
+		"operator-token": claims("alice", expires, "operator"),
+		"viewer-token":   claims("bob", expires, "viewer"),
+		"eternal-token":  claims("carol", 0, "operator"),
+	}
+	handler := &handlerStub{}
+	executor, err := NewExecutor(testInfo, verifier, handler, logger.NewMockClient())
+	require.NoError(t, err)
+	return executor, handler
+}
+
+func request(requestId string, token string, deviceName string, commandName string, method string) []byte {
+	payload, _ := json.Marshal(Request{
+		RequestId:   requestId,
+		Token:       token,
+		DeviceName:  deviceName,
+		CommandName: commandName,
+		Method:      method,
+		Parameters:  json.RawMessage(`{"temperature":"21"}`),
+	})
+	return payload
+}
+
+func TestRemoteCommandsInfo_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *RemoteCommandsInfo)
+		valid  bool
+	}{
+		{"Valid", func(c *RemoteCommandsInfo) {}, true},
+		{"Valid - disabled", func(c *RemoteCommandsInfo) { c.Enabled = false; c.Endpoint = "" }, true},
+		{"Invalid - no endpoint", func(c *RemoteCommandsInfo) { c.Endpoint = "" }, false},
+		{"Invalid - no client id", func(c *RemoteCommandsInfo) { c.ClientId = "" }, false},
+		{"Invalid - no request topic", func(c *RemoteCommandsInfo) { c.RequestTopic = "" }, false},
+		{"Invalid - response topic wildcard", func(c *RemoteCommandsInfo) { c.ResponseTopic = "edgex/+/response" }, false},
+		{"Invalid - QoS 2", func(c *RemoteCommandsInfo) { c.QoS = 2 }, false},
+		{"Invalid - request timeout", func(c *RemoteCommandsInfo) { c.RequestTimeout = "0s" }, false},
+		{"Invalid - device pattern", func(c *RemoteCommandsInfo) {
+			c.Allowed = []AllowedCommandInfo{{DeviceName: "[", Commands: []string{"*"}}}
+		}, false},
+		{"Invalid - no commands", func(c *RemoteCommandsInfo) { c.Allowed = []AllowedCommandInfo{{DeviceName: "*"}} }, false},
+		{"Invalid - method", func(c *RemoteCommandsInfo) {
+			c.Allowed = []AllowedCommandInfo{{DeviceName: "*", Commands: []string{"*"}, Methods: []string{http.MethodDelete}}}
+		}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			info := testInfo
+			testCase.modify(&info)
+			err := info.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	assert.Equal(t, "ssl://broker:8883", testInfo.BrokerURL())
+	info := testInfo
+	info.Insecure = true
+	assert.Equal(t, "tcp://broker:1883", info.BrokerURL())
+}
+
+func TestExecutor_Execute(t *testing.T) {
+	executor, handler := newTestExecutor(t)
+
+	tests := []struct {
+		name           string
+		payload        []byte
+		expectedStatus int
+	}{
+		{"PUT allowed for the role", request("1", "operator-token", "thermostat-1", "setpoint", "put"), http.StatusOK},
+		{"GET allowed for any token", request("2", "viewer-token", "thermostat-1", "mode", http.MethodGet), http.StatusOK},
+		{"status of the REST API", request("3", "operator-token", "thermostat-2", "setpoint", http.MethodPut), http.StatusLocked},
+		{"invalid JSON", []byte("{"), http.StatusBadRequest},
+		{"no request id", request("", "operator-token", "thermostat-1", "setpoint", http.MethodPut), http.StatusBadRequest},
+		{"no device", request("4", "operator-token", "", "setpoint", http.MethodPut), http.StatusBadRequest},
+		{"unsupported method", request("5", "operator-token", "thermostat-1", "setpoint", http.MethodDelete), http.StatusBadRequest},
+		{"invalid token", request("6", "forged-token", "thermostat-1", "setpoint", http.MethodPut), http.StatusUnauthorized},
+		{"token without expiry", request("7", "eternal-token", "thermostat-1", "setpoint", http.MethodPut), http.StatusUnauthorized},
+		{"role not allowed", request("8", "viewer-token", "thermostat-1", "setpoint", http.MethodPut), http.StatusForbidden},
+		{"device not allowed", request("9", "operator-token", "boiler", "setpoint", http.MethodGet), http.StatusForbidden},
+		{"replayed request", request("1", "operator-token", "thermostat-1", "setpoint", http.MethodPut), http.StatusConflict},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			response := executor.Execute(context.Background(), testCase.payload)
+			assert.Equal(t, testCase.expectedStatus, response.StatusCode, response.Error)
+			if testCase.expectedStatus == http.StatusOK {
+				assert.Empty(t, response.Error)
+			} else {
+				assert.NotEmpty(t, response.Error)
+			}
+		})
+	}
+
+	require.Len(t, handler.received, 3, "only the allowed requests should be executed")
+	assert.Equal(t, received{
+		method:        http.MethodPut,
+		path:          "/api/v1/device/name/thermostat-1/command/setpoint",
+		authorization: "Bearer operator-token",
+		body:          `{"temperature":"21"}`,
+	}, handler.received[0])
+	assert.Equal(t, http.MethodGet, handler.received[1].method)
+
+	response := executor.Execute(context.Background(), request("10", "operator-token", "thermostat-1", "setpoint", http.MethodGet))
+	assert.Equal(t, Response{RequestId: "10", CorrelationId: "10", StatusCode: http.StatusOK, Body: `{"device":"thermostat-1"}`}, response)
+	response = executor.Execute(context.Background(), request("11", "operator-token", "thermostat-2", "setpoint", http.MethodPut))
+	assert.Equal(t, "device is unreachable", response.Error)
+
+	// the request ids are forgotten once their token expired
+	executor.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	executor.firstReceived("12", time.Now().Add(3*time.Hour))
+	assert.NotContains(t, executor.received, "1")
+}
+
+func TestExecutor_Serve(t *testing.T) {
+	executor, handler := newTestExecutor(t)
+	connection := &connectionStub{}
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	require.NoError(t, executor.Serve(ctx, &wg, connection))
+	assert.Equal(t, "edgex/commands/request", connection.topic)
+
+	connection.handler(request("1", "operator-token", "thermostat-1", "setpoint", http.MethodPut))
+	connection.handler(request("2", "forged-token", "thermostat-1", "setpoint", http.MethodPut))
+	cancel()
+	wg.Wait()
+	connection.handler(request("3", "operator-token", "thermostat-1", "setpoint", http.MethodPut))
+
+	assert.True(t, connection.closed)
+	assert.Len(t, handler.received, 1)
+	require.Len(t, connection.published, 2, "the requests should be responded to before the connection is closed")
+	statuses := map[string]int{}
+	for _, payload := range connection.published {
+		var response Response
+		require.NoError(t, json.Unmarshal(payload, &response))
+		statuses[response.RequestId] = response.StatusCode
+	}
+	assert.Equal(t, map[string]int{"1": http.StatusOK, "2": http.StatusUnauthorized}, statuses)
+}
@@ -240,7 +240,9 @@ func TestRestPutDeviceCommandByCommandID(t *testing.T) {
 				tt.dbMock,
 				tt.dcMock,
 				errorconcept.NewErrorHandler(loggerMock),
-				httpCaller)
+				httpCaller,
+				true,
+				nil)
 			response := rr.Result()
 			require.Equal(t, tt.expectedStatus, response.StatusCode)
 		})
@@ -22,6 +22,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/offline"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/errorconcept"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
@@ -40,7 +41,7 @@ func restGetDeviceCommandByCommandID(
 	httpErrorHandler errorconcept.ErrorHandler,
 	httpCaller internal.HttpCaller) {
 
-	issueDeviceCommand(w, originalRequest, lc, dbClient, deviceClient, httpErrorHandler, httpCaller)
+	issueDeviceCommand(w, originalRequest, lc, dbClient, deviceClient, httpErrorHandler, httpCaller, false, nil)
 }
 
 func restPutDeviceCommandByCommandID(
@@ -50,9 +51,11 @@ func restPutDeviceCommandByCommandID(
 	dbClient interfaces.DBClient,
 	deviceClient metadata.DeviceClient,
 	httpErrorHandler errorconcept.ErrorHandler,
-	httpCaller internal.HttpCaller) {
+	httpCaller internal.HttpCaller,
+	transformParameters bool,
+	queue *offline.Queue) {
 
-	issueDeviceCommand(w, originalRequest, lc, dbClient, deviceClient, httpErrorHandler, httpCaller)
+	issueDeviceCommand(w, originalRequest, lc, dbClient, deviceClient, httpErrorHandler, httpCaller, transformParameters, queue)
 }
 
 func issueDeviceCommand(
@@ -62,7 +65,9 @@ func issueDeviceCommand(
 	dbClient interfaces.DBClient,
 	deviceClient metadata.DeviceClient,
 	httpErrorHandler errorconcept.ErrorHandler,
-	httpCaller internal.HttpCaller) {
+	httpCaller internal.HttpCaller,
+	transformParameters bool,
+	queue *offline.Queue) {
 
 	defer originalRequest.Body.Close()
 
@@ -78,7 +83,9 @@ func issueDeviceCommand(
 		lc,
 		dbClient,
 		deviceClient,
-		httpCaller)
+		httpCaller,
+		transformParameters,
+		queue)
 
 	if err != nil {
 		httpErrorHandler.HandleManyVariants(
@@ -89,6 +96,7 @@ func issueDeviceCommand(
 				errorconcept.Device.Locked,
 				errorconcept.Database.NotFound,
 				errorconcept.Command.NotAssociatedWithDevice,
+				errorconcept.Command.InvalidParameters,
 			},
 			errorconcept.Default.InternalServerError)
 		return
@@ -108,7 +116,7 @@ func issueDeviceCommand(
 	// Set the returned header Content-type based on header Content-type received in
 	// the Device Service request (No need to inspect it).
 	w.Header().Set(clients.ContentType, headers[clients.ContentType])
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(commandStatusCode(w, deviceServiceResponse))
 	w.Write([]byte(deviceServiceResponseBody))
 }
 
@@ -121,7 +129,7 @@ func restGetDeviceCommandByNames(
 	httpErrorHandler errorconcept.ErrorHandler,
 	httpCaller internal.HttpCaller) {
 
-	issueDeviceCommandByNames(w, originalRequest, lc, dbClient, deviceClient, httpErrorHandler, httpCaller)
+	issueDeviceCommandByNames(w, originalRequest, lc, dbClient, deviceClient, httpErrorHandler, httpCaller, false, nil)
 }
 
 func restPutDeviceCommandByNames(
@@ -131,9 +139,11 @@ func restPutDeviceCommandByNames(
 	dbClient interfaces.DBClient,
 	deviceClient metadata.DeviceClient,
 	httpErrorHandler errorconcept.ErrorHandler,
-	httpCaller internal.HttpCaller) {
+	httpCaller internal.HttpCaller,
+	transformParameters bool,
+	queue *offline.Queue) {
 
-	issueDeviceCommandByNames(w, originalRequest, lc, dbClient, deviceClient, httpErrorHandler, httpCaller)
+	issueDeviceCommandByNames(w, originalRequest, lc, dbClient, deviceClient, httpErrorHandler, httpCaller, transformParameters, queue)
 }
 
 func issueDeviceCommandByNames(
@@ -143,7 +153,9 @@ func issueDeviceCommandByNames(
 	dbClient interfaces.DBClient,
 	deviceClient metadata.DeviceClient,
 	httpErrorHandler errorconcept.ErrorHandler,
-	httpCaller internal.HttpCaller) {
+	httpCaller internal.HttpCaller,
+	transformParameters bool,
+	queue *offline.Queue) {
 
 	defer originalRequest.Body.Close()
 
@@ -168,7 +180,9 @@ func issueDeviceCommandByNames(
 		lc,
 		dbClient,
 		deviceClient,
-		httpCaller)
+		httpCaller,
+		transformParameters,
+		queue)
 
 	if err != nil {
 		httpErrorHandler.HandleManyVariants(
@@ -178,6 +192,7 @@ func issueDeviceCommandByNames(
 				errorconcept.NewServiceClientHttpError(err),
 				errorconcept.Device.Locked,
 				errorconcept.Database.NotFound,
+				errorconcept.Command.InvalidParameters,
 			},
 			errorconcept.Default.InternalServerError)
 		return
@@ -197,7 +212,7 @@ func issueDeviceCommandByNames(
 	// Set the returned header Content-type based on header Content-type received in
 	// the Device Service request (No need to inspect it).
 	w.Header().Set(clients.ContentType, headers[clients.ContentType])
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(commandStatusCode(w, deviceServiceResponse))
 	w.Write([]byte(deviceServiceResponseBody))
 }
 
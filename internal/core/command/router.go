@@ -104,7 +104,7 @@ func loadDeviceRoutes(b *mux.Router, dic *di.Container) {
 				container.DBClientFrom(dic.Get),
 				commandContainer.MetadataDeviceClientFrom(dic.Get),
 				errorContainer.ErrorHandlerFrom(dic.Get),
-				&http.Client{})
+				container.HTTPClientFrom(dic.Get))
 		}).Methods(http.MethodGet)
 	d.HandleFunc(
 		"/{"+ID+"}/"+COMMAND+"/{"+COMMANDID+"}",
@@ -116,18 +116,12 @@ func loadDeviceRoutes(b *mux.Router, dic *di.Container) {
 				container.DBClientFrom(dic.Get),
 				commandContainer.MetadataDeviceClientFrom(dic.Get),
 				errorContainer.ErrorHandlerFrom(dic.Get),
-				&http.Client{})
+				container.HTTPClientFrom(dic.Get),
+				commandContainer.ConfigurationFrom(dic.Get).Writable.TransformSetParameters,
+				commandContainer.OfflineQueueFrom(dic.Get))
 		}).Methods(http.MethodPut)
-	// In the block of code above, as well as in the one that follows below,
-	// there are two references each to http.Client. Putting them into the
-	// DI container(dic) and retrieving the value like we do for other components
-	// would bring about further consistency in the code base. But the concern
-	// then would be the creation of a race condition because we can only have a
-	// single http.Client instance in the dic. In turn, every invocation of this
-	// REST handler would be served by a different goroutine. This would create
-	// a situation where each one of them would use the same http.Client instance,
-	// resulting in state divergence, misalignment. So the decision is to not
-	// put this into the DI container(dic).
+	// The commands, above and below, are sent to the device services by the shared client of the service, which
+	// applies the timeouts, retries and circuit breaking of the outbound requests.
 
 	// /api/<version>/device/name
 	dn := d.PathPrefix("/" + NAME).Subrouter()
@@ -154,7 +148,7 @@ func loadDeviceRoutes(b *mux.Router, dic *di.Container) {
 				container.DBClientFrom(dic.Get),
 				commandContainer.MetadataDeviceClientFrom(dic.Get),
 				errorContainer.ErrorHandlerFrom(dic.Get),
-				&http.Client{})
+				container.HTTPClientFrom(dic.Get))
 		}).Methods(http.MethodGet)
 	dn.HandleFunc(
 		"/{"+NAME+"}/"+COMMAND+"/{"+COMMANDNAME+"}",
@@ -166,6 +160,8 @@ func loadDeviceRoutes(b *mux.Router, dic *di.Container) {
 				container.DBClientFrom(dic.Get),
 				commandContainer.MetadataDeviceClientFrom(dic.Get),
 				errorContainer.ErrorHandlerFrom(dic.Get),
-				&http.Client{})
+				container.HTTPClientFrom(dic.Get),
+				commandContainer.ConfigurationFrom(dic.Get).Writable.TransformSetParameters,
+				commandContainer.OfflineQueueFrom(dic.Get))
 		}).Methods(http.MethodPut)
 }
@@ -0,0 +1,219 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/errors"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// transformation is the read transformation a device profile declares for a device resource. Device services read
+// value = ((raw & mask) >> shift), then base^value, * scale and + offset; a PUT parameter is transformed the inverse
+// way so that clients send engineering-unit values.
+type transformation struct {
+	valueType string
+	mask      uint64
+	shift     int64
+	scale     float64
+	offset    float64
+	base      float64
+}
+
+// newTransformation returns the transformation of a device resource, and false if the resource declares none
+func newTransformation(value contract.PropertyValue) (transformation, bool, error) {
+	t := transformation{valueType: strings.ToLower(value.Type), scale: 1}
+	var err error
+	if value.Mask != "" {
+		if t.mask, err = strconv.ParseUint(value.Mask, 0, 64); err != nil {
+			return t, false, fmt.Errorf("invalid mask '%s'", value.Mask)
+		}
+	}
+	if value.Shift != "" {
+		if t.shift, err = strconv.ParseInt(value.Shift, 0, 64); err != nil {
+			return t, false, fmt.Errorf("invalid shift '%s'", value.Shift)
+		}
+	}
+	if value.Scale != "" {
+		if t.scale, err = strconv.ParseFloat(value.Scale, 64); err != nil || t.scale == 0 {
+			return t, false, fmt.Errorf("invalid scale '%s'", value.Scale)
+		}
+	}
+	if value.Offset != "" {
+		if t.offset, err = strconv.ParseFloat(value.Offset, 64); err != nil {
+			return t, false, fmt.Errorf("invalid offset '%s'", value.Offset)
+		}
+	}
+	if value.Base != "" {
+		if t.base, err = strconv.ParseFloat(value.Base, 64); err != nil || t.base < 0 || t.base == 1 {
+			return t, false, fmt.Errorf("invalid base '%s'", value.Base)
+		}
+	}
+
+	if !t.isInteger() && !t.isFloat() {
+		return t, false, nil
+	}
+	declared := t.scale != 1 || t.offset != 0 || t.base != 0
+	if t.isInteger() {
+		declared = declared || t.mask != 0 || t.shift != 0
+	}
+	return t, declared, nil
+}
+
+func (t transformation) isInteger() bool {
+	return strings.HasPrefix(t.valueType, "int") || strings.HasPrefix(t.valueType, "uint")
+}
+
+func (t transformation) isFloat() bool {
+	return strings.HasPrefix(t.valueType, "float")
+}
+
+// bitSize returns the size of the value type, 64 if the type doesn't tell it
+func (t transformation) bitSize() int {
+	size, err := strconv.Atoi(strings.TrimLeft(t.valueType, "uint float"))
+	if err != nil {
+		return 64
+	}
+	return size
+}
+
+// inverse transforms the engineering-unit value of a parameter into the device-native value
+func (t transformation) inverse(value float64) (string, error) {
+	value = (value - t.offset) / t.scale
+	if t.base != 0 {
+		if value <= 0 {
+			return "", fmt.Errorf("%v is out of the range of base %v", value, t.base)
+		}
+		// the common bases have exact logarithms
+		switch t.base {
+		case 2:
+			value = math.Log2(value)
+		case 10:
+			value = math.Log10(value)
+		default:
+			value = math.Log(value) / math.Log(t.base)
+		}
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "", fmt.Errorf("%v can't be represented", value)
+	}
+	if t.isFloat() {
+		return strconv.FormatFloat(value, 'f', -1, t.bitSize()), nil
+	}
+
+	rounded := math.Round(value)
+	bits := t.bitSize()
+	if strings.HasPrefix(t.valueType, "uint") {
+		if rounded < 0 || rounded > math.Ldexp(1, bits)-1 {
+			return "", fmt.Errorf("%v is out of the range of %s", rounded, t.valueType)
+		}
+		raw := uint64(rounded)
+		if t.shift > 0 {
+			raw <<= uint64(t.shift)
+		} else if t.shift < 0 {
+			raw >>= uint64(-t.shift)
+		}
+		if t.mask != 0 {
+			if raw&^t.mask != 0 {
+				return "", fmt.Errorf("%v is out of the range of mask %#x", rounded, t.mask)
+			}
+		}
+		return strconv.FormatUint(raw, 10), nil
+	}
+
+	if rounded < -math.Ldexp(1, bits-1) || rounded > math.Ldexp(1, bits-1)-1 {
+		return "", fmt.Errorf("%v is out of the range of %s", rounded, t.valueType)
+	}
+	raw := int64(rounded)
+	if t.shift > 0 {
+		raw <<= uint64(t.shift)
+	} else if t.shift < 0 {
+		raw >>= uint64(-t.shift)
+	}
+	if t.mask != 0 && uint64(raw)&^t.mask != 0 {
+		return "", fmt.Errorf("%v is out of the range of mask %#x", rounded, t.mask)
+	}
+	return strconv.FormatInt(raw, 10), nil
+}
+
+// transformPutParameters applies the inverse of the transformations the device profile of device declares to the
+// parameters of the JSON body of a PUT command, so that the device service receives device-native values. The
+// parameters of device resources without transformation are left as sent.
+func transformPutParameters(device contract.Device, body string) (string, error) {
+	transformations := make(map[string]transformation)
+	for _, resource := range device.Profile.DeviceResources {
+		t, declared, err := newTransformation(resource.Properties.Value)
+		if err != nil {
+			return "", errors.NewErrInvalidParameters(fmt.Sprintf("device resource '%s' has %s", resource.Name, err.Error()))
+		}
+		if declared {
+			transformations[resource.Name] = t
+		}
+	}
+	if len(transformations) == 0 || strings.TrimSpace(body) == "" {
+		return body, nil
+	}
+
+	var parameters map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(body), &parameters); err != nil {
+		return "", errors.NewErrInvalidParameters("the body isn't a JSON object of parameter values")
+	}
+
+	transformed := false
+	for name, raw := range parameters {
+		t, ok := transformations[name]
+		if !ok {
+			continue
+		}
+
+		// parameters are sent as strings, but numbers are accepted too and kept as numbers
+		var text string
+		quoted := json.Unmarshal(raw, &text) == nil
+		if !quoted {
+			text = string(raw)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return "", errors.NewErrInvalidParameters(fmt.Sprintf("'%s' of '%s' isn't a number", text, name))
+		}
+		native, err := t.inverse(value)
+		if err != nil {
+			return "", errors.NewErrInvalidParameters(fmt.Sprintf("'%s' of '%s': %s", text, name, err.Error()))
+		}
+
+		if quoted {
+			raw, _ = json.Marshal(native)
+		} else {
+			raw = json.RawMessage(native)
+		}
+		parameters[name] = raw
+		transformed = true
+	}
+	if !transformed {
+		return body, nil
+	}
+
+	b, err := json.Marshal(parameters)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
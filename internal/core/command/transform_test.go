@@ -0,0 +1,108 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package command
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/errors"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTransformTestDevice(values map[string]contract.PropertyValue) contract.Device {
+	device := contract.Device{Name: "TransformTestDevice"}
+	for name, value := range values {
+		device.Profile.DeviceResources = append(device.Profile.DeviceResources, contract.DeviceResource{
+			Name:       name,
+			Properties: contract.ProfileProperty{Value: value},
+		})
+	}
+	return device
+}
+
+func TestTransformPutParameters(t *testing.T) {
+	device := newTransformTestDevice(map[string]contract.PropertyValue{
+		"Temperature": {Type: "Int16", Scale: "0.1", Offset: "0.0", Mask: "0x00", Shift: "0", Base: "0"},
+		"Pressure":    {Type: "Float32", Scale: "2", Offset: "10"},
+		"Speed":       {Type: "Uint8", Scale: "0.1"},
+		"Mode":        {Type: "Uint8", Shift: "4", Mask: "0xF0"},
+		"Gain":        {Type: "Float64", Base: "10"},
+		"Label":       {Type: "String", Scale: "1.0"},
+		"Switch":      {Type: "Bool"},
+	})
+
+	tests := []struct {
+		name          string
+		body          string
+		expectedBody  string
+		expectedError bool
+	}{
+		{"scale of an integer", `{"Temperature":"25.5"}`, `{"Temperature":"255"}`, false},
+		{"rounding of an integer", `{"Temperature":"-1.26"}`, `{"Temperature":"-13"}`, false},
+		{"number kept as a number", `{"Pressure":30}`, `{"Pressure":10}`, false},
+		{"shift and mask", `{"Mode":"3"}`, `{"Mode":"48"}`, false},
+		{"base", `{"Gain":"1000"}`, `{"Gain":"3"}`, false},
+		{"parameters without transformation", `{"Temperature":"1", "Label":"abc", "Switch":"true"}`,
+			`{"Temperature":"10","Label":"abc","Switch":"true"}`, false},
+		{"out of the range of the value type", `{"Speed":"30"}`, "", true},
+		{"negative unsigned integer", `{"Speed":"-1"}`, "", true},
+		{"out of the range of the mask", `{"Mode":"16"}`, "", true},
+		{"out of the range of the base", `{"Gain":"0"}`, "", true},
+		{"not a number", `{"Temperature":"abc"}`, "", true},
+		{"not a JSON object", `[25.5]`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := transformPutParameters(device, tt.body)
+			if tt.expectedError {
+				require.Error(t, err)
+				assert.IsType(t, errors.ErrInvalidParameters{}, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedBody, body)
+		})
+	}
+}
+
+func TestTransformPutParametersUntransformed(t *testing.T) {
+	device := newTransformTestDevice(map[string]contract.PropertyValue{
+		"Temperature": {Type: "Int16", Scale: "1.0", Offset: "0.0", Mask: "0x00", Shift: "0", Base: "0"},
+		"Pressure":    {Type: "Float32", Scale: "2"},
+	})
+
+	for _, body := range []string{`{ "Temperature" : "25" }`, `{"Humidity": "40"}`, "", "not JSON"} {
+		actual, err := transformPutParameters(device, body)
+		if body == "not JSON" {
+			assert.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, body, actual, "the body of untransformed parameters should be sent as is")
+	}
+}
+
+func TestTransformPutParametersInvalidProfile(t *testing.T) {
+	device := newTransformTestDevice(map[string]contract.PropertyValue{
+		"Temperature": {Type: "Int16", Scale: "0"},
+	})
+
+	_, err := transformPutParameters(device, `{"Temperature":"25"}`)
+	assert.IsType(t, errors.ErrInvalidParameters{}, err)
+}
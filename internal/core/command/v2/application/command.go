@@ -10,6 +10,9 @@ import (
 	"fmt"
 
 	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	V2Container "github.com/edgexfoundry/go-mod-bootstrap/v2/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
@@ -113,45 +116,65 @@ func CommandsByDeviceName(name string, dic *di.Container) (deviceCoreCommand dto
 }
 
 // IssueGetCommandByName issues the specified get(read) command referenced by the command name to the device/sensor, also
-// referenced by name.
-func IssueGetCommandByName(deviceName string, commandName string, queryParams string, dic *di.Container) (event dtos.Event, err errors.EdgeX) {
+// referenced by name. The warnings announce the deprecation of the command by the device profile of the device.
+func IssueGetCommandByName(deviceName string, commandName string, queryParams string, ctx context.Context, dic *di.Container) (event dtos.Event, warnings []string, err errors.EdgeX) {
 	if deviceName == "" {
-		return event, errors.NewCommonEdgeX(errors.KindContractInvalid, "device name cannot be empty", nil)
+		return event, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "device name cannot be empty", nil)
 	}
 
 	if commandName == "" {
-		return event, errors.NewCommonEdgeX(errors.KindContractInvalid, "command name cannot be empty", nil)
+		return event, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "command name cannot be empty", nil)
 	}
 
 	// retrieve device information through Metadata DeviceClient
 	dc := V2Container.MetadataDeviceClientFrom(dic.Get)
 	if dc == nil {
-		return event, errors.NewCommonEdgeX(errors.KindClientError, "nil MetadataDeviceClient returned", nil)
+		return event, nil, errors.NewCommonEdgeX(errors.KindClientError, "nil MetadataDeviceClient returned", nil)
 	}
 	deviceResponse, err := dc.DeviceByName(context.Background(), deviceName)
 	if err != nil {
-		return event, errors.NewCommonEdgeXWrapper(err)
+		return event, nil, errors.NewCommonEdgeXWrapper(err)
 	}
 
 	// retrieve device service information through Metadata DeviceClient
 	dsc := V2Container.MetadataDeviceServiceClientFrom(dic.Get)
 	if dsc == nil {
-		return event, errors.NewCommonEdgeX(errors.KindClientError, "nil MetadataDeviceServiceClient returned", nil)
+		return event, nil, errors.NewCommonEdgeX(errors.KindClientError, "nil MetadataDeviceServiceClient returned", nil)
 	}
 	deviceServiceResponse, err := dsc.DeviceServiceByName(context.Background(), deviceResponse.Device.ServiceName)
 	if err != nil {
-		return event, errors.NewCommonEdgeXWrapper(err)
+		return event, nil, errors.NewCommonEdgeXWrapper(err)
 	}
 
 	// Issue command by passing the base address of device service into DeviceServiceCommandClient
 	dscc := V2Container.DeviceServiceCommandClientFrom(dic.Get)
 	if dscc == nil {
-		return event, errors.NewCommonEdgeX(errors.KindClientError, "nil DeviceServiceCommandClient returned", nil)
+		return event, nil, errors.NewCommonEdgeX(errors.KindClientError, "nil DeviceServiceCommandClient returned", nil)
 	}
 	eventResponse, err := dscc.GetCommand(context.Background(), deviceServiceResponse.Service.BaseAddress, deviceName, commandName, queryParams)
 	if err != nil {
-		return event, errors.NewCommonEdgeXWrapper(err)
+		return event, nil, errors.NewCommonEdgeXWrapper(err)
 	}
 
-	return eventResponse.Event, nil
+	return eventResponse.Event, deprecationWarnings(deviceResponse.Device.ProfileName, commandName, ctx, dic), nil
+}
+
+// deprecationWarnings returns the Warning header values announcing the deprecation of the command of the device
+// profile. The deprecations which can't be queried are logged rather than failing the command.
+func deprecationWarnings(profileName string, commandName string, ctx context.Context, dic *di.Container) []string {
+	client := commandContainer.DeprecationsClientFrom(dic.Get)
+	if client == nil {
+		return nil
+	}
+	profileDeprecations, err := client.DeprecationsByProfileName(ctx, profileName)
+	if err != nil {
+		container.LoggingClientFrom(dic.Get).Warnf("failed to query the deprecations of device profile %s: %s, Correlation-ID: %s",
+			profileName, err.Error(), correlation.FromContext(ctx))
+		return nil
+	}
+	d, ok := deprecations.Find(profileDeprecations, commandName)
+	if !ok {
+		return nil
+	}
+	return []string{d.Warning()}
 }
@@ -0,0 +1,92 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/analytics"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+const (
+	// ApiCommandAnalyticsRoute reports the usage statistics of the commands issued through core-command
+	ApiCommandAnalyticsRoute = v2.ApiBase + "/command/analytics"
+
+	// Device is the query parameter restricting the statistics to one device
+	Device = "device"
+	// SortBy is the query parameter ordering the statistics: errors, errorRate, count or latency
+	SortBy = "sortBy"
+)
+
+// CommandAnalyticsResponse defines the response content of ApiCommandAnalyticsRoute
+type CommandAnalyticsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	// Since is when the counting started, in milliseconds since the epoch
+	Since    int64                    `json:"since"`
+	Commands []analytics.CommandStats `json:"commands"`
+}
+
+func (cc *CommandController) CommandAnalytics(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(cc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := commandContainer.ConfigurationFrom(cc.dic.Get)
+	tracker := commandContainer.CommandTrackerFrom(cc.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	// parse URL query string for offset, limit, device and sort order
+	offset, limit, _, edgexErr := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if edgexErr == nil {
+		device := utils.ParseQueryStringToString(r, Device, "")
+		sortBy := utils.ParseQueryStringToString(r, SortBy, analytics.SortByErrors)
+		stats, err := tracker.Query(device, sortBy)
+		if err != nil {
+			edgexErr = errors.NewCommonEdgeX(errors.KindContractInvalid, err.Error(), nil)
+		} else {
+			response = CommandAnalyticsResponse{
+				BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+				Since:        tracker.Since(),
+				Commands:     page(stats, offset, limit),
+			}
+			statusCode = http.StatusOK
+		}
+	}
+
+	if edgexErr != nil {
+		lc.Error(edgexErr.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(edgexErr.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", edgexErr.Message(), edgexErr.Code())
+		statusCode = edgexErr.Code()
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	// encode and send out the response
+	pkg.Encode(response, w, lc)
+}
+
+// page returns the limit statistics following offset, or all of them when limit is negative
+func page(stats []analytics.CommandStats, offset int, limit int) []analytics.CommandStats {
+	if offset >= len(stats) {
+		return []analytics.CommandStats{}
+	}
+	stats = stats[offset:]
+	if limit >= 0 && limit < len(stats) {
+		stats = stats[:limit]
+	}
+	return stats
+}
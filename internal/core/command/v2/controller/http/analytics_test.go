@@ -0,0 +1,89 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/analytics"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandAnalytics(t *testing.T) {
+	tracker := analytics.NewTracker()
+	tracker.Record(testDeviceName, testCommandName, 10*time.Millisecond, http.StatusOK)
+	tracker.Record(testDeviceName, testCommandName, 10*time.Millisecond, http.StatusInternalServerError)
+	tracker.Record(testDeviceName, "otherCommand", 10*time.Millisecond, http.StatusOK)
+	tracker.Record("otherDevice", testCommandName, 10*time.Millisecond, http.StatusOK)
+
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.CommandTrackerName: func(get di.Get) interface{} {
+			return tracker
+		},
+	})
+	cc := NewCommandController(dic)
+
+	tests := []struct {
+		name               string
+		device             string
+		sortBy             string
+		offset             string
+		limit              string
+		errorExpected      bool
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - all devices", "", "", "", "", false, 3, http.StatusOK},
+		{"Valid - one device", testDeviceName, analytics.SortByCount, "", "", false, 2, http.StatusOK},
+		{"Valid - with offset and limit", "", analytics.SortByErrors, "1", "1", false, 1, http.StatusOK},
+		{"Valid - offset past the end", "", "", "5", "", false, 0, http.StatusOK},
+		{"Invalid - unknown sort order", "", "unknown", "", "", true, 0, http.StatusBadRequest},
+		{"Invalid - invalid limit format", "", "", "", "aaa", true, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ApiCommandAnalyticsRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			for key, value := range map[string]string{Device: testCase.device, SortBy: testCase.sortBy, v2.Offset: testCase.offset, v2.Limit: testCase.limit} {
+				if value != "" {
+					query.Add(key, value)
+				}
+			}
+			req.URL.RawQuery = query.Encode()
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(cc.CommandAnalytics)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res CommandAnalyticsResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedCount, len(res.Commands), "Command count not as expected")
+				assert.Equal(t, tracker.Since(), res.Since)
+			}
+		})
+	}
+}
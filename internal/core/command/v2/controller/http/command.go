@@ -13,6 +13,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/command/v2/application"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
@@ -112,7 +113,7 @@ func (cc *CommandController) IssueGetCommandByName(w http.ResponseWriter, r *htt
 	var response interface{}
 	var statusCode int
 
-	event, err := application.IssueGetCommandByName(deviceName, commandName, queryParams, cc.dic)
+	event, warnings, err := application.IssueGetCommandByName(deviceName, commandName, queryParams, ctx, cc.dic)
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
@@ -121,6 +122,9 @@ func (cc *CommandController) IssueGetCommandByName(w http.ResponseWriter, r *htt
 	} else {
 		response = responseDTO.NewEventResponse("", "", http.StatusOK, event)
 		statusCode = http.StatusOK
+		for _, warning := range warnings {
+			w.Header().Add(deprecations.WarningHeader, warning)
+		}
 	}
 
 	utils.WriteHttpHeader(w, ctx, statusCode)
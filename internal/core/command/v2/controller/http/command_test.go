@@ -14,6 +14,7 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
 	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
@@ -396,3 +397,73 @@ func TestIssueReadCommand(t *testing.T) {
 		})
 	}
 }
+
+// deprecationsClient returns the deprecations of testProfileName
+type deprecationsClient []deprecations.Deprecation
+
+func (c deprecationsClient) DeprecationsByProfileName(_ context.Context, name string) ([]deprecations.Deprecation, errors.EdgeX) {
+	if name != testProfileName {
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device profile doesn't exist", nil)
+	}
+	return c, nil
+}
+
+func TestIssueReadCommandDeprecated(t *testing.T) {
+	deprecation := deprecations.Deprecation{
+		ProfileName:    testProfileName,
+		ResourceName:   testCommandName,
+		RemovalVersion: "2.0",
+		Replacement:    testResourceName,
+	}
+
+	dcMock := &mocks.DeviceClient{}
+	dcMock.On("DeviceByName", context.Background(), testDeviceName).Return(buildDeviceResponse(), nil)
+	dscMock := &mocks.DeviceServiceClient{}
+	dscMock.On("DeviceServiceByName", context.Background(), testDeviceServiceName).Return(buildDeviceServiceResponse(), nil)
+	dsccMock := &mocks.DeviceServiceCommandClient{}
+	dsccMock.On("GetCommand", context.Background(), testBaseAddress, testDeviceName, testCommandName, "").Return(buildEventResponse(), nil)
+	dsccMock.On("GetCommand", context.Background(), testBaseAddress, testDeviceName, testResourceName, "").Return(buildEventResponse(), nil)
+
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		V2Container.MetadataDeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		V2Container.MetadataDeviceServiceClientName: func(get di.Get) interface{} {
+			return dscMock
+		},
+		V2Container.DeviceServiceCommandClientName: func(get di.Get) interface{} {
+			return dsccMock
+		},
+		commandContainer.DeprecationsClientName: func(get di.Get) interface{} {
+			return deprecationsClient{deprecation}
+		},
+	})
+	cc := NewCommandController(dic)
+	require.NotNil(t, cc)
+
+	tests := []struct {
+		name             string
+		commandName      string
+		expectedWarnings []string
+	}{
+		{"Deprecated command", testCommandName, []string{deprecation.Warning()}},
+		{"Command not deprecated", testResourceName, nil},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, v2.ApiDeviceNameCommandNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testDeviceName, v2.Command: testCase.commandName})
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(cc.IssueGetCommandByName)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedWarnings, recorder.Result().Header.Values(deprecations.WarningHeader))
+		})
+	}
+}
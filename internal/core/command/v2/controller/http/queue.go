@@ -0,0 +1,122 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/offline"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiCommandQueueRoute reports the PUT commands queued until their device is reachable again
+	ApiCommandQueueRoute = v2.ApiBase + "/command/queue"
+	// ApiCommandQueueByIdRoute cancels a queued command
+	ApiCommandQueueByIdRoute = ApiCommandQueueRoute + "/" + v2.Id + "/{" + v2.Id + "}"
+	// ApiCommandQueueByDeviceNameRoute cancels the queued commands of a device
+	ApiCommandQueueByDeviceNameRoute = ApiCommandQueueRoute + "/" + v2.Device + "/" + v2.Name + "/{" + v2.Name + "}"
+)
+
+// CommandQueueResponse defines the response content of ApiCommandQueueRoute
+type CommandQueueResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Commands               []offline.Command `json:"commands"`
+}
+
+func (cc *CommandController) CommandQueue(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(cc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := commandContainer.ConfigurationFrom(cc.dic.Get)
+	queue := commandContainer.OfflineQueueFrom(cc.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	// parse URL query string for offset, limit and device
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		commands := queue.Commands(utils.ParseQueryStringToString(r, Device, ""))
+		if offset >= len(commands) {
+			commands = []offline.Command{}
+		} else {
+			commands = commands[offset:]
+		}
+		if limit >= 0 && limit < len(commands) {
+			commands = commands[:limit]
+		}
+		response = CommandQueueResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+			Commands:     commands,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (cc *CommandController) CancelQueuedCommand(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(cc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	queue := commandContainer.OfflineQueueFrom(cc.dic.Get)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	id := vars[v2.Id]
+
+	var response interface{}
+	var statusCode int
+
+	if !queue.Cancel(id) {
+		err := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("no queued command with id %s", id), nil)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		lc.Info(fmt.Sprintf("queued command %s cancelled", id), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", "", http.StatusOK)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (cc *CommandController) CancelQueuedCommandsByDeviceName(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(cc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	queue := commandContainer.OfflineQueueFrom(cc.dic.Get)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	name := vars[v2.Name]
+
+	count := queue.CancelDevice(name)
+	lc.Info(fmt.Sprintf("%d queued commands of device %s cancelled", count, name), clients.CorrelationHeader, correlationId)
+
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	pkg.Encode(commonDTO.NewCountResponse("", "", http.StatusOK, uint32(count)), w, lc)
+}
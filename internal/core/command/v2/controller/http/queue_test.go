@@ -0,0 +1,98 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/offline"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandQueue(t *testing.T) {
+	queue, err := offline.NewQueue(offline.OfflineQueueInfo{Enabled: true})
+	require.NoError(t, err)
+	var queued []offline.Command
+	for _, deviceName := range []string{testDeviceName, testDeviceName, "otherDevice"} {
+		req, err := http.NewRequest(http.MethodPut, "http://localhost:49990/api/v1/device/"+deviceName, http.NoBody)
+		require.NoError(t, err)
+		c, err := queue.Enqueue(deviceName, testCommandName, req, "{}", http.Header{})
+		require.NoError(t, err)
+		queued = append(queued, c)
+	}
+
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.OfflineQueueName: func(get di.Get) interface{} {
+			return queue
+		},
+	})
+	cc := NewCommandController(dic)
+
+	tests := []struct {
+		name               string
+		device             string
+		limit              string
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - all devices", "", "", 3, http.StatusOK},
+		{"Valid - one device", testDeviceName, "", 2, http.StatusOK},
+		{"Valid - with limit", "", "1", 1, http.StatusOK},
+		{"Invalid - invalid limit format", "", "aaa", 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ApiCommandQueueRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			if testCase.device != "" {
+				query.Add(Device, testCase.device)
+			}
+			if testCase.limit != "" {
+				query.Add(v2.Limit, testCase.limit)
+			}
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(cc.CommandQueue).ServeHTTP(recorder, req)
+
+			var res CommandQueueResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Len(t, res.Commands, testCase.expectedCount, "Queued command count not as expected")
+		})
+	}
+
+	// cancel one command, then the commands of a device
+	req, err := http.NewRequest(http.MethodDelete, ApiCommandQueueByIdRoute, http.NoBody)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	http.HandlerFunc(cc.CancelQueuedCommand).ServeHTTP(recorder, mux.SetURLVars(req, map[string]string{v2.Id: queued[0].Id}))
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	recorder = httptest.NewRecorder()
+	http.HandlerFunc(cc.CancelQueuedCommand).ServeHTTP(recorder, mux.SetURLVars(req, map[string]string{v2.Id: queued[0].Id}))
+	assert.Equal(t, http.StatusNotFound, recorder.Result().StatusCode)
+
+	req, err = http.NewRequest(http.MethodDelete, ApiCommandQueueByDeviceNameRoute, http.NoBody)
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	http.HandlerFunc(cc.CancelQueuedCommandsByDeviceName).ServeHTTP(recorder, mux.SetURLVars(req, map[string]string{v2.Name: testDeviceName}))
+	var res common.CountResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	assert.Equal(t, uint32(1), res.Count)
+	assert.Len(t, queue.Commands(""), 1)
+}
@@ -8,11 +8,18 @@ package v2
 import (
 	"net/http"
 
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
 	commandController "github.com/edgexfoundry/edgex-go/internal/core/command/v2/controller/http"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/openapi"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/schema"
 	commonController "github.com/edgexfoundry/edgex-go/internal/pkg/v2/controller/http"
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	v2Constant "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
 	"github.com/gorilla/mux"
 )
 
@@ -24,12 +31,28 @@ func LoadRestRoutes(r *mux.Router, dic *di.Container) {
 	r.HandleFunc(v2Constant.ApiVersionRoute, cc.Version).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiConfigRoute, cc.Config).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiMetricsRoute, cc.Metrics).Methods(http.MethodGet)
+	r.HandleFunc(correlation.ApiTraceRoute, cc.Trace).Methods(http.MethodGet)
+	r.HandleFunc(features.ApiCapabilitiesRoute, cc.Capabilities).Methods(http.MethodGet)
+	r.HandleFunc(httpclient.ApiOutboundMetricsRoute, cc.OutboundMetrics).Methods(http.MethodGet)
+
+	// Schemas
+	schemas := schema.NewRegistry(bootstrapContainer.LoggingClientFrom(dic.Get))
+	schemas.Add(responseDTO.DeviceCoreCommandResponse{}, responseDTO.MultiDeviceCoreCommandsResponse{})
+	schema.LoadRestRoutes(r, schemas)
+
+	// OpenAPI document
+	openapi.LoadRestRoutes(r, bootstrapContainer.LoggingClientFrom(dic.Get), openapi.CoreCommandSpec,
+		commandContainer.ConfigurationFrom(dic.Get).JWTAuth.Enabled)
 
 	// Command
 	cmd := commandController.NewCommandController(dic)
 	r.HandleFunc(v2Constant.ApiAllDeviceRoute, cmd.AllCommands).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiDeviceByNameRoute, cmd.CommandsByDeviceName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiDeviceNameCommandNameRoute, cmd.IssueGetCommandByName).Methods(http.MethodGet)
+	r.HandleFunc(commandController.ApiCommandAnalyticsRoute, cmd.CommandAnalytics).Methods(http.MethodGet)
+	r.HandleFunc(commandController.ApiCommandQueueRoute, cmd.CommandQueue).Methods(http.MethodGet)
+	r.HandleFunc(commandController.ApiCommandQueueByIdRoute, cmd.CancelQueuedCommand).Methods(http.MethodDelete)
+	r.HandleFunc(commandController.ApiCommandQueueByDeviceNameRoute, cmd.CancelQueuedCommandsByDeviceName).Methods(http.MethodDelete)
 
 	r.Use(correlation.ManageHeader)
 	r.Use(correlation.OnResponseComplete)
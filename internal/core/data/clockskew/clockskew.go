@@ -0,0 +1,133 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clockskew guards core-data against the events whose origin timestamp is too far from the time of the
+// gateway, such as those of the devices with a dead RTC battery, which would pollute the time-series stores
+// downstream.
+package clockskew
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// ActionReject rejects the events out of the limits
+	ActionReject = "reject"
+	// ActionFlag accepts the events out of the limits, tagged with FlagTag
+	ActionFlag = "flag"
+
+	// FlagTag is the tag of the flagged events, valued Past or Future
+	FlagTag = "clockSkew"
+	// Past is the direction of the skew of an origin before the time of the gateway
+	Past = "past"
+	// Future is the direction of the skew of an origin after the time of the gateway
+	Future = "future"
+)
+
+// LimitsInfo configures how far the origin of an event may be from the time of the gateway. A limit is disabled when
+// empty.
+type LimitsInfo struct {
+	// MaxPast is how far in the past the origin may be, e.g. "24h"
+	MaxPast string
+	// MaxFuture is how far in the future the origin may be, e.g. "5m"
+	MaxFuture string
+}
+
+// ClockSkewInfo holds the limits of the clock skew of the events
+type ClockSkewInfo struct {
+	// Action is applied to the events out of the limits, ActionReject or ActionFlag
+	Action string
+	// Default limits the origin of the events of all devices
+	Default LimitsInfo
+	// DeviceOverrides replaces the Default limits for the named devices
+	DeviceOverrides map[string]LimitsInfo
+}
+
+// Skew is the outcome of ClockSkewInfo.Check
+type Skew struct {
+	// Direction is Past or Future, empty when the origin is within the limits
+	Direction string
+	// Offset is how far the origin is from the time of the gateway, negative in the past
+	Offset time.Duration
+	// Limit is the limit exceeded
+	Limit time.Duration
+}
+
+// Exceeded is true when the origin is out of the limits
+func (s Skew) Exceeded() bool {
+	return s.Direction != ""
+}
+
+// String describes the skew of an origin out of the limits
+func (s Skew) String() string {
+	offset := s.Offset
+	if offset < 0 {
+		offset = -offset
+	}
+	return fmt.Sprintf("origin is %s in the %s, more than the %s allowed", offset, s.Direction, s.Limit)
+}
+
+func parseLimit(name string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	limit, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s '%s': %s", name, value, err.Error())
+	}
+	if limit <= 0 {
+		return 0, fmt.Errorf("%s '%s' must be positive", name, value)
+	}
+	return limit, nil
+}
+
+func (l LimitsInfo) durations() (maxPast time.Duration, maxFuture time.Duration, err error) {
+	if maxPast, err = parseLimit("MaxPast", l.MaxPast); err != nil {
+		return 0, 0, err
+	}
+	if maxFuture, err = parseLimit("MaxFuture", l.MaxFuture); err != nil {
+		return 0, 0, err
+	}
+	return maxPast, maxFuture, nil
+}
+
+// Validate checks the action and the limits
+func (info ClockSkewInfo) Validate() error {
+	if info.Action != ActionReject && info.Action != ActionFlag {
+		return fmt.Errorf("invalid Action '%s', must be '%s' or '%s'", info.Action, ActionReject, ActionFlag)
+	}
+	if _, _, err := info.Default.durations(); err != nil {
+		return fmt.Errorf("default limits: %s", err.Error())
+	}
+	for deviceName, limits := range info.DeviceOverrides {
+		if _, _, err := limits.durations(); err != nil {
+			return fmt.Errorf("limits of device %s: %s", deviceName, err.Error())
+		}
+	}
+	return nil
+}
+
+// Check returns the skew of origin, in nanoseconds, from now when it is out of the limits of deviceName. An origin of
+// zero isn't set and is never out of the limits.
+func (info ClockSkewInfo) Check(deviceName string, origin int64, now time.Time) (Skew, error) {
+	limits, ok := info.DeviceOverrides[deviceName]
+	if !ok {
+		limits = info.Default
+	}
+	maxPast, maxFuture, err := limits.durations()
+	if err != nil || origin == 0 {
+		return Skew{}, err
+	}
+
+	offset := time.Unix(0, origin).Sub(now)
+	switch {
+	case maxPast > 0 && offset < -maxPast:
+		return Skew{Direction: Past, Offset: offset, Limit: maxPast}, nil
+	case maxFuture > 0 && offset > maxFuture:
+		return Skew{Direction: Future, Offset: offset, Limit: maxFuture}, nil
+	}
+	return Skew{}, nil
+}
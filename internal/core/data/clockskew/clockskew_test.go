@@ -0,0 +1,92 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clockskew
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	now := time.Unix(1600666185, 0)
+	info := ClockSkewInfo{
+		Action:  ActionReject,
+		Default: LimitsInfo{MaxPast: "24h", MaxFuture: "5m"},
+		DeviceOverrides: map[string]LimitsInfo{
+			"buffering-device": {MaxPast: "720h"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		deviceName        string
+		origin            time.Time
+		expectedDirection string
+	}{
+		{"Within - now", "device", now, ""},
+		{"Within - past", "device", now.Add(-23 * time.Hour), ""},
+		{"Within - future", "device", now.Add(4 * time.Minute), ""},
+		{"Exceeded - past", "device", now.Add(-25 * time.Hour), Past},
+		{"Exceeded - future", "device", now.Add(6 * time.Minute), Future},
+		{"Exceeded - dead RTC battery", "device", time.Unix(0, 0).Add(time.Hour), Past},
+		{"Within - past of override", "buffering-device", now.Add(-25 * time.Hour), ""},
+		{"Within - future of override without limit", "buffering-device", now.Add(time.Hour), ""},
+		{"Exceeded - past of override", "buffering-device", now.Add(-721 * time.Hour), Past},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			skew, err := info.Check(testCase.deviceName, testCase.origin.UnixNano(), now)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedDirection, skew.Direction)
+			assert.Equal(t, testCase.expectedDirection != "", skew.Exceeded())
+			if skew.Exceeded() {
+				assert.Equal(t, testCase.origin.Sub(now), skew.Offset)
+			}
+		})
+	}
+}
+
+func TestCheckOriginNotSet(t *testing.T) {
+	info := ClockSkewInfo{Action: ActionReject, Default: LimitsInfo{MaxPast: "1h"}}
+
+	skew, err := info.Check("device", 0, time.Now())
+	require.NoError(t, err)
+	assert.False(t, skew.Exceeded())
+}
+
+func TestSkewString(t *testing.T) {
+	skew := Skew{Direction: Past, Offset: -25 * time.Hour, Limit: 24 * time.Hour}
+
+	assert.Equal(t, "origin is 25h0m0s in the past, more than the 24h0m0s allowed", skew.String())
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		info        ClockSkewInfo
+		expectError bool
+	}{
+		{"Valid - no limits", ClockSkewInfo{Action: ActionReject}, false},
+		{"Valid - flag", ClockSkewInfo{Action: ActionFlag, Default: LimitsInfo{MaxPast: "24h", MaxFuture: "5m"}}, false},
+		{"Invalid - action", ClockSkewInfo{Action: "drop"}, true},
+		{"Invalid - default limit", ClockSkewInfo{Action: ActionReject, Default: LimitsInfo{MaxPast: "a day"}}, true},
+		{"Invalid - negative limit", ClockSkewInfo{Action: ActionReject, Default: LimitsInfo{MaxFuture: "-5m"}}, true},
+		{"Invalid - override limit", ClockSkewInfo{Action: ActionReject, DeviceOverrides: map[string]LimitsInfo{"device": {MaxPast: "0s"}}}, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.info.Validate()
+			if testCase.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/cloudbridge"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// startCloudBridge connects to the cloud and adds the bridge forwarding the events to the DIC. The bridge is stopped
+// once httpServer stops, so that the events of the in-flight requests are still forwarded.
+func startCloudBridge(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	dic *di.Container,
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	httpServer httpServer) error {
+
+	info := configuration.CloudBridge
+	bridge, err := cloudbridge.NewBridge(info, lc)
+	if err != nil {
+		return err
+	}
+	will, err := bridge.Will()
+	if err != nil {
+		return err
+	}
+	connection, err := cloudbridge.Connect(info, container.SecretProviderFrom(dic.Get), lc, will, bridge.Connected)
+	if err != nil {
+		return err
+	}
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.CloudBridgeName: func(get di.Get) interface{} {
+			return bridge
+		},
+	})
+
+	bridgeCtx, stop := context.WithCancel(context.Background())
+	bridge.Run(bridgeCtx, wg, connection)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		for httpServer.IsRunning() {
+			time.Sleep(time.Second)
+		}
+		stop()
+	}()
+
+	lc.Info(fmt.Sprintf("Forwarding the events to %s as %s", info.Provider, info.ClientId))
+	return nil
+}
@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// startReadingCompression compresses the readings older than ReadingCompression.After every
+// ReadingCompression.Interval until ctx is done, on the leader only when the instances are coordinated
+func startReadingCompression(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	dic *di.Container,
+	lc logger.LoggingClient,
+	info config.ReadingCompressionInfo) error {
+
+	if err := info.Validate(); err != nil {
+		return err
+	}
+	// validated above
+	after, interval, _ := info.Durations()
+
+	compress := func() {
+		started := time.Now()
+		before := common.MakeTimestamp() - after.Milliseconds()
+		compressed, err := v2DataContainer.DBClientFrom(dic.Get).CompressReadings(before, info.ChunkSize)
+		if err != nil {
+			lc.Error(fmt.Sprintf("failed to compress the readings older than %s: %s", info.After, err.Error()))
+			return
+		}
+		lc.Debug(fmt.Sprintf("%d readings older than %s compressed in %s", compressed, info.After, time.Since(started)))
+	}
+
+	runPeriodically(ctx, wg, dic, "reading compression", interval, compress)
+
+	lc.Info(fmt.Sprintf("Readings older than %s compressed every %s by chunks of %d", info.After, info.Interval, info.ChunkSize))
+	return nil
+}
@@ -15,18 +15,64 @@ package config
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/clockskew"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/ratelimit"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/storageguard"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/cloudbridge"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/enrichment"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/federation"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/kafka"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/onchange"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/provenance"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/redaction"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/tail"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/partitions"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/profiling"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/readonly"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/registrywatch"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
 
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 )
 
 type ConfigurationStruct struct {
-	Writable     WritableInfo
-	MessageQueue MessageQueueInfo
-	Clients      map[string]bootstrapConfig.ClientInfo
-	Databases    map[string]bootstrapConfig.Database
-	Registry     bootstrapConfig.RegistryInfo
-	Service      bootstrapConfig.ServiceInfo
-	SecretStore  bootstrapConfig.SecretStoreInfo
+	Writable           WritableInfo
+	MessageQueue       MessageQueueInfo
+	Clients            map[string]bootstrapConfig.ClientInfo
+	Databases          map[string]bootstrapConfig.Database
+	Registry           bootstrapConfig.RegistryInfo
+	Service            bootstrapConfig.ServiceInfo
+	SecretStore        bootstrapConfig.SecretStoreInfo
+	Coordination       CoordinationInfo
+	DatabaseMigration  DatabaseMigrationInfo
+	StorageMigration   StorageMigrationInfo
+	Jobs               jobs.JobsInfo
+	ReadingCompression ReadingCompressionInfo
+	JWTAuth            jwtauth.JWTAuthInfo
+	Enrichment         enrichment.EnrichmentInfo
+	Redaction          redaction.RedactionInfo
+	OnChange           onchange.OnChangeInfo
+	UDPIngestion       UDPIngestionInfo
+	TagIndex           tags.IndexInfo
+	EventPartitions    partitions.PartitionInfo
+	EventSigning       eventsig.EventSigningInfo
+	StorageGuard       storageguard.StorageGuardInfo
+	SecretCache        secretcache.SecretCacheInfo
+	RegistryWatch      registrywatch.RegistryWatchInfo
+	Kafka              kafka.KafkaInfo
+	CloudBridge        cloudbridge.CloudBridgeInfo
+	Provenance         provenance.ProvenanceInfo
+	Federation         federation.FederationInfo
+	EventTail          tail.TailInfo
 }
 
 type WritableInfo struct {
@@ -36,8 +82,58 @@ type WritableInfo struct {
 	ServiceUpdateLastConnected bool
 	ValidateCheck              bool
 	LogLevel                   string
+	ShutdownTimeout            string
+	RequestLimits              requestlimits.RequestLimitsInfo
+	ProblemDetails             problem.ProblemDetailsInfo
+	CORS                       cors.CORSInfo
+	ReadOnly                   readonly.ReadOnlyInfo
+	Profiling                  profiling.ProfilingInfo
+	IngestionLimits            ratelimit.IngestionLimitsInfo
+	ClockSkew                  clockskew.ClockSkewInfo
 	ChecksumAlgo               string
 	InsecureSecrets            bootstrapConfig.InsecureSecrets
+	// StorageCutover makes Databases.Secondary serve reads and be written first while StorageMigration is enabled.
+	// It can be switched back and forth without restarting the service.
+	StorageCutover bool
+	EventExpiry    EventExpiryInfo
+}
+
+// EventExpiryInfo configures the expiry of the events, set when they are written. The expired events are deleted a
+// few at a time on each insert, and by a sweep every minute, rather than all at once by the periodic scrubbing of
+// support-scheduler.
+type EventExpiryInfo struct {
+	// TTL is how long the events written from now on are kept, e.g. "24h". The events don't expire when empty.
+	TTL string
+	// DeleteBatchSize is the maximum number of expired events deleted on each insert. It must be greater than 1 for
+	// the deletions to catch up with the inserts.
+	DeleteBatchSize int
+}
+
+// Duration returns the TTL of the events, zero when they don't expire
+func (e EventExpiryInfo) Duration() (time.Duration, error) {
+	if e.TTL == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(e.TTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid TTL '%s': %s", e.TTL, err.Error())
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("TTL '%s' must be positive", e.TTL)
+	}
+	return ttl, nil
+}
+
+// Validate checks the TTL and, when the events expire, the DeleteBatchSize
+func (e EventExpiryInfo) Validate() error {
+	ttl, err := e.Duration()
+	if err != nil {
+		return err
+	}
+	if ttl > 0 && e.DeleteBatchSize < 2 {
+		return fmt.Errorf("DeleteBatchSize %d must be at least 2 for the deletions to catch up with the inserts", e.DeleteBatchSize)
+	}
+	return nil
 }
 
 // MessageQueueInfo provides parameters related to connecting to a message queue
@@ -62,6 +158,102 @@ type MessageQueueInfo struct {
 	Optional map[string]string
 }
 
+// CoordinationInfo configures leader election between core-data instances sharing the same database
+type CoordinationInfo struct {
+	// Enabled turns on leader election. Leave disabled when running a single instance.
+	Enabled bool
+	// InstanceId identifies this instance as lease owner. Defaults to the host name when empty.
+	InstanceId string
+	// LeaseKey is the database key holding the leader lease.
+	LeaseKey string
+	// LeaseDuration is how long the lease is held without renewal, e.g. "15s".
+	LeaseDuration string
+	// RenewInterval is how often the lease is renewed, or campaigned for, e.g. "5s". Must be less than LeaseDuration.
+	RenewInterval string
+}
+
+// DatabaseMigrationInfo configures the migration of the layout of the keys stored in the database on startup
+type DatabaseMigrationInfo struct {
+	// DryRun logs what the pending migrations would change without changing anything, and then stops the service.
+	DryRun bool
+}
+
+// StorageMigrationInfo configures the dual-write mode migrating core-data to the database of Databases.Secondary
+// without downtime
+type StorageMigrationInfo struct {
+	// Enabled writes to both Databases.Primary and Databases.Secondary, the primary serving reads until
+	// Writable.StorageCutover is set
+	Enabled bool
+	// CheckInterval is how often the consistency of both databases is checked and logged, e.g. "10m". The periodic
+	// check is disabled when empty.
+	CheckInterval string
+	// CheckSampleSize is the number of most recent events a consistency check looks up in both databases
+	CheckSampleSize int
+}
+
+// ReadingCompressionInfo configures the compression of the stored numeric readings, which are then decoded
+// transparently by the queries
+type ReadingCompressionInfo struct {
+	// Enabled turns on the periodic compression of the readings
+	Enabled bool
+	// After is the age of the readings compressed, e.g. "1h", the recent readings being left uncompressed
+	After string
+	// Interval is how often the readings are compressed, e.g. "10m"
+	Interval string
+	// ChunkSize is the maximum number of readings of a device resource compressed together, at least 2
+	ChunkSize int
+}
+
+// Durations returns the After and Interval durations
+func (r ReadingCompressionInfo) Durations() (after time.Duration, interval time.Duration, err error) {
+	if after, err = time.ParseDuration(r.After); err != nil {
+		return 0, 0, fmt.Errorf("invalid After '%s': %s", r.After, err.Error())
+	}
+	if after < 0 {
+		return 0, 0, fmt.Errorf("After '%s' must not be negative", r.After)
+	}
+	if interval, err = time.ParseDuration(r.Interval); err != nil {
+		return 0, 0, fmt.Errorf("invalid Interval '%s': %s", r.Interval, err.Error())
+	}
+	if interval <= 0 {
+		return 0, 0, fmt.Errorf("Interval '%s' must be positive", r.Interval)
+	}
+	return after, interval, nil
+}
+
+// Validate checks the durations and the ChunkSize
+func (r ReadingCompressionInfo) Validate() error {
+	if _, _, err := r.Durations(); err != nil {
+		return err
+	}
+	if r.ChunkSize < 2 {
+		return fmt.Errorf("ChunkSize %d must be at least 2", r.ChunkSize)
+	}
+	return nil
+}
+
+// UDPIngestionInfo configures the UDP listener of the compact events
+type UDPIngestionInfo struct {
+	// Enabled turns on the listener
+	Enabled bool
+	// Host is the address the listener binds to. Binds to all the interfaces when empty.
+	Host string
+	// Port is the UDP port the listener binds to
+	Port int
+	// MaxPacketSize is the size in bytes of the largest datagram accepted
+	MaxPacketSize int
+	DTLS          DTLSInfo
+}
+
+// DTLSInfo configures the DTLS security of the UDP listener
+type DTLSInfo struct {
+	// Enabled requires the devices to send their events over DTLS
+	Enabled bool
+	// SecretPath is the secret holding the PEM-encoded certificate (cert) and private key (key) of the listener and,
+	// optionally, the CA certificate (ca) the client certificates of the devices must be signed by
+	SecretPath string
+}
+
 // URL constructs a URL from the protocol, host and port and returns that as a string.
 func (m MessageQueueInfo) URL() string {
 	return fmt.Sprintf("%s://%s:%v", m.Protocol, m.Host, m.Port)
@@ -126,6 +318,51 @@ func (c *ConfigurationStruct) GetDatabaseInfo() map[string]bootstrapConfig.Datab
 	return c.Databases
 }
 
+// GetRequestLimits returns the limits applied to incoming requests.
+func (c *ConfigurationStruct) GetRequestLimits() requestlimits.RequestLimitsInfo {
+	return c.Writable.RequestLimits
+}
+
+// GetSecretCache returns the configuration of the cache of the secrets read from the secret store.
+func (c *ConfigurationStruct) GetSecretCache() secretcache.SecretCacheInfo {
+	return c.SecretCache
+}
+
+// GetRegistryWatch returns the configuration of the watch of the registration of the service with the registry.
+func (c *ConfigurationStruct) GetRegistryWatch() registrywatch.RegistryWatchInfo {
+	return c.RegistryWatch
+}
+
+// GetProblemDetails returns whether the failed requests are responded to with problem details.
+func (c *ConfigurationStruct) GetProblemDetails() problem.ProblemDetailsInfo {
+	return c.Writable.ProblemDetails
+}
+
+// GetReadOnly returns the read-only mode applied to incoming requests.
+func (c *ConfigurationStruct) GetReadOnly() readonly.ReadOnlyInfo {
+	return c.Writable.ReadOnly
+}
+
+// GetProfiling returns the configuration of the profiling endpoints.
+func (c *ConfigurationStruct) GetProfiling() profiling.ProfilingInfo {
+	return c.Writable.Profiling
+}
+
+// GetCORS returns the CORS policy applied to incoming requests.
+func (c *ConfigurationStruct) GetCORS() cors.CORSInfo {
+	return c.Writable.CORS
+}
+
+// GetIngestionLimits returns the rate limits applied to incoming events.
+func (c *ConfigurationStruct) GetIngestionLimits() ratelimit.IngestionLimitsInfo {
+	return c.Writable.IngestionLimits
+}
+
+// GetShutdownTimeout returns how long in-flight requests are given to complete when the service is stopped.
+func (c *ConfigurationStruct) GetShutdownTimeout() string {
+	return c.Writable.ShutdownTimeout
+}
+
 // GetInsecureSecrets returns the service's InsecureSecrets.
 func (c *ConfigurationStruct) GetInsecureSecrets() bootstrapConfig.InsecureSecrets {
 	return c.Writable.InsecureSecrets
@@ -0,0 +1,33 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/coordination"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// ElectorName contains the name of the leader Elector instance in the DIC.
+var ElectorName = di.TypeInstanceToName(coordination.Elector{})
+
+// ElectorFrom helper function queries the DIC and returns the leader Elector, or nil if coordination is disabled.
+func ElectorFrom(get di.Get) *coordination.Elector {
+	elector, ok := get(ElectorName).(*coordination.Elector)
+	if !ok {
+		return nil
+	}
+	return elector
+}
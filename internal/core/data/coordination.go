@@ -0,0 +1,92 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+package data
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/coordination"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const defaultLeaseKey = "edgex-core-data:leader"
+
+// newElector builds the leader Elector used to make sure background jobs run on a single core-data
+// instance when several of them share the same database.
+func newElector(lc logger.LoggingClient, dbClient interface{}, info config.CoordinationInfo) (*coordination.Elector, error) {
+	lease, ok := dbClient.(coordination.Lease)
+	if !ok {
+		return nil, fmt.Errorf("database client %T does not support leases", dbClient)
+	}
+
+	leaseDuration, err := time.ParseDuration(info.LeaseDuration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Coordination.LeaseDuration '%s': %s", info.LeaseDuration, err.Error())
+	}
+	renewInterval, err := time.ParseDuration(info.RenewInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Coordination.RenewInterval '%s': %s", info.RenewInterval, err.Error())
+	}
+	if renewInterval <= 0 || renewInterval >= leaseDuration {
+		return nil, fmt.Errorf("Coordination.RenewInterval (%s) must be positive and less than Coordination.LeaseDuration (%s)",
+			renewInterval, leaseDuration)
+	}
+
+	instanceId := info.InstanceId
+	if instanceId == "" {
+		if instanceId, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("unable to default Coordination.InstanceId to the host name: %s", err.Error())
+		}
+	}
+
+	leaseKey := info.LeaseKey
+	if leaseKey == "" {
+		leaseKey = defaultLeaseKey
+	}
+
+	return coordination.NewElector(lc, lease, leaseKey, instanceId, leaseDuration, renewInterval), nil
+}
+
+// runPeriodically calls job every interval until ctx is done. When instance coordination is enabled, job is only
+// called by the leader, so that the jobs acting on the shared database run once whatever the number of instances.
+func runPeriodically(ctx context.Context, wg *sync.WaitGroup, dic *di.Container, name string, interval time.Duration, job func()) {
+	if elector := dataContainer.ElectorFrom(dic.Get); elector != nil {
+		elector.RunExclusive(ctx, wg, name, interval, job)
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				job()
+			}
+		}
+	}()
+}
@@ -0,0 +1,59 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLease struct{}
+
+func (stubLease) AcquireLease(string, string, time.Duration) (bool, error) { return true, nil }
+func (stubLease) ReleaseLease(string, string) error                        { return nil }
+
+func TestNewElector(t *testing.T) {
+	valid := config.CoordinationInfo{InstanceId: "core-data-1", LeaseDuration: "15s", RenewInterval: "5s"}
+
+	tests := []struct {
+		name        string
+		dbClient    interface{}
+		info        config.CoordinationInfo
+		expectError bool
+	}{
+		{"valid", stubLease{}, valid, false},
+		{"no lease support", struct{}{}, valid, true},
+		{"bad duration", stubLease{}, config.CoordinationInfo{LeaseDuration: "soon", RenewInterval: "5s"}, true},
+		{"renew not shorter than lease", stubLease{}, config.CoordinationInfo{LeaseDuration: "5s", RenewInterval: "5s"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elector, err := newElector(logger.MockLogger{}, tt.dbClient, tt.info)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.info.InstanceId, elector.InstanceId())
+		})
+	}
+}
@@ -286,6 +286,8 @@ func putEventOnQueue(
 
 	lc.Debug("Putting event on message queue")
 
+	// Every published event carries a correlation id, even when not added through a request
+	ctx = correlation.NewContext(ctx)
 	evt.CorrelationId = correlation.FromContext(ctx)
 	// Re-marshal JSON content into bytes.
 	if clients.FromContext(ctx, clients.ContentType) == clients.ContentTypeJSON {
@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	// expirySweepInterval is how often the expired events left by the inserts are deleted
+	expirySweepInterval = time.Minute
+	// expirySweepBatchSize is the number of expired events deleted at once by the sweep
+	expirySweepBatchSize = 100
+)
+
+// startEventExpirySweep deletes the expired events every expirySweepInterval until ctx is done, on the leader only
+// when the instances are coordinated. Each insert deletes a few expired events already, so the sweep mostly deletes
+// the events which expired while no event was added.
+func startEventExpirySweep(ctx context.Context, wg *sync.WaitGroup, dic *di.Container, lc logger.LoggingClient) {
+	sweep := func() {
+		dbClient := v2DataContainer.DBClientFrom(dic.Get)
+		var total uint32
+		for ctx.Err() == nil {
+			deleted, err := dbClient.DeleteExpiredEvents(common.MakeTimestamp(), expirySweepBatchSize)
+			total += deleted
+			if err != nil {
+				lc.Error(fmt.Sprintf("failed to delete the expired events: %s", err.Error()))
+				break
+			}
+			if deleted < expirySweepBatchSize {
+				break
+			}
+		}
+		if total > 0 {
+			lc.Debug(fmt.Sprintf("%d expired events deleted by the sweep", total))
+		}
+	}
+
+	runPeriodically(ctx, wg, dic, "event expiry sweep", expirySweepInterval, sweep)
+}
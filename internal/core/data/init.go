@@ -18,11 +18,33 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/edgexfoundry/edgex-go"
 	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/ratelimit"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/storageguard"
 	"github.com/edgexfoundry/edgex-go/internal/core/data/v2"
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/controller/udp"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/enrichment"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/federation"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/onchange"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/provenance"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/redaction"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/tail"
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
 	errorContainer "github.com/edgexfoundry/edgex-go/internal/pkg/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/errorconcept"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/profiling"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/readonly"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/secret"
@@ -37,26 +59,97 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// httpServer defines the contract used to determine whether or not the http httpServer is running.
+type httpServer interface {
+	IsRunning() bool
+}
+
 // Bootstrap contains references to dependencies required by the BootstrapHandler.
 type Bootstrap struct {
-	router *mux.Router
+	router     *mux.Router
+	httpServer httpServer
 }
 
 // NewBootstrap is a factory method that returns an initialized Bootstrap receiver struct.
-func NewBootstrap(router *mux.Router) *Bootstrap {
+func NewBootstrap(router *mux.Router, httpServer httpServer) *Bootstrap {
 	return &Bootstrap{
-		router: router,
+		router:     router,
+		httpServer: httpServer,
 	}
 }
 
 // BootstrapHandler fulfills the BootstrapHandler contract and performs initialization needed by the data service.
 func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	// the bulk deletions run in background jobs, whose routes are loaded with the v2 ones
+	jobManager, err := jobs.NewManager(dataContainer.ConfigurationFrom(dic.Get).Jobs, container.LoggingClientFrom(dic.Get))
+	if err != nil {
+		container.LoggingClientFrom(dic.Get).Error("invalid Jobs configuration: " + err.Error())
+		return false
+	}
+	jobManager.Start(ctx, wg)
+	dic.Update(di.ServiceConstructorMap{
+		pkgContainer.JobManagerName: func(get di.Get) interface{} {
+			return jobManager
+		},
+	})
+
+	// the optional subsystems of the service, reported at features.ApiCapabilitiesRoute
+	registry := features.NewRegistry(container.LoggingClientFrom(dic.Get))
+	registry.Add(features.CloudBridge, dataContainer.ConfigurationFrom(dic.Get).CloudBridge.Enabled)
+	registry.Add(features.Kafka, dataContainer.ConfigurationFrom(dic.Get).Kafka.Enabled)
+	registry.Add(features.Federation, dataContainer.ConfigurationFrom(dic.Get).Federation.Enabled)
+	dic.Update(di.ServiceConstructorMap{
+		pkgContainer.FeaturesName: func(get di.Get) interface{} {
+			return registry
+		},
+	})
+
 	loadRestRoutes(b.router, dic)
 	v2.LoadRestRoutes(b.router, dic)
+	profiling.LoadRoutes(b.router, container.LoggingClientFrom(dic.Get), dataContainer.ConfigurationFrom(dic.Get), container.SecretProviderFrom(dic.Get))
+	b.router.Use(problem.NewMiddleware(clients.CoreDataServiceKey, dataContainer.ConfigurationFrom(dic.Get)))
+	cors.UseMiddleware(b.router, dataContainer.ConfigurationFrom(dic.Get))
+	b.router.Use(requestlimits.NewMiddleware(container.LoggingClientFrom(dic.Get), dataContainer.ConfigurationFrom(dic.Get)))
+	b.router.Use(readonly.NewMiddleware(container.LoggingClientFrom(dic.Get), dataContainer.ConfigurationFrom(dic.Get)))
+	b.router.Use(ratelimit.NewMiddleware(container.LoggingClientFrom(dic.Get), dataContainer.IngestionLimiterFrom(dic.Get), dataContainer.ConfigurationFrom(dic.Get)))
+	b.router.Use(storageguard.NewMiddleware(container.LoggingClientFrom(dic.Get), dataContainer.StorageGuardFrom(dic.Get)))
+	if err := jwtauth.UseMiddleware(b.router, dic, dataContainer.ConfigurationFrom(dic.Get).JWTAuth); err != nil {
+		container.LoggingClientFrom(dic.Get).Error("failed to enable JWT verification: " + err.Error())
+		return false
+	}
 
 	configuration := dataContainer.ConfigurationFrom(dic.Get)
 	lc := container.LoggingClientFrom(dic.Get)
 
+	if configuration.Coordination.Enabled {
+		elector, err := newElector(lc, pkgContainer.DBClientFrom(dic.Get), configuration.Coordination)
+		if err != nil {
+			lc.Error(fmt.Sprintf("failed to set up instance coordination: %s", err.Error()))
+			return false
+		}
+		elector.Run(ctx, wg)
+		dic.Update(di.ServiceConstructorMap{
+			dataContainer.ElectorName: func(get di.Get) interface{} {
+				return elector
+			},
+		})
+	}
+
+	if err := migrateDatabase(lc, v2DataContainer.DBClientFrom(dic.Get), configuration.DatabaseMigration, startupTimer); err != nil {
+		lc.Error(fmt.Sprintf("failed to migrate the database: %s", err.Error()))
+		return false
+	}
+	if configuration.DatabaseMigration.DryRun {
+		lc.Error("database migration dry run completed, set DatabaseMigration.DryRun to false to migrate and start the service")
+		return false
+	}
+	if configuration.StorageMigration.Enabled {
+		if err := startStorageMigration(ctx, wg, startupTimer, dic, lc, configuration, b.httpServer); err != nil {
+			lc.Error(fmt.Sprintf("failed to start the storage migration: %s", err.Error()))
+			return false
+		}
+	}
+
 	mdc := metadata.NewDeviceClient(local.New(configuration.Clients["Metadata"].Url() + clients.ApiDeviceRoute))
 	msc := metadata.NewDeviceServiceClient(local.New(configuration.Clients["Metadata"].Url() + clients.ApiDeviceRoute))
 
@@ -110,17 +203,16 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		for {
-			select {
-			case <-ctx.Done():
-				if err := msgClient.Disconnect(); err != nil {
-					lc.Error("failed to disconnect from the Message Bus")
-					return
-				}
-				lc.Info("Message Bus disconnected")
-				return
-			}
+		<-ctx.Done()
+		// wait for the http server to finish in-flight requests so their events are still published.
+		for b.httpServer.IsRunning() {
+			time.Sleep(time.Second)
 		}
+		if err := msgClient.Disconnect(); err != nil {
+			lc.Error("failed to disconnect from the Message Bus")
+			return
+		}
+		lc.Info("Message Bus disconnected")
 	}()
 
 	lc.Info(fmt.Sprintf(
@@ -135,6 +227,150 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 	// initialize event handlers
 	initEventHandlers(lc, chEvents, mdc, msc, configuration)
 
+	if err := configuration.TagIndex.Validate(); err != nil {
+		lc.Error(fmt.Sprintf("invalid tag index configuration: %s", err.Error()))
+		return false
+	}
+
+	stamper, err := provenance.NewStamper(configuration.Provenance, edgex.Version)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid event provenance configuration: %s", err.Error()))
+		return false
+	}
+	if stamper != nil {
+		// the events are queried by gateway and version with tag expressions
+		for _, name := range stamper.IndexedTags() {
+			if !configuration.TagIndex.Indexed(name) {
+				configuration.TagIndex.Tags = append(configuration.TagIndex.Tags, name)
+			}
+		}
+		lc.Info(fmt.Sprintf("Events stamped with the provenance of gateway '%s'", stamper.GatewayId()))
+		dic.Update(di.ServiceConstructorMap{
+			v2DataContainer.ProvenanceStamperName: func(get di.Get) interface{} {
+				return stamper
+			},
+		})
+	}
+
+	if err := configuration.EventPartitions.Validate(); err != nil {
+		lc.Error(fmt.Sprintf("invalid event partitions configuration: %s", err.Error()))
+		return false
+	}
+	// Validate checked the span already
+	span, _ := configuration.EventPartitions.SpanDuration()
+	v2DataContainer.DBClientFrom(dic.Get).SetEventPartitioning(configuration.EventPartitions.HotThreshold, span)
+
+	if err := configuration.Writable.EventExpiry.Validate(); err != nil {
+		lc.Error(fmt.Sprintf("invalid event expiry configuration: %s", err.Error()))
+		return false
+	}
+
+	if err := configuration.Writable.ClockSkew.Validate(); err != nil {
+		lc.Error(fmt.Sprintf("invalid clock skew configuration: %s", err.Error()))
+		return false
+	}
+
+	pipelines, err := enrichment.NewPipelines(configuration.Enrichment)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid event enrichment configuration: %s", err.Error()))
+		return false
+	}
+	if pipelines != nil {
+		lc.Info(fmt.Sprintf("Event enrichment enabled for %d device profile(s)", len(configuration.Enrichment.Profiles)))
+		dic.Update(di.ServiceConstructorMap{
+			v2DataContainer.EnrichmentPipelinesName: func(get di.Get) interface{} {
+				return pipelines
+			},
+		})
+	}
+
+	onChangeFilter, err := onchange.NewFilter(configuration.OnChange)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid on-change publishing configuration: %s", err.Error()))
+		return false
+	}
+	if onChangeFilter != nil {
+		lc.Info(fmt.Sprintf("Readings published on change by %d policy(ies)", len(configuration.OnChange.Policies)))
+		dic.Update(di.ServiceConstructorMap{
+			v2DataContainer.OnChangeFilterName: func(get di.Get) interface{} {
+				return onChangeFilter
+			},
+		})
+	}
+
+	tailBuffer, err := tail.NewBuffer(configuration.EventTail, time.Duration(configuration.Service.Timeout)*time.Millisecond)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid event tail configuration: %s", err.Error()))
+		return false
+	}
+	if tailBuffer != nil {
+		lc.Info(fmt.Sprintf("The %d newest events kept for the tail requests", configuration.EventTail.BufferSize))
+		dic.Update(di.ServiceConstructorMap{
+			v2DataContainer.TailBufferName: func(get di.Get) interface{} {
+				return tailBuffer
+			},
+		})
+	}
+
+	redactor, err := redaction.NewRedactor(configuration.Redaction)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid event redaction configuration: %s", err.Error()))
+		return false
+	}
+	if redactor != nil {
+		lc.Info(fmt.Sprintf("Published events redacted by %d rule(s)", len(configuration.Redaction.Rules)))
+		dic.Update(di.ServiceConstructorMap{
+			v2DataContainer.RedactorName: func(get di.Get) interface{} {
+				return redactor
+			},
+		})
+	}
+
+	if configuration.EventSigning.Enabled {
+		signer, err := eventsig.LoadSigner(container.SecretProviderFrom(dic.Get), configuration.EventSigning)
+		if err != nil {
+			lc.Error(fmt.Sprintf("failed to load the event signing key: %s", err.Error()))
+			return false
+		}
+		lc.Info(fmt.Sprintf("Event signing enabled with key '%s'", signer.KeyId()))
+		dic.Update(di.ServiceConstructorMap{
+			v2DataContainer.EventSignerName: func(get di.Get) interface{} {
+				return signer
+			},
+		})
+	}
+
+	// the queries to this gateway are served by its router, as if they were not federated
+	if features.FederationCompiled {
+		proxy, err := federation.NewProxy(configuration.Federation, container.SecretProviderFrom(dic.Get), b.router, lc)
+		if err != nil {
+			lc.Error(fmt.Sprintf("invalid federation configuration: %s", err.Error()))
+			return false
+		}
+		if proxy != nil {
+			lc.Info(fmt.Sprintf("Queries federated with the peer gateway(s) %v", proxy.Peers()))
+			dic.Update(di.ServiceConstructorMap{
+				v2DataContainer.FederationProxyName: func(get di.Get) interface{} {
+					return proxy
+				},
+			})
+		}
+	}
+
+	if features.KafkaCompiled && configuration.Kafka.Enabled {
+		if err := startKafkaPublisher(ctx, wg, dic, lc, configuration, b.httpServer); err != nil {
+			lc.Error(fmt.Sprintf("failed to start the Kafka publisher: %s", err.Error()))
+			return false
+		}
+	}
+
+	if features.CloudBridgeCompiled && configuration.CloudBridge.Enabled {
+		if err := startCloudBridge(ctx, wg, dic, lc, configuration, b.httpServer); err != nil {
+			lc.Error(fmt.Sprintf("failed to start the cloud bridge: %s", err.Error()))
+			return false
+		}
+	}
+
 	dic.Update(di.ServiceConstructorMap{
 		dataContainer.MetadataDeviceClientName: func(get di.Get) interface{} {
 			return mdc
@@ -150,5 +386,30 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 		},
 	})
 
+	if configuration.StorageGuard.Enabled {
+		if err := startStorageGuard(ctx, wg, dic, lc, configuration); err != nil {
+			lc.Error(fmt.Sprintf("failed to start the storage guard: %s", err.Error()))
+			return false
+		}
+	}
+
+	// the TTL can be set at runtime, and the events written with one still expire once it is unset
+	startEventExpirySweep(ctx, wg, dic, lc)
+
+	if configuration.ReadingCompression.Enabled {
+		if err := startReadingCompression(ctx, wg, dic, lc, configuration.ReadingCompression); err != nil {
+			lc.Error(fmt.Sprintf("failed to start the reading compression: %s", err.Error()))
+			return false
+		}
+	}
+
+	if configuration.UDPIngestion.Enabled {
+		listener := udp.NewListener(dic, configuration.UDPIngestion)
+		if err := listener.Start(ctx, wg, container.SecretProviderFrom(dic.Get)); err != nil {
+			lc.Error(fmt.Sprintf("failed to start the UDP event listener: %s", err.Error()))
+			return false
+		}
+	}
+
 	return true
 }
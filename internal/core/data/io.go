@@ -6,11 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"strings"
 
 	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bufferpool"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation/models"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
@@ -74,7 +74,8 @@ func NewCborReader(configuration *config.ConfigurationStruct) cborReader {
 func (cr cborReader) Read(reader io.Reader, ctx *context.Context) (models.Event, error) {
 	c := context.WithValue(*ctx, clients.ContentType, clients.ContentTypeCBOR)
 	event := models.Event{}
-	bytes, err := ioutil.ReadAll(io.LimitReader(reader, maxEventSize))
+	// the event keeps the bytes, which are thus copied out of the pooled buffer they are read into
+	bytes, err := bufferpool.ReadAll(io.LimitReader(reader, maxEventSize))
 	if err != nil {
 		return event, err
 	}
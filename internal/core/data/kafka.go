@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/kafka"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// startKafkaPublisher adds the publisher of the events to Kafka to the DIC. The pending events are flushed once
+// httpServer stops.
+func startKafkaPublisher(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	dic *di.Container,
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	httpServer httpServer) error {
+
+	info := configuration.Kafka
+	writer, err := kafka.NewWriter(info, container.SecretProviderFrom(dic.Get), lc)
+	if err != nil {
+		return err
+	}
+	publisher, err := kafka.NewPublisher(info, writer, lc)
+	if err != nil {
+		return err
+	}
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.KafkaPublisherName: func(get di.Get) interface{} {
+			return publisher
+		},
+	})
+	lc.Info(fmt.Sprintf("Publishing the events to Kafka brokers %v", info.Brokers))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		for httpServer.IsRunning() {
+			time.Sleep(time.Second)
+		}
+		if err := publisher.Close(); err != nil {
+			lc.Error(fmt.Sprintf("failed to flush the events published to Kafka: %s", err.Error()))
+			return
+		}
+		lc.Info("Kafka publisher closed")
+	}()
+	return nil
+}
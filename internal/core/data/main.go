@@ -16,14 +16,23 @@ package data
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/edgexfoundry/edgex-go"
 	"github.com/edgexfoundry/edgex-go/internal"
 	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
 	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/ratelimit"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/storageguard"
 	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configflags"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configprovider"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/database"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/httpserver"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/registry"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/chaos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/telemetry"
 	v2Handlers "github.com/edgexfoundry/edgex-go/internal/pkg/v2/bootstrap/handlers"
 
@@ -48,32 +57,57 @@ func Main(ctx context.Context, cancel context.CancelFunc, router *mux.Router, re
 	//      ....
 	//      flags.Parse(os.Args[1:])
 	//
-	f := flags.New()
+	var inMemory bool
+	var chaosPath string
+	var configFlags configflags.Flags
+	f := flags.NewWithUsage(database.InMemoryUsage + chaos.Usage + configflags.Usage)
+	f.FlagSet.BoolVar(&inMemory, database.InMemoryFlag, false, "")
+	f.FlagSet.StringVar(&chaosPath, chaos.Flag, "", "")
+	configFlags.Register(f.FlagSet)
 	f.Parse(os.Args[1:])
+	if err := configFlags.Apply(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	providerFlags := configprovider.NewFlags(f)
 
 	configuration := &config.ConfigurationStruct{}
+	limiter := ratelimit.NewLimiter()
+	guard := storageguard.NewGuard()
 	dic := di.NewContainer(di.ServiceConstructorMap{
 		dataContainer.ConfigurationName: func(get di.Get) interface{} {
 			return configuration
 		},
+		dataContainer.IngestionLimiterName: func(get di.Get) interface{} {
+			return limiter
+		},
+		dataContainer.StorageGuardName: func(get di.Get) interface{} {
+			return guard
+		},
 	})
 
-	httpServer := handlers.NewHttpServer(router, true)
+	httpServer := httpserver.NewHttpServer(router, configuration, true)
 
 	bootstrap.Run(
 		ctx,
 		cancel,
-		f,
+		providerFlags,
 		clients.CoreDataServiceKey,
 		internal.ConfigStemCore+internal.ConfigMajorVersion,
 		configuration,
 		startupTimer,
 		dic,
 		[]interfaces.BootstrapHandler{
+			configprovider.NewHandler(providerFlags, clients.CoreDataServiceKey, internal.ConfigStemCore+internal.ConfigMajorVersion, configuration).BootstrapHandler,
+			configflags.NewHandler(&configFlags, configuration).BootstrapHandler,
 			handlers.SecureProviderBootstrapHandler,
+			secretcache.NewHandler(configuration).BootstrapHandler,
+			database.NewInMemory(configuration, inMemory).BootstrapHandler,
 			database.NewDatabaseForCoreData(httpServer, configuration).BootstrapHandler,
 			v2Handlers.NewDatabase(httpServer, configuration, v2DataContainer.DBClientInterfaceName).BootstrapHandler, // add v2 db client bootstrap handler
-			NewBootstrap(router).BootstrapHandler,
+			NewBootstrap(router, httpServer).BootstrapHandler,
+			chaos.NewHandler(router, chaosPath).BootstrapHandler,
+			registry.NewHandler(configuration, clients.CoreDataServiceKey).BootstrapHandler,
 			telemetry.BootstrapHandler,
 			httpServer.BootstrapHandler,
 			handlers.NewStartMessage(clients.CoreDataServiceKey, edgex.Version).BootstrapHandler,
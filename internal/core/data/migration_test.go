@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package data
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+	redisClient "github.com/edgexfoundry/edgex-go/internal/pkg/db/redis"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubMigrator returns the errors in order, then nil
+type stubMigrator struct {
+	errors []error
+	dryRun bool
+}
+
+func (m *stubMigrator) MigrateCoreData(dryRun bool) error {
+	m.dryRun = dryRun
+	if len(m.errors) == 0 {
+		return nil
+	}
+	err := m.errors[0]
+	m.errors = m.errors[1:]
+	return err
+}
+
+func TestMigrateDatabase(t *testing.T) {
+	tests := []struct {
+		name        string
+		dbClient    interface{}
+		expectError bool
+	}{
+		{"migrated", &stubMigrator{}, false},
+		{"migrated after another instance", &stubMigrator{errors: []error{redisClient.ErrMigrationLocked}}, false},
+		{"migration failed", &stubMigrator{errors: []error{errors.New("failed")}}, true},
+		{"no migration support", struct{}{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := migrateDatabase(logger.MockLogger{}, tt.dbClient, config.DatabaseMigrationInfo{DryRun: true}, startup.NewTimer(5, 1))
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if migrator, ok := tt.dbClient.(*stubMigrator); ok {
+				assert.True(t, migrator.dryRun)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package ratelimit limits the rate at which core-data accepts events, per device and in total, so that a
+// misbehaving device service cannot flood the gateway.
+package ratelimit
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	ScopeGlobal = "global"
+	ScopeDevice = "device"
+
+	// OtherDevices is the key of Metrics.RejectedByDevice counting the rejected events of the devices beyond the
+	// first MaxRejectedDevices ones. The parentheses are not allowed in device names.
+	OtherDevices = "(other)"
+	// MaxRejectedDevices bounds the number of devices counted separately in Metrics.RejectedByDevice, so that
+	// a device service sending events under ever-changing device names cannot grow it without bound.
+	MaxRejectedDevices = 100
+
+	// pruneInterval is how often buckets that have refilled completely are dropped. A full bucket behaves
+	// exactly like a new one, so dropping it only bounds the memory used by devices that stopped sending.
+	pruneInterval = time.Minute
+)
+
+// RateLimitInfo configures a token bucket. A Rate of zero or less disables the limit.
+type RateLimitInfo struct {
+	// Rate is the sustained number of events per second.
+	Rate float64
+	// Burst is the number of events that may be accepted at once. Defaults to Rate rounded up when zero.
+	Burst int
+}
+
+// IngestionLimitsInfo holds the ingestion rate limits.
+type IngestionLimitsInfo struct {
+	// Global limits the events accepted from all devices combined.
+	Global RateLimitInfo
+	// Device limits the events accepted from each device.
+	Device RateLimitInfo
+	// DeviceOverrides replaces the Device limit for the named devices.
+	DeviceOverrides map[string]RateLimitInfo
+}
+
+// Configuration is implemented by the service configuration that defines the ingestion limits.
+type Configuration interface {
+	// GetIngestionLimits returns the service's current ingestion limits.
+	GetIngestionLimits() IngestionLimitsInfo
+}
+
+// Decision is the outcome of Limiter.Allow.
+type Decision struct {
+	// Allowed is true when the event may be ingested.
+	Allowed bool
+	// Scope is the limit that rejected the event, ScopeGlobal or ScopeDevice.
+	Scope string
+	// RetryAfter is how long until the rejecting bucket holds a token again.
+	RetryAfter time.Duration
+	// Throttling is true for the first rejection of a device after it was last allowed, so that callers
+	// can log the transition once instead of every rejected event.
+	Throttling bool
+}
+
+// Metrics is a snapshot of the Limiter counters.
+type Metrics struct {
+	Accepted       uint64 `json:"accepted"`
+	RejectedGlobal uint64 `json:"rejectedGlobal"`
+	RejectedDevice uint64 `json:"rejectedDevice"`
+	// RejectedByDevice counts the rejected events of up to MaxRejectedDevices devices, and of the others
+	// under OtherDevices.
+	RejectedByDevice map[string]uint64 `json:"rejectedByDevice"`
+}
+
+// tokenBucket holds up to burst tokens and gains rate tokens per second.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(info RateLimitInfo, now time.Time) *tokenBucket {
+	if info.Rate <= 0 {
+		return nil
+	}
+	burst := float64(info.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(info.Rate))
+	}
+	return &tokenBucket{rate: info.Rate, burst: burst, tokens: burst, last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+	}
+	b.last = now
+}
+
+// wait returns how long until the bucket holds a whole token.
+func (b *tokenBucket) wait() time.Duration {
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// Limiter applies IngestionLimitsInfo with one token bucket for all devices and one per device.
+type Limiter struct {
+	mutex      sync.Mutex
+	now        func() time.Time
+	limits     IngestionLimitsInfo
+	configured bool
+	global     *tokenBucket
+	devices    map[string]*tokenBucket
+	throttled  map[string]bool
+	lastPrune  time.Time
+	metrics    Metrics
+}
+
+// NewLimiter creates a Limiter. The limits are passed to each Allow call so that changes to the
+// service's Writable configuration are applied without a restart.
+func NewLimiter() *Limiter {
+	return &Limiter{
+		now:       time.Now,
+		devices:   make(map[string]*tokenBucket),
+		throttled: make(map[string]bool),
+		metrics:   Metrics{RejectedByDevice: make(map[string]uint64)},
+	}
+}
+
+// Allow reports whether an event from deviceName may be ingested under limits, and consumes a token from
+// both the device and the global bucket when it may.
+func (l *Limiter) Allow(limits IngestionLimitsInfo, deviceName string) Decision {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.configure(limits, now)
+	l.prune(now)
+
+	device := l.deviceBucket(deviceName, now)
+	if device != nil {
+		device.refill(now)
+		if device.tokens < 1 {
+			return l.reject(ScopeDevice, deviceName, device.wait())
+		}
+	}
+	if l.global != nil {
+		l.global.refill(now)
+		if l.global.tokens < 1 {
+			return l.reject(ScopeGlobal, deviceName, l.global.wait())
+		}
+		l.global.tokens--
+	}
+	if device != nil {
+		device.tokens--
+	}
+
+	delete(l.throttled, deviceName)
+	l.metrics.Accepted++
+	return Decision{Allowed: true}
+}
+
+// Metrics returns a snapshot of the counters.
+func (l *Limiter) Metrics() Metrics {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	snapshot := l.metrics
+	snapshot.RejectedByDevice = make(map[string]uint64, len(l.metrics.RejectedByDevice))
+	for name, count := range l.metrics.RejectedByDevice {
+		snapshot.RejectedByDevice[name] = count
+	}
+	return snapshot
+}
+
+// configure resets the buckets when the limits differ from the ones last applied.
+func (l *Limiter) configure(limits IngestionLimitsInfo, now time.Time) {
+	if l.configured && reflect.DeepEqual(limits, l.limits) {
+		return
+	}
+	l.limits = limits
+	l.configured = true
+	l.global = newTokenBucket(limits.Global, now)
+	l.devices = make(map[string]*tokenBucket)
+}
+
+func (l *Limiter) deviceBucket(deviceName string, now time.Time) *tokenBucket {
+	if bucket, ok := l.devices[deviceName]; ok {
+		return bucket
+	}
+	info := l.limits.Device
+	if override, ok := l.limits.DeviceOverrides[deviceName]; ok {
+		info = override
+	}
+	bucket := newTokenBucket(info, now)
+	if bucket != nil {
+		l.devices[deviceName] = bucket
+	}
+	return bucket
+}
+
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < pruneInterval {
+		return
+	}
+	l.lastPrune = now
+	for name, bucket := range l.devices {
+		bucket.refill(now)
+		if bucket.tokens >= bucket.burst {
+			delete(l.devices, name)
+			delete(l.throttled, name)
+		}
+	}
+}
+
+func (l *Limiter) reject(scope string, deviceName string, retryAfter time.Duration) Decision {
+	if scope == ScopeGlobal {
+		l.metrics.RejectedGlobal++
+	} else {
+		l.metrics.RejectedDevice++
+	}
+	counted := deviceName
+	if _, ok := l.metrics.RejectedByDevice[deviceName]; !ok && len(l.metrics.RejectedByDevice) >= MaxRejectedDevices {
+		counted = OtherDevices
+	}
+	l.metrics.RejectedByDevice[counted]++
+
+	throttling := !l.throttled[deviceName]
+	l.throttled[deviceName] = true
+	return Decision{Scope: scope, RetryAfter: retryAfter, Throttling: throttling}
+}
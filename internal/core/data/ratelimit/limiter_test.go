@@ -0,0 +1,160 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(now *time.Time) *Limiter {
+	limiter := NewLimiter()
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func TestLimiterDeviceLimit(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := newTestLimiter(&now)
+	limits := IngestionLimitsInfo{Device: RateLimitInfo{Rate: 1, Burst: 2}}
+
+	assert.True(t, limiter.Allow(limits, "device1").Allowed)
+	assert.True(t, limiter.Allow(limits, "device1").Allowed)
+	decision := limiter.Allow(limits, "device1")
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, ScopeDevice, decision.Scope)
+	assert.Equal(t, time.Second, decision.RetryAfter)
+	assert.True(t, decision.Throttling)
+	assert.False(t, limiter.Allow(limits, "device1").Throttling, "only the first rejection starts throttling")
+
+	// Other devices have their own bucket
+	assert.True(t, limiter.Allow(limits, "device2").Allowed)
+
+	now = now.Add(time.Second)
+	assert.True(t, limiter.Allow(limits, "device1").Allowed)
+
+	metrics := limiter.Metrics()
+	assert.Equal(t, uint64(4), metrics.Accepted)
+	assert.Equal(t, uint64(2), metrics.RejectedDevice)
+	assert.Equal(t, uint64(0), metrics.RejectedGlobal)
+	assert.Equal(t, map[string]uint64{"device1": 2}, metrics.RejectedByDevice)
+}
+
+func TestLimiterRejectedByDeviceBound(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := newTestLimiter(&now)
+	limits := IngestionLimitsInfo{Device: RateLimitInfo{Rate: 1}}
+
+	for i := 0; i < MaxRejectedDevices+10; i++ {
+		name := fmt.Sprintf("device%d", i)
+		require.True(t, limiter.Allow(limits, name).Allowed)
+		require.False(t, limiter.Allow(limits, name).Allowed)
+	}
+	// Devices already counted keep their own counter
+	require.False(t, limiter.Allow(limits, "device0").Allowed)
+
+	metrics := limiter.Metrics()
+	assert.Len(t, metrics.RejectedByDevice, MaxRejectedDevices+1)
+	assert.Equal(t, uint64(2), metrics.RejectedByDevice["device0"])
+	assert.Equal(t, uint64(10), metrics.RejectedByDevice[OtherDevices])
+	assert.Equal(t, uint64(MaxRejectedDevices+11), metrics.RejectedDevice)
+}
+
+func TestLimiterGlobalLimit(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := newTestLimiter(&now)
+	limits := IngestionLimitsInfo{Global: RateLimitInfo{Rate: 2}}
+
+	assert.True(t, limiter.Allow(limits, "device1").Allowed)
+	assert.True(t, limiter.Allow(limits, "device2").Allowed)
+	decision := limiter.Allow(limits, "device3")
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, ScopeGlobal, decision.Scope)
+	assert.Equal(t, uint64(1), limiter.Metrics().RejectedGlobal)
+}
+
+func TestLimiterDeviceOverride(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := newTestLimiter(&now)
+	limits := IngestionLimitsInfo{
+		Device:          RateLimitInfo{Rate: 1},
+		DeviceOverrides: map[string]RateLimitInfo{"busy": {Rate: 3}},
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, limiter.Allow(limits, "busy").Allowed)
+	}
+	assert.False(t, limiter.Allow(limits, "busy").Allowed)
+	assert.True(t, limiter.Allow(limits, "quiet").Allowed)
+	assert.False(t, limiter.Allow(limits, "quiet").Allowed)
+}
+
+func TestLimiterDisabledAndReconfigured(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := newTestLimiter(&now)
+
+	for i := 0; i < 100; i++ {
+		require.True(t, limiter.Allow(IngestionLimitsInfo{}, "device1").Allowed)
+	}
+
+	limits := IngestionLimitsInfo{Device: RateLimitInfo{Rate: 1}}
+	assert.True(t, limiter.Allow(limits, "device1").Allowed)
+	assert.False(t, limiter.Allow(limits, "device1").Allowed)
+
+	// Changed limits take effect with fresh buckets
+	limits = IngestionLimitsInfo{Device: RateLimitInfo{Rate: 5}}
+	assert.True(t, limiter.Allow(limits, "device1").Allowed)
+}
+
+func TestMiddleware(t *testing.T) {
+	limiter := NewLimiter()
+	configuration := testConfiguration{IngestionLimitsInfo{Device: RateLimitInfo{Rate: 0.001}}}
+	router := mux.NewRouter()
+	router.HandleFunc(v2.ApiEventProfileNameDeviceNameRoute, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}).Methods(http.MethodPost)
+	router.Use(NewMiddleware(logger.MockLogger{}, limiter, configuration))
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v2/event/profile1/device1", http.NoBody)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusCreated, send().Code)
+	rejected := send()
+	assert.Equal(t, http.StatusTooManyRequests, rejected.Code)
+	assert.NotEmpty(t, rejected.Header().Get(retryAfterHeader))
+	assert.Equal(t, map[string]uint64{"device1": 1}, limiter.Metrics().RejectedByDevice)
+}
+
+type testConfiguration struct {
+	limits IngestionLimitsInfo
+}
+
+func (c testConfiguration) GetIngestionLimits() IngestionLimitsInfo {
+	return c.limits
+}
@@ -0,0 +1,91 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+const retryAfterHeader = "Retry-After"
+
+// NewMiddleware returns a middleware that responds 429 (Too Many Requests) to V2 add event requests once the
+// device named in the route, or all devices together, exceed the limits returned by configuration.
+func NewMiddleware(lc logger.LoggingClient, limiter *Limiter, configuration Configuration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || !isAddEventRoute(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			deviceName := mux.Vars(r)[v2.DeviceName]
+			decision := limiter.Allow(configuration.GetIngestionLimits(), deviceName)
+			if decision.Allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			message := Log(lc, decision, deviceName, correlation.FromContext(ctx))
+			SetRetryAfter(w, decision)
+			utils.WriteHttpHeader(w, ctx, http.StatusTooManyRequests)
+			pkg.Encode(commonDTO.NewBaseResponse("", message, http.StatusTooManyRequests), w, lc)
+		})
+	}
+}
+
+// Log logs a rejected event and returns the message describing the rejection. The first rejection of a
+// throttled device is logged as a warning, the following ones only at debug level.
+func Log(lc logger.LoggingClient, decision Decision, deviceName string, correlationId string) string {
+	message := fmt.Sprintf("event from device %s rejected by the %s ingestion rate limit", deviceName, decision.Scope)
+	if decision.Throttling {
+		lc.Warn(message, clients.CorrelationHeader, correlationId)
+	} else {
+		lc.Debug(message, clients.CorrelationHeader, correlationId)
+	}
+	return message
+}
+
+// SetRetryAfter sets the Retry-After header, in whole seconds, from the decision.
+func SetRetryAfter(w http.ResponseWriter, decision Decision) {
+	seconds := int64(math.Ceil(decision.RetryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set(retryAfterHeader, strconv.FormatInt(seconds, 10))
+}
+
+func isAddEventRoute(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+	template, err := route.GetPathTemplate()
+	return err == nil && template == v2.ApiEventProfileNameDeviceNameRoute
+}
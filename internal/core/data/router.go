@@ -26,6 +26,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/data/interfaces"
 	readingOperator "github.com/edgexfoundry/edgex-go/internal/core/data/operators/reading"
 	"github.com/edgexfoundry/edgex-go/internal/core/data/operators/value_descriptor"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/ratelimit"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
 	errorContainer "github.com/edgexfoundry/edgex-go/internal/pkg/container"
@@ -88,7 +89,8 @@ func loadRestRoutes(r *mux.Router, dic *di.Container) {
 				dataContainer.MessagingClientFrom(dic.Get),
 				dataContainer.MetadataDeviceClientFrom(dic.Get),
 				errorContainer.ErrorHandlerFrom(dic.Get),
-				dataContainer.ConfigurationFrom(dic.Get))
+				dataContainer.ConfigurationFrom(dic.Get),
+				dataContainer.IngestionLimiterFrom(dic.Get))
 		}).Methods(http.MethodGet, http.MethodPut, http.MethodPost)
 	r.HandleFunc(clients.ApiEventRoute, func(writer http.ResponseWriter, request *http.Request) {
 		eventHandler(
@@ -100,7 +102,8 @@ func loadRestRoutes(r *mux.Router, dic *di.Container) {
 			dataContainer.MessagingClientFrom(dic.Get),
 			dataContainer.MetadataDeviceClientFrom(dic.Get),
 			errorContainer.ErrorHandlerFrom(dic.Get),
-			dataContainer.ConfigurationFrom(dic.Get))
+			dataContainer.ConfigurationFrom(dic.Get),
+			dataContainer.IngestionLimiterFrom(dic.Get))
 	}).Methods(http.MethodGet, http.MethodPut, http.MethodPost)
 
 	e := r.PathPrefix(clients.ApiEventRoute).Subrouter()
@@ -593,7 +596,8 @@ func eventHandler(
 	msgClient messaging.MessageClient,
 	mdc metadata.DeviceClient,
 	httpErrorHandler errorconcept.ErrorHandler,
-	configuration *config.ConfigurationStruct) {
+	configuration *config.ConfigurationStruct,
+	limiter *ratelimit.Limiter) {
 
 	if r.Body != nil {
 		defer func() { _ = r.Body.Close() }()
@@ -622,6 +626,13 @@ func eventHandler(
 			httpErrorHandler.Handle(w, err, errorconcept.Default.InternalServerError)
 			return
 		}
+		decision := limiter.Allow(configuration.GetIngestionLimits(), evt.Device)
+		if !decision.Allowed {
+			message := ratelimit.Log(lc, decision, evt.Device, correlation.FromContext(ctx))
+			ratelimit.SetRetryAfter(w, decision)
+			http.Error(w, message, http.StatusTooManyRequests)
+			return
+		}
 		newId, err := addNewEvent(evt, ctx, lc, dbClient, chEvents, msgClient, mdc, configuration)
 		if err != nil {
 			httpErrorHandler.HandleManyVariants(
@@ -0,0 +1,138 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/storageguard"
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+)
+
+// startStorageGuard checks the usage of the storage every StorageGuard.CheckInterval until ctx is done, updating the
+// guard of the DIC, deleting the old events while the storage runs low, and publishing an alert on every change of
+// state of the guard. Every instance updates its own guard, but only the leader deletes the events and publishes the
+// alerts when instance coordination is enabled.
+func startStorageGuard(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	dic *di.Container,
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct) error {
+
+	info := configuration.StorageGuard
+	if err := info.Validate(); err != nil {
+		return err
+	}
+	// validated above
+	checkInterval, _ := time.ParseDuration(info.CheckInterval)
+	if _, err := storageguard.ReadUsage(info.Path); err != nil {
+		return err
+	}
+
+	guard := dataContainer.StorageGuardFrom(dic.Get)
+	check := func() {
+		usage, err := storageguard.ReadUsage(info.Path)
+		if err != nil {
+			lc.Error(fmt.Sprintf("failed to check the storage usage: %s", err.Error()))
+			return
+		}
+		previous, current := guard.Update(info, usage)
+		if previous != current {
+			message := storageguard.Describe(previous, current, usage)
+			if current == storageguard.StateNormal {
+				lc.Info(message)
+			} else {
+				lc.Warn(message)
+			}
+			if elector := dataContainer.ElectorFrom(dic.Get); elector == nil || elector.IsLeader() {
+				publishStorageAlert(ctx, dic, lc, info, storageguard.NewAlert(
+					clients.CoreDataServiceKey, info.Path, previous, current, usage, time.Now()))
+			}
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		check()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				check()
+			}
+		}
+	}()
+
+	runPeriodically(ctx, wg, dic, "storage retention", checkInterval, func() {
+		if guard.State() != storageguard.StateNormal {
+			applyStorageRetention(dic, lc, info)
+		}
+	})
+
+	lc.Info(fmt.Sprintf("Storage guard enabled for %s, checked every %s", info.Path, info.CheckInterval))
+	return nil
+}
+
+// applyStorageRetention deletes the events older than StorageGuard.RetentionMaxAge
+func applyStorageRetention(dic *di.Container, lc logger.LoggingClient, info storageguard.StorageGuardInfo) {
+	// validated on startup
+	maxAge, _ := time.ParseDuration(info.RetentionMaxAge)
+	err := v2DataContainer.DBClientFrom(dic.Get).DeleteEventsByAge(maxAge.Milliseconds())
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to delete the events older than %s: %s", info.RetentionMaxAge, err.Error()))
+		return
+	}
+	lc.Debug(fmt.Sprintf("Deleted the events older than %s to free storage", info.RetentionMaxAge))
+}
+
+// publishStorageAlert publishes the alert on StorageGuard.AlertTopic, when configured
+func publishStorageAlert(
+	ctx context.Context,
+	dic *di.Container,
+	lc logger.LoggingClient,
+	info storageguard.StorageGuardInfo,
+	alert storageguard.Alert) {
+
+	if info.AlertTopic == "" {
+		return
+	}
+	payload, err := alert.Payload()
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to encode the storage alert: %s", err.Error()))
+		return
+	}
+	ctx = context.WithValue(correlation.NewContext(ctx), clients.ContentType, clients.ContentTypeJSON)
+	msgClient := dataContainer.MessagingClientFrom(dic.Get)
+	if err := msgClient.Publish(msgTypes.NewMessageEnvelope(payload, ctx), info.AlertTopic); err != nil {
+		lc.Error(fmt.Sprintf("failed to publish the storage alert on %s: %s", info.AlertTopic, err.Error()),
+			clients.CorrelationHeader, correlation.FromContext(ctx))
+	}
+}
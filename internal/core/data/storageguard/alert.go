@@ -0,0 +1,76 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package storageguard
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AlertType is the type of the system events of the storage alerts
+const AlertType = "storage"
+
+// Alert is published when the guard changes state. It has the form of the system events, so that support-notifications
+// can turn it into a notification with a rule of type AlertType, the action being the new state.
+type Alert struct {
+	Type      string                 `json:"type"`
+	Action    string                 `json:"action"`
+	Source    string                 `json:"source"`
+	Name      string                 `json:"name"`
+	Details   map[string]interface{} `json:"details"`
+	Timestamp int64                  `json:"timestamp"`
+}
+
+// NewAlert returns the alert of the change of state of the guard of the volume holding path
+func NewAlert(source string, path string, previous string, current string, usage Usage, now time.Time) Alert {
+	return Alert{
+		Type:   AlertType,
+		Action: current,
+		Source: source,
+		Name:   path,
+		Details: map[string]interface{}{
+			"previous":          previous,
+			"diskFreePercent":   round(usage.DiskFreePercent()),
+			"memoryUsedPercent": round(usage.MemoryUsedPercent()),
+			"reason":            Describe(previous, current, usage),
+		},
+		Timestamp: now.UnixNano() / int64(time.Millisecond),
+	}
+}
+
+// Payload returns the JSON encoding of the alert
+func (a Alert) Payload() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// Describe returns the message logged when the guard changes state
+func Describe(previous string, current string, usage Usage) string {
+	var effect string
+	switch current {
+	case StateReject:
+		effect = "new events are rejected"
+	case StateRetention:
+		effect = "old events are deleted"
+	default:
+		effect = "events are accepted"
+	}
+	return fmt.Sprintf("storage %s, was %s: %s, with %.1f%% free disk space and %.1f%% used memory",
+		current, previous, effect, usage.DiskFreePercent(), usage.MemoryUsedPercent())
+}
+
+func round(percent float64) float64 {
+	return float64(int64(percent*10+0.5)) / 10
+}
@@ -0,0 +1,185 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package storageguard watches the free space of the volume storing the database and the free memory of the gateway,
+// so that core-data deletes old events aggressively, and then rejects new events, before the database runs out of
+// space or memory and takes the gateway down with it.
+package storageguard
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// States of the guard, from the least to the most restrictive
+const (
+	// StateNormal accepts the events and applies no retention of its own
+	StateNormal = "normal"
+	// StateRetention deletes the events older than StorageGuardInfo.RetentionMaxAge on every check
+	StateRetention = "retention"
+	// StateReject rejects the new events, on top of the retention
+	StateReject = "reject"
+
+	// recoveryMargin is how many percentage points the usage must clear a threshold by to leave its state, so that
+	// a usage oscillating around the threshold doesn't flap between states
+	recoveryMargin = 2.0
+)
+
+// StorageGuardInfo configures the guard. A threshold of zero disables it.
+type StorageGuardInfo struct {
+	// Enabled checks the usage every CheckInterval
+	Enabled bool
+	// Path is a path on the volume storing the database, e.g. the Redis directory of the RDB and AOF files or the
+	// Postgres data directory, mounted in the core-data container when running in Docker
+	Path string
+	// CheckInterval is how often the free space and memory are checked, e.g. "30s"
+	CheckInterval string
+	// RetentionMinFreeDiskPercent is the free space of the volume, in percent, below which retention starts
+	RetentionMinFreeDiskPercent float64
+	// RetentionMaxMemoryPercent is the used memory, in percent, above which retention starts
+	RetentionMaxMemoryPercent float64
+	// RetentionMaxAge is the age, e.g. "1h", of the events deleted on every check during retention
+	RetentionMaxAge string
+	// RejectMinFreeDiskPercent is the free space of the volume, in percent, below which the new events are rejected
+	RejectMinFreeDiskPercent float64
+	// RejectMaxMemoryPercent is the used memory, in percent, above which the new events are rejected
+	RejectMaxMemoryPercent float64
+	// AlertTopic is the message bus topic the changes of state are published to, as system events. No alerts are
+	// published when empty.
+	AlertTopic string
+}
+
+// Validate checks the durations and thresholds
+func (info StorageGuardInfo) Validate() error {
+	if info.Path == "" {
+		return fmt.Errorf("no StorageGuard.Path to watch")
+	}
+	if interval, err := time.ParseDuration(info.CheckInterval); err != nil || interval <= 0 {
+		return fmt.Errorf("invalid StorageGuard.CheckInterval '%s'", info.CheckInterval)
+	}
+	if age, err := time.ParseDuration(info.RetentionMaxAge); err != nil || age <= 0 {
+		return fmt.Errorf("invalid StorageGuard.RetentionMaxAge '%s'", info.RetentionMaxAge)
+	}
+	for name, percent := range map[string]float64{
+		"RetentionMinFreeDiskPercent": info.RetentionMinFreeDiskPercent,
+		"RetentionMaxMemoryPercent":   info.RetentionMaxMemoryPercent,
+		"RejectMinFreeDiskPercent":    info.RejectMinFreeDiskPercent,
+		"RejectMaxMemoryPercent":      info.RejectMaxMemoryPercent,
+	} {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("StorageGuard.%s must be between 0 and 100", name)
+		}
+	}
+	if info.RetentionMinFreeDiskPercent > 0 && info.RejectMinFreeDiskPercent > info.RetentionMinFreeDiskPercent {
+		return fmt.Errorf("StorageGuard.RejectMinFreeDiskPercent must not be above RetentionMinFreeDiskPercent")
+	}
+	if info.RejectMaxMemoryPercent > 0 && info.RetentionMaxMemoryPercent > 0 &&
+		info.RejectMaxMemoryPercent < info.RetentionMaxMemoryPercent {
+		return fmt.Errorf("StorageGuard.RejectMaxMemoryPercent must not be below RetentionMaxMemoryPercent")
+	}
+	return nil
+}
+
+// Usage is a measure of the free space of the volume and of the memory
+type Usage struct {
+	DiskFreeBytes    uint64 `json:"diskFreeBytes"`
+	DiskTotalBytes   uint64 `json:"diskTotalBytes"`
+	MemoryUsedBytes  uint64 `json:"memoryUsedBytes"`
+	MemoryTotalBytes uint64 `json:"memoryTotalBytes"`
+}
+
+// DiskFreePercent returns the free space of the volume in percent, 100 when unknown
+func (u Usage) DiskFreePercent() float64 {
+	if u.DiskTotalBytes == 0 {
+		return 100
+	}
+	return float64(u.DiskFreeBytes) / float64(u.DiskTotalBytes) * 100
+}
+
+// MemoryUsedPercent returns the used memory in percent, 0 when unknown
+func (u Usage) MemoryUsedPercent() float64 {
+	if u.MemoryTotalBytes == 0 {
+		return 0
+	}
+	return float64(u.MemoryUsedBytes) / float64(u.MemoryTotalBytes) * 100
+}
+
+// Guard holds the state of the storage, derived from the last usage measured
+type Guard struct {
+	mutex sync.RWMutex
+	state string
+	usage Usage
+}
+
+// NewGuard creates a Guard in StateNormal, which it stays in until Update is called
+func NewGuard() *Guard {
+	return &Guard{state: StateNormal}
+}
+
+// State returns the current state of the guard
+func (g *Guard) State() string {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.state
+}
+
+// Rejecting returns whether the new events must be rejected
+func (g *Guard) Rejecting() bool {
+	return g.State() == StateReject
+}
+
+// Usage returns the last usage measured
+func (g *Guard) Usage() Usage {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.usage
+}
+
+// Update derives the state of the guard from usage and the thresholds of info, and returns the previous state
+func (g *Guard) Update(info StorageGuardInfo, usage Usage) (previous string, current string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	previous = g.state
+	g.state = nextState(info, usage, previous)
+	g.usage = usage
+	return previous, g.state
+}
+
+func nextState(info StorageGuardInfo, usage Usage, current string) string {
+	if exceeds(info.RejectMinFreeDiskPercent, info.RejectMaxMemoryPercent, usage, current == StateReject) {
+		return StateReject
+	}
+	if current == StateReject {
+		// leaving the rejection goes through the retention, until the usage clears the retention thresholds as well
+		current = StateRetention
+	}
+	if exceeds(info.RetentionMinFreeDiskPercent, info.RetentionMaxMemoryPercent, usage, current == StateRetention) {
+		return StateRetention
+	}
+	return StateNormal
+}
+
+// exceeds returns whether usage crosses the thresholds, which must be cleared by recoveryMargin when already crossed
+func exceeds(minFreeDiskPercent float64, maxMemoryPercent float64, usage Usage, crossed bool) bool {
+	margin := 0.0
+	if crossed {
+		margin = recoveryMargin
+	}
+	if minFreeDiskPercent > 0 && usage.DiskFreePercent() < minFreeDiskPercent+margin {
+		return true
+	}
+	return maxMemoryPercent > 0 && usage.MemoryUsedPercent() > maxMemoryPercent-margin
+}
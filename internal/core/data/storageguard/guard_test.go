@@ -0,0 +1,148 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package storageguard
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gib = 1 << 30
+
+var testInfo = StorageGuardInfo{
+	Enabled:                     true,
+	Path:                        "/data",
+	CheckInterval:               "30s",
+	RetentionMinFreeDiskPercent: 20,
+	RetentionMaxMemoryPercent:   85,
+	RetentionMaxAge:             "1h",
+	RejectMinFreeDiskPercent:    5,
+	RejectMaxMemoryPercent:      95,
+}
+
+// newUsage returns the usage of a 100 GiB volume and 100 GiB of memory
+func newUsage(diskFreeGiB uint64, memoryUsedGiB uint64) Usage {
+	return Usage{
+		DiskFreeBytes:    diskFreeGiB * gib,
+		DiskTotalBytes:   100 * gib,
+		MemoryUsedBytes:  memoryUsedGiB * gib,
+		MemoryTotalBytes: 100 * gib,
+	}
+}
+
+func TestValidate(t *testing.T) {
+	noPath := testInfo
+	noPath.Path = ""
+	invalidInterval := testInfo
+	invalidInterval.CheckInterval = "30"
+	invalidAge := testInfo
+	invalidAge.RetentionMaxAge = "-1h"
+	invalidPercent := testInfo
+	invalidPercent.RejectMaxMemoryPercent = 120
+	rejectAboveRetention := testInfo
+	rejectAboveRetention.RejectMinFreeDiskPercent = 30
+	rejectBelowRetention := testInfo
+	rejectBelowRetention.RejectMaxMemoryPercent = 80
+	rejectOnly := testInfo
+	rejectOnly.RetentionMinFreeDiskPercent = 0
+	rejectOnly.RetentionMaxMemoryPercent = 0
+
+	tests := []struct {
+		name          string
+		info          StorageGuardInfo
+		expectedError bool
+	}{
+		{"valid", testInfo, false},
+		{"valid - reject only", rejectOnly, false},
+		{"no path", noPath, true},
+		{"invalid check interval", invalidInterval, true},
+		{"invalid retention age", invalidAge, true},
+		{"invalid percent", invalidPercent, true},
+		{"reject disk threshold above retention", rejectAboveRetention, true},
+		{"reject memory threshold below retention", rejectBelowRetention, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.info.Validate()
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		usage    Usage
+		expected string
+	}{
+		{"normal", StateNormal, newUsage(50, 50), StateNormal},
+		{"unknown usage", StateNormal, Usage{}, StateNormal},
+		{"low disk space", StateNormal, newUsage(15, 50), StateRetention},
+		{"high memory", StateNormal, newUsage(50, 90), StateRetention},
+		{"very low disk space", StateNormal, newUsage(4, 50), StateReject},
+		{"very high memory", StateRetention, newUsage(50, 96), StateReject},
+		{"retention within the recovery margin", StateRetention, newUsage(21, 50), StateRetention},
+		{"retention recovered", StateRetention, newUsage(23, 50), StateNormal},
+		{"reject within the recovery margin", StateReject, newUsage(6, 50), StateReject},
+		{"reject recovered to retention", StateReject, newUsage(8, 50), StateRetention},
+		{"reject recovered", StateReject, newUsage(30, 50), StateNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := &Guard{state: tt.current}
+			previous, current := guard.Update(testInfo, tt.usage)
+			assert.Equal(t, tt.current, previous)
+			assert.Equal(t, tt.expected, current)
+			assert.Equal(t, tt.expected, guard.State())
+			assert.Equal(t, tt.expected == StateReject, guard.Rejecting())
+			assert.Equal(t, tt.usage, guard.Usage())
+		})
+	}
+}
+
+func TestNewGuard(t *testing.T) {
+	guard := NewGuard()
+	assert.Equal(t, StateNormal, guard.State())
+	assert.False(t, guard.Rejecting())
+}
+
+func TestNewAlert(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	alert := NewAlert("core-data", "/data", StateRetention, StateReject, newUsage(4, 50), now)
+
+	payload, err := alert.Payload()
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(payload, &decoded))
+	assert.Equal(t, AlertType, decoded["type"])
+	assert.Equal(t, StateReject, decoded["action"])
+	assert.Equal(t, "core-data", decoded["source"])
+	assert.Equal(t, "/data", decoded["name"])
+	assert.Equal(t, float64(1600000000000), decoded["timestamp"])
+	details := decoded["details"].(map[string]interface{})
+	assert.Equal(t, StateRetention, details["previous"])
+	assert.Equal(t, 4.0, details["diskFreePercent"])
+	assert.Equal(t, 50.0, details["memoryUsedPercent"])
+	assert.Contains(t, details["reason"], "new events are rejected")
+}
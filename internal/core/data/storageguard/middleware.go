@@ -0,0 +1,60 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package storageguard
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+// RejectMessage is the message of the responses to the events rejected by the guard
+const RejectMessage = "event rejected: the storage of core-data is running out of space or memory"
+
+// NewMiddleware returns a middleware that responds 507 (Insufficient Storage) to the add event requests of the V1 and
+// V2 APIs while the guard rejects the new events
+func NewMiddleware(lc logger.LoggingClient, guard *Guard) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || !guard.Rejecting() || !isAddEventRoute(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			lc.Debug(RejectMessage, clients.CorrelationHeader, correlation.FromContext(ctx))
+			utils.WriteHttpHeader(w, ctx, http.StatusInsufficientStorage)
+			pkg.Encode(commonDTO.NewBaseResponse("", RejectMessage, http.StatusInsufficientStorage), w, lc)
+		})
+	}
+}
+
+func isAddEventRoute(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+	template, err := route.GetPathTemplate()
+	return err == nil && (template == v2.ApiEventProfileNameDeviceNameRoute || template == clients.ApiEventRoute)
+}
@@ -0,0 +1,88 @@
+// +build linux
+
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package storageguard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const memInfoPath = "/proc/meminfo"
+
+// ReadUsage measures the free space of the volume holding path, and the memory of the host
+func ReadUsage(path string) (Usage, error) {
+	var usage Usage
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return usage, fmt.Errorf("failed to read the free space of %s: %s", path, err.Error())
+	}
+	// the blocks reserved to root aren't available to the database
+	usage.DiskFreeBytes = stat.Bavail * uint64(stat.Bsize)
+	usage.DiskTotalBytes = stat.Blocks * uint64(stat.Bsize)
+
+	file, err := os.Open(memInfoPath)
+	if err != nil {
+		return usage, fmt.Errorf("failed to read the memory usage: %s", err.Error())
+	}
+	defer func() { _ = file.Close() }()
+	total, available, err := parseMemInfo(file)
+	if err != nil {
+		return usage, err
+	}
+	usage.MemoryTotalBytes = total
+	usage.MemoryUsedBytes = total - available
+	return usage, nil
+}
+
+// parseMemInfo returns the MemTotal and MemAvailable of /proc/meminfo, in bytes
+func parseMemInfo(r io.Reader) (total uint64, available uint64, err error) {
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// e.g. "MemAvailable:    8046572 kB"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		name := strings.TrimSuffix(fields[0], ":")
+		if name != "MemTotal" && name != "MemAvailable" {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s in %s: %s", name, memInfoPath, err.Error())
+		}
+		if len(fields) > 2 && fields[2] == "kB" {
+			value *= 1024
+		}
+		values[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	total, hasTotal := values["MemTotal"]
+	available, hasAvailable := values["MemAvailable"]
+	if !hasTotal || !hasAvailable || available > total {
+		return 0, 0, fmt.Errorf("no valid MemTotal and MemAvailable in %s", memInfoPath)
+	}
+	return total, available, nil
+}
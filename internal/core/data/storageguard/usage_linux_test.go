@@ -0,0 +1,54 @@
+// +build linux
+
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package storageguard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemInfo(t *testing.T) {
+	memInfo := `MemTotal:       16318420 kB
+MemFree:          602448 kB
+MemAvailable:    8046572 kB
+Buffers:          428620 kB
+`
+	total, available, err := parseMemInfo(strings.NewReader(memInfo))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(16318420*1024), total)
+	assert.Equal(t, uint64(8046572*1024), available)
+
+	_, _, err = parseMemInfo(strings.NewReader("MemTotal:       16318420 kB\n"))
+	assert.Error(t, err, "MemAvailable is required")
+	_, _, err = parseMemInfo(strings.NewReader("MemTotal: abc kB\nMemAvailable: 1 kB\n"))
+	assert.Error(t, err)
+}
+
+func TestReadUsage(t *testing.T) {
+	usage, err := ReadUsage(t.TempDir())
+	require.NoError(t, err)
+	assert.NotZero(t, usage.DiskTotalBytes)
+	assert.LessOrEqual(t, usage.DiskFreeBytes, usage.DiskTotalBytes)
+	assert.NotZero(t, usage.MemoryTotalBytes)
+	assert.LessOrEqual(t, usage.MemoryUsedBytes, usage.MemoryTotalBytes)
+
+	_, err = ReadUsage("/does/not/exist")
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/dualwrite"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces"
+	v2Handlers "github.com/edgexfoundry/edgex-go/internal/pkg/v2/bootstrap/handlers"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const secondaryDatabase = "Secondary"
+
+// startStorageMigration connects to the secondary database and replaces the core-data database client with one
+// writing to both databases. The consistency of the databases is then checked every StorageMigration.CheckInterval,
+// and the secondary database is disconnected once httpServer stops.
+func startStorageMigration(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	startupTimer startup.Timer,
+	dic *di.Container,
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	httpServer httpServer) error {
+
+	info := configuration.StorageMigration
+	var checkInterval time.Duration
+	if info.CheckInterval != "" {
+		var err error
+		if checkInterval, err = time.ParseDuration(info.CheckInterval); err != nil || checkInterval <= 0 {
+			return fmt.Errorf("invalid StorageMigration.CheckInterval '%s'", info.CheckInterval)
+		}
+	}
+
+	secondary, err := connectSecondaryDatabase(lc, configuration, startupTimer)
+	if err != nil {
+		return err
+	}
+	if err := migrateDatabase(lc, secondary, configuration.DatabaseMigration, startupTimer); err != nil {
+		secondary.CloseSession()
+		return fmt.Errorf("failed to migrate the secondary database: %s", err.Error())
+	}
+
+	client := dualwrite.NewClient(lc, v2DataContainer.DBClientFrom(dic.Get), secondary, func() bool {
+		return configuration.Writable.StorageCutover
+	})
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return client
+		},
+	})
+	lc.Info(fmt.Sprintf("Storage migration enabled, writing to the %s database too", secondaryDatabase))
+
+	if checkInterval > 0 {
+		runPeriodically(ctx, wg, dic, "storage consistency check", checkInterval, func() {
+			client.LogCheck(info.CheckSampleSize)
+		})
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		// the primary database is disconnected by the database bootstrap handler
+		for httpServer.IsRunning() {
+			time.Sleep(time.Second)
+		}
+		secondary.CloseSession()
+		lc.Info(fmt.Sprintf("%s database disconnected", secondaryDatabase))
+	}()
+	return nil
+}
+
+// connectSecondaryDatabase returns a client of the secondary database, retrying until startupTimer elapses
+func connectSecondaryDatabase(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	startupTimer startup.Timer) (interfaces.DBClient, error) {
+
+	databaseInfo, ok := configuration.Databases[secondaryDatabase]
+	if !ok {
+		return nil, fmt.Errorf("StorageMigration is enabled but Databases.%s is not configured", secondaryDatabase)
+	}
+
+	var lastErr error
+	for startupTimer.HasNotElapsed() {
+		dbClient, err := v2Handlers.NewDBClient(lc, databaseInfo)
+		if err == nil {
+			coreDataClient, ok := dbClient.(interfaces.DBClient)
+			if !ok {
+				dbClient.CloseSession()
+				return nil, fmt.Errorf("database client %T does not support core-data", dbClient)
+			}
+			return coreDataClient, nil
+		}
+		lastErr = err
+		lc.Warn(fmt.Sprintf("couldn't create %s database client: %s", secondaryDatabase, err.Error()))
+		startupTimer.SleepForInterval()
+	}
+	return nil, fmt.Errorf("couldn't create %s database client in allotted time: %v", secondaryDatabase, lastErr)
+}
@@ -0,0 +1,78 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// The types of the bulk deletion jobs
+const (
+	JobDeleteEventsByTimeRange              = "DeleteEventsByTimeRange"
+	JobDeleteEventsByDeviceNameAndTimeRange = "DeleteEventsByDeviceNameAndTimeRange"
+)
+
+// SubmitDeleteEventsByTimeRange submits the job deleting, with their readings, the events created within the time
+// range [start, end], by batches run in the background
+func SubmitDeleteEventsByTimeRange(start int, end int, dic *di.Container) (jobs.Job, errors.EdgeX) {
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+
+	total, err := dbClient.EventCountByTimeRange(start, end)
+	if err != nil {
+		return jobs.Job{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	job, err := pkgContainer.JobManagerFrom(dic.Get).Submit(JobDeleteEventsByTimeRange,
+		fmt.Sprintf("events created from %d to %d", start, end), total,
+		deleteEventsBatch(func(limit int) (uint32, errors.EdgeX) {
+			return dbClient.DeleteEventsByTimeRange(start, end, limit)
+		}))
+	if err != nil {
+		return jobs.Job{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	return job, nil
+}
+
+// SubmitDeleteEventsByDeviceNameAndTimeRange submits the job deleting, with their readings, the events of a device
+// created within the time range [start, end], by batches run in the background
+func SubmitDeleteEventsByDeviceNameAndTimeRange(deviceName string, start int, end int, dic *di.Container) (jobs.Job, errors.EdgeX) {
+	if len(strings.TrimSpace(deviceName)) <= 0 {
+		return jobs.Job{}, errors.NewCommonEdgeX(errors.KindInvalidId, "blank device name is not allowed", nil)
+	}
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+
+	total, err := dbClient.EventCountByDeviceNameAndTimeRange(deviceName, start, end)
+	if err != nil {
+		return jobs.Job{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	job, err := pkgContainer.JobManagerFrom(dic.Get).Submit(JobDeleteEventsByDeviceNameAndTimeRange,
+		fmt.Sprintf("events of device %s created from %d to %d", deviceName, start, end), total,
+		deleteEventsBatch(func(limit int) (uint32, errors.EdgeX) {
+			return dbClient.DeleteEventsByDeviceNameAndTimeRange(deviceName, start, end, limit)
+		}))
+	if err != nil {
+		return jobs.Job{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	return job, nil
+}
+
+// deleteEventsBatch returns the jobs.Batch of the deletion, done once it deletes fewer events than its limit
+func deleteEventsBatch(deleteEvents func(limit int) (uint32, errors.EdgeX)) jobs.Batch {
+	return func(limit int) (uint32, bool, errors.EdgeX) {
+		deleted, err := deleteEvents(limit)
+		if err != nil {
+			return deleted, false, errors.NewCommonEdgeXWrapper(err)
+		}
+		return deleted, int(deleted) < limit, nil
+	}
+}
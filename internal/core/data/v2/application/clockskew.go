@@ -0,0 +1,49 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/clockskew"
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// CheckClockSkew applies Writable.ClockSkew to e, before the event is signed, persisted and published. It rejects the
+// event whose origin is out of the limits of its device, or tags it with clockskew.FlagTag when the action is to flag.
+func CheckClockSkew(e *dtos.Event, ctx context.Context, dic *di.Container) errors.EdgeX {
+	info := dataContainer.ConfigurationFrom(dic.Get).Writable.ClockSkew
+	lc := container.LoggingClientFrom(dic.Get)
+	skew, err := info.Check(e.DeviceName, e.Origin, time.Now())
+	if err != nil {
+		// validated on startup, but the Writable configuration can be changed at runtime
+		lc.Error(fmt.Sprintf("clock skew of event %s not checked, invalid clock skew configuration: %s", e.Id, err.Error()))
+		return nil
+	}
+	if !skew.Exceeded() {
+		return nil
+	}
+
+	message := fmt.Sprintf("event %s of device %s: %s", e.Id, e.DeviceName, skew.String())
+	if info.Action != clockskew.ActionFlag {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, message, nil)
+	}
+	if e.Tags == nil {
+		e.Tags = make(map[string]string)
+	}
+	e.Tags[clockskew.FlagTag] = skew.Direction
+	lc.Warn(fmt.Sprintf("%s, event flagged", message), clients.CorrelationHeader, correlation.FromContext(ctx))
+	return nil
+}
@@ -0,0 +1,64 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// EventCountByTimeRange return the count of the events created within the time range [start, end] and error if any
+func EventCountByTimeRange(start int, end int, dic *di.Container) (uint32, errors.EdgeX) {
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	count, err := dbClient.EventCountByTimeRange(start, end)
+	if err != nil {
+		return 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	return count, nil
+}
+
+// EventCountByDeviceNameAndTimeRange return the count of the events of the device created within the time range
+// [start, end] and error if any
+func EventCountByDeviceNameAndTimeRange(deviceName string, start int, end int, dic *di.Container) (uint32, errors.EdgeX) {
+	if deviceName == "" {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	count, err := dbClient.EventCountByDeviceNameAndTimeRange(deviceName, start, end)
+	if err != nil {
+		return 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	return count, nil
+}
+
+// ReadingCountByDeviceNameAndTimeRange return the count of the readings of the device created within the time range
+// [start, end], but the readings of the excluded qualities, and error if any
+func ReadingCountByDeviceNameAndTimeRange(deviceName string, start int, end int, excludedQualities []string, dic *di.Container) (uint32, errors.EdgeX) {
+	if deviceName == "" {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	count, err := dbClient.ReadingCountByDeviceNameAndTimeRange(deviceName, start, end, excludedQualities)
+	if err != nil {
+		return 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	return count, nil
+}
+
+// DeviceHasEventsSince return whether the device has events created at or after the since timestamp and error if any
+func DeviceHasEventsSince(deviceName string, since int, dic *di.Container) (bool, errors.EdgeX) {
+	if deviceName == "" {
+		return false, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	exists, err := dbClient.DeviceHasEventsSince(deviceName, since)
+	if err != nil {
+		return false, errors.NewCommonEdgeXWrapper(err)
+	}
+	return exists, nil
+}
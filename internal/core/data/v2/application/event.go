@@ -14,6 +14,8 @@ import (
 	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
 	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
@@ -40,9 +42,28 @@ func ValidateEvent(e models.Event, profileName string, deviceName string, ctx co
 	return nil
 }
 
-// The AddEvent function accepts the new event model from the controller functions
-// and invokes addEvent function in the infrastructure layer
-func AddEvent(e models.Event, profileName string, deviceName string, ctx context.Context, dic *di.Container) (err errors.EdgeX) {
+// EnrichEvent applies the enrichment pipeline configured for the profile of e, and then stamps the provenance tags of
+// the event, before the event is persisted and published. It returns false when the pipeline dropped the event.
+func EnrichEvent(e *dtos.Event, ctx context.Context, dic *di.Container) (bool, errors.EdgeX) {
+	pipelines := v2DataContainer.EnrichmentPipelinesFrom(dic.Get)
+	keep, err := pipelines.Apply(e)
+	if err != nil {
+		return false, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to enrich event of profile %s", e.ProfileName), err)
+	}
+	if !keep {
+		lc := container.LoggingClientFrom(dic.Get)
+		lc.Debug(fmt.Sprintf("Event from device %s dropped by the enrichment pipeline of profile %s", e.DeviceName, e.ProfileName),
+			clients.CorrelationHeader, correlation.FromContext(ctx))
+		return false, nil
+	}
+	// the provenance tags are stamped last so that no pipeline step overrides them, and before the event is signed
+	v2DataContainer.ProvenanceStamperFrom(dic.Get).Stamp(e)
+	return true, nil
+}
+
+// The AddEvent function accepts the new event model from the controller functions, along with the annotations of its
+// readings by reading id, and invokes addEvent function in the infrastructure layer
+func AddEvent(e models.Event, annotations map[string]quality.Annotation, profileName string, deviceName string, ctx context.Context, dic *di.Container) (err errors.EdgeX) {
 	configuration := dataContainer.ConfigurationFrom(dic.Get)
 	if !configuration.Writable.PersistData {
 		return nil
@@ -54,12 +75,51 @@ func AddEvent(e models.Event, profileName string, deviceName string, ctx context
 	// Add the event and readings to the database
 	if configuration.Writable.PersistData {
 		correlationId := correlation.FromContext(ctx)
-		addedEvent, err := dbClient.AddEvent(e)
+		// the stamped events are created at their ingestion time, so that the time range queries filter on it
+		if ingested := v2DataContainer.ProvenanceStamperFrom(dic.Get).Ingested(e.Tags); ingested > 0 {
+			e.Created = ingested
+		}
+		// the expiry is stored along with the event, so that no event is left without it
+		ttl := eventTTL(e, dic)
+		var addedEvent models.Event
+		if ttl > 0 {
+			addedEvent, err = dbClient.AddExpiringEvent(e, ttl)
+		} else {
+			addedEvent, err = dbClient.AddEvent(e)
+		}
 		if err != nil {
 			return errors.NewCommonEdgeXWrapper(err)
 		}
 		e = addedEvent
 
+		// the annotations of the readings dropped by the enrichment pipeline are left out
+		kept := make(map[string]quality.Annotation)
+		for _, r := range e.Readings {
+			id := r.GetBaseReading().Id
+			if annotation, ok := annotations[id]; ok {
+				kept[id] = annotation
+			}
+		}
+		if len(kept) > 0 {
+			if err := dbClient.AddReadingAnnotations(kept); err != nil {
+				return errors.NewCommonEdgeXWrapper(err)
+			}
+		}
+
+		// only the tags of the allow-list are indexed, to bound the growth of the index
+		if indexed := configuration.TagIndex.Filter(e.Tags); len(indexed) > 0 {
+			if err := dbClient.AddEventTagIndex(e.Id, e.Created, indexed); err != nil {
+				return errors.NewCommonEdgeXWrapper(err)
+			}
+		}
+
+		if ttl > 0 {
+			deleteExpiredEvents(dic)
+		}
+
+		// the event is followed by the tail requests once stored, with its id and creation time
+		v2DataContainer.TailBufferFrom(dic.Get).Add(e)
+
 		lc.Debug(fmt.Sprintf(
 			"Event created on DB successfully. Event-id: %s, Correlation-id: %s ",
 			e.Id,
@@ -70,11 +130,13 @@ func AddEvent(e models.Event, profileName string, deviceName string, ctx context
 	return nil
 }
 
-// PublishEvent publishes incoming AddEventRequest through MessageClient
+// PublishEvent publishes incoming AddEventRequest through MessageClient, and to Kafka and the cloud when enabled
 func PublishEvent(addEventReq dto.AddEventRequest, profileName string, deviceName string, ctx context.Context, dic *di.Container) {
 	lc := container.LoggingClientFrom(dic.Get)
 	msgClient := dataContainer.MessagingClientFrom(dic.Get)
 	configuration := dataContainer.ConfigurationFrom(dic.Get)
+	// Every published event carries a correlation id, even when not added through a request
+	ctx = correlation.NewContext(ctx)
 	correlationId := correlation.FromContext(ctx)
 
 	lc.Debug("Putting V2 Event DTO on message queue", clients.CorrelationHeader, correlationId)
@@ -86,6 +148,26 @@ func PublishEvent(addEventReq dto.AddEventRequest, profileName string, deviceNam
 		ctx = context.WithValue(ctx, clients.ContentType, clients.ContentTypeJSON)
 	}
 
+	// the unchanged readings are filtered before the redaction so that their values are compared unredacted
+	if event, filtered := v2DataContainer.OnChangeFilterFrom(dic.Get).Filter(addEventReq.Event); filtered {
+		if len(event.Readings) == 0 {
+			lc.Debug(fmt.Sprintf("Event from device %s not published, none of its readings changed", deviceName),
+				clients.CorrelationHeader, correlationId)
+			return
+		}
+		addEventReq.Event = event
+	}
+
+	// the readings kept local are redacted from the published event only, the persisted one is left unchanged
+	if event, redacted := v2DataContainer.RedactorFrom(dic.Get).Redact(addEventReq.Event); redacted {
+		if len(event.Readings) == 0 {
+			lc.Debug(fmt.Sprintf("Event from device %s not published, all of its readings are redacted", deviceName),
+				clients.CorrelationHeader, correlationId)
+			return
+		}
+		addEventReq.Event = event
+	}
+
 	// Must make sure API Version for embedded DTOs is set since it isn't required by the request,
 	// but is needed when published to Message Bus.
 	addEventReq.Event.Versionable = common.NewVersionable()
@@ -109,6 +191,8 @@ func PublishEvent(addEventReq dto.AddEventRequest, profileName string, deviceNam
 		lc.Debug(fmt.Sprintf(
 			"V2 API Event Published on message queue. Topic: %s, Correlation-id: %s ", publishTopic, correlationId))
 	}
+	v2DataContainer.KafkaPublisherFrom(dic.Get).Publish(ctx, data, profileName, deviceName, correlationId)
+	v2DataContainer.CloudBridgeFrom(dic.Get).Forward(addEventReq.Event, correlationId)
 }
 
 func EventById(id string, dic *di.Container) (dtos.Event, errors.EdgeX) {
@@ -239,6 +323,51 @@ func EventsByTimeRange(start int, end int, offset int, limit int, dic *di.Contai
 	return events, nil
 }
 
+// parseTagExpression returns the terms of the tag expression, which may only be on indexed tags
+func parseTagExpression(expression string, dic *di.Container) ([]tags.Term, errors.EdgeX) {
+	terms, err := tags.ParseExpression(expression)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid tag expression", err)
+	}
+	configuration := dataContainer.ConfigurationFrom(dic.Get)
+	if err = configuration.TagIndex.ValidateTerms(terms); err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, err.Error(), nil)
+	}
+	return terms, nil
+}
+
+// EventsByTags query events matching the tag expression with offset and limit
+func EventsByTags(expression string, offset int, limit int, dic *di.Container) (events []dtos.Event, err errors.EdgeX) {
+	terms, err := parseTagExpression(expression, dic)
+	if err != nil {
+		return events, err
+	}
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	eventModels, err := dbClient.EventsByTags(terms, offset, limit)
+	if err != nil {
+		return events, errors.NewCommonEdgeXWrapper(err)
+	}
+	events = make([]dtos.Event, len(eventModels))
+	for i, e := range eventModels {
+		events[i] = dtos.FromEventModelToDTO(e)
+	}
+	return events, nil
+}
+
+// EventCountByTags return the count of the events matching the tag expression and error if any
+func EventCountByTags(expression string, dic *di.Container) (uint32, errors.EdgeX) {
+	terms, err := parseTagExpression(expression, dic)
+	if err != nil {
+		return 0, err
+	}
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	count, err := dbClient.EventCountByTags(terms)
+	if err != nil {
+		return 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	return count, nil
+}
+
 // The DeleteEventsByAge function will be invoked by controller functions
 // and then invokes DeleteEventsByAge function in the infrastructure layer to remove
 // events that are older than age.  Age is supposed in milliseconds since created timestamp.
@@ -4,16 +4,22 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/edgexfoundry/edgex-go/internal/core/data/clockskew"
 	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
 	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
 	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
 	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces/mocks"
 	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/provenance"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -212,7 +218,7 @@ func TestAddEvent(t *testing.T) {
 					return dbClientMock
 				},
 			})
-			err := AddEvent(evt, testCase.profileName, testCase.deviceName, context.Background(), dic)
+			err := AddEvent(evt, nil, testCase.profileName, testCase.deviceName, context.Background(), dic)
 
 			if testCase.errorExpected {
 				assert.Error(t, err)
@@ -228,6 +234,148 @@ func TestAddEvent(t *testing.T) {
 	}
 }
 
+func TestAddEvent_Annotations(t *testing.T) {
+	keptId := persistedEvent.Readings[0].GetBaseReading().Id
+	annotations := map[string]quality.Annotation{
+		keptId:      {Quality: quality.Bad, Null: true},
+		"droppedId": {Quality: quality.Stale},
+	}
+
+	dbClientMock := newMockDB(true)
+	dbClientMock.On("AddReadingAnnotations", mock.Anything).Return(nil)
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		dataContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{
+					PersistData: true,
+				},
+			}
+		},
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	err := AddEvent(persistedEvent, annotations, testProfileName, testDeviceName, context.Background(), dic)
+	require.NoError(t, err)
+	dbClientMock.AssertCalled(t, "AddReadingAnnotations", map[string]quality.Annotation{keptId: annotations[keptId]})
+}
+
+func TestAddEvent_Expiry(t *testing.T) {
+	tests := []struct {
+		name           string
+		ttl            string
+		expectedExpiry bool
+	}{
+		{"Valid - events expire", "1h", true},
+		{"Valid - events don't expire", "", false},
+		{"Invalid - TTL changed at runtime", "one hour", false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dbClientMock := newMockDB(true)
+			dbClientMock.On("AddExpiringEvent", mock.Anything, int64(3600000)).Return(persistedEvent, nil)
+			dbClientMock.On("DeleteExpiredEvents", mock.AnythingOfType("int64"), 4).Return(uint32(2), nil)
+			dic := mocks.NewMockDIC()
+			dic.Update(di.ServiceConstructorMap{
+				dataContainer.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{
+						Writable: config.WritableInfo{
+							PersistData: true,
+							EventExpiry: config.EventExpiryInfo{TTL: testCase.ttl, DeleteBatchSize: 4},
+						},
+					}
+				},
+				v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+					return dbClientMock
+				},
+			})
+
+			err := AddEvent(persistedEvent, nil, testProfileName, testDeviceName, context.Background(), dic)
+			require.NoError(t, err)
+			if testCase.expectedExpiry {
+				dbClientMock.AssertCalled(t, "AddExpiringEvent", persistedEvent, int64(3600000))
+				dbClientMock.AssertNotCalled(t, "AddEvent", mock.Anything)
+				dbClientMock.AssertCalled(t, "DeleteExpiredEvents", mock.AnythingOfType("int64"), 4)
+			} else {
+				dbClientMock.AssertCalled(t, "AddEvent", persistedEvent)
+				dbClientMock.AssertNotCalled(t, "AddExpiringEvent", mock.Anything, mock.Anything)
+				dbClientMock.AssertNotCalled(t, "DeleteExpiredEvents", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAddEvent_ExpiryDeletionFailure(t *testing.T) {
+	dbClientMock := newMockDB(true)
+	dbClientMock.On("AddExpiringEvent", mock.Anything, int64(86400000)).Return(persistedEvent, nil)
+	dbClientMock.On("DeleteExpiredEvents", mock.AnythingOfType("int64"), 2).
+		Return(uint32(0), errors.NewCommonEdgeX(errors.KindDatabaseError, "unavailable", nil))
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		dataContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{
+					PersistData: true,
+					EventExpiry: config.EventExpiryInfo{TTL: "24h", DeleteBatchSize: 2},
+				},
+			}
+		},
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	// the event is stored, the expired events are left to the next insert
+	err := AddEvent(persistedEvent, nil, testProfileName, testDeviceName, context.Background(), dic)
+	require.NoError(t, err)
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name          string
+		action        string
+		origin        time.Time
+		errorExpected bool
+		expectedTag   string
+	}{
+		{"Valid - within the limits", clockskew.ActionReject, now.Add(-time.Hour), false, ""},
+		{"Invalid - rejected in the past", clockskew.ActionReject, now.Add(-48 * time.Hour), true, ""},
+		{"Invalid - rejected in the future", clockskew.ActionReject, now.Add(time.Hour), true, ""},
+		{"Valid - flagged in the past", clockskew.ActionFlag, now.Add(-48 * time.Hour), false, clockskew.Past},
+		{"Valid - flagged in the future", clockskew.ActionFlag, now.Add(time.Hour), false, clockskew.Future},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dic := mocks.NewMockDIC()
+			dic.Update(di.ServiceConstructorMap{
+				dataContainer.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{
+						Writable: config.WritableInfo{
+							ClockSkew: clockskew.ClockSkewInfo{
+								Action:  testCase.action,
+								Default: clockskew.LimitsInfo{MaxPast: "24h", MaxFuture: "5m"},
+							},
+						},
+					}
+				},
+			})
+			event := dtos.Event{Id: testUUIDString, DeviceName: testDeviceName, Origin: testCase.origin.UnixNano()}
+
+			err := CheckClockSkew(&event, context.Background(), dic)
+			if testCase.errorExpected {
+				require.Error(t, err)
+				assert.Equal(t, http.StatusBadRequest, err.Code())
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedTag, event.Tags[clockskew.FlagTag])
+		})
+	}
+}
+
 func TestEventById(t *testing.T) {
 	validEventId := testUUIDString
 	emptyEventId := ""
@@ -453,3 +601,140 @@ func TestDeleteEventsByAge(t *testing.T) {
 	err := DeleteEventsByAge(0, dic)
 	require.NoError(t, err)
 }
+
+func TestAddEvent_TagIndex(t *testing.T) {
+	evt := persistedEvent
+	evt.Tags = map[string]string{"site": "plant-1", "batch": "42"}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddEvent", mock.Anything).Return(evt, nil)
+	dbClientMock.On("AddEventTagIndex", evt.Id, evt.Created, mock.Anything).Return(nil)
+
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		dataContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{
+					PersistData: true,
+				},
+				TagIndex: tags.IndexInfo{Tags: []string{"site", "line"}},
+			}
+		},
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	err := AddEvent(evt, nil, testProfileName, testDeviceName, context.Background(), dic)
+	require.NoError(t, err)
+	dbClientMock.AssertCalled(t, "AddEventTagIndex", evt.Id, evt.Created, map[string]string{"site": "plant-1"})
+}
+
+func TestEnrichEvent_Provenance(t *testing.T) {
+	stamper, err := provenance.NewStamper(provenance.ProvenanceInfo{Enabled: true, GatewayId: "gw-1"}, "2.0.0")
+	require.NoError(t, err)
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.ProvenanceStamperName: func(get di.Get) interface{} {
+			return stamper
+		},
+	})
+
+	event := dtos.FromEventModelToDTO(persistedEvent)
+	event.Tags = map[string]string{provenance.DefaultGatewayIdTag: "spoofed"}
+	keep, edgexErr := EnrichEvent(&event, context.Background(), dic)
+
+	require.NoError(t, edgexErr)
+	assert.True(t, keep)
+	assert.Equal(t, "gw-1", event.Tags[provenance.DefaultGatewayIdTag])
+	assert.Equal(t, "2.0.0", event.Tags[provenance.DefaultVersionTag])
+	assert.NotEmpty(t, event.Tags[provenance.DefaultIngestedTag])
+}
+
+func TestAddEvent_Provenance(t *testing.T) {
+	stamper, err := provenance.NewStamper(provenance.ProvenanceInfo{Enabled: true, GatewayId: "gw-1"}, "2.0.0")
+	require.NoError(t, err)
+	evt := persistedEvent
+	evt.Created = 0
+	evt.Tags = map[string]string{
+		provenance.DefaultGatewayIdTag: "gw-1",
+		provenance.DefaultVersionTag:   "2.0.0",
+		provenance.DefaultIngestedTag:  "1609459200000",
+	}
+	stored := evt
+	stored.Created = 1609459200000
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddEvent", stored).Return(stored, nil)
+	dbClientMock.On("AddEventTagIndex", stored.Id, stored.Created, mock.Anything).Return(nil)
+
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		dataContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{
+					PersistData: true,
+				},
+				TagIndex: tags.IndexInfo{Tags: stamper.IndexedTags()},
+			}
+		},
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		v2DataContainer.ProvenanceStamperName: func(get di.Get) interface{} {
+			return stamper
+		},
+	})
+
+	err = AddEvent(evt, nil, testProfileName, testDeviceName, context.Background(), dic)
+	require.NoError(t, err)
+	dbClientMock.AssertCalled(t, "AddEvent", stored)
+	dbClientMock.AssertCalled(t, "AddEventTagIndex", stored.Id, stored.Created,
+		map[string]string{provenance.DefaultGatewayIdTag: "gw-1", provenance.DefaultVersionTag: "2.0.0"})
+}
+
+func TestEventsByTags(t *testing.T) {
+	siteTerms := []tags.Term{{Name: "site", Operator: tags.Equal, Value: "plant-1"}}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("EventsByTags", siteTerms, 0, 20).Return([]models.Event{persistedEvent}, nil)
+	dbClientMock.On("EventCountByTags", siteTerms).Return(uint32(1), nil)
+
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		dataContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				TagIndex: tags.IndexInfo{Tags: []string{"site"}},
+			}
+		},
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	tests := []struct {
+		name            string
+		expression      string
+		errorExpected   bool
+		ExpectedErrKind errors.ErrKind
+		expectedCount   int
+	}{
+		{"Valid - indexed tag", "site=plant-1", false, "", 1},
+		{"Invalid - tag not indexed", "batch=42", true, errors.KindContractInvalid, 0},
+		{"Invalid - empty expression", "", true, errors.KindContractInvalid, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			events, err := EventsByTags(testCase.expression, 0, 20, dic)
+			count, countErr := EventCountByTags(testCase.expression, dic)
+			if testCase.errorExpected {
+				require.Error(t, err)
+				require.Error(t, countErr)
+				assert.Equal(t, testCase.ExpectedErrKind, errors.Kind(err), "Error kind not as expected")
+				assert.Equal(t, http.StatusBadRequest, err.Code(), "Status code not as expected")
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, countErr)
+				assert.Equal(t, testCase.expectedCount, len(events), "Event count is not expected")
+				assert.Equal(t, uint32(testCase.expectedCount), count, "Event count is not expected")
+			}
+		})
+	}
+}
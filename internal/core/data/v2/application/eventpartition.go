@@ -0,0 +1,37 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/partitions"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// EventPartitions return how the events of the hot devices are indexed by offset and limit, and error if any
+func EventPartitions(offset int, limit int, dic *di.Container) ([]partitions.DevicePartitions, errors.EdgeX) {
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	stats, err := dbClient.EventPartitions(offset, limit)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	return stats, nil
+}
+
+// EventPartitionsByDeviceName return how the events of the device are indexed, and error if any
+func EventPartitionsByDeviceName(deviceName string, dic *di.Container) (partitions.DevicePartitions, errors.EdgeX) {
+	if deviceName == "" {
+		return partitions.DevicePartitions{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	stats, err := dbClient.EventPartitionsByDeviceName(deviceName)
+	if err != nil {
+		return partitions.DevicePartitions{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,56 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// SignEvent signs e with the key of the gateway when event signing is enabled, before the event is persisted and
+// published
+func SignEvent(e *dtos.Event, dic *di.Container) errors.EdgeX {
+	signer := v2DataContainer.EventSignerFrom(dic.Get)
+	if signer == nil {
+		return nil
+	}
+	if err := signer.Sign(e); err != nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to sign event %s", e.Id), err)
+	}
+	return nil
+}
+
+// VerifyEvent verifies the signature of e with the key of the gateway. It returns the reason the signature isn't
+// valid, and an error if event signing isn't enabled.
+func VerifyEvent(e dtos.Event, dic *di.Container) (string, errors.EdgeX) {
+	signer := v2DataContainer.EventSignerFrom(dic.Get)
+	if signer == nil {
+		return "", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "event signing is not enabled", nil)
+	}
+	if err := signer.Verifier().Verify(e); err != nil {
+		return err.Error(), nil
+	}
+	return "", nil
+}
+
+// EventSigningKey returns the id and the PEM encoded public key verifying the signatures of the events, and an error
+// if event signing isn't enabled
+func EventSigningKey(dic *di.Container) (string, string, errors.EdgeX) {
+	signer := v2DataContainer.EventSignerFrom(dic.Get)
+	if signer == nil {
+		return "", "", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "event signing is not enabled", nil)
+	}
+	publicKey, err := signer.PublicKey()
+	if err != nil {
+		return "", "", errors.NewCommonEdgeX(errors.KindServerError, "failed to encode the event signing key", err)
+	}
+	return signer.KeyId(), string(publicKey), nil
+}
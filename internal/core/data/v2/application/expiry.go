@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// eventTTL returns the TTL in milliseconds of the added event e when Writable.EventExpiry has one, zero otherwise
+func eventTTL(e models.Event, dic *di.Container) int64 {
+	ttl, err := dataContainer.ConfigurationFrom(dic.Get).Writable.EventExpiry.Duration()
+	if err != nil {
+		// validated on startup, but the Writable configuration can be changed at runtime
+		lc := container.LoggingClientFrom(dic.Get)
+		lc.Error(fmt.Sprintf("event %s doesn't expire, invalid event expiry configuration: %s", e.Id, err.Error()))
+		return 0
+	}
+	return ttl.Milliseconds()
+}
+
+// deleteExpiredEvents deletes a batch of the expired events after an event is added, so the events are deleted at
+// the pace they are added rather than all at once by the scrubbing. The events expired while none is added are
+// deleted by the periodic sweep.
+func deleteExpiredEvents(dic *di.Container) {
+	info := dataContainer.ConfigurationFrom(dic.Get).Writable.EventExpiry
+	lc := container.LoggingClientFrom(dic.Get)
+	// the event is stored, failing to delete the expired ones is left to the next insert
+	deleted, err := v2DataContainer.DBClientFrom(dic.Get).DeleteExpiredEvents(common.MakeTimestamp(), info.DeleteBatchSize)
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to delete the expired events: %s", err.Error()))
+	} else if deleted > 0 {
+		lc.Debug(fmt.Sprintf("%d expired events deleted", deleted))
+	}
+}
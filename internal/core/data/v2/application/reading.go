@@ -2,17 +2,19 @@ package application
 
 import (
 	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 )
 
-// ReadingTotalCount return the count of all of readings currently stored in the database and error if any
-func ReadingTotalCount(dic *di.Container) (uint32, errors.EdgeX) {
+// ReadingTotalCount return the count of all of readings currently stored in the database, but the readings of the
+// excluded qualities, and error if any
+func ReadingTotalCount(excludedQualities []string, dic *di.Container) (uint32, errors.EdgeX) {
 	dbClient := v2DataContainer.DBClientFrom(dic.Get)
 
-	count, err := dbClient.ReadingTotalCount()
+	count, err := dbClient.ReadingTotalCount(excludedQualities)
 	if err != nil {
 		return 0, errors.NewCommonEdgeXWrapper(err)
 	}
@@ -20,71 +22,99 @@ func ReadingTotalCount(dic *di.Container) (uint32, errors.EdgeX) {
 	return count, nil
 }
 
-// AllReadings query events by offset, and limit
-func AllReadings(offset int, limit int, dic *di.Container) (readings []dtos.BaseReading, err errors.EdgeX) {
+// AllReadings query readings by offset, and limit, leaving out the readings of the excluded qualities
+func AllReadings(offset int, limit int, excludedQualities []string, dic *di.Container) (readings []quality.Reading, err errors.EdgeX) {
 	dbClient := v2DataContainer.DBClientFrom(dic.Get)
-	readingModels, err := dbClient.AllReadings(offset, limit)
+	readingModels, err := dbClient.AllReadings(offset, limit, excludedQualities)
 	if err != nil {
 		return readings, errors.NewCommonEdgeXWrapper(err)
 	}
-	return convertReadingModelsToDTOs(readingModels)
+	return convertReadingModelsToDTOs(readingModels, dic)
 }
 
-// ReadingsByResourceName query readings with offset, limit, and resource name
-func ReadingsByResourceName(offset int, limit int, resourceName string, dic *di.Container) (readings []dtos.BaseReading, err errors.EdgeX) {
+// ReadingsByResourceName query readings with offset, limit, and resource name, leaving out the readings of the
+// excluded qualities
+func ReadingsByResourceName(offset int, limit int, resourceName string, excludedQualities []string, dic *di.Container) (readings []quality.Reading, err errors.EdgeX) {
 	if resourceName == "" {
 		return readings, errors.NewCommonEdgeX(errors.KindContractInvalid, "resourceName is empty", nil)
 	}
 	dbClient := v2DataContainer.DBClientFrom(dic.Get)
-	readingModels, err := dbClient.ReadingsByResourceName(offset, limit, resourceName)
+	readingModels, err := dbClient.ReadingsByResourceName(offset, limit, resourceName, excludedQualities)
 	if err != nil {
 		return readings, errors.NewCommonEdgeXWrapper(err)
 	}
-	readings = make([]dtos.BaseReading, len(readingModels))
-	for i, r := range readingModels {
-		readings[i] = dtos.FromReadingModelToDTO(r)
-	}
-	return readings, nil
+	return convertReadingModelsToDTOs(readingModels, dic)
 }
 
-// ReadingsByDeviceName query readings with offset, limit, and device name
-func ReadingsByDeviceName(offset int, limit int, name string, dic *di.Container) (readings []dtos.BaseReading, err errors.EdgeX) {
+// ReadingsByDeviceName query readings with offset, limit, and device name, leaving out the readings of the excluded
+// qualities
+func ReadingsByDeviceName(offset int, limit int, name string, excludedQualities []string, dic *di.Container) (readings []quality.Reading, err errors.EdgeX) {
 	if name == "" {
 		return readings, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
 	}
 	dbClient := v2DataContainer.DBClientFrom(dic.Get)
-	readingModels, err := dbClient.ReadingsByDeviceName(offset, limit, name)
+	readingModels, err := dbClient.ReadingsByDeviceName(offset, limit, name, excludedQualities)
+	if err != nil {
+		return readings, errors.NewCommonEdgeXWrapper(err)
+	}
+	return convertReadingModelsToDTOs(readingModels, dic)
+}
+
+// ReadingsByTimeRange query readings with offset, limit and time range, leaving out the readings of the excluded
+// qualities
+func ReadingsByTimeRange(start int, end int, offset int, limit int, excludedQualities []string, dic *di.Container) (readings []quality.Reading, err errors.EdgeX) {
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	readingModels, err := dbClient.ReadingsByTimeRange(start, end, offset, limit, excludedQualities)
 	if err != nil {
 		return readings, errors.NewCommonEdgeXWrapper(err)
 	}
-	return convertReadingModelsToDTOs(readingModels)
+	return convertReadingModelsToDTOs(readingModels, dic)
 }
 
-// ReadingsByTimeRange query readings with offset, limit and time range
-func ReadingsByTimeRange(start int, end int, offset int, limit int, dic *di.Container) (readings []dtos.BaseReading, err errors.EdgeX) {
+// ReadingsByTags query the readings of the events matching the tag expression with offset and limit
+func ReadingsByTags(expression string, offset int, limit int, dic *di.Container) (readings []quality.Reading, err errors.EdgeX) {
+	terms, err := parseTagExpression(expression, dic)
+	if err != nil {
+		return readings, err
+	}
 	dbClient := v2DataContainer.DBClientFrom(dic.Get)
-	readingModels, err := dbClient.ReadingsByTimeRange(start, end, offset, limit)
+	readingModels, err := dbClient.ReadingsByTags(terms, offset, limit)
 	if err != nil {
 		return readings, errors.NewCommonEdgeXWrapper(err)
 	}
-	return convertReadingModelsToDTOs(readingModels)
+	return convertReadingModelsToDTOs(readingModels, dic)
 }
 
-func convertReadingModelsToDTOs(readingModels []models.Reading) (readings []dtos.BaseReading, err errors.EdgeX) {
-	readings = make([]dtos.BaseReading, len(readingModels))
+// convertReadingModelsToDTOs converts the reading models to DTOs along with their quality and null flag
+func convertReadingModelsToDTOs(readingModels []models.Reading, dic *di.Container) (readings []quality.Reading, err errors.EdgeX) {
+	ids := make([]string, len(readingModels))
+	for i, r := range readingModels {
+		ids[i] = r.GetBaseReading().Id
+	}
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	annotations, err := dbClient.ReadingAnnotations(ids)
+	if err != nil {
+		return readings, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	readings = make([]quality.Reading, len(readingModels))
 	for i, r := range readingModels {
-		readings[i] = dtos.FromReadingModelToDTO(r)
+		readings[i] = quality.Reading{
+			BaseReading: dtos.FromReadingModelToDTO(r),
+			Annotation:  annotations[ids[i]],
+		}
 	}
 	return readings, nil
 }
 
-// ReadingCountByDeviceName return the count of all of readings associated with given device and error if any
-func ReadingCountByDeviceName(deviceName string, dic *di.Container) (uint32, errors.EdgeX) {
+// ReadingCountByDeviceName return the count of all of readings associated with given device, but the readings of the
+// excluded qualities, and error if any
+func ReadingCountByDeviceName(deviceName string, excludedQualities []string, dic *di.Container) (uint32, errors.EdgeX) {
 	if deviceName == "" {
 		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
 	}
 	dbClient := v2DataContainer.DBClientFrom(dic.Get)
-	count, err := dbClient.ReadingCountByDeviceName(deviceName)
+	count, err := dbClient.ReadingCountByDeviceName(deviceName, excludedQualities)
 	if err != nil {
 		return 0, errors.NewCommonEdgeXWrapper(err)
 	}
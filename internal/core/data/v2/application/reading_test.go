@@ -7,10 +7,12 @@ import (
 	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
 	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces/mocks"
 	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -19,8 +21,9 @@ func TestAllReadings(t *testing.T) {
 
 	dic := mocks.NewMockDIC()
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("AllReadings", 0, 20).Return(readings, nil)
-	dbClientMock.On("AllReadings", 3, 10).Return([]models.Reading{}, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range.", nil))
+	dbClientMock.On("AllReadings", 0, 20, []string(nil)).Return(readings, nil)
+	dbClientMock.On("AllReadings", 3, 10, []string(nil)).Return([]models.Reading{}, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range.", nil))
+	dbClientMock.On("ReadingAnnotations", mock.Anything).Return(map[string]quality.Annotation{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -41,7 +44,7 @@ func TestAllReadings(t *testing.T) {
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
-			readings, err := AllReadings(testCase.offset, testCase.limit, dic)
+			readings, err := AllReadings(testCase.offset, testCase.limit, nil, dic)
 			if testCase.errorExpected {
 				require.Error(t, err)
 				assert.NotEmpty(t, err.Error(), "Error message is empty")
@@ -60,10 +63,11 @@ func TestReadingsByTimeRange(t *testing.T) {
 
 	dic := mocks.NewMockDIC()
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("ReadingsByTimeRange", int(readings[0].GetBaseReading().Created), int(readings[4].GetBaseReading().Created), 0, 10).Return(readings, nil)
-	dbClientMock.On("ReadingsByTimeRange", int(readings[1].GetBaseReading().Created), int(readings[3].GetBaseReading().Created), 0, 10).Return([]models.Reading{readings[3], readings[2], readings[1]}, nil)
-	dbClientMock.On("ReadingsByTimeRange", int(readings[1].GetBaseReading().Created), int(readings[3].GetBaseReading().Created), 1, 2).Return([]models.Reading{readings[2], readings[1]}, nil)
-	dbClientMock.On("ReadingsByTimeRange", int(readings[1].GetBaseReading().Created), int(readings[3].GetBaseReading().Created), 4, 2).Return(nil, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range", nil))
+	dbClientMock.On("ReadingsByTimeRange", int(readings[0].GetBaseReading().Created), int(readings[4].GetBaseReading().Created), 0, 10, []string(nil)).Return(readings, nil)
+	dbClientMock.On("ReadingsByTimeRange", int(readings[1].GetBaseReading().Created), int(readings[3].GetBaseReading().Created), 0, 10, []string(nil)).Return([]models.Reading{readings[3], readings[2], readings[1]}, nil)
+	dbClientMock.On("ReadingsByTimeRange", int(readings[1].GetBaseReading().Created), int(readings[3].GetBaseReading().Created), 1, 2, []string(nil)).Return([]models.Reading{readings[2], readings[1]}, nil)
+	dbClientMock.On("ReadingsByTimeRange", int(readings[1].GetBaseReading().Created), int(readings[3].GetBaseReading().Created), 4, 2, []string(nil)).Return(nil, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range", nil))
+	dbClientMock.On("ReadingAnnotations", mock.Anything).Return(map[string]quality.Annotation{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -88,7 +92,7 @@ func TestReadingsByTimeRange(t *testing.T) {
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
-			readings, err := ReadingsByTimeRange(testCase.start, testCase.end, testCase.offset, testCase.limit, dic)
+			readings, err := ReadingsByTimeRange(testCase.start, testCase.end, testCase.offset, testCase.limit, nil, dic)
 			if testCase.errorExpected {
 				require.Error(t, err)
 				assert.NotEmpty(t, err.Error(), "Error message is empty")
@@ -107,8 +111,9 @@ func TestReadingsByResourceName(t *testing.T) {
 
 	dic := mocks.NewMockDIC()
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("ReadingsByResourceName", 0, 20, testDeviceResourceName).Return(readings, nil)
-	dbClientMock.On("ReadingsByResourceName", len(readings)+1, 10, testDeviceResourceName).Return([]models.Reading{}, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range.", nil))
+	dbClientMock.On("ReadingsByResourceName", 0, 20, testDeviceResourceName, []string(nil)).Return(readings, nil)
+	dbClientMock.On("ReadingsByResourceName", len(readings)+1, 10, testDeviceResourceName, []string(nil)).Return([]models.Reading{}, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range.", nil))
+	dbClientMock.On("ReadingAnnotations", mock.Anything).Return(map[string]quality.Annotation{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -131,7 +136,7 @@ func TestReadingsByResourceName(t *testing.T) {
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
-			readings, err := ReadingsByResourceName(testCase.offset, testCase.limit, testCase.resourceName, dic)
+			readings, err := ReadingsByResourceName(testCase.offset, testCase.limit, testCase.resourceName, nil, dic)
 			if testCase.errorExpected {
 				require.Error(t, err)
 				assert.NotEmpty(t, err.Error(), "Error message is empty")
@@ -150,8 +155,9 @@ func TestReadingsByDeviceName(t *testing.T) {
 
 	dic := mocks.NewMockDIC()
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("ReadingsByDeviceName", 0, 20, testDeviceName).Return(readings, nil)
-	dbClientMock.On("ReadingsByDeviceName", 3, 10, testDeviceName).Return([]models.Reading{}, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range.", nil))
+	dbClientMock.On("ReadingsByDeviceName", 0, 20, testDeviceName, []string(nil)).Return(readings, nil)
+	dbClientMock.On("ReadingsByDeviceName", 3, 10, testDeviceName, []string(nil)).Return([]models.Reading{}, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range.", nil))
+	dbClientMock.On("ReadingAnnotations", mock.Anything).Return(map[string]quality.Annotation{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -173,7 +179,7 @@ func TestReadingsByDeviceName(t *testing.T) {
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
-			readings, err := ReadingsByDeviceName(testCase.offset, testCase.limit, testCase.deviceName, dic)
+			readings, err := ReadingsByDeviceName(testCase.offset, testCase.limit, testCase.deviceName, nil, dic)
 			if testCase.errorExpected {
 				require.Error(t, err)
 				assert.NotEmpty(t, err.Error(), "Error message is empty")
@@ -191,7 +197,7 @@ func TestReadingCountByDeviceName(t *testing.T) {
 	expectedReadingCount := uint32(656672)
 	dic := mocks.NewMockDIC()
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("ReadingCountByDeviceName", testDeviceName).Return(expectedReadingCount, nil)
+	dbClientMock.On("ReadingCountByDeviceName", testDeviceName, []string(nil)).Return(expectedReadingCount, nil)
 	dic.Update(di.ServiceConstructorMap{
 		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -211,7 +217,7 @@ func TestReadingCountByDeviceName(t *testing.T) {
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
-			count, err := ReadingCountByDeviceName(testCase.deviceName, dic)
+			count, err := ReadingCountByDeviceName(testCase.deviceName, nil, dic)
 			if testCase.errorExpected {
 				require.Error(t, err)
 				assert.NotEmpty(t, err.Error(), "Error message is empty")
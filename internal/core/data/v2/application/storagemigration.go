@@ -0,0 +1,25 @@
+package application
+
+import (
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/dualwrite"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// StorageMigrationReport checks the consistency of the databases written by the storage migration, and returns
+// the report and error if any
+func StorageMigrationReport(dic *di.Container) (dualwrite.Report, errors.EdgeX) {
+	dbClient, ok := v2DataContainer.DBClientFrom(dic.Get).(*dualwrite.Client)
+	if !ok {
+		return dualwrite.Report{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "storage migration is not enabled", nil)
+	}
+
+	report, err := dbClient.Check(dataContainer.ConfigurationFrom(dic.Get).StorageMigration.CheckSampleSize)
+	if err != nil {
+		return report, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	return report, nil
+}
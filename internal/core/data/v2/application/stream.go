@@ -0,0 +1,82 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// StreamBatchSize is the number of events or readings read from the database at once by the streamed queries
+const StreamBatchSize = 100
+
+// EventQuery queries the events with offset and limit, such as AllEvents
+type EventQuery func(offset int, limit int) ([]dtos.Event, errors.EdgeX)
+
+// ReadingQuery queries the readings with offset and limit, such as AllReadings
+type ReadingQuery func(offset int, limit int) ([]quality.Reading, errors.EdgeX)
+
+// StreamEvents queries the events from offset, up to limit of them or all of them when limit is -1, by batches of
+// StreamBatchSize, passing each batch to emit as soon as it is read so that the events are never all held in memory.
+// The batches are separate queries, so an event added or deleted while streaming may shift the following ones.
+func StreamEvents(offset int, limit int, query EventQuery, emit func([]dtos.Event) error) errors.EdgeX {
+	return streamBatches(offset, limit, func(offset int, limit int) (int, errors.EdgeX) {
+		events, err := query(offset, limit)
+		if err != nil {
+			return 0, err
+		}
+		if err := emit(events); err != nil {
+			return 0, errors.NewCommonEdgeX(errors.KindServerError, "failed to stream events", err)
+		}
+		return len(events), nil
+	})
+}
+
+// StreamReadings queries the readings from offset, up to limit of them or all of them when limit is -1, by batches
+// of StreamBatchSize, passing each batch to emit as soon as it is read, as StreamEvents does.
+func StreamReadings(offset int, limit int, query ReadingQuery, emit func([]quality.Reading) error) errors.EdgeX {
+	return streamBatches(offset, limit, func(offset int, limit int) (int, errors.EdgeX) {
+		readings, err := query(offset, limit)
+		if err != nil {
+			return 0, err
+		}
+		if err := emit(readings); err != nil {
+			return 0, errors.NewCommonEdgeX(errors.KindServerError, "failed to stream readings", err)
+		}
+		return len(readings), nil
+	})
+}
+
+// streamBatches calls batch with the offset and limit of each batch until limit results were read or a batch is
+// short. Only the failure of the first batch is returned as is, the client having asked for a range the database
+// doesn't hold; when the results shrink under the next batches, the stream just ends.
+func streamBatches(offset int, limit int, batch func(offset int, limit int) (int, errors.EdgeX)) errors.EdgeX {
+	first := true
+	for limit != 0 {
+		size := StreamBatchSize
+		if limit > 0 && limit < size {
+			size = limit
+		}
+		count, err := batch(offset, size)
+		if err != nil {
+			if !first && errors.Kind(err) == errors.KindRangeNotSatisfiable {
+				return nil
+			}
+			return err
+		}
+		if count < size {
+			return nil
+		}
+		first = false
+		offset += count
+		if limit > 0 {
+			limit -= count
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchesOf returns a batch function over total results, recording the offset and limit of each batch
+func batchesOf(total int, calls *[][2]int) func(offset int, limit int) (int, errors.EdgeX) {
+	return func(offset int, limit int) (int, errors.EdgeX) {
+		*calls = append(*calls, [2]int{offset, limit})
+		if offset > total {
+			return 0, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range", nil)
+		}
+		count := total - offset
+		if count > limit {
+			count = limit
+		}
+		return count, nil
+	}
+}
+
+func TestStreamBatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		offset   int
+		limit    int
+		expected [][2]int
+	}{
+		{"all", 250, 0, -1, [][2]int{{0, 100}, {100, 100}, {200, 100}}},
+		{"multiple of the batch size", 200, 0, -1, [][2]int{{0, 100}, {100, 100}, {200, 100}}},
+		{"limit", 250, 20, 150, [][2]int{{20, 100}, {120, 50}}},
+		{"limit below the batch size", 250, 0, 10, [][2]int{{0, 10}}},
+		{"no limit", 250, 0, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls [][2]int
+			require.NoError(t, streamBatches(tt.offset, tt.limit, batchesOf(tt.total, &calls)))
+			assert.Equal(t, tt.expected, calls)
+		})
+	}
+
+	var calls [][2]int
+	err := streamBatches(300, -1, batchesOf(250, &calls))
+	require.Error(t, err, "The range of the first batch should be checked")
+	assert.Equal(t, errors.KindRangeNotSatisfiable, errors.Kind(err))
+
+	shrinking := func(offset int, limit int) (int, errors.EdgeX) {
+		if offset > 0 {
+			return 0, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range", nil)
+		}
+		return limit, nil
+	}
+	assert.NoError(t, streamBatches(0, -1, shrinking), "The stream should end when the results shrink under the next batches")
+}
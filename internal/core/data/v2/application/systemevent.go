@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// AddSystemEvents validates and persists the system events recorded by the services
+func AddSystemEvents(events []audit.SystemEvent, dic *di.Container) errors.EdgeX {
+	for _, e := range events {
+		if e.Service == "" || e.Actor == "" || e.Action == "" {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid,
+				fmt.Sprintf("system event of %s %s must name the service, actor and action", e.Method, e.Path), nil)
+		}
+	}
+
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	_, err := dbClient.AddSystemEvents(events)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// SystemEventsByTimeRange query system events with offset, limit, and time range, by the actor if it is not empty
+func SystemEventsByTimeRange(start int, end int, actor string, offset int, limit int, dic *di.Container) ([]audit.SystemEvent, errors.EdgeX) {
+	dbClient := v2DataContainer.DBClientFrom(dic.Get)
+	events, err := dbClient.SystemEventsByTimeRange(start, end, actor, offset, limit)
+	if err != nil {
+		return events, errors.NewCommonEdgeXWrapper(err)
+	}
+	return events, nil
+}
@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"time"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/tail"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// TailEvents returns up to limit of the newest events when since is empty, or of the events added after the since
+// cursor, waiting up to wait for one to be added, along with the cursor of the next request and the number of the
+// events missed since the cursor. It returns an error if the tail isn't enabled.
+func TailEvents(ctx context.Context, since string, limit int, wait time.Duration, dic *di.Container) (
+	events []dtos.Event, cursor string, missed uint64, err errors.EdgeX) {
+	buffer := v2DataContainer.TailBufferFrom(dic.Get)
+	if buffer == nil {
+		return events, "", 0, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "the event tail is not enabled", nil)
+	}
+
+	var page tail.Page
+	if since == "" {
+		page = buffer.Newest(limit)
+	} else {
+		var sinceErr error
+		if page, sinceErr = buffer.Since(ctx, since, limit, wait); sinceErr != nil {
+			return events, "", 0, errors.NewCommonEdgeX(errors.KindContractInvalid, sinceErr.Error(), nil)
+		}
+	}
+	events = make([]dtos.Event, len(page.Events))
+	for i, e := range page.Events {
+		events[i] = dtos.FromEventModelToDTO(e)
+	}
+	return events, page.Cursor, page.Missed, nil
+}
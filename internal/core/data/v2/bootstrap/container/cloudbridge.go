@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/cloudbridge"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// CloudBridgeName contains the name of the cloudbridge.Bridge instance in the DIC.
+var CloudBridgeName = di.TypeInstanceToName(cloudbridge.Bridge{})
+
+// CloudBridgeFrom helper function queries the DIC and returns the cloudbridge.Bridge instance, or nil if forwarding
+// events to the cloud is disabled.
+func CloudBridgeFrom(get di.Get) *cloudbridge.Bridge {
+	bridge, ok := get(CloudBridgeName).(*cloudbridge.Bridge)
+	if !ok {
+		return nil
+	}
+	return bridge
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/enrichment"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// EnrichmentPipelinesName contains the name of the enrichment.Pipelines instance in the DIC.
+var EnrichmentPipelinesName = di.TypeInstanceToName(enrichment.Pipelines{})
+
+// EnrichmentPipelinesFrom helper function queries the DIC and returns the enrichment.Pipelines instance, or nil
+// if enrichment is disabled.
+func EnrichmentPipelinesFrom(get di.Get) *enrichment.Pipelines {
+	pipelines, ok := get(EnrichmentPipelinesName).(*enrichment.Pipelines)
+	if !ok {
+		return nil
+	}
+	return pipelines
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// EventSignerName contains the name of the eventsig.Signer instance in the DIC.
+var EventSignerName = di.TypeInstanceToName(eventsig.Signer{})
+
+// EventSignerFrom helper function queries the DIC and returns the eventsig.Signer instance, or nil if event signing
+// is disabled.
+func EventSignerFrom(get di.Get) *eventsig.Signer {
+	signer, ok := get(EventSignerName).(*eventsig.Signer)
+	if !ok {
+		return nil
+	}
+	return signer
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/federation"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// FederationProxyName contains the name of the federation.Proxy instance in the DIC.
+var FederationProxyName = di.TypeInstanceToName(federation.Proxy{})
+
+// FederationProxyFrom helper function queries the DIC and returns the federation.Proxy instance, or nil if federation
+// is disabled.
+func FederationProxyFrom(get di.Get) *federation.Proxy {
+	proxy, ok := get(FederationProxyName).(*federation.Proxy)
+	if !ok {
+		return nil
+	}
+	return proxy
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/kafka"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// KafkaPublisherName contains the name of the kafka.Publisher instance in the DIC.
+var KafkaPublisherName = di.TypeInstanceToName(kafka.Publisher{})
+
+// KafkaPublisherFrom helper function queries the DIC and returns the kafka.Publisher instance, or nil if publishing
+// to Kafka is disabled.
+func KafkaPublisherFrom(get di.Get) *kafka.Publisher {
+	publisher, ok := get(KafkaPublisherName).(*kafka.Publisher)
+	if !ok {
+		return nil
+	}
+	return publisher
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/onchange"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// OnChangeFilterName contains the name of the onchange.Filter instance in the DIC.
+var OnChangeFilterName = di.TypeInstanceToName(onchange.Filter{})
+
+// OnChangeFilterFrom helper function queries the DIC and returns the onchange.Filter instance, or nil if the on-change
+// publishing is disabled.
+func OnChangeFilterFrom(get di.Get) *onchange.Filter {
+	filter, ok := get(OnChangeFilterName).(*onchange.Filter)
+	if !ok {
+		return nil
+	}
+	return filter
+}
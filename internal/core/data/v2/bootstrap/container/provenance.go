@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/provenance"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// ProvenanceStamperName contains the name of the provenance.Stamper instance in the DIC.
+var ProvenanceStamperName = di.TypeInstanceToName(provenance.Stamper{})
+
+// ProvenanceStamperFrom helper function queries the DIC and returns the provenance.Stamper instance, or nil if the
+// provenance stamping is disabled.
+func ProvenanceStamperFrom(get di.Get) *provenance.Stamper {
+	stamper, ok := get(ProvenanceStamperName).(*provenance.Stamper)
+	if !ok {
+		return nil
+	}
+	return stamper
+}
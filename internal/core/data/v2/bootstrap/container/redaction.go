@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/redaction"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// RedactorName contains the name of the redaction.Redactor instance in the DIC.
+var RedactorName = di.TypeInstanceToName(redaction.Redactor{})
+
+// RedactorFrom helper function queries the DIC and returns the redaction.Redactor instance, or nil if redaction is
+// disabled.
+func RedactorFrom(get di.Get) *redaction.Redactor {
+	redactor, ok := get(RedactorName).(*redaction.Redactor)
+	if !ok {
+		return nil
+	}
+	return redactor
+}
@@ -0,0 +1,24 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/tail"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// TailBufferName contains the name of the tail.Buffer instance in the DIC.
+var TailBufferName = di.TypeInstanceToName(tail.Buffer{})
+
+// TailBufferFrom helper function queries the DIC and returns the tail.Buffer instance, or nil if the tail is disabled.
+func TailBufferFrom(get di.Get) *tail.Buffer {
+	buffer, ok := get(TailBufferName).(*tail.Buffer)
+	if !ok {
+		return nil
+	}
+	return buffer
+}
@@ -0,0 +1,601 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloudbridge forwards selected events core-data publishes to AWS IoT Core or Azure IoT Hub, following the
+// MQTT conventions of each cloud, and reports the latest values of their simple readings in the thing shadow or device
+// twin. The events can also be forwarded to any MQTT broker, as JSON or encoded as Sparkplug B for the SCADA systems.
+package cloudbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/sparkplug"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// Providers
+const (
+	AWS   = "AWS"
+	Azure = "Azure"
+	// MQTT is any MQTT broker, e.g. the broker of a SCADA system
+	MQTT = "MQTT"
+)
+
+// Encodings of the events forwarded to an MQTT broker
+const (
+	JSON = "JSON"
+	// SparkplugB publishes each EdgeX device as a Sparkplug device of the edge node core-data is, with the births,
+	// aliases and sequence numbers the Sparkplug primary applications expect
+	SparkplugB = "SparkplugB"
+)
+
+// Keys of the secret at CloudBridgeInfo.SecretPath
+const (
+	// CertKey and KeyKey are the PEM-encoded X.509 certificate and private key core-data authenticates with
+	CertKey = "cert"
+	KeyKey  = "key"
+	// CAKey is the PEM-encoded CA certificate the endpoint's certificate must be signed by. The system roots are used
+	// when not set.
+	CAKey = "ca"
+	// UsernameKey and PasswordKey are the credentials core-data authenticates to an MQTT broker with, if any
+	UsernameKey = "username"
+	PasswordKey = "password"
+)
+
+const (
+	// DefaultTopicTemplate is the topic of the events forwarded to AWS IoT Core
+	DefaultTopicTemplate = "edgex/{{.ProfileName}}/{{.DeviceName}}"
+	// AzureApiVersion is the version of the IoT Hub API given in the MQTT user name
+	AzureApiVersion = "2021-04-12"
+
+	defaultPort           = 8883
+	defaultPlainPort      = 1883
+	defaultQueueSize      = 100
+	defaultConnectTimeout = 30 * time.Second
+)
+
+// CloudBridgeInfo configures the forwarding of the events to the cloud
+type CloudBridgeInfo struct {
+	// Enabled forwards the selected events to the cloud, in addition to the message bus
+	Enabled bool
+	// Provider is the cloud, AWS or Azure, or MQTT for any MQTT broker
+	Provider string
+	// Endpoint is the host of the MQTT endpoint: the device data endpoint of the AWS account, e.g.
+	// "xxx-ats.iot.eu-west-1.amazonaws.com", the host name of the Azure IoT hub, e.g. "myhub.azure-devices.net", or
+	// the host of the MQTT broker
+	Endpoint string
+	// Port is the MQTT port, 8883 by default, or 1883 for an MQTT broker without TLS
+	Port int
+	// TLS connects to an MQTT broker over TLS. The clouds are always connected to over TLS.
+	TLS bool
+	// ClientId is the AWS thing name, or the Azure device id, core-data connects as
+	ClientId string
+	// SecretPath is the secret holding the X.509 credentials of the thing or device, with the keys CertKey, KeyKey and
+	// CAKey. The secret is optional for an MQTT broker, and can also hold the UsernameKey and PasswordKey.
+	SecretPath string
+	// Encoding is the encoding of the events forwarded to an MQTT broker, JSON by default or SparkplugB. The events are
+	// forwarded as JSON to the clouds.
+	Encoding string
+	// TopicTemplate is the text/template of the topic of an event on AWS or on an MQTT broker, given its ProfileName
+	// and DeviceName. The events are sent to the device-to-cloud messages of the device on Azure, and to the Sparkplug
+	// topics when encoded as Sparkplug B.
+	TopicTemplate string
+	// QoS is the MQTT quality of service of the messages, 0 or 1
+	QoS byte
+	// ProfileNames and DeviceNames select the forwarded events, all the events when both are empty
+	ProfileNames []string
+	DeviceNames  []string
+	// QueueSize bounds the events waiting to be forwarded. The events are dropped while the queue is full.
+	QueueSize int
+	// ConnectTimeout bounds the connection to the endpoint and the publishing of each message, e.g. "30s"
+	ConnectTimeout string
+	Shadow         ShadowInfo
+	Sparkplug      SparkplugInfo
+}
+
+// ShadowInfo configures the reporting of the latest values of the simple readings in the AWS thing shadow or the Azure
+// device twin, as reported properties grouped by device name. Not used on an MQTT broker.
+type ShadowInfo struct {
+	// Enabled reports the values of the readings of the forwarded events
+	Enabled bool
+	// ResourceNames restricts the reported readings to these resources, all of them when empty
+	ResourceNames []string
+	// Name is the AWS named shadow the values are reported in, the classic shadow when empty. Not used on Azure.
+	Name string
+}
+
+// SparkplugInfo identifies the Sparkplug edge node core-data is when the events are encoded as Sparkplug B
+type SparkplugInfo struct {
+	// GroupId is the Sparkplug group of the edge node
+	GroupId string
+	// NodeId is the id of the edge node, the ClientId by default
+	NodeId string
+}
+
+// Validate checks the configuration is usable when enabled
+func (c CloudBridgeInfo) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	switch c.Provider {
+	case AWS, Azure, MQTT:
+	default:
+		return fmt.Errorf("unknown Provider '%s', expected one of %v", c.Provider, []string{AWS, Azure, MQTT})
+	}
+	if c.Endpoint == "" {
+		return errors.New("Endpoint is required")
+	}
+	if c.ClientId == "" {
+		return errors.New("ClientId is required")
+	}
+	if c.SecretPath == "" && c.Provider != MQTT {
+		return errors.New("SecretPath is required for the X.509 credentials")
+	}
+	switch c.Encoding {
+	case "", JSON:
+	case SparkplugB:
+		if c.Provider != MQTT {
+			return fmt.Errorf("the %s Encoding is only supported by the %s Provider", SparkplugB, MQTT)
+		}
+		if err := sparkplug.ValidId(c.Sparkplug.GroupId); err != nil {
+			return fmt.Errorf("invalid Sparkplug GroupId: %s", err.Error())
+		}
+		if err := sparkplug.ValidId(c.NodeId()); err != nil {
+			return fmt.Errorf("invalid Sparkplug NodeId: %s", err.Error())
+		}
+	default:
+		return fmt.Errorf("unknown Encoding '%s', expected one of %v", c.Encoding, []string{JSON, SparkplugB})
+	}
+	if c.Shadow.Enabled && c.Provider == MQTT {
+		return fmt.Errorf("the Shadow is not supported by the %s Provider", MQTT)
+	}
+	if c.QoS > 1 {
+		return fmt.Errorf("QoS %d is not supported, expected 0 or 1", c.QoS)
+	}
+	if c.QueueSize < 0 {
+		return fmt.Errorf("QueueSize %d is negative", c.QueueSize)
+	}
+	if c.Provider == AWS || (c.Provider == MQTT && c.Encoding != SparkplugB) {
+		if _, err := newTopicTemplate(c.TopicTemplate); err != nil {
+			return err
+		}
+	}
+	if _, err := c.Timeout(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UsesTLS tells whether the MQTT endpoint is connected to over TLS
+func (c CloudBridgeInfo) UsesTLS() bool {
+	return c.Provider != MQTT || c.TLS
+}
+
+// BrokerURL returns the URL of the MQTT endpoint
+func (c CloudBridgeInfo) BrokerURL() string {
+	scheme, port := "ssl", defaultPort
+	if !c.UsesTLS() {
+		scheme, port = "tcp", defaultPlainPort
+	}
+	if c.Port != 0 {
+		port = c.Port
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, c.Endpoint, port)
+}
+
+// NodeId returns the id of the Sparkplug edge node
+func (c CloudBridgeInfo) NodeId() string {
+	if c.Sparkplug.NodeId == "" {
+		return c.ClientId
+	}
+	return c.Sparkplug.NodeId
+}
+
+// Username returns the MQTT user name required by the provider, if any
+func (c CloudBridgeInfo) Username() string {
+	if c.Provider == Azure {
+		return fmt.Sprintf("%s/%s/?api-version=%s", c.Endpoint, c.ClientId, AzureApiVersion)
+	}
+	return ""
+}
+
+// Timeout returns the ConnectTimeout
+func (c CloudBridgeInfo) Timeout() (time.Duration, error) {
+	if c.ConnectTimeout == "" {
+		return defaultConnectTimeout, nil
+	}
+	d, err := time.ParseDuration(c.ConnectTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ConnectTimeout: %s", err.Error())
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("ConnectTimeout %s is not positive", c.ConnectTimeout)
+	}
+	return d, nil
+}
+
+type topicFields struct {
+	ProfileName string
+	DeviceName  string
+}
+
+func newTopicTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultTopicTemplate
+	}
+	if strings.HasPrefix(text, "$") {
+		return nil, errors.New("invalid TopicTemplate: the topics starting with $ are reserved")
+	}
+	t, err := template.New("topic").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TopicTemplate: %s", err.Error())
+	}
+	if err = t.Execute(&bytes.Buffer{}, topicFields{}); err != nil {
+		return nil, fmt.Errorf("invalid TopicTemplate: %s", err.Error())
+	}
+	return t, nil
+}
+
+// Connection publishes the messages to the MQTT endpoint of the cloud
+type Connection interface {
+	Publish(topic string, qos byte, payload []byte) error
+	// Subscribe calls handler with the payload of each message published to the topic
+	Subscribe(topic string, qos byte, handler func(payload []byte)) error
+	Close()
+}
+
+// Will is the message the MQTT endpoint publishes when the connection of core-data is lost
+type Will struct {
+	Topic   string
+	QoS     byte
+	Payload []byte
+}
+
+// message is a message waiting to be published
+type message struct {
+	topic         string
+	payload       []byte
+	correlationId string
+}
+
+// queuedEvent is an event waiting to be forwarded
+type queuedEvent struct {
+	event         dtos.Event
+	correlationId string
+}
+
+// Bridge forwards the selected events to the cloud in the background. A nil Bridge forwards nothing.
+type Bridge struct {
+	info      CloudBridgeInfo
+	lc        logger.LoggingClient
+	topic     *template.Template
+	profiles  map[string]bool
+	devices   map[string]bool
+	resources map[string]bool
+	queue     chan queuedEvent
+	requestId uint64
+	// node is the state of the Sparkplug edge node when the events are encoded as Sparkplug B
+	node *sparkplug.Node
+	// births requests the publishing of the Sparkplug births
+	births chan struct{}
+}
+
+// NewBridge creates the bridge, or returns nil when the forwarding isn't enabled
+func NewBridge(info CloudBridgeInfo, lc logger.LoggingClient) (*Bridge, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	b := &Bridge{
+		info:      info,
+		lc:        lc,
+		profiles:  toSet(info.ProfileNames),
+		devices:   toSet(info.DeviceNames),
+		resources: toSet(info.Shadow.ResourceNames),
+		births:    make(chan struct{}, 1),
+	}
+	queueSize := info.QueueSize
+	if queueSize == 0 {
+		queueSize = defaultQueueSize
+	}
+	b.queue = make(chan queuedEvent, queueSize)
+	if info.Encoding == SparkplugB {
+		// the bdSeq of a new process differs from the one of the NDEATH the broker may still publish for the previous
+		b.node = sparkplug.NewNode(info.Sparkplug.GroupId, info.NodeId(), uint64(time.Now().Unix()))
+	} else if info.Provider != Azure {
+		// validated above
+		b.topic, _ = newTopicTemplate(info.TopicTemplate)
+	}
+	return b, nil
+}
+
+// Will returns the will of the connection, the NDEATH of the edge node when the events are encoded as Sparkplug B, or
+// nil
+func (b *Bridge) Will() (*Will, error) {
+	if b.node == nil {
+		return nil, nil
+	}
+	death, err := b.node.Death()
+	if err != nil {
+		return nil, err
+	}
+	return &Will{Topic: death.Topic, QoS: 1, Payload: death.Payload}, nil
+}
+
+// Connected is called each time the connection is established. When the events are encoded as Sparkplug B, it
+// subscribes to the NCMD of the edge node for the rebirth requests and requests the births, which the Sparkplug
+// primary applications expect first in each MQTT session.
+func (b *Bridge) Connected(connection Connection) {
+	if b.node == nil {
+		return
+	}
+	topic := b.node.Topic(sparkplug.NCMD, "")
+	err := connection.Subscribe(topic, 1, func(payload []byte) {
+		rebirth, err := sparkplug.IsRebirthRequest(payload)
+		if err != nil {
+			b.lc.Warn(fmt.Sprintf("invalid Sparkplug command received on %s: %s", topic, err.Error()))
+			return
+		}
+		if rebirth {
+			b.lc.Info("Sparkplug rebirth requested")
+			b.requestBirths()
+		}
+	})
+	if err != nil {
+		b.lc.Error(fmt.Sprintf("failed to subscribe to %s, the rebirth requests won't be answered: %s", topic, err.Error()))
+	}
+	b.requestBirths()
+}
+
+func (b *Bridge) requestBirths() {
+	select {
+	case b.births <- struct{}{}:
+	default:
+		// already requested
+	}
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// Selected returns whether the event is forwarded
+func (b *Bridge) Selected(event dtos.Event) bool {
+	if len(b.profiles) == 0 && len(b.devices) == 0 {
+		return true
+	}
+	return b.profiles[event.ProfileName] || b.devices[event.DeviceName]
+}
+
+// Forward queues the event to be published, with the shadow update of its readings, unless the event isn't selected
+func (b *Bridge) Forward(event dtos.Event, correlationId string) {
+	if b == nil || !b.Selected(event) {
+		return
+	}
+	select {
+	case b.queue <- queuedEvent{event: event, correlationId: correlationId}:
+	default:
+		b.lc.Warn(fmt.Sprintf("event of device %s dropped, the queue of the events forwarded to %s is full", event.DeviceName, b.info.Provider),
+			clients.CorrelationHeader, correlationId)
+	}
+}
+
+// messages returns the messages publishing the event and the shadow update of its readings. They are created in the
+// order they are published, the request ids of the twin patches and the Sparkplug sequence numbers following it.
+func (b *Bridge) messages(event dtos.Event, correlationId string) ([]message, error) {
+	if b.node != nil {
+		return b.sparkplugMessages(event, correlationId)
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	topic, err := b.telemetryTopic(event, correlationId)
+	if err != nil {
+		return nil, err
+	}
+	messages := []message{{topic: topic, payload: payload, correlationId: correlationId}}
+
+	if !b.info.Shadow.Enabled {
+		return messages, nil
+	}
+	values := b.reportedValues(event)
+	if len(values) == 0 {
+		return messages, nil
+	}
+	var reported interface{}
+	switch b.info.Provider {
+	case AWS:
+		reported = map[string]interface{}{"state": map[string]interface{}{"reported": values}}
+		topic = "$aws/things/" + b.info.ClientId + "/shadow/update"
+		if b.info.Shadow.Name != "" {
+			topic = "$aws/things/" + b.info.ClientId + "/shadow/name/" + b.info.Shadow.Name + "/update"
+		}
+	case Azure:
+		reported = values
+		topic = "$iothub/twin/PATCH/properties/reported/?$rid=" + strconv.FormatUint(atomic.AddUint64(&b.requestId, 1), 10)
+	}
+	if payload, err = json.Marshal(reported); err != nil {
+		return nil, err
+	}
+	return append(messages, message{topic: topic, payload: payload, correlationId: correlationId}), nil
+}
+
+// sparkplugMessages returns the DDATA publishing the readings of the event, or the DBIRTH of its device when it is new
+// or has new readings
+func (b *Bridge) sparkplugMessages(event dtos.Event, correlationId string) ([]message, error) {
+	metrics, errs := sparkplug.Metrics(event)
+	for _, err := range errs {
+		b.lc.Warn(err.Error(), clients.CorrelationHeader, correlationId)
+	}
+	if len(metrics) == 0 {
+		return nil, nil
+	}
+	data, err := b.node.Data(event.DeviceName, metrics)
+	if err != nil {
+		return nil, err
+	}
+	return toMessages(data, correlationId), nil
+}
+
+func toMessages(sparkplugMessages []sparkplug.Message, correlationId string) []message {
+	messages := make([]message, 0, len(sparkplugMessages))
+	for _, m := range sparkplugMessages {
+		messages = append(messages, message{topic: m.Topic, payload: m.Payload, correlationId: correlationId})
+	}
+	return messages
+}
+
+// telemetryTopic returns the topic of the event: the topic of the template on AWS and on an MQTT broker, the
+// device-to-cloud messages of the device on Azure, with the profile and device names and the correlation id as message
+// properties
+func (b *Bridge) telemetryTopic(event dtos.Event, correlationId string) (string, error) {
+	if b.info.Provider == Azure {
+		properties := url.Values{"profileName": {event.ProfileName}, "deviceName": {event.DeviceName}}
+		return fmt.Sprintf("devices/%s/messages/events/$.ct=%s&$.ce=utf-8&$.cid=%s&%s",
+			b.info.ClientId, url.QueryEscape(clients.ContentTypeJSON), url.QueryEscape(correlationId), properties.Encode()), nil
+	}
+	var buffer bytes.Buffer
+	if err := b.topic.Execute(&buffer, topicFields{ProfileName: event.ProfileName, DeviceName: event.DeviceName}); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// reportedValues returns the values of the simple readings of the event, typed after their value type, by resource
+// name under the device name
+func (b *Bridge) reportedValues(event dtos.Event) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, reading := range event.Readings {
+		if reading.ValueType == v2.ValueTypeBinary || (len(b.resources) > 0 && !b.resources[reading.ResourceName]) {
+			continue
+		}
+		values[b.propertyName(reading.ResourceName)] = typedValue(reading.ValueType, reading.Value)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return map[string]interface{}{b.propertyName(event.DeviceName): values}
+}
+
+// propertyName replaces the characters the Azure device twin doesn't allow in property names
+func (b *Bridge) propertyName(name string) string {
+	if b.info.Provider != Azure {
+		return name
+	}
+	return strings.NewReplacer(".", "_", "$", "_", " ", "_").Replace(name)
+}
+
+// typedValue returns the value as a JSON boolean or number after its value type, or as a string
+func typedValue(valueType string, value string) interface{} {
+	switch valueType {
+	case v2.ValueTypeBool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64:
+		if u, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return u
+		}
+	case v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
+
+// Run publishes the queued events with connection until ctx is done, and then the events still queued before closing
+// the connection. The requested Sparkplug births are published before the next event.
+func (b *Bridge) Run(ctx context.Context, wg *sync.WaitGroup, connection Connection) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-b.births:
+				b.publishBirths(connection)
+				continue
+			default:
+			}
+			select {
+			case <-ctx.Done():
+				b.drain(connection)
+				connection.Close()
+				return
+			case <-b.births:
+				b.publishBirths(connection)
+			case queued := <-b.queue:
+				b.forward(connection, queued)
+			}
+		}
+	}()
+}
+
+func (b *Bridge) drain(connection Connection) {
+	for {
+		select {
+		case queued := <-b.queue:
+			b.forward(connection, queued)
+		default:
+			return
+		}
+	}
+}
+
+func (b *Bridge) publishBirths(connection Connection) {
+	births, err := b.node.Birth()
+	if err != nil {
+		b.lc.Error(fmt.Sprintf("failed to encode the Sparkplug births: %s", err.Error()))
+		return
+	}
+	b.publish(connection, toMessages(births, ""))
+}
+
+func (b *Bridge) forward(connection Connection, queued queuedEvent) {
+	messages, err := b.messages(queued.event, queued.correlationId)
+	if err != nil {
+		b.lc.Error(fmt.Sprintf("failed to forward the event of device %s to %s: %s", queued.event.DeviceName, b.info.Provider, err.Error()),
+			clients.CorrelationHeader, queued.correlationId)
+		return
+	}
+	b.publish(connection, messages)
+}
+
+func (b *Bridge) publish(connection Connection, messages []message) {
+	for _, m := range messages {
+		if err := connection.Publish(m.topic, b.info.QoS, m.payload); err != nil {
+			b.lc.Error(fmt.Sprintf("failed to publish to %s topic %s: %s", b.info.Provider, m.topic, err.Error()),
+				clients.CorrelationHeader, m.correlationId)
+			return
+		}
+		b.lc.Debug(fmt.Sprintf("Published to %s topic %s", b.info.Provider, m.topic), clients.CorrelationHeader, m.correlationId)
+	}
+}
@@ -0,0 +1,279 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudbridge
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/sparkplug"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type published struct {
+	topic   string
+	qos     byte
+	payload string
+}
+
+type connectionStub struct {
+	mutex      sync.Mutex
+	published  []published
+	subscribed map[string]func(payload []byte)
+	closed     bool
+}
+
+func (c *connectionStub) Publish(topic string, qos byte, payload []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.published = append(c.published, published{topic: topic, qos: qos, payload: string(payload)})
+	return nil
+}
+
+func (c *connectionStub) Subscribe(topic string, qos byte, handler func(payload []byte)) error {
+	if c.subscribed == nil {
+		c.subscribed = make(map[string]func(payload []byte))
+	}
+	c.subscribed[topic] = handler
+	return nil
+}
+
+func (c *connectionStub) Close() {
+	c.closed = true
+}
+
+func testEvent(profileName string, deviceName string) dtos.Event {
+	event := dtos.NewEvent(profileName, deviceName)
+	event.Readings = []dtos.BaseReading{
+		{ResourceName: "temperature", ValueType: v2.ValueTypeFloat64, SimpleReading: dtos.SimpleReading{Value: "21.5"}},
+		{ResourceName: "heating", ValueType: v2.ValueTypeBool, SimpleReading: dtos.SimpleReading{Value: "true"}},
+		{ResourceName: "mode.name", ValueType: v2.ValueTypeString, SimpleReading: dtos.SimpleReading{Value: "eco"}},
+		{ResourceName: "snapshot", ValueType: v2.ValueTypeBinary, BinaryReading: dtos.BinaryReading{BinaryValue: []byte{1}, MediaType: "image/png"}},
+	}
+	return event
+}
+
+func TestCloudBridgeInfo_Validate(t *testing.T) {
+	valid := CloudBridgeInfo{Enabled: true, Provider: AWS, Endpoint: "xxx-ats.iot.eu-west-1.amazonaws.com", ClientId: "gateway", SecretPath: "cloudbridge"}
+	tests := []struct {
+		name   string
+		modify func(c *CloudBridgeInfo)
+		valid  bool
+	}{
+		{"Valid - AWS", func(c *CloudBridgeInfo) {}, true},
+		{"Valid - Azure", func(c *CloudBridgeInfo) { c.Provider = Azure; c.TopicTemplate = "$ignored" }, true},
+		{"Valid - disabled", func(c *CloudBridgeInfo) { c.Enabled = false; c.Provider = "" }, true},
+		{"Invalid - provider", func(c *CloudBridgeInfo) { c.Provider = "GCP" }, false},
+		{"Invalid - no endpoint", func(c *CloudBridgeInfo) { c.Endpoint = "" }, false},
+		{"Invalid - no client id", func(c *CloudBridgeInfo) { c.ClientId = "" }, false},
+		{"Invalid - no secret path", func(c *CloudBridgeInfo) { c.SecretPath = "" }, false},
+		{"Invalid - QoS 2", func(c *CloudBridgeInfo) { c.QoS = 2 }, false},
+		{"Invalid - reserved topic", func(c *CloudBridgeInfo) { c.TopicTemplate = "$aws/things/{{.DeviceName}}" }, false},
+		{"Invalid - topic template field", func(c *CloudBridgeInfo) { c.TopicTemplate = "edgex/{{.Device}}" }, false},
+		{"Invalid - connect timeout", func(c *CloudBridgeInfo) { c.ConnectTimeout = "soon" }, false},
+		{"Valid - MQTT", func(c *CloudBridgeInfo) { c.Provider = MQTT; c.SecretPath = "" }, true},
+		{"Valid - Sparkplug B", func(c *CloudBridgeInfo) {
+			c.Provider = MQTT
+			c.Encoding = SparkplugB
+			c.Sparkplug.GroupId = "plant-1"
+			c.TopicTemplate = "$ignored"
+		}, true},
+		{"Invalid - encoding", func(c *CloudBridgeInfo) { c.Provider = MQTT; c.Encoding = "CBOR" }, false},
+		{"Invalid - Sparkplug B on AWS", func(c *CloudBridgeInfo) { c.Encoding = SparkplugB; c.Sparkplug.GroupId = "plant-1" }, false},
+		{"Invalid - no Sparkplug group", func(c *CloudBridgeInfo) { c.Provider = MQTT; c.Encoding = SparkplugB }, false},
+		{"Invalid - Sparkplug node id", func(c *CloudBridgeInfo) {
+			c.Provider = MQTT
+			c.Encoding = SparkplugB
+			c.Sparkplug = SparkplugInfo{GroupId: "plant-1", NodeId: "gateway/1"}
+		}, false},
+		{"Invalid - shadow on MQTT", func(c *CloudBridgeInfo) { c.Provider = MQTT; c.Shadow.Enabled = true }, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			info := valid
+			testCase.modify(&info)
+			err := info.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCloudBridgeInfo_Connection(t *testing.T) {
+	info := CloudBridgeInfo{Provider: AWS, Endpoint: "xxx-ats.iot.eu-west-1.amazonaws.com", ClientId: "gateway"}
+	assert.Equal(t, "ssl://xxx-ats.iot.eu-west-1.amazonaws.com:8883", info.BrokerURL())
+	assert.Empty(t, info.Username())
+
+	info = CloudBridgeInfo{Provider: Azure, Endpoint: "myhub.azure-devices.net", Port: 443, ClientId: "gateway"}
+	assert.Equal(t, "ssl://myhub.azure-devices.net:443", info.BrokerURL())
+	assert.Equal(t, "myhub.azure-devices.net/gateway/?api-version="+AzureApiVersion, info.Username())
+
+	info = CloudBridgeInfo{Provider: MQTT, Endpoint: "broker", ClientId: "gateway"}
+	assert.Equal(t, "tcp://broker:1883", info.BrokerURL())
+	assert.Empty(t, info.Username())
+	assert.Equal(t, "gateway", info.NodeId())
+	info.TLS = true
+	info.Sparkplug.NodeId = "edge-1"
+	assert.Equal(t, "ssl://broker:8883", info.BrokerURL())
+	assert.Equal(t, "edge-1", info.NodeId())
+}
+
+func TestBridge_Messages(t *testing.T) {
+	awsInfo := CloudBridgeInfo{Enabled: true, Provider: AWS, Endpoint: "aws", ClientId: "gateway", SecretPath: "cloudbridge",
+		Shadow: ShadowInfo{Enabled: true}}
+	bridge, err := NewBridge(awsInfo, logger.NewMockClient())
+	require.NoError(t, err)
+	event := testEvent("thermostat", "living.room")
+
+	messages, err := bridge.messages(event, "correlation-id")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "edgex/thermostat/living.room", messages[0].topic)
+	var forwarded dtos.Event
+	require.NoError(t, json.Unmarshal(messages[0].payload, &forwarded))
+	assert.Equal(t, event.Id, forwarded.Id)
+	assert.Equal(t, "$aws/things/gateway/shadow/update", messages[1].topic)
+	assert.JSONEq(t, `{"state":{"reported":{"living.room":{"temperature":21.5,"heating":true,"mode.name":"eco"}}}}`, string(messages[1].payload))
+
+	awsInfo.Shadow = ShadowInfo{Enabled: true, Name: "edgex", ResourceNames: []string{"temperature"}}
+	bridge, err = NewBridge(awsInfo, logger.NewMockClient())
+	require.NoError(t, err)
+	messages, err = bridge.messages(event, "correlation-id")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "$aws/things/gateway/shadow/name/edgex/update", messages[1].topic)
+	assert.JSONEq(t, `{"state":{"reported":{"living.room":{"temperature":21.5}}}}`, string(messages[1].payload))
+
+	azureInfo := CloudBridgeInfo{Enabled: true, Provider: Azure, Endpoint: "myhub.azure-devices.net", ClientId: "gateway",
+		SecretPath: "cloudbridge", Shadow: ShadowInfo{Enabled: true}}
+	bridge, err = NewBridge(azureInfo, logger.NewMockClient())
+	require.NoError(t, err)
+	messages, err = bridge.messages(event, "correlation-id")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "devices/gateway/messages/events/$.ct=application%2Fjson&$.ce=utf-8&$.cid=correlation-id&"+
+		"deviceName=living.room&profileName=thermostat", messages[0].topic)
+	assert.Equal(t, "$iothub/twin/PATCH/properties/reported/?$rid=1", messages[1].topic)
+	assert.JSONEq(t, `{"living_room":{"temperature":21.5,"heating":true,"mode_name":"eco"}}`, string(messages[1].payload))
+	messages, err = bridge.messages(event, "correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, "$iothub/twin/PATCH/properties/reported/?$rid=2", messages[1].topic, "each patch should have its own request id")
+
+	azureInfo.Shadow = ShadowInfo{Enabled: true, ResourceNames: []string{"snapshot"}}
+	bridge, err = NewBridge(azureInfo, logger.NewMockClient())
+	require.NoError(t, err)
+	messages, err = bridge.messages(event, "correlation-id")
+	require.NoError(t, err)
+	assert.Len(t, messages, 1, "the binary readings should not be reported")
+
+	mqttInfo := CloudBridgeInfo{Enabled: true, Provider: MQTT, Endpoint: "broker", ClientId: "gateway"}
+	bridge, err = NewBridge(mqttInfo, logger.NewMockClient())
+	require.NoError(t, err)
+	messages, err = bridge.messages(event, "correlation-id")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "edgex/thermostat/living.room", messages[0].topic)
+	require.NoError(t, json.Unmarshal(messages[0].payload, &forwarded))
+	assert.Equal(t, event.Id, forwarded.Id)
+}
+
+func TestBridge_Sparkplug(t *testing.T) {
+	info := CloudBridgeInfo{Enabled: true, Provider: MQTT, Endpoint: "broker", ClientId: "gateway", Encoding: SparkplugB,
+		Sparkplug: SparkplugInfo{GroupId: "plant-1"}}
+	bridge, err := NewBridge(info, logger.NewMockClient())
+	require.NoError(t, err)
+
+	will, err := bridge.Will()
+	require.NoError(t, err)
+	assert.Equal(t, "spBv1.0/plant-1/NDEATH/gateway", will.Topic)
+	assert.Equal(t, byte(1), will.QoS)
+
+	connection := &connectionStub{}
+	bridge.Connected(connection)
+	require.Contains(t, connection.subscribed, "spBv1.0/plant-1/NCMD/gateway")
+	bridge.Forward(testEvent("thermostat", "living-room"), "id")
+	bridge.Forward(testEvent("thermostat", "living-room"), "id")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var wg sync.WaitGroup
+	bridge.Run(ctx, &wg, connection)
+	wg.Wait()
+
+	var topics []string
+	for _, p := range connection.published {
+		topics = append(topics, p.topic)
+	}
+	assert.Equal(t, []string{
+		"spBv1.0/plant-1/NBIRTH/gateway",
+		"spBv1.0/plant-1/DBIRTH/gateway/living-room",
+		"spBv1.0/plant-1/DDATA/gateway/living-room",
+	}, topics, "the births should be published first on connection")
+	data, err := sparkplug.Unmarshal([]byte(connection.published[2].payload))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), data.Seq)
+	require.Len(t, data.Metrics, 4)
+	assert.Equal(t, 21.5, data.Metrics[0].Value)
+
+	// a rebirth request publishes the births of the node and of the devices again
+	connection.published = nil
+	request, err := sparkplug.Payload{Metrics: []sparkplug.Metric{{Name: sparkplug.RebirthMetric, DataType: sparkplug.Boolean, Value: true}}}.Marshal()
+	require.NoError(t, err)
+	connection.subscribed["spBv1.0/plant-1/NCMD/gateway"](request)
+	bridge.Run(ctx, &wg, connection)
+	wg.Wait()
+	require.Len(t, connection.published, 2)
+	assert.Equal(t, "spBv1.0/plant-1/NBIRTH/gateway", connection.published[0].topic)
+	assert.Equal(t, "spBv1.0/plant-1/DBIRTH/gateway/living-room", connection.published[1].topic)
+}
+
+func TestBridge_Forward(t *testing.T) {
+	var bridge *Bridge
+	bridge.Forward(testEvent("thermostat", "living-room"), "id")
+
+	connection := &connectionStub{}
+	info := CloudBridgeInfo{Enabled: true, Provider: AWS, Endpoint: "aws", ClientId: "gateway", SecretPath: "cloudbridge",
+		QoS: 1, QueueSize: 2, ProfileNames: []string{"thermostat"}, DeviceNames: []string{"boiler"}}
+	bridge, err := NewBridge(info, logger.NewMockClient())
+	require.NoError(t, err)
+
+	bridge.Forward(testEvent("thermostat", "living-room"), "id")
+	bridge.Forward(testEvent("camera", "door"), "id")
+	bridge.Forward(testEvent("heater", "boiler"), "id")
+	bridge.Forward(testEvent("thermostat", "kitchen"), "id")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var wg sync.WaitGroup
+	bridge.Run(ctx, &wg, connection)
+	wg.Wait()
+
+	require.Len(t, connection.published, 2, "the unselected events and the events past the queue size should be dropped")
+	assert.Equal(t, "edgex/thermostat/living-room", connection.published[0].topic)
+	assert.Equal(t, byte(1), connection.published[0].qos)
+	assert.Equal(t, "edgex/heater/boiler", connection.published[1].topic)
+	assert.True(t, connection.closed, "the queued events should be published before the connection is closed")
+}
+
+func TestTypedValue(t *testing.T) {
+	assert.Equal(t, int64(-3), typedValue(v2.ValueTypeInt8, "-3"))
+	assert.Equal(t, uint64(18446744073709551615), typedValue(v2.ValueTypeUint64, "18446744073709551615"))
+	assert.Equal(t, 1.5e-7, typedValue(v2.ValueTypeFloat32, "1.5e-07"))
+	assert.Equal(t, false, typedValue(v2.ValueTypeBool, "false"))
+	assert.Equal(t, "NaN?", typedValue(v2.ValueTypeInt32, "NaN?"), "an unparsable value should be kept as a string")
+	assert.Equal(t, "21", typedValue(v2.ValueTypeString, "21"))
+}
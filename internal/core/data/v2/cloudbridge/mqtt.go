@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudbridge
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const quiesce = 250
+
+// SecretProvider reads the secrets from the service's secret store, e.g. a bootstrap interfaces.SecretProvider
+type SecretProvider interface {
+	GetSecrets(path string, keys ...string) (map[string]string, error)
+}
+
+// connection is the Connection of a paho MQTT client
+type connection struct {
+	client  mqtt.Client
+	timeout time.Duration
+}
+
+// Connect connects to the MQTT endpoint with the credentials of the secret at info.SecretPath, leaving will if not nil.
+// The connection is re-established when lost, connected being called each time it is established.
+func Connect(info CloudBridgeInfo, secretProvider SecretProvider, lc logger.LoggingClient, will *Will, connected func(Connection)) (Connection, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	// validated above
+	timeout, _ := info.Timeout()
+	secrets := map[string]string{}
+	if info.SecretPath != "" {
+		var err error
+		if secrets, err = secretProvider.GetSecrets(info.SecretPath); err != nil {
+			return nil, fmt.Errorf("failed to read the credentials from secret %s: %s", info.SecretPath, err.Error())
+		}
+	}
+
+	c := &connection{timeout: timeout}
+	options := mqtt.NewClientOptions().
+		AddBroker(info.BrokerURL()).
+		SetClientID(info.ClientId).
+		SetCleanSession(true).
+		SetAutoReconnect(true).
+		SetConnectTimeout(timeout).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			lc.Warn(fmt.Sprintf("connection to %s lost: %s", info.BrokerURL(), err.Error()))
+		}).
+		SetOnConnectHandler(func(_ mqtt.Client) {
+			lc.Info(fmt.Sprintf("Connected to %s as %s", info.BrokerURL(), info.ClientId))
+			connected(c)
+		})
+	if info.UsesTLS() {
+		config, err := tlsConfig(info, secrets)
+		if err != nil {
+			return nil, err
+		}
+		options.SetTLSConfig(config)
+	}
+	if username := info.Username(); username != "" {
+		options.SetUsername(username)
+	} else if secrets[UsernameKey] != "" {
+		options.SetUsername(secrets[UsernameKey])
+		options.SetPassword(secrets[PasswordKey])
+	}
+	if will != nil {
+		options.SetBinaryWill(will.Topic, will.Payload, will.QoS, false)
+	}
+
+	c.client = mqtt.NewClient(options)
+	token := c.client.Connect()
+	if !token.WaitTimeout(timeout) {
+		return nil, fmt.Errorf("timed out connecting to %s", info.BrokerURL())
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %s", info.BrokerURL(), err.Error())
+	}
+	return c, nil
+}
+
+// tlsConfig returns the TLS configuration with the X.509 credentials of the secrets, which are optional for an MQTT
+// broker
+func tlsConfig(info CloudBridgeInfo, secrets map[string]string) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: info.Endpoint,
+		MinVersion: tls.VersionTLS12,
+	}
+	if info.Provider != MQTT || secrets[CertKey] != "" {
+		certificate, err := tls.X509KeyPair([]byte(secrets[CertKey]), []byte(secrets[KeyKey]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid X.509 credentials in secret %s: %s", info.SecretPath, err.Error())
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	if ca := secrets[CAKey]; ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("invalid CA certificate in secret %s", info.SecretPath)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+func (c *connection) Publish(topic string, qos byte, payload []byte) error {
+	token := c.client.Publish(topic, qos, false, payload)
+	if !token.WaitTimeout(c.timeout) {
+		return errors.New("timed out")
+	}
+	return token.Error()
+}
+
+func (c *connection) Subscribe(topic string, qos byte, handler func(payload []byte)) error {
+	token := c.client.Subscribe(topic, qos, func(_ mqtt.Client, m mqtt.Message) {
+		handler(m.Payload())
+	})
+	if !token.WaitTimeout(c.timeout) {
+		return errors.New("timed out")
+	}
+	return token.Error()
+}
+
+func (c *connection) Close() {
+	// waits for the in-flight messages for up to quiesce milliseconds
+	c.client.Disconnect(quiesce)
+}
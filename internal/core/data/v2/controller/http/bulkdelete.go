@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gorilla/mux"
+)
+
+// ApiEventByDeviceNameTimeRangeRoute deletes the events of a device created within a time range, in a background job
+// as the DELETE requests of v2.ApiEventByTimeRangeRoute do
+const ApiEventByDeviceNameTimeRangeRoute = v2.ApiEventByDeviceNameRoute + "/start/{" + v2.Start + "}/end/{" + v2.End + "}"
+
+func (ec *EventController) DeleteEventsByTimeRange(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+
+	var job jobs.Job
+	start, end, err := parseTimeRange(r)
+	if err == nil {
+		job, err = application.SubmitDeleteEventsByTimeRange(start, end, ec.dic)
+	}
+	jobs.WriteSubmitted(w, r, lc, job, err)
+}
+
+func (ec *EventController) DeleteEventsByDeviceNameAndTimeRange(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+
+	var job jobs.Job
+	start, end, err := parseTimeRange(r)
+	if err == nil {
+		job, err = application.SubmitDeleteEventsByDeviceNameAndTimeRange(mux.Vars(r)[v2.Name], start, end, ec.dic)
+	}
+	jobs.WriteSubmitted(w, r, lc, job, err)
+}
@@ -0,0 +1,158 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBulkDeleteTestDIC returns the DIC of the bulk deletion tests, whose jobs are run by batches of 2 events
+func newBulkDeleteTestDIC(t *testing.T, dbClientMock *dbMock.DBClient) (*di.Container, *jobs.Manager) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	manager, err := jobs.NewManager(jobs.JobsInfo{BatchSize: 2}, logger.NewMockClient())
+	require.NoError(t, err)
+	manager.Start(ctx, wg)
+
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		pkgContainer.JobManagerName: func(get di.Get) interface{} {
+			return manager
+		},
+	})
+	return dic, manager
+}
+
+// waitForJob returns the job of the given id once it is finished
+func waitForJob(t *testing.T, manager *jobs.Manager, id string) jobs.Job {
+	var job jobs.Job
+	require.Eventually(t, func() bool {
+		job, _ = manager.Job(id)
+		return job.Finished > 0
+	}, time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestDeleteEventsByTimeRange(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("EventCountByTimeRange", 1000, 2000).Return(uint32(3), nil)
+	dbClientMock.On("DeleteEventsByTimeRange", 1000, 2000, 2).Return(uint32(2), nil).Once()
+	dbClientMock.On("DeleteEventsByTimeRange", 1000, 2000, 2).Return(uint32(1), nil).Once()
+	dic, manager := newBulkDeleteTestDIC(t, dbClientMock)
+	ec := NewEventController(dic)
+
+	tests := []struct {
+		name               string
+		start              string
+		end                string
+		expectedStatusCode int
+	}{
+		{"Valid - time range", "1000", "2000", http.StatusAccepted},
+		{"Invalid - end before start", "2000", "1000", http.StatusBadRequest},
+		{"Invalid - start not a number", "one", "2000", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodDelete, v2.ApiEventByTimeRangeRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Start: testCase.start, v2.End: testCase.end})
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(ec.DeleteEventsByTimeRange)
+			handler.ServeHTTP(recorder, req)
+
+			var actualResponse common.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &actualResponse)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(actualResponse.StatusCode), "Response status code not as expected")
+			if testCase.expectedStatusCode != http.StatusAccepted {
+				assert.NotEmpty(t, actualResponse.Message, "Response message doesn't contain the error message")
+				return
+			}
+			assert.Equal(t, jobs.ApiJobRoute+"/"+v2.Id+"/"+actualResponse.Id, recorder.Header().Get("Location"))
+
+			job := waitForJob(t, manager, actualResponse.Id)
+			assert.Equal(t, jobs.StatusCompleted, job.Status)
+			assert.Equal(t, uint32(3), job.Total)
+			assert.Equal(t, uint32(3), job.Processed)
+			dbClientMock.AssertNumberOfCalls(t, "DeleteEventsByTimeRange", 2)
+		})
+	}
+}
+
+func TestDeleteEventsByDeviceNameAndTimeRange(t *testing.T) {
+	deviceName := "deviceA"
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("EventCountByDeviceNameAndTimeRange", deviceName, 1000, 2000).Return(uint32(4), nil)
+	// the job is done once a batch deletes fewer events than its limit, none after the 4 events
+	dbClientMock.On("DeleteEventsByDeviceNameAndTimeRange", deviceName, 1000, 2000, 2).Return(uint32(2), nil).Twice()
+	dbClientMock.On("DeleteEventsByDeviceNameAndTimeRange", deviceName, 1000, 2000, 2).Return(uint32(0), nil).Once()
+	dic, manager := newBulkDeleteTestDIC(t, dbClientMock)
+	ec := NewEventController(dic)
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		expectedStatusCode int
+	}{
+		{"Valid", deviceName, http.StatusAccepted},
+		{"Invalid - blank device name", " ", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodDelete, ApiEventByDeviceNameTimeRangeRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.deviceName, v2.Start: "1000", v2.End: "2000"})
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(ec.DeleteEventsByDeviceNameAndTimeRange)
+			handler.ServeHTTP(recorder, req)
+
+			var actualResponse common.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &actualResponse)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusAccepted {
+				assert.NotEmpty(t, actualResponse.Message, "Response message doesn't contain the error message")
+				return
+			}
+
+			job := waitForJob(t, manager, actualResponse.Id)
+			assert.Equal(t, jobs.StatusCompleted, job.Status)
+			assert.Equal(t, uint32(4), job.Processed)
+			dbClientMock.AssertNumberOfCalls(t, "DeleteEventsByDeviceNameAndTimeRange", 3)
+		})
+	}
+}
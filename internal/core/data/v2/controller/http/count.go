@@ -0,0 +1,156 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+// Since is the path parameter of the timestamp, in milliseconds, from which the data is looked up, and the query
+// parameter of the cursor from which the events are tailed
+const Since = "since"
+
+// The count and existence routes are answered by the database indexes without reading the events and readings, for
+// the dashboards polling them
+const (
+	// ApiEventCountByTimeRangeRoute counts the events created within a time range
+	ApiEventCountByTimeRangeRoute = v2.ApiBase + "/event/count/start/{" + v2.Start + "}/end/{" + v2.End + "}"
+	// ApiEventCountByDeviceNameTimeRangeRoute counts the events of a device created within a time range
+	ApiEventCountByDeviceNameTimeRangeRoute = v2.ApiEventCountByDeviceNameRoute + "/start/{" + v2.Start + "}/end/{" + v2.End + "}"
+	// ApiReadingCountByDeviceNameTimeRangeRoute counts the readings of a device created within a time range, but those
+	// of the qualities of the quality.ExcludeQuality query parameter
+	ApiReadingCountByDeviceNameTimeRangeRoute = v2.ApiReadingCountByDeviceNameRoute + "/start/{" + v2.Start + "}/end/{" + v2.End + "}"
+	// ApiEventExistsByDeviceNameSinceRoute tells whether a device has events created since a timestamp
+	ApiEventExistsByDeviceNameSinceRoute = v2.ApiBase + "/event/exists/device/name/{" + v2.Name + "}/since/{" + Since + "}"
+)
+
+// ExistsResponse defines the response content of ApiEventExistsByDeviceNameSinceRoute
+type ExistsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Exists                 bool `json:"exists"`
+}
+
+// parseTimeRange parses the start and end path parameters of a time range
+func parseTimeRange(r *http.Request) (start int, end int, edgexErr errors.EdgeX) {
+	start, edgexErr = utils.ParsePathParamToInt(r, v2.Start)
+	if edgexErr != nil {
+		return start, end, edgexErr
+	}
+	end, edgexErr = utils.ParsePathParamToInt(r, v2.End)
+	if edgexErr != nil {
+		return start, end, edgexErr
+	}
+	if end < start {
+		return start, end, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("end's value %v is not allowed to be lower than start's value %v", end, start), nil)
+	}
+	return start, end, nil
+}
+
+// writeCountResponse writes the count, or the error if any
+func writeCountResponse(w http.ResponseWriter, r *http.Request, lc logger.LoggingClient, count uint32, err errors.EdgeX) {
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var countResponse interface{}
+	var statusCode int
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		countResponse = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		countResponse = commonDTO.NewCountResponse("", "", http.StatusOK, count)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(countResponse, w, lc) // encode and send out the response
+}
+
+func (ec *EventController) EventCountByTimeRange(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+
+	var count uint32
+	start, end, err := parseTimeRange(r)
+	if err == nil {
+		count, err = application.EventCountByTimeRange(start, end, ec.dic)
+	}
+	writeCountResponse(w, r, lc, count, err)
+}
+
+func (ec *EventController) EventCountByDeviceNameAndTimeRange(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+
+	var count uint32
+	start, end, err := parseTimeRange(r)
+	if err == nil {
+		count, err = application.EventCountByDeviceNameAndTimeRange(mux.Vars(r)[v2.Name], start, end, ec.dic)
+	}
+	writeCountResponse(w, r, lc, count, err)
+}
+
+func (rc *ReadingController) ReadingCountByDeviceNameAndTimeRange(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(rc.dic.Get)
+
+	var count uint32
+	start, end, err := parseTimeRange(r)
+	var excludedQualities []string
+	if err == nil {
+		excludedQualities, err = parseExcludedQualities(r)
+	}
+	if err == nil {
+		count, err = application.ReadingCountByDeviceNameAndTimeRange(mux.Vars(r)[v2.Name], start, end, excludedQualities, rc.dic)
+	}
+	writeCountResponse(w, r, lc, count, err)
+}
+
+func (ec *EventController) EventExistsByDeviceNameSince(w http.ResponseWriter, r *http.Request) {
+	// retrieve all the service injections from bootstrap
+	lc := container.LoggingClientFrom(ec.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	var exists bool
+	since, err := utils.ParsePathParamToInt(r, Since)
+	if err == nil {
+		exists, err = application.DeviceHasEventsSince(mux.Vars(r)[v2.Name], since, ec.dic)
+	}
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = ExistsResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+			Exists:       exists,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc) // encode and send out the response
+}
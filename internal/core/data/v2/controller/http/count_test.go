@@ -0,0 +1,176 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCountTestDIC(dbClientMock *dbMock.DBClient) *di.Container {
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	return dic
+}
+
+func TestEventCountByTimeRange(t *testing.T) {
+	expectedEventCount := uint32(1200)
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("EventCountByTimeRange", 1000, 2000).Return(expectedEventCount, nil)
+	ec := NewEventController(newCountTestDIC(dbClientMock))
+
+	tests := []struct {
+		name               string
+		start              string
+		end                string
+		expectedStatusCode int
+	}{
+		{"Valid - time range", "1000", "2000", http.StatusOK},
+		{"Invalid - end before start", "2000", "1000", http.StatusBadRequest},
+		{"Invalid - start not a number", "one", "2000", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ApiEventCountByTimeRangeRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Start: testCase.start, v2.End: testCase.end})
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(ec.EventCountByTimeRange)
+			handler.ServeHTTP(recorder, req)
+
+			var actualResponse common.CountResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &actualResponse)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(actualResponse.StatusCode), "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, expectedEventCount, actualResponse.Count, "Event count in the response body is not expected")
+			} else {
+				assert.NotEmpty(t, actualResponse.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+}
+
+func TestEventCountByDeviceNameAndTimeRange(t *testing.T) {
+	expectedEventCount := uint32(42)
+	deviceName := "deviceA"
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("EventCountByDeviceNameAndTimeRange", deviceName, 1000, 2000).Return(expectedEventCount, nil)
+	ec := NewEventController(newCountTestDIC(dbClientMock))
+
+	req, err := http.NewRequest(http.MethodGet, ApiEventCountByDeviceNameTimeRangeRoute, http.NoBody)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{v2.Name: deviceName, v2.Start: "1000", v2.End: "2000"})
+
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(ec.EventCountByDeviceNameAndTimeRange)
+	handler.ServeHTTP(recorder, req)
+
+	var actualResponse common.CountResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &actualResponse)
+	require.NoError(t, err)
+	assert.Equal(t, v2.ApiVersion, actualResponse.ApiVersion, "API Version not as expected")
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+	assert.Equal(t, expectedEventCount, actualResponse.Count, "Event count in the response body is not expected")
+}
+
+func TestReadingCountByDeviceNameAndTimeRange(t *testing.T) {
+	expectedReadingCount := uint32(84)
+	deviceName := "deviceA"
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("ReadingCountByDeviceNameAndTimeRange", deviceName, 1000, 2000, []string{quality.Bad}).Return(expectedReadingCount, nil)
+	rc := NewReadingController(newCountTestDIC(dbClientMock))
+
+	tests := []struct {
+		name               string
+		excludeQuality     string
+		expectedStatusCode int
+	}{
+		{"Valid - bad readings excluded", quality.Bad, http.StatusOK},
+		{"Invalid - unknown quality", "unknown", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ApiReadingCountByDeviceNameTimeRangeRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(quality.ExcludeQuality, testCase.excludeQuality)
+			req.URL.RawQuery = query.Encode()
+			req = mux.SetURLVars(req, map[string]string{v2.Name: deviceName, v2.Start: "1000", v2.End: "2000"})
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(rc.ReadingCountByDeviceNameAndTimeRange)
+			handler.ServeHTTP(recorder, req)
+
+			var actualResponse common.CountResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &actualResponse)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, expectedReadingCount, actualResponse.Count, "Reading count in the response body is not expected")
+			}
+		})
+	}
+}
+
+func TestEventExistsByDeviceNameSince(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceHasEventsSince", "deviceA", 1000).Return(true, nil)
+	dbClientMock.On("DeviceHasEventsSince", "deviceB", 1000).Return(false, nil)
+	ec := NewEventController(newCountTestDIC(dbClientMock))
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		since              string
+		expectedStatusCode int
+		expectedExists     bool
+	}{
+		{"Valid - device with events", "deviceA", "1000", http.StatusOK, true},
+		{"Valid - device without events", "deviceB", "1000", http.StatusOK, false},
+		{"Invalid - since not a number", "deviceA", "yesterday", http.StatusBadRequest, false},
+		{"Invalid - empty device name", "", "1000", http.StatusBadRequest, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ApiEventExistsByDeviceNameSinceRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.deviceName, Since: testCase.since})
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(ec.EventExistsByDeviceNameSince)
+			handler.ServeHTTP(recorder, req)
+
+			var actualResponse ExistsResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &actualResponse)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(actualResponse.StatusCode), "Response status code not as expected")
+			assert.Equal(t, testCase.expectedExists, actualResponse.Exists, "Existence in the response body is not expected")
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -18,6 +19,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
 	requestDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
 	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
@@ -25,6 +27,13 @@ import (
 	"github.com/gorilla/mux"
 )
 
+const (
+	// ApiEventByTagsRoute queries the events matching the tag expression of the tags.Expression query parameter
+	ApiEventByTagsRoute = v2.ApiBase + "/event/tags"
+	// ApiEventCountByTagsRoute counts the events matching the tag expression of the tags.Expression query parameter
+	ApiEventCountByTagsRoute = v2.ApiBase + "/event/count/tags"
+)
+
 type EventController struct {
 	reader io.EventReader
 	dic    *di.Container
@@ -54,7 +63,7 @@ func (ec *EventController) AddEvent(w http.ResponseWriter, r *http.Request) {
 	profileName := vars[v2.ProfileName]
 	deviceName := vars[v2.DeviceName]
 
-	addEventReqDTO, err := ec.reader.ReadAddEventRequest(r.Body)
+	addEventReqDTO, annotations, err := ec.reader.ReadAddEventRequest(r.Body)
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
@@ -68,10 +77,19 @@ func (ec *EventController) AddEvent(w http.ResponseWriter, r *http.Request) {
 	var addEventResponse interface{}
 	var statusCode int
 
+	keep, err := application.EnrichEvent(&addEventReqDTO.Event, ctx, ec.dic)
+	if err == nil && keep {
+		err = application.CheckClockSkew(&addEventReqDTO.Event, ctx, ec.dic)
+	}
+	if err == nil && keep {
+		err = application.SignEvent(&addEventReqDTO.Event, ec.dic)
+	}
 	event := requestDTO.AddEventReqToEventModel(addEventReqDTO)
-	err = application.ValidateEvent(event, profileName, deviceName, ctx, ec.dic)
 	if err == nil {
-		err = application.AddEvent(event, profileName, deviceName, ctx, ec.dic)
+		err = application.ValidateEvent(event, profileName, deviceName, ctx, ec.dic)
+	}
+	if err == nil && keep {
+		err = application.AddEvent(event, annotations, profileName, deviceName, ctx, ec.dic)
 	}
 
 	if err != nil {
@@ -86,7 +104,10 @@ func (ec *EventController) AddEvent(w http.ResponseWriter, r *http.Request) {
 			http.StatusCreated,
 			event.Id)
 		statusCode = http.StatusCreated
-		application.PublishEvent(addEventReqDTO, profileName, deviceName, ctx, ec.dic)
+		// an event dropped by enrichment is acknowledged so that the device service does not retry it
+		if keep {
+			application.PublishEvent(addEventReqDTO, profileName, deviceName, ctx, ec.dic)
+		}
 	}
 
 	utils.WriteHttpHeader(w, ctx, statusCode)
@@ -215,6 +236,32 @@ func (ec *EventController) EventCountByDeviceName(w http.ResponseWriter, r *http
 	pkg.Encode(countResponse, w, lc) // encode and send out the response
 }
 
+func (ec *EventController) EventCountByTags(w http.ResponseWriter, r *http.Request) {
+	// retrieve all the service injections from bootstrap
+	lc := container.LoggingClientFrom(ec.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var countResponse interface{}
+	var statusCode int
+
+	// Count the events by tags
+	count, err := application.EventCountByTags(utils.ParseQueryStringToString(r, tags.Expression, ""), ec.dic)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		countResponse = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		countResponse = commonDTO.NewCountResponse("", "", http.StatusOK, count)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(countResponse, w, lc) // encode and send out the response
+}
+
 func (ec *EventController) AllEvents(w http.ResponseWriter, r *http.Request) {
 	lc := container.LoggingClientFrom(ec.dic.Get)
 	ctx := r.Context()
@@ -225,12 +272,17 @@ func (ec *EventController) AllEvents(w http.ResponseWriter, r *http.Request) {
 	var statusCode int
 
 	// parse URL query string for offset, limit
-	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, maxResultCount(r, config.Service.MaxResultCount))
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 		statusCode = err.Code()
+	} else if streamRequested(r) {
+		streamEvents(w, ctx, lc, offset, limit, func(offset int, limit int) ([]dtos.Event, errors.EdgeX) {
+			return application.AllEvents(offset, limit, ec.dic)
+		})
+		return
 	} else {
 		events, err := application.AllEvents(offset, limit, ec.dic)
 		if err != nil {
@@ -263,12 +315,17 @@ func (ec *EventController) EventsByDeviceName(w http.ResponseWriter, r *http.Req
 	var statusCode int
 
 	// parse URL query string for offset, limit
-	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, maxResultCount(r, config.Service.MaxResultCount))
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 		statusCode = err.Code()
+	} else if streamRequested(r) {
+		streamEvents(w, ctx, lc, offset, limit, func(offset int, limit int) ([]dtos.Event, errors.EdgeX) {
+			return application.EventsByDeviceName(offset, limit, name, ec.dic)
+		})
+		return
 	} else {
 		events, err := application.EventsByDeviceName(offset, limit, name, ec.dic)
 		if err != nil {
@@ -327,12 +384,17 @@ func (ec *EventController) EventsByTimeRange(w http.ResponseWriter, r *http.Requ
 	var statusCode int
 
 	// parse time range (start, end), offset, and limit from incoming request
-	start, end, offset, limit, err := utils.ParseTimeRangeOffsetLimit(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	start, end, offset, limit, err := utils.ParseTimeRangeOffsetLimit(r, 0, math.MaxInt32, -1, maxResultCount(r, config.Service.MaxResultCount))
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 		statusCode = err.Code()
+	} else if streamRequested(r) {
+		streamEvents(w, ctx, lc, offset, limit, func(offset int, limit int) ([]dtos.Event, errors.EdgeX) {
+			return application.EventsByTimeRange(start, end, offset, limit, ec.dic)
+		})
+		return
 	} else {
 		events, err := application.EventsByTimeRange(start, end, offset, limit, ec.dic)
 		if err != nil {
@@ -386,3 +448,43 @@ func (ec *EventController) DeleteEventsByAge(w http.ResponseWriter, r *http.Requ
 	// encode and send out the response
 	pkg.Encode(response, w, lc)
 }
+
+func (ec *EventController) EventsByTags(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := dataContainer.ConfigurationFrom(ec.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	// parse URL query string for offset, limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, maxResultCount(r, config.Service.MaxResultCount))
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else if streamRequested(r) {
+		streamEvents(w, ctx, lc, offset, limit, func(offset int, limit int) ([]dtos.Event, errors.EdgeX) {
+			return application.EventsByTags(utils.ParseQueryStringToString(r, tags.Expression, ""), offset, limit, ec.dic)
+		})
+		return
+	} else {
+		events, err := application.EventsByTags(utils.ParseQueryStringToString(r, tags.Expression, ""), offset, limit, ec.dic)
+		if err != nil {
+			if errors.Kind(err) != errors.KindEntityDoesNotExist {
+				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			}
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = responseDTO.NewMultiEventsResponse("", "", http.StatusOK, events)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
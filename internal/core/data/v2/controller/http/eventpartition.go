@@ -0,0 +1,109 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/partitions"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiEventPartitionsRoute lists how the events of the hot devices are indexed, by offset and limit
+	ApiEventPartitionsRoute = v2.ApiBase + "/event/partitions"
+	// ApiEventPartitionsByDeviceNameRoute returns how the events of a device, hot or not, are indexed
+	ApiEventPartitionsByDeviceNameRoute = ApiEventPartitionsRoute + "/device/name/{" + v2.Name + "}"
+)
+
+// MultiEventPartitionsResponse defines the response content of ApiEventPartitionsRoute
+type MultiEventPartitionsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	HotThreshold           int                           `json:"hotThreshold"`
+	Span                   string                        `json:"span"`
+	Devices                []partitions.DevicePartitions `json:"devices"`
+}
+
+// EventPartitionsResponse defines the response content of ApiEventPartitionsByDeviceNameRoute
+type EventPartitionsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Device                 partitions.DevicePartitions `json:"device"`
+}
+
+func (ec *EventController) EventPartitions(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := dataContainer.ConfigurationFrom(ec.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	var devices []partitions.DevicePartitions
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err == nil {
+		devices, err = application.EventPartitions(offset, limit, ec.dic)
+	}
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = MultiEventPartitionsResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+			HotThreshold: config.EventPartitions.HotThreshold,
+			Span:         config.EventPartitions.Span,
+			Devices:      devices,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc) // encode and send out the response
+}
+
+func (ec *EventController) EventPartitionsByDeviceName(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	device, err := application.EventPartitionsByDeviceName(mux.Vars(r)[v2.Name], ec.dic)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = EventPartitionsResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+			Device:       device,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc) // encode and send out the response
+}
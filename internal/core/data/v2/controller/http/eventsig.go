@@ -0,0 +1,122 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+const (
+	// ApiEventVerifyRoute verifies the signature of an event with the key of the gateway
+	ApiEventVerifyRoute = v2.ApiBase + "/event/verify"
+	// ApiEventSigningKeyRoute returns the public key verifying the signatures of the events
+	ApiEventSigningKeyRoute = v2.ApiBase + "/event/signingkey"
+)
+
+// VerifyEventRequest defines the request content of ApiEventVerifyRoute
+type VerifyEventRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	Event                 dtos.Event `json:"event"`
+}
+
+// VerifyEventResponse defines the response content of ApiEventVerifyRoute
+type VerifyEventResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Valid                  bool `json:"valid"`
+	// Reason is why the signature isn't valid
+	Reason string `json:"reason,omitempty"`
+}
+
+// EventSigningKeyResponse defines the response content of ApiEventSigningKeyRoute
+type EventSigningKeyResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	KeyId                  string `json:"keyId,omitempty"`
+	// PublicKey is the PEM encoded public key
+	PublicKey string `json:"publicKey"`
+}
+
+func (ec *EventController) VerifyEvent(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(ec.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	var request VerifyEventRequest
+	var reason string
+	var edgexErr errors.EdgeX
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		edgexErr = errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+	} else {
+		reason, edgexErr = application.VerifyEvent(request.Event, ec.dic)
+	}
+
+	if edgexErr != nil {
+		lc.Error(edgexErr.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(edgexErr.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse(request.RequestId, edgexErr.Message(), edgexErr.Code())
+		statusCode = edgexErr.Code()
+	} else {
+		if reason != "" {
+			lc.Warn(reason, clients.CorrelationHeader, correlationId)
+		}
+		response = VerifyEventResponse{
+			BaseResponse: commonDTO.NewBaseResponse(request.RequestId, "", http.StatusOK),
+			Valid:        reason == "",
+			Reason:       reason,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc) // encode and send out the response
+}
+
+func (ec *EventController) EventSigningKey(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	keyId, publicKey, err := application.EventSigningKey(ec.dic)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = EventSigningKeyResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+			KeyId:        keyId,
+			PublicKey:    publicKey,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc) // encode and send out the response
+}
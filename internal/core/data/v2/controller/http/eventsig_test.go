@@ -0,0 +1,134 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T) *eventsig.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	signer, err := eventsig.NewSigner(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), "gateway-1")
+	require.NoError(t, err)
+	return signer
+}
+
+func newSignedEventBody(t *testing.T, signer *eventsig.Signer, value string) string {
+	e := dtos.Event{
+		Id:          ExampleUUID,
+		DeviceName:  TestDeviceName,
+		ProfileName: TestDeviceProfileName,
+		Origin:      TestOriginTime,
+		Readings: []dtos.BaseReading{{
+			DeviceName:    TestDeviceName,
+			ResourceName:  TestDeviceResourceName,
+			ProfileName:   TestDeviceProfileName,
+			Origin:        TestOriginTime,
+			ValueType:     "Int8",
+			SimpleReading: dtos.SimpleReading{Value: TestReadingValue},
+		}},
+	}
+	require.NoError(t, signer.Sign(&e))
+	e.Readings[0].Value = value
+	body, err := json.Marshal(VerifyEventRequest{Event: e})
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestVerifyEvent(t *testing.T) {
+	signer := newTestSigner(t)
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.EventSignerName: func(get di.Get) interface{} {
+			return signer
+		},
+	})
+	ec := NewEventController(dic)
+	disabled := NewEventController(mocks.NewMockDIC())
+
+	tests := []struct {
+		name               string
+		controller         *EventController
+		body               string
+		expectedStatusCode int
+		expectedValid      bool
+	}{
+		{"Valid", ec, newSignedEventBody(t, signer, TestReadingValue), http.StatusOK, true},
+		{"Valid - tampered", ec, newSignedEventBody(t, signer, "46"), http.StatusOK, false},
+		{"Valid - signed by another key", ec, newSignedEventBody(t, newTestSigner(t), TestReadingValue), http.StatusOK, false},
+		{"Invalid - bad JSON", ec, "{", http.StatusBadRequest, false},
+		{"Invalid - signing disabled", disabled, newSignedEventBody(t, signer, TestReadingValue), http.StatusNotFound, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, ApiEventVerifyRoute, strings.NewReader(testCase.body))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(testCase.controller.VerifyEvent)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			var res VerifyEventResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+			assert.Equal(t, testCase.expectedValid, res.Valid)
+			if testCase.expectedStatusCode == http.StatusOK && !testCase.expectedValid {
+				assert.NotEmpty(t, res.Reason)
+			}
+		})
+	}
+}
+
+func TestEventSigningKey(t *testing.T) {
+	signer := newTestSigner(t)
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.EventSignerName: func(get di.Get) interface{} {
+			return signer
+		},
+	})
+
+	req, err := http.NewRequest(http.MethodGet, ApiEventSigningKeyRoute, http.NoBody)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	http.HandlerFunc(NewEventController(dic).EventSigningKey).ServeHTTP(recorder, req)
+
+	var res EventSigningKeyResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	assert.Equal(t, "gateway-1", res.KeyId)
+	verifier, err := eventsig.NewVerifier([]byte(res.PublicKey))
+	require.NoError(t, err)
+	assert.NotNil(t, verifier)
+
+	recorder = httptest.NewRecorder()
+	http.HandlerFunc(NewEventController(mocks.NewMockDIC()).EventSigningKey).ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNotFound, recorder.Result().StatusCode, "event signing is disabled")
+}
@@ -0,0 +1,155 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/federation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiFederationPrefix prefixes the federated queries, e.g. GET /api/v2/federation/reading/device/name/{name}
+	// merges the results of GET /api/v2/reading/device/name/{name} on this gateway and on its peers
+	ApiFederationPrefix = v2.ApiBase + "/federation"
+	// FederationUnavailableHeader lists, comma separated, the gateways whose results are missing from a federated query
+	FederationUnavailableHeader = "X-Federation-Unavailable"
+)
+
+// federatedRoutes are the queries that can be federated, with the kind of their results
+var federatedRoutes = map[string]federation.Kind{
+	v2.ApiAllEventRoute:                       federation.Events,
+	v2.ApiEventByDeviceNameRoute:              federation.Events,
+	v2.ApiEventByTimeRangeRoute:               federation.Events,
+	ApiEventByTagsRoute:                       federation.Events,
+	v2.ApiAllReadingRoute:                     federation.Readings,
+	v2.ApiReadingByDeviceNameRoute:            federation.Readings,
+	v2.ApiReadingByTimeRangeRoute:             federation.Readings,
+	v2.ApiReadingByResourceNameRoute:          federation.Readings,
+	ApiReadingByTagsRoute:                     federation.Readings,
+	v2.ApiEventCountRoute:                     federation.Count,
+	v2.ApiEventCountByDeviceNameRoute:         federation.Count,
+	ApiEventCountByTimeRangeRoute:             federation.Count,
+	ApiEventCountByDeviceNameTimeRangeRoute:   federation.Count,
+	ApiEventCountByTagsRoute:                  federation.Count,
+	v2.ApiReadingCountRoute:                   federation.Count,
+	v2.ApiReadingCountByDeviceNameRoute:       federation.Count,
+	ApiReadingCountByDeviceNameTimeRangeRoute: federation.Count,
+}
+
+type FederationController struct {
+	routes *mux.Router
+	dic    *di.Container
+}
+
+// NewFederationController creates and initializes a FederationController
+func NewFederationController(dic *di.Container) *FederationController {
+	routes := mux.NewRouter()
+	for route := range federatedRoutes {
+		routes.NewRoute().Path(route).Methods(http.MethodGet)
+	}
+	return &FederationController{
+		routes: routes,
+		dic:    dic,
+	}
+}
+
+// Query serves the federated query of the route following ApiFederationPrefix
+func (fc *FederationController) Query(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(fc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := dataContainer.ConfigurationFrom(fc.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	result, err := fc.query(r, config.Service.MaxResultCount)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		if len(result.Unavailable) > 0 {
+			w.Header().Set(FederationUnavailableHeader, strings.Join(result.Unavailable, ","))
+		}
+		switch result.kind {
+		case federation.Events:
+			response = responseDTO.NewMultiEventsResponse("", "", http.StatusOK, result.Events)
+		case federation.Readings:
+			response = quality.NewMultiReadingsResponse("", "", http.StatusOK, result.Readings)
+		default:
+			response = commonDTO.NewCountResponse("", "", http.StatusOK, result.Count)
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+// federatedResult is the result of a federated query of the given kind
+type federatedResult struct {
+	federation.Result
+	kind federation.Kind
+}
+
+func (fc *FederationController) query(r *http.Request, maxResultCount int) (federatedResult, errors.EdgeX) {
+	proxy := v2DataContainer.FederationProxyFrom(fc.dic.Get)
+	if proxy == nil {
+		return federatedResult{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "federation is not enabled", nil)
+	}
+
+	target := r.Clone(r.Context())
+	target.URL.Path = v2.ApiBase + strings.TrimPrefix(r.URL.Path, ApiFederationPrefix)
+	target.URL.RawPath = ""
+	var match mux.RouteMatch
+	if !fc.routes.Match(target, &match) {
+		return federatedResult{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist,
+			fmt.Sprintf("%s is not a query that can be federated", target.URL.Path), nil)
+	}
+	// the templates are those of federatedRoutes
+	template, _ := match.Route.GetPathTemplate()
+	kind := federatedRoutes[template]
+
+	offset, limit := 0, 0
+	if kind != federation.Count {
+		var err errors.EdgeX
+		// the results are merged in memory, so they are never streamed
+		offset, limit, _, err = utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, maxResultCount)
+		if err != nil {
+			return federatedResult{}, err
+		}
+	}
+	result, err := proxy.Query(target, kind, offset, limit)
+	if err != nil {
+		return federatedResult{}, err
+	}
+	return federatedResult{Result: result, kind: kind}, nil
+}
@@ -0,0 +1,86 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/federation"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederatedQuery(t *testing.T) {
+	var paths []string
+	local := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_ = json.NewEncoder(w).Encode(responseDTO.NewMultiEventsResponse("", "", http.StatusOK, []dtos.Event{{Created: 10}}))
+	})
+	stopped := httptest.NewServer(http.NotFoundHandler())
+	stopped.Close()
+	proxy, err := federation.NewProxy(federation.FederationInfo{
+		Enabled: true,
+		Peers:   []federation.PeerInfo{{Name: "gw-2", URL: stopped.URL}},
+	}, nil, local, logger.NewMockClient())
+	require.NoError(t, err)
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.FederationProxyName: func(get di.Get) interface{} {
+			return proxy
+		},
+	})
+	controller := NewFederationController(dic)
+
+	req, err := http.NewRequest(http.MethodGet, ApiFederationPrefix+"/event/device/name/sensor", http.NoBody)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	http.HandlerFunc(controller.Query).ServeHTTP(recorder, req)
+
+	var res responseDTO.MultiEventsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	assert.Len(t, res.Events, 1)
+	assert.Equal(t, "gw-2", recorder.Header().Get(FederationUnavailableHeader))
+	assert.Equal(t, []string{"/api/v2/event/device/name/sensor"}, paths)
+
+	req, err = http.NewRequest(http.MethodGet, ApiFederationPrefix+"/event/count", http.NoBody)
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	http.HandlerFunc(controller.Query).ServeHTTP(recorder, req)
+	var count commonDTO.CountResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &count))
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+	tests := []struct {
+		name       string
+		dic        *di.Container
+		path       string
+		statusCode int
+	}{
+		{"Invalid - limit", dic, ApiFederationPrefix + "/event/device/name/sensor?limit=1000", http.StatusBadRequest},
+		{"Not found - route not federated", dic, ApiFederationPrefix + "/event/partitions", http.StatusNotFound},
+		{"Not found - federation disabled", mocks.NewMockDIC(), ApiFederationPrefix + "/event/device/name/sensor", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testCase.path, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(NewFederationController(testCase.dic).Query).ServeHTTP(recorder, req)
+			assert.Equal(t, testCase.statusCode, recorder.Result().StatusCode)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/ratelimit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// ApiIngestionMetricsRoute reports the counters of the event ingestion rate limits
+const ApiIngestionMetricsRoute = v2.ApiBase + "/ingestion/metrics"
+
+// IngestionMetricsResponse defines the response content of ApiIngestionMetricsRoute
+type IngestionMetricsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Metrics                ratelimit.Metrics `json:"metrics"`
+}
+
+func (ec *EventController) IngestionMetrics(w http.ResponseWriter, r *http.Request) {
+	// retrieve all the service injections from bootstrap
+	lc := container.LoggingClientFrom(ec.dic.Get)
+	limiter := dataContainer.IngestionLimiterFrom(ec.dic.Get)
+
+	response := IngestionMetricsResponse{
+		BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+		Metrics:      limiter.Metrics(),
+	}
+
+	utils.WriteHttpHeader(w, r.Context(), http.StatusOK)
+	pkg.Encode(response, w, lc) // encode and send out the response
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
@@ -15,10 +17,13 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
-	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
 	"github.com/gorilla/mux"
 )
 
+// ApiReadingByTagsRoute queries the readings of the events matching the tag expression of the tags.Expression query
+// parameter
+const ApiReadingByTagsRoute = v2.ApiBase + "/reading/tags"
+
 type ReadingController struct {
 	dic *di.Container
 }
@@ -41,7 +46,11 @@ func (rc *ReadingController) ReadingTotalCount(w http.ResponseWriter, r *http.Re
 	var statusCode int
 
 	// Count readings
-	count, err := application.ReadingTotalCount(rc.dic)
+	var count uint32
+	excludedQualities, err := parseExcludedQualities(r)
+	if err == nil {
+		count, err = application.ReadingTotalCount(excludedQualities, rc.dic)
+	}
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
@@ -66,14 +75,23 @@ func (rc *ReadingController) AllReadings(w http.ResponseWriter, r *http.Request)
 	var statusCode int
 
 	// parse URL query string for offset, and limit, and labels
-	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, maxResultCount(r, config.Service.MaxResultCount))
+	var excludedQualities []string
+	if err == nil {
+		excludedQualities, err = parseExcludedQualities(r)
+	}
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 		statusCode = err.Code()
+	} else if streamRequested(r) {
+		streamReadings(w, ctx, lc, offset, limit, func(offset int, limit int) ([]quality.Reading, errors.EdgeX) {
+			return application.AllReadings(offset, limit, excludedQualities, rc.dic)
+		})
+		return
 	} else {
-		readings, err := application.AllReadings(offset, limit, rc.dic)
+		readings, err := application.AllReadings(offset, limit, excludedQualities, rc.dic)
 		if err != nil {
 			if errors.Kind(err) != errors.KindEntityDoesNotExist {
 				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
@@ -82,7 +100,7 @@ func (rc *ReadingController) AllReadings(w http.ResponseWriter, r *http.Request)
 			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 			statusCode = err.Code()
 		} else {
-			response = responseDTO.NewMultiReadingsResponse("", "", http.StatusOK, readings)
+			response = quality.NewMultiReadingsResponse("", "", http.StatusOK, readings)
 			statusCode = http.StatusOK
 		}
 	}
@@ -101,14 +119,23 @@ func (rc *ReadingController) ReadingsByTimeRange(w http.ResponseWriter, r *http.
 	var statusCode int
 
 	// parse time range (start, end), offset, and limit from incoming request
-	start, end, offset, limit, err := utils.ParseTimeRangeOffsetLimit(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	start, end, offset, limit, err := utils.ParseTimeRangeOffsetLimit(r, 0, math.MaxInt32, -1, maxResultCount(r, config.Service.MaxResultCount))
+	var excludedQualities []string
+	if err == nil {
+		excludedQualities, err = parseExcludedQualities(r)
+	}
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 		statusCode = err.Code()
+	} else if streamRequested(r) {
+		streamReadings(w, ctx, lc, offset, limit, func(offset int, limit int) ([]quality.Reading, errors.EdgeX) {
+			return application.ReadingsByTimeRange(start, end, offset, limit, excludedQualities, rc.dic)
+		})
+		return
 	} else {
-		readings, err := application.ReadingsByTimeRange(start, end, offset, limit, rc.dic)
+		readings, err := application.ReadingsByTimeRange(start, end, offset, limit, excludedQualities, rc.dic)
 		if err != nil {
 			if errors.Kind(err) != errors.KindEntityDoesNotExist {
 				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
@@ -117,7 +144,7 @@ func (rc *ReadingController) ReadingsByTimeRange(w http.ResponseWriter, r *http.
 			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 			statusCode = err.Code()
 		} else {
-			response = responseDTO.NewMultiReadingsResponse("", "", http.StatusOK, readings)
+			response = quality.NewMultiReadingsResponse("", "", http.StatusOK, readings)
 			statusCode = http.StatusOK
 		}
 	}
@@ -139,14 +166,23 @@ func (rc *ReadingController) ReadingsByResourceName(w http.ResponseWriter, r *ht
 	var statusCode int
 
 	// parse URL query string for offset, limit
-	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, maxResultCount(r, config.Service.MaxResultCount))
+	var excludedQualities []string
+	if err == nil {
+		excludedQualities, err = parseExcludedQualities(r)
+	}
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 		statusCode = err.Code()
+	} else if streamRequested(r) {
+		streamReadings(w, ctx, lc, offset, limit, func(offset int, limit int) ([]quality.Reading, errors.EdgeX) {
+			return application.ReadingsByResourceName(offset, limit, resourceName, excludedQualities, rc.dic)
+		})
+		return
 	} else {
-		readings, err := application.ReadingsByResourceName(offset, limit, resourceName, rc.dic)
+		readings, err := application.ReadingsByResourceName(offset, limit, resourceName, excludedQualities, rc.dic)
 		if err != nil {
 			if errors.Kind(err) != errors.KindEntityDoesNotExist {
 				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
@@ -155,7 +191,7 @@ func (rc *ReadingController) ReadingsByResourceName(w http.ResponseWriter, r *ht
 			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 			statusCode = err.Code()
 		} else {
-			response = responseDTO.NewMultiReadingsResponse("", "", http.StatusOK, readings)
+			response = quality.NewMultiReadingsResponse("", "", http.StatusOK, readings)
 			statusCode = http.StatusOK
 		}
 	}
@@ -177,14 +213,63 @@ func (rc *ReadingController) ReadingsByDeviceName(w http.ResponseWriter, r *http
 	var statusCode int
 
 	// parse URL query string for offset, limit
-	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, maxResultCount(r, config.Service.MaxResultCount))
+	var excludedQualities []string
+	if err == nil {
+		excludedQualities, err = parseExcludedQualities(r)
+	}
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else if streamRequested(r) {
+		streamReadings(w, ctx, lc, offset, limit, func(offset int, limit int) ([]quality.Reading, errors.EdgeX) {
+			return application.ReadingsByDeviceName(offset, limit, name, excludedQualities, rc.dic)
+		})
+		return
+	} else {
+		readings, err := application.ReadingsByDeviceName(offset, limit, name, excludedQualities, rc.dic)
+		if err != nil {
+			if errors.Kind(err) != errors.KindEntityDoesNotExist {
+				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			}
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = quality.NewMultiReadingsResponse("", "", http.StatusOK, readings)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (rc *ReadingController) ReadingsByTags(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(rc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := dataContainer.ConfigurationFrom(rc.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	// parse URL query string for offset, limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, maxResultCount(r, config.Service.MaxResultCount))
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 		statusCode = err.Code()
+	} else if streamRequested(r) {
+		streamReadings(w, ctx, lc, offset, limit, func(offset int, limit int) ([]quality.Reading, errors.EdgeX) {
+			return application.ReadingsByTags(utils.ParseQueryStringToString(r, tags.Expression, ""), offset, limit, rc.dic)
+		})
+		return
 	} else {
-		readings, err := application.ReadingsByDeviceName(offset, limit, name, rc.dic)
+		readings, err := application.ReadingsByTags(utils.ParseQueryStringToString(r, tags.Expression, ""), offset, limit, rc.dic)
 		if err != nil {
 			if errors.Kind(err) != errors.KindEntityDoesNotExist {
 				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
@@ -193,7 +278,7 @@ func (rc *ReadingController) ReadingsByDeviceName(w http.ResponseWriter, r *http
 			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 			statusCode = err.Code()
 		} else {
-			response = responseDTO.NewMultiReadingsResponse("", "", http.StatusOK, readings)
+			response = quality.NewMultiReadingsResponse("", "", http.StatusOK, readings)
 			statusCode = http.StatusOK
 		}
 	}
@@ -217,7 +302,11 @@ func (rc *ReadingController) ReadingCountByDeviceName(w http.ResponseWriter, r *
 	var statusCode int
 
 	// Count the event by device
-	count, err := application.ReadingCountByDeviceName(deviceName, rc.dic)
+	var count uint32
+	excludedQualities, err := parseExcludedQualities(r)
+	if err == nil {
+		count, err = application.ReadingCountByDeviceName(deviceName, excludedQualities, rc.dic)
+	}
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
@@ -231,3 +320,12 @@ func (rc *ReadingController) ReadingCountByDeviceName(w http.ResponseWriter, r *
 	utils.WriteHttpHeader(w, ctx, statusCode)
 	pkg.Encode(countResponse, w, lc) // encode and send out the response
 }
+
+// parseExcludedQualities returns the qualities of the readings left out of the query of r
+func parseExcludedQualities(r *http.Request) ([]string, errors.EdgeX) {
+	excluded, err := quality.ParseExcluded(utils.ParseQueryStringToString(r, quality.ExcludeQuality, ""))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid excludeQuality query parameter", err)
+	}
+	return excluded, nil
+}
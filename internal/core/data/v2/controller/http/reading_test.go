@@ -9,6 +9,7 @@ import (
 	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
 	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces/mocks"
 	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
@@ -16,13 +17,14 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 func TestReadingTotalCount(t *testing.T) {
 	expectedReadingCount := uint32(656672)
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("ReadingTotalCount").Return(expectedReadingCount, nil)
+	dbClientMock.On("ReadingTotalCount", []string(nil)).Return(expectedReadingCount, nil)
 
 	dic := mocks.NewMockDIC()
 	dic.Update(di.ServiceConstructorMap{
@@ -52,8 +54,9 @@ func TestReadingTotalCount(t *testing.T) {
 func TestAllReadings(t *testing.T) {
 	dic := mocks.NewMockDIC()
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("AllReadings", 0, 20).Return([]models.Reading{}, nil)
-	dbClientMock.On("AllReadings", 0, 1).Return([]models.Reading{}, nil)
+	dbClientMock.On("AllReadings", 0, 20, []string(nil)).Return([]models.Reading{}, nil)
+	dbClientMock.On("AllReadings", 0, 1, []string(nil)).Return([]models.Reading{}, nil)
+	dbClientMock.On("ReadingAnnotations", mock.Anything).Return(map[string]quality.Annotation{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -114,10 +117,68 @@ func TestAllReadings(t *testing.T) {
 	}
 }
 
+func TestAllReadings_ExcludeQuality(t *testing.T) {
+	dic := mocks.NewMockDIC()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AllReadings", 0, 20, []string{quality.Bad, quality.Stale}).Return([]models.Reading{persistedReading}, nil)
+	dbClientMock.On("ReadingAnnotations", []string{ExampleUUID}).Return(map[string]quality.Annotation{
+		ExampleUUID: {Quality: quality.Uncertain},
+	}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewReadingController(dic)
+	assert.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		excludeQuality     string
+		errorExpected      bool
+		expectedStatusCode int
+	}{
+		{"Valid - exclude bad and stale readings", "bad,stale", false, http.StatusOK},
+		{"Invalid - exclude good readings", "good", true, http.StatusBadRequest},
+		{"Invalid - unknown quality", "excellent", true, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, v2.ApiAllReadingRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(quality.ExcludeQuality, testCase.excludeQuality)
+			req.URL.RawQuery = query.Encode()
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AllReadings)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res quality.MultiReadingsResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res.Readings, 1)
+				assert.Equal(t, quality.Uncertain, res.Readings[0].Quality)
+				assert.False(t, res.Readings[0].Null)
+			}
+		})
+	}
+}
+
 func TestReadingsByTimeRange(t *testing.T) {
 	dic := mocks.NewMockDIC()
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("ReadingsByTimeRange", 0, 100, 0, 10).Return([]models.Reading{}, nil)
+	dbClientMock.On("ReadingsByTimeRange", 0, 100, 0, 10, []string(nil)).Return([]models.Reading{}, nil)
+	dbClientMock.On("ReadingAnnotations", mock.Anything).Return(map[string]quality.Annotation{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -186,8 +247,9 @@ func TestReadingsByTimeRange(t *testing.T) {
 func TestReadingsByResourceName(t *testing.T) {
 	dic := mocks.NewMockDIC()
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("ReadingsByResourceName", 0, 20, TestDeviceResourceName).Return([]models.Reading{}, nil)
-	dbClientMock.On("ReadingsByResourceName", 0, 1, TestDeviceResourceName).Return([]models.Reading{}, nil)
+	dbClientMock.On("ReadingsByResourceName", 0, 20, TestDeviceResourceName, []string(nil)).Return([]models.Reading{}, nil)
+	dbClientMock.On("ReadingsByResourceName", 0, 1, TestDeviceResourceName, []string(nil)).Return([]models.Reading{}, nil)
+	dbClientMock.On("ReadingAnnotations", mock.Anything).Return(map[string]quality.Annotation{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -253,8 +315,9 @@ func TestReadingsByResourceName(t *testing.T) {
 func TestReadingsByDeviceName(t *testing.T) {
 	dic := mocks.NewMockDIC()
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("ReadingsByDeviceName", 0, 20, TestDeviceName).Return([]models.Reading{}, nil)
-	dbClientMock.On("ReadingsByDeviceName", 0, 1, TestDeviceName).Return([]models.Reading{}, nil)
+	dbClientMock.On("ReadingsByDeviceName", 0, 20, TestDeviceName, []string(nil)).Return([]models.Reading{}, nil)
+	dbClientMock.On("ReadingsByDeviceName", 0, 1, TestDeviceName, []string(nil)).Return([]models.Reading{}, nil)
+	dbClientMock.On("ReadingAnnotations", mock.Anything).Return(map[string]quality.Annotation{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -321,7 +384,7 @@ func TestReadingCountByDeviceName(t *testing.T) {
 	expectedReadingCount := uint32(656672)
 	deviceName := "deviceA"
 	dbClientMock := &dbMock.DBClient{}
-	dbClientMock.On("ReadingCountByDeviceName", deviceName).Return(expectedReadingCount, nil)
+	dbClientMock.On("ReadingCountByDeviceName", deviceName, []string(nil)).Return(expectedReadingCount, nil)
 
 	dic := mocks.NewMockDIC()
 	dic.Update(di.ServiceConstructorMap{
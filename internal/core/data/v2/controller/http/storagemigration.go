@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/dualwrite"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// ApiStorageMigrationRoute checks the consistency of the databases written while StorageMigration is enabled
+const ApiStorageMigrationRoute = v2.ApiBase + "/storage/migration"
+
+// StorageMigrationResponse defines the response content of ApiStorageMigrationRoute
+type StorageMigrationResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Report                 dualwrite.Report `json:"report"`
+}
+
+func (ec *EventController) StorageMigration(w http.ResponseWriter, r *http.Request) {
+	// retrieve all the service injections from bootstrap
+	lc := container.LoggingClientFrom(ec.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	report, err := application.StorageMigrationReport(ec.dic)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = StorageMigrationResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+			Report:       report,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc) // encode and send out the response
+}
@@ -0,0 +1,131 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// ContentTypeNDJSON is the media type of the streamed query results, a JSON object per line, which the clients
+// accept to have the events or readings written as they are read rather than all at once
+const ContentTypeNDJSON = "application/x-ndjson"
+
+// streamRequested tells whether the client of r accepts the streamed query results
+func streamRequested(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType := strings.TrimSpace(strings.SplitN(mediaRange, ";", 2)[0])
+			if strings.EqualFold(mediaType, ContentTypeNDJSON) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maxResultCount returns the greatest limit of a query, the streamed ones not being limited by maxResultCount as
+// their results are never all held in memory
+func maxResultCount(r *http.Request, maxResultCount int) int {
+	if streamRequested(r) {
+		return math.MaxInt32
+	}
+	return maxResultCount
+}
+
+// streamWriter writes the query results as newline delimited JSON, the response header being written with the first
+// results so that the query can still fail with an error response until then
+type streamWriter struct {
+	w       http.ResponseWriter
+	ctx     context.Context
+	encoder *json.Encoder
+	started bool
+}
+
+func newStreamWriter(w http.ResponseWriter, ctx context.Context) *streamWriter {
+	return &streamWriter{w: w, ctx: ctx, encoder: json.NewEncoder(w)}
+}
+
+func (s *streamWriter) start() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.w.Header().Set(clients.CorrelationHeader, correlation.FromContext(s.ctx))
+	s.w.Header().Set(clients.ContentType, ContentTypeNDJSON)
+	s.w.WriteHeader(http.StatusOK)
+}
+
+// write writes each of the values on its own line, then flushes them to the client
+func (s *streamWriter) write(count int, value func(i int) interface{}) error {
+	s.start()
+	for i := 0; i < count; i++ {
+		if err := s.encoder.Encode(value(i)); err != nil {
+			return err
+		}
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return s.ctx.Err()
+}
+
+// end writes the response of the stream once all the results were written, or of err. Since the status code was
+// sent with the first results, a failure after them aborts the response so that the client doesn't take the
+// truncated results for complete ones.
+func (s *streamWriter) end(err errors.EdgeX, lc logger.LoggingClient) {
+	correlationId := correlation.FromContext(s.ctx)
+	if err == nil {
+		s.start()
+		return
+	}
+	if s.started {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		panic(http.ErrAbortHandler)
+	}
+	if errors.Kind(err) != errors.KindEntityDoesNotExist {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+	}
+	lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+	utils.WriteHttpHeader(s.w, s.ctx, err.Code())
+	pkg.Encode(commonDTO.NewBaseResponse("", err.Message(), err.Code()), s.w, lc)
+}
+
+// streamEvents writes the events of query from offset, up to limit of them, as they are read
+func streamEvents(w http.ResponseWriter, ctx context.Context, lc logger.LoggingClient, offset int, limit int,
+	query application.EventQuery) {
+	s := newStreamWriter(w, ctx)
+	err := application.StreamEvents(offset, limit, query, func(events []dtos.Event) error {
+		return s.write(len(events), func(i int) interface{} { return events[i] })
+	})
+	s.end(err, lc)
+}
+
+// streamReadings writes the readings of query from offset, up to limit of them, as they are read
+func streamReadings(w http.ResponseWriter, ctx context.Context, lc logger.LoggingClient, offset int, limit int,
+	query application.ReadingQuery) {
+	s := newStreamWriter(w, ctx)
+	err := application.StreamReadings(offset, limit, query, func(readings []quality.Reading) error {
+		return s.write(len(readings), func(i int) interface{} { return readings[i] })
+	})
+	s.end(err, lc)
+}
@@ -0,0 +1,186 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func repeatedEvents(count int) []models.Event {
+	events := make([]models.Event, count)
+	for i := range events {
+		events[i] = persistedEvent
+	}
+	return events
+}
+
+func streamRequest(t *testing.T, route string, offset string, limit string) *http.Request {
+	req, err := http.NewRequest(http.MethodGet, route, http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("Accept", ContentTypeNDJSON)
+	query := req.URL.Query()
+	query.Add(v2.Offset, offset)
+	query.Add(v2.Limit, limit)
+	req.URL.RawQuery = query.Encode()
+	return req
+}
+
+// streamLines returns the lines of the streamed response
+func streamLines(t *testing.T, recorder *httptest.ResponseRecorder) []string {
+	var lines []string
+	scanner := bufio.NewScanner(recorder.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
+
+func TestStreamRequested(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   []string
+		expected bool
+	}{
+		{"no Accept header", nil, false},
+		{"JSON", []string{clients.ContentTypeJSON}, false},
+		{"NDJSON", []string{ContentTypeNDJSON}, true},
+		{"NDJSON with parameters among other media ranges", []string{"application/json;q=0.5, Application/X-NDJSON;q=1"}, true},
+		{"NDJSON in another header value", []string{clients.ContentTypeJSON, ContentTypeNDJSON}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, v2.ApiAllEventRoute, http.NoBody)
+			for _, accept := range tt.accept {
+				req.Header.Add("Accept", accept)
+			}
+			assert.Equal(t, tt.expected, streamRequested(req))
+		})
+	}
+}
+
+func TestAllEventsStreamed(t *testing.T) {
+	dic := mocks.NewMockDIC()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AllEvents", 0, 100).Return(repeatedEvents(100), nil)
+	dbClientMock.On("AllEvents", 100, 100).Return(repeatedEvents(3), nil)
+	dbClientMock.On("AllEvents", 100, 50).Return(repeatedEvents(3), nil)
+	dbClientMock.On("AllEvents", 10, 5).Return(repeatedEvents(5), nil)
+	dbClientMock.On("AllEvents", 200, 100).Return([]models.Event{}, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, "query objects bounds out of range", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	ec := NewEventController(dic)
+
+	tests := []struct {
+		name          string
+		offset        string
+		limit         string
+		expectedCount int
+	}{
+		{"all events", "0", "-1", 103},
+		{"limit above MaxResultCount", "0", "150", 103},
+		{"single batch", "10", "5", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(ec.AllEvents).ServeHTTP(recorder, streamRequest(t, v2.ApiAllEventRoute, tt.offset, tt.limit))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, ContentTypeNDJSON, recorder.Header().Get(clients.ContentType))
+			lines := streamLines(t, recorder)
+			require.Len(t, lines, tt.expectedCount)
+			var event dtos.Event
+			require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+			assert.Equal(t, expectedEventId, event.Id)
+		})
+	}
+
+	t.Run("offset out of range", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		http.HandlerFunc(ec.AllEvents).ServeHTTP(recorder, streamRequest(t, v2.ApiAllEventRoute, "200", "-1"))
+
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, recorder.Code, "The failure of the first batch should be responded as usual")
+		var res common.BaseResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		assert.NotEmpty(t, res.Message)
+	})
+}
+
+func TestAllEventsStreamedFailure(t *testing.T) {
+	dic := mocks.NewMockDIC()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AllEvents", 0, 100).Return(repeatedEvents(100), nil)
+	dbClientMock.On("AllEvents", 100, 100).Return([]models.Event{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "connection lost", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	ec := NewEventController(dic)
+
+	recorder := httptest.NewRecorder()
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		http.HandlerFunc(ec.AllEvents).ServeHTTP(recorder, streamRequest(t, v2.ApiAllEventRoute, "0", "-1"))
+	}, "A failure after the first events should abort the response")
+	assert.Len(t, streamLines(t, recorder), 100)
+}
+
+func TestReadingsByDeviceNameStreamed(t *testing.T) {
+	dic := mocks.NewMockDIC()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("ReadingsByDeviceName", 0, 100, TestDeviceName, []string(nil)).Return([]models.Reading{persistedReading, persistedReading}, nil)
+	dbClientMock.On("ReadingAnnotations", mock.Anything).Return(map[string]quality.Annotation{
+		persistedReading.Id: {Quality: quality.Uncertain},
+	}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	rc := NewReadingController(dic)
+
+	req := streamRequest(t, v2.ApiReadingByDeviceNameRoute, "0", "-1")
+	req = mux.SetURLVars(req, map[string]string{v2.Name: TestDeviceName})
+	recorder := httptest.NewRecorder()
+	http.HandlerFunc(rc.ReadingsByDeviceName).ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	lines := streamLines(t, recorder)
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.True(t, strings.HasPrefix(line, "{"))
+		var reading quality.Reading
+		require.NoError(t, json.Unmarshal([]byte(line), &reading))
+		assert.Equal(t, quality.Uncertain, reading.Quality)
+	}
+}
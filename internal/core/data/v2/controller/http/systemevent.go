@@ -0,0 +1,116 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// MultiSystemEventsResponse defines the response content of audit.ApiSystemEventByTimeRangeRoute
+type MultiSystemEventsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	SystemEvents           []audit.SystemEvent `json:"systemEvents"`
+}
+
+type SystemEventController struct {
+	dic *di.Container
+}
+
+// NewSystemEventController creates and initializes a SystemEventController
+func NewSystemEventController(dic *di.Container) *SystemEventController {
+	return &SystemEventController{
+		dic: dic,
+	}
+}
+
+func (sc *SystemEventController) AddSystemEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	var events []audit.SystemEvent
+	var edgexErr errors.EdgeX
+	if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+		edgexErr = errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+	} else {
+		edgexErr = application.AddSystemEvents(events, sc.dic)
+	}
+
+	if edgexErr != nil {
+		lc.Error(edgexErr.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(edgexErr.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", edgexErr.Message(), edgexErr.Code())
+		statusCode = edgexErr.Code()
+	} else {
+		response = commonDTO.NewBaseResponse("", "", http.StatusCreated)
+		statusCode = http.StatusCreated
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (sc *SystemEventController) SystemEventsByTimeRange(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := dataContainer.ConfigurationFrom(sc.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	// parse time range (start, end), offset, limit, and actor from incoming request
+	start, end, offset, limit, err := utils.ParseTimeRangeOffsetLimit(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		actor := utils.ParseQueryStringToString(r, audit.Actor, "")
+		events, err := application.SystemEventsByTimeRange(start, end, actor, offset, limit, sc.dic)
+		if err != nil {
+			if errors.Kind(err) != errors.KindEntityDoesNotExist {
+				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			}
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = MultiSystemEventsResponse{
+				BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+				SystemEvents: events,
+			}
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
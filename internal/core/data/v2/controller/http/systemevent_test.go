@@ -0,0 +1,148 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSystemEvent = audit.SystemEvent{
+	Timestamp:  TestCreatedTime,
+	Service:    "edgex-core-metadata",
+	Actor:      "admin",
+	Action:     audit.ActionDelete,
+	ObjectType: "device",
+	ObjectName: TestDeviceName,
+	Method:     http.MethodDelete,
+	Path:       v2.ApiDeviceRoute + "/name/" + TestDeviceName,
+	StatusCode: http.StatusOK,
+}
+
+func TestAddSystemEvents(t *testing.T) {
+	dic := mocks.NewMockDIC()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddSystemEvents", []audit.SystemEvent{testSystemEvent}).Return([]audit.SystemEvent{testSystemEvent}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	sc := NewSystemEventController(dic)
+
+	noActor := testSystemEvent
+	noActor.Actor = ""
+	valid, err := json.Marshal([]audit.SystemEvent{testSystemEvent})
+	require.NoError(t, err)
+	invalid, err := json.Marshal([]audit.SystemEvent{noActor})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		body               string
+		expectedStatusCode int
+	}{
+		{"Valid", string(valid), http.StatusCreated},
+		{"Invalid - no actor", string(invalid), http.StatusBadRequest},
+		{"Invalid - bad JSON", "{", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, audit.ApiSystemEventRoute, strings.NewReader(testCase.body))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(sc.AddSystemEvents)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			var res common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "AddSystemEvents", 1)
+}
+
+func TestSystemEventsByTimeRange(t *testing.T) {
+	dic := mocks.NewMockDIC()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("SystemEventsByTimeRange", 0, 100, "", 0, 10).Return([]audit.SystemEvent{testSystemEvent}, nil)
+	dbClientMock.On("SystemEventsByTimeRange", 0, 100, "admin", 0, 10).Return([]audit.SystemEvent{testSystemEvent}, nil)
+	dbClientMock.On("SystemEventsByTimeRange", 0, 100, "nobody", 0, 10).Return([]audit.SystemEvent{}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	sc := NewSystemEventController(dic)
+
+	tests := []struct {
+		name               string
+		start              string
+		end                string
+		actor              string
+		errorExpected      bool
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - all actors", "0", "100", "", false, 1, http.StatusOK},
+		{"Valid - by actor", "0", "100", "admin", false, 1, http.StatusOK},
+		{"Valid - unknown actor", "0", "100", "nobody", false, 0, http.StatusOK},
+		{"Invalid - end before start", "10", "0", "", true, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, audit.ApiSystemEventByTimeRangeRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(v2.Offset, "0")
+			query.Add(v2.Limit, "10")
+			if testCase.actor != "" {
+				query.Add(audit.Actor, testCase.actor)
+			}
+			req.URL.RawQuery = query.Encode()
+			req = mux.SetURLVars(req, map[string]string{v2.Start: testCase.start, v2.End: testCase.end})
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(sc.SystemEventsByTimeRange)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res MultiSystemEventsResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedCount, len(res.SystemEvents), "System event count not as expected")
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			}
+		})
+	}
+}
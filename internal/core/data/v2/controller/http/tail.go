@@ -0,0 +1,100 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+const (
+	// ApiEventTailRoute returns the newest events, or the events added after the cursor of the Since query parameter,
+	// returned by the previous tail request
+	ApiEventTailRoute = v2.ApiBase + "/event/tail"
+	// Wait is the query parameter of the longest a tail request waits for an event to be added, e.g. "2s", at most
+	// the configured MaxWait, which is the default
+	Wait = "wait"
+)
+
+// TailEventsResponse defines the response content of ApiEventTailRoute
+type TailEventsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	// Cursor follows the last of the Events, to be sent as the Since query parameter of the next request
+	Cursor string `json:"cursor"`
+	// Missed is the number of the events added after the cursor of the request but no longer kept
+	Missed uint64       `json:"missed,omitempty"`
+	Events []dtos.Event `json:"events"`
+}
+
+func (ec *EventController) TailEvents(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := dataContainer.ConfigurationFrom(ec.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	limit, err := utils.ParseQueryStringToInt(r, v2.Limit, v2.DefaultLimit, 1, config.Service.MaxResultCount)
+	var wait time.Duration
+	if err == nil {
+		wait, err = parseWait(r)
+	}
+	var events []dtos.Event
+	var cursor string
+	var missed uint64
+	if err == nil {
+		events, cursor, missed, err = application.TailEvents(ctx, r.URL.Query().Get(Since), limit, wait, ec.dic)
+	}
+
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = TailEventsResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+			Cursor:       cursor,
+			Missed:       missed,
+			Events:       events,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc) // encode and send out the response
+}
+
+// parseWait returns the duration of the Wait query parameter, the longest possible when it is absent
+func parseWait(r *http.Request) (time.Duration, errors.EdgeX) {
+	value := r.URL.Query().Get(Wait)
+	if value == "" {
+		return time.Duration(math.MaxInt64), nil
+	}
+	wait, err := time.ParseDuration(value)
+	if err != nil || wait < 0 {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid wait "+value, err)
+	}
+	return wait, nil
+}
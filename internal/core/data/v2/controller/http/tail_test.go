@@ -0,0 +1,76 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v2DataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/tail"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailEvents(t *testing.T) {
+	buffer, err := tail.NewBuffer(tail.TailInfo{Enabled: true, BufferSize: 10, MaxWait: "100ms"}, time.Second)
+	require.NoError(t, err)
+	buffer.Add(models.Event{Id: ExampleUUID, DeviceName: TestDeviceName, ProfileName: TestDeviceProfileName})
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		v2DataContainer.TailBufferName: func(get di.Get) interface{} {
+			return buffer
+		},
+	})
+	ec := NewEventController(dic)
+
+	get := func(controller *EventController, query string) (int, TailEventsResponse) {
+		req, err := http.NewRequest(http.MethodGet, ApiEventTailRoute+query, http.NoBody)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		http.HandlerFunc(controller.TailEvents).ServeHTTP(recorder, req)
+		var response TailEventsResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, recorder.Code, response.StatusCode)
+		return recorder.Code, response
+	}
+
+	status, newest := get(ec, "")
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, newest.Events, 1)
+	assert.Equal(t, ExampleUUID, newest.Events[0].Id)
+
+	status, next := get(ec, "?wait=10ms&since="+newest.Cursor)
+	require.Equal(t, http.StatusOK, status)
+	assert.Empty(t, next.Events)
+	assert.Equal(t, newest.Cursor, next.Cursor)
+
+	tests := []struct {
+		name               string
+		controller         *EventController
+		query              string
+		expectedStatusCode int
+	}{
+		{"Invalid - cursor", ec, "?since=42", http.StatusBadRequest},
+		{"Invalid - wait", ec, "?wait=-1s&since=" + newest.Cursor, http.StatusBadRequest},
+		{"Invalid - limit", ec, "?limit=0", http.StatusBadRequest},
+		{"Not found - tail disabled", NewEventController(mocks.NewMockDIC()), "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, _ := get(tt.controller, tt.query)
+			assert.Equal(t, tt.expectedStatusCode, status)
+		})
+	}
+}
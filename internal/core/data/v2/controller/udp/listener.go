@@ -0,0 +1,271 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package udp provides the optional UDP listener through which constrained devices send compact events to core-data
+// directly, without going through a device service. The datagrams may be secured with DTLS.
+package udp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/ratelimit"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/storageguard"
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	requestDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+
+	"github.com/google/uuid"
+	"github.com/pion/dtls/v2"
+)
+
+const (
+	defaultMaxPacketSize = 1024
+	// handshakeTimeout bounds the DTLS handshake of a new peer
+	handshakeTimeout = 30 * time.Second
+	// idleTimeout closes the DTLS connections of the peers that stopped sending
+	idleTimeout = 5 * time.Minute
+
+	// Keys of the secret holding the DTLS certificates
+	CertKey = "cert"
+	KeyKey  = "key"
+	CAKey   = "ca"
+)
+
+// IngestFunc ingests the payload of one datagram
+type IngestFunc func(ctx context.Context, data []byte) errors.EdgeX
+
+// Listener receives compact events over UDP and ingests them the same way as the events added through the REST API.
+type Listener struct {
+	lc     logger.LoggingClient
+	info   config.UDPIngestionInfo
+	ingest IngestFunc
+}
+
+// NewListener is a factory function that returns a Listener ingesting the events with the services in dic.
+func NewListener(dic *di.Container, info config.UDPIngestionInfo) *Listener {
+	return newListener(container.LoggingClientFrom(dic.Get), info, NewIngestFunc(dic))
+}
+
+func newListener(lc logger.LoggingClient, info config.UDPIngestionInfo, ingest IngestFunc) *Listener {
+	if info.MaxPacketSize <= 0 {
+		info.MaxPacketSize = defaultMaxPacketSize
+	}
+	return &Listener{
+		lc:     lc,
+		info:   info,
+		ingest: ingest,
+	}
+}
+
+// NewIngestFunc returns the IngestFunc decoding a compact event and then applying the ingestion rate limits, the
+// enrichment pipeline and the clock skew limits before persisting and publishing the event. The events are rejected
+// while the storage guard rejects the new events.
+func NewIngestFunc(dic *di.Container) IngestFunc {
+	return func(ctx context.Context, data []byte) errors.EdgeX {
+		lc := container.LoggingClientFrom(dic.Get)
+		configuration := dataContainer.ConfigurationFrom(dic.Get)
+		limiter := dataContainer.IngestionLimiterFrom(dic.Get)
+
+		if dataContainer.StorageGuardFrom(dic.Get).Rejecting() {
+			return errors.NewCommonEdgeX(errors.KindServiceUnavailable, storageguard.RejectMessage, nil)
+		}
+
+		addEventReq, err := decodeEvent(data, time.Now())
+		if err != nil {
+			return err
+		}
+
+		deviceName := addEventReq.Event.DeviceName
+		profileName := addEventReq.Event.ProfileName
+		decision := limiter.Allow(configuration.GetIngestionLimits(), deviceName)
+		if !decision.Allowed {
+			message := ratelimit.Log(lc, decision, deviceName, correlation.FromContext(ctx))
+			return errors.NewCommonEdgeX(errors.KindServiceUnavailable, message, nil)
+		}
+
+		keep, err := application.EnrichEvent(&addEventReq.Event, ctx, dic)
+		if err != nil {
+			return err
+		}
+		if !keep {
+			return nil
+		}
+		if err = application.CheckClockSkew(&addEventReq.Event, ctx, dic); err != nil {
+			return err
+		}
+		if err = application.SignEvent(&addEventReq.Event, dic); err != nil {
+			return err
+		}
+		event := requestDTO.AddEventReqToEventModel(addEventReq)
+		if err = application.AddEvent(event, nil, profileName, deviceName, ctx, dic); err != nil {
+			return err
+		}
+		application.PublishEvent(addEventReq, profileName, deviceName, ctx, dic)
+		return nil
+	}
+}
+
+// Start binds the listener and serves the datagrams until ctx is done. Certificates are read from secretProvider
+// when DTLS is enabled.
+func (l *Listener) Start(ctx context.Context, wg *sync.WaitGroup, secretProvider SecretProvider) error {
+	address := fmt.Sprintf("%s:%d", l.info.Host, l.info.Port)
+	if !l.info.DTLS.Enabled {
+		conn, err := net.ListenPacket("udp", address)
+		if err != nil {
+			return err
+		}
+		l.lc.Info(fmt.Sprintf("Listening for UDP events on %s", conn.LocalAddr().String()))
+		l.servePackets(ctx, wg, conn)
+		return nil
+	}
+
+	config, err := l.dtlsConfig(secretProvider)
+	if err != nil {
+		return err
+	}
+	udpAddress, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return err
+	}
+	listener, err := dtls.Listen("udp", udpAddress, config)
+	if err != nil {
+		return err
+	}
+	l.lc.Info(fmt.Sprintf("Listening for DTLS events on %s", listener.Addr().String()))
+	l.serveConnections(ctx, wg, listener)
+	return nil
+}
+
+// SecretProvider reads the secrets from the service's secret store, e.g. a bootstrap interfaces.SecretProvider
+type SecretProvider interface {
+	GetSecrets(path string, keys ...string) (map[string]string, error)
+}
+
+func (l *Listener) dtlsConfig(secretProvider SecretProvider) (*dtls.Config, error) {
+	secrets, err := secretProvider.GetSecrets(l.info.DTLS.SecretPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the DTLS certificates from secret %s: %s", l.info.DTLS.SecretPath, err.Error())
+	}
+	certificate, err := tls.X509KeyPair([]byte(secrets[CertKey]), []byte(secrets[KeyKey]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTLS certificate in secret %s: %s", l.info.DTLS.SecretPath, err.Error())
+	}
+
+	config := &dtls.Config{
+		Certificates:         []tls.Certificate{certificate},
+		ExtendedMasterSecret: dtls.RequireExtendedMasterSecret,
+		ConnectContextMaker: func() (context.Context, func()) {
+			return context.WithTimeout(context.Background(), handshakeTimeout)
+		},
+	}
+	if ca := secrets[CAKey]; ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("invalid DTLS CA certificate in secret %s", l.info.DTLS.SecretPath)
+		}
+		config.ClientAuth = dtls.RequireAndVerifyClientCert
+		config.ClientCAs = pool
+	}
+	return config, nil
+}
+
+// servePackets ingests the plain datagrams received on conn, one at a time.
+func (l *Listener) servePackets(ctx context.Context, wg *sync.WaitGroup, conn net.PacketConn) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		buffer := make([]byte, l.info.MaxPacketSize)
+		for {
+			n, peer, err := conn.ReadFrom(buffer)
+			if err != nil {
+				if ctx.Err() == nil {
+					l.lc.Error(fmt.Sprintf("UDP listener stopped: %s", err.Error()))
+				}
+				return
+			}
+			l.handle(ctx, buffer[:n], peer)
+		}
+	}()
+}
+
+// serveConnections ingests the datagrams of each DTLS peer accepted by listener.
+func (l *Listener) serveConnections(ctx context.Context, wg *sync.WaitGroup, listener net.Listener) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				// a failed handshake only concerns that peer
+				l.lc.Warn(fmt.Sprintf("DTLS connection rejected: %s", err.Error()))
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.serveConnection(ctx, conn)
+			}()
+		}
+	}()
+}
+
+func (l *Listener) serveConnection(ctx context.Context, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	buffer := make([]byte, l.info.MaxPacketSize)
+	for ctx.Err() == nil {
+		if err := conn.SetReadDeadline(time.Now().Add(idleTimeout)); err != nil {
+			return
+		}
+		n, err := conn.Read(buffer)
+		if err != nil {
+			l.lc.Debug(fmt.Sprintf("DTLS connection from %s closed: %s", conn.RemoteAddr().String(), err.Error()))
+			return
+		}
+		l.handle(ctx, buffer[:n], conn.RemoteAddr())
+	}
+}
+
+// handle ingests one datagram. There is no response to send back, so failures are only logged.
+func (l *Listener) handle(ctx context.Context, data []byte, peer net.Addr) {
+	correlationId := uuid.New().String()
+	ctx = context.WithValue(ctx, clients.CorrelationHeader, correlationId)
+	if err := l.ingest(ctx, data); err != nil {
+		l.lc.Error(fmt.Sprintf("failed to ingest UDP event from %s: %s", peer.String(), err.Error()),
+			clients.CorrelationHeader, correlationId)
+		l.lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+	}
+}
@@ -0,0 +1,128 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package udp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	edgexErrors "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPayload = `{"d":"sensor01","p":"TempSensor","o":1617000000000000000,"r":[{"n":"temperature","t":"Float32","v":"21.5"}]}`
+
+func TestDecodeEvent(t *testing.T) {
+	received := time.Unix(1618000000, 0)
+
+	tests := []struct {
+		name           string
+		payload        string
+		errorExpected  bool
+		expectedOrigin int64
+	}{
+		{"Valid", testPayload, false, 1617000000000000000},
+		{"Valid - origin defaults to the reception time", `{"d":"sensor01","p":"TempSensor","r":[{"n":"temperature","t":"Float32","v":"21.5"}]}`, false, received.UnixNano()},
+		{"Invalid - not json", `temperature=21.5`, true, 0},
+		{"Invalid - no device name", `{"p":"TempSensor","r":[{"n":"temperature","t":"Float32","v":"21.5"}]}`, true, 0},
+		{"Invalid - no readings", `{"d":"sensor01","p":"TempSensor","r":[]}`, true, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request, err := decodeEvent([]byte(testCase.payload), received)
+			if testCase.errorExpected {
+				require.Error(t, err)
+				assert.Equal(t, edgexErrors.KindContractInvalid, edgexErrors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			event := request.Event
+			assert.NotEmpty(t, event.Id)
+			assert.Equal(t, "sensor01", event.DeviceName)
+			assert.Equal(t, "TempSensor", event.ProfileName)
+			assert.Equal(t, testCase.expectedOrigin, event.Origin)
+			require.Len(t, event.Readings, 1)
+			assert.Equal(t, "temperature", event.Readings[0].ResourceName)
+			assert.Equal(t, "Float32", event.Readings[0].ValueType)
+			assert.Equal(t, "21.5", event.Readings[0].Value)
+			assert.Equal(t, "sensor01", event.Readings[0].DeviceName)
+			assert.Equal(t, testCase.expectedOrigin, event.Readings[0].Origin)
+		})
+	}
+}
+
+func TestListenerPlainUDP(t *testing.T) {
+	received := make(chan string, 1)
+	ingest := func(ctx context.Context, data []byte) edgexErrors.EdgeX {
+		received <- string(data)
+		return nil
+	}
+	port := freeUDPPort(t)
+	listener := newListener(logger.NewMockClient(), config.UDPIngestionInfo{Host: "127.0.0.1", Port: port}, ingest)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	require.NoError(t, listener.Start(ctx, wg, nil))
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	conn, err := net.Dial("udp", (&net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: port}).String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	_, err = conn.Write([]byte(testPayload))
+	require.NoError(t, err)
+
+	select {
+	case data := <-received:
+		assert.Equal(t, testPayload, data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the datagram wasn't ingested")
+	}
+}
+
+func freeUDPPort(t *testing.T) int {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+type stubSecretProvider struct {
+	secrets map[string]string
+	err     error
+}
+
+func (p stubSecretProvider) GetSecrets(_ string, _ ...string) (map[string]string, error) {
+	return p.secrets, p.err
+}
+
+func TestDTLSConfigErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider stubSecretProvider
+	}{
+		{"secret store unavailable", stubSecretProvider{err: errors.New("secret store unavailable")}},
+		{"no certificate", stubSecretProvider{secrets: map[string]string{}}},
+		{"invalid certificate", stubSecretProvider{secrets: map[string]string{CertKey: "cert", KeyKey: "key"}}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			listener := newListener(logger.NewMockClient(), config.UDPIngestionInfo{DTLS: config.DTLSInfo{Enabled: true, SecretPath: "udp"}}, nil)
+			_, err := listener.dtlsConfig(testCase.provider)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package udp
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	dto "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+
+	"github.com/google/uuid"
+)
+
+// compactEvent is the event payload sent by constrained devices, using single letter keys to fit in one datagram:
+//
+//	{"d":"sensor01","p":"TempSensor","o":1617000000000000000,"r":[{"n":"temperature","t":"Float32","v":"21.5"}]}
+type compactEvent struct {
+	// DeviceName of the device sending the event
+	DeviceName string `json:"d"`
+	// ProfileName of the device sending the event
+	ProfileName string `json:"p"`
+	// Origin is when the readings were taken, in nanoseconds since the epoch. Defaults to when the event is received.
+	Origin   int64            `json:"o"`
+	Readings []compactReading `json:"r"`
+}
+
+type compactReading struct {
+	ResourceName string `json:"n"`
+	ValueType    string `json:"t"`
+	Value        string `json:"v"`
+}
+
+// decodeEvent converts a compact event payload into the request of the equivalent REST call, using received as the
+// origin of the event when it has none.
+func decodeEvent(data []byte, received time.Time) (dto.AddEventRequest, errors.EdgeX) {
+	var compact compactEvent
+	if err := json.Unmarshal(data, &compact); err != nil {
+		return dto.AddEventRequest{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "compact event json decoding failed", err)
+	}
+
+	origin := compact.Origin
+	if origin == 0 {
+		origin = received.UnixNano()
+	}
+	event := dtos.Event{
+		Versionable: common.NewVersionable(),
+		Id:          uuid.New().String(),
+		DeviceName:  compact.DeviceName,
+		ProfileName: compact.ProfileName,
+		Origin:      origin,
+		Readings:    make([]dtos.BaseReading, len(compact.Readings)),
+	}
+	for i, r := range compact.Readings {
+		event.Readings[i] = dtos.BaseReading{
+			Versionable:  common.NewVersionable(),
+			DeviceName:   compact.DeviceName,
+			ResourceName: r.ResourceName,
+			ProfileName:  compact.ProfileName,
+			Origin:       origin,
+			ValueType:    r.ValueType,
+			SimpleReading: dtos.SimpleReading{
+				Value: r.Value,
+			},
+		}
+	}
+
+	request := dto.AddEventRequest{
+		BaseRequest: common.BaseRequest{
+			RequestId:   uuid.New().String(),
+			Versionable: common.NewVersionable(),
+		},
+		Event: event,
+	}
+	if err := request.Validate(); err != nil {
+		return dto.AddEventRequest{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid compact event", err)
+	}
+	return request, nil
+}
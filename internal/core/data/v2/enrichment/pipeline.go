@@ -0,0 +1,131 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package enrichment provides the per device profile pipelines that transform incoming events before they are
+// persisted and published.
+package enrichment
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// StepInfo configures one step of a pipeline.
+type StepInfo struct {
+	// Type is the name the step was registered with, e.g. "AddTags".
+	Type string
+	// Parameters are passed to the step factory; their meaning depends on the Type.
+	Parameters map[string]string
+}
+
+// PipelineInfo configures the steps applied, in order, to the events of a device profile.
+type PipelineInfo struct {
+	Steps []StepInfo
+}
+
+// EnrichmentInfo configures the event enrichment pipelines.
+type EnrichmentInfo struct {
+	// Enabled turns on the pipelines. Events are ingested unchanged when disabled.
+	Enabled bool
+	// Profiles holds the pipeline of each device profile, keyed by profile name.
+	Profiles map[string]PipelineInfo
+}
+
+// Step transforms an event in place. Removing all of its readings drops the event.
+type Step interface {
+	Enrich(event *dtos.Event) error
+}
+
+// StepFunc adapts a function to the Step interface.
+type StepFunc func(event *dtos.Event) error
+
+// Enrich calls f(event).
+func (f StepFunc) Enrich(event *dtos.Event) error {
+	return f(event)
+}
+
+// StepFactory creates a Step from its configured parameters, returning an error if they are invalid.
+type StepFactory func(parameters map[string]string) (Step, error)
+
+var (
+	factoriesMutex sync.RWMutex
+	factories      = make(map[string]StepFactory)
+)
+
+// RegisterStep makes a step type available to the pipeline configuration. Step types other than the built-in
+// ones, such as steps evaluating a script, are compiled in by registering their factory from an init function.
+func RegisterStep(stepType string, factory StepFactory) {
+	factoriesMutex.Lock()
+	defer factoriesMutex.Unlock()
+	factories[stepType] = factory
+}
+
+// StepTypes returns the names of the registered step types.
+func StepTypes() []string {
+	factoriesMutex.RLock()
+	defer factoriesMutex.RUnlock()
+	types := make([]string, 0, len(factories))
+	for stepType := range factories {
+		types = append(types, stepType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func newStep(info StepInfo) (Step, error) {
+	factoriesMutex.RLock()
+	factory, ok := factories[info.Type]
+	factoriesMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown step type '%s', expected one of %v", info.Type, StepTypes())
+	}
+	return factory(info.Parameters)
+}
+
+// Pipelines applies the pipeline configured for the profile of each event.
+type Pipelines struct {
+	byProfile map[string][]Step
+}
+
+// NewPipelines creates the pipelines described by info, or returns nil when enrichment is disabled.
+func NewPipelines(info EnrichmentInfo) (*Pipelines, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+
+	pipelines := &Pipelines{byProfile: make(map[string][]Step, len(info.Profiles))}
+	for profileName, pipeline := range info.Profiles {
+		steps := make([]Step, len(pipeline.Steps))
+		for i, stepInfo := range pipeline.Steps {
+			step, err := newStep(stepInfo)
+			if err != nil {
+				return nil, fmt.Errorf("step %d of profile %s: %s", i+1, profileName, err.Error())
+			}
+			steps[i] = step
+		}
+		pipelines.byProfile[profileName] = steps
+	}
+	return pipelines, nil
+}
+
+// Apply runs the pipeline of the event's profile over event. It returns false when the event is to be dropped
+// because the pipeline removed all of its readings. A nil Pipelines keeps every event unchanged.
+func (p *Pipelines) Apply(event *dtos.Event) (bool, error) {
+	if p == nil {
+		return true, nil
+	}
+	for _, step := range p.byProfile[event.ProfileName] {
+		if err := step.Enrich(event); err != nil {
+			return false, err
+		}
+		if len(event.Readings) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
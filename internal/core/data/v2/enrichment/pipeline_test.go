@@ -0,0 +1,147 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package enrichment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfileName = "testProfile"
+
+func buildTestEvent() dtos.Event {
+	return dtos.Event{
+		DeviceName:  "testDevice",
+		ProfileName: testProfileName,
+		Readings: []dtos.BaseReading{
+			{ResourceName: "Temperature"},
+			{ResourceName: "Humidity"},
+			{ResourceName: "Debug"},
+		},
+	}
+}
+
+func resourceNames(event dtos.Event) []string {
+	names := make([]string, len(event.Readings))
+	for i, reading := range event.Readings {
+		names[i] = reading.ResourceName
+	}
+	return names
+}
+
+func TestPipelines(t *testing.T) {
+	pipelines, err := NewPipelines(EnrichmentInfo{
+		Enabled: true,
+		Profiles: map[string]PipelineInfo{
+			testProfileName: {Steps: []StepInfo{
+				{Type: AddTags, Parameters: map[string]string{"site": "plant-1"}},
+				{Type: RenameResources, Parameters: map[string]string{"Temperature": "Temp"}},
+				{Type: DropReadings, Parameters: map[string]string{ResourceNamesParameter: "Debug, Unknown"}},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	event := buildTestEvent()
+	keep, err := pipelines.Apply(&event)
+
+	require.NoError(t, err)
+	assert.True(t, keep)
+	assert.Equal(t, map[string]string{"site": "plant-1"}, event.Tags)
+	assert.Equal(t, []string{"Temp", "Humidity"}, resourceNames(event))
+}
+
+func TestPipelinesOtherProfileUnchanged(t *testing.T) {
+	pipelines, err := NewPipelines(EnrichmentInfo{
+		Enabled: true,
+		Profiles: map[string]PipelineInfo{
+			"otherProfile": {Steps: []StepInfo{{Type: DropReadings, Parameters: map[string]string{ResourceNamesParameter: "Debug"}}}},
+		},
+	})
+	require.NoError(t, err)
+
+	event := buildTestEvent()
+	keep, err := pipelines.Apply(&event)
+
+	require.NoError(t, err)
+	assert.True(t, keep)
+	assert.Equal(t, buildTestEvent(), event)
+}
+
+func TestPipelinesDropEvent(t *testing.T) {
+	pipelines, err := NewPipelines(EnrichmentInfo{
+		Enabled: true,
+		Profiles: map[string]PipelineInfo{
+			testProfileName: {Steps: []StepInfo{
+				{Type: DropReadings, Parameters: map[string]string{ResourceNamesParameter: "Temperature,Humidity,Debug"}},
+				{Type: AddTags, Parameters: map[string]string{"site": "plant-1"}},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	event := buildTestEvent()
+	keep, err := pipelines.Apply(&event)
+
+	require.NoError(t, err)
+	assert.False(t, keep)
+	assert.Nil(t, event.Tags, "steps after the event was dropped should not run")
+}
+
+func TestPipelinesStepError(t *testing.T) {
+	RegisterStep("testFailing", func(parameters map[string]string) (Step, error) {
+		return StepFunc(func(event *dtos.Event) error { return errors.New("failed") }), nil
+	})
+	pipelines, err := NewPipelines(EnrichmentInfo{
+		Enabled:  true,
+		Profiles: map[string]PipelineInfo{testProfileName: {Steps: []StepInfo{{Type: "testFailing"}}}},
+	})
+	require.NoError(t, err)
+
+	event := buildTestEvent()
+	_, err = pipelines.Apply(&event)
+
+	assert.Error(t, err)
+}
+
+func TestNewPipelinesInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		step StepInfo
+	}{
+		{"unknown type", StepInfo{Type: "Unknown"}},
+		{"no tags", StepInfo{Type: AddTags}},
+		{"empty new resource name", StepInfo{Type: RenameResources, Parameters: map[string]string{"Temperature": " "}}},
+		{"no resources to drop", StepInfo{Type: DropReadings, Parameters: map[string]string{ResourceNamesParameter: " , "}}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewPipelines(EnrichmentInfo{
+				Enabled:  true,
+				Profiles: map[string]PipelineInfo{testProfileName: {Steps: []StepInfo{testCase.step}}},
+			})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPipelinesDisabled(t *testing.T) {
+	pipelines, err := NewPipelines(EnrichmentInfo{
+		Profiles: map[string]PipelineInfo{testProfileName: {Steps: []StepInfo{{Type: "Unknown"}}}},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, pipelines)
+
+	event := buildTestEvent()
+	keep, err := pipelines.Apply(&event)
+	require.NoError(t, err)
+	assert.True(t, keep)
+}
@@ -0,0 +1,87 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package enrichment
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// Built-in step types
+const (
+	// AddTags sets each parameter as a tag of the event, overwriting a tag of the same name.
+	AddTags = "AddTags"
+	// RenameResources renames the readings of each resource named by a parameter key to the parameter value.
+	RenameResources = "RenameResources"
+	// DropReadings removes the readings of the resources listed in the comma separated ResourceNames parameter.
+	DropReadings = "DropReadings"
+
+	ResourceNamesParameter = "ResourceNames"
+)
+
+func init() {
+	RegisterStep(AddTags, newAddTagsStep)
+	RegisterStep(RenameResources, newRenameResourcesStep)
+	RegisterStep(DropReadings, newDropReadingsStep)
+}
+
+func newAddTagsStep(parameters map[string]string) (Step, error) {
+	if len(parameters) == 0 {
+		return nil, errors.New("at least one tag must be specified")
+	}
+	return StepFunc(func(event *dtos.Event) error {
+		if event.Tags == nil {
+			event.Tags = make(map[string]string, len(parameters))
+		}
+		for name, value := range parameters {
+			event.Tags[name] = value
+		}
+		return nil
+	}), nil
+}
+
+func newRenameResourcesStep(parameters map[string]string) (Step, error) {
+	if len(parameters) == 0 {
+		return nil, errors.New("at least one resource must be renamed")
+	}
+	for from, to := range parameters {
+		if strings.TrimSpace(to) == "" {
+			return nil, errors.New("new name of resource " + from + " is empty")
+		}
+	}
+	return StepFunc(func(event *dtos.Event) error {
+		for i := range event.Readings {
+			if to, ok := parameters[event.Readings[i].ResourceName]; ok {
+				event.Readings[i].ResourceName = to
+			}
+		}
+		return nil
+	}), nil
+}
+
+func newDropReadingsStep(parameters map[string]string) (Step, error) {
+	drop := make(map[string]bool)
+	for _, name := range strings.Split(parameters[ResourceNamesParameter], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			drop[name] = true
+		}
+	}
+	if len(drop) == 0 {
+		return nil, errors.New("the " + ResourceNamesParameter + " parameter must list at least one resource")
+	}
+	return StepFunc(func(event *dtos.Event) error {
+		readings := event.Readings[:0]
+		for _, reading := range event.Readings {
+			if !drop[reading.ResourceName] {
+				readings = append(readings, reading)
+			}
+		}
+		event.Readings = readings
+		return nil
+	}), nil
+}
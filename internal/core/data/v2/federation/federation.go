@@ -0,0 +1,434 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package federation proxies the read queries of core-data to the core-data of the peer gateways of a site, merging
+// their results with the local ones, so that a supervisor gateway answers the queries on any device of the site
+// whichever gateway owns it.
+package federation
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	edgexErrors "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+)
+
+// Keys of the secret at PeerInfo.SecretPath
+const (
+	// TokenKey is the bearer token, e.g. a JWT issued by the API gateway of the peer, the queries are authorized with
+	TokenKey = "token"
+	// CAKey is the PEM-encoded CA certificate the certificate of the peer must be signed by. The system roots are used
+	// when not set.
+	CAKey = "ca"
+	// CertKey and KeyKey are the PEM-encoded X.509 certificate and private key authenticating to the peer, if any
+	CertKey = "cert"
+	KeyKey  = "key"
+)
+
+// LocalGateway names this gateway among the unavailable gateways of a query
+const LocalGateway = "local"
+
+const (
+	defaultTimeout = 10 * time.Second
+	// maxResponseSize bounds the response of a peer read in memory
+	maxResponseSize = 64 << 20
+)
+
+// PeerInfo configures a peer gateway the queries are proxied to
+type PeerInfo struct {
+	// Name identifies the peer in the logs and in the unavailable gateways of a query
+	Name string
+	// URL is the base URL of the core-data of the peer, e.g. "https://gateway-2:59880", or of the route to it through
+	// the API gateway of the peer
+	URL string
+	// SecretPath is the secret holding the credentials of the peer, with the keys TokenKey, CAKey, CertKey and KeyKey.
+	// The queries are sent without credentials when empty.
+	SecretPath string
+}
+
+// FederationInfo configures the proxying of the read queries to the peer gateways
+type FederationInfo struct {
+	// Enabled serves the federated queries under /api/v2/federation
+	Enabled bool
+	// Peers are the gateways the queries are proxied to along with this one
+	Peers []PeerInfo
+	// Timeout bounds the query of each peer, e.g. "10s". The results of the peers answering late are left out.
+	Timeout string
+}
+
+// Validate checks the configuration is usable when enabled
+func (f FederationInfo) Validate() error {
+	if !f.Enabled {
+		return nil
+	}
+	names := make(map[string]bool, len(f.Peers))
+	for _, peer := range f.Peers {
+		if peer.Name == "" || peer.Name == LocalGateway || strings.Contains(peer.Name, ",") {
+			return fmt.Errorf("peer name '%s' must be set, other than '%s' and without ','", peer.Name, LocalGateway)
+		}
+		if names[peer.Name] {
+			return fmt.Errorf("peer %s is configured more than once", peer.Name)
+		}
+		names[peer.Name] = true
+		u, err := url.Parse(peer.URL)
+		if err != nil {
+			return fmt.Errorf("invalid URL of peer %s: %s", peer.Name, err.Error())
+		}
+		if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("URL '%s' of peer %s must be an absolute http or https URL", peer.URL, peer.Name)
+		}
+		if peer.SecretPath != "" && u.Scheme != "https" {
+			return fmt.Errorf("the credentials of peer %s can only be sent over https", peer.Name)
+		}
+	}
+	_, err := f.TimeoutDuration()
+	return err
+}
+
+// TimeoutDuration returns the Timeout, 10 seconds when empty
+func (f FederationInfo) TimeoutDuration() (time.Duration, error) {
+	if f.Timeout == "" {
+		return defaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(f.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Timeout '%s': %s", f.Timeout, err.Error())
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("Timeout '%s' must be positive", f.Timeout)
+	}
+	return timeout, nil
+}
+
+// SecretProvider reads the secrets from the service's secret store, e.g. a bootstrap interfaces.SecretProvider
+type SecretProvider interface {
+	GetSecrets(path string, keys ...string) (map[string]string, error)
+}
+
+// Kind is the kind of results of a federated query, which tells how they are merged
+type Kind int
+
+const (
+	// Events are merged most recently created first, as returned by the event queries
+	Events Kind = iota
+	// Readings are merged most recently created first, as returned by the reading queries
+	Readings
+	// Count is summed
+	Count
+)
+
+// Result holds the merged results of a federated query
+type Result struct {
+	Events   []dtos.Event
+	Readings []quality.Reading
+	Count    uint32
+	// Unavailable lists the gateways whose results are missing, since they failed or didn't answer in time
+	Unavailable []string
+}
+
+type peer struct {
+	name   string
+	url    string
+	token  string
+	client *http.Client
+}
+
+// Proxy sends the federated queries to this gateway and to its peers
+type Proxy struct {
+	local http.Handler
+	peers []peer
+	lc    logger.LoggingClient
+}
+
+// NewProxy creates the proxy of the queries to the peers configured by info, the queries to this gateway being served
+// by local, or returns nil when federation is disabled. The credentials of the peers are read from secretProvider.
+func NewProxy(info FederationInfo, secretProvider SecretProvider, local http.Handler, lc logger.LoggingClient) (*Proxy, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	// validated above
+	timeout, _ := info.TimeoutDuration()
+
+	p := &Proxy{local: local, lc: lc}
+	for _, peerInfo := range info.Peers {
+		var secrets map[string]string
+		if peerInfo.SecretPath != "" {
+			var err error
+			if secrets, err = secretProvider.GetSecrets(peerInfo.SecretPath); err != nil {
+				return nil, fmt.Errorf("failed to read the credentials of peer %s from secret %s: %s", peerInfo.Name, peerInfo.SecretPath, err.Error())
+			}
+		}
+		config, err := tlsConfig(peerInfo, secrets)
+		if err != nil {
+			return nil, err
+		}
+		p.peers = append(p.peers, peer{
+			name:  peerInfo.Name,
+			url:   strings.TrimSuffix(peerInfo.URL, "/"),
+			token: secrets[TokenKey],
+			client: &http.Client{
+				Timeout:   timeout,
+				Transport: &http.Transport{TLSClientConfig: config},
+				// a redirect could send the token elsewhere
+				CheckRedirect: func(*http.Request, []*http.Request) error {
+					return http.ErrUseLastResponse
+				},
+			},
+		})
+	}
+	return p, nil
+}
+
+func tlsConfig(info PeerInfo, secrets map[string]string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if secrets[CertKey] != "" {
+		certificate, err := tls.X509KeyPair([]byte(secrets[CertKey]), []byte(secrets[KeyKey]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid X.509 credentials in secret %s: %s", info.SecretPath, err.Error())
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	if ca := secrets[CAKey]; ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("invalid CA certificate in secret %s", info.SecretPath)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// Peers returns the names of the peers
+func (p *Proxy) Peers() []string {
+	names := make([]string, len(p.peers))
+	for i, peer := range p.peers {
+		names[i] = peer.name
+	}
+	return names
+}
+
+// answer is the response of a gateway to a query, or the error sending it
+type answer struct {
+	gateway    string
+	statusCode int
+	body       []byte
+	err        error
+}
+
+// Query sends the query r, a GET of a core-data route of the given kind, to this gateway and to the peers, and
+// returns their merged results. The events and readings are paged by offset and limit after they are merged, so each
+// gateway is queried for the first offset+limit of them. The query fails only when this gateway rejects it as
+// invalid, or when no gateway answers it.
+func (p *Proxy) Query(r *http.Request, kind Kind, offset int, limit int) (Result, edgexErrors.EdgeX) {
+	query := r.URL.Query()
+	if kind != Count {
+		query.Set(v2.Offset, "0")
+		if limit < 0 {
+			query.Set(v2.Limit, "-1")
+		} else {
+			query.Set(v2.Limit, strconv.Itoa(offset+limit))
+		}
+	}
+
+	answers := make([]answer, len(p.peers)+1)
+	var wg sync.WaitGroup
+	for i := range p.peers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			answers[i+1] = p.peers[i].query(r, query)
+		}(i)
+	}
+	answers[0] = p.queryLocal(r, query)
+	wg.Wait()
+
+	// the query is invalid when rejected by this gateway, which serves the same routes as the peers
+	if local := answers[0]; local.err == nil && local.statusCode >= 400 && local.statusCode < 500 &&
+		local.statusCode != http.StatusNotFound {
+		return Result{}, edgexErrors.NewCommonEdgeX(edgexErrors.KindMapping(local.statusCode), local.message(), nil)
+	}
+
+	result := Result{Events: []dtos.Event{}, Readings: []quality.Reading{}}
+	answered := false
+	for _, a := range answers {
+		if err := result.add(kind, a); err != nil {
+			p.lc.Warn(fmt.Sprintf("Results of gateway %s left out of the federated query %s: %s", a.gateway, r.URL.Path, err.Error()),
+				clients.CorrelationHeader, correlation.FromContext(r.Context()))
+			result.Unavailable = append(result.Unavailable, a.gateway)
+			continue
+		}
+		answered = true
+	}
+	if !answered {
+		return Result{}, edgexErrors.NewCommonEdgeX(edgexErrors.KindServiceUnavailable, "no gateway answered the federated query", nil)
+	}
+	result.page(offset, limit)
+	return result, nil
+}
+
+// message returns the message of the error response of the answer, if any
+func (a answer) message() string {
+	var response common.BaseResponse
+	if err := json.Unmarshal(a.body, &response); err != nil {
+		return ""
+	}
+	message, _ := response.Message.(string)
+	return message
+}
+
+// queryLocal serves the query by this gateway
+func (p *Proxy) queryLocal(r *http.Request, query url.Values) answer {
+	local := r.Clone(r.Context())
+	local.URL.RawQuery = query.Encode()
+	local.RequestURI = local.URL.RequestURI()
+	local.Header.Set(clients.ContentType, clients.ContentTypeJSON)
+	local.Header.Set("Accept", clients.ContentTypeJSON)
+	response := &bufferedResponse{header: make(http.Header), statusCode: http.StatusOK}
+	p.local.ServeHTTP(response, local)
+	return answer{gateway: LocalGateway, statusCode: response.statusCode, body: response.body.Bytes()}
+}
+
+// query sends the query to the peer
+func (p peer) query(r *http.Request, query url.Values) answer {
+	a := answer{gateway: p.name}
+	request, err := http.NewRequestWithContext(r.Context(), http.MethodGet, p.url+r.URL.EscapedPath()+"?"+query.Encode(), nil)
+	if err != nil {
+		a.err = err
+		return a
+	}
+	request.Header.Set("Accept", clients.ContentTypeJSON)
+	request.Header.Set(clients.CorrelationHeader, correlation.FromContext(r.Context()))
+	if p.token != "" {
+		request.Header.Set("Authorization", "Bearer "+p.token)
+	}
+	response, err := p.client.Do(request)
+	if err != nil {
+		a.err = err
+		return a
+	}
+	defer response.Body.Close()
+	a.statusCode = response.StatusCode
+	a.body, a.err = ioutil.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+	return a
+}
+
+// add merges the results of the answer, a gateway not finding any result adding none
+func (result *Result) add(kind Kind, a answer) error {
+	if a.err != nil {
+		return a.err
+	}
+	if a.statusCode == http.StatusNotFound {
+		return nil
+	}
+	if a.statusCode != http.StatusOK {
+		if message := a.message(); message != "" {
+			return fmt.Errorf("status %d: %s", a.statusCode, message)
+		}
+		return fmt.Errorf("status %d", a.statusCode)
+	}
+
+	switch kind {
+	case Events:
+		var response responses.MultiEventsResponse
+		if err := json.Unmarshal(a.body, &response); err != nil {
+			return err
+		}
+		result.Events = append(result.Events, response.Events...)
+	case Readings:
+		var response quality.MultiReadingsResponse
+		if err := json.Unmarshal(a.body, &response); err != nil {
+			return err
+		}
+		result.Readings = append(result.Readings, response.Readings...)
+	case Count:
+		var response common.CountResponse
+		if err := json.Unmarshal(a.body, &response); err != nil {
+			return err
+		}
+		result.Count += response.Count
+	default:
+		return errors.New("unknown kind of results")
+	}
+	return nil
+}
+
+// page orders the merged events and readings most recently created first, and keeps those of the page
+func (result *Result) page(offset int, limit int) {
+	sort.SliceStable(result.Events, func(i, j int) bool {
+		return newer(result.Events[i].Created, result.Events[i].Origin, result.Events[j].Created, result.Events[j].Origin)
+	})
+	sort.SliceStable(result.Readings, func(i, j int) bool {
+		return newer(result.Readings[i].Created, result.Readings[i].Origin, result.Readings[j].Created, result.Readings[j].Origin)
+	})
+	start, end := pageBounds(len(result.Events), offset, limit)
+	result.Events = result.Events[start:end]
+	start, end = pageBounds(len(result.Readings), offset, limit)
+	result.Readings = result.Readings[start:end]
+}
+
+func newer(created1 int64, origin1 int64, created2 int64, origin2 int64) bool {
+	if created1 != created2 {
+		return created1 > created2
+	}
+	return origin1 > origin2
+}
+
+// pageBounds returns the bounds of the page of offset and limit among length results, the page holding all the
+// results after offset when limit is negative
+func pageBounds(length int, offset int, limit int) (int, int) {
+	start := offset
+	if start > length {
+		start = length
+	}
+	end := length
+	if limit >= 0 && start+limit < end {
+		end = start + limit
+	}
+	return start, end
+}
+
+// bufferedResponse holds the response of this gateway to a query
+type bufferedResponse struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	return b.body.Write(data)
+}
+
+func (b *bufferedResponse) WriteHeader(statusCode int) {
+	b.statusCode = statusCode
+}
@@ -0,0 +1,214 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package federation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	edgexErrors "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRoute = v2.ApiBase + "/event/device/name/sensor"
+
+type secretProvider map[string]map[string]string
+
+func (s secretProvider) GetSecrets(path string, _ ...string) (map[string]string, error) {
+	secrets, ok := s[path]
+	if !ok {
+		return nil, errors.New("no secret")
+	}
+	return secrets, nil
+}
+
+// eventsHandler answers the event queries with events of the given created timestamps, and records the queries
+func eventsHandler(created []int64, queries *[]*http.Request) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r)
+		events := make([]dtos.Event, len(created))
+		for i, c := range created {
+			events[i] = dtos.Event{Id: r.Host, Created: c}
+		}
+		_ = json.NewEncoder(w).Encode(responses.NewMultiEventsResponse("", "", http.StatusOK, events))
+	}
+}
+
+func statusHandler(statusCode int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(common.NewBaseResponse("", "failed", statusCode))
+	}
+}
+
+func newTestProxy(t *testing.T, local http.Handler, peers ...*httptest.Server) *Proxy {
+	info := FederationInfo{Enabled: true}
+	for i, peer := range peers {
+		info.Peers = append(info.Peers, PeerInfo{Name: string(rune('a' + i)), URL: peer.URL})
+	}
+	proxy, err := NewProxy(info, secretProvider{}, local, logger.NewMockClient())
+	require.NoError(t, err)
+	return proxy
+}
+
+func TestFederationInfoValidate(t *testing.T) {
+	assert.NoError(t, FederationInfo{Peers: []PeerInfo{{}}}.Validate(), "disabled federation should not be validated")
+
+	tests := []struct {
+		name  string
+		info  FederationInfo
+		valid bool
+	}{
+		{"Valid", FederationInfo{Enabled: true, Peers: []PeerInfo{{Name: "gw-2", URL: "https://gw-2:59880", SecretPath: "gw-2"}}}, true},
+		{"Valid - no peer", FederationInfo{Enabled: true}, true},
+		{"Invalid - no name", FederationInfo{Enabled: true, Peers: []PeerInfo{{URL: "https://gw-2:59880"}}}, false},
+		{"Invalid - local name", FederationInfo{Enabled: true, Peers: []PeerInfo{{Name: LocalGateway, URL: "https://gw-2:59880"}}}, false},
+		{"Invalid - same name", FederationInfo{Enabled: true, Peers: []PeerInfo{{Name: "gw-2", URL: "https://gw-2:59880"}, {Name: "gw-2", URL: "https://gw-3:59880"}}}, false},
+		{"Invalid - relative URL", FederationInfo{Enabled: true, Peers: []PeerInfo{{Name: "gw-2", URL: "gw-2:59880"}}}, false},
+		{"Invalid - credentials over http", FederationInfo{Enabled: true, Peers: []PeerInfo{{Name: "gw-2", URL: "http://gw-2:59880", SecretPath: "gw-2"}}}, false},
+		{"Invalid - timeout", FederationInfo{Enabled: true, Timeout: "-1s"}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.info.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNewProxySecrets(t *testing.T) {
+	info := FederationInfo{Enabled: true, Peers: []PeerInfo{{Name: "gw-2", URL: "https://gw-2:59880", SecretPath: "gw-2"}}}
+
+	proxy, err := NewProxy(info, secretProvider{"gw-2": {TokenKey: "jwt"}}, http.NotFoundHandler(), logger.NewMockClient())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gw-2"}, proxy.Peers())
+	assert.Equal(t, "jwt", proxy.peers[0].token)
+
+	_, err = NewProxy(info, secretProvider{}, http.NotFoundHandler(), logger.NewMockClient())
+	assert.Error(t, err, "the missing secret should fail")
+	_, err = NewProxy(info, secretProvider{"gw-2": {CAKey: "not PEM"}}, http.NotFoundHandler(), logger.NewMockClient())
+	assert.Error(t, err, "the invalid CA should fail")
+
+	proxy, err = NewProxy(FederationInfo{}, secretProvider{}, http.NotFoundHandler(), logger.NewMockClient())
+	require.NoError(t, err)
+	assert.Nil(t, proxy, "federation should be disabled")
+}
+
+func TestQueryEvents(t *testing.T) {
+	var localQueries, peerQueries []*http.Request
+	peer := httptest.NewServer(eventsHandler([]int64{50, 30, 10}, &peerQueries))
+	defer peer.Close()
+	proxy := newTestProxy(t, eventsHandler([]int64{40, 20}, &localQueries), peer)
+
+	request := httptest.NewRequest(http.MethodGet, testRoute+"?offset=1&limit=3", nil)
+	result, err := proxy.Query(request, Events, 1, 3)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Unavailable)
+	created := make([]int64, len(result.Events))
+	for i, e := range result.Events {
+		created[i] = e.Created
+	}
+	assert.Equal(t, []int64{40, 30, 20}, created, "the events should be merged and paged")
+	require.Len(t, peerQueries, 1)
+	assert.Equal(t, testRoute, peerQueries[0].URL.Path)
+	assert.Equal(t, "0", peerQueries[0].URL.Query().Get(v2.Offset))
+	assert.Equal(t, "4", peerQueries[0].URL.Query().Get(v2.Limit))
+	require.Len(t, localQueries, 1)
+	assert.Equal(t, "4", localQueries[0].URL.Query().Get(v2.Limit))
+}
+
+func TestQueryToken(t *testing.T) {
+	var queries []*http.Request
+	peer := httptest.NewTLSServer(eventsHandler([]int64{10}, &queries))
+	defer peer.Close()
+	proxy := newTestProxy(t, statusHandler(http.StatusNotFound), peer)
+	proxy.peers[0].token = "jwt"
+	proxy.peers[0].client = peer.Client()
+
+	result, err := proxy.Query(httptest.NewRequest(http.MethodGet, testRoute, nil), Events, 0, 20)
+
+	require.NoError(t, err)
+	assert.Len(t, result.Events, 1)
+	require.Len(t, queries, 1)
+	assert.Equal(t, "Bearer jwt", queries[0].Header.Get("Authorization"))
+}
+
+func TestQueryReadingsAndCount(t *testing.T) {
+	readings := func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(quality.NewMultiReadingsResponse("", "", http.StatusOK, []quality.Reading{
+			{BaseReading: dtos.BaseReading{Created: 20}}, {BaseReading: dtos.BaseReading{Created: 10}},
+		}))
+	}
+	count := func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(common.NewCountResponse("", "", http.StatusOK, 7))
+	}
+	peer := httptest.NewServer(http.HandlerFunc(readings))
+	defer peer.Close()
+	proxy := newTestProxy(t, http.HandlerFunc(readings), peer)
+
+	result, err := proxy.Query(httptest.NewRequest(http.MethodGet, testRoute, nil), Readings, 0, -1)
+	require.NoError(t, err)
+	assert.Len(t, result.Readings, 4)
+	assert.Equal(t, int64(20), result.Readings[1].Created)
+
+	countPeer := httptest.NewServer(http.HandlerFunc(count))
+	defer countPeer.Close()
+	proxy = newTestProxy(t, http.HandlerFunc(count), countPeer)
+	result, err = proxy.Query(httptest.NewRequest(http.MethodGet, testRoute, nil), Count, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(14), result.Count)
+}
+
+func TestQueryUnavailable(t *testing.T) {
+	var queries []*http.Request
+	failing := httptest.NewServer(statusHandler(http.StatusInternalServerError))
+	defer failing.Close()
+	notFound := httptest.NewServer(statusHandler(http.StatusNotFound))
+	defer notFound.Close()
+	stopped := httptest.NewServer(http.NotFoundHandler())
+	stopped.Close()
+	proxy := newTestProxy(t, eventsHandler([]int64{10}, &queries), failing, notFound, stopped)
+
+	result, err := proxy.Query(httptest.NewRequest(http.MethodGet, testRoute, nil), Events, 0, 20)
+
+	require.NoError(t, err)
+	assert.Len(t, result.Events, 1)
+	assert.Equal(t, []string{"a", "c"}, result.Unavailable, "the peer finding no event should not be unavailable")
+}
+
+func TestQueryErrors(t *testing.T) {
+	var queries []*http.Request
+	peer := httptest.NewServer(eventsHandler([]int64{10}, &queries))
+	defer peer.Close()
+
+	proxy := newTestProxy(t, statusHandler(http.StatusBadRequest), peer)
+	_, err := proxy.Query(httptest.NewRequest(http.MethodGet, testRoute, nil), Events, 0, 20)
+	require.Error(t, err)
+	assert.Equal(t, edgexErrors.KindContractInvalid, edgexErrors.Kind(err), "the query rejected locally should be invalid")
+
+	failing := httptest.NewServer(statusHandler(http.StatusInternalServerError))
+	defer failing.Close()
+	proxy = newTestProxy(t, statusHandler(http.StatusInternalServerError), failing)
+	_, err = proxy.Query(httptest.NewRequest(http.MethodGet, testRoute, nil), Events, 0, 20)
+	require.Error(t, err)
+	assert.Equal(t, edgexErrors.KindServiceUnavailable, edgexErrors.Kind(err))
+}
@@ -0,0 +1,117 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dualwrite
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	model "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// Report is the outcome of a consistency check between the leading and the following database
+type Report struct {
+	// Cutover tells whether the secondary database was the leading one
+	Cutover             bool   `json:"cutover"`
+	LeadingEventCount   uint32 `json:"leadingEventCount"`
+	FollowingEventCount uint32 `json:"followingEventCount"`
+	// SampledEvents is the number of most recent events of the leading database looked up in the following one
+	SampledEvents int `json:"sampledEvents"`
+	// MissingEvents are the ids of the sampled events which the following database doesn't hold
+	MissingEvents []string `json:"missingEvents,omitempty"`
+	// MismatchedEvents are the ids of the sampled events which the following database holds with other values
+	MismatchedEvents []string `json:"mismatchedEvents,omitempty"`
+	// FailedWrites is the number of writes which failed on the following database since the service started
+	FailedWrites uint64 `json:"failedWrites"`
+	// Consistent is true when both databases hold the same number of events, and all the sampled events match
+	Consistent bool `json:"consistent"`
+}
+
+// Check compares the event counts of both databases, and looks up the sampleSize most recent events of the leading
+// database in the following one
+func (c *Client) Check(sampleSize int) (Report, errors.EdgeX) {
+	report := Report{Cutover: c.cutover()}
+	leading, following := c.primary, c.secondary
+	if report.Cutover {
+		leading, following = c.secondary, c.primary
+	}
+
+	var err errors.EdgeX
+	if report.LeadingEventCount, err = leading.EventTotalCount(); err != nil {
+		return report, errors.NewCommonEdgeX(errors.Kind(err), "failed to count the events of the leading database", err)
+	}
+	if report.FollowingEventCount, err = following.EventTotalCount(); err != nil {
+		return report, errors.NewCommonEdgeX(errors.Kind(err), "failed to count the events of the following database", err)
+	}
+
+	if sampleSize > 0 {
+		sample, err := leading.AllEvents(0, sampleSize)
+		if err != nil {
+			return report, errors.NewCommonEdgeX(errors.Kind(err), "failed to sample the events of the leading database", err)
+		}
+		report.SampledEvents = len(sample)
+		for _, event := range sample {
+			followed, err := following.EventById(event.Id)
+			switch {
+			case errors.Kind(err) == errors.KindEntityDoesNotExist:
+				report.MissingEvents = append(report.MissingEvents, event.Id)
+			case err != nil:
+				return report, errors.NewCommonEdgeX(errors.Kind(err),
+					fmt.Sprintf("failed to look up event %s in the following database", event.Id), err)
+			case !sameEvent(event, followed):
+				report.MismatchedEvents = append(report.MismatchedEvents, event.Id)
+			}
+		}
+	}
+
+	report.FailedWrites = c.FailedWrites()
+	report.Consistent = report.LeadingEventCount == report.FollowingEventCount &&
+		len(report.MissingEvents) == 0 && len(report.MismatchedEvents) == 0
+	return report, nil
+}
+
+// LogCheck checks the consistency of both databases, sampling sampleSize events, and logs the report
+func (c *Client) LogCheck(sampleSize int) {
+	report, err := c.Check(sampleSize)
+	switch {
+	case err != nil:
+		c.lc.Error(fmt.Sprintf("storage consistency check failed: %s", err.Error()))
+	case report.Consistent:
+		c.lc.Info(fmt.Sprintf("storage consistency check passed: %d events, %d sampled, %d failed writes",
+			report.LeadingEventCount, report.SampledEvents, report.FailedWrites))
+	default:
+		c.lc.Warn(fmt.Sprintf("storage consistency check found differences: %d events in the leading database "+
+			"and %d in the following one, %d of %d sampled events missing and %d mismatched, %d failed writes",
+			report.LeadingEventCount, report.FollowingEventCount, len(report.MissingEvents), report.SampledEvents,
+			len(report.MismatchedEvents), report.FailedWrites))
+	}
+}
+
+// sameEvent compares the stored values of two events, regardless of the order of their readings
+func sameEvent(a model.Event, b model.Event) bool {
+	if a.Id != b.Id || a.DeviceName != b.DeviceName || a.ProfileName != b.ProfileName || a.Created != b.Created ||
+		a.Origin != b.Origin || len(a.Readings) != len(b.Readings) {
+		return false
+	}
+	aIds := readingIds(a.Readings)
+	bIds := readingIds(b.Readings)
+	for i := range aIds {
+		if aIds[i] != bIds[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func readingIds(readings []model.Reading) []string {
+	ids := make([]string, 0, len(readings))
+	for _, reading := range readings {
+		ids = append(ids, reading.GetBaseReading().Id)
+	}
+	sort.Strings(ids)
+	return ids
+}
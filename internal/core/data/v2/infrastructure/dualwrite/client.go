@@ -0,0 +1,341 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dualwrite implements the core-data database client used while migrating to another database. Writes go
+// to both the primary and the secondary database, reads are served by the primary until the cutover switch makes
+// the secondary the source of truth.
+package dualwrite
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/partitions"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	model "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// Client is an interfaces.DBClient writing to two databases. The leading database serves the reads and is written
+// first; the write is then replayed on the following database with the values assigned by the leading one, such as
+// ids and timestamps. A failed write to the following database is logged and counted rather than failing the
+// request, the consistency check reporting the differences.
+type Client struct {
+	lc        logger.LoggingClient
+	primary   interfaces.DBClient
+	secondary interfaces.DBClient
+	cutover   func() bool
+	// failedWrites counts the writes which failed on the following database
+	failedWrites uint64
+	// cutoverApplied is 1 once the last request was served after the cutover, used to log the switch once
+	cutoverApplied uint32
+}
+
+// NewClient creates a Client migrating from primary to secondary. cutover is called on each request and returns
+// whether the secondary database has become the leading one.
+func NewClient(lc logger.LoggingClient, primary interfaces.DBClient, secondary interfaces.DBClient, cutover func() bool) *Client {
+	return &Client{
+		lc:        lc,
+		primary:   primary,
+		secondary: secondary,
+		cutover:   cutover,
+	}
+}
+
+// FailedWrites returns the number of writes which failed on the following database
+func (c *Client) FailedWrites() uint64 {
+	return atomic.LoadUint64(&c.failedWrites)
+}
+
+// leading returns the database serving reads and written first, and the one following it
+func (c *Client) leading() (leading interfaces.DBClient, following interfaces.DBClient) {
+	var applied uint32
+	if c.cutover() {
+		applied = 1
+	}
+	if atomic.SwapUint32(&c.cutoverApplied, applied) != applied {
+		if applied == 1 {
+			c.lc.Info("storage cutover applied, the secondary database now serves reads and is written first")
+		} else {
+			c.lc.Info("storage cutover reverted, the primary database now serves reads and is written first")
+		}
+	}
+
+	if applied == 1 {
+		return c.secondary, c.primary
+	}
+	return c.primary, c.secondary
+}
+
+// follow logs and counts the error of a write replayed on the following database
+func (c *Client) follow(operation string, err errors.EdgeX) {
+	if err == nil {
+		return
+	}
+	atomic.AddUint64(&c.failedWrites, 1)
+	c.lc.Warn(fmt.Sprintf("dual write: %s failed on the following database: %s", operation, err.Error()))
+}
+
+// CloseSession closes the sessions of both databases
+func (c *Client) CloseSession() {
+	c.primary.CloseSession()
+	c.secondary.CloseSession()
+}
+
+func (c *Client) AddEvent(e model.Event) (model.Event, errors.EdgeX) {
+	leading, following := c.leading()
+	added, err := leading.AddEvent(e)
+	if err != nil {
+		return added, err
+	}
+	_, err = following.AddEvent(added)
+	c.follow("AddEvent", err)
+	return added, nil
+}
+
+func (c *Client) EventById(id string) (model.Event, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventById(id)
+}
+
+func (c *Client) DeleteEventById(id string) errors.EdgeX {
+	leading, following := c.leading()
+	if err := leading.DeleteEventById(id); err != nil {
+		return err
+	}
+	c.follow("DeleteEventById", following.DeleteEventById(id))
+	return nil
+}
+
+func (c *Client) EventTotalCount() (uint32, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventTotalCount()
+}
+
+func (c *Client) EventCountByDeviceName(deviceName string) (uint32, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventCountByDeviceName(deviceName)
+}
+
+func (c *Client) EventCountByTimeRange(start int, end int) (uint32, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventCountByTimeRange(start, end)
+}
+
+func (c *Client) EventCountByDeviceNameAndTimeRange(deviceName string, start int, end int) (uint32, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventCountByDeviceNameAndTimeRange(deviceName, start, end)
+}
+
+func (c *Client) DeviceHasEventsSince(deviceName string, since int) (bool, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.DeviceHasEventsSince(deviceName, since)
+}
+
+func (c *Client) SetEventPartitioning(hotThreshold int, span time.Duration) {
+	c.primary.SetEventPartitioning(hotThreshold, span)
+	c.secondary.SetEventPartitioning(hotThreshold, span)
+}
+
+func (c *Client) EventPartitions(offset int, limit int) ([]partitions.DevicePartitions, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventPartitions(offset, limit)
+}
+
+func (c *Client) EventPartitionsByDeviceName(deviceName string) (partitions.DevicePartitions, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventPartitionsByDeviceName(deviceName)
+}
+
+func (c *Client) AllEvents(offset int, limit int) ([]model.Event, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.AllEvents(offset, limit)
+}
+
+func (c *Client) EventsByDeviceName(offset int, limit int, name string) ([]model.Event, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventsByDeviceName(offset, limit, name)
+}
+
+func (c *Client) DeleteEventsByDeviceName(deviceName string) errors.EdgeX {
+	leading, following := c.leading()
+	if err := leading.DeleteEventsByDeviceName(deviceName); err != nil {
+		return err
+	}
+	c.follow("DeleteEventsByDeviceName", following.DeleteEventsByDeviceName(deviceName))
+	return nil
+}
+
+func (c *Client) EventsByTimeRange(start int, end int, offset int, limit int) ([]model.Event, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventsByTimeRange(start, end, offset, limit)
+}
+
+func (c *Client) DeleteEventsByAge(age int64) errors.EdgeX {
+	leading, following := c.leading()
+	if err := leading.DeleteEventsByAge(age); err != nil {
+		return err
+	}
+	c.follow("DeleteEventsByAge", following.DeleteEventsByAge(age))
+	return nil
+}
+
+// DeleteEventsByTimeRange deletes a batch of the events of the time range from both databases, and returns the number
+// of events deleted from the leading one
+func (c *Client) DeleteEventsByTimeRange(start int, end int, limit int) (uint32, errors.EdgeX) {
+	leading, following := c.leading()
+	deleted, err := leading.DeleteEventsByTimeRange(start, end, limit)
+	if err != nil {
+		return deleted, err
+	}
+	_, err = following.DeleteEventsByTimeRange(start, end, limit)
+	c.follow("DeleteEventsByTimeRange", err)
+	return deleted, nil
+}
+
+// DeleteEventsByDeviceNameAndTimeRange deletes a batch of the events of a device and time range from both databases,
+// and returns the number of events deleted from the leading one
+func (c *Client) DeleteEventsByDeviceNameAndTimeRange(deviceName string, start int, end int, limit int) (uint32, errors.EdgeX) {
+	leading, following := c.leading()
+	deleted, err := leading.DeleteEventsByDeviceNameAndTimeRange(deviceName, start, end, limit)
+	if err != nil {
+		return deleted, err
+	}
+	_, err = following.DeleteEventsByDeviceNameAndTimeRange(deviceName, start, end, limit)
+	c.follow("DeleteEventsByDeviceNameAndTimeRange", err)
+	return deleted, nil
+}
+
+func (c *Client) ReadingTotalCount(excludedQualities []string) (uint32, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.ReadingTotalCount(excludedQualities)
+}
+
+func (c *Client) AllReadings(offset int, limit int, excludedQualities []string) ([]model.Reading, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.AllReadings(offset, limit, excludedQualities)
+}
+
+func (c *Client) ReadingsByTimeRange(start int, end int, offset int, limit int, excludedQualities []string) ([]model.Reading, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.ReadingsByTimeRange(start, end, offset, limit, excludedQualities)
+}
+
+func (c *Client) ReadingsByResourceName(offset int, limit int, resourceName string, excludedQualities []string) ([]model.Reading, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.ReadingsByResourceName(offset, limit, resourceName, excludedQualities)
+}
+
+func (c *Client) ReadingsByDeviceName(offset int, limit int, name string, excludedQualities []string) ([]model.Reading, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.ReadingsByDeviceName(offset, limit, name, excludedQualities)
+}
+
+func (c *Client) ReadingCountByDeviceName(deviceName string, excludedQualities []string) (uint32, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.ReadingCountByDeviceName(deviceName, excludedQualities)
+}
+
+func (c *Client) ReadingCountByDeviceNameAndTimeRange(deviceName string, start int, end int, excludedQualities []string) (uint32, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.ReadingCountByDeviceNameAndTimeRange(deviceName, start, end, excludedQualities)
+}
+
+func (c *Client) AddReadingAnnotations(annotations map[string]quality.Annotation) errors.EdgeX {
+	leading, following := c.leading()
+	if err := leading.AddReadingAnnotations(annotations); err != nil {
+		return err
+	}
+	c.follow("AddReadingAnnotations", following.AddReadingAnnotations(annotations))
+	return nil
+}
+
+func (c *Client) ReadingAnnotations(ids []string) (map[string]quality.Annotation, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.ReadingAnnotations(ids)
+}
+
+func (c *Client) AddEventTagIndex(id string, created int64, eventTags map[string]string) errors.EdgeX {
+	leading, following := c.leading()
+	if err := leading.AddEventTagIndex(id, created, eventTags); err != nil {
+		return err
+	}
+	c.follow("AddEventTagIndex", following.AddEventTagIndex(id, created, eventTags))
+	return nil
+}
+
+func (c *Client) AddExpiringEvent(e model.Event, ttl int64) (model.Event, errors.EdgeX) {
+	leading, following := c.leading()
+	added, err := leading.AddExpiringEvent(e, ttl)
+	if err != nil {
+		return added, err
+	}
+	_, err = following.AddExpiringEvent(added, ttl)
+	c.follow("AddExpiringEvent", err)
+	return added, nil
+}
+
+// DeleteExpiredEvents deletes the expired events of both databases, each holding the expiry of its events, and
+// returns the number of events deleted from the leading one
+func (c *Client) DeleteExpiredEvents(now int64, limit int) (uint32, errors.EdgeX) {
+	leading, following := c.leading()
+	deleted, err := leading.DeleteExpiredEvents(now, limit)
+	if err != nil {
+		return deleted, err
+	}
+	_, err = following.DeleteExpiredEvents(now, limit)
+	c.follow("DeleteExpiredEvents", err)
+	return deleted, nil
+}
+
+// CompressReadings compresses the readings of both databases, each compressing its own readings, and returns the
+// number of readings compressed in the leading one
+func (c *Client) CompressReadings(before int64, chunkSize int) (uint32, errors.EdgeX) {
+	leading, following := c.leading()
+	compressed, err := leading.CompressReadings(before, chunkSize)
+	if err != nil {
+		return compressed, err
+	}
+	_, err = following.CompressReadings(before, chunkSize)
+	c.follow("CompressReadings", err)
+	return compressed, nil
+}
+
+func (c *Client) EventsByTags(terms []tags.Term, offset int, limit int) ([]model.Event, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventsByTags(terms, offset, limit)
+}
+
+func (c *Client) EventCountByTags(terms []tags.Term) (uint32, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.EventCountByTags(terms)
+}
+
+func (c *Client) ReadingsByTags(terms []tags.Term, offset int, limit int) ([]model.Reading, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.ReadingsByTags(terms, offset, limit)
+}
+
+func (c *Client) AddSystemEvents(events []audit.SystemEvent) ([]audit.SystemEvent, errors.EdgeX) {
+	leading, following := c.leading()
+	added, err := leading.AddSystemEvents(events)
+	if err != nil {
+		return added, err
+	}
+	_, err = following.AddSystemEvents(added)
+	c.follow("AddSystemEvents", err)
+	return added, nil
+}
+
+func (c *Client) SystemEventsByTimeRange(start int, end int, actor string, offset int, limit int) ([]audit.SystemEvent, errors.EdgeX) {
+	leading, _ := c.leading()
+	return leading.SystemEventsByTimeRange(start, end, actor, offset, limit)
+}
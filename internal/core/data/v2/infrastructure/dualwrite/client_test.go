@@ -0,0 +1,149 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dualwrite
+
+import (
+	"testing"
+
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEventId   = "ca93c8fa-9919-4ec5-85d3-f81b2b6a7bc1"
+	testReadingId = "7003cacc-0e00-4676-977c-4e58b9612abd"
+	testCreated   = int64(1600666214495)
+)
+
+func testEvent() models.Event {
+	return models.Event{
+		Id:          testEventId,
+		DeviceName:  "TestDevice",
+		ProfileName: "TestProfile",
+		Origin:      1600666185705354000,
+		Readings: []models.Reading{
+			models.SimpleReading{BaseReading: models.BaseReading{Id: testReadingId}, Value: "45"},
+		},
+	}
+}
+
+func newTestClient(cutover *bool) (*Client, *dbMock.DBClient, *dbMock.DBClient) {
+	primary := &dbMock.DBClient{}
+	secondary := &dbMock.DBClient{}
+	return NewClient(logger.NewMockClient(), primary, secondary, func() bool { return *cutover }), primary, secondary
+}
+
+func TestAddEvent(t *testing.T) {
+	event := testEvent()
+	added := testEvent()
+	added.Created = testCreated
+	cutover := false
+	client, primary, secondary := newTestClient(&cutover)
+	primary.On("AddEvent", event).Return(added, nil)
+	secondary.On("AddEvent", added).Return(added, nil)
+
+	result, err := client.AddEvent(event)
+
+	require.NoError(t, err)
+	assert.Equal(t, added, result)
+	primary.AssertExpectations(t)
+	secondary.AssertExpectations(t)
+	assert.Zero(t, client.FailedWrites())
+}
+
+func TestAddEventFollowingFailure(t *testing.T) {
+	event := testEvent()
+	cutover := false
+	client, primary, secondary := newTestClient(&cutover)
+	primary.On("AddEvent", event).Return(event, nil)
+	secondary.On("AddEvent", event).Return(models.Event{}, errors.NewCommonEdgeX(errors.KindServerError, "unreachable", nil))
+
+	_, err := client.AddEvent(event)
+
+	require.NoError(t, err, "the following database must not fail the request")
+	assert.Equal(t, uint64(1), client.FailedWrites())
+}
+
+func TestAddEventLeadingFailure(t *testing.T) {
+	event := testEvent()
+	cutover := false
+	client, primary, secondary := newTestClient(&cutover)
+	primary.On("AddEvent", event).Return(models.Event{}, errors.NewCommonEdgeX(errors.KindServerError, "unreachable", nil))
+
+	_, err := client.AddEvent(event)
+
+	require.Error(t, err)
+	secondary.AssertNotCalled(t, "AddEvent", event)
+}
+
+func TestCutover(t *testing.T) {
+	cutover := false
+	client, primary, secondary := newTestClient(&cutover)
+	primary.On("EventTotalCount").Return(uint32(1), nil)
+	secondary.On("EventTotalCount").Return(uint32(2), nil)
+	primary.On("DeleteEventsByAge", int64(10)).Return(nil)
+	secondary.On("DeleteEventsByAge", int64(10)).Return(nil)
+
+	count, err := client.EventTotalCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), count, "the primary database serves reads before the cutover")
+
+	cutover = true
+	count, err = client.EventTotalCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), count, "the secondary database serves reads after the cutover")
+
+	require.NoError(t, client.DeleteEventsByAge(10))
+	primary.AssertCalled(t, "DeleteEventsByAge", int64(10))
+	secondary.AssertCalled(t, "DeleteEventsByAge", int64(10))
+}
+
+func TestCheck(t *testing.T) {
+	event := testEvent()
+	event.Created = testCreated
+	mismatched := event
+	mismatched.Origin = 0
+
+	tests := []struct {
+		name       string
+		followed   models.Event
+		followErr  errors.EdgeX
+		count      uint32
+		consistent bool
+		missing    []string
+		mismatched []string
+	}{
+		{"consistent", event, nil, 2, true, nil, nil},
+		{"count differs", event, nil, 1, false, nil, nil},
+		{"missing event", models.Event{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil), 2, false,
+			[]string{testEventId}, nil},
+		{"mismatched event", mismatched, nil, 2, false, nil, []string{testEventId}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			cutover := false
+			client, primary, secondary := newTestClient(&cutover)
+			primary.On("EventTotalCount").Return(uint32(2), nil)
+			secondary.On("EventTotalCount").Return(testCase.count, nil)
+			primary.On("AllEvents", 0, 1).Return([]models.Event{event}, nil)
+			secondary.On("EventById", testEventId).Return(testCase.followed, testCase.followErr)
+
+			report, err := client.Check(1)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.consistent, report.Consistent)
+			assert.Equal(t, 1, report.SampledEvents)
+			assert.Equal(t, testCase.missing, report.MissingEvents)
+			assert.Equal(t, testCase.mismatched, report.MismatchedEvents)
+		})
+	}
+}
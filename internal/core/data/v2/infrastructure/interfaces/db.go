@@ -6,6 +6,13 @@
 package interfaces
 
 import (
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/partitions"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
+
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	model "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 )
@@ -18,15 +25,35 @@ type DBClient interface {
 	DeleteEventById(id string) errors.EdgeX
 	EventTotalCount() (uint32, errors.EdgeX)
 	EventCountByDeviceName(deviceName string) (uint32, errors.EdgeX)
+	EventCountByTimeRange(start int, end int) (uint32, errors.EdgeX)
+	EventCountByDeviceNameAndTimeRange(deviceName string, start int, end int) (uint32, errors.EdgeX)
+	DeviceHasEventsSince(deviceName string, since int) (bool, errors.EdgeX)
 	AllEvents(offset int, limit int) ([]model.Event, errors.EdgeX)
 	EventsByDeviceName(offset int, limit int, name string) ([]model.Event, errors.EdgeX)
 	DeleteEventsByDeviceName(deviceName string) errors.EdgeX
 	EventsByTimeRange(start int, end int, offset int, limit int) ([]model.Event, errors.EdgeX)
 	DeleteEventsByAge(age int64) errors.EdgeX
-	ReadingTotalCount() (uint32, errors.EdgeX)
-	AllReadings(offset int, limit int) ([]model.Reading, errors.EdgeX)
-	ReadingsByTimeRange(start int, end int, offset int, limit int) ([]model.Reading, errors.EdgeX)
-	ReadingsByResourceName(offset int, limit int, resourceName string) ([]model.Reading, errors.EdgeX)
-	ReadingsByDeviceName(offset int, limit int, name string) ([]model.Reading, errors.EdgeX)
-	ReadingCountByDeviceName(deviceName string) (uint32, errors.EdgeX)
+	DeleteEventsByTimeRange(start int, end int, limit int) (uint32, errors.EdgeX)
+	DeleteEventsByDeviceNameAndTimeRange(deviceName string, start int, end int, limit int) (uint32, errors.EdgeX)
+	SetEventPartitioning(hotThreshold int, span time.Duration)
+	EventPartitions(offset int, limit int) ([]partitions.DevicePartitions, errors.EdgeX)
+	EventPartitionsByDeviceName(deviceName string) (partitions.DevicePartitions, errors.EdgeX)
+	ReadingTotalCount(excludedQualities []string) (uint32, errors.EdgeX)
+	AllReadings(offset int, limit int, excludedQualities []string) ([]model.Reading, errors.EdgeX)
+	ReadingsByTimeRange(start int, end int, offset int, limit int, excludedQualities []string) ([]model.Reading, errors.EdgeX)
+	ReadingsByResourceName(offset int, limit int, resourceName string, excludedQualities []string) ([]model.Reading, errors.EdgeX)
+	ReadingsByDeviceName(offset int, limit int, name string, excludedQualities []string) ([]model.Reading, errors.EdgeX)
+	ReadingCountByDeviceName(deviceName string, excludedQualities []string) (uint32, errors.EdgeX)
+	ReadingCountByDeviceNameAndTimeRange(deviceName string, start int, end int, excludedQualities []string) (uint32, errors.EdgeX)
+	AddReadingAnnotations(annotations map[string]quality.Annotation) errors.EdgeX
+	ReadingAnnotations(ids []string) (map[string]quality.Annotation, errors.EdgeX)
+	AddEventTagIndex(id string, created int64, eventTags map[string]string) errors.EdgeX
+	AddExpiringEvent(e model.Event, ttl int64) (model.Event, errors.EdgeX)
+	DeleteExpiredEvents(now int64, limit int) (uint32, errors.EdgeX)
+	CompressReadings(before int64, chunkSize int) (uint32, errors.EdgeX)
+	EventsByTags(terms []tags.Term, offset int, limit int) ([]model.Event, errors.EdgeX)
+	EventCountByTags(terms []tags.Term) (uint32, errors.EdgeX)
+	ReadingsByTags(terms []tags.Term, offset int, limit int) ([]model.Reading, errors.EdgeX)
+	AddSystemEvents(events []audit.SystemEvent) ([]audit.SystemEvent, errors.EdgeX)
+	SystemEventsByTimeRange(start int, end int, actor string, offset int, limit int) ([]audit.SystemEvent, errors.EdgeX)
 }
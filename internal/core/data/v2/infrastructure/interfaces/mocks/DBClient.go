@@ -3,11 +3,21 @@
 package mocks
 
 import (
+	audit "github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+
+	partitions "github.com/edgexfoundry/edgex-go/internal/pkg/partitions"
+
+	quality "github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	tags "github.com/edgexfoundry/edgex-go/internal/pkg/tags"
+
 	errors "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	time "time"
 )
 
 // DBClient is an autogenerated mock type for the DBClient type
@@ -38,6 +48,86 @@ func (_m *DBClient) AddEvent(e models.Event) (models.Event, errors.EdgeX) {
 	return r0, r1
 }
 
+// AddExpiringEvent provides a mock function with given fields: e, ttl
+func (_m *DBClient) AddExpiringEvent(e models.Event, ttl int64) (models.Event, errors.EdgeX) {
+	ret := _m.Called(e, ttl)
+
+	var r0 models.Event
+	if rf, ok := ret.Get(0).(func(models.Event, int64) models.Event); ok {
+		r0 = rf(e, ttl)
+	} else {
+		r0 = ret.Get(0).(models.Event)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(models.Event, int64) errors.EdgeX); ok {
+		r1 = rf(e, ttl)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AddEventTagIndex provides a mock function with given fields: id, created, eventTags
+func (_m *DBClient) AddEventTagIndex(id string, created int64, eventTags map[string]string) errors.EdgeX {
+	ret := _m.Called(id, created, eventTags)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string, int64, map[string]string) errors.EdgeX); ok {
+		r0 = rf(id, created, eventTags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// AddReadingAnnotations provides a mock function with given fields: annotations
+func (_m *DBClient) AddReadingAnnotations(annotations map[string]quality.Annotation) errors.EdgeX {
+	ret := _m.Called(annotations)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(map[string]quality.Annotation) errors.EdgeX); ok {
+		r0 = rf(annotations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// AddSystemEvents provides a mock function with given fields: events
+func (_m *DBClient) AddSystemEvents(events []audit.SystemEvent) ([]audit.SystemEvent, errors.EdgeX) {
+	ret := _m.Called(events)
+
+	var r0 []audit.SystemEvent
+	if rf, ok := ret.Get(0).(func([]audit.SystemEvent) []audit.SystemEvent); ok {
+		r0 = rf(events)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]audit.SystemEvent)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func([]audit.SystemEvent) errors.EdgeX); ok {
+		r1 = rf(events)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AllEvents provides a mock function with given fields: offset, limit
 func (_m *DBClient) AllEvents(offset int, limit int) ([]models.Event, errors.EdgeX) {
 	ret := _m.Called(offset, limit)
@@ -63,13 +153,13 @@ func (_m *DBClient) AllEvents(offset int, limit int) ([]models.Event, errors.Edg
 	return r0, r1
 }
 
-// AllReadings provides a mock function with given fields: offset, limit
-func (_m *DBClient) AllReadings(offset int, limit int) ([]models.Reading, errors.EdgeX) {
-	ret := _m.Called(offset, limit)
+// AllReadings provides a mock function with given fields: offset, limit, excludedQualities
+func (_m *DBClient) AllReadings(offset int, limit int, excludedQualities []string) ([]models.Reading, errors.EdgeX) {
+	ret := _m.Called(offset, limit, excludedQualities)
 
 	var r0 []models.Reading
-	if rf, ok := ret.Get(0).(func(int, int) []models.Reading); ok {
-		r0 = rf(offset, limit)
+	if rf, ok := ret.Get(0).(func(int, int, []string) []models.Reading); ok {
+		r0 = rf(offset, limit, excludedQualities)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Reading)
@@ -77,8 +167,8 @@ func (_m *DBClient) AllReadings(offset int, limit int) ([]models.Reading, errors
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
-		r1 = rf(offset, limit)
+	if rf, ok := ret.Get(1).(func(int, int, []string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, excludedQualities)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -93,6 +183,29 @@ func (_m *DBClient) CloseSession() {
 	_m.Called()
 }
 
+// CompressReadings provides a mock function with given fields: before, chunkSize
+func (_m *DBClient) CompressReadings(before int64, chunkSize int) (uint32, errors.EdgeX) {
+	ret := _m.Called(before, chunkSize)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(int64, int) uint32); ok {
+		r0 = rf(before, chunkSize)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int64, int) errors.EdgeX); ok {
+		r1 = rf(before, chunkSize)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // DeleteEventById provides a mock function with given fields: id
 func (_m *DBClient) DeleteEventById(id string) errors.EdgeX {
 	ret := _m.Called(id)
@@ -141,6 +254,98 @@ func (_m *DBClient) DeleteEventsByDeviceName(deviceName string) errors.EdgeX {
 	return r0
 }
 
+// DeleteEventsByDeviceNameAndTimeRange provides a mock function with given fields: deviceName, start, end, limit
+func (_m *DBClient) DeleteEventsByDeviceNameAndTimeRange(deviceName string, start int, end int, limit int) (uint32, errors.EdgeX) {
+	ret := _m.Called(deviceName, start, end, limit)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string, int, int, int) uint32); ok {
+		r0 = rf(deviceName, start, end, limit)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string, int, int, int) errors.EdgeX); ok {
+		r1 = rf(deviceName, start, end, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteEventsByTimeRange provides a mock function with given fields: start, end, limit
+func (_m *DBClient) DeleteEventsByTimeRange(start int, end int, limit int) (uint32, errors.EdgeX) {
+	ret := _m.Called(start, end, limit)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(int, int, int) uint32); ok {
+		r0 = rf(start, end, limit)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int, int) errors.EdgeX); ok {
+		r1 = rf(start, end, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteExpiredEvents provides a mock function with given fields: now, limit
+func (_m *DBClient) DeleteExpiredEvents(now int64, limit int) (uint32, errors.EdgeX) {
+	ret := _m.Called(now, limit)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(int64, int) uint32); ok {
+		r0 = rf(now, limit)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int64, int) errors.EdgeX); ok {
+		r1 = rf(now, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeviceHasEventsSince provides a mock function with given fields: deviceName, since
+func (_m *DBClient) DeviceHasEventsSince(deviceName string, since int) (bool, errors.EdgeX) {
+	ret := _m.Called(deviceName, since)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, int) bool); ok {
+		r0 = rf(deviceName, since)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string, int) errors.EdgeX); ok {
+		r1 = rf(deviceName, since)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // EventById provides a mock function with given fields: id
 func (_m *DBClient) EventById(id string) (models.Event, errors.EdgeX) {
 	ret := _m.Called(id)
@@ -187,6 +392,123 @@ func (_m *DBClient) EventCountByDeviceName(deviceName string) (uint32, errors.Ed
 	return r0, r1
 }
 
+// EventCountByDeviceNameAndTimeRange provides a mock function with given fields: deviceName, start, end
+func (_m *DBClient) EventCountByDeviceNameAndTimeRange(deviceName string, start int, end int) (uint32, errors.EdgeX) {
+	ret := _m.Called(deviceName, start, end)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string, int, int) uint32); ok {
+		r0 = rf(deviceName, start, end)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string, int, int) errors.EdgeX); ok {
+		r1 = rf(deviceName, start, end)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// EventCountByTags provides a mock function with given fields: terms
+func (_m *DBClient) EventCountByTags(terms []tags.Term) (uint32, errors.EdgeX) {
+	ret := _m.Called(terms)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func([]tags.Term) uint32); ok {
+		r0 = rf(terms)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func([]tags.Term) errors.EdgeX); ok {
+		r1 = rf(terms)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// EventCountByTimeRange provides a mock function with given fields: start, end
+func (_m *DBClient) EventCountByTimeRange(start int, end int) (uint32, errors.EdgeX) {
+	ret := _m.Called(start, end)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(int, int) uint32); ok {
+		r0 = rf(start, end)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(start, end)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// EventPartitions provides a mock function with given fields: offset, limit
+func (_m *DBClient) EventPartitions(offset int, limit int) ([]partitions.DevicePartitions, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []partitions.DevicePartitions
+	if rf, ok := ret.Get(0).(func(int, int) []partitions.DevicePartitions); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]partitions.DevicePartitions)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// EventPartitionsByDeviceName provides a mock function with given fields: deviceName
+func (_m *DBClient) EventPartitionsByDeviceName(deviceName string) (partitions.DevicePartitions, errors.EdgeX) {
+	ret := _m.Called(deviceName)
+
+	var r0 partitions.DevicePartitions
+	if rf, ok := ret.Get(0).(func(string) partitions.DevicePartitions); ok {
+		r0 = rf(deviceName)
+	} else {
+		r0 = ret.Get(0).(partitions.DevicePartitions)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(deviceName)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // EventTotalCount provides a mock function with given fields:
 func (_m *DBClient) EventTotalCount() (uint32, errors.EdgeX) {
 	ret := _m.Called()
@@ -235,6 +557,31 @@ func (_m *DBClient) EventsByDeviceName(offset int, limit int, name string) ([]mo
 	return r0, r1
 }
 
+// EventsByTags provides a mock function with given fields: terms, offset, limit
+func (_m *DBClient) EventsByTags(terms []tags.Term, offset int, limit int) ([]models.Event, errors.EdgeX) {
+	ret := _m.Called(terms, offset, limit)
+
+	var r0 []models.Event
+	if rf, ok := ret.Get(0).(func([]tags.Term, int, int) []models.Event); ok {
+		r0 = rf(terms, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Event)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func([]tags.Term, int, int) errors.EdgeX); ok {
+		r1 = rf(terms, offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // EventsByTimeRange provides a mock function with given fields: start, end, offset, limit
 func (_m *DBClient) EventsByTimeRange(start int, end int, offset int, limit int) ([]models.Event, errors.EdgeX) {
 	ret := _m.Called(start, end, offset, limit)
@@ -260,20 +607,45 @@ func (_m *DBClient) EventsByTimeRange(start int, end int, offset int, limit int)
 	return r0, r1
 }
 
-// ReadingCountByDeviceName provides a mock function with given fields: deviceName
-func (_m *DBClient) ReadingCountByDeviceName(deviceName string) (uint32, errors.EdgeX) {
-	ret := _m.Called(deviceName)
+// ReadingAnnotations provides a mock function with given fields: ids
+func (_m *DBClient) ReadingAnnotations(ids []string) (map[string]quality.Annotation, errors.EdgeX) {
+	ret := _m.Called(ids)
+
+	var r0 map[string]quality.Annotation
+	if rf, ok := ret.Get(0).(func([]string) map[string]quality.Annotation); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]quality.Annotation)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func([]string) errors.EdgeX); ok {
+		r1 = rf(ids)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ReadingCountByDeviceName provides a mock function with given fields: deviceName, excludedQualities
+func (_m *DBClient) ReadingCountByDeviceName(deviceName string, excludedQualities []string) (uint32, errors.EdgeX) {
+	ret := _m.Called(deviceName, excludedQualities)
 
 	var r0 uint32
-	if rf, ok := ret.Get(0).(func(string) uint32); ok {
-		r0 = rf(deviceName)
+	if rf, ok := ret.Get(0).(func(string, []string) uint32); ok {
+		r0 = rf(deviceName, excludedQualities)
 	} else {
 		r0 = ret.Get(0).(uint32)
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
-		r1 = rf(deviceName)
+	if rf, ok := ret.Get(1).(func(string, []string) errors.EdgeX); ok {
+		r1 = rf(deviceName, excludedQualities)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -283,20 +655,20 @@ func (_m *DBClient) ReadingCountByDeviceName(deviceName string) (uint32, errors.
 	return r0, r1
 }
 
-// ReadingTotalCount provides a mock function with given fields:
-func (_m *DBClient) ReadingTotalCount() (uint32, errors.EdgeX) {
-	ret := _m.Called()
+// ReadingCountByDeviceNameAndTimeRange provides a mock function with given fields: deviceName, start, end, excludedQualities
+func (_m *DBClient) ReadingCountByDeviceNameAndTimeRange(deviceName string, start int, end int, excludedQualities []string) (uint32, errors.EdgeX) {
+	ret := _m.Called(deviceName, start, end, excludedQualities)
 
 	var r0 uint32
-	if rf, ok := ret.Get(0).(func() uint32); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string, int, int, []string) uint32); ok {
+		r0 = rf(deviceName, start, end, excludedQualities)
 	} else {
 		r0 = ret.Get(0).(uint32)
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func() errors.EdgeX); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, int, int, []string) errors.EdgeX); ok {
+		r1 = rf(deviceName, start, end, excludedQualities)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -306,13 +678,36 @@ func (_m *DBClient) ReadingTotalCount() (uint32, errors.EdgeX) {
 	return r0, r1
 }
 
-// ReadingsByDeviceName provides a mock function with given fields: offset, limit, name
-func (_m *DBClient) ReadingsByDeviceName(offset int, limit int, name string) ([]models.Reading, errors.EdgeX) {
-	ret := _m.Called(offset, limit, name)
+// ReadingTotalCount provides a mock function with given fields: excludedQualities
+func (_m *DBClient) ReadingTotalCount(excludedQualities []string) (uint32, errors.EdgeX) {
+	ret := _m.Called(excludedQualities)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func([]string) uint32); ok {
+		r0 = rf(excludedQualities)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func([]string) errors.EdgeX); ok {
+		r1 = rf(excludedQualities)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ReadingsByDeviceName provides a mock function with given fields: offset, limit, name, excludedQualities
+func (_m *DBClient) ReadingsByDeviceName(offset int, limit int, name string, excludedQualities []string) ([]models.Reading, errors.EdgeX) {
+	ret := _m.Called(offset, limit, name, excludedQualities)
 
 	var r0 []models.Reading
-	if rf, ok := ret.Get(0).(func(int, int, string) []models.Reading); ok {
-		r0 = rf(offset, limit, name)
+	if rf, ok := ret.Get(0).(func(int, int, string, []string) []models.Reading); ok {
+		r0 = rf(offset, limit, name, excludedQualities)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Reading)
@@ -320,8 +715,8 @@ func (_m *DBClient) ReadingsByDeviceName(offset int, limit int, name string) ([]
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(int, int, string) errors.EdgeX); ok {
-		r1 = rf(offset, limit, name)
+	if rf, ok := ret.Get(1).(func(int, int, string, []string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, name, excludedQualities)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -331,13 +726,13 @@ func (_m *DBClient) ReadingsByDeviceName(offset int, limit int, name string) ([]
 	return r0, r1
 }
 
-// ReadingsByResourceName provides a mock function with given fields: offset, limit, resourceName
-func (_m *DBClient) ReadingsByResourceName(offset int, limit int, resourceName string) ([]models.Reading, errors.EdgeX) {
-	ret := _m.Called(offset, limit, resourceName)
+// ReadingsByResourceName provides a mock function with given fields: offset, limit, resourceName, excludedQualities
+func (_m *DBClient) ReadingsByResourceName(offset int, limit int, resourceName string, excludedQualities []string) ([]models.Reading, errors.EdgeX) {
+	ret := _m.Called(offset, limit, resourceName, excludedQualities)
 
 	var r0 []models.Reading
-	if rf, ok := ret.Get(0).(func(int, int, string) []models.Reading); ok {
-		r0 = rf(offset, limit, resourceName)
+	if rf, ok := ret.Get(0).(func(int, int, string, []string) []models.Reading); ok {
+		r0 = rf(offset, limit, resourceName, excludedQualities)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Reading)
@@ -345,8 +740,8 @@ func (_m *DBClient) ReadingsByResourceName(offset int, limit int, resourceName s
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(int, int, string) errors.EdgeX); ok {
-		r1 = rf(offset, limit, resourceName)
+	if rf, ok := ret.Get(1).(func(int, int, string, []string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, resourceName, excludedQualities)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -356,13 +751,13 @@ func (_m *DBClient) ReadingsByResourceName(offset int, limit int, resourceName s
 	return r0, r1
 }
 
-// ReadingsByTimeRange provides a mock function with given fields: start, end, offset, limit
-func (_m *DBClient) ReadingsByTimeRange(start int, end int, offset int, limit int) ([]models.Reading, errors.EdgeX) {
-	ret := _m.Called(start, end, offset, limit)
+// ReadingsByTags provides a mock function with given fields: terms, offset, limit
+func (_m *DBClient) ReadingsByTags(terms []tags.Term, offset int, limit int) ([]models.Reading, errors.EdgeX) {
+	ret := _m.Called(terms, offset, limit)
 
 	var r0 []models.Reading
-	if rf, ok := ret.Get(0).(func(int, int, int, int) []models.Reading); ok {
-		r0 = rf(start, end, offset, limit)
+	if rf, ok := ret.Get(0).(func([]tags.Term, int, int) []models.Reading); ok {
+		r0 = rf(terms, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Reading)
@@ -370,8 +765,63 @@ func (_m *DBClient) ReadingsByTimeRange(start int, end int, offset int, limit in
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(int, int, int, int) errors.EdgeX); ok {
-		r1 = rf(start, end, offset, limit)
+	if rf, ok := ret.Get(1).(func([]tags.Term, int, int) errors.EdgeX); ok {
+		r1 = rf(terms, offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ReadingsByTimeRange provides a mock function with given fields: start, end, offset, limit, excludedQualities
+func (_m *DBClient) ReadingsByTimeRange(start int, end int, offset int, limit int, excludedQualities []string) ([]models.Reading, errors.EdgeX) {
+	ret := _m.Called(start, end, offset, limit, excludedQualities)
+
+	var r0 []models.Reading
+	if rf, ok := ret.Get(0).(func(int, int, int, int, []string) []models.Reading); ok {
+		r0 = rf(start, end, offset, limit, excludedQualities)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reading)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int, int, int, []string) errors.EdgeX); ok {
+		r1 = rf(start, end, offset, limit, excludedQualities)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// SetEventPartitioning provides a mock function with given fields: hotThreshold, span
+func (_m *DBClient) SetEventPartitioning(hotThreshold int, span time.Duration) {
+	_m.Called(hotThreshold, span)
+}
+
+// SystemEventsByTimeRange provides a mock function with given fields: start, end, actor, offset, limit
+func (_m *DBClient) SystemEventsByTimeRange(start int, end int, actor string, offset int, limit int) ([]audit.SystemEvent, errors.EdgeX) {
+	ret := _m.Called(start, end, actor, offset, limit)
+
+	var r0 []audit.SystemEvent
+	if rf, ok := ret.Get(0).(func(int, int, string, int, int) []audit.SystemEvent); ok {
+		r0 = rf(start, end, actor, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]audit.SystemEvent)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int, string, int, int) errors.EdgeX); ok {
+		r1 = rf(start, end, actor, offset, limit)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
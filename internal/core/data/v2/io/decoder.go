@@ -0,0 +1,622 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	dto "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+)
+
+const (
+	// maxNames is the number of names a decoder interns before starting over, which bounds the memory it holds
+	maxNames = 4096
+	// maxDepth is the nesting of the values skipped by a decoder, which encoding/json bounds too
+	maxDepth = 10000
+)
+
+// errSyntax is returned by the decoder for the documents it can't decode, which are left to encoding/json to report
+var errSyntax = fmt.Errorf("unexpected JSON")
+
+// decoders pools the eventDecoders, whose interned names are thus shared by the requests of the same devices
+var decoders = sync.Pool{
+	New: func() interface{} {
+		return &eventDecoder{names: make(map[string]string)}
+	},
+}
+
+// eventDecoder decodes the JSON AddEventRequest without the reflection and the intermediate maps of encoding/json,
+// which allocate several times per field on the hot path of the event ingestion. The names repeated from one event
+// to the next, such as the device, profile and resource names and the value types, are interned so that they are
+// allocated once. It matches the field names case-insensitively as encoding/json does, but leaves to encoding/json
+// the documents that it doesn't decode, such as the type mismatches, so that their errors are reported the usual way.
+type eventDecoder struct {
+	data        []byte
+	pos         int
+	names       map[string]string
+	annotations []quality.Annotation
+}
+
+// decode decodes data into request, without validating it, which valid does, and returns the annotations of its readings by index.
+// The annotations are those of extractAnnotations, and are valid until the decoder is returned to the pool.
+func (d *eventDecoder) decode(data []byte, request *dto.AddEventRequest) ([]quality.Annotation, error) {
+	d.data = data
+	d.pos = 0
+	d.annotations = d.annotations[:0]
+	defer func() {
+		d.data = nil
+	}()
+
+	err := d.object(func(key []byte) error {
+		switch {
+		case bytes.EqualFold(key, []byte("apiVersion")):
+			return d.name(&request.ApiVersion)
+		case bytes.EqualFold(key, []byte("requestId")):
+			return d.string(&request.RequestId)
+		case bytes.EqualFold(key, []byte("event")):
+			return d.event(&request.Event)
+		default:
+			return d.skip(0)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.space()
+	if d.pos != len(d.data) {
+		return nil, errSyntax
+	}
+	return d.annotations, nil
+}
+
+func (d *eventDecoder) event(event *dtos.Event) error {
+	if d.null() {
+		return nil
+	}
+	return d.object(func(key []byte) error {
+		switch {
+		case bytes.EqualFold(key, []byte("apiVersion")):
+			return d.name(&event.ApiVersion)
+		case bytes.EqualFold(key, []byte("id")):
+			return d.string(&event.Id)
+		case bytes.EqualFold(key, []byte("deviceName")):
+			return d.name(&event.DeviceName)
+		case bytes.EqualFold(key, []byte("profileName")):
+			return d.name(&event.ProfileName)
+		case bytes.EqualFold(key, []byte("created")):
+			return d.int64(&event.Created)
+		case bytes.EqualFold(key, []byte("origin")):
+			return d.int64(&event.Origin)
+		case bytes.EqualFold(key, []byte("readings")):
+			return d.readings(&event.Readings)
+		case bytes.EqualFold(key, []byte("tags")):
+			return d.tags(&event.Tags)
+		default:
+			return d.skip(0)
+		}
+	})
+}
+
+func (d *eventDecoder) readings(readings *[]dtos.BaseReading) error {
+	if d.null() {
+		*readings = nil
+		return nil
+	}
+	if !d.consume('[') {
+		return errSyntax
+	}
+	*readings = (*readings)[:0]
+	d.annotations = d.annotations[:0]
+	if d.consume(']') {
+		return nil
+	}
+	for {
+		*readings = append(*readings, dtos.BaseReading{})
+		d.annotations = append(d.annotations, quality.Annotation{})
+		if err := d.reading(&(*readings)[len(*readings)-1], &d.annotations[len(d.annotations)-1]); err != nil {
+			return err
+		}
+		if d.consume(']') {
+			return nil
+		}
+		if !d.consume(',') {
+			return errSyntax
+		}
+	}
+}
+
+// reading decodes a reading as extractAnnotations and encoding/json do: the null value is replaced by the
+// placeholder of the value type, and the quality is validated.
+func (d *eventDecoder) reading(reading *dtos.BaseReading, annotation *quality.Annotation) error {
+	err := d.object(func(key []byte) error {
+		switch {
+		case bytes.Equal(key, []byte(readingQuality)):
+			var q string
+			if err := d.name(&q); err != nil {
+				return err
+			}
+			q = strings.ToLower(q)
+			if quality.Validate(q) != nil {
+				return errSyntax
+			}
+			annotation.Quality = q
+			return nil
+		case bytes.Equal(key, []byte(readingValue)) && d.null():
+			annotation.Null = true
+			return nil
+		case bytes.EqualFold(key, []byte("apiVersion")):
+			return d.name(&reading.ApiVersion)
+		case bytes.EqualFold(key, []byte("id")):
+			return d.string(&reading.Id)
+		case bytes.EqualFold(key, []byte("created")):
+			return d.int64(&reading.Created)
+		case bytes.EqualFold(key, []byte("origin")):
+			return d.int64(&reading.Origin)
+		case bytes.EqualFold(key, []byte("deviceName")):
+			return d.name(&reading.DeviceName)
+		case bytes.EqualFold(key, []byte("resourceName")):
+			return d.name(&reading.ResourceName)
+		case bytes.EqualFold(key, []byte("profileName")):
+			return d.name(&reading.ProfileName)
+		case bytes.EqualFold(key, []byte("valueType")):
+			return d.name(&reading.ValueType)
+		case bytes.EqualFold(key, []byte("value")):
+			return d.string(&reading.Value)
+		case bytes.EqualFold(key, []byte("binaryValue")):
+			return d.binary(&reading.BinaryValue)
+		case bytes.EqualFold(key, []byte("mediaType")):
+			return d.name(&reading.MediaType)
+		default:
+			return d.skip(0)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if annotation.Null {
+		reading.Value = nullPlaceholder(reading.ValueType)
+	}
+	return nil
+}
+
+func (d *eventDecoder) tags(tags *map[string]string) error {
+	if d.null() {
+		*tags = nil
+		return nil
+	}
+	if *tags == nil {
+		*tags = make(map[string]string)
+	}
+	return d.object(func(key []byte) error {
+		var value string
+		if err := d.string(&value); err != nil {
+			return err
+		}
+		(*tags)[d.intern(key)] = value
+		return nil
+	})
+}
+
+// valid tells whether the decoded request is valid, checking it as the validation tags of the DTOs and
+// AddEventRequest.Validate do but without their reflection, and normalizes the value types of its readings. It
+// accepts no request that AddEventRequest.Validate rejects, leaving those to encoding/json to report the errors.
+func valid(request *dto.AddEventRequest) bool {
+	event := &request.Event
+	if request.ApiVersion == "" || (request.RequestId != "" && !isUUID(request.RequestId)) ||
+		event.ApiVersion == "" || !isUUID(event.Id) || !isUnreserved(event.DeviceName) ||
+		!isUnreserved(event.ProfileName) || event.Origin == 0 || len(event.Readings) == 0 {
+		return false
+	}
+	for i := range event.Readings {
+		reading := &event.Readings[i]
+		if reading.ApiVersion == "" || reading.Origin == 0 || !isUnreserved(reading.DeviceName) ||
+			!isUnreserved(reading.ResourceName) || !isUnreserved(reading.ProfileName) {
+			return false
+		}
+		valueType, ok := normalizeValueType(reading.ValueType)
+		if !ok {
+			return false
+		}
+		reading.ValueType = valueType
+		if valueType != v2.ValueTypeBinary {
+			if reading.Value == "" {
+				return false
+			}
+			continue
+		}
+		// the binary values are validated as gt=0,dive,required, which rejects the zero bytes
+		if len(reading.BinaryValue) == 0 || reading.MediaType == "" || bytes.IndexByte(reading.BinaryValue, 0) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// valueTypes are the value types normalized by v2.NormalizeValueType
+var valueTypes = []string{
+	v2.ValueTypeBool, v2.ValueTypeString,
+	v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64,
+	v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64,
+	v2.ValueTypeFloat32, v2.ValueTypeFloat64,
+	v2.ValueTypeBinary,
+	v2.ValueTypeBoolArray, v2.ValueTypeStringArray,
+	v2.ValueTypeUint8Array, v2.ValueTypeUint16Array, v2.ValueTypeUint32Array, v2.ValueTypeUint64Array,
+	v2.ValueTypeInt8Array, v2.ValueTypeInt16Array, v2.ValueTypeInt32Array, v2.ValueTypeInt64Array,
+	v2.ValueTypeFloat32Array, v2.ValueTypeFloat64Array,
+}
+
+// normalizeValueType normalizes valueType as v2.NormalizeValueType does, without lowering the case of every value
+// type it compares valueType with. The value types that aren't ASCII are left to v2.NormalizeValueType, whose case
+// mapping differs from the case folding of strings.EqualFold for a few characters.
+func normalizeValueType(valueType string) (string, bool) {
+	for _, v := range valueTypes {
+		if valueType == v {
+			return v, true
+		}
+	}
+	for i := 0; i < len(valueType); i++ {
+		if valueType[i] >= 0x80 {
+			return "", false
+		}
+	}
+	for _, v := range valueTypes {
+		if strings.EqualFold(valueType, v) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// isUUID tells whether s is a UUID in the lower case form accepted by the uuid validation tag
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !((s[i] >= '0' && s[i] <= '9') || (s[i] >= 'a' && s[i] <= 'f')) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isUnreserved tells whether s is made of the unreserved characters of RFC 3986 only, which the names must be
+func isUnreserved(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~') {
+			return false
+		}
+	}
+	return true
+}
+
+// object decodes an object, calling field with the key of each of its fields for it to decode the value
+func (d *eventDecoder) object(field func(key []byte) error) error {
+	if !d.consume('{') {
+		return errSyntax
+	}
+	if d.consume('}') {
+		return nil
+	}
+	for {
+		key, err := d.literal()
+		if err != nil {
+			return err
+		}
+		if !d.consume(':') {
+			return errSyntax
+		}
+		if err = field(key); err != nil {
+			return err
+		}
+		if d.consume('}') {
+			return nil
+		}
+		if !d.consume(',') {
+			return errSyntax
+		}
+	}
+}
+
+// string decodes a string into s, leaving s as is for null as encoding/json does
+func (d *eventDecoder) string(s *string) error {
+	if d.null() {
+		return nil
+	}
+	value, err := d.literal()
+	if err != nil {
+		return err
+	}
+	*s = string(value)
+	return nil
+}
+
+// name decodes a string repeated from one request to the next into s, interning it
+func (d *eventDecoder) name(s *string) error {
+	if d.null() {
+		return nil
+	}
+	value, err := d.literal()
+	if err != nil {
+		return err
+	}
+	*s = d.intern(value)
+	return nil
+}
+
+func (d *eventDecoder) intern(value []byte) string {
+	// the conversion of the lookup doesn't allocate
+	if s, ok := d.names[string(value)]; ok {
+		return s
+	}
+	if len(d.names) >= maxNames {
+		d.names = make(map[string]string)
+	}
+	s := string(value)
+	d.names[s] = s
+	return s
+}
+
+// binary decodes a base64 string into b as encoding/json does
+func (d *eventDecoder) binary(b *[]byte) error {
+	if d.null() {
+		*b = nil
+		return nil
+	}
+	value, err := d.literal()
+	if err != nil {
+		return err
+	}
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(value)))
+	n, err := base64.StdEncoding.Decode(decoded, value)
+	if err != nil {
+		return errSyntax
+	}
+	*b = decoded[:n]
+	return nil
+}
+
+// int64 decodes an integer into i, leaving i as is for null. The numbers with a fraction or an exponent are left
+// to encoding/json, which rejects them.
+func (d *eventDecoder) int64(i *int64) error {
+	if d.null() {
+		return nil
+	}
+	negative := d.accept('-')
+	start := d.pos
+	var value uint64
+	for d.pos < len(d.data) && d.data[d.pos] >= '0' && d.data[d.pos] <= '9' {
+		digit := uint64(d.data[d.pos] - '0')
+		if value > (1<<63-digit)/10 {
+			return errSyntax
+		}
+		value = value*10 + digit
+		d.pos++
+	}
+	if d.pos == start || (d.data[start] == '0' && d.pos-start > 1) {
+		return errSyntax
+	}
+	if d.pos < len(d.data) && (d.data[d.pos] == '.' || d.data[d.pos] == 'e' || d.data[d.pos] == 'E') {
+		return errSyntax
+	}
+	if negative {
+		*i = -int64(value)
+	} else if value > 1<<63-1 {
+		return errSyntax
+	} else {
+		*i = int64(value)
+	}
+	return nil
+}
+
+// literal decodes the string at the current position. The strings without escapes nor multi-byte characters, the
+// names and values of the events, are returned as a slice of the data; the others are unquoted by encoding/json.
+func (d *eventDecoder) literal() ([]byte, error) {
+	if !d.peek('"') {
+		return nil, errSyntax
+	}
+	start := d.pos + 1
+	for i := start; i < len(d.data); i++ {
+		switch c := d.data[i]; {
+		case c == '"':
+			d.pos = i + 1
+			return d.data[start:i], nil
+		case c == '\\' || c < ' ' || c >= 0x80:
+			return d.unquote()
+		}
+	}
+	return nil, errSyntax
+}
+
+func (d *eventDecoder) unquote() ([]byte, error) {
+	start := d.pos
+	if err := d.skipString(); err != nil {
+		return nil, err
+	}
+	var s string
+	if err := json.Unmarshal(d.data[start:d.pos], &s); err != nil {
+		return nil, errSyntax
+	}
+	return []byte(s), nil
+}
+
+// skip skips the value at the current position, checking its syntax
+func (d *eventDecoder) skip(depth int) error {
+	if depth > maxDepth {
+		return errSyntax
+	}
+	d.space()
+	if d.pos >= len(d.data) {
+		return errSyntax
+	}
+	switch c := d.data[d.pos]; {
+	case c == '{':
+		return d.object(func([]byte) error {
+			return d.skip(depth + 1)
+		})
+	case c == '[':
+		d.pos++
+		if d.consume(']') {
+			return nil
+		}
+		for {
+			if err := d.skip(depth + 1); err != nil {
+				return err
+			}
+			if d.consume(']') {
+				return nil
+			}
+			if !d.consume(',') {
+				return errSyntax
+			}
+		}
+	case c == '"':
+		return d.skipString()
+	case c == '-' || (c >= '0' && c <= '9'):
+		return d.skipNumber()
+	default:
+		for _, literal := range []string{"true", "false", "null"} {
+			if bytes.HasPrefix(d.data[d.pos:], []byte(literal)) {
+				d.pos += len(literal)
+				return nil
+			}
+		}
+		return errSyntax
+	}
+}
+
+// skipString skips the string at the current position, checking its escapes
+func (d *eventDecoder) skipString() error {
+	d.pos++
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		d.pos++
+		switch {
+		case c == '"':
+			return nil
+		case c < ' ':
+			return errSyntax
+		case c == '\\':
+			if d.pos >= len(d.data) {
+				return errSyntax
+			}
+			switch d.data[d.pos] {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+				d.pos++
+			case 'u':
+				if d.pos+5 > len(d.data) {
+					return errSyntax
+				}
+				for _, h := range d.data[d.pos+1 : d.pos+5] {
+					if !isHex(h) {
+						return errSyntax
+					}
+				}
+				d.pos += 5
+			default:
+				return errSyntax
+			}
+		}
+	}
+	return errSyntax
+}
+
+// skipNumber skips the number at the current position, checking its grammar
+func (d *eventDecoder) skipNumber() error {
+	d.accept('-')
+	switch {
+	case d.accept('0'):
+	case d.digits() == 0:
+		return errSyntax
+	}
+	if d.accept('.') && d.digits() == 0 {
+		return errSyntax
+	}
+	if d.accept('e') || d.accept('E') {
+		if !d.accept('+') {
+			d.accept('-')
+		}
+		if d.digits() == 0 {
+			return errSyntax
+		}
+	}
+	return nil
+}
+
+func (d *eventDecoder) digits() int {
+	start := d.pos
+	for d.pos < len(d.data) && d.data[d.pos] >= '0' && d.data[d.pos] <= '9' {
+		d.pos++
+	}
+	return d.pos - start
+}
+
+// accept consumes c, returning false when the character at the current position is something else
+func (d *eventDecoder) accept(c byte) bool {
+	if d.pos < len(d.data) && d.data[d.pos] == c {
+		d.pos++
+		return true
+	}
+	return false
+}
+
+// null consumes null, returning false when the value at the current position is something else
+func (d *eventDecoder) null() bool {
+	d.space()
+	if bytes.HasPrefix(d.data[d.pos:], []byte("null")) {
+		d.pos += len("null")
+		return true
+	}
+	return false
+}
+
+// consume consumes c after the white space, returning false when the next character is something else
+func (d *eventDecoder) consume(c byte) bool {
+	d.space()
+	return d.accept(c)
+}
+
+// peek returns whether c is the next character after the white space, without consuming it
+func (d *eventDecoder) peek(c byte) bool {
+	d.space()
+	return d.pos < len(d.data) && d.data[d.pos] == c
+}
+
+func (d *eventDecoder) space() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\n', '\r':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
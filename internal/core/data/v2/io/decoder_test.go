@@ -0,0 +1,190 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	dto "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDecoder(t *testing.T) {
+	tests := []struct {
+		name    string
+		request string
+	}{
+		{"Valid", addEventRequest(reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, ""))},
+		{"Valid - several readings", addEventRequest(
+			reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, ""),
+			reading("", v2.ValueTypeInt32, `"-7"`, ""),
+			reading("", v2.ValueTypeBool, `"true"`, ""))},
+		{"Valid - annotations", addEventRequest(
+			reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, `, "quality": "Uncertain"`),
+			reading("", v2.ValueTypeFloat64, "null", `, "quality": "bad"`),
+			reading("", v2.ValueTypeString, "null", ""))},
+		{"Valid - binary", addEventRequest(reading(testReadingId, v2.ValueTypeBinary, `""`,
+			`, "binaryValue": "AQIDBA==", "mediaType": "application/octet-stream"`))},
+		{"Valid - escaped and unicode strings", addEventRequest(reading(testReadingId, v2.ValueTypeString,
+			`"line\n\"quoted\" été café ☕"`, ""))},
+		{"Valid - tags and unknown fields", `{"apiVersion": "v2", "requestId": "", "extra": [1, -2.5e3, {"a": [true, false, null]}],
+			"event": {"apiVersion": "v2", "id": "7a1707f0-166f-4c4b-bc9d-1d54c74e0137", "deviceName": "Boiler",
+			"profileName": "BoilerProfile", "origin": 1602168089665565200, "tags": {"site": "Paris", "floor": null},
+			"readings": [` + reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, `, "unit": "°C"`) + `]}}`},
+		{"Valid - case-insensitive names", strings.Replace(addEventRequest(reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, "")),
+			`"deviceName"`, `"DEVICENAME"`, -1)},
+		{"Valid - lower case value type", addEventRequest(reading(testReadingId, "float64", `"21.5"`, ""))},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var addEvent dto.AddEventRequest
+			annotations, err := (&eventDecoder{names: make(map[string]string)}).decode([]byte(testCase.request), &addEvent)
+			require.NoError(t, err)
+			require.True(t, valid(&addEvent))
+
+			expected, expectedAnnotations, edgexErr := decodeAddEventRequest([]byte(testCase.request))
+			require.NoError(t, edgexErr)
+			assert.Equal(t, expected, addEvent, "the decoder should decode as encoding/json")
+			if expectedAnnotations == nil {
+				for _, annotation := range annotations {
+					assert.True(t, annotation.IsZero())
+				}
+			} else {
+				assert.Equal(t, expectedAnnotations, annotations)
+			}
+		})
+	}
+}
+
+func TestEventDecoder_Unexpected(t *testing.T) {
+	valid := addEventRequest(reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, ""))
+	tests := []struct {
+		name    string
+		request string
+	}{
+		{"Not an object", `[]`},
+		{"Truncated", valid[:len(valid)-10]},
+		{"Trailing data", valid + "{}"},
+		{"Origin not an integer", strings.Replace(valid, "1602168089665565200", "1.6e18", 1)},
+		{"Origin overflows", strings.Replace(valid, "1602168089665565200", "9223372036854775808", 1)},
+		{"Origin with leading zero", strings.Replace(valid, "1602168089665565200", "01602168089665565200", 1)},
+		{"Device name not a string", strings.Replace(valid, `"Boiler"`, "1", 1)},
+		{"Invalid escape", strings.Replace(valid, `"21.5"`, `"\x"`, 1)},
+		{"Invalid number skipped", strings.Replace(valid, `"apiVersion": "v2",`, `"extra": 1.e5, "apiVersion": "v2",`, 1)},
+		{"Invalid literal skipped", strings.Replace(valid, `"apiVersion": "v2",`, `"extra": nul, "apiVersion": "v2",`, 1)},
+		{"Unknown quality", addEventRequest(reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, `, "quality": "excellent"`))},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var addEvent dto.AddEventRequest
+			_, err := (&eventDecoder{names: make(map[string]string)}).decode([]byte(testCase.request), &addEvent)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValid_Invalid(t *testing.T) {
+	request := addEventRequest(reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, ""))
+	tests := []struct {
+		name    string
+		request string
+	}{
+		{"No api version", strings.Replace(request, `"apiVersion": "v2", "event"`, `"event"`, 1)},
+		{"Invalid request id", strings.Replace(request, `"apiVersion": "v2", "event"`, `"apiVersion": "v2", "requestId": "1", "event"`, 1)},
+		{"Upper case event id", strings.Replace(request, "7a1707f0-166f-4c4b-bc9d-1d54c74e0137", "7A1707F0-166F-4C4B-BC9D-1D54C74E0137", 1)},
+		{"Reserved characters in device name", strings.Replace(request, `"Boiler"`, `"Boiler 1"`, 1)},
+		{"No origin", strings.Replace(request, "1602168089665565200", "0", 1)},
+		{"No readings", addEventRequest()},
+		{"Unknown value type", addEventRequest(reading(testReadingId, "Decimal", `"21.5"`, ""))},
+		{"Empty value", addEventRequest(reading(testReadingId, v2.ValueTypeFloat64, `""`, ""))},
+		{"Binary without media type", addEventRequest(reading(testReadingId, v2.ValueTypeBinary, `""`, `, "binaryValue": "AQIDBA=="`))},
+		{"Binary with a zero byte", addEventRequest(reading(testReadingId, v2.ValueTypeBinary, `""`,
+			`, "binaryValue": "AQADBA==", "mediaType": "application/octet-stream"`))},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var addEvent dto.AddEventRequest
+			_, err := (&eventDecoder{names: make(map[string]string)}).decode([]byte(testCase.request), &addEvent)
+			require.NoError(t, err)
+			assert.False(t, valid(&addEvent))
+
+			_, _, edgexErr := decodeAddEventRequest([]byte(testCase.request))
+			assert.Error(t, edgexErr, "the request should be rejected by encoding/json too")
+		})
+	}
+}
+
+func TestEventDecoder_Interning(t *testing.T) {
+	decoder := &eventDecoder{names: make(map[string]string)}
+	request := []byte(addEventRequest(reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, "")))
+	var first, second dto.AddEventRequest
+	_, err := decoder.decode(request, &first)
+	require.NoError(t, err)
+	_, err = decoder.decode(bytes.Replace(request, []byte("21.5"), []byte("22.5"), 1), &second)
+	require.NoError(t, err)
+
+	assert.Equal(t, "22.5", second.Event.Readings[0].Value)
+	// the names of both requests should share their memory
+	assert.True(t, sameString(first.Event.DeviceName, second.Event.DeviceName))
+	assert.True(t, sameString(first.Event.Readings[0].ResourceName, second.Event.Readings[0].ResourceName))
+	assert.True(t, sameString(first.Event.DeviceName, second.Event.Readings[0].DeviceName))
+}
+
+// sameString tells whether a and b share their memory
+func sameString(a string, b string) bool {
+	return (*reflect.StringHeader)(unsafe.Pointer(&a)).Data == (*reflect.StringHeader)(unsafe.Pointer(&b)).Data
+}
+
+func TestReadAddEventRequest_Allocations(t *testing.T) {
+	request := []byte(benchmarkRequest())
+	decoded := testing.AllocsPerRun(100, func() {
+		decoder := decoders.Get().(*eventDecoder)
+		var addEvent dto.AddEventRequest
+		_, _ = decoder.decode(request, &addEvent)
+		_ = valid(&addEvent)
+		decoders.Put(decoder)
+	})
+	standard := testing.AllocsPerRun(100, func() {
+		_, _, _ = decodeAddEventRequest(request)
+	})
+	assert.Less(t, decoded, standard/10, "the decoder should allocate a tenth as much as encoding/json at most")
+}
+
+func benchmarkRequest() string {
+	readings := make([]string, 10)
+	for i := range readings {
+		readings[i] = reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, "")
+	}
+	return addEventRequest(readings...)
+}
+
+func BenchmarkReadAddEventRequest(b *testing.B) {
+	request := []byte(benchmarkRequest())
+	reader := NewEventRequestReader()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := reader.ReadAddEventRequest(bytes.NewReader(request)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeAddEventRequest(b *testing.B) {
+	request := []byte(benchmarkRequest())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := decodeAddEventRequest(request); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
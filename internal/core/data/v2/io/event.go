@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2020 IOTech Ltd
+// Copyright (C) 2020-2021 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -8,14 +8,29 @@ package io
 import (
 	"encoding/json"
 	"io"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bufferpool"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
 	dto "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+
+	"github.com/google/uuid"
+)
+
+const (
+	readingQuality   = "quality"
+	readingValue     = "value"
+	readingValueType = "valueType"
 )
 
 // EventReader unmarshals a request body into an Event type
 type EventReader interface {
-	ReadAddEventRequest(reader io.Reader) (dto.AddEventRequest, errors.EdgeX)
+	// ReadAddEventRequest returns the request along with the annotations of its readings, by reading id. The readings
+	// reported without an id are given one.
+	ReadAddEventRequest(reader io.Reader) (dto.AddEventRequest, map[string]quality.Annotation, errors.EdgeX)
 }
 
 // NewRequestReader returns a BodyReader capable of processing the request body
@@ -31,12 +46,124 @@ func NewJsonReader() jsonEventReader {
 	return jsonEventReader{}
 }
 
-// Read reads and converts the request's JSON event data into an Event struct
-func (jsonEventReader) ReadAddEventRequest(reader io.Reader) (dto.AddEventRequest, errors.EdgeX) {
+// Read reads and converts the request's JSON event data into an Event struct. The quality of the readings and their
+// null values, which the Event struct can't carry, are returned as annotations. The request is read into a pooled
+// buffer and decoded by an eventDecoder, encoding/json decoding the requests it can't, or that it doesn't find valid,
+// so that the errors are those of the standard decoding.
+func (jsonEventReader) ReadAddEventRequest(reader io.Reader) (dto.AddEventRequest, map[string]quality.Annotation, errors.EdgeX) {
+	buffer := bufferpool.Get()
+	defer bufferpool.Put(buffer)
+	if _, err := buffer.ReadFrom(reader); err != nil {
+		return dto.AddEventRequest{}, nil, errors.NewCommonEdgeX(errors.KindServerError, "event reading failed", err)
+	}
+
+	decoder := decoders.Get().(*eventDecoder)
+	defer decoders.Put(decoder)
 	var addEvent dto.AddEventRequest
-	err := json.NewDecoder(reader).Decode(&addEvent)
+	annotations, err := decoder.decode(buffer.Bytes(), &addEvent)
+	if err != nil || !valid(&addEvent) {
+		var edgexErr errors.EdgeX
+		addEvent, annotations, edgexErr = decodeAddEventRequest(buffer.Bytes())
+		if edgexErr != nil {
+			return addEvent, nil, edgexErr
+		}
+	}
+
+	byId := make(map[string]quality.Annotation)
+	for i := range addEvent.Event.Readings {
+		if addEvent.Event.Readings[i].Id == "" {
+			addEvent.Event.Readings[i].Id = uuid.New().String()
+		}
+		if i >= len(annotations) || annotations[i].IsZero() {
+			continue
+		}
+		if annotations[i].Null {
+			addEvent.Event.Readings[i].Value = ""
+		}
+		byId[addEvent.Event.Readings[i].Id] = annotations[i]
+	}
+	return addEvent, byId, nil
+}
+
+// decodeAddEventRequest decodes the JSON event request data with encoding/json, which validates it and normalizes
+// the value types of its readings through AddEventRequest.UnmarshalJSON
+func decodeAddEventRequest(data []byte) (dto.AddEventRequest, []quality.Annotation, errors.EdgeX) {
+	var addEvent dto.AddEventRequest
+	data, annotations, edgexErr := extractAnnotations(data)
+	if edgexErr != nil {
+		return addEvent, nil, edgexErr
+	}
+	err := json.Unmarshal(data, &addEvent)
 	if err != nil {
-		return addEvent, errors.NewCommonEdgeX(errors.KindContractInvalid, "event json decoding failed", err)
+		return addEvent, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "event json decoding failed", err)
+	}
+	return addEvent, annotations, nil
+}
+
+// extractAnnotations returns the annotations of the readings of the JSON event request data, by reading index, and
+// the data without them. Null values are replaced by a placeholder valid for the value type of the reading, so that
+// the request passes validation.
+func extractAnnotations(data []byte) ([]byte, []quality.Annotation, errors.EdgeX) {
+	var request map[string]json.RawMessage
+	var event map[string]json.RawMessage
+	var readings []map[string]json.RawMessage
+	if json.Unmarshal(data, &request) != nil || json.Unmarshal(request["event"], &event) != nil ||
+		json.Unmarshal(event["readings"], &readings) != nil {
+		// let the decoding of the request report the error
+		return data, nil, nil
+	}
+
+	annotations := make([]quality.Annotation, len(readings))
+	annotated := false
+	for i, reading := range readings {
+		if raw, ok := reading[readingQuality]; ok {
+			var q string
+			if err := json.Unmarshal(raw, &q); err != nil {
+				return nil, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "reading quality must be a string", err)
+			}
+			q = strings.ToLower(q)
+			if err := quality.Validate(q); err != nil {
+				return nil, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, err.Error(), nil)
+			}
+			annotations[i].Quality = q
+			delete(reading, readingQuality)
+			annotated = true
+		}
+		if raw, ok := reading[readingValue]; ok && string(raw) == "null" {
+			var valueType string
+			_ = json.Unmarshal(reading[readingValueType], &valueType)
+			placeholder, _ := json.Marshal(nullPlaceholder(valueType))
+			reading[readingValue] = placeholder
+			annotations[i].Null = true
+			annotated = true
+		}
+	}
+	if !annotated {
+		return data, nil, nil
+	}
+
+	var err error
+	if event["readings"], err = json.Marshal(readings); err == nil {
+		if request["event"], err = json.Marshal(event); err == nil {
+			data, err = json.Marshal(request)
+		}
+	}
+	if err != nil {
+		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, "event json encoding failed", err)
+	}
+	return data, annotations, nil
+}
+
+// nullPlaceholder returns a value valid for valueType, standing in for a null value until the request is validated
+func nullPlaceholder(valueType string) string {
+	switch {
+	case valueType == v2.ValueTypeBool:
+		return "false"
+	case valueType == v2.ValueTypeString:
+		return "null"
+	case strings.HasSuffix(valueType, "Array"):
+		return "[]"
+	default:
+		return "0"
 	}
-	return addEvent, nil
 }
@@ -0,0 +1,80 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testReadingId = "b9a7e1a4-3c2c-4d23-8a3b-7b0b1ab0a9f1"
+
+func addEventRequest(readings ...string) string {
+	return fmt.Sprintf(`{"apiVersion": "v2", "event": {"apiVersion": "v2", "id": "7a1707f0-166f-4c4b-bc9d-1d54c74e0137",
+		"deviceName": "Boiler", "profileName": "BoilerProfile", "origin": 1602168089665565200, "readings": [%s]}}`,
+		strings.Join(readings, ","))
+}
+
+func reading(id string, valueType string, value string, extra string) string {
+	return fmt.Sprintf(`{"apiVersion": "v2", "id": "%s", "deviceName": "Boiler", "resourceName": "Temperature",
+		"profileName": "BoilerProfile", "origin": 1602168089665565200, "valueType": "%s", "value": %s%s}`,
+		id, valueType, value, extra)
+}
+
+func TestReadAddEventRequest(t *testing.T) {
+	request := addEventRequest(
+		reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, `, "quality": "Uncertain"`),
+		reading("", v2.ValueTypeFloat64, "null", `, "quality": "bad"`),
+		reading("", v2.ValueTypeString, "null", ""),
+		reading("", v2.ValueTypeBool, `"true"`, ""),
+	)
+
+	addEvent, annotations, err := NewEventRequestReader().ReadAddEventRequest(strings.NewReader(request))
+
+	require.NoError(t, err)
+	readings := addEvent.Event.Readings
+	require.Len(t, readings, 4)
+	for _, r := range readings {
+		assert.NotEmpty(t, r.Id, "Readings should be given an id")
+	}
+	assert.Equal(t, testReadingId, readings[0].Id)
+	assert.Equal(t, "21.5", readings[0].Value)
+	assert.Empty(t, readings[1].Value, "Null value should be empty")
+	assert.Empty(t, readings[2].Value, "Null value should be empty")
+	assert.Equal(t, map[string]quality.Annotation{
+		readings[0].Id: {Quality: quality.Uncertain},
+		readings[1].Id: {Quality: quality.Bad, Null: true},
+		readings[2].Id: {Null: true},
+	}, annotations)
+}
+
+func TestReadAddEventRequest_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		request string
+	}{
+		{"Invalid - unknown quality", addEventRequest(reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, `, "quality": "excellent"`))},
+		{"Invalid - quality not a string", addEventRequest(reading(testReadingId, v2.ValueTypeFloat64, `"21.5"`, `, "quality": 1`))},
+		{"Invalid - not an event", `{"event": []}`},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, _, err := NewEventRequestReader().ReadAddEventRequest(strings.NewReader(testCase.request))
+
+			require.Error(t, err)
+			assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+		})
+	}
+}
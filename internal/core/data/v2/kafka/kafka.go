@@ -0,0 +1,254 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package kafka publishes the events core-data publishes on the message bus to Apache Kafka too, so that the backends
+// consuming Kafka receive them without an application service in between.
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"text/template"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// SASL mechanisms
+const (
+	Plain       = "PLAIN"
+	ScramSha256 = "SCRAM-SHA-256"
+	ScramSha512 = "SCRAM-SHA-512"
+)
+
+// Keys of the secret at KafkaInfo.SecretPath
+const (
+	// UsernameKey and PasswordKey are the SASL credentials
+	UsernameKey = "username"
+	PasswordKey = "password"
+	// CAKey is the PEM-encoded CA certificate the brokers' certificates must be signed by. The system roots are
+	// used when not set.
+	CAKey = "ca"
+	// CertKey and KeyKey are the PEM-encoded client certificate and private key presented to the brokers
+	CertKey = "cert"
+	KeyKey  = "key"
+)
+
+const (
+	// DefaultTopicTemplate publishes all the events to a single topic, the events of each device going to the same
+	// partition since they are keyed by device name
+	DefaultTopicTemplate = "edgex-events"
+
+	defaultBatchTimeout = time.Second
+)
+
+var (
+	compressions = []string{"", "none", "gzip", "snappy", "lz4", "zstd"}
+	requiredAcks = []string{"", "none", "one", "all"}
+	// invalidTopicChars are the characters not allowed in the Kafka topic names
+	invalidTopicChars = regexp.MustCompile(`[^a-zA-Z0-9._\-]`)
+)
+
+// maxTopicLength is the length of the longest Kafka topic name
+const maxTopicLength = 249
+
+// KafkaInfo configures the publishing of the events to Kafka
+type KafkaInfo struct {
+	// Enabled publishes the events to Kafka, in addition to the message bus
+	Enabled bool
+	// Brokers are the addresses, host:port, of the bootstrap brokers
+	Brokers []string
+	// TopicTemplate is the text/template of the topic of an event, given its ProfileName and DeviceName, e.g.
+	// "edgex.{{.ProfileName}}". The characters not allowed in topic names are replaced by _.
+	TopicTemplate string
+	// ClientId identifies core-data to the brokers
+	ClientId string
+	// SecretPath is the secret holding the SASL credentials and TLS certificates, with the keys UsernameKey,
+	// PasswordKey, CAKey, CertKey and KeyKey
+	SecretPath string
+	// SASLMechanism authenticates core-data with the credentials of SecretPath, one of PLAIN, SCRAM-SHA-256 and
+	// SCRAM-SHA-512. No authentication when empty.
+	SASLMechanism string
+	// TLS connects to the brokers over TLS
+	TLS bool
+	// BatchSize is the maximum number of events sent to a partition at once
+	BatchSize int
+	// BatchBytes is the maximum size in bytes of a batch
+	BatchBytes int64
+	// BatchTimeout is how long an incomplete batch waits for more events before being sent, e.g. "100ms"
+	BatchTimeout string
+	// Compression of the batches, one of none, gzip, snappy, lz4 and zstd
+	Compression string
+	// RequiredAcks is the acknowledgement required from the brokers, one of none, one and all
+	RequiredAcks string
+}
+
+// Validate checks the configuration is usable when enabled
+func (k KafkaInfo) Validate() error {
+	if !k.Enabled {
+		return nil
+	}
+	if len(k.Brokers) == 0 {
+		return errors.New("at least one Kafka broker is required")
+	}
+	if _, err := newTopicTemplate(k.TopicTemplate); err != nil {
+		return err
+	}
+	switch k.SASLMechanism {
+	case "", Plain, ScramSha256, ScramSha512:
+	default:
+		return fmt.Errorf("unknown SASLMechanism '%s', expected one of %v", k.SASLMechanism, []string{Plain, ScramSha256, ScramSha512})
+	}
+	if k.SASLMechanism != "" && k.SecretPath == "" {
+		return errors.New("SecretPath is required for the SASL credentials")
+	}
+	if !contains(compressions, k.Compression) {
+		return fmt.Errorf("unknown Compression '%s', expected one of %v", k.Compression, compressions[1:])
+	}
+	if !contains(requiredAcks, k.RequiredAcks) {
+		return fmt.Errorf("unknown RequiredAcks '%s', expected one of %v", k.RequiredAcks, requiredAcks[1:])
+	}
+	if _, err := k.batchTimeout(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (k KafkaInfo) batchTimeout() (time.Duration, error) {
+	if k.BatchTimeout == "" {
+		return defaultBatchTimeout, nil
+	}
+	d, err := time.ParseDuration(k.BatchTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid BatchTimeout: %s", err.Error())
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("BatchTimeout %s is not positive", k.BatchTimeout)
+	}
+	return d, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Message is a message written to Kafka
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// Writer writes the messages to Kafka, batching them in the background. The errors are reported asynchronously.
+type Writer interface {
+	WriteMessages(ctx context.Context, messages ...Message) error
+	Close() error
+}
+
+// topicFields are the fields of the topic template
+type topicFields struct {
+	ProfileName string
+	DeviceName  string
+}
+
+func newTopicTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultTopicTemplate
+	}
+	t, err := template.New("topic").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TopicTemplate: %s", err.Error())
+	}
+	if err = t.Execute(&bytes.Buffer{}, topicFields{}); err != nil {
+		return nil, fmt.Errorf("invalid TopicTemplate: %s", err.Error())
+	}
+	return t, nil
+}
+
+// Publisher publishes the events to the topics of the template. A nil Publisher publishes nothing.
+type Publisher struct {
+	writer Writer
+	topic  *template.Template
+	lc     logger.LoggingClient
+}
+
+// NewPublisher creates the publisher writing the events with writer, or returns nil when the publishing isn't
+// enabled
+func NewPublisher(info KafkaInfo, writer Writer, lc logger.LoggingClient) (*Publisher, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	topic, err := newTopicTemplate(info.TopicTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &Publisher{writer: writer, topic: topic, lc: lc}, nil
+}
+
+// Topic returns the topic of the events of the profile and device
+func (p *Publisher) Topic(profileName string, deviceName string) (string, error) {
+	var buffer bytes.Buffer
+	if err := p.topic.Execute(&buffer, topicFields{ProfileName: profileName, DeviceName: deviceName}); err != nil {
+		return "", err
+	}
+	topic := invalidTopicChars.ReplaceAllString(buffer.String(), "_")
+	if topic == "" || topic == "." || topic == ".." {
+		return "", fmt.Errorf("invalid topic '%s'", topic)
+	}
+	if len(topic) > maxTopicLength {
+		topic = topic[:maxTopicLength]
+	}
+	return topic, nil
+}
+
+// Publish writes data, the published AddEventRequest, to the topic of the event, keyed by device name so that the
+// events of a device are kept in order
+func (p *Publisher) Publish(ctx context.Context, data []byte, profileName string, deviceName string, correlationId string) {
+	if p == nil {
+		return
+	}
+	topic, err := p.Topic(profileName, deviceName)
+	if err != nil {
+		p.lc.Error(fmt.Sprintf("failed to publish the event of device %s to Kafka: %s", deviceName, err.Error()),
+			clients.CorrelationHeader, correlationId)
+		return
+	}
+	message := Message{
+		Topic: topic,
+		Key:   []byte(deviceName),
+		Value: data,
+		Headers: map[string]string{
+			clients.CorrelationHeader: correlationId,
+			clients.ContentType:       clients.ContentTypeJSON,
+		},
+	}
+	if err = p.writer.WriteMessages(ctx, message); err != nil {
+		p.lc.Error(fmt.Sprintf("failed to publish the event of device %s to Kafka topic %s: %s", deviceName, topic, err.Error()),
+			clients.CorrelationHeader, correlationId)
+		return
+	}
+	p.lc.Debug(fmt.Sprintf("Event of device %s queued for Kafka topic %s", deviceName, topic), clients.CorrelationHeader, correlationId)
+}
+
+// Close flushes the pending events and closes the writer
+func (p *Publisher) Close() error {
+	if p == nil {
+		return nil
+	}
+	return p.writer.Close()
+}
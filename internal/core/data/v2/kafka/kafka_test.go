@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type writerStub struct {
+	messages []Message
+	err      error
+	closed   bool
+}
+
+func (w *writerStub) WriteMessages(_ context.Context, messages ...Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.messages = append(w.messages, messages...)
+	return nil
+}
+
+func (w *writerStub) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestKafkaInfo_Validate(t *testing.T) {
+	valid := KafkaInfo{Enabled: true, Brokers: []string{"kafka:9092"}}
+	tests := []struct {
+		name   string
+		modify func(k *KafkaInfo)
+		valid  bool
+	}{
+		{"Valid", func(k *KafkaInfo) {}, true},
+		{"Valid - disabled", func(k *KafkaInfo) { k.Enabled = false; k.Brokers = nil }, true},
+		{"Valid - all settings", func(k *KafkaInfo) {
+			k.TopicTemplate = "edgex.{{.ProfileName}}.{{.DeviceName}}"
+			k.SASLMechanism = ScramSha512
+			k.SecretPath = "kafka"
+			k.Compression = "zstd"
+			k.RequiredAcks = "all"
+			k.BatchTimeout = "50ms"
+		}, true},
+		{"Invalid - no broker", func(k *KafkaInfo) { k.Brokers = nil }, false},
+		{"Invalid - topic template syntax", func(k *KafkaInfo) { k.TopicTemplate = "edgex.{{.ProfileName" }, false},
+		{"Invalid - topic template field", func(k *KafkaInfo) { k.TopicTemplate = "edgex.{{.Profile}}" }, false},
+		{"Invalid - SASL mechanism", func(k *KafkaInfo) { k.SASLMechanism = "GSSAPI"; k.SecretPath = "kafka" }, false},
+		{"Invalid - SASL without secret", func(k *KafkaInfo) { k.SASLMechanism = Plain }, false},
+		{"Invalid - compression", func(k *KafkaInfo) { k.Compression = "brotli" }, false},
+		{"Invalid - acks", func(k *KafkaInfo) { k.RequiredAcks = "two" }, false},
+		{"Invalid - batch timeout", func(k *KafkaInfo) { k.BatchTimeout = "0s" }, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			info := valid
+			testCase.modify(&info)
+			err := info.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestPublisher_Topic(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"default", "", DefaultTopicTemplate},
+		{"fields", "edgex.{{.ProfileName}}.{{.DeviceName}}", "edgex.thermostat.living-room"},
+		{"invalid characters", "edgex/{{.ProfileName}} {{.DeviceName}}", "edgex_thermostat_living-room"},
+		{"too long", "edgex." + strings.Repeat("x", 300), "edgex." + strings.Repeat("x", maxTopicLength-len("edgex."))},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			publisher, err := NewPublisher(KafkaInfo{Enabled: true, Brokers: []string{"kafka:9092"}, TopicTemplate: testCase.template},
+				&writerStub{}, logger.NewMockClient())
+			require.NoError(t, err)
+			topic, err := publisher.Topic("thermostat", "living-room")
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, topic)
+		})
+	}
+}
+
+func TestPublisher_Publish(t *testing.T) {
+	publisher, err := NewPublisher(KafkaInfo{}, &writerStub{}, logger.NewMockClient())
+	require.NoError(t, err)
+	assert.Nil(t, publisher, "the publisher should be disabled")
+	publisher.Publish(context.Background(), []byte("{}"), "thermostat", "living-room", "id")
+	assert.NoError(t, publisher.Close())
+
+	writer := &writerStub{}
+	info := KafkaInfo{Enabled: true, Brokers: []string{"kafka:9092"}, TopicTemplate: "edgex.{{.ProfileName}}"}
+	publisher, err = NewPublisher(info, writer, logger.NewMockClient())
+	require.NoError(t, err)
+	publisher.Publish(context.Background(), []byte(`{"event":{}}`), "thermostat", "living-room", "correlation-id")
+	require.Len(t, writer.messages, 1)
+	assert.Equal(t, Message{
+		Topic: "edgex.thermostat",
+		Key:   []byte("living-room"),
+		Value: []byte(`{"event":{}}`),
+		Headers: map[string]string{
+			clients.CorrelationHeader: "correlation-id",
+			clients.ContentType:       clients.ContentTypeJSON,
+		},
+	}, writer.messages[0])
+
+	writer.err = errors.New("kafka: write timeout")
+	publisher.Publish(context.Background(), []byte(`{"event":{}}`), "thermostat", "living-room", "correlation-id")
+	assert.Len(t, writer.messages, 1)
+
+	require.NoError(t, publisher.Close())
+	assert.True(t, writer.closed)
+}
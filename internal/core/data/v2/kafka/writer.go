@@ -0,0 +1,144 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+var (
+	compressionCodecs = map[string]kafkago.Compression{
+		"gzip":   kafkago.Gzip,
+		"snappy": kafkago.Snappy,
+		"lz4":    kafkago.Lz4,
+		"zstd":   kafkago.Zstd,
+	}
+	acks = map[string]kafkago.RequiredAcks{
+		"":     kafkago.RequireOne,
+		"none": kafkago.RequireNone,
+		"one":  kafkago.RequireOne,
+		"all":  kafkago.RequireAll,
+	}
+)
+
+// SecretProvider reads the secrets from the service's secret store, e.g. a bootstrap interfaces.SecretProvider
+type SecretProvider interface {
+	GetSecrets(path string, keys ...string) (map[string]string, error)
+}
+
+// writer is the Writer of a kafka-go Writer
+type writer struct {
+	writer *kafkago.Writer
+}
+
+// NewWriter creates the writer of the configured brokers, authenticated with the credentials and certificates of
+// the secret at info.SecretPath when TLS or SASL is enabled. The messages are sent asynchronously in batches, the failures being logged.
+func NewWriter(info KafkaInfo, secretProvider SecretProvider, lc logger.LoggingClient) (Writer, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	var secrets map[string]string
+	if info.SecretPath != "" && (info.TLS || info.SASLMechanism != "") {
+		var err error
+		if secrets, err = secretProvider.GetSecrets(info.SecretPath); err != nil {
+			return nil, fmt.Errorf("failed to read the Kafka credentials from secret %s: %s", info.SecretPath, err.Error())
+		}
+	}
+
+	transport := &kafkago.Transport{ClientID: info.ClientId}
+	if info.TLS {
+		config, err := tlsConfig(secrets)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Kafka TLS certificates in secret %s: %s", info.SecretPath, err.Error())
+		}
+		transport.TLS = config
+	}
+	mechanism, err := saslMechanism(info.SASLMechanism, secrets[UsernameKey], secrets[PasswordKey])
+	if err != nil {
+		return nil, err
+	}
+	transport.SASL = mechanism
+
+	batchTimeout, _ := info.batchTimeout()
+	w := &kafkago.Writer{
+		Addr:         kafkago.TCP(info.Brokers...),
+		Balancer:     &kafkago.Hash{},
+		BatchSize:    info.BatchSize,
+		BatchBytes:   info.BatchBytes,
+		BatchTimeout: batchTimeout,
+		RequiredAcks: acks[info.RequiredAcks],
+		Compression:  compressionCodecs[info.Compression],
+		Transport:    transport,
+		Async:        true,
+		Completion: func(messages []kafkago.Message, err error) {
+			if err != nil {
+				lc.Error(fmt.Sprintf("failed to publish %d event(s) to Kafka: %s", len(messages), err.Error()))
+			}
+		},
+	}
+	return &writer{writer: w}, nil
+}
+
+func (w *writer) WriteMessages(ctx context.Context, messages ...Message) error {
+	kafkaMessages := make([]kafkago.Message, len(messages))
+	for i, m := range messages {
+		kafkaMessages[i] = kafkago.Message{Topic: m.Topic, Key: m.Key, Value: m.Value}
+		for key, value := range m.Headers {
+			kafkaMessages[i].Headers = append(kafkaMessages[i].Headers, kafkago.Header{Key: key, Value: []byte(value)})
+		}
+	}
+	return w.writer.WriteMessages(ctx, kafkaMessages...)
+}
+
+func (w *writer) Close() error {
+	return w.writer.Close()
+}
+
+func tlsConfig(secrets map[string]string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if ca := secrets[CAKey]; ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, errors.New("invalid CA certificate")
+		}
+		config.RootCAs = pool
+	}
+	if secrets[CertKey] != "" || secrets[KeyKey] != "" {
+		certificate, err := tls.X509KeyPair([]byte(secrets[CertKey]), []byte(secrets[KeyKey]))
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+func saslMechanism(name string, username string, password string) (sasl.Mechanism, error) {
+	if name != "" && (username == "" || password == "") {
+		return nil, fmt.Errorf("the %s and %s of the Kafka SASL credentials are required", UsernameKey, PasswordKey)
+	}
+	switch name {
+	case Plain:
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case ScramSha256:
+		return scram.Mechanism(scram.SHA256, username, password)
+	case ScramSha512:
+		return scram.Mechanism(scram.SHA512, username, password)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,226 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package onchange provides the policies publishing the readings of some resources only when their value changes,
+// beyond a deadband for the numeric values, so that the values of the slow-changing sensors don't use the bandwidth
+// of the north-bound consumers while they are steady.
+package onchange
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// PolicyInfo configures the on-change publishing of the readings of some resources
+type PolicyInfo struct {
+	// ProfileName restricts the policy to the events of the profile, the events of all the profiles when empty
+	ProfileName string
+	// ResourceNames are the resources of the readings the policy applies to, all the readings of the events when
+	// empty
+	ResourceNames []string
+	// Deadband is the change of a numeric value, since the last value published, below which the value isn't
+	// published. Any change is published with 0, as for the other value types.
+	Deadband float64
+	// MinSpan is the shortest time between two values published for a reading, e.g. "10s". The changes in between
+	// aren't published.
+	MinSpan string
+	// Heartbeat is the time after which the value of a reading is published whether it changed or not, e.g. "15m",
+	// so that the consumers know the sensor is alive. The unchanged values are never published when empty.
+	Heartbeat string
+}
+
+// OnChangeInfo configures the on-change publishing of the events
+type OnChangeInfo struct {
+	// Enabled turns on the policies. All the readings are published when disabled.
+	Enabled bool
+	// Policies are matched in order to each reading, the first matching one applying. The readings no policy matches
+	// are always published.
+	Policies []PolicyInfo
+}
+
+type policy struct {
+	profileName string
+	resources   map[string]bool
+	deadband    float64
+	minSpan     time.Duration
+	heartbeat   time.Duration
+}
+
+func newPolicy(info PolicyInfo) (policy, error) {
+	p := policy{profileName: info.ProfileName, deadband: info.Deadband}
+	if info.ProfileName == "" && len(info.ResourceNames) == 0 {
+		return p, errors.New("at least the ProfileName or one of the ResourceNames must be specified")
+	}
+	if info.Deadband < 0 || math.IsNaN(info.Deadband) {
+		return p, fmt.Errorf("invalid Deadband %v", info.Deadband)
+	}
+	if len(info.ResourceNames) > 0 {
+		p.resources = make(map[string]bool, len(info.ResourceNames))
+		for _, name := range info.ResourceNames {
+			if name = strings.TrimSpace(name); name != "" {
+				p.resources[name] = true
+			}
+		}
+	}
+	var err error
+	if info.MinSpan != "" {
+		if p.minSpan, err = parsePositiveDuration(info.MinSpan); err != nil {
+			return p, fmt.Errorf("invalid MinSpan: %s", err.Error())
+		}
+	}
+	if info.Heartbeat != "" {
+		if p.heartbeat, err = parsePositiveDuration(info.Heartbeat); err != nil {
+			return p, fmt.Errorf("invalid Heartbeat: %s", err.Error())
+		}
+	}
+	return p, nil
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s is not positive", value)
+	}
+	return d, nil
+}
+
+func (p policy) matches(profileName string, resourceName string) bool {
+	return (p.profileName == "" || p.profileName == profileName) && (p.resources == nil || p.resources[resourceName])
+}
+
+// published is the last value published for a reading
+type published struct {
+	reading dtos.BaseReading
+	at      time.Time
+}
+
+// Filter removes the readings whose value didn't change enough from the published events
+type Filter struct {
+	policies []policy
+	now      func() time.Time
+
+	mutex sync.Mutex
+	// last is the last value published for each resource of each device
+	last map[string]published
+}
+
+// NewFilter creates the Filter of the policies of info, or returns nil when the on-change publishing is disabled
+func NewFilter(info OnChangeInfo) (*Filter, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+
+	f := &Filter{policies: make([]policy, len(info.Policies)), now: time.Now, last: make(map[string]published)}
+	for i, policyInfo := range info.Policies {
+		p, err := newPolicy(policyInfo)
+		if err != nil {
+			return nil, fmt.Errorf("policy %d: %s", i+1, err.Error())
+		}
+		f.policies[i] = p
+	}
+	return f, nil
+}
+
+// Filter returns a copy of event without the readings not to publish, leaving event unchanged, and whether any
+// reading was removed. The signature of an event losing readings is removed since it no longer matches them. A nil
+// Filter removes nothing.
+func (f *Filter) Filter(event dtos.Event) (dtos.Event, bool) {
+	if f == nil {
+		return event, false
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	now := f.now()
+	var readings []dtos.BaseReading
+	for _, reading := range event.Readings {
+		if f.publish(event.ProfileName, event.DeviceName, reading, now) {
+			readings = append(readings, reading)
+		}
+	}
+	if len(readings) == len(event.Readings) {
+		return event, false
+	}
+
+	tags := make(map[string]string, len(event.Tags))
+	for name, value := range event.Tags {
+		if name != eventsig.SignatureTag {
+			tags[name] = value
+		}
+	}
+	event.Tags = tags
+	event.Readings = readings
+	return event, true
+}
+
+// publish tells whether the reading is published, recording its value when it is
+func (f *Filter) publish(profileName string, deviceName string, reading dtos.BaseReading, now time.Time) bool {
+	var p *policy
+	for i := range f.policies {
+		if f.policies[i].matches(profileName, reading.ResourceName) {
+			p = &f.policies[i]
+			break
+		}
+	}
+	if p == nil {
+		return true
+	}
+
+	key := deviceName + "/" + reading.ResourceName
+	last, ok := f.last[key]
+	if ok {
+		elapsed := now.Sub(last.at)
+		heartbeat := p.heartbeat > 0 && elapsed >= p.heartbeat
+		if !heartbeat && (elapsed < p.minSpan || !changed(last.reading, reading, p.deadband)) {
+			return false
+		}
+	}
+	f.last[key] = published{reading: reading, at: now}
+	return true
+}
+
+// changed tells whether the value of reading differs from the value of last, by more than deadband for the numeric
+// values
+func changed(last dtos.BaseReading, reading dtos.BaseReading, deadband float64) bool {
+	if last.ValueType != reading.ValueType {
+		return true
+	}
+	if reading.ValueType == v2.ValueTypeBinary {
+		return !bytes.Equal(last.BinaryValue, reading.BinaryValue) || last.MediaType != reading.MediaType
+	}
+	if deadband > 0 && numeric(reading.ValueType) {
+		lastValue, lastErr := strconv.ParseFloat(last.Value, 64)
+		value, err := strconv.ParseFloat(reading.Value, 64)
+		if lastErr == nil && err == nil {
+			return math.Abs(value-lastValue) > deadband
+		}
+	}
+	return last.Value != reading.Value
+}
+
+func numeric(valueType string) bool {
+	switch valueType {
+	case v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64,
+		v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64,
+		v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package onchange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent(deviceName string, temperature string, state string) dtos.Event {
+	event := dtos.NewEvent("thermostat", deviceName)
+	event.Tags = map[string]string{eventsig.SignatureTag: "signature", "site": "plant-1"}
+	// the device services send the float values in E notation
+	event.Readings = append(event.Readings, dtos.BaseReading{ResourceName: "temperature", ValueType: v2.ValueTypeFloat32,
+		SimpleReading: dtos.SimpleReading{Value: temperature}})
+	_ = event.AddSimpleReading("state", v2.ValueTypeString, state)
+	event.AddBinaryReading("snapshot", []byte{1, 2, 3}, "image/jpeg")
+	return event
+}
+
+func resourceNames(event dtos.Event) []string {
+	var names []string
+	for _, reading := range event.Readings {
+		names = append(names, reading.ResourceName)
+	}
+	return names
+}
+
+func TestNewFilter(t *testing.T) {
+	filter, err := NewFilter(OnChangeInfo{Policies: []PolicyInfo{{MinSpan: "often"}}})
+	require.NoError(t, err)
+	assert.Nil(t, filter, "the on-change publishing should be disabled")
+	event := testEvent("thermostat-1", "2.15e+01", "on")
+	filtered, removed := filter.Filter(event)
+	assert.False(t, removed)
+	assert.Equal(t, event, filtered)
+
+	tests := []struct {
+		name   string
+		policy PolicyInfo
+		valid  bool
+	}{
+		{"Valid - profile", PolicyInfo{ProfileName: "thermostat"}, true},
+		{"Valid - resources", PolicyInfo{ResourceNames: []string{"temperature"}, Deadband: 0.5, MinSpan: "10s", Heartbeat: "15m"}, true},
+		{"Invalid - no profile nor resource", PolicyInfo{Deadband: 0.5}, false},
+		{"Invalid - negative deadband", PolicyInfo{ProfileName: "thermostat", Deadband: -1}, false},
+		{"Invalid - min span", PolicyInfo{ProfileName: "thermostat", MinSpan: "often"}, false},
+		{"Invalid - heartbeat", PolicyInfo{ProfileName: "thermostat", Heartbeat: "0s"}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewFilter(OnChangeInfo{Enabled: true, Policies: []PolicyInfo{testCase.policy}})
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	filter, err := NewFilter(OnChangeInfo{Enabled: true, Policies: []PolicyInfo{
+		{ProfileName: "camera", ResourceNames: []string{"snapshot"}},
+		{ResourceNames: []string{"temperature"}, Deadband: 0.5, Heartbeat: "15m"},
+		{ProfileName: "thermostat", MinSpan: "10s"},
+	}})
+	require.NoError(t, err)
+	now := time.Now()
+	filter.now = func() time.Time { return now }
+
+	tests := []struct {
+		name        string
+		deviceName  string
+		temperature string
+		state       string
+		elapsed     time.Duration
+		expected    []string
+	}{
+		{"first values", "thermostat-1", "2.15e+01", "on", 0, []string{"temperature", "state", "snapshot"}},
+		{"other device", "thermostat-2", "2.15e+01", "on", 0, []string{"temperature", "state", "snapshot"}},
+		{"unchanged", "thermostat-1", "2.15e+01", "on", time.Minute, nil},
+		{"within deadband", "thermostat-1", "2.19e+01", "on", time.Second, nil},
+		{"drift beyond deadband", "thermostat-1", "2.21e+01", "off", time.Second, []string{"temperature", "state"}},
+		{"changed within min span", "thermostat-1", "2.21e+01", "on", 5 * time.Second, nil},
+		{"changed after min span", "thermostat-1", "2.21e+01", "on", 5 * time.Second, []string{"state"}},
+		{"heartbeat", "thermostat-1", "2.21e+01", "on", 15 * time.Minute, []string{"temperature"}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			now = now.Add(testCase.elapsed)
+			event := testEvent(testCase.deviceName, testCase.temperature, testCase.state)
+			filtered, removed := filter.Filter(event)
+			assert.Equal(t, testCase.expected, resourceNames(filtered))
+			assert.True(t, removed == (len(testCase.expected) < 3))
+			assert.Len(t, event.Readings, 3, "the original event should be left unchanged")
+			if removed {
+				assert.NotContains(t, filtered.Tags, eventsig.SignatureTag)
+				assert.Equal(t, "plant-1", filtered.Tags["site"])
+				assert.Contains(t, event.Tags, eventsig.SignatureTag)
+			}
+		})
+	}
+}
+
+func TestChanged(t *testing.T) {
+	reading := func(valueType string, value string) dtos.BaseReading {
+		return dtos.BaseReading{ValueType: valueType, SimpleReading: dtos.SimpleReading{Value: value}}
+	}
+	binary := func(value []byte, mediaType string) dtos.BaseReading {
+		return dtos.BaseReading{ValueType: v2.ValueTypeBinary, BinaryReading: dtos.BinaryReading{BinaryValue: value, MediaType: mediaType}}
+	}
+
+	tests := []struct {
+		name     string
+		last     dtos.BaseReading
+		reading  dtos.BaseReading
+		deadband float64
+		changed  bool
+	}{
+		{"same integer", reading(v2.ValueTypeInt32, "10"), reading(v2.ValueTypeInt32, "10"), 0, false},
+		{"different integer", reading(v2.ValueTypeInt32, "10"), reading(v2.ValueTypeInt32, "11"), 0, true},
+		{"integer within deadband", reading(v2.ValueTypeUint16, "10"), reading(v2.ValueTypeUint16, "12"), 2, false},
+		{"integer beyond deadband", reading(v2.ValueTypeUint16, "10"), reading(v2.ValueTypeUint16, "7"), 2, true},
+		{"float within deadband", reading(v2.ValueTypeFloat64, "1.5e1"), reading(v2.ValueTypeFloat64, "15.05"), 0.1, false},
+		{"string ignores deadband", reading(v2.ValueTypeString, "1"), reading(v2.ValueTypeString, "2"), 5, true},
+		{"value type changed", reading(v2.ValueTypeInt32, "10"), reading(v2.ValueTypeInt64, "10"), 0, true},
+		{"same binary", binary([]byte{1, 2}, "image/png"), binary([]byte{1, 2}, "image/png"), 0, false},
+		{"different binary", binary([]byte{1, 2}, "image/png"), binary([]byte{1, 3}, "image/png"), 0, true},
+		{"different media type", binary([]byte{1, 2}, "image/png"), binary([]byte{1, 2}, "image/jpeg"), 0, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.changed, changed(testCase.last, testCase.reading, testCase.deadband))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package provenance stamps the events ingested by core-data with the identity of the gateway, the version of
+// core-data and the time of their ingestion, in reserved tags, so that the backends collecting the events of many
+// gateways can attribute the data streams to their gateway and de-duplicate them.
+package provenance
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/v2/redaction"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// Default names of the reserved tags
+const (
+	DefaultGatewayIdTag = "gatewayId"
+	DefaultVersionTag   = "coreDataVersion"
+	DefaultIngestedTag  = "ingested"
+)
+
+// ProvenanceInfo configures the provenance tags of the events
+type ProvenanceInfo struct {
+	// Enabled turns on the stamping of the events. The events keep the tags they are added with when disabled.
+	Enabled bool
+	// GatewayId identifies the gateway in the events, the host name when empty. It must be unique among the gateways
+	// sending their events to a same backend.
+	GatewayId string
+	// GatewayIdTag is the name of the tag carrying GatewayId, DefaultGatewayIdTag when empty
+	GatewayIdTag string
+	// VersionTag is the name of the tag carrying the version of core-data, DefaultVersionTag when empty
+	VersionTag string
+	// IngestedTag is the name of the tag carrying the time the event was ingested, in milliseconds since the epoch,
+	// DefaultIngestedTag when empty
+	IngestedTag string
+}
+
+// Stamper sets the provenance tags of the events
+type Stamper struct {
+	gatewayId    string
+	version      string
+	gatewayIdTag string
+	versionTag   string
+	ingestedTag  string
+	now          func() int64
+}
+
+// NewStamper creates the stamper of the events ingested by the given version of core-data, or returns nil when
+// the stamping is disabled.
+func NewStamper(info ProvenanceInfo, version string) (*Stamper, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+
+	s := &Stamper{
+		gatewayId:    info.GatewayId,
+		version:      version,
+		gatewayIdTag: defaultName(info.GatewayIdTag, DefaultGatewayIdTag),
+		versionTag:   defaultName(info.VersionTag, DefaultVersionTag),
+		ingestedTag:  defaultName(info.IngestedTag, DefaultIngestedTag),
+		now:          common.MakeTimestamp,
+	}
+	if s.gatewayId == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("GatewayId is empty and the host name is unknown: %s", err.Error())
+		}
+		s.gatewayId = hostname
+	}
+
+	names := []string{s.gatewayIdTag, s.versionTag, s.ingestedTag}
+	// the gateway and version tags are indexed, so the names follow the rules of the indexed tags
+	if err := (tags.IndexInfo{Tags: names}).Validate(); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			return nil, fmt.Errorf("tag %s is set more than once", name)
+		}
+		if name == eventsig.SignatureTag || name == redaction.RedactedTag {
+			return nil, fmt.Errorf("tag %s is reserved by core-data", name)
+		}
+		seen[name] = true
+	}
+	return s, nil
+}
+
+func defaultName(name string, defaultName string) string {
+	if name == "" {
+		return defaultName
+	}
+	return name
+}
+
+// GatewayId returns the identity of the gateway stamped in the events
+func (s *Stamper) GatewayId() string {
+	return s.gatewayId
+}
+
+// Stamp sets the provenance tags of event, replacing those it was added with so that they can't be spoofed by the
+// device services. A nil Stamper leaves event unchanged.
+func (s *Stamper) Stamp(event *dtos.Event) {
+	if s == nil {
+		return
+	}
+	if event.Tags == nil {
+		event.Tags = make(map[string]string)
+	}
+	event.Tags[s.gatewayIdTag] = s.gatewayId
+	event.Tags[s.versionTag] = s.version
+	event.Tags[s.ingestedTag] = strconv.FormatInt(s.now(), 10)
+}
+
+// Ingested returns the ingestion time stamped in eventTags, or 0 when it isn't stamped or the Stamper is nil
+func (s *Stamper) Ingested(eventTags map[string]string) int64 {
+	if s == nil {
+		return 0
+	}
+	ingested, err := strconv.ParseInt(eventTags[s.ingestedTag], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return ingested
+}
+
+// IndexedTags returns the names of the tags that are indexed for the events to be queried by gateway and version.
+// The ingestion time isn't indexed, since its values are all different; the events are created at their ingestion
+// time instead, so that the time range queries filter on it.
+func (s *Stamper) IndexedTags() []string {
+	if s == nil {
+		return nil
+	}
+	return []string{s.gatewayIdTag, s.versionTag}
+}
@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStamper(t *testing.T) {
+	stamper, err := NewStamper(ProvenanceInfo{GatewayId: "gw-1"}, "2.0.0")
+	require.NoError(t, err)
+	assert.Nil(t, stamper, "provenance stamping should be disabled")
+
+	stamper, err = NewStamper(ProvenanceInfo{Enabled: true}, "2.0.0")
+	require.NoError(t, err)
+	hostname, _ := os.Hostname()
+	assert.Equal(t, hostname, stamper.GatewayId(), "the gateway id should default to the host name")
+	assert.Equal(t, []string{DefaultGatewayIdTag, DefaultVersionTag}, stamper.IndexedTags())
+
+	tests := []struct {
+		name string
+		info ProvenanceInfo
+	}{
+		{"Invalid - tag name with separator", ProvenanceInfo{Enabled: true, GatewayIdTag: "edgex:gateway"}},
+		{"Invalid - same tag twice", ProvenanceInfo{Enabled: true, VersionTag: DefaultIngestedTag}},
+		{"Invalid - signature tag", ProvenanceInfo{Enabled: true, IngestedTag: eventsig.SignatureTag}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewStamper(testCase.info, "2.0.0")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStamp(t *testing.T) {
+	stamper, err := NewStamper(ProvenanceInfo{Enabled: true, GatewayId: "gw-1", GatewayIdTag: "gateway"}, "2.0.0")
+	require.NoError(t, err)
+	stamper.now = func() int64 { return 1609459200000 }
+
+	event := dtos.NewEvent("profile", "device")
+	event.Tags = map[string]string{"site": "plant-1", "gateway": "spoofed"}
+	stamper.Stamp(&event)
+
+	assert.Equal(t, map[string]string{
+		"site":             "plant-1",
+		"gateway":          "gw-1",
+		DefaultVersionTag:  "2.0.0",
+		DefaultIngestedTag: "1609459200000",
+	}, event.Tags)
+	assert.Equal(t, int64(1609459200000), stamper.Ingested(event.Tags))
+	assert.Equal(t, int64(0), stamper.Ingested(map[string]string{"site": "plant-1"}))
+
+	untagged := dtos.NewEvent("profile", "device")
+	stamper.Stamp(&untagged)
+	assert.Equal(t, "gw-1", untagged.Tags["gateway"])
+}
+
+func TestNilStamper(t *testing.T) {
+	var stamper *Stamper
+	event := dtos.NewEvent("profile", "device")
+	stamper.Stamp(&event)
+
+	assert.Nil(t, event.Tags)
+	assert.Equal(t, int64(0), stamper.Ingested(map[string]string{DefaultIngestedTag: "1609459200000"}))
+	assert.Nil(t, stamper.IndexedTags())
+}
@@ -0,0 +1,199 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redaction provides the rules redacting the readings of the events core-data publishes, so that the
+// readings carrying personal data are kept local while the other readings flow north.
+package redaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// Rule actions
+const (
+	// Drop removes the readings from the published event.
+	Drop = "Drop"
+	// Mask replaces the value of the readings by the Mask of the rule.
+	Mask = "Mask"
+	// Hash replaces the value of the readings by its hex encoded SHA-256 hash, so that the readings of a same value
+	// can still be counted and grouped.
+	Hash = "Hash"
+
+	// DefaultMask is the value of the masked readings when the rule doesn't set one
+	DefaultMask = "***"
+	// RedactedTag is the event tag listing, comma separated, the resources of the readings redacted from the event
+	RedactedTag = "redacted"
+)
+
+// RuleInfo configures the redaction of the readings of some resources, or of the readings of some events.
+type RuleInfo struct {
+	// ResourceNames are the resources of the readings redacted, all the readings of the matched events when empty.
+	ResourceNames []string
+	// Tags restricts the rule to the events carrying all these tags, by tag name with the value it must have, or
+	// with an empty value for any value.
+	Tags map[string]string
+	// Action is what happens to the redacted readings, one of Drop, Mask and Hash.
+	Action string
+	// Mask is the value of the readings masked by the rule, DefaultMask when empty.
+	Mask string
+}
+
+// RedactionInfo configures the redaction of the published events.
+type RedactionInfo struct {
+	// Enabled turns on the rules. The events are published as persisted when disabled.
+	Enabled bool
+	// Rules are applied in order to each published event.
+	Rules []RuleInfo
+}
+
+type rule struct {
+	resources map[string]bool
+	tags      map[string]string
+	action    string
+	mask      string
+}
+
+func newRule(info RuleInfo) (rule, error) {
+	r := rule{tags: info.Tags, action: info.Action, mask: info.Mask}
+	switch info.Action {
+	case Drop, Hash:
+	case Mask:
+		if r.mask == "" {
+			r.mask = DefaultMask
+		}
+	default:
+		return r, fmt.Errorf("unknown action '%s', expected one of %v", info.Action, []string{Drop, Mask, Hash})
+	}
+	if len(info.ResourceNames) > 0 {
+		r.resources = make(map[string]bool, len(info.ResourceNames))
+		for _, name := range info.ResourceNames {
+			if name = strings.TrimSpace(name); name != "" {
+				r.resources[name] = true
+			}
+		}
+	}
+	if len(r.resources) == 0 && len(r.tags) == 0 {
+		return r, errors.New("at least one resource name or tag must be specified")
+	}
+	return r, nil
+}
+
+// matches tells whether the rule applies to the events tagged with tags
+func (r rule) matches(tags map[string]string) bool {
+	for name, value := range r.tags {
+		actual, ok := tags[name]
+		if !ok || (value != "" && actual != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Redactor applies the redaction rules to the published events.
+type Redactor struct {
+	rules []rule
+}
+
+// NewRedactor creates the Redactor of the rules of info, or returns nil when redaction is disabled.
+func NewRedactor(info RedactionInfo) (*Redactor, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+
+	redactor := &Redactor{rules: make([]rule, len(info.Rules))}
+	for i, ruleInfo := range info.Rules {
+		r, err := newRule(ruleInfo)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %s", i+1, err.Error())
+		}
+		redactor.rules[i] = r
+	}
+	return redactor, nil
+}
+
+// Redact returns a copy of event with the readings redacted by the rules, leaving event unchanged, and whether any
+// reading was redacted. The redacted event is tagged with RedactedTag, and its signature is removed since it no
+// longer matches its readings. A nil Redactor redacts nothing.
+func (r *Redactor) Redact(event dtos.Event) (dtos.Event, bool) {
+	if r == nil {
+		return event, false
+	}
+
+	var readings []dtos.BaseReading
+	var redacted []string
+	for _, reading := range event.Readings {
+		keep := true
+		for _, rule := range r.rules {
+			if !rule.matches(event.Tags) || (rule.resources != nil && !rule.resources[reading.ResourceName]) {
+				continue
+			}
+			switch rule.action {
+			case Drop:
+				keep = false
+			case Mask:
+				reading = replaceValue(reading, rule.mask)
+			case Hash:
+				reading = replaceValue(reading, hash(reading))
+			}
+			redacted = appendDistinct(redacted, reading.ResourceName)
+			if !keep {
+				break
+			}
+		}
+		if keep {
+			readings = append(readings, reading)
+		}
+	}
+	if len(redacted) == 0 {
+		return event, false
+	}
+
+	tags := make(map[string]string, len(event.Tags)+1)
+	for name, value := range event.Tags {
+		if name != eventsig.SignatureTag {
+			tags[name] = value
+		}
+	}
+	tags[RedactedTag] = strings.Join(redacted, ",")
+	event.Tags = tags
+	event.Readings = readings
+	return event, true
+}
+
+// replaceValue returns reading with value as its string value, in place of its value or binary value
+func replaceValue(reading dtos.BaseReading, value string) dtos.BaseReading {
+	reading.ValueType = v2.ValueTypeString
+	reading.Value = value
+	reading.BinaryValue = nil
+	reading.MediaType = ""
+	return reading
+}
+
+func hash(reading dtos.BaseReading) string {
+	value := []byte(reading.Value)
+	if reading.ValueType == v2.ValueTypeBinary {
+		value = reading.BinaryValue
+	}
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:])
+}
+
+func appendDistinct(names []string, name string) []string {
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
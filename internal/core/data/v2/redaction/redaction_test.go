@@ -0,0 +1,97 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redaction
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/eventsig"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent(tags map[string]string) dtos.Event {
+	event := dtos.NewEvent("camera", "entrance")
+	event.Tags = tags
+	_ = event.AddSimpleReading("badge", v2.ValueTypeString, "jdoe")
+	_ = event.AddSimpleReading("temperature", v2.ValueTypeFloat32, float32(21.5))
+	event.AddBinaryReading("snapshot", []byte{1, 2, 3}, "image/jpeg")
+	return event
+}
+
+func TestNewRedactor(t *testing.T) {
+	redactor, err := NewRedactor(RedactionInfo{Rules: []RuleInfo{{Action: "Encrypt"}}})
+	require.NoError(t, err)
+	assert.Nil(t, redactor, "redaction should be disabled")
+
+	tests := []struct {
+		name  string
+		rule  RuleInfo
+		valid bool
+	}{
+		{"Valid - resource", RuleInfo{ResourceNames: []string{"badge"}, Action: Drop}, true},
+		{"Valid - tag", RuleInfo{Tags: map[string]string{"pii": ""}, Action: Mask}, true},
+		{"Invalid - unknown action", RuleInfo{ResourceNames: []string{"badge"}, Action: "Encrypt"}, false},
+		{"Invalid - no resource nor tag", RuleInfo{ResourceNames: []string{" "}, Action: Hash}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewRedactor(RedactionInfo{Enabled: true, Rules: []RuleInfo{testCase.rule}})
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	redactor, err := NewRedactor(RedactionInfo{Enabled: true, Rules: []RuleInfo{
+		{ResourceNames: []string{"badge"}, Action: Hash},
+		{ResourceNames: []string{"snapshot"}, Action: Drop},
+		{Tags: map[string]string{"site": "hospital"}, Action: Mask},
+	}})
+	require.NoError(t, err)
+
+	original := testEvent(map[string]string{"site": "plant-1", eventsig.SignatureTag: "signature"})
+	event, redacted := redactor.Redact(original)
+	require.True(t, redacted)
+	require.Len(t, event.Readings, 2)
+	assert.Equal(t, "badge", event.Readings[0].ResourceName)
+	assert.Len(t, event.Readings[0].Value, 64)
+	assert.Equal(t, original.Readings[1], event.Readings[1])
+	assert.Equal(t, map[string]string{"site": "plant-1", RedactedTag: "badge,snapshot"}, event.Tags)
+
+	assert.Len(t, original.Readings, 3, "the event should not be changed")
+	assert.Equal(t, "jdoe", original.Readings[0].Value, "the event should not be changed")
+	assert.Contains(t, original.Tags, eventsig.SignatureTag, "the event should not be changed")
+
+	other, _ := redactor.Redact(testEvent(nil))
+	assert.Equal(t, event.Readings[0].Value, other.Readings[0].Value, "the same values should have the same hash")
+
+	event, redacted = redactor.Redact(testEvent(map[string]string{"site": "hospital"}))
+	require.True(t, redacted)
+	require.Len(t, event.Readings, 2)
+	assert.Equal(t, DefaultMask, event.Readings[0].Value)
+	assert.Equal(t, DefaultMask, event.Readings[1].Value)
+	assert.Equal(t, v2.ValueTypeString, event.Readings[1].ValueType)
+	assert.Equal(t, "badge,temperature,snapshot", event.Tags[RedactedTag])
+
+	redactor, err = NewRedactor(RedactionInfo{Enabled: true, Rules: []RuleInfo{{ResourceNames: []string{"door"}, Action: Drop}}})
+	require.NoError(t, err)
+	original = testEvent(nil)
+	event, redacted = redactor.Redact(original)
+	assert.False(t, redacted)
+	assert.Equal(t, original, event)
+
+	var disabled *Redactor
+	_, redacted = disabled.Redact(original)
+	assert.False(t, redacted)
+}
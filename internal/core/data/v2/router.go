@@ -3,12 +3,23 @@ package v2
 import (
 	"net/http"
 
+	dataContainer "github.com/edgexfoundry/edgex-go/internal/core/data/container"
 	dataController "github.com/edgexfoundry/edgex-go/internal/core/data/v2/controller/http"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/openapi"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/schema"
 	commonController "github.com/edgexfoundry/edgex-go/internal/pkg/v2/controller/http"
 
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	v2Constant "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
 
 	"github.com/gorilla/mux"
 )
@@ -21,19 +32,47 @@ func LoadRestRoutes(r *mux.Router, dic *di.Container) {
 	r.HandleFunc(v2Constant.ApiVersionRoute, cc.Version).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiConfigRoute, cc.Config).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiMetricsRoute, cc.Metrics).Methods(http.MethodGet)
+	r.HandleFunc(correlation.ApiTraceRoute, cc.Trace).Methods(http.MethodGet)
+	r.HandleFunc(features.ApiCapabilitiesRoute, cc.Capabilities).Methods(http.MethodGet)
+
+	// Schemas
+	schemas := schema.NewRegistry(bootstrapContainer.LoggingClientFrom(dic.Get))
+	schemas.Add(responseDTO.EventResponse{}, responseDTO.MultiEventsResponse{}, quality.MultiReadingsResponse{},
+		jobs.JobResponse{}, jobs.MultiJobsResponse{})
+	schema.LoadRestRoutes(r, schemas)
+
+	// OpenAPI document
+	openapi.LoadRestRoutes(r, bootstrapContainer.LoggingClientFrom(dic.Get), openapi.CoreDataSpec,
+		dataContainer.ConfigurationFrom(dic.Get).JWTAuth.Enabled)
 
 	// Events
 	ec := dataController.NewEventController(dic)
-	r.HandleFunc(v2Constant.ApiEventProfileNameDeviceNameRoute, ec.AddEvent).Methods(http.MethodPost)
+	addEvent := schemas.ValidateRequest(requests.AddEventRequest{}, ec.AddEvent)
+	quality.ExtendEventSchema(schemas.Schema(schema.Name(requests.AddEventRequest{})))
+	r.HandleFunc(v2Constant.ApiEventProfileNameDeviceNameRoute, addEvent).Methods(http.MethodPost)
 	r.HandleFunc(v2Constant.ApiEventIdRoute, ec.EventById).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiEventIdRoute, ec.DeleteEventById).Methods(http.MethodDelete)
 	r.HandleFunc(v2Constant.ApiEventCountRoute, ec.EventTotalCount).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiEventCountByDeviceNameRoute, ec.EventCountByDeviceName).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiEventCountByTimeRangeRoute, ec.EventCountByTimeRange).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiEventCountByDeviceNameTimeRangeRoute, ec.EventCountByDeviceNameAndTimeRange).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiEventExistsByDeviceNameSinceRoute, ec.EventExistsByDeviceNameSince).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiAllEventRoute, ec.AllEvents).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiEventByDeviceNameRoute, ec.EventsByDeviceName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiEventByDeviceNameRoute, ec.DeleteEventsByDeviceName).Methods(http.MethodDelete)
 	r.HandleFunc(v2Constant.ApiEventByTimeRangeRoute, ec.EventsByTimeRange).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiEventByAgeRoute, ec.DeleteEventsByAge).Methods(http.MethodDelete)
+	r.HandleFunc(v2Constant.ApiEventByTimeRangeRoute, ec.DeleteEventsByTimeRange).Methods(http.MethodDelete)
+	r.HandleFunc(dataController.ApiEventByDeviceNameTimeRangeRoute, ec.DeleteEventsByDeviceNameAndTimeRange).Methods(http.MethodDelete)
+	r.HandleFunc(dataController.ApiEventByTagsRoute, ec.EventsByTags).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiEventCountByTagsRoute, ec.EventCountByTags).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiEventPartitionsRoute, ec.EventPartitions).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiEventPartitionsByDeviceNameRoute, ec.EventPartitionsByDeviceName).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiIngestionMetricsRoute, ec.IngestionMetrics).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiStorageMigrationRoute, ec.StorageMigration).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiEventVerifyRoute, ec.VerifyEvent).Methods(http.MethodPost)
+	r.HandleFunc(dataController.ApiEventSigningKeyRoute, ec.EventSigningKey).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiEventTailRoute, ec.TailEvents).Methods(http.MethodGet)
 
 	// Readings
 	rc := dataController.NewReadingController(dic)
@@ -43,6 +82,20 @@ func LoadRestRoutes(r *mux.Router, dic *di.Container) {
 	r.HandleFunc(v2Constant.ApiReadingByTimeRangeRoute, rc.ReadingsByTimeRange).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiReadingByResourceNameRoute, rc.ReadingsByResourceName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiReadingCountByDeviceNameRoute, rc.ReadingCountByDeviceName).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiReadingCountByDeviceNameTimeRangeRoute, rc.ReadingCountByDeviceNameAndTimeRange).Methods(http.MethodGet)
+	r.HandleFunc(dataController.ApiReadingByTagsRoute, rc.ReadingsByTags).Methods(http.MethodGet)
+
+	// Federated queries
+	fc := dataController.NewFederationController(dic)
+	r.PathPrefix(dataController.ApiFederationPrefix + "/").HandlerFunc(fc.Query).Methods(http.MethodGet)
+
+	// System Events
+	sc := dataController.NewSystemEventController(dic)
+	r.HandleFunc(audit.ApiSystemEventRoute, schemas.ValidateRequest([]audit.SystemEvent{}, sc.AddSystemEvents)).Methods(http.MethodPost)
+	r.HandleFunc(audit.ApiSystemEventByTimeRangeRoute, sc.SystemEventsByTimeRange).Methods(http.MethodGet)
+
+	// Jobs
+	jobs.LoadRestRoutes(r, pkgContainer.JobManagerFrom(dic.Get))
 
 	r.Use(correlation.ManageHeader)
 	r.Use(correlation.OnResponseComplete)
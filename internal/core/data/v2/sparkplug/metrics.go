@@ -0,0 +1,84 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+// Metrics returns the metrics of the readings of the event, named after their device resource and timestamped with
+// their origin. The array readings are published as strings, which the Sparkplug B data types of the SCADA systems
+// don't support. The readings whose value can't be converted are returned as errors and skipped.
+func Metrics(event dtos.Event) ([]Metric, []error) {
+	var metrics []Metric
+	var errs []error
+	for _, r := range event.Readings {
+		m, err := metric(r)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reading %s of device %s skipped: %s", r.ResourceName, event.DeviceName, err.Error()))
+			continue
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, errs
+}
+
+func metric(r dtos.BaseReading) (Metric, error) {
+	m := Metric{Name: r.ResourceName, Timestamp: uint64(r.Origin / int64(time.Millisecond))}
+	var err error
+	switch r.ValueType {
+	case v2.ValueTypeBool:
+		m.DataType = Boolean
+		m.Value, err = strconv.ParseBool(r.Value)
+	case v2.ValueTypeInt8:
+		m.DataType = Int8
+		m.Value, err = strconv.ParseInt(r.Value, 10, 8)
+	case v2.ValueTypeInt16:
+		m.DataType = Int16
+		m.Value, err = strconv.ParseInt(r.Value, 10, 16)
+	case v2.ValueTypeInt32:
+		m.DataType = Int32
+		m.Value, err = strconv.ParseInt(r.Value, 10, 32)
+	case v2.ValueTypeInt64:
+		m.DataType = Int64
+		m.Value, err = strconv.ParseInt(r.Value, 10, 64)
+	case v2.ValueTypeUint8:
+		m.DataType = UInt8
+		m.Value, err = strconv.ParseUint(r.Value, 10, 8)
+	case v2.ValueTypeUint16:
+		m.DataType = UInt16
+		m.Value, err = strconv.ParseUint(r.Value, 10, 16)
+	case v2.ValueTypeUint32:
+		m.DataType = UInt32
+		m.Value, err = strconv.ParseUint(r.Value, 10, 32)
+	case v2.ValueTypeUint64:
+		m.DataType = UInt64
+		m.Value, err = strconv.ParseUint(r.Value, 10, 64)
+	case v2.ValueTypeFloat32:
+		m.DataType = Float
+		var f float64
+		f, err = strconv.ParseFloat(r.Value, 32)
+		m.Value = float32(f)
+	case v2.ValueTypeFloat64:
+		m.DataType = Double
+		m.Value, err = strconv.ParseFloat(r.Value, 64)
+	case v2.ValueTypeBinary:
+		m.DataType = Bytes
+		m.Value = r.BinaryValue
+	default:
+		m.DataType = String
+		m.Value = r.Value
+	}
+	if err != nil {
+		return Metric{}, fmt.Errorf("invalid %s value %s", r.ValueType, r.Value)
+	}
+	return m, nil
+}
@@ -0,0 +1,55 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reading(resourceName string, valueType string, value string) dtos.BaseReading {
+	return dtos.BaseReading{
+		ResourceName:  resourceName,
+		Origin:        1620000000123 * int64(time.Millisecond),
+		ValueType:     valueType,
+		SimpleReading: dtos.SimpleReading{Value: value},
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	event := dtos.NewEvent("thermostat", "boiler")
+	event.Readings = []dtos.BaseReading{
+		reading("heating", v2.ValueTypeBool, "true"),
+		reading("offset", v2.ValueTypeInt8, "-3"),
+		reading("count", v2.ValueTypeUint64, "18446744073709551615"),
+		reading("temperature", v2.ValueTypeFloat32, "2.15e+01"),
+		reading("pressure", v2.ValueTypeFloat64, "1.013e+05"),
+		reading("mode", v2.ValueTypeString, "eco"),
+		reading("history", v2.ValueTypeInt16Array, "[1, 2]"),
+		reading("overflow", v2.ValueTypeUint8, "256"),
+		{ResourceName: "snapshot", ValueType: v2.ValueTypeBinary, BinaryReading: dtos.BinaryReading{BinaryValue: []byte{1}, MediaType: "image/png"}},
+	}
+
+	metrics, errs := Metrics(event)
+	require.Len(t, errs, 1, "the reading whose value is out of range should be skipped")
+	assert.Contains(t, errs[0].Error(), "overflow")
+	assert.Equal(t, []Metric{
+		{Name: "heating", Timestamp: 1620000000123, DataType: Boolean, Value: true},
+		{Name: "offset", Timestamp: 1620000000123, DataType: Int8, Value: int64(-3)},
+		{Name: "count", Timestamp: 1620000000123, DataType: UInt64, Value: uint64(18446744073709551615)},
+		{Name: "temperature", Timestamp: 1620000000123, DataType: Float, Value: float32(21.5)},
+		{Name: "pressure", Timestamp: 1620000000123, DataType: Double, Value: 101300.0},
+		{Name: "mode", Timestamp: 1620000000123, DataType: String, Value: "eco"},
+		{Name: "history", Timestamp: 1620000000123, DataType: String, Value: "[1, 2]"},
+		{Name: "snapshot", DataType: Bytes, Value: []byte{1}},
+	}, metrics)
+}
@@ -0,0 +1,258 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sparkplug encodes the EdgeX events as Sparkplug B messages, so that the SCADA systems implementing Sparkplug,
+// e.g. Ignition, discover and consume the EdgeX devices without any mapping. Core-data is a Sparkplug edge node, each
+// EdgeX device being one of its Sparkplug devices and each device resource one of the metrics of the device.
+package sparkplug
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Namespace is the first level of the Sparkplug B topics
+const Namespace = "spBv1.0"
+
+// Message types of the Sparkplug B topics
+const (
+	NBIRTH = "NBIRTH"
+	NDEATH = "NDEATH"
+	NDATA  = "NDATA"
+	NCMD   = "NCMD"
+	DBIRTH = "DBIRTH"
+	DDATA  = "DDATA"
+)
+
+const (
+	// BdSeqMetric is the node metric matching an NDEATH with the NBIRTH of the same session
+	BdSeqMetric = "bdSeq"
+	// RebirthMetric is the node metric the primary application writes true through NCMD to request the births
+	RebirthMetric = "Node Control/Rebirth"
+)
+
+// Message is a Sparkplug B message to publish
+type Message struct {
+	Topic   string
+	Payload []byte
+}
+
+// ValidId checks that id can be the group id or the edge node id of the topics
+func ValidId(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty id")
+	}
+	if strings.ContainsAny(id, "/+#") {
+		return fmt.Errorf("id %s contains one of the '/', '+' and '#' characters", id)
+	}
+	return nil
+}
+
+// DeviceId returns the Sparkplug device id of the EdgeX device, which can't contain the '/', '+' and '#' characters
+func DeviceId(deviceName string) string {
+	return strings.NewReplacer("/", "_", "+", "_", "#", "_").Replace(deviceName)
+}
+
+// Node is the state of a Sparkplug edge node: the sequence numbers, the aliases of the metrics and the metrics of the
+// devices already born. The aliases are kept for the life of the node, so that a metric keeps its alias across the
+// births.
+type Node struct {
+	mutex     sync.Mutex
+	now       func() time.Time
+	groupId   string
+	nodeId    string
+	bdSeq     uint64
+	seq       uint64
+	born      bool
+	nextAlias uint64
+	aliases   map[string]uint64
+	devices   map[string]*device
+	// deviceIds is the devices in the order they were first seen, to publish the births in that order
+	deviceIds []string
+}
+
+type device struct {
+	born bool
+	// metrics is the last value of each metric, in the order they were first seen
+	metrics map[string]*Metric
+	names   []string
+}
+
+// NewNode creates the state of the edge node of the group, whose NBIRTH and NDEATH carry bdSeq
+func NewNode(groupId string, nodeId string, bdSeq uint64) *Node {
+	return &Node{
+		now:       time.Now,
+		groupId:   groupId,
+		nodeId:    nodeId,
+		bdSeq:     bdSeq % 256,
+		nextAlias: 1,
+		aliases:   make(map[string]uint64),
+		devices:   make(map[string]*device),
+	}
+}
+
+// Topic returns the topic of the message type of the node, or of the device if deviceId isn't empty
+func (n *Node) Topic(messageType string, deviceId string) string {
+	topic := strings.Join([]string{Namespace, n.groupId, messageType, n.nodeId}, "/")
+	if deviceId != "" {
+		topic += "/" + deviceId
+	}
+	return topic
+}
+
+// Death returns the NDEATH of the node, which is the will of its MQTT connection
+func (n *Node) Death() (Message, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.message(NDEATH, "", false, []Metric{{Name: BdSeqMetric, DataType: Int64, Value: int64(n.bdSeq)}})
+}
+
+// Birth returns the NBIRTH of the node followed by the DBIRTH of each device already seen, which are published when the
+// MQTT connection is established and when the primary application requests a rebirth
+func (n *Node) Birth() ([]Message, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.born = false
+	for _, d := range n.devices {
+		d.born = false
+	}
+	messages, err := n.nodeBirth()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range n.deviceIds {
+		birth, err := n.deviceBirth(id)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, birth)
+	}
+	return messages, nil
+}
+
+func (n *Node) nodeBirth() ([]Message, error) {
+	n.seq = 0
+	birth, err := n.message(NBIRTH, "", true, []Metric{
+		{Name: BdSeqMetric, DataType: Int64, Value: int64(n.bdSeq)},
+		{Name: RebirthMetric, DataType: Boolean, Value: false},
+	})
+	if err != nil {
+		return nil, err
+	}
+	n.born = true
+	return []Message{birth}, nil
+}
+
+// deviceBirth returns the DBIRTH of the device, with all its metrics and their last value
+func (n *Node) deviceBirth(deviceId string) (Message, error) {
+	d := n.devices[deviceId]
+	metrics := make([]Metric, 0, len(d.names))
+	for _, name := range d.names {
+		metrics = append(metrics, *d.metrics[name])
+	}
+	birth, err := n.message(DBIRTH, deviceId, true, metrics)
+	if err != nil {
+		return Message{}, err
+	}
+	d.born = true
+	return birth, nil
+}
+
+// Data returns the messages publishing the metrics of the EdgeX device: a DDATA carrying the metrics by alias, or a
+// DBIRTH when the device isn't born yet or has a new metric or a metric whose data type changed, the DBIRTH carrying
+// the new values. The NBIRTH is returned first if the node isn't born yet.
+func (n *Node) Data(deviceName string, metrics []Metric) ([]Message, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	var messages []Message
+	if !n.born {
+		var err error
+		if messages, err = n.nodeBirth(); err != nil {
+			return nil, err
+		}
+	}
+
+	id := DeviceId(deviceName)
+	d, ok := n.devices[id]
+	if !ok {
+		d = &device{metrics: make(map[string]*Metric)}
+		n.devices[id] = d
+		n.deviceIds = append(n.deviceIds, id)
+	}
+
+	rebirth := !d.born
+	data := make([]Metric, 0, len(metrics))
+	for _, m := range metrics {
+		last, ok := d.metrics[m.Name]
+		if !ok {
+			last = &Metric{Name: m.Name, Alias: n.alias(id, m.Name)}
+			d.metrics[m.Name] = last
+			d.names = append(d.names, m.Name)
+			rebirth = true
+		} else if last.DataType != m.DataType {
+			rebirth = true
+		}
+		last.Timestamp, last.DataType, last.Value = m.Timestamp, m.DataType, m.Value
+		data = append(data, Metric{Alias: last.Alias, Timestamp: m.Timestamp, DataType: m.DataType, Value: m.Value})
+	}
+
+	if rebirth {
+		birth, err := n.deviceBirth(id)
+		if err != nil {
+			return nil, err
+		}
+		return append(messages, birth), nil
+	}
+	message, err := n.message(DDATA, id, true, data)
+	if err != nil {
+		return nil, err
+	}
+	return append(messages, message), nil
+}
+
+// alias returns the alias of the metric of the device, unique among the metrics of the node
+func (n *Node) alias(deviceId string, name string) uint64 {
+	key := deviceId + "/" + name
+	alias, ok := n.aliases[key]
+	if !ok {
+		alias = n.nextAlias
+		n.nextAlias++
+		n.aliases[key] = alias
+	}
+	return alias
+}
+
+// message encodes the payload of the message type with the next sequence number, if withSeq, which wraps after 255
+func (n *Node) message(messageType string, deviceId string, withSeq bool, metrics []Metric) (Message, error) {
+	p := Payload{Timestamp: uint64(n.now().UnixNano() / int64(time.Millisecond)), Metrics: metrics}
+	if withSeq {
+		p.Seq, p.HasSeq = n.seq, true
+		n.seq = (n.seq + 1) % 256
+	}
+	payload, err := p.Marshal()
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to encode the %s of %s: %s", messageType, n.Topic(messageType, deviceId), err.Error())
+	}
+	return Message{Topic: n.Topic(messageType, deviceId), Payload: payload}, nil
+}
+
+// IsRebirthRequest tells whether the payload of an NCMD requests the births of the node
+func IsRebirthRequest(payload []byte) (bool, error) {
+	p, err := Unmarshal(payload)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range p.Metrics {
+		if m.Name == RebirthMetric && m.Value == true {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,146 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNode() *Node {
+	n := NewNode("edgex", "gateway", 260)
+	n.now = func() time.Time { return time.Unix(1620000000, 0) }
+	return n
+}
+
+func decode(t *testing.T, m Message) Payload {
+	p, err := Unmarshal(m.Payload)
+	require.NoError(t, err)
+	return p
+}
+
+func TestValidId(t *testing.T) {
+	assert.NoError(t, ValidId("plant-1"))
+	assert.Error(t, ValidId(""))
+	assert.Error(t, ValidId("plant/1"))
+	assert.Error(t, ValidId("plant+"))
+	assert.Error(t, ValidId("#"))
+	assert.Equal(t, "zone_1_boiler_", DeviceId("zone/1+boiler#"))
+}
+
+func TestNode_DeathAndBirth(t *testing.T) {
+	n := newTestNode()
+
+	death, err := n.Death()
+	require.NoError(t, err)
+	assert.Equal(t, "spBv1.0/edgex/NDEATH/gateway", death.Topic)
+	p := decode(t, death)
+	assert.False(t, p.HasSeq)
+	assert.Equal(t, []Metric{{Name: BdSeqMetric, DataType: Int64, Value: int64(4)}}, p.Metrics)
+
+	births, err := n.Birth()
+	require.NoError(t, err)
+	require.Len(t, births, 1)
+	assert.Equal(t, "spBv1.0/edgex/NBIRTH/gateway", births[0].Topic)
+	p = decode(t, births[0])
+	assert.Equal(t, uint64(1620000000000), p.Timestamp)
+	assert.Equal(t, uint64(0), p.Seq)
+	assert.Equal(t, []Metric{
+		{Name: BdSeqMetric, DataType: Int64, Value: int64(4)},
+		{Name: RebirthMetric, DataType: Boolean, Value: false},
+	}, p.Metrics, "the NBIRTH should have the bdSeq of the NDEATH")
+}
+
+func TestNode_Data(t *testing.T) {
+	n := newTestNode()
+	temperature := Metric{Name: "temperature", Timestamp: 10, DataType: Double, Value: 21.5}
+	heating := Metric{Name: "heating", Timestamp: 10, DataType: Boolean, Value: true}
+
+	messages, err := n.Data("boiler", []Metric{temperature})
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "spBv1.0/edgex/NBIRTH/gateway", messages[0].Topic, "the node should be born first")
+	assert.Equal(t, "spBv1.0/edgex/DBIRTH/gateway/boiler", messages[1].Topic)
+	p := decode(t, messages[1])
+	assert.Equal(t, uint64(1), p.Seq)
+	assert.Equal(t, []Metric{{Name: "temperature", Alias: 1, Timestamp: 10, DataType: Double, Value: 21.5}}, p.Metrics)
+
+	temperature.Value = 22.0
+	messages, err = n.Data("boiler", []Metric{temperature})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "spBv1.0/edgex/DDATA/gateway/boiler", messages[0].Topic)
+	p = decode(t, messages[0])
+	assert.Equal(t, uint64(2), p.Seq)
+	assert.Equal(t, []Metric{{Alias: 1, Timestamp: 10, DataType: Double, Value: 22.0}}, p.Metrics, "the DDATA should use the aliases")
+
+	messages, err = n.Data("boiler", []Metric{heating})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "spBv1.0/edgex/DBIRTH/gateway/boiler", messages[0].Topic, "a new metric should rebirth the device")
+	assert.Equal(t, []Metric{
+		{Name: "temperature", Alias: 1, Timestamp: 10, DataType: Double, Value: 22.0},
+		{Name: "heating", Alias: 2, Timestamp: 10, DataType: Boolean, Value: true},
+	}, decode(t, messages[0]).Metrics, "the DBIRTH should have all the metrics with their last value")
+
+	messages, err = n.Data("fan", []Metric{temperature})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "spBv1.0/edgex/DBIRTH/gateway/fan", messages[0].Topic)
+	assert.Equal(t, uint64(3), decode(t, messages[0]).Metrics[0].Alias, "the aliases should be unique in the node")
+
+	messages, err = n.Data("fan", []Metric{{Name: "temperature", Timestamp: 11, DataType: Float, Value: float32(23)}})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "spBv1.0/edgex/DBIRTH/gateway/fan", messages[0].Topic, "a data type change should rebirth the device")
+	assert.Equal(t, uint64(3), decode(t, messages[0]).Metrics[0].Alias)
+
+	births, err := n.Birth()
+	require.NoError(t, err)
+	require.Len(t, births, 3)
+	assert.Equal(t, "spBv1.0/edgex/NBIRTH/gateway", births[0].Topic)
+	assert.Equal(t, uint64(0), decode(t, births[0]).Seq)
+	assert.Equal(t, "spBv1.0/edgex/DBIRTH/gateway/boiler", births[1].Topic)
+	assert.Len(t, decode(t, births[1]).Metrics, 2)
+	assert.Equal(t, "spBv1.0/edgex/DBIRTH/gateway/fan", births[2].Topic)
+	assert.Equal(t, uint64(2), decode(t, births[2]).Seq)
+}
+
+func TestNode_SeqWraps(t *testing.T) {
+	n := newTestNode()
+	metric := Metric{Name: "temperature", DataType: Double, Value: 21.5}
+	// the NBIRTH and DBIRTH have the seq 0 and 1, the DDATA the following ones
+	var last Message
+	for i := 0; i < 255; i++ {
+		messages, err := n.Data("boiler", []Metric{metric})
+		require.NoError(t, err)
+		last = messages[len(messages)-1]
+	}
+	assert.Equal(t, uint64(255), decode(t, last).Seq)
+	messages, err := n.Data("boiler", []Metric{metric})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), decode(t, messages[0]).Seq, "the seq should wrap after 255")
+}
+
+func TestIsRebirthRequest(t *testing.T) {
+	payload, err := Payload{Metrics: []Metric{{Name: RebirthMetric, DataType: Boolean, Value: true}}}.Marshal()
+	require.NoError(t, err)
+	rebirth, err := IsRebirthRequest(payload)
+	require.NoError(t, err)
+	assert.True(t, rebirth)
+
+	payload, err = Payload{Metrics: []Metric{{Name: "Node Control/Reboot", DataType: Boolean, Value: true}}}.Marshal()
+	require.NoError(t, err)
+	rebirth, err = IsRebirthRequest(payload)
+	require.NoError(t, err)
+	assert.False(t, rebirth)
+
+	_, err = IsRebirthRequest([]byte{0x12, 0x08})
+	assert.Error(t, err)
+}
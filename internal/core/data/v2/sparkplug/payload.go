@@ -0,0 +1,328 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Data types of the metrics
+const (
+	Int8    uint32 = 1
+	Int16   uint32 = 2
+	Int32   uint32 = 3
+	Int64   uint32 = 4
+	UInt8   uint32 = 5
+	UInt16  uint32 = 6
+	UInt32  uint32 = 7
+	UInt64  uint32 = 8
+	Float   uint32 = 9
+	Double  uint32 = 10
+	Boolean uint32 = 11
+	String  uint32 = 12
+	Bytes   uint32 = 17
+)
+
+// Metric is a metric of a Sparkplug B payload. Value is nil for a null metric, an int64 for the signed integer types,
+// a uint64 for the unsigned ones, a float32, a float64, a bool, a string or a []byte.
+type Metric struct {
+	Name      string
+	Alias     uint64
+	Timestamp uint64
+	DataType  uint32
+	Value     interface{}
+}
+
+// Payload is a Sparkplug B payload. The timestamps are in milliseconds since the epoch.
+type Payload struct {
+	Timestamp uint64
+	Metrics   []Metric
+	Seq       uint64
+	// HasSeq tells whether Seq is set, NDEATH payloads having no sequence number
+	HasSeq bool
+}
+
+// protobuf field numbers of the Sparkplug B schema
+const (
+	payloadTimestamp = 1
+	payloadMetrics   = 2
+	payloadSeq       = 3
+
+	metricName         = 1
+	metricAlias        = 2
+	metricTimestamp    = 3
+	metricDataType     = 4
+	metricIsNull       = 7
+	metricIntValue     = 10
+	metricLongValue    = 11
+	metricFloatValue   = 12
+	metricDoubleValue  = 13
+	metricBooleanValue = 14
+	metricStringValue  = 15
+	metricBytesValue   = 16
+)
+
+// protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// Marshal encodes the payload in the protobuf wire format of the Sparkplug B schema
+func (p Payload) Marshal() ([]byte, error) {
+	var b []byte
+	b = appendVarintField(b, payloadTimestamp, p.Timestamp)
+	for _, m := range p.Metrics {
+		metric, err := m.marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = appendBytesField(b, payloadMetrics, metric)
+	}
+	if p.HasSeq {
+		b = appendVarintField(b, payloadSeq, p.Seq)
+	}
+	return b, nil
+}
+
+func (m Metric) marshal() ([]byte, error) {
+	var b []byte
+	if m.Name != "" {
+		b = appendBytesField(b, metricName, []byte(m.Name))
+	}
+	if m.Alias != 0 {
+		b = appendVarintField(b, metricAlias, m.Alias)
+	}
+	if m.Timestamp != 0 {
+		b = appendVarintField(b, metricTimestamp, m.Timestamp)
+	}
+	b = appendVarintField(b, metricDataType, uint64(m.DataType))
+	if m.Value == nil {
+		return appendVarintField(b, metricIsNull, 1), nil
+	}
+
+	invalid := fmt.Errorf("invalid value %v of metric %s of data type %d", m.Value, m.Name, m.DataType)
+	switch m.DataType {
+	case Int8, Int16, Int32:
+		v, ok := m.Value.(int64)
+		if !ok {
+			return nil, invalid
+		}
+		// the signed values are stored as their two's complement in the unsigned 32-bit field
+		b = appendVarintField(b, metricIntValue, uint64(uint32(int32(v))))
+	case UInt8, UInt16, UInt32:
+		v, ok := m.Value.(uint64)
+		if !ok {
+			return nil, invalid
+		}
+		b = appendVarintField(b, metricIntValue, uint64(uint32(v)))
+	case Int64:
+		v, ok := m.Value.(int64)
+		if !ok {
+			return nil, invalid
+		}
+		b = appendVarintField(b, metricLongValue, uint64(v))
+	case UInt64:
+		v, ok := m.Value.(uint64)
+		if !ok {
+			return nil, invalid
+		}
+		b = appendVarintField(b, metricLongValue, v)
+	case Float:
+		v, ok := m.Value.(float32)
+		if !ok {
+			return nil, invalid
+		}
+		var fixed [4]byte
+		binary.LittleEndian.PutUint32(fixed[:], math.Float32bits(v))
+		b = append(appendTag(b, metricFloatValue, wireFixed32), fixed[:]...)
+	case Double:
+		v, ok := m.Value.(float64)
+		if !ok {
+			return nil, invalid
+		}
+		var fixed [8]byte
+		binary.LittleEndian.PutUint64(fixed[:], math.Float64bits(v))
+		b = append(appendTag(b, metricDoubleValue, wireFixed64), fixed[:]...)
+	case Boolean:
+		v, ok := m.Value.(bool)
+		if !ok {
+			return nil, invalid
+		}
+		var u uint64
+		if v {
+			u = 1
+		}
+		b = appendVarintField(b, metricBooleanValue, u)
+	case String:
+		v, ok := m.Value.(string)
+		if !ok {
+			return nil, invalid
+		}
+		b = appendBytesField(b, metricStringValue, []byte(v))
+	case Bytes:
+		v, ok := m.Value.([]byte)
+		if !ok {
+			return nil, invalid
+		}
+		b = appendBytesField(b, metricBytesValue, v)
+	default:
+		return nil, fmt.Errorf("unsupported data type %d of metric %s", m.DataType, m.Name)
+	}
+	return b, nil
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var varint [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(varint[:], v)
+	return append(b, varint[:n]...)
+}
+
+func appendTag(b []byte, field int, wireType int) []byte {
+	return appendUvarint(b, uint64(field<<3|wireType))
+}
+
+func appendVarintField(b []byte, field int, v uint64) []byte {
+	return appendUvarint(appendTag(b, field, wireVarint), v)
+}
+
+func appendBytesField(b []byte, field int, v []byte) []byte {
+	b = appendUvarint(appendTag(b, field, wireBytes), uint64(len(v)))
+	return append(b, v...)
+}
+
+var errTruncated = errors.New("truncated Sparkplug B payload")
+
+// Unmarshal decodes a payload in the protobuf wire format of the Sparkplug B schema. The fields which aren't
+// supported by Payload and Metric are skipped.
+func Unmarshal(data []byte) (Payload, error) {
+	var p Payload
+	err := readFields(data, func(field int, wireType int, varint uint64, bytes []byte) error {
+		switch {
+		case field == payloadTimestamp && wireType == wireVarint:
+			p.Timestamp = varint
+		case field == payloadSeq && wireType == wireVarint:
+			p.Seq = varint
+			p.HasSeq = true
+		case field == payloadMetrics && wireType == wireBytes:
+			m, err := unmarshalMetric(bytes)
+			if err != nil {
+				return err
+			}
+			p.Metrics = append(p.Metrics, m)
+		}
+		return nil
+	})
+	return p, err
+}
+
+func unmarshalMetric(data []byte) (Metric, error) {
+	var m Metric
+	var isNull bool
+	var intValue, longValue uint64
+	var raw interface{}
+	err := readFields(data, func(field int, wireType int, varint uint64, bytes []byte) error {
+		switch field {
+		case metricName:
+			m.Name = string(bytes)
+		case metricAlias:
+			m.Alias = varint
+		case metricTimestamp:
+			m.Timestamp = varint
+		case metricDataType:
+			m.DataType = uint32(varint)
+		case metricIsNull:
+			isNull = varint != 0
+		case metricIntValue:
+			intValue = varint
+		case metricLongValue:
+			longValue = varint
+		case metricFloatValue:
+			raw = math.Float32frombits(uint32(varint))
+		case metricDoubleValue:
+			raw = math.Float64frombits(varint)
+		case metricBooleanValue:
+			raw = varint != 0
+		case metricStringValue:
+			raw = string(bytes)
+		case metricBytesValue:
+			raw = append([]byte{}, bytes...)
+		}
+		return nil
+	})
+	if err != nil || isNull {
+		return m, err
+	}
+	switch m.DataType {
+	case Int8:
+		m.Value = int64(int8(intValue))
+	case Int16:
+		m.Value = int64(int16(intValue))
+	case Int32:
+		m.Value = int64(int32(intValue))
+	case UInt8, UInt16, UInt32:
+		m.Value = intValue
+	case Int64:
+		m.Value = int64(longValue)
+	case UInt64:
+		m.Value = longValue
+	default:
+		m.Value = raw
+	}
+	return m, nil
+}
+
+// readFields calls read with each field of the protobuf message data, with its value as varint for the varint and
+// fixed size fields, or as bytes for the length-delimited fields
+func readFields(data []byte, read func(field int, wireType int, varint uint64, bytes []byte) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		field, wireType := int(tag>>3), int(tag&7)
+		var varint uint64
+		var bytes []byte
+		switch wireType {
+		case wireVarint:
+			if varint, n = binary.Uvarint(data); n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			varint = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			varint = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return errTruncated
+			}
+			bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		default:
+			return fmt.Errorf("unsupported protobuf wire type %d", wireType)
+		}
+		if err := read(field, wireType, varint, bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
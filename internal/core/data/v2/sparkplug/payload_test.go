@@ -0,0 +1,85 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayload_Marshal(t *testing.T) {
+	payload, err := Payload{
+		Timestamp: 1,
+		Metrics:   []Metric{{Name: "a", Alias: 2, DataType: Boolean, Value: true}},
+		Seq:       3,
+		HasSeq:    true,
+	}.Marshal()
+	require.NoError(t, err)
+	expected := []byte{
+		0x08, 0x01, // timestamp
+		0x12, 0x09, // metrics, 9 bytes
+		0x0a, 0x01, 'a', // name
+		0x10, 0x02, // alias
+		0x20, 0x0b, // datatype
+		0x70, 0x01, // boolean_value
+		0x18, 0x03, // seq
+	}
+	assert.Equal(t, expected, payload)
+
+	payload, err = Payload{Timestamp: 1}.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x08, 0x01}, payload, "the seq should be omitted when not set")
+}
+
+func TestPayload_RoundTrip(t *testing.T) {
+	metrics := []Metric{
+		{Name: "int8", DataType: Int8, Value: int64(-8)},
+		{Name: "int16", DataType: Int16, Value: int64(-1600)},
+		{Name: "int32", DataType: Int32, Value: int64(-320000)},
+		{Name: "int64", Alias: 300, DataType: Int64, Value: int64(-6400000000)},
+		{Name: "uint8", DataType: UInt8, Value: uint64(255)},
+		{Name: "uint16", DataType: UInt16, Value: uint64(65535)},
+		{Name: "uint32", DataType: UInt32, Value: uint64(4294967295)},
+		{Name: "uint64", DataType: UInt64, Value: uint64(18446744073709551615)},
+		{Name: "float", Timestamp: 1620000000000, DataType: Float, Value: float32(1.5)},
+		{Name: "double", DataType: Double, Value: -2.25},
+		{Name: "boolean", DataType: Boolean, Value: false},
+		{Name: "string", DataType: String, Value: "héllo"},
+		{Name: "bytes", DataType: Bytes, Value: []byte{0, 1, 2}},
+		{Name: "null", DataType: Double},
+	}
+	payload, err := Payload{Timestamp: 1620000000001, Metrics: metrics, Seq: 255, HasSeq: true}.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := Unmarshal(payload)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1620000000001), decoded.Timestamp)
+	assert.Equal(t, uint64(255), decoded.Seq)
+	assert.True(t, decoded.HasSeq)
+	assert.Equal(t, metrics, decoded.Metrics)
+}
+
+func TestPayload_MarshalInvalid(t *testing.T) {
+	_, err := Payload{Metrics: []Metric{{Name: "a", DataType: Int32, Value: "1"}}}.Marshal()
+	assert.Error(t, err)
+	_, err = Payload{Metrics: []Metric{{Name: "a", DataType: 19, Value: "template"}}}.Marshal()
+	assert.Error(t, err, "the unsupported data types should be rejected")
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	_, err := Unmarshal([]byte{0x12, 0x08, 0x0a})
+	assert.Error(t, err, "the truncated payloads should be rejected")
+	_, err = Unmarshal([]byte{0x0b})
+	assert.Error(t, err, "the groups should be rejected")
+
+	// the unknown fields, e.g. the metadata of a metric, are skipped
+	p, err := Unmarshal([]byte{0x12, 0x06, 0x0a, 0x01, 'a', 0x42, 0x01, 0x00, 0x28, 0x01})
+	require.NoError(t, err)
+	require.Len(t, p.Metrics, 1)
+	assert.Equal(t, "a", p.Metrics[0].Name)
+}
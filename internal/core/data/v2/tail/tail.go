@@ -0,0 +1,195 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tail keeps the newest events added to core-data in memory, so that the command-line and test tools follow
+// the live data with long-polled requests rather than a websocket: each request returns the events added after the
+// cursor returned by the previous one, waiting for one to be added when there is none yet.
+package tail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// TailInfo configures the events kept for the tail requests
+type TailInfo struct {
+	// Enabled keeps the newest events in memory and serves the tail requests
+	Enabled bool
+	// BufferSize is the number of the newest events kept. The events of a cursor older than the oldest of them are
+	// missed.
+	BufferSize int
+	// MaxWait is the longest a tail request waits for an event to be added, e.g. "4s". It must be shorter than
+	// Service.Timeout, after which the request would time out.
+	MaxWait string
+}
+
+// Page holds the events returned by a tail request
+type Page struct {
+	// Events are the events added after the cursor of the request, the oldest first
+	Events []models.Event
+	// Cursor follows the last of the Events, to be sent by the next request
+	Cursor string
+	// Missed is the number of the events added after the cursor of the request but no longer kept
+	Missed uint64
+}
+
+// entry is an event kept with its sequence number
+type entry struct {
+	seq   uint64
+	event models.Event
+}
+
+// Buffer keeps the newest events in a ring. The events are numbered from 1 in the order they are added, the cursors
+// carrying the number of the last event returned along with the epoch of the Buffer, so that the cursors of a
+// previous run of the service aren't mistaken for the current ones.
+type Buffer struct {
+	maxWait time.Duration
+	epoch   string
+
+	mutex   sync.Mutex
+	entries []entry
+	// last is the number of the last event added, 0 while none is
+	last uint64
+	// added is closed, and replaced, when an event is added
+	added chan struct{}
+}
+
+// NewBuffer creates the Buffer configured by info, or returns nil if the tail is disabled. requestTimeout is the time
+// after which the requests to the service time out.
+func NewBuffer(info TailInfo, requestTimeout time.Duration) (*Buffer, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+	if info.BufferSize <= 0 {
+		return nil, fmt.Errorf("invalid BufferSize %d, it must be positive", info.BufferSize)
+	}
+	maxWait, err := time.ParseDuration(info.MaxWait)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MaxWait: %s", err.Error())
+	}
+	if maxWait < 0 || maxWait >= requestTimeout {
+		return nil, fmt.Errorf("invalid MaxWait %s, it must be shorter than the %s request timeout", info.MaxWait, requestTimeout)
+	}
+	return &Buffer{
+		maxWait: maxWait,
+		epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
+		entries: make([]entry, info.BufferSize),
+		added:   make(chan struct{}),
+	}, nil
+}
+
+// MaxWait returns the longest a tail request waits for an event to be added
+func (b *Buffer) MaxWait() time.Duration {
+	return b.maxWait
+}
+
+// Add keeps e as the newest event, in place of the oldest one once the buffer is full, and wakes up the requests
+// waiting for it. It does nothing on a nil Buffer.
+func (b *Buffer) Add(e models.Event) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.last++
+	b.entries[b.last%uint64(len(b.entries))] = entry{seq: b.last, event: e}
+	close(b.added)
+	b.added = make(chan struct{})
+}
+
+// Newest returns up to limit of the newest events, with the cursor of the newest one
+func (b *Buffer) Newest(limit int) Page {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	after := b.oldest() - 1
+	if b.last-after > uint64(limit) {
+		after = b.last - uint64(limit)
+	}
+	return b.page(after, limit)
+}
+
+// Since returns up to limit of the events added after cursor, waiting up to wait, at most MaxWait, for one to be added
+// when there is none yet. The page has no events when none is added in time, or when ctx is done, its cursor being
+// unchanged. A cursor of a previous run of the service returns the events kept since the service started.
+func (b *Buffer) Since(ctx context.Context, cursor string, limit int, wait time.Duration) (Page, error) {
+	after, err := b.parseCursor(cursor)
+	if err != nil {
+		return Page{}, err
+	}
+	if wait > b.maxWait {
+		wait = b.maxWait
+	}
+
+	b.mutex.Lock()
+	if after > b.last {
+		b.mutex.Unlock()
+		return Page{}, fmt.Errorf("cursor %s is ahead of the newest event", cursor)
+	}
+	if after == b.last && wait > 0 {
+		added := b.added
+		b.mutex.Unlock()
+		timer := time.NewTimer(wait)
+		select {
+		case <-added:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+		timer.Stop()
+		b.mutex.Lock()
+	}
+	defer b.mutex.Unlock()
+	return b.page(after, limit), nil
+}
+
+// page returns up to limit of the events added after the event numbered after. The mutex must be held.
+func (b *Buffer) page(after uint64, limit int) Page {
+	p := Page{Events: []models.Event{}}
+	first := after + 1
+	if oldest := b.oldest(); first < oldest {
+		p.Missed = oldest - first
+		first = oldest
+	}
+	for seq := first; seq <= b.last && len(p.Events) < limit; seq++ {
+		p.Events = append(p.Events, b.entries[seq%uint64(len(b.entries))].event)
+		after = seq
+	}
+	p.Cursor = b.cursor(after)
+	return p
+}
+
+// oldest returns the number of the oldest event kept, or of the next event while none is. The mutex must be held.
+func (b *Buffer) oldest() uint64 {
+	if size := uint64(len(b.entries)); b.last > size {
+		return b.last - size + 1
+	}
+	return 1
+}
+
+func (b *Buffer) cursor(seq uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// parseCursor returns the number of the event of cursor, 0 for a cursor of a previous run of the service
+func (b *Buffer) parseCursor(cursor string) (uint64, error) {
+	parts := strings.SplitN(cursor, "-", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return 0, errors.New("invalid cursor " + cursor)
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid cursor " + cursor)
+	}
+	if parts[0] != b.epoch {
+		return 0, nil
+	}
+	return seq, nil
+}
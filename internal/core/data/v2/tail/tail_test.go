@@ -0,0 +1,127 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tail
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBuffer(t *testing.T, size int) *Buffer {
+	b, err := NewBuffer(TailInfo{Enabled: true, BufferSize: size, MaxWait: "200ms"}, 5*time.Second)
+	require.NoError(t, err)
+	return b
+}
+
+func ids(events []models.Event) []string {
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.Id
+	}
+	return ids
+}
+
+func addEvents(b *Buffer, from int, to int) {
+	for i := from; i <= to; i++ {
+		b.Add(models.Event{Id: strconv.Itoa(i)})
+	}
+}
+
+func TestNewBuffer(t *testing.T) {
+	b, err := NewBuffer(TailInfo{BufferSize: 10, MaxWait: "1s"}, 5*time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, b, "no buffer when disabled")
+	b.Add(models.Event{})
+
+	tests := []struct {
+		name string
+		info TailInfo
+	}{
+		{"no buffer", TailInfo{Enabled: true, MaxWait: "1s"}},
+		{"invalid wait", TailInfo{Enabled: true, BufferSize: 10, MaxWait: "1"}},
+		{"wait longer than the request timeout", TailInfo{Enabled: true, BufferSize: 10, MaxWait: "5s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBuffer(tt.info, 5*time.Second)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewestAndSince(t *testing.T) {
+	b := newTestBuffer(t, 3)
+	empty := b.Newest(10)
+	assert.Empty(t, empty.Events)
+
+	addEvents(b, 1, 2)
+	page, err := b.Since(context.Background(), empty.Cursor, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, ids(page.Events))
+
+	addEvents(b, 3, 4)
+	newest := b.Newest(2)
+	assert.Equal(t, []string{"3", "4"}, ids(newest.Events))
+
+	page, err = b.Since(context.Background(), page.Cursor, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3"}, ids(page.Events), "the events are paged by limit, the oldest first")
+	assert.Zero(t, page.Missed)
+
+	addEvents(b, 5, 7)
+	page, err = b.Since(context.Background(), page.Cursor, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5", "6", "7"}, ids(page.Events))
+	assert.Equal(t, uint64(1), page.Missed, "event 4 is no longer kept")
+
+	page, err = b.Since(context.Background(), "previousrun-42", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5", "6", "7"}, ids(page.Events), "the cursor of a previous run returns the events kept")
+
+	for _, cursor := range []string{"", "42", b.epoch + "-x", b.epoch + "-8"} {
+		_, err = b.Since(context.Background(), cursor, 10, 0)
+		assert.Error(t, err, cursor)
+	}
+}
+
+func TestSinceWaits(t *testing.T) {
+	b := newTestBuffer(t, 10)
+	cursor := b.Newest(10).Cursor
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		addEvents(b, 1, 1)
+	}()
+	page, err := b.Since(context.Background(), cursor, 10, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, ids(page.Events), "the request returns once an event is added")
+
+	started := time.Now()
+	next, err := b.Since(context.Background(), page.Cursor, 10, time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, next.Events)
+	assert.Less(t, int64(time.Since(started)), int64(time.Second), "the wait is bounded by MaxWait")
+
+	started = time.Now()
+	next, err = b.Since(context.Background(), page.Cursor, 10, 20*time.Millisecond)
+	require.NoError(t, err)
+	assert.Empty(t, next.Events)
+	assert.Equal(t, page.Cursor, next.Cursor, "the cursor is unchanged when no event is added in time")
+	assert.GreaterOrEqual(t, int64(time.Since(started)), int64(20*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	next, err = b.Since(ctx, page.Cursor, 10, time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, next.Events, "the request returns when cancelled")
+}
@@ -0,0 +1,108 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-messaging/v2/messaging"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+)
+
+// startCallbackOutbox connects to the message bus and publishes the callbacks of the device services from the outbox
+// of the database, the outbox being added to the DIC so that the changes are queued in it
+func startCallbackOutbox(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	configuration := container.ConfigurationFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	if err := configuration.Callbacks.Validate(); err != nil {
+		lc.Error("invalid Callbacks configuration: " + err.Error())
+		return false
+	}
+
+	// For Redis Streams MessageBus, we reuse the Redis instance running for the DB, which may have a password,
+	// so we need to get and use the DB credentials for the MessageBus connection.
+	if configuration.MessageQueue.Type == "redisstreams" {
+		secretProvider := bootstrapContainer.SecretProviderFrom(dic.Get)
+		credentials, err := secretProvider.GetSecrets(configuration.Databases["Primary"].Type)
+		if err != nil {
+			lc.Error(fmt.Sprintf("Error getting DB creds for RedisStreams: %s", err.Error()))
+			return false
+		}
+		configuration.MessageQueue.Optional["Password"] = credentials[secret.PasswordKey]
+	}
+
+	host := msgTypes.HostInfo{
+		Host:     configuration.MessageQueue.Host,
+		Port:     configuration.MessageQueue.Port,
+		Protocol: configuration.MessageQueue.Protocol,
+	}
+	msgClient, err := messaging.NewMessageClient(
+		msgTypes.MessageBusConfig{
+			PublishHost:   host,
+			SubscribeHost: host,
+			Type:          configuration.MessageQueue.Type,
+			Optional:      configuration.MessageQueue.Optional,
+		})
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to create messaging client: %s", err.Error()))
+		return false
+	}
+
+	for startupTimer.HasNotElapsed() {
+		err = msgClient.Connect()
+		if err == nil {
+			break
+		}
+
+		lc.Warn(fmt.Sprintf("couldn't connect to message bus: %s", err.Error()))
+		startupTimer.SleepForInterval()
+	}
+	if err != nil {
+		lc.Error("failed to connect to message bus in allotted time")
+		return false
+	}
+
+	outbox, err := callbacks.NewOutbox(configuration.Callbacks, v2MetadataContainer.DBClientFrom(dic.Get), msgClient, lc)
+	if err == nil {
+		err = outbox.Start(ctx, wg)
+	}
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to start publishing the callbacks: %s", err.Error()))
+		_ = msgClient.Disconnect()
+		return false
+	}
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.CallbackOutboxName: func(get di.Get) interface{} {
+			return outbox
+		},
+	})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if err := msgClient.Disconnect(); err != nil {
+			lc.Error("failed to disconnect from the Message Bus")
+			return
+		}
+		lc.Info("Message Bus disconnected")
+	}()
+
+	lc.Info(fmt.Sprintf("publishing the callbacks of the device services to %s of %s Message Bus @ %s",
+		configuration.Callbacks.TopicPrefix, configuration.MessageQueue.Type, configuration.MessageQueue.URL()))
+	return true
+}
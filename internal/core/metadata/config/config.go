@@ -15,6 +15,25 @@
 package config
 
 import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicesecrets"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dryrun"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/graphql"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/labels"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/profiling"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/readonly"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/registrywatch"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
+
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 )
 
@@ -27,12 +46,30 @@ type ConfigurationStruct struct {
 	Registry      bootstrapConfig.RegistryInfo
 	Service       bootstrapConfig.ServiceInfo
 	SecretStore   bootstrapConfig.SecretStoreInfo
+	JWTAuth       jwtauth.JWTAuthInfo
+	Audit         audit.AuditInfo
+	DeviceSecrets devicesecrets.DeviceSecretsInfo
+	ClaimCodes    claimcodes.ClaimCodesInfo
+	SecretCache   secretcache.SecretCacheInfo
+	RegistryWatch registrywatch.RegistryWatchInfo
+	GraphQL       graphql.GraphQLInfo
+	DryRun        dryrun.DryRunInfo
+	Callbacks     callbacks.CallbacksInfo
+	MessageQueue  MessageQueueInfo
 }
 
 type WritableInfo struct {
 	LogLevel                        string
+	ShutdownTimeout                 string
+	RequestLimits                   requestlimits.RequestLimitsInfo
+	ProblemDetails                  problem.ProblemDetailsInfo
+	CORS                            cors.CORSInfo
+	ReadOnly                        readonly.ReadOnlyInfo
+	Profiling                       profiling.ProfilingInfo
 	EnableValueDescriptorManagement bool
 	InsecureSecrets                 bootstrapConfig.InsecureSecrets
+	DiscoveryLimits                 discovery.LimitsInfo
+	Labels                          labels.LabelRegistryInfo
 }
 
 // Notification Info provides properties related to the assembly of notification content
@@ -45,6 +82,27 @@ type NotificationInfo struct {
 	Slug              string
 }
 
+// MessageQueueInfo provides parameters related to connecting to the message bus the callbacks are published on
+type MessageQueueInfo struct {
+	// Host is the hostname or IP address of the broker, if applicable.
+	Host string
+	// Port defines the port on which to access the message queue.
+	Port int
+	// Protocol indicates the protocol to use when accessing the message queue.
+	Protocol string
+	// Indicates the message queue platform being used.
+	Type string
+	// Provides additional configuration properties which do not fit within the existing field.
+	// Typically the key is the name of the configuration property and the value is a string representation of the
+	// desired value for the configuration property.
+	Optional map[string]string
+}
+
+// URL constructs a URL from the protocol, host and port and returns that as a string.
+func (m MessageQueueInfo) URL() string {
+	return fmt.Sprintf("%s://%s:%v", m.Protocol, m.Host, m.Port)
+}
+
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
 // then used to overwrite the service's existing configuration struct.
 func (c *ConfigurationStruct) UpdateFromRaw(rawConfig interface{}) bool {
@@ -104,6 +162,46 @@ func (c *ConfigurationStruct) GetDatabaseInfo() map[string]bootstrapConfig.Datab
 	return c.Databases
 }
 
+// GetRequestLimits returns the limits applied to incoming requests.
+func (c *ConfigurationStruct) GetRequestLimits() requestlimits.RequestLimitsInfo {
+	return c.Writable.RequestLimits
+}
+
+// GetSecretCache returns the configuration of the cache of the secrets read from the secret store.
+func (c *ConfigurationStruct) GetSecretCache() secretcache.SecretCacheInfo {
+	return c.SecretCache
+}
+
+// GetRegistryWatch returns the configuration of the watch of the registration of the service with the registry.
+func (c *ConfigurationStruct) GetRegistryWatch() registrywatch.RegistryWatchInfo {
+	return c.RegistryWatch
+}
+
+// GetProblemDetails returns whether the failed requests are responded to with problem details.
+func (c *ConfigurationStruct) GetProblemDetails() problem.ProblemDetailsInfo {
+	return c.Writable.ProblemDetails
+}
+
+// GetReadOnly returns the read-only mode applied to incoming requests.
+func (c *ConfigurationStruct) GetReadOnly() readonly.ReadOnlyInfo {
+	return c.Writable.ReadOnly
+}
+
+// GetProfiling returns the configuration of the profiling endpoints.
+func (c *ConfigurationStruct) GetProfiling() profiling.ProfilingInfo {
+	return c.Writable.Profiling
+}
+
+// GetCORS returns the CORS policy applied to incoming requests.
+func (c *ConfigurationStruct) GetCORS() cors.CORSInfo {
+	return c.Writable.CORS
+}
+
+// GetShutdownTimeout returns how long in-flight requests are given to complete when the service is stopped.
+func (c *ConfigurationStruct) GetShutdownTimeout() string {
+	return c.Writable.ShutdownTimeout
+}
+
 // GetInsecureSecrets returns the service's InsecureSecrets.
 func (c *ConfigurationStruct) GetInsecureSecrets() bootstrapConfig.InsecureSecrets {
 	return c.Writable.InsecureSecrets
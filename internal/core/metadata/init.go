@@ -16,15 +16,28 @@ package metadata
 
 import (
 	"context"
-
+	"fmt"
+	"os"
 	"sync"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/urlclient/local"
 
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
 	errorContainer "github.com/edgexfoundry/edgex-go/internal/pkg/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicesecrets"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/errorconcept"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/profiling"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/readonly"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
@@ -32,6 +45,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/coredata"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/notifications"
+	v2HttpClient "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/clients/http"
 
 	"github.com/gorilla/mux"
 )
@@ -49,9 +63,34 @@ func NewBootstrap(router *mux.Router) *Bootstrap {
 }
 
 // BootstrapHandler fulfills the BootstrapHandler contract and performs initialization needed by the metadata service.
-func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	// the outbox is started first, so that its routes are loaded along with the v2 routes
+	if container.ConfigurationFrom(dic.Get).Callbacks.Enabled && !startCallbackOutbox(ctx, wg, startupTimer, dic) {
+		return false
+	}
+
+	// the optional subsystems of the service, reported at features.ApiCapabilitiesRoute
+	registry := features.NewRegistry(bootstrapContainer.LoggingClientFrom(dic.Get))
+	registry.Add(features.GraphQL, container.ConfigurationFrom(dic.Get).GraphQL.Enabled)
+	dic.Update(di.ServiceConstructorMap{
+		pkgContainer.FeaturesName: func(get di.Get) interface{} {
+			return registry
+		},
+	})
+
 	loadRestRoutes(b.router, dic)
 	v2.LoadRestRoutes(b.router, dic)
+	profiling.LoadRoutes(b.router, bootstrapContainer.LoggingClientFrom(dic.Get), container.ConfigurationFrom(dic.Get), bootstrapContainer.SecretProviderFrom(dic.Get))
+	b.router.Use(problem.NewMiddleware(clients.CoreMetaDataServiceKey, container.ConfigurationFrom(dic.Get)))
+	cors.UseMiddleware(b.router, container.ConfigurationFrom(dic.Get))
+	b.router.Use(requestlimits.NewMiddleware(bootstrapContainer.LoggingClientFrom(dic.Get), container.ConfigurationFrom(dic.Get)))
+	b.router.Use(readonly.NewMiddleware(bootstrapContainer.LoggingClientFrom(dic.Get), container.ConfigurationFrom(dic.Get)))
+	if err := jwtauth.UseMiddleware(b.router, dic, container.ConfigurationFrom(dic.Get).JWTAuth); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("failed to enable JWT verification: " + err.Error())
+		return false
+	}
+	recorder := audit.UseMiddleware(ctx, wg, b.router, dic, container.ConfigurationFrom(dic.Get).Audit, clients.CoreMetaDataServiceKey,
+		container.ConfigurationFrom(dic.Get).Clients["CoreData"].Url(), audit.ClassifyWrite)
 
 	// TODO: there is an outstanding known issue (https://github.com/edgexfoundry/edgex-go/issues/2462)
 	// 		that could be seemingly be solved by moving from JIT initialization of these external clients to static
@@ -62,6 +101,11 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _
 	//		https://github.com/edgexfoundry/edgex-go/issues/2421, the correct fix is to bump up the client timeout.
 	configuration := container.ConfigurationFrom(dic.Get)
 
+	if err := migrateDatabase(bootstrapContainer.LoggingClientFrom(dic.Get), v2MetadataContainer.DBClientFrom(dic.Get), startupTimer); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error(fmt.Sprintf("failed to migrate the database: %s", err.Error()))
+		return false
+	}
+
 	// add dependencies to container
 	dic.Update(di.ServiceConstructorMap{
 		errorContainer.ErrorHandlerName: func(get di.Get) interface{} {
@@ -76,7 +120,65 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _
 				local.New(configuration.Clients["Notifications"].Url() + clients.ApiNotificationRoute))
 
 		},
+		// the dry runs of the updates check the recent readings of the devices
+		v2MetadataContainer.ReadingClientName: func(get di.Get) interface{} {
+			return v2HttpClient.NewReadingClient(configuration.Clients["CoreData"].Url())
+		},
+		// the admin state changes of the devices run by the schedules are recorded as system events
+		pkgContainer.AuditRecorderName: func(get di.Get) interface{} {
+			return recorder
+		},
 	})
 
+	// the admin state schedules are run by support-scheduler, requesting this service
+	if schedulerInfo, ok := configuration.Clients["Scheduler"]; ok {
+		scheduler := adminschedules.NewScheduler(schedulerInfo.Url(), adminschedules.Callback{
+			Protocol: configuration.Service.Protocol,
+			Host:     configuration.Service.Host,
+			Port:     configuration.Service.Port,
+		})
+		dic.Update(di.ServiceConstructorMap{
+			v2MetadataContainer.AdminSchedulerName: func(get di.Get) interface{} {
+				return scheduler
+			},
+		})
+	}
+
+	if err := configuration.ClaimCodes.Validate(); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("failed to enable the claim codes: " + err.Error())
+		return false
+	}
+
+	if err := configuration.DryRun.Validate(); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("failed to enable the dry runs: " + err.Error())
+		return false
+	}
+
+	if configuration.DeviceSecrets.Enabled {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		if os.Getenv("EDGEX_SECURITY_SECRET_STORE") == "false" {
+			// the device services read their secrets from their own insecure configuration, out of reach
+			lc.Warn("the secret store is disabled, the secrets of the devices can't be set through core-metadata")
+		} else {
+			store, err := devicesecrets.NewVaultStore(devicesecrets.VaultInfo{
+				Url: fmt.Sprintf("%s://%s:%d", configuration.SecretStore.Protocol, configuration.SecretStore.Host,
+					configuration.SecretStore.Port),
+				BasePath:       configuration.DeviceSecrets.SecretBasePath,
+				TokenFile:      configuration.SecretStore.TokenFile,
+				RootCaCertPath: configuration.SecretStore.RootCaCertPath,
+				ServerName:     configuration.SecretStore.ServerName,
+			})
+			if err != nil {
+				lc.Error("failed to enable the secrets of the devices: " + err.Error())
+				return false
+			}
+			dic.Update(di.ServiceConstructorMap{
+				v2MetadataContainer.DeviceSecretStoreName: func(get di.Get) interface{} {
+					return store
+				},
+			})
+		}
+	}
+
 	return true
 }
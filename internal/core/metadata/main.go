@@ -16,6 +16,7 @@ package metadata
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/edgexfoundry/edgex-go"
@@ -23,7 +24,13 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configflags"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configprovider"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/database"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/httpserver"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/registry"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/chaos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/telemetry"
 	v2Handlers "github.com/edgexfoundry/edgex-go/internal/pkg/v2/bootstrap/handlers"
 
@@ -47,8 +54,19 @@ func Main(ctx context.Context, cancel context.CancelFunc, router *mux.Router, re
 	//      ....
 	//      flags.Parse(os.Args[1:])
 	//
-	f := flags.New()
+	var inMemory bool
+	var chaosPath string
+	var configFlags configflags.Flags
+	f := flags.NewWithUsage(database.InMemoryUsage + chaos.Usage + configflags.Usage)
+	f.FlagSet.BoolVar(&inMemory, database.InMemoryFlag, false, "")
+	f.FlagSet.StringVar(&chaosPath, chaos.Flag, "", "")
+	configFlags.Register(f.FlagSet)
 	f.Parse(os.Args[1:])
+	if err := configFlags.Apply(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	providerFlags := configprovider.NewFlags(f)
 
 	configuration := &config.ConfigurationStruct{}
 	dic := di.NewContainer(di.ServiceConstructorMap{
@@ -57,22 +75,28 @@ func Main(ctx context.Context, cancel context.CancelFunc, router *mux.Router, re
 		},
 	})
 
-	httpServer := handlers.NewHttpServer(router, true)
+	httpServer := httpserver.NewHttpServer(router, configuration, true)
 
 	bootstrap.Run(
 		ctx,
 		cancel,
-		f,
+		providerFlags,
 		clients.CoreMetaDataServiceKey,
 		internal.ConfigStemCore+internal.ConfigMajorVersion,
 		configuration,
 		startupTimer,
 		dic,
 		[]interfaces.BootstrapHandler{
+			configprovider.NewHandler(providerFlags, clients.CoreMetaDataServiceKey, internal.ConfigStemCore+internal.ConfigMajorVersion, configuration).BootstrapHandler,
+			configflags.NewHandler(&configFlags, configuration).BootstrapHandler,
 			handlers.SecureProviderBootstrapHandler,
+			secretcache.NewHandler(configuration).BootstrapHandler,
+			database.NewInMemory(configuration, inMemory).BootstrapHandler,
 			database.NewDatabase(httpServer, configuration).BootstrapHandler,
 			v2Handlers.NewDatabase(httpServer, configuration, v2MetadataContainer.DBClientInterfaceName).BootstrapHandler, // add v2 db client bootstrap handler
 			NewBootstrap(router).BootstrapHandler,
+			chaos.NewHandler(router, chaosPath).BootstrapHandler,
+			registry.NewHandler(configuration, clients.CoreMetaDataServiceKey).BootstrapHandler,
 			telemetry.BootstrapHandler,
 			httpServer.BootstrapHandler,
 			handlers.NewStartMessage(clients.CoreMetaDataServiceKey, edgex.Version).BootstrapHandler,
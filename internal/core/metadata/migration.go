@@ -0,0 +1,41 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"fmt"
+
+	redisClient "github.com/edgexfoundry/edgex-go/internal/pkg/db/redis"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// databaseMigrator is implemented by the database clients migrating the layout of the core-metadata keys
+type databaseMigrator interface {
+	MigrateCoreMetadata(dryRun bool) error
+}
+
+// migrateDatabase runs the pending migrations of the layout of the core-metadata keys, such as the indexing for search
+// of the devices and device profiles stored by the previous releases, waiting while another instance sharing the
+// database migrates them. The databases without migrations, such as the in-memory one, are left as they are.
+func migrateDatabase(lc logger.LoggingClient, dbClient interface{}, startupTimer startup.Timer) error {
+	migrator, ok := dbClient.(databaseMigrator)
+	if !ok {
+		lc.Debug(fmt.Sprintf("database client %T has no migrations", dbClient))
+		return nil
+	}
+
+	for startupTimer.HasNotElapsed() {
+		err := migrator.MigrateCoreMetadata(false)
+		if err != redisClient.ErrMigrationLocked {
+			return err
+		}
+		lc.Info("waiting for another instance to migrate the database")
+		startupTimer.SleepForInterval()
+	}
+	return fmt.Errorf("another instance is still migrating the database")
+}
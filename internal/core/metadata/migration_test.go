@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"errors"
+	"testing"
+
+	redisClient "github.com/edgexfoundry/edgex-go/internal/pkg/db/redis"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubMigrator returns the errors in order, then nil
+type stubMigrator struct {
+	errors []error
+	calls  int
+}
+
+func (m *stubMigrator) MigrateCoreMetadata(_ bool) error {
+	m.calls++
+	if len(m.errors) == 0 {
+		return nil
+	}
+	err := m.errors[0]
+	m.errors = m.errors[1:]
+	return err
+}
+
+func TestMigrateDatabase(t *testing.T) {
+	tests := []struct {
+		name          string
+		migrator      *stubMigrator
+		expectedCalls int
+		expectError   bool
+	}{
+		{"migrated", &stubMigrator{}, 1, false},
+		{"migrated after another instance", &stubMigrator{errors: []error{redisClient.ErrMigrationLocked}}, 2, false},
+		{"migration failed", &stubMigrator{errors: []error{errors.New("failed")}}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := migrateDatabase(logger.MockLogger{}, tt.migrator, startup.NewTimer(5, 1))
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, tt.migrator.calls)
+		})
+	}
+}
+
+func TestMigrateDatabase_NoMigrations(t *testing.T) {
+	assert.NoError(t, migrateDatabase(logger.MockLogger{}, struct{}{}, startup.NewTimer(5, 1)))
+}
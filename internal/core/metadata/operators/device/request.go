@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 )
 
@@ -55,6 +57,7 @@ func NewRequester(key RequestType, logger logger.LoggingClient, ctx context.Cont
 }
 
 func (op httpRequester) Execute(req *http.Request) {
+	correlation.Propagate(op.ctx, req)
 	resp, err := op.client.Do(req)
 	if err == nil {
 		defer resp.Body.Close()
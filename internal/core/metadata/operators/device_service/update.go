@@ -16,12 +16,14 @@ package device_service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/errors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
@@ -201,5 +203,7 @@ func createCallbackRequest(httpMethod string, service contract.DeviceService) (*
 		return nil, err
 	}
 	req.Header.Add(clients.ContentType, clients.ContentTypeJSON)
+	// The callback is made once the request has been answered, so it starts a new correlation id
+	correlation.Propagate(correlation.NewContext(context.Background()), req)
 	return req, nil
 }
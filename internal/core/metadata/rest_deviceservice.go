@@ -27,6 +27,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/operators/device_service"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/errorconcept"
 
@@ -817,6 +818,9 @@ func callback(
 			return err
 		}
 		req.Header.Add(clients.ContentType, clients.ContentTypeJSON)
+		// The callback is asynchronous and not tied to the request changing the object, so it starts a new
+		// correlation id
+		correlation.Propagate(correlation.NewContext(context.Background()), req)
 
 		go makeRequest(client, req, lc)
 	} else {
@@ -0,0 +1,253 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// AddAdminSchedule validates the admin state schedule, checks that it doesn't change the admin state of the devices
+// of another schedule to the opposite one at the same time, and then adds it and registers it in support-scheduler
+func AddAdminSchedule(s adminschedules.AdminSchedule, ctx context.Context, dic *di.Container) (id string, err errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	if err = s.Validate(common.MakeTimestamp()); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	scheduler := v2MetadataContainer.AdminSchedulerFrom(dic.Get)
+	if scheduler == nil {
+		return "", errors.NewCommonEdgeX(errors.KindServiceUnavailable,
+			"admin state schedules require the Scheduler client to be configured", nil)
+	}
+	if s.DeviceName != "" {
+		if _, err = dbClient.DeviceByName(s.DeviceName); err != nil {
+			return "", errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	if err = checkAdminScheduleConflicts(s, dbClient); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	added, err := dbClient.AddAdminSchedule(s)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	if err = scheduler.Register(ctx, added); err != nil {
+		// the schedule is removed not to be kept without ever running
+		if deleteErr := dbClient.DeleteAdminScheduleByName(added.Name); deleteErr != nil {
+			lc.Errorf("failed to delete the unregistered admin state schedule %s: %v", added.Name, deleteErr)
+		}
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Admin state schedule created on DB successfully. Admin state schedule ID: %s, Correlation-ID: %s ",
+		added.Id,
+		correlation.FromContext(ctx))
+
+	return added.Id, nil
+}
+
+// checkAdminScheduleConflicts returns a KindStatusConflict error when s and another schedule change the admin state
+// of a same device to opposite states at the same time. The groups of devices which don't always overlap are
+// compared with the devices having their labels now.
+func checkAdminScheduleConflicts(s adminschedules.AdminSchedule, dbClient interfaces.DBClient) errors.EdgeX {
+	all, err := dbClient.AllAdminSchedules(0, -1)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	var devices []models.Device
+	resolved := false
+	for _, other := range all {
+		if other.AdminState == s.AdminState {
+			continue
+		}
+		at, collides := adminschedules.Collision(s, other)
+		if !collides {
+			continue
+		}
+		overlap := adminschedules.SameTarget(s, other)
+		if !overlap {
+			if !resolved {
+				if devices, err = adminScheduleDevices(s, dbClient); err != nil {
+					return errors.NewCommonEdgeXWrapper(err)
+				}
+				resolved = true
+			}
+			otherDevices, err := adminScheduleDevices(other, dbClient)
+			if err != nil {
+				return errors.NewCommonEdgeXWrapper(err)
+			}
+			overlap = intersects(devices, otherDevices)
+		}
+		if overlap {
+			return errors.NewCommonEdgeX(errors.KindStatusConflict,
+				fmt.Sprintf("admin state schedule %s conflicts with %s, which sets the admin state %s of the same devices at %s",
+					s.Name, other.Name, other.AdminState, time.Unix(at, 0).UTC().Format(adminschedules.TimeLayout)), nil)
+		}
+	}
+	return nil
+}
+
+// adminScheduleDevices returns the devices of s, none when its device doesn't exist anymore
+func adminScheduleDevices(s adminschedules.AdminSchedule, dbClient interfaces.DBClient) ([]models.Device, errors.EdgeX) {
+	var devices []models.Device
+	if s.DeviceName != "" {
+		device, err := dbClient.DeviceByName(s.DeviceName)
+		if err != nil {
+			if errors.Kind(err) == errors.KindEntityDoesNotExist {
+				return nil, nil
+			}
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+		devices = append(devices, device)
+	} else {
+		var err errors.EdgeX
+		if devices, err = dbClient.AllDevices(0, -1, s.Labels); err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+
+	group := make([]models.Device, 0, len(devices))
+	for _, device := range devices {
+		// the devices are queried by any of the labels while the group has all of them
+		if s.DeviceName == "" && !s.HasLabels(device.Labels) {
+			continue
+		}
+		group = append(group, device)
+	}
+	return group, nil
+}
+
+func intersects(a []models.Device, b []models.Device) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.Name == y.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AllAdminSchedules queries the admin state schedules by offset and limit
+func AllAdminSchedules(offset, limit int, dic *di.Container) ([]adminschedules.AdminSchedule, errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.AllAdminSchedules(offset, limit)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
+
+// AdminScheduleByName queries the admin state schedule by name
+func AdminScheduleByName(name string, dic *di.Container) (s adminschedules.AdminSchedule, err errors.EdgeX) {
+	if name == "" {
+		return s, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	s, err = dbClient.AdminScheduleByName(name)
+	if err != nil {
+		return s, errors.NewCommonEdgeXWrapper(err)
+	}
+	return s, nil
+}
+
+// DeleteAdminScheduleByName unregisters the admin state schedule from support-scheduler and then deletes it
+func DeleteAdminScheduleByName(name string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	s, err := dbClient.AdminScheduleByName(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if scheduler := v2MetadataContainer.AdminSchedulerFrom(dic.Get); scheduler != nil {
+		if err = scheduler.Unregister(ctx, s); err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	if err = dbClient.DeleteAdminScheduleByName(name); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// RunAdminSchedule changes the admin state of the devices of the schedule which don't have it yet, recording a
+// system event for each of them, and returns their names. The devices whose admin state can't be changed are
+// reported in the returned error once the others were changed.
+func RunAdminSchedule(name string, authorization string, ctx context.Context, dic *di.Container) ([]string, errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+	recorder := pkgContainer.AuditRecorderFrom(dic.Get)
+
+	s, err := AdminScheduleByName(name, dic)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	devices, err := adminScheduleDevices(s, dbClient)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	path := strings.Replace(adminschedules.ApiAdminScheduleRunRoute, "{"+contractsV2.Name+"}", url.PathEscape(s.Name), 1)
+	changed := make([]string, 0, len(devices))
+	var failed []string
+	for _, device := range devices {
+		if string(device.AdminState) == s.AdminState {
+			continue
+		}
+		adminState := s.AdminState
+		deviceName := device.Name
+		if err := PatchDevice(dtos.UpdateDevice{Name: &deviceName, AdminState: &adminState}, ctx, dic); err != nil {
+			lc.Errorf("admin state schedule %s failed to set the admin state of the device %s: %v", s.Name, device.Name, err)
+			failed = append(failed, device.Name)
+			continue
+		}
+		changed = append(changed, device.Name)
+		if recorder != nil {
+			recorder.Record(audit.SystemEvent{
+				Timestamp:     common.MakeTimestamp(),
+				Service:       clients.CoreMetaDataServiceKey,
+				Actor:         s.IntervalName(),
+				Action:        audit.ActionUpdate,
+				ObjectType:    "device",
+				ObjectName:    device.Name,
+				Method:        http.MethodPut,
+				Path:          path,
+				StatusCode:    http.StatusOK,
+				CorrelationId: correlation.FromContext(ctx),
+			}, authorization)
+		}
+	}
+	if len(failed) > 0 {
+		return changed, errors.NewCommonEdgeX(errors.KindServerError,
+			fmt.Sprintf("admin state schedule %s failed to set the admin state of the devices %s", s.Name, strings.Join(failed, ", ")), nil)
+	}
+	return changed, nil
+}
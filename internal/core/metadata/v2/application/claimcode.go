@@ -0,0 +1,147 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicesecrets"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// IssueClaimCode issues the claim code of a locked device, replacing its previous one, and returns it with its
+// expiry in milliseconds. Only the hash of the code is stored.
+func IssueClaimCode(name string, ctx context.Context, dic *di.Container) (code string, expires int64, err errors.EdgeX) {
+	if name == "" {
+		return "", 0, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	info := metadataContainer.ConfigurationFrom(dic.Get).ClaimCodes
+	if !info.Enabled {
+		return "", 0, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "claim codes are not enabled", nil)
+	}
+	lifetime, parseErr := info.LifetimeDuration()
+	if parseErr != nil {
+		return "", 0, errors.NewCommonEdgeX(errors.KindServerError, parseErr.Error(), nil)
+	}
+
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	device, err := dbClient.DeviceByName(name)
+	if err != nil {
+		return "", 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	if device.AdminState != models.Locked {
+		return "", 0, errors.NewCommonEdgeX(errors.KindStatusConflict,
+			fmt.Sprintf("device %s is not a placeholder, only the %s devices can be claimed", name, models.Locked), nil)
+	}
+
+	code, genErr := claimcodes.Generate(rand.Reader)
+	if genErr != nil {
+		return "", 0, errors.NewCommonEdgeX(errors.KindServerError, genErr.Error(), genErr)
+	}
+	now := common.MakeTimestamp()
+	expires = now + lifetime.Milliseconds()
+	err = dbClient.AddClaimCode(claimcodes.ClaimCode{
+		Hash:       claimcodes.Hash(code),
+		DeviceName: device.Name,
+		Created:    now,
+		Expires:    expires,
+	})
+	if err != nil {
+		return "", 0, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	container.LoggingClientFrom(dic.Get).Debugf("Claim code of device %s issued, expiring at %s. Correlation-ID: %s ",
+		device.Name,
+		time.Unix(0, expires*int64(time.Millisecond)).UTC().Format(time.RFC3339),
+		correlation.FromContext(ctx))
+	return code, expires, nil
+}
+
+// RevokeClaimCode revokes the claim code of the device
+func RevokeClaimCode(name string, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	if !metadataContainer.ConfigurationFrom(dic.Get).ClaimCodes.Enabled {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "claim codes are not enabled", nil)
+	}
+	if err := v2MetadataContainer.DBClientFrom(dic.Get).DeleteClaimCodeByDeviceName(name); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// RedeemClaimCode finalizes the registration of the device of the claim code: its protocols are replaced and its
+// secrets stored, and then it is unlocked. The code is taken once; when the registration fails it is put back, so
+// that the technician can retry until it expires. The name of the device is returned.
+func RedeemClaimCode(req claimcodes.ClaimRequest, ctx context.Context, dic *di.Container) (string, errors.EdgeX) {
+	if !metadataContainer.ConfigurationFrom(dic.Get).ClaimCodes.Enabled {
+		return "", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "claim codes are not enabled", nil)
+	}
+	code, normalizeErr := claimcodes.Normalize(req.Code)
+	if normalizeErr != nil {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, normalizeErr.Error(), nil)
+	}
+	for secretName := range req.Secrets {
+		if err := devicesecrets.ValidateName(secretName); err != nil {
+			return "", errors.NewCommonEdgeX(errors.KindContractInvalid, err.Error(), nil)
+		}
+	}
+	if len(req.Secrets) > 0 && v2MetadataContainer.DeviceSecretStoreFrom(dic.Get) == nil {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "device secrets are not enabled", nil)
+	}
+
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+	claim, err := dbClient.TakeClaimCode(claimcodes.Hash(code))
+	if err != nil {
+		if errors.Kind(err) == errors.KindEntityDoesNotExist {
+			// not to tell the unknown codes from the expired or redeemed ones
+			return "", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "invalid or expired claim code", nil)
+		}
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	if claim.Expired(common.MakeTimestamp()) {
+		return "", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "invalid or expired claim code", nil)
+	}
+
+	if err = claimDevice(claim.DeviceName, req, ctx, dic); err != nil {
+		if restoreErr := dbClient.AddClaimCode(claim); restoreErr != nil {
+			lc.Errorf("failed to restore the claim code of device %s: %v", claim.DeviceName, restoreErr)
+		}
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Infof("Device %s claimed. Correlation-ID: %s ", claim.DeviceName, correlation.FromContext(ctx))
+	return claim.DeviceName, nil
+}
+
+func claimDevice(name string, req claimcodes.ClaimRequest, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if len(req.Secrets) > 0 {
+		if err := SetDeviceSecrets(name, req.Secrets, ctx, dic); err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	adminState := models.Unlocked
+	update := dtos.UpdateDevice{Name: &name, AdminState: &adminState, Protocols: req.Protocols}
+	if err := PatchDevice(update, ctx, dic); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
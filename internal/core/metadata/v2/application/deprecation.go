@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// AddDeprecation validates the deprecation against the device profile it deprecates a resource of, and then adds it
+func AddDeprecation(d deprecations.Deprecation, ctx context.Context, dic *di.Container) (id string, err errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	profile, err := dbClient.DeviceProfileByName(d.ProfileName)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	if err = d.Validate(profile); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	added, err := dbClient.AddDeprecation(d)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Deprecation created on DB successfully. Deprecation ID: %s, Correlation-ID: %s ",
+		added.Id,
+		correlation.FromContext(ctx))
+
+	return added.Id, nil
+}
+
+// AllDeprecations queries deprecations by offset and limit
+func AllDeprecations(offset, limit int, dic *di.Container) ([]deprecations.Deprecation, errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.AllDeprecations(offset, limit)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
+
+// DeprecationsByProfileName queries the deprecations of a device profile
+func DeprecationsByProfileName(name string, dic *di.Container) ([]deprecations.Deprecation, errors.EdgeX) {
+	if name == "" {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.DeprecationsByProfileName(name)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
+
+// DeleteDeprecationByProfileNameAndResourceName withdraws the deprecation of a resource of a device profile
+func DeleteDeprecationByProfileNameAndResourceName(profileName string, resourceName string, dic *di.Container) errors.EdgeX {
+	if profileName == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	if resourceName == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "resource name is empty", nil)
+	}
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteDeprecationByProfileNameAndResourceName(profileName, resourceName)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// DeprecationUsages returns, for each deprecation, the devices which still reference the deprecated resource
+func DeprecationUsages(dic *di.Container) ([]deprecations.Usage, errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	all, err := dbClient.AllDeprecations(0, -1)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	usages := make([]deprecations.Usage, 0, len(all))
+	// the devices are queried once per device profile
+	devicesByProfile := make(map[string][]models.Device)
+	for _, d := range all {
+		devices, ok := devicesByProfile[d.ProfileName]
+		if !ok {
+			devices, err = dbClient.DevicesByProfileName(0, -1, d.ProfileName)
+			if err != nil {
+				return nil, errors.NewCommonEdgeXWrapper(err)
+			}
+			devicesByProfile[d.ProfileName] = devices
+		}
+		usages = append(usages, deprecations.NewUsage(d, devices))
+	}
+	return usages, nil
+}
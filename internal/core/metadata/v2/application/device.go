@@ -29,6 +29,12 @@ func AddDevice(d models.Device, ctx context.Context, dic *di.Container) (id stri
 	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
 	lc := container.LoggingClientFrom(dic.Get)
 
+	if edgeXerr = checkDeviceSecrets(d, dic); edgeXerr != nil {
+		return id, edgeXerr
+	}
+	if edgeXerr = checkLabels("device", d.Name, d.Labels, dic); edgeXerr != nil {
+		return id, edgeXerr
+	}
 	exists, edgeXerr := dbClient.DeviceServiceNameExists(d.ServiceName)
 	if edgeXerr != nil {
 		return id, errors.NewCommonEdgeXWrapper(edgeXerr)
@@ -52,7 +58,7 @@ func AddDevice(d models.Device, ctx context.Context, dic *di.Container) (id stri
 		addedDevice.Id,
 		correlation.FromContext(ctx),
 	))
-	go addDeviceCallback(ctx, dic, dtos.FromDeviceModelToDTO(d))
+	addDeviceCallback(ctx, dic, dtos.FromDeviceModelToDTO(d))
 	return addedDevice.Id, nil
 }
 
@@ -70,7 +76,7 @@ func DeleteDeviceByName(name string, ctx context.Context, dic *di.Container) err
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
-	go deleteDeviceCallback(ctx, dic, device)
+	deleteDeviceCallback(ctx, dic, device)
 	return nil
 }
 
@@ -109,36 +115,17 @@ func PatchDevice(dto dtos.UpdateDevice, ctx context.Context, dic *di.Container)
 	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
 	lc := container.LoggingClientFrom(dic.Get)
 
-	if dto.ServiceName != nil {
-		exists, edgeXerr := dbClient.DeviceServiceNameExists(*dto.ServiceName)
-		if edgeXerr != nil {
-			return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("device service '%s' existence check failed", *dto.ServiceName), edgeXerr)
-		} else if !exists {
-			return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("device service '%s' does not exists", *dto.ServiceName), nil)
-		}
-	}
-	if dto.ProfileName != nil {
-		exists, edgeXerr := dbClient.DeviceProfileNameExists(*dto.ProfileName)
-		if edgeXerr != nil {
-			return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("device profile '%s' existence check failed", *dto.ProfileName), edgeXerr)
-		} else if !exists {
-			return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("device profile '%s' does not exists", *dto.ProfileName), nil)
-		}
-	}
-
-	device, err := deviceByDTO(dbClient, dto)
+	original, device, err := patchedDevice(dbClient, dto, dic)
 	if err != nil {
-		return errors.NewCommonEdgeXWrapper(err)
+		return err
 	}
 
 	// Old service name is used for invoking callback
 	var oldServiceName string
-	if dto.ServiceName != nil && *dto.ServiceName != device.ServiceName {
-		oldServiceName = device.ServiceName
+	if original.ServiceName != device.ServiceName {
+		oldServiceName = original.ServiceName
 	}
 
-	requests.ReplaceDeviceModelFieldsWithDTO(&device, dto)
-
 	err = dbClient.UpdateDevice(device)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -150,12 +137,47 @@ func PatchDevice(dto dtos.UpdateDevice, ctx context.Context, dic *di.Container)
 	))
 
 	if oldServiceName != "" {
-		go updateDeviceCallback(ctx, dic, oldServiceName, device)
+		updateDeviceCallback(ctx, dic, oldServiceName, device)
 	}
-	go updateDeviceCallback(ctx, dic, device.ServiceName, device)
+	updateDeviceCallback(ctx, dic, device.ServiceName, device)
 	return nil
 }
 
+// patchedDevice validates the PATCH operation with the device DTO, and returns the device before and after it
+func patchedDevice(dbClient interfaces.DBClient, dto dtos.UpdateDevice, dic *di.Container) (original models.Device, device models.Device, err errors.EdgeX) {
+	if dto.ServiceName != nil {
+		exists, edgeXerr := dbClient.DeviceServiceNameExists(*dto.ServiceName)
+		if edgeXerr != nil {
+			return original, device, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("device service '%s' existence check failed", *dto.ServiceName), edgeXerr)
+		} else if !exists {
+			return original, device, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("device service '%s' does not exists", *dto.ServiceName), nil)
+		}
+	}
+	if dto.ProfileName != nil {
+		exists, edgeXerr := dbClient.DeviceProfileNameExists(*dto.ProfileName)
+		if edgeXerr != nil {
+			return original, device, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("device profile '%s' existence check failed", *dto.ProfileName), edgeXerr)
+		} else if !exists {
+			return original, device, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("device profile '%s' does not exists", *dto.ProfileName), nil)
+		}
+	}
+
+	device, err = deviceByDTO(dbClient, dto)
+	if err != nil {
+		return original, device, errors.NewCommonEdgeXWrapper(err)
+	}
+	original = device
+
+	requests.ReplaceDeviceModelFieldsWithDTO(&device, dto)
+	if err = checkDeviceSecrets(device, dic); err != nil {
+		return original, device, err
+	}
+	if err = checkLabels("device", device.Name, device.Labels, dic); err != nil {
+		return original, device, err
+	}
+	return original, device, nil
+}
+
 func deviceByDTO(dbClient interfaces.DBClient, dto dtos.UpdateDevice) (device models.Device, edgeXerr errors.EdgeX) {
 	if dto.Id != nil {
 		if *dto.Id == "" {
@@ -198,6 +220,20 @@ func AllDevices(offset int, limit int, labels []string, dic *di.Container) (devi
 	return devices, nil
 }
 
+// DevicesBySearch query the devices matching the free-text search query with offset, limit, and labels
+func DevicesBySearch(offset int, limit int, search string, labels []string, dic *di.Container) (devices []dtos.Device, err errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	ds, err := dbClient.DevicesBySearch(offset, limit, search, labels)
+	if err != nil {
+		return devices, errors.NewCommonEdgeXWrapper(err)
+	}
+	devices = make([]dtos.Device, len(ds))
+	for i, d := range ds {
+		devices[i] = dtos.FromDeviceModelToDTO(d)
+	}
+	return devices, nil
+}
+
 // DeviceByName query the device by name
 func DeviceByName(name string, dic *di.Container) (device dtos.Device, err errors.EdgeX) {
 	if name == "" {
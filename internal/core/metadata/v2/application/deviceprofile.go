@@ -25,6 +25,9 @@ func AddDeviceProfile(d models.DeviceProfile, ctx context.Context, dic *di.Conta
 	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
 	lc := container.LoggingClientFrom(dic.Get)
 
+	if err = checkLabels("device profile", d.Name, d.Labels, dic); err != nil {
+		return "", err
+	}
 	correlationId := correlation.FromContext(ctx)
 	addedDeviceProfile, err := dbClient.AddDeviceProfile(d)
 	if err != nil {
@@ -46,6 +49,9 @@ func UpdateDeviceProfile(d models.DeviceProfile, ctx context.Context, dic *di.Co
 	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
 	lc := container.LoggingClientFrom(dic.Get)
 
+	if err = checkLabels("device profile", d.Name, d.Labels, dic); err != nil {
+		return err
+	}
 	err = dbClient.UpdateDeviceProfile(d)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -55,7 +61,7 @@ func UpdateDeviceProfile(d models.DeviceProfile, ctx context.Context, dic *di.Co
 		"DeviceProfile updated on DB successfully. Correlation-id: %s ",
 		correlation.FromContext(ctx),
 	))
-	go updateDeviceProfileCallback(ctx, dic, dtos.FromDeviceProfileModelToDTO(d))
+	updateDeviceProfileCallback(ctx, dic, dtos.FromDeviceProfileModelToDTO(d))
 	return nil
 }
 
@@ -117,6 +123,20 @@ func AllDeviceProfiles(offset int, limit int, labels []string, dic *di.Container
 	return deviceProfiles, nil
 }
 
+// DeviceProfilesBySearch query the device profiles matching the free-text search query with offset, limit, and labels
+func DeviceProfilesBySearch(offset int, limit int, search string, labels []string, dic *di.Container) (deviceProfiles []dtos.DeviceProfile, err errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	dps, err := dbClient.DeviceProfilesBySearch(offset, limit, search, labels)
+	if err != nil {
+		return deviceProfiles, errors.NewCommonEdgeXWrapper(err)
+	}
+	deviceProfiles = make([]dtos.DeviceProfile, len(dps))
+	for i, dp := range dps {
+		deviceProfiles[i] = dtos.FromDeviceProfileModelToDTO(dp)
+	}
+	return deviceProfiles, nil
+}
+
 // DeviceProfilesByModel query the device profiles with offset, limit and model
 func DeviceProfilesByModel(offset int, limit int, model string, dic *di.Container) (deviceProfiles []dtos.DeviceProfile, err errors.EdgeX) {
 	if model == "" {
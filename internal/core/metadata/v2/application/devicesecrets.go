@@ -0,0 +1,68 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicesecrets"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// SetDeviceSecrets writes the secrets of the device to the secret store path of the device, under the path of its
+// device service. The values of the secrets are never logged.
+func SetDeviceSecrets(name string, secrets map[string]string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	store := v2MetadataContainer.DeviceSecretStoreFrom(dic.Get)
+	if store == nil {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device secrets are not enabled", nil)
+	}
+	for secretName := range secrets {
+		if err := devicesecrets.ValidateName(secretName); err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, err.Error(), nil)
+		}
+	}
+
+	device, edgeXerr := v2MetadataContainer.DBClientFrom(dic.Get).DeviceByName(name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if err := store.StoreSecrets(device.ServiceName, device.Name, secrets); err != nil {
+		return errors.NewCommonEdgeX(errors.KindServiceUnavailable, fmt.Sprintf("failed to store the secrets of device %s", name), err)
+	}
+
+	container.LoggingClientFrom(dic.Get).Debug(fmt.Sprintf(
+		"%d secrets of device %s stored at %s. Correlation-ID: %s ",
+		len(secrets),
+		name,
+		devicesecrets.Path(device.ServiceName, device.Name),
+		correlation.FromContext(ctx),
+	))
+	return nil
+}
+
+// checkDeviceSecrets rejects the device if it holds the value of a sensitive protocol property rather than a
+// reference to a secret
+func checkDeviceSecrets(d models.Device, dic *di.Container) errors.EdgeX {
+	info := metadataContainer.ConfigurationFrom(dic.Get).DeviceSecrets
+	if !info.Enabled {
+		return nil
+	}
+	if err := devicesecrets.CheckPlaintext(d.Protocols, info.SensitiveProperties); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device %s: %s", d.Name, err.Error()), nil)
+	}
+	return nil
+}
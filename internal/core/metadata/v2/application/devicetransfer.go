@@ -0,0 +1,74 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicetransfer"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// TransferDevice transfers the device to the device service of the request, and returns the device service it was
+// transferred from. The device service the device is transferred from is called back as if the device was deleted,
+// so that it stops serving it, and the device service it is transferred to as if the device was added.
+func TransferDevice(name string, request devicetransfer.TransferRequest, ctx context.Context, dic *di.Container) (from string, err errors.EdgeX) {
+	if name == "" {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	device, err := dbClient.DeviceByName(name)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	if device.ServiceName == request.ServiceName {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("device %s already belongs to device service %s", name, request.ServiceName), nil)
+	}
+	target, err := dbClient.DeviceServiceByName(request.ServiceName)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	if target.AdminState == models.Locked {
+		return "", errors.NewCommonEdgeX(errors.KindStatusConflict,
+			fmt.Sprintf("device service %s is %s", target.Name, models.Locked), nil)
+	}
+
+	if !request.Force {
+		devices, err := dbClient.DevicesByServiceName(0, -1, target.Name)
+		if err != nil {
+			return "", errors.NewCommonEdgeXWrapper(err)
+		}
+		watchers, err := dbClient.ProvisionWatchersByServiceName(0, -1, target.Name)
+		if err != nil {
+			return "", errors.NewCommonEdgeXWrapper(err)
+		}
+		if checkErr := devicetransfer.CheckCompatibility(device, target, devices, watchers); checkErr != nil {
+			return "", errors.NewCommonEdgeX(errors.KindStatusConflict, checkErr.Error(), nil)
+		}
+	}
+
+	transferred := device
+	transferred.ServiceName = target.Name
+	err = dbClient.UpdateDevice(transferred)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	container.LoggingClientFrom(dic.Get).Debugf("Device %s transferred from device service %s to %s. Correlation-ID: %s ",
+		name, device.ServiceName, target.Name, correlation.FromContext(ctx))
+	deleteDeviceCallback(ctx, dic, device)
+	addDeviceCallback(ctx, dic, dtos.FromDeviceModelToDTO(transferred))
+	return device.ServiceName, nil
+}
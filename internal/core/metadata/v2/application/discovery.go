@@ -0,0 +1,157 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/notifications"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+const discoveryLimitSlug = "discovery-limit-"
+
+// AddDiscoveredDevice adds a device discovered by a device service and matched with the provision watcher named
+// watcherName, and records it in the discovery audit log. The provision watcher is locked once it reaches its
+// discovery limit, locked provision watchers rejecting the devices they match.
+func AddDiscoveredDevice(watcherName string, discoveryId string, d models.Device, ctx context.Context, dic *di.Container) (id string, err errors.EdgeX) {
+	if watcherName == "" {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	pw, err := dbClient.ProvisionWatcherByName(watcherName)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	if pw.AdminState == models.Locked {
+		return "", errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("provision watcher %s is locked", pw.Name), nil)
+	}
+	if d.ServiceName != pw.ServiceName || d.ProfileName != pw.ProfileName {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("device %s of device service %s and device profile %s doesn't match provision watcher %s",
+				d.Name, d.ServiceName, d.ProfileName, pw.Name), nil)
+	}
+
+	id, err = AddDevice(d, ctx, dic)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	record, err := dbClient.AddDiscoveryRecord(discovery.Record{
+		ProvisionWatcherName: pw.Name,
+		DiscoveryId:          discoveryId,
+		DeviceId:             id,
+		DeviceName:           d.Name,
+		ServiceName:          d.ServiceName,
+		ProfileName:          d.ProfileName,
+	})
+	if err != nil {
+		// the device is added regardless, the audit log missing it rather than the device service retrying
+		lc.Errorf("fail to record device %s discovered through provision watcher %s, err: %v", d.Name, pw.Name, err)
+		return id, nil
+	}
+	lc.Debugf("Discovery record created on DB successfully. Discovery record ID: %s, Correlation-ID: %s ",
+		record.Id,
+		correlation.FromContext(ctx))
+
+	if err = enforceDiscoveryLimit(ctx, dic, pw); err != nil {
+		lc.Errorf("fail to enforce the discovery limit of provision watcher %s, err: %v", pw.Name, err)
+	}
+	return id, nil
+}
+
+// enforceDiscoveryLimit locks the provision watcher, and notifies it, once it added the maximum number of devices of
+// its limit within the limit window
+func enforceDiscoveryLimit(ctx context.Context, dic *di.Container, pw models.ProvisionWatcher) errors.EdgeX {
+	configuration := metadataContainer.ConfigurationFrom(dic.Get)
+	limit := configuration.Writable.DiscoveryLimits.For(pw.Name)
+	if !limit.Enabled() {
+		return nil
+	}
+	since, err := limit.Since(time.Now())
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	count, err := dbClient.DiscoveryRecordCountByProvisionWatcherName(pw.Name, since)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if int(count) < limit.MaxDevices {
+		return nil
+	}
+
+	pw.AdminState = models.Locked
+	if err = dbClient.UpdateProvisionWatcher(pw); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	content := fmt.Sprintf("provision watcher %s added %d devices", pw.Name, count)
+	if limit.Window != "" {
+		content += " within " + limit.Window
+	}
+	content += ", it is locked until unlocked by an operator"
+	container.LoggingClientFrom(dic.Get).Warn(content)
+
+	updateProvisionWatcherCallback(ctx, dic, pw.ServiceName, pw)
+	go notifyDiscoveryLimit(ctx, dic, content)
+	return nil
+}
+
+// notifyDiscoveryLimit sends the notification of a provision watcher locked by its discovery limit
+func notifyDiscoveryLimit(ctx context.Context, dic *di.Container, content string) {
+	configuration := metadataContainer.ConfigurationFrom(dic.Get)
+	notification := notifications.Notification{
+		Slug:        discoveryLimitSlug + strconv.FormatInt(common.MakeTimestamp(), 10),
+		Content:     content,
+		Category:    notifications.SW_HEALTH,
+		Description: "provision watcher discovery limit reached",
+		Labels:      []string{configuration.Notifications.Label},
+		Sender:      configuration.Notifications.Sender,
+		Severity:    notifications.CRITICAL,
+	}
+	if err := metadataContainer.NotificationsClientFrom(dic.Get).SendNotification(ctx, notification); err != nil {
+		container.LoggingClientFrom(dic.Get).Errorf("fail to send the discovery limit notification, err: %v", err)
+	}
+}
+
+// AllDiscoveryRecords queries discovery records by offset and limit
+func AllDiscoveryRecords(offset, limit int, dic *di.Container) ([]discovery.Record, errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.AllDiscoveryRecords(offset, limit)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
+
+// DiscoveryRecordsByProvisionWatcherName queries the discovery records of a provision watcher by offset and limit
+func DiscoveryRecordsByProvisionWatcherName(offset, limit int, name string, dic *di.Container) ([]discovery.Record, errors.EdgeX) {
+	if name == "" {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.DiscoveryRecordsByProvisionWatcherName(offset, limit, name)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dryrun"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// DryRunPatchDevice validates the PATCH operation with the device DTO without applying it, and returns the findings
+// of the change of the device profile of the device against its recent readings
+func DryRunPatchDevice(dto dtos.UpdateDevice, ctx context.Context, dic *di.Container) ([]dryrun.Finding, errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	original, device, err := patchedDevice(dbClient, dto, dic)
+	if err != nil {
+		return nil, err
+	}
+	if original.ProfileName == device.ProfileName {
+		return nil, nil
+	}
+
+	old, err := dbClient.DeviceProfileByName(original.ProfileName)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	updated, err := dbClient.DeviceProfileByName(device.ProfileName)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	return checkRecentReadings(old, updated, []models.Device{original}, ctx, dic), nil
+}
+
+// DryRunUpdateDeviceProfile validates the update of the device profile without applying it, and returns the findings
+// of the changes of its device resources against the recent readings of its devices
+func DryRunUpdateDeviceProfile(d models.DeviceProfile, ctx context.Context, dic *di.Container) ([]dryrun.Finding, errors.EdgeX) {
+	if err := checkLabels("device profile", d.Name, d.Labels, dic); err != nil {
+		return nil, err
+	}
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	old, err := dbClient.DeviceProfileByName(d.Name)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	if len(dryrun.Changes(old, d)) == 0 {
+		return nil, nil
+	}
+
+	deviceLimit := metadataContainer.ConfigurationFrom(dic.Get).DryRun.DeviceLimit
+	limit := -1
+	if deviceLimit > 0 {
+		// one more device tells whether some devices are left unchecked
+		limit = deviceLimit + 1
+	}
+	devices, err := dbClient.DevicesByProfileName(0, limit, d.Name)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	var unchecked []dryrun.Finding
+	if deviceLimit > 0 && len(devices) > deviceLimit {
+		devices = devices[:deviceLimit]
+		unchecked = append(unchecked, dryrun.Finding{
+			Severity: dryrun.SeverityWarning,
+			Message: fmt.Sprintf("device profile %s has more than %d devices, the readings of the others are not checked",
+				d.Name, deviceLimit),
+		})
+	}
+	return append(checkRecentReadings(old, d, devices, ctx, dic), unchecked...), nil
+}
+
+// checkRecentReadings returns the changes of the device resources from old to updated, along with the breaking
+// findings of the recent readings of devices queried from core-data. The devices whose readings can't be queried are
+// reported as warnings.
+func checkRecentReadings(old models.DeviceProfile, updated models.DeviceProfile, devices []models.Device, ctx context.Context, dic *di.Container) []dryrun.Finding {
+	findings := dryrun.Changes(old, updated)
+	info := metadataContainer.ConfigurationFrom(dic.Get).DryRun
+	client := v2MetadataContainer.ReadingClientFrom(dic.Get)
+	if len(findings) == 0 || info.ReadingLimit <= 0 || client == nil {
+		return findings
+	}
+	// the timeout was validated on startup
+	timeout, _ := info.TimeoutDuration()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, d := range devices {
+		response, err := client.ReadingsByDeviceName(ctx, d.Name, 0, info.ReadingLimit)
+		if err != nil {
+			if errors.Kind(err) == errors.KindEntityDoesNotExist {
+				continue
+			}
+			findings = append(findings, dryrun.Finding{
+				DeviceName: d.Name,
+				Severity:   dryrun.SeverityWarning,
+				Message:    fmt.Sprintf("the recent readings of device %s can't be checked: %s", d.Name, err.Message()),
+			})
+			continue
+		}
+		findings = append(findings, dryrun.CheckReadings(old, updated, response.Readings)...)
+	}
+	return findings
+}
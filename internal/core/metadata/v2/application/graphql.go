@@ -0,0 +1,414 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/graphql"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// ExecuteGraphQL executes a read-only GraphQL query of the devices, device profiles, device services and provision
+// watchers, which resolves their relationships so that a client fetches a device with its profile and service in one
+// request. The objects have the fields of their JSON representation in the REST API, the maps such as the protocols
+// and attributes being returned whole.
+func ExecuteGraphQL(ctx context.Context, request graphql.Request, dic *di.Container) graphql.Response {
+	config := metadataContainer.ConfigurationFrom(dic.Get)
+	r := &graphQLResolver{
+		dbClient:       v2MetadataContainer.DBClientFrom(dic.Get),
+		maxResultCount: config.Service.MaxResultCount,
+		profiles:       make(map[string]interface{}),
+		services:       make(map[string]interface{}),
+	}
+	schema, err := r.schema()
+	if err != nil {
+		return graphql.Response{Errors: []graphql.Error{{Message: fmt.Sprintf("invalid GraphQL schema: %s", err.Error())}}}
+	}
+	return graphql.Execute(ctx, schema, config.GraphQL.MaxDepth, request)
+}
+
+// graphQLResolver resolves the fields of a query, reading each device profile and device service referenced by the
+// devices and provision watchers once
+type graphQLResolver struct {
+	dbClient       interfaces.DBClient
+	maxResultCount int
+	profiles       map[string]interface{}
+	services       map[string]interface{}
+}
+
+// fields returns the fields of type fieldType resolved from the JSON representation of the objects
+func fields(fieldType gql.Output, names ...string) gql.Fields {
+	set := make(gql.Fields, len(names))
+	for _, name := range names {
+		set[name] = &gql.Field{Type: fieldType}
+	}
+	return set
+}
+
+// object returns an object type with the fields of all the sets of fields
+func object(name string, sets ...gql.Fields) *gql.Object {
+	all := make(gql.Fields)
+	for _, set := range sets {
+		for n, f := range set {
+			all[n] = f
+		}
+	}
+	return gql.NewObject(gql.ObjectConfig{Name: name, Fields: all})
+}
+
+func (r *graphQLResolver) schema() (gql.Schema, error) {
+	nameArgs := gql.FieldConfigArgument{v2.Name: {Type: gql.NewNonNull(gql.String)}}
+	pageArgs := func() gql.FieldConfigArgument {
+		return gql.FieldConfigArgument{
+			v2.Offset: {Type: gql.Int, DefaultValue: v2.DefaultOffset},
+			v2.Limit:  {Type: gql.Int, DefaultValue: v2.DefaultLimit},
+		}
+	}
+	listArgs := func(filters ...string) gql.FieldConfigArgument {
+		args := pageArgs()
+		args[v2.Labels] = &gql.ArgumentConfig{Type: gql.NewList(gql.NewNonNull(gql.String))}
+		for _, f := range filters {
+			args[f] = &gql.ArgumentConfig{Type: gql.String}
+		}
+		return args
+	}
+	labels := gql.Fields{"labels": {Type: gql.NewList(gql.String)}}
+
+	autoEvent := object("AutoEvent", fields(gql.String, "frequency", "resource"), fields(gql.Boolean, "onChange"))
+	properties := object("ResourceProperties", fields(gql.String, "valueType", "readWrite", "units", "minimum",
+		"maximum", "defaultValue", "mask", "shift", "scale", "offset", "base", "assertion", "mediaType"))
+	resource := object("DeviceResource", fields(gql.String, "name", "description", "tag"),
+		fields(graphql.JSON, "attributes"), gql.Fields{"properties": {Type: properties}})
+	operation := object("ResourceOperation", fields(gql.String, "deviceResource", "parameter"),
+		fields(graphql.JSON, "mappings"))
+	command := object("DeviceCommand", fields(gql.String, "name"),
+		fields(gql.NewList(operation), "get", "set"))
+	coreCommand := object("CoreCommand", fields(gql.String, "name"), fields(gql.Boolean, "get", "set"))
+
+	device := object("Device", labels,
+		fields(gql.String, "id", "name", "description", "adminState", "operatingState", "serviceName", "profileName"),
+		fields(graphql.Long, "created", "modified", "lastConnected", "lastReported"),
+		fields(graphql.JSON, "location", "protocols"),
+		gql.Fields{"autoEvents": {Type: gql.NewList(autoEvent)}})
+	profile := object("DeviceProfile", labels,
+		fields(gql.String, "id", "name", "manufacturer", "model", "description"),
+		gql.Fields{
+			"deviceResources": {Type: gql.NewList(resource)},
+			"deviceCommands":  {Type: gql.NewList(command)},
+			"coreCommands":    {Type: gql.NewList(coreCommand)},
+		})
+	service := object("DeviceService", labels,
+		fields(gql.String, "id", "name", "description", "baseAddress", "adminState"),
+		fields(graphql.Long, "created", "modified", "lastConnected", "lastReported"))
+	watcher := object("ProvisionWatcher", labels,
+		fields(gql.String, "id", "name", "profileName", "serviceName", "adminState"),
+		fields(graphql.JSON, "identifiers", "blockingIdentifiers"),
+		gql.Fields{"autoEvents": {Type: gql.NewList(autoEvent)}})
+
+	// the relationships are added once all the object types exist, as they refer to each other
+	device.AddFieldConfig("profile", &gql.Field{Type: profile, Resolve: r.profileOf})
+	device.AddFieldConfig("service", &gql.Field{Type: service, Resolve: r.serviceOf})
+	profile.AddFieldConfig("devices", &gql.Field{Type: gql.NewList(device), Args: pageArgs(), Resolve: r.devicesOfProfile})
+	profile.AddFieldConfig("provisionWatchers", &gql.Field{Type: gql.NewList(watcher), Args: pageArgs(), Resolve: r.provisionWatchersOfProfile})
+	service.AddFieldConfig("devices", &gql.Field{Type: gql.NewList(device), Args: pageArgs(), Resolve: r.devicesOfService})
+	service.AddFieldConfig("provisionWatchers", &gql.Field{Type: gql.NewList(watcher), Args: pageArgs(), Resolve: r.provisionWatchersOfService})
+	watcher.AddFieldConfig("profile", &gql.Field{Type: profile, Resolve: r.profileOf})
+	watcher.AddFieldConfig("service", &gql.Field{Type: service, Resolve: r.serviceOf})
+
+	query := object("Query", gql.Fields{
+		"device":            {Type: device, Args: nameArgs, Resolve: r.device},
+		"devices":           {Type: gql.NewList(device), Args: listArgs(v2.ServiceName, v2.ProfileName), Resolve: r.devices},
+		"deviceProfile":     {Type: profile, Args: nameArgs, Resolve: r.deviceProfile},
+		"deviceProfiles":    {Type: gql.NewList(profile), Args: listArgs(v2.Manufacturer, v2.Model), Resolve: r.deviceProfiles},
+		"deviceService":     {Type: service, Args: nameArgs, Resolve: r.deviceService},
+		"deviceServices":    {Type: gql.NewList(service), Args: listArgs(), Resolve: r.deviceServices},
+		"provisionWatcher":  {Type: watcher, Args: nameArgs, Resolve: r.provisionWatcher},
+		"provisionWatchers": {Type: gql.NewList(watcher), Args: listArgs(v2.ServiceName, v2.ProfileName), Resolve: r.provisionWatchers},
+	})
+	return gql.NewSchema(gql.SchemaConfig{Query: query})
+}
+
+// toObject returns the JSON representation of a DTO, from which the scalar fields are resolved
+func toObject(dto interface{}) (interface{}, error) {
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// toObjects returns the JSON representation of the DTOs of a list of models
+func toObjects(count int, dto func(i int) interface{}) ([]interface{}, error) {
+	objects := make([]interface{}, count)
+	for i := range objects {
+		var err error
+		if objects[i], err = toObject(dto(i)); err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// byName returns the object read by name, or nil when it doesn't exist
+func byName(name string, read func(name string) (interface{}, errors.EdgeX)) (interface{}, error) {
+	dto, err := read(name)
+	if errors.Kind(err) == errors.KindEntityDoesNotExist {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return toObject(dto)
+}
+
+// stringArg returns the value of a string argument, or "" when it isn't given
+func stringArg(args map[string]interface{}, name string) string {
+	value, _ := args[name].(string)
+	return value
+}
+
+// labelsArg returns the values of the labels argument
+func labelsArg(args map[string]interface{}) []string {
+	values, _ := args[v2.Labels].([]interface{})
+	var labels []string
+	for _, v := range values {
+		labels = append(labels, v.(string))
+	}
+	return labels
+}
+
+// withArg returns a copy of args in which the argument name has value
+func withArg(args map[string]interface{}, name string, value interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(args)+1)
+	for n, v := range args {
+		copied[n] = v
+	}
+	copied[name] = value
+	return copied
+}
+
+// page returns the offset and limit arguments, checked as the query parameters of the REST API
+func (r *graphQLResolver) page(args map[string]interface{}) (int, int, error) {
+	offset, _ := args[v2.Offset].(int)
+	limit, _ := args[v2.Limit].(int)
+	if offset < 0 {
+		return 0, 0, fmt.Errorf("argument %s must not be negative", v2.Offset)
+	}
+	if limit < -1 || limit > r.maxResultCount {
+		return 0, 0, fmt.Errorf("argument %s %d is out of the range -1 ~ %d", v2.Limit, limit, r.maxResultCount)
+	}
+	return offset, limit, nil
+}
+
+// filter returns the value of the only filter argument given among names, and its name
+func filter(args map[string]interface{}, names ...string) (string, string, error) {
+	var name, value string
+	for _, n := range names {
+		v := stringArg(args, n)
+		if v == "" {
+			continue
+		}
+		if name != "" {
+			return "", "", fmt.Errorf("arguments %s and %s can't be given together", name, n)
+		}
+		name, value = n, v
+	}
+	return name, value, nil
+}
+
+func (r *graphQLResolver) device(p gql.ResolveParams) (interface{}, error) {
+	return byName(stringArg(p.Args, v2.Name), func(name string) (interface{}, errors.EdgeX) {
+		d, err := r.dbClient.DeviceByName(name)
+		return dtos.FromDeviceModelToDTO(d), err
+	})
+}
+
+func (r *graphQLResolver) devices(p gql.ResolveParams) (interface{}, error) {
+	offset, limit, err := r.page(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	labels := labelsArg(p.Args)
+	name, value, err := filter(p.Args, v2.ServiceName, v2.ProfileName)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" && len(labels) > 0 {
+		return nil, fmt.Errorf("arguments %s and %s can't be given together", v2.Labels, name)
+	}
+
+	var devices []models.Device
+	var edgeXerr errors.EdgeX
+	switch name {
+	case v2.ServiceName:
+		devices, edgeXerr = r.dbClient.DevicesByServiceName(offset, limit, value)
+	case v2.ProfileName:
+		devices, edgeXerr = r.dbClient.DevicesByProfileName(offset, limit, value)
+	default:
+		devices, edgeXerr = r.dbClient.AllDevices(offset, limit, labels)
+	}
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	return toObjects(len(devices), func(i int) interface{} { return dtos.FromDeviceModelToDTO(devices[i]) })
+}
+
+func (r *graphQLResolver) deviceProfile(p gql.ResolveParams) (interface{}, error) {
+	return byName(stringArg(p.Args, v2.Name), func(name string) (interface{}, errors.EdgeX) {
+		profile, err := r.dbClient.DeviceProfileByName(name)
+		return dtos.FromDeviceProfileModelToDTO(profile), err
+	})
+}
+
+func (r *graphQLResolver) deviceProfiles(p gql.ResolveParams) (interface{}, error) {
+	offset, limit, err := r.page(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	labels := labelsArg(p.Args)
+	manufacturer := stringArg(p.Args, v2.Manufacturer)
+	model := stringArg(p.Args, v2.Model)
+	if (manufacturer != "" || model != "") && len(labels) > 0 {
+		return nil, fmt.Errorf("argument %s can't be given with %s or %s", v2.Labels, v2.Manufacturer, v2.Model)
+	}
+
+	var profiles []models.DeviceProfile
+	var edgeXerr errors.EdgeX
+	switch {
+	case manufacturer != "" && model != "":
+		profiles, edgeXerr = r.dbClient.DeviceProfilesByManufacturerAndModel(offset, limit, manufacturer, model)
+	case manufacturer != "":
+		profiles, edgeXerr = r.dbClient.DeviceProfilesByManufacturer(offset, limit, manufacturer)
+	case model != "":
+		profiles, edgeXerr = r.dbClient.DeviceProfilesByModel(offset, limit, model)
+	default:
+		profiles, edgeXerr = r.dbClient.AllDeviceProfiles(offset, limit, labels)
+	}
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	return toObjects(len(profiles), func(i int) interface{} { return dtos.FromDeviceProfileModelToDTO(profiles[i]) })
+}
+
+func (r *graphQLResolver) deviceService(p gql.ResolveParams) (interface{}, error) {
+	return byName(stringArg(p.Args, v2.Name), func(name string) (interface{}, errors.EdgeX) {
+		s, err := r.dbClient.DeviceServiceByName(name)
+		return dtos.FromDeviceServiceModelToDTO(s), err
+	})
+}
+
+func (r *graphQLResolver) deviceServices(p gql.ResolveParams) (interface{}, error) {
+	offset, limit, err := r.page(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	services, edgeXerr := r.dbClient.AllDeviceServices(offset, limit, labelsArg(p.Args))
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	return toObjects(len(services), func(i int) interface{} { return dtos.FromDeviceServiceModelToDTO(services[i]) })
+}
+
+func (r *graphQLResolver) provisionWatcher(p gql.ResolveParams) (interface{}, error) {
+	return byName(stringArg(p.Args, v2.Name), func(name string) (interface{}, errors.EdgeX) {
+		pw, err := r.dbClient.ProvisionWatcherByName(name)
+		return dtos.FromProvisionWatcherModelToDTO(pw), err
+	})
+}
+
+func (r *graphQLResolver) provisionWatchers(p gql.ResolveParams) (interface{}, error) {
+	offset, limit, err := r.page(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	labels := labelsArg(p.Args)
+	name, value, err := filter(p.Args, v2.ServiceName, v2.ProfileName)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" && len(labels) > 0 {
+		return nil, fmt.Errorf("arguments %s and %s can't be given together", v2.Labels, name)
+	}
+
+	var watchers []models.ProvisionWatcher
+	var edgeXerr errors.EdgeX
+	switch name {
+	case v2.ServiceName:
+		watchers, edgeXerr = r.dbClient.ProvisionWatchersByServiceName(offset, limit, value)
+	case v2.ProfileName:
+		watchers, edgeXerr = r.dbClient.ProvisionWatchersByProfileName(offset, limit, value)
+	default:
+		watchers, edgeXerr = r.dbClient.AllProvisionWatchers(offset, limit, labels)
+	}
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	return toObjects(len(watchers), func(i int) interface{} { return dtos.FromProvisionWatcherModelToDTO(watchers[i]) })
+}
+
+// related returns the object named by the field of source read by read, cached by name
+func related(source interface{}, field string, cache map[string]interface{}, read func(name string) (interface{}, errors.EdgeX)) (interface{}, error) {
+	name, _ := source.(map[string]interface{})[field].(string)
+	if object, cached := cache[name]; cached {
+		return object, nil
+	}
+	object, err := byName(name, read)
+	if err != nil {
+		return nil, err
+	}
+	cache[name] = object
+	return object, nil
+}
+
+func (r *graphQLResolver) profileOf(p gql.ResolveParams) (interface{}, error) {
+	return related(p.Source, v2.ProfileName, r.profiles, func(name string) (interface{}, errors.EdgeX) {
+		profile, err := r.dbClient.DeviceProfileByName(name)
+		return dtos.FromDeviceProfileModelToDTO(profile), err
+	})
+}
+
+func (r *graphQLResolver) serviceOf(p gql.ResolveParams) (interface{}, error) {
+	return related(p.Source, v2.ServiceName, r.services, func(name string) (interface{}, errors.EdgeX) {
+		s, err := r.dbClient.DeviceServiceByName(name)
+		return dtos.FromDeviceServiceModelToDTO(s), err
+	})
+}
+
+func (r *graphQLResolver) devicesOfProfile(p gql.ResolveParams) (interface{}, error) {
+	p.Args = withArg(p.Args, v2.ProfileName, p.Source.(map[string]interface{})[v2.Name])
+	return r.devices(p)
+}
+
+func (r *graphQLResolver) devicesOfService(p gql.ResolveParams) (interface{}, error) {
+	p.Args = withArg(p.Args, v2.ServiceName, p.Source.(map[string]interface{})[v2.Name])
+	return r.devices(p)
+}
+
+func (r *graphQLResolver) provisionWatchersOfProfile(p gql.ResolveParams) (interface{}, error) {
+	p.Args = withArg(p.Args, v2.ProfileName, p.Source.(map[string]interface{})[v2.Name])
+	return r.provisionWatchers(p)
+}
+
+func (r *graphQLResolver) provisionWatchersOfService(p gql.ResolveParams) (interface{}, error) {
+	p.Args = withArg(p.Args, v2.ServiceName, p.Source.(map[string]interface{})[v2.Name])
+	return r.provisionWatchers(p)
+}
@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/labels"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// LabelUsages returns the labels of all the devices and device profiles, with the number of devices and device
+// profiles having each and its description in the label registry
+func LabelUsages(dic *di.Container) ([]labels.Usage, errors.EdgeX) {
+	dbClient := v2MetadataContainer.DBClientFrom(dic.Get)
+	devices, err := dbClient.AllDevices(0, -1, nil)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	profiles, err := dbClient.AllDeviceProfiles(0, -1, nil)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	deviceLabels := make([][]string, len(devices))
+	for i, d := range devices {
+		deviceLabels[i] = d.Labels
+	}
+	profileLabels := make([][]string, len(profiles))
+	for i, p := range profiles {
+		profileLabels[i] = p.Labels
+	}
+	registry := metadataContainer.ConfigurationFrom(dic.Get).Writable.Labels
+	return labels.Count(deviceLabels, profileLabels, registry), nil
+}
+
+// checkLabels returns a contract invalid error when the label registry is enforced and doesn't allow one of the
+// labels of the named device or device profile
+func checkLabels(kind string, name string, values []string, dic *di.Container) errors.EdgeX {
+	registry := metadataContainer.ConfigurationFrom(dic.Get).Writable.Labels
+	if err := registry.Check(values); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("%s %s: %s", kind, name, err.Error()), nil)
+	}
+	return nil
+}
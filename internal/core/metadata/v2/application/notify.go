@@ -9,6 +9,9 @@ import (
 	"context"
 	"net/http"
 
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 
@@ -20,6 +23,105 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 )
 
+// enqueueCallback queues the callback in the outbox when the callbacks are published on the message bus, returning
+// false when the callback API of the device service is to be requested instead
+func enqueueCallback(dic *di.Container, serviceName string, objectType string, action string, name string, payload interface{}) bool {
+	outbox := v2MetadataContainer.CallbackOutboxFrom(dic.Get)
+	if outbox == nil {
+		return false
+	}
+	err := outbox.Enqueue(serviceName, objectType, action, name, payload)
+	if err != nil {
+		lc := container.LoggingClientFrom(dic.Get)
+		lc.Errorf("fail to queue the %s callback of %s %s for device service %s, requesting its callback API instead, err: %v", action, objectType, name, serviceName, err)
+		return false
+	}
+	return true
+}
+
+// addDeviceCallback notifies the device service of the new device
+func addDeviceCallback(ctx context.Context, dic *di.Container, device dtos.Device) {
+	if enqueueCallback(dic, device.ServiceName, callbacks.Device, callbacks.Add, device.Name, requests.AddDeviceRequest{Device: device}) {
+		return
+	}
+	go addDeviceRestCallback(ctx, dic, device)
+}
+
+// updateDeviceCallback notifies the device service serviceName of the updated device
+func updateDeviceCallback(ctx context.Context, dic *di.Container, serviceName string, device models.Device) {
+	request := requests.UpdateDeviceRequest{Device: dtos.FromDeviceModelToUpdateDTO(device)}
+	if enqueueCallback(dic, serviceName, callbacks.Device, callbacks.Update, device.Name, request) {
+		return
+	}
+	go updateDeviceRestCallback(ctx, dic, serviceName, device)
+}
+
+// deleteDeviceCallback notifies the device service of the deleted device
+func deleteDeviceCallback(ctx context.Context, dic *di.Container, device models.Device) {
+	if enqueueCallback(dic, device.ServiceName, callbacks.Device, callbacks.Delete, device.Name, dtos.FromDeviceModelToDTO(device)) {
+		return
+	}
+	go deleteDeviceRestCallback(ctx, dic, device)
+}
+
+// updateDeviceProfileCallback notifies the device services of the devices of the updated device profile
+func updateDeviceProfileCallback(ctx context.Context, dic *di.Container, deviceProfile dtos.DeviceProfile) {
+	if v2MetadataContainer.CallbackOutboxFrom(dic.Get) == nil {
+		go updateDeviceProfileRestCallback(ctx, dic, deviceProfile)
+		return
+	}
+	lc := container.LoggingClientFrom(dic.Get)
+	devices, err := DevicesByProfileName(0, -1, deviceProfile.Name, dic)
+	if err != nil {
+		lc.Errorf("fail to query associated devices by deviceProfile name %s, err: %v", deviceProfile.Name, err)
+		return
+	}
+	request := requests.DeviceProfileRequest{Profile: deviceProfile}
+	dsMap := make(map[string]bool)
+	for _, d := range devices {
+		if dsMap[d.ServiceName] {
+			continue
+		}
+		dsMap[d.ServiceName] = true
+		enqueueCallback(dic, d.ServiceName, callbacks.DeviceProfile, callbacks.Update, deviceProfile.Name, request)
+	}
+}
+
+// addProvisionWatcherCallback notifies the device service of the new provision watcher
+func addProvisionWatcherCallback(ctx context.Context, dic *di.Container, pw dtos.ProvisionWatcher) {
+	request := requests.AddProvisionWatcherRequest{ProvisionWatcher: pw}
+	if enqueueCallback(dic, pw.ServiceName, callbacks.ProvisionWatcher, callbacks.Add, pw.Name, request) {
+		return
+	}
+	go addProvisionWatcherRestCallback(ctx, dic, pw)
+}
+
+// updateProvisionWatcherCallback notifies the device service serviceName of the updated provision watcher
+func updateProvisionWatcherCallback(ctx context.Context, dic *di.Container, serviceName string, pw models.ProvisionWatcher) {
+	request := requests.UpdateProvisionWatcherRequest{ProvisionWatcher: dtos.FromProvisionWatcherModelToUpdateDTO(pw)}
+	if enqueueCallback(dic, serviceName, callbacks.ProvisionWatcher, callbacks.Update, pw.Name, request) {
+		return
+	}
+	go updateProvisionWatcherRestCallback(ctx, dic, serviceName, pw)
+}
+
+// deleteProvisionWatcherCallback notifies the device service of the deleted provision watcher
+func deleteProvisionWatcherCallback(ctx context.Context, dic *di.Container, pw models.ProvisionWatcher) {
+	if enqueueCallback(dic, pw.ServiceName, callbacks.ProvisionWatcher, callbacks.Delete, pw.Name, dtos.FromProvisionWatcherModelToDTO(pw)) {
+		return
+	}
+	go deleteProvisionWatcherRestCallback(ctx, dic, pw)
+}
+
+// updateDeviceServiceCallback notifies the device service of its update
+func updateDeviceServiceCallback(ctx context.Context, dic *di.Container, ds models.DeviceService) {
+	request := requests.UpdateDeviceServiceRequest{Service: dtos.FromDeviceServiceModelToUpdateDTO(ds)}
+	if enqueueCallback(dic, ds.Name, callbacks.DeviceService, callbacks.Update, ds.Name, request) {
+		return
+	}
+	go updateDeviceServiceRestCallback(ctx, dic, ds)
+}
+
 func newDeviceServiceCallbackClient(ctx context.Context, dic *di.Container, deviceServiceName string) (interfaces.DeviceServiceCallbackClient, errors.EdgeX) {
 	ds, err := DeviceServiceByName(deviceServiceName, ctx, dic)
 	if err != nil {
@@ -28,24 +130,26 @@ func newDeviceServiceCallbackClient(ctx context.Context, dic *di.Container, devi
 	return v2HttpClient.NewDeviceServiceCallbackClient(ds.BaseAddress), nil
 }
 
-// addDeviceCallback invoke device service's callback function for adding new device
-func addDeviceCallback(ctx context.Context, dic *di.Container, device dtos.Device) {
+// addDeviceRestCallback invoke device service's callback function for adding new device
+func addDeviceRestCallback(ctx context.Context, dic *di.Container, device dtos.Device) {
 	lc := container.LoggingClientFrom(dic.Get)
 	deviceServiceCallbackClient, err := newDeviceServiceCallbackClient(ctx, dic, device.ServiceName)
 	if err != nil {
 		lc.Errorf("fail to new a device service callback client by serviceName %s, err: %v", device.ServiceName, err)
+		return
 	}
 	response, err := deviceServiceCallbackClient.AddDeviceCallback(ctx, requests.AddDeviceRequest{Device: device})
 	if err != nil {
 		lc.Errorf("fail to invoke device service callback for adding device %s, err: %v", device.Name, err)
+		return
 	}
 	if response.StatusCode != http.StatusOK {
 		lc.Errorf("fail to invoke device service callback for adding device %s, err: %s", device.Name, response.Message)
 	}
 }
 
-// updateDeviceCallback invoke device service's callback function for updating device
-func updateDeviceCallback(ctx context.Context, dic *di.Container, serviceName string, device models.Device) {
+// updateDeviceRestCallback invoke device service's callback function for updating device
+func updateDeviceRestCallback(ctx context.Context, dic *di.Container, serviceName string, device models.Device) {
 	lc := container.LoggingClientFrom(dic.Get)
 	deviceServiceCallbackClient, err := newDeviceServiceCallbackClient(ctx, dic, serviceName)
 	if err != nil {
@@ -62,12 +166,13 @@ func updateDeviceCallback(ctx context.Context, dic *di.Container, serviceName st
 	}
 }
 
-// deleteDeviceCallback invoke device service's callback function for deleting device
-func deleteDeviceCallback(ctx context.Context, dic *di.Container, device models.Device) {
+// deleteDeviceRestCallback invoke device service's callback function for deleting device
+func deleteDeviceRestCallback(ctx context.Context, dic *di.Container, device models.Device) {
 	lc := container.LoggingClientFrom(dic.Get)
 	deviceServiceCallbackClient, err := newDeviceServiceCallbackClient(ctx, dic, device.ServiceName)
 	if err != nil {
 		lc.Errorf("fail to new a device service callback client by serviceName %s, err: %v", device.ServiceName, err)
+		return
 	}
 	response, err := deviceServiceCallbackClient.DeleteDeviceCallback(ctx, device.Id)
 	if err != nil {
@@ -79,8 +184,8 @@ func deleteDeviceCallback(ctx context.Context, dic *di.Container, device models.
 	}
 }
 
-// updateDeviceProfileCallback invoke device service's callback function for updating device profile
-func updateDeviceProfileCallback(ctx context.Context, dic *di.Container, deviceProfile dtos.DeviceProfile) {
+// updateDeviceProfileRestCallback invoke device service's callback function for updating device profile
+func updateDeviceProfileRestCallback(ctx context.Context, dic *di.Container, deviceProfile dtos.DeviceProfile) {
 	lc := container.LoggingClientFrom(dic.Get)
 	devices, err := DevicesByProfileName(0, -1, deviceProfile.Name, dic)
 	if err != nil {
@@ -109,8 +214,8 @@ func updateDeviceProfileCallback(ctx context.Context, dic *di.Container, deviceP
 	}
 }
 
-// addProvisionWatcherCallback invoke device service's callback function for adding new provision watcher
-func addProvisionWatcherCallback(ctx context.Context, dic *di.Container, pw dtos.ProvisionWatcher) {
+// addProvisionWatcherRestCallback invoke device service's callback function for adding new provision watcher
+func addProvisionWatcherRestCallback(ctx context.Context, dic *di.Container, pw dtos.ProvisionWatcher) {
 	lc := container.LoggingClientFrom(dic.Get)
 	deviceServiceCallbackClient, err := newDeviceServiceCallbackClient(ctx, dic, pw.ServiceName)
 	if err != nil {
@@ -125,8 +230,8 @@ func addProvisionWatcherCallback(ctx context.Context, dic *di.Container, pw dtos
 	}
 }
 
-// updateProvisionWatcherCallback invoke device service's callback function for updating provision watcher
-func updateProvisionWatcherCallback(ctx context.Context, dic *di.Container, serviceName string, pw models.ProvisionWatcher) {
+// updateProvisionWatcherRestCallback invoke device service's callback function for updating provision watcher
+func updateProvisionWatcherRestCallback(ctx context.Context, dic *di.Container, serviceName string, pw models.ProvisionWatcher) {
 	lc := container.LoggingClientFrom(dic.Get)
 	deviceServiceCallbackClient, err := newDeviceServiceCallbackClient(ctx, dic, serviceName)
 	if err != nil {
@@ -143,8 +248,8 @@ func updateProvisionWatcherCallback(ctx context.Context, dic *di.Container, serv
 	}
 }
 
-// deleteProvisionWatcherCallback invoke device service's callback function for deleting provision watcher
-func deleteProvisionWatcherCallback(ctx context.Context, dic *di.Container, pw models.ProvisionWatcher) {
+// deleteProvisionWatcherRestCallback invoke device service's callback function for deleting provision watcher
+func deleteProvisionWatcherRestCallback(ctx context.Context, dic *di.Container, pw models.ProvisionWatcher) {
 	lc := container.LoggingClientFrom(dic.Get)
 	deviceServiceCallbackClient, err := newDeviceServiceCallbackClient(ctx, dic, pw.ServiceName)
 	if err != nil {
@@ -160,8 +265,8 @@ func deleteProvisionWatcherCallback(ctx context.Context, dic *di.Container, pw m
 	}
 }
 
-// updateDeviceServiceCallback invoke device service's callback function for updating device service
-func updateDeviceServiceCallback(ctx context.Context, dic *di.Container, ds models.DeviceService) {
+// updateDeviceServiceRestCallback invoke device service's callback function for updating device service
+func updateDeviceServiceRestCallback(ctx context.Context, dic *di.Container, ds models.DeviceService) {
 	lc := container.LoggingClientFrom(dic.Get)
 	deviceServiceCallbackClient, err := newDeviceServiceCallbackClient(ctx, dic, ds.Name)
 	if err != nil {
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// AdminSchedulerName contains the name of the adminschedules.Scheduler implementation in the DIC.
+var AdminSchedulerName = di.TypeInstanceToName((*adminschedules.Scheduler)(nil))
+
+// AdminSchedulerFrom helper function queries the DIC and returns the adminschedules.Scheduler implementation, or nil
+// if support-scheduler isn't configured.
+func AdminSchedulerFrom(get di.Get) adminschedules.Scheduler {
+	scheduler, ok := get(AdminSchedulerName).(adminschedules.Scheduler)
+	if !ok {
+		return nil
+	}
+	return scheduler
+}
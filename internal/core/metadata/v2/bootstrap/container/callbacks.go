@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// CallbackOutboxName contains the name of the callbacks.Outbox implementation in the DIC.
+var CallbackOutboxName = di.TypeInstanceToName((*callbacks.Outbox)(nil))
+
+// CallbackOutboxFrom helper function queries the DIC and returns the callbacks.Outbox implementation, or nil if the
+// callbacks aren't published on the message bus.
+func CallbackOutboxFrom(get di.Get) *callbacks.Outbox {
+	outbox, ok := get(CallbackOutboxName).(*callbacks.Outbox)
+	if !ok {
+		return nil
+	}
+	return outbox
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicesecrets"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// DeviceSecretStoreName contains the name of the devicesecrets.Store implementation in the DIC.
+var DeviceSecretStoreName = di.TypeInstanceToName((*devicesecrets.Store)(nil))
+
+// DeviceSecretStoreFrom helper function queries the DIC and returns the devicesecrets.Store implementation, or nil if
+// the secrets of the devices are disabled.
+func DeviceSecretStoreFrom(get di.Get) devicesecrets.Store {
+	store, ok := get(DeviceSecretStoreName).(devicesecrets.Store)
+	if !ok {
+		return nil
+	}
+	return store
+}
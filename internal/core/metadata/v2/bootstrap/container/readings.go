@@ -0,0 +1,24 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/clients/interfaces"
+)
+
+// ReadingClientName contains the name of the core-data interfaces.ReadingClient implementation in the DIC.
+var ReadingClientName = di.TypeInstanceToName((*interfaces.ReadingClient)(nil))
+
+// ReadingClientFrom helper function queries the DIC and returns the core-data interfaces.ReadingClient
+// implementation, or nil if it isn't set.
+func ReadingClientFrom(get di.Get) interfaces.ReadingClient {
+	client, ok := get(ReadingClientName).(interfaces.ReadingClient)
+	if !ok {
+		return nil
+	}
+	return client
+}
@@ -0,0 +1,284 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingScheduler struct {
+	registered   []string
+	unregistered []string
+	err          errors.EdgeX
+}
+
+func (s *recordingScheduler) Register(_ context.Context, schedule adminschedules.AdminSchedule) errors.EdgeX {
+	if s.err != nil {
+		return s.err
+	}
+	s.registered = append(s.registered, schedule.Name)
+	return nil
+}
+
+func (s *recordingScheduler) Unregister(_ context.Context, schedule adminschedules.AdminSchedule) errors.EdgeX {
+	s.unregistered = append(s.unregistered, schedule.Name)
+	return nil
+}
+
+func buildTestAdminSchedule(name string, adminState string, hour int) adminschedules.AdminSchedule {
+	tomorrow := time.Now().UTC().Add(24 * time.Hour)
+	start := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), hour, 0, 0, 0, time.UTC)
+	return adminschedules.AdminSchedule{
+		Name:       name,
+		DeviceName: TestDeviceName,
+		AdminState: adminState,
+		Start:      start.Format(adminschedules.TimeLayout),
+		Frequency:  "24h",
+	}
+}
+
+func TestAdminScheduleController_AddAdminSchedule(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	lock := buildTestAdminSchedule("lock", models.Locked, 22)
+	unlock := buildTestAdminSchedule("unlock", models.Unlocked, 6)
+	conflicting := buildTestAdminSchedule("conflicting", models.Unlocked, 22)
+	unknownDevice := buildTestAdminSchedule("unknownDevice", models.Locked, 22)
+	unknownDevice.DeviceName = "unknown"
+	noTarget := buildTestAdminSchedule("noTarget", models.Locked, 22)
+	noTarget.DeviceName = ""
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceByName", TestDeviceName).Return(device, nil)
+	dbClientMock.On("DeviceByName", unknownDevice.DeviceName).Return(models.Device{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device doesn't exist in the database", nil))
+	dbClientMock.On("AllAdminSchedules", 0, -1).Return([]adminschedules.AdminSchedule{lock}, nil)
+	dbClientMock.On("AddAdminSchedule", unlock).Return(adminschedules.AdminSchedule{Id: ExampleUUID, Name: unlock.Name}, nil)
+	scheduler := &recordingScheduler{}
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		v2MetadataContainer.AdminSchedulerName: func(get di.Get) interface{} {
+			return scheduler
+		},
+	})
+
+	controller := NewAdminScheduleController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		schedule           adminschedules.AdminSchedule
+		expectedStatusCode int
+	}{
+		{"Valid - unlocks when the other schedule doesn't lock", unlock, http.StatusCreated},
+		{"Invalid - unlocks when the other schedule locks", conflicting, http.StatusConflict},
+		{"Invalid - unknown device", unknownDevice, http.StatusNotFound},
+		{"Invalid - neither device nor labels", noTarget, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := []adminschedules.AddAdminScheduleRequest{{
+				BaseRequest:   common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+				AdminSchedule: testCase.schedule,
+			}}
+			jsonData, err := json.Marshal(request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, adminschedules.ApiAdminScheduleRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AddAdminSchedule)
+			handler.ServeHTTP(recorder, req)
+			var res []common.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res[0].Id)
+			} else {
+				assert.NotEmpty(t, res[0].Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "AddAdminSchedule", 1)
+	assert.Equal(t, []string{unlock.Name}, scheduler.registered)
+}
+
+func TestAdminScheduleController_AddAdminSchedule_SchedulerFailure(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	lock := buildTestAdminSchedule("lock", models.Locked, 22)
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceByName", TestDeviceName).Return(device, nil)
+	dbClientMock.On("AllAdminSchedules", 0, -1).Return([]adminschedules.AdminSchedule{}, nil)
+	dbClientMock.On("AddAdminSchedule", lock).Return(lock, nil)
+	dbClientMock.On("DeleteAdminScheduleByName", lock.Name).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		v2MetadataContainer.AdminSchedulerName: func(get di.Get) interface{} {
+			return &recordingScheduler{err: errors.NewCommonEdgeX(errors.KindServiceUnavailable, "support-scheduler is down", nil)}
+		},
+	})
+
+	controller := NewAdminScheduleController(dic)
+	request := []adminschedules.AddAdminScheduleRequest{{
+		BaseRequest:   common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+		AdminSchedule: lock,
+	}}
+	jsonData, err := json.Marshal(request)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, adminschedules.ApiAdminScheduleRoute, strings.NewReader(string(jsonData)))
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(controller.AddAdminSchedule)
+	handler.ServeHTTP(recorder, req)
+	var res []common.BaseWithIdResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, res, 1)
+	assert.Equal(t, http.StatusServiceUnavailable, int(res[0].StatusCode), "BaseResponse status code not as expected")
+	dbClientMock.AssertCalled(t, "DeleteAdminScheduleByName", lock.Name)
+}
+
+func TestAdminScheduleController_DeleteAdminScheduleByName(t *testing.T) {
+	lock := buildTestAdminSchedule("lock", models.Locked, 22)
+	notFound := "notFound"
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("AdminScheduleByName", lock.Name).Return(lock, nil)
+	dbClientMock.On("AdminScheduleByName", notFound).Return(adminschedules.AdminSchedule{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "admin state schedule doesn't exist in the database", nil))
+	dbClientMock.On("DeleteAdminScheduleByName", lock.Name).Return(nil)
+	scheduler := &recordingScheduler{}
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		v2MetadataContainer.AdminSchedulerName: func(get di.Get) interface{} {
+			return scheduler
+		},
+	})
+
+	controller := NewAdminScheduleController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		scheduleName       string
+		expectedStatusCode int
+	}{
+		{"Valid - delete admin state schedule", lock.Name, http.StatusNoContent},
+		{"Invalid - name is empty", "", http.StatusBadRequest},
+		{"Invalid - admin state schedule not found", notFound, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodDelete, adminschedules.ApiAdminScheduleByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.scheduleName})
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DeleteAdminScheduleByName)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+	assert.Equal(t, []string{lock.Name}, scheduler.unregistered)
+}
+
+func TestAdminScheduleController_RunAdminSchedule(t *testing.T) {
+	locked := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	locked.Name = "locked"
+	locked.AdminState = models.Locked
+	locked.Labels = []string{"floor1"}
+	unlocked := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	unlocked.Name = "unlocked"
+	unlocked.AdminState = models.Unlocked
+	unlocked.Labels = []string{"floor1", "hvac"}
+	other := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	other.Name = "other"
+	other.AdminState = models.Unlocked
+	other.Labels = []string{"floor1"}
+	lock := buildTestAdminSchedule("lock", models.Locked, 22)
+	lock.DeviceName = ""
+	lock.Labels = []string{"floor1"}
+	lockHvac := lock
+	lockHvac.Name = "lockHvac"
+	lockHvac.Labels = []string{"floor1", "hvac"}
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("AdminScheduleByName", lockHvac.Name).Return(lockHvac, nil)
+	dbClientMock.On("AllDevices", 0, -1, lockHvac.Labels).Return([]models.Device{locked, unlocked, other}, nil)
+	dbClientMock.On("DeviceByName", unlocked.Name).Return(unlocked, nil)
+	dbClientMock.On("UpdateDevice", mock.Anything).Return(nil)
+	dbClientMock.On("DeviceServiceByName", mock.Anything).Return(models.DeviceService{BaseAddress: testBaseAddress}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewAdminScheduleController(dic)
+	require.NotNil(t, controller)
+
+	req, err := http.NewRequest(http.MethodPut, adminschedules.ApiAdminScheduleRunRoute, http.NoBody)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{contractsV2.Name: lockHvac.Name})
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(controller.RunAdminSchedule)
+	handler.ServeHTTP(recorder, req)
+	var res adminschedules.RunResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+	assert.Equal(t, []string{unlocked.Name}, res.ChangedDevices, "only the unlocked devices of the group should be locked")
+	dbClientMock.AssertCalled(t, "UpdateDevice", mock.MatchedBy(func(d models.Device) bool {
+		return d.Name == unlocked.Name && d.AdminState == models.Locked
+	}))
+}
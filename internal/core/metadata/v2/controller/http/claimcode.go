@@ -0,0 +1,140 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/io"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+type ClaimCodeController struct {
+	reader io.ClaimReader
+	dic    *di.Container
+}
+
+// NewClaimCodeController creates and initializes a ClaimCodeController
+func NewClaimCodeController(dic *di.Container) *ClaimCodeController {
+	return &ClaimCodeController{
+		reader: io.NewClaimRequestReader(),
+		dic:    dic,
+	}
+}
+
+func (cc *ClaimCodeController) IssueClaimCode(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(cc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	name := vars[contractsV2.Name]
+
+	var response interface{}
+	var statusCode int
+
+	code, expires, err := application.IssueClaimCode(name, ctx, cc.dic)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = claimcodes.ClaimCodeResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusCreated),
+			DeviceName:   name,
+			Code:         code,
+			URI:          claimcodes.URI(code),
+			Expires:      expires,
+		}
+		statusCode = http.StatusCreated
+	}
+
+	// the code must not be kept by the caches between the administrator and the service
+	w.Header().Set("Cache-Control", "no-store")
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (cc *ClaimCodeController) RevokeClaimCode(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(cc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	name := vars[contractsV2.Name]
+
+	var response interface{}
+	var statusCode int
+
+	err := application.RevokeClaimCode(name, cc.dic)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = commonDTO.NewBaseResponse("", "", http.StatusNoContent)
+		statusCode = http.StatusNoContent
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (cc *ClaimCodeController) Claim(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(cc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	request, err := cc.reader.ReadClaimRequest(r.Body)
+	var deviceName string
+	if err == nil {
+		deviceName, err = application.RedeemClaimCode(request, ctx, cc.dic)
+	}
+	if err != nil {
+		// the rejected codes are logged as warnings, repeated ones hinting at codes being guessed
+		lc.Warn(err.Error(), clients.CorrelationHeader, correlationId, "remoteAddr", r.RemoteAddr)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse(request.RequestId, err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = claimcodes.ClaimResponse{
+			BaseResponse: commonDTO.NewBaseResponse(request.RequestId, "", http.StatusOK),
+			DeviceName:   deviceName,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
@@ -0,0 +1,228 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicesecrets"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func nowMillis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+// claimCodesDic returns a container whose configuration enables the claim codes
+func claimCodesDic(dbClient *mocks.DBClient) *di.Container {
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		metadataContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable:   config.WritableInfo{LogLevel: "DEBUG"},
+				Service:    bootstrapConfig.ServiceInfo{MaxResultCount: 30},
+				ClaimCodes: claimcodes.ClaimCodesInfo{Enabled: true, Lifetime: "15m"},
+			}
+		},
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClient
+		},
+	})
+	return dic
+}
+
+func TestClaimCodeController_IssueClaimCode(t *testing.T) {
+	placeholder := models.Device{Name: TestDeviceName, ServiceName: TestDeviceServiceName, AdminState: models.Locked}
+	unlocked := models.Device{Name: "unlocked", ServiceName: TestDeviceServiceName, AdminState: models.Unlocked}
+	unknown := "unknown"
+
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceByName", placeholder.Name).Return(placeholder, nil)
+	dbClientMock.On("DeviceByName", unlocked.Name).Return(unlocked, nil)
+	dbClientMock.On("DeviceByName", unknown).Return(models.Device{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device doesn't exist in the database", nil))
+	dbClientMock.On("AddClaimCode", mock.Anything).Return(nil)
+	enabled := NewClaimCodeController(claimCodesDic(dbClientMock))
+
+	disabledDic := mockDic()
+	disabledDic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	disabled := NewClaimCodeController(disabledDic)
+
+	tests := []struct {
+		name               string
+		controller         *ClaimCodeController
+		deviceName         string
+		expectedStatusCode int
+	}{
+		{"Valid - placeholder", enabled, placeholder.Name, http.StatusCreated},
+		{"Invalid - unlocked device", enabled, unlocked.Name, http.StatusConflict},
+		{"Invalid - unknown device", enabled, unknown, http.StatusNotFound},
+		{"Invalid - claim codes disabled", disabled, placeholder.Name, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, claimcodes.ApiDeviceClaimCodeRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.deviceName})
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(testCase.controller.IssueClaimCode)
+			handler.ServeHTTP(recorder, req)
+			var res claimcodes.ClaimCodeResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+			if testCase.expectedStatusCode != http.StatusCreated {
+				assert.Empty(t, res.Code)
+				return
+			}
+			assert.Equal(t, claimcodes.URI(res.Code), res.URI)
+			assert.Greater(t, res.Expires, nowMillis())
+			dbClientMock.AssertCalled(t, "AddClaimCode", mock.MatchedBy(func(c claimcodes.ClaimCode) bool {
+				return c.DeviceName == placeholder.Name && c.Hash == claimcodes.Hash(res.Code) && c.Expires == res.Expires
+			}))
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "AddClaimCode", 1)
+}
+
+func TestClaimCodeController_Claim(t *testing.T) {
+	code := "7KQ2-M9TX-4HCR"
+	placeholder := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	placeholder.AdminState = models.Locked
+	claim := claimcodes.ClaimCode{
+		Hash:       claimcodes.Hash(code),
+		DeviceName: placeholder.Name,
+		Expires:    nowMillis() + 60000,
+	}
+	secrets := map[string]string{"password": "admin123"}
+	protocols := map[string]dtos.ProtocolProperties{"modbus-ip": {"Address": "10.0.0.12", "Port": "502"}}
+
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("TakeClaimCode", claim.Hash).Return(claim, nil).Once()
+	dbClientMock.On("TakeClaimCode", mock.Anything).Return(claimcodes.ClaimCode{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "claim code doesn't exist in the database", nil))
+	dbClientMock.On("DeviceByName", placeholder.Name).Return(placeholder, nil)
+	dbClientMock.On("UpdateDevice", mock.Anything).Return(nil)
+	dbClientMock.On("DeviceServiceByName", mock.Anything).Return(models.DeviceService{BaseAddress: testBaseAddress}, nil)
+	dic := claimCodesDic(dbClientMock)
+	store := &mockDeviceSecretStore{stored: make(map[string]map[string]string)}
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DeviceSecretStoreName: func(get di.Get) interface{} {
+			return store
+		},
+	})
+	controller := NewClaimCodeController(dic)
+
+	tests := []struct {
+		name               string
+		code               string
+		expectedStatusCode int
+	}{
+		{"Valid - read from the QR code", strings.ToLower(claimcodes.URI(code)), http.StatusOK},
+		{"Invalid - already redeemed", code, http.StatusNotFound},
+		{"Invalid - malformed code", "7KQ2", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := claimcodes.ClaimRequest{
+				BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+				Code:        testCase.code,
+				Protocols:   protocols,
+				Secrets:     secrets,
+			}
+			jsonData, err := json.Marshal(request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, claimcodes.ApiClaimRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.Claim)
+			handler.ServeHTTP(recorder, req)
+			var res claimcodes.ClaimResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, ExampleUUID, res.RequestId)
+			assert.NotContains(t, recorder.Body.String(), "admin123")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, placeholder.Name, res.DeviceName)
+			}
+		})
+	}
+	assert.Equal(t, secrets, store.stored[devicesecrets.Path(placeholder.ServiceName, placeholder.Name)])
+	dbClientMock.AssertCalled(t, "UpdateDevice", mock.MatchedBy(func(d models.Device) bool {
+		return d.Name == placeholder.Name && d.AdminState == models.Unlocked && d.Protocols["modbus-ip"]["Address"] == "10.0.0.12"
+	}))
+	dbClientMock.AssertNotCalled(t, "AddClaimCode", mock.Anything)
+}
+
+func TestClaimCodeController_Claim_RestoresTheCodeOnFailure(t *testing.T) {
+	code := "7KQ2-M9TX-4HCR"
+	claim := claimcodes.ClaimCode{
+		Hash:       claimcodes.Hash(code),
+		DeviceName: TestDeviceName,
+		Expires:    nowMillis() + 60000,
+	}
+
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("TakeClaimCode", claim.Hash).Return(claim, nil)
+	dbClientMock.On("DeviceByName", TestDeviceName).Return(models.Device{},
+		errors.NewCommonEdgeX(errors.KindDatabaseError, "database unavailable", nil))
+	dbClientMock.On("AddClaimCode", claim).Return(nil)
+	controller := NewClaimCodeController(claimCodesDic(dbClientMock))
+
+	request := claimcodes.ClaimRequest{
+		BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+		Code:        code,
+	}
+	jsonData, err := json.Marshal(request)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, claimcodes.ApiClaimRoute, strings.NewReader(string(jsonData)))
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(controller.Claim)
+	handler.ServeHTTP(recorder, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode, "HTTP status code not as expected")
+	dbClientMock.AssertCalled(t, "AddClaimCode", claim)
+}
@@ -0,0 +1,196 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestDeprecation() deprecations.Deprecation {
+	return deprecations.Deprecation{
+		ProfileName:    TestDeviceProfileName,
+		ResourceName:   TestDeviceCommandName,
+		RemovalVersion: "2.0",
+		Replacement:    TestDeviceResourceName,
+	}
+}
+
+func TestDeprecationController_AddDeprecation(t *testing.T) {
+	profile := dtos.ToDeviceProfileModel(buildTestDeviceProfileRequest().Profile)
+	valid := buildTestDeprecation()
+	unknownResource := valid
+	unknownResource.ResourceName = "unknown"
+	noRemovalVersion := valid
+	noRemovalVersion.RemovalVersion = ""
+	unknownProfile := valid
+	unknownProfile.ProfileName = "unknown"
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceProfileByName", TestDeviceProfileName).Return(profile, nil)
+	dbClientMock.On("DeviceProfileByName", unknownProfile.ProfileName).Return(models.DeviceProfile{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device profile doesn't exist in the database", nil))
+	dbClientMock.On("AddDeprecation", valid).Return(deprecations.Deprecation{Id: ExampleUUID}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeprecationController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		deprecation        deprecations.Deprecation
+		expectedStatusCode int
+	}{
+		{"Valid", valid, http.StatusCreated},
+		{"Invalid - unknown resource", unknownResource, http.StatusNotFound},
+		{"Invalid - no removal version", noRemovalVersion, http.StatusBadRequest},
+		{"Invalid - unknown device profile", unknownProfile, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := []deprecations.AddDeprecationRequest{{
+				BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+				Deprecation: testCase.deprecation,
+			}}
+			jsonData, err := json.Marshal(request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, deprecations.ApiDeprecationRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AddDeprecation)
+			handler.ServeHTTP(recorder, req)
+			var res []common.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res[0].Id)
+			} else {
+				assert.NotEmpty(t, res[0].Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "AddDeprecation", 1)
+}
+
+func TestDeprecationController_DeleteDeprecationByProfileNameAndResourceName(t *testing.T) {
+	d := buildTestDeprecation()
+	notDeprecated := "notDeprecated"
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeleteDeprecationByProfileNameAndResourceName", d.ProfileName, d.ResourceName).Return(nil)
+	dbClientMock.On("DeleteDeprecationByProfileNameAndResourceName", d.ProfileName, notDeprecated).Return(
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "deprecation doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeprecationController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		resourceName       string
+		expectedStatusCode int
+	}{
+		{"Valid - withdraw deprecation", d.ResourceName, http.StatusNoContent},
+		{"Invalid - resource name is empty", "", http.StatusBadRequest},
+		{"Invalid - resource not deprecated", notDeprecated, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodDelete, deprecations.ApiDeprecationByProfileNameAndResourceNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: d.ProfileName, contractsV2.ResourceName: testCase.resourceName})
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DeleteDeprecationByProfileNameAndResourceName)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusNoContent {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+			}
+		})
+	}
+}
+
+func TestDeprecationController_DeprecationUsages(t *testing.T) {
+	command := buildTestDeprecation()
+	resource := buildTestDeprecation()
+	resource.ResourceName = TestDeviceResourceName
+	resource.Replacement = ""
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	device.AutoEvents = []models.AutoEvent{{Resource: TestDeviceResourceName, Frequency: "10s"}}
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("AllDeprecations", 0, -1).Return([]deprecations.Deprecation{command, resource}, nil)
+	dbClientMock.On("DevicesByProfileName", 0, -1, TestDeviceProfileName).Return([]models.Device{device}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeprecationController(dic)
+	require.NotNil(t, controller)
+
+	req, err := http.NewRequest(http.MethodGet, deprecations.ApiDeprecationUsageRoute, http.NoBody)
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(controller.DeprecationUsages)
+	handler.ServeHTTP(recorder, req)
+	var res deprecations.MultiUsagesResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+	require.Len(t, res.Usages, 2)
+	assert.Equal(t, []string{device.Name}, res.Usages[0].Devices)
+	assert.Empty(t, res.Usages[0].AutoEventDevices)
+	assert.Equal(t, []string{device.Name}, res.Usages[1].AutoEventDevices)
+	dbClientMock.AssertNumberOfCalls(t, "DevicesByProfileName", 1)
+	dbClientMock.AssertExpectations(t)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dryrun"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -21,6 +22,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
 	requestDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
 	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
@@ -199,7 +201,11 @@ func (dc *DeviceController) PatchDevice(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 	correlationId := correlation.FromContext(ctx)
 
-	updateDeviceDTOs, err := dc.reader.ReadUpdateDeviceRequest(r.Body)
+	dryRun, err := parseDryRun(r)
+	var updateDeviceDTOs []requestDTO.UpdateDeviceRequest
+	if err == nil {
+		updateDeviceDTOs, err = dc.reader.ReadUpdateDeviceRequest(r.Body)
+	}
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
@@ -216,7 +222,15 @@ func (dc *DeviceController) PatchDevice(w http.ResponseWriter, r *http.Request)
 	for _, dto := range updateDeviceDTOs {
 		var response interface{}
 		reqId := dto.RequestId
-		err := application.PatchDevice(dto.Device, ctx, dc.dic)
+		var err errors.EdgeX
+		if dryRun {
+			var findings []dryrun.Finding
+			findings, err = application.DryRunPatchDevice(dto.Device, ctx, dc.dic)
+			response = newDryRunResponse(reqId, findings)
+		} else {
+			err = application.PatchDevice(dto.Device, ctx, dc.dic)
+			response = commonDTO.NewBaseResponse(reqId, "", http.StatusOK)
+		}
 		if err != nil {
 			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
@@ -224,11 +238,6 @@ func (dc *DeviceController) PatchDevice(w http.ResponseWriter, r *http.Request)
 				reqId,
 				err.Message(),
 				err.Code())
-		} else {
-			response = commonDTO.NewBaseResponse(
-				reqId,
-				"",
-				http.StatusOK)
 		}
 		updateResponses = append(updateResponses, response)
 	}
@@ -246,15 +255,21 @@ func (dc *DeviceController) AllDevices(w http.ResponseWriter, r *http.Request) {
 	var response interface{}
 	var statusCode int
 
-	// parse URL query string for offset, limit, and labels
+	// parse URL query string for offset, limit, labels and the optional free-text search
 	offset, limit, labels, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	search := utils.ParseQueryStringToString(r, utils.Search, "")
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 		statusCode = err.Code()
 	} else {
-		devices, err := application.AllDevices(offset, limit, labels, dc.dic)
+		var devices []dtos.Device
+		if search != "" {
+			devices, err = application.DevicesBySearch(offset, limit, search, labels, dc.dic)
+		} else {
+			devices, err = application.AllDevices(offset, limit, labels, dc.dic)
+		}
 		if err != nil {
 			if errors.Kind(err) != errors.KindEntityDoesNotExist {
 				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
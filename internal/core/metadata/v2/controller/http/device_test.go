@@ -15,6 +15,7 @@ import (
 
 	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
 	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
@@ -632,6 +633,72 @@ func TestAllDevices(t *testing.T) {
 	}
 }
 
+func TestAllDevicesBySearch(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	devices := []models.Device{device, device}
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DevicesBySearch", 0, 10, "therm", []string(nil)).Return(devices, nil)
+	dbClientMock.On("DevicesBySearch", 0, 10, "therm", testDeviceLabels).Return([]models.Device{devices[0]}, nil)
+	dbClientMock.On("DevicesBySearch", 0, 10, "x", []string(nil)).Return([]models.Device{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "search query must contain at least one word", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceController(dic)
+	assert.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		search             string
+		labels             string
+		errorExpected      bool
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - search devices", "therm", "", false, 2, http.StatusOK},
+		{"Valid - search devices with labels", "therm", strings.Join(testDeviceLabels, ","), false, 1, http.StatusOK},
+		{"Invalid - search query too short", "x", "", true, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, v2.ApiAllDeviceRoute, http.NoBody)
+			query := req.URL.Query()
+			query.Add(v2.Offset, "0")
+			query.Add(v2.Limit, "10")
+			query.Add(utils.Search, testCase.search)
+			if len(testCase.labels) > 0 {
+				query.Add(v2.Labels, testCase.labels)
+			}
+			req.URL.RawQuery = query.Encode()
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AllDevices)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res responseDTO.MultiDevicesResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+				assert.Equal(t, testCase.expectedCount, len(res.Devices), "Device count not as expected")
+			}
+			dbClientMock.AssertNotCalled(t, "AllDevices", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestDeviceByName(t *testing.T) {
 	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
 	emptyName := ""
@@ -9,6 +9,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dryrun"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -98,7 +99,11 @@ func (dc *DeviceProfileController) UpdateDeviceProfile(w http.ResponseWriter, r
 	ctx := r.Context()
 	correlationId := correlation.FromContext(ctx)
 
-	updateDeviceProfileReq, err := dc.reader.ReadDeviceProfileRequest(r.Body)
+	dryRun, err := parseDryRun(r)
+	var updateDeviceProfileReq []requestDTO.DeviceProfileRequest
+	if err == nil {
+		updateDeviceProfileReq, err = dc.reader.ReadDeviceProfileRequest(r.Body)
+	}
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
@@ -116,7 +121,15 @@ func (dc *DeviceProfileController) UpdateDeviceProfile(w http.ResponseWriter, r
 	for i, d := range deviceProfiles {
 		var response interface{}
 		reqId := updateDeviceProfileReq[i].RequestId
-		err := application.UpdateDeviceProfile(d, ctx, dc.dic)
+		var err errors.EdgeX
+		if dryRun {
+			var findings []dryrun.Finding
+			findings, err = application.DryRunUpdateDeviceProfile(d, ctx, dc.dic)
+			response = newDryRunResponse(reqId, findings)
+		} else {
+			err = application.UpdateDeviceProfile(d, ctx, dc.dic)
+			response = commonDTO.NewBaseResponse(reqId, "", http.StatusOK)
+		}
 		if err != nil {
 			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
@@ -124,11 +137,6 @@ func (dc *DeviceProfileController) UpdateDeviceProfile(w http.ResponseWriter, r
 				reqId,
 				err.Message(),
 				err.Code())
-		} else {
-			response = commonDTO.NewBaseResponse(
-				reqId,
-				"",
-				http.StatusOK)
 		}
 		responses = append(responses, response)
 	}
@@ -196,7 +204,11 @@ func (dc *DeviceProfileController) UpdateDeviceProfileByYaml(w http.ResponseWrit
 	var response interface{}
 	var statusCode int
 
-	deviceProfileDTO, err := dc.reader.ReadDeviceProfileYaml(r)
+	dryRun, err := parseDryRun(r)
+	var deviceProfileDTO dtos.DeviceProfile
+	if err == nil {
+		deviceProfileDTO, err = dc.reader.ReadDeviceProfileYaml(r)
+	}
 	if err != nil {
 		response = commonDTO.NewBaseResponse(
 			"",
@@ -210,7 +222,19 @@ func (dc *DeviceProfileController) UpdateDeviceProfileByYaml(w http.ResponseWrit
 	}
 
 	deviceProfile := dtos.ToDeviceProfileModel(deviceProfileDTO)
-	err = application.UpdateDeviceProfile(deviceProfile, ctx, dc.dic)
+	if dryRun {
+		var findings []dryrun.Finding
+		findings, err = application.DryRunUpdateDeviceProfile(deviceProfile, ctx, dc.dic)
+		if err == nil {
+			dryRunResponse := newDryRunResponse("", findings)
+			response = dryRunResponse
+			statusCode = dryRunResponse.StatusCode
+		}
+	} else {
+		err = application.UpdateDeviceProfile(deviceProfile, ctx, dc.dic)
+		response = commonDTO.NewBaseResponse("", "", http.StatusOK)
+		statusCode = http.StatusOK
+	}
 	if err != nil {
 		response = commonDTO.NewBaseResponse(
 			"",
@@ -219,12 +243,6 @@ func (dc *DeviceProfileController) UpdateDeviceProfileByYaml(w http.ResponseWrit
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		statusCode = err.Code()
-	} else {
-		response = commonDTO.NewBaseResponse(
-			"",
-			"",
-			http.StatusOK)
-		statusCode = http.StatusOK
 	}
 
 	utils.WriteHttpHeader(w, ctx, statusCode)
@@ -299,15 +317,21 @@ func (dc *DeviceProfileController) AllDeviceProfiles(w http.ResponseWriter, r *h
 	var response interface{}
 	var statusCode int
 
-	// parse URL query string for offset, limit, and labels
+	// parse URL query string for offset, limit, labels and the optional free-text search
 	offset, limit, labels, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	search := utils.ParseQueryStringToString(r, utils.Search, "")
 	if err != nil {
 		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
 		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
 		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
 		statusCode = err.Code()
 	} else {
-		deviceProfiles, err := application.AllDeviceProfiles(offset, limit, labels, dc.dic)
+		var deviceProfiles []dtos.DeviceProfile
+		if search != "" {
+			deviceProfiles, err = application.DeviceProfilesBySearch(offset, limit, search, labels, dc.dic)
+		} else {
+			deviceProfiles, err = application.AllDeviceProfiles(offset, limit, labels, dc.dic)
+		}
 		if err != nil {
 			if errors.Kind(err) != errors.KindEntityDoesNotExist {
 				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
@@ -0,0 +1,172 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicesecrets"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDeviceSecretStore struct {
+	stored map[string]map[string]string
+}
+
+func (s *mockDeviceSecretStore) StoreSecrets(serviceName string, deviceName string, secrets map[string]string) error {
+	s.stored[devicesecrets.Path(serviceName, deviceName)] = secrets
+	return nil
+}
+
+func TestDeviceSecretsController_SetDeviceSecrets(t *testing.T) {
+	unknown := "UnknownDevice"
+	secrets := map[string]string{"username": "admin", "password": "admin123"}
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceByName", TestDeviceName).Return(models.Device{Name: TestDeviceName, ServiceName: TestDeviceServiceName}, nil)
+	dbClientMock.On("DeviceByName", unknown).Return(models.Device{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	disabled := NewDeviceSecretsController(dic)
+
+	store := &mockDeviceSecretStore{stored: make(map[string]map[string]string)}
+	enabledDic := mockDic()
+	enabledDic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		v2MetadataContainer.DeviceSecretStoreName: func(get di.Get) interface{} {
+			return store
+		},
+	})
+	enabled := NewDeviceSecretsController(enabledDic)
+
+	tests := []struct {
+		name               string
+		controller         *DeviceSecretsController
+		deviceName         string
+		secrets            map[string]string
+		expectedStatusCode int
+	}{
+		{"Valid", enabled, TestDeviceName, secrets, http.StatusOK},
+		{"Invalid - unknown device", enabled, unknown, secrets, http.StatusNotFound},
+		{"Invalid - no secrets", enabled, TestDeviceName, map[string]string{}, http.StatusBadRequest},
+		{"Invalid - secret name", enabled, TestDeviceName, map[string]string{"a/b": "admin123"}, http.StatusBadRequest},
+		{"Invalid - device secrets disabled", disabled, TestDeviceName, secrets, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := devicesecrets.UpdateDeviceSecretsRequest{
+				BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+				Secrets:     testCase.secrets,
+			}
+			jsonData, err := json.Marshal(request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPut, devicesecrets.ApiDeviceSecretRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.deviceName})
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(testCase.controller.SetDeviceSecrets)
+			handler.ServeHTTP(recorder, req)
+			var res common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "BaseResponse status code not as expected")
+			assert.Equal(t, ExampleUUID, res.RequestId)
+			assert.NotContains(t, recorder.Body.String(), "admin123")
+		})
+	}
+	assert.Equal(t, secrets, store.stored[devicesecrets.Path(TestDeviceServiceName, TestDeviceName)])
+	assert.Len(t, store.stored, 1)
+}
+
+func TestAddDevice_PlaintextSecret(t *testing.T) {
+	reference := buildTestDeviceRequest()
+	reference.Device.Protocols = map[string]dtos.ProtocolProperties{
+		"onvif": {"Address": "10.0.0.1", "Username": "secret:username", "Password": "secret:password"},
+	}
+	plaintext := buildTestDeviceRequest()
+	plaintext.Device.Protocols = map[string]dtos.ProtocolProperties{
+		"onvif": {"Address": "10.0.0.1", "Username": "admin", "Password": "admin123"},
+	}
+	deviceModel := requests.AddDeviceReqToDeviceModels([]requests.AddDeviceRequest{reference})[0]
+
+	dic := mockDic()
+	metadataContainer.ConfigurationFrom(dic.Get).DeviceSecrets = devicesecrets.DeviceSecretsInfo{
+		Enabled:             true,
+		SensitiveProperties: []string{"Username", "Password"},
+	}
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceServiceNameExists", deviceModel.ServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileNameExists", deviceModel.ProfileName).Return(true, nil)
+	dbClientMock.On("AddDevice", deviceModel).Return(deviceModel, nil)
+	dbClientMock.On("DeviceServiceByName", deviceModel.ServiceName).Return(models.DeviceService{BaseAddress: testBaseAddress}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceController(dic)
+
+	tests := []struct {
+		name               string
+		request            requests.AddDeviceRequest
+		expectedStatusCode int
+	}{
+		{"Valid - secret references", reference, http.StatusCreated},
+		{"Invalid - plaintext secrets", plaintext, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal([]requests.AddDeviceRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, contractsV2.ApiDeviceRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AddDevice)
+			handler.ServeHTTP(recorder, req)
+			var res []common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "BaseResponse status code not as expected")
+			assert.NotContains(t, recorder.Body.String(), "admin123")
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "AddDevice", 1)
+}
@@ -0,0 +1,108 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicetransfer"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceTransferController_TransferDevice(t *testing.T) {
+	device := models.Device{
+		Id:          ExampleUUID,
+		Name:        TestDeviceName,
+		ServiceName: TestDeviceServiceName,
+		ProfileName: TestDeviceProfileName,
+		Protocols:   map[string]models.ProtocolProperties{"modbus-ip": {"Address": "10.0.0.12"}},
+	}
+	standby := models.DeviceService{Name: "standby", BaseAddress: testBaseAddress}
+	busy := models.DeviceService{Name: "busy", BaseAddress: testBaseAddress}
+	locked := models.DeviceService{Name: "locked", BaseAddress: testBaseAddress, AdminState: models.Locked}
+	notFound := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "doesn't exist in the database", nil)
+
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceByName", device.Name).Return(device, nil)
+	dbClientMock.On("DeviceByName", "unknown").Return(models.Device{}, notFound)
+	dbClientMock.On("DeviceServiceByName", TestDeviceServiceName).Return(models.DeviceService{Name: TestDeviceServiceName, BaseAddress: testBaseAddress}, nil)
+	dbClientMock.On("DeviceServiceByName", standby.Name).Return(standby, nil)
+	dbClientMock.On("DeviceServiceByName", busy.Name).Return(busy, nil)
+	dbClientMock.On("DeviceServiceByName", locked.Name).Return(locked, nil)
+	dbClientMock.On("DeviceServiceByName", "unknown").Return(models.DeviceService{}, notFound)
+	dbClientMock.On("DevicesByServiceName", 0, -1, standby.Name).Return([]models.Device(nil), nil)
+	dbClientMock.On("DevicesByServiceName", 0, -1, busy.Name).Return([]models.Device{
+		{Name: "camera", ProfileName: "camera", Protocols: map[string]models.ProtocolProperties{"onvif": {}}}}, nil)
+	dbClientMock.On("ProvisionWatchersByServiceName", 0, -1, mock.Anything).Return([]models.ProvisionWatcher(nil), nil)
+	dbClientMock.On("UpdateDevice", mock.Anything).Return(nil)
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceTransferController(dic)
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		body               string
+		expectedStatusCode int
+	}{
+		{"Valid - blank standby", device.Name, `{"apiVersion": "v2", "serviceName": "standby"}`, http.StatusOK},
+		{"Valid - forced", device.Name, `{"apiVersion": "v2", "serviceName": "busy", "force": true}`, http.StatusOK},
+		{"Invalid - incompatible service", device.Name, `{"apiVersion": "v2", "serviceName": "busy"}`, http.StatusConflict},
+		{"Invalid - locked service", device.Name, `{"apiVersion": "v2", "serviceName": "locked", "force": true}`, http.StatusConflict},
+		{"Invalid - same service", device.Name, `{"apiVersion": "v2", "serviceName": "` + TestDeviceServiceName + `"}`, http.StatusBadRequest},
+		{"Invalid - no service", device.Name, `{"apiVersion": "v2"}`, http.StatusBadRequest},
+		{"Invalid - unknown service", device.Name, `{"apiVersion": "v2", "serviceName": "unknown"}`, http.StatusNotFound},
+		{"Invalid - unknown device", "unknown", `{"apiVersion": "v2", "serviceName": "standby"}`, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, devicetransfer.ApiDeviceTransferRoute, strings.NewReader(testCase.body))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.deviceName})
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.TransferDevice)
+			handler.ServeHTTP(recorder, req)
+			var res devicetransfer.TransferResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				assert.NotEmpty(t, res.Message, "Message is empty")
+				return
+			}
+			assert.Equal(t, TestDeviceServiceName, res.FromServiceName)
+			assert.Equal(t, device.Name, res.DeviceName)
+		})
+	}
+	dbClientMock.AssertCalled(t, "UpdateDevice", mock.MatchedBy(func(d models.Device) bool {
+		return d.Name == device.Name && d.ServiceName == standby.Name
+	}))
+	dbClientMock.AssertNumberOfCalls(t, "UpdateDevice", 2)
+}
@@ -0,0 +1,199 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/notifications"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDiscoveryId = "bacnet-scan-1"
+
+type mockNotificationsClient struct{}
+
+func (mockNotificationsClient) SendNotification(_ context.Context, _ notifications.Notification) error {
+	return nil
+}
+
+func TestDiscoveryController_AddDiscoveredDevice(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	watcher := models.ProvisionWatcher{
+		Name:        testProvisionWatcherName,
+		ServiceName: TestDeviceServiceName,
+		ProfileName: TestDeviceProfileName,
+		AdminState:  models.Unlocked,
+	}
+	limited := watcher
+	limited.Name = "LimitedProvisionWatcher"
+	lockedByLimit := limited
+	lockedByLimit.AdminState = models.Locked
+	locked := watcher
+	locked.Name = "LockedProvisionWatcher"
+	locked.AdminState = models.Locked
+	otherService := watcher
+	otherService.Name = "OtherServiceProvisionWatcher"
+	otherService.ServiceName = "OtherDeviceService"
+	unknown := "UnknownProvisionWatcher"
+
+	record := func(watcherName string) discovery.Record {
+		return discovery.Record{
+			ProvisionWatcherName: watcherName,
+			DiscoveryId:          testDiscoveryId,
+			DeviceId:             ExampleUUID,
+			DeviceName:           device.Name,
+			ServiceName:          device.ServiceName,
+			ProfileName:          device.ProfileName,
+		}
+	}
+
+	dic := mockDic()
+	metadataContainer.ConfigurationFrom(dic.Get).Writable.DiscoveryLimits = discovery.LimitsInfo{
+		Default: discovery.Limit{MaxDevices: 2},
+	}
+	dbClientMock := &mocks.DBClient{}
+	for _, pw := range []models.ProvisionWatcher{watcher, limited, locked, otherService} {
+		dbClientMock.On("ProvisionWatcherByName", pw.Name).Return(pw, nil)
+	}
+	dbClientMock.On("ProvisionWatcherByName", unknown).Return(models.ProvisionWatcher{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "provision watcher doesn't exist in the database", nil))
+	dbClientMock.On("DeviceServiceNameExists", device.ServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileNameExists", device.ProfileName).Return(true, nil)
+	dbClientMock.On("AddDevice", device).Return(device, nil)
+	dbClientMock.On("DeviceServiceByName", device.ServiceName).Return(models.DeviceService{BaseAddress: testBaseAddress}, nil)
+	dbClientMock.On("AddDiscoveryRecord", record(watcher.Name)).Return(record(watcher.Name), nil)
+	dbClientMock.On("AddDiscoveryRecord", record(limited.Name)).Return(record(limited.Name), nil)
+	dbClientMock.On("DiscoveryRecordCountByProvisionWatcherName", watcher.Name, int64(0)).Return(uint32(1), nil)
+	dbClientMock.On("DiscoveryRecordCountByProvisionWatcherName", limited.Name, int64(0)).Return(uint32(2), nil)
+	dbClientMock.On("UpdateProvisionWatcher", lockedByLimit).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		metadataContainer.NotificationsClientName: func(get di.Get) interface{} {
+			return mockNotificationsClient{}
+		},
+	})
+
+	controller := NewDiscoveryController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		watcherName        string
+		expectedStatusCode int
+	}{
+		{"Valid", watcher.Name, http.StatusCreated},
+		{"Valid - discovery limit reached", limited.Name, http.StatusCreated},
+		{"Invalid - locked provision watcher", locked.Name, http.StatusConflict},
+		{"Invalid - device of another device service", otherService.Name, http.StatusBadRequest},
+		{"Invalid - unknown provision watcher", unknown, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := []discovery.AddDiscoveredDeviceRequest{{
+				BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+				DiscoveryId: testDiscoveryId,
+				Device:      buildTestDeviceRequest().Device,
+			}}
+			jsonData, err := json.Marshal(request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, discovery.ApiDiscoveredDeviceRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.watcherName})
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AddDiscoveredDevice)
+			handler.ServeHTTP(recorder, req)
+			var res []common.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res[0].Id)
+			} else {
+				assert.NotEmpty(t, res[0].Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "AddDevice", 2)
+	dbClientMock.AssertNumberOfCalls(t, "UpdateProvisionWatcher", 1)
+}
+
+func TestDiscoveryController_DiscoveryRecordsByProvisionWatcherName(t *testing.T) {
+	records := []discovery.Record{{
+		Id:                   ExampleUUID,
+		ProvisionWatcherName: testProvisionWatcherName,
+		DeviceId:             ExampleUUID,
+		DeviceName:           TestDeviceName,
+		ServiceName:          TestDeviceServiceName,
+		ProfileName:          TestDeviceProfileName,
+	}}
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DiscoveryRecordsByProvisionWatcherName", 0, 20, testProvisionWatcherName).Return(records, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDiscoveryController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		watcherName        string
+		expectedStatusCode int
+		expectedCount      int
+	}{
+		{"Valid", testProvisionWatcherName, http.StatusOK, 1},
+		{"Invalid - name is empty", "", http.StatusBadRequest, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, discovery.ApiDiscoveryRecordByProvisionWatcherNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.watcherName})
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DiscoveryRecordsByProvisionWatcherName)
+			handler.ServeHTTP(recorder, req)
+			var res discovery.MultiRecordsResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Len(t, res.Records, testCase.expectedCount)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dryrun"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// parseDryRun parses the DryRun query parameter of the updates
+func parseDryRun(r *http.Request) (bool, errors.EdgeX) {
+	dryRun, err := strconv.ParseBool(utils.ParseQueryStringToString(r, DryRun, "false"))
+	if err != nil {
+		return false, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid dryRun value", err)
+	}
+	return dryRun, nil
+}
+
+// newDryRunResponse returns the response of the dry run of an update with findings, http.StatusConflict when one of
+// the findings is breaking
+func newDryRunResponse(requestId string, findings []dryrun.Finding) dryrun.Response {
+	if findings == nil {
+		findings = []dryrun.Finding{}
+	}
+	if dryrun.Breaking(findings) {
+		return dryrun.Response{
+			BaseResponse: commonDTO.NewBaseResponse(requestId, "the update breaks the recent readings", http.StatusConflict),
+			Findings:     findings,
+		}
+	}
+	return dryrun.Response{
+		BaseResponse: commonDTO.NewBaseResponse(requestId, "", http.StatusOK),
+		Findings:     findings,
+	}
+}
@@ -0,0 +1,186 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dryrun"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// dryRunDic returns a container checking the readings of a single device of a device profile
+func dryRunDic(dbClient *mocks.DBClient, readingClient *clientMocks.ReadingClient) *di.Container {
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		metadataContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{LogLevel: "DEBUG"},
+				Service:  bootstrapConfig.ServiceInfo{MaxResultCount: 30},
+				DryRun:   dryrun.DryRunInfo{ReadingLimit: 10, DeviceLimit: 1, Timeout: "5s"},
+			}
+		},
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClient
+		},
+		v2MetadataContainer.ReadingClientName: func(get di.Get) interface{} {
+			return readingClient
+		},
+	})
+	return dic
+}
+
+func dryRunProfile(name string, valueType string) models.DeviceProfile {
+	return models.DeviceProfile{
+		Name: name,
+		DeviceResources: []models.DeviceResource{{
+			Name:       TestDeviceResourceName,
+			Properties: models.PropertyValue{ValueType: valueType, ReadWrite: "R"},
+		}},
+	}
+}
+
+func dryRunReadings(deviceName string, valueType string, values ...string) responses.MultiReadingsResponse {
+	readings := make([]dtos.BaseReading, len(values))
+	for i, v := range values {
+		readings[i] = dtos.BaseReading{DeviceName: deviceName, ResourceName: TestDeviceResourceName, ValueType: valueType,
+			SimpleReading: dtos.SimpleReading{Value: v}}
+	}
+	return responses.NewMultiReadingsResponse("", "", http.StatusOK, readings)
+}
+
+func TestDeviceController_PatchDevice_DryRun(t *testing.T) {
+	device := models.Device{Name: TestDeviceName, ServiceName: TestDeviceServiceName, ProfileName: "float32"}
+
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceByName", device.Name).Return(device, nil)
+	dbClientMock.On("DeviceProfileNameExists", mock.Anything).Return(true, nil)
+	for _, valueType := range []string{contractsV2.ValueTypeFloat32, contractsV2.ValueTypeFloat64, contractsV2.ValueTypeInt64} {
+		dbClientMock.On("DeviceProfileByName", valueType).Return(dryRunProfile(valueType, valueType), nil)
+	}
+	dbClientMock.On("DeviceProfileByName", "float32").Return(dryRunProfile("float32", contractsV2.ValueTypeFloat32), nil)
+	readingClientMock := &clientMocks.ReadingClient{}
+	readingClientMock.On("ReadingsByDeviceName", mock.Anything, device.Name, 0, 10).
+		Return(dryRunReadings(device.Name, contractsV2.ValueTypeFloat32, "2.150000e+01", "2.000000e+01"), nil)
+	controller := NewDeviceController(dryRunDic(dbClientMock, readingClientMock))
+
+	tests := []struct {
+		name               string
+		profileName        string
+		expectedStatusCode int
+		expectedFindings   int
+	}{
+		{"Valid - same profile", device.ProfileName, http.StatusOK, 0},
+		{"Valid - compatible profile", contractsV2.ValueTypeFloat64, http.StatusOK, 1},
+		{"Invalid - incompatible profile", contractsV2.ValueTypeInt64, http.StatusConflict, 2},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			name := device.Name
+			profileName := testCase.profileName
+			request := []requests.UpdateDeviceRequest{{
+				BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+				Device:      dtos.UpdateDevice{Versionable: common.NewVersionable(), Name: &name, ProfileName: &profileName},
+			}}
+			jsonData, err := json.Marshal(request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPatch, contractsV2.ApiDeviceRoute+"?"+DryRun+"=true", bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.PatchDevice)
+			handler.ServeHTTP(recorder, req)
+			var res []dryrun.Response
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "Response status code not as expected")
+			assert.Len(t, res[0].Findings, testCase.expectedFindings)
+		})
+	}
+	dbClientMock.AssertNotCalled(t, "UpdateDevice", mock.Anything)
+}
+
+func TestDeviceProfileController_UpdateDeviceProfile_DryRun(t *testing.T) {
+	stored := dryRunProfile(TestDeviceProfileName, contractsV2.ValueTypeInt16)
+	devices := []models.Device{{Name: "d1", ProfileName: stored.Name}, {Name: "d2", ProfileName: stored.Name}}
+
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceProfileByName", stored.Name).Return(stored, nil)
+	dbClientMock.On("DeviceProfileByName", "unknown").Return(models.DeviceProfile{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device profile doesn't exist in the database", nil))
+	dbClientMock.On("DevicesByProfileName", 0, 2, stored.Name).Return(devices, nil)
+	readingClientMock := &clientMocks.ReadingClient{}
+	readingClientMock.On("ReadingsByDeviceName", mock.Anything, "d1", 0, 10).
+		Return(dryRunReadings("d1", contractsV2.ValueTypeInt16, "-300"), nil)
+	controller := NewDeviceProfileController(dryRunDic(dbClientMock, readingClientMock))
+
+	tests := []struct {
+		name               string
+		profileName        string
+		valueType          string
+		expectedStatusCode int
+		expectedFindings   int
+	}{
+		{"Valid - unchanged", stored.Name, contractsV2.ValueTypeInt16, http.StatusOK, 0},
+		{"Valid - compatible type", stored.Name, contractsV2.ValueTypeInt32, http.StatusOK, 2},
+		{"Invalid - incompatible type", stored.Name, contractsV2.ValueTypeUint16, http.StatusConflict, 3},
+		{"Invalid - unknown profile", "unknown", contractsV2.ValueTypeInt16, http.StatusNotFound, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := buildTestDeviceProfileRequest()
+			request.Profile.Name = testCase.profileName
+			request.Profile.DeviceResources[0].Properties.ValueType = testCase.valueType
+			jsonData, err := json.Marshal([]requests.DeviceProfileRequest{request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPut, contractsV2.ApiDeviceProfileRoute+"?"+DryRun+"=true", bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.UpdateDeviceProfile)
+			handler.ServeHTTP(recorder, req)
+			var res []dryrun.Response
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "Response status code not as expected")
+			assert.Len(t, res[0].Findings, testCase.expectedFindings)
+		})
+	}
+	dbClientMock.AssertNotCalled(t, "UpdateDeviceProfile", mock.Anything)
+	readingClientMock.AssertNotCalled(t, "ReadingsByDeviceName", mock.Anything, "d2", mock.Anything, mock.Anything)
+}
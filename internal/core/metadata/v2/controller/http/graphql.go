@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/graphql"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+// ApiGraphQLRoute serves the read-only GraphQL queries of the devices, device profiles, device services and provision
+// watchers
+const ApiGraphQLRoute = v2.ApiBase + "/graphql"
+
+// contentTypeGraphQL is the content type of a request whose body is the query itself
+const contentTypeGraphQL = "application/graphql"
+
+type GraphQLController struct {
+	dic *di.Container
+}
+
+// NewGraphQLController creates and initializes a GraphQLController
+func NewGraphQLController(dic *di.Container) *GraphQLController {
+	return &GraphQLController{
+		dic: dic,
+	}
+}
+
+// Query executes the GraphQL query posted in JSON, as {"query": ..., "operationName": ..., "variables": {...}}, or
+// posted as is with the application/graphql content type, or given by the query, operationName and variables query
+// parameters of a GET request. The invalid requests are answered 400 (Bad Request) with the errors, and the executed
+// ones 200 (OK) with the data and the errors of the fields which failed to resolve.
+func (gc *GraphQLController) Query(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(gc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response graphql.Response
+	statusCode := http.StatusOK
+
+	request, err := readGraphQLRequest(r)
+	if err != nil {
+		response = graphql.Response{Errors: []graphql.Error{{Message: err.Error()}}}
+	} else {
+		response = application.ExecuteGraphQL(ctx, request, gc.dic)
+	}
+	if response.Data == nil {
+		statusCode = http.StatusBadRequest
+	}
+	for _, e := range response.Errors {
+		lc.Debug(fmt.Sprintf("GraphQL query error: %s", e.Message), clients.CorrelationHeader, correlationId)
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func readGraphQLRequest(r *http.Request) (graphql.Request, error) {
+	var request graphql.Request
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		request.Query = query.Get("query")
+		request.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				return request, fmt.Errorf("failed to parse the variables: %s", err.Error())
+			}
+		}
+	} else if strings.HasPrefix(r.Header.Get(clients.ContentType), contentTypeGraphQL) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return request, fmt.Errorf("failed to read the request body: %s", err.Error())
+		}
+		request.Query = string(body)
+	} else if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return request, fmt.Errorf("failed to parse the request: %s", err.Error())
+	}
+
+	if strings.TrimSpace(request.Query) == "" {
+		return request, fmt.Errorf("the request has no query")
+	}
+	return request, nil
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLController_Query(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	otherDevice := device
+	otherDevice.Name = "otherDevice"
+	profile := dtos.ToDeviceProfileModel(buildTestDeviceProfileRequest().Profile)
+	service := dtos.ToDeviceServiceModel(buildTestDeviceServiceRequest().Service)
+	service.Name = TestDeviceServiceName
+	notFoundName := "notFoundName"
+
+	dic := mockDic()
+	metadataContainer.ConfigurationFrom(dic.Get).GraphQL.MaxDepth = 4
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceByName", TestDeviceName).Return(device, nil)
+	dbClientMock.On("DeviceByName", notFoundName).Return(models.Device{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device doesn't exist in the database", nil))
+	dbClientMock.On("AllDevices", 0, 2, []string(nil)).Return([]models.Device{device, otherDevice}, nil)
+	dbClientMock.On("DeviceProfileByName", TestDeviceProfileName).Return(profile, nil)
+	dbClientMock.On("DeviceServiceByName", TestDeviceServiceName).Return(service, nil)
+	dbClientMock.On("DevicesByProfileName", 0, 20, TestDeviceProfileName).Return([]models.Device{device}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewGraphQLController(dic)
+	require.NotNil(t, controller)
+
+	getQuery := url.Values{
+		"query":     {`query ($name: String!) { device(name: $name) { name service { name baseAddress } } }`},
+		"variables": {`{"name": "` + TestDeviceName + `"}`},
+	}
+
+	tests := []struct {
+		name               string
+		method             string
+		contentType        string
+		body               string
+		rawQuery           string
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{"Valid - devices with their profile read once", http.MethodPost, clients.ContentTypeJSON,
+			`{"query": "{ devices(limit: 2) { name profile { name manufacturer } } }"}`, "", http.StatusOK,
+			`{"data":{"devices":[{"name":"` + TestDeviceName + `","profile":{"name":"` + TestDeviceProfileName + `","manufacturer":"` + TestManufacturer + `"}},{"name":"otherDevice","profile":{"name":"` + TestDeviceProfileName + `","manufacturer":"` + TestManufacturer + `"}}]}}`},
+		{"Valid - query given by the query parameters", http.MethodGet, "", "", getQuery.Encode(), http.StatusOK,
+			`{"data":{"device":{"name":"` + TestDeviceName + `","service":{"name":"` + TestDeviceServiceName + `","baseAddress":"` + testBaseAddress + `"}}}}`},
+		{"Valid - query posted as is", http.MethodPost, contentTypeGraphQL,
+			`{ device(name: "` + TestDeviceName + `") { profile { devices { name } } } missing: device(name: "` + notFoundName + `") { name } }`, "", http.StatusOK,
+			`{"data":{"device":{"profile":{"devices":[{"name":"` + TestDeviceName + `"}]}},"missing":null}}`},
+		{"Valid - limit out of range", http.MethodPost, contentTypeGraphQL,
+			`{ devices(limit: 50) { name } }`, "", http.StatusOK,
+			`{"data":{"devices":null},"errors":[{"message":"argument limit 50 is out of the range -1 ~ 30","locations":[{"line":1,"column":3}],"path":["devices"]}]}`},
+		{"Invalid - unknown field", http.MethodPost, contentTypeGraphQL,
+			`{ devices { serial } }`, "", http.StatusBadRequest,
+			`{"errors":[{"message":"Cannot query field \"serial\" on type \"Device\". Did you mean \"service\"?","locations":[{"line":1,"column":13}]}]}`},
+		{"Invalid - too deep", http.MethodPost, contentTypeGraphQL,
+			`{ devices { profile { devices { profile { name } } } } }`, "", http.StatusBadRequest,
+			`{"errors":[{"message":"field name exceeds the maximum depth of 4","locations":[{"line":1,"column":43}]}]}`},
+		{"Invalid - no query", http.MethodGet, "", "", "", http.StatusBadRequest,
+			`{"errors":[{"message":"the request has no query"}]}`},
+		{"Invalid - malformed JSON", http.MethodPost, clients.ContentTypeJSON, `{"query": `, "", http.StatusBadRequest,
+			`{"errors":[{"message":"failed to parse the request: unexpected EOF"}]}`},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(testCase.method, ApiGraphQLRoute, strings.NewReader(testCase.body))
+			require.NoError(t, err)
+			req.URL.RawQuery = testCase.rawQuery
+			if testCase.contentType != "" {
+				req.Header.Set(clients.ContentType, testCase.contentType)
+			}
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.Query)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, clients.ContentTypeJSON, recorder.Header().Get(clients.ContentType), "Content type not as expected")
+			assert.JSONEq(t, testCase.expectedResponse, recorder.Body.String(), "Response not as expected")
+		})
+	}
+	dbClientMock.AssertExpectations(t)
+	dbClientMock.AssertNumberOfCalls(t, "DeviceProfileByName", 2)
+}
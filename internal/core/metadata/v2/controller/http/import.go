@@ -0,0 +1,92 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	requestDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+)
+
+const (
+	// DryRun is the query parameter returning the device profile built from an imported file without adding it, or
+	// validating the update of a device or device profile against the recent readings without applying it
+	DryRun = "dryRun"
+
+	// Form fields of the device profile imports
+	ImportFile         = "file"
+	ImportName         = "name"
+	ImportManufacturer = "manufacturer"
+	ImportModel        = "model"
+	ImportDescription  = "description"
+	ImportLabels       = "labels"
+)
+
+// importForm holds the fields of a device profile import, uploaded as a multipart form
+type importForm struct {
+	dryRun       bool
+	fileName     string
+	data         []byte
+	name         string
+	manufacturer string
+	model        string
+	description  string
+	labels       []string
+}
+
+func readImportForm(r *http.Request) (importForm, errors.EdgeX) {
+	dryRun, err := strconv.ParseBool(utils.ParseQueryStringToString(r, DryRun, "false"))
+	if err != nil {
+		return importForm{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid dryRun value", err)
+	}
+
+	f, header, err := r.FormFile(ImportFile)
+	if err != nil {
+		return importForm{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "missing file to import", err)
+	}
+	defer func() { _ = f.Close() }()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return importForm{}, errors.NewCommonEdgeX(errors.KindServerError, "failed to read the file to import", err)
+	}
+
+	form := importForm{
+		dryRun:       dryRun,
+		fileName:     header.Filename,
+		data:         data,
+		name:         r.FormValue(ImportName),
+		manufacturer: r.FormValue(ImportManufacturer),
+		model:        r.FormValue(ImportModel),
+		description:  r.FormValue(ImportDescription),
+	}
+	for _, label := range strings.Split(r.FormValue(ImportLabels), ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			form.labels = append(form.labels, label)
+		}
+	}
+	return form, nil
+}
+
+// validateImportedProfile validates an imported device profile the same way as one added through
+// ApiDeviceProfileRoute
+func validateImportedProfile(deviceProfile dtos.DeviceProfile) errors.EdgeX {
+	request := requestDTO.DeviceProfileRequest{
+		BaseRequest: commonDTO.BaseRequest{Versionable: commonDTO.NewVersionable()},
+		Profile:     deviceProfile,
+	}
+	if err := request.Validate(); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid device profile", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/labels"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestLabelRegistry() labels.LabelRegistryInfo {
+	return labels.LabelRegistryInfo{
+		Enforced: true,
+		Keys: map[string]labels.KeyInfo{
+			"MODBUS":   {Description: "Modbus device"},
+			"TEMP":     {Description: "Temperature sensor"},
+			"location": {Description: "Where the device is", Values: map[string]string{"building-1": "Main building"}},
+		},
+	}
+}
+
+func TestLabelController_AllLabels(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	device.Labels = append(device.Labels, "location:building-1")
+	profile := dtos.ToDeviceProfileModel(buildTestDeviceProfileRequest().Profile)
+
+	dic := mockDic()
+	metadataContainer.ConfigurationFrom(dic.Get).Writable.Labels = buildTestLabelRegistry()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("AllDevices", 0, -1, []string(nil)).Return([]models.Device{device}, nil)
+	dbClientMock.On("AllDeviceProfiles", 0, -1, []string(nil)).Return([]models.DeviceProfile{profile}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewLabelController(dic)
+	require.NotNil(t, controller)
+
+	req, err := http.NewRequest(http.MethodGet, labels.ApiAllLabelRoute, http.NoBody)
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(controller.AllLabels)
+	handler.ServeHTTP(recorder, req)
+	var res labels.MultiUsagesResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+	require.Len(t, res.Labels, 3)
+	assert.Equal(t, labels.Usage{Label: "MODBUS", Key: "MODBUS", Description: "Modbus device", Registered: true, Devices: 1, DeviceProfiles: 1}, res.Labels[0])
+	assert.Equal(t, "TEMP", res.Labels[1].Label)
+	assert.Equal(t, labels.Usage{Label: "location:building-1", Key: "location", Value: "building-1", Description: "Main building", Registered: true, Devices: 1}, res.Labels[2])
+	dbClientMock.AssertExpectations(t)
+}
+
+func TestAddDevice_UnregisteredLabel(t *testing.T) {
+	registered := buildTestDeviceRequest()
+	registered.Device.Labels = []string{"MODBUS", "location:building-1"}
+	unregisteredKey := buildTestDeviceRequest()
+	unregisteredKey.Device.Labels = []string{"MODBUS", "floor:1"}
+	unregisteredValue := buildTestDeviceRequest()
+	unregisteredValue.Device.Labels = []string{"location:building-2"}
+	deviceModel := requests.AddDeviceReqToDeviceModels([]requests.AddDeviceRequest{registered})[0]
+
+	dic := mockDic()
+	metadataContainer.ConfigurationFrom(dic.Get).Writable.Labels = buildTestLabelRegistry()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceServiceNameExists", deviceModel.ServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileNameExists", deviceModel.ProfileName).Return(true, nil)
+	dbClientMock.On("AddDevice", deviceModel).Return(deviceModel, nil)
+	dbClientMock.On("DeviceServiceByName", deviceModel.ServiceName).Return(models.DeviceService{BaseAddress: testBaseAddress}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceController(dic)
+
+	tests := []struct {
+		name               string
+		request            requests.AddDeviceRequest
+		expectedStatusCode int
+	}{
+		{"Valid - registered labels", registered, http.StatusCreated},
+		{"Invalid - unregistered key", unregisteredKey, http.StatusBadRequest},
+		{"Invalid - unregistered value", unregisteredValue, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal([]requests.AddDeviceRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, contractsV2.ApiDeviceRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AddDevice)
+			handler.ServeHTTP(recorder, req)
+			var res []common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "BaseResponse status code not as expected")
+		})
+	}
+}
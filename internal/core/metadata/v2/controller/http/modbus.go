@@ -0,0 +1,94 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/modbus"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+)
+
+// ApiDeviceProfileImportModbusRoute creates a device profile from a Modbus register map
+const ApiDeviceProfileImportModbusRoute = v2.ApiBase + "/deviceprofile/import/modbus"
+
+// ImportModbusDeviceProfile creates a device profile from the Modbus register map, a CSV or Excel file, uploaded
+// as a multipart form along with the name and the other fields of the profile.
+func (dc *DeviceProfileController) ImportModbusDeviceProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	deviceProfile, dryRun, err := readModbusRegisterMap(r)
+	if err == nil && dryRun {
+		response = responseDTO.NewDeviceProfileResponse("", "", http.StatusOK, deviceProfile)
+		statusCode = http.StatusOK
+	} else if err == nil {
+		var newId string
+		newId, err = application.AddDeviceProfile(dtos.ToDeviceProfileModel(deviceProfile), ctx, dc.dic)
+		if err == nil {
+			response = commonDTO.NewBaseWithIdResponse("", "", http.StatusCreated, newId)
+			statusCode = http.StatusCreated
+		}
+	}
+
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	// Encode and send the resp body as JSON format
+	pkg.Encode(response, w, lc)
+}
+
+// readModbusRegisterMap returns the validated device profile built from the register map uploaded in r, and whether
+// the profile is only to be returned.
+func readModbusRegisterMap(r *http.Request) (dtos.DeviceProfile, bool, errors.EdgeX) {
+	form, edgexErr := readImportForm(r)
+	if edgexErr != nil {
+		return dtos.DeviceProfile{}, false, edgexErr
+	}
+	rows, err := modbus.ReadRows(form.data, form.fileName)
+	if err != nil {
+		return dtos.DeviceProfile{}, false, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to read register map file", err)
+	}
+
+	deviceProfile, err := modbus.ToDeviceProfile(rows, modbus.ProfileInfo{
+		Name:         form.name,
+		Manufacturer: form.manufacturer,
+		Model:        form.model,
+		Description:  form.description,
+		Labels:       form.labels,
+	})
+	if err != nil {
+		return dtos.DeviceProfile{}, false, errors.NewCommonEdgeX(errors.KindContractInvalid, err.Error(), nil)
+	}
+	if edgexErr = validateImportedProfile(deviceProfile); edgexErr != nil {
+		return dtos.DeviceProfile{}, false, edgexErr
+	}
+	return deviceProfile, form.dryRun, nil
+}
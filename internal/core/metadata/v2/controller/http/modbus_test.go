@@ -0,0 +1,179 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testRegisterMap = "Name,Address,Value Type,Units,Scale\nTemperature,40001,Int16,degC,0.1\nRunning,10001,,,\n"
+
+func createImportRequest(route string, fileName string, fileContents []byte, fields map[string]string, query string) (*http.Request, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	if fileName != "" {
+		part, err := writer.CreateFormFile(ImportFile, fileName)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = part.Write(fileContents); err != nil {
+			return nil, err
+		}
+	}
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, route+query, body)
+	req.Header.Set(clients.ContentType, writer.FormDataContentType())
+	return req, nil
+}
+
+func TestImportModbusDeviceProfile_Created(t *testing.T) {
+	expectedId := "1dc44f6c-a557-4d4a-9d2b-ccdadd674c9d"
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddDeviceProfile", mock.Anything).Return(models.DeviceProfile{Id: expectedId}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeviceProfileController(dic)
+	require.NotNil(t, controller)
+
+	req, err := createImportRequest(ApiDeviceProfileImportModbusRoute, "registers.csv", []byte(testRegisterMap), map[string]string{
+		ImportName:         "Thermostat",
+		ImportManufacturer: "Acme",
+		ImportLabels:       "modbus, hvac",
+	}, "")
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(controller.ImportModbusDeviceProfile)
+	handler.ServeHTTP(recorder, req)
+	var res common.BaseWithIdResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, recorder.Result().StatusCode, "HTTP status code not as expected")
+	assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "BaseResponse status code not as expected")
+	assert.Equal(t, expectedId, res.Id, "Id not as expected")
+
+	dbClientMock.AssertNumberOfCalls(t, "AddDeviceProfile", 1)
+	added := dbClientMock.Calls[0].Arguments.Get(0).(models.DeviceProfile)
+	assert.Equal(t, "Thermostat", added.Name)
+	assert.Equal(t, "Acme", added.Manufacturer)
+	assert.Equal(t, []string{"modbus", "hvac"}, added.Labels)
+	require.Len(t, added.DeviceResources, 2)
+	assert.Equal(t, "Temperature", added.DeviceResources[0].Name)
+	assert.Equal(t, "HOLDING_REGISTERS", added.DeviceResources[0].Attributes["primaryTable"])
+	assert.Equal(t, "0", added.DeviceResources[0].Attributes["startingAddress"])
+	assert.Equal(t, contractsV2.ValueTypeInt16, added.DeviceResources[0].Properties.ValueType)
+	assert.Equal(t, "0.1", added.DeviceResources[0].Properties.Scale)
+	assert.Equal(t, "DISCRETE_INPUTS", added.DeviceResources[1].Attributes["primaryTable"])
+	assert.Equal(t, contractsV2.ValueTypeBool, added.DeviceResources[1].Properties.ValueType)
+}
+
+func TestImportModbusDeviceProfile_DryRun(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeviceProfileController(dic)
+	require.NotNil(t, controller)
+
+	req, err := createImportRequest(ApiDeviceProfileImportModbusRoute, "registers.csv", []byte(testRegisterMap), map[string]string{ImportName: "Thermostat"}, "?"+DryRun+"=true")
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(controller.ImportModbusDeviceProfile)
+	handler.ServeHTTP(recorder, req)
+	var res responseDTO.DeviceProfileResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+	assert.Equal(t, http.StatusOK, res.StatusCode, "BaseResponse status code not as expected")
+	assert.Equal(t, "Thermostat", res.Profile.Name)
+	assert.Len(t, res.Profile.DeviceResources, 2)
+	assert.Len(t, res.Profile.CoreCommands, 2)
+	dbClientMock.AssertNotCalled(t, "AddDeviceProfile", mock.Anything)
+}
+
+func TestImportModbusDeviceProfile_BadRequest(t *testing.T) {
+	dic := mockDic()
+	controller := NewDeviceProfileController(dic)
+	require.NotNil(t, controller)
+
+	name := map[string]string{ImportName: "Thermostat"}
+	tests := []struct {
+		name     string
+		fileName string
+		contents string
+		fields   map[string]string
+		query    string
+	}{
+		{"Invalid - no file", "", "", name, ""},
+		{"Invalid - unsupported file", "registers.pdf", testRegisterMap, name, ""},
+		{"Invalid - invalid register", "registers.csv", "Name,Address\nTemperature,20001\n", name, ""},
+		{"Invalid - no profile name", "registers.csv", testRegisterMap, nil, ""},
+		{"Invalid - dryRun value", "registers.csv", testRegisterMap, name, "?" + DryRun + "=maybe"},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := createImportRequest(ApiDeviceProfileImportModbusRoute, testCase.fileName, []byte(testCase.contents), testCase.fields, testCase.query)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.ImportModbusDeviceProfile)
+			handler.ServeHTTP(recorder, req)
+			var res common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "BaseResponse status code not as expected")
+			assert.NotEmpty(t, res.Message, "Message should not be empty")
+		})
+	}
+}
@@ -0,0 +1,104 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/opcua"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// ApiDeviceProfileImportOPCUARoute creates a device profile from an OPC UA NodeSet2 XML file
+const ApiDeviceProfileImportOPCUARoute = v2.ApiBase + "/deviceprofile/import/opcua"
+
+// OPCUAImportResponse reports the variables of the nodeset that couldn't be converted, along with the id of the
+// added device profile or, for a dry run, the device profile itself.
+type OPCUAImportResponse struct {
+	commonDTO.BaseWithIdResponse `json:",inline"`
+	Profile                      *dtos.DeviceProfile `json:"profile,omitempty"`
+	Report                       opcua.Report        `json:"report"`
+}
+
+// ImportOPCUADeviceProfile creates a device profile from the variables of an OPC UA NodeSet2 XML file, uploaded as a
+// multipart form along with the name and the other fields of the profile.
+func (dc *DeviceProfileController) ImportOPCUADeviceProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	deviceProfile, report, dryRun, err := readOPCUANodeSet(r)
+	if err == nil && dryRun {
+		response = OPCUAImportResponse{
+			BaseWithIdResponse: commonDTO.NewBaseWithIdResponse("", "", http.StatusOK, ""),
+			Profile:            &deviceProfile,
+			Report:             report,
+		}
+		statusCode = http.StatusOK
+	} else if err == nil {
+		var newId string
+		newId, err = application.AddDeviceProfile(dtos.ToDeviceProfileModel(deviceProfile), ctx, dc.dic)
+		if err == nil {
+			response = OPCUAImportResponse{
+				BaseWithIdResponse: commonDTO.NewBaseWithIdResponse("", "", http.StatusCreated, newId),
+				Report:             report,
+			}
+			statusCode = http.StatusCreated
+		}
+	}
+
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	// Encode and send the resp body as JSON format
+	pkg.Encode(response, w, lc)
+}
+
+// readOPCUANodeSet returns the validated device profile built from the nodeset uploaded in r, the conversion report,
+// and whether the profile is only to be returned.
+func readOPCUANodeSet(r *http.Request) (dtos.DeviceProfile, opcua.Report, bool, errors.EdgeX) {
+	form, edgexErr := readImportForm(r)
+	if edgexErr != nil {
+		return dtos.DeviceProfile{}, opcua.Report{}, false, edgexErr
+	}
+
+	deviceProfile, report, err := opcua.ToDeviceProfile(form.data, opcua.ProfileInfo{
+		Name:         form.name,
+		Manufacturer: form.manufacturer,
+		Model:        form.model,
+		Description:  form.description,
+		Labels:       form.labels,
+	})
+	if err != nil {
+		return dtos.DeviceProfile{}, report, false, errors.NewCommonEdgeX(errors.KindContractInvalid, err.Error(), nil)
+	}
+	if edgexErr = validateImportedProfile(deviceProfile); edgexErr != nil {
+		return dtos.DeviceProfile{}, report, false, edgexErr
+	}
+	return deviceProfile, report, form.dryRun, nil
+}
@@ -0,0 +1,148 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testNodeSet = `<?xml version="1.0" encoding="utf-8"?>
+<UANodeSet xmlns="http://opcfoundation.org/UA/2011/03/UANodeSet.xsd">
+  <Aliases>
+    <Alias Alias="Double">i=11</Alias>
+    <Alias Alias="DateTime">i=13</Alias>
+  </Aliases>
+  <UAVariable NodeId="ns=1;i=6001" BrowseName="1:Temperature" DataType="Double" AccessLevel="3"/>
+  <UAVariable NodeId="ns=1;i=6002" BrowseName="1:LastService" DataType="DateTime"/>
+</UANodeSet>`
+
+func TestImportOPCUADeviceProfile_Created(t *testing.T) {
+	expectedId := "1dc44f6c-a557-4d4a-9d2b-ccdadd674c9d"
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddDeviceProfile", mock.Anything).Return(models.DeviceProfile{Id: expectedId}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeviceProfileController(dic)
+	require.NotNil(t, controller)
+
+	req, err := createImportRequest(ApiDeviceProfileImportOPCUARoute, "boiler.xml", []byte(testNodeSet), map[string]string{ImportName: "Boiler"}, "")
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(controller.ImportOPCUADeviceProfile)
+	handler.ServeHTTP(recorder, req)
+	var res OPCUAImportResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, recorder.Result().StatusCode, "HTTP status code not as expected")
+	assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "BaseResponse status code not as expected")
+	assert.Equal(t, expectedId, res.Id, "Id not as expected")
+	assert.Nil(t, res.Profile, "Profile should only be returned by a dry run")
+	assert.Equal(t, 1, res.Report.Converted)
+	require.Len(t, res.Report.Skipped, 1)
+	assert.Equal(t, "ns=1;i=6002", res.Report.Skipped[0].NodeId)
+
+	dbClientMock.AssertNumberOfCalls(t, "AddDeviceProfile", 1)
+	added := dbClientMock.Calls[0].Arguments.Get(0).(models.DeviceProfile)
+	assert.Equal(t, "Boiler", added.Name)
+	require.Len(t, added.DeviceResources, 1)
+	assert.Equal(t, "ns=1;i=6001", added.DeviceResources[0].Attributes["nodeId"])
+}
+
+func TestImportOPCUADeviceProfile_DryRun(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeviceProfileController(dic)
+	require.NotNil(t, controller)
+
+	req, err := createImportRequest(ApiDeviceProfileImportOPCUARoute, "boiler.xml", []byte(testNodeSet), map[string]string{ImportName: "Boiler"}, "?"+DryRun+"=true")
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler := http.HandlerFunc(controller.ImportOPCUADeviceProfile)
+	handler.ServeHTTP(recorder, req)
+	var res OPCUAImportResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+	assert.Equal(t, http.StatusOK, res.StatusCode, "BaseResponse status code not as expected")
+	require.NotNil(t, res.Profile)
+	assert.Equal(t, "Boiler", res.Profile.Name)
+	assert.Len(t, res.Profile.DeviceResources, 1)
+	assert.Len(t, res.Report.Skipped, 1)
+	dbClientMock.AssertNotCalled(t, "AddDeviceProfile", mock.Anything)
+}
+
+func TestImportOPCUADeviceProfile_BadRequest(t *testing.T) {
+	dic := mockDic()
+	controller := NewDeviceProfileController(dic)
+	require.NotNil(t, controller)
+
+	name := map[string]string{ImportName: "Boiler"}
+	tests := []struct {
+		name     string
+		fileName string
+		contents string
+		fields   map[string]string
+	}{
+		{"Invalid - no file", "", "", name},
+		{"Invalid - not a nodeset", "boiler.xml", "<UAObject/>", name},
+		{"Invalid - no convertible variable", "boiler.xml", `<UANodeSet><UAVariable NodeId="ns=1;i=1" BrowseName="1:LastService" DataType="i=13"/></UANodeSet>`, name},
+		{"Invalid - no profile name", "boiler.xml", testNodeSet, nil},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := createImportRequest(ApiDeviceProfileImportOPCUARoute, testCase.fileName, []byte(testCase.contents), testCase.fields, "")
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.ImportOPCUADeviceProfile)
+			handler.ServeHTTP(recorder, req)
+			var res common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "BaseResponse status code not as expected")
+			assert.NotEmpty(t, res.Message, "Message should not be empty")
+		})
+	}
+}
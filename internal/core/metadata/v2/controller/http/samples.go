@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/samples"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiDeviceProfileSamplesRoute generates sample events of the device profile
+	ApiDeviceProfileSamplesRoute = v2.ApiDeviceProfileByNameRoute + "/samples"
+	// Seed is the query parameter of the seed of the values of the sample events, random by default
+	Seed = "seed"
+	// defaultSampleDeviceName is the name of the device of the sample events, unless the deviceName query parameter
+	// is set
+	defaultSampleDeviceName = "sample-device"
+)
+
+// DeviceProfileSamples generates representative events of the device profile, as a device service would report them,
+// count times, for prototyping the UIs and testing the pipelines before the physical device exists.
+func (dc *DeviceProfileController) DeviceProfileSamples(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := metadataContainer.ConfigurationFrom(dc.dic.Get)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	name := vars[v2.Name]
+
+	var response interface{}
+	var statusCode int
+
+	count, err := utils.ParseQueryStringToInt(r, v2.Count, 1, 1, config.Service.MaxResultCount)
+	var seed int64
+	if err == nil {
+		seed, err = parseSeed(r)
+	}
+	var deviceProfile dtos.DeviceProfile
+	if err == nil {
+		deviceProfile, err = application.DeviceProfileByName(name, ctx, dc.dic)
+	}
+	var events []dtos.Event
+	if err == nil {
+		var generateErr error
+		deviceName := utils.ParseQueryStringToString(r, v2.DeviceName, defaultSampleDeviceName)
+		if events, generateErr = samples.Generate(deviceProfile, deviceName, count, seed); generateErr != nil {
+			err = errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to generate the sample events of device profile "+name, generateErr)
+		}
+	}
+
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		if events == nil {
+			events = []dtos.Event{}
+		}
+		response = samples.SamplesResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+			Seed:         seed,
+			Events:       events,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc) // encode and send out the response
+}
+
+// parseSeed returns the Seed query parameter, or a random seed when it is absent
+func parseSeed(r *http.Request) (int64, errors.EdgeX) {
+	value := r.URL.Query().Get(Seed)
+	if value == "" {
+		return time.Now().UnixNano(), nil
+	}
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid seed "+value, err)
+	}
+	return seed, nil
+}
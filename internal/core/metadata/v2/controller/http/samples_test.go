@@ -0,0 +1,101 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/samples"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceProfileSamples(t *testing.T) {
+	deviceProfile := dtos.ToDeviceProfileModel(buildTestDeviceProfileRequest().Profile)
+	invalidProfile := deviceProfile
+	invalidProfile.Name = "invalidProfile"
+	invalidProfile.DeviceResources = []models.DeviceResource{{Name: TestDeviceResourceName,
+		Properties: models.PropertyValue{ValueType: contractsV2.ValueTypeInt16, ReadWrite: "R", Minimum: "low"}}}
+	notFoundName := "notFoundName"
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceProfileByName", deviceProfile.Name).Return(deviceProfile, nil)
+	dbClientMock.On("DeviceProfileByName", invalidProfile.Name).Return(invalidProfile, nil)
+	dbClientMock.On("DeviceProfileByName", notFoundName).Return(models.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device profile doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2MetadataContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeviceProfileController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		deviceProfileName  string
+		query              string
+		expectedStatusCode int
+		expectedEvents     int
+	}{
+		{"Valid - default count", deviceProfile.Name, "", http.StatusOK, 2},
+		{"Valid - count, seed and device name", deviceProfile.Name, "?count=3&seed=42&deviceName=thermostat-1", http.StatusOK, 6},
+		{"Invalid - count greater than MaxResultCount", deviceProfile.Name, "?count=31", http.StatusBadRequest, 0},
+		{"Invalid - seed", deviceProfile.Name, "?seed=x", http.StatusBadRequest, 0},
+		{"Invalid - device resource minimum", invalidProfile.Name, "", http.StatusBadRequest, 0},
+		{"Invalid - device profile not found by name", notFoundName, "", http.StatusNotFound, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			reqPath := strings.Replace(ApiDeviceProfileSamplesRoute, "{"+contractsV2.Name+"}", testCase.deviceProfileName, 1) + testCase.query
+			req, err := http.NewRequest(http.MethodGet, reqPath, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.deviceProfileName})
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DeviceProfileSamples)
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				var res common.BaseResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+				return
+			}
+			var res samples.SamplesResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+			// the test profile has a device command reading its single device resource
+			assert.Len(t, res.Events, testCase.expectedEvents)
+			assert.NotZero(t, res.Seed)
+			for _, e := range res.Events {
+				assert.Equal(t, deviceProfile.Name, e.ProfileName)
+				if testCase.query == "" {
+					assert.Equal(t, defaultSampleDeviceName, e.DeviceName)
+				} else {
+					assert.Equal(t, "thermostat-1", e.DeviceName)
+				}
+			}
+		})
+	}
+}
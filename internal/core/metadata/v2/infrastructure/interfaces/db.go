@@ -6,6 +6,12 @@
 package interfaces
 
 import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	model "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 )
@@ -20,6 +26,7 @@ type DBClient interface {
 	DeleteDeviceProfileByName(name string) errors.EdgeX
 	DeviceProfileNameExists(name string) (bool, errors.EdgeX)
 	AllDeviceProfiles(offset int, limit int, labels []string) ([]model.DeviceProfile, errors.EdgeX)
+	DeviceProfilesBySearch(offset int, limit int, query string, labels []string) ([]model.DeviceProfile, errors.EdgeX)
 	DeviceProfilesByModel(offset int, limit int, model string) ([]model.DeviceProfile, errors.EdgeX)
 	DeviceProfilesByManufacturer(offset int, limit int, manufacturer string) ([]model.DeviceProfile, errors.EdgeX)
 	DeviceProfilesByManufacturerAndModel(offset int, limit int, manufacturer string, model string) ([]model.DeviceProfile, errors.EdgeX)
@@ -42,6 +49,7 @@ type DBClient interface {
 	DeviceById(id string) (model.Device, errors.EdgeX)
 	DeviceByName(name string) (model.Device, errors.EdgeX)
 	AllDevices(offset int, limit int, labels []string) ([]model.Device, errors.EdgeX)
+	DevicesBySearch(offset int, limit int, query string, labels []string) ([]model.Device, errors.EdgeX)
 	DevicesByProfileName(offset int, limit int, profileName string) ([]model.Device, errors.EdgeX)
 	UpdateDevice(d model.Device) errors.EdgeX
 
@@ -53,4 +61,28 @@ type DBClient interface {
 	AllProvisionWatchers(offset int, limit int, labels []string) ([]model.ProvisionWatcher, errors.EdgeX)
 	DeleteProvisionWatcherByName(name string) errors.EdgeX
 	UpdateProvisionWatcher(pw model.ProvisionWatcher) errors.EdgeX
+
+	AddDeprecation(d deprecations.Deprecation) (deprecations.Deprecation, errors.EdgeX)
+	AllDeprecations(offset int, limit int) ([]deprecations.Deprecation, errors.EdgeX)
+	DeprecationsByProfileName(profileName string) ([]deprecations.Deprecation, errors.EdgeX)
+	DeleteDeprecationByProfileNameAndResourceName(profileName string, resourceName string) errors.EdgeX
+
+	AddDiscoveryRecord(r discovery.Record) (discovery.Record, errors.EdgeX)
+	AllDiscoveryRecords(offset int, limit int) ([]discovery.Record, errors.EdgeX)
+	DiscoveryRecordsByProvisionWatcherName(offset int, limit int, name string) ([]discovery.Record, errors.EdgeX)
+	DiscoveryRecordCountByProvisionWatcherName(name string, since int64) (uint32, errors.EdgeX)
+
+	AddAdminSchedule(s adminschedules.AdminSchedule) (adminschedules.AdminSchedule, errors.EdgeX)
+	AllAdminSchedules(offset int, limit int) ([]adminschedules.AdminSchedule, errors.EdgeX)
+	AdminScheduleByName(name string) (adminschedules.AdminSchedule, errors.EdgeX)
+	DeleteAdminScheduleByName(name string) errors.EdgeX
+
+	AddClaimCode(c claimcodes.ClaimCode) errors.EdgeX
+	TakeClaimCode(hash string) (claimcodes.ClaimCode, errors.EdgeX)
+	DeleteClaimCodeByDeviceName(name string) errors.EdgeX
+
+	AddCallbackMessage(m callbacks.Message) (callbacks.Message, errors.EdgeX)
+	AllCallbackMessages(offset int, limit int) ([]callbacks.Message, errors.EdgeX)
+	UpdateCallbackMessage(m callbacks.Message) errors.EdgeX
+	DeleteCallbackMessageById(id string) errors.EdgeX
 }
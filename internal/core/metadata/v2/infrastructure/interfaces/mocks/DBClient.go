@@ -3,6 +3,16 @@
 package mocks
 
 import (
+	adminschedules "github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+
+	callbacks "github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+
+	claimcodes "github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+
+	deprecations "github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+
+	discovery "github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+
 	errors "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 
 	mock "github.com/stretchr/testify/mock"
@@ -15,6 +25,91 @@ type DBClient struct {
 	mock.Mock
 }
 
+// AddAdminSchedule provides a mock function with given fields: s
+func (_m *DBClient) AddAdminSchedule(s adminschedules.AdminSchedule) (adminschedules.AdminSchedule, errors.EdgeX) {
+	ret := _m.Called(s)
+
+	var r0 adminschedules.AdminSchedule
+	if rf, ok := ret.Get(0).(func(adminschedules.AdminSchedule) adminschedules.AdminSchedule); ok {
+		r0 = rf(s)
+	} else {
+		r0 = ret.Get(0).(adminschedules.AdminSchedule)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(adminschedules.AdminSchedule) errors.EdgeX); ok {
+		r1 = rf(s)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AddCallbackMessage provides a mock function with given fields: m
+func (_m *DBClient) AddCallbackMessage(m callbacks.Message) (callbacks.Message, errors.EdgeX) {
+	ret := _m.Called(m)
+
+	var r0 callbacks.Message
+	if rf, ok := ret.Get(0).(func(callbacks.Message) callbacks.Message); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(callbacks.Message)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(callbacks.Message) errors.EdgeX); ok {
+		r1 = rf(m)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AddClaimCode provides a mock function with given fields: c
+func (_m *DBClient) AddClaimCode(c claimcodes.ClaimCode) errors.EdgeX {
+	ret := _m.Called(c)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(claimcodes.ClaimCode) errors.EdgeX); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// AddDeprecation provides a mock function with given fields: d
+func (_m *DBClient) AddDeprecation(d deprecations.Deprecation) (deprecations.Deprecation, errors.EdgeX) {
+	ret := _m.Called(d)
+
+	var r0 deprecations.Deprecation
+	if rf, ok := ret.Get(0).(func(deprecations.Deprecation) deprecations.Deprecation); ok {
+		r0 = rf(d)
+	} else {
+		r0 = ret.Get(0).(deprecations.Deprecation)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(deprecations.Deprecation) errors.EdgeX); ok {
+		r1 = rf(d)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AddDevice provides a mock function with given fields: d
 func (_m *DBClient) AddDevice(d models.Device) (models.Device, errors.EdgeX) {
 	ret := _m.Called(d)
@@ -84,6 +179,29 @@ func (_m *DBClient) AddDeviceService(ds models.DeviceService) (models.DeviceServ
 	return r0, r1
 }
 
+// AddDiscoveryRecord provides a mock function with given fields: r
+func (_m *DBClient) AddDiscoveryRecord(r discovery.Record) (discovery.Record, errors.EdgeX) {
+	ret := _m.Called(r)
+
+	var r0 discovery.Record
+	if rf, ok := ret.Get(0).(func(discovery.Record) discovery.Record); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(discovery.Record)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(discovery.Record) errors.EdgeX); ok {
+		r1 = rf(r)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AddProvisionWatcher provides a mock function with given fields: pw
 func (_m *DBClient) AddProvisionWatcher(pw models.ProvisionWatcher) (models.ProvisionWatcher, errors.EdgeX) {
 	ret := _m.Called(pw)
@@ -107,6 +225,104 @@ func (_m *DBClient) AddProvisionWatcher(pw models.ProvisionWatcher) (models.Prov
 	return r0, r1
 }
 
+// AdminScheduleByName provides a mock function with given fields: name
+func (_m *DBClient) AdminScheduleByName(name string) (adminschedules.AdminSchedule, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 adminschedules.AdminSchedule
+	if rf, ok := ret.Get(0).(func(string) adminschedules.AdminSchedule); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(adminschedules.AdminSchedule)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AllAdminSchedules provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllAdminSchedules(offset int, limit int) ([]adminschedules.AdminSchedule, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []adminschedules.AdminSchedule
+	if rf, ok := ret.Get(0).(func(int, int) []adminschedules.AdminSchedule); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]adminschedules.AdminSchedule)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AllCallbackMessages provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllCallbackMessages(offset int, limit int) ([]callbacks.Message, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []callbacks.Message
+	if rf, ok := ret.Get(0).(func(int, int) []callbacks.Message); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]callbacks.Message)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AllDeprecations provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllDeprecations(offset int, limit int) ([]deprecations.Deprecation, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []deprecations.Deprecation
+	if rf, ok := ret.Get(0).(func(int, int) []deprecations.Deprecation); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]deprecations.Deprecation)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AllDeviceProfiles provides a mock function with given fields: offset, limit, labels
 func (_m *DBClient) AllDeviceProfiles(offset int, limit int, labels []string) ([]models.DeviceProfile, errors.EdgeX) {
 	ret := _m.Called(offset, limit, labels)
@@ -182,6 +398,31 @@ func (_m *DBClient) AllDevices(offset int, limit int, labels []string) ([]models
 	return r0, r1
 }
 
+// AllDiscoveryRecords provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllDiscoveryRecords(offset int, limit int) ([]discovery.Record, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []discovery.Record
+	if rf, ok := ret.Get(0).(func(int, int) []discovery.Record); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]discovery.Record)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AllProvisionWatchers provides a mock function with given fields: offset, limit, labels
 func (_m *DBClient) AllProvisionWatchers(offset int, limit int, labels []string) ([]models.ProvisionWatcher, errors.EdgeX) {
 	ret := _m.Called(offset, limit, labels)
@@ -212,6 +453,70 @@ func (_m *DBClient) CloseSession() {
 	_m.Called()
 }
 
+// DeleteAdminScheduleByName provides a mock function with given fields: name
+func (_m *DBClient) DeleteAdminScheduleByName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// DeleteCallbackMessageById provides a mock function with given fields: id
+func (_m *DBClient) DeleteCallbackMessageById(id string) errors.EdgeX {
+	ret := _m.Called(id)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// DeleteClaimCodeByDeviceName provides a mock function with given fields: name
+func (_m *DBClient) DeleteClaimCodeByDeviceName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// DeleteDeprecationByProfileNameAndResourceName provides a mock function with given fields: profileName, resourceName
+func (_m *DBClient) DeleteDeprecationByProfileNameAndResourceName(profileName string, resourceName string) errors.EdgeX {
+	ret := _m.Called(profileName, resourceName)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string, string) errors.EdgeX); ok {
+		r0 = rf(profileName, resourceName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
 // DeleteDeviceById provides a mock function with given fields: id
 func (_m *DBClient) DeleteDeviceById(id string) errors.EdgeX {
 	ret := _m.Called(id)
@@ -324,6 +629,31 @@ func (_m *DBClient) DeleteProvisionWatcherByName(name string) errors.EdgeX {
 	return r0
 }
 
+// DeprecationsByProfileName provides a mock function with given fields: profileName
+func (_m *DBClient) DeprecationsByProfileName(profileName string) ([]deprecations.Deprecation, errors.EdgeX) {
+	ret := _m.Called(profileName)
+
+	var r0 []deprecations.Deprecation
+	if rf, ok := ret.Get(0).(func(string) []deprecations.Deprecation); ok {
+		r0 = rf(profileName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]deprecations.Deprecation)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(profileName)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // DeviceById provides a mock function with given fields: id
 func (_m *DBClient) DeviceById(id string) (models.Device, errors.EdgeX) {
 	ret := _m.Called(id)
@@ -537,6 +867,31 @@ func (_m *DBClient) DeviceProfilesByModel(offset int, limit int, model string) (
 	return r0, r1
 }
 
+// DeviceProfilesBySearch provides a mock function with given fields: offset, limit, query, labels
+func (_m *DBClient) DeviceProfilesBySearch(offset int, limit int, query string, labels []string) ([]models.DeviceProfile, errors.EdgeX) {
+	ret := _m.Called(offset, limit, query, labels)
+
+	var r0 []models.DeviceProfile
+	if rf, ok := ret.Get(0).(func(int, int, string, []string) []models.DeviceProfile); ok {
+		r0 = rf(offset, limit, query, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceProfile)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int, string, []string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, query, labels)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // DeviceServiceById provides a mock function with given fields: id
 func (_m *DBClient) DeviceServiceById(id string) (models.DeviceService, errors.EdgeX) {
 	ret := _m.Called(id)
@@ -631,6 +986,31 @@ func (_m *DBClient) DevicesByProfileName(offset int, limit int, profileName stri
 	return r0, r1
 }
 
+// DevicesBySearch provides a mock function with given fields: offset, limit, query, labels
+func (_m *DBClient) DevicesBySearch(offset int, limit int, query string, labels []string) ([]models.Device, errors.EdgeX) {
+	ret := _m.Called(offset, limit, query, labels)
+
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(int, int, string, []string) []models.Device); ok {
+		r0 = rf(offset, limit, query, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int, string, []string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, query, labels)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // DevicesByServiceName provides a mock function with given fields: offset, limit, name
 func (_m *DBClient) DevicesByServiceName(offset int, limit int, name string) ([]models.Device, errors.EdgeX) {
 	ret := _m.Called(offset, limit, name)
@@ -656,6 +1036,54 @@ func (_m *DBClient) DevicesByServiceName(offset int, limit int, name string) ([]
 	return r0, r1
 }
 
+// DiscoveryRecordCountByProvisionWatcherName provides a mock function with given fields: name, since
+func (_m *DBClient) DiscoveryRecordCountByProvisionWatcherName(name string, since int64) (uint32, errors.EdgeX) {
+	ret := _m.Called(name, since)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string, int64) uint32); ok {
+		r0 = rf(name, since)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string, int64) errors.EdgeX); ok {
+		r1 = rf(name, since)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DiscoveryRecordsByProvisionWatcherName provides a mock function with given fields: offset, limit, name
+func (_m *DBClient) DiscoveryRecordsByProvisionWatcherName(offset int, limit int, name string) ([]discovery.Record, errors.EdgeX) {
+	ret := _m.Called(offset, limit, name)
+
+	var r0 []discovery.Record
+	if rf, ok := ret.Get(0).(func(int, int, string) []discovery.Record); ok {
+		r0 = rf(offset, limit, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]discovery.Record)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int, string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // ProvisionWatcherById provides a mock function with given fields: id
 func (_m *DBClient) ProvisionWatcherById(id string) (models.ProvisionWatcher, errors.EdgeX) {
 	ret := _m.Called(id)
@@ -752,6 +1180,45 @@ func (_m *DBClient) ProvisionWatchersByServiceName(offset int, limit int, name s
 	return r0, r1
 }
 
+// TakeClaimCode provides a mock function with given fields: hash
+func (_m *DBClient) TakeClaimCode(hash string) (claimcodes.ClaimCode, errors.EdgeX) {
+	ret := _m.Called(hash)
+
+	var r0 claimcodes.ClaimCode
+	if rf, ok := ret.Get(0).(func(string) claimcodes.ClaimCode); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Get(0).(claimcodes.ClaimCode)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(hash)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateCallbackMessage provides a mock function with given fields: m
+func (_m *DBClient) UpdateCallbackMessage(m callbacks.Message) errors.EdgeX {
+	ret := _m.Called(m)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(callbacks.Message) errors.EdgeX); ok {
+		r0 = rf(m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
 // UpdateDevice provides a mock function with given fields: d
 func (_m *DBClient) UpdateDevice(d models.Device) errors.EdgeX {
 	ret := _m.Called(d)
@@ -784,13 +1251,13 @@ func (_m *DBClient) UpdateDeviceProfile(e models.DeviceProfile) errors.EdgeX {
 	return r0
 }
 
-// UpdateProvisionWatcher provides a mock function with given fields: pw
-func (_m *DBClient) UpdateProvisionWatcher(pw models.ProvisionWatcher) errors.EdgeX {
-	ret := _m.Called(pw)
+// UpdateDeviceService provides a mock function with given fields: ds
+func (_m *DBClient) UpdateDeviceService(ds models.DeviceService) errors.EdgeX {
+	ret := _m.Called(ds)
 
 	var r0 errors.EdgeX
-	if rf, ok := ret.Get(0).(func(models.ProvisionWatcher) errors.EdgeX); ok {
-		r0 = rf(pw)
+	if rf, ok := ret.Get(0).(func(models.DeviceService) errors.EdgeX); ok {
+		r0 = rf(ds)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.EdgeX)
@@ -800,13 +1267,13 @@ func (_m *DBClient) UpdateProvisionWatcher(pw models.ProvisionWatcher) errors.Ed
 	return r0
 }
 
-// UpdateDeviceService provides a mock function with given fields: ds
-func (_m *DBClient) UpdateDeviceService(ds models.DeviceService) errors.EdgeX {
-	ret := _m.Called(ds)
+// UpdateProvisionWatcher provides a mock function with given fields: pw
+func (_m *DBClient) UpdateProvisionWatcher(pw models.ProvisionWatcher) errors.EdgeX {
+	ret := _m.Called(pw)
 
 	var r0 errors.EdgeX
-	if rf, ok := ret.Get(0).(func(models.DeviceService) errors.EdgeX); ok {
-		r0 = rf(ds)
+	if rf, ok := ret.Get(0).(func(models.ProvisionWatcher) errors.EdgeX); ok {
+		r0 = rf(pw)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.EdgeX)
@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// AdminScheduleReader unmarshals a request body into an array of AdminSchedule type
+type AdminScheduleReader interface {
+	ReadAddAdminScheduleRequest(reader io.Reader) ([]adminschedules.AddAdminScheduleRequest, errors.EdgeX)
+}
+
+// NewAdminScheduleRequestReader returns a BodyReader capable of processing the request body
+func NewAdminScheduleRequestReader() AdminScheduleReader {
+	return NewJsonAdminScheduleReader()
+}
+
+// NewJsonAdminScheduleReader creates a new instance of jsonAdminScheduleReader
+func NewJsonAdminScheduleReader() jsonAdminScheduleReader {
+	return jsonAdminScheduleReader{}
+}
+
+// jsonAdminScheduleReader unmarshals the JSON request body payload
+type jsonAdminScheduleReader struct{}
+
+// ReadAddAdminScheduleRequest reads a request and then converts its JSON data into an array of AddAdminScheduleRequest struct
+func (jsonAdminScheduleReader) ReadAddAdminScheduleRequest(reader io.Reader) ([]adminschedules.AddAdminScheduleRequest, errors.EdgeX) {
+	var addAdminSchedules []adminschedules.AddAdminScheduleRequest
+	err := json.NewDecoder(reader).Decode(&addAdminSchedules)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "admin state schedule json decoding failed", err)
+	}
+	return addAdminSchedules, nil
+}
@@ -0,0 +1,46 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// ClaimReader unmarshals a request body into a ClaimRequest type
+type ClaimReader interface {
+	ReadClaimRequest(reader io.Reader) (claimcodes.ClaimRequest, errors.EdgeX)
+}
+
+// NewClaimRequestReader returns a BodyReader capable of processing the request body
+func NewClaimRequestReader() ClaimReader {
+	return NewJsonClaimReader()
+}
+
+// NewJsonClaimReader creates a new instance of jsonClaimReader
+func NewJsonClaimReader() jsonClaimReader {
+	return jsonClaimReader{}
+}
+
+// jsonClaimReader unmarshals the JSON request body payload
+type jsonClaimReader struct{}
+
+// ReadClaimRequest reads a request and then converts its JSON data into a ClaimRequest struct
+func (jsonClaimReader) ReadClaimRequest(reader io.Reader) (claimcodes.ClaimRequest, errors.EdgeX) {
+	var request claimcodes.ClaimRequest
+	err := json.NewDecoder(reader).Decode(&request)
+	if err != nil {
+		return request, errors.NewCommonEdgeX(errors.KindContractInvalid, "claim json decoding failed", err)
+	}
+	if request.Code == "" {
+		return request, errors.NewCommonEdgeX(errors.KindContractInvalid, "no claim code in the request", nil)
+	}
+	return request, nil
+}
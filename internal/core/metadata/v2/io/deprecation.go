@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// DeprecationReader unmarshals a request body into an array of Deprecation type
+type DeprecationReader interface {
+	ReadAddDeprecationRequest(reader io.Reader) ([]deprecations.AddDeprecationRequest, errors.EdgeX)
+}
+
+// NewDeprecationRequestReader returns a BodyReader capable of processing the request body
+func NewDeprecationRequestReader() DeprecationReader {
+	return NewJsonDeprecationReader()
+}
+
+// NewJsonDeprecationReader creates a new instance of jsonDeprecationReader
+func NewJsonDeprecationReader() jsonDeprecationReader {
+	return jsonDeprecationReader{}
+}
+
+// jsonDeprecationReader unmarshals the JSON request body payload
+type jsonDeprecationReader struct{}
+
+// ReadAddDeprecationRequest reads a request and then converts its JSON data into an array of AddDeprecationRequest struct
+func (jsonDeprecationReader) ReadAddDeprecationRequest(reader io.Reader) ([]deprecations.AddDeprecationRequest, errors.EdgeX) {
+	var addDeprecations []deprecations.AddDeprecationRequest
+	err := json.NewDecoder(reader).Decode(&addDeprecations)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "deprecation json decoding failed", err)
+	}
+	return addDeprecations, nil
+}
@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicesecrets"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// DeviceSecretsReader unmarshals a request body into an UpdateDeviceSecretsRequest type
+type DeviceSecretsReader interface {
+	ReadUpdateDeviceSecretsRequest(reader io.Reader) (devicesecrets.UpdateDeviceSecretsRequest, errors.EdgeX)
+}
+
+// NewDeviceSecretsRequestReader returns a BodyReader capable of processing the request body
+func NewDeviceSecretsRequestReader() DeviceSecretsReader {
+	return NewJsonDeviceSecretsReader()
+}
+
+// NewJsonDeviceSecretsReader creates a new instance of jsonDeviceSecretsReader
+func NewJsonDeviceSecretsReader() jsonDeviceSecretsReader {
+	return jsonDeviceSecretsReader{}
+}
+
+// jsonDeviceSecretsReader unmarshals the JSON request body payload
+type jsonDeviceSecretsReader struct{}
+
+// ReadUpdateDeviceSecretsRequest reads a request and then converts its JSON data into an UpdateDeviceSecretsRequest
+// struct
+func (jsonDeviceSecretsReader) ReadUpdateDeviceSecretsRequest(reader io.Reader) (devicesecrets.UpdateDeviceSecretsRequest, errors.EdgeX) {
+	var request devicesecrets.UpdateDeviceSecretsRequest
+	err := json.NewDecoder(reader).Decode(&request)
+	if err != nil {
+		return request, errors.NewCommonEdgeX(errors.KindContractInvalid, "device secrets json decoding failed", err)
+	}
+	if len(request.Secrets) == 0 {
+		return request, errors.NewCommonEdgeX(errors.KindContractInvalid, "no secrets in the request", nil)
+	}
+	return request, nil
+}
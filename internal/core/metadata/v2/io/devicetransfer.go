@@ -0,0 +1,48 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicetransfer"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+// DeviceTransferReader unmarshals a request body into a TransferRequest type
+type DeviceTransferReader interface {
+	ReadTransferRequest(reader io.Reader) (devicetransfer.TransferRequest, errors.EdgeX)
+}
+
+// NewDeviceTransferRequestReader returns a BodyReader capable of processing the request body
+func NewDeviceTransferRequestReader() DeviceTransferReader {
+	return NewJsonDeviceTransferReader()
+}
+
+// NewJsonDeviceTransferReader creates a new instance of jsonDeviceTransferReader
+func NewJsonDeviceTransferReader() jsonDeviceTransferReader {
+	return jsonDeviceTransferReader{}
+}
+
+// jsonDeviceTransferReader unmarshals the JSON request body payload
+type jsonDeviceTransferReader struct{}
+
+// ReadTransferRequest reads a request and then converts its JSON data into a TransferRequest struct
+func (jsonDeviceTransferReader) ReadTransferRequest(reader io.Reader) (devicetransfer.TransferRequest, errors.EdgeX) {
+	var request devicetransfer.TransferRequest
+	err := json.NewDecoder(reader).Decode(&request)
+	if err != nil {
+		return request, errors.NewCommonEdgeX(errors.KindContractInvalid, "device transfer json decoding failed", err)
+	}
+	err = v2.Validate(request)
+	if err != nil {
+		return request, errors.NewCommonEdgeX(errors.KindContractInvalid, "device transfer validation failed", err)
+	}
+	return request, nil
+}
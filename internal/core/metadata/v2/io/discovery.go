@@ -0,0 +1,44 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// DiscoveryReader unmarshals a request body into an array of AddDiscoveredDeviceRequest type
+type DiscoveryReader interface {
+	ReadAddDiscoveredDeviceRequest(reader io.Reader) ([]discovery.AddDiscoveredDeviceRequest, errors.EdgeX)
+}
+
+// NewDiscoveryRequestReader returns a BodyReader capable of processing the request body
+func NewDiscoveryRequestReader() DiscoveryReader {
+	return NewJsonDiscoveryReader()
+}
+
+// NewJsonDiscoveryReader creates a new instance of jsonDiscoveryReader
+func NewJsonDiscoveryReader() jsonDiscoveryReader {
+	return jsonDiscoveryReader{}
+}
+
+// jsonDiscoveryReader unmarshals the JSON request body payload
+type jsonDiscoveryReader struct{}
+
+// ReadAddDiscoveredDeviceRequest reads a request and then converts its JSON data into an array of
+// AddDiscoveredDeviceRequest struct
+func (jsonDiscoveryReader) ReadAddDiscoveredDeviceRequest(reader io.Reader) ([]discovery.AddDiscoveredDeviceRequest, errors.EdgeX) {
+	var addDevices []discovery.AddDiscoveredDeviceRequest
+	err := json.NewDecoder(reader).Decode(&addDevices)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "discovered device json decoding failed", err)
+	}
+	return addDevices, nil
+}
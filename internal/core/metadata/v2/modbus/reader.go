@@ -0,0 +1,212 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxXLSXPartSize bounds the uncompressed size of each part read from a workbook
+const maxXLSXPartSize = 32 << 20
+
+// ReadRows returns the rows of the first sheet of an Excel workbook (.xlsx) or of a CSV file, depending on the
+// extension of fileName.
+func ReadRows(data []byte, fileName string) ([][]string, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".xlsx":
+		return readXLSX(data)
+	case ".csv", ".txt":
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		// a semicolon is the separator of the CSV files exported by Excel in many locales
+		if firstLine := strings.SplitN(string(data), "\n", 2)[0]; strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+			reader.Comma = ';'
+		}
+		return reader.ReadAll()
+	}
+	return nil, fmt.Errorf("unsupported register map file %s, expected a .csv or .xlsx file", fileName)
+}
+
+// The parts of the SpreadsheetML schema needed to read the values of the first sheet
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationshipId string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Reference string   `xml:"r,attr"`
+			Type      string   `xml:"t,attr"`
+			Value     string   `xml:"v"`
+			Inline    xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not an Excel workbook: %s", err.Error())
+	}
+	files := make(map[string]*zip.File)
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodeXLSXPart(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, fmt.Errorf("the workbook has no sheet")
+	}
+	var relationships xlsxRelationships
+	if err := decodeXLSXPart(files, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return nil, err
+	}
+	sheetPart := ""
+	for _, relationship := range relationships.Relationships {
+		if relationship.Id == workbook.Sheets[0].RelationshipId {
+			sheetPart = relationship.Target
+			if strings.HasPrefix(sheetPart, "/") {
+				sheetPart = strings.TrimPrefix(sheetPart, "/")
+			} else {
+				sheetPart = path.Join("xl", sheetPart)
+			}
+		}
+	}
+	if sheetPart == "" {
+		return nil, fmt.Errorf("the first sheet of the workbook can't be found")
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(files, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+	var sheet xlsxWorksheet
+	if err := decodeXLSXPart(files, sheetPart, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, xlsxRow := range sheet.Rows {
+		// keep the empty rows Excel leaves out so that the rows are reported with the number Excel shows
+		for len(rows)+1 < xlsxRow.Number {
+			rows = append(rows, nil)
+		}
+		var row []string
+		for i, c := range xlsxRow.Cells {
+			column := columnIndex(c.Reference)
+			if column < 0 {
+				column = i
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+			switch c.Type {
+			case "s":
+				index, err := strconv.Atoi(c.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("invalid shared string reference in cell %s", c.Reference)
+				}
+				row[column] = sharedStrings.Items[index].String()
+			case "inlineStr":
+				row[column] = c.Inline.String()
+			case "", "n":
+				// numbers are stored with their binary floating point representation, e.g. 0.1 as
+				// 0.10000000000000001, format them back as they were typed
+				if f, err := strconv.ParseFloat(c.Value, 64); err == nil {
+					row[column] = strconv.FormatFloat(f, 'f', -1, 64)
+				} else {
+					row[column] = c.Value
+				}
+			default:
+				row[column] = c.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func decodeXLSXPart(files map[string]*zip.File, name string, v interface{}) error {
+	file, ok := files[name]
+	if !ok {
+		return fmt.Errorf("not an Excel workbook: %s is missing", name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+	contents, err := ioutil.ReadAll(io.LimitReader(reader, maxXLSXPartSize+1))
+	if err != nil {
+		return err
+	}
+	if len(contents) > maxXLSXPartSize {
+		return fmt.Errorf("%s is larger than %d bytes once uncompressed", name, maxXLSXPartSize)
+	}
+	if err := xml.Unmarshal(contents, v); err != nil {
+		return fmt.Errorf("invalid %s in the workbook: %s", name, err.Error())
+	}
+	return nil
+}
+
+// columnIndex returns the zero-based column of a cell reference such as AB12, or -1 when there is no column
+func columnIndex(reference string) int {
+	index := 0
+	for _, r := range reference {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+	}
+	return index - 1
+}
@@ -0,0 +1,91 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildXLSX returns a minimal workbook whose first sheet holds a shared string header row, an empty row, and a row
+// mixing shared, inline and numeric cells with a gap in column C.
+func buildXLSX(t *testing.T) []byte {
+	parts := map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Registers" sheetId="1" r:id="rId2"/><sheet name="Notes" sheetId="2" r:id="rId1"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Name</t></si><si><r><t>Addr</t></r><r><t>ess</t></r></si><si><t>Scale</t></si><si><t>Units</t></si><si><t>Temperature</t></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c><c r="D1" t="s"><v>3</v></c></row>
+<row r="3"><c r="A3" t="s"><v>4</v></c><c r="B3"><v>40001</v></c><c r="D3" t="inlineStr"><is><t>degC</t></is></c></row>
+<row r="4"><c r="A4" t="inlineStr"><is><t>Pressure</t></is></c><c r="B4"><v>40002</v></c><c r="C4"><v>0.10000000000000001</v></c></row>
+</sheetData></worksheet>`,
+	}
+
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for name, contents := range parts {
+		part, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestReadRowsXLSX(t *testing.T) {
+	rows, err := ReadRows(buildXLSX(t), "registers.XLSX")
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Address", "Scale", "Units"},
+		nil,
+		{"Temperature", "40001", "", "degC"},
+		{"Pressure", "40002", "0.1"},
+	}, rows)
+}
+
+func TestReadRowsCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"comma separated", "Name,Address\nTemperature,40001\n"},
+		{"semicolon separated", "Name;Address\nTemperature;40001\n"},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			rows, err := ReadRows([]byte(testCase.data), "registers.csv")
+			require.NoError(t, err)
+			assert.Equal(t, [][]string{{"Name", "Address"}, {"Temperature", "40001"}}, rows)
+		})
+	}
+}
+
+func TestReadRowsErrors(t *testing.T) {
+	_, err := ReadRows([]byte("Name,Address"), "registers.xls")
+	assert.Error(t, err, "legacy Excel files are not supported")
+
+	_, err = ReadRows([]byte("Name,Address"), "registers.xlsx")
+	assert.Error(t, err, "an xlsx file must be a zip archive")
+}
@@ -0,0 +1,348 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package modbus converts the Modbus register maps published by device vendors into device profiles for the Modbus
+// device service.
+package modbus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Primary tables of the Modbus data model, as expected in the primaryTable attribute by the Modbus device service
+const (
+	Coils            = "COILS"
+	DiscreteInputs   = "DISCRETE_INPUTS"
+	InputRegisters   = "INPUT_REGISTERS"
+	HoldingRegisters = "HOLDING_REGISTERS"
+)
+
+// Attributes of the device resources read by the Modbus device service
+const (
+	PrimaryTableAttribute    = "primaryTable"
+	StartingAddressAttribute = "startingAddress"
+	RawTypeAttribute         = "rawType"
+	IsByteSwapAttribute      = "isByteSwap"
+	IsWordSwapAttribute      = "isWordSwap"
+)
+
+// Columns of a register map. The header row names the columns in any order; case, spaces and punctuation are
+// ignored, so "Value Type" names the valuetype column. Columns that aren't listed here are ignored.
+const (
+	NameColumn        = "name"
+	TableColumn       = "table"
+	AddressColumn     = "address"
+	ValueTypeColumn   = "valuetype"
+	ReadWriteColumn   = "readwrite"
+	UnitsColumn       = "units"
+	ScaleColumn       = "scale"
+	OffsetColumn      = "offset"
+	MinimumColumn     = "minimum"
+	MaximumColumn     = "maximum"
+	RawTypeColumn     = "rawtype"
+	ByteSwapColumn    = "byteswap"
+	WordSwapColumn    = "wordswap"
+	DescriptionColumn = "description"
+)
+
+// columnAliases maps the other names commonly used by vendors to the column they stand for
+var columnAliases = map[string]string{
+	"resource":        NameColumn,
+	"resourcename":    NameColumn,
+	"primarytable":    TableColumn,
+	"registertype":    TableColumn,
+	"startingaddress": AddressColumn,
+	"register":        AddressColumn,
+	"type":            ValueTypeColumn,
+	"datatype":        ValueTypeColumn,
+	"access":          ReadWriteColumn,
+	"unit":            UnitsColumn,
+	"min":             MinimumColumn,
+	"max":             MaximumColumn,
+}
+
+var tables = map[string]string{
+	"coils":             Coils,
+	"coil":              Coils,
+	"discrete_inputs":   DiscreteInputs,
+	"discreteinputs":    DiscreteInputs,
+	"discrete":          DiscreteInputs,
+	"input_registers":   InputRegisters,
+	"inputregisters":    InputRegisters,
+	"input":             InputRegisters,
+	"holding_registers": HoldingRegisters,
+	"holdingregisters":  HoldingRegisters,
+	"holding":           HoldingRegisters,
+}
+
+// modiconPrefixes maps the leading digit of a 5 or 6 digit Modicon address, e.g. 40001, to its table
+var modiconPrefixes = map[byte]string{
+	'0': Coils,
+	'1': DiscreteInputs,
+	'3': InputRegisters,
+	'4': HoldingRegisters,
+}
+
+var valueTypes = map[string]string{
+	"bool":    v2.ValueTypeBool,
+	"boolean": v2.ValueTypeBool,
+	"int16":   v2.ValueTypeInt16,
+	"uint16":  v2.ValueTypeUint16,
+	"int32":   v2.ValueTypeInt32,
+	"uint32":  v2.ValueTypeUint32,
+	"int64":   v2.ValueTypeInt64,
+	"uint64":  v2.ValueTypeUint64,
+	"float32": v2.ValueTypeFloat32,
+	"float":   v2.ValueTypeFloat32,
+	"float64": v2.ValueTypeFloat64,
+	"double":  v2.ValueTypeFloat64,
+	"string":  v2.ValueTypeString,
+}
+
+// ProfileInfo describes the device profile built from a register map
+type ProfileInfo struct {
+	Name         string
+	Manufacturer string
+	Model        string
+	Description  string
+	Labels       []string
+}
+
+// ToDeviceProfile converts the rows of a register map, the first one being the header, into a device profile with
+// one device resource per register and one core command per device resource. All the invalid rows are reported in
+// the returned error.
+func ToDeviceProfile(rows [][]string, info ProfileInfo) (dtos.DeviceProfile, error) {
+	if len(rows) < 2 {
+		return dtos.DeviceProfile{}, fmt.Errorf("the register map must have a header row and at least one register")
+	}
+	columns := make(map[string]int)
+	for i, header := range rows[0] {
+		column := normalizeHeader(header)
+		if alias, ok := columnAliases[column]; ok {
+			column = alias
+		}
+		if _, ok := columns[column]; !ok {
+			columns[column] = i
+		}
+	}
+	for _, required := range []string{NameColumn, AddressColumn} {
+		if _, ok := columns[required]; !ok {
+			return dtos.DeviceProfile{}, fmt.Errorf("the register map has no %s column", required)
+		}
+	}
+
+	profile := dtos.DeviceProfile{
+		Versionable:  common.NewVersionable(),
+		Name:         info.Name,
+		Manufacturer: info.Manufacturer,
+		Model:        info.Model,
+		Description:  info.Description,
+		Labels:       info.Labels,
+	}
+	var problems []string
+	names := make(map[string]int)
+	for i, row := range rows[1:] {
+		line := i + 2
+		cell := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[index])
+		}
+		if isBlank(row) {
+			continue
+		}
+
+		resource, err := toDeviceResource(cell)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %s", line, err.Error()))
+			continue
+		}
+		if previous, ok := names[resource.Name]; ok {
+			problems = append(problems, fmt.Sprintf("row %d: name %s is already used by row %d", line, resource.Name, previous))
+			continue
+		}
+		names[resource.Name] = line
+
+		profile.DeviceResources = append(profile.DeviceResources, resource)
+		profile.CoreCommands = append(profile.CoreCommands, dtos.Command{
+			Name: resource.Name,
+			Get:  strings.Contains(resource.Properties.ReadWrite, "R"),
+			Set:  strings.Contains(resource.Properties.ReadWrite, "W"),
+		})
+	}
+	if len(problems) > 0 {
+		return dtos.DeviceProfile{}, fmt.Errorf("invalid register map: %s", strings.Join(problems, "; "))
+	}
+	if len(profile.DeviceResources) == 0 {
+		return dtos.DeviceProfile{}, fmt.Errorf("the register map has no register")
+	}
+	return profile, nil
+}
+
+func toDeviceResource(cell func(column string) string) (dtos.DeviceResource, error) {
+	name := cell(NameColumn)
+	if name == "" {
+		return dtos.DeviceResource{}, fmt.Errorf("name is empty")
+	}
+	table, address, err := parseAddress(cell(TableColumn), cell(AddressColumn))
+	if err != nil {
+		return dtos.DeviceResource{}, err
+	}
+
+	valueType := v2.ValueTypeUint16
+	if table == Coils || table == DiscreteInputs {
+		valueType = v2.ValueTypeBool
+	}
+	if value := cell(ValueTypeColumn); value != "" {
+		var ok bool
+		if valueType, ok = valueTypes[strings.ToLower(value)]; !ok {
+			return dtos.DeviceResource{}, fmt.Errorf("unsupported value type %s", value)
+		}
+	}
+
+	readWrite := "R"
+	if table == Coils || table == HoldingRegisters {
+		readWrite = "RW"
+	}
+	if value := cell(ReadWriteColumn); value != "" {
+		switch strings.ToUpper(value) {
+		case "R", "RO", "READ":
+			readWrite = "R"
+		case "W", "WO", "WRITE":
+			readWrite = "W"
+		case "RW", "R/W", "READWRITE", "READ/WRITE":
+			readWrite = "RW"
+		default:
+			return dtos.DeviceResource{}, fmt.Errorf("unsupported read/write access %s", value)
+		}
+	}
+	if strings.Contains(readWrite, "W") && (table == DiscreteInputs || table == InputRegisters) {
+		return dtos.DeviceResource{}, fmt.Errorf("%s are read-only", strings.ToLower(table))
+	}
+
+	attributes := map[string]string{
+		PrimaryTableAttribute:    table,
+		StartingAddressAttribute: strconv.Itoa(address),
+	}
+	if value := cell(RawTypeColumn); value != "" {
+		rawType, ok := valueTypes[strings.ToLower(value)]
+		if !ok {
+			return dtos.DeviceResource{}, fmt.Errorf("unsupported raw type %s", value)
+		}
+		attributes[RawTypeAttribute] = strings.ToUpper(rawType)
+	}
+	for _, swap := range []struct{ column, attribute string }{
+		{ByteSwapColumn, IsByteSwapAttribute},
+		{WordSwapColumn, IsWordSwapAttribute},
+	} {
+		if value := cell(swap.column); value != "" {
+			enabled, err := parseBool(value)
+			if err != nil {
+				return dtos.DeviceResource{}, fmt.Errorf("invalid %s %s", swap.column, value)
+			}
+			attributes[swap.attribute] = strconv.FormatBool(enabled)
+		}
+	}
+
+	properties := dtos.PropertyValue{
+		ValueType: valueType,
+		ReadWrite: readWrite,
+		Units:     cell(UnitsColumn),
+	}
+	for _, number := range []struct {
+		column   string
+		property *string
+	}{
+		{ScaleColumn, &properties.Scale},
+		{OffsetColumn, &properties.Offset},
+		{MinimumColumn, &properties.Minimum},
+		{MaximumColumn, &properties.Maximum},
+	} {
+		if value := cell(number.column); value != "" {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return dtos.DeviceResource{}, fmt.Errorf("%s %s is not a number", number.column, value)
+			}
+			*number.property = value
+		}
+	}
+
+	return dtos.DeviceResource{
+		Name:        name,
+		Description: cell(DescriptionColumn),
+		Attributes:  attributes,
+		Properties:  properties,
+	}, nil
+}
+
+// parseAddress returns the table and zero-based address of a register. The address is either the protocol address
+// of the register in the given table, or a 5 or 6 digit Modicon address such as 40001 when the table is empty.
+func parseAddress(table string, address string) (string, int, error) {
+	value, err := strconv.Atoi(address)
+	if err != nil || value < 0 {
+		return "", 0, fmt.Errorf("invalid address %s", address)
+	}
+
+	if table == "" {
+		if len(address) != 5 && len(address) != 6 {
+			return "", 0, fmt.Errorf("table is empty and address %s is not a Modicon address", address)
+		}
+		prefixTable, ok := modiconPrefixes[address[0]]
+		if !ok {
+			return "", 0, fmt.Errorf("address %s is not a Modicon address", address)
+		}
+		offset, _ := strconv.Atoi(address[1:])
+		if offset == 0 {
+			return "", 0, fmt.Errorf("Modicon address %s is out of range", address)
+		}
+		return prefixTable, offset - 1, nil
+	}
+
+	normalized, ok := tables[strings.ToLower(strings.Join(strings.Fields(table), "_"))]
+	if !ok {
+		return "", 0, fmt.Errorf("unknown table %s", table)
+	}
+	if value > 65535 {
+		return "", 0, fmt.Errorf("address %s is out of range", address)
+	}
+	return normalized, value, nil
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func normalizeHeader(header string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(header) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func isBlank(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
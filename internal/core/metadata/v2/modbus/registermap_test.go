@@ -0,0 +1,101 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testProfileInfo = ProfileInfo{
+	Name:         "PowerMeter",
+	Manufacturer: "Acme",
+	Model:        "PM-100",
+	Labels:       []string{"modbus"},
+}
+
+func TestToDeviceProfile(t *testing.T) {
+	rows := [][]string{
+		{"Name", "Primary Table", "Starting Address", "Value Type", "Read/Write", "Unit", "Scale", "Raw Type", "Word Swap", "Vendor Notes"},
+		{"Voltage", "", "30001", "Float32", "", "V", "", "", "yes", "phase A"},
+		{"Relay", "Coils", "0", "", "", "", "", "", "", ""},
+		{},
+		{"Setpoint", "holding registers", "10", "Float32", "RW", "degC", "0.1", "Int16", "", ""},
+		{"Alarm", "", "10005", "", "", "", "", "", "", ""},
+	}
+
+	profile, err := ToDeviceProfile(rows, testProfileInfo)
+
+	require.NoError(t, err)
+	assert.Equal(t, "PowerMeter", profile.Name)
+	assert.Equal(t, "Acme", profile.Manufacturer)
+	assert.Equal(t, "PM-100", profile.Model)
+	assert.Equal(t, []string{"modbus"}, profile.Labels)
+	assert.Equal(t, []dtos.DeviceResource{
+		{
+			Name:       "Voltage",
+			Attributes: map[string]string{PrimaryTableAttribute: InputRegisters, StartingAddressAttribute: "0", IsWordSwapAttribute: "true"},
+			Properties: dtos.PropertyValue{ValueType: v2.ValueTypeFloat32, ReadWrite: "R", Units: "V"},
+		},
+		{
+			Name:       "Relay",
+			Attributes: map[string]string{PrimaryTableAttribute: Coils, StartingAddressAttribute: "0"},
+			Properties: dtos.PropertyValue{ValueType: v2.ValueTypeBool, ReadWrite: "RW"},
+		},
+		{
+			Name:       "Setpoint",
+			Attributes: map[string]string{PrimaryTableAttribute: HoldingRegisters, StartingAddressAttribute: "10", RawTypeAttribute: "INT16"},
+			Properties: dtos.PropertyValue{ValueType: v2.ValueTypeFloat32, ReadWrite: "RW", Units: "degC", Scale: "0.1"},
+		},
+		{
+			Name:       "Alarm",
+			Attributes: map[string]string{PrimaryTableAttribute: DiscreteInputs, StartingAddressAttribute: "4"},
+			Properties: dtos.PropertyValue{ValueType: v2.ValueTypeBool, ReadWrite: "R"},
+		},
+	}, profile.DeviceResources)
+	assert.Equal(t, []dtos.Command{
+		{Name: "Voltage", Get: true},
+		{Name: "Relay", Get: true, Set: true},
+		{Name: "Setpoint", Get: true, Set: true},
+		{Name: "Alarm", Get: true},
+	}, profile.CoreCommands)
+}
+
+func TestToDeviceProfileErrors(t *testing.T) {
+	header := []string{"Name", "Table", "Address", "Type", "Access", "Scale"}
+
+	tests := []struct {
+		name          string
+		rows          [][]string
+		expectedError string
+	}{
+		{"no register", [][]string{header}, "header row and at least one register"},
+		{"only blank rows", [][]string{header, {"", ""}}, "no register"},
+		{"no address column", [][]string{{"Name"}, {"Voltage"}}, "no address column"},
+		{"empty name", [][]string{header, {"", "holding", "1"}}, "row 2: name is empty"},
+		{"unknown table", [][]string{header, {"Voltage", "registers", "1"}}, "row 2: unknown table"},
+		{"not a Modicon address", [][]string{header, {"Voltage", "", "12"}}, "row 2: table is empty"},
+		{"invalid Modicon prefix", [][]string{header, {"Voltage", "", "20001"}}, "row 2: address 20001 is not a Modicon address"},
+		{"invalid address", [][]string{header, {"Voltage", "holding", "0x10"}}, "row 2: invalid address"},
+		{"unsupported type", [][]string{header, {"Voltage", "holding", "1", "Decimal"}}, "row 2: unsupported value type"},
+		{"writing an input register", [][]string{header, {"Voltage", "input", "1", "", "RW"}}, "row 2: input_registers are read-only"},
+		{"invalid scale", [][]string{header, {"Voltage", "holding", "1", "", "", "ten"}}, "row 2: scale ten is not a number"},
+		{"duplicate name", [][]string{header, {"Voltage", "holding", "1"}, {"Voltage", "holding", "2"}}, "row 3: name Voltage is already used by row 2"},
+		{"all invalid rows reported", [][]string{header, {"", "holding", "1"}, {"Current", "holding", "x"}}, "row 2: name is empty; row 3: invalid address"},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := ToDeviceProfile(testCase.rows, testProfileInfo)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,360 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// NodeIdAttribute is the attribute of the device resources read by the OPC UA device service
+const NodeIdAttribute = "nodeId"
+
+// Properties of the variables mapped to the properties of their device resource
+const (
+	engineeringUnits = "EngineeringUnits"
+	euRange          = "EURange"
+)
+
+// Value ranks of the variables that can be converted
+const (
+	scalar       = -1
+	oneDimension = 1
+)
+
+// maxSubtypeDepth bounds the walk from a data type defined by the nodeset to the built-in type it derives from
+const maxSubtypeDepth = 16
+
+// dataTypes maps the namespace 0 data types to their value type
+var dataTypes = map[string]string{
+	"i=1":   v2.ValueTypeBool,
+	"i=2":   v2.ValueTypeInt8,
+	"i=3":   v2.ValueTypeUint8,
+	"i=4":   v2.ValueTypeInt16,
+	"i=5":   v2.ValueTypeUint16,
+	"i=6":   v2.ValueTypeInt32,
+	"i=7":   v2.ValueTypeUint32,
+	"i=8":   v2.ValueTypeInt64,
+	"i=9":   v2.ValueTypeUint64,
+	"i=10":  v2.ValueTypeFloat32,
+	"i=11":  v2.ValueTypeFloat64,
+	"i=12":  v2.ValueTypeString,
+	"i=15":  v2.ValueTypeBinary,
+	"i=29":  v2.ValueTypeInt32,   // Enumeration
+	"i=288": v2.ValueTypeUint32,  // IntegerId
+	"i=289": v2.ValueTypeUint32,  // Counter
+	"i=290": v2.ValueTypeFloat64, // Duration
+	"i=295": v2.ValueTypeString,  // LocaleId
+}
+
+// ProfileInfo describes the device profile built from a nodeset
+type ProfileInfo struct {
+	Name         string
+	Manufacturer string
+	Model        string
+	Description  string
+	Labels       []string
+}
+
+// Report lists the variables of a nodeset that couldn't be converted into device resources
+type Report struct {
+	Converted int           `json:"converted"`
+	Skipped   []SkippedNode `json:"skipped,omitempty"`
+}
+
+// SkippedNode is a variable of a nodeset left out of the device profile, and why
+type SkippedNode struct {
+	NodeId     string `json:"nodeId"`
+	BrowseName string `json:"browseName"`
+	Reason     string `json:"reason"`
+}
+
+func (r *Report) skip(v variable, format string, args ...interface{}) {
+	r.Skipped = append(r.Skipped, SkippedNode{NodeId: v.NodeId, BrowseName: v.BrowseName, Reason: fmt.Sprintf(format, args...)})
+}
+
+// converter holds the indexes of a nodeset needed to convert its variables
+type converter struct {
+	aliases   map[string]string
+	nodes     map[string]node
+	variables map[string]variable
+	dataTypes map[string]node
+	// properties maps a variable to the variables that are its properties
+	properties map[string][]variable
+	// owners maps a property to the variable it belongs to
+	owners map[string]string
+}
+
+// ToDeviceProfile converts the variables of a NodeSet2 XML document into a device profile with one device resource
+// per variable and one core command per device resource. The returned report lists the variables that couldn't be
+// converted. An error is returned when the document is invalid or when no variable could be converted.
+func ToDeviceProfile(data []byte, info ProfileInfo) (dtos.DeviceProfile, Report, error) {
+	set, err := parseNodeSet(data)
+	if err != nil {
+		return dtos.DeviceProfile{}, Report{}, err
+	}
+	c := newConverter(set)
+
+	profile := dtos.DeviceProfile{
+		Versionable:  common.NewVersionable(),
+		Name:         info.Name,
+		Manufacturer: info.Manufacturer,
+		Model:        info.Model,
+		Description:  info.Description,
+		Labels:       info.Labels,
+	}
+	var report Report
+	var converted []variable
+	for _, v := range set.Variables {
+		if owner, ok := c.owners[v.NodeId]; ok {
+			if name := v.name(); name != engineeringUnits && name != euRange {
+				report.skip(v, "property %s of variable %s isn't mapped to the device resource", name, owner)
+			}
+			continue
+		}
+		resource, reason := c.toDeviceResource(v)
+		if reason != "" {
+			report.skip(v, "%s", reason)
+			continue
+		}
+		profile.DeviceResources = append(profile.DeviceResources, resource)
+		converted = append(converted, v)
+	}
+
+	// browse names are only unique among siblings, qualify the duplicated ones with the browse name of their parent
+	counts := make(map[string]int)
+	for _, resource := range profile.DeviceResources {
+		counts[resource.Name]++
+	}
+	resources := profile.DeviceResources[:0]
+	names := make(map[string]string)
+	for i, resource := range profile.DeviceResources {
+		v := converted[i]
+		if counts[resource.Name] > 1 {
+			if parent, ok := c.parent(v); ok {
+				resource.Name = resourceName(parent.name() + "." + v.name())
+			}
+		}
+		if previous, ok := names[resource.Name]; ok {
+			report.skip(v, "name %s is already used by node %s", resource.Name, previous)
+			continue
+		}
+		names[resource.Name] = v.NodeId
+		resources = append(resources, resource)
+		profile.CoreCommands = append(profile.CoreCommands, dtos.Command{
+			Name: resource.Name,
+			Get:  strings.Contains(resource.Properties.ReadWrite, "R"),
+			Set:  strings.Contains(resource.Properties.ReadWrite, "W"),
+		})
+	}
+	profile.DeviceResources = resources
+	report.Converted = len(resources)
+
+	if len(resources) == 0 {
+		reasons := make([]string, len(report.Skipped))
+		for i, skipped := range report.Skipped {
+			reasons[i] = fmt.Sprintf("%s: %s", skipped.NodeId, skipped.Reason)
+		}
+		if len(reasons) == 0 {
+			return dtos.DeviceProfile{}, report, fmt.Errorf("the nodeset has no variable")
+		}
+		return dtos.DeviceProfile{}, report, fmt.Errorf("no variable of the nodeset can be converted: %s", strings.Join(reasons, "; "))
+	}
+	return profile, report, nil
+}
+
+func newConverter(set nodeSet) converter {
+	c := converter{
+		aliases:    set.aliases(),
+		nodes:      make(map[string]node),
+		variables:  make(map[string]variable),
+		dataTypes:  make(map[string]node),
+		properties: make(map[string][]variable),
+		owners:     make(map[string]string),
+	}
+	for _, o := range set.Objects {
+		c.nodes[o.NodeId] = o
+	}
+	for _, v := range set.Variables {
+		c.nodes[v.NodeId] = v.node
+		c.variables[v.NodeId] = v
+	}
+	for _, t := range set.DataTypes {
+		c.dataTypes[t.NodeId] = t
+	}
+
+	// a HasProperty reference may be declared by the variable, by its property, or by both
+	for _, v := range set.Variables {
+		for _, ref := range v.References {
+			if c.resolve(ref.ReferenceType) != hasProperty {
+				continue
+			}
+			owner, property := v.NodeId, strings.TrimSpace(ref.Target)
+			if !ref.forward() {
+				owner, property = property, owner
+			}
+			if _, ok := c.variables[owner]; !ok {
+				continue
+			}
+			if _, ok := c.owners[property]; ok {
+				continue
+			}
+			if p, ok := c.variables[property]; ok {
+				c.owners[property] = owner
+				c.properties[owner] = append(c.properties[owner], p)
+			}
+		}
+	}
+	return c
+}
+
+func (r reference) forward() bool {
+	return !strings.EqualFold(strings.TrimSpace(r.IsForward), "false")
+}
+
+// resolve returns the node id of an alias, or the given node id
+func (c converter) resolve(nodeId string) string {
+	nodeId = strings.TrimSpace(nodeId)
+	if resolved, ok := c.aliases[nodeId]; ok {
+		return resolved
+	}
+	return nodeId
+}
+
+// parent returns the node a variable is a component of
+func (c converter) parent(v variable) (node, bool) {
+	if parent, ok := c.nodes[v.ParentNodeId]; ok {
+		return parent, true
+	}
+	for _, ref := range v.References {
+		if !ref.forward() && c.resolve(ref.ReferenceType) == hasComponent {
+			if parent, ok := c.nodes[strings.TrimSpace(ref.Target)]; ok {
+				return parent, true
+			}
+		}
+	}
+	return node{}, false
+}
+
+// toDeviceResource returns the device resource of a variable, or the reason why it can't be converted
+func (c converter) toDeviceResource(v variable) (dtos.DeviceResource, string) {
+	name := resourceName(v.name())
+	if name == "" {
+		return dtos.DeviceResource{}, "the browse name is empty"
+	}
+
+	dataType := v.DataType
+	if dataType == "" {
+		// BaseDataType, the default data type of a variable, can hold a value of any type
+		return dtos.DeviceResource{}, "the data type is not set"
+	}
+	valueType, ok := c.valueType(c.resolve(dataType))
+	if !ok {
+		return dtos.DeviceResource{}, fmt.Sprintf("unsupported data type %s", dataType)
+	}
+
+	valueRank := scalar
+	if v.ValueRank != "" {
+		rank, err := strconv.Atoi(v.ValueRank)
+		if err != nil {
+			return dtos.DeviceResource{}, fmt.Sprintf("invalid value rank %s", v.ValueRank)
+		}
+		valueRank = rank
+	}
+	switch valueRank {
+	case scalar:
+	case oneDimension:
+		if valueType == v2.ValueTypeBinary {
+			return dtos.DeviceResource{}, "arrays of ByteString are not supported"
+		}
+		// the array value types are named after the type of their elements
+		valueType = valueType + "Array"
+	default:
+		return dtos.DeviceResource{}, fmt.Sprintf("unsupported value rank %d, only scalars and one dimension arrays are supported", valueRank)
+	}
+
+	// CurrentRead is the default access level of a variable
+	accessLevel := uint64(1)
+	if v.AccessLevel != "" {
+		level, err := strconv.ParseUint(v.AccessLevel, 10, 8)
+		if err != nil {
+			return dtos.DeviceResource{}, fmt.Sprintf("invalid access level %s", v.AccessLevel)
+		}
+		accessLevel = level
+	}
+	readWrite := ""
+	if accessLevel&1 != 0 {
+		readWrite += "R"
+	}
+	if accessLevel&2 != 0 {
+		readWrite += "W"
+	}
+	if readWrite == "" {
+		return dtos.DeviceResource{}, "the variable is neither readable nor writable"
+	}
+
+	properties := dtos.PropertyValue{
+		ValueType: valueType,
+		ReadWrite: readWrite,
+	}
+	for _, p := range c.properties[v.NodeId] {
+		switch p.name() {
+		case engineeringUnits:
+			properties.Units = strings.TrimSpace(p.Value.Units)
+		case euRange:
+			properties.Minimum = strings.TrimSpace(p.Value.Low)
+			properties.Maximum = strings.TrimSpace(p.Value.High)
+		}
+	}
+
+	return dtos.DeviceResource{
+		Name:        name,
+		Description: v.description(),
+		Attributes:  map[string]string{NodeIdAttribute: v.NodeId},
+		Properties:  properties,
+	}, ""
+}
+
+// valueType returns the value type of a data type, walking up the data types defined by the nodeset to the
+// namespace 0 data type they derive from
+func (c converter) valueType(dataType string) (string, bool) {
+	for i := 0; i < maxSubtypeDepth; i++ {
+		if valueType, ok := dataTypes[dataType]; ok {
+			return valueType, true
+		}
+		t, ok := c.dataTypes[dataType]
+		if !ok {
+			return "", false
+		}
+		base := ""
+		for _, ref := range t.References {
+			if !ref.forward() && c.resolve(ref.ReferenceType) == hasSubtype {
+				base = c.resolve(ref.Target)
+			}
+		}
+		if base == "" {
+			return "", false
+		}
+		dataType = base
+	}
+	return "", false
+}
+
+// resourceName replaces the characters of a browse name that aren't allowed in the name of a device resource
+func resourceName(browseName string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '_', r == '~':
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(browseName))
+}
@@ -0,0 +1,99 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToDeviceProfile(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/boiler.xml")
+	require.NoError(t, err)
+
+	profile, report, err := ToDeviceProfile(data, ProfileInfo{Name: "Boiler", Manufacturer: "Acme", Labels: []string{"opcua"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Boiler", profile.Name)
+	assert.Equal(t, "Acme", profile.Manufacturer)
+	assert.Equal(t, []string{"opcua"}, profile.Labels)
+	assert.Equal(t, []dtos.DeviceResource{
+		{
+			Name:        "Boiler1.Temperature",
+			Description: "Water temperature",
+			Attributes:  map[string]string{NodeIdAttribute: "ns=1;i=6001"},
+			Properties:  dtos.PropertyValue{ValueType: v2.ValueTypeFloat64, ReadWrite: "RW", Units: "°C", Minimum: "0", Maximum: "120"},
+		},
+		{
+			Name:       "Boiler2.Temperature",
+			Attributes: map[string]string{NodeIdAttribute: "ns=1;i=6004"},
+			Properties: dtos.PropertyValue{ValueType: v2.ValueTypeFloat64, ReadWrite: "R"},
+		},
+		{
+			Name:       "Mode",
+			Attributes: map[string]string{NodeIdAttribute: "ns=1;i=6006"},
+			Properties: dtos.PropertyValue{ValueType: v2.ValueTypeInt32, ReadWrite: "RW"},
+		},
+		{
+			Name:       "Alarms",
+			Attributes: map[string]string{NodeIdAttribute: "ns=1;i=6009"},
+			Properties: dtos.PropertyValue{ValueType: v2.ValueTypeString + "Array", ReadWrite: "R"},
+		},
+		{
+			Name:       "Burner_On",
+			Attributes: map[string]string{NodeIdAttribute: "ns=1;i=6011"},
+			Properties: dtos.PropertyValue{ValueType: v2.ValueTypeBool, ReadWrite: "W"},
+		},
+	}, profile.DeviceResources)
+	assert.Equal(t, []dtos.Command{
+		{Name: "Boiler1.Temperature", Get: true, Set: true},
+		{Name: "Boiler2.Temperature", Get: true},
+		{Name: "Mode", Get: true, Set: true},
+		{Name: "Alarms", Get: true},
+		{Name: "Burner_On", Set: true},
+	}, profile.CoreCommands)
+
+	assert.Equal(t, 5, report.Converted)
+	skipped := make(map[string]string)
+	for _, node := range report.Skipped {
+		skipped[node.NodeId] = node.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"ns=1;i=6005": "property InstrumentRange of variable ns=1;i=6004 isn't mapped to the device resource",
+		"ns=1;i=6007": "unsupported data type ns=1;i=3002",
+		"ns=1;i=6008": "unsupported data type DateTime",
+		"ns=1;i=6010": "unsupported value rank 2, only scalars and one dimension arrays are supported",
+		"ns=1;i=6012": "the variable is neither readable nor writable",
+	}, skipped)
+}
+
+func TestToDeviceProfileErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectedError string
+	}{
+		{"not XML", "registers", "invalid NodeSet2 XML"},
+		{"not a nodeset", "<UAObject/>", "invalid NodeSet2 XML"},
+		{"no variable", "<UANodeSet><UAObject NodeId=\"ns=1;i=1\" BrowseName=\"1:Boiler\"/></UANodeSet>", "the nodeset has no variable"},
+		{"no convertible variable",
+			"<UANodeSet><UAVariable NodeId=\"ns=1;i=1\" BrowseName=\"1:LastService\" DataType=\"i=13\"/></UANodeSet>",
+			"no variable of the nodeset can be converted: ns=1;i=1: unsupported data type i=13"},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, _, err := ToDeviceProfile([]byte(testCase.data), ProfileInfo{Name: "Boiler"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package opcua converts OPC UA information models, published as NodeSet2 XML files, into device profiles for the
+// OPC UA device service.
+package opcua
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// Node ids of the namespace 0 reference types used by the conversion
+const (
+	hasSubtype        = "i=45"
+	hasProperty       = "i=46"
+	hasComponent      = "i=47"
+	hasTypeDefinition = "i=40"
+)
+
+// standardAliases are the aliases of namespace 0 used when a nodeset references a node by its name without
+// declaring the alias
+var standardAliases = map[string]string{
+	"HasSubtype":        hasSubtype,
+	"HasProperty":       hasProperty,
+	"HasComponent":      hasComponent,
+	"HasTypeDefinition": hasTypeDefinition,
+}
+
+// The parts of the UANodeSet schema needed to convert the variables of a nodeset
+type nodeSet struct {
+	XMLName       xml.Name `xml:"UANodeSet"`
+	NamespaceUris []string `xml:"NamespaceUris>Uri"`
+	Aliases       []struct {
+		Alias  string `xml:"Alias,attr"`
+		NodeId string `xml:",chardata"`
+	} `xml:"Aliases>Alias"`
+	Objects   []node     `xml:"UAObject"`
+	Variables []variable `xml:"UAVariable"`
+	DataTypes []node     `xml:"UADataType"`
+}
+
+type node struct {
+	NodeId       string      `xml:"NodeId,attr"`
+	BrowseName   string      `xml:"BrowseName,attr"`
+	ParentNodeId string      `xml:"ParentNodeId,attr"`
+	DisplayNames []string    `xml:"DisplayName"`
+	Descriptions []string    `xml:"Description"`
+	References   []reference `xml:"References>Reference"`
+}
+
+type reference struct {
+	ReferenceType string `xml:"ReferenceType,attr"`
+	IsForward     string `xml:"IsForward,attr"`
+	Target        string `xml:",chardata"`
+}
+
+type variable struct {
+	node
+	DataType    string `xml:"DataType,attr"`
+	ValueRank   string `xml:"ValueRank,attr"`
+	AccessLevel string `xml:"AccessLevel,attr"`
+	Value       struct {
+		Units string `xml:"ExtensionObject>Body>EUInformation>DisplayName>Text"`
+		Low   string `xml:"ExtensionObject>Body>Range>Low"`
+		High  string `xml:"ExtensionObject>Body>Range>High"`
+	} `xml:"Value"`
+}
+
+// name returns the browse name of the node without its namespace index
+func (n node) name() string {
+	if i := strings.Index(n.BrowseName, ":"); i >= 0 {
+		return n.BrowseName[i+1:]
+	}
+	return n.BrowseName
+}
+
+func (n node) description() string {
+	if len(n.Descriptions) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(n.Descriptions[0])
+}
+
+func parseNodeSet(data []byte) (nodeSet, error) {
+	var set nodeSet
+	if err := xml.Unmarshal(data, &set); err != nil {
+		return nodeSet{}, fmt.Errorf("invalid NodeSet2 XML: %s", err.Error())
+	}
+	return set, nil
+}
+
+// aliases returns the node ids of the aliases declared by the nodeset, along with the standard ones
+func (set nodeSet) aliases() map[string]string {
+	aliases := make(map[string]string, len(standardAliases)+len(set.Aliases))
+	for alias, nodeId := range standardAliases {
+		aliases[alias] = nodeId
+	}
+	for _, alias := range set.Aliases {
+		aliases[alias.Alias] = strings.TrimSpace(alias.NodeId)
+	}
+	return aliases
+}
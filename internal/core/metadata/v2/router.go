@@ -8,12 +8,30 @@ package v2
 import (
 	"net/http"
 
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	v2MetadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/bootstrap/container"
 	metadataController "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/controller/http"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/samples"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicesecrets"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicetransfer"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dryrun"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/labels"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/openapi"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/schema"
 	commonController "github.com/edgexfoundry/edgex-go/internal/pkg/v2/controller/http"
 
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	v2Constant "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
 
 	"github.com/gorilla/mux"
 )
@@ -26,15 +44,37 @@ func LoadRestRoutes(r *mux.Router, dic *di.Container) {
 	r.HandleFunc(v2Constant.ApiVersionRoute, cc.Version).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiConfigRoute, cc.Config).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiMetricsRoute, cc.Metrics).Methods(http.MethodGet)
+	r.HandleFunc(correlation.ApiTraceRoute, cc.Trace).Methods(http.MethodGet)
+	r.HandleFunc(features.ApiCapabilitiesRoute, cc.Capabilities).Methods(http.MethodGet)
+
+	// Schemas
+	schemas := schema.NewRegistry(bootstrapContainer.LoggingClientFrom(dic.Get))
+	schemas.Add(responseDTO.DeviceProfileResponse{}, responseDTO.MultiDeviceProfilesResponse{},
+		responseDTO.DeviceServiceResponse{}, responseDTO.MultiDeviceServicesResponse{},
+		responseDTO.DeviceResponse{}, responseDTO.MultiDevicesResponse{},
+		responseDTO.ProvisionWatcherResponse{}, responseDTO.MultiProvisionWatchersResponse{},
+		deprecations.MultiDeprecationsResponse{}, deprecations.MultiUsagesResponse{},
+		discovery.MultiRecordsResponse{}, labels.MultiUsagesResponse{},
+		adminschedules.AdminScheduleResponse{}, adminschedules.MultiAdminSchedulesResponse{}, adminschedules.RunResponse{},
+		claimcodes.ClaimCodeResponse{}, claimcodes.ClaimResponse{}, devicetransfer.TransferResponse{},
+		dryrun.Response{}, callbacks.MultiCallbacksResponse{}, samples.SamplesResponse{})
+	schema.LoadRestRoutes(r, schemas)
+
+	// OpenAPI document
+	openapi.LoadRestRoutes(r, bootstrapContainer.LoggingClientFrom(dic.Get), openapi.CoreMetadataSpec,
+		metadataContainer.ConfigurationFrom(dic.Get).JWTAuth.Enabled)
 
 	// Device Profile
 	dc := metadataController.NewDeviceProfileController(dic)
-	r.HandleFunc(v2Constant.ApiDeviceProfileRoute, dc.AddDeviceProfile).Methods(http.MethodPost)
-	r.HandleFunc(v2Constant.ApiDeviceProfileRoute, dc.UpdateDeviceProfile).Methods(http.MethodPut)
+	r.HandleFunc(v2Constant.ApiDeviceProfileRoute, schemas.ValidateRequest([]requests.DeviceProfileRequest{}, dc.AddDeviceProfile)).Methods(http.MethodPost)
+	r.HandleFunc(v2Constant.ApiDeviceProfileRoute, schemas.ValidateRequest([]requests.DeviceProfileRequest{}, dc.UpdateDeviceProfile)).Methods(http.MethodPut)
 	r.HandleFunc(v2Constant.ApiDeviceProfileUploadFileRoute, dc.AddDeviceProfileByYaml).Methods(http.MethodPost)
 	r.HandleFunc(v2Constant.ApiDeviceProfileUploadFileRoute, dc.UpdateDeviceProfileByYaml).Methods(http.MethodPut)
+	r.HandleFunc(metadataController.ApiDeviceProfileImportModbusRoute, dc.ImportModbusDeviceProfile).Methods(http.MethodPost)
+	r.HandleFunc(metadataController.ApiDeviceProfileImportOPCUARoute, dc.ImportOPCUADeviceProfile).Methods(http.MethodPost)
 	r.HandleFunc(v2Constant.ApiDeviceProfileByNameRoute, dc.DeviceProfileByName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiDeviceProfileByNameRoute, dc.DeleteDeviceProfileByName).Methods(http.MethodDelete)
+	r.HandleFunc(metadataController.ApiDeviceProfileSamplesRoute, dc.DeviceProfileSamples).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiAllDeviceProfileRoute, dc.AllDeviceProfiles).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiDeviceProfileByModelRoute, dc.DeviceProfilesByModel).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiDeviceProfileByManufacturerRoute, dc.DeviceProfilesByManufacturer).Methods(http.MethodGet)
@@ -42,32 +82,81 @@ func LoadRestRoutes(r *mux.Router, dic *di.Container) {
 
 	// Device Service
 	ds := metadataController.NewDeviceServiceController(dic)
-	r.HandleFunc(v2Constant.ApiDeviceServiceRoute, ds.AddDeviceService).Methods(http.MethodPost)
-	r.HandleFunc(v2Constant.ApiDeviceServiceRoute, ds.PatchDeviceService).Methods(http.MethodPatch)
+	r.HandleFunc(v2Constant.ApiDeviceServiceRoute, schemas.ValidateRequest([]requests.AddDeviceServiceRequest{}, ds.AddDeviceService)).Methods(http.MethodPost)
+	r.HandleFunc(v2Constant.ApiDeviceServiceRoute, schemas.ValidateRequest([]requests.UpdateDeviceServiceRequest{}, ds.PatchDeviceService)).Methods(http.MethodPatch)
 	r.HandleFunc(v2Constant.ApiDeviceServiceByNameRoute, ds.DeviceServiceByName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiDeviceServiceByNameRoute, ds.DeleteDeviceServiceByName).Methods(http.MethodDelete)
 	r.HandleFunc(v2Constant.ApiAllDeviceServiceRoute, ds.AllDeviceServices).Methods(http.MethodGet)
 
 	// Device
 	d := metadataController.NewDeviceController(dic)
-	r.HandleFunc(v2Constant.ApiDeviceRoute, d.AddDevice).Methods(http.MethodPost)
+	r.HandleFunc(v2Constant.ApiDeviceRoute, schemas.ValidateRequest([]requests.AddDeviceRequest{}, d.AddDevice)).Methods(http.MethodPost)
 	r.HandleFunc(v2Constant.ApiDeviceByNameRoute, d.DeleteDeviceByName).Methods(http.MethodDelete)
 	r.HandleFunc(v2Constant.ApiDeviceByServiceNameRoute, d.DevicesByServiceName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiDeviceNameExistsRoute, d.DeviceNameExists).Methods(http.MethodGet)
-	r.HandleFunc(v2Constant.ApiDeviceRoute, d.PatchDevice).Methods(http.MethodPatch)
+	r.HandleFunc(v2Constant.ApiDeviceRoute, schemas.ValidateRequest([]requests.UpdateDeviceRequest{}, d.PatchDevice)).Methods(http.MethodPatch)
 	r.HandleFunc(v2Constant.ApiAllDeviceRoute, d.AllDevices).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiDeviceByNameRoute, d.DeviceByName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiDeviceByProfileNameRoute, d.DevicesByProfileName).Methods(http.MethodGet)
 
+	// Device Secrets
+	dsec := metadataController.NewDeviceSecretsController(dic)
+	r.HandleFunc(devicesecrets.ApiDeviceSecretRoute, schemas.ValidateRequest(devicesecrets.UpdateDeviceSecretsRequest{}, dsec.SetDeviceSecrets)).Methods(http.MethodPut)
+
+	// Claim Code
+	ccc := metadataController.NewClaimCodeController(dic)
+	r.HandleFunc(claimcodes.ApiDeviceClaimCodeRoute, ccc.IssueClaimCode).Methods(http.MethodPost)
+	r.HandleFunc(claimcodes.ApiDeviceClaimCodeRoute, ccc.RevokeClaimCode).Methods(http.MethodDelete)
+	r.HandleFunc(claimcodes.ApiClaimRoute, schemas.ValidateRequest(claimcodes.ClaimRequest{}, ccc.Claim)).Methods(http.MethodPost)
+
+	// Device Transfer
+	dtc := metadataController.NewDeviceTransferController(dic)
+	r.HandleFunc(devicetransfer.ApiDeviceTransferRoute, schemas.ValidateRequest(devicetransfer.TransferRequest{}, dtc.TransferDevice)).Methods(http.MethodPost)
+
 	// ProvisionWatcher
 	pwc := metadataController.NewProvisionWatcherController(dic)
-	r.HandleFunc(v2Constant.ApiProvisionWatcherRoute, pwc.AddProvisionWatcher).Methods(http.MethodPost)
+	r.HandleFunc(v2Constant.ApiProvisionWatcherRoute, schemas.ValidateRequest([]requests.AddProvisionWatcherRequest{}, pwc.AddProvisionWatcher)).Methods(http.MethodPost)
 	r.HandleFunc(v2Constant.ApiProvisionWatcherByNameRoute, pwc.ProvisionWatcherByName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiProvisionWatcherByServiceNameRoute, pwc.ProvisionWatchersByServiceName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiProvisionWatcherByProfileNameRoute, pwc.ProvisionWatchersByProfileName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiAllProvisionWatcherRoute, pwc.AllProvisionWatchers).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiProvisionWatcherByNameRoute, pwc.DeleteProvisionWatcherByName).Methods(http.MethodDelete)
-	r.HandleFunc(v2Constant.ApiProvisionWatcherRoute, pwc.PatchProvisionWatcher).Methods(http.MethodPatch)
+	r.HandleFunc(v2Constant.ApiProvisionWatcherRoute, schemas.ValidateRequest([]requests.UpdateProvisionWatcherRequest{}, pwc.PatchProvisionWatcher)).Methods(http.MethodPatch)
+
+	// Deprecation
+	dpc := metadataController.NewDeprecationController(dic)
+	r.HandleFunc(deprecations.ApiDeprecationRoute, schemas.ValidateRequest([]deprecations.AddDeprecationRequest{}, dpc.AddDeprecation)).Methods(http.MethodPost)
+	r.HandleFunc(deprecations.ApiAllDeprecationRoute, dpc.AllDeprecations).Methods(http.MethodGet)
+	r.HandleFunc(deprecations.ApiDeprecationByProfileNameRoute, dpc.DeprecationsByProfileName).Methods(http.MethodGet)
+	r.HandleFunc(deprecations.ApiDeprecationByProfileNameAndResourceNameRoute, dpc.DeleteDeprecationByProfileNameAndResourceName).Methods(http.MethodDelete)
+	r.HandleFunc(deprecations.ApiDeprecationUsageRoute, dpc.DeprecationUsages).Methods(http.MethodGet)
+
+	// Admin State Schedule
+	asc := metadataController.NewAdminScheduleController(dic)
+	r.HandleFunc(adminschedules.ApiAdminScheduleRoute, schemas.ValidateRequest([]adminschedules.AddAdminScheduleRequest{}, asc.AddAdminSchedule)).Methods(http.MethodPost)
+	r.HandleFunc(adminschedules.ApiAllAdminScheduleRoute, asc.AllAdminSchedules).Methods(http.MethodGet)
+	r.HandleFunc(adminschedules.ApiAdminScheduleByNameRoute, asc.AdminScheduleByName).Methods(http.MethodGet)
+	r.HandleFunc(adminschedules.ApiAdminScheduleByNameRoute, asc.DeleteAdminScheduleByName).Methods(http.MethodDelete)
+	r.HandleFunc(adminschedules.ApiAdminScheduleRunRoute, asc.RunAdminSchedule).Methods(http.MethodPut)
+
+	// Discovery
+	dsc := metadataController.NewDiscoveryController(dic)
+	r.HandleFunc(discovery.ApiDiscoveredDeviceRoute, schemas.ValidateRequest([]discovery.AddDiscoveredDeviceRequest{}, dsc.AddDiscoveredDevice)).Methods(http.MethodPost)
+	r.HandleFunc(discovery.ApiAllDiscoveryRecordRoute, dsc.AllDiscoveryRecords).Methods(http.MethodGet)
+	r.HandleFunc(discovery.ApiDiscoveryRecordByProvisionWatcherNameRoute, dsc.DiscoveryRecordsByProvisionWatcherName).Methods(http.MethodGet)
+
+	// Label
+	lbc := metadataController.NewLabelController(dic)
+	r.HandleFunc(labels.ApiAllLabelRoute, lbc.AllLabels).Methods(http.MethodGet)
+
+	// Callback
+	callbacks.LoadRestRoutes(r, v2MetadataContainer.CallbackOutboxFrom(dic.Get), metadataContainer.ConfigurationFrom(dic.Get).Service.MaxResultCount)
+
+	// GraphQL
+	if features.GraphQLCompiled && metadataContainer.ConfigurationFrom(dic.Get).GraphQL.Enabled {
+		gqc := metadataController.NewGraphQLController(dic)
+		r.HandleFunc(metadataController.ApiGraphQLRoute, gqc.Query).Methods(http.MethodGet, http.MethodPost)
+	}
 
 	r.Use(correlation.ManageHeader)
 	r.Use(correlation.OnResponseComplete)
@@ -0,0 +1,19 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package samples
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// SamplesResponse defines the response content of the sample events of a device profile
+type SamplesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	// Seed generates the same values when sent back as the seed query parameter
+	Seed   int64        `json:"seed"`
+	Events []dtos.Event `json:"events"`
+}
@@ -0,0 +1,273 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package samples generates representative events of a device profile, as a device service would report them, so
+// that the UIs and the pipelines can be prototyped and tested before the physical device exists. The values respect
+// the value types, the minimum and maximum of the device resources, the mappings of the device commands, which
+// enumerate the values they report, and the assertions. The same seed generates the same values.
+package samples
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+const (
+	// defaultSpan is the width of the range of the numeric values when the minimum or the maximum isn't defined
+	defaultSpan = 100
+	// arrayLength is the number of elements of the array values
+	arrayLength = 3
+	// binaryLength is the number of bytes of the binary values
+	binaryLength = 16
+	// defaultMediaType is the media type of the binary values of the device resources that don't define it
+	defaultMediaType = "application/octet-stream"
+	arraySuffix      = "Array"
+)
+
+// Generate returns count rounds of the events that a device named deviceName reports: one for each device command
+// of profile reading device resources, then one for each readable device resource, the rounds being a second apart,
+// the last one now. It fails when a device resource defines an invalid value type, minimum or maximum, or a device
+// command reads an undefined device resource.
+func Generate(profile dtos.DeviceProfile, deviceName string, count int, seed int64) ([]dtos.Event, error) {
+	resources := make(map[string]dtos.DeviceResource, len(profile.DeviceResources))
+	for _, r := range profile.DeviceResources {
+		resources[r.Name] = r
+	}
+	g := generator{random: rand.New(rand.NewSource(seed))}
+
+	var events []dtos.Event
+	now := time.Now()
+	for i := 0; i < count; i++ {
+		origin := now.Add(time.Duration(i-count+1) * time.Second).UnixNano()
+		for _, c := range profile.DeviceCommands {
+			if len(c.Get) == 0 {
+				continue
+			}
+			event := newEvent(profile.Name, deviceName, origin)
+			for _, operation := range c.Get {
+				r, ok := resources[operation.DeviceResource]
+				if !ok {
+					return nil, fmt.Errorf("device command %s reads the undefined device resource %s", c.Name,
+						operation.DeviceResource)
+				}
+				if err := g.addReading(&event, r, operation.Mappings); err != nil {
+					return nil, err
+				}
+			}
+			events = append(events, event)
+		}
+		for _, r := range profile.DeviceResources {
+			if !readable(r) {
+				continue
+			}
+			event := newEvent(profile.Name, deviceName, origin)
+			if err := g.addReading(&event, r, nil); err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func newEvent(profileName string, deviceName string, origin int64) dtos.Event {
+	event := dtos.NewEvent(profileName, deviceName)
+	event.Origin = origin
+	return event
+}
+
+// readable tells whether the device resource is read, i.e. isn't write-only
+func readable(r dtos.DeviceResource) bool {
+	return r.Properties.ReadWrite != "W"
+}
+
+// generator generates the values of the readings
+type generator struct {
+	random *rand.Rand
+}
+
+// addReading adds a reading of the device resource r to event. Its value is one of mappings when there are some, or
+// the assertion of r, in place of the generated value, as the device services map and check the values read.
+func (g generator) addReading(event *dtos.Event, r dtos.DeviceResource, mappings map[string]string) error {
+	valueType := r.Properties.ValueType
+	if valueType == v2.ValueTypeBinary {
+		mediaType := r.Properties.MediaType
+		if mediaType == "" {
+			mediaType = defaultMediaType
+		}
+		value := make([]byte, binaryLength)
+		g.random.Read(value)
+		event.AddBinaryReading(r.Name, value, mediaType)
+		event.Readings[len(event.Readings)-1].Origin = event.Origin
+		return nil
+	}
+
+	var value interface{}
+	var err error
+	if strings.HasSuffix(valueType, arraySuffix) {
+		value, err = g.array(r.Properties, strings.TrimSuffix(valueType, arraySuffix))
+	} else {
+		value, err = g.scalar(r.Properties, valueType)
+	}
+	if err != nil {
+		return fmt.Errorf("device resource %s: %s", r.Name, err.Error())
+	}
+	if err = event.AddSimpleReading(r.Name, valueType, value); err != nil {
+		return fmt.Errorf("device resource %s: %s", r.Name, err.Error())
+	}
+	reading := &event.Readings[len(event.Readings)-1]
+	reading.Origin = event.Origin
+	if len(mappings) > 0 {
+		values := make([]string, 0, len(mappings))
+		for _, v := range mappings {
+			values = append(values, v)
+		}
+		// sorted, so that the seed picks the same value
+		sort.Strings(values)
+		reading.Value = values[g.random.Intn(len(values))]
+	} else if r.Properties.Assertion != "" {
+		reading.Value = r.Properties.Assertion
+	}
+	return nil
+}
+
+// array returns an array value of the elementType, whose elements respect the minimum and the maximum of properties
+func (g generator) array(properties dtos.PropertyValue, elementType string) (interface{}, error) {
+	var values reflect.Value
+	for i := 0; i < arrayLength; i++ {
+		e, err := g.scalar(properties, elementType)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			values = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(e)), arrayLength, arrayLength)
+		}
+		values.Index(i).Set(reflect.ValueOf(e))
+	}
+	return values.Interface(), nil
+}
+
+// scalar returns a value of the valueType respecting the minimum and the maximum of properties
+func (g generator) scalar(properties dtos.PropertyValue, valueType string) (interface{}, error) {
+	switch valueType {
+	case v2.ValueTypeBool:
+		return g.random.Intn(2) == 1, nil
+	case v2.ValueTypeString:
+		return "sample-" + strconv.Itoa(g.random.Intn(1000)), nil
+	}
+
+	low, high, integer, ok := typeRange(valueType)
+	if !ok {
+		return nil, fmt.Errorf("invalid value type %s", valueType)
+	}
+	min, max, err := bounds(properties, low, high)
+	if err != nil {
+		return nil, err
+	}
+	var value float64
+	if integer {
+		min, max = math.Ceil(min), math.Floor(max)
+		if min > max {
+			return nil, fmt.Errorf("no %s between the minimum %s and the maximum %s", valueType,
+				properties.Minimum, properties.Maximum)
+		}
+		value = math.Min(math.Floor(min+g.random.Float64()*(max-min+1)), max)
+	} else {
+		// rounded to two decimals, as the sensors report them
+		value = math.Max(min, math.Min(max, math.Round((min+g.random.Float64()*(max-min))*100)/100))
+	}
+	return convert(value, valueType), nil
+}
+
+// typeRange returns the range of the numeric valueType, and whether it is an integer type. The ranges of the integer
+// types are bounded by the largest float64 below 2^size, as 2^64-1 rounds up to 2^64, whose conversion overflows.
+func typeRange(valueType string) (low float64, high float64, integer bool, ok bool) {
+	switch valueType {
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64:
+		size, _ := strconv.Atoi(strings.TrimPrefix(valueType, "Uint"))
+		return 0, math.Nextafter(math.Pow(2, float64(size)), 0), true, true
+	case v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64:
+		size, _ := strconv.Atoi(strings.TrimPrefix(valueType, "Int"))
+		limit := math.Pow(2, float64(size-1))
+		return -limit, math.Nextafter(limit, 0), true, true
+	case v2.ValueTypeFloat32:
+		return -math.MaxFloat32, math.MaxFloat32, false, true
+	case v2.ValueTypeFloat64:
+		return -math.MaxFloat64, math.MaxFloat64, false, true
+	}
+	return 0, 0, false, false
+}
+
+// bounds returns the range of the values of properties within low and high: from its minimum to its maximum, or
+// defaultSpan wide from the one defined, or from 0 when neither is
+func bounds(properties dtos.PropertyValue, low float64, high float64) (float64, float64, error) {
+	min, hasMin, err := parseBound(properties.Minimum, "minimum")
+	if err != nil {
+		return 0, 0, err
+	}
+	max, hasMax, err := parseBound(properties.Maximum, "maximum")
+	if err != nil {
+		return 0, 0, err
+	}
+	switch {
+	case !hasMin && !hasMax:
+		min, max = 0, defaultSpan
+	case !hasMax:
+		max = min + defaultSpan
+	case !hasMin:
+		min = max - defaultSpan
+	}
+	min, max = math.Max(min, low), math.Min(max, high)
+	if min > max {
+		return 0, 0, fmt.Errorf("the minimum %s is greater than the maximum %s", properties.Minimum, properties.Maximum)
+	}
+	return min, max, nil
+}
+
+func parseBound(value string, name string) (float64, bool, error) {
+	if value == "" {
+		return 0, false, nil
+	}
+	bound, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s %s", name, value)
+	}
+	return bound, true, nil
+}
+
+// convert returns value as the numeric valueType
+func convert(value float64, valueType string) interface{} {
+	switch valueType {
+	case v2.ValueTypeUint8:
+		return uint8(value)
+	case v2.ValueTypeUint16:
+		return uint16(value)
+	case v2.ValueTypeUint32:
+		return uint32(value)
+	case v2.ValueTypeUint64:
+		return uint64(value)
+	case v2.ValueTypeInt8:
+		return int8(value)
+	case v2.ValueTypeInt16:
+		return int16(value)
+	case v2.ValueTypeInt32:
+		return int32(value)
+	case v2.ValueTypeInt64:
+		return int64(value)
+	case v2.ValueTypeFloat32:
+		return float32(value)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,122 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package samples
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProfile() dtos.DeviceProfile {
+	return dtos.DeviceProfile{
+		Name: "Thermostat",
+		DeviceResources: []dtos.DeviceResource{
+			{Name: "Temperature", Properties: dtos.PropertyValue{ValueType: v2.ValueTypeInt16, ReadWrite: "R", Minimum: "-40", Maximum: "85"}},
+			{Name: "Mode", Properties: dtos.PropertyValue{ValueType: v2.ValueTypeUint8, ReadWrite: "RW", Maximum: "2"}},
+			{Name: "Setpoint", Properties: dtos.PropertyValue{ValueType: v2.ValueTypeFloat32, ReadWrite: "W"}},
+			{Name: "Status", Properties: dtos.PropertyValue{ValueType: v2.ValueTypeString, ReadWrite: "R", Assertion: "OK"}},
+			{Name: "History", Properties: dtos.PropertyValue{ValueType: v2.ValueTypeUint64Array, ReadWrite: "R", Minimum: "10", Maximum: "20"}},
+			{Name: "Snapshot", Properties: dtos.PropertyValue{ValueType: v2.ValueTypeBinary, ReadWrite: "R", MediaType: "image/jpeg"}},
+		},
+		DeviceCommands: []dtos.DeviceCommand{
+			{Name: "State", Get: []dtos.ResourceOperation{
+				{DeviceResource: "Temperature"},
+				{DeviceResource: "Mode", Mappings: map[string]string{"0": "Off", "1": "Heat", "2": "Cool"}},
+			}},
+			{Name: "SetState", Set: []dtos.ResourceOperation{{DeviceResource: "Setpoint"}}},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	events, err := Generate(testProfile(), "thermostat-1", 2, 42)
+	require.NoError(t, err)
+	// the State command and the readable device resources, but Setpoint, twice
+	require.Len(t, events, 12)
+	assert.Less(t, events[0].Origin, events[6].Origin, "the rounds are ordered")
+
+	for _, e := range events {
+		require.NoError(t, requests.NewAddEventRequest(e).Validate(), "the events are valid")
+		assert.Equal(t, "Thermostat", e.ProfileName)
+		assert.Equal(t, "thermostat-1", e.DeviceName)
+		for _, r := range e.Readings {
+			assert.Equal(t, e.Origin, r.Origin)
+			switch r.ResourceName {
+			case "Temperature":
+				value, err := strconv.Atoi(r.Value)
+				require.NoError(t, err)
+				assert.True(t, value >= -40 && value <= 85, r.Value)
+			case "Mode":
+				if len(e.Readings) > 1 {
+					assert.Contains(t, []string{"Off", "Heat", "Cool"}, r.Value, "the command maps the values")
+				} else {
+					assert.Contains(t, []string{"0", "1", "2"}, r.Value)
+				}
+			case "Status":
+				assert.Equal(t, "OK", r.Value, "the value is the assertion")
+			case "History":
+				assert.Regexp(t, `^\[(1\d|20), (1\d|20), (1\d|20)\]$`, r.Value)
+			case "Snapshot":
+				assert.Equal(t, "image/jpeg", r.MediaType)
+				assert.Len(t, r.BinaryValue, binaryLength)
+			default:
+				assert.Fail(t, "unexpected reading", r.ResourceName)
+			}
+		}
+	}
+	assert.Len(t, events[0].Readings, 2, "the command reads two device resources")
+
+	again, err := Generate(testProfile(), "thermostat-1", 2, 42)
+	require.NoError(t, err)
+	for i := range events {
+		for j := range events[i].Readings {
+			assert.Equal(t, events[i].Readings[j].Value, again[i].Readings[j].Value, "the seed generates the same values")
+		}
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties dtos.PropertyValue
+	}{
+		{"invalid value type", dtos.PropertyValue{ValueType: "Int128"}},
+		{"invalid minimum", dtos.PropertyValue{ValueType: v2.ValueTypeInt8, Minimum: "low"}},
+		{"minimum greater than the maximum", dtos.PropertyValue{ValueType: v2.ValueTypeFloat64, Minimum: "10", Maximum: "1"}},
+		{"no integer in range", dtos.PropertyValue{ValueType: v2.ValueTypeInt32, Minimum: "0.2", Maximum: "0.8"}},
+		{"out of the type range", dtos.PropertyValue{ValueType: v2.ValueTypeUint8, Minimum: "300"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := dtos.DeviceProfile{Name: "test", DeviceResources: []dtos.DeviceResource{{Name: "r", Properties: tt.properties}}}
+			_, err := Generate(profile, "test", 1, 1)
+			assert.Error(t, err)
+		})
+	}
+
+	profile := testProfile()
+	profile.DeviceCommands[0].Get[0].DeviceResource = "undefined"
+	_, err := Generate(profile, "test", 1, 1)
+	assert.Error(t, err, "the command reads an undefined device resource")
+}
+
+func TestTypeRanges(t *testing.T) {
+	for _, valueType := range []string{v2.ValueTypeUint64, v2.ValueTypeInt64} {
+		low, high, _, _ := typeRange(valueType)
+		profile := dtos.PropertyValue{Minimum: strconv.FormatFloat(low, 'f', -1, 64), Maximum: strconv.FormatFloat(high, 'f', -1, 64)}
+		value, err := generator{random: rand.New(rand.NewSource(1))}.scalar(profile, valueType)
+		require.NoError(t, err, valueType)
+		assert.NotNil(t, value)
+	}
+}
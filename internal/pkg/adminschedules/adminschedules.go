@@ -0,0 +1,249 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package adminschedules defines the admin state changes scheduled by core-metadata for a device, or for the group of
+// devices having given labels, e.g. locking the devices every night at 22:00 and unlocking them at 06:00. Each
+// schedule is registered in support-scheduler as an interval and its interval action, which asks core-metadata to
+// run the schedule when it is due.
+package adminschedules
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+const (
+	// ApiAdminScheduleRoute accepts the admin state schedules to add
+	ApiAdminScheduleRoute = v2.ApiBase + "/adminschedule"
+	// ApiAllAdminScheduleRoute returns the admin state schedules, the latest created first
+	ApiAllAdminScheduleRoute = ApiAdminScheduleRoute + "/" + v2.All
+	// ApiAdminScheduleByNameRoute returns or deletes an admin state schedule
+	ApiAdminScheduleByNameRoute = ApiAdminScheduleRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+	// ApiAdminScheduleRunRoute changes the admin state of the devices of a schedule, called by support-scheduler when
+	// the schedule is due
+	ApiAdminScheduleRunRoute = ApiAdminScheduleByNameRoute + "/run"
+
+	// TimeLayout is the layout of the Start and End of the schedules, in UTC, that of the support-scheduler intervals
+	TimeLayout = "20060102T150405"
+	// IntervalPrefix prefixes the names of the support-scheduler intervals and interval actions of the schedules
+	IntervalPrefix = "adminschedule-"
+)
+
+// AdminSchedule changes the admin state of the device DeviceName, or of the group of the devices having all the
+// Labels, to AdminState at Start, and then every Frequency until End
+type AdminSchedule struct {
+	Id          string   `json:"id,omitempty"`
+	Created     int64    `json:"created,omitempty"`
+	Modified    int64    `json:"modified,omitempty"`
+	Name        string   `json:"name" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Description string   `json:"description,omitempty"`
+	DeviceName  string   `json:"deviceName,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	AdminState  string   `json:"adminState" validate:"oneof='LOCKED' 'UNLOCKED'"`
+	// Start is the time of the first change, in the TimeLayout
+	Start string `json:"start" validate:"required"`
+	// Frequency is the time between the changes, e.g. "24h", in whole seconds; the admin state is changed once when
+	// empty
+	Frequency string `json:"frequency,omitempty"`
+	// End is the time after which the admin state isn't changed anymore, in the TimeLayout, never when empty
+	End string `json:"end,omitempty"`
+}
+
+// Validate checks that the schedule changes the admin state of either a device or a group of devices, at least once
+// after the timestamp now, in milliseconds
+func (s AdminSchedule) Validate(now int64) errors.EdgeX {
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "admin state schedule name is empty", nil)
+	}
+	hasDevice := strings.TrimSpace(s.DeviceName) != ""
+	hasLabels := false
+	for _, label := range s.Labels {
+		if strings.TrimSpace(label) == "" {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, "admin state schedule labels must not be empty", nil)
+		}
+		hasLabels = true
+	}
+	if hasDevice == hasLabels {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("admin state schedule %s must have either a deviceName or labels", s.Name), nil)
+	}
+	if s.AdminState != models.Locked && s.AdminState != models.Unlocked {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("admin state schedule %s adminState '%s' is neither %s nor %s", s.Name, s.AdminState, models.Locked, models.Unlocked), nil)
+	}
+	w, err := s.window()
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if w.end < w.start {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("admin state schedule %s ends before it starts", s.Name), nil)
+	}
+	if w.last() < now/1000 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("admin state schedule %s would only change the admin state in the past", s.Name), nil)
+	}
+	return nil
+}
+
+// IntervalName returns the name of the support-scheduler interval and interval action of the schedule
+func (s AdminSchedule) IntervalName() string {
+	return IntervalPrefix + s.Name
+}
+
+// window is the times a schedule changes the admin state at, in seconds since the epoch: from start, every every
+// seconds until end, or once when every is 0
+type window struct {
+	start int64
+	every int64
+	end   int64
+}
+
+func (s AdminSchedule) window() (window, errors.EdgeX) {
+	start, err := time.Parse(TimeLayout, s.Start)
+	if err != nil {
+		return window{}, errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("admin state schedule %s start '%s' doesn't match %s", s.Name, s.Start, TimeLayout), nil)
+	}
+	w := window{start: start.Unix(), end: math.MaxInt64}
+	if s.Frequency != "" {
+		every, err := time.ParseDuration(s.Frequency)
+		if err != nil || every < time.Second || every%time.Second != 0 {
+			return window{}, errors.NewCommonEdgeX(errors.KindContractInvalid,
+				fmt.Sprintf("admin state schedule %s frequency '%s' is not a whole number of seconds", s.Name, s.Frequency), nil)
+		}
+		w.every = int64(every / time.Second)
+	}
+	if s.End != "" {
+		end, err := time.Parse(TimeLayout, s.End)
+		if err != nil {
+			return window{}, errors.NewCommonEdgeX(errors.KindContractInvalid,
+				fmt.Sprintf("admin state schedule %s end '%s' doesn't match %s", s.Name, s.End, TimeLayout), nil)
+		}
+		w.end = end.Unix()
+	}
+	return w, nil
+}
+
+// last returns the time of the last change of w
+func (w window) last() int64 {
+	switch {
+	case w.every == 0:
+		return w.start
+	case w.end == math.MaxInt64:
+		return math.MaxInt64
+	default:
+		return w.end - (w.end-w.start)%w.every
+	}
+}
+
+// includes tells whether w changes the admin state at t
+func (w window) includes(t int64) bool {
+	if t < w.start || t > w.end {
+		return false
+	}
+	if w.every == 0 {
+		return t == w.start
+	}
+	return (t-w.start)%w.every == 0
+}
+
+// firstCommon returns the first time both a and b change the admin state at, if any
+func firstCommon(a window, b window) (int64, bool) {
+	if a.every == 0 {
+		return a.start, b.includes(a.start)
+	}
+	if b.every == 0 {
+		return b.start, a.includes(b.start)
+	}
+
+	// the times a.start + i*a.every = b.start + j*b.every, solved for i modulo b.every/g with the Chinese remainder
+	// theorem, repeat every lcm(a.every, b.every) seconds; the products are computed in big integers not to overflow
+	g := gcd(a.every, b.every)
+	diff := b.start - a.start
+	if diff%g != 0 {
+		return 0, false
+	}
+	m := big.NewInt(b.every / g)
+	i := big.NewInt(0)
+	if m.Int64() > 1 {
+		inverse := new(big.Int).ModInverse(big.NewInt(a.every/g), m)
+		i.Mul(big.NewInt(diff/g), inverse)
+		i.Mod(i, m)
+	}
+	t := new(big.Int).Mul(i, big.NewInt(a.every))
+	t.Add(t, big.NewInt(a.start))
+	lcm := new(big.Int).Mul(big.NewInt(a.every/g), big.NewInt(b.every))
+	if lower := big.NewInt(b.start); t.Cmp(lower) < 0 {
+		// the first common time from b.start, a.start being already reached
+		periods := new(big.Int).Sub(lower, t)
+		periods.Add(periods, new(big.Int).Sub(lcm, big.NewInt(1)))
+		periods.Div(periods, lcm)
+		t.Add(t, periods.Mul(periods, lcm))
+	}
+	if !t.IsInt64() || t.Int64() > a.end || t.Int64() > b.end {
+		return 0, false
+	}
+	return t.Int64(), true
+}
+
+func gcd(a int64, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Collision returns the first time a and b both change the admin state at, in seconds since the epoch, if they ever
+// do. The schedules are expected to be valid.
+func Collision(a AdminSchedule, b AdminSchedule) (int64, bool) {
+	wa, err := a.window()
+	if err != nil {
+		return 0, false
+	}
+	wb, err := b.window()
+	if err != nil {
+		return 0, false
+	}
+	return firstCommon(wa, wb)
+}
+
+// SameTarget tells whether a and b always change the admin state of some same devices, whatever the labels of the
+// devices: they name the same device, or the labels of a group include those of the other
+func SameTarget(a AdminSchedule, b AdminSchedule) bool {
+	if a.DeviceName != "" || b.DeviceName != "" {
+		return a.DeviceName == b.DeviceName
+	}
+	return includesLabels(a.Labels, b.Labels) || includesLabels(b.Labels, a.Labels)
+}
+
+// includesLabels tells whether all of the labels are among labels
+func includesLabels(labels []string, of []string) bool {
+	for _, label := range of {
+		found := false
+		for _, l := range labels {
+			if l == label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// HasLabels tells whether a device with deviceLabels belongs to the group of the schedule
+func (s AdminSchedule) HasLabels(deviceLabels []string) bool {
+	return len(s.Labels) > 0 && includesLabels(deviceLabels, s.Labels)
+}
@@ -0,0 +1,160 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package adminschedules
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// now is 2021-06-01T00:00:00Z, in milliseconds
+const now = int64(1622505600000)
+
+func daily(name string, adminState string, start string) AdminSchedule {
+	return AdminSchedule{Name: name, DeviceName: "device", AdminState: adminState, Start: start, Frequency: "24h"}
+}
+
+func TestValidate(t *testing.T) {
+	valid := daily("lock", models.Locked, "20210601T220000")
+	group := valid
+	group.DeviceName = ""
+	group.Labels = []string{"floor1"}
+	both := valid
+	both.Labels = []string{"floor1"}
+	neither := valid
+	neither.DeviceName = ""
+	emptyLabel := group
+	emptyLabel.Labels = []string{""}
+	invalidState := valid
+	invalidState.AdminState = "DISABLED"
+	invalidStart := valid
+	invalidStart.Start = "2021-06-01 22:00"
+	invalidFrequency := valid
+	invalidFrequency.Frequency = "1500ms"
+	endBeforeStart := valid
+	endBeforeStart.End = "20210501T000000"
+	past := valid
+	past.Frequency = ""
+	past.Start = "20210501T000000"
+	ended := valid
+	ended.Start = "20210501T000000"
+	ended.End = "20210520T000000"
+	recurringFromThePast := valid
+	recurringFromThePast.Start = "20210501T220000"
+
+	tests := []struct {
+		name     string
+		schedule AdminSchedule
+		valid    bool
+	}{
+		{"valid", valid, true},
+		{"valid group", group, true},
+		{"valid recurring from the past", recurringFromThePast, true},
+		{"device and labels", both, false},
+		{"neither device nor labels", neither, false},
+		{"empty label", emptyLabel, false},
+		{"invalid admin state", invalidState, false},
+		{"invalid start", invalidStart, false},
+		{"invalid frequency", invalidFrequency, false},
+		{"end before start", endBeforeStart, false},
+		{"once in the past", past, false},
+		{"ended", ended, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate(now)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCollision(t *testing.T) {
+	lock := daily("lock", models.Locked, "20210601T220000")
+	unlock := daily("unlock", models.Unlocked, "20210602T060000")
+	sameTime := daily("sameTime", models.Unlocked, "20210610T220000")
+	once := AdminSchedule{Name: "once", AdminState: models.Unlocked, Start: "20210605T220000"}
+	onceOtherTime := AdminSchedule{Name: "onceOtherTime", AdminState: models.Unlocked, Start: "20210605T221500"}
+	onceBeforeStart := AdminSchedule{Name: "onceBeforeStart", AdminState: models.Unlocked, Start: "20210531T220000"}
+	ended := lock
+	ended.End = "20210603T000000"
+	every8h := AdminSchedule{Name: "every8h", AdminState: models.Unlocked, Start: "20210601T060000", Frequency: "8h"}
+	every7h := AdminSchedule{Name: "every7h", AdminState: models.Unlocked, Start: "20210601T000000", Frequency: "7h"}
+
+	tests := []struct {
+		name     string
+		a        AdminSchedule
+		b        AdminSchedule
+		collides bool
+		at       string
+	}{
+		{"lock at 22:00 and unlock at 06:00", lock, unlock, false, ""},
+		{"both at 22:00", lock, sameTime, true, "20210610T220000"},
+		{"both at 22:00, the other first", sameTime, lock, true, "20210610T220000"},
+		{"once at 22:00", lock, once, true, "20210605T220000"},
+		{"once at 22:15", lock, onceOtherTime, false, ""},
+		{"once before the start", lock, onceBeforeStart, false, ""},
+		{"once after the end", ended, once, false, ""},
+		{"every 8 hours from 06:00", lock, every8h, true, "20210601T220000"},
+		{"every 7 hours from 00:00", lock, every7h, true, "20210603T220000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, collides := Collision(tt.a, tt.b)
+			require.Equal(t, tt.collides, collides)
+			if tt.collides {
+				assert.Equal(t, tt.at, time.Unix(at, 0).UTC().Format(TimeLayout))
+			}
+		})
+	}
+}
+
+func TestSameTarget(t *testing.T) {
+	device := AdminSchedule{DeviceName: "device"}
+	otherDevice := AdminSchedule{DeviceName: "other"}
+	floor1 := AdminSchedule{Labels: []string{"floor1"}}
+	floor1Hvac := AdminSchedule{Labels: []string{"floor1", "hvac"}}
+	hvac := AdminSchedule{Labels: []string{"hvac"}}
+	floor2 := AdminSchedule{Labels: []string{"floor2"}}
+
+	assert.True(t, SameTarget(device, device))
+	assert.False(t, SameTarget(device, otherDevice))
+	assert.False(t, SameTarget(device, floor1))
+	assert.True(t, SameTarget(floor1, floor1Hvac))
+	assert.True(t, SameTarget(floor1Hvac, hvac))
+	assert.False(t, SameTarget(floor1, floor2))
+	assert.False(t, SameTarget(floor1, hvac), "the groups may overlap depending on the labels of the devices")
+
+	assert.True(t, floor1Hvac.HasLabels([]string{"hvac", "floor1", "sensor"}))
+	assert.False(t, floor1Hvac.HasLabels([]string{"floor1"}))
+	assert.False(t, device.HasLabels([]string{"floor1"}))
+}
+
+func TestIntervalAction(t *testing.T) {
+	s := daily("lock night", models.Locked, "20210601T220000")
+
+	interval := Interval(s)
+	assert.Equal(t, "adminschedule-lock night", interval.Name)
+	assert.False(t, interval.RunOnce)
+	s.Frequency = ""
+	assert.True(t, Interval(s).RunOnce)
+
+	action := IntervalAction(s, Callback{Host: "edgex-core-metadata", Port: 48081})
+	assert.Equal(t, interval.Name, action.Interval)
+	assert.Equal(t, clients.CoreMetaDataServiceKey, action.Target)
+	assert.Equal(t, "http", action.Protocol)
+	assert.Equal(t, http.MethodPut, action.HTTPMethod)
+	assert.Equal(t, "/api/v2/adminschedule/name/lock%20night/run", action.Path)
+}
@@ -0,0 +1,35 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package adminschedules
+
+import (
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// AddAdminScheduleRequest defines the request content of an admin state schedule added through ApiAdminScheduleRoute
+type AddAdminScheduleRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	AdminSchedule         AdminSchedule `json:"adminSchedule" validate:"required"`
+}
+
+// AdminScheduleResponse defines the response content of ApiAdminScheduleByNameRoute
+type AdminScheduleResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	AdminSchedule          AdminSchedule `json:"adminSchedule"`
+}
+
+// MultiAdminSchedulesResponse defines the response content of ApiAllAdminScheduleRoute
+type MultiAdminSchedulesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	AdminSchedules         []AdminSchedule `json:"adminSchedules"`
+}
+
+// RunResponse defines the response content of ApiAdminScheduleRunRoute, naming the devices whose admin state was
+// changed
+type RunResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	ChangedDevices         []string `json:"changedDevices"`
+}
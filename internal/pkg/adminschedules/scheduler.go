@@ -0,0 +1,126 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package adminschedules
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/scheduler"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/types"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/urlclient/local"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+// Scheduler registers the schedules in support-scheduler
+type Scheduler interface {
+	// Register adds the interval of s and the interval action running s when it is due
+	Register(ctx context.Context, s AdminSchedule) errors.EdgeX
+	// Unregister deletes the interval action and interval of s, those already deleted being ignored
+	Unregister(ctx context.Context, s AdminSchedule) errors.EdgeX
+}
+
+// Callback locates the core-metadata service requested by the interval actions of the schedules
+type Callback struct {
+	Protocol string
+	Host     string
+	Port     int
+}
+
+type schedulerClient struct {
+	intervals scheduler.IntervalClient
+	actions   scheduler.IntervalActionClient
+	callback  Callback
+}
+
+// NewScheduler returns a Scheduler registering the schedules in the support-scheduler service at schedulerUrl, their
+// interval actions requesting callback
+func NewScheduler(schedulerUrl string, callback Callback) Scheduler {
+	schedulerUrl = strings.TrimSuffix(schedulerUrl, "/")
+	return &schedulerClient{
+		intervals: scheduler.NewIntervalClient(local.New(schedulerUrl + clients.ApiIntervalRoute)),
+		actions:   scheduler.NewIntervalActionClient(local.New(schedulerUrl + clients.ApiIntervalActionRoute)),
+		callback:  callback,
+	}
+}
+
+// Interval returns the support-scheduler interval of s
+func Interval(s AdminSchedule) models.Interval {
+	return models.Interval{
+		Name:      s.IntervalName(),
+		Start:     s.Start,
+		End:       s.End,
+		Frequency: s.Frequency,
+		RunOnce:   s.Frequency == "",
+	}
+}
+
+// IntervalAction returns the support-scheduler interval action of s, requesting its ApiAdminScheduleRunRoute from
+// callback
+func IntervalAction(s AdminSchedule, callback Callback) models.IntervalAction {
+	protocol := callback.Protocol
+	if protocol == "" {
+		protocol = "http"
+	}
+	return models.IntervalAction{
+		Name:       s.IntervalName(),
+		Interval:   s.IntervalName(),
+		Target:     clients.CoreMetaDataServiceKey,
+		Protocol:   protocol,
+		HTTPMethod: http.MethodPut,
+		Address:    callback.Host,
+		Port:       callback.Port,
+		Path:       strings.Replace(ApiAdminScheduleRunRoute, "{"+v2.Name+"}", url.PathEscape(s.Name), 1),
+	}
+}
+
+func (c *schedulerClient) Register(ctx context.Context, s AdminSchedule) errors.EdgeX {
+	interval := Interval(s)
+	if _, err := c.intervals.Add(ctx, &interval); err != nil {
+		return schedulerError(fmt.Sprintf("failed to add the interval %s", interval.Name), err)
+	}
+	action := IntervalAction(s, c.callback)
+	if _, err := c.actions.Add(ctx, &action); err != nil {
+		// the interval is removed not to be left over without its action
+		_ = c.intervals.DeleteByName(ctx, interval.Name)
+		return schedulerError(fmt.Sprintf("failed to add the interval action %s", action.Name), err)
+	}
+	return nil
+}
+
+func (c *schedulerClient) Unregister(ctx context.Context, s AdminSchedule) errors.EdgeX {
+	if err := c.actions.DeleteByName(ctx, s.IntervalName()); err != nil && !notFound(err) {
+		return schedulerError(fmt.Sprintf("failed to delete the interval action %s", s.IntervalName()), err)
+	}
+	if err := c.intervals.DeleteByName(ctx, s.IntervalName()); err != nil && !notFound(err) {
+		return schedulerError(fmt.Sprintf("failed to delete the interval %s", s.IntervalName()), err)
+	}
+	return nil
+}
+
+// schedulerError converts an error of the support-scheduler clients, the service being unavailable when it didn't
+// respond
+func schedulerError(message string, err error) errors.EdgeX {
+	if serviceErr, ok := err.(types.ErrServiceClient); ok {
+		kind := errors.KindServerError
+		if serviceErr.StatusCode == http.StatusConflict || serviceErr.StatusCode == http.StatusBadRequest {
+			kind = errors.KindStatusConflict
+		}
+		return errors.NewCommonEdgeX(kind, message, err)
+	}
+	return errors.NewCommonEdgeX(errors.KindServiceUnavailable, message, err)
+}
+
+func notFound(err error) bool {
+	serviceErr, ok := err.(types.ErrServiceClient)
+	return ok && serviceErr.StatusCode == http.StatusNotFound
+}
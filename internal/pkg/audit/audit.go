@@ -0,0 +1,66 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package audit records the administrative operations performed through the services' REST APIs, such as
+// creating a device or issuing a command, as system events stored by core-data.
+package audit
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+const (
+	// ApiSystemEventRoute accepts a JSON array of system events to store
+	ApiSystemEventRoute = v2.ApiBase + "/systemevent"
+	// ApiSystemEventByTimeRangeRoute returns the system events recorded in a time range, optionally filtered
+	// by the Actor query parameter
+	ApiSystemEventByTimeRangeRoute = ApiSystemEventRoute + "/" + v2.Start + "/{" + v2.Start + "}/" + v2.End + "/{" + v2.End + "}"
+
+	// Actor is the query parameter filtering system events by actor
+	Actor = "actor"
+)
+
+// Actions recorded by system events
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionCommand = "command"
+)
+
+// AnonymousActor is recorded when a request carries neither a verified JWT nor a gateway consumer
+const AnonymousActor = "anonymous"
+
+// AuditInfo configures the recording of system events
+type AuditInfo struct {
+	// Enabled turns on the recording of the service's administrative operations
+	Enabled bool
+	// BufferSize is the number of system events queued for core-data; events are dropped while it is full
+	BufferSize int
+}
+
+// SystemEvent records who performed an administrative operation, and on which object
+type SystemEvent struct {
+	Id            string `json:"id,omitempty"`
+	Timestamp     int64  `json:"timestamp"`
+	Service       string `json:"service"`
+	Actor         string `json:"actor"`
+	Action        string `json:"action"`
+	ObjectType    string `json:"objectType,omitempty"`
+	ObjectName    string `json:"objectName,omitempty"`
+	Method        string `json:"method"`
+	Path          string `json:"path"`
+	StatusCode    int    `json:"statusCode"`
+	CorrelationId string `json:"correlationId,omitempty"`
+}
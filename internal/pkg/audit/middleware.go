@@ -0,0 +1,230 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	// consumerHeader names the consumer authenticated by the API gateway
+	consumerHeader = "X-Consumer-Username"
+)
+
+// commandVars are the route variables naming the command of the core-command routes
+var commandVars = []string{"commandname", "commandid", "command"}
+
+// Classifier returns the action performed by a request, or an empty string if the request is not recorded
+type Classifier func(r *http.Request) string
+
+// ClassifyWrite records the requests creating, updating or deleting objects
+func ClassifyWrite(r *http.Request) string {
+	switch r.Method {
+	case http.MethodPost:
+		return ActionCreate
+	case http.MethodPut, http.MethodPatch:
+		return ActionUpdate
+	case http.MethodDelete:
+		return ActionDelete
+	default:
+		return ""
+	}
+}
+
+// ClassifyCommand records the requests issuing device commands, whatever their method, in addition to the
+// requests recorded by ClassifyWrite
+func ClassifyCommand(r *http.Request) string {
+	if commandName(r) != "" {
+		return ActionCommand
+	}
+	return ClassifyWrite(r)
+}
+
+// statusWriter remembers the status code written by the handler
+type statusWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// NewMiddleware returns a middleware that records a system event for each successful request classify
+// returns an action for. It must be used after the JWT middleware so that the token's actor is known.
+func NewMiddleware(service string, recorder *Recorder, classify Classifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			action := classify(r)
+			if action == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var names []string
+			if action == ActionCreate && r.Body != nil && r.Body != http.NoBody {
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				r.Body = ioutil.NopCloser(bytes.NewReader(body))
+				names = requestObjectNames(body)
+			}
+
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+			if sw.statusCode == 0 || sw.statusCode >= http.StatusBadRequest {
+				return
+			}
+
+			objectName := objectNameFromRoute(r)
+			if objectName == "" {
+				objectName = strings.Join(names, ",")
+			}
+			recorder.Record(SystemEvent{
+				Timestamp:     time.Now().UnixNano() / int64(time.Millisecond),
+				Service:       service,
+				Actor:         actor(r),
+				Action:        action,
+				ObjectType:    objectType(r.URL.Path),
+				ObjectName:    objectName,
+				Method:        r.Method,
+				Path:          r.URL.Path,
+				StatusCode:    sw.statusCode,
+				CorrelationId: correlation.FromContext(r.Context()),
+			}, r.Header.Get(authorizationHeader))
+		})
+	}
+}
+
+// actor returns the subject of the verified JWT, falling back to the consumer authenticated by the gateway
+func actor(r *http.Request) string {
+	if claims := jwtauth.ClaimsFromContext(r.Context()); claims != nil && claims.Actor() != "" {
+		return claims.Actor()
+	}
+	if consumer := r.Header.Get(consumerHeader); consumer != "" {
+		return consumer
+	}
+	return AnonymousActor
+}
+
+// objectType returns the path segment following the API version, e.g. "device" for /api/v2/device/name/d1
+func objectType(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "api" {
+		return ""
+	}
+	return segments[2]
+}
+
+// objectNameFromRoute returns the name or id given in the route, followed by the command name for the
+// core-command routes
+func objectNameFromRoute(r *http.Request) string {
+	vars := mux.Vars(r)
+	name := vars[v2.Name]
+	if name == "" {
+		name = vars[v2.Id]
+	}
+	if command := commandName(r); command != "" {
+		name += "/" + command
+	}
+	return name
+}
+
+func commandName(r *http.Request) string {
+	vars := mux.Vars(r)
+	for _, key := range commandVars {
+		if vars[key] != "" {
+			return vars[key]
+		}
+	}
+	return ""
+}
+
+// requestObjectNames returns the names of the objects created by a V1 request, e.g. {"name": "d1"}, or by a
+// V2 request, e.g. [{"device": {"name": "d1"}}]
+func requestObjectNames(body []byte) []string {
+	var request interface{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil
+	}
+
+	var names []string
+	switch request := request.(type) {
+	case map[string]interface{}:
+		if name, ok := request[v2.Name].(string); ok {
+			names = append(names, name)
+		}
+	case []interface{}:
+		for _, element := range request {
+			object, ok := element.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, value := range object {
+				if nested, ok := value.(map[string]interface{}); ok {
+					if name, ok := nested[v2.Name].(string); ok {
+						names = append(names, name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// UseMiddleware adds the system event middleware to router and starts sending the recorded events to
+// core-data when info.Enabled is set. It returns the Recorder of the middleware, for the operations the service
+// performs on its own, or nil when the system events aren't recorded.
+func UseMiddleware(ctx context.Context, wg *sync.WaitGroup, router *mux.Router, dic *di.Container, info AuditInfo,
+	service string, coreDataURL string, classify Classifier) *Recorder {
+	if !info.Enabled {
+		return nil
+	}
+
+	lc := container.LoggingClientFrom(dic.Get)
+	recorder := NewRecorder(lc, &http.Client{Timeout: 10 * time.Second}, coreDataURL, info.BufferSize)
+	recorder.Run(ctx, wg)
+	router.Use(NewMiddleware(service, recorder, classify))
+	lc.Info(fmt.Sprintf("recording system events to %s", coreDataURL))
+	return recorder
+}
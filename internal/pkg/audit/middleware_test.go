@@ -0,0 +1,139 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package audit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testService = "edgex-core-metadata"
+
+type capturingCaller struct {
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (c *capturingCaller) Do(req *http.Request) (*http.Response, error) {
+	body, _ := ioutil.ReadAll(req.Body)
+	c.requests = append(c.requests, req)
+	c.bodies = append(c.bodies, body)
+	return &http.Response{StatusCode: http.StatusCreated, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func newTestRouter(recorder *Recorder, classify Classifier, status int) *mux.Router {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v2/device", handler).Methods(http.MethodPost)
+	router.HandleFunc("/api/v2/device/name/{name}", handler).Methods(http.MethodGet, http.MethodDelete)
+	router.HandleFunc("/api/v1/device/name/{name}/command/{commandname}", handler).Methods(http.MethodGet)
+	router.Use(NewMiddleware(testService, recorder, classify))
+	return router
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name         string
+		classify     Classifier
+		method       string
+		path         string
+		body         string
+		status       int
+		expectAction string
+		expectType   string
+		expectName   string
+	}{
+		{"create", ClassifyWrite, http.MethodPost, "/api/v2/device", `[{"apiVersion": "v2", "device": {"name": "d1"}}, {"device": {"name": "d2"}}]`, http.StatusMultiStatus, ActionCreate, "device", "d1,d2"},
+		{"delete", ClassifyWrite, http.MethodDelete, "/api/v2/device/name/d1", "", http.StatusOK, ActionDelete, "device", "d1"},
+		{"command", ClassifyCommand, http.MethodGet, "/api/v1/device/name/d1/command/reset", "", http.StatusOK, ActionCommand, "device", "d1/reset"},
+		{"read not recorded", ClassifyWrite, http.MethodGet, "/api/v2/device/name/d1", "", http.StatusOK, "", "", ""},
+		{"command read not recorded by ClassifyWrite", ClassifyWrite, http.MethodGet, "/api/v1/device/name/d1/command/reset", "", http.StatusOK, "", "", ""},
+		{"failure not recorded", ClassifyWrite, http.MethodDelete, "/api/v2/device/name/d1", "", http.StatusNotFound, "", "", ""},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := NewRecorder(logger.MockLogger{}, &capturingCaller{}, "http://localhost:48080", 1)
+			router := newTestRouter(recorder, testCase.classify, testCase.status)
+
+			req := httptest.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.body))
+			req.Header.Set(consumerHeader, "admin")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if testCase.expectAction == "" {
+				assert.Empty(t, recorder.queue)
+				return
+			}
+			require.Len(t, recorder.queue, 1)
+			event := (<-recorder.queue).event
+			assert.Equal(t, testService, event.Service)
+			assert.Equal(t, "admin", event.Actor)
+			assert.Equal(t, testCase.expectAction, event.Action)
+			assert.Equal(t, testCase.expectType, event.ObjectType)
+			assert.Equal(t, testCase.expectName, event.ObjectName)
+			assert.Equal(t, testCase.status, event.StatusCode)
+			assert.NotZero(t, event.Timestamp)
+		})
+	}
+}
+
+func TestMiddlewareAnonymous(t *testing.T) {
+	recorder := NewRecorder(logger.MockLogger{}, &capturingCaller{}, "http://localhost:48080", 1)
+	router := newTestRouter(recorder, ClassifyWrite, http.StatusOK)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/v2/device/name/d1", http.NoBody))
+
+	require.Len(t, recorder.queue, 1)
+	assert.Equal(t, AnonymousActor, (<-recorder.queue).event.Actor)
+}
+
+func TestRecorderQueueFull(t *testing.T) {
+	recorder := NewRecorder(logger.MockLogger{}, &capturingCaller{}, "http://localhost:48080", 1)
+
+	recorder.Record(SystemEvent{ObjectName: "d1"}, "")
+	recorder.Record(SystemEvent{ObjectName: "d2"}, "")
+
+	require.Len(t, recorder.queue, 1)
+	assert.Equal(t, "d1", (<-recorder.queue).event.ObjectName)
+}
+
+func TestRecorderSend(t *testing.T) {
+	caller := &capturingCaller{}
+	recorder := NewRecorder(logger.MockLogger{}, caller, "http://localhost:48080/", 1)
+	event := SystemEvent{Service: testService, Actor: "admin", Action: ActionDelete, CorrelationId: "123"}
+
+	err := recorder.send(queuedEvent{event: event, authorization: "Bearer token"})
+
+	require.NoError(t, err)
+	require.Len(t, caller.requests, 1)
+	req := caller.requests[0]
+	assert.Equal(t, "http://localhost:48080"+ApiSystemEventRoute, req.URL.String())
+	assert.Equal(t, "Bearer token", req.Header.Get(authorizationHeader))
+	var sent []SystemEvent
+	require.NoError(t, json.Unmarshal(caller.bodies[0], &sent))
+	assert.Equal(t, []SystemEvent{event}, sent)
+}
@@ -0,0 +1,123 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const defaultBufferSize = 100
+
+type queuedEvent struct {
+	event         SystemEvent
+	authorization string
+}
+
+// Recorder sends system events to core-data in the background, so that recording an operation never delays
+// the response to the request that performed it.
+type Recorder struct {
+	lc     logger.LoggingClient
+	client internal.HttpCaller
+	url    string
+	queue  chan queuedEvent
+}
+
+// NewRecorder creates a Recorder posting to the core-data service at coreDataURL
+func NewRecorder(lc logger.LoggingClient, client internal.HttpCaller, coreDataURL string, bufferSize int) *Recorder {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Recorder{
+		lc:     lc,
+		client: client,
+		url:    strings.TrimSuffix(coreDataURL, "/") + ApiSystemEventRoute,
+		queue:  make(chan queuedEvent, bufferSize),
+	}
+}
+
+// Record queues event to be sent with the Authorization header of the request that performed the operation,
+// so that core-data accepts it when it verifies JWTs too. The event is dropped when the queue is full.
+func (r *Recorder) Record(event SystemEvent, authorization string) {
+	select {
+	case r.queue <- queuedEvent{event: event, authorization: authorization}:
+	default:
+		r.lc.Warn(fmt.Sprintf("system event queue is full, dropping %s %s of %s by %s",
+			event.Action, event.ObjectType, event.ObjectName, event.Actor))
+	}
+}
+
+// Run sends the queued system events until ctx is done
+func (r *Recorder) Run(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case queued := <-r.queue:
+				if err := r.send(queued); err != nil {
+					r.lc.Error(fmt.Sprintf("failed to record %s %s of %s by %s: %s",
+						queued.event.Action, queued.event.ObjectType, queued.event.ObjectName, queued.event.Actor, err.Error()),
+						clients.CorrelationHeader, queued.event.CorrelationId)
+				}
+			}
+		}
+	}()
+}
+
+func (r *Recorder) send(queued queuedEvent) error {
+	body, err := json.Marshal([]SystemEvent{queued.event})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(clients.ContentType, clients.ContentTypeJSON)
+	if queued.authorization != "" {
+		req.Header.Set(authorizationHeader, queued.authorization)
+	}
+	if queued.event.CorrelationId != "" {
+		req.Header.Set(clients.CorrelationHeader, queued.event.CorrelationId)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("core-data responded %d", resp.StatusCode)
+	}
+	return nil
+}
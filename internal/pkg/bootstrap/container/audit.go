@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// AuditRecorderName contains the name of the audit.Recorder instance in the DIC.
+var AuditRecorderName = di.TypeInstanceToName(audit.Recorder{})
+
+// AuditRecorderFrom helper function queries the DIC and returns the audit.Recorder of the system events, or nil if
+// they aren't recorded.
+func AuditRecorderFrom(get di.Get) *audit.Recorder {
+	recorder, ok := get(AuditRecorderName).(*audit.Recorder)
+	if !ok {
+		return nil
+	}
+	return recorder
+}
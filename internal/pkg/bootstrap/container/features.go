@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// FeaturesName contains the name of the features.Registry instance in the DIC.
+var FeaturesName = di.TypeInstanceToName(features.Registry{})
+
+// FeaturesFrom helper function queries the DIC and returns the features.Registry of the optional subsystems of the
+// service, or nil if the service has none.
+func FeaturesFrom(get di.Get) *features.Registry {
+	registry, ok := get(FeaturesName).(*features.Registry)
+	if !ok {
+		return nil
+	}
+	return registry
+}
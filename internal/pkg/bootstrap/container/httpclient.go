@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// HTTPClientName contains the name of the httpclient.Client instance in the DIC.
+var HTTPClientName = di.TypeInstanceToName(httpclient.Client{})
+
+// HTTPClientFrom helper function queries the DIC and returns the httpclient.Client sending the outbound requests of
+// the service, or nil if the service has none.
+func HTTPClientFrom(get di.Get) *httpclient.Client {
+	client, ok := get(HTTPClientName).(*httpclient.Client)
+	if !ok {
+		return nil
+	}
+	return client
+}
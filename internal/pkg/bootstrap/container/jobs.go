@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// JobManagerName contains the name of the jobs.Manager instance in the DIC.
+var JobManagerName = di.TypeInstanceToName(jobs.Manager{})
+
+// JobManagerFrom helper function queries the DIC and returns the jobs.Manager running the background jobs, or nil if
+// none is.
+func JobManagerFrom(get di.Get) *jobs.Manager {
+	manager, ok := get(JobManagerName).(*jobs.Manager)
+	if !ok {
+		return nil
+	}
+	return manager
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/registrywatch"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// RegistryWatcherName contains the name of the registrywatch.Watcher instance in the DIC.
+var RegistryWatcherName = di.TypeInstanceToName(registrywatch.Watcher{})
+
+// RegistryWatcherFrom helper function queries the DIC and returns the registrywatch.Watcher of the registration of the
+// service, or nil if the service isn't registered with the registry or its registration isn't watched.
+func RegistryWatcherFrom(get di.Get) *registrywatch.Watcher {
+	watcher, ok := get(RegistryWatcherName).(*registrywatch.Watcher)
+	if !ok {
+		return nil
+	}
+	return watcher
+}
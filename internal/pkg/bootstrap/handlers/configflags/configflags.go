@@ -0,0 +1,273 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configflags adds the command-line flags overriding the configuration keys of a service and printing its
+// effective configuration, for debugging a service outside of its container. The overrides are applied through the
+// environment variables go-mod-bootstrap already overrides the configuration with, so a key set on the command-line
+// takes precedence over its environment variable, the configuration file and the configuration provider.
+package configflags
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	// PrintFlag is the command-line flag printing the effective configuration in the given format and exiting
+	PrintFlag = "print-config"
+	// SetFlag is the command-line flag overriding a configuration key, repeated for each key
+	SetFlag = "set"
+	// Usage documents PrintFlag and SetFlag in the usage of the services supporting them
+	Usage = "    --print-config=<format>         Prints the effective configuration, secrets redacted, as toml, json or env and exits;\n" +
+		"                                    env lists the environment variable overriding each key\n" +
+		"    --set <key>=<value>             Overrides a configuration key, e.g. --set Writable.LogLevel=DEBUG, can be repeated\n"
+
+	// Formats of the printed configuration
+	FormatTOML = "toml"
+	FormatJSON = "json"
+	FormatEnv  = "env"
+
+	// Redacted replaces the values of the secrets in the printed configuration
+	Redacted = "<redacted>"
+)
+
+// secretsKey is the key of the tables holding the secrets of the InsecureSecrets of a service
+const secretsKey = "Secrets"
+
+// sensitiveSuffixes are the lowercase suffixes of the keys whose values are redacted wherever they are found
+var sensitiveSuffixes = []string{"password", "token", "secret", "apikey", "privatekey"}
+
+// Flags holds the values of PrintFlag and SetFlag
+type Flags struct {
+	// Print is the format the configuration is printed in, empty when it isn't printed
+	Print string
+	// Overrides maps the dotted path of each overridden key to its value, in the order given
+	Overrides Overrides
+}
+
+// Register adds PrintFlag and SetFlag to flagSet, e.g. the FlagSet of a flags.Default
+func (f *Flags) Register(flagSet *flag.FlagSet) {
+	flagSet.Var(printFormat{&f.Print}, PrintFlag, "")
+	flagSet.Var(&f.Overrides, SetFlag, "")
+}
+
+// Apply sets the environment variable of each override so that go-mod-bootstrap overrides the configuration with it.
+// It must be called before bootstrap.Run.
+func (f *Flags) Apply() error {
+	for _, override := range f.Overrides {
+		if err := os.Setenv(EnvVar(override.Key), override.Value); err != nil {
+			return fmt.Errorf("failed to override %s: %s", override.Key, err.Error())
+		}
+	}
+	return nil
+}
+
+// EnvVar returns the name of the environment variable go-mod-bootstrap overrides the key at path with
+func EnvVar(path string) string {
+	return strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
+
+// Override is a configuration key set on the command-line
+type Override struct {
+	// Key is the dotted path of the key, e.g. Writable.LogLevel
+	Key   string
+	Value string
+}
+
+// Overrides is the flag.Value of the repeated SetFlag
+type Overrides []Override
+
+func (o *Overrides) String() string {
+	if o == nil {
+		return ""
+	}
+	overrides := make([]string, len(*o))
+	for i, override := range *o {
+		overrides[i] = override.Key + "=" + override.Value
+	}
+	return strings.Join(overrides, ",")
+}
+
+// Set parses a <key>=<value> override
+func (o *Overrides) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("%s isn't of the form <key>=<value>", value)
+	}
+	key := strings.TrimSpace(parts[0])
+	if key == "" || strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") || strings.Contains(key, "..") {
+		return fmt.Errorf("%s isn't the dotted path of a configuration key, e.g. Writable.LogLevel", parts[0])
+	}
+	*o = append(*o, Override{Key: key, Value: parts[1]})
+	return nil
+}
+
+// printFormat is the flag.Value of PrintFlag, rejecting the unknown formats
+type printFormat struct {
+	format *string
+}
+
+func (p printFormat) String() string {
+	if p.format == nil {
+		return ""
+	}
+	return *p.format
+}
+
+func (p printFormat) Set(value string) error {
+	switch value {
+	case FormatTOML, FormatJSON, FormatEnv:
+		*p.format = value
+		return nil
+	}
+	return fmt.Errorf("unknown format %s, expected %s, %s or %s", value, FormatTOML, FormatJSON, FormatEnv)
+}
+
+// Settings returns configuration, a pointer to the configuration struct of a service, as a map of its TOML tables
+// with the values of its secrets redacted
+func Settings(configuration interface{}) (map[string]interface{}, error) {
+	var buffer bytes.Buffer
+	if err := toml.NewEncoder(&buffer).Encode(configuration); err != nil {
+		return nil, fmt.Errorf("failed to encode the configuration: %s", err.Error())
+	}
+	var settings map[string]interface{}
+	if _, err := toml.Decode(buffer.String(), &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode the configuration: %s", err.Error())
+	}
+	redact(settings, false)
+	return settings, nil
+}
+
+// redact replaces the non-empty values of the sensitive keys of settings, or of all its keys if secrets is set
+func redact(settings map[string]interface{}, secrets bool) {
+	for key, value := range settings {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			redact(v, secrets || key == secretsKey)
+		case []map[string]interface{}:
+			for _, table := range v {
+				redact(table, secrets)
+			}
+		default:
+			if (secrets || isSensitive(key)) && !isEmpty(value) {
+				settings[key] = Redacted
+			}
+		}
+	}
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// Paths returns the dotted paths of the keys of settings that can be overridden, i.e. all but the arrays of tables,
+// sorted
+func Paths(settings map[string]interface{}) []string {
+	var paths []string
+	walk(settings, "", func(path string, _ interface{}) {
+		paths = append(paths, path)
+	})
+	sort.Strings(paths)
+	return paths
+}
+
+// walk calls fn with the dotted path and value of each key of settings that can be overridden
+func walk(settings map[string]interface{}, prefix string, fn func(path string, value interface{})) {
+	for key, value := range settings {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			walk(v, path, fn)
+		case []map[string]interface{}:
+			// go-mod-bootstrap doesn't override the arrays of tables
+		default:
+			fn(path, value)
+		}
+	}
+}
+
+// Validate checks that each override names a key of settings that can be overridden, go-mod-bootstrap silently
+// ignoring the environment variables of unknown keys. The keys are matched regardless of their case, as their
+// environment variables are.
+func (f *Flags) Validate(settings map[string]interface{}) error {
+	known := make(map[string]bool)
+	for _, path := range Paths(settings) {
+		known[EnvVar(path)] = true
+	}
+	var unknown []string
+	for _, override := range f.Overrides {
+		if !known[EnvVar(override.Key)] {
+			unknown = append(unknown, override.Key)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown configuration key(s) %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Print writes settings to w in format
+func Print(w io.Writer, settings map[string]interface{}, format string) error {
+	switch format {
+	case FormatTOML:
+		return toml.NewEncoder(w).Encode(settings)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(settings)
+	case FormatEnv:
+		values := make(map[string]interface{})
+		walk(settings, "", func(path string, value interface{}) {
+			values[path] = value
+		})
+		for _, path := range Paths(settings) {
+			if _, err := fmt.Fprintf(w, "%s=%s\n", EnvVar(path), envValue(values[path])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return errors.New("unknown format " + format)
+}
+
+// envValue formats value as go-mod-bootstrap parses it from an environment variable, the arrays being comma-separated
+func envValue(value interface{}) string {
+	if values, ok := value.([]interface{}); ok {
+		items := make([]string, len(values))
+		for i, item := range values {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
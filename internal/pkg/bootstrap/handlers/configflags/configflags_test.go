@@ -0,0 +1,187 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configflags
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type insecureSecret struct {
+	Path    string
+	Secrets map[string]string
+}
+
+type configuration struct {
+	Writable struct {
+		LogLevel        string
+		InsecureSecrets map[string]insecureSecret
+	}
+	Service struct {
+		Host     string
+		Port     int
+		Timeout  int
+		Password string
+	}
+	SecretStore struct {
+		TokenFile string
+	}
+	Filters []string
+	Rules   []struct {
+		Name  string
+		Token string
+	}
+}
+
+func newConfiguration() *configuration {
+	c := &configuration{}
+	c.Writable.LogLevel = "INFO"
+	c.Writable.InsecureSecrets = map[string]insecureSecret{
+		"DB": {Path: "redisdb", Secrets: map[string]string{"username": "", "password": "s3cr3t"}},
+	}
+	c.Service.Host = "localhost"
+	c.Service.Port = 48080
+	c.Service.Timeout = 5000
+	c.Service.Password = "hunter2"
+	c.SecretStore.TokenFile = "/vault/config/assets/resp-init.json"
+	c.Filters = []string{"a", "b"}
+	c.Rules = append(c.Rules, struct {
+		Name  string
+		Token string
+	}{"rule1", "abc"})
+	return c
+}
+
+func TestFlags(t *testing.T) {
+	var f Flags
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.SetOutput(&bytes.Buffer{})
+	f.Register(flagSet)
+
+	require.NoError(t, flagSet.Parse([]string{"--print-config=json", "--set", "Writable.LogLevel=DEBUG", "--set=Service.Host=a=b"}))
+	assert.Equal(t, FormatJSON, f.Print)
+	assert.Equal(t, Overrides{{"Writable.LogLevel", "DEBUG"}, {"Service.Host", "a=b"}}, f.Overrides)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"unknown format", []string{"--print-config=yaml"}},
+		{"no value", []string{"--set", "Writable.LogLevel"}},
+		{"no key", []string{"--set", "=DEBUG"}},
+		{"invalid key", []string{"--set", "Writable..LogLevel=DEBUG"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, flagSet.Parse(tt.args))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	f := Flags{Overrides: Overrides{{"Writable.LogLevel", "DEBUG"}}}
+	defer os.Unsetenv("WRITABLE_LOGLEVEL")
+	require.NoError(t, f.Apply())
+	assert.Equal(t, "DEBUG", os.Getenv("WRITABLE_LOGLEVEL"))
+}
+
+func TestSettingsRedacted(t *testing.T) {
+	settings, err := Settings(newConfiguration())
+	require.NoError(t, err)
+
+	writable := settings["Writable"].(map[string]interface{})
+	db := writable["InsecureSecrets"].(map[string]interface{})["DB"].(map[string]interface{})
+	assert.Equal(t, "redisdb", db["Path"])
+	assert.Equal(t, map[string]interface{}{"username": "", "password": Redacted}, db["Secrets"], "the empty secrets are shown as unset")
+	service := settings["Service"].(map[string]interface{})
+	assert.Equal(t, Redacted, service["Password"])
+	assert.Equal(t, int64(48080), service["Port"])
+	assert.Equal(t, "/vault/config/assets/resp-init.json", settings["SecretStore"].(map[string]interface{})["TokenFile"])
+	assert.Equal(t, Redacted, settings["Rules"].([]map[string]interface{})[0]["Token"])
+}
+
+func TestValidate(t *testing.T) {
+	settings, err := Settings(newConfiguration())
+	require.NoError(t, err)
+
+	f := Flags{Overrides: Overrides{{"writable.loglevel", "DEBUG"}, {"Writable.InsecureSecrets.DB.Path", "db"}}}
+	assert.NoError(t, f.Validate(settings), "the keys are matched regardless of their case")
+
+	f.Overrides = Overrides{{"Writable.LogLvl", "DEBUG"}, {"Rules", "x"}, {"Service", "x"}}
+	err = f.Validate(settings)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Writable.LogLvl, Rules, Service")
+}
+
+func TestPrint(t *testing.T) {
+	settings, err := Settings(newConfiguration())
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, Print(&out, settings, FormatEnv))
+	assert.Equal(t, "FILTERS=a,b\n"+
+		"SECRETSTORE_TOKENFILE=/vault/config/assets/resp-init.json\n"+
+		"SERVICE_HOST=localhost\n"+
+		"SERVICE_PASSWORD=<redacted>\n"+
+		"SERVICE_PORT=48080\n"+
+		"SERVICE_TIMEOUT=5000\n"+
+		"WRITABLE_INSECURESECRETS_DB_PATH=redisdb\n"+
+		"WRITABLE_INSECURESECRETS_DB_SECRETS_PASSWORD=<redacted>\n"+
+		"WRITABLE_INSECURESECRETS_DB_SECRETS_USERNAME=\n"+
+		"WRITABLE_LOGLEVEL=INFO\n", out.String())
+
+	out.Reset()
+	require.NoError(t, Print(&out, settings, FormatJSON))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "INFO", decoded["Writable"].(map[string]interface{})["LogLevel"])
+
+	out.Reset()
+	require.NoError(t, Print(&out, settings, FormatTOML))
+	assert.Contains(t, out.String(), "[Service]")
+	assert.Contains(t, out.String(), `Password = "<redacted>"`)
+	assert.NotContains(t, out.String(), "hunter2")
+}
+
+func TestBootstrapHandler(t *testing.T) {
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+	})
+
+	c := newConfiguration()
+	h := NewHandler(&Flags{}, c)
+	assert.True(t, h.BootstrapHandler(context.Background(), nil, startup.NewStartUpTimer("test"), dic), "nothing to do without the flags")
+
+	defer os.Unsetenv("SERVICE_PORT")
+	f := &Flags{Overrides: Overrides{{"Service.Port", "59880"}}}
+	require.NoError(t, f.Apply())
+	h = NewHandler(f, c)
+	assert.True(t, h.BootstrapHandler(context.Background(), nil, startup.NewStartUpTimer("test"), dic))
+	assert.Equal(t, 59880, c.Service.Port, "the overrides are applied again over the configuration of the provider")
+
+	f.Overrides = append(f.Overrides, Override{"Service.Prt", "1"})
+	assert.False(t, h.BootstrapHandler(context.Background(), nil, startup.NewStartUpTimer("test"), dic), "the service doesn't start with an unknown key")
+
+	var out bytes.Buffer
+	h = NewHandler(&Flags{Print: FormatEnv}, c)
+	h.out = &out
+	assert.False(t, h.BootstrapHandler(context.Background(), nil, startup.NewStartUpTimer("test"), dic), "the service doesn't start once the configuration is printed")
+	assert.Contains(t, out.String(), "SERVICE_PORT=59880\n")
+}
@@ -0,0 +1,72 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configflags
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// Handler checks the overrides of the Flags of a service and prints its effective configuration when requested. Its
+// BootstrapHandler must run right after the configuration provider handler, before the configuration is used.
+type Handler struct {
+	flags         *Flags
+	configuration interface{}
+	out           io.Writer
+}
+
+// NewHandler is a factory method that returns an initialized Handler receiver struct. configuration is the pointer
+// to the configuration struct of the service.
+func NewHandler(f *Flags, configuration interface{}) *Handler {
+	return &Handler{
+		flags:         f,
+		configuration: configuration,
+		out:           os.Stdout,
+	}
+}
+
+// BootstrapHandler fulfills the BootstrapHandler contract. The overrides are applied again, as the configuration
+// provider handler replaces the configuration overridden by go-mod-bootstrap when the provider is a file or etcd
+// URL. When the configuration is printed, the bootstrap stops there so that the service doesn't start.
+func (h *Handler) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+	if len(h.flags.Overrides) == 0 && h.flags.Print == "" {
+		return true
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	if len(h.flags.Overrides) > 0 {
+		if _, err := environment.NewVariables(lc).OverrideConfiguration(h.configuration); err != nil {
+			lc.Error(err.Error())
+			return false
+		}
+	}
+
+	settings, err := Settings(h.configuration)
+	if err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+	if err = h.flags.Validate(settings); err != nil {
+		lc.Error(fmt.Sprintf("invalid --%s: %s", SetFlag, err.Error()))
+		return false
+	}
+
+	if h.flags.Print == "" {
+		return true
+	}
+	if err = Print(h.out, settings, h.flags.Print); err != nil {
+		lc.Error(fmt.Sprintf("failed to print the configuration: %s", err.Error()))
+	}
+	return false
+}
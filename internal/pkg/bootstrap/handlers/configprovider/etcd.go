@@ -0,0 +1,113 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package configprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const etcdRequestTimeout = 10 * time.Second
+
+// etcdSource stores the configuration of a service as a TOML document under a key of etcd, through the JSON gateway
+// of the etcd v3 API
+type etcdSource struct {
+	baseUrl string
+	key     string
+	client  *http.Client
+}
+
+// etcdKeyValue is a key-value pair of the etcd v3 API, whose keys and values are base64 encoded
+type etcdKeyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	ModRevision int64  `json:"mod_revision,string,omitempty"`
+}
+
+type etcdRangeResponse struct {
+	Kvs []etcdKeyValue `json:"kvs"`
+}
+
+// NewEtcdSource is a factory function that returns the Source of the configuration stored under key by the etcd
+// server at baseUrl, e.g. http://localhost:2379.
+func NewEtcdSource(baseUrl string, key string) Source {
+	return etcdSource{
+		baseUrl: baseUrl,
+		key:     key,
+		client:  &http.Client{Timeout: etcdRequestTimeout},
+	}
+}
+
+func (s etcdSource) Read(ctx context.Context) (Document, bool, error) {
+	var response etcdRangeResponse
+	if err := s.post(ctx, "/v3/kv/range", etcdKeyValue{Key: encode(s.key)}, &response); err != nil {
+		return Document{}, false, err
+	}
+	if len(response.Kvs) == 0 {
+		return Document{}, false, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(response.Kvs[0].Value)
+	if err != nil {
+		return Document{}, false, fmt.Errorf("invalid value of key %s: %s", s.key, err.Error())
+	}
+	return Document{Data: data, Format: formatTOML, Version: strconv.FormatInt(response.Kvs[0].ModRevision, 10)}, true, nil
+}
+
+func (s etcdSource) Write(ctx context.Context, data []byte) error {
+	return s.post(ctx, "/v3/kv/put", etcdKeyValue{Key: encode(s.key), Value: base64.StdEncoding.EncodeToString(data)}, nil)
+}
+
+func (s etcdSource) post(ctx context.Context, path string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseUrl+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("etcd responded %d: %s", resp.StatusCode, string(data))
+	}
+	if response == nil {
+		return nil
+	}
+	return json.Unmarshal(data, response)
+}
+
+func (s etcdSource) String() string {
+	return fmt.Sprintf("etcd key %s of %s", s.key, s.baseUrl)
+}
+
+func encode(key string) string {
+	return base64.StdEncoding.EncodeToString([]byte(key))
+}
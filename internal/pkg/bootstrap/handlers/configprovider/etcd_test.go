@@ -0,0 +1,84 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package configprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEtcdServer emulates the JSON gateway of the etcd v3 API
+func newEtcdServer(t *testing.T) *httptest.Server {
+	var mutex sync.Mutex
+	values := make(map[string]etcdKeyValue)
+	revision := int64(0)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		var request etcdKeyValue
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		switch r.URL.Path {
+		case "/v3/kv/range":
+			response := map[string]interface{}{"header": map[string]string{"revision": strconv.FormatInt(revision, 10)}}
+			if value, ok := values[request.Key]; ok {
+				response["kvs"] = []etcdKeyValue{value}
+				response["count"] = "1"
+			}
+			_ = json.NewEncoder(w).Encode(response)
+		case "/v3/kv/put":
+			revision++
+			request.ModRevision = revision
+			values[request.Key] = request
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestEtcdSource(t *testing.T) {
+	server := newEtcdServer(t)
+	defer server.Close()
+	source := NewEtcdSource(server.URL, "edgex/core/2.0/edgex-core-data")
+
+	_, found, err := source.Read(context.Background())
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, source.Write(context.Background(), []byte("[Service]\nPort = 48080\n")))
+	doc, found, err := source.Read(context.Background())
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "[Service]\nPort = 48080\n", string(doc.Data))
+	assert.Equal(t, formatTOML, doc.Format)
+	assert.Equal(t, "1", doc.Version)
+
+	require.NoError(t, source.Write(context.Background(), []byte("[Service]\nPort = 59880\n")))
+	doc, _, err = source.Read(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "2", doc.Version, "the version should change with the value")
+
+	_, _, err = NewEtcdSource(server.URL+"/unknown", "key").Read(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package configprovider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// fileSource stores the configuration of a service in the <service key>.toml, .yaml or .yml file of a directory
+type fileSource struct {
+	directory  string
+	serviceKey string
+}
+
+// NewFileSource is a factory function that returns the Source of the configuration of the service in directory.
+func NewFileSource(directory string, serviceKey string) Source {
+	return fileSource{
+		directory:  directory,
+		serviceKey: serviceKey,
+	}
+}
+
+func (s fileSource) Read(_ context.Context) (Document, bool, error) {
+	files := []struct {
+		suffix string
+		format string
+	}{
+		{".toml", formatTOML},
+		{".yaml", formatYAML},
+		{".yml", formatYAML},
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(filepath.Join(s.directory, s.serviceKey+file.suffix))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return Document{}, false, err
+		}
+		// the content is hashed since the modification time may not change on quick successive edits
+		hash := sha256.Sum256(data)
+		return Document{Data: data, Format: file.format, Version: hex.EncodeToString(hash[:])}, true, nil
+	}
+	return Document{}, false, nil
+}
+
+// Write replaces the TOML file of the service. The file is renamed into place so that it is never read half written.
+func (s fileSource) Write(_ context.Context, data []byte) error {
+	if err := os.MkdirAll(s.directory, 0755); err != nil {
+		return err
+	}
+	file, err := ioutil.TempFile(s.directory, s.serviceKey+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), filepath.Join(s.directory, s.serviceKey+".toml"))
+}
+
+func (s fileSource) String() string {
+	return fmt.Sprintf("directory %s", s.directory)
+}
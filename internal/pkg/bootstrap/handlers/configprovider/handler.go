@@ -0,0 +1,108 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package configprovider
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// Flags wraps the flags.Common of a service, hiding from go-mod-bootstrap the configuration provider URLs handled
+// by this package
+type Flags struct {
+	flags.Common
+	providerUrl string
+}
+
+// NewFlags takes over the configuration provider URL of the parsed flags f, given by the -cp flag or overridden by
+// the environment variable, when it is a file or etcd URL.
+func NewFlags(f flags.Common) *Flags {
+	providerUrl := f.ConfigProviderUrl()
+	if value := os.Getenv(internal.ConfigProviderEnvVar); value != "" {
+		providerUrl = value
+	}
+	if !IsSupported(providerUrl) {
+		return &Flags{Common: f}
+	}
+
+	// Make sure go-mod-bootstrap doesn't try to use the provider itself.
+	_ = os.Setenv(internal.ConfigProviderEnvVar, "")
+	return &Flags{Common: f, providerUrl: providerUrl}
+}
+
+// ConfigProviderUrl returns the empty url when the configuration provider is handled by this package
+func (f *Flags) ConfigProviderUrl() string {
+	if f.providerUrl != "" {
+		return ""
+	}
+	return f.Common.ConfigProviderUrl()
+}
+
+// Handler contains references to dependencies required by the configuration provider bootstrap implementation.
+type Handler struct {
+	flags      *Flags
+	serviceKey string
+	configStem string
+	config     Configuration
+}
+
+// NewHandler is a factory method that returns an initialized Handler receiver struct.
+func NewHandler(f *Flags, serviceKey string, configStem string, config Configuration) *Handler {
+	return &Handler{
+		flags:      f,
+		serviceKey: serviceKey,
+		configStem: configStem,
+		config:     config,
+	}
+}
+
+// BootstrapHandler fulfills the BootstrapHandler contract. When the configuration provider is a file or etcd URL, it
+// loads the service configuration from it and watches its Writable section. It must come first in the handlers of
+// the service, so that the other handlers use the configuration of the provider.
+func (h *Handler) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	if h.flags.providerUrl == "" {
+		return true
+	}
+
+	lc := container.LoggingClientFrom(dic.Get)
+	provider, err := NewProvider(h.flags.providerUrl, h.configStem, h.serviceKey, h.config, lc)
+	if err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+
+	for startupTimer.HasNotElapsed() {
+		if err = provider.Load(ctx, h.flags.OverwriteConfig()); err == nil {
+			break
+		}
+		lc.Warn(err.Error())
+		startupTimer.SleepForInterval()
+	}
+	if err != nil {
+		lc.Error("failed to load the configuration from the configuration provider in allotted time")
+		return false
+	}
+
+	provider.Watch(ctx, wg)
+	return true
+}
@@ -0,0 +1,292 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package configprovider lets the services use an etcd endpoint or a directory of TOML or YAML files as their
+// configuration provider when Consul isn't deployed, go-mod-bootstrap only supporting Consul. As with Consul, the
+// local configuration is pushed to the provider when it doesn't hold the configuration of the service yet, the
+// configuration of the provider is used otherwise, and the changes of its Writable section are applied while the
+// service runs.
+package configprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// FileScheme is the scheme of the provider URLs naming a directory holding a <service key>.toml, .yaml or .yml
+	// file per service, e.g. file:///etc/edgex/config
+	FileScheme = "file"
+	// EtcdScheme is the scheme of the provider URLs naming an etcd endpoint, e.g. etcd://localhost:2379. The
+	// configuration of each service is stored as a TOML document under the key <config stem><service key>.
+	EtcdScheme = "etcd"
+	// PollIntervalParam is the query parameter of the provider URL giving how often the provider is checked for
+	// changes, e.g. etcd://localhost:2379?pollInterval=10s
+	PollIntervalParam = "pollInterval"
+
+	formatTOML = "toml"
+	formatYAML = "yaml"
+
+	writableKey         = "Writable"
+	defaultPollInterval = 5 * time.Second
+)
+
+// Configuration is the part of the service configuration, e.g. a config.ConfigurationStruct, updated from the provider
+type Configuration interface {
+	UpdateFromRaw(rawConfig interface{}) bool
+	EmptyWritablePtr() interface{}
+	UpdateWritableFromRaw(rawWritable interface{}) bool
+	GetLogLevel() string
+}
+
+// Document is the configuration of a service as stored by a Source
+type Document struct {
+	Data []byte
+	// Format is "toml" or "yaml"
+	Format string
+	// Version changes whenever the document does
+	Version string
+}
+
+// Source stores the configuration of a service
+type Source interface {
+	// Read returns the configuration, found being false when the source doesn't hold it
+	Read(ctx context.Context) (doc Document, found bool, err error)
+	// Write stores the configuration, a TOML document
+	Write(ctx context.Context, data []byte) error
+	String() string
+}
+
+// IsSupported returns whether the configuration provider URL is handled by this package
+func IsSupported(providerUrl string) bool {
+	u, err := url.Parse(providerUrl)
+	return err == nil && (u.Scheme == FileScheme || u.Scheme == EtcdScheme)
+}
+
+// Provider loads the configuration of a service from its Source and applies the changes of its Writable section
+type Provider struct {
+	source       Source
+	config       Configuration
+	lc           logger.LoggingClient
+	pollInterval time.Duration
+	version      string
+}
+
+// NewProvider is a factory function that returns the Provider of the service configuration stored at providerUrl.
+func NewProvider(providerUrl string, configStem string, serviceKey string, config Configuration, lc logger.LoggingClient) (*Provider, error) {
+	u, err := url.Parse(providerUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration provider URL '%s': %s", providerUrl, err.Error())
+	}
+
+	p := &Provider{
+		config:       config,
+		lc:           lc,
+		pollInterval: defaultPollInterval,
+	}
+	if value := u.Query().Get(PollIntervalParam); value != "" {
+		if p.pollInterval, err = time.ParseDuration(value); err != nil || p.pollInterval <= 0 {
+			return nil, fmt.Errorf("invalid %s '%s' of configuration provider URL", PollIntervalParam, value)
+		}
+	}
+
+	switch u.Scheme {
+	case FileScheme:
+		// file:///etc/edgex has an empty host, file://config a relative directory
+		p.source = NewFileSource(u.Host+u.Path, serviceKey)
+	case EtcdScheme:
+		p.source = NewEtcdSource("http://"+u.Host, configStem+serviceKey)
+	default:
+		return nil, fmt.Errorf("unsupported configuration provider URL '%s'", providerUrl)
+	}
+	return p, nil
+}
+
+// Load replaces the service configuration by the one of the provider, or pushes the service configuration to the
+// provider when the provider doesn't hold it yet or overwrite is true
+func (p *Provider) Load(ctx context.Context, overwrite bool) error {
+	doc, found, err := p.source.Read(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read the configuration from %s: %s", p.source, err.Error())
+	}
+
+	if !found || overwrite {
+		var buffer bytes.Buffer
+		if err := toml.NewEncoder(&buffer).Encode(p.config); err != nil {
+			return fmt.Errorf("failed to encode the configuration: %s", err.Error())
+		}
+		if err := p.source.Write(ctx, buffer.Bytes()); err != nil {
+			return fmt.Errorf("failed to push the configuration to %s: %s", p.source, err.Error())
+		}
+		if doc, _, err = p.source.Read(ctx); err != nil {
+			return fmt.Errorf("failed to read the configuration from %s: %s", p.source, err.Error())
+		}
+		p.version = doc.Version
+		p.lc.Info(fmt.Sprintf("configuration pushed to %s", p.source))
+		return nil
+	}
+
+	values, err := parse(doc)
+	if err != nil {
+		return fmt.Errorf("invalid configuration in %s: %s", p.source, err.Error())
+	}
+	// the settings missing from the provider keep their local value
+	raw, err := clone(p.config)
+	if err == nil {
+		err = decode(values, raw)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid configuration in %s: %s", p.source, err.Error())
+	}
+	if !p.config.UpdateFromRaw(raw) {
+		return fmt.Errorf("invalid configuration in %s", p.source)
+	}
+	p.version = doc.Version
+	p.updateLogLevel()
+	p.lc.Info(fmt.Sprintf("configuration loaded from %s", p.source))
+	return nil
+}
+
+// Watch applies the changes of the Writable section of the configuration every poll interval until ctx is done.
+// Like with Consul, the changes of the other sections are only applied when the service restarts.
+func (p *Provider) Watch(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(p.pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.reload(ctx)
+			}
+		}
+	}()
+}
+
+// reload applies the Writable section of the provider configuration if the configuration changed
+func (p *Provider) reload(ctx context.Context) {
+	doc, found, err := p.source.Read(ctx)
+	if err != nil {
+		p.lc.Warn(fmt.Sprintf("failed to read the configuration from %s: %s", p.source, err.Error()))
+		return
+	}
+	if !found || doc.Version == p.version {
+		return
+	}
+	p.version = doc.Version
+
+	values, err := parse(doc)
+	if err != nil {
+		p.lc.Error(fmt.Sprintf("invalid configuration in %s: %s", p.source, err.Error()))
+		return
+	}
+	writable, ok := values[writableKey]
+	if !ok {
+		p.lc.Warn(fmt.Sprintf("no %s section in the configuration of %s", writableKey, p.source))
+		return
+	}
+	raw := p.config.EmptyWritablePtr()
+	if err := decode(writable, raw); err != nil || !p.config.UpdateWritableFromRaw(raw) {
+		p.lc.Error(fmt.Sprintf("invalid %s section in the configuration of %s", writableKey, p.source))
+		return
+	}
+	p.updateLogLevel()
+	p.lc.Info(fmt.Sprintf("%s configuration updated from %s", writableKey, p.source))
+}
+
+func (p *Provider) updateLogLevel() {
+	if err := p.lc.SetLogLevel(p.config.GetLogLevel()); err != nil {
+		p.lc.Error(fmt.Sprintf("failed to set the log level: %s", err.Error()))
+	}
+}
+
+// parse returns the settings of the document by name
+func parse(doc Document) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	switch doc.Format {
+	case formatTOML:
+		if _, err := toml.Decode(string(doc.Data), &values); err != nil {
+			return nil, err
+		}
+	case formatYAML:
+		var document interface{}
+		if err := yaml.Unmarshal(doc.Data, &document); err != nil {
+			return nil, err
+		}
+		normalized, ok := normalize(document).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the YAML document isn't a mapping")
+		}
+		values = normalized
+	default:
+		return nil, fmt.Errorf("unsupported format %s", doc.Format)
+	}
+	return values, nil
+}
+
+// normalize converts the map[interface{}]interface{} of the YAML mappings to map[string]interface{}
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[fmt.Sprint(key)] = normalize(item)
+		}
+		return result
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// decode sets the fields of target, a pointer to a struct, from the values named like them. JSON is used as the
+// intermediate format since it matches the names case-insensitively, the configuration structs having no tags.
+func decode(values interface{}, target interface{}) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// clone returns a deep copy of config, a pointer to a struct
+func clone(config Configuration) (interface{}, error) {
+	value := reflect.ValueOf(config)
+	if value.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("the configuration must be a pointer, got %s", value.Type())
+	}
+	raw := reflect.New(value.Elem().Type()).Interface()
+	if err := decode(config, raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
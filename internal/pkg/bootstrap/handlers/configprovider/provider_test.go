@@ -0,0 +1,176 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package configprovider
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testService struct {
+	Host string
+	Port int
+}
+
+type testWritable struct {
+	LogLevel    string
+	PersistData bool
+}
+
+type testConfig struct {
+	Writable testWritable
+	Service  testService
+	Clients  map[string]testService
+}
+
+func (c *testConfig) UpdateFromRaw(rawConfig interface{}) bool {
+	configuration, ok := rawConfig.(*testConfig)
+	if ok {
+		if configuration.Service.Port == 0 {
+			return false
+		}
+		*c = *configuration
+	}
+	return ok
+}
+
+func (c *testConfig) EmptyWritablePtr() interface{} {
+	return &testWritable{}
+}
+
+func (c *testConfig) UpdateWritableFromRaw(rawWritable interface{}) bool {
+	writable, ok := rawWritable.(*testWritable)
+	if ok {
+		c.Writable = *writable
+	}
+	return ok
+}
+
+func (c *testConfig) GetLogLevel() string {
+	return c.Writable.LogLevel
+}
+
+func newTestConfig() *testConfig {
+	return &testConfig{
+		Writable: testWritable{LogLevel: "INFO", PersistData: true},
+		Service:  testService{Host: "localhost", Port: 48080},
+		Clients:  map[string]testService{"Metadata": {Host: "localhost", Port: 48081}},
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported("file:///etc/edgex"))
+	assert.True(t, IsSupported("etcd://localhost:2379"))
+	assert.False(t, IsSupported("consul://localhost:8500"))
+	assert.False(t, IsSupported(""))
+}
+
+func TestNewProvider(t *testing.T) {
+	lc := logger.NewMockClient()
+	p, err := NewProvider("file:///etc/edgex?pollInterval=1m", "edgex/core/2.0/", "edgex-core-data", newTestConfig(), lc)
+	require.NoError(t, err)
+	assert.Equal(t, "directory /etc/edgex", p.source.String())
+	assert.Equal(t, time.Minute, p.pollInterval)
+
+	p, err = NewProvider("etcd://etcd:2379", "edgex/core/2.0/", "edgex-core-data", newTestConfig(), lc)
+	require.NoError(t, err)
+	assert.Equal(t, "etcd key edgex/core/2.0/edgex-core-data of http://etcd:2379", p.source.String())
+	assert.Equal(t, defaultPollInterval, p.pollInterval)
+
+	_, err = NewProvider("etcd://etcd:2379?pollInterval=0s", "edgex/core/2.0/", "edgex-core-data", newTestConfig(), lc)
+	assert.Error(t, err)
+	_, err = NewProvider("consul://localhost:8500", "edgex/core/2.0/", "edgex-core-data", newTestConfig(), lc)
+	assert.Error(t, err)
+}
+
+func TestProvider_Load(t *testing.T) {
+	lc := logger.NewMockClient()
+	directory := t.TempDir()
+	local := newTestConfig()
+	p, err := NewProvider("file://"+directory, "", "edgex-core-data", local, lc)
+	require.NoError(t, err)
+
+	// the local configuration is pushed when the provider doesn't hold it
+	require.NoError(t, p.Load(context.Background(), false))
+	assert.Equal(t, newTestConfig(), local)
+	data, err := ioutil.ReadFile(filepath.Join(directory, "edgex-core-data.toml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Port = 48080")
+
+	// the configuration of the provider is used otherwise, the missing settings keeping their local value
+	yamlConfig := `
+Writable:
+  LogLevel: DEBUG
+Service:
+  Port: 59880
+`
+	directory = t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(directory, "edgex-core-data.yaml"), []byte(yamlConfig), 0644))
+	local = newTestConfig()
+	p, err = NewProvider("file://"+directory, "", "edgex-core-data", local, lc)
+	require.NoError(t, err)
+	require.NoError(t, p.Load(context.Background(), false))
+	assert.Equal(t, "DEBUG", local.Writable.LogLevel)
+	assert.True(t, local.Writable.PersistData)
+	assert.Equal(t, testService{Host: "localhost", Port: 59880}, local.Service)
+	assert.Equal(t, 48081, local.Clients["Metadata"].Port)
+
+	// the provider configuration is replaced when overwriting
+	local = newTestConfig()
+	require.NoError(t, NewFileSource(directory, "edgex-core-data").Write(context.Background(), []byte("[Service]\nPort = 59880\n")))
+	p, err = NewProvider("file://"+directory, "", "edgex-core-data", local, lc)
+	require.NoError(t, err)
+	require.NoError(t, p.Load(context.Background(), true))
+	assert.Equal(t, 48080, local.Service.Port)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(directory, "edgex-core-data.toml"), []byte("[Service]\nPort = 'abc'\n"), 0644))
+	assert.Error(t, p.Load(context.Background(), false))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(directory, "edgex-core-data.toml"), []byte("[Service]\nPort = 0\n"), 0644))
+	assert.Error(t, p.Load(context.Background(), false), "the invalid configuration should be rejected")
+}
+
+func TestProvider_Watch(t *testing.T) {
+	directory := t.TempDir()
+	local := newTestConfig()
+	p, err := NewProvider("file://"+directory+"?pollInterval=10ms", "", "edgex-core-data", local, logger.NewMockClient())
+	require.NoError(t, err)
+	require.NoError(t, p.Load(context.Background(), false))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	p.Watch(ctx, wg)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	changed := "[Writable]\nLogLevel = 'DEBUG'\nPersistData = false\n[Service]\nPort = 59880\n"
+	require.NoError(t, NewFileSource(directory, "edgex-core-data").Write(context.Background(), []byte(changed)))
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.Equal(t, testWritable{LogLevel: "DEBUG", PersistData: false}, local.Writable)
+	assert.Equal(t, 48080, local.Service.Port, "only the Writable changes should be applied")
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
 	dbInterfaces "github.com/edgexfoundry/edgex-go/internal/pkg/db/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db/memory"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db/redis"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -83,6 +84,8 @@ func (d Database) newDBClient(
 			return redis.NewCoreDataClient(conf, lc)
 		}
 		return redis.NewClient(conf, lc)
+	case db.MemoryDB:
+		return memory.NewClient(), nil
 	default:
 		return nil, db.ErrUnsupportedDatabase
 	}
@@ -98,9 +101,9 @@ func (d Database) BootstrapHandler(
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	secretProvider := bootstrapContainer.SecretProviderFrom(dic.Get)
 
-	// get database credentials.
+	// get database credentials, which the in-memory database has none of.
 	var credentials bootstrapConfig.Credentials
-	for startupTimer.HasNotElapsed() {
+	for d.database.GetDatabaseInfo()["Primary"].Type != db.MemoryDB && startupTimer.HasNotElapsed() {
 		var err error
 
 		secrets, err := secretProvider.GetSecrets(d.database.GetDatabaseInfo()["Primary"].Type)
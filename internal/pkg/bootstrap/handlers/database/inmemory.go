@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+const (
+	// InMemoryFlag is the command-line flag running a service on the in-memory database rather than the configured one
+	InMemoryFlag = "in-memory"
+	// InMemoryUsage documents InMemoryFlag in the usage of the services supporting it
+	InMemoryUsage = "    --in-memory                     Keeps the data in memory rather than in the configured database, for development and tests\n"
+)
+
+// InMemory switches the primary database of a service to the in-memory one when its InMemoryFlag is set. Its
+// BootstrapHandler must run after the configuration is loaded and before the database handlers.
+type InMemory struct {
+	database interfaces.Database
+	enabled  bool
+}
+
+// NewInMemory is a factory method that returns an initialized InMemory receiver struct.
+func NewInMemory(database interfaces.Database, enabled bool) InMemory {
+	return InMemory{
+		database: database,
+		enabled:  enabled,
+	}
+}
+
+// BootstrapHandler fulfills the BootstrapHandler contract and overrides the type of the primary database.
+func (m InMemory) BootstrapHandler(
+	_ context.Context,
+	_ *sync.WaitGroup,
+	_ startup.Timer,
+	dic *di.Container) bool {
+
+	if !m.enabled {
+		return true
+	}
+
+	databases := m.database.GetDatabaseInfo()
+	primary := databases["Primary"]
+	primary.Type = db.MemoryDB
+	databases["Primary"] = primary
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Warn("Running on the in-memory database, the data is lost when the service stops and isn't shared with the other services")
+	return true
+}
@@ -0,0 +1,184 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/interfaces"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/gorilla/mux"
+)
+
+// DefaultShutdownTimeout is used when the service configuration does not specify a valid shutdown timeout.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// HttpServer contains references to dependencies required by the http server implementation. Unlike the
+// go-mod-bootstrap server, it only reports that it has stopped running once in-flight requests have completed
+// (or the shutdown timeout has elapsed), so handlers waiting on IsRunning() do not close the database or the
+// message bus underneath a request that is still being processed.
+type HttpServer struct {
+	router           *mux.Router
+	shutdown         interfaces.Shutdown
+	doListenAndServe bool
+
+	mutex     sync.RWMutex
+	isRunning bool
+	draining  bool
+}
+
+// NewHttpServer is a factory method that returns an initialized HttpServer receiver struct.
+func NewHttpServer(router *mux.Router, shutdown interfaces.Shutdown, doListenAndServe bool) *HttpServer {
+	return &HttpServer{
+		router:           router,
+		shutdown:         shutdown,
+		doListenAndServe: doListenAndServe,
+	}
+}
+
+// IsRunning returns whether or not the http server is running (or draining in-flight requests).
+func (b *HttpServer) IsRunning() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.isRunning
+}
+
+// IsDraining returns whether or not the http server has stopped accepting new requests.
+func (b *HttpServer) IsDraining() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.draining
+}
+
+func (b *HttpServer) setState(isRunning bool, draining bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.isRunning = isRunning
+	b.draining = draining
+}
+
+// shutdownTimeout returns the configured shutdown timeout, falling back to DefaultShutdownTimeout.
+func (b *HttpServer) shutdownTimeout() (time.Duration, error) {
+	if b.shutdown == nil || b.shutdown.GetShutdownTimeout() == "" {
+		return DefaultShutdownTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(b.shutdown.GetShutdownTimeout())
+	if err != nil || timeout <= 0 {
+		return DefaultShutdownTimeout, fmt.Errorf("invalid ShutdownTimeout '%s'", b.shutdown.GetShutdownTimeout())
+	}
+	return timeout, nil
+}
+
+// drainMiddleware asks clients not to reuse their connection once the server is draining, so that they
+// reconnect to another instance instead of this one.
+func (b *HttpServer) drainMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if b.IsDraining() {
+			w.Header().Set("Connection", "close")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// BootstrapHandler fulfills the BootstrapHandler contract.  It creates two go routines -- one that executes
+// ListenAndServe() and another that waits on closure of a context's done channel before draining and shutting
+// down the http server.
+func (b *HttpServer) BootstrapHandler(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	_ startup.Timer,
+	dic *di.Container) bool {
+
+	lc := container.LoggingClientFrom(dic.Get)
+	bootstrapConfig := container.ConfigurationFrom(dic.Get).GetBootstrap()
+
+	if !b.doListenAndServe {
+		lc.Info("Web server intentionally NOT started.")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			b.setState(true, false)
+			<-ctx.Done()
+			b.setState(false, false)
+		}()
+		return true
+	}
+
+	host := bootstrapConfig.Service.ServerBindAddr
+	if host == "" {
+		host = bootstrapConfig.Service.Host
+	}
+	addr := host + ":" + strconv.Itoa(bootstrapConfig.Service.Port)
+	timeout := time.Millisecond * time.Duration(bootstrapConfig.Service.Timeout)
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      b.drainMiddleware(http.TimeoutHandler(b.router, timeout, "Request timed out")),
+		WriteTimeout: timeout,
+		ReadTimeout:  timeout,
+	}
+
+	lc.Info("Web server starting (" + addr + ")")
+
+	b.setState(true, false)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// ListenAndServe returns as soon as Shutdown is called; in-flight requests are tracked by the
+		// shutdown go routine below.
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			lc.Error(fmt.Sprintf("Web server failed: %s", err.Error()))
+			b.setState(false, false)
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		<-ctx.Done()
+		shutdownTimeout, err := b.shutdownTimeout()
+		if err != nil {
+			lc.Warn(fmt.Sprintf("%s, using default of %s", err.Error(), shutdownTimeout))
+		}
+
+		lc.Info(fmt.Sprintf("Web server draining, waiting up to %s for in-flight requests", shutdownTimeout))
+		b.setState(true, true)
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			lc.Warn(fmt.Sprintf("Web server did not drain within %s, closing remaining connections: %s",
+				shutdownTimeout, err.Error()))
+			_ = server.Close()
+		}
+
+		b.setState(false, false)
+		lc.Info("Web server shut down")
+	}()
+
+	return true
+}
@@ -0,0 +1,73 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+type shutdownConfig string
+
+func (s shutdownConfig) GetShutdownTimeout() string {
+	return string(s)
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	tests := []struct {
+		name          string
+		configured    string
+		expected      time.Duration
+		expectedError bool
+	}{
+		{"Configured", "5s", 5 * time.Second, false},
+		{"Not configured", "", DefaultShutdownTimeout, false},
+		{"Invalid", "soon", DefaultShutdownTimeout, true},
+		{"Negative", "-1s", DefaultShutdownTimeout, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewHttpServer(mux.NewRouter(), shutdownConfig(tt.configured), true)
+
+			timeout, err := server.shutdownTimeout()
+
+			assert.Equal(t, tt.expected, timeout)
+			assert.Equal(t, tt.expectedError, err != nil)
+		})
+	}
+}
+
+func TestDrainMiddleware(t *testing.T) {
+	server := NewHttpServer(mux.NewRouter(), shutdownConfig(""), true)
+	handler := server.drainMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil))
+	assert.Empty(t, recorder.Header().Get("Connection"))
+
+	server.setState(true, true)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil))
+	assert.Equal(t, "close", recorder.Header().Get("Connection"))
+	assert.True(t, server.IsRunning(), "a draining server is still running until in-flight requests complete")
+}
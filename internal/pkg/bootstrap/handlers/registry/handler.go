@@ -0,0 +1,100 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/registrywatch"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// Configuration is implemented by the service configurations that define the watch of their registration.
+type Configuration interface {
+	// GetBootstrap returns the configuration elements required by the bootstrap.
+	GetBootstrap() bootstrapConfig.BootstrapConfiguration
+	// GetRegistryWatch returns the service's registration watch configuration.
+	GetRegistryWatch() registrywatch.RegistryWatchInfo
+}
+
+// Handler contains references to dependencies required by the registration watch bootstrap implementation.
+type Handler struct {
+	configuration Configuration
+	serviceKey    string
+}
+
+// NewHandler is a factory method that returns an initialized Handler receiver struct.
+func NewHandler(configuration Configuration, serviceKey string) Handler {
+	return Handler{configuration: configuration, serviceKey: serviceKey}
+}
+
+// BootstrapHandler fulfills the BootstrapHandler contract. When the service was registered with the registry, it
+// registers the deregistration check with Consul and checks the registration until the service is stopped, the
+// bootstrap deregistering the service once the check stopped so that it isn't registered again meanwhile.
+func (h Handler) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	info := h.configuration.GetRegistryWatch()
+	registryClient := bootstrapContainer.RegistryFrom(dic.Get)
+	if !info.Enabled || registryClient == nil {
+		return true
+	}
+
+	if err := info.Validate(); err != nil {
+		lc.Error("invalid RegistryWatch configuration: " + err.Error())
+		return false
+	}
+	interval, deregisterAfter, _ := info.Durations()
+
+	bootstrap := h.configuration.GetBootstrap()
+	var deregistration *registrywatch.Deregistration
+	if deregisterAfter > 0 {
+		if bootstrap.Registry.Type == "consul" {
+			deregistration = &registrywatch.Deregistration{
+				Url:        fmt.Sprintf("http://%s:%d", bootstrap.Registry.Host, bootstrap.Registry.Port),
+				ServiceKey: h.serviceKey,
+				CheckUrl:   bootstrap.Service.HealthCheck(),
+				Interval:   bootstrap.Service.CheckInterval,
+				After:      deregisterAfter,
+			}
+		} else {
+			lc.Warn(fmt.Sprintf("DeregisterCriticalAfter ignored, unsupported by the %s registry", bootstrap.Registry.Type))
+		}
+	}
+
+	watcher := registrywatch.NewWatcher(lc, registryClient, h.serviceKey, deregistration)
+	// the service was just registered by the bootstrap, the failure being retried when registering it again
+	_ = watcher.RegisterDeregistration()
+	dic.Update(di.ServiceConstructorMap{
+		container.RegistryWatcherName: func(get di.Get) interface{} {
+			return watcher
+		},
+	})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				watcher.Check()
+			}
+		}
+	}()
+	lc.Info(fmt.Sprintf("registration with the registry checked every %s", interval))
+	return true
+}
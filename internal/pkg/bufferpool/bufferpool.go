@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bufferpool provides the pool of the buffers the request bodies are read into on the hot paths, such as the
+// event ingestion, so that reading a body doesn't grow a new buffer for each request.
+package bufferpool
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// MaxPooledSize is the capacity above which a buffer isn't returned to the pool, so that an unusually large body
+// isn't held in memory once read
+const MaxPooledSize = 1 << 20
+
+var pool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// Get returns an empty buffer from the pool
+func Get() *bytes.Buffer {
+	buffer := pool.Get().(*bytes.Buffer)
+	buffer.Reset()
+	return buffer
+}
+
+// Put returns buffer to the pool. Neither buffer nor the slices of its contents may be used afterwards.
+func Put(buffer *bytes.Buffer) {
+	if buffer.Cap() > MaxPooledSize {
+		return
+	}
+	pool.Put(buffer)
+}
+
+// ReadAll reads r until EOF through a pooled buffer and returns a copy of what it read, which is thus allocated once
+// at its size rather than grown as by ioutil.ReadAll
+func ReadAll(r io.Reader) ([]byte, error) {
+	buffer := Get()
+	defer Put(buffer)
+	if _, err := buffer.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	data := make([]byte, buffer.Len())
+	copy(data, buffer.Bytes())
+	return data, nil
+}
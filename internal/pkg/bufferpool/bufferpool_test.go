@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bufferpool
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestReadAll(t *testing.T) {
+	data, err := ReadAll(strings.NewReader("event"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("event"), data)
+	assert.Equal(t, len(data), cap(data), "the data should be allocated at its size")
+
+	// the next read mustn't overwrite the data returned
+	_, err = ReadAll(strings.NewReader("other"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("event"), data)
+
+	data, err = ReadAll(strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	_, err = ReadAll(failingReader{})
+	assert.Error(t, err)
+}
+
+func TestGet(t *testing.T) {
+	buffer := Get()
+	buffer.WriteString("event")
+	Put(buffer)
+	assert.Zero(t, Get().Len(), "the buffers should be reset")
+
+	large := bytes.NewBuffer(make([]byte, 0, MaxPooledSize+1))
+	Put(large)
+	assert.NotSame(t, large, Get(), "the large buffers shouldn't be pooled")
+}
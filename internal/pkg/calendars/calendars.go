@@ -0,0 +1,196 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package calendars excludes days from the intervals of support-scheduler, e.g. the shutdown days of a plant, so that
+// the interval actions scheduled by an interval skip those days without the intervals being disabled by hand. The
+// excluded days are given as dates, weekdays and recurrence rules, the rules following the RRULE of iCalendar
+// (RFC 5545) so that holiday schedules can be imported from iCal files.
+package calendars
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+const (
+	// ApiCalendarRoute accepts the exclusion calendars of the intervals
+	ApiCalendarRoute = v2.ApiBase + "/interval/calendar"
+	// ApiAllCalendarRoute returns the exclusion calendars, the latest created first
+	ApiAllCalendarRoute = ApiCalendarRoute + "/" + v2.All
+	// ApiCalendarByNameRoute returns, with its upcoming excluded days, or deletes the exclusion calendar of the named
+	// interval
+	ApiCalendarByNameRoute = ApiCalendarRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+	// ApiCalendarICalRoute accepts the exclusion calendar of the named interval as an iCal file, whose events are
+	// the excluded days
+	ApiCalendarICalRoute = ApiCalendarByNameRoute + "/ical"
+)
+
+const (
+	// DateLayout is the layout of the dates of the calendars
+	DateLayout = "2006-01-02"
+	// UpcomingLimit is the maximum number of upcoming excluded days returned with a calendar
+	UpcomingLimit = 10
+	// upcomingDays is the number of days searched for the upcoming excluded days
+	upcomingDays = 366
+)
+
+// Calendar excludes days from the interval Interval. A day is excluded when it is one of the Dates or Weekdays, or
+// when it is excluded by one of the Rules.
+type Calendar struct {
+	Id       string `json:"id,omitempty"`
+	Created  int64  `json:"created,omitempty"`
+	Modified int64  `json:"modified,omitempty"`
+	// Interval is the name of the interval, which has one exclusion calendar at most
+	Interval string `json:"interval" validate:"required,edgex-dto-none-empty-string"`
+	// Timezone is the IANA time zone of the excluded days, e.g. Europe/Berlin, the local time zone when empty
+	Timezone string `json:"timezone,omitempty"`
+	// Dates are the excluded dates, formatted as DateLayout
+	Dates []string `json:"dates,omitempty"`
+	// Weekdays are the excluded days of the week, as the two-letter days of iCalendar, e.g. SA and SU
+	Weekdays []string `json:"weekdays,omitempty"`
+	Rules    []Rule   `json:"rules,omitempty"`
+}
+
+// Rule excludes Days days from each occurrence of the recurrence rule RRule, starting on Start. A rule without RRule
+// has a single occurrence, on Start.
+type Rule struct {
+	Summary string `json:"summary,omitempty"`
+	// Start is the date of the first occurrence, formatted as DateLayout
+	Start string `json:"start"`
+	// Days is the number of days excluded from each occurrence, 1 when 0
+	Days int `json:"days,omitempty"`
+	// RRule is the recurrence rule of iCalendar, e.g. FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25
+	RRule string `json:"rrule,omitempty"`
+	// Except are the dates of the occurrences that aren't excluded, formatted as DateLayout
+	Except []string `json:"except,omitempty"`
+}
+
+// Status tells whether a day is excluded by a calendar, and why
+type Status struct {
+	Excluded bool   `json:"excluded"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// ExcludedDay is a day excluded by a calendar, and why
+type ExcludedDay struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
+
+// Validate checks the time zone, the dates, the weekdays and the rules of the calendar
+func (c Calendar) Validate() errors.EdgeX {
+	_, err := c.compile()
+	return err
+}
+
+// Excludes returns whether the day of t, in the time zone of the calendar, is excluded
+func (c Calendar) Excludes(t time.Time) (Status, errors.EdgeX) {
+	compiled, err := c.compile()
+	if err != nil {
+		return Status{}, err
+	}
+	return compiled.status(compiled.day(t)), nil
+}
+
+// Upcoming returns, the earliest first, the excluded days of the coming year from the day of t, UpcomingLimit of them
+// at most
+func (c Calendar) Upcoming(t time.Time) ([]ExcludedDay, errors.EdgeX) {
+	compiled, err := c.compile()
+	if err != nil {
+		return nil, err
+	}
+	result := []ExcludedDay{}
+	day := compiled.day(t)
+	for i := 0; i < upcomingDays && len(result) < UpcomingLimit; i++ {
+		if status := compiled.status(day); status.Excluded {
+			result = append(result, ExcludedDay{Date: day.Format(DateLayout), Reason: status.Reason})
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return result, nil
+}
+
+// compiled is a calendar ready to be evaluated. Its days are dates at midnight UTC, so that the date arithmetic is
+// free of the daylight saving changes of the calendar's time zone.
+type compiled struct {
+	location *time.Location
+	dates    map[time.Time]bool
+	weekdays map[time.Weekday]bool
+	rules    []rule
+}
+
+func (c Calendar) compile() (compiled, errors.EdgeX) {
+	result := compiled{
+		location: time.Local,
+		dates:    make(map[time.Time]bool, len(c.Dates)),
+		weekdays: make(map[time.Weekday]bool, len(c.Weekdays)),
+	}
+	if c.Timezone != "" {
+		location, err := time.LoadLocation(c.Timezone)
+		if err != nil {
+			return result, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("calendar of interval %s has an unknown timezone %s", c.Interval, c.Timezone), err)
+		}
+		result.location = location
+	}
+	if len(c.Dates) == 0 && len(c.Weekdays) == 0 && len(c.Rules) == 0 {
+		return result, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("calendar of interval %s must have dates, weekdays or rules", c.Interval), nil)
+	}
+
+	for _, d := range c.Dates {
+		date, err := parseDate(d)
+		if err != nil {
+			return result, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("calendar of interval %s has an invalid date", c.Interval), err)
+		}
+		result.dates[date] = true
+	}
+	for _, w := range c.Weekdays {
+		weekday, ok := weekdays[strings.ToUpper(w)]
+		if !ok {
+			return result, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("calendar of interval %s has an invalid weekday %s, expecting MO, TU, WE, TH, FR, SA or SU", c.Interval, w), nil)
+		}
+		result.weekdays[weekday] = true
+	}
+	for i, r := range c.Rules {
+		compiledRule, err := r.compile()
+		if err != nil {
+			return result, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("calendar of interval %s has an invalid rule %d", c.Interval, i+1), err)
+		}
+		result.rules = append(result.rules, compiledRule)
+	}
+	return result, nil
+}
+
+// day returns the day of t in the time zone of the calendar
+func (c compiled) day(t time.Time) time.Time {
+	year, month, day := t.In(c.location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (c compiled) status(day time.Time) Status {
+	if c.dates[day] {
+		return Status{Excluded: true, Reason: fmt.Sprintf("%s is excluded", day.Format(DateLayout))}
+	}
+	if c.weekdays[day.Weekday()] {
+		return Status{Excluded: true, Reason: fmt.Sprintf("%s is excluded", day.Weekday())}
+	}
+	for _, r := range c.rules {
+		if r.excludes(day) {
+			return Status{Excluded: true, Reason: r.reason()}
+		}
+	}
+	return Status{}
+}
+
+func parseDate(s string) (time.Time, error) {
+	date, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return date, fmt.Errorf("date %s is not formatted as %s", s, DateLayout)
+	}
+	return date, nil
+}
@@ -0,0 +1,187 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package calendars
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(date string) time.Time {
+	t, _ := time.Parse(DateLayout, date)
+	return t.Add(12 * time.Hour)
+}
+
+func TestCalendar_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar Calendar
+		valid    bool
+	}{
+		{"Valid - dates", Calendar{Interval: "daily", Dates: []string{"2021-12-24"}}, true},
+		{"Valid - weekdays", Calendar{Interval: "daily", Timezone: "Europe/Berlin", Weekdays: []string{"sa", "SU"}}, true},
+		{"Valid - rules", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", RRule: "FREQ=YEARLY"}}}, true},
+		{"Invalid - nothing excluded", Calendar{Interval: "daily"}, false},
+		{"Invalid - timezone", Calendar{Interval: "daily", Timezone: "Europe/Nowhere", Dates: []string{"2021-12-24"}}, false},
+		{"Invalid - date", Calendar{Interval: "daily", Dates: []string{"24/12/2021"}}, false},
+		{"Invalid - weekday", Calendar{Interval: "daily", Weekdays: []string{"Sunday"}}, false},
+		{"Invalid - rule start", Calendar{Interval: "daily", Rules: []Rule{{RRule: "FREQ=YEARLY"}}}, false},
+		{"Invalid - rule days", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", Days: -1}}}, false},
+		{"Invalid - rule except", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", Except: []string{"2021"}}}}, false},
+		{"Invalid - hourly", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", RRule: "FREQ=HOURLY"}}}, false},
+		{"Invalid - no frequency", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", RRule: "BYMONTH=12"}}}, false},
+		{"Invalid - unsupported part", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", RRule: "FREQ=MONTHLY;BYSETPOS=-1"}}}, false},
+		{"Invalid - interval", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", RRule: "FREQ=DAILY;INTERVAL=0"}}}, false},
+		{"Invalid - count and until", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", RRule: "FREQ=DAILY;COUNT=2;UNTIL=20211231"}}}, false},
+		{"Invalid - numbered weekly weekday", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", RRule: "FREQ=WEEKLY;BYDAY=1MO"}}}, false},
+		{"Invalid - month day", Calendar{Interval: "daily", Rules: []Rule{{Start: "2021-12-25", RRule: "FREQ=MONTHLY;BYMONTHDAY=32"}}}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.calendar.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCalendar_Excludes(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		excluded []string
+		included []string
+	}{
+		{"Single day", Rule{Start: "2021-05-03"},
+			[]string{"2021-05-03"}, []string{"2021-05-02", "2022-05-03"}},
+		{"Yearly", Rule{Start: "2021-12-25", RRule: "FREQ=YEARLY"},
+			[]string{"2021-12-25", "2022-12-25"}, []string{"2020-12-25", "2022-12-24"}},
+		{"Yearly by month and numbered weekday", Rule{Start: "2021-01-01", RRule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH"},
+			[]string{"2021-11-25", "2022-11-24"}, []string{"2021-11-18", "2022-11-25"}},
+		{"Monthly last weekday", Rule{Start: "2021-01-01", RRule: "FREQ=MONTHLY;BYDAY=-1FR"},
+			[]string{"2021-01-29", "2021-04-30"}, []string{"2021-01-22", "2021-04-23"}},
+		{"Monthly last day", Rule{Start: "2021-01-01", RRule: "FREQ=MONTHLY;BYMONTHDAY=-1"},
+			[]string{"2021-02-28", "2024-02-29"}, []string{"2024-02-28"}},
+		{"Every other week", Rule{Start: "2021-01-04", RRule: "FREQ=WEEKLY;INTERVAL=2"},
+			[]string{"2021-01-04", "2021-01-18"}, []string{"2021-01-11", "2021-01-19"}},
+		{"Count", Rule{Start: "2021-01-04", RRule: "FREQ=DAILY;COUNT=3"},
+			[]string{"2021-01-04", "2021-01-06"}, []string{"2021-01-07"}},
+		{"Until", Rule{Start: "2021-01-01", RRule: "FREQ=WEEKLY;BYDAY=SA,SU;UNTIL=20210131T000000Z"},
+			[]string{"2021-01-30", "2021-01-31"}, []string{"2021-01-29", "2021-02-06"}},
+		{"Except", Rule{Start: "2021-12-25", RRule: "FREQ=YEARLY", Except: []string{"2022-12-25"}},
+			[]string{"2023-12-25"}, []string{"2022-12-25"}},
+		{"Several days", Rule{Start: "2021-12-24", Days: 9, RRule: "FREQ=YEARLY"},
+			[]string{"2021-12-24", "2022-01-01", "2022-12-30"}, []string{"2021-12-23", "2022-01-02"}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			calendar := Calendar{Interval: "daily", Timezone: "UTC", Rules: []Rule{testCase.rule}}
+			for _, date := range testCase.excluded {
+				status, err := calendar.Excludes(day(date))
+				require.NoError(t, err)
+				assert.True(t, status.Excluded, "%s should be excluded", date)
+			}
+			for _, date := range testCase.included {
+				status, err := calendar.Excludes(day(date))
+				require.NoError(t, err)
+				assert.False(t, status.Excluded, "%s should not be excluded", date)
+			}
+		})
+	}
+}
+
+func TestCalendar_ExcludesDatesAndWeekdays(t *testing.T) {
+	calendar := Calendar{Interval: "daily", Timezone: "Asia/Tokyo", Dates: []string{"2021-05-03"}, Weekdays: []string{"SU"}}
+
+	status, err := calendar.Excludes(time.Date(2021, 5, 2, 20, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, Status{Excluded: true, Reason: "2021-05-03 is excluded"}, status, "the date should be that of the calendar's time zone")
+
+	status, err = calendar.Excludes(time.Date(2021, 5, 2, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, Status{Excluded: true, Reason: "Sunday is excluded"}, status)
+
+	status, err = calendar.Excludes(time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.False(t, status.Excluded)
+
+	_, err = Calendar{Interval: "daily"}.Excludes(time.Now())
+	assert.Error(t, err)
+}
+
+func TestCalendar_Upcoming(t *testing.T) {
+	calendar := Calendar{Interval: "daily", Timezone: "UTC", Dates: []string{"2021-12-31"},
+		Rules: []Rule{{Summary: "Christmas", Start: "2021-12-25", RRule: "FREQ=YEARLY"}}}
+
+	upcoming, err := calendar.Upcoming(day("2021-12-26"))
+	require.NoError(t, err)
+	assert.Equal(t, []ExcludedDay{
+		{Date: "2021-12-31", Reason: "2021-12-31 is excluded"},
+		{Date: "2022-12-25", Reason: "Christmas is excluded"},
+	}, upcoming)
+
+	calendar = Calendar{Interval: "daily", Timezone: "UTC", Weekdays: []string{"SA", "SU"}}
+	upcoming, err = calendar.Upcoming(day("2021-12-26"))
+	require.NoError(t, err)
+	assert.Len(t, upcoming, UpcomingLimit)
+}
+
+func TestParseICal(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"X-WR-TIMEZONE:Europe/Berlin\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Christmas shutdown\\, plant 1\r\n" +
+		"DTSTART;VALUE=DATE:20211224\r\n" +
+		"DTEND;VALUE=DATE:20220102\r\n" +
+		"RRULE:FREQ=YEARLY\r\n" +
+		"EXDATE;VALUE=DATE:20231224\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Maintenance\r\n" +
+		"DTSTART;TZID=Europe/Berlin:20210105T080000\r\n" +
+		"DTEND;TZID=Europe/Berlin:20210106T170000\r\n" +
+		"RRULE:FREQ=MONTHLY;BYDAY=1TU;\r\n" +
+		" COUNT=12\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20210501T000000Z\r\n" +
+		"DURATION:P1W\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	calendar, err := ParseICal([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, Calendar{
+		Timezone: "Europe/Berlin",
+		Rules: []Rule{
+			{Summary: "Christmas shutdown, plant 1", Start: "2021-12-24", Days: 9, RRule: "FREQ=YEARLY", Except: []string{"2023-12-24"}},
+			{Summary: "Maintenance", Start: "2021-01-05", Days: 2, RRule: "FREQ=MONTHLY;BYDAY=1TU;COUNT=12"},
+			{Start: "2021-05-01", Days: 7},
+		},
+	}, calendar)
+	calendar.Interval = "daily"
+	assert.NoError(t, calendar.Validate())
+
+	invalid := []string{
+		"",
+		"BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VEVENT\r\nSUMMARY:no start\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nDTSTART:2021\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nDTSTART:20211224\r\n",
+		"BEGIN:VEVENT\r\nnot a property\r\nEND:VEVENT\r\n",
+	}
+	for _, data := range invalid {
+		_, err := ParseICal([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
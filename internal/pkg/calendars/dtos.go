@@ -0,0 +1,36 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package calendars
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Store provides the exclusion calendars of the intervals when they are executed
+type Store interface {
+	CalendarByInterval(name string) (Calendar, errors.EdgeX)
+}
+
+// AddCalendarRequest defines the request content of an exclusion calendar added through ApiCalendarRoute
+type AddCalendarRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	Calendar              Calendar `json:"calendar" validate:"required"`
+}
+
+// CalendarResponse defines the response content of ApiCalendarByNameRoute, with the upcoming excluded days of the
+// interval
+type CalendarResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Calendar               Calendar      `json:"calendar"`
+	Upcoming               []ExcludedDay `json:"upcoming"`
+}
+
+// MultiCalendarsResponse defines the response content of ApiAllCalendarRoute
+type MultiCalendarsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Calendars              []Calendar `json:"calendars"`
+}
@@ -0,0 +1,170 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package calendars
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationDays matches the DURATION of the events lasting whole days or weeks, e.g. P3D or P1W
+var durationDays = regexp.MustCompile(`^P(\d+)([DW])$`)
+
+// ParseICal returns the calendar excluding the days of the events of the iCal file data, each event being a rule.
+// The dates of the events are taken as they are written, the time zone of the calendar being the X-WR-TIMEZONE of the
+// file, if any. The calendar has no interval.
+func ParseICal(data []byte) (Calendar, error) {
+	var result Calendar
+	var event *icalEvent
+	lines := unfold(string(data))
+	for i, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			return result, fmt.Errorf("line %d is not an iCal property", i+1)
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = &icalEvent{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event == nil {
+				return result, fmt.Errorf("line %d ends an event that doesn't begin", i+1)
+			}
+			if err := event.setDays(); err != nil {
+				return result, fmt.Errorf("event ending on line %d: %s", i+1, err.Error())
+			}
+			result.Rules = append(result.Rules, event.Rule)
+			event = nil
+		case name == "X-WR-TIMEZONE" && event == nil:
+			result.Timezone = value
+		case event == nil:
+			// the properties of the calendar and of its other components don't exclude days
+		case name == "SUMMARY":
+			event.Summary = unescape(value)
+		case name == "DTSTART":
+			start, err := icalDate(value)
+			if err != nil {
+				return result, fmt.Errorf("line %d: %s", i+1, err.Error())
+			}
+			event.Start = start
+		case name == "DTEND":
+			// an end date is excluded from the event, an end time is included unless it is midnight
+			date, err := icalDate(value)
+			if err != nil {
+				return result, fmt.Errorf("line %d: %s", i+1, err.Error())
+			}
+			event.end = date
+			event.endIncluded = !strings.Contains(params, "VALUE=DATE") && len(value) > 8 && !strings.HasPrefix(value[8:], "T000000")
+		case name == "DURATION":
+			if match := durationDays.FindStringSubmatch(value); match != nil {
+				days, _ := strconv.Atoi(match[1])
+				if match[2] == "W" {
+					days *= 7
+				}
+				event.Days = days
+			}
+		case name == "RRULE":
+			event.RRule = value
+		case name == "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				date, err := icalDate(v)
+				if err != nil {
+					return result, fmt.Errorf("line %d: %s", i+1, err.Error())
+				}
+				event.Except = append(event.Except, date)
+			}
+		}
+	}
+	if event != nil {
+		return result, fmt.Errorf("event doesn't end")
+	}
+	if len(result.Rules) == 0 {
+		return result, fmt.Errorf("no event found")
+	}
+	return result, nil
+}
+
+// icalEvent is the rule of a VEVENT, with the DTEND date of the event if any
+type icalEvent struct {
+	Rule
+	end         string
+	endIncluded bool
+}
+
+// setDays sets the days of the rule from the end of the event, if any
+func (e *icalEvent) setDays() error {
+	if e.Start == "" {
+		return fmt.Errorf("DTSTART is missing")
+	}
+	if e.end == "" {
+		return nil
+	}
+	startDate, err := parseDate(e.Start)
+	if err != nil {
+		return err
+	}
+	endDate, err := parseDate(e.end)
+	if err != nil {
+		return err
+	}
+	days := daysBetween(startDate, endDate)
+	if e.endIncluded {
+		days++
+	}
+	if days > 1 {
+		e.Days = days
+	}
+	return nil
+}
+
+// unfold joins the folded lines of an iCal file, which continue on the lines starting with a space or a tab
+func unfold(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\n ", "")
+	data = strings.ReplaceAll(data, "\n\t", "")
+	return strings.Split(data, "\n")
+}
+
+// splitProperty splits a content line NAME;PARAMS:VALUE, the parameters possibly quoting colons
+func splitProperty(line string) (name string, params string, value string, ok bool) {
+	quoted := false
+	for i, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == ':' && !quoted:
+			nameParams := strings.SplitN(line[:i], ";", 2)
+			name = strings.ToUpper(strings.TrimSpace(nameParams[0]))
+			if len(nameParams) == 2 {
+				params = strings.ToUpper(nameParams[1])
+			}
+			return name, params, strings.TrimSpace(line[i+1:]), name != ""
+		}
+	}
+	return "", "", "", false
+}
+
+// icalDate returns the date of an iCal DATE or DATE-TIME, formatted as DateLayout
+func icalDate(value string) (string, error) {
+	if len(value) < 8 {
+		return "", fmt.Errorf("%s is not an iCal date", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return "", fmt.Errorf("%s is not an iCal date", value)
+	}
+	return date.Format(DateLayout), nil
+}
+
+func unescape(text string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(text)
+}
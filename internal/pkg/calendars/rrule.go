@@ -0,0 +1,356 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package calendars
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The frequencies of the recurrence rules, the rules recurring at most daily since the calendars exclude days
+const (
+	daily   = "DAILY"
+	weekly  = "WEEKLY"
+	monthly = "MONTHLY"
+	yearly  = "YEARLY"
+)
+
+// maxDays bounds the days excluded from each occurrence of a rule
+const maxDays = 366
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// rule is a compiled Rule
+type rule struct {
+	Rule
+	start      time.Time
+	days       int
+	recurrence *recurrence
+	except     map[time.Time]bool
+}
+
+func (r Rule) compile() (rule, error) {
+	result := rule{Rule: r, days: r.Days, except: make(map[time.Time]bool, len(r.Except))}
+	var err error
+	if result.start, err = parseDate(r.Start); err != nil {
+		return result, err
+	}
+	if r.Days < 0 || r.Days > maxDays {
+		return result, fmt.Errorf("days %d must be between 0 and %d", r.Days, maxDays)
+	} else if r.Days == 0 {
+		result.days = 1
+	}
+	for _, e := range r.Except {
+		date, err := parseDate(e)
+		if err != nil {
+			return result, err
+		}
+		result.except[date] = true
+	}
+	if strings.TrimSpace(r.RRule) != "" {
+		if result.recurrence, err = parseRecurrence(r.RRule, result.start); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// excludes tells whether day is one of the days of an occurrence of the rule
+func (r rule) excludes(day time.Time) bool {
+	for i := 0; i < r.days; i++ {
+		if r.occurs(day.AddDate(0, 0, -i)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r rule) occurs(day time.Time) bool {
+	if day.Before(r.start) || r.except[day] {
+		return false
+	}
+	if r.recurrence == nil {
+		return day.Equal(r.start)
+	}
+	return r.recurrence.occurs(day)
+}
+
+func (r rule) reason() string {
+	description := r.Summary
+	if description == "" {
+		description = "rule starting on " + r.Start
+		if r.RRule != "" {
+			description += " " + r.RRule
+		}
+	}
+	return fmt.Sprintf("%s is excluded", description)
+}
+
+// weekdayNum is a day of the BYDAY part of a recurrence rule, e.g. -1FR for the last Friday
+type weekdayNum struct {
+	ordinal int
+	weekday time.Weekday
+}
+
+// recurrence is the subset of the RRULE of RFC 5545 recurring daily at most: FREQ, INTERVAL, COUNT, UNTIL, WKST,
+// BYMONTH, BYMONTHDAY and BYDAY. The parts left out of the rule are derived from the start, as RFC 5545 specifies.
+type recurrence struct {
+	start      time.Time
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	weekStart  time.Weekday
+	byMonth    []time.Month
+	byMonthDay []int
+	byDay      []weekdayNum
+}
+
+func parseRecurrence(s string, start time.Time) (*recurrence, error) {
+	r := &recurrence{start: start, interval: 1, weekStart: time.Monday}
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "RRULE:"), ";") {
+		nameValue := strings.SplitN(part, "=", 2)
+		if len(nameValue) != 2 {
+			return nil, fmt.Errorf("rrule part %s is not formatted as NAME=VALUE", part)
+		}
+		name, value := strings.ToUpper(nameValue[0]), strings.ToUpper(nameValue[1])
+		var err error
+		switch name {
+		case "FREQ":
+			switch value {
+			case daily, weekly, monthly, yearly:
+				r.freq = value
+			default:
+				return nil, fmt.Errorf("rrule frequency %s is not supported, expecting DAILY, WEEKLY, MONTHLY or YEARLY", value)
+			}
+		case "INTERVAL":
+			r.interval, err = parsePositive(name, value)
+		case "COUNT":
+			r.count, err = parsePositive(name, value)
+		case "UNTIL":
+			// the UNTIL time, if any, doesn't matter to the days
+			if len(value) < 8 {
+				return nil, fmt.Errorf("rrule until %s is not a date", value)
+			}
+			r.until, err = time.Parse("20060102", value[:8])
+		case "WKST":
+			var ok bool
+			if r.weekStart, ok = weekdays[value]; !ok {
+				err = fmt.Errorf("rrule week start %s is not a weekday", value)
+			}
+		case "BYMONTH":
+			err = parseList(name, value, func(n int) bool { return n >= 1 && n <= 12 }, func(n int) {
+				r.byMonth = append(r.byMonth, time.Month(n))
+			})
+		case "BYMONTHDAY":
+			err = parseList(name, value, func(n int) bool { return n != 0 && n >= -31 && n <= 31 }, func(n int) {
+				r.byMonthDay = append(r.byMonthDay, n)
+			})
+		case "BYDAY":
+			r.byDay, err = parseByDay(value)
+		default:
+			return nil, fmt.Errorf("rrule part %s is not supported", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.freq == "" {
+		return nil, fmt.Errorf("rrule %s has no FREQ", s)
+	}
+	if r.count > 0 && !r.until.IsZero() {
+		return nil, fmt.Errorf("rrule %s must not have both COUNT and UNTIL", s)
+	}
+	for _, d := range r.byDay {
+		if d.ordinal != 0 && r.freq != monthly && r.freq != yearly {
+			return nil, fmt.Errorf("rrule %s has numbered weekdays, which need a MONTHLY or YEARLY frequency", s)
+		}
+	}
+	// the parts left out are derived from the start
+	if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+		switch r.freq {
+		case weekly:
+			r.byDay = []weekdayNum{{weekday: start.Weekday()}}
+		case yearly:
+			if len(r.byMonth) == 0 {
+				r.byMonth = []time.Month{start.Month()}
+			}
+			r.byMonthDay = []int{start.Day()}
+		case monthly:
+			r.byMonthDay = []int{start.Day()}
+		}
+	}
+	return r, nil
+}
+
+func parsePositive(name string, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("rrule %s %s is not a positive integer", strings.ToLower(name), value)
+	}
+	return n, nil
+}
+
+func parseList(name string, value string, valid func(int) bool, add func(int)) error {
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil || !valid(n) {
+			return fmt.Errorf("rrule %s %s is out of range", strings.ToLower(name), v)
+		}
+		add(n)
+	}
+	return nil
+}
+
+func parseByDay(value string) ([]weekdayNum, error) {
+	var result []weekdayNum
+	for _, v := range strings.Split(value, ",") {
+		if len(v) < 2 {
+			return nil, fmt.Errorf("rrule byday %s is not a weekday", v)
+		}
+		weekday, ok := weekdays[v[len(v)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("rrule byday %s is not a weekday", v)
+		}
+		d := weekdayNum{weekday: weekday}
+		if ordinal := v[:len(v)-2]; ordinal != "" {
+			n, err := strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("rrule byday %s has an invalid ordinal", v)
+			}
+			d.ordinal = n
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// occurs tells whether the recurrence occurs on day, which is not before its start
+func (r *recurrence) occurs(day time.Time) bool {
+	if !r.until.IsZero() && day.After(r.until) {
+		return false
+	}
+	if !r.matches(day) {
+		return false
+	}
+	if r.count == 0 {
+		return true
+	}
+	// the COUNT-th occurrence ends the recurrence
+	n := 0
+	for d := r.start; !d.After(day); d = d.AddDate(0, 0, 1) {
+		if r.matches(d) {
+			n++
+			if n > r.count {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matches tells whether day belongs to a period of the recurrence and to its BYxxx parts
+func (r *recurrence) matches(day time.Time) bool {
+	if r.period(day)%r.interval != 0 {
+		return false
+	}
+	if len(r.byMonth) > 0 && !containsMonth(r.byMonth, day.Month()) {
+		return false
+	}
+	if len(r.byMonthDay) > 0 && !matchesMonthDay(r.byMonthDay, day) {
+		return false
+	}
+	if len(r.byDay) > 0 && !r.matchesByDay(day) {
+		return false
+	}
+	return true
+}
+
+// period returns the number of periods of the recurrence frequency from its start to day
+func (r *recurrence) period(day time.Time) int {
+	switch r.freq {
+	case weekly:
+		return daysBetween(r.weekOf(r.start), r.weekOf(day)) / 7
+	case monthly:
+		return (day.Year()-r.start.Year())*12 + int(day.Month()) - int(r.start.Month())
+	case yearly:
+		return day.Year() - r.start.Year()
+	default:
+		return daysBetween(r.start, day)
+	}
+}
+
+// weekOf returns the first day of the week of day
+func (r *recurrence) weekOf(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(r.weekStart) + 7) % 7))
+}
+
+// matchesByDay tells whether day is one of the BYDAY days. The numbered weekdays are counted in the month for a
+// MONTHLY recurrence or a recurrence by month, in the year otherwise.
+func (r *recurrence) matchesByDay(day time.Time) bool {
+	inMonth := r.freq == monthly || len(r.byMonth) > 0
+	for _, d := range r.byDay {
+		if d.weekday != day.Weekday() {
+			continue
+		}
+		if d.ordinal == 0 {
+			return true
+		}
+		position, length := day.YearDay(), daysInYear(day.Year())
+		if inMonth {
+			position, length = day.Day(), daysInMonth(day)
+		}
+		if d.ordinal > 0 && (position-1)/7+1 == d.ordinal {
+			return true
+		}
+		if d.ordinal < 0 && -((length-position)/7+1) == d.ordinal {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthDay tells whether day is one of the days of the month, the negative days counting from the month end
+func matchesMonthDay(monthDays []int, day time.Time) bool {
+	for _, d := range monthDays {
+		if d == day.Day() || (d < 0 && daysInMonth(day)+d+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func daysInMonth(day time.Time) int {
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
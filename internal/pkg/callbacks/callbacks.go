@@ -0,0 +1,451 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package callbacks publishes the changes of the devices, device profiles, provision watchers and device services
+// made in core-metadata to their device service on the message bus, instead of requesting the callback API of the
+// device service. The changes are first stored in an outbox of the database, and published from it in order for each
+// device service, again and again until they are published, or acknowledged when acknowledgements are expected, so
+// that a device service offline for a while doesn't miss them.
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiCallbackRoute is the base route of the callbacks pending in the outbox
+	ApiCallbackRoute = v2.ApiBase + "/callback"
+	// ApiAllCallbackRoute returns the callbacks pending in the outbox, the oldest first
+	ApiAllCallbackRoute = ApiCallbackRoute + "/" + v2.All
+	// ApiCallbackByIdRoute acknowledges a callback when deleted, as the messages of the AckTopic do
+	ApiCallbackByIdRoute = ApiCallbackRoute + "/" + v2.Id + "/{" + v2.Id + "}"
+)
+
+// The types of the objects the callbacks are about
+const (
+	Device           = "device"
+	DeviceProfile    = "deviceProfile"
+	DeviceService    = "deviceService"
+	ProvisionWatcher = "provisionWatcher"
+)
+
+// The actions of the callbacks
+const (
+	Add    = "add"
+	Update = "update"
+	Delete = "delete"
+)
+
+// The defaults of CallbacksInfo
+const (
+	DefaultTopicPrefix      = "edgex/callbacks"
+	DefaultRetryInterval    = time.Second
+	DefaultMaxRetryInterval = 5 * time.Minute
+	DefaultBatchSize        = 100
+)
+
+// CallbacksInfo configures the callbacks published on the message bus
+type CallbacksInfo struct {
+	// Enabled publishes the callbacks on the message bus, the callback API of the device services being requested
+	// otherwise
+	Enabled bool
+	// TopicPrefix prefixes the topics of the callbacks, to which /<device service name>/<type>/<action> is added,
+	// DefaultTopicPrefix when empty
+	TopicPrefix string
+	// AckTopic is the topic of the acknowledgements of the device services, {"id": "<callback id>"}, a callback
+	// being published again until acknowledged. The callbacks are delivered once published when empty.
+	AckTopic string
+	// RetryInterval is how often the outbox is checked, and the wait before the second attempt of a callback, e.g.
+	// "1s", doubled on each attempt up to MaxRetryInterval
+	RetryInterval string
+	// MaxRetryInterval is the longest wait between two attempts of a callback, e.g. "5m"
+	MaxRetryInterval string
+	// MaxAge is how long a callback is attempted, e.g. "72h", the older ones being dropped; forever when empty
+	MaxAge string
+	// BatchSize is the number of the oldest callbacks checked each time, DefaultBatchSize when 0
+	BatchSize int
+}
+
+// Validate checks the durations and the batch size
+func (info CallbacksInfo) Validate() error {
+	if info.BatchSize < 0 {
+		return fmt.Errorf("BatchSize %d must not be negative", info.BatchSize)
+	}
+	_, err := info.durations()
+	return err
+}
+
+// durations returns the retry interval, max retry interval and max age, their defaults applied
+func (info CallbacksInfo) durations() ([3]time.Duration, error) {
+	durations := [3]time.Duration{DefaultRetryInterval, DefaultMaxRetryInterval, 0}
+	for i, setting := range []struct {
+		name  string
+		value string
+	}{{"RetryInterval", info.RetryInterval}, {"MaxRetryInterval", info.MaxRetryInterval}, {"MaxAge", info.MaxAge}} {
+		if setting.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(setting.value)
+		if err != nil {
+			return durations, fmt.Errorf("invalid %s %s: %s", setting.name, setting.value, err.Error())
+		}
+		if d <= 0 {
+			return durations, fmt.Errorf("%s %s must be positive", setting.name, setting.value)
+		}
+		durations[i] = d
+	}
+	if durations[1] < durations[0] {
+		return durations, fmt.Errorf("MaxRetryInterval %s must not be shorter than RetryInterval %s", durations[1], durations[0])
+	}
+	return durations, nil
+}
+
+// Message is a callback of a device service, published on the message bus
+type Message struct {
+	Id string `json:"id"`
+	// ServiceName is the device service the callback is published to
+	ServiceName string `json:"serviceName"`
+	// Type is the type of the object changed, e.g. "device"
+	Type string `json:"type"`
+	// Action is the change, e.g. "add"
+	Action string `json:"action"`
+	// Name is the name of the object changed
+	Name string `json:"name"`
+	// Payload is the body of the callback request of the REST API, e.g. an AddDeviceRequest, or the DTO of the object
+	// deleted
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Created int64           `json:"created"`
+	// Attempts is the number of times the callback was published, or failed to be
+	Attempts int `json:"attempts,omitempty"`
+	// NextAttempt is when the callback is published again if still pending
+	NextAttempt int64 `json:"nextAttempt,omitempty"`
+	// LastError is the error of the last failed attempt
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Ack is the message of a device service acknowledging a callback on the AckTopic
+type Ack struct {
+	Id string `json:"id"`
+}
+
+// MultiCallbacksResponse is the response returning the callbacks pending in the outbox
+type MultiCallbacksResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Callbacks              []Message `json:"callbacks"`
+}
+
+// Store keeps the outbox of the callbacks, e.g. the core-metadata DBClient
+type Store interface {
+	AddCallbackMessage(m Message) (Message, errors.EdgeX)
+	// AllCallbackMessages returns the callbacks by offset and limit, the oldest first
+	AllCallbackMessages(offset int, limit int) ([]Message, errors.EdgeX)
+	UpdateCallbackMessage(m Message) errors.EdgeX
+	DeleteCallbackMessageById(id string) errors.EdgeX
+}
+
+// MessageClient publishes to and subscribes to the topics of the message bus, e.g. a messaging.MessageClient
+type MessageClient interface {
+	Publish(message msgTypes.MessageEnvelope, topic string) error
+	Subscribe(topics []msgTypes.TopicChannel, messageErrors chan error) error
+}
+
+// Topic returns the topic of the callbacks of the given type and action published to a device service
+func Topic(prefix string, serviceName string, objectType string, action string) string {
+	return strings.Join([]string{prefix, serviceName, objectType, action}, "/")
+}
+
+// Outbox publishes the callbacks it keeps in its Store, once started
+type Outbox struct {
+	lc               logger.LoggingClient
+	store            Store
+	client           MessageClient
+	topicPrefix      string
+	ackTopic         string
+	retryInterval    time.Duration
+	maxRetryInterval time.Duration
+	maxAge           time.Duration
+	batchSize        int
+	// wake triggers the publication of the callbacks before the next retry interval
+	wake chan struct{}
+	// mutex serializes the passes over the outbox, which the acknowledgements trigger too
+	mutex sync.Mutex
+	// lastCreated is the creation of the last callback queued, the callbacks being ordered by their creation
+	lastCreated  int64
+	createdMutex sync.Mutex
+}
+
+// NewOutbox creates the outbox of the callbacks configured by info, kept in store and published with client
+func NewOutbox(info CallbacksInfo, store Store, client MessageClient, lc logger.LoggingClient) (*Outbox, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	durations, _ := info.durations()
+	o := &Outbox{
+		lc:               lc,
+		store:            store,
+		client:           client,
+		topicPrefix:      info.TopicPrefix,
+		ackTopic:         info.AckTopic,
+		retryInterval:    durations[0],
+		maxRetryInterval: durations[1],
+		maxAge:           durations[2],
+		batchSize:        info.BatchSize,
+		wake:             make(chan struct{}, 1),
+	}
+	if o.topicPrefix == "" {
+		o.topicPrefix = DefaultTopicPrefix
+	}
+	if o.batchSize == 0 {
+		o.batchSize = DefaultBatchSize
+	}
+	return o, nil
+}
+
+// Start subscribes to the AckTopic, if any, and starts publishing the callbacks until ctx is done. The callbacks
+// still pending then are published once the service is started again.
+func (o *Outbox) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	if o.ackTopic != "" {
+		acks := make(chan msgTypes.MessageEnvelope)
+		messageErrors := make(chan error)
+		if err := o.client.Subscribe([]msgTypes.TopicChannel{{Topic: o.ackTopic, Messages: acks}}, messageErrors); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %s", o.ackTopic, err.Error())
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case err := <-messageErrors:
+					o.lc.Error(fmt.Sprintf("failed to receive the callback acknowledgements: %s", err.Error()))
+				case envelope := <-acks:
+					o.receiveAck(envelope)
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(o.retryInterval)
+		defer ticker.Stop()
+		for {
+			o.publishDue()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-o.wake:
+			}
+		}
+	}()
+	return nil
+}
+
+// Enqueue stores the callback of the given type and action in the outbox, to be published to the device service
+// serviceName along with payload
+func (o *Outbox) Enqueue(serviceName string, objectType string, action string, name string, payload interface{}) errors.EdgeX {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to encode the %s callback of %s %s", action, objectType, name), err)
+	}
+
+	o.createdMutex.Lock()
+	defer o.createdMutex.Unlock()
+	// the callbacks queued within the same millisecond are given distinct creations to keep them in order
+	created := common.MakeTimestamp()
+	if created <= o.lastCreated {
+		created = o.lastCreated + 1
+	}
+	m, edgexErr := o.store.AddCallbackMessage(Message{
+		Id:          uuid.New().String(),
+		ServiceName: serviceName,
+		Type:        objectType,
+		Action:      action,
+		Name:        name,
+		Payload:     data,
+		Created:     created,
+	})
+	if edgexErr != nil {
+		return errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+	o.lastCreated = created
+	o.lc.Debug(fmt.Sprintf("%s callback %s of %s %s queued for device service %s", action, m.Id, objectType, name, serviceName))
+	o.trigger()
+	return nil
+}
+
+// Acknowledge removes the callback of the given id from the outbox, the next callback of its device service being
+// published then
+func (o *Outbox) Acknowledge(id string) errors.EdgeX {
+	if err := o.store.DeleteCallbackMessageById(id); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	o.lc.Debug(fmt.Sprintf("callback %s acknowledged", id))
+	o.trigger()
+	return nil
+}
+
+func (o *Outbox) trigger() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Outbox) receiveAck(envelope msgTypes.MessageEnvelope) {
+	var ack Ack
+	if err := json.Unmarshal(envelope.Payload, &ack); err != nil || ack.Id == "" {
+		o.lc.Warn(fmt.Sprintf("ignoring an invalid callback acknowledgement on %s", o.ackTopic))
+		return
+	}
+	if err := o.Acknowledge(ack.Id); err != nil {
+		// the callback was acknowledged already, or dropped
+		o.lc.Debug(fmt.Sprintf("callback acknowledgement ignored: %s", err.Error()))
+	}
+}
+
+// publishDue publishes the first callback pending for each device service, when due, so that a device service
+// receives its callbacks in the order of the changes
+func (o *Outbox) publishDue() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	messages, err := o.store.AllCallbackMessages(0, o.batchSize)
+	if err != nil {
+		o.lc.Error(fmt.Sprintf("failed to read the callback outbox: %s", err.Error()))
+		return
+	}
+	now := common.MakeTimestamp()
+	pending := make(map[string]bool)
+	for _, m := range messages {
+		if pending[m.ServiceName] {
+			continue
+		}
+		if o.maxAge > 0 && now-m.Created > o.maxAge.Milliseconds() {
+			o.lc.Error(fmt.Sprintf("dropping the %s callback %s of %s %s for device service %s after %d attempts, older than %s",
+				m.Action, m.Id, m.Type, m.Name, m.ServiceName, m.Attempts, o.maxAge))
+			if err := o.store.DeleteCallbackMessageById(m.Id); err != nil {
+				o.lc.Error(fmt.Sprintf("failed to drop the callback %s: %s", m.Id, err.Error()))
+				pending[m.ServiceName] = true
+			}
+			continue
+		}
+		pending[m.ServiceName] = true
+		if m.NextAttempt <= now {
+			o.publish(m, now)
+		}
+	}
+}
+
+// publish publishes m, which is removed from the outbox unless acknowledgements are expected or its publication
+// failed; it is published again once its next attempt is due otherwise
+func (o *Outbox) publish(m Message, now int64) {
+	m.Attempts++
+	m.NextAttempt = now + o.backoff(m.Attempts).Milliseconds()
+	m.LastError = ""
+
+	data, err := json.Marshal(m)
+	if err == nil {
+		err = o.client.Publish(msgTypes.MessageEnvelope{
+			CorrelationID: m.Id,
+			Payload:       data,
+			ContentType:   clients.ContentTypeJSON,
+		}, Topic(o.topicPrefix, m.ServiceName, m.Type, m.Action))
+	}
+	if err != nil {
+		m.LastError = err.Error()
+		o.lc.Warn(fmt.Sprintf("failed to publish the %s callback %s of %s %s to device service %s, attempt %d: %s",
+			m.Action, m.Id, m.Type, m.Name, m.ServiceName, m.Attempts, err.Error()))
+	} else if o.ackTopic == "" {
+		if err := o.store.DeleteCallbackMessageById(m.Id); err != nil {
+			o.lc.Error(fmt.Sprintf("failed to remove the published callback %s: %s", m.Id, err.Error()))
+		}
+		return
+	}
+	if err := o.store.UpdateCallbackMessage(m); err != nil {
+		o.lc.Error(fmt.Sprintf("failed to update the callback %s: %s", m.Id, err.Error()))
+	}
+}
+
+// backoff returns the wait after the given attempt, doubling the retry interval on each attempt
+func (o *Outbox) backoff(attempts int) time.Duration {
+	wait := float64(o.retryInterval) * math.Pow(2, float64(attempts-1))
+	if wait > float64(o.maxRetryInterval) {
+		return o.maxRetryInterval
+	}
+	return time.Duration(wait)
+}
+
+// AllCallbacks handles the request returning the callbacks pending in the outbox, the oldest first
+func (o *Outbox) AllCallbacks(maxResultCount int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, maxResultCount)
+		var messages []Message
+		if err == nil {
+			messages, err = o.store.AllCallbackMessages(offset, limit)
+		}
+		if err != nil {
+			o.writeError(w, r, err)
+			return
+		}
+		utils.WriteHttpHeader(w, r.Context(), http.StatusOK)
+		pkg.Encode(MultiCallbacksResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+			Callbacks:    messages,
+		}, w, o.lc)
+	}
+}
+
+// AcknowledgeCallbackById handles the request acknowledging the callback whose id is in the path
+func (o *Outbox) AcknowledgeCallbackById(w http.ResponseWriter, r *http.Request) {
+	if err := o.Acknowledge(mux.Vars(r)[v2.Id]); err != nil {
+		o.writeError(w, r, err)
+		return
+	}
+	utils.WriteHttpHeader(w, r.Context(), http.StatusOK)
+	pkg.Encode(commonDTO.NewBaseResponse("", "", http.StatusOK), w, o.lc)
+}
+
+func (o *Outbox) writeError(w http.ResponseWriter, r *http.Request, err errors.EdgeX) {
+	correlationId := correlation.FromContext(r.Context())
+	o.lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+	o.lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+	utils.WriteHttpHeader(w, r.Context(), err.Code())
+	pkg.Encode(commonDTO.NewBaseResponse("", err.Message(), err.Code()), w, o.lc)
+}
+
+// LoadRestRoutes adds the routes of the callbacks pending in outbox to router, none when outbox is nil
+func LoadRestRoutes(router *mux.Router, outbox *Outbox, maxResultCount int) {
+	if outbox == nil {
+		return
+	}
+	router.HandleFunc(ApiAllCallbackRoute, outbox.AllCallbacks(maxResultCount)).Methods(http.MethodGet)
+	router.HandleFunc(ApiCallbackByIdRoute, outbox.AcknowledgeCallbackById).Methods(http.MethodDelete)
+}
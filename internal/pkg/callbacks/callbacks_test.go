@@ -0,0 +1,297 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package callbacks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	edgexErrors "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type store struct {
+	mutex    sync.Mutex
+	messages map[string]Message
+}
+
+func newStore() *store {
+	return &store{messages: make(map[string]Message)}
+}
+
+func (s *store) AddCallbackMessage(m Message) (Message, edgexErrors.EdgeX) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.messages[m.Id] = m
+	return m, nil
+}
+
+func (s *store) AllCallbackMessages(offset int, limit int) ([]Message, edgexErrors.EdgeX) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	messages := make([]Message, 0, len(s.messages))
+	for _, m := range s.messages {
+		messages = append(messages, m)
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Created < messages[j].Created })
+	if offset > len(messages) {
+		offset = len(messages)
+	}
+	messages = messages[offset:]
+	if limit >= 0 && limit < len(messages) {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+func (s *store) UpdateCallbackMessage(m Message) edgexErrors.EdgeX {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.messages[m.Id]; !ok {
+		return edgexErrors.NewCommonEdgeX(edgexErrors.KindEntityDoesNotExist, "no callback "+m.Id, nil)
+	}
+	s.messages[m.Id] = m
+	return nil
+}
+
+func (s *store) DeleteCallbackMessageById(id string) edgexErrors.EdgeX {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.messages[id]; !ok {
+		return edgexErrors.NewCommonEdgeX(edgexErrors.KindEntityDoesNotExist, "no callback "+id, nil)
+	}
+	delete(s.messages, id)
+	return nil
+}
+
+type published struct {
+	topic   string
+	message Message
+}
+
+type client struct {
+	mutex     sync.Mutex
+	err       error
+	published []published
+}
+
+func (c *client) Publish(envelope msgTypes.MessageEnvelope, topic string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	var m Message
+	if err := json.Unmarshal(envelope.Payload, &m); err != nil {
+		return err
+	}
+	c.published = append(c.published, published{topic, m})
+	return nil
+}
+
+func (c *client) Subscribe([]msgTypes.TopicChannel, chan error) error {
+	return nil
+}
+
+func (c *client) names() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	names := make([]string, len(c.published))
+	for i, p := range c.published {
+		names[i] = p.message.Name
+	}
+	return names
+}
+
+func newTestOutbox(t *testing.T, info CallbacksInfo) (*Outbox, *store, *client) {
+	s := newStore()
+	c := &client{}
+	o, err := NewOutbox(info, s, c, logger.NewMockClient())
+	require.NoError(t, err)
+	return o, s, c
+}
+
+func TestCallbacksInfoValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		info          CallbacksInfo
+		expectedError bool
+	}{
+		{"defaults", CallbacksInfo{}, false},
+		{"valid", CallbacksInfo{RetryInterval: "2s", MaxRetryInterval: "1m", MaxAge: "24h", BatchSize: 10}, false},
+		{"invalid retry interval", CallbacksInfo{RetryInterval: "often"}, true},
+		{"zero retry interval", CallbacksInfo{RetryInterval: "0s"}, true},
+		{"negative max age", CallbacksInfo{MaxAge: "-1h"}, true},
+		{"max retry interval shorter", CallbacksInfo{RetryInterval: "1m", MaxRetryInterval: "10s"}, true},
+		{"negative batch size", CallbacksInfo{BatchSize: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.info.Validate()
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	o, s, c := newTestOutbox(t, CallbacksInfo{})
+
+	request := requests.AddDeviceRequest{}
+	request.Device.Name = "device1"
+	require.NoError(t, o.Enqueue("device-virtual", Device, Add, "device1", request))
+	require.NoError(t, o.Enqueue("device-virtual", Device, Delete, "device1", request.Device))
+
+	messages, err := s.AllCallbackMessages(0, -1)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Less(t, messages[0].Created, messages[1].Created, "callbacks queued in the same millisecond must keep their order")
+	assert.Equal(t, Add, messages[0].Action)
+
+	o.publishDue()
+	o.publishDue()
+	require.Len(t, c.published, 2)
+	assert.Equal(t, "edgex/callbacks/device-virtual/device/add", c.published[0].topic)
+	assert.Equal(t, "edgex/callbacks/device-virtual/device/delete", c.published[1].topic)
+	var payload struct {
+		Device struct {
+			Name string
+		}
+	}
+	require.NoError(t, json.Unmarshal(c.published[0].message.Payload, &payload))
+	assert.Equal(t, "device1", payload.Device.Name)
+
+	messages, err = s.AllCallbackMessages(0, -1)
+	require.NoError(t, err)
+	assert.Empty(t, messages, "the published callbacks are removed when no acknowledgement is expected")
+}
+
+func TestPublishDueInOrderPerDeviceService(t *testing.T) {
+	o, s, c := newTestOutbox(t, CallbacksInfo{AckTopic: "edgex/callbacks/ack"})
+	for i, service := range []string{"ds1", "ds1", "ds2", "ds1"} {
+		_, _ = s.AddCallbackMessage(Message{Id: fmt.Sprintf("m%d", i), ServiceName: service, Type: Device, Action: Update,
+			Name: fmt.Sprintf("device%d", i), Created: int64(i + 1)})
+	}
+
+	o.publishDue()
+	assert.Equal(t, []string{"device0", "device2"}, c.names(), "only the first callback of each device service is published")
+
+	// unacknowledged callbacks aren't published again before they are due
+	o.publishDue()
+	assert.Len(t, c.names(), 2)
+
+	require.NoError(t, o.Acknowledge("m0"))
+	o.publishDue()
+	assert.Equal(t, []string{"device0", "device2", "device1"}, c.names())
+
+	require.Error(t, o.Acknowledge("m0"), "a callback is acknowledged once")
+}
+
+func TestPublishRetry(t *testing.T) {
+	o, s, c := newTestOutbox(t, CallbacksInfo{RetryInterval: "1s", MaxRetryInterval: "3s"})
+	c.err = errors.New("broker unavailable")
+	require.NoError(t, o.Enqueue("ds1", DeviceService, Update, "ds1", nil))
+
+	o.publishDue()
+	messages, _ := s.AllCallbackMessages(0, -1)
+	require.Len(t, messages, 1)
+	assert.Equal(t, 1, messages[0].Attempts)
+	assert.Equal(t, "broker unavailable", messages[0].LastError)
+	assert.InDelta(t, messages[0].Created+1000, messages[0].NextAttempt, 100)
+
+	// not due yet
+	o.publishDue()
+	messages, _ = s.AllCallbackMessages(0, -1)
+	assert.Equal(t, 1, messages[0].Attempts)
+
+	c.err = nil
+	messages[0].NextAttempt = 0
+	require.NoError(t, s.UpdateCallbackMessage(messages[0]))
+	o.publishDue()
+	assert.Equal(t, []string{"ds1"}, c.names())
+	messages, _ = s.AllCallbackMessages(0, -1)
+	assert.Empty(t, messages)
+}
+
+func TestBackoff(t *testing.T) {
+	o, _, _ := newTestOutbox(t, CallbacksInfo{RetryInterval: "1s", MaxRetryInterval: "5s"})
+	assert.Equal(t, time.Second, o.backoff(1))
+	assert.Equal(t, 2*time.Second, o.backoff(2))
+	assert.Equal(t, 4*time.Second, o.backoff(3))
+	assert.Equal(t, 5*time.Second, o.backoff(4))
+	assert.Equal(t, 5*time.Second, o.backoff(100))
+}
+
+func TestPublishDueDropsExpired(t *testing.T) {
+	o, s, c := newTestOutbox(t, CallbacksInfo{MaxAge: "1h"})
+	_, _ = s.AddCallbackMessage(Message{Id: "old", ServiceName: "ds1", Name: "old", Created: 1})
+	require.NoError(t, o.Enqueue("ds1", Device, Add, "new", nil))
+
+	o.publishDue()
+	assert.Equal(t, []string{"new"}, c.names(), "the expired callback is dropped, the next one published")
+	messages, _ := s.AllCallbackMessages(0, -1)
+	assert.Empty(t, messages)
+}
+
+func TestReceiveAck(t *testing.T) {
+	o, s, _ := newTestOutbox(t, CallbacksInfo{AckTopic: "edgex/callbacks/ack"})
+	_, _ = s.AddCallbackMessage(Message{Id: "m1", ServiceName: "ds1", Created: 1})
+
+	o.receiveAck(msgTypes.MessageEnvelope{Payload: []byte(`{"id": "unknown"}`)})
+	o.receiveAck(msgTypes.MessageEnvelope{Payload: []byte(`not json`)})
+	messages, _ := s.AllCallbackMessages(0, -1)
+	assert.Len(t, messages, 1)
+
+	o.receiveAck(msgTypes.MessageEnvelope{Payload: []byte(`{"id": "m1"}`)})
+	messages, _ = s.AllCallbackMessages(0, -1)
+	assert.Empty(t, messages)
+}
+
+func TestRoutes(t *testing.T) {
+	o, s, _ := newTestOutbox(t, CallbacksInfo{AckTopic: "edgex/callbacks/ack"})
+	_, _ = s.AddCallbackMessage(Message{Id: "m2", ServiceName: "ds1", Created: 2})
+	_, _ = s.AddCallbackMessage(Message{Id: "m1", ServiceName: "ds1", Created: 1})
+	router := mux.NewRouter()
+	LoadRestRoutes(router, o, 1024)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ApiAllCallbackRoute, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response MultiCallbacksResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Callbacks, 2)
+	assert.Equal(t, "m1", response.Callbacks[0].Id)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, ApiCallbackRoute+"/id/m1", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, ApiCallbackRoute+"/id/m1", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	router = mux.NewRouter()
+	LoadRestRoutes(router, nil, 1024)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ApiAllCallbackRoute, nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "no routes without an outbox")
+}
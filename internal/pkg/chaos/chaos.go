@@ -0,0 +1,267 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package chaos injects faults in a service for development, so the resilience of its clients and of the application
+// services can be validated on real hardware: latency and 5xx errors on the REST requests, and delayed or dropped
+// message bus publishes, according to a TOML file such as
+//
+//	[[Requests]]
+//	Path = "/api/v2/event"
+//	Methods = ["POST"]
+//	Latency = "200ms"
+//	Jitter = "100ms"
+//	ErrorRate = 0.1
+//	ErrorStatus = 503
+//
+//	[[Publishes]]
+//	Topic = "edgex/events"
+//	Latency = "50ms"
+//	DropRate = 0.2
+//
+// The faults are only injected by the services built with the chaos build tag and started with the --chaos flag
+// naming the file.
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-messaging/v2/messaging"
+	"github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+
+	"github.com/BurntSushi/toml"
+)
+
+// FaultHeader is set on the responses whose error was injected, so they can be told from the genuine ones
+const FaultHeader = "X-Chaos-Fault"
+
+// Config holds the faults injected in a service
+type Config struct {
+	// Requests are the faults of the REST requests, the first matching one applying
+	Requests []RequestFault
+	// Publishes are the faults of the message bus publishes, the first matching one applying
+	Publishes []PublishFault
+}
+
+// RequestFault delays or fails the REST requests whose path starts with Path
+type RequestFault struct {
+	// Path is the prefix of the paths of the requests, all of them when empty
+	Path string
+	// Methods are the methods of the requests, all of them when empty
+	Methods []string
+	// Latency delays the requests, such as "200ms"
+	Latency string
+	// Jitter adds a random delay of up to Jitter to Latency
+	Jitter string
+	// ErrorRate is the probability, from 0 to 1, of failing a request instead of serving it
+	ErrorRate float64
+	// ErrorStatus is the 5xx status of the failed requests, 503 when 0
+	ErrorStatus int
+
+	latency time.Duration
+	jitter  time.Duration
+}
+
+// PublishFault delays or drops the publishes to the topics starting with Topic
+type PublishFault struct {
+	// Topic is the prefix of the topics of the publishes, all of them when empty
+	Topic string
+	// Latency delays the publishes, such as "50ms"
+	Latency string
+	// DropRate is the probability, from 0 to 1, of dropping a publish silently, as a lossy bus would
+	DropRate float64
+
+	latency time.Duration
+}
+
+// LoadConfig reads the faults from the TOML file at path and validates them
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("could not read the chaos configuration %s: %s", path, err.Error())
+	}
+	if err := toml.Unmarshal(contents, &config); err != nil {
+		return config, fmt.Errorf("could not parse the chaos configuration %s: %s", path, err.Error())
+	}
+	return config, config.validate()
+}
+
+func (c *Config) validate() error {
+	for i := range c.Requests {
+		fault := &c.Requests[i]
+		var err error
+		if fault.latency, err = parseDuration(fault.Latency); err != nil {
+			return fmt.Errorf("invalid Latency of the request fault %d: %s", i, err.Error())
+		}
+		if fault.jitter, err = parseDuration(fault.Jitter); err != nil {
+			return fmt.Errorf("invalid Jitter of the request fault %d: %s", i, err.Error())
+		}
+		if fault.ErrorRate < 0 || fault.ErrorRate > 1 {
+			return fmt.Errorf("invalid ErrorRate %v of the request fault %d, it must be between 0 and 1", fault.ErrorRate, i)
+		}
+		if fault.ErrorStatus == 0 {
+			fault.ErrorStatus = http.StatusServiceUnavailable
+		}
+		if fault.ErrorStatus < 500 || fault.ErrorStatus > 599 {
+			return fmt.Errorf("invalid ErrorStatus %d of the request fault %d, it must be a 5xx status", fault.ErrorStatus, i)
+		}
+	}
+	for i := range c.Publishes {
+		fault := &c.Publishes[i]
+		var err error
+		if fault.latency, err = parseDuration(fault.Latency); err != nil {
+			return fmt.Errorf("invalid Latency of the publish fault %d: %s", i, err.Error())
+		}
+		if fault.DropRate < 0 || fault.DropRate > 1 {
+			return fmt.Errorf("invalid DropRate %v of the publish fault %d, it must be between 0 and 1", fault.DropRate, i)
+		}
+	}
+	return nil
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err == nil && d < 0 {
+		err = fmt.Errorf("negative duration %s", value)
+	}
+	return d, err
+}
+
+// Injector injects the faults of its Config
+type Injector struct {
+	lc     logger.LoggingClient
+	config Config
+	mutex  sync.Mutex
+	random *rand.Rand
+}
+
+// NewInjector returns an Injector of the faults of the validated config, drawing its probabilities from source
+func NewInjector(lc logger.LoggingClient, config Config, source rand.Source) *Injector {
+	return &Injector{
+		lc:     lc,
+		config: config,
+		random: rand.New(source),
+	}
+}
+
+// Middleware delays and fails the requests as configured
+func (i *Injector) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault := i.requestFault(r)
+		if fault == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !sleep(r.Context(), fault.latency+i.duration(fault.jitter)) {
+			return
+		}
+		if i.draw(fault.ErrorRate) {
+			i.lc.Debug(fmt.Sprintf("chaos: failing %s %s with %d", r.Method, r.URL.Path, fault.ErrorStatus))
+			w.Header().Set(FaultHeader, "error")
+			http.Error(w, "fault injected", fault.ErrorStatus)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MessageClient returns a client delaying and dropping the publishes as configured
+func (i *Injector) MessageClient(client messaging.MessageClient) messaging.MessageClient {
+	return &messageClient{MessageClient: client, injector: i}
+}
+
+func (i *Injector) requestFault(r *http.Request) *RequestFault {
+	for index := range i.config.Requests {
+		fault := &i.config.Requests[index]
+		if !strings.HasPrefix(r.URL.Path, fault.Path) {
+			continue
+		}
+		if len(fault.Methods) == 0 {
+			return fault
+		}
+		for _, method := range fault.Methods {
+			if strings.EqualFold(method, r.Method) {
+				return fault
+			}
+		}
+	}
+	return nil
+}
+
+func (i *Injector) publishFault(topic string) *PublishFault {
+	for index := range i.config.Publishes {
+		if fault := &i.config.Publishes[index]; strings.HasPrefix(topic, fault.Topic) {
+			return fault
+		}
+	}
+	return nil
+}
+
+// draw returns true with the probability
+func (i *Injector) draw(probability float64) bool {
+	if probability <= 0 {
+		return false
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return i.random.Float64() < probability
+}
+
+// duration returns a random duration of up to max
+func (i *Injector) duration(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return time.Duration(i.random.Int63n(int64(max) + 1))
+}
+
+// sleep waits for d, and returns false if ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// messageClient delays and drops the publishes of the MessageClient it wraps
+type messageClient struct {
+	messaging.MessageClient
+	injector *Injector
+}
+
+func (c *messageClient) Publish(message types.MessageEnvelope, topic string) error {
+	fault := c.injector.publishFault(topic)
+	if fault == nil {
+		return c.MessageClient.Publish(message, topic)
+	}
+
+	time.Sleep(fault.latency)
+	if c.injector.draw(fault.DropRate) {
+		c.injector.lc.Debug(fmt.Sprintf("chaos: dropping the publish of %s to %s", message.CorrelationID, topic))
+		return nil
+	}
+	return c.MessageClient.Publish(message, topic)
+}
@@ -0,0 +1,173 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "chaos.toml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	config, err := LoadConfig(writeConfig(t, `
+[[Requests]]
+Path = "/api/v2/event"
+Methods = ["POST"]
+Latency = "200ms"
+Jitter = "100ms"
+ErrorRate = 0.1
+
+[[Publishes]]
+Topic = "edgex/events"
+DropRate = 0.2
+`))
+	require.NoError(t, err)
+	require.Len(t, config.Requests, 1)
+	assert.Equal(t, 200*time.Millisecond, config.Requests[0].latency)
+	assert.Equal(t, 100*time.Millisecond, config.Requests[0].jitter)
+	assert.Equal(t, http.StatusServiceUnavailable, config.Requests[0].ErrorStatus, "the default status must be 503")
+	require.Len(t, config.Publishes, 1)
+	assert.Equal(t, 0.2, config.Publishes[0].DropRate)
+
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{"invalid TOML", "[[Requests]\n"},
+		{"invalid latency", "[[Requests]]\nLatency = \"soon\""},
+		{"negative jitter", "[[Requests]]\nJitter = \"-1s\""},
+		{"error rate above 1", "[[Requests]]\nErrorRate = 1.5"},
+		{"not a 5xx status", "[[Requests]]\nErrorStatus = 404"},
+		{"negative drop rate", "[[Publishes]]\nDropRate = -0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, tt.contents))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err = LoadConfig(filepath.Join(t.TempDir(), "missing.toml"))
+	assert.Error(t, err)
+}
+
+func newTestInjector(t *testing.T, config Config) *Injector {
+	require.NoError(t, config.validate())
+	return NewInjector(logger.MockLogger{}, config, rand.NewSource(1))
+}
+
+func TestMiddleware(t *testing.T) {
+	injector := newTestInjector(t, Config{Requests: []RequestFault{
+		{Path: "/api/v2/event", Methods: []string{"POST"}, ErrorRate: 1, ErrorStatus: http.StatusBadGateway},
+		{Path: "/api/v2/reading", Latency: "20ms"},
+	}})
+	handler := injector.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedDelay  time.Duration
+	}{
+		{"failed", http.MethodPost, "/api/v2/event/profile/device/source", http.StatusBadGateway, 0},
+		{"other method", http.MethodGet, "/api/v2/event/all", http.StatusOK, 0},
+		{"delayed", http.MethodGet, "/api/v2/reading/all", http.StatusOK, 20 * time.Millisecond},
+		{"other path", http.MethodGet, "/api/v2/ping", http.StatusOK, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			start := time.Now()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, http.NoBody))
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.GreaterOrEqual(t, int64(time.Since(start)), int64(tt.expectedDelay))
+			if tt.expectedStatus != http.StatusOK {
+				assert.Equal(t, "error", recorder.Header().Get(FaultHeader))
+			} else {
+				assert.Empty(t, recorder.Header().Get(FaultHeader))
+			}
+		})
+	}
+}
+
+func TestMiddlewareCancelled(t *testing.T) {
+	injector := newTestInjector(t, Config{Requests: []RequestFault{{Latency: "1h"}}})
+	served := false
+	handler := injector.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v2/ping", http.NoBody).WithContext(ctx))
+	assert.False(t, served, "the request of a gone client must not be served")
+}
+
+func TestMiddlewareErrorRate(t *testing.T) {
+	injector := newTestInjector(t, Config{Requests: []RequestFault{{ErrorRate: 0.25}}})
+	handler := injector.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	failed := 0
+	for i := 0; i < 1000; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v2/ping", http.NoBody))
+		if recorder.Code == http.StatusServiceUnavailable {
+			failed++
+		}
+	}
+	assert.InDelta(t, 250, failed, 50)
+}
+
+// publishRecorder records the topics published to
+type publishRecorder struct {
+	topics []string
+}
+
+func (r *publishRecorder) Connect() error { return nil }
+func (r *publishRecorder) Subscribe(topics []types.TopicChannel, messageErrors chan error) error {
+	return nil
+}
+func (r *publishRecorder) Disconnect() error { return nil }
+func (r *publishRecorder) Publish(message types.MessageEnvelope, topic string) error {
+	r.topics = append(r.topics, topic)
+	return nil
+}
+
+func TestMessageClient(t *testing.T) {
+	injector := newTestInjector(t, Config{Publishes: []PublishFault{
+		{Topic: "edgex/events/dropped", DropRate: 1},
+		{Topic: "edgex/events", Latency: "10ms"},
+	}})
+	recorder := &publishRecorder{}
+	client := injector.MessageClient(recorder)
+
+	require.NoError(t, client.Publish(types.MessageEnvelope{}, "edgex/events/dropped/device"))
+	start := time.Now()
+	require.NoError(t, client.Publish(types.MessageEnvelope{}, "edgex/events/device"))
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(10*time.Millisecond))
+	require.NoError(t, client.Publish(types.MessageEnvelope{}, "edgex/alerts"))
+	assert.Equal(t, []string{"edgex/events/device", "edgex/alerts"}, recorder.topics)
+}
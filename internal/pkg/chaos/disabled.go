@@ -0,0 +1,11 @@
+// +build !chaos
+
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+// Enabled reports whether the service is built with the chaos tag, without which the faults aren't injected
+const Enabled = false
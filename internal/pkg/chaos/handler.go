@@ -0,0 +1,81 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-messaging/v2/messaging"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// Flag is the command-line flag naming the TOML file of the faults to inject
+	Flag = "chaos"
+	// Usage documents Flag in the usage of the services supporting it
+	Usage = "    --chaos=<path>                  Injects the faults of the TOML file, if built with the chaos tag, for development\n"
+)
+
+// messagingClientName is the name of the message bus client of the services publishing to the bus
+var messagingClientName = di.TypeInstanceToName((*messaging.MessageClient)(nil))
+
+// Handler injects the faults of the file named by the Flag of a service. Its BootstrapHandler must run after the
+// message bus client is added to the DIC, if the service has one.
+type Handler struct {
+	router *mux.Router
+	path   string
+}
+
+// NewHandler is a factory method that returns an initialized Handler receiver struct.
+func NewHandler(router *mux.Router, path string) Handler {
+	return Handler{
+		router: router,
+		path:   path,
+	}
+}
+
+// BootstrapHandler fulfills the BootstrapHandler contract. It adds the middleware injecting the faults of the REST
+// requests to the router, and replaces the message bus client by one injecting the faults of the publishes.
+func (h Handler) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+	if h.path == "" {
+		return true
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	if !Enabled {
+		lc.Warn(fmt.Sprintf("ignoring --%s, the service isn't built with the chaos tag", Flag))
+		return true
+	}
+
+	config, err := LoadConfig(h.path)
+	if err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+	injector := NewInjector(lc, config, rand.NewSource(time.Now().UnixNano()))
+
+	h.router.Use(injector.Middleware)
+	if client, ok := dic.Get(messagingClientName).(messaging.MessageClient); ok {
+		dic.Update(di.ServiceConstructorMap{
+			messagingClientName: func(get di.Get) interface{} {
+				return injector.MessageClient(client)
+			},
+		})
+	}
+
+	lc.Warn(fmt.Sprintf("injecting the %d request and %d publish faults of %s, for development only",
+		len(config.Requests), len(config.Publishes), h.path))
+	return true
+}
@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package claimcodes defines the short-lived, single-use codes issued by core-metadata for the devices pre-registered
+// as locked placeholders. A field technician's tool redeems the code, printed or shown as a QR code, to finalize the
+// registration of the device and push its secrets, without holding any administrator credentials: the code is the
+// only credential of ApiClaimRoute, which is meant to be exempted from the JWT verification.
+package claimcodes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+const (
+	// ApiDeviceClaimCodeRoute issues, or revokes, the claim code of the device named in the route
+	ApiDeviceClaimCodeRoute = v2.ApiDeviceByNameRoute + "/claimcode"
+	// ApiClaimRoute redeems a claim code, finalizing the registration of its device
+	ApiClaimRoute = v2.ApiBase + "/claim"
+
+	// URIScheme is the scheme of the URIs of the claim codes, encoded in the QR codes read by the technicians' tools
+	URIScheme = "edgex-claim"
+
+	// alphabet is Crockford's base32, which has neither I, L, O nor U not to be misread
+	alphabet   = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	codeLength = 12
+	groupSize  = 4
+)
+
+// ClaimCodesInfo configures the claim codes of core-metadata
+type ClaimCodesInfo struct {
+	// Enabled serves ApiDeviceClaimCodeRoute and ApiClaimRoute
+	Enabled bool
+	// Lifetime is how long a claim code can be redeemed after being issued, e.g. "15m"
+	Lifetime string
+}
+
+// Validate checks the Lifetime of the enabled claim codes
+func (info ClaimCodesInfo) Validate() error {
+	if !info.Enabled {
+		return nil
+	}
+	_, err := info.LifetimeDuration()
+	return err
+}
+
+// LifetimeDuration returns the parsed Lifetime
+func (info ClaimCodesInfo) LifetimeDuration() (time.Duration, error) {
+	lifetime, err := time.ParseDuration(info.Lifetime)
+	if err != nil || lifetime <= 0 {
+		return 0, fmt.Errorf("invalid ClaimCodes.Lifetime '%s', expected a positive duration", info.Lifetime)
+	}
+	return lifetime, nil
+}
+
+// ClaimCode is an issued claim code, stored by its Hash so that the codes can't be read back from the database
+type ClaimCode struct {
+	Hash       string `json:"hash"`
+	DeviceName string `json:"deviceName"`
+	Created    int64  `json:"created"`
+	// Expires is when the code can't be redeemed anymore, in milliseconds
+	Expires int64 `json:"expires"`
+}
+
+// Expired tells whether the code can't be redeemed anymore at the timestamp now, in milliseconds
+func (c ClaimCode) Expired(now int64) bool {
+	return now >= c.Expires
+}
+
+// Generate returns a new code of 12 characters of random, 60 bits, in groups of 4 such as "7KQ2-M9TX-4HCR"
+func Generate(random io.Reader) (string, error) {
+	b := make([]byte, codeLength)
+	if _, err := io.ReadFull(random, b); err != nil {
+		return "", fmt.Errorf("unable to generate a claim code: %s", err.Error())
+	}
+	var code strings.Builder
+	for i, c := range b {
+		if i > 0 && i%groupSize == 0 {
+			code.WriteByte('-')
+		}
+		// 256 is a multiple of 32, the characters are uniformly distributed
+		code.WriteByte(alphabet[int(c)%len(alphabet)])
+	}
+	return code.String(), nil
+}
+
+// Normalize returns code as generated, whatever its case, separators, and the letters mistaken for digits. The URI
+// of the code is accepted too, as read from its QR code.
+func Normalize(code string) (string, error) {
+	var normalized strings.Builder
+	for _, c := range strings.ToUpper(strings.TrimPrefix(code, URIScheme+":")) {
+		switch c {
+		case '-', ' ':
+			continue
+		case 'O':
+			c = '0'
+		case 'I', 'L':
+			c = '1'
+		}
+		if !strings.ContainsRune(alphabet, c) {
+			return "", errors.New("the claim code holds invalid characters")
+		}
+		if normalized.Len() > 0 && normalized.Len()%(groupSize+1) == groupSize {
+			normalized.WriteByte('-')
+		}
+		normalized.WriteRune(c)
+	}
+	if normalized.Len() != codeLength+codeLength/groupSize-1 {
+		return "", fmt.Errorf("the claim code should have %d characters", codeLength)
+	}
+	return normalized.String(), nil
+}
+
+// Hash returns the hash the normalized code is stored by
+func Hash(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// URI returns the URI of code encoded in its QR code, such as "edgex-claim:7KQ2-M9TX-4HCR"
+func URI(code string) string {
+	return URIScheme + ":" + code
+}
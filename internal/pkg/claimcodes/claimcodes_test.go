@@ -0,0 +1,88 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package claimcodes
+
+import (
+	"bytes"
+	"crypto/rand"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	code, err := Generate(rand.Reader)
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{4}-[0-9A-HJKMNP-TV-Z]{4}-[0-9A-HJKMNP-TV-Z]{4}$`), code)
+
+	other, err := Generate(rand.Reader)
+	require.NoError(t, err)
+	assert.NotEqual(t, code, other)
+
+	code, err = Generate(bytes.NewReader([]byte{0, 1, 2, 3, 31, 32, 33, 255, 10, 11, 12, 13}))
+	require.NoError(t, err)
+	assert.Equal(t, "0123-Z01Z-ABCD", code)
+
+	_, err = Generate(bytes.NewReader([]byte{0, 1}))
+	assert.Error(t, err)
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+		valid    bool
+	}{
+		{"as generated", "7KQ2-M9TX-4HCR", "7KQ2-M9TX-4HCR", true},
+		{"lower case", "7kq2-m9tx-4hcr", "7KQ2-M9TX-4HCR", true},
+		{"without separators", "7KQ2M9TX4HCR", "7KQ2-M9TX-4HCR", true},
+		{"with spaces", "7KQ2 M9TX 4HCR", "7KQ2-M9TX-4HCR", true},
+		{"misread letters", "O1I2-LXXX-0000", "0112-1XXX-0000", true},
+		{"URI", "edgex-claim:7KQ2-M9TX-4HCR", "7KQ2-M9TX-4HCR", true},
+		{"too short", "7KQ2-M9TX", "", false},
+		{"too long", "7KQ2-M9TX-4HCR-0", "", false},
+		{"invalid character", "7KQ2-M9TX-4HCU", "", false},
+		{"empty", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := Normalize(tt.code)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	assert.Len(t, Hash("7KQ2-M9TX-4HCR"), 64)
+	assert.Equal(t, Hash("7KQ2-M9TX-4HCR"), Hash("7KQ2-M9TX-4HCR"))
+	assert.NotEqual(t, Hash("7KQ2-M9TX-4HCR"), Hash("7KQ2-M9TX-4HCS"))
+	assert.NotContains(t, Hash("7KQ2-M9TX-4HCR"), "7KQ2")
+}
+
+func TestURI(t *testing.T) {
+	assert.Equal(t, "edgex-claim:7KQ2-M9TX-4HCR", URI("7KQ2-M9TX-4HCR"))
+}
+
+func TestExpired(t *testing.T) {
+	c := ClaimCode{Expires: 1000}
+	assert.False(t, c.Expired(999))
+	assert.True(t, c.Expired(1000))
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, ClaimCodesInfo{}.Validate())
+	assert.NoError(t, ClaimCodesInfo{Enabled: true, Lifetime: "15m"}.Validate())
+	assert.Error(t, ClaimCodesInfo{Enabled: true}.Validate())
+	assert.Error(t, ClaimCodesInfo{Enabled: true, Lifetime: "-1m"}.Validate())
+}
@@ -0,0 +1,37 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package claimcodes
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// ClaimCodeResponse defines the response content of ApiDeviceClaimCodeRoute, the only one holding the code
+type ClaimCodeResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	DeviceName             string `json:"deviceName"`
+	Code                   string `json:"code"`
+	// URI is the content of the QR code of the code
+	URI string `json:"uri"`
+	// Expires is when the code can't be redeemed anymore, in milliseconds
+	Expires int64 `json:"expires"`
+}
+
+// ClaimRequest defines the request content of ApiClaimRoute. The protocols replace those of the device, and the
+// secrets are stored as the device secrets, before the device is unlocked.
+type ClaimRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	Code                  string                             `json:"code" validate:"required"`
+	Protocols             map[string]dtos.ProtocolProperties `json:"protocols,omitempty" validate:"omitempty,gt=0"`
+	Secrets               map[string]string                  `json:"secrets,omitempty"`
+}
+
+// ClaimResponse defines the response content of ApiClaimRoute, naming the registered device
+type ClaimResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	DeviceName             string `json:"deviceName"`
+}
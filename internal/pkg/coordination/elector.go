@@ -0,0 +1,162 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package coordination lets several instances of the same service share a database while
+// making sure that background jobs are only run by one of them at a time.
+package coordination
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// Lease is implemented by the database clients that can hold a named, expiring lock.
+type Lease interface {
+	// AcquireLease takes the lease for owner, or extends it if owner already holds it.
+	AcquireLease(key string, owner string, ttl time.Duration) (bool, error)
+	// ReleaseLease gives up the lease if it is held by owner.
+	ReleaseLease(key string, owner string) error
+}
+
+// Elector elects a single leader amongst the instances sharing a Lease.
+type Elector struct {
+	lc            logger.LoggingClient
+	lease         Lease
+	key           string
+	instanceId    string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+
+	mutex    sync.RWMutex
+	isLeader bool
+}
+
+// NewElector is a factory method that returns an initialized Elector. renewInterval must be shorter
+// than leaseDuration so that the leader extends its lease before it expires.
+func NewElector(
+	lc logger.LoggingClient,
+	lease Lease,
+	key string,
+	instanceId string,
+	leaseDuration time.Duration,
+	renewInterval time.Duration) *Elector {
+
+	return &Elector{
+		lc:            lc,
+		lease:         lease,
+		key:           key,
+		instanceId:    instanceId,
+		leaseDuration: leaseDuration,
+		renewInterval: renewInterval,
+	}
+}
+
+// InstanceId returns the identifier this instance uses as lease owner.
+func (e *Elector) InstanceId() string {
+	return e.instanceId
+}
+
+// IsLeader reports whether this instance held the lease as of the last renewal.
+func (e *Elector) IsLeader() bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.isLeader
+}
+
+// Campaign makes a single attempt to acquire or renew the lease and returns the resulting leadership state.
+func (e *Elector) Campaign() bool {
+	acquired, err := e.lease.AcquireLease(e.key, e.instanceId, e.leaseDuration)
+	if err != nil {
+		e.lc.Error(fmt.Sprintf("instance %s failed to acquire lease %s: %s", e.instanceId, e.key, err.Error()))
+		// The lease may expire before we can reach the database again, so stop acting as leader.
+		acquired = false
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if acquired != e.isLeader {
+		if acquired {
+			e.lc.Info(fmt.Sprintf("instance %s is now the leader for %s", e.instanceId, e.key))
+		} else {
+			e.lc.Info(fmt.Sprintf("instance %s is no longer the leader for %s", e.instanceId, e.key))
+		}
+	}
+	e.isLeader = acquired
+	return acquired
+}
+
+// Run campaigns for the lease every renewInterval until ctx is cancelled, then releases it.
+func (e *Elector) Run(ctx context.Context, wg *sync.WaitGroup) {
+	e.Campaign()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(e.renewInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				e.resign()
+				return
+			case <-ticker.C:
+				e.Campaign()
+			}
+		}
+	}()
+}
+
+// RunExclusive calls job every interval until ctx is cancelled, skipping the calls made while this
+// instance is not the leader.
+func (e *Elector) RunExclusive(ctx context.Context, wg *sync.WaitGroup, name string, interval time.Duration, job func()) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !e.IsLeader() {
+					e.lc.Debug(fmt.Sprintf("instance %s is not the leader, skipping %s", e.instanceId, name))
+					continue
+				}
+				job()
+			}
+		}
+	}()
+}
+
+func (e *Elector) resign() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if !e.isLeader {
+		return
+	}
+
+	e.isLeader = false
+	if err := e.lease.ReleaseLease(e.key, e.instanceId); err != nil {
+		e.lc.Warn(fmt.Sprintf("instance %s failed to release lease %s: %s", e.instanceId, e.key, err.Error()))
+		return
+	}
+	e.lc.Info(fmt.Sprintf("instance %s released lease %s", e.instanceId, e.key))
+}
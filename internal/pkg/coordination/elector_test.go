@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package coordination
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testKey = "edgex-core-data:leader"
+
+// memoryLease is an in-process Lease shared by the electors under test.
+type memoryLease struct {
+	mutex sync.Mutex
+	owner string
+	err   error
+}
+
+func (m *memoryLease) AcquireLease(_ string, owner string, _ time.Duration) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.err != nil {
+		return false, m.err
+	}
+	if m.owner == "" || m.owner == owner {
+		m.owner = owner
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m *memoryLease) ReleaseLease(_ string, owner string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.owner == owner {
+		m.owner = ""
+	}
+	return nil
+}
+
+func TestCampaignSingleLeader(t *testing.T) {
+	lease := &memoryLease{}
+	first := NewElector(logger.MockLogger{}, lease, testKey, "first", time.Second, time.Millisecond)
+	second := NewElector(logger.MockLogger{}, lease, testKey, "second", time.Second, time.Millisecond)
+
+	assert.True(t, first.Campaign())
+	assert.False(t, second.Campaign())
+	assert.True(t, first.Campaign(), "leader should be able to renew its own lease")
+	assert.True(t, first.IsLeader())
+	assert.False(t, second.IsLeader())
+}
+
+func TestCampaignErrorDropsLeadership(t *testing.T) {
+	lease := &memoryLease{}
+	elector := NewElector(logger.MockLogger{}, lease, testKey, "first", time.Second, time.Millisecond)
+	assert.True(t, elector.Campaign())
+
+	lease.err = errors.New("connection refused")
+
+	assert.False(t, elector.Campaign())
+	assert.False(t, elector.IsLeader())
+}
+
+func TestRunReleasesLeaseOnShutdown(t *testing.T) {
+	lease := &memoryLease{}
+	first := NewElector(logger.MockLogger{}, lease, testKey, "first", time.Second, time.Hour)
+	second := NewElector(logger.MockLogger{}, lease, testKey, "second", time.Second, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	first.Run(ctx, wg)
+	assert.True(t, first.IsLeader())
+
+	cancel()
+	wg.Wait()
+
+	assert.False(t, first.IsLeader())
+	assert.True(t, second.Campaign(), "lease should be free once the leader has shut down")
+}
+
+func TestRunExclusiveOnlyRunsOnLeader(t *testing.T) {
+	lease := &memoryLease{owner: "other"}
+	elector := NewElector(logger.MockLogger{}, lease, testKey, "first", time.Second, time.Hour)
+	elector.Campaign()
+
+	var mutex sync.Mutex
+	runs := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	elector.RunExclusive(ctx, wg, "test job", time.Millisecond, func() {
+		mutex.Lock()
+		runs++
+		mutex.Unlock()
+	})
+
+	time.Sleep(20 * time.Millisecond)
+	mutex.Lock()
+	assert.Equal(t, 0, runs, "job must not run while another instance is leader")
+	mutex.Unlock()
+
+	lease.ReleaseLease(testKey, "other")
+	elector.Campaign()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.Greater(t, runs, 0)
+}
@@ -0,0 +1,93 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package coordination
+
+import (
+	"sync"
+	"time"
+)
+
+// LockMetrics is a snapshot of the ExecutionLock counters.
+type LockMetrics struct {
+	InstanceId string `json:"instanceId"`
+	// Owned is the number of executions this instance took the lock of, and so ran.
+	Owned uint64 `json:"owned"`
+	// Skipped is the number of executions skipped because another instance held the lock.
+	Skipped uint64 `json:"skipped"`
+	// Failed is the number of executions the lock could not be checked for.
+	Failed     uint64            `json:"failed"`
+	OwnedByJob map[string]uint64 `json:"ownedByJob"`
+}
+
+// ExecutionLock makes sure that an execution of a job is only run by one of the instances sharing a Lease,
+// letting every instance schedule the job. Unlike an Elector, any instance may win any execution.
+type ExecutionLock struct {
+	lease      Lease
+	keyPrefix  string
+	instanceId string
+
+	mutex   sync.Mutex
+	metrics LockMetrics
+}
+
+// NewExecutionLock is a factory method that returns an initialized ExecutionLock. The lock of a job is stored
+// at keyPrefix followed by the job name.
+func NewExecutionLock(lease Lease, keyPrefix string, instanceId string) *ExecutionLock {
+	return &ExecutionLock{
+		lease:      lease,
+		keyPrefix:  keyPrefix,
+		instanceId: instanceId,
+		metrics:    LockMetrics{InstanceId: instanceId, OwnedByJob: make(map[string]uint64)},
+	}
+}
+
+// InstanceId returns the identifier this instance uses as lock owner.
+func (l *ExecutionLock) InstanceId() string {
+	return l.instanceId
+}
+
+// TryLock takes the lock of the job for hold, which must cover the time the other instances may take to
+// schedule the same execution but be shorter than the time until the next one. The lock isn't released
+// after the execution, so that a late instance doesn't run it again. It returns false when another instance
+// holds the lock, and the error when the lock can't be checked, leaving the caller to decide whether to run.
+func (l *ExecutionLock) TryLock(job string, hold time.Duration) (bool, error) {
+	owned, err := l.lease.AcquireLease(l.keyPrefix+job, l.instanceId, hold)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	switch {
+	case err != nil:
+		l.metrics.Failed++
+	case owned:
+		l.metrics.Owned++
+		l.metrics.OwnedByJob[job]++
+	default:
+		l.metrics.Skipped++
+	}
+	return owned, err
+}
+
+// Metrics returns a snapshot of the counters.
+func (l *ExecutionLock) Metrics() LockMetrics {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	snapshot := l.metrics
+	snapshot.OwnedByJob = make(map[string]uint64, len(l.metrics.OwnedByJob))
+	for job, count := range l.metrics.OwnedByJob {
+		snapshot.OwnedByJob[job] = count
+	}
+	return snapshot
+}
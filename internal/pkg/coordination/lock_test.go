@@ -0,0 +1,95 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package coordination
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockPrefix = "edgex-support-scheduler:lock:"
+
+// memoryLocks is an in-process Lease holding one lock per key, which never expires.
+type memoryLocks struct {
+	mutex  sync.Mutex
+	owners map[string]string
+	err    error
+}
+
+func (m *memoryLocks) AcquireLease(key string, owner string, _ time.Duration) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.err != nil {
+		return false, m.err
+	}
+	if current, held := m.owners[key]; held && current != owner {
+		return false, nil
+	}
+	m.owners[key] = owner
+	return true, nil
+}
+
+func (m *memoryLocks) ReleaseLease(key string, owner string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.owners[key] == owner {
+		delete(m.owners, key)
+	}
+	return nil
+}
+
+func TestTryLockSingleOwner(t *testing.T) {
+	locks := &memoryLocks{owners: make(map[string]string)}
+	first := NewExecutionLock(locks, testLockPrefix, "first")
+	second := NewExecutionLock(locks, testLockPrefix, "second")
+
+	owned, err := first.TryLock("scrub", time.Second)
+	require.NoError(t, err)
+	assert.True(t, owned)
+	owned, err = second.TryLock("scrub", time.Second)
+	require.NoError(t, err)
+	assert.False(t, owned, "another instance holds the lock")
+	owned, err = second.TryLock("report", time.Second)
+	require.NoError(t, err)
+	assert.True(t, owned, "the locks of the jobs are independent")
+	assert.Equal(t, "first", locks.owners[testLockPrefix+"scrub"])
+
+	assert.Equal(t, LockMetrics{InstanceId: "first", Owned: 1, OwnedByJob: map[string]uint64{"scrub": 1}}, first.Metrics())
+	assert.Equal(t, LockMetrics{InstanceId: "second", Owned: 1, Skipped: 1, OwnedByJob: map[string]uint64{"report": 1}}, second.Metrics())
+}
+
+func TestTryLockError(t *testing.T) {
+	locks := &memoryLocks{owners: make(map[string]string), err: errors.New("connection refused")}
+	lock := NewExecutionLock(locks, testLockPrefix, "first")
+
+	owned, err := lock.TryLock("scrub", time.Second)
+	assert.Error(t, err)
+	assert.False(t, owned)
+	assert.Equal(t, uint64(1), lock.Metrics().Failed)
+}
+
+func TestMetricsSnapshot(t *testing.T) {
+	lock := NewExecutionLock(&memoryLocks{owners: make(map[string]string)}, testLockPrefix, "first")
+	_, _ = lock.TryLock("scrub", time.Second)
+
+	snapshot := lock.Metrics()
+	snapshot.OwnedByJob["scrub"] = 10
+	assert.Equal(t, uint64(1), lock.Metrics().OwnedByJob["scrub"], "the snapshot must not share the counters")
+}
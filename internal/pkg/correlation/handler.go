@@ -2,7 +2,10 @@ package correlation
 
 import (
 	"context"
+	"net/http"
+
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/google/uuid"
 )
 
 func FromContext(ctx context.Context) string {
@@ -12,3 +15,25 @@ func FromContext(ctx context.Context) string {
 	}
 	return hdr
 }
+
+// NewContext returns ctx when it carries a correlation id, otherwise a context derived from it carrying a new one.
+// Work not started by a request, like scheduled executions or resent notifications, uses it so that the requests
+// and messages it sends can still be traced.
+func NewContext(ctx context.Context) context.Context {
+	if FromContext(ctx) != "" {
+		return ctx
+	}
+	return context.WithValue(ctx, clients.CorrelationHeader, uuid.New().String())
+}
+
+// Propagate sets the correlation id carried by ctx on the header of the outbound request req, unless the header is
+// already set. The clients of go-mod-core-contracts and the message envelopes read the id from the context
+// themselves, so it is only needed by the requests built by hand.
+func Propagate(ctx context.Context, req *http.Request) {
+	if req == nil || req.Header.Get(clients.CorrelationHeader) != "" {
+		return
+	}
+	if correlationId := FromContext(ctx); correlationId != "" {
+		req.Header.Set(clients.CorrelationHeader, correlationId)
+	}
+}
@@ -11,25 +11,95 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 )
 
+// maxIdLength is the longest correlation id accepted from a client, longer ones being replaced
+const maxIdLength = 128
+
 var LoggingClient logger.LoggingClient
 
+// ManageHeader guarantees that every request carries a correlation id, in its context and header, and echoes it
+// in the response header. The id received from the client is kept unless it is missing or invalid, in which case
+// a new one is generated. The id already given to the request is kept when the middleware is applied twice, as
+// when the V1 and V2 routes share a router.
 func ManageHeader(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		hdr := r.Header.Get(clients.CorrelationHeader)
+		hdr := FromContext(r.Context())
 		if hdr == "" {
+			hdr = r.Header.Get(clients.CorrelationHeader)
+		}
+		if !validId(hdr) {
 			hdr = uuid.New().String()
 		}
+		r.Header.Set(clients.CorrelationHeader, hdr)
+		w.Header().Set(clients.CorrelationHeader, hdr)
 		ctx := context.WithValue(r.Context(), clients.CorrelationHeader, hdr)
 		r = r.WithContext(ctx)
 		next.ServeHTTP(w, r)
 	})
 }
 
+// validId tells whether id can be used as correlation id, that is whether it is a short string of printable
+// ASCII characters which can be safely logged and sent on.
+func validId(id string) bool {
+	if id == "" || len(id) > maxIdLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// statusWriter remembers the status code written by the handler
+type statusWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends the buffered response to the client, so that the streamed responses aren't held back
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// OnResponseComplete records the completed request in RecentRequests, once even when the middleware is applied
+// twice, so that it can be traced by its correlation id.
 func OnResponseComplete(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, recorded := w.(*statusWriter); recorded {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		begin := time.Now()
-		next.ServeHTTP(w, r)
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+		if sw.statusCode == 0 {
+			sw.statusCode = http.StatusOK
+		}
 		correlationId := FromContext(r.Context())
+		RecentRequests.Record(Request{
+			CorrelationId: correlationId,
+			Method:        r.Method,
+			Path:          r.URL.Path,
+			StatusCode:    sw.statusCode,
+			Timestamp:     begin.UnixNano() / int64(time.Millisecond),
+			Duration:      time.Since(begin).String(),
+		})
 		if LoggingClient != nil {
 			LoggingClient.Trace("Response complete", clients.CorrelationHeader, correlationId, internal.LogDurationKey, time.Since(begin).String())
 		}
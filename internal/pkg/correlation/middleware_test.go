@@ -0,0 +1,106 @@
+package correlation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve runs request through the middlewares applied twice, as the routers sharing the V1 and V2 routes do, and
+// returns the response and the correlation id the handler saw.
+func serve(request *http.Request) (*httptest.ResponseRecorder, string) {
+	var seen string
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = FromContext(r.Context())
+		w.WriteHeader(http.StatusAccepted)
+	})
+	for i := 0; i < 2; i++ {
+		handler = ManageHeader(OnResponseComplete(OnRequestBegin(handler)))
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder, seen
+}
+
+func TestManageHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		received string
+		kept     bool
+	}{
+		{"kept", "0b1f7e48-8c7c-4a3d-9b0a-3d4e0a1a9c11", true},
+		{"missing", "", false},
+		{"too long", strings.Repeat("a", maxIdLength+1), false},
+		{"not printable", "id\twith\ttabs", false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/v2/ping", nil)
+			request.Header.Set(clients.CorrelationHeader, testCase.received)
+
+			recorder, seen := serve(request)
+
+			require.NotEmpty(t, seen)
+			assert.Equal(t, seen, recorder.Header().Get(clients.CorrelationHeader), "the id must be echoed in the response")
+			if testCase.kept {
+				assert.Equal(t, testCase.received, seen)
+			} else {
+				assert.NotEqual(t, testCase.received, seen)
+			}
+		})
+	}
+}
+
+func TestOnResponseCompleteRecordsOnce(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/api/v2/event", nil)
+	_, seen := serve(request)
+
+	requests := RecentRequests.Find(seen)
+	require.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPost, requests[0].Method)
+	assert.Equal(t, "/api/v2/event", requests[0].Path)
+	assert.Equal(t, http.StatusAccepted, requests[0].StatusCode)
+}
+
+func TestRequestLog(t *testing.T) {
+	log := NewRequestLog(3)
+	for _, id := range []string{"a", "b", "a", "c", "a"} {
+		log.Record(Request{CorrelationId: id, Path: id})
+	}
+
+	assert.Len(t, log.Find("a"), 2, "the oldest request must be dropped once the log is full")
+	assert.Len(t, log.Find("c"), 1)
+	assert.Empty(t, log.Find("b"))
+	assert.Equal(t, []Request{}, log.Find("unknown"))
+}
+
+func TestNewContext(t *testing.T) {
+	ctx := NewContext(context.Background())
+	id := FromContext(ctx)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, id, FromContext(NewContext(ctx)), "an existing id must be kept")
+}
+
+func TestPropagate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), clients.CorrelationHeader, "from-context")
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	Propagate(ctx, request)
+	assert.Equal(t, "from-context", request.Header.Get(clients.CorrelationHeader))
+
+	request = httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(clients.CorrelationHeader, "already-set")
+	Propagate(ctx, request)
+	assert.Equal(t, "already-set", request.Header.Get(clients.CorrelationHeader))
+
+	request = httptest.NewRequest(http.MethodGet, "/", nil)
+	Propagate(context.Background(), request)
+	assert.Empty(t, request.Header.Get(clients.CorrelationHeader))
+}
@@ -0,0 +1,81 @@
+package correlation
+
+import (
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+const (
+	// Id is the route variable holding the traced correlation id
+	Id = "correlationId"
+	// ApiTraceRoute returns the recent requests of the service carrying a correlation id
+	ApiTraceRoute = v2.ApiBase + "/trace/{" + Id + "}"
+
+	defaultRequestLogSize = 1000
+)
+
+// Request is a request handled by the service
+type Request struct {
+	CorrelationId string `json:"correlationId"`
+	Method        string `json:"method"`
+	Path          string `json:"path"`
+	StatusCode    int    `json:"statusCode"`
+	// Timestamp is when the request was received, in milliseconds since the epoch
+	Timestamp int64  `json:"timestamp"`
+	Duration  string `json:"duration"`
+}
+
+// RequestLog keeps the most recent requests handled by the service, the oldest ones being dropped first.
+type RequestLog struct {
+	mutex    sync.Mutex
+	requests []Request
+	// next is the index the next request is written to once the log is full
+	next int
+	size int
+}
+
+// RecentRequests is the log OnResponseComplete records the requests of the service in
+var RecentRequests = NewRequestLog(defaultRequestLogSize)
+
+// NewRequestLog is a factory function that returns an initialized RequestLog keeping up to size requests.
+func NewRequestLog(size int) *RequestLog {
+	if size <= 0 {
+		size = defaultRequestLogSize
+	}
+	return &RequestLog{size: size}
+}
+
+// Record adds request to the log, dropping the oldest request when the log is full.
+func (l *RequestLog) Record(request Request) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if len(l.requests) < l.size {
+		l.requests = append(l.requests, request)
+		return
+	}
+	l.requests[l.next] = request
+	l.next = (l.next + 1) % l.size
+}
+
+// Find returns the logged requests carrying correlationId, the oldest first.
+func (l *RequestLog) Find(correlationId string) []Request {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	result := []Request{}
+	for i := range l.requests {
+		request := l.requests[(l.next+i)%len(l.requests)]
+		if request.CorrelationId == correlationId {
+			result = append(result, request)
+		}
+	}
+	return result
+}
+
+// TraceResponse defines the response content of ApiTraceRoute
+type TraceResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	CorrelationId          string    `json:"correlationId"`
+	Requests               []Request `json:"requests"`
+}
@@ -0,0 +1,179 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package cors provides the middleware shared by the services to answer the Cross-Origin Resource Sharing
+// requests of browser based UIs calling the services directly rather than through a proxy.
+package cors
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Wildcard allows any origin in AllowedOrigins, or any header in AllowedHeaders.
+const Wildcard = "*"
+
+const (
+	headerOrigin           = "Origin"
+	headerVary             = "Vary"
+	headerRequestMethod    = "Access-Control-Request-Method"
+	headerRequestHeaders   = "Access-Control-Request-Headers"
+	headerAllowOrigin      = "Access-Control-Allow-Origin"
+	headerAllowMethods     = "Access-Control-Allow-Methods"
+	headerAllowHeaders     = "Access-Control-Allow-Headers"
+	headerAllowCredentials = "Access-Control-Allow-Credentials"
+	headerExposeHeaders    = "Access-Control-Expose-Headers"
+	headerMaxAge           = "Access-Control-Max-Age"
+)
+
+// CORSInfo holds the CORS policy of a service. An empty AllowedOrigins disables CORS, so that browsers
+// keep blocking cross-origin calls.
+type CORSInfo struct {
+	// AllowedOrigins lists the origins, e.g. http://localhost:4000, allowed to call the service, or "*" for any.
+	AllowedOrigins []string
+	// AllowedMethods lists the methods allowed in cross-origin requests.
+	AllowedMethods []string
+	// AllowedHeaders lists the request headers allowed in cross-origin requests, or "*" for any.
+	AllowedHeaders []string
+	// ExposedHeaders lists the response headers readable by the browser scripts beyond the safelisted ones.
+	ExposedHeaders []string
+	// AllowCredentials lets the browsers send cookies and Authorization headers along with the requests.
+	// The origin of the request is then echoed rather than "*" as the CORS specification requires.
+	AllowCredentials bool
+	// MaxAge is how long in seconds the browsers may cache the response to a preflight request. 0 leaves
+	// it to the browser.
+	MaxAge int
+}
+
+// Configuration is implemented by the service configurations that define a CORS policy.
+type Configuration interface {
+	// GetCORS returns the service's current CORS policy.
+	GetCORS() CORSInfo
+}
+
+// NewMiddleware returns a middleware that adds the CORS headers to the responses to the allowed origins
+// and answers their preflight requests itself, before the authentication middlewares that would reject
+// them. The policy is read on every request so that changes to the service's Writable configuration are
+// applied without a restart.
+func NewMiddleware(configuration Configuration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			info := configuration.GetCORS()
+			origin := r.Header.Get(headerOrigin)
+			if origin == "" || len(info.AllowedOrigins) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add(headerVary, headerOrigin)
+			if !contains(info.AllowedOrigins, origin) && !contains(info.AllowedOrigins, Wildcard) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get(headerRequestMethod) != "" {
+				preflight(w, r, info, origin)
+				return
+			}
+
+			setAllowOrigin(w, info, origin)
+			if len(info.ExposedHeaders) > 0 {
+				w.Header().Set(headerExposeHeaders, strings.Join(info.ExposedHeaders, ", "))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// preflight answers the preflight request of an allowed origin. A method or header that isn't allowed
+// gets a response without the CORS headers, which the browser reports as a CORS failure.
+func preflight(w http.ResponseWriter, r *http.Request, info CORSInfo, origin string) {
+	w.Header().Add(headerVary, headerRequestMethod)
+	w.Header().Add(headerVary, headerRequestHeaders)
+
+	method := r.Header.Get(headerRequestMethod)
+	if !contains(info.AllowedMethods, method) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	requested := requestedHeaders(r)
+	if !contains(info.AllowedHeaders, Wildcard) {
+		for _, header := range requested {
+			if !contains(info.AllowedHeaders, header) {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+	}
+
+	setAllowOrigin(w, info, origin)
+	w.Header().Set(headerAllowMethods, strings.Join(info.AllowedMethods, ", "))
+	if len(requested) > 0 {
+		w.Header().Set(headerAllowHeaders, strings.Join(requested, ", "))
+	}
+	if info.MaxAge > 0 {
+		w.Header().Set(headerMaxAge, strconv.Itoa(info.MaxAge))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setAllowOrigin(w http.ResponseWriter, info CORSInfo, origin string) {
+	if info.AllowCredentials {
+		w.Header().Set(headerAllowOrigin, origin)
+		w.Header().Set(headerAllowCredentials, "true")
+		return
+	}
+	if contains(info.AllowedOrigins, Wildcard) {
+		w.Header().Set(headerAllowOrigin, Wildcard)
+		return
+	}
+	w.Header().Set(headerAllowOrigin, origin)
+}
+
+// requestedHeaders returns the headers listed by the Access-Control-Request-Headers header of a preflight request.
+func requestedHeaders(r *http.Request) []string {
+	var headers []string
+	for _, value := range r.Header.Values(headerRequestHeaders) {
+		for _, header := range strings.Split(value, ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				headers = append(headers, header)
+			}
+		}
+	}
+	return headers
+}
+
+// contains returns whether values holds value, ignoring case as origins, methods and header names are
+// case insensitive.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// UseMiddleware adds the CORS middleware to router. It must be called after the routes are loaded and
+// before any other middleware: the preflight requests are routed to a catch-all OPTIONS route, since the
+// router only runs its middlewares for matching routes, and are answered before being authenticated.
+func UseMiddleware(router *mux.Router, configuration Configuration) {
+	router.PathPrefix("/").Methods(http.MethodOptions).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+	router.Use(NewMiddleware(configuration))
+}
@@ -0,0 +1,104 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+type corsConfig CORSInfo
+
+func (c corsConfig) GetCORS() CORSInfo {
+	return CORSInfo(c)
+}
+
+func TestMiddleware(t *testing.T) {
+	const uiOrigin = "http://localhost:4000"
+	policy := CORSInfo{
+		AllowedOrigins: []string{uiOrigin},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+		ExposedHeaders: []string{"X-Correlation-ID"},
+		MaxAge:         600,
+	}
+	anyOrigin := policy
+	anyOrigin.AllowedOrigins = []string{Wildcard}
+	withCredentials := anyOrigin
+	withCredentials.AllowCredentials = true
+
+	tests := []struct {
+		name                string
+		policy              CORSInfo
+		method              string
+		origin              string
+		requestMethod       string
+		requestHeaders      string
+		expectedStatus      int
+		expectedAllowOrigin string
+		expectedAllowHeader string
+	}{
+		{"Disabled", CORSInfo{}, http.MethodGet, uiOrigin, "", "", http.StatusOK, "", ""},
+		{"Same origin request", policy, http.MethodGet, "", "", "", http.StatusOK, "", ""},
+		{"Allowed origin", policy, http.MethodGet, uiOrigin, "", "", http.StatusOK, uiOrigin, ""},
+		{"Origin not allowed", policy, http.MethodGet, "http://example.com", "", "", http.StatusOK, "", ""},
+		{"Any origin", anyOrigin, http.MethodGet, "http://example.com", "", "", http.StatusOK, Wildcard, ""},
+		{"Any origin with credentials", withCredentials, http.MethodGet, "http://example.com", "", "", http.StatusOK, "http://example.com", ""},
+		{"Preflight", policy, http.MethodOptions, uiOrigin, http.MethodPost, "content-type, authorization", http.StatusNoContent, uiOrigin, "content-type, authorization"},
+		{"Preflight method not allowed", policy, http.MethodOptions, uiOrigin, http.MethodDelete, "", http.StatusNoContent, "", ""},
+		{"Preflight header not allowed", policy, http.MethodOptions, uiOrigin, http.MethodPost, "X-Custom", http.StatusNoContent, "", ""},
+		{"Preflight origin not allowed", policy, http.MethodOptions, "http://example.com", http.MethodPost, "", http.StatusMethodNotAllowed, "", ""},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			router.HandleFunc("/api/v2/ping", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}).Methods(http.MethodGet, http.MethodPost)
+			UseMiddleware(router, corsConfig(testCase.policy))
+
+			req := httptest.NewRequest(testCase.method, "/api/v2/ping", nil)
+			if testCase.origin != "" {
+				req.Header.Set(headerOrigin, testCase.origin)
+			}
+			if testCase.requestMethod != "" {
+				req.Header.Set(headerRequestMethod, testCase.requestMethod)
+			}
+			if testCase.requestHeaders != "" {
+				req.Header.Set(headerRequestHeaders, testCase.requestHeaders)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, testCase.expectedStatus, recorder.Code)
+			assert.Equal(t, testCase.expectedAllowOrigin, recorder.Header().Get(headerAllowOrigin))
+			assert.Equal(t, testCase.expectedAllowHeader, recorder.Header().Get(headerAllowHeaders))
+			if testCase.expectedAllowOrigin == "" {
+				return
+			}
+			if testCase.method == http.MethodOptions {
+				assert.Equal(t, "GET, POST", recorder.Header().Get(headerAllowMethods))
+				assert.Equal(t, "600", recorder.Header().Get(headerMaxAge))
+			} else {
+				assert.Equal(t, "X-Correlation-ID", recorder.Header().Get(headerExposeHeaders))
+			}
+			assert.Equal(t, testCase.policy.AllowCredentials, recorder.Header().Get(headerAllowCredentials) == "true")
+		})
+	}
+}
@@ -23,6 +23,8 @@ const (
 	// Databases
 
 	RedisDB = "redisdb"
+	// MemoryDB keeps the data in the memory of the service, which loses it when stopped
+	MemoryDB = "memory"
 
 	// Data
 	EventsCollection          = "event"
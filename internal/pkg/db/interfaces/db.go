@@ -184,6 +184,7 @@ type DBClient interface {
 	DeleteNotificationById(id string) error
 	DeleteNotificationBySlug(slug string) error
 	DeleteNotificationsOld(age int) error
+	DeleteProcessedNotificationsBefore(end int64, offset int, limit int) (int, int, error)
 
 	/*
 		Subscriptions
@@ -0,0 +1,210 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory implements the DBClient interfaces over in-memory collections, mirroring the behavior of the Redis
+// implementation so that the services can be unit tested and run in development without a database.
+package memory
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+)
+
+// Client is a DBClient keeping its data in memory, which is lost once the client is garbage collected
+type Client struct {
+	mutex sync.RWMutex
+
+	events            *collection
+	readings          *collection
+	valueDescriptors  *collection
+	deviceReports     *collection
+	devices           *collection
+	deviceProfiles    *collection
+	addressables      *collection
+	deviceServices    *collection
+	provisionWatchers *collection
+	commands          *collection
+	deviceCommands    map[string][]string
+	notifications     *collection
+	subscriptions     *collection
+	transmissions     *collection
+	intervals         *collection
+	intervalActions   *collection
+}
+
+// NewClient returns an empty Client
+func NewClient() *Client {
+	c := &Client{}
+	c.scrubData()
+	c.scrubMetadata()
+	c.notifications = newCollection()
+	c.subscriptions = newCollection()
+	c.transmissions = newCollection()
+	c.intervals = newCollection()
+	c.intervalActions = newCollection()
+	return c
+}
+
+// CloseSession has nothing to release, the data being kept until the Client is garbage collected
+func (c *Client) CloseSession() {
+}
+
+// entry is an object of a collection along with its unique name, if any
+type entry struct {
+	name   string
+	object interface{}
+}
+
+// collection keeps the objects of a kind by id, in the order they were added, and indexes the named ones by name
+type collection struct {
+	ids     []string
+	entries map[string]entry
+	names   map[string]string
+}
+
+func newCollection() *collection {
+	return &collection{
+		entries: make(map[string]entry),
+		names:   make(map[string]string),
+	}
+}
+
+// put adds the object, or replaces the object of the same id while keeping its position
+func (c *collection) put(id string, name string, object interface{}) {
+	if old, ok := c.entries[id]; ok {
+		if old.name != "" {
+			delete(c.names, old.name)
+		}
+	} else {
+		c.ids = append(c.ids, id)
+	}
+	c.entries[id] = entry{name: name, object: object}
+	if name != "" {
+		c.names[name] = id
+	}
+}
+
+func (c *collection) get(id string) (interface{}, bool) {
+	e, ok := c.entries[id]
+	return e.object, ok
+}
+
+func (c *collection) getByName(name string) (interface{}, bool) {
+	id, ok := c.names[name]
+	if !ok {
+		return nil, false
+	}
+	return c.get(id)
+}
+
+func (c *collection) hasName(name string) bool {
+	_, ok := c.names[name]
+	return ok
+}
+
+// delete removes the object of the given id, returning whether it existed
+func (c *collection) delete(id string) bool {
+	e, ok := c.entries[id]
+	if !ok {
+		return false
+	}
+	delete(c.entries, id)
+	if e.name != "" {
+		delete(c.names, e.name)
+	}
+	for i, existing := range c.ids {
+		if existing == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// list returns the objects in the order they were added
+func (c *collection) list() []interface{} {
+	objects := make([]interface{}, len(c.ids))
+	for i, id := range c.ids {
+		objects[i] = c.entries[id].object
+	}
+	return objects
+}
+
+func (c *collection) len() int {
+	return len(c.ids)
+}
+
+// limitOf returns how many of the count objects a query limited to limit returns, a limit of zero or less meaning all
+func limitOf(count int, limit int) int {
+	if limit <= 0 || limit > count {
+		return count
+	}
+	return limit
+}
+
+// metadataId returns the id of a new metadata object, which is given a new uuid unless it already has a valid one
+func metadataId(id string) string {
+	if _, err := uuid.Parse(id); err != nil {
+		return uuid.New().String()
+	}
+	return id
+}
+
+// merge sets the zero fields of the struct pointed by dst to the ones of src, as the Redis implementation does with
+// mergo when updating an object from the given fields
+func merge(dst interface{}, src interface{}) {
+	mergeValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src))
+}
+
+func mergeValue(dst reflect.Value, src reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			mergeValue(field, src.Field(i))
+		} else if field.IsZero() {
+			field.Set(src.Field(i))
+		}
+	}
+}
+
+// dataId returns the id of a new event, reading or value descriptor, which is given a new uuid unless it already has
+// one, the invalid ones being rejected
+func dataId(id string) (string, error) {
+	if id == "" {
+		return uuid.New().String(), nil
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return "", db.ErrInvalidObjectId
+	}
+	return id, nil
+}
+
+// newTimestamps returns the timestamps of an added or updated metadata object, which is modified now and created now
+// unless it already was
+func newTimestamps(ts contract.Timestamps) contract.Timestamps {
+	now := db.MakeTimestamp()
+	if ts.Created == 0 {
+		ts.Created = now
+	}
+	ts.Modified = now
+	return ts
+}
+
+func contains(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
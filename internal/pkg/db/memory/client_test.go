@@ -0,0 +1,22 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db/test"
+)
+
+var _ interfaces.DBClient = &Client{}
+
+func TestMemoryDB(t *testing.T) {
+	test.TestDataDB(t, NewClient())
+	test.TestMetadataDB(t, NewClient())
+	test.TestNotificationsDB(t, NewClient())
+	test.TestSchedulerDB(t, NewClient())
+}
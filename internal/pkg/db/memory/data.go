@@ -0,0 +1,566 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"sort"
+
+	correlation "github.com/edgexfoundry/edgex-go/internal/pkg/correlation/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// storedEvent is an event without its readings, which are kept in the readings collection
+type storedEvent struct {
+	event      contract.Event
+	checksum   string
+	readingIds []string
+}
+
+// scrubData clears the events, the readings and the value descriptors
+func (c *Client) scrubData() {
+	c.events = newCollection()
+	c.readings = newCollection()
+	c.valueDescriptors = newCollection()
+}
+
+// ******************************* EVENTS **********************************
+
+// Events returns all the events in the order they were added
+func (c *Client) Events() ([]contract.Event, error) {
+	return c.EventsWithLimit(0)
+}
+
+// EventsWithLimit returns up to limit events in the order they were added
+func (c *Client) EventsWithLimit(limit int) ([]contract.Event, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	events := c.filterEvents(func(contract.Event) bool { return true })
+	return events[:limitOf(len(events), limit)], nil
+}
+
+// AddEvent adds the event and its readings, which are stored without their binary values
+func (c *Client) AddEvent(e correlation.Event) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, err := dataId(e.ID)
+	if err != nil {
+		return "", err
+	}
+	if e.Created == 0 {
+		e.Created = db.MakeTimestamp()
+	}
+
+	stored := storedEvent{event: e.Event, checksum: e.Checksum}
+	stored.event.ID = id
+	stored.event.Readings = nil
+	for _, r := range e.Readings {
+		r.Created = e.Created
+		r.Device = e.Device
+		readingId, err := c.addReading(r)
+		if err != nil {
+			return "", err
+		}
+		stored.readingIds = append(stored.readingIds, readingId)
+	}
+	c.events.put(id, "", stored)
+	return id, nil
+}
+
+// UpdateEvent updates the event of the same id with the non-zero fields of e, leaving its readings as they are
+func (c *Client) UpdateEvent(e correlation.Event) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	o, ok := c.events.get(e.ID)
+	if !ok {
+		return db.ErrNotFound
+	}
+	stored := o.(storedEvent)
+
+	updated := e.Event
+	updated.Readings = nil
+	updated.Modified = db.MakeTimestamp()
+	merge(&updated, stored.event)
+	stored.event = updated
+	if e.Checksum != "" {
+		stored.checksum = e.Checksum
+	}
+	c.events.put(e.ID, "", stored)
+	return nil
+}
+
+// EventById returns the event of the given id
+func (c *Client) EventById(id string) (contract.Event, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.events.get(id)
+	if !ok {
+		return contract.Event{}, db.ErrNotFound
+	}
+	return c.eventOf(o.(storedEvent)), nil
+}
+
+// EventsByChecksum returns the events added with the given checksum
+func (c *Client) EventsByChecksum(checksum string) ([]contract.Event, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var events []contract.Event
+	for _, o := range c.events.list() {
+		if stored := o.(storedEvent); stored.checksum == checksum {
+			events = append(events, c.eventOf(stored))
+		}
+	}
+	if len(events) == 0 {
+		return []contract.Event{}, db.ErrNotFound
+	}
+	return events, nil
+}
+
+// EventCount returns the number of events
+func (c *Client) EventCount() (int, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.events.len(), nil
+}
+
+// EventCountByDeviceId returns the number of events of the given device
+func (c *Client) EventCountByDeviceId(id string) (int, error) {
+	events, err := c.EventsForDevice(id)
+	return len(events), err
+}
+
+// DeleteEventById deletes the event of the given id, leaving its readings to the caller
+func (c *Client) DeleteEventById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.events.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteEventsByDevice deletes the events and the readings of the given device, returning the number of events
+// deleted
+func (c *Client) DeleteEventsByDevice(deviceId string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.deleteReadingsByDevice(deviceId)
+	count := 0
+	for _, o := range c.events.list() {
+		if stored := o.(storedEvent); stored.event.Device == deviceId {
+			c.events.delete(stored.event.ID)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// EventsForDeviceLimit returns up to limit events of the given device, the newest first
+func (c *Client) EventsForDeviceLimit(id string, limit int) ([]contract.Event, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	events := c.filterEvents(func(e contract.Event) bool { return e.Device == id })
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Created > events[j].Created })
+	return events[:limitOf(len(events), limit)], nil
+}
+
+// EventsForDevice returns the events of the given device, the newest first
+func (c *Client) EventsForDevice(id string) ([]contract.Event, error) {
+	return c.EventsForDeviceLimit(id, 0)
+}
+
+// EventsByCreationTime returns up to limit events created between startTime and endTime, the oldest first
+func (c *Client) EventsByCreationTime(startTime, endTime int64, limit int) ([]contract.Event, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	events := c.filterEvents(func(e contract.Event) bool { return e.Created >= startTime && e.Created <= endTime })
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Created < events[j].Created })
+	return events[:limitOf(len(events), limit)], nil
+}
+
+// EventsOlderThanAge returns the events created more than age milliseconds ago
+func (c *Client) EventsOlderThanAge(age int64) ([]contract.Event, error) {
+	return c.EventsByCreationTime(0, db.MakeTimestamp()-age, 0)
+}
+
+// EventsPushed returns the events which have been pushed
+func (c *Client) EventsPushed() ([]contract.Event, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterEvents(func(e contract.Event) bool { return e.Pushed > 0 }), nil
+}
+
+// ScrubAllEvents deletes all the events and readings
+func (c *Client) ScrubAllEvents() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.events = newCollection()
+	c.readings = newCollection()
+	return nil
+}
+
+// filterEvents returns the events matching match in the order they were added
+func (c *Client) filterEvents(match func(contract.Event) bool) []contract.Event {
+	events := []contract.Event{}
+	for _, o := range c.events.list() {
+		if stored := o.(storedEvent); match(stored.event) {
+			events = append(events, c.eventOf(stored))
+		}
+	}
+	return events
+}
+
+// eventOf returns the stored event along with its readings which have not been deleted
+func (c *Client) eventOf(stored storedEvent) contract.Event {
+	e := stored.event
+	e.Readings = []contract.Reading{}
+	for _, id := range stored.readingIds {
+		if r, ok := c.readings.get(id); ok {
+			e.Readings = append(e.Readings, r.(contract.Reading))
+		}
+	}
+	return e
+}
+
+// ******************************* READINGS **********************************
+
+// Readings returns all the readings in the order they were added
+func (c *Client) Readings() ([]contract.Reading, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterReadings(func(contract.Reading) bool { return true }), nil
+}
+
+// AddReading adds the reading without its binary value
+func (c *Client) AddReading(r contract.Reading) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.addReading(r)
+}
+
+// UpdateReading updates the reading of the same id with the non-zero fields of r
+func (c *Client) UpdateReading(r contract.Reading) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	o, ok := c.readings.get(r.Id)
+	if !ok {
+		return db.ErrNotFound
+	}
+
+	r.Modified = db.MakeTimestamp()
+	r.BinaryValue = []byte{}
+	merge(&r, o.(contract.Reading))
+	c.readings.put(r.Id, "", r)
+	return nil
+}
+
+// ReadingById returns the reading of the given id
+func (c *Client) ReadingById(id string) (contract.Reading, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.readings.get(id)
+	if !ok {
+		return contract.Reading{}, db.ErrNotFound
+	}
+	return o.(contract.Reading), nil
+}
+
+// ReadingCount returns the number of readings
+func (c *Client) ReadingCount() (int, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.readings.len(), nil
+}
+
+// DeleteReadingById deletes the reading of the given id
+func (c *Client) DeleteReadingById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.readings.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteReadingsByDevice deletes the readings of the given device
+func (c *Client) DeleteReadingsByDevice(deviceId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.deleteReadingsByDevice(deviceId)
+	return nil
+}
+
+// ReadingsByDevice returns up to limit readings of the given device, the newest first
+func (c *Client) ReadingsByDevice(id string, limit int) ([]contract.Reading, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	readings := c.filterReadings(func(r contract.Reading) bool { return r.Device == id })
+	sort.SliceStable(readings, func(i, j int) bool { return readings[i].Created > readings[j].Created })
+	return readings[:limitOf(len(readings), limit)], nil
+}
+
+// ReadingsByValueDescriptor returns up to limit readings of the given value descriptor, the oldest first
+func (c *Client) ReadingsByValueDescriptor(name string, limit int) ([]contract.Reading, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	readings := c.readingsByName(name)
+	return readings[:limitOf(len(readings), limit)], nil
+}
+
+// ReadingsByValueDescriptorNames returns up to limit readings of the given value descriptors, those of each value
+// descriptor following the ones of the previous value descriptor
+func (c *Client) ReadingsByValueDescriptorNames(names []string, limit int) ([]contract.Reading, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	readings := []contract.Reading{}
+	if limit == 0 {
+		return readings, nil
+	}
+	for _, name := range names {
+		readings = append(readings, c.readingsByName(name)...)
+		if limit > 0 && len(readings) >= limit {
+			return readings[:limit], nil
+		}
+	}
+	return readings, nil
+}
+
+// ReadingsByCreationTime returns up to limit readings created between start and end, the oldest first
+func (c *Client) ReadingsByCreationTime(start, end int64, limit int) ([]contract.Reading, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if limit == 0 {
+		return []contract.Reading{}, nil
+	}
+	readings := c.filterReadings(func(r contract.Reading) bool { return r.Created >= start && r.Created <= end })
+	sort.SliceStable(readings, func(i, j int) bool { return readings[i].Created < readings[j].Created })
+	return readings[:limitOf(len(readings), limit)], nil
+}
+
+// ReadingsByDeviceAndValueDescriptor returns up to limit readings of the given device and value descriptor, the
+// newest first
+func (c *Client) ReadingsByDeviceAndValueDescriptor(deviceId, valueDescriptor string, limit int) ([]contract.Reading, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if limit == 0 {
+		return []contract.Reading{}, nil
+	}
+	readings := c.filterReadings(func(r contract.Reading) bool {
+		return r.Device == deviceId && r.Name == valueDescriptor
+	})
+	sort.SliceStable(readings, func(i, j int) bool { return readings[i].Created > readings[j].Created })
+	return readings[:limitOf(len(readings), limit)], nil
+}
+
+func (c *Client) addReading(r contract.Reading) (string, error) {
+	id, err := dataId(r.Id)
+	if err != nil {
+		return "", err
+	}
+	r.Id = id
+	// the binary values are not persisted, as with the Redis implementation
+	r.BinaryValue = []byte{}
+	if r.Created == 0 {
+		r.Created = db.MakeTimestamp()
+	}
+	c.readings.put(id, "", r)
+	return id, nil
+}
+
+func (c *Client) deleteReadingsByDevice(deviceId string) {
+	for _, o := range c.readings.list() {
+		if r := o.(contract.Reading); r.Device == deviceId {
+			c.readings.delete(r.Id)
+		}
+	}
+}
+
+// readingsByName returns the readings of the given value descriptor, the oldest first
+func (c *Client) readingsByName(name string) []contract.Reading {
+	readings := c.filterReadings(func(r contract.Reading) bool { return r.Name == name })
+	sort.SliceStable(readings, func(i, j int) bool { return readings[i].Created < readings[j].Created })
+	return readings
+}
+
+// filterReadings returns the readings matching match in the order they were added
+func (c *Client) filterReadings(match func(contract.Reading) bool) []contract.Reading {
+	readings := []contract.Reading{}
+	for _, o := range c.readings.list() {
+		if r := o.(contract.Reading); match(r) {
+			readings = append(readings, r)
+		}
+	}
+	return readings
+}
+
+// ******************************* VALUE DESCRIPTORS **********************************
+
+// AddValueDescriptor adds the value descriptor, whose name must be unique
+func (c *Client) AddValueDescriptor(v contract.ValueDescriptor) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, err := dataId(v.Id)
+	if err != nil {
+		return "", err
+	}
+	if c.valueDescriptors.hasName(v.Name) {
+		return "", db.ErrNotUnique
+	}
+
+	v.Id = id
+	if v.Created == 0 {
+		v.Created = db.MakeTimestamp()
+	}
+	c.valueDescriptors.put(id, v.Name, v)
+	return id, nil
+}
+
+// ValueDescriptors returns all the value descriptors in the order they were added
+func (c *Client) ValueDescriptors() ([]contract.ValueDescriptor, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterValueDescriptors(func(contract.ValueDescriptor) bool { return true }), nil
+}
+
+// UpdateValueDescriptor updates the value descriptor of the same id with the non-zero fields of v, its name having to
+// remain unique
+func (c *Client) UpdateValueDescriptor(v contract.ValueDescriptor) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if id, ok := c.valueDescriptors.names[v.Name]; ok && id != v.Id {
+		return db.ErrNotUnique
+	}
+	o, ok := c.valueDescriptors.get(v.Id)
+	if !ok {
+		return db.ErrNotFound
+	}
+
+	v.Modified = db.MakeTimestamp()
+	merge(&v, o.(contract.ValueDescriptor))
+	c.valueDescriptors.put(v.Id, v.Name, v)
+	return nil
+}
+
+// DeleteValueDescriptorById deletes the value descriptor of the given id
+func (c *Client) DeleteValueDescriptorById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.valueDescriptors.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// ValueDescriptorByName returns the value descriptor of the given name
+func (c *Client) ValueDescriptorByName(name string) (contract.ValueDescriptor, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.valueDescriptors.getByName(name)
+	if !ok {
+		return contract.ValueDescriptor{}, db.ErrNotFound
+	}
+	return o.(contract.ValueDescriptor), nil
+}
+
+// ValueDescriptorsByName returns the value descriptors of the given names, skipping the unknown ones
+func (c *Client) ValueDescriptorsByName(names []string) ([]contract.ValueDescriptor, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	values := []contract.ValueDescriptor{}
+	for _, name := range names {
+		if o, ok := c.valueDescriptors.getByName(name); ok {
+			values = append(values, o.(contract.ValueDescriptor))
+		}
+	}
+	return values, nil
+}
+
+// ValueDescriptorById returns the value descriptor of the given id
+func (c *Client) ValueDescriptorById(id string) (contract.ValueDescriptor, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.valueDescriptors.get(id)
+	if !ok {
+		return contract.ValueDescriptor{}, db.ErrNotFound
+	}
+	return o.(contract.ValueDescriptor), nil
+}
+
+// ValueDescriptorsByUomLabel returns the value descriptors of the given unit of measure label
+func (c *Client) ValueDescriptorsByUomLabel(uomLabel string) ([]contract.ValueDescriptor, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterValueDescriptors(func(v contract.ValueDescriptor) bool { return v.UomLabel == uomLabel }), nil
+}
+
+// ValueDescriptorsByLabel returns the value descriptors having the given label
+func (c *Client) ValueDescriptorsByLabel(label string) ([]contract.ValueDescriptor, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterValueDescriptors(func(v contract.ValueDescriptor) bool { return contains(v.Labels, label) }), nil
+}
+
+// ValueDescriptorsByType returns the value descriptors of the given type
+func (c *Client) ValueDescriptorsByType(t string) ([]contract.ValueDescriptor, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterValueDescriptors(func(v contract.ValueDescriptor) bool { return v.Type == t }), nil
+}
+
+// ScrubAllValueDescriptors deletes all the value descriptors
+func (c *Client) ScrubAllValueDescriptors() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.valueDescriptors = newCollection()
+	return nil
+}
+
+// filterValueDescriptors returns the value descriptors matching match in the order they were added
+func (c *Client) filterValueDescriptors(match func(contract.ValueDescriptor) bool) []contract.ValueDescriptor {
+	values := []contract.ValueDescriptor{}
+	for _, o := range c.valueDescriptors.list() {
+		if v := o.(contract.ValueDescriptor); match(v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
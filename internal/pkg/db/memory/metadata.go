@@ -0,0 +1,886 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"errors"
+	"fmt"
+
+	types "github.com/edgexfoundry/edgex-go/internal/core/metadata/errors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+)
+
+// scrubMetadata clears the device reports, devices, device profiles, addressables, device services, provision watchers
+// and commands
+func (c *Client) scrubMetadata() {
+	c.deviceReports = newCollection()
+	c.devices = newCollection()
+	c.deviceProfiles = newCollection()
+	c.addressables = newCollection()
+	c.deviceServices = newCollection()
+	c.provisionWatchers = newCollection()
+	c.commands = newCollection()
+	c.deviceCommands = make(map[string][]string)
+}
+
+// ScrubMetadata deletes all the metadata
+func (c *Client) ScrubMetadata() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.scrubMetadata()
+	return nil
+}
+
+// checkUpdate returns the error of updating the object of the given id of a collection to the given name, which must
+// remain unique
+func checkUpdate(objects *collection, id string, name string) error {
+	if _, ok := objects.get(id); !ok {
+		return db.ErrNotFound
+	}
+	if existingId, ok := objects.names[name]; ok && existingId != id {
+		return db.ErrNotUnique
+	}
+	return nil
+}
+
+/* ----------------------------- Device Report ---------------------------------- */
+
+// GetAllDeviceReports returns all the device reports in the order they were added
+func (c *Client) GetAllDeviceReports() ([]contract.DeviceReport, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceReports(func(contract.DeviceReport) bool { return true }), nil
+}
+
+// GetDeviceReportByDeviceName returns the device reports of the given device
+func (c *Client) GetDeviceReportByDeviceName(n string) ([]contract.DeviceReport, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceReports(func(dr contract.DeviceReport) bool { return dr.Device == n }), nil
+}
+
+// GetDeviceReportByName returns the device report of the given name
+func (c *Client) GetDeviceReportByName(n string) (contract.DeviceReport, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.deviceReports.getByName(n)
+	if !ok {
+		return contract.DeviceReport{}, db.ErrNotFound
+	}
+	return o.(contract.DeviceReport), nil
+}
+
+// GetDeviceReportById returns the device report of the given id
+func (c *Client) GetDeviceReportById(id string) (contract.DeviceReport, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.deviceReports.get(id)
+	if !ok {
+		return contract.DeviceReport{}, db.ErrNotFound
+	}
+	return o.(contract.DeviceReport), nil
+}
+
+// GetDeviceReportsByAction returns the device reports of the given interval action
+func (c *Client) GetDeviceReportsByAction(n string) ([]contract.DeviceReport, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceReports(func(dr contract.DeviceReport) bool { return dr.Action == n }), nil
+}
+
+// AddDeviceReport adds the device report, whose name must be unique
+func (c *Client) AddDeviceReport(d contract.DeviceReport) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.deviceReports.hasName(d.Name) {
+		return "", db.ErrNotUnique
+	}
+	d.Id = metadataId(d.Id)
+	d.Timestamps = newTimestamps(d.Timestamps)
+	c.deviceReports.put(d.Id, d.Name, d)
+	return d.Id, nil
+}
+
+// UpdateDeviceReport replaces the device report of the same id
+func (c *Client) UpdateDeviceReport(dr contract.DeviceReport) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := checkUpdate(c.deviceReports, dr.Id, dr.Name); err != nil {
+		return err
+	}
+	dr.Timestamps = newTimestamps(dr.Timestamps)
+	c.deviceReports.put(dr.Id, dr.Name, dr)
+	return nil
+}
+
+// DeleteDeviceReportById deletes the device report of the given id
+func (c *Client) DeleteDeviceReportById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.deviceReports.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (c *Client) filterDeviceReports(match func(contract.DeviceReport) bool) []contract.DeviceReport {
+	reports := []contract.DeviceReport{}
+	for _, o := range c.deviceReports.list() {
+		if dr := o.(contract.DeviceReport); match(dr) {
+			reports = append(reports, dr)
+		}
+	}
+	return reports
+}
+
+/* ----------------------------- Device ---------------------------------- */
+
+// AddDevice adds the device, whose name must be unique, along with its commands. The device refers to its device
+// service and device profile by id, their current version being returned along with the device.
+func (c *Client) AddDevice(d contract.Device, commands []contract.Command) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.devices.hasName(d.Name) {
+		return "", db.ErrNotUnique
+	}
+	d.Id = metadataId(d.Id)
+	c.putDevice(d, commands)
+	return d.Id, nil
+}
+
+// UpdateDevice replaces the device of the same id, along with its commands by the core commands of its profile
+func (c *Client) UpdateDevice(d contract.Device) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := checkUpdate(c.devices, d.Id, d.Name); err != nil {
+		return err
+	}
+	c.deleteDeviceCommands(d.Id)
+	c.putDevice(d, d.Profile.CoreCommands)
+	return nil
+}
+
+// DeleteDeviceById deletes the device of the given id and its commands
+func (c *Client) DeleteDeviceById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.devices.delete(id) {
+		return db.ErrNotFound
+	}
+	c.deleteDeviceCommands(id)
+	return nil
+}
+
+// GetDevicesByProfileId returns the devices of the given device profile
+func (c *Client) GetDevicesByProfileId(id string) ([]contract.Device, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDevices(func(d contract.Device) bool { return d.Profile.Id == id })
+}
+
+// GetDeviceById returns the device of the given id
+func (c *Client) GetDeviceById(id string) (contract.Device, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.devices.get(id)
+	if !ok {
+		return contract.Device{}, db.ErrNotFound
+	}
+	return c.deviceOf(o.(contract.Device))
+}
+
+// GetDeviceByName returns the device of the given name
+func (c *Client) GetDeviceByName(n string) (contract.Device, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.devices.getByName(n)
+	if !ok {
+		return contract.Device{}, db.ErrNotFound
+	}
+	return c.deviceOf(o.(contract.Device))
+}
+
+// GetAllDevices returns all the devices in the order they were added
+func (c *Client) GetAllDevices() ([]contract.Device, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDevices(func(contract.Device) bool { return true })
+}
+
+// GetDevicesByServiceId returns the devices of the given device service
+func (c *Client) GetDevicesByServiceId(id string) ([]contract.Device, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDevices(func(d contract.Device) bool { return d.Service.Id == id })
+}
+
+// GetDevicesWithLabel returns the devices having the given label
+func (c *Client) GetDevicesWithLabel(l string) ([]contract.Device, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDevices(func(d contract.Device) bool { return contains(d.Labels, l) })
+}
+
+func (c *Client) putDevice(d contract.Device, commands []contract.Command) {
+	d.DescribedObject.Timestamps = newTimestamps(d.DescribedObject.Timestamps)
+	c.devices.put(d.Id, d.Name, d)
+
+	ids := make([]string, len(commands))
+	for i, cmd := range commands {
+		cmd.Id = uuid.New().String()
+		cmd.Timestamps = newTimestamps(cmd.Timestamps)
+		c.commands.put(cmd.Id, "", cmd)
+		ids[i] = cmd.Id
+	}
+	c.deviceCommands[d.Id] = ids
+}
+
+func (c *Client) deleteDeviceCommands(deviceId string) {
+	for _, id := range c.deviceCommands[deviceId] {
+		c.commands.delete(id)
+	}
+	delete(c.deviceCommands, deviceId)
+}
+
+// deviceOf returns the stored device along with its device service and device profile
+func (c *Client) deviceOf(d contract.Device) (contract.Device, error) {
+	var err error
+	d.Service, err = c.deviceServiceById(d.Service.Id)
+	if err != nil {
+		return contract.Device{}, err
+	}
+	o, ok := c.deviceProfiles.get(d.Profile.Id)
+	if !ok {
+		return contract.Device{}, db.ErrNotFound
+	}
+	d.Profile = o.(contract.DeviceProfile)
+	return d, nil
+}
+
+// filterDevices returns the devices whose stored version matches match, in the order they were added
+func (c *Client) filterDevices(match func(contract.Device) bool) ([]contract.Device, error) {
+	devices := []contract.Device{}
+	for _, o := range c.devices.list() {
+		if d := o.(contract.Device); match(d) {
+			d, err := c.deviceOf(d)
+			if err != nil {
+				return []contract.Device{}, err
+			}
+			devices = append(devices, d)
+		}
+	}
+	return devices, nil
+}
+
+/* ----------------------------- Device Profile ---------------------------------- */
+
+// AddDeviceProfile adds the device profile, whose name must be unique
+func (c *Client) AddDeviceProfile(dp contract.DeviceProfile) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.deviceProfiles.hasName(dp.Name) {
+		return "", db.ErrNotUnique
+	}
+	dp.Id = metadataId(dp.Id)
+	dp.DescribedObject.Timestamps = newTimestamps(dp.DescribedObject.Timestamps)
+	c.deviceProfiles.put(dp.Id, dp.Name, dp)
+	return dp.Id, nil
+}
+
+// UpdateDeviceProfile replaces the device profile of the same id
+func (c *Client) UpdateDeviceProfile(dp contract.DeviceProfile) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := checkUpdate(c.deviceProfiles, dp.Id, dp.Name); err != nil {
+		return err
+	}
+	dp.DescribedObject.Timestamps = newTimestamps(dp.DescribedObject.Timestamps)
+	c.deviceProfiles.put(dp.Id, dp.Name, dp)
+	return nil
+}
+
+// GetAllDeviceProfiles returns all the device profiles in the order they were added
+func (c *Client) GetAllDeviceProfiles() ([]contract.DeviceProfile, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceProfiles(func(contract.DeviceProfile) bool { return true }), nil
+}
+
+// GetDeviceProfileById returns the device profile of the given id
+func (c *Client) GetDeviceProfileById(id string) (contract.DeviceProfile, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.deviceProfiles.get(id)
+	if !ok {
+		return contract.DeviceProfile{}, db.ErrNotFound
+	}
+	return o.(contract.DeviceProfile), nil
+}
+
+// DeleteDeviceProfileById deletes the device profile of the given id
+func (c *Client) DeleteDeviceProfileById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.deviceProfiles.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// GetDeviceProfilesByModel returns the device profiles of the given model
+func (c *Client) GetDeviceProfilesByModel(model string) ([]contract.DeviceProfile, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceProfiles(func(dp contract.DeviceProfile) bool { return dp.Model == model }), nil
+}
+
+// GetDeviceProfilesWithLabel returns the device profiles having the given label
+func (c *Client) GetDeviceProfilesWithLabel(l string) ([]contract.DeviceProfile, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceProfiles(func(dp contract.DeviceProfile) bool { return contains(dp.Labels, l) }), nil
+}
+
+// GetDeviceProfilesByManufacturerModel returns the device profiles of the given manufacturer and model
+func (c *Client) GetDeviceProfilesByManufacturerModel(man string, mod string) ([]contract.DeviceProfile, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceProfiles(func(dp contract.DeviceProfile) bool {
+		return dp.Manufacturer == man && dp.Model == mod
+	}), nil
+}
+
+// GetDeviceProfilesByManufacturer returns the device profiles of the given manufacturer
+func (c *Client) GetDeviceProfilesByManufacturer(man string) ([]contract.DeviceProfile, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceProfiles(func(dp contract.DeviceProfile) bool { return dp.Manufacturer == man }), nil
+}
+
+// GetDeviceProfileByName returns the device profile of the given name
+func (c *Client) GetDeviceProfileByName(n string) (contract.DeviceProfile, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.deviceProfiles.getByName(n)
+	if !ok {
+		return contract.DeviceProfile{}, db.ErrNotFound
+	}
+	return o.(contract.DeviceProfile), nil
+}
+
+func (c *Client) filterDeviceProfiles(match func(contract.DeviceProfile) bool) []contract.DeviceProfile {
+	profiles := []contract.DeviceProfile{}
+	for _, o := range c.deviceProfiles.list() {
+		if dp := o.(contract.DeviceProfile); match(dp) {
+			profiles = append(profiles, dp)
+		}
+	}
+	return profiles
+}
+
+/* ----------------------------- Addressable ---------------------------------- */
+
+// UpdateAddressable replaces the addressable of the same id
+func (c *Client) UpdateAddressable(a contract.Addressable) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := checkUpdate(c.addressables, a.Id, a.Name); err != nil {
+		return err
+	}
+	a.Timestamps = newTimestamps(a.Timestamps)
+	c.addressables.put(a.Id, a.Name, a)
+	return nil
+}
+
+// AddAddressable adds the addressable, whose name must be unique
+func (c *Client) AddAddressable(a contract.Addressable) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.addressables.hasName(a.Name) {
+		return a.Id, db.ErrNotUnique
+	}
+	a.Id = metadataId(a.Id)
+	a.Timestamps = newTimestamps(a.Timestamps)
+	c.addressables.put(a.Id, a.Name, a)
+	return a.Id, nil
+}
+
+// GetAddressableById returns the addressable of the given id
+func (c *Client) GetAddressableById(id string) (contract.Addressable, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.addressables.get(id)
+	if !ok {
+		return contract.Addressable{}, db.ErrNotFound
+	}
+	return o.(contract.Addressable), nil
+}
+
+// GetAddressableByName returns the addressable of the given name
+func (c *Client) GetAddressableByName(n string) (contract.Addressable, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.addressables.getByName(n)
+	if !ok {
+		return contract.Addressable{}, db.ErrNotFound
+	}
+	return o.(contract.Addressable), nil
+}
+
+// GetAddressablesByTopic returns the addressables of the given topic
+func (c *Client) GetAddressablesByTopic(t string) ([]contract.Addressable, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterAddressables(func(a contract.Addressable) bool { return a.Topic == t }), nil
+}
+
+// GetAddressablesByPort returns the addressables of the given port
+func (c *Client) GetAddressablesByPort(p int) ([]contract.Addressable, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterAddressables(func(a contract.Addressable) bool { return a.Port == p }), nil
+}
+
+// GetAddressablesByPublisher returns the addressables of the given publisher
+func (c *Client) GetAddressablesByPublisher(p string) ([]contract.Addressable, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterAddressables(func(a contract.Addressable) bool { return a.Publisher == p }), nil
+}
+
+// GetAddressablesByAddress returns the addressables of the given address
+func (c *Client) GetAddressablesByAddress(add string) ([]contract.Addressable, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterAddressables(func(a contract.Addressable) bool { return a.Address == add }), nil
+}
+
+// GetAddressables returns all the addressables in the order they were added
+func (c *Client) GetAddressables() ([]contract.Addressable, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterAddressables(func(contract.Addressable) bool { return true }), nil
+}
+
+// DeleteAddressableById deletes the addressable of the given id
+func (c *Client) DeleteAddressableById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.addressables.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (c *Client) filterAddressables(match func(contract.Addressable) bool) []contract.Addressable {
+	addressables := []contract.Addressable{}
+	for _, o := range c.addressables.list() {
+		if a := o.(contract.Addressable); match(a) {
+			addressables = append(addressables, a)
+		}
+	}
+	return addressables
+}
+
+/* ----------------------------- Device Service ---------------------------------- */
+
+// UpdateDeviceService replaces the device service of the same id
+func (c *Client) UpdateDeviceService(ds contract.DeviceService) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := checkUpdate(c.deviceServices, ds.Id, ds.Name); err != nil {
+		return err
+	}
+	return c.putDeviceService(ds)
+}
+
+// GetDeviceServicesByAddressableId returns the device services of the given addressable
+func (c *Client) GetDeviceServicesByAddressableId(id string) ([]contract.DeviceService, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceServices(func(ds contract.DeviceService) bool { return ds.Addressable.Id == id })
+}
+
+// GetDeviceServicesWithLabel returns the device services having the given label
+func (c *Client) GetDeviceServicesWithLabel(l string) ([]contract.DeviceService, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceServices(func(ds contract.DeviceService) bool { return contains(ds.Labels, l) })
+}
+
+// GetDeviceServiceById returns the device service of the given id
+func (c *Client) GetDeviceServiceById(id string) (contract.DeviceService, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.deviceServiceById(id)
+}
+
+// GetDeviceServiceByName returns the device service of the given name
+func (c *Client) GetDeviceServiceByName(n string) (contract.DeviceService, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.deviceServices.getByName(n)
+	if !ok {
+		return contract.DeviceService{}, db.ErrNotFound
+	}
+	return c.deviceServiceOf(o.(contract.DeviceService))
+}
+
+// GetAllDeviceServices returns all the device services in the order they were added
+func (c *Client) GetAllDeviceServices() ([]contract.DeviceService, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterDeviceServices(func(contract.DeviceService) bool { return true })
+}
+
+// AddDeviceService adds the device service, whose name must be unique and whose addressable, given by id or name, must
+// exist. The device service refers to its addressable by id, its current version being returned along with the
+// device service.
+func (c *Client) AddDeviceService(ds contract.DeviceService) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.deviceServices.hasName(ds.Name) {
+		return "", db.ErrNotUnique
+	}
+	ds.Id = metadataId(ds.Id)
+	if err := c.putDeviceService(ds); err != nil {
+		return "", err
+	}
+	return ds.Id, nil
+}
+
+// DeleteDeviceServiceById deletes the device service of the given id
+func (c *Client) DeleteDeviceServiceById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.deviceServices.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (c *Client) putDeviceService(ds contract.DeviceService) error {
+	if _, ok := c.addressables.get(ds.Addressable.Id); !ok {
+		id, ok := c.addressables.names[ds.Addressable.Name]
+		if !ok {
+			return errors.New("Invalid addressable")
+		}
+		ds.Addressable.Id = id
+	}
+	ds.DescribedObject.Timestamps = newTimestamps(ds.DescribedObject.Timestamps)
+	c.deviceServices.put(ds.Id, ds.Name, ds)
+	return nil
+}
+
+func (c *Client) deviceServiceById(id string) (contract.DeviceService, error) {
+	o, ok := c.deviceServices.get(id)
+	if !ok {
+		return contract.DeviceService{}, db.ErrNotFound
+	}
+	return c.deviceServiceOf(o.(contract.DeviceService))
+}
+
+// deviceServiceOf returns the stored device service along with its addressable
+func (c *Client) deviceServiceOf(ds contract.DeviceService) (contract.DeviceService, error) {
+	o, ok := c.addressables.get(ds.Addressable.Id)
+	if !ok {
+		return contract.DeviceService{}, db.ErrNotFound
+	}
+	ds.Addressable = o.(contract.Addressable)
+	return ds, nil
+}
+
+// filterDeviceServices returns the device services whose stored version matches match, in the order they were added
+func (c *Client) filterDeviceServices(match func(contract.DeviceService) bool) ([]contract.DeviceService, error) {
+	services := []contract.DeviceService{}
+	for _, o := range c.deviceServices.list() {
+		if ds := o.(contract.DeviceService); match(ds) {
+			ds, err := c.deviceServiceOf(ds)
+			if err != nil {
+				return []contract.DeviceService{}, err
+			}
+			services = append(services, ds)
+		}
+	}
+	return services, nil
+}
+
+/* ----------------------------- Provision Watcher ---------------------------------- */
+
+// GetProvisionWatcherById returns the provision watcher of the given id
+func (c *Client) GetProvisionWatcherById(id string) (contract.ProvisionWatcher, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.provisionWatchers.get(id)
+	if !ok {
+		return contract.ProvisionWatcher{}, db.ErrNotFound
+	}
+	return c.provisionWatcherOf(o.(contract.ProvisionWatcher))
+}
+
+// GetAllProvisionWatchers returns all the provision watchers in the order they were added
+func (c *Client) GetAllProvisionWatchers() ([]contract.ProvisionWatcher, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterProvisionWatchers(func(contract.ProvisionWatcher) bool { return true })
+}
+
+// GetProvisionWatcherByName returns the provision watcher of the given name
+func (c *Client) GetProvisionWatcherByName(n string) (contract.ProvisionWatcher, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.provisionWatchers.getByName(n)
+	if !ok {
+		return contract.ProvisionWatcher{}, db.ErrNotFound
+	}
+	return c.provisionWatcherOf(o.(contract.ProvisionWatcher))
+}
+
+// GetProvisionWatchersByProfileId returns the provision watchers of the given device profile
+func (c *Client) GetProvisionWatchersByProfileId(id string) ([]contract.ProvisionWatcher, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterProvisionWatchers(func(pw contract.ProvisionWatcher) bool { return pw.Profile.Id == id })
+}
+
+// GetProvisionWatchersByServiceId returns the provision watchers of the given device service
+func (c *Client) GetProvisionWatchersByServiceId(id string) ([]contract.ProvisionWatcher, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterProvisionWatchers(func(pw contract.ProvisionWatcher) bool { return pw.Service.Id == id })
+}
+
+// GetProvisionWatchersByIdentifier returns the provision watchers having the given value for the identifier k
+func (c *Client) GetProvisionWatchersByIdentifier(k string, v string) ([]contract.ProvisionWatcher, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterProvisionWatchers(func(pw contract.ProvisionWatcher) bool {
+		value, ok := pw.Identifiers[k]
+		return ok && value == v
+	})
+}
+
+// AddProvisionWatcher adds the provision watcher, whose name must be unique and whose device profile and device
+// service, given by id or name, must exist
+func (c *Client) AddProvisionWatcher(pw contract.ProvisionWatcher) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.provisionWatchers.hasName(pw.Name) {
+		return "", db.ErrNotUnique
+	}
+	pw.Id = metadataId(pw.Id)
+	if err := c.putProvisionWatcher(pw); err != nil {
+		return "", err
+	}
+	return pw.Id, nil
+}
+
+// UpdateProvisionWatcher replaces the provision watcher of the same id
+func (c *Client) UpdateProvisionWatcher(pw contract.ProvisionWatcher) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := checkUpdate(c.provisionWatchers, pw.Id, pw.Name); err != nil {
+		return err
+	}
+	return c.putProvisionWatcher(pw)
+}
+
+// DeleteProvisionWatcherById deletes the provision watcher of the given id
+func (c *Client) DeleteProvisionWatcherById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.provisionWatchers.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (c *Client) putProvisionWatcher(pw contract.ProvisionWatcher) error {
+	if _, ok := c.deviceProfiles.get(pw.Profile.Id); !ok {
+		id, ok := c.deviceProfiles.names[pw.Profile.Name]
+		if !ok {
+			return errors.New("Invalid Device Profile")
+		}
+		pw.Profile.Id = id
+	}
+	if _, ok := c.deviceServices.get(pw.Service.Id); !ok {
+		id, ok := c.deviceServices.names[pw.Service.Name]
+		if !ok {
+			return errors.New("Invalid Device Service")
+		}
+		pw.Service.Id = id
+	}
+	pw.Timestamps = newTimestamps(pw.Timestamps)
+	c.provisionWatchers.put(pw.Id, pw.Name, pw)
+	return nil
+}
+
+// provisionWatcherOf returns the stored provision watcher along with its device profile and device service
+func (c *Client) provisionWatcherOf(pw contract.ProvisionWatcher) (contract.ProvisionWatcher, error) {
+	o, ok := c.deviceProfiles.get(pw.Profile.Id)
+	if !ok {
+		return contract.ProvisionWatcher{}, db.ErrNotFound
+	}
+	pw.Profile = o.(contract.DeviceProfile)
+	var err error
+	pw.Service, err = c.deviceServiceById(pw.Service.Id)
+	if err != nil {
+		return contract.ProvisionWatcher{}, err
+	}
+	return pw, nil
+}
+
+// filterProvisionWatchers returns the provision watchers whose stored version matches match, in the order they were
+// added
+func (c *Client) filterProvisionWatchers(match func(contract.ProvisionWatcher) bool) ([]contract.ProvisionWatcher, error) {
+	watchers := []contract.ProvisionWatcher{}
+	for _, o := range c.provisionWatchers.list() {
+		if pw := o.(contract.ProvisionWatcher); match(pw) {
+			pw, err := c.provisionWatcherOf(pw)
+			if err != nil {
+				return []contract.ProvisionWatcher{}, err
+			}
+			watchers = append(watchers, pw)
+		}
+	}
+	return watchers, nil
+}
+
+/* ----------------------------- Command ---------------------------------- */
+
+// GetAllCommands returns the commands of all the devices
+func (c *Client) GetAllCommands() ([]contract.Command, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterCommands(func(contract.Command) bool { return true }), nil
+}
+
+// GetCommandById returns the command of the given id
+func (c *Client) GetCommandById(id string) (contract.Command, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.commands.get(id)
+	if !ok {
+		return contract.Command{}, db.ErrNotFound
+	}
+	return o.(contract.Command), nil
+}
+
+// GetCommandsByName returns the commands of the given name, among all the devices
+func (c *Client) GetCommandsByName(n string) ([]contract.Command, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterCommands(func(cmd contract.Command) bool { return cmd.Name == n }), nil
+}
+
+// GetCommandsByDeviceId returns the commands of the given device
+func (c *Client) GetCommandsByDeviceId(did string) ([]contract.Command, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if _, ok := c.devices.get(did); !ok {
+		return []contract.Command{}, types.NewErrItemNotFound(fmt.Sprintf("device with id %s not found", did))
+	}
+	return c.deviceCommandsOf(did), nil
+}
+
+// GetCommandByNameAndDeviceId returns the command of the given name of the given device
+func (c *Client) GetCommandByNameAndDeviceId(cname string, did string) (contract.Command, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var found []contract.Command
+	for _, cmd := range c.deviceCommandsOf(did) {
+		if cmd.Name == cname {
+			found = append(found, cmd)
+		}
+	}
+	if len(found) != 1 {
+		return contract.Command{}, db.ErrNotFound
+	}
+	return found[0], nil
+}
+
+func (c *Client) deviceCommandsOf(deviceId string) []contract.Command {
+	commands := []contract.Command{}
+	for _, id := range c.deviceCommands[deviceId] {
+		if o, ok := c.commands.get(id); ok {
+			commands = append(commands, o.(contract.Command))
+		}
+	}
+	return commands
+}
+
+func (c *Client) filterCommands(match func(contract.Command) bool) []contract.Command {
+	commands := []contract.Command{}
+	for _, o := range c.commands.list() {
+		if cmd := o.(contract.Command); match(cmd) {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
@@ -0,0 +1,581 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"sort"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// inRange returns whether the timestamp is within start and end, an end lower than zero meaning no end
+func inRange(timestamp int64, start int64, end int64) bool {
+	return timestamp >= start && (end < 0 || timestamp <= end)
+}
+
+/* ----------------------------- Notification ---------------------------------- */
+
+// AddNotification adds the notification, whose slug must be unique
+func (c *Client) AddNotification(n contract.Notification) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.notifications.hasName(n.Slug) {
+		return "", errors.Errorf("%v, slug=%v", db.ErrNotUnique, n.Slug)
+	}
+	if n.ID == "" {
+		n.ID = uuid.New().String()
+	}
+	c.putNotification(n)
+	return n.ID, nil
+}
+
+// UpdateNotification replaces the notification of the same id
+func (c *Client) UpdateNotification(n contract.Notification) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.updateNotification(n)
+}
+
+// GetNotifications returns all the notifications in the order they were added
+func (c *Client) GetNotifications() ([]contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterNotifications(func(contract.Notification) bool { return true }), nil
+}
+
+// GetNotificationById returns the notification of the given id
+func (c *Client) GetNotificationById(id string) (contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.notifications.get(id)
+	if !ok {
+		return contract.Notification{}, db.ErrNotFound
+	}
+	return o.(contract.Notification), nil
+}
+
+// GetNotificationBySlug returns the notification of the given slug
+func (c *Client) GetNotificationBySlug(slug string) (contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.notifications.getByName(slug)
+	if !ok {
+		return contract.Notification{}, db.ErrNotFound
+	}
+	return o.(contract.Notification), nil
+}
+
+// GetNotificationBySender returns up to limit notifications of the given sender, in the order they were added
+func (c *Client) GetNotificationBySender(sender string, limit int) ([]contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	notifications := c.filterNotifications(func(n contract.Notification) bool { return n.Sender == sender })
+	return notifications[:limitOf(len(notifications), limit)], nil
+}
+
+// GetNotificationsByLabels returns up to limit notifications having any of the given labels, in the order they were
+// added
+func (c *Client) GetNotificationsByLabels(labels []string, limit int) ([]contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	notifications := c.filterNotifications(func(n contract.Notification) bool {
+		for _, label := range labels {
+			if contains(n.Labels, label) {
+				return true
+			}
+		}
+		return false
+	})
+	return notifications[:limitOf(len(notifications), limit)], nil
+}
+
+// GetNotificationsByStartEnd returns up to limit notifications created between start and end, the oldest first
+func (c *Client) GetNotificationsByStartEnd(start int64, end int64, limit int) ([]contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.notificationsByCreated(start, end, limit), nil
+}
+
+// GetNotificationsByStart returns up to limit notifications created since start, the oldest first
+func (c *Client) GetNotificationsByStart(start int64, limit int) ([]contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.notificationsByCreated(start, -1, limit), nil
+}
+
+// GetNotificationsByEnd returns up to limit notifications created until end, the oldest first
+func (c *Client) GetNotificationsByEnd(end int64, limit int) ([]contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.notificationsByCreated(0, end, limit), nil
+}
+
+// GetNewNotifications returns up to limit notifications of the NEW status, in the order they were added
+func (c *Client) GetNewNotifications(limit int) ([]contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	notifications := c.filterNotifications(func(n contract.Notification) bool { return n.Status == contract.New })
+	return notifications[:limitOf(len(notifications), limit)], nil
+}
+
+// GetNewNormalNotifications returns up to limit notifications of the NEW status and NORMAL severity, the latest added
+// first
+func (c *Client) GetNewNormalNotifications(limit int) ([]contract.Notification, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	notifications := c.filterNotifications(func(n contract.Notification) bool {
+		return n.Status == contract.New && n.Severity == contract.Normal
+	})
+	for i, j := 0, len(notifications)-1; i < j; i, j = i+1, j-1 {
+		notifications[i], notifications[j] = notifications[j], notifications[i]
+	}
+	return notifications[:limitOf(len(notifications), limit)], nil
+}
+
+// MarkNotificationProcessed updates the notification to the PROCESSED status
+func (c *Client) MarkNotificationProcessed(n contract.Notification) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	n.Status = contract.Processed
+	return c.updateNotification(n)
+}
+
+// DeleteNotificationById deletes the notification of the given id along with its transmissions
+func (c *Client) DeleteNotificationById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.deleteNotification(id)
+}
+
+// DeleteNotificationBySlug deletes the notification of the given slug along with its transmissions
+func (c *Client) DeleteNotificationBySlug(slug string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.notifications.names[slug]
+	if !ok {
+		return db.ErrNotFound
+	}
+	return c.deleteNotification(id)
+}
+
+// DeleteNotificationsOld deletes the processed notifications which were not modified for the given age in milliseconds
+func (c *Client) DeleteNotificationsOld(age int) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	end := db.MakeTimestamp() - int64(age)
+	for _, n := range c.filterNotifications(func(n contract.Notification) bool {
+		return n.Status == contract.Processed && n.Modified <= end
+	}) {
+		c.notifications.delete(n.ID)
+	}
+	return nil
+}
+
+// DeleteProcessedNotificationsBefore scans up to limit notifications modified at the end timestamp at the latest, the
+// least recently modified first from offset, and deletes the processed ones. It returns the number of notifications
+// deleted and the number scanned.
+func (c *Client) DeleteProcessedNotificationsBefore(end int64, offset int, limit int) (int, int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	notifications := c.filterNotifications(func(n contract.Notification) bool { return n.Modified <= end })
+	if offset >= len(notifications) {
+		return 0, 0, nil
+	}
+	sort.SliceStable(notifications, func(i, j int) bool { return notifications[i].Modified < notifications[j].Modified })
+	notifications = notifications[offset:]
+	notifications = notifications[:limitOf(len(notifications), limit)]
+
+	deleted := 0
+	for _, n := range notifications {
+		if n.Status == contract.Processed {
+			c.notifications.delete(n.ID)
+			deleted++
+		}
+	}
+	return deleted, len(notifications), nil
+}
+
+func (c *Client) putNotification(n contract.Notification) {
+	if n.Created == 0 {
+		n.Created = db.MakeTimestamp()
+		n.Modified = n.Created
+	}
+	c.notifications.put(n.ID, n.Slug, n)
+}
+
+func (c *Client) updateNotification(n contract.Notification) error {
+	if err := checkUpdate(c.notifications, n.ID, n.Slug); err != nil {
+		if err == db.ErrNotUnique {
+			return errors.Errorf("%v, slug=%v", db.ErrNotUnique, n.Slug)
+		}
+		return err
+	}
+	n.Modified = db.MakeTimestamp()
+	c.putNotification(n)
+	return nil
+}
+
+func (c *Client) deleteNotification(id string) error {
+	o, ok := c.notifications.get(id)
+	if !ok {
+		return db.ErrNotFound
+	}
+	c.notifications.delete(id)
+	c.deleteTransmissionsBySlug(o.(contract.Notification).Slug)
+	return nil
+}
+
+// notificationsByCreated returns up to limit notifications created between start and end, the oldest first
+func (c *Client) notificationsByCreated(start int64, end int64, limit int) []contract.Notification {
+	notifications := c.filterNotifications(func(n contract.Notification) bool { return inRange(n.Created, start, end) })
+	sort.SliceStable(notifications, func(i, j int) bool { return notifications[i].Created < notifications[j].Created })
+	return notifications[:limitOf(len(notifications), limit)]
+}
+
+func (c *Client) filterNotifications(match func(contract.Notification) bool) []contract.Notification {
+	notifications := []contract.Notification{}
+	for _, o := range c.notifications.list() {
+		if n := o.(contract.Notification); match(n) {
+			notifications = append(notifications, n)
+		}
+	}
+	return notifications
+}
+
+/* ----------------------------- Subscription ---------------------------------- */
+
+// AddSubscription adds the subscription, whose slug must be unique
+func (c *Client) AddSubscription(s contract.Subscription) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.subscriptions.hasName(s.Slug) {
+		return "", errors.Errorf("%v, slug=%v", db.ErrNotUnique, s.Slug)
+	}
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	c.putSubscription(s)
+	return s.ID, nil
+}
+
+// UpdateSubscription replaces the subscription of the same id, doing nothing when there is none as the Redis
+// implementation does
+func (c *Client) UpdateSubscription(s contract.Subscription) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := checkUpdate(c.subscriptions, s.ID, s.Slug); err != nil {
+		if err == db.ErrNotUnique {
+			return errors.Errorf("%v, slug=%v", db.ErrNotUnique, s.Slug)
+		}
+		return nil
+	}
+	s.Modified = db.MakeTimestamp()
+	c.putSubscription(s)
+	return nil
+}
+
+// GetSubscriptions returns all the subscriptions in the order they were added
+func (c *Client) GetSubscriptions() ([]contract.Subscription, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterSubscriptions(func(contract.Subscription) bool { return true }), nil
+}
+
+// GetSubscriptionById returns the subscription of the given id
+func (c *Client) GetSubscriptionById(id string) (contract.Subscription, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.subscriptions.get(id)
+	if !ok {
+		return contract.Subscription{}, db.ErrNotFound
+	}
+	return o.(contract.Subscription), nil
+}
+
+// DeleteSubscriptionById deletes the subscription of the given id
+func (c *Client) DeleteSubscriptionById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.subscriptions.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// GetSubscriptionBySlug returns the subscription of the given slug
+func (c *Client) GetSubscriptionBySlug(slug string) (contract.Subscription, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.subscriptions.getByName(slug)
+	if !ok {
+		return contract.Subscription{}, db.ErrNotFound
+	}
+	return o.(contract.Subscription), nil
+}
+
+// GetSubscriptionByReceiver returns the subscriptions of the given receiver
+func (c *Client) GetSubscriptionByReceiver(receiver string) ([]contract.Subscription, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterSubscriptions(func(s contract.Subscription) bool { return s.Receiver == receiver }), nil
+}
+
+// GetSubscriptionByCategories returns the subscriptions to any of the given categories
+func (c *Client) GetSubscriptionByCategories(categories []string) ([]contract.Subscription, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.subscriptionsByCategoriesLabels(categories, nil), nil
+}
+
+// GetSubscriptionByLabels returns the subscriptions to any of the given labels
+func (c *Client) GetSubscriptionByLabels(labels []string) ([]contract.Subscription, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.subscriptionsByCategoriesLabels(nil, labels), nil
+}
+
+// GetSubscriptionByCategoriesLabels returns the subscriptions to any of the given categories or labels
+func (c *Client) GetSubscriptionByCategoriesLabels(categories []string, labels []string) ([]contract.Subscription, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.subscriptionsByCategoriesLabels(categories, labels), nil
+}
+
+// DeleteSubscriptionBySlug deletes the subscription of the given slug
+func (c *Client) DeleteSubscriptionBySlug(slug string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.subscriptions.names[slug]
+	if !ok {
+		return db.ErrNotFound
+	}
+	c.subscriptions.delete(id)
+	return nil
+}
+
+func (c *Client) putSubscription(s contract.Subscription) {
+	if s.Created == 0 {
+		s.Created = db.MakeTimestamp()
+		s.Modified = s.Created
+	}
+	c.subscriptions.put(s.ID, s.Slug, s)
+}
+
+func (c *Client) subscriptionsByCategoriesLabels(categories []string, labels []string) []contract.Subscription {
+	return c.filterSubscriptions(func(s contract.Subscription) bool {
+		for _, category := range s.SubscribedCategories {
+			if contains(categories, string(category)) {
+				return true
+			}
+		}
+		for _, label := range s.SubscribedLabels {
+			if contains(labels, label) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (c *Client) filterSubscriptions(match func(contract.Subscription) bool) []contract.Subscription {
+	subscriptions := []contract.Subscription{}
+	for _, o := range c.subscriptions.list() {
+		if s := o.(contract.Subscription); match(s) {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	return subscriptions
+}
+
+/* ----------------------------- Transmission ---------------------------------- */
+
+// AddTransmission adds the transmission
+func (c *Client) AddTransmission(t contract.Transmission) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	c.putTransmission(t)
+	return t.ID, nil
+}
+
+// UpdateTransmission replaces the transmission of the same id, doing nothing when there is none as the Redis
+// implementation does
+func (c *Client) UpdateTransmission(t contract.Transmission) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.transmissions.get(t.ID); !ok {
+		return nil
+	}
+	t.Modified = db.MakeTimestamp()
+	c.putTransmission(t)
+	return nil
+}
+
+// GetTransmissionsByNotificationSlug returns up to limit transmissions of the notification of the given slug, the
+// oldest first
+func (c *Client) GetTransmissionsByNotificationSlug(slug string, limit int) ([]contract.Transmission, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.transmissionsByCreated(slug, 0, -1, limit), nil
+}
+
+// GetTransmissionsByNotificationSlugAndStartEnd returns up to limit transmissions of the notification of the given slug
+// created between start and end, the oldest first
+func (c *Client) GetTransmissionsByNotificationSlugAndStartEnd(slug string, start int64, end int64, limit int) ([]contract.Transmission, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.transmissionsByCreated(slug, start, end, limit), nil
+}
+
+// GetTransmissionsByStartEnd returns up to limit transmissions created between start and end, the oldest first
+func (c *Client) GetTransmissionsByStartEnd(start int64, end int64, limit int) ([]contract.Transmission, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.transmissionsByCreated("", start, end, limit), nil
+}
+
+// GetTransmissionsByStart returns up to limit transmissions created since start, the oldest first
+func (c *Client) GetTransmissionsByStart(start int64, limit int) ([]contract.Transmission, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.transmissionsByCreated("", start, -1, limit), nil
+}
+
+// GetTransmissionById returns the transmission of the given id
+func (c *Client) GetTransmissionById(id string) (contract.Transmission, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.transmissions.get(id)
+	if !ok {
+		return contract.Transmission{}, db.ErrNotFound
+	}
+	return o.(contract.Transmission), nil
+}
+
+// GetTransmissionsByEnd returns up to limit transmissions created until end, the oldest first
+func (c *Client) GetTransmissionsByEnd(end int64, limit int) ([]contract.Transmission, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.transmissionsByCreated("", 0, end, limit), nil
+}
+
+// GetTransmissionsByStatus returns up to limit transmissions of the given status, in the order they were added
+func (c *Client) GetTransmissionsByStatus(limit int, status contract.TransmissionStatus) ([]contract.Transmission, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	transmissions := c.filterTransmissions(func(t contract.Transmission) bool { return t.Status == status })
+	return transmissions[:limitOf(len(transmissions), limit)], nil
+}
+
+// DeleteTransmission deletes the transmissions of the given status which were not modified for the given age in
+// milliseconds
+func (c *Client) DeleteTransmission(age int64, status contract.TransmissionStatus) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	end := db.MakeTimestamp() - age
+	for _, t := range c.filterTransmissions(func(t contract.Transmission) bool {
+		return t.Status == status && t.Modified <= end
+	}) {
+		c.transmissions.delete(t.ID)
+	}
+	return nil
+}
+
+// Cleanup deletes all the notifications along with their transmissions
+func (c *Client) Cleanup() error {
+	return c.CleanupOld(0)
+}
+
+// CleanupOld deletes the notifications created before the given age in milliseconds, along with their transmissions
+func (c *Client) CleanupOld(age int) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, n := range c.notificationsByCreated(0, db.MakeTimestamp()-int64(age), 0) {
+		if err := c.deleteNotification(n.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) putTransmission(t contract.Transmission) {
+	if t.Created == 0 {
+		t.Created = db.MakeTimestamp()
+		t.Modified = t.Created
+	}
+	c.transmissions.put(t.ID, "", t)
+}
+
+func (c *Client) deleteTransmissionsBySlug(slug string) {
+	for _, t := range c.filterTransmissions(func(t contract.Transmission) bool { return t.Notification.Slug == slug }) {
+		c.transmissions.delete(t.ID)
+	}
+}
+
+// transmissionsByCreated returns up to limit transmissions created between start and end, the oldest first, only
+// keeping the ones of the notification of the given slug unless it is empty
+func (c *Client) transmissionsByCreated(slug string, start int64, end int64, limit int) []contract.Transmission {
+	transmissions := c.filterTransmissions(func(t contract.Transmission) bool {
+		return (slug == "" || t.Notification.Slug == slug) && inRange(t.Created, start, end)
+	})
+	sort.SliceStable(transmissions, func(i, j int) bool { return transmissions[i].Created < transmissions[j].Created })
+	return transmissions[:limitOf(len(transmissions), limit)]
+}
+
+func (c *Client) filterTransmissions(match func(contract.Transmission) bool) []contract.Transmission {
+	transmissions := []contract.Transmission{}
+	for _, o := range c.transmissions.list() {
+		if t := o.(contract.Transmission); match(t) {
+			transmissions = append(transmissions, t)
+		}
+	}
+	return transmissions
+}
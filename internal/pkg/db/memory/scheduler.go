@@ -0,0 +1,234 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+/* ----------------------------- Interval ---------------------------------- */
+
+// Intervals returns all the intervals in the order they were added
+func (c *Client) Intervals() ([]contract.Interval, error) {
+	return c.IntervalsWithLimit(0)
+}
+
+// IntervalsWithLimit returns up to limit intervals in the order they were added
+func (c *Client) IntervalsWithLimit(limit int) ([]contract.Interval, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects := c.intervals.list()
+	intervals := make([]contract.Interval, limitOf(len(objects), limit))
+	for i := range intervals {
+		intervals[i] = objects[i].(contract.Interval)
+	}
+	return intervals, nil
+}
+
+// IntervalByName returns the interval of the given name
+func (c *Client) IntervalByName(name string) (contract.Interval, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.intervals.getByName(name)
+	if !ok {
+		return contract.Interval{}, db.ErrNotFound
+	}
+	return o.(contract.Interval), nil
+}
+
+// IntervalById returns the interval of the given id
+func (c *Client) IntervalById(id string) (contract.Interval, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.intervals.get(id)
+	if !ok {
+		return contract.Interval{}, db.ErrNotFound
+	}
+	return o.(contract.Interval), nil
+}
+
+// AddInterval adds the interval, whose name must be unique
+func (c *Client) AddInterval(from contract.Interval) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.intervals.hasName(from.Name) {
+		return "", db.ErrNotUnique
+	}
+	id, err := dataId(from.ID)
+	if err != nil {
+		return "", err
+	}
+	from.ID = id
+	if from.Timestamps.Created == 0 {
+		from.Timestamps.Created = db.MakeTimestamp()
+		from.Timestamps.Modified = from.Timestamps.Created
+	}
+	c.intervals.put(from.ID, from.Name, from)
+	return from.ID, nil
+}
+
+// UpdateInterval updates the interval of the same id, keeping the fields left empty
+func (c *Client) UpdateInterval(from contract.Interval) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := checkUpdate(c.intervals, from.ID, from.Name); err != nil {
+		return err
+	}
+	o, _ := c.intervals.get(from.ID)
+	from.Timestamps.Modified = db.MakeTimestamp()
+	merge(&from, o.(contract.Interval))
+	c.intervals.put(from.ID, from.Name, from)
+	return nil
+}
+
+// DeleteIntervalById deletes the interval of the given id
+func (c *Client) DeleteIntervalById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.intervals.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// ScrubAllIntervals deletes all the intervals
+func (c *Client) ScrubAllIntervals() (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.intervals = newCollection()
+	return 0, nil
+}
+
+/* ----------------------------- Interval Action ---------------------------------- */
+
+// IntervalActions returns all the interval actions in the order they were added
+func (c *Client) IntervalActions() ([]contract.IntervalAction, error) {
+	return c.IntervalActionsWithLimit(0)
+}
+
+// IntervalActionsWithLimit returns up to limit interval actions in the order they were added
+func (c *Client) IntervalActionsWithLimit(limit int) ([]contract.IntervalAction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	actions := c.filterIntervalActions(func(contract.IntervalAction) bool { return true })
+	return actions[:limitOf(len(actions), limit)], nil
+}
+
+// IntervalActionsByIntervalName returns the interval actions of the interval of the given name
+func (c *Client) IntervalActionsByIntervalName(name string) ([]contract.IntervalAction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterIntervalActions(func(a contract.IntervalAction) bool { return a.Interval == name }), nil
+}
+
+// IntervalActionsByTarget returns the interval actions of the given target
+func (c *Client) IntervalActionsByTarget(name string) ([]contract.IntervalAction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.filterIntervalActions(func(a contract.IntervalAction) bool { return a.Target == name }), nil
+}
+
+// IntervalActionById returns the interval action of the given id
+func (c *Client) IntervalActionById(id string) (contract.IntervalAction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.intervalActions.get(id)
+	if !ok {
+		return contract.IntervalAction{}, db.ErrNotFound
+	}
+	return o.(contract.IntervalAction), nil
+}
+
+// IntervalActionByName returns the interval action of the given name
+func (c *Client) IntervalActionByName(name string) (contract.IntervalAction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.intervalActions.getByName(name)
+	if !ok {
+		return contract.IntervalAction{}, db.ErrNotFound
+	}
+	return o.(contract.IntervalAction), nil
+}
+
+// AddIntervalAction adds the interval action, whose name must be unique
+func (c *Client) AddIntervalAction(from contract.IntervalAction) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.intervalActions.hasName(from.Name) {
+		return "", db.ErrNotUnique
+	}
+	id, err := dataId(from.ID)
+	if err != nil {
+		return "", err
+	}
+	from.ID = id
+	if from.Created == 0 {
+		from.Created = db.MakeTimestamp()
+		from.Modified = from.Created
+	}
+	c.intervalActions.put(from.ID, from.Name, from)
+	return from.ID, nil
+}
+
+// UpdateIntervalAction updates the interval action of the same id, keeping the fields left empty
+func (c *Client) UpdateIntervalAction(from contract.IntervalAction) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := checkUpdate(c.intervalActions, from.ID, from.Name); err != nil {
+		return err
+	}
+	o, _ := c.intervalActions.get(from.ID)
+	from.Modified = db.MakeTimestamp()
+	merge(&from, o.(contract.IntervalAction))
+	c.intervalActions.put(from.ID, from.Name, from)
+	return nil
+}
+
+// DeleteIntervalActionById deletes the interval action of the given id
+func (c *Client) DeleteIntervalActionById(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.intervalActions.delete(id) {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// ScrubAllIntervalActions deletes all the interval actions
+func (c *Client) ScrubAllIntervalActions() (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.intervalActions = newCollection()
+	return 0, nil
+}
+
+func (c *Client) filterIntervalActions(match func(contract.IntervalAction) bool) []contract.IntervalAction {
+	actions := []contract.IntervalAction{}
+	for _, o := range c.intervalActions.list() {
+		if a := o.(contract.IntervalAction); match(a) {
+			actions = append(actions, a)
+		}
+	}
+	return actions
+}
@@ -0,0 +1,44 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+package redis
+
+import (
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// AcquireLease takes the lease stored at key for owner, or extends it if owner already holds it.
+// It returns false if another owner holds an unexpired lease.
+func (c *Client) AcquireLease(key string, owner string, ttl time.Duration) (bool, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	s := scripts["acquireLease"]
+	acquired, err := redis.Int(s.Do(conn, key, owner, ttl.Milliseconds()))
+	if err != nil {
+		return false, err
+	}
+	return acquired == 1, nil
+}
+
+// ReleaseLease deletes the lease stored at key if, and only if, it is held by owner.
+func (c *Client) ReleaseLease(key string, owner string) error {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	s := scripts["releaseLease"]
+	_, err := s.Do(conn, key, owner)
+	return err
+}
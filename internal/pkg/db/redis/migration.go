@@ -0,0 +1,279 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/coordination"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+)
+
+const (
+	// migrationLeaseDuration is how long the migration lock is held without a batch being migrated
+	migrationLeaseDuration = 30 * time.Second
+	// migrationProgressInterval is how often the progress of a migration is logged
+	migrationProgressInterval = 10 * time.Second
+	// scanBatchSize is the number of keys scanned by each batch of the migrations built by ScanMigration
+	scanBatchSize = 1000
+
+	progressVersion  = "version"
+	progressCursor   = "cursor"
+	progressMigrated = "migrated"
+)
+
+// ErrMigrationLocked is returned by Migrate when another instance is migrating the same keys
+var ErrMigrationLocked = errors.New("the database is being migrated by another instance")
+
+// Migration changes the layout of the keys from the one of the previous version to the one of Version.
+type Migration struct {
+	// Version is the schema version of the layout once migrated, starting at 1
+	Version int
+	// Description tells what the migration changes, for the logs
+	Description string
+	// Step migrates the batch of keys following cursor, "0" for the first batch, and returns the cursor of the next
+	// batch, "0" once done, along with the number of keys migrated. In dry runs it only counts the keys it would
+	// migrate. Steps must be idempotent since the batch that was interrupted is migrated again on resume.
+	Step func(conn redis.Conn, cursor string, dryRun bool) (next string, migrated int, err error)
+}
+
+// ScanMigration returns a Migration calling migrate for each key matching the pattern match, which returns whether
+// the key needed to be migrated. The keys are scanned in batches so that an interrupted migration is resumed from
+// the last completed batch.
+func ScanMigration(
+	version int,
+	description string,
+	match string,
+	migrate func(conn redis.Conn, key string, dryRun bool) (bool, error)) Migration {
+
+	return Migration{
+		Version:     version,
+		Description: description,
+		Step: func(conn redis.Conn, cursor string, dryRun bool) (string, int, error) {
+			values, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", match, "COUNT", scanBatchSize))
+			if err != nil {
+				return "", 0, err
+			}
+			var next string
+			var keys []string
+			if _, err := redis.Scan(values, &next, &keys); err != nil {
+				return "", 0, err
+			}
+
+			migrated := 0
+			for _, key := range keys {
+				changed, err := migrate(conn, key, dryRun)
+				if err != nil {
+					return "", migrated, fmt.Errorf("failed to migrate key %s: %s", key, err.Error())
+				}
+				if changed {
+					migrated++
+				}
+			}
+			return next, migrated, nil
+		},
+	}
+}
+
+// Migrator brings the layout of the keys of a service up to the latest version of its migrations. The version of
+// the layout is stored along with the keys, and the progress of a migration after each batch so that a migration
+// interrupted by a restart resumes where it stopped.
+type Migrator struct {
+	lc          logger.LoggingClient
+	pool        *redis.Pool
+	lease       coordination.Lease
+	owner       string
+	versionKey  string
+	progressKey string
+	lockKey     string
+	migrations  []Migration
+}
+
+// NewMigrator is a factory method that returns a Migrator storing its state under the keys starting with keyPrefix.
+// The migrations must be ordered by strictly increasing versions.
+func NewMigrator(
+	lc logger.LoggingClient,
+	pool *redis.Pool,
+	lease coordination.Lease,
+	keyPrefix string,
+	migrations []Migration) (*Migrator, error) {
+
+	previous := 0
+	for _, m := range migrations {
+		if m.Version <= previous {
+			return nil, fmt.Errorf("migration '%s' has version %d, which doesn't follow version %d", m.Description, m.Version, previous)
+		}
+		if m.Step == nil {
+			return nil, fmt.Errorf("migration to version %d has no step", m.Version)
+		}
+		previous = m.Version
+	}
+
+	return &Migrator{
+		lc:          lc,
+		pool:        pool,
+		lease:       lease,
+		owner:       uuid.New().String(),
+		versionKey:  keyPrefix + "|schema|version",
+		progressKey: keyPrefix + "|schema|progress",
+		lockKey:     keyPrefix + "|schema|lock",
+		migrations:  migrations,
+	}, nil
+}
+
+// LatestVersion returns the version of the layout once every migration is run
+func (m *Migrator) LatestVersion() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the version of the stored layout, 0 when no migration ever completed
+func (m *Migrator) Version() (int, error) {
+	conn := m.pool.Get()
+	defer conn.Close()
+
+	return m.version(conn)
+}
+
+func (m *Migrator) version(conn redis.Conn) (int, error) {
+	version, err := redis.Int(conn.Do("GET", m.versionKey))
+	if err == redis.ErrNil {
+		return 0, nil
+	}
+	return version, err
+}
+
+// Migrate runs the pending migrations in order. In a dry run, nothing is changed and the number of keys each pending
+// migration would migrate is logged, counted against the current layout. ErrMigrationLocked is returned while
+// another instance is migrating.
+func (m *Migrator) Migrate(dryRun bool) error {
+	acquired, err := m.lease.AcquireLease(m.lockKey, m.owner, migrationLeaseDuration)
+	if err != nil {
+		return fmt.Errorf("failed to lock the database for migration: %s", err.Error())
+	}
+	if !acquired {
+		return ErrMigrationLocked
+	}
+	defer func() {
+		if err := m.lease.ReleaseLease(m.lockKey, m.owner); err != nil {
+			m.lc.Warn(fmt.Sprintf("failed to unlock the database after migration: %s", err.Error()))
+		}
+	}()
+
+	conn := m.pool.Get()
+	defer conn.Close()
+
+	current, err := m.version(conn)
+	if err != nil {
+		return fmt.Errorf("failed to read the database schema version: %s", err.Error())
+	}
+	latest := m.LatestVersion()
+	if current > latest {
+		return fmt.Errorf("the database schema version %d is newer than the latest version %d known to this release", current, latest)
+	}
+	if current == latest {
+		m.lc.Debug(fmt.Sprintf("database schema is up to date at version %d", current))
+		return nil
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err := m.run(conn, migration, dryRun); err != nil {
+			return fmt.Errorf("migration to database schema version %d failed: %s", migration.Version, err.Error())
+		}
+	}
+	return nil
+}
+
+// run runs a migration batch by batch, saving its progress after each batch and the new version once done
+func (m *Migrator) run(conn redis.Conn, migration Migration, dryRun bool) error {
+	cursor, migrated := "0", 0
+	if !dryRun {
+		progress, err := redis.StringMap(conn.Do("HGETALL", m.progressKey))
+		if err != nil {
+			return err
+		}
+		if progress[progressVersion] == strconv.Itoa(migration.Version) {
+			cursor = progress[progressCursor]
+			migrated, _ = strconv.Atoi(progress[progressMigrated])
+			m.lc.Info(fmt.Sprintf("resuming migration to database schema version %d after %d migrated keys", migration.Version, migrated))
+		}
+	}
+
+	mode := ""
+	if dryRun {
+		mode = " (dry run)"
+	}
+	m.lc.Info(fmt.Sprintf("migrating database schema to version %d%s: %s", migration.Version, mode, migration.Description))
+
+	lastLog := time.Now()
+	for {
+		// Extending the lease after each batch keeps other instances out as long as the migration progresses
+		acquired, err := m.lease.AcquireLease(m.lockKey, m.owner, migrationLeaseDuration)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			return errors.New("lost the database migration lock")
+		}
+
+		next, count, err := migration.Step(conn, cursor, dryRun)
+		if err != nil {
+			return err
+		}
+		migrated += count
+		// The last batch isn't saved, the new version is
+		if !dryRun && next != "0" {
+			_, err = conn.Do("HSET", m.progressKey,
+				progressVersion, migration.Version, progressCursor, next, progressMigrated, migrated)
+			if err != nil {
+				return fmt.Errorf("failed to save the migration progress: %s", err.Error())
+			}
+		}
+		if next == "0" {
+			break
+		}
+		cursor = next
+
+		if time.Since(lastLog) >= migrationProgressInterval {
+			m.lc.Info(fmt.Sprintf("migrating database schema to version %d%s: %d keys migrated so far", migration.Version, mode, migrated))
+			lastLog = time.Now()
+		}
+	}
+
+	if dryRun {
+		m.lc.Info(fmt.Sprintf("migration to database schema version %d would migrate %d keys", migration.Version, migrated))
+		return nil
+	}
+
+	_ = conn.Send("MULTI")
+	_ = conn.Send("SET", m.versionKey, migration.Version)
+	_ = conn.Send("DEL", m.progressKey)
+	if _, err := conn.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to save the database schema version: %s", err.Error())
+	}
+	m.lc.Info(fmt.Sprintf("migrated database schema to version %d: %d keys migrated", migration.Version, migrated))
+	return nil
+}
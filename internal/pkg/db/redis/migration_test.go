@@ -0,0 +1,266 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore holds the string and hash keys of a fake Redis, enough for the migrations
+type fakeStore struct {
+	strings map[string]string
+	hashes  map[string]map[string]string
+	cursors []string
+}
+
+func toReplies(values []string) []interface{} {
+	replies := make([]interface{}, len(values))
+	for i, value := range values {
+		replies[i] = []byte(value)
+	}
+	return replies
+}
+
+// fakeConn runs the commands used by the migrations against a fakeStore. SCAN returns up to two keys per batch.
+type fakeConn struct {
+	store   *fakeStore
+	pending [][]interface{}
+}
+
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Err() error   { return nil }
+func (c *fakeConn) Flush() error { return nil }
+
+func (c *fakeConn) Receive() (interface{}, error) { return nil, errors.New("not supported") }
+
+func (c *fakeConn) Send(command string, args ...interface{}) error {
+	if command != "MULTI" {
+		c.pending = append(c.pending, append([]interface{}{command}, args...))
+	}
+	return nil
+}
+
+func (c *fakeConn) Do(command string, args ...interface{}) (interface{}, error) {
+	s := c.store
+	switch command {
+	case "":
+		return nil, nil
+	case "EXEC":
+		var replies []interface{}
+		for _, cmd := range c.pending {
+			reply, err := c.Do(cmd[0].(string), cmd[1:]...)
+			if err != nil {
+				return nil, err
+			}
+			replies = append(replies, reply)
+		}
+		c.pending = nil
+		return replies, nil
+	case "GET":
+		value, ok := s.strings[fmt.Sprint(args[0])]
+		if !ok {
+			return nil, nil
+		}
+		return []byte(value), nil
+	case "SET":
+		s.strings[fmt.Sprint(args[0])] = fmt.Sprint(args[1])
+		return "OK", nil
+	case "DEL":
+		delete(s.strings, fmt.Sprint(args[0]))
+		delete(s.hashes, fmt.Sprint(args[0]))
+		return int64(1), nil
+	case "RENAME":
+		s.strings[fmt.Sprint(args[1])] = s.strings[fmt.Sprint(args[0])]
+		delete(s.strings, fmt.Sprint(args[0]))
+		return "OK", nil
+	case "HSET":
+		hash, ok := s.hashes[fmt.Sprint(args[0])]
+		if !ok {
+			hash = make(map[string]string)
+			s.hashes[fmt.Sprint(args[0])] = hash
+		}
+		for i := 1; i+1 < len(args); i += 2 {
+			hash[fmt.Sprint(args[i])] = fmt.Sprint(args[i+1])
+		}
+		return int64(len(args) / 2), nil
+	case "HGETALL":
+		var reply []interface{}
+		for field, value := range s.hashes[fmt.Sprint(args[0])] {
+			reply = append(reply, []byte(field), []byte(value))
+		}
+		return reply, nil
+	case "SCAN":
+		// The cursors are stable as the keys are renamed, as those of Redis are
+		cursor, _ := strconv.Atoi(fmt.Sprint(args[0]))
+		prefix := strings.TrimSuffix(fmt.Sprint(args[2]), "*")
+		var keys []string
+		for key := range s.strings {
+			if strings.HasPrefix(key, prefix) && (cursor == 0 || key > s.cursors[cursor]) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		if len(keys) <= 2 {
+			return []interface{}{[]byte("0"), toReplies(keys)}, nil
+		}
+		keys = keys[:2]
+		s.cursors = append(s.cursors, keys[1])
+		return []interface{}{[]byte(strconv.Itoa(len(s.cursors) - 1)), toReplies(keys)}, nil
+	}
+	return nil, fmt.Errorf("command %s not supported", command)
+}
+
+type fakeLease struct {
+	owner string
+}
+
+func (l *fakeLease) AcquireLease(_ string, owner string, _ time.Duration) (bool, error) {
+	if l.owner != "" && l.owner != owner {
+		return false, nil
+	}
+	l.owner = owner
+	return true, nil
+}
+
+func (l *fakeLease) ReleaseLease(_ string, owner string) error {
+	if l.owner == owner {
+		l.owner = ""
+	}
+	return nil
+}
+
+func newTestStore() *fakeStore {
+	store := &fakeStore{strings: make(map[string]string), hashes: make(map[string]map[string]string), cursors: []string{""}}
+	for i := 1; i <= 5; i++ {
+		store.strings[fmt.Sprintf("old:%d", i)] = strconv.Itoa(i)
+	}
+	return store
+}
+
+func newTestPool(store *fakeStore) *redis.Pool {
+	return &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return &fakeConn{store: store}, nil
+		},
+	}
+}
+
+// renameMigration renames the old keys, failing on the key failOn if set
+func renameMigration(failOn *string) Migration {
+	return ScanMigration(1, "rename the old keys", "old:*", func(conn redis.Conn, key string, dryRun bool) (bool, error) {
+		if failOn != nil && key == *failOn {
+			return false, errors.New("interrupted")
+		}
+		if dryRun {
+			return true, nil
+		}
+		_, err := conn.Do("RENAME", key, "new:"+strings.TrimPrefix(key, "old:"))
+		return err == nil, err
+	})
+}
+
+func TestNewMigrator(t *testing.T) {
+	step := renameMigration(nil).Step
+	tests := []struct {
+		name          string
+		migrations    []Migration
+		errorExpected bool
+	}{
+		{"Valid", []Migration{{Version: 1, Step: step}, {Version: 3, Step: step}}, false},
+		{"Valid - no migrations", nil, false},
+		{"Invalid - version 0", []Migration{{Version: 0, Step: step}}, true},
+		{"Invalid - not ordered", []Migration{{Version: 2, Step: step}, {Version: 1, Step: step}}, true},
+		{"Invalid - no step", []Migration{{Version: 1}}, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewMigrator(logger.NewMockClient(), newTestPool(newTestStore()), &fakeLease{}, "test", testCase.migrations)
+			if testCase.errorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	store := newTestStore()
+	migrator, err := NewMigrator(logger.NewMockClient(), newTestPool(store), &fakeLease{}, "test",
+		[]Migration{renameMigration(nil)})
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Migrate(true))
+	assert.Contains(t, store.strings, "old:1", "a dry run must not change the keys")
+	version, err := migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, 0, version, "a dry run must not change the version")
+
+	require.NoError(t, migrator.Migrate(false))
+	for i := 1; i <= 5; i++ {
+		assert.Equal(t, strconv.Itoa(i), store.strings[fmt.Sprintf("new:%d", i)])
+		assert.NotContains(t, store.strings, fmt.Sprintf("old:%d", i))
+	}
+	version, err = migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+	assert.NotContains(t, store.hashes, "test|schema|progress", "the progress must be cleared once migrated")
+
+	require.NoError(t, migrator.Migrate(false), "migrating an up to date layout must do nothing")
+}
+
+func TestMigrateResume(t *testing.T) {
+	store := newTestStore()
+	failOn := "old:4"
+	migrator, err := NewMigrator(logger.NewMockClient(), newTestPool(store), &fakeLease{}, "test",
+		[]Migration{renameMigration(&failOn)})
+	require.NoError(t, err)
+
+	require.Error(t, migrator.Migrate(false))
+	assert.Contains(t, store.strings, "new:2", "the completed batches must be kept")
+	assert.Equal(t, "1", store.hashes["test|schema|progress"][progressCursor])
+	assert.Equal(t, "2", store.hashes["test|schema|progress"][progressMigrated])
+
+	failOn = ""
+	require.NoError(t, migrator.Migrate(false))
+	assert.Len(t, store.strings, 6, "every key must be renamed and the version stored")
+	assert.Equal(t, "1", store.strings["test|schema|version"])
+}
+
+func TestMigrateErrors(t *testing.T) {
+	store := newTestStore()
+	store.strings["test|schema|version"] = "2"
+	lease := &fakeLease{}
+	migrator, err := NewMigrator(logger.NewMockClient(), newTestPool(store), lease, "test",
+		[]Migration{renameMigration(nil)})
+	require.NoError(t, err)
+
+	assert.Error(t, migrator.Migrate(false), "a layout newer than the migrations must be rejected")
+	assert.Empty(t, lease.owner, "the lock must be released")
+
+	lease.owner = "another instance"
+	assert.Equal(t, ErrMigrationLocked, migrator.Migrate(false))
+}
@@ -290,6 +290,45 @@ func (c Client) DeleteNotificationsOld(age int) error {
 	return err
 }
 
+// DeleteProcessedNotificationsBefore scans up to limit notifications modified at the end timestamp at the latest, the
+// least recently modified first from offset, and deletes the processed ones. It returns the number of notifications
+// deleted and the number scanned, those left being skipped by the offset of the next call.
+func (c Client) DeleteProcessedNotificationsBefore(end int64, offset int, limit int) (int, int, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	ids, err := redis.Values(conn.Do("ZRANGEBYSCORE", db.Notification+":modified", 0, end, "LIMIT", offset, limit))
+	if err != nil && err != redis.ErrNil {
+		return 0, 0, err
+	}
+	if len(ids) == 0 {
+		return 0, 0, nil
+	}
+	objects, err := redis.ByteSlices(conn.Do("MGET", ids...))
+	if err != nil {
+		return 0, len(ids), err
+	}
+
+	deleted := 0
+	for _, object := range objects {
+		if len(object) == 0 {
+			continue
+		}
+		var n contract.Notification
+		if err := unmarshalObject(object, &n); err != nil {
+			return deleted, len(ids), err
+		}
+		if n.Status != contract.Processed {
+			continue
+		}
+		if err := deleteNotification(conn, n.ID); err != nil {
+			return deleted, len(ids), err
+		}
+		deleted++
+	}
+	return deleted, len(ids), nil
+}
+
 // ******************************* SUBSCRIPTIONS **********************************
 func (c Client) AddSubscription(s contract.Subscription) (string, error) {
 	conn := c.Pool.Get()
@@ -108,6 +108,23 @@ const (
 		end
 	until c == 0
 	`
+	scriptAcquireLease = `
+	local owner = redis.call('GET', KEYS[1])
+	if owner == ARGV[1] then
+		redis.call('PEXPIRE', KEYS[1], ARGV[2])
+		return 1
+	elseif owner == false then
+		redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+		return 1
+	end
+	return 0
+	`
+	scriptReleaseLease = `
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+	`
 )
 
 var scripts = map[string]redis.Script{
@@ -116,6 +133,8 @@ var scripts = map[string]redis.Script{
 	"getObjectsByScore":       *redis.NewScript(1, scriptGetObjectsByScore),
 	"unlinkZsetMembers":       *redis.NewScript(1, scriptUnlinkZsetMembers),
 	"unlinkCollection":        *redis.NewScript(0, scriptUnlinkCollection),
+	"acquireLease":            *redis.NewScript(1, scriptAcquireLease),
+	"releaseLease":            *redis.NewScript(1, scriptReleaseLease),
 }
 
 func getObjectsByRangeLua(conn redis.Conn, key string, start, end int) (objects [][]byte, err error) {
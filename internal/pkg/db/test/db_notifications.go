@@ -170,6 +170,46 @@ func testDBNotification(t *testing.T, db interfaces.DBClient) {
 		t.Fatalf("Fail to delete notification by slug '%v'", notification.Slug)
 	}
 
+	// Test DeleteProcessedNotificationsBefore
+	notifications, err = db.GetNotifications()
+	if err != nil {
+		t.Fatalf("Error getting notifications %v", err)
+	}
+	processed := 0
+	for _, n := range notifications {
+		if n.Status == contract.Processed {
+			processed++
+		}
+	}
+	end := dbp.MakeTimestamp()
+	deleted, offset := 0, 0
+	for {
+		batchDeleted, scanned, err := db.DeleteProcessedNotificationsBefore(end, offset, 3)
+		if err != nil {
+			t.Fatalf("Fail to delete processed notifications, '%v'", err)
+		}
+		deleted += batchDeleted
+		offset += scanned - batchDeleted
+		if scanned < 3 {
+			break
+		}
+	}
+	if deleted != processed {
+		t.Fatalf("%d processed notifications should be deleted instead of %d", processed, deleted)
+	}
+	notifications, err = db.GetNotifications()
+	if err != nil {
+		t.Fatalf("Error getting notifications %v", err)
+	}
+	if len(notifications) != offset {
+		t.Fatalf("There should be %d notifications left instead of %d", offset, len(notifications))
+	}
+	for _, n := range notifications {
+		if n.Status == contract.Processed {
+			t.Fatalf("Processed notification %v should be deleted", n.Slug)
+		}
+	}
+
 	// Test DeleteNotificationsOld
 	err = db.DeleteNotificationsOld(0)
 	if err != nil {
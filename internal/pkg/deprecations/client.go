@@ -0,0 +1,68 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deprecations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Client queries the deprecations recorded by core-metadata
+type Client interface {
+	// DeprecationsByProfileName returns the deprecations of the device profile named name
+	DeprecationsByProfileName(ctx context.Context, name string) ([]Deprecation, errors.EdgeX)
+}
+
+type httpClient struct {
+	baseUrl string
+	client  *http.Client
+}
+
+// NewClient is a factory function that returns a Client querying the core-metadata service at baseUrl
+func NewClient(baseUrl string) Client {
+	return &httpClient{
+		baseUrl: strings.TrimSuffix(baseUrl, "/"),
+		client:  http.DefaultClient,
+	}
+}
+
+func (c *httpClient) DeprecationsByProfileName(ctx context.Context, name string) ([]Deprecation, errors.EdgeX) {
+	route := strings.Replace(ApiDeprecationByProfileNameRoute, "{"+v2.Name+"}", url.PathEscape(name), 1)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseUrl+route, nil)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindClientError, "failed to create the deprecations request", err)
+	}
+	correlation.Propagate(ctx, req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindServiceUnavailable, "failed to query the deprecations", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		var response commonDTO.BaseResponse
+		_ = json.NewDecoder(resp.Body).Decode(&response)
+		return nil, errors.NewCommonEdgeX(errors.KindServerError,
+			fmt.Sprintf("failed to query the deprecations of device profile %s, status %d: %s", name, resp.StatusCode, response.Message), nil)
+	}
+
+	var response MultiDeprecationsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "deprecations json decoding failed", err)
+	}
+	return response.Deprecations, nil
+}
@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deprecations defines the deprecation of the device resources and device commands of a device profile,
+// recorded by core-metadata ahead of their removal so that core-command can warn the clients still using them.
+package deprecations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+const (
+	// ApiDeprecationRoute accepts the deprecations to record
+	ApiDeprecationRoute = v2.ApiBase + "/deprecation"
+	// ApiAllDeprecationRoute returns the deprecations, the most recent first
+	ApiAllDeprecationRoute = ApiDeprecationRoute + "/" + v2.All
+	// ApiDeprecationByProfileNameRoute returns the deprecations of a device profile
+	ApiDeprecationByProfileNameRoute = ApiDeprecationRoute + "/" + v2.Profile + "/" + v2.Name + "/{" + v2.Name + "}"
+	// ApiDeprecationByProfileNameAndResourceNameRoute withdraws the deprecation of a device resource or command
+	ApiDeprecationByProfileNameAndResourceNameRoute = ApiDeprecationByProfileNameRoute + "/resource/{" + v2.ResourceName + "}"
+	// ApiDeprecationUsageRoute returns the devices still referencing the deprecated resources
+	ApiDeprecationUsageRoute = ApiDeprecationRoute + "/usage"
+
+	// WarningHeader is the response header core-command lists the deprecations of the issued command in
+	WarningHeader = "Warning"
+)
+
+// Deprecation marks a device resource or device command of a device profile as deprecated until RemovalVersion.
+// Deprecating a device resource also deprecates the core command of the same name.
+type Deprecation struct {
+	Id           string `json:"id,omitempty"`
+	Created      int64  `json:"created,omitempty"`
+	Modified     int64  `json:"modified,omitempty"`
+	ProfileName  string `json:"profileName" validate:"required,edgex-dto-none-empty-string"`
+	ResourceName string `json:"resourceName" validate:"required,edgex-dto-none-empty-string"`
+	// RemovalVersion is the version of the profile, or of EdgeX, the resource is going to be removed in
+	RemovalVersion string `json:"removalVersion" validate:"required,edgex-dto-none-empty-string"`
+	// Replacement is the device resource or command to use instead, if any
+	Replacement string `json:"replacement,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// Validate checks that the deprecation is complete and that the deprecated resource belongs to profile
+func (d Deprecation) Validate(profile models.DeviceProfile) errors.EdgeX {
+	if strings.TrimSpace(d.ProfileName) == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "deprecation profile name is empty", nil)
+	}
+	if strings.TrimSpace(d.ResourceName) == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "deprecation resource name is empty", nil)
+	}
+	if strings.TrimSpace(d.RemovalVersion) == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("deprecation of %s removal version is empty", d.ResourceName), nil)
+	}
+	if profile.Name != d.ProfileName {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("deprecation of %s is for device profile %s, not %s", d.ResourceName, d.ProfileName, profile.Name), nil)
+	}
+	if !hasResource(profile, d.ResourceName) {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist,
+			fmt.Sprintf("device profile %s has no device resource or device command %s", d.ProfileName, d.ResourceName), nil)
+	}
+	if d.Replacement != "" && !hasResource(profile, d.Replacement) {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("device profile %s has no device resource or device command %s to replace %s", d.ProfileName, d.Replacement, d.ResourceName), nil)
+	}
+	return nil
+}
+
+func hasResource(profile models.DeviceProfile, name string) bool {
+	for _, r := range profile.DeviceResources {
+		if r.Name == name {
+			return true
+		}
+	}
+	for _, c := range profile.DeviceCommands {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Warning returns the value of the Warning header, as defined by RFC 7234, announcing the deprecation
+func (d Deprecation) Warning() string {
+	text := fmt.Sprintf("%s of device profile %s is deprecated and will be removed in %s", d.ResourceName, d.ProfileName, d.RemovalVersion)
+	if d.Replacement != "" {
+		text += ", use " + d.Replacement + " instead"
+	}
+	return fmt.Sprintf(`299 - "%s"`, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text))
+}
+
+// Find returns the deprecation of the device resource or command named resourceName among deprecations
+func Find(deprecations []Deprecation, resourceName string) (Deprecation, bool) {
+	for _, d := range deprecations {
+		if d.ResourceName == resourceName {
+			return d, true
+		}
+	}
+	return Deprecation{}, false
+}
+
+// Usage lists the devices still referencing a deprecated resource
+type Usage struct {
+	Deprecation Deprecation `json:"deprecation"`
+	// Devices are the devices using the device profile, which can all issue the deprecated command
+	Devices []string `json:"devices"`
+	// AutoEventDevices are the devices among them with an AutoEvent on the deprecated resource
+	AutoEventDevices []string `json:"autoEventDevices,omitempty"`
+}
+
+// NewUsage returns the usage of the deprecated resource by devices, the devices of its device profile
+func NewUsage(d Deprecation, devices []models.Device) Usage {
+	usage := Usage{Deprecation: d, Devices: []string{}}
+	for _, device := range devices {
+		usage.Devices = append(usage.Devices, device.Name)
+		for _, autoEvent := range device.AutoEvents {
+			if autoEvent.Resource == d.ResourceName {
+				usage.AutoEventDevices = append(usage.AutoEventDevices, device.Name)
+				break
+			}
+		}
+	}
+	return usage
+}
@@ -0,0 +1,131 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deprecations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProfile() models.DeviceProfile {
+	return models.DeviceProfile{
+		Name:            "thermostat",
+		DeviceResources: []models.DeviceResource{{Name: "temperature"}, {Name: "celsius"}},
+		DeviceCommands:  []models.DeviceCommand{{Name: "readings"}},
+	}
+}
+
+func testDeprecation() Deprecation {
+	return Deprecation{ProfileName: "thermostat", ResourceName: "temperature", RemovalVersion: "2.0", Replacement: "celsius"}
+}
+
+func TestValidate(t *testing.T) {
+	command := testDeprecation()
+	command.ResourceName = "readings"
+	noReplacement := testDeprecation()
+	noReplacement.Replacement = ""
+	noVersion := testDeprecation()
+	noVersion.RemovalVersion = " "
+	otherProfile := testDeprecation()
+	otherProfile.ProfileName = "fan"
+	unknownResource := testDeprecation()
+	unknownResource.ResourceName = "humidity"
+	unknownReplacement := testDeprecation()
+	unknownReplacement.Replacement = "fahrenheit"
+
+	tests := []struct {
+		name         string
+		deprecation  Deprecation
+		expectedKind errors.ErrKind
+	}{
+		{"device resource", testDeprecation(), ""},
+		{"device command", command, ""},
+		{"no replacement", noReplacement, ""},
+		{"no removal version", noVersion, errors.KindContractInvalid},
+		{"other device profile", otherProfile, errors.KindContractInvalid},
+		{"unknown resource", unknownResource, errors.KindEntityDoesNotExist},
+		{"unknown replacement", unknownReplacement, errors.KindContractInvalid},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.deprecation.Validate(testProfile())
+			if testCase.expectedKind == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, testCase.expectedKind, errors.Kind(err))
+		})
+	}
+}
+
+func TestWarning(t *testing.T) {
+	assert.Equal(t, `299 - "temperature of device profile thermostat is deprecated and will be removed in 2.0, use celsius instead"`,
+		testDeprecation().Warning())
+
+	quoted := Deprecation{ProfileName: "thermostat", ResourceName: `"temperature"`, RemovalVersion: "2.0"}
+	assert.Equal(t, `299 - "\"temperature\" of device profile thermostat is deprecated and will be removed in 2.0"`,
+		quoted.Warning())
+}
+
+func TestFind(t *testing.T) {
+	deprecations := []Deprecation{testDeprecation()}
+
+	d, ok := Find(deprecations, "temperature")
+	assert.True(t, ok)
+	assert.Equal(t, testDeprecation(), d)
+
+	_, ok = Find(deprecations, "celsius")
+	assert.False(t, ok)
+}
+
+func TestNewUsage(t *testing.T) {
+	devices := []models.Device{
+		{Name: "kitchen", AutoEvents: []models.AutoEvent{{Resource: "temperature", Frequency: "10s"}}},
+		{Name: "garage", AutoEvents: []models.AutoEvent{{Resource: "celsius", Frequency: "10s"}}},
+	}
+
+	usage := NewUsage(testDeprecation(), devices)
+	assert.Equal(t, []string{"kitchen", "garage"}, usage.Devices)
+	assert.Equal(t, []string{"kitchen"}, usage.AutoEventDevices)
+
+	usage = NewUsage(testDeprecation(), nil)
+	assert.Equal(t, []string{}, usage.Devices, "the devices must be encoded as an empty array")
+}
+
+func TestClient(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(clients.CorrelationHeader)
+		if r.URL.Path != ApiDeprecationRoute+"/profile/name/thermostat" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(MultiDeprecationsResponse{Deprecations: []Deprecation{testDeprecation()}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.WithValue(context.Background(), clients.CorrelationHeader, "command-request")
+
+	result, err := client.DeprecationsByProfileName(ctx, "thermostat")
+	require.NoError(t, err)
+	assert.Equal(t, []Deprecation{testDeprecation()}, result)
+	assert.Equal(t, "command-request", received, "the correlation id must be propagated")
+
+	_, err = client.DeprecationsByProfileName(ctx, "fan")
+	assert.Error(t, err)
+}
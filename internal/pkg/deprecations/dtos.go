@@ -0,0 +1,28 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deprecations
+
+import (
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// AddDeprecationRequest defines the request content of a deprecation recorded through ApiDeprecationRoute
+type AddDeprecationRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	Deprecation           Deprecation `json:"deprecation" validate:"required"`
+}
+
+// MultiDeprecationsResponse defines the response content of ApiAllDeprecationRoute and ApiDeprecationByProfileNameRoute
+type MultiDeprecationsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Deprecations           []Deprecation `json:"deprecations"`
+}
+
+// MultiUsagesResponse defines the response content of ApiDeprecationUsageRoute
+type MultiUsagesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Usages                 []Usage `json:"usages"`
+}
@@ -0,0 +1,104 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package devicescopes binds the interval actions of support-scheduler to a device, or to the group of devices having
+// given labels, so that the actions are suspended while their devices are locked or deleted in core-metadata and the
+// scheduled actuation stays consistent with the metadata state.
+package devicescopes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+const (
+	// ApiDeviceScopeRoute accepts the device scopes of the interval actions
+	ApiDeviceScopeRoute = v2.ApiBase + "/intervalaction/devicescope"
+	// ApiAllDeviceScopeRoute returns the device scopes, the latest created first
+	ApiAllDeviceScopeRoute = ApiDeviceScopeRoute + "/" + v2.All
+	// ApiDeviceScopeByNameRoute returns, with its status, or deletes the device scope of the named interval action
+	ApiDeviceScopeByNameRoute = ApiDeviceScopeRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+)
+
+// DeviceScope binds the interval action IntervalAction to the device DeviceName, or to the group of the devices having
+// all the Labels
+type DeviceScope struct {
+	Id       string `json:"id,omitempty"`
+	Created  int64  `json:"created,omitempty"`
+	Modified int64  `json:"modified,omitempty"`
+	// IntervalAction is the name of the interval action, which has one device scope at most
+	IntervalAction string   `json:"intervalAction" validate:"required,edgex-dto-none-empty-string"`
+	DeviceName     string   `json:"deviceName,omitempty"`
+	Labels         []string `json:"labels,omitempty"`
+}
+
+// Validate checks that the scope is either a device or a group of devices
+func (s DeviceScope) Validate() errors.EdgeX {
+	hasDevice := strings.TrimSpace(s.DeviceName) != ""
+	hasLabels := false
+	for _, label := range s.Labels {
+		if strings.TrimSpace(label) == "" {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, "device scope labels must not be empty", nil)
+		}
+		hasLabels = true
+	}
+	if hasDevice == hasLabels {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("device scope of interval action %s must have either a deviceName or labels", s.IntervalAction), nil)
+	}
+	return nil
+}
+
+// Status tells whether the interval action of a device scope is suspended, and why
+type Status struct {
+	Suspended bool   `json:"suspended"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// DeviceSource provides the devices of core-metadata, e.g. its V2 device client
+type DeviceSource interface {
+	DeviceByName(ctx context.Context, name string) (responses.DeviceResponse, errors.EdgeX)
+	AllDevices(ctx context.Context, labels []string, offset int, limit int) (responses.MultiDevicesResponse, errors.EdgeX)
+}
+
+// Evaluate returns the status of the interval action of s from the devices of source. The action of a device is
+// suspended while the device is locked or doesn't exist; the action of a group of devices while none of them is
+// unlocked, the group being empty when all its devices are deleted.
+func Evaluate(ctx context.Context, s DeviceScope, source DeviceSource) (Status, errors.EdgeX) {
+	if s.DeviceName != "" {
+		response, err := source.DeviceByName(ctx, s.DeviceName)
+		if err != nil {
+			if err.Code() == http.StatusNotFound {
+				return Status{Suspended: true, Reason: fmt.Sprintf("device %s does not exist", s.DeviceName)}, nil
+			}
+			return Status{}, errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("unable to get device %s", s.DeviceName), err)
+		}
+		if response.Device.AdminState == models.Locked {
+			return Status{Suspended: true, Reason: fmt.Sprintf("device %s is %s", s.DeviceName, models.Locked)}, nil
+		}
+		return Status{}, nil
+	}
+
+	response, err := source.AllDevices(ctx, s.Labels, 0, -1)
+	if err != nil {
+		return Status{}, errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("unable to get the devices labeled %s", strings.Join(s.Labels, ",")), err)
+	}
+	for _, d := range response.Devices {
+		if d.AdminState != models.Locked {
+			return Status{}, nil
+		}
+	}
+	if len(response.Devices) == 0 {
+		return Status{Suspended: true, Reason: fmt.Sprintf("no device is labeled %s", strings.Join(s.Labels, ","))}, nil
+	}
+	return Status{Suspended: true, Reason: fmt.Sprintf("all the %d devices labeled %s are %s", len(response.Devices), strings.Join(s.Labels, ","), models.Locked)}, nil
+}
@@ -0,0 +1,91 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package devicescopes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceScope_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope DeviceScope
+		valid bool
+	}{
+		{"Valid - device", DeviceScope{IntervalAction: "open-valve", DeviceName: "valve-1"}, true},
+		{"Valid - group", DeviceScope{IntervalAction: "open-valve", Labels: []string{"valve", "zone-1"}}, true},
+		{"Invalid - no device nor labels", DeviceScope{IntervalAction: "open-valve", DeviceName: " "}, false},
+		{"Invalid - device and labels", DeviceScope{IntervalAction: "open-valve", DeviceName: "valve-1", Labels: []string{"valve"}}, false},
+		{"Invalid - empty label", DeviceScope{IntervalAction: "open-valve", Labels: []string{"valve", ""}}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.scope.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+			}
+		})
+	}
+}
+
+func device(name string, adminState string) dtos.Device {
+	return dtos.Device{Name: name, AdminState: adminState}
+}
+
+func TestEvaluate(t *testing.T) {
+	source := &mocks.DeviceClient{}
+	source.On("DeviceByName", mock.Anything, "unlocked").Return(responses.DeviceResponse{Device: device("unlocked", models.Unlocked)}, nil)
+	source.On("DeviceByName", mock.Anything, "locked").Return(responses.DeviceResponse{Device: device("locked", models.Locked)}, nil)
+	source.On("DeviceByName", mock.Anything, "deleted").Return(responses.DeviceResponse{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device not found", nil))
+	source.On("DeviceByName", mock.Anything, "unreachable").Return(responses.DeviceResponse{},
+		errors.NewCommonEdgeX(errors.KindServiceUnavailable, "connection refused", nil))
+	source.On("AllDevices", mock.Anything, []string{"zone-1"}, 0, -1).Return(responses.MultiDevicesResponse{
+		Devices: []dtos.Device{device("locked", models.Locked), device("unlocked", models.Unlocked)}}, nil)
+	source.On("AllDevices", mock.Anything, []string{"zone-2"}, 0, -1).Return(responses.MultiDevicesResponse{
+		Devices: []dtos.Device{device("locked", models.Locked)}}, nil)
+	source.On("AllDevices", mock.Anything, []string{"zone-3"}, 0, -1).Return(responses.MultiDevicesResponse{}, nil)
+
+	tests := []struct {
+		name      string
+		scope     DeviceScope
+		suspended bool
+		reason    string
+	}{
+		{"unlocked device", DeviceScope{DeviceName: "unlocked"}, false, ""},
+		{"locked device", DeviceScope{DeviceName: "locked"}, true, "device locked is LOCKED"},
+		{"deleted device", DeviceScope{DeviceName: "deleted"}, true, "device deleted does not exist"},
+		{"group with an unlocked device", DeviceScope{Labels: []string{"zone-1"}}, false, ""},
+		{"group of locked devices", DeviceScope{Labels: []string{"zone-2"}}, true, "all the 1 devices labeled zone-2 are LOCKED"},
+		{"empty group", DeviceScope{Labels: []string{"zone-3"}}, true, "no device is labeled zone-3"},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			status, err := Evaluate(context.Background(), testCase.scope, source)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.suspended, status.Suspended)
+			assert.Equal(t, testCase.reason, status.Reason)
+		})
+	}
+
+	_, err := Evaluate(context.Background(), DeviceScope{DeviceName: "unreachable"}, source)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindServiceUnavailable, errors.Kind(err))
+}
@@ -0,0 +1,36 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package devicescopes
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Store provides the device scopes of the interval actions when they are executed
+type Store interface {
+	DeviceScopeByIntervalAction(name string) (DeviceScope, errors.EdgeX)
+}
+
+// AddDeviceScopeRequest defines the request content of a device scope added through ApiDeviceScopeRoute
+type AddDeviceScopeRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	DeviceScope           DeviceScope `json:"deviceScope" validate:"required"`
+}
+
+// DeviceScopeResponse defines the response content of ApiDeviceScopeByNameRoute, with the current status of the
+// interval action
+type DeviceScopeResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	DeviceScope            DeviceScope `json:"deviceScope"`
+	Status                 Status      `json:"status"`
+}
+
+// MultiDeviceScopesResponse defines the response content of ApiAllDeviceScopeRoute
+type MultiDeviceScopesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	DeviceScopes           []DeviceScope `json:"deviceScopes"`
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package devicesecrets lets the protocol properties of a device reference secrets, such as the password of a BACnet,
+// Modbus or ONVIF device, instead of holding them in plaintext in core-metadata. The secrets are written by
+// core-metadata to the secret store path of the device, under the path of its device service, which resolves the
+// references when connecting to the device.
+package devicesecrets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+const (
+	// ApiDeviceSecretRoute sets the secrets of the device named in the route
+	ApiDeviceSecretRoute = v2.ApiDeviceByNameRoute + "/secret"
+
+	// ReferencePrefix prefixes the value of a protocol property referencing a secret, e.g. "secret:password" references
+	// the password secret of the device
+	ReferencePrefix = "secret:"
+)
+
+// DeviceSecretsInfo configures the secrets of the devices
+type DeviceSecretsInfo struct {
+	// Enabled accepts the secrets of the devices, and rejects the devices holding a SensitiveProperties in plaintext
+	Enabled bool
+	// SecretBasePath is the secret store path the paths of the device services are under, e.g. '/v1/secret/edgex/'
+	SecretBasePath string
+	// SensitiveProperties are the names, case insensitive, of the protocol properties which must reference a secret
+	SensitiveProperties []string
+}
+
+// Path returns the path of the secrets of the device, relative to DeviceSecretsInfo.SecretBasePath, so that a device
+// service reads them as "devices/<device name>" under its own path
+func Path(serviceName string, deviceName string) string {
+	return serviceName + "/devices/" + deviceName
+}
+
+// ParseReference returns the name of the secret the protocol property value references, if it is a reference
+func ParseReference(value string) (string, bool) {
+	if !strings.HasPrefix(value, ReferencePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(value, ReferencePrefix), true
+}
+
+// References returns the sorted names of the secrets referenced by the protocol properties
+func References(protocols map[string]models.ProtocolProperties) []string {
+	names := make(map[string]bool)
+	for _, properties := range protocols {
+		for _, value := range properties {
+			if name, ok := ParseReference(value); ok {
+				names[name] = true
+			}
+		}
+	}
+	references := make([]string, 0, len(names))
+	for name := range names {
+		references = append(references, name)
+	}
+	sort.Strings(references)
+	return references
+}
+
+// CheckPlaintext returns an error naming the first protocol property of sensitive which holds a value rather than a
+// reference to a secret. The error never contains the value.
+func CheckPlaintext(protocols map[string]models.ProtocolProperties, sensitive []string) error {
+	protocolNames := make([]string, 0, len(protocols))
+	for protocol := range protocols {
+		protocolNames = append(protocolNames, protocol)
+	}
+	sort.Strings(protocolNames)
+
+	for _, protocol := range protocolNames {
+		for property, value := range protocols[protocol] {
+			if value == "" || !isSensitive(property, sensitive) {
+				continue
+			}
+			if name, ok := ParseReference(value); !ok || ValidateName(name) != nil {
+				return fmt.Errorf("protocol property %s.%s must reference a secret as '%s<name>' rather than hold its value",
+					protocol, property, ReferencePrefix)
+			}
+		}
+	}
+	return nil
+}
+
+func isSensitive(property string, sensitive []string) bool {
+	for _, s := range sensitive {
+		if strings.EqualFold(property, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateName returns an error if name can't name a secret, as it must be a single element of the secret path
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("the secret name is empty")
+	}
+	if strings.ContainsAny(name, "/?#") {
+		return fmt.Errorf("secret name '%s' must not contain '/', '?' or '#'", name)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package devicesecrets
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sensitive = []string{"Password", "Token"}
+
+func TestReferences(t *testing.T) {
+	protocols := map[string]models.ProtocolProperties{
+		"bacnet-ip": {"DeviceInstance": "1234", "Password": "secret:password"},
+		"onvif":     {"Address": "10.0.0.1", "Username": "secret:username", "Password": "secret:password"},
+	}
+
+	assert.Equal(t, []string{"password", "username"}, References(protocols))
+	assert.Empty(t, References(map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.2"}}))
+}
+
+func TestCheckPlaintext(t *testing.T) {
+	tests := []struct {
+		name          string
+		protocols     map[string]models.ProtocolProperties
+		expectedError bool
+	}{
+		{"reference", map[string]models.ProtocolProperties{"onvif": {"Address": "10.0.0.1", "Password": "secret:password"}}, false},
+		{"case insensitive", map[string]models.ProtocolProperties{"onvif": {"password": "secret:password"}}, false},
+		{"no sensitive property", map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.2"}}, false},
+		{"empty", map[string]models.ProtocolProperties{"onvif": {"Password": ""}}, false},
+		{"plaintext", map[string]models.ProtocolProperties{"onvif": {"Password": "admin123"}}, true},
+		{"plaintext case insensitive", map[string]models.ProtocolProperties{"bacnet-ip": {"TOKEN": "abc"}}, true},
+		{"invalid reference", map[string]models.ProtocolProperties{"onvif": {"Password": "secret:"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPlaintext(tt.protocols, sensitive)
+			if tt.expectedError {
+				require.Error(t, err)
+				assert.NotContains(t, err.Error(), "admin123")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+type vaultStub struct {
+	secrets map[string]map[string]string
+	tokens  []string
+}
+
+func (v *vaultStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.tokens = append(v.tokens, r.Header.Get("X-Vault-Token"))
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := v.secrets[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	case http.MethodPost:
+		var data map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&data)
+		v.secrets[r.URL.Path] = data
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(v.secrets, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestStore(t *testing.T, vault http.Handler) *VaultStore {
+	server := httptest.NewServer(vault)
+	t.Cleanup(server.Close)
+	tokenFile := filepath.Join(t.TempDir(), "secrets-token.json")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte(`{"auth":{"client_token":"metadata-token"}}`), 0600))
+
+	store, err := NewVaultStore(VaultInfo{Url: server.URL, BasePath: "/v1/secret/edgex", TokenFile: tokenFile})
+	require.NoError(t, err)
+	return store
+}
+
+func TestVaultStoreStoreSecrets(t *testing.T) {
+	vault := &vaultStub{secrets: make(map[string]map[string]string)}
+	store := newTestStore(t, vault)
+	path := "/v1/secret/edgex/device-onvif-camera/devices/Camera01"
+
+	require.NoError(t, store.StoreSecrets("device-onvif-camera", "Camera01", map[string]string{"username": "admin", "password": "admin123"}))
+	assert.Equal(t, map[string]string{"username": "admin", "password": "admin123"}, vault.secrets[path])
+
+	// the secrets are merged, an empty value removing a secret
+	require.NoError(t, store.StoreSecrets("device-onvif-camera", "Camera01", map[string]string{"password": "s3cret", "username": ""}))
+	assert.Equal(t, map[string]string{"password": "s3cret"}, vault.secrets[path])
+
+	require.NoError(t, store.StoreSecrets("device-onvif-camera", "Camera01", map[string]string{"password": ""}))
+	assert.NotContains(t, vault.secrets, path)
+
+	for _, token := range vault.tokens {
+		assert.Equal(t, "metadata-token", token)
+	}
+}
+
+func TestVaultStoreErrors(t *testing.T) {
+	forbidden := newTestStore(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	err := forbidden.StoreSecrets("device-onvif-camera", "Camera01", map[string]string{"password": "admin123"})
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "403"))
+	assert.NotContains(t, err.Error(), "admin123")
+
+	store := newTestStore(t, &vaultStub{secrets: make(map[string]map[string]string)})
+	assert.Error(t, store.StoreSecrets("device-onvif-camera", "Camera01", map[string]string{"a/b": "admin123"}))
+	assert.Error(t, store.StoreSecrets("device-onvif-camera", "Camera01", map[string]string{"": "admin123"}))
+
+	store.info.TokenFile = filepath.Join(t.TempDir(), "missing.json")
+	assert.Error(t, store.StoreSecrets("device-onvif-camera", "Camera01", map[string]string{"password": "admin123"}))
+
+	_, err = NewVaultStore(VaultInfo{Url: "http://localhost:8200"})
+	assert.Error(t, err, "the base path is required")
+}
@@ -0,0 +1,17 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package devicesecrets
+
+import (
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// UpdateDeviceSecretsRequest defines the request content of ApiDeviceSecretRoute. The secrets are merged with those
+// already stored for the device, a secret with an empty value being removed.
+type UpdateDeviceSecretsRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	Secrets               map[string]string `json:"secrets" validate:"required"`
+}
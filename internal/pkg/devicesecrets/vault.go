@@ -0,0 +1,190 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package devicesecrets
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Store writes the secrets of the devices
+type Store interface {
+	// StoreSecrets merges secrets with the secrets stored for the device, removing those with an empty value
+	StoreSecrets(serviceName string, deviceName string, secrets map[string]string) error
+}
+
+// VaultInfo locates the Vault KV secret engine the device services read their secrets from
+type VaultInfo struct {
+	// Url is the base URL of Vault, e.g. "http://localhost:8200"
+	Url string
+	// BasePath is the path the paths of the device services are under, e.g. "/v1/secret/edgex/"
+	BasePath string
+	// TokenFile is the file of the token of core-metadata, as written by the security-file-token-provider
+	TokenFile string
+	// RootCaCertPath is the PEM file of the CA certificate of Vault, if served over TLS with a private CA
+	RootCaCertPath string
+	// ServerName overrides the host name of the Vault certificate
+	ServerName string
+}
+
+const vaultTimeout = 10 * time.Second
+
+// VaultStore writes the secrets of the devices to Vault
+type VaultStore struct {
+	info   VaultInfo
+	client *http.Client
+}
+
+// NewVaultStore returns a VaultStore writing to the KV secret engine of info
+func NewVaultStore(info VaultInfo) (*VaultStore, error) {
+	if info.BasePath == "" {
+		return nil, fmt.Errorf("no secret base path for the secrets of the devices")
+	}
+	if !strings.HasSuffix(info.BasePath, "/") {
+		info.BasePath += "/"
+	}
+
+	tlsConfig := &tls.Config{ServerName: info.ServerName}
+	if info.RootCaCertPath != "" {
+		pem, err := ioutil.ReadFile(info.RootCaCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the secret store CA certificate: %s", err.Error())
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate in the secret store CA certificate file %s", info.RootCaCertPath)
+		}
+	}
+
+	return &VaultStore{
+		info: info,
+		client: &http.Client{
+			Timeout:   vaultTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+type tokenFileContents struct {
+	Auth struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+// token reads the token on every request, so that the token renewed by the security services is picked up
+func (s *VaultStore) token() (string, error) {
+	contents, err := ioutil.ReadFile(s.info.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the secret store token: %s", err.Error())
+	}
+	var tokenFile tokenFileContents
+	if err = json.Unmarshal(contents, &tokenFile); err != nil {
+		return "", fmt.Errorf("failed to parse the secret store token file: %s", err.Error())
+	}
+	if tokenFile.Auth.ClientToken == "" {
+		return "", fmt.Errorf("no token in the secret store token file")
+	}
+	return tokenFile.Auth.ClientToken, nil
+}
+
+func (s *VaultStore) url(serviceName string, deviceName string) string {
+	return strings.TrimSuffix(s.info.Url, "/") + s.info.BasePath +
+		url.PathEscape(serviceName) + "/devices/" + url.PathEscape(deviceName)
+}
+
+func (s *VaultStore) do(method string, url string, token string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return s.client.Do(req)
+}
+
+// StoreSecrets merges secrets with the secrets stored in Vault for the device, removing those with an empty value
+func (s *VaultStore) StoreSecrets(serviceName string, deviceName string, secrets map[string]string) error {
+	for name := range secrets {
+		if err := ValidateName(name); err != nil {
+			return err
+		}
+	}
+	token, err := s.token()
+	if err != nil {
+		return err
+	}
+	secretsUrl := s.url(serviceName, deviceName)
+
+	stored, err := s.read(secretsUrl, token)
+	if err != nil {
+		return err
+	}
+	for name, value := range secrets {
+		if value == "" {
+			delete(stored, name)
+		} else {
+			stored[name] = value
+		}
+	}
+
+	var resp *http.Response
+	if len(stored) == 0 {
+		resp, err = s.do(http.MethodDelete, secretsUrl, token, nil)
+	} else {
+		var body []byte
+		body, err = json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+		resp, err = s.do(http.MethodPost, secretsUrl, token, bytes.NewReader(body))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the secrets of device %s: %s", deviceName, err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to write the secrets of device %s: secret store responded %s", deviceName, resp.Status)
+	}
+	return nil
+}
+
+// read returns the secrets stored at secretsUrl, none if there's no secret yet
+func (s *VaultStore) read(secretsUrl string, token string) (map[string]string, error) {
+	resp, err := s.do(http.MethodGet, secretsUrl, token, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the stored secrets: %s", err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	stored := make(map[string]string)
+	if resp.StatusCode == http.StatusNotFound {
+		return stored, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to read the stored secrets: secret store responded %s", resp.Status)
+	}
+	var secret struct {
+		Data map[string]string `json:"data"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, fmt.Errorf("failed to decode the stored secrets: %s", err.Error())
+	}
+	for name, value := range secret.Data {
+		stored[name] = value
+	}
+	return stored, nil
+}
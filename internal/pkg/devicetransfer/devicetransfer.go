@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package devicetransfer defines the transfer of a device from its device service to another one, e.g. to move the
+// devices of a failing device service instance to a standby instance during a maintenance.
+package devicetransfer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// ApiDeviceTransferRoute transfers the device named in the route to the device service of the TransferRequest
+const ApiDeviceTransferRoute = v2.ApiDeviceByNameRoute + "/transfer"
+
+// CheckCompatibility returns an error when the device service target, serving devices and watchers, can't take over
+// device. A device service proves the devices it can serve by those it already serves: it must serve a device or a
+// provision watcher of the device profile of device, or a device with all the protocols of device. A device service
+// serving neither devices nor provision watchers is taken as a blank standby, which is compatible with any device.
+func CheckCompatibility(device models.Device, target models.DeviceService, devices []models.Device, watchers []models.ProvisionWatcher) error {
+	if len(devices) == 0 && len(watchers) == 0 {
+		return nil
+	}
+	for _, w := range watchers {
+		if w.ProfileName == device.ProfileName {
+			return nil
+		}
+	}
+	for _, d := range devices {
+		if d.ProfileName == device.ProfileName || hasProtocols(d, device.Protocols) {
+			return nil
+		}
+	}
+	return fmt.Errorf("device service %s serves neither the device profile %s nor the protocols %s of device %s",
+		target.Name, device.ProfileName, strings.Join(protocolNames(device.Protocols), ","), device.Name)
+}
+
+func hasProtocols(d models.Device, protocols map[string]models.ProtocolProperties) bool {
+	if len(protocols) == 0 {
+		return false
+	}
+	for name := range protocols {
+		if _, ok := d.Protocols[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func protocolNames(protocols map[string]models.ProtocolProperties) []string {
+	names := make([]string, 0, len(protocols))
+	for name := range protocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
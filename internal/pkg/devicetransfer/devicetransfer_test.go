@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package devicetransfer
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	device := models.Device{
+		Name:        "pump-1",
+		ProfileName: "pump",
+		Protocols:   map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.12"}},
+	}
+	standby := models.DeviceService{Name: "device-modbus-standby"}
+	sameProfile := models.Device{Name: "pump-2", ProfileName: "pump"}
+	sameProtocols := models.Device{Name: "meter-1", ProfileName: "meter",
+		Protocols: map[string]models.ProtocolProperties{"modbus-tcp": {}, "other": {}}}
+	otherProtocols := models.Device{Name: "camera-1", ProfileName: "camera",
+		Protocols: map[string]models.ProtocolProperties{"onvif": {}}}
+
+	tests := []struct {
+		name       string
+		devices    []models.Device
+		watchers   []models.ProvisionWatcher
+		compatible bool
+	}{
+		{"Valid - blank standby", nil, nil, true},
+		{"Valid - same profile", []models.Device{otherProtocols, sameProfile}, nil, true},
+		{"Valid - same protocols", []models.Device{sameProtocols}, nil, true},
+		{"Valid - watcher of the profile", nil, []models.ProvisionWatcher{{ProfileName: "pump"}}, true},
+		{"Invalid - other protocols", []models.Device{otherProtocols}, nil, false},
+		{"Invalid - watcher of another profile", nil, []models.ProvisionWatcher{{ProfileName: "camera"}}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := CheckCompatibility(device, standby, testCase.devices, testCase.watchers)
+			if testCase.compatible {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
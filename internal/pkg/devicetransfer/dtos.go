@@ -0,0 +1,28 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package devicetransfer
+
+import (
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// TransferRequest defines the request content of ApiDeviceTransferRoute. Force skips the compatibility check of the
+// device service, which must still exist and not be locked.
+type TransferRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	ServiceName           string `json:"serviceName" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Force                 bool   `json:"force,omitempty"`
+}
+
+// TransferResponse defines the response content of ApiDeviceTransferRoute
+type TransferResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	DeviceName             string `json:"deviceName"`
+	// FromServiceName is the device service the device was transferred from
+	FromServiceName string `json:"fromServiceName"`
+	// ToServiceName is the device service the device was transferred to
+	ToServiceName string `json:"toServiceName"`
+}
@@ -0,0 +1,82 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package discovery defines the audit records of the devices added through provision watchers, and the limits after
+// which core-metadata auto-disables a provision watcher adding too many devices.
+package discovery
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+const (
+	// ApiDiscoveredDeviceRoute accepts the devices a device service discovered and matched with a provision watcher
+	ApiDiscoveredDeviceRoute = v2.ApiProvisionWatcherByNameRoute + "/device"
+	// ApiDiscoveryRecordRoute is the base route of the discovery audit records
+	ApiDiscoveryRecordRoute = v2.ApiBase + "/discovery/record"
+	// ApiAllDiscoveryRecordRoute returns the discovery records, the most recent first
+	ApiAllDiscoveryRecordRoute = ApiDiscoveryRecordRoute + "/" + v2.All
+	// ApiDiscoveryRecordByProvisionWatcherNameRoute returns the discovery records of a provision watcher
+	ApiDiscoveryRecordByProvisionWatcherNameRoute = ApiDiscoveryRecordRoute + "/provisionwatcher/" + v2.Name + "/{" + v2.Name + "}"
+)
+
+// Record audits a device added by a device service through a provision watcher
+type Record struct {
+	Id      string `json:"id,omitempty"`
+	Created int64  `json:"created,omitempty"`
+	// ProvisionWatcherName is the provision watcher the discovered device matched
+	ProvisionWatcherName string `json:"provisionWatcherName"`
+	// DiscoveryId identifies the discovery run of the device service which found the device, if any
+	DiscoveryId string `json:"discoveryId,omitempty"`
+	DeviceId    string `json:"deviceId"`
+	DeviceName  string `json:"deviceName"`
+	ServiceName string `json:"serviceName"`
+	ProfileName string `json:"profileName"`
+}
+
+// Limit bounds the number of devices a provision watcher may add
+type Limit struct {
+	// MaxDevices is the number of devices after which the provision watcher is disabled, 0 meaning no limit
+	MaxDevices int
+	// Window is the duration, such as '1h', the devices are counted over. Empty counts all the devices ever added
+	Window string
+}
+
+// LimitsInfo configures the limits of the provision watchers
+type LimitsInfo struct {
+	// Default applies to the provision watchers without a limit of their own
+	Default Limit
+	// Watchers are the limits of specific provision watchers, by name
+	Watchers map[string]Limit
+}
+
+// For returns the limit of the provision watcher named name
+func (l LimitsInfo) For(name string) Limit {
+	if limit, ok := l.Watchers[name]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// Enabled tells whether the limit bounds the number of devices at all
+func (l Limit) Enabled() bool {
+	return l.MaxDevices > 0
+}
+
+// Since returns the timestamp, in milliseconds, from which the devices are counted at now
+func (l Limit) Since(now time.Time) (int64, errors.EdgeX) {
+	if l.Window == "" {
+		return 0, nil
+	}
+	window, err := time.ParseDuration(l.Window)
+	if err != nil || window <= 0 {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid discovery limit window '%s'", l.Window), err)
+	}
+	return now.Add(-window).UnixNano() / int64(time.Millisecond), nil
+}
@@ -0,0 +1,53 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitsInfoFor(t *testing.T) {
+	limits := LimitsInfo{
+		Default:  Limit{MaxDevices: 100, Window: "24h"},
+		Watchers: map[string]Limit{"modbus-watcher": {MaxDevices: 5}},
+	}
+
+	assert.Equal(t, Limit{MaxDevices: 5}, limits.For("modbus-watcher"))
+	assert.Equal(t, limits.Default, limits.For("bacnet-watcher"))
+	assert.False(t, LimitsInfo{}.For("bacnet-watcher").Enabled())
+}
+
+func TestLimitSince(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	tests := []struct {
+		name          string
+		window        string
+		expected      int64
+		expectedError bool
+	}{
+		{"no window", "", 0, false},
+		{"one hour", "1h", now.Add(-time.Hour).UnixNano() / int64(time.Millisecond), false},
+		{"invalid window", "one hour", 0, true},
+		{"negative window", "-1h", 0, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			since, err := Limit{MaxDevices: 1, Window: testCase.window}.Since(now)
+
+			if testCase.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, since)
+		})
+	}
+}
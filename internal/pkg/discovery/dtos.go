@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// AddDiscoveredDeviceRequest defines the request content of a device added through ApiDiscoveredDeviceRoute
+type AddDiscoveredDeviceRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	DiscoveryId           string      `json:"discoveryId,omitempty"`
+	Device                dtos.Device `json:"device" validate:"required"`
+}
+
+// MultiRecordsResponse defines the response content of ApiAllDiscoveryRecordRoute and
+// ApiDiscoveryRecordByProvisionWatcherNameRoute
+type MultiRecordsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Records                []Record `json:"records"`
+}
@@ -0,0 +1,225 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dryrun checks the updates of the device profiles, and the changes of the device profile of the devices,
+// against the recent readings of the devices in core-data, flagging the updates that would break the data flowing
+// from the devices before they are applied, e.g. a device resource whose type changes from Int64 to Float32 is
+// compatible with the readings it reported, while one changing from Float32 to Int64 is not.
+package dryrun
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+const (
+	// SeverityBreaking flags the recent readings of a device that the update can't represent
+	SeverityBreaking = "breaking"
+	// SeverityWarning flags the changes of the device resources that no recent reading contradicts
+	SeverityWarning = "warning"
+
+	arraySuffix = "Array"
+)
+
+// DryRunInfo configures the readings checked by the dry runs
+type DryRunInfo struct {
+	// ReadingLimit is the number of the most recent readings of each device checked against an update
+	ReadingLimit int
+	// DeviceLimit is the number of devices of an updated device profile whose readings are checked
+	DeviceLimit int
+	// Timeout bounds the queries of the readings of a dry run, e.g. "5s"
+	Timeout string
+}
+
+// Validate checks the Timeout of the dry runs
+func (info DryRunInfo) Validate() error {
+	_, err := info.TimeoutDuration()
+	return err
+}
+
+// TimeoutDuration returns the parsed Timeout
+func (info DryRunInfo) TimeoutDuration() (time.Duration, error) {
+	timeout, err := time.ParseDuration(info.Timeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid DryRun.Timeout '%s', expected a positive duration", info.Timeout)
+	}
+	return timeout, nil
+}
+
+// Finding is a change of a device resource, or a device whose readings the change breaks
+type Finding struct {
+	// DeviceName is the device whose readings are broken, empty for a change of the device profile
+	DeviceName   string `json:"deviceName,omitempty"`
+	ResourceName string `json:"resourceName,omitempty"`
+	Severity     string `json:"severity"`
+	Message      string `json:"message"`
+}
+
+// Breaking tells whether one of findings is breaking
+func Breaking(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityBreaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Changes returns the warnings of the device resources of old that updated removes or whose value type it changes
+func Changes(old models.DeviceProfile, updated models.DeviceProfile) []Finding {
+	updatedTypes := valueTypes(updated)
+	var findings []Finding
+	for _, r := range old.DeviceResources {
+		valueType, ok := updatedTypes[r.Name]
+		if !ok {
+			findings = append(findings, Finding{
+				ResourceName: r.Name,
+				Severity:     SeverityWarning,
+				Message:      fmt.Sprintf("device resource %s is not defined by device profile %s", r.Name, updated.Name),
+			})
+		} else if valueType != r.Properties.ValueType {
+			findings = append(findings, Finding{
+				ResourceName: r.Name,
+				Severity:     SeverityWarning,
+				Message: fmt.Sprintf("the value type of device resource %s changes from %s to %s", r.Name,
+					r.Properties.ValueType, valueType),
+			})
+		}
+	}
+	return findings
+}
+
+// CheckReadings returns the breaking findings of the readings of the device resources changed from old to updated:
+// the readings of a device resource that updated doesn't define, and those whose value can't be read as the value
+// type that updated defines. The readings of the unchanged device resources aren't checked.
+func CheckReadings(old models.DeviceProfile, updated models.DeviceProfile, readings []dtos.BaseReading) []Finding {
+	oldTypes := valueTypes(old)
+	updatedTypes := valueTypes(updated)
+
+	type key struct{ device, resource string }
+	type count struct {
+		total, broken int
+		example       string
+	}
+	counts := make(map[key]*count)
+	var keys []key
+	for _, r := range readings {
+		oldType, defined := oldTypes[r.ResourceName]
+		valueType, kept := updatedTypes[r.ResourceName]
+		if !defined || (kept && valueType == oldType) {
+			continue
+		}
+		k := key{r.DeviceName, r.ResourceName}
+		c, ok := counts[k]
+		if !ok {
+			c = &count{}
+			counts[k] = c
+			keys = append(keys, k)
+		}
+		c.total++
+		if !kept || !Convertible(r.Value, r.ValueType, valueType) {
+			if c.broken == 0 {
+				c.example = r.Value
+			}
+			c.broken++
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].device != keys[j].device {
+			return keys[i].device < keys[j].device
+		}
+		return keys[i].resource < keys[j].resource
+	})
+	var findings []Finding
+	for _, k := range keys {
+		c := counts[k]
+		if c.broken == 0 {
+			continue
+		}
+		finding := Finding{DeviceName: k.device, ResourceName: k.resource, Severity: SeverityBreaking}
+		if valueType, kept := updatedTypes[k.resource]; !kept {
+			finding.Message = fmt.Sprintf("device %s reported %d recent readings of device resource %s, which device profile %s doesn't define",
+				k.device, c.total, k.resource, updated.Name)
+		} else {
+			finding.Message = fmt.Sprintf("%d of the %d recent readings of device resource %s of device %s can't be read as %s, e.g. '%s'",
+				c.broken, c.total, k.resource, k.device, valueType, c.example)
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+func valueTypes(profile models.DeviceProfile) map[string]string {
+	types := make(map[string]string, len(profile.DeviceResources))
+	for _, r := range profile.DeviceResources {
+		types[r.Name] = r.Properties.ValueType
+	}
+	return types
+}
+
+// Convertible tells whether value, a reading value of the from value type, can be read as the to value type
+func Convertible(value string, from string, to string) bool {
+	switch {
+	case from == to || to == v2.ValueTypeString:
+		return true
+	case from == v2.ValueTypeBinary || to == v2.ValueTypeBinary:
+		return false
+	case strings.HasSuffix(to, arraySuffix) != strings.HasSuffix(from, arraySuffix):
+		return false
+	case to == v2.ValueTypeStringArray:
+		return true
+	case strings.HasSuffix(to, arraySuffix):
+		elements := strings.TrimSpace(value)
+		if !strings.HasPrefix(elements, "[") || !strings.HasSuffix(elements, "]") {
+			return false
+		}
+		elements = strings.TrimSpace(elements[1 : len(elements)-1])
+		if elements == "" {
+			return true
+		}
+		elementType := strings.TrimSuffix(to, arraySuffix)
+		for _, e := range strings.Split(elements, ",") {
+			if !parsable(strings.Trim(strings.TrimSpace(e), `"`), elementType) {
+				return false
+			}
+		}
+		return true
+	default:
+		return parsable(value, to)
+	}
+}
+
+func parsable(value string, valueType string) bool {
+	var err error
+	switch valueType {
+	case v2.ValueTypeBool:
+		_, err = strconv.ParseBool(value)
+	case v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64:
+		_, err = strconv.ParseInt(value, 10, bitSize(valueType))
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64:
+		_, err = strconv.ParseUint(value, 10, bitSize(valueType))
+	case v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+		_, err = strconv.ParseFloat(value, bitSize(valueType))
+	case v2.ValueTypeString:
+	default:
+		return false
+	}
+	return err == nil
+}
+
+// bitSize returns the size of the numeric value type, e.g. 16 for Uint16
+func bitSize(valueType string) int {
+	size, _ := strconv.Atoi(strings.TrimLeftFunc(valueType, unicode.IsLetter))
+	return size
+}
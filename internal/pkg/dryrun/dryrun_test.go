@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dryrun
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func profile(name string, valueTypes ...string) models.DeviceProfile {
+	p := models.DeviceProfile{Name: name}
+	for i := 0; i < len(valueTypes); i += 2 {
+		p.DeviceResources = append(p.DeviceResources, models.DeviceResource{
+			Name:       valueTypes[i],
+			Properties: models.PropertyValue{ValueType: valueTypes[i+1]},
+		})
+	}
+	return p
+}
+
+func reading(device string, resource string, valueType string, value string) dtos.BaseReading {
+	return dtos.BaseReading{DeviceName: device, ResourceName: resource, ValueType: valueType,
+		SimpleReading: dtos.SimpleReading{Value: value}}
+}
+
+func TestConvertible(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		from        string
+		to          string
+		convertible bool
+	}{
+		{"Same type", "12", v2.ValueTypeInt64, v2.ValueTypeInt64, true},
+		{"Int to float", "12", v2.ValueTypeInt64, v2.ValueTypeFloat32, true},
+		{"Anything to string", "1.5e+00", v2.ValueTypeFloat64, v2.ValueTypeString, true},
+		{"Float to int", "1.5e+00", v2.ValueTypeFloat64, v2.ValueTypeInt64, false},
+		{"Out of range", "300", v2.ValueTypeInt16, v2.ValueTypeInt8, false},
+		{"Negative to unsigned", "-1", v2.ValueTypeInt32, v2.ValueTypeUint32, false},
+		{"Float64 overflowing Float32", "1e+300", v2.ValueTypeFloat64, v2.ValueTypeFloat32, false},
+		{"String to bool", "true", v2.ValueTypeString, v2.ValueTypeBool, true},
+		{"Binary", "", v2.ValueTypeBinary, v2.ValueTypeString, true},
+		{"Binary to int", "", v2.ValueTypeBinary, v2.ValueTypeInt8, false},
+		{"Array", "[1, 2, 3]", v2.ValueTypeInt64Array, v2.ValueTypeFloat64Array, true},
+		{"Empty array", "[]", v2.ValueTypeFloat64Array, v2.ValueTypeInt8Array, true},
+		{"Array elements out of range", "[1, 256]", v2.ValueTypeUint16Array, v2.ValueTypeUint8Array, false},
+		{"Array to scalar", "[1]", v2.ValueTypeInt8Array, v2.ValueTypeInt8, false},
+		{"Scalar to array", "1", v2.ValueTypeInt8, v2.ValueTypeInt8Array, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.convertible, Convertible(testCase.value, testCase.from, testCase.to))
+		})
+	}
+}
+
+func TestChanges(t *testing.T) {
+	old := profile("thermostat", "temperature", v2.ValueTypeInt64, "humidity", v2.ValueTypeInt64, "mode", v2.ValueTypeString)
+	updated := profile("thermostat", "temperature", v2.ValueTypeFloat32, "mode", v2.ValueTypeString, "fan", v2.ValueTypeBool)
+
+	findings := Changes(old, updated)
+
+	require.Len(t, findings, 2)
+	assert.Equal(t, "temperature", findings[0].ResourceName)
+	assert.Equal(t, "humidity", findings[1].ResourceName)
+	assert.False(t, Breaking(findings), "the changes alone should not be breaking")
+	assert.Empty(t, Changes(old, old))
+}
+
+func TestCheckReadings(t *testing.T) {
+	old := profile("thermostat", "temperature", v2.ValueTypeFloat32, "humidity", v2.ValueTypeInt64, "mode", v2.ValueTypeString)
+	readings := []dtos.BaseReading{
+		reading("t-2", "temperature", v2.ValueTypeFloat32, "2.150000e+01"),
+		reading("t-1", "temperature", v2.ValueTypeFloat32, "2.100000e+01"),
+		reading("t-1", "temperature", v2.ValueTypeFloat32, "2.050000e+01"),
+		reading("t-1", "humidity", v2.ValueTypeInt64, "40"),
+		reading("t-1", "mode", v2.ValueTypeString, "heat"),
+		reading("t-1", "unknown", v2.ValueTypeString, "x"),
+	}
+
+	findings := CheckReadings(old, profile("thermostat", "temperature", v2.ValueTypeFloat64, "humidity", v2.ValueTypeFloat32, "mode", v2.ValueTypeString), readings)
+	assert.Empty(t, findings, "all the values should convert, and the unchanged resources should not be checked")
+
+	updated := profile("thermostat", "temperature", v2.ValueTypeInt64, "mode", v2.ValueTypeString)
+	findings = CheckReadings(old, updated, readings)
+	require.Len(t, findings, 3)
+	assert.True(t, Breaking(findings))
+	assert.Equal(t, Finding{DeviceName: "t-1", ResourceName: "humidity", Severity: SeverityBreaking,
+		Message: "device t-1 reported 1 recent readings of device resource humidity, which device profile thermostat doesn't define"}, findings[0])
+	assert.Equal(t, "t-1", findings[1].DeviceName)
+	assert.Equal(t, "temperature", findings[1].ResourceName)
+	assert.Equal(t, "2 of the 2 recent readings of device resource temperature of device t-1 can't be read as Int64, e.g. '2.100000e+01'", findings[1].Message)
+	assert.Equal(t, "t-2", findings[2].DeviceName)
+}
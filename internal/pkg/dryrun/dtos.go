@@ -0,0 +1,17 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dryrun
+
+import (
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Response defines the response content of a dry run, in place of that of the update. Its status code is
+// http.StatusConflict when one of the findings is breaking.
+type Response struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Findings               []Finding `json:"findings"`
+}
@@ -25,6 +25,7 @@ var Command commandErrorConcept
 // ValueDescriptorsErrorConcept represents the accessor for the value-descriptor-specific error concepts
 type commandErrorConcept struct {
 	NotAssociatedWithDevice commandNotAssociatedWithDevice
+	InvalidParameters       commandInvalidParameters
 }
 
 type commandNotAssociatedWithDevice struct{}
@@ -41,3 +42,18 @@ func (r commandNotAssociatedWithDevice) isA(err error) bool {
 func (r commandNotAssociatedWithDevice) message(err error) string {
 	return err.Error()
 }
+
+type commandInvalidParameters struct{}
+
+func (r commandInvalidParameters) httpErrorCode() int {
+	return http.StatusBadRequest
+}
+
+func (r commandInvalidParameters) isA(err error) bool {
+	_, ok := err.(errors.ErrInvalidParameters)
+	return ok
+}
+
+func (r commandInvalidParameters) message(err error) string {
+	return err.Error()
+}
@@ -0,0 +1,278 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package eventsig signs the events ingested by core-data with the key of the gateway, and verifies the signatures,
+// so that the consumers of the persisted and exported events can detect tampered or spoofed data. The signature is
+// a JWS with a detached payload, the canonical form of the event, carried by the SignatureTag tag of the event.
+package eventsig
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// SignatureTag is the event tag carrying the signature
+const SignatureTag = "signature"
+
+// Secret keys read from EventSigningInfo.SecretPath
+const (
+	// PrivateKeySecret is the PEM encoded ECDSA (P-256 or P-384) or RSA private key of the gateway
+	PrivateKeySecret = "privateKey"
+	// KeyIdSecret identifies the key in the signatures, so that the consumers can pick the public key of the gateway
+	KeyIdSecret = "keyId"
+)
+
+// EventSigningInfo configures the signing of the events
+type EventSigningInfo struct {
+	// Enabled signs the events before they are persisted and published
+	Enabled bool
+	// SecretPath is the path of the secrets holding the private key and key id in the service's secret store
+	SecretPath string
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyId     string `json:"kid,omitempty"`
+}
+
+// canonicalEvent holds the signed fields of an event, so that the signature doesn't depend on the fields set as the
+// event is persisted and published, like the API version, the created timestamps or the ids of the readings
+type canonicalEvent struct {
+	Id          string             `json:"id"`
+	DeviceName  string             `json:"deviceName"`
+	ProfileName string             `json:"profileName"`
+	Origin      int64              `json:"origin"`
+	Readings    []canonicalReading `json:"readings"`
+	Tags        map[string]string  `json:"tags,omitempty"`
+}
+
+type canonicalReading struct {
+	DeviceName   string `json:"deviceName"`
+	ResourceName string `json:"resourceName"`
+	ProfileName  string `json:"profileName"`
+	Origin       int64  `json:"origin"`
+	ValueType    string `json:"valueType"`
+	Value        string `json:"value,omitempty"`
+	BinaryValue  []byte `json:"binaryValue,omitempty"`
+	MediaType    string `json:"mediaType,omitempty"`
+}
+
+// Payload returns the canonical form of the event signed: the JSON encoding of its id, device name, profile name,
+// origin, readings and tags other than SignatureTag, the readings without their id and created timestamp
+func Payload(e dtos.Event) ([]byte, error) {
+	c := canonicalEvent{
+		Id:          e.Id,
+		DeviceName:  e.DeviceName,
+		ProfileName: e.ProfileName,
+		Origin:      e.Origin,
+		Readings:    make([]canonicalReading, len(e.Readings)),
+	}
+	for i, r := range e.Readings {
+		c.Readings[i] = canonicalReading{
+			DeviceName:   r.DeviceName,
+			ResourceName: r.ResourceName,
+			ProfileName:  r.ProfileName,
+			Origin:       r.Origin,
+			ValueType:    r.ValueType,
+			Value:        r.Value,
+			BinaryValue:  r.BinaryValue,
+			MediaType:    r.MediaType,
+		}
+	}
+	for name, value := range e.Tags {
+		if name == SignatureTag {
+			continue
+		}
+		if c.Tags == nil {
+			c.Tags = make(map[string]string)
+		}
+		c.Tags[name] = value
+	}
+	return json.Marshal(c)
+}
+
+// Signer signs the events with the private key of the gateway
+type Signer struct {
+	method jwt.SigningMethod
+	key    interface{}
+	keyId  string
+	public interface{}
+}
+
+// NewSigner returns the signer of the PEM encoded ECDSA or RSA private key, identified by keyId in the signatures
+func NewSigner(privateKey []byte, keyId string) (*Signer, error) {
+	if key, err := jwt.ParseECPrivateKeyFromPEM(privateKey); err == nil {
+		method, err := ecdsaMethod(&key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		return &Signer{method: method, key: key, keyId: keyId, public: &key.PublicKey}, nil
+	}
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey); err == nil {
+		return &Signer{method: jwt.SigningMethodRS256, key: key, keyId: keyId, public: &key.PublicKey}, nil
+	}
+	return nil, fmt.Errorf("the private key must be a PEM encoded ECDSA or RSA key")
+}
+
+// SecretProvider reads the secrets from the service's secret store, e.g. a bootstrap interfaces.SecretProvider
+type SecretProvider interface {
+	GetSecrets(path string, keys ...string) (map[string]string, error)
+}
+
+// LoadSigner returns the signer of the private key and key id read from info.SecretPath
+func LoadSigner(secretProvider SecretProvider, info EventSigningInfo) (*Signer, error) {
+	if info.SecretPath == "" {
+		return nil, fmt.Errorf("no EventSigning.SecretPath to read the private key from")
+	}
+	secrets, err := secretProvider.GetSecrets(info.SecretPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the signing key from secret %s: %s", info.SecretPath, err.Error())
+	}
+	signer, err := NewSigner([]byte(secrets[PrivateKeySecret]), secrets[KeyIdSecret])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key in secret %s: %s", info.SecretPath, err.Error())
+	}
+	return signer, nil
+}
+
+func ecdsaMethod(key *ecdsa.PublicKey) (jwt.SigningMethod, error) {
+	switch key.Curve.Params().BitSize {
+	case 256:
+		return jwt.SigningMethodES256, nil
+	case 384:
+		return jwt.SigningMethodES384, nil
+	}
+	return nil, fmt.Errorf("unsupported ECDSA curve %s, P-256 or P-384 are supported", key.Curve.Params().Name)
+}
+
+// KeyId returns the id of the key of the signer
+func (s *Signer) KeyId() string {
+	return s.keyId
+}
+
+// PublicKey returns the PEM encoded public key verifying the signatures of the signer
+func (s *Signer) PublicKey() ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(s.public)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// Verifier returns the verifier of the signatures of the signer
+func (s *Signer) Verifier() *Verifier {
+	return &Verifier{key: s.public}
+}
+
+// Sign signs the event, replacing its SignatureTag tag by the signature
+func (s *Signer) Sign(e *dtos.Event) error {
+	payload, err := Payload(*e)
+	if err != nil {
+		return err
+	}
+	h, err := json.Marshal(header{Algorithm: s.method.Alg(), KeyId: s.keyId})
+	if err != nil {
+		return err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(h)
+	signature, err := s.method.Sign(protected+"."+base64.RawURLEncoding.EncodeToString(payload), s.key)
+	if err != nil {
+		return err
+	}
+
+	if e.Tags == nil {
+		e.Tags = make(map[string]string)
+	}
+	// the payload is detached, the consumers computing it from the event
+	e.Tags[SignatureTag] = protected + ".." + signature
+	return nil
+}
+
+// Verifier verifies the signatures of the events with the public key of a gateway
+type Verifier struct {
+	key interface{}
+}
+
+// NewVerifier returns the verifier of the PEM encoded ECDSA or RSA public key, or certificate, of a gateway
+func NewVerifier(publicKey []byte) (*Verifier, error) {
+	if key, err := jwt.ParseECPublicKeyFromPEM(publicKey); err == nil {
+		return &Verifier{key: key}, nil
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(publicKey); err == nil {
+		return &Verifier{key: key}, nil
+	}
+	return nil, fmt.Errorf("the public key must be a PEM encoded ECDSA or RSA key or certificate")
+}
+
+// KeyId returns the id of the key which signed the event, read from the signature without verifying it
+func KeyId(e dtos.Event) (string, error) {
+	h, _, err := parseSignature(e)
+	if err != nil {
+		return "", err
+	}
+	return h.KeyId, nil
+}
+
+func parseSignature(e dtos.Event) (header, []string, error) {
+	var h header
+	signature, ok := e.Tags[SignatureTag]
+	if !ok {
+		return h, nil, fmt.Errorf("event %s isn't signed", e.Id)
+	}
+	parts := strings.Split(signature, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return h, nil, fmt.Errorf("the signature of event %s isn't a JWS with a detached payload", e.Id)
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return h, nil, fmt.Errorf("the signature header of event %s isn't base64url encoded", e.Id)
+	}
+	if err = json.Unmarshal(decoded, &h); err != nil {
+		return h, nil, fmt.Errorf("the signature header of event %s isn't valid JSON", e.Id)
+	}
+	return h, parts, nil
+}
+
+// Verify returns an error if the event isn't signed, or its signature isn't valid for the key of the verifier
+func (v *Verifier) Verify(e dtos.Event) error {
+	h, parts, err := parseSignature(e)
+	if err != nil {
+		return err
+	}
+	method := jwt.GetSigningMethod(h.Algorithm)
+	switch method.(type) {
+	case *jwt.SigningMethodECDSA:
+		if _, ok := v.key.(*ecdsa.PublicKey); !ok {
+			return fmt.Errorf("event %s is signed with %s, the key isn't an ECDSA key", e.Id, h.Algorithm)
+		}
+	case *jwt.SigningMethodRSA:
+		if _, ok := v.key.(*rsa.PublicKey); !ok {
+			return fmt.Errorf("event %s is signed with %s, the key isn't an RSA key", e.Id, h.Algorithm)
+		}
+	default:
+		// the other algorithms, e.g. "none" or HMAC, can't prove the origin of the event
+		return fmt.Errorf("event %s is signed with unsupported algorithm '%s'", e.Id, h.Algorithm)
+	}
+
+	payload, err := Payload(e)
+	if err != nil {
+		return err
+	}
+	err = method.Verify(parts[0]+"."+base64.RawURLEncoding.EncodeToString(payload), parts[2], v.key)
+	if err != nil {
+		return fmt.Errorf("invalid signature of event %s: %s", e.Id, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventsig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newECKey(t *testing.T, curve elliptic.Curve) []byte {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func newRSAKey(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func newTestEvent() dtos.Event {
+	return dtos.Event{
+		Id:          "7a1707f0-166f-4c4b-bc9d-1d54c74e0137",
+		DeviceName:  "Boiler",
+		ProfileName: "BoilerProfile",
+		Origin:      1600000000000000000,
+		Readings: []dtos.BaseReading{
+			{
+				DeviceName:    "Boiler",
+				ResourceName:  "Temperature",
+				ProfileName:   "BoilerProfile",
+				Origin:        1600000000000000000,
+				ValueType:     "Float64",
+				SimpleReading: dtos.SimpleReading{Value: "85.5"},
+			},
+			{
+				DeviceName:    "Boiler",
+				ResourceName:  "Snapshot",
+				ProfileName:   "BoilerProfile",
+				Origin:        1600000000000000000,
+				ValueType:     "Binary",
+				BinaryReading: dtos.BinaryReading{BinaryValue: []byte{0x01, 0x02}, MediaType: "image/png"},
+			},
+		},
+		Tags: map[string]string{"site": "plant-1"},
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       []byte
+		algorithm string
+	}{
+		{"P-256", newECKey(t, elliptic.P256()), "ES256"},
+		{"P-384", newECKey(t, elliptic.P384()), "ES384"},
+		{"RSA", newRSAKey(t), "RS256"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewSigner(tt.key, "gateway-1")
+			require.NoError(t, err)
+			publicKey, err := signer.PublicKey()
+			require.NoError(t, err)
+			verifier, err := NewVerifier(publicKey)
+			require.NoError(t, err)
+
+			e := newTestEvent()
+			require.NoError(t, signer.Sign(&e))
+			require.Contains(t, e.Tags, SignatureTag)
+			assert.Equal(t, "plant-1", e.Tags["site"])
+			h, _, err := parseSignature(e)
+			require.NoError(t, err)
+			assert.Equal(t, tt.algorithm, h.Algorithm)
+			keyId, err := KeyId(e)
+			require.NoError(t, err)
+			assert.Equal(t, "gateway-1", keyId)
+
+			assert.NoError(t, verifier.Verify(e))
+			assert.NoError(t, signer.Verifier().Verify(e))
+		})
+	}
+}
+
+func TestVerifyUnsignedFields(t *testing.T) {
+	signer, err := NewSigner(newECKey(t, elliptic.P256()), "")
+	require.NoError(t, err)
+	e := newTestEvent()
+	require.NoError(t, signer.Sign(&e))
+
+	// the fields set as the event is persisted and published don't invalidate the signature
+	e.Versionable = common.NewVersionable()
+	e.Created = 1600000000001
+	for i := range e.Readings {
+		e.Readings[i].Versionable = common.NewVersionable()
+		e.Readings[i].Id = "c2c4d6a9-5ed9-4d4a-8c2e-0c9f5f3d1f4e"
+		e.Readings[i].Created = 1600000000001
+	}
+	assert.NoError(t, signer.Verifier().Verify(e))
+}
+
+func TestVerifyTampered(t *testing.T) {
+	signer, err := NewSigner(newECKey(t, elliptic.P256()), "gateway-1")
+	require.NoError(t, err)
+	other, err := NewSigner(newECKey(t, elliptic.P256()), "gateway-1")
+	require.NoError(t, err)
+	rsaSigner, err := NewSigner(newRSAKey(t), "gateway-1")
+	require.NoError(t, err)
+	signed := newTestEvent()
+	require.NoError(t, signer.Sign(&signed))
+
+	tests := []struct {
+		name   string
+		tamper func(e *dtos.Event)
+	}{
+		{"value", func(e *dtos.Event) { e.Readings[0].Value = "20.0" }},
+		{"binary value", func(e *dtos.Event) { e.Readings[1].BinaryValue = []byte{0x03} }},
+		{"device", func(e *dtos.Event) { e.DeviceName = "Furnace" }},
+		{"origin", func(e *dtos.Event) { e.Origin++ }},
+		{"reading removed", func(e *dtos.Event) { e.Readings = e.Readings[:1] }},
+		{"tag added", func(e *dtos.Event) { e.Tags["site"] = "plant-2" }},
+		{"signed by another key", func(e *dtos.Event) { require.NoError(t, other.Sign(e)) }},
+		{"signed by another algorithm", func(e *dtos.Event) { require.NoError(t, rsaSigner.Sign(e)) }},
+		{"unsigned", func(e *dtos.Event) { delete(e.Tags, SignatureTag) }},
+		{"payload not detached", func(e *dtos.Event) { e.Tags[SignatureTag] = "eyJhbGciOiJFUzI1NiJ9.e30.c2ln" }},
+		{"none algorithm", func(e *dtos.Event) { e.Tags[SignatureTag] = "eyJhbGciOiJub25lIn0.." }},
+		{"not a JWS", func(e *dtos.Event) { e.Tags[SignatureTag] = "signature" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEvent()
+			e.Tags[SignatureTag] = signed.Tags[SignatureTag]
+			require.NoError(t, signer.Verifier().Verify(e))
+
+			tt.tamper(&e)
+			assert.Error(t, signer.Verifier().Verify(e))
+		})
+	}
+}
+
+func TestNewSignerInvalidKey(t *testing.T) {
+	_, err := NewSigner([]byte("not a key"), "")
+	assert.Error(t, err)
+	_, err = NewSigner(newECKey(t, elliptic.P224()), "")
+	assert.Error(t, err, "P-224 isn't supported")
+	_, err = NewVerifier(newRSAKey(t))
+	assert.Error(t, err, "a private key isn't a public key")
+}
+
+type stubSecretProvider struct {
+	secrets map[string]string
+	err     error
+}
+
+func (p stubSecretProvider) GetSecrets(_ string, _ ...string) (map[string]string, error) {
+	return p.secrets, p.err
+}
+
+func TestLoadSigner(t *testing.T) {
+	info := EventSigningInfo{Enabled: true, SecretPath: "eventsigning"}
+	key := newECKey(t, elliptic.P256())
+
+	signer, err := LoadSigner(stubSecretProvider{secrets: map[string]string{PrivateKeySecret: string(key), KeyIdSecret: "gateway-1"}}, info)
+	require.NoError(t, err)
+	assert.Equal(t, "gateway-1", signer.KeyId())
+
+	_, err = LoadSigner(stubSecretProvider{err: errors.New("secret store unavailable")}, info)
+	assert.Error(t, err)
+	_, err = LoadSigner(stubSecretProvider{secrets: map[string]string{}}, info)
+	assert.Error(t, err, "no private key")
+	_, err = LoadSigner(stubSecretProvider{}, EventSigningInfo{Enabled: true})
+	assert.Error(t, err, "no secret path")
+}
@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package features enumerates the optional subsystems of the services, which a distribution can strip from the
+// binaries to reduce their footprint by building them with the no<name> tag of the subsystem, e.g. with
+//
+//	make build GOTAGS="nokafka nocloudbridge"
+//
+// The <Name>Compiled constants are checked along with the configuration before starting a subsystem, so that the
+// compiler drops its code when its tag is set. A stripped subsystem stays disabled whatever its configuration. The
+// Registry of a service reports its subsystems at ApiCapabilitiesRoute, so that the clients can adapt to them.
+package features
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Names of the optional subsystems
+const (
+	// CloudBridge forwards the core-data events to AWS IoT Core, Azure IoT Hub or an MQTT broker
+	CloudBridge = "cloud-bridge"
+	// Kafka publishes the core-data events to Kafka
+	Kafka = "kafka"
+	// Federation serves the queries of core-data federated with the peer gateways
+	Federation = "federation"
+	// GraphQL serves the read-only GraphQL endpoint of core-metadata
+	GraphQL = "graphql"
+	// RemoteCommands executes the core-command commands requested through an MQTT broker
+	RemoteCommands = "remote-commands"
+)
+
+// ApiCapabilitiesRoute reports the optional subsystems of the service, whether they are compiled and enabled
+const ApiCapabilitiesRoute = v2.ApiBase + "/capabilities"
+
+// compiled maps the name of each subsystem to whether it is compiled
+var compiled = map[string]bool{
+	CloudBridge:    CloudBridgeCompiled,
+	Kafka:          KafkaCompiled,
+	Federation:     FederationCompiled,
+	GraphQL:        GraphQLCompiled,
+	RemoteCommands: RemoteCommandsCompiled,
+}
+
+// Compiled reports whether the service is built with the subsystem name
+func Compiled(name string) bool {
+	return compiled[name]
+}
+
+// Feature is an optional subsystem of a service
+type Feature struct {
+	Name string `json:"name"`
+	// Compiled reports whether the service is built with the subsystem
+	Compiled bool `json:"compiled"`
+	// Enabled reports whether the subsystem is compiled and enabled by the configuration of the service
+	Enabled bool `json:"enabled"`
+}
+
+// CapabilitiesResponse defines the response content of ApiCapabilitiesRoute
+type CapabilitiesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Features               []Feature `json:"features"`
+}
+
+// Registry holds the optional subsystems of a service, in the order they are added
+type Registry struct {
+	lc       logger.LoggingClient
+	features []Feature
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry(lc logger.LoggingClient) *Registry {
+	return &Registry{lc: lc}
+}
+
+// Add adds the subsystem name of the service, enabled by its configuration when configured is set. A subsystem
+// configured but not compiled is logged, as the service runs without it.
+func (r *Registry) Add(name string, configured bool) {
+	feature := Feature{Name: name, Compiled: Compiled(name)}
+	feature.Enabled = feature.Compiled && configured
+	if configured && !feature.Compiled {
+		r.lc.Warn(fmt.Sprintf("%s is enabled by the configuration but the service is built with the no%s tag, ignoring it",
+			name, tag(name)))
+	}
+	r.features = append(r.features, feature)
+}
+
+// Features returns the subsystems of the service, none for a nil Registry
+func (r *Registry) Features() []Feature {
+	if r == nil {
+		return []Feature{}
+	}
+	features := make([]Feature, len(r.features))
+	copy(features, r.features)
+	return features
+}
+
+// tag returns the suffix of the build tag stripping the subsystem name, its name without the dashes
+func tag(name string) string {
+	return strings.ReplaceAll(name, "-", "")
+}
@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package features
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(logger.NewMockClient())
+	r.Add(Kafka, true)
+	r.Add(CloudBridge, false)
+	r.Add("unknown", true)
+
+	assert.Equal(t, []Feature{
+		{Name: Kafka, Compiled: KafkaCompiled, Enabled: KafkaCompiled},
+		{Name: CloudBridge, Compiled: CloudBridgeCompiled, Enabled: false},
+		{Name: "unknown", Compiled: false, Enabled: false},
+	}, r.Features(), "a subsystem is enabled when compiled and configured")
+
+	var nilRegistry *Registry
+	data, err := json.Marshal(nilRegistry.Features())
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(data), "a service without optional subsystems reports none")
+}
+
+func TestCompiled(t *testing.T) {
+	for name, constant := range map[string]bool{
+		CloudBridge:    CloudBridgeCompiled,
+		Kafka:          KafkaCompiled,
+		Federation:     FederationCompiled,
+		GraphQL:        GraphQLCompiled,
+		RemoteCommands: RemoteCommandsCompiled,
+	} {
+		assert.Equal(t, constant, Compiled(name), name)
+	}
+	assert.Equal(t, "remotecommands", tag(RemoteCommands))
+}
@@ -0,0 +1,11 @@
+// +build nocloudbridge
+
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package features
+
+// CloudBridgeCompiled reports whether the service is built with the cloud bridge, i.e. without the nocloudbridge tag
+const CloudBridgeCompiled = false
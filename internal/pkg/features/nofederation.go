@@ -0,0 +1,11 @@
+// +build nofederation
+
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package features
+
+// FederationCompiled reports whether the service is built with the federated queries, i.e. without the nofederation tag
+const FederationCompiled = false
@@ -0,0 +1,11 @@
+// +build nographql
+
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package features
+
+// GraphQLCompiled reports whether the service is built with the GraphQL endpoint, i.e. without the nographql tag
+const GraphQLCompiled = false
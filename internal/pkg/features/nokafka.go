@@ -0,0 +1,11 @@
+// +build nokafka
+
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package features
+
+// KafkaCompiled reports whether the service is built with the Kafka publisher, i.e. without the nokafka tag
+const KafkaCompiled = false
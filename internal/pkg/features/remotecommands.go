@@ -0,0 +1,11 @@
+// +build !noremotecommands
+
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package features
+
+// RemoteCommandsCompiled reports whether the service is built with the MQTT remote commands, i.e. without the noremotecommands tag
+const RemoteCommandsCompiled = true
@@ -0,0 +1,286 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gorilla implements the compression of the time series of the Facebook Gorilla database: the timestamps are
+// encoded by their delta-of-delta and the values by their XOR with the previous value, so that the regular timestamps
+// and the slowly changing values of the sensors take a few bits each. Each column of a series is encoded on its own.
+package gorilla
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrTruncated is returned when the encoded data ends before the number of values to decode
+var ErrTruncated = errors.New("truncated gorilla encoding")
+
+// bitWriter appends bits to a byte slice, the most significant bit first
+type bitWriter struct {
+	data []byte
+	// free is the number of unused bits of the last byte
+	free uint
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if w.free == 0 {
+		w.data = append(w.data, 0)
+		w.free = 8
+	}
+	w.free--
+	if bit {
+		w.data[len(w.data)-1] |= 1 << w.free
+	}
+}
+
+// writeBits writes the n least significant bits of value
+func (w *bitWriter) writeBits(value uint64, n uint) {
+	for n > 0 {
+		if w.free == 0 {
+			w.data = append(w.data, 0)
+			w.free = 8
+		}
+		count := n
+		if count > w.free {
+			count = w.free
+		}
+		n -= count
+		w.free -= count
+		chunk := byte(value>>n) & byte(1<<count-1)
+		w.data[len(w.data)-1] |= chunk << w.free
+	}
+}
+
+// bitReader reads the bits written by a bitWriter
+type bitReader struct {
+	data []byte
+	// position is the index of the next bit to read
+	position uint
+}
+
+func (r *bitReader) readBit() (bool, error) {
+	if r.position >= uint(len(r.data))*8 {
+		return false, ErrTruncated
+	}
+	bit := r.data[r.position/8]&(1<<(7-r.position%8)) != 0
+	r.position++
+	return bit, nil
+}
+
+func (r *bitReader) readBits(n uint) (uint64, error) {
+	if r.position+n > uint(len(r.data))*8 {
+		return 0, ErrTruncated
+	}
+	var value uint64
+	for n > 0 {
+		available := 8 - r.position%8
+		count := n
+		if count > available {
+			count = available
+		}
+		chunk := uint64(r.data[r.position/8]>>(available-count)) & (1<<count - 1)
+		value = value<<count | chunk
+		n -= count
+		r.position += count
+	}
+	return value, nil
+}
+
+// timestampBits are the bits of the delta-of-delta after a prefix of as many 1 bits as its index, terminated by a 0
+// bit except after the last prefix. A delta-of-delta of 0 is encoded by a single 0 bit.
+var timestampBits = []uint{0, 7, 9, 12, 32, 64}
+
+// fits tells whether value is encoded by the two's complement on n bits
+func fits(value int64, n uint) bool {
+	if n == 64 {
+		return true
+	}
+	limit := int64(1) << (n - 1)
+	return value >= -limit && value < limit
+}
+
+// EncodeTimestamps encodes the timestamps by their delta-of-delta: the first timestamp takes 64 bits, and each next
+// one a single bit when it is as far from the previous one as the previous one was from its own previous one
+func EncodeTimestamps(timestamps []int64) []byte {
+	w := &bitWriter{}
+	var previous, delta int64
+	for i, timestamp := range timestamps {
+		if i == 0 {
+			w.writeBits(uint64(timestamp), 64)
+			previous = timestamp
+			continue
+		}
+		newDelta := timestamp - previous
+		dod := newDelta - delta
+		previous, delta = timestamp, newDelta
+		if dod == 0 {
+			w.writeBit(false)
+			continue
+		}
+		for ones := 1; ones < len(timestampBits); ones++ {
+			n := timestampBits[ones]
+			if !fits(dod, n) {
+				continue
+			}
+			for j := 0; j < ones; j++ {
+				w.writeBit(true)
+			}
+			if ones < len(timestampBits)-1 {
+				w.writeBit(false)
+			}
+			w.writeBits(uint64(dod), n)
+			break
+		}
+	}
+	return w.data
+}
+
+// DecodeTimestamps decodes count timestamps encoded by EncodeTimestamps
+func DecodeTimestamps(data []byte, count int) ([]int64, error) {
+	r := &bitReader{data: data}
+	timestamps := make([]int64, 0, count)
+	var previous, delta int64
+	for i := 0; i < count; i++ {
+		if i == 0 {
+			value, err := r.readBits(64)
+			if err != nil {
+				return nil, err
+			}
+			previous = int64(value)
+			timestamps = append(timestamps, previous)
+			continue
+		}
+		ones := 0
+		for ones < len(timestampBits)-1 {
+			bit, err := r.readBit()
+			if err != nil {
+				return nil, err
+			}
+			if !bit {
+				break
+			}
+			ones++
+		}
+		var dod int64
+		if n := timestampBits[ones]; n > 0 {
+			value, err := r.readBits(n)
+			if err != nil {
+				return nil, err
+			}
+			dod = signExtend(value, n)
+		}
+		delta += dod
+		previous += delta
+		timestamps = append(timestamps, previous)
+	}
+	return timestamps, nil
+}
+
+// signExtend returns the value of the two's complement on the n least significant bits of value
+func signExtend(value uint64, n uint) int64 {
+	shift := 64 - n
+	return int64(value<<shift) >> shift
+}
+
+// EncodeValues encodes the values, e.g. the bits of floats, by their XOR with the previous value: the first value
+// takes 64 bits, an unchanged value a single bit, and a changed value the bits differing from the previous value,
+// which are few for the values changing slowly
+func EncodeValues(values []uint64) []byte {
+	w := &bitWriter{}
+	var previous uint64
+	// the leading and trailing zeros of the XOR last written with their count, none before
+	var leading, trailing uint = 64, 0
+	for i, value := range values {
+		if i == 0 {
+			w.writeBits(value, 64)
+			previous = value
+			continue
+		}
+		xor := value ^ previous
+		previous = value
+		if xor == 0 {
+			w.writeBit(false)
+			continue
+		}
+		w.writeBit(true)
+		newLeading := uint(bits.LeadingZeros64(xor))
+		newTrailing := uint(bits.TrailingZeros64(xor))
+		// the count of leading zeros is written on 5 bits
+		if newLeading > 31 {
+			newLeading = 31
+		}
+		if leading != 64 && newLeading >= leading && newTrailing >= trailing {
+			// the meaningful bits fit in the window of the previous XOR
+			w.writeBit(false)
+			w.writeBits(xor>>trailing, 64-leading-trailing)
+			continue
+		}
+		leading, trailing = newLeading, newTrailing
+		significant := 64 - leading - trailing
+		w.writeBit(true)
+		w.writeBits(uint64(leading), 5)
+		// 64 significant bits are written as 0, a changed value having at least one
+		w.writeBits(uint64(significant&63), 6)
+		w.writeBits(xor>>trailing, significant)
+	}
+	return w.data
+}
+
+// DecodeValues decodes count values encoded by EncodeValues
+func DecodeValues(data []byte, count int) ([]uint64, error) {
+	r := &bitReader{data: data}
+	values := make([]uint64, 0, count)
+	var previous uint64
+	var leading, trailing uint
+	windowed := false
+	for i := 0; i < count; i++ {
+		if i == 0 {
+			value, err := r.readBits(64)
+			if err != nil {
+				return nil, err
+			}
+			previous = value
+			values = append(values, value)
+			continue
+		}
+		changed, err := r.readBit()
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			newWindow, err := r.readBit()
+			if err != nil {
+				return nil, err
+			}
+			if newWindow {
+				value, err := r.readBits(5)
+				if err != nil {
+					return nil, err
+				}
+				leading = uint(value)
+				if value, err = r.readBits(6); err != nil {
+					return nil, err
+				}
+				significant := uint(value)
+				if significant == 0 {
+					significant = 64
+				}
+				if leading+significant > 64 {
+					return nil, errors.New("invalid gorilla value encoding")
+				}
+				trailing = 64 - leading - significant
+				windowed = true
+			} else if !windowed {
+				return nil, errors.New("invalid gorilla value encoding")
+			}
+			xor, err := r.readBits(64 - leading - trailing)
+			if err != nil {
+				return nil, err
+			}
+			previous ^= xor << trailing
+		}
+		values = append(values, previous)
+	}
+	return values, nil
+}
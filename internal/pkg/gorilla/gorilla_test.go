@@ -0,0 +1,114 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gorilla
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamps(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	jittered := make([]int64, 1000)
+	for i := range jittered {
+		jittered[i] = 1620000000000000000 + int64(i)*int64(1e9) + random.Int63n(int64(1e6))
+	}
+
+	tests := []struct {
+		name       string
+		timestamps []int64
+	}{
+		{"empty", nil},
+		{"single", []int64{1620000000000}},
+		{"regular", []int64{1620000000000, 1620000001000, 1620000002000, 1620000003000}},
+		{"irregular", []int64{1620000000000, 1620000000999, 1620000002001, 1620000002001, 1620000010000, 1620000009000}},
+		{"extremes", []int64{math.MaxInt64, math.MinInt64, 0, math.MaxInt64, -1}},
+		{"nanosecond jitter", jittered},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			data := EncodeTimestamps(testCase.timestamps)
+			decoded, err := DecodeTimestamps(data, len(testCase.timestamps))
+			require.NoError(t, err)
+			assert.Equal(t, len(testCase.timestamps), len(decoded))
+			for i := range decoded {
+				assert.Equal(t, testCase.timestamps[i], decoded[i])
+			}
+		})
+	}
+
+	regular := make([]int64, 120)
+	for i := range regular {
+		regular[i] = 1620000000000 + int64(i)*1000
+	}
+	// 64 bits for the first timestamp, 4+12 bits for the first delta, 1 bit for each next one
+	assert.Len(t, EncodeTimestamps(regular), (64+4+12+118+7)/8)
+}
+
+func TestValues(t *testing.T) {
+	floats := func(values ...float64) []uint64 {
+		result := make([]uint64, len(values))
+		for i, value := range values {
+			result[i] = math.Float64bits(value)
+		}
+		return result
+	}
+	random := rand.New(rand.NewSource(1))
+	randomValues := make([]uint64, 1000)
+	for i := range randomValues {
+		randomValues[i] = random.Uint64() >> uint(random.Intn(64))
+	}
+
+	tests := []struct {
+		name   string
+		values []uint64
+	}{
+		{"empty", nil},
+		{"single", floats(21.5)},
+		{"steady", floats(21.5, 21.5, 21.5, 21.5)},
+		{"unchanged then changed", floats(21.5, 21.5, 21.5, 21.75, 21.5, 22)},
+		{"slowly changing", floats(21.5, 21.6, 21.7, 21.65, 21.6, 21.5, 21.4)},
+		{"special", floats(0, math.NaN(), math.Inf(1), math.Inf(-1), -0.0, math.MaxFloat64, math.SmallestNonzeroFloat64)},
+		{"integers", []uint64{0, 1, 2, 3, math.MaxUint64, 1 << 63, 1, 0}},
+		{"random", randomValues},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			data := EncodeValues(testCase.values)
+			decoded, err := DecodeValues(data, len(testCase.values))
+			require.NoError(t, err)
+			assert.Equal(t, len(testCase.values), len(decoded))
+			for i := range decoded {
+				assert.Equal(t, testCase.values[i], decoded[i])
+			}
+		})
+	}
+
+	steady := make([]float64, 120)
+	for i := range steady {
+		steady[i] = 21.5
+	}
+	// 64 bits for the first value, 1 bit for each next one
+	assert.Len(t, EncodeValues(floats(steady...)), (64+119+7)/8)
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	timestamps := EncodeTimestamps([]int64{1620000000000, 1620000001000, 1620000002500})
+	_, err := DecodeTimestamps(timestamps[:len(timestamps)-1], 3)
+	assert.Equal(t, ErrTruncated, err)
+	_, err = DecodeTimestamps(timestamps, 10)
+	assert.Equal(t, ErrTruncated, err)
+
+	values := EncodeValues([]uint64{math.Float64bits(21.5), math.Float64bits(22.5)})
+	_, err = DecodeValues(values[:len(values)-1], 2)
+	assert.Equal(t, ErrTruncated, err)
+	_, err = DecodeValues(nil, 1)
+	assert.Equal(t, ErrTruncated, err)
+}
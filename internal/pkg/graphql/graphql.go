@@ -0,0 +1,190 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package graphql executes the read-only GraphQL queries of a service with github.com/graphql-go/graphql, bounding
+// the nesting of their fields, and provides the scalar types of the values of the REST API which GraphQL lacks.
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// GraphQLInfo configures the GraphQL endpoint of a service
+type GraphQLInfo struct {
+	// Enabled serves the GraphQL queries
+	Enabled bool
+	// MaxDepth bounds the nesting of the fields of a query, 0 for no bound
+	MaxDepth int
+}
+
+// Request is a GraphQL request, as posted in JSON
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the result of a GraphQL request. Data is nil when the request is invalid, and holds the resolved
+// fields otherwise, the fields which failed to resolve being null and reported in Errors.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []Error     `json:"errors,omitempty"`
+}
+
+// Error is an error of a GraphQL request, located in the query or by the path of the field which failed to resolve
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+// Location is the line and column of an element of a query, both starting at 1
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Long is the scalar type of the 64-bit integers, such as the timestamps in milliseconds, which don't fit the 32-bit
+// Int type of GraphQL
+var Long = gql.NewScalar(gql.ScalarConfig{
+	Name:        "Long",
+	Description: "A 64-bit integer, such as a timestamp in milliseconds",
+	Serialize:   toLong,
+	ParseValue:  toLong,
+	ParseLiteral: func(value ast.Value) interface{} {
+		if v, ok := value.(*ast.IntValue); ok {
+			if i, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+				return i
+			}
+		}
+		return nil
+	},
+})
+
+func toLong(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		// the numbers decoded from JSON
+		if v == math.Trunc(v) && v >= math.MinInt64 && v <= math.MaxInt64 {
+			return int64(v)
+		}
+	}
+	return nil
+}
+
+// JSON is the scalar type of the values returned whole as their JSON encoding, such as the maps of the protocols or
+// attributes whose keys vary. It is an output type only.
+var JSON = gql.NewScalar(gql.ScalarConfig{
+	Name:        "JSON",
+	Description: "A value returned as its JSON encoding, whatever its structure",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+})
+
+// Execute validates and executes the query of the request on schema, rejecting the queries whose fields are nested
+// deeper than maxDepth unless it is 0
+func Execute(ctx context.Context, schema gql.Schema, maxDepth int, request Request) Response {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(request.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return Response{Errors: toErrors(gqlerrors.FormatErrors(err))}
+	}
+	if validation := gql.ValidateDocument(&schema, doc, nil); !validation.IsValid {
+		return Response{Errors: toErrors(validation.Errors)}
+	}
+	if maxDepth > 0 {
+		if errs := checkDepth(doc, maxDepth); len(errs) > 0 {
+			return Response{Errors: toErrors(errs)}
+		}
+	}
+
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        schema,
+		AST:           doc,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       ctx,
+	})
+	return Response{Data: result.Data, Errors: toErrors(result.Errors)}
+}
+
+// checkDepth returns the errors of the fields of the operations of the validated doc nested deeper than maxDepth,
+// the fragments being expanded where they are spread. The introspection fields are exempt, as their nesting is bounded
+// by the schema and the introspection queries of the GraphQL tools nest the types of the fields deeply.
+func checkDepth(doc *ast.Document, maxDepth int) []gqlerrors.FormattedError {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	var errs []gqlerrors.FormattedError
+	var visit func(selectionSet *ast.SelectionSet, depth int)
+	visit = func(selectionSet *ast.SelectionSet, depth int) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch s := selection.(type) {
+			case *ast.Field:
+				if strings.HasPrefix(s.Name.Value, "__") {
+					continue
+				}
+				if depth > maxDepth {
+					err := fmt.Errorf("field %s exceeds the maximum depth of %d", s.Name.Value, maxDepth)
+					errs = append(errs, gqlerrors.FormatError(gqlerrors.NewLocatedError(err, []ast.Node{s})))
+					continue
+				}
+				visit(s.SelectionSet, depth+1)
+			case *ast.InlineFragment:
+				visit(s.SelectionSet, depth)
+			case *ast.FragmentSpread:
+				// the fragments don't spread themselves once validated
+				if fragment, ok := fragments[s.Name.Value]; ok {
+					visit(fragment.SelectionSet, depth)
+				}
+			}
+		}
+	}
+	for _, definition := range doc.Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			visit(operation.SelectionSet, 1)
+		}
+	}
+	return errs
+}
+
+func toErrors(formatted []gqlerrors.FormattedError) []Error {
+	if len(formatted) == 0 {
+		return nil
+	}
+	errs := make([]Error, len(formatted))
+	for i, f := range formatted {
+		errs[i] = Error{Message: f.Message, Path: f.Path}
+		for _, l := range f.Locations {
+			errs[i].Locations = append(errs[i].Locations, Location{Line: l.Line, Column: l.Column})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,179 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDevices = []map[string]interface{}{
+	{"name": "pump-1", "profileName": "pump", "created": int64(1612345678901), "labels": []string{"zone-1"}, "protocols": map[string]interface{}{"modbus-tcp": map[string]string{"Port": "502"}}},
+	{"name": "pump-2", "profileName": "pump", "created": int64(1612345678902), "labels": []string{"zone-2"}},
+	{"name": "fan-1", "profileName": "unknown", "created": int64(1612345678903)},
+}
+
+func testSchema(t *testing.T) gql.Schema {
+	deviceByName := func(name string) interface{} {
+		for _, d := range testDevices {
+			if d["name"] == name {
+				return d
+			}
+		}
+		return nil
+	}
+
+	device := gql.NewObject(gql.ObjectConfig{Name: "Device", Fields: gql.Fields{
+		"name":      {Type: gql.String},
+		"created":   {Type: Long},
+		"labels":    {Type: gql.NewList(gql.String)},
+		"protocols": {Type: JSON},
+	}})
+	profile := gql.NewObject(gql.ObjectConfig{Name: "Profile", Fields: gql.Fields{
+		"name": {Type: gql.String},
+		"devices": {Type: gql.NewList(device), Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			var devices []map[string]interface{}
+			for _, d := range testDevices {
+				if d["profileName"] == p.Source.(map[string]interface{})["name"] {
+					devices = append(devices, d)
+				}
+			}
+			return devices, nil
+		}},
+	}})
+	device.AddFieldConfig("profile", &gql.Field{Type: profile, Resolve: func(p gql.ResolveParams) (interface{}, error) {
+		name := p.Source.(map[string]interface{})["profileName"].(string)
+		if name == "unknown" {
+			return nil, errors.New("device profile unknown not found")
+		}
+		return map[string]interface{}{"name": name}, nil
+	}})
+
+	schema, err := gql.NewSchema(gql.SchemaConfig{Query: gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: gql.Fields{
+		"device": {
+			Type: device,
+			Args: gql.FieldConfigArgument{"name": {Type: gql.NewNonNull(gql.String)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return deviceByName(p.Args["name"].(string)), nil
+			},
+		},
+		"devices": {
+			Type: gql.NewList(device),
+			Args: gql.FieldConfigArgument{
+				"limit":        {Type: gql.Int},
+				"createdAfter": {Type: Long},
+			},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				var devices []map[string]interface{}
+				for _, d := range testDevices {
+					if after, ok := p.Args["createdAfter"].(int64); !ok || d["created"].(int64) > after {
+						devices = append(devices, d)
+					}
+				}
+				if limit, ok := p.Args["limit"].(int); ok && limit < len(devices) {
+					devices = devices[:limit]
+				}
+				return devices, nil
+			},
+		},
+	}})})
+	require.NoError(t, err)
+	return schema
+}
+
+func executeJSON(t *testing.T, maxDepth int, request Request) string {
+	response := Execute(context.Background(), testSchema(t), maxDepth, request)
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		expected  string
+	}{
+		{"nested fields",
+			`{ device(name: "pump-1") { profile { devices { name } name } name labels protocols created } }`,
+			nil,
+			`{"data":{"device":{"profile":{"devices":[{"name":"pump-1"},{"name":"pump-2"}],"name":"pump"},"name":"pump-1","labels":["zone-1"],"protocols":{"modbus-tcp":{"Port":"502"}},"created":1612345678901}}}`},
+		{"aliases and typename",
+			`query { first: device(name: "pump-1") { __typename name } second: device(name: "pump-2") { name } }`,
+			nil,
+			`{"data":{"first":{"__typename":"Device","name":"pump-1"},"second":{"name":"pump-2"}}}`},
+		{"variables decoded from JSON",
+			`query ($limit: Int, $after: Long) { devices(limit: $limit, createdAfter: $after) { name } }`,
+			map[string]interface{}{"limit": float64(1), "after": float64(1612345678901)},
+			`{"data":{"devices":[{"name":"pump-2"}]}}`},
+		{"long literal",
+			`{ devices(createdAfter: 1612345678902) { name } }`,
+			nil,
+			`{"data":{"devices":[{"name":"fan-1"}]}}`},
+		{"fragments",
+			`{ device(name: "pump-2") { ...names ... on Device { labels } } } fragment names on Device { name }`,
+			nil,
+			`{"data":{"device":{"name":"pump-2","labels":["zone-2"]}}}`},
+		{"missing object",
+			`{ device(name: "none") { name } }`,
+			nil,
+			`{"data":{"device":null}}`},
+		{"resolver errors with paths",
+			`{ devices { name profile { name } } }`,
+			nil,
+			`{"data":{"devices":[{"name":"pump-1","profile":{"name":"pump"}},{"name":"pump-2","profile":{"name":"pump"}},{"name":"fan-1","profile":null}]},"errors":[{"message":"device profile unknown not found","locations":[{"line":1,"column":18}],"path":["devices",2,"profile"]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.JSONEq(t, tt.expected, executeJSON(t, 0, Request{Query: tt.query, Variables: tt.variables}))
+		})
+	}
+}
+
+func TestExecute_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  Request
+		maxDepth int
+		expected Error
+	}{
+		{"syntax error", Request{Query: "{ device(name: \"pump-1\") {\n name " + "}"}, 0,
+			Error{Message: "Syntax Error GraphQL request (2:8) Expected Name, found EOF\n\n1: { device(name: \"pump-1\") {\n2:  name }\n          ^\n",
+				Locations: []Location{{Line: 2, Column: 8}}}},
+		{"mutation", Request{Query: `mutation { device(name: "pump-1") { name } }`}, 0,
+			Error{Message: "Schema is not configured for mutations", Locations: []Location{{Line: 1, Column: 1}}}},
+		{"unknown field", Request{Query: `{ devices { name serial } }`}, 0,
+			Error{Message: `Cannot query field "serial" on type "Device".`, Locations: []Location{{Line: 1, Column: 18}}}},
+		{"missing argument", Request{Query: `{ device { name } }`}, 0,
+			Error{Message: `Field "device" argument "name" of type "String!" is required but not provided.`, Locations: []Location{{Line: 1, Column: 3}}}},
+		{"too deep", Request{Query: `{ devices { profile { devices { name } } } }`}, 2,
+			Error{Message: "field devices exceeds the maximum depth of 2", Locations: []Location{{Line: 1, Column: 23}}}},
+		{"too deep in a fragment", Request{Query: `{ devices { ...profile } } fragment profile on Device { profile { devices { name } } }`}, 2,
+			Error{Message: "field devices exceeds the maximum depth of 2", Locations: []Location{{Line: 1, Column: 67}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := Execute(context.Background(), testSchema(t), tt.maxDepth, tt.request)
+			assert.Nil(t, response.Data, "an invalid request should not be executed")
+			require.Len(t, response.Errors, 1)
+			assert.Equal(t, tt.expected, response.Errors[0])
+		})
+	}
+
+	response := Execute(context.Background(), testSchema(t), 4, Request{Query: `{ device(name: "pump-1") { __typename profile { devices { name } } } }`})
+	assert.Empty(t, response.Errors, "a query within the maximum depth should be executed")
+
+	response = Execute(context.Background(), testSchema(t), 1, Request{Query: `{ __schema { queryType { fields { name type { ofType { name } } } } } }`})
+	assert.Empty(t, response.Errors, "the introspection fields should be exempt from the maximum depth")
+	assert.NotNil(t, response.Data)
+}
@@ -0,0 +1,82 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package httpclient
+
+import (
+	"time"
+)
+
+// States of the circuit breaker of a destination
+const (
+	// StateClosed lets the requests through
+	StateClosed = "closed"
+	// StateOpen fails the requests without sending them, until the circuit is half-open
+	StateOpen = "open"
+	// StateHalfOpen lets one trial request through, closing the circuit when it succeeds and opening it again when
+	// it fails
+	StateHalfOpen = "half-open"
+)
+
+// breaker opens the circuit of a destination after threshold consecutive failures, for openDuration. A threshold of
+// zero or less never opens the circuit.
+type breaker struct {
+	threshold    int
+	openDuration time.Duration
+	state        string
+	failures     int
+	openedAt     time.Time
+	trialSent    bool
+}
+
+func newBreaker(threshold int, openDuration time.Duration) *breaker {
+	return &breaker{threshold: threshold, openDuration: openDuration, state: StateClosed}
+}
+
+// allow tells whether a request may be sent at now, moving the open circuit to half-open once openDuration has
+// elapsed
+func (b *breaker) allow(now time.Time) bool {
+	switch b.state {
+	case StateOpen:
+		if now.Sub(b.openedAt) < b.openDuration {
+			return false
+		}
+		b.state = StateHalfOpen
+		b.trialSent = true
+		return true
+	case StateHalfOpen:
+		if b.trialSent {
+			return false
+		}
+		b.trialSent = true
+		return true
+	}
+	return true
+}
+
+// record records the outcome of a request sent at now and returns the state of the circuit before and after it
+func (b *breaker) record(failed bool, now time.Time) (previous string, current string) {
+	previous = b.state
+	switch {
+	case !failed:
+		b.state = StateClosed
+		b.failures = 0
+	case b.state == StateHalfOpen:
+		b.open(now)
+	case b.state == StateClosed:
+		b.failures++
+		if b.threshold > 0 && b.failures >= b.threshold {
+			b.open(now)
+		}
+	}
+	return previous, b.state
+}
+
+func (b *breaker) open(now time.Time) {
+	b.state = StateOpen
+	b.openedAt = now
+	b.failures = 0
+	b.trialSent = false
+}
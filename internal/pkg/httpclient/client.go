@@ -0,0 +1,346 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package httpclient provides the client shared by the services to send their outbound requests, to the device
+// services, the notification channels, the interval action targets or the secret store, with per-destination
+// timeouts, bounded retries of the safe requests and circuit breaking.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// ErrCircuitOpen is wrapped by the error returned for the requests not sent because the circuit of their destination
+// is open
+var ErrCircuitOpen = errors.New("circuit open")
+
+// retriesKey is the context key of the requests whose caller allows to send them again though their method isn't safe
+type retriesKey struct{}
+
+// AllowRetries returns a copy of ctx allowing the PUT and DELETE requests sent with it to be sent again after failing,
+// which the caller should only do when the destination handles them idempotently. A device command, for instance,
+// may well trigger an action on every request.
+func AllowRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retriesKey{}, true)
+}
+
+// DestinationInfo configures the requests sent to a destination
+type DestinationInfo struct {
+	// Timeout is how long each attempt of a request may take, e.g. "5s". The timeout of the underlying client applies
+	// when empty.
+	Timeout string
+	// MaxRetries is the number of times a GET, HEAD or OPTIONS request, or a PUT or DELETE request allowed by
+	// AllowRetries, is sent again after failing
+	MaxRetries int
+	// RetryWait is the wait before the first retry, e.g. "250ms", doubled before each following retry
+	RetryWait string
+	// FailureThreshold is the number of consecutive failures opening the circuit of the destination. The circuit
+	// never opens when zero.
+	FailureThreshold int
+	// OpenDuration is how long the circuit stays open before a trial request is let through, e.g. "30s"
+	OpenDuration string
+}
+
+// OutboundHTTPInfo configures the outbound requests of a service
+type OutboundHTTPInfo struct {
+	// Default applies to all destinations
+	Default DestinationInfo
+	// Destinations replaces the Default settings for the destinations named by their host:port, e.g.
+	// "localhost:49990"
+	Destinations map[string]DestinationInfo
+}
+
+// DestinationMetrics holds the counters of a destination
+type DestinationMetrics struct {
+	// Requests is the number of attempts sent, retries included
+	Requests uint64 `json:"requests"`
+	// Failures is the number of attempts which failed
+	Failures uint64 `json:"failures"`
+	// Retries is the number of attempts which were retries
+	Retries uint64 `json:"retries"`
+	// Rejected is the number of attempts not sent because the circuit was open
+	Rejected uint64 `json:"rejected"`
+	// State is the state of the circuit
+	State string `json:"state"`
+}
+
+// settings holds the parsed DestinationInfo
+type settings struct {
+	timeout          time.Duration
+	maxRetries       int
+	retryWait        time.Duration
+	failureThreshold int
+	openDuration     time.Duration
+}
+
+func parseDuration(name string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s '%s': %s", name, value, err.Error())
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("%s '%s' must not be negative", name, value)
+	}
+	return duration, nil
+}
+
+func (d DestinationInfo) settings() (s settings, err error) {
+	if s.timeout, err = parseDuration("Timeout", d.Timeout); err != nil {
+		return s, err
+	}
+	if s.retryWait, err = parseDuration("RetryWait", d.RetryWait); err != nil {
+		return s, err
+	}
+	if s.openDuration, err = parseDuration("OpenDuration", d.OpenDuration); err != nil {
+		return s, err
+	}
+	if d.MaxRetries < 0 {
+		return s, fmt.Errorf("MaxRetries %d must not be negative", d.MaxRetries)
+	}
+	if d.FailureThreshold > 0 && s.openDuration == 0 {
+		return s, fmt.Errorf("OpenDuration must be set when FailureThreshold is")
+	}
+	s.maxRetries = d.MaxRetries
+	s.failureThreshold = d.FailureThreshold
+	return s, nil
+}
+
+// Validate checks the Default settings and those of each destination
+func (info OutboundHTTPInfo) Validate() error {
+	if _, err := info.Default.settings(); err != nil {
+		return fmt.Errorf("default outbound HTTP settings: %s", err.Error())
+	}
+	for host, destination := range info.Destinations {
+		if _, err := destination.settings(); err != nil {
+			return fmt.Errorf("outbound HTTP settings of %s: %s", host, err.Error())
+		}
+	}
+	return nil
+}
+
+// destination holds the circuit and the counters of a destination
+type destination struct {
+	settings settings
+	breaker  *breaker
+	metrics  DestinationMetrics
+}
+
+// Client sends the requests with the underlying caller, applying the settings of their destination, the host:port of
+// their URL. It is safe for concurrent use.
+type Client struct {
+	lc       logger.LoggingClient
+	caller   internal.HttpCaller
+	info     OutboundHTTPInfo
+	mutex    sync.Mutex
+	hosts    map[string]*destination
+	now      func() time.Time
+	waitFunc func(ctx context.Context, d time.Duration) bool
+}
+
+// NewClient is a factory function that returns a Client sending the requests with caller, usually an *http.Client,
+// under the settings of info. It returns an error when info isn't valid.
+func NewClient(lc logger.LoggingClient, info OutboundHTTPInfo, caller internal.HttpCaller) (*Client, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	return &Client{
+		lc:       lc,
+		caller:   caller,
+		info:     info,
+		hosts:    make(map[string]*destination),
+		now:      time.Now,
+		waitFunc: wait,
+	}, nil
+}
+
+// wait waits for d, returning false when ctx is done first
+func wait(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// destination returns the destination of host, created on its first request
+func (c *Client) destination(host string) *destination {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if d, ok := c.hosts[host]; ok {
+		return d
+	}
+	info, ok := c.info.Destinations[host]
+	if !ok {
+		info = c.info.Default
+	}
+	// validated by NewClient
+	s, _ := info.settings()
+	d := &destination{settings: s, breaker: newBreaker(s.failureThreshold, s.openDuration)}
+	d.metrics.State = StateClosed
+	c.hosts[host] = d
+	return d
+}
+
+// Metrics returns the counters of the destinations the client sent requests to, by host:port
+func (c *Client) Metrics() map[string]DestinationMetrics {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	metrics := make(map[string]DestinationMetrics, len(c.hosts))
+	for host, d := range c.hosts {
+		metrics[host] = d.metrics
+	}
+	return metrics
+}
+
+// retryable tells whether req may be sent again, that is whether its method is safe per RFC 7231, or idempotent and
+// its caller allowed the retries, and its body, if any, can be read again
+func retryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	case http.MethodPut, http.MethodDelete:
+		if allowed, _ := req.Context().Value(retriesKey{}).(bool); !allowed {
+			return false
+		}
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// failed tells whether an attempt failed to reach its destination, or the destination failed to answer in time. The
+// other error statuses are answers of the destination, which are left to the caller.
+func failed(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusGatewayTimeout
+}
+
+// allow tells whether an attempt may be sent to d, counting it
+func (c *Client) allow(d *destination, retry bool) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !d.breaker.allow(c.now()) {
+		d.metrics.Rejected++
+		return false
+	}
+	d.metrics.Requests++
+	if retry {
+		d.metrics.Retries++
+	}
+	d.metrics.State = d.breaker.state
+	return true
+}
+
+// record records the outcome of an attempt sent to d, logging the changes of state of its circuit
+func (c *Client) record(host string, d *destination, failure bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if failure {
+		d.metrics.Failures++
+	}
+	previous, current := d.breaker.record(failure, c.now())
+	d.metrics.State = current
+	if previous == current {
+		return
+	}
+	switch current {
+	case StateOpen:
+		c.lc.Warn(fmt.Sprintf("circuit of %s open for %s after failed requests", host, d.settings.openDuration))
+	case StateClosed:
+		c.lc.Info(fmt.Sprintf("circuit of %s closed", host))
+	}
+}
+
+// Do sends req, again after the failures of a retryable request up to MaxRetries times. It fails without sending
+// req when the circuit of its destination is open.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	d := c.destination(host)
+	attempts := 1
+	if retryable(req) {
+		attempts += d.settings.maxRetries
+	}
+
+	retryWait := d.settings.retryWait
+	for attempt := 1; ; attempt++ {
+		if !c.allow(d, attempt > 1) {
+			return nil, fmt.Errorf("request to %s not sent: %w", host, ErrCircuitOpen)
+		}
+		resp, err := c.send(req, d.settings.timeout, attempt > 1)
+		failure := failed(resp, err)
+		c.record(host, d, failure)
+		if !failure || attempt >= attempts || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		c.lc.Debug(fmt.Sprintf("retrying %s %s in %s, attempt %d failed", req.Method, req.URL.String(), retryWait, attempt))
+		if !c.waitFunc(req.Context(), retryWait) {
+			return nil, req.Context().Err()
+		}
+		retryWait *= 2
+	}
+}
+
+// send sends an attempt of req, with a fresh body for the retries, within timeout when set
+func (c *Client) send(req *http.Request, timeout time.Duration, retry bool) (*http.Response, error) {
+	ctx := req.Context()
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	attempt := req.WithContext(ctx)
+	if retry && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		attempt.Body = body
+	}
+
+	resp, err := c.caller.Do(attempt)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// the timeout still applies to reading the body, until the caller closes it
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the context of a request once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
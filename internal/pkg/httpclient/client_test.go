@@ -0,0 +1,235 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusServer answers the requests with the statuses in turn, the last one repeatedly, recording the bodies received
+func statusServer(t *testing.T, statuses ...int) (*httptest.Server, *[]string) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	return server, &bodies
+}
+
+func newTestClient(t *testing.T, info OutboundHTTPInfo, now *time.Time) *Client {
+	client, err := NewClient(logger.NewMockClient(), info, &http.Client{})
+	require.NoError(t, err)
+	client.now = func() time.Time { return *now }
+	client.waitFunc = func(ctx context.Context, d time.Duration) bool { return true }
+	return client
+}
+
+func hostOf(t *testing.T, server *httptest.Server) string {
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return serverUrl.Host
+}
+
+func TestDoRetries(t *testing.T) {
+	now := time.Unix(1000, 0)
+	info := OutboundHTTPInfo{Default: DestinationInfo{MaxRetries: 2, RetryWait: "10ms"}}
+
+	tests := []struct {
+		name             string
+		method           string
+		statuses         []int
+		expectedStatus   int
+		expectedRequests int
+	}{
+		{"GET retried until success", http.MethodGet, []int{http.StatusBadGateway, http.StatusGatewayTimeout, http.StatusOK}, http.StatusOK, 3},
+		{"GET retried up to MaxRetries", http.MethodGet, []int{http.StatusBadGateway}, http.StatusBadGateway, 3},
+		{"PUT not retried", http.MethodPut, []int{http.StatusBadGateway, http.StatusOK}, http.StatusBadGateway, 1},
+		{"DELETE not retried", http.MethodDelete, []int{http.StatusBadGateway, http.StatusOK}, http.StatusBadGateway, 1},
+		{"POST not retried", http.MethodPost, []int{http.StatusBadGateway, http.StatusOK}, http.StatusBadGateway, 1},
+		{"Answer of the destination not retried", http.MethodGet, []int{http.StatusServiceUnavailable, http.StatusOK}, http.StatusServiceUnavailable, 1},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, bodies := statusServer(t, testCase.statuses...)
+			defer server.Close()
+			client := newTestClient(t, info, &now)
+
+			req, err := http.NewRequest(testCase.method, server.URL, bytes.NewBufferString("payload"))
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, testCase.expectedStatus, resp.StatusCode)
+			require.Len(t, *bodies, testCase.expectedRequests)
+			for _, body := range *bodies {
+				assert.Equal(t, "payload", body)
+			}
+			metrics := client.Metrics()[hostOf(t, server)]
+			assert.Equal(t, uint64(testCase.expectedRequests), metrics.Requests)
+			assert.Equal(t, uint64(testCase.expectedRequests-1), metrics.Retries)
+		})
+	}
+}
+
+func TestDoAllowRetries(t *testing.T) {
+	now := time.Unix(1000, 0)
+	info := OutboundHTTPInfo{Default: DestinationInfo{MaxRetries: 2, RetryWait: "10ms"}}
+
+	tests := []struct {
+		name             string
+		method           string
+		expectedStatus   int
+		expectedRequests int
+	}{
+		{"PUT body sent again", http.MethodPut, http.StatusOK, 2},
+		{"DELETE sent again", http.MethodDelete, http.StatusOK, 2},
+		{"POST still not retried", http.MethodPost, http.StatusBadGateway, 1},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, bodies := statusServer(t, http.StatusBadGateway, http.StatusOK)
+			defer server.Close()
+			client := newTestClient(t, info, &now)
+
+			req, err := http.NewRequest(testCase.method, server.URL, bytes.NewBufferString("payload"))
+			require.NoError(t, err)
+			resp, err := client.Do(req.WithContext(AllowRetries(context.Background())))
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, testCase.expectedStatus, resp.StatusCode)
+			require.Len(t, *bodies, testCase.expectedRequests)
+			for _, body := range *bodies {
+				assert.Equal(t, "payload", body)
+			}
+		})
+	}
+}
+
+func TestDoTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+	now := time.Unix(1000, 0)
+	client := newTestClient(t, OutboundHTTPInfo{
+		Default:      DestinationInfo{Timeout: "10s"},
+		Destinations: map[string]DestinationInfo{hostOf(t, server): {Timeout: "20ms"}},
+	}, &now)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+	begin := time.Now()
+	_, err = client.Do(req)
+
+	require.Error(t, err)
+	assert.Less(t, int64(time.Since(begin)), int64(time.Second), "the timeout of the destination should apply")
+	assert.Equal(t, uint64(1), client.Metrics()[hostOf(t, server)].Failures)
+}
+
+func TestDoCircuitBreaker(t *testing.T) {
+	server, bodies := statusServer(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK)
+	defer server.Close()
+	host := hostOf(t, server)
+	now := time.Unix(1000, 0)
+	client := newTestClient(t, OutboundHTTPInfo{Default: DestinationInfo{FailureThreshold: 2, OpenDuration: "30s"}}, &now)
+
+	get := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	_, err := get()
+	require.NoError(t, err)
+	assert.Equal(t, StateClosed, client.Metrics()[host].State)
+	_, err = get()
+	require.NoError(t, err)
+	assert.Equal(t, StateOpen, client.Metrics()[host].State, "the circuit should open after 2 failures")
+
+	_, err = get()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Len(t, *bodies, 2, "no request should be sent while the circuit is open")
+
+	now = now.Add(30 * time.Second)
+	resp, err := get()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	metrics := client.Metrics()[host]
+	assert.Equal(t, StateClosed, metrics.State, "the successful trial request should close the circuit")
+	assert.Equal(t, uint64(3), metrics.Requests)
+	assert.Equal(t, uint64(2), metrics.Failures)
+	assert.Equal(t, uint64(1), metrics.Rejected)
+}
+
+func TestBreakerHalfOpenFailure(t *testing.T) {
+	now := time.Unix(1000, 0)
+	b := newBreaker(1, time.Minute)
+
+	_, current := b.record(true, now)
+	assert.Equal(t, StateOpen, current)
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow(now), "the trial request should be let through")
+	assert.False(t, b.allow(now), "a single trial request should be let through")
+	_, current = b.record(true, now)
+	assert.Equal(t, StateOpen, current, "the failed trial request should open the circuit again")
+	assert.False(t, b.allow(now.Add(time.Second)))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		info        OutboundHTTPInfo
+		expectError bool
+	}{
+		{"Valid - empty", OutboundHTTPInfo{}, false},
+		{"Valid - all set", OutboundHTTPInfo{Default: DestinationInfo{Timeout: "5s", MaxRetries: 2, RetryWait: "250ms", FailureThreshold: 5, OpenDuration: "30s"}}, false},
+		{"Invalid - timeout", OutboundHTTPInfo{Default: DestinationInfo{Timeout: "five seconds"}}, true},
+		{"Invalid - negative retries", OutboundHTTPInfo{Default: DestinationInfo{MaxRetries: -1}}, true},
+		{"Invalid - threshold without open duration", OutboundHTTPInfo{Default: DestinationInfo{FailureThreshold: 5}}, true},
+		{"Invalid - destination", OutboundHTTPInfo{Destinations: map[string]DestinationInfo{"localhost:49990": {RetryWait: "-1s"}}}, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.info.Validate()
+			if testCase.expectError {
+				assert.Error(t, err)
+				assert.True(t, strings.Contains(err.Error(), "outbound HTTP settings"))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
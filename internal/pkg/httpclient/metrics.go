@@ -0,0 +1,20 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package httpclient
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// ApiOutboundMetricsRoute reports the counters and the circuit state of the destinations of the outbound requests
+const ApiOutboundMetricsRoute = v2.ApiBase + "/outbound/metrics"
+
+// MetricsResponse defines the response content of ApiOutboundMetricsRoute
+type MetricsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Destinations           map[string]DestinationMetrics `json:"destinations"`
+}
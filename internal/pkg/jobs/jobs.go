@@ -0,0 +1,373 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jobs runs the long operations of a service, such as the bulk deletions, in the background and by batches.
+// The requests starting them return at once with the id of a job whose progress can be polled, instead of blocking
+// until the gateways in front of the service time out.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiJobRoute is the base route of the jobs
+	ApiJobRoute = v2.ApiBase + "/job"
+	// ApiAllJobRoute returns the pending, running and latest finished jobs, the latest submitted first
+	ApiAllJobRoute = ApiJobRoute + "/" + v2.All
+	// ApiJobByIdRoute returns a job by id
+	ApiJobByIdRoute = ApiJobRoute + "/" + v2.Id + "/{" + v2.Id + "}"
+)
+
+// The statuses of a Job
+const (
+	StatusPending   = "PENDING"
+	StatusRunning   = "RUNNING"
+	StatusCompleted = "COMPLETED"
+	StatusFailed    = "FAILED"
+)
+
+// The defaults of JobsInfo
+const (
+	DefaultBatchSize  = 500
+	DefaultHistory    = 100
+	DefaultMaxPending = 10
+)
+
+// JobsInfo configures how the jobs of a service are run
+type JobsInfo struct {
+	// BatchSize is the number of items processed by each batch of a job, DefaultBatchSize when 0
+	BatchSize int
+	// BatchInterval is the pause between two batches of a job, e.g. "100ms", which leaves the database to the other
+	// requests meanwhile. The batches follow each other at once when empty.
+	BatchInterval string
+	// History is the number of finished jobs whose status is kept, DefaultHistory when 0
+	History int
+	// MaxPending is the number of jobs waiting for the running one at most, DefaultMaxPending when 0. The jobs
+	// submitted beyond are rejected.
+	MaxPending int
+}
+
+// Validate checks that the sizes aren't negative and that BatchInterval is a duration
+func (info JobsInfo) Validate() error {
+	if info.BatchSize < 0 {
+		return fmt.Errorf("BatchSize %d must not be negative", info.BatchSize)
+	}
+	if info.History < 0 {
+		return fmt.Errorf("History %d must not be negative", info.History)
+	}
+	if info.MaxPending < 0 {
+		return fmt.Errorf("MaxPending %d must not be negative", info.MaxPending)
+	}
+	if _, err := info.interval(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (info JobsInfo) interval() (time.Duration, error) {
+	if info.BatchInterval == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(info.BatchInterval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid BatchInterval %s: %s", info.BatchInterval, err.Error())
+	}
+	if interval < 0 {
+		return 0, fmt.Errorf("BatchInterval %s must not be negative", info.BatchInterval)
+	}
+	return interval, nil
+}
+
+// Job is the status of a job
+type Job struct {
+	Id string `json:"id"`
+	// Type names the operation of the job, e.g. DeleteEventsByTimeRange
+	Type string `json:"type"`
+	// Description details the parameters of the operation
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status"`
+	Created     int64  `json:"created"`
+	Started     int64  `json:"started,omitempty"`
+	Finished    int64  `json:"finished,omitempty"`
+	// Total is the number of items to process estimated when the job was submitted, 0 when unknown
+	Total uint32 `json:"total"`
+	// Processed is the number of items processed so far
+	Processed uint32 `json:"processed"`
+	// Message is the error which failed the job
+	Message string `json:"message,omitempty"`
+}
+
+// JobResponse is the response returning a job
+type JobResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Job                    Job `json:"job"`
+}
+
+// MultiJobsResponse is the response returning several jobs
+type MultiJobsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Jobs                   []Job `json:"jobs"`
+}
+
+// Batch processes the next batch of up to limit items of a job. It returns the number of items processed, and
+// whether the job is done.
+type Batch func(limit int) (processed uint32, done bool, err errors.EdgeX)
+
+// task is a job waiting to run
+type task struct {
+	id    string
+	batch Batch
+}
+
+// Manager runs the jobs submitted one at a time, once started, and keeps their status
+type Manager struct {
+	lc        logger.LoggingClient
+	batchSize int
+	interval  time.Duration
+	history   int
+	queue     chan task
+	mutex     sync.RWMutex
+	jobs      map[string]*Job
+	// ids lists the jobs in the order they were submitted
+	ids []string
+}
+
+// NewManager creates the manager of the jobs configured by info
+func NewManager(info JobsInfo, lc logger.LoggingClient) (*Manager, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	interval, _ := info.interval()
+	m := &Manager{
+		lc:        lc,
+		batchSize: info.BatchSize,
+		interval:  interval,
+		history:   info.History,
+		jobs:      make(map[string]*Job),
+	}
+	if m.batchSize == 0 {
+		m.batchSize = DefaultBatchSize
+	}
+	if m.history == 0 {
+		m.history = DefaultHistory
+	}
+	maxPending := info.MaxPending
+	if maxPending == 0 {
+		maxPending = DefaultMaxPending
+	}
+	m.queue = make(chan task, maxPending)
+	return m, nil
+}
+
+// Start starts the worker running the jobs, which stops when ctx is done. The job running then fails, and the
+// pending ones never run.
+func (m *Manager) Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case t := <-m.queue:
+				m.run(ctx, t)
+			}
+		}
+	}()
+}
+
+// Submit queues a job of the given type running batch until it is done, total being the estimated number of items
+// to process. It fails with KindServiceUnavailable when too many jobs are pending, or when m is nil.
+func (m *Manager) Submit(jobType string, description string, total uint32, batch Batch) (Job, errors.EdgeX) {
+	if m == nil {
+		return Job{}, errors.NewCommonEdgeX(errors.KindServiceUnavailable, "the jobs are not run by the service", nil)
+	}
+	job := &Job{
+		Id:          uuid.New().String(),
+		Type:        jobType,
+		Description: description,
+		Status:      StatusPending,
+		Created:     common.MakeTimestamp(),
+		Total:       total,
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	select {
+	case m.queue <- task{id: job.Id, batch: batch}:
+	default:
+		return Job{}, errors.NewCommonEdgeX(errors.KindServiceUnavailable,
+			fmt.Sprintf("%d jobs are pending already, retry once they are done", cap(m.queue)), nil)
+	}
+	m.jobs[job.Id] = job
+	m.ids = append(m.ids, job.Id)
+	m.lc.Info(fmt.Sprintf("job %s submitted: %s %s", job.Id, jobType, description))
+	return *job, nil
+}
+
+// Job returns the job of the given id
+func (m *Manager) Job(id string) (Job, errors.EdgeX) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("job %s does not exist", id), nil)
+	}
+	return *job, nil
+}
+
+// Jobs returns the pending, running and kept finished jobs, the latest submitted first
+func (m *Manager) Jobs() []Job {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	jobs := make([]Job, 0, len(m.ids))
+	for i := len(m.ids) - 1; i >= 0; i-- {
+		jobs = append(jobs, *m.jobs[m.ids[i]])
+	}
+	return jobs
+}
+
+// run runs the batches of the job t until it is done, fails, or ctx is done
+func (m *Manager) run(ctx context.Context, t task) {
+	m.update(t.id, func(job *Job) {
+		job.Status = StatusRunning
+		job.Started = common.MakeTimestamp()
+	})
+	for {
+		processed, done, err := t.batch(m.batchSize)
+		m.update(t.id, func(job *Job) {
+			job.Processed += processed
+		})
+		if err != nil {
+			m.lc.Error(fmt.Sprintf("job %s failed: %s", t.id, err.Error()))
+			m.finish(t.id, StatusFailed, err.Message())
+			return
+		}
+		if done {
+			m.finish(t.id, StatusCompleted, "")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			m.finish(t.id, StatusFailed, "the service stopped before the job was done")
+			return
+		case <-time.After(m.interval):
+		}
+	}
+}
+
+func (m *Manager) update(id string, apply func(job *Job)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	apply(m.jobs[id])
+}
+
+// finish sets the final status of the job of the given id, and forgets the oldest finished jobs beyond the history
+func (m *Manager) finish(id string, status string, message string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	job := m.jobs[id]
+	job.Status = status
+	job.Message = message
+	job.Finished = common.MakeTimestamp()
+	m.lc.Info(fmt.Sprintf("job %s %s after processing %d items", id, status, job.Processed))
+
+	finished := 0
+	for _, id := range m.ids {
+		if m.jobs[id].Finished > 0 {
+			finished++
+		}
+	}
+	ids := m.ids[:0]
+	for _, id := range m.ids {
+		if finished > m.history && m.jobs[id].Finished > 0 {
+			delete(m.jobs, id)
+			finished--
+			continue
+		}
+		ids = append(ids, id)
+	}
+	m.ids = ids
+}
+
+// AllJobs handles the request returning the pending, running and kept finished jobs
+func (m *Manager) AllJobs(w http.ResponseWriter, r *http.Request) {
+	response := MultiJobsResponse{
+		BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+		Jobs:         m.Jobs(),
+	}
+	utils.WriteHttpHeader(w, r.Context(), http.StatusOK)
+	pkg.Encode(response, w, m.lc)
+}
+
+// JobById handles the request returning the job whose id is in the path
+func (m *Manager) JobById(w http.ResponseWriter, r *http.Request) {
+	job, err := m.Job(mux.Vars(r)[v2.Id])
+	if err != nil {
+		correlationId := correlation.FromContext(r.Context())
+		m.lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		utils.WriteHttpHeader(w, r.Context(), err.Code())
+		pkg.Encode(commonDTO.NewBaseResponse("", err.Message(), err.Code()), w, m.lc)
+		return
+	}
+	response := JobResponse{
+		BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+		Job:          job,
+	}
+	utils.WriteHttpHeader(w, r.Context(), http.StatusOK)
+	pkg.Encode(response, w, m.lc)
+}
+
+// WriteSubmitted writes the response of a request submitting job, 202 (Accepted) with the job id and its route in the
+// Location header, or the error which prevented its submission
+func WriteSubmitted(w http.ResponseWriter, r *http.Request, lc logger.LoggingClient, job Job, err errors.EdgeX) {
+	ctx := r.Context()
+	if err != nil {
+		correlationId := correlation.FromContext(ctx)
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		utils.WriteHttpHeader(w, ctx, err.Code())
+		pkg.Encode(commonDTO.NewBaseResponse("", err.Message(), err.Code()), w, lc)
+		return
+	}
+	w.Header().Set("Location", strings.Replace(ApiJobByIdRoute, "{"+v2.Id+"}", job.Id, 1))
+	utils.WriteHttpHeader(w, ctx, http.StatusAccepted)
+	pkg.Encode(commonDTO.NewBaseWithIdResponse("", "", http.StatusAccepted, job.Id), w, lc)
+}
+
+// LoadRestRoutes adds the routes returning the jobs of manager to router, none when manager is nil
+func LoadRestRoutes(router *mux.Router, manager *Manager) {
+	if manager == nil {
+		return
+	}
+	router.HandleFunc(ApiAllJobRoute, manager.AllJobs).Methods(http.MethodGet)
+	router.HandleFunc(ApiJobByIdRoute, manager.JobById).Methods(http.MethodGet)
+}
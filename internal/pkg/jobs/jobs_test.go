@@ -0,0 +1,221 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startManager(t *testing.T, info JobsInfo) (*Manager, func()) {
+	manager, err := NewManager(info, logger.NewMockClient())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	manager.Start(ctx, &wg)
+	return manager, func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// countdown returns a Batch processing items items, recording the limits it is called with
+func countdown(items uint32, limits *[]int) Batch {
+	return func(limit int) (uint32, bool, errors.EdgeX) {
+		*limits = append(*limits, limit)
+		processed := uint32(limit)
+		if processed > items {
+			processed = items
+		}
+		items -= processed
+		return processed, items == 0, nil
+	}
+}
+
+func waitFor(t *testing.T, manager *Manager, id string, status string) Job {
+	var job Job
+	require.Eventually(t, func() bool {
+		var err errors.EdgeX
+		job, err = manager.Job(id)
+		require.NoError(t, err)
+		return job.Status == status
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestJobsInfoValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		info  JobsInfo
+		valid bool
+	}{
+		{"Valid - defaults", JobsInfo{}, true},
+		{"Valid", JobsInfo{BatchSize: 100, BatchInterval: "50ms", History: 10, MaxPending: 2}, true},
+		{"Invalid - batch size", JobsInfo{BatchSize: -1}, false},
+		{"Invalid - history", JobsInfo{History: -1}, false},
+		{"Invalid - max pending", JobsInfo{MaxPending: -1}, false},
+		{"Invalid - batch interval", JobsInfo{BatchInterval: "fast"}, false},
+		{"Invalid - negative batch interval", JobsInfo{BatchInterval: "-1s"}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.info.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestManager(t *testing.T) {
+	manager, stop := startManager(t, JobsInfo{BatchSize: 4})
+	defer stop()
+
+	var limits []int
+	job, err := manager.Submit("Count", "down from 10", 10, countdown(10, &limits))
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, job.Status)
+	assert.NotEmpty(t, job.Id)
+
+	job = waitFor(t, manager, job.Id, StatusCompleted)
+	assert.Equal(t, uint32(10), job.Processed)
+	assert.Equal(t, uint32(10), job.Total)
+	assert.NotZero(t, job.Started)
+	assert.NotZero(t, job.Finished)
+	assert.Equal(t, []int{4, 4, 4}, limits, "the job should be processed by batches of 4")
+}
+
+func TestManager_Failed(t *testing.T) {
+	manager, stop := startManager(t, JobsInfo{})
+	defer stop()
+
+	batches := 0
+	job, err := manager.Submit("Fail", "", 0, func(limit int) (uint32, bool, errors.EdgeX) {
+		batches++
+		if batches == 2 {
+			return 1, false, errors.NewCommonEdgeX(errors.KindDatabaseError, "database unreachable", nil)
+		}
+		return 2, false, nil
+	})
+	require.NoError(t, err)
+
+	job = waitFor(t, manager, job.Id, StatusFailed)
+	assert.Equal(t, uint32(3), job.Processed, "the items processed before the failure should be counted")
+	assert.Equal(t, "database unreachable", job.Message)
+}
+
+func TestManager_Stopped(t *testing.T) {
+	manager, stop := startManager(t, JobsInfo{BatchInterval: "1h"})
+
+	job, err := manager.Submit("Endless", "", 0, func(limit int) (uint32, bool, errors.EdgeX) {
+		return 1, false, nil
+	})
+	require.NoError(t, err)
+	waitFor(t, manager, job.Id, StatusRunning)
+	stop()
+
+	job, err = manager.Job(job.Id)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, job.Status)
+}
+
+func TestManager_TooManyPending(t *testing.T) {
+	// the manager isn't started, so the jobs stay pending
+	manager, err := NewManager(JobsInfo{MaxPending: 2}, logger.NewMockClient())
+	require.NoError(t, err)
+
+	var limits []int
+	for i := 0; i < 2; i++ {
+		_, err := manager.Submit("Count", "", 1, countdown(1, &limits))
+		require.NoError(t, err)
+	}
+	_, edgexErr := manager.Submit("Count", "", 1, countdown(1, &limits))
+	require.Error(t, edgexErr)
+	assert.Equal(t, errors.KindServiceUnavailable, errors.Kind(edgexErr))
+	assert.Len(t, manager.Jobs(), 2, "the rejected job should not be kept")
+}
+
+func TestManager_Nil(t *testing.T) {
+	var manager *Manager
+	var limits []int
+	_, err := manager.Submit("Count", "", 1, countdown(1, &limits))
+	require.Error(t, err)
+	assert.Equal(t, errors.KindServiceUnavailable, errors.Kind(err))
+}
+
+func TestManager_History(t *testing.T) {
+	manager, stop := startManager(t, JobsInfo{History: 2})
+	defer stop()
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		var limits []int
+		job, err := manager.Submit("Count", "", 1, countdown(1, &limits))
+		require.NoError(t, err)
+		waitFor(t, manager, job.Id, StatusCompleted)
+		ids = append(ids, job.Id)
+	}
+
+	jobs := manager.Jobs()
+	require.Len(t, jobs, 2, "only the 2 latest finished jobs should be kept")
+	assert.Equal(t, ids[3], jobs[0].Id, "the latest submitted job should be first")
+	assert.Equal(t, ids[2], jobs[1].Id)
+	_, err := manager.Job(ids[0])
+	assert.Equal(t, errors.KindEntityDoesNotExist, errors.Kind(err))
+}
+
+func TestLoadRestRoutes(t *testing.T) {
+	manager, stop := startManager(t, JobsInfo{})
+	defer stop()
+	var limits []int
+	job, err := manager.Submit("Count", "", 3, countdown(3, &limits))
+	require.NoError(t, err)
+	waitFor(t, manager, job.Id, StatusCompleted)
+
+	router := mux.NewRouter()
+	LoadRestRoutes(router, manager)
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{"Valid - all jobs", ApiAllJobRoute, http.StatusOK},
+		{"Valid - job by id", strings.Replace(ApiJobByIdRoute, "{id}", job.Id, 1), http.StatusOK},
+		{"Invalid - unknown job", strings.Replace(ApiJobByIdRoute, "{id}", "unknown", 1), http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, testCase.path, http.NoBody)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			assert.Equal(t, testCase.expectedStatus, recorder.Code, recorder.Body.String())
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, strings.Replace(ApiJobByIdRoute, "{id}", job.Id, 1), http.NoBody)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	var response JobResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, StatusCompleted, response.Job.Status)
+	assert.Equal(t, uint32(3), response.Job.Processed)
+}
@@ -0,0 +1,118 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package jwtauth
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// anySubPath is the pattern suffix matching any number of trailing path segments
+const anySubPath = "/**"
+
+// PolicyInfo grants the requests matching Methods and Path to the tokens carrying one of Roles
+type PolicyInfo struct {
+	// Methods are the HTTP methods the policy applies to. Empty means all methods.
+	Methods []string
+	// Path is matched with path.Match, so '*' matches a single segment; a trailing '/**' matches any sub-path.
+	Path string
+	// Roles that are granted access
+	Roles []string
+}
+
+func (p PolicyInfo) matches(method string, urlPath string) bool {
+	if len(p.Methods) > 0 {
+		found := false
+		for _, m := range p.Methods {
+			if strings.EqualFold(m, method) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if strings.HasSuffix(p.Path, anySubPath) {
+		prefix := strings.TrimSuffix(p.Path, anySubPath)
+		return urlPath == prefix || strings.HasPrefix(urlPath, prefix+"/")
+	}
+	matched, _ := path.Match(p.Path, urlPath)
+	return matched
+}
+
+func (p PolicyInfo) grants(roles []string) bool {
+	for _, granted := range p.Roles {
+		for _, role := range roles {
+			if role == granted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewAuthorizationMiddleware returns a middleware that responds 403 (Forbidden) when the first policy matching
+// the request does not grant any of the roles in the verified token. It must be used after the middleware
+// returned by NewMiddleware, which attaches the token claims to the request context.
+func NewAuthorizationMiddleware(lc logger.LoggingClient, policies []PolicyInfo, exemptPaths []string) (func(http.Handler) http.Handler, error) {
+	for _, policy := range policies {
+		if _, err := path.Match(strings.TrimSuffix(policy.Path, anySubPath), ""); err != nil {
+			return nil, fmt.Errorf("invalid JWTAuth policy path '%s': %s", policy.Path, err.Error())
+		}
+		if len(policy.Roles) == 0 {
+			return nil, fmt.Errorf("JWTAuth policy for '%s' grants no roles", policy.Path)
+		}
+	}
+
+	exempt := make(map[string]bool, len(exemptPaths))
+	for _, p := range exemptPaths {
+		exempt[p] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if exempt[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for _, policy := range policies {
+				if !policy.matches(r.Method, r.URL.Path) {
+					continue
+				}
+
+				claims := ClaimsFromContext(r.Context())
+				if claims == nil || !policy.grants(claims.Roles) {
+					actor, roles := "", []string(nil)
+					if claims != nil {
+						actor, roles = claims.Actor(), claims.Roles
+					}
+					lc.Warn(fmt.Sprintf("denying %s %s to '%s' with roles %v: policy for '%s' requires one of %v",
+						r.Method, r.URL.Path, actor, roles, policy.Path, policy.Roles))
+					http.Error(w, "Forbidden", http.StatusForbidden)
+					return
+				}
+				break
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
@@ -0,0 +1,79 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package jwtauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizationMiddleware(t *testing.T) {
+	policies := []PolicyInfo{
+		{Methods: []string{http.MethodPost}, Path: "/api/v2/event/*/*", Roles: []string{"device"}},
+		{Methods: []string{http.MethodDelete}, Path: "/api/v2/**", Roles: []string{"admin"}},
+	}
+	middleware, err := NewAuthorizationMiddleware(logger.MockLogger{}, policies, []string{"/api/v2/ping"})
+	require.NoError(t, err)
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		roles          []string
+		noClaims       bool
+		expectedStatus int
+	}{
+		{"Device posts event", http.MethodPost, "/api/v2/event/profile/device", []string{"device"}, false, http.StatusOK},
+		{"Reader posts event", http.MethodPost, "/api/v2/event/profile/device", []string{"reader"}, false, http.StatusForbidden},
+		{"Admin deletes event", http.MethodDelete, "/api/v2/event/id/1234", []string{"admin", "device"}, false, http.StatusOK},
+		{"Device deletes event", http.MethodDelete, "/api/v2/event/id/1234", []string{"device"}, false, http.StatusForbidden},
+		{"No matching policy", http.MethodGet, "/api/v2/event/all", nil, false, http.StatusOK},
+		{"No claims", http.MethodDelete, "/api/v2/event/id/1234", nil, true, http.StatusForbidden},
+		{"Exempt path", http.MethodDelete, "/api/v2/ping", nil, true, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if !tt.noClaims {
+				claims := &Claims{Roles: tt.roles}
+				req = req.WithContext(context.WithValue(req.Context(), contextKey{}, claims))
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestAuthorizationMiddlewareInvalidPolicy(t *testing.T) {
+	_, err := NewAuthorizationMiddleware(logger.MockLogger{}, []PolicyInfo{{Path: "/api/v2/[", Roles: []string{"admin"}}}, nil)
+	assert.Error(t, err)
+
+	_, err = NewAuthorizationMiddleware(logger.MockLogger{}, []PolicyInfo{{Path: "/api/v2/**"}}, nil)
+	assert.Error(t, err)
+}
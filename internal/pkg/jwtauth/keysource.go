@@ -0,0 +1,197 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package jwtauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	KongKeySource  = "kong"
+	VaultKeySource = "vault"
+	FileKeySource  = "file"
+
+	kongJWTsPath = "/jwts"
+	// minRefetchInterval stops a flood of tokens with unknown issuers from hammering the key source
+	minRefetchInterval = 10 * time.Second
+)
+
+// KeySource returns the PEM-encoded public keys that can verify gateway tokens, indexed by the token issuer
+// (the "id" given to the proxy adduser command).
+type KeySource interface {
+	FetchKeys() (map[string]string, error)
+}
+
+// kongKeySource reads the consumers' JWT credentials from the Kong admin API, the same keys the gateway uses.
+type kongKeySource struct {
+	client   internal.HttpCaller
+	adminURL string
+}
+
+// NewKongKeySource creates a KeySource backed by the Kong admin API at adminURL
+func NewKongKeySource(client internal.HttpCaller, adminURL string) KeySource {
+	return &kongKeySource{client: client, adminURL: strings.TrimSuffix(adminURL, "/")}
+}
+
+type kongJWTCredentials struct {
+	Data []struct {
+		Key          string `json:"key"`
+		RSAPublicKey string `json:"rsa_public_key"`
+	} `json:"data"`
+	Next string `json:"next"`
+}
+
+func (k *kongKeySource) FetchKeys() (map[string]string, error) {
+	keys := make(map[string]string)
+	next := kongJWTsPath
+	for next != "" {
+		req, err := http.NewRequest(http.MethodGet, k.adminURL+next, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := k.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var page kongJWTCredentials
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to list kong JWT credentials: status %d", resp.StatusCode)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode kong JWT credentials: %s", err.Error())
+		}
+
+		for _, credential := range page.Data {
+			keys[credential.Key] = credential.RSAPublicKey
+		}
+		next = page.Next
+	}
+	return keys, nil
+}
+
+// vaultKeySource reads the keys from a secret in the service's secret store, one entry per issuer.
+type vaultKeySource struct {
+	secretProvider interfaces.SecretProvider
+	path           string
+}
+
+// NewVaultKeySource creates a KeySource backed by the secret at path
+func NewVaultKeySource(secretProvider interfaces.SecretProvider, path string) KeySource {
+	return &vaultKeySource{secretProvider: secretProvider, path: path}
+}
+
+func (v *vaultKeySource) FetchKeys() (map[string]string, error) {
+	return v.secretProvider.GetSecrets(v.path)
+}
+
+// fileKeySource reads the keys from the <issuer>.pem files of a directory, for the deployments without the API
+// gateway nor a secret store, e.g. those made by 'edgex quickstart'
+type fileKeySource struct {
+	directory string
+}
+
+// NewFileKeySource creates a KeySource backed by the .pem files of directory
+func NewFileKeySource(directory string) KeySource {
+	return &fileKeySource{directory: directory}
+}
+
+func (f *fileKeySource) FetchKeys() (map[string]string, error) {
+	paths, err := filepath.Glob(filepath.Join(f.directory, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]string, len(paths))
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		keys[strings.TrimSuffix(filepath.Base(path), ".pem")] = string(content)
+	}
+	return keys, nil
+}
+
+// keyCache holds the parsed public keys and refreshes them from the KeySource
+type keyCache struct {
+	source          KeySource
+	refreshInterval time.Duration
+
+	mutex     sync.Mutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+func newKeyCache(source KeySource, refreshInterval time.Duration) *keyCache {
+	return &keyCache{source: source, refreshInterval: refreshInterval}
+}
+
+// key returns the public key for issuer, refreshing the cache when it is stale or, at most once every
+// minRefetchInterval, when the issuer is unknown (e.g. a user was added since the last refresh).
+func (c *keyCache) key(issuer string) (interface{}, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key, found := c.keys[issuer]
+	age := time.Since(c.fetchedAt)
+	if c.keys == nil || age > c.refreshInterval || (!found && age > minRefetchInterval) {
+		if err := c.refresh(); err != nil {
+			// Keep using the previous keys, if any, while the key source is unavailable
+			if c.keys == nil {
+				return nil, err
+			}
+		}
+		key, found = c.keys[issuer]
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no key for issuer '%s'", issuer)
+	}
+	return key, nil
+}
+
+func (c *keyCache) refresh() error {
+	c.fetchedAt = time.Now()
+	pems, err := c.source.FetchKeys()
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]interface{}, len(pems))
+	for issuer, pem := range pems {
+		if key, err := jwt.ParseRSAPublicKeyFromPEM([]byte(pem)); err == nil {
+			keys[issuer] = key
+		} else if key, err := jwt.ParseECPublicKeyFromPEM([]byte(pem)); err == nil {
+			keys[issuer] = key
+		}
+	}
+	c.keys = keys
+	return nil
+}
@@ -0,0 +1,258 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package jwtauth lets a service verify the JWT issued for the API gateway itself, so that requests sent
+// directly to the service port, bypassing the gateway, are authenticated too.
+package jwtauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/mux"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+// JWTAuthInfo configures in-service verification of gateway issued JWTs
+type JWTAuthInfo struct {
+	// Enabled turns on JWT verification for every route not listed in ExemptPaths
+	Enabled bool
+	// KeySource is where the verification keys are read from: "kong", "vault" or "file"
+	KeySource string
+	// KongAdminURL is the Kong admin API used when KeySource is "kong"
+	KongAdminURL string
+	// SecretPath is the secret holding one PEM-encoded public key per issuer when KeySource is "vault"
+	SecretPath string
+	// KeysDirectory holds one PEM-encoded public key per issuer, named <issuer>.pem, when KeySource is "file"
+	KeysDirectory string
+	// RefreshInterval is how often the keys are re-read from the key source, e.g. "5m"
+	RefreshInterval string
+	// ExemptPaths are served without a token, e.g. the ping route used by the registry health check
+	ExemptPaths []string
+	// Policies restrict routes to the tokens carrying one of the listed roles. Routes that match no policy
+	// are allowed for any valid token.
+	Policies []PolicyInfo
+	// Revocation rejects the revoked tokens
+	Revocation RevocationInfo
+}
+
+// Claims are the JWT claims understood by the services
+type Claims struct {
+	jwt.StandardClaims
+	// Roles are checked against the Policies of the service being called
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Actor identifies who the token was issued to: the subject when set, otherwise the issuer, which is the
+// id of the gateway user
+func (c *Claims) Actor() string {
+	if c.Subject != "" {
+		return c.Subject
+	}
+	return c.Issuer
+}
+
+type contextKey struct{}
+
+// ClaimsFromContext returns the claims of the verified token attached to ctx, or nil if there is none
+func ClaimsFromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(contextKey{}).(*Claims)
+	return claims
+}
+
+// NewMiddleware returns a middleware that responds 401 (Unauthorized) to requests that do not carry a valid
+// RS256 or ES256 JWT, signed by the key registered for the token's issuer.
+func NewMiddleware(lc logger.LoggingClient, info JWTAuthInfo, source KeySource) (func(http.Handler) http.Handler, error) {
+	refreshInterval, err := time.ParseDuration(info.RefreshInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWTAuth.RefreshInterval '%s': %s", info.RefreshInterval, err.Error())
+	}
+	return NewVerifierMiddleware(lc, info, NewVerifier(source, refreshInterval)), nil
+}
+
+// NewVerifierMiddleware returns the middleware of NewMiddleware, verifying the tokens with verifier
+func NewVerifierMiddleware(lc logger.LoggingClient, info JWTAuthInfo, verifier *Verifier) func(http.Handler) http.Handler {
+	exempt := make(map[string]bool, len(info.ExemptPaths))
+	for _, path := range info.ExemptPaths {
+		exempt[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if exempt[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := verifier.verify(r.Header.Get(authorizationHeader))
+			if err != nil {
+				lc.Warn(fmt.Sprintf("rejecting %s %s from %s: %s", r.Method, r.URL.Path, r.RemoteAddr, err.Error()))
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, claims)))
+		})
+	}
+}
+
+// Verifier verifies the RS256 and ES256 JWTs with the key registered for their issuer
+type Verifier struct {
+	cache       *keyCache
+	revocations *revocationCache
+}
+
+// NewVerifier creates a Verifier of the keys of source, re-read every refreshInterval
+func NewVerifier(source KeySource, refreshInterval time.Duration) *Verifier {
+	return &Verifier{cache: newKeyCache(source, refreshInterval)}
+}
+
+// UseRevocations rejects the tokens revoked in store, whose revocation list is re-read every refreshInterval
+func (v *Verifier) UseRevocations(store RevocationStore, refreshInterval time.Duration) {
+	v.revocations = newRevocationCache(store, refreshInterval)
+}
+
+// Verify returns the claims of token once its signature and validity are verified
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	return v.verify(bearerPrefix + token)
+}
+
+func (v *Verifier) verify(authorization string) (*Claims, error) {
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return nil, fmt.Errorf("missing bearer token")
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(authorization, bearerPrefix), claims,
+		func(token *jwt.Token) (interface{}, error) {
+			switch token.Method.(type) {
+			case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			default:
+				return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+			}
+			return v.cache.key(token.Claims.(*Claims).Issuer)
+		})
+	if err != nil {
+		return nil, err
+	}
+	if v.revocations != nil {
+		if err = v.revocations.check(claims); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+// UseMiddleware adds the JWT verification middleware to router when info.Enabled is set
+func UseMiddleware(router *mux.Router, dic *di.Container, info JWTAuthInfo) error {
+	if !info.Enabled {
+		return nil
+	}
+
+	lc := container.LoggingClientFrom(dic.Get)
+
+	source, err := NewConfiguredKeySource(dic, info)
+	if err != nil {
+		return err
+	}
+
+	refreshInterval, err := time.ParseDuration(info.RefreshInterval)
+	if err != nil {
+		return fmt.Errorf("invalid JWTAuth.RefreshInterval '%s': %s", info.RefreshInterval, err.Error())
+	}
+	verifier := NewVerifier(source, refreshInterval)
+
+	if info.Revocation.Enabled {
+		revocationInterval, err := time.ParseDuration(info.Revocation.RefreshInterval)
+		if err != nil {
+			return fmt.Errorf("invalid JWTAuth.Revocation.RefreshInterval '%s': %s",
+				info.Revocation.RefreshInterval, err.Error())
+		}
+		store, err := NewConfiguredRevocationStore(dic, info.Revocation)
+		if err != nil {
+			return err
+		}
+		verifier.UseRevocations(store, revocationInterval)
+		if err = LoadRevocationRoutes(router, lc, verifier); err != nil {
+			return err
+		}
+		lc.Info("JWT revocation enabled")
+	}
+
+	router.Use(NewVerifierMiddleware(lc, info, verifier))
+	lc.Info(fmt.Sprintf("JWT verification enabled using keys from %s", info.KeySource))
+
+	if len(info.Policies) > 0 {
+		authorization, err := NewAuthorizationMiddleware(lc, info.Policies, info.ExemptPaths)
+		if err != nil {
+			return err
+		}
+		router.Use(authorization)
+		lc.Info(fmt.Sprintf("role based authorization enabled with %d policies", len(info.Policies)))
+	}
+	return nil
+}
+
+// NewConfiguredRevocationStore creates the Redis RevocationStore of info, with the password of the secret at
+// info.CredentialsPath when set
+func NewConfiguredRevocationStore(dic *di.Container, info RevocationInfo) (RevocationStore, error) {
+	var password string
+	if info.CredentialsPath != "" {
+		secretProvider, ok := dic.Get(container.SecretProviderName).(interfaces.SecretProvider)
+		if !ok {
+			return nil, fmt.Errorf("JWTAuth.Revocation.CredentialsPath '%s' requires a secret provider", info.CredentialsPath)
+		}
+		secrets, err := secretProvider.GetSecrets(info.CredentialsPath, "password")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the JWTAuth.Revocation credentials: %s", err.Error())
+		}
+		password = secrets["password"]
+	}
+	return NewRedisRevocationStore(info, password), nil
+}
+
+// NewConfiguredKeySource creates the KeySource of info.KeySource, whether the verification of the requests is enabled
+// or not
+func NewConfiguredKeySource(dic *di.Container, info JWTAuthInfo) (KeySource, error) {
+	switch info.KeySource {
+	case KongKeySource:
+		return NewKongKeySource(&http.Client{Timeout: 10 * time.Second}, info.KongAdminURL), nil
+	case VaultKeySource:
+		secretProvider, ok := dic.Get(container.SecretProviderName).(interfaces.SecretProvider)
+		if !ok {
+			return nil, fmt.Errorf("JWTAuth.KeySource '%s' requires a secret provider", VaultKeySource)
+		}
+		return NewVaultKeySource(secretProvider, info.SecretPath), nil
+	case FileKeySource:
+		if info.KeysDirectory == "" {
+			return nil, fmt.Errorf("JWTAuth.KeySource '%s' requires a KeysDirectory", FileKeySource)
+		}
+		return NewFileKeySource(info.KeysDirectory), nil
+	default:
+		return nil, fmt.Errorf("unsupported JWTAuth.KeySource '%s'", info.KeySource)
+	}
+}
@@ -0,0 +1,150 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package jwtauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIssuer = "edgex-user"
+
+type staticKeySource map[string]string
+
+func (s staticKeySource) FetchKeys() (map[string]string, error) {
+	return s, nil
+}
+
+func newTestKey(t *testing.T) (*rsa.PrivateKey, string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	return privateKey, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, issuer string, expiresIn time.Duration) string {
+	now := time.Now().Unix()
+	token := jwt.NewWithClaims(method, &jwt.StandardClaims{
+		Issuer:    issuer,
+		IssuedAt:  now,
+		NotBefore: now,
+		ExpiresAt: now + int64(expiresIn.Seconds()),
+	})
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestMiddleware(t *testing.T) {
+	privateKey, publicPEM := newTestKey(t)
+	otherKey, _ := newTestKey(t)
+	info := JWTAuthInfo{RefreshInterval: "5m", ExemptPaths: []string{"/api/v2/ping"}}
+	middleware, err := NewMiddleware(logger.MockLogger{}, info, staticKeySource{testIssuer: publicPEM})
+	require.NoError(t, err)
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		path           string
+		authorization  string
+		expectedStatus int
+	}{
+		{"Valid token", "/api/v2/event", "Bearer " + signToken(t, jwt.SigningMethodRS256, privateKey, testIssuer, time.Hour), http.StatusOK},
+		{"Exempt path", "/api/v2/ping", "", http.StatusOK},
+		{"Missing token", "/api/v2/event", "", http.StatusUnauthorized},
+		{"Expired token", "/api/v2/event", "Bearer " + signToken(t, jwt.SigningMethodRS256, privateKey, testIssuer, -time.Hour), http.StatusUnauthorized},
+		{"Unknown issuer", "/api/v2/event", "Bearer " + signToken(t, jwt.SigningMethodRS256, privateKey, "someone", time.Hour), http.StatusUnauthorized},
+		{"Wrong key", "/api/v2/event", "Bearer " + signToken(t, jwt.SigningMethodRS256, otherKey, testIssuer, time.Hour), http.StatusUnauthorized},
+		{"HMAC token", "/api/v2/event", "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(publicPEM), testIssuer, time.Hour), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set(authorizationHeader, tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	privateKey, publicPEM := newTestKey(t)
+	verifier := NewVerifier(staticKeySource{testIssuer: publicPEM}, 5*time.Minute)
+
+	claims, err := verifier.Verify(signToken(t, jwt.SigningMethodRS256, privateKey, testIssuer, time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, testIssuer, claims.Actor())
+	_, err = verifier.Verify(signToken(t, jwt.SigningMethodRS256, privateKey, testIssuer, -time.Hour))
+	assert.Error(t, err)
+	_, err = verifier.Verify("")
+	assert.Error(t, err)
+}
+
+func TestNewMiddlewareInvalidRefreshInterval(t *testing.T) {
+	_, err := NewMiddleware(logger.MockLogger{}, JWTAuthInfo{RefreshInterval: "often"}, staticKeySource{})
+	assert.Error(t, err)
+}
+
+func TestKongKeySource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, kongJWTsPath, r.URL.Path)
+		if r.URL.Query().Get("offset") == "" {
+			_, _ = fmt.Fprint(w, `{"data":[{"key":"user1","rsa_public_key":"pem1"}],"next":"/jwts?offset=abc"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"data":[{"key":"user2","rsa_public_key":"pem2"}],"next":null}`)
+	}))
+	defer ts.Close()
+
+	keys, err := NewKongKeySource(ts.Client(), ts.URL+"/").FetchKeys()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user1": "pem1", "user2": "pem2"}, keys)
+}
+
+func TestFileKeySource(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "user1.pem"), []byte("pem1"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a key"), 0644))
+
+	keys, err := NewFileKeySource(dir).FetchKeys()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user1": "pem1"}, keys)
+}
@@ -0,0 +1,87 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package jwtauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// revocationsKey is the hash of the revocations by their Key, shared by the services
+const revocationsKey = "jwt|revocation"
+
+// redisRevocationStore keeps the revocation list in Redis
+type redisRevocationStore struct {
+	pool *redis.Pool
+}
+
+// NewRedisRevocationStore creates a RevocationStore backed by the Redis of info, authenticated with password unless
+// it is empty
+func NewRedisRevocationStore(info RevocationInfo, password string) RevocationStore {
+	address := fmt.Sprintf("%s:%d", info.Host, info.Port)
+	opts := []redis.DialOption{
+		redis.DialConnectTimeout(time.Duration(info.Timeout) * time.Millisecond),
+	}
+	if password != "" {
+		opts = append(opts, redis.DialPassword(password))
+	}
+	return &redisRevocationStore{
+		pool: &redis.Pool{
+			MaxIdle: 2,
+			Dial: func() (redis.Conn, error) {
+				return redis.Dial("tcp", address, opts...)
+			},
+		},
+	}
+}
+
+func (s *redisRevocationStore) Revoke(revocation Revocation) error {
+	value, err := json.Marshal(revocation)
+	if err != nil {
+		return err
+	}
+	conn := s.pool.Get()
+	defer conn.Close()
+	_, err = conn.Do("HSET", revocationsKey, revocation.Key(), value)
+	return err
+}
+
+// Revocations returns the revocations, removing the expired ones from the list
+func (s *redisRevocationStore) Revocations() ([]Revocation, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+	values, err := redis.StringMap(conn.Do("HGETALL", revocationsKey))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	var revocations []Revocation
+	for key, value := range values {
+		var revocation Revocation
+		if err = json.Unmarshal([]byte(value), &revocation); err != nil {
+			return nil, fmt.Errorf("failed to decode the revocation %s: %s", key, err.Error())
+		}
+		if revocation.Expired(now) {
+			_, _ = conn.Do("HDEL", revocationsKey, key)
+			continue
+		}
+		revocations = append(revocations, revocation)
+	}
+	return revocations, nil
+}
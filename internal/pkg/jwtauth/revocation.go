@@ -0,0 +1,241 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package jwtauth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiTokenIntrospectRoute tells whether the token posted as the "token" form value is active, as per RFC 7662
+	ApiTokenIntrospectRoute = v2.ApiBase + "/token/introspect"
+	// ApiTokenRevokeRoute revokes the token posted as the "token" form value, as per RFC 7009, or all the tokens
+	// issued so far to the "issuer" form value
+	ApiTokenRevokeRoute = v2.ApiBase + "/token/revoke"
+
+	tokenForm  = "token"
+	issuerForm = "issuer"
+)
+
+// RevocationInfo configures the revocation list of the tokens, shared by the services through Redis
+type RevocationInfo struct {
+	// Enabled rejects the revoked tokens and serves ApiTokenIntrospectRoute and ApiTokenRevokeRoute
+	Enabled bool
+	Host    string
+	Port    int
+	// Timeout is the timeout of the connections to Redis, in milliseconds
+	Timeout int
+	// CredentialsPath is the secret holding the password of Redis, e.g. "redisdb" as for the database
+	CredentialsPath string
+	// RefreshInterval is how often the list is re-read, e.g. "10s", which bounds how long a token revoked through
+	// another service is still accepted
+	RefreshInterval string
+}
+
+// Revocation revokes either the token whose id is ID until it Expires, or all the tokens issued to Issuer up to
+// IssuedBefore. The times are in seconds, as the claims.
+type Revocation struct {
+	ID           string `json:"id,omitempty"`
+	Issuer       string `json:"issuer,omitempty"`
+	IssuedBefore int64  `json:"issuedBefore,omitempty"`
+	// Expires is when the revoked token expires, and the revocation with it. It never does when zero.
+	Expires int64 `json:"expires,omitempty"`
+	// Revoked is when the revocation was made, and RevokedBy the actor of the token it was requested with
+	Revoked   int64  `json:"revoked"`
+	RevokedBy string `json:"revokedBy,omitempty"`
+}
+
+// Key returns the key of the revocation in the list, a later revocation of the same token or issuer replacing it
+func (r Revocation) Key() string {
+	if r.ID != "" {
+		return "jti:" + r.ID
+	}
+	return "iss:" + r.Issuer
+}
+
+// Expired tells whether the revoked token has expired at the time now, in seconds
+func (r Revocation) Expired(now int64) bool {
+	return r.Expires != 0 && r.Expires < now
+}
+
+// RevocationStore holds the revocation list
+type RevocationStore interface {
+	// Revoke adds the revocation to the list
+	Revoke(revocation Revocation) error
+	// Revocations returns the list, without the expired revocations
+	Revocations() ([]Revocation, error)
+}
+
+// revocationCache holds the revocation list and refreshes it from the RevocationStore
+type revocationCache struct {
+	store           RevocationStore
+	refreshInterval time.Duration
+
+	mutex     sync.Mutex
+	tokens    map[string]bool
+	issuers   map[string]int64
+	fetchedAt time.Time
+}
+
+func newRevocationCache(store RevocationStore, refreshInterval time.Duration) *revocationCache {
+	return &revocationCache{store: store, refreshInterval: refreshInterval}
+}
+
+// check returns an error when the token of claims is revoked. The previous list is used while the store is
+// unavailable, but the tokens are rejected as long as it has never been read.
+func (c *revocationCache) check(claims *Claims) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.tokens == nil || time.Since(c.fetchedAt) > c.refreshInterval {
+		if err := c.refresh(); err != nil && c.tokens == nil {
+			return fmt.Errorf("revocation list unavailable: %s", err.Error())
+		}
+	}
+	if claims.Id != "" && c.tokens[claims.Id] {
+		return errors.New("token revoked")
+	}
+	if issuedBefore, found := c.issuers[claims.Issuer]; found && claims.IssuedAt <= issuedBefore {
+		return fmt.Errorf("tokens of issuer '%s' revoked", claims.Issuer)
+	}
+	return nil
+}
+
+func (c *revocationCache) refresh() error {
+	c.fetchedAt = time.Now()
+	revocations, err := c.store.Revocations()
+	if err != nil {
+		return err
+	}
+	c.tokens = make(map[string]bool)
+	c.issuers = make(map[string]int64)
+	for _, revocation := range revocations {
+		c.add(revocation)
+	}
+	return nil
+}
+
+func (c *revocationCache) add(revocation Revocation) {
+	if revocation.ID != "" {
+		c.tokens[revocation.ID] = true
+		return
+	}
+	if revocation.IssuedBefore > c.issuers[revocation.Issuer] {
+		c.issuers[revocation.Issuer] = revocation.IssuedBefore
+	}
+}
+
+// revoke adds the revocation to the store, and to the list at once
+func (c *revocationCache) revoke(revocation Revocation) error {
+	if err := c.store.Revoke(revocation); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.tokens != nil {
+		c.add(revocation)
+	}
+	return nil
+}
+
+// IntrospectionResponse is the response of ApiTokenIntrospectRoute, which only holds Active when the token isn't
+type IntrospectionResponse struct {
+	Active    bool     `json:"active"`
+	Issuer    string   `json:"iss,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	ID        string   `json:"jti,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+// LoadRevocationRoutes serves ApiTokenIntrospectRoute and ApiTokenRevokeRoute, with the tokens verified by verifier
+// and revoked in its revocation list. Both routes require a token, as the other routes.
+func LoadRevocationRoutes(router *mux.Router, lc logger.LoggingClient, verifier *Verifier) error {
+	if verifier.revocations == nil {
+		return errors.New("the verifier has no revocation list")
+	}
+
+	router.HandleFunc(ApiTokenIntrospectRoute, func(w http.ResponseWriter, r *http.Request) {
+		response := IntrospectionResponse{}
+		if claims, err := verifier.Verify(r.FormValue(tokenForm)); err == nil {
+			response = IntrospectionResponse{
+				Active:    true,
+				Issuer:    claims.Issuer,
+				Subject:   claims.Subject,
+				ID:        claims.Id,
+				IssuedAt:  claims.IssuedAt,
+				ExpiresAt: claims.ExpiresAt,
+				Roles:     claims.Roles,
+			}
+		}
+		w.Header().Set(clients.ContentType, clients.ContentTypeJSON)
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(response)
+	}).Methods(http.MethodPost)
+
+	router.HandleFunc(ApiTokenRevokeRoute, func(w http.ResponseWriter, r *http.Request) {
+		token, issuer := r.FormValue(tokenForm), r.FormValue(issuerForm)
+		if (token == "") == (issuer == "") {
+			http.Error(w, "either the token or the issuer is required", http.StatusBadRequest)
+			return
+		}
+
+		revocation := Revocation{Issuer: issuer, IssuedBefore: time.Now().Unix(), Revoked: time.Now().Unix()}
+		if actor := ClaimsFromContext(r.Context()); actor != nil {
+			revocation.RevokedBy = actor.Actor()
+		}
+		if token != "" {
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				// as per RFC 7009, the invalid tokens need no revocation
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			if claims.Id == "" {
+				http.Error(w, "the token has no jti, revoke its issuer instead", http.StatusBadRequest)
+				return
+			}
+			revocation = Revocation{
+				ID:        claims.Id,
+				Issuer:    claims.Issuer,
+				Expires:   claims.ExpiresAt,
+				Revoked:   revocation.Revoked,
+				RevokedBy: revocation.RevokedBy,
+			}
+		}
+
+		if err := verifier.revocations.revoke(revocation); err != nil {
+			lc.Error(fmt.Sprintf("failed to revoke %s: %s", revocation.Key(), err.Error()))
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		lc.Info(fmt.Sprintf("%s revoked by '%s'", revocation.Key(), revocation.RevokedBy))
+		w.WriteHeader(http.StatusOK)
+	}).Methods(http.MethodPost)
+
+	return nil
+}
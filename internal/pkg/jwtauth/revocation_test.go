@@ -0,0 +1,207 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package jwtauth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRevocationStore is a RevocationStore failing while unavailable
+type memoryRevocationStore struct {
+	mutex       sync.Mutex
+	revocations map[string]Revocation
+	unavailable bool
+}
+
+func newMemoryRevocationStore() *memoryRevocationStore {
+	return &memoryRevocationStore{revocations: make(map[string]Revocation)}
+}
+
+func (s *memoryRevocationStore) Revoke(revocation Revocation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.unavailable {
+		return errors.New("unavailable")
+	}
+	s.revocations[revocation.Key()] = revocation
+	return nil
+}
+
+func (s *memoryRevocationStore) Revocations() ([]Revocation, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.unavailable {
+		return nil, errors.New("unavailable")
+	}
+	var revocations []Revocation
+	for _, revocation := range s.revocations {
+		revocations = append(revocations, revocation)
+	}
+	return revocations, nil
+}
+
+func signClaims(t *testing.T, key interface{}, id string, issuedAt int64) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, &Claims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        id,
+			Issuer:    testIssuer,
+			IssuedAt:  issuedAt,
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+		Roles: []string{"admin"},
+	})
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestRevocation(t *testing.T) {
+	now := time.Now().Unix()
+	token := Revocation{ID: "b3f1c2d4", Issuer: testIssuer, Expires: now + 60}
+	issuer := Revocation{Issuer: testIssuer, IssuedBefore: now}
+
+	assert.Equal(t, "jti:b3f1c2d4", token.Key())
+	assert.Equal(t, "iss:"+testIssuer, issuer.Key())
+	assert.False(t, token.Expired(now))
+	assert.True(t, token.Expired(now+61))
+	assert.False(t, issuer.Expired(now+3600), "the revocations of the issuers should never expire")
+}
+
+func TestVerifierRevocations(t *testing.T) {
+	privateKey, publicPEM := newTestKey(t)
+	// a minute ago, so that the tokens issued later aren't used before being issued
+	now := time.Now().Unix() - 60
+	store := newMemoryRevocationStore()
+	verifier := NewVerifier(staticKeySource{testIssuer: publicPEM}, time.Hour)
+	verifier.UseRevocations(store, time.Hour)
+
+	store.unavailable = true
+	_, err := verifier.Verify(signClaims(t, privateKey, "first", now))
+	require.Error(t, err, "the tokens should be rejected until the revocation list is read")
+	verifier.revocations.fetchedAt = time.Time{}
+	store.unavailable = false
+
+	revoked := signClaims(t, privateKey, "revoked", now)
+	valid := signClaims(t, privateKey, "valid", now)
+	_, err = verifier.Verify(revoked)
+	require.NoError(t, err)
+	require.NoError(t, verifier.revocations.revoke(Revocation{ID: "revoked", Issuer: testIssuer}))
+	_, err = verifier.Verify(revoked)
+	assert.Error(t, err, "the revocation should apply at once")
+	_, err = verifier.Verify(valid)
+	assert.NoError(t, err)
+
+	// revoked through another service, and read once the list is stale
+	require.NoError(t, store.Revoke(Revocation{Issuer: testIssuer, IssuedBefore: now}))
+	_, err = verifier.Verify(valid)
+	assert.NoError(t, err)
+	verifier.revocations.fetchedAt = time.Time{}
+	_, err = verifier.Verify(valid)
+	assert.Error(t, err, "the tokens issued up to the revocation of the issuer should be revoked")
+	_, err = verifier.Verify(signClaims(t, privateKey, "later", now+1))
+	assert.NoError(t, err, "the tokens issued after the revocation of the issuer should be valid")
+
+	// the previous list is kept while the store is unavailable
+	store.unavailable = true
+	verifier.revocations.fetchedAt = time.Time{}
+	_, err = verifier.Verify(revoked)
+	assert.Error(t, err)
+	_, err = verifier.Verify(signClaims(t, privateKey, "later", now+1))
+	assert.NoError(t, err)
+}
+
+func TestRevocationRoutes(t *testing.T) {
+	privateKey, publicPEM := newTestKey(t)
+	now := time.Now().Unix()
+	store := newMemoryRevocationStore()
+	verifier := NewVerifier(staticKeySource{testIssuer: publicPEM}, time.Hour)
+	verifier.UseRevocations(store, time.Hour)
+
+	router := mux.NewRouter()
+	require.NoError(t, LoadRevocationRoutes(router, logger.MockLogger{}, verifier))
+	router.HandleFunc("/api/v2/event", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Use(NewVerifierMiddleware(logger.MockLogger{}, JWTAuthInfo{}, verifier))
+
+	caller := signClaims(t, privateKey, "caller", now)
+	leaked := signClaims(t, privateKey, "leaked", now)
+	post := func(route string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, route, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set(authorizationHeader, bearerPrefix+caller)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	introspect := func(token string) IntrospectionResponse {
+		recorder := post(ApiTokenIntrospectRoute, url.Values{tokenForm: {token}})
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+		var response IntrospectionResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		return response
+	}
+
+	active := introspect(leaked)
+	assert.True(t, active.Active)
+	assert.Equal(t, testIssuer, active.Issuer)
+	assert.Equal(t, "leaked", active.ID)
+	assert.Equal(t, []string{"admin"}, active.Roles)
+	assert.Equal(t, IntrospectionResponse{}, introspect("not a token"))
+
+	assert.Equal(t, http.StatusBadRequest, post(ApiTokenRevokeRoute, url.Values{}).Code)
+	assert.Equal(t, http.StatusBadRequest,
+		post(ApiTokenRevokeRoute, url.Values{tokenForm: {leaked}, issuerForm: {testIssuer}}).Code)
+	assert.Equal(t, http.StatusBadRequest, post(ApiTokenRevokeRoute, url.Values{tokenForm: {signClaims(t, privateKey, "", now)}}).Code,
+		"the tokens without id should only be revoked with their issuer")
+	assert.Equal(t, http.StatusOK, post(ApiTokenRevokeRoute, url.Values{tokenForm: {"not a token"}}).Code)
+	assert.Empty(t, store.revocations)
+
+	require.Equal(t, http.StatusOK, post(ApiTokenRevokeRoute, url.Values{tokenForm: {leaked}}).Code)
+	require.Contains(t, store.revocations, "jti:leaked")
+	assert.Equal(t, testIssuer, store.revocations["jti:leaked"].RevokedBy)
+	assert.Equal(t, IntrospectionResponse{}, introspect(leaked))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/event", nil)
+	req.Header.Set(authorizationHeader, bearerPrefix+leaked)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	store.unavailable = true
+	assert.Equal(t, http.StatusInternalServerError, post(ApiTokenRevokeRoute, url.Values{issuerForm: {testIssuer}}).Code)
+	store.unavailable = false
+	require.Equal(t, http.StatusOK, post(ApiTokenRevokeRoute, url.Values{issuerForm: {testIssuer}}).Code)
+	assert.Equal(t, http.StatusUnauthorized, post(ApiTokenIntrospectRoute, url.Values{tokenForm: {leaked}}).Code,
+		"the caller's own token should be revoked with its issuer")
+
+	assert.Error(t, LoadRevocationRoutes(mux.NewRouter(), logger.MockLogger{}, NewVerifier(staticKeySource{}, time.Hour)))
+}
@@ -0,0 +1,16 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package labels
+
+import (
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// MultiUsagesResponse defines the response content of ApiAllLabelRoute
+type MultiUsagesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Labels                 []Usage `json:"labels"`
+}
@@ -0,0 +1,155 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package labels defines the registry of the label keys and values core-metadata accepts on the devices and device
+// profiles, so that the labels the group queries rely on stay consistent, and the counts of the labels in use.
+package labels
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+const (
+	// ApiLabelRoute is the base route of the labels
+	ApiLabelRoute = v2.ApiBase + "/label"
+	// ApiAllLabelRoute returns the labels in use by the devices and device profiles, with their counts
+	ApiAllLabelRoute = ApiLabelRoute + "/" + v2.All
+
+	// Separator separates the key of a label from its value, e.g. "location:building-1"
+	Separator = ":"
+)
+
+// KeyInfo describes a registered label key
+type KeyInfo struct {
+	Description string
+	// Values are the allowed values of the key, by value with their description. The labels of the key may have any
+	// value, or none, when empty.
+	Values map[string]string
+}
+
+// LabelRegistryInfo configures the registry of the labels
+type LabelRegistryInfo struct {
+	// Enforced rejects the devices and device profiles with a label whose key isn't registered, or whose value isn't
+	// one of those of its key. The registry only describes the labels when not enforced.
+	Enforced bool
+	// Keys are the registered label keys, by key
+	Keys map[string]KeyInfo
+}
+
+// Split returns the key and the value of label, the value being empty for the labels without Separator
+func Split(label string) (key string, value string) {
+	if i := strings.Index(label, Separator); i >= 0 {
+		return label[:i], label[i+len(Separator):]
+	}
+	return label, ""
+}
+
+// Lookup returns the description of label, that of its value if any or else that of its key, and whether the label
+// is allowed by the registry
+func (info LabelRegistryInfo) Lookup(label string) (description string, registered bool) {
+	key, value := Split(label)
+	keyInfo, ok := info.Keys[key]
+	if !ok {
+		return "", false
+	}
+	if len(keyInfo.Values) == 0 {
+		return keyInfo.Description, true
+	}
+	valueDescription, ok := keyInfo.Values[value]
+	if !ok {
+		return keyInfo.Description, false
+	}
+	if valueDescription == "" {
+		valueDescription = keyInfo.Description
+	}
+	return valueDescription, true
+}
+
+// Check returns an error naming the first of labels the registry doesn't allow, when enforced
+func (info LabelRegistryInfo) Check(labels []string) error {
+	if !info.Enforced {
+		return nil
+	}
+	for _, label := range labels {
+		if _, registered := info.Lookup(label); registered {
+			continue
+		}
+		key, _ := Split(label)
+		keyInfo, ok := info.Keys[key]
+		if !ok {
+			return fmt.Errorf("label key '%s' of label '%s' is not registered", key, label)
+		}
+		values := make([]string, 0, len(keyInfo.Values))
+		for value := range keyInfo.Values {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		return fmt.Errorf("label '%s' has none of the values %v of key '%s'", label, values, key)
+	}
+	return nil
+}
+
+// Usage counts the devices and device profiles having a label
+type Usage struct {
+	Label string `json:"label"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	// Description is the description of the label in the registry
+	Description string `json:"description,omitempty"`
+	// Registered tells whether the registry allows the label
+	Registered     bool `json:"registered"`
+	Devices        int  `json:"devices"`
+	DeviceProfiles int  `json:"deviceProfiles"`
+}
+
+// Count returns the usages of the labels of the devices and of the device profiles, given as the labels of each,
+// sorted by label
+func Count(deviceLabels [][]string, profileLabels [][]string, info LabelRegistryInfo) []Usage {
+	usages := make(map[string]*Usage)
+	usage := func(label string) *Usage {
+		u, ok := usages[label]
+		if !ok {
+			key, value := Split(label)
+			description, registered := info.Lookup(label)
+			u = &Usage{Label: label, Key: key, Value: value, Description: description, Registered: registered}
+			usages[label] = u
+		}
+		return u
+	}
+	for _, labels := range deviceLabels {
+		for _, label := range distinct(labels) {
+			usage(label).Devices++
+		}
+	}
+	for _, labels := range profileLabels {
+		for _, label := range distinct(labels) {
+			usage(label).DeviceProfiles++
+		}
+	}
+
+	result := make([]Usage, 0, len(usages))
+	for _, u := range usages {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Label < result[j].Label })
+	return result
+}
+
+// distinct returns labels without the duplicates, which would be counted twice
+func distinct(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if !seen[label] {
+			seen[label] = true
+			result = append(result, label)
+		}
+	}
+	return result
+}
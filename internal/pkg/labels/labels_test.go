@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var registry = LabelRegistryInfo{
+	Enforced: true,
+	Keys: map[string]KeyInfo{
+		"location": {Description: "Where the device is", Values: map[string]string{
+			"building-1": "Main building",
+			"building-2": "",
+		}},
+		"vendor":    {Description: "Manufacturer of the device"},
+		"simulated": {Description: "Simulated device"},
+	},
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		label         string
+		expectedKey   string
+		expectedValue string
+	}{
+		{"location:building-1", "location", "building-1"},
+		{"simulated", "simulated", ""},
+		{"url:http://localhost", "url", "http://localhost"},
+		{"location:", "location", ""},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.label, func(t *testing.T) {
+			key, value := Split(testCase.label)
+			assert.Equal(t, testCase.expectedKey, key)
+			assert.Equal(t, testCase.expectedValue, value)
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name                string
+		label               string
+		expectedDescription string
+		expectedRegistered  bool
+	}{
+		{"Registered value", "location:building-1", "Main building", true},
+		{"Registered value without description", "location:building-2", "Where the device is", true},
+		{"Unregistered value", "location:building-3", "Where the device is", false},
+		{"Missing value", "location", "Where the device is", false},
+		{"Key with any value", "vendor:acme", "Manufacturer of the device", true},
+		{"Key without value", "simulated", "Simulated device", true},
+		{"Unregistered key", "floor:1", "", false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			description, registered := registry.Lookup(testCase.label)
+			assert.Equal(t, testCase.expectedDescription, description)
+			assert.Equal(t, testCase.expectedRegistered, registered)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	assert.NoError(t, registry.Check([]string{"location:building-1", "vendor:acme", "simulated"}))
+	assert.NoError(t, registry.Check(nil))
+
+	err := registry.Check([]string{"vendor:acme", "floor:1"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "'floor'")
+	}
+	err = registry.Check([]string{"location:building-3"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "[building-1 building-2]")
+	}
+
+	notEnforced := registry
+	notEnforced.Enforced = false
+	assert.NoError(t, notEnforced.Check([]string{"floor:1"}))
+}
+
+func TestCount(t *testing.T) {
+	deviceLabels := [][]string{
+		{"location:building-1", "vendor:acme"},
+		{"location:building-1", "location:building-1"},
+		nil,
+	}
+	profileLabels := [][]string{{"vendor:acme"}, {"floor:1"}}
+
+	usages := Count(deviceLabels, profileLabels, registry)
+
+	assert.Equal(t, []Usage{
+		{Label: "floor:1", Key: "floor", Value: "1", DeviceProfiles: 1},
+		{Label: "location:building-1", Key: "location", Value: "building-1", Description: "Main building", Registered: true, Devices: 2},
+		{Label: "vendor:acme", Key: "vendor", Value: "acme", Description: "Manufacturer of the device", Registered: true, Devices: 1, DeviceProfiles: 1},
+	}, usages)
+}
@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mutes
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Store provides the stored mute windows, and keeps the ids of the notifications queued by them
+type Store interface {
+	AllMuteWindows(offset int, limit int) ([]MuteWindow, errors.EdgeX)
+	// HoldNotification keeps the id of a queued notification, created at the timestamp created
+	HoldNotification(id string, created int64) errors.EdgeX
+	// HeldNotifications returns the ids of the queued notifications, the first created first
+	HeldNotifications(offset int, limit int) ([]string, errors.EdgeX)
+	ReleaseNotification(id string) errors.EdgeX
+}
+
+// AddMuteWindowRequest defines the request content of a mute window added through ApiMuteWindowRoute
+type AddMuteWindowRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	MuteWindow            MuteWindow `json:"muteWindow" validate:"required"`
+}
+
+// UpdateMuteWindowRequest defines the request content of a mute window changed through ApiMuteWindowRoute
+type UpdateMuteWindowRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	MuteWindow            UpdateMuteWindow `json:"muteWindow" validate:"required"`
+}
+
+// MuteWindowResponse defines the response content of ApiMuteWindowByNameRoute
+type MuteWindowResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	MuteWindow             MuteWindow `json:"muteWindow"`
+}
+
+// MultiMuteWindowsResponse defines the response content of ApiAllMuteWindowRoute
+type MultiMuteWindowsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	MuteWindows            []MuteWindow `json:"muteWindows"`
+}
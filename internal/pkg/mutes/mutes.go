@@ -0,0 +1,215 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mutes defines the mute windows during which the matching notifications are suppressed or queued, so that
+// planned maintenance doesn't page the on-call.
+package mutes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+const (
+	// ApiMuteWindowRoute accepts the mute windows to add, and the changes of existing mute windows
+	ApiMuteWindowRoute = v2.ApiBase + "/mutewindow"
+	// ApiAllMuteWindowRoute returns the mute windows, the latest created first
+	ApiAllMuteWindowRoute = ApiMuteWindowRoute + "/" + v2.All
+	// ApiMuteWindowByNameRoute returns or deletes a mute window
+	ApiMuteWindowByNameRoute = ApiMuteWindowRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+
+	// DeviceGroupLabelPrefix prefixes the notification label naming the device group the notification is about,
+	// e.g. "devicegroup:building-1"
+	DeviceGroupLabelPrefix = "devicegroup:"
+)
+
+// Actions applied to the notifications matching an active mute window
+const (
+	// Suppress marks the notifications processed without sending them
+	Suppress = "SUPPRESS"
+	// Queue holds the notifications until no mute window matches them anymore, and then sends them
+	Queue = "QUEUE"
+)
+
+// Recurrences of a mute window
+const (
+	Daily  = "DAILY"
+	Weekly = "WEEKLY"
+)
+
+var periods = map[string]int64{
+	Daily:  int64(24 * time.Hour / time.Millisecond),
+	Weekly: int64(7 * 24 * time.Hour / time.Millisecond),
+}
+
+// MuteWindow mutes the matching notifications from Start to End, and then every day or week when recurring. The
+// notifications match when they are of one of Categories, carry all of Labels and are about one of DeviceGroups,
+// the empty criteria matching any notification.
+type MuteWindow struct {
+	Id          string `json:"id,omitempty"`
+	Created     int64  `json:"created,omitempty"`
+	Modified    int64  `json:"modified,omitempty"`
+	Name        string `json:"name" validate:"required,edgex-dto-none-empty-string"`
+	Description string `json:"description,omitempty"`
+	// Categories are the categories of the muted notifications
+	Categories []string `json:"categories,omitempty"`
+	// Labels restrict the window to the notifications carrying all of them
+	Labels []string `json:"labels,omitempty"`
+	// DeviceGroups restrict the window to the notifications labelled with one of them, see DeviceGroupLabelPrefix
+	DeviceGroups []string `json:"deviceGroups,omitempty"`
+	// Start and End are the first occurrence of the window, in milliseconds
+	Start int64 `json:"start" validate:"required"`
+	End   int64 `json:"end" validate:"required,gtfield=Start"`
+	// Recurrence repeats the window every day or week, by the same number of milliseconds whatever the time zone
+	Recurrence string `json:"recurrence,omitempty" validate:"omitempty,oneof=DAILY WEEKLY"`
+	// Until ends the recurrence, in milliseconds; the window recurs forever when zero
+	Until  int64  `json:"until,omitempty"`
+	Action string `json:"action" validate:"required,oneof=SUPPRESS QUEUE"`
+}
+
+// UpdateMuteWindow carries the fields of a mute window to change, the window being identified by Id or Name
+type UpdateMuteWindow struct {
+	Id           *string  `json:"id" validate:"required_without=Name,omitempty,uuid"`
+	Name         *string  `json:"name" validate:"required_without=Id,omitempty,edgex-dto-none-empty-string"`
+	Description  *string  `json:"description"`
+	Categories   []string `json:"categories"`
+	Labels       []string `json:"labels"`
+	DeviceGroups []string `json:"deviceGroups"`
+	Start        *int64   `json:"start"`
+	End          *int64   `json:"end"`
+	Recurrence   *string  `json:"recurrence" validate:"omitempty,oneof='' 'DAILY' 'WEEKLY'"`
+	Until        *int64   `json:"until"`
+	Action       *string  `json:"action" validate:"omitempty,oneof=SUPPRESS QUEUE"`
+}
+
+// Apply replaces the fields of w with those set in update
+func (update UpdateMuteWindow) Apply(w *MuteWindow) {
+	if update.Description != nil {
+		w.Description = *update.Description
+	}
+	if update.Categories != nil {
+		w.Categories = update.Categories
+	}
+	if update.Labels != nil {
+		w.Labels = update.Labels
+	}
+	if update.DeviceGroups != nil {
+		w.DeviceGroups = update.DeviceGroups
+	}
+	if update.Start != nil {
+		w.Start = *update.Start
+	}
+	if update.End != nil {
+		w.End = *update.End
+	}
+	if update.Recurrence != nil {
+		w.Recurrence = *update.Recurrence
+	}
+	if update.Until != nil {
+		w.Until = *update.Until
+	}
+	if update.Action != nil {
+		w.Action = *update.Action
+	}
+}
+
+// Validate checks that the mute window is complete and that its occurrences don't overlap
+func (w MuteWindow) Validate() errors.EdgeX {
+	if strings.TrimSpace(w.Name) == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "mute window name is empty", nil)
+	}
+	if w.Action != Suppress && w.Action != Queue {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("mute window %s action '%s' is neither %s nor %s", w.Name, w.Action, Suppress, Queue), nil)
+	}
+	if w.Start <= 0 || w.End <= w.Start {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("mute window %s doesn't end after it starts", w.Name), nil)
+	}
+	if w.Recurrence == "" {
+		return nil
+	}
+	period, ok := periods[w.Recurrence]
+	if !ok {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("mute window %s recurrence '%s' is neither %s nor %s", w.Name, w.Recurrence, Daily, Weekly), nil)
+	}
+	if w.End-w.Start > period {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("mute window %s lasts longer than its %s recurrence", w.Name, strings.ToLower(w.Recurrence)), nil)
+	}
+	if w.Until != 0 && w.Until <= w.Start {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("mute window %s recurs until before it starts", w.Name), nil)
+	}
+	return nil
+}
+
+// Active returns whether an occurrence of the window covers the timestamp at, in milliseconds
+func (w MuteWindow) Active(at int64) bool {
+	if at < w.Start {
+		return false
+	}
+	period, ok := periods[w.Recurrence]
+	if !ok {
+		return at < w.End
+	}
+	if w.Until != 0 && at >= w.Until {
+		return false
+	}
+	return (at-w.Start)%period < w.End-w.Start
+}
+
+// Matches returns whether the window mutes the notification when active
+func (w MuteWindow) Matches(n models.Notification) bool {
+	if len(w.Categories) > 0 && !contains(w.Categories, string(n.Category)) {
+		return false
+	}
+	for _, label := range w.Labels {
+		if !contains(n.Labels, label) {
+			return false
+		}
+	}
+	if len(w.DeviceGroups) == 0 {
+		return true
+	}
+	for _, group := range w.DeviceGroups {
+		if contains(n.Labels, DeviceGroupLabelPrefix+group) {
+			return true
+		}
+	}
+	return false
+}
+
+// Muting returns the window muting the notification at the timestamp at, in milliseconds: among the active windows
+// matching it, the first suppressing it, else the first queueing it. ok is false when none mutes it.
+func Muting(windows []MuteWindow, n models.Notification, at int64) (muting MuteWindow, ok bool) {
+	for _, w := range windows {
+		if !w.Active(at) || !w.Matches(n) {
+			continue
+		}
+		if w.Action == Suppress {
+			return w, true
+		}
+		if !ok {
+			muting, ok = w, true
+		}
+	}
+	return muting, ok
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mutes
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	hour int64 = 60 * 60 * 1000
+	day        = 24 * hour
+	// start is 2021-01-01 22:00 UTC
+	start int64 = 1609459200000 + 22*hour
+)
+
+func testWindow() MuteWindow {
+	return MuteWindow{
+		Name:   "maintenance",
+		Start:  start,
+		End:    start + 2*hour,
+		Action: Suppress,
+	}
+}
+
+func testNotification() models.Notification {
+	return models.Notification{
+		Slug:     "fan-failure",
+		Category: models.Hwhealth,
+		Severity: models.Critical,
+		Labels:   []string{"cooling", DeviceGroupLabelPrefix + "building-1"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	noName := testWindow()
+	noName.Name = " "
+	noAction := testWindow()
+	noAction.Action = ""
+	endBeforeStart := testWindow()
+	endBeforeStart.End = endBeforeStart.Start
+	daily := testWindow()
+	daily.Recurrence = Daily
+	daily.Until = start + 7*day
+	unknownRecurrence := testWindow()
+	unknownRecurrence.Recurrence = "MONTHLY"
+	longerThanRecurrence := testWindow()
+	longerThanRecurrence.Recurrence = Daily
+	longerThanRecurrence.End = start + day + 1
+	untilBeforeStart := testWindow()
+	untilBeforeStart.Recurrence = Weekly
+	untilBeforeStart.Until = start - 1
+
+	tests := []struct {
+		name          string
+		window        MuteWindow
+		errorExpected bool
+	}{
+		{"Valid", testWindow(), false},
+		{"Valid - recurring", daily, false},
+		{"Invalid - no name", noName, true},
+		{"Invalid - no action", noAction, true},
+		{"Invalid - ends before it starts", endBeforeStart, true},
+		{"Invalid - unknown recurrence", unknownRecurrence, true},
+		{"Invalid - longer than its recurrence", longerThanRecurrence, true},
+		{"Invalid - recurs until before it starts", untilBeforeStart, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.window.Validate()
+			if testCase.errorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestActive(t *testing.T) {
+	once := testWindow()
+	daily := testWindow()
+	daily.Recurrence = Daily
+	daily.Until = start + 3*day
+	weekly := testWindow()
+	weekly.Recurrence = Weekly
+
+	tests := []struct {
+		name     string
+		window   MuteWindow
+		at       int64
+		expected bool
+	}{
+		{"Before", once, start - 1, false},
+		{"Start", once, start, true},
+		{"During", once, start + hour, true},
+		{"End", once, start + 2*hour, false},
+		{"Not recurring", once, start + day, false},
+		{"Daily - next day", daily, start + day + hour, true},
+		{"Daily - between occurrences", daily, start + day + 3*hour, false},
+		{"Daily - until", daily, start + 3*day + hour, false},
+		{"Weekly - next day", weekly, start + day + hour, false},
+		{"Weekly - next week", weekly, start + 7*day + hour, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.window.Active(testCase.at))
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		window   MuteWindow
+		expected bool
+	}{
+		{"Any notification", MuteWindow{}, true},
+		{"Category", MuteWindow{Categories: []string{"SECURITY", "HW_HEALTH"}}, true},
+		{"Other category", MuteWindow{Categories: []string{"SECURITY"}}, false},
+		{"Labels", MuteWindow{Labels: []string{"cooling"}}, true},
+		{"Missing label", MuteWindow{Labels: []string{"cooling", "power"}}, false},
+		{"Device group", MuteWindow{DeviceGroups: []string{"building-2", "building-1"}}, true},
+		{"Other device group", MuteWindow{DeviceGroups: []string{"building-2"}}, false},
+		{"Device group name as label", MuteWindow{Labels: []string{"building-1"}}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.window.Matches(testNotification()))
+		})
+	}
+}
+
+func TestMuting(t *testing.T) {
+	queue := testWindow()
+	queue.Name = "queue"
+	queue.Action = Queue
+	suppress := testWindow()
+	suppress.Name = "suppress"
+	suppress.Categories = []string{"HW_HEALTH"}
+	otherCategory := testWindow()
+	otherCategory.Name = "other-category"
+	otherCategory.Categories = []string{"SECURITY"}
+	n := testNotification()
+
+	muting, ok := Muting([]MuteWindow{queue, suppress, otherCategory}, n, start+hour)
+	assert.True(t, ok)
+	assert.Equal(t, suppress.Name, muting.Name, "suppressing must prevail over queueing")
+
+	muting, ok = Muting([]MuteWindow{queue, otherCategory}, n, start+hour)
+	assert.True(t, ok)
+	assert.Equal(t, queue.Name, muting.Name)
+
+	_, ok = Muting([]MuteWindow{queue, suppress}, n, start+3*hour)
+	assert.False(t, ok, "no window is active")
+
+	_, ok = Muting([]MuteWindow{otherCategory}, n, start+hour)
+	assert.False(t, ok, "no window matches")
+}
+
+func TestApply(t *testing.T) {
+	w := testWindow()
+	recurrence := Daily
+	end := start + hour
+	UpdateMuteWindow{Recurrence: &recurrence, End: &end, DeviceGroups: []string{"building-1"}}.Apply(&w)
+	assert.Equal(t, Daily, w.Recurrence)
+	assert.Equal(t, end, w.End)
+	assert.Equal(t, []string{"building-1"}, w.DeviceGroups)
+	assert.Equal(t, start, w.Start, "the fields not set must be kept")
+	assert.Equal(t, Suppress, w.Action, "the fields not set must be kept")
+}
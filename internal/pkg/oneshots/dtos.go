@@ -0,0 +1,36 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oneshots
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Store provides the pending one-shot jobs to run, and deletes them once run
+type Store interface {
+	// DueOneShots returns the one-shot jobs to run at or before the timestamp at, the first to run first
+	DueOneShots(at int64) ([]OneShot, errors.EdgeX)
+	DeleteOneShotByName(name string) errors.EdgeX
+}
+
+// AddOneShotRequest defines the request content of a one-shot job scheduled through ApiOneShotRoute
+type AddOneShotRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	OneShot               OneShot `json:"oneShot" validate:"required"`
+}
+
+// OneShotResponse defines the response content of ApiOneShotByNameRoute
+type OneShotResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	OneShot                OneShot `json:"oneShot"`
+}
+
+// MultiOneShotsResponse defines the response content of ApiAllOneShotRoute
+type MultiOneShotsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	OneShots               []OneShot `json:"oneShots"`
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oneshots defines the one-shot jobs run once by support-scheduler at a given timestamp and then deleted, so
+// that services don't have to emulate them with throwaway intervals.
+package oneshots
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+const (
+	// ApiOneShotRoute accepts the one-shot jobs to schedule
+	ApiOneShotRoute = v2.ApiBase + "/oneshot"
+	// ApiAllOneShotRoute returns the pending one-shot jobs, the first to run first
+	ApiAllOneShotRoute = ApiOneShotRoute + "/" + v2.All
+	// ApiOneShotByNameRoute returns or cancels a pending one-shot job
+	ApiOneShotByNameRoute = ApiOneShotRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+)
+
+// OneShot is a job sending an HTTP request once, at RunAt. The target fields are those of an interval action.
+type OneShot struct {
+	Id          string `json:"id,omitempty"`
+	Created     int64  `json:"created,omitempty"`
+	Modified    int64  `json:"modified,omitempty"`
+	Name        string `json:"name" validate:"required,edgex-dto-none-empty-string"`
+	Description string `json:"description,omitempty"`
+	// RunAt is when the job runs, in milliseconds
+	RunAt int64 `json:"runAt" validate:"required"`
+	// Protocol is http when empty
+	Protocol string `json:"protocol,omitempty" validate:"omitempty,oneof=http https"`
+	Host     string `json:"host" validate:"required,edgex-dto-none-empty-string"`
+	Port     int    `json:"port" validate:"required"`
+	Path     string `json:"path,omitempty"`
+	// Parameters is the body of the request
+	Parameters string `json:"parameters,omitempty"`
+	HTTPMethod string `json:"httpMethod" validate:"required,oneof=GET HEAD POST PUT DELETE"`
+}
+
+var methods = map[string]bool{"GET": true, "HEAD": true, "POST": true, "PUT": true, "DELETE": true}
+
+// Validate checks that the job is complete and is due at or after the timestamp now, in milliseconds
+func (o OneShot) Validate(now int64) errors.EdgeX {
+	if strings.TrimSpace(o.Name) == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "one-shot job name is empty", nil)
+	}
+	if strings.TrimSpace(o.Host) == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("one-shot job %s host is empty", o.Name), nil)
+	}
+	if o.Protocol != "" && o.Protocol != "http" && o.Protocol != "https" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("one-shot job %s protocol '%s' is neither http nor https", o.Name, o.Protocol), nil)
+	}
+	if o.Port <= 0 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("one-shot job %s port is missing", o.Name), nil)
+	}
+	if !methods[o.HTTPMethod] {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("one-shot job %s HTTP method '%s' is not supported", o.Name, o.HTTPMethod), nil)
+	}
+	if o.RunAt < now {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("one-shot job %s would run in the past", o.Name), nil)
+	}
+	return nil
+}
+
+// IntervalAction returns the interval action sending the request of the job
+func (o OneShot) IntervalAction() models.IntervalAction {
+	protocol := o.Protocol
+	if protocol == "" {
+		protocol = "http"
+	}
+	return models.IntervalAction{
+		Name:       o.Name,
+		Protocol:   protocol,
+		Address:    o.Host,
+		Port:       o.Port,
+		Path:       o.Path,
+		Parameters: o.Parameters,
+		HTTPMethod: o.HTTPMethod,
+	}
+}
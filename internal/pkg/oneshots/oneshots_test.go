@@ -0,0 +1,81 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oneshots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const now int64 = 1609459200000
+
+func testOneShot() OneShot {
+	return OneShot{
+		Name:       "purge-after-upgrade",
+		RunAt:      now + 60*1000,
+		Protocol:   "http",
+		Host:       "localhost",
+		Port:       48080,
+		Path:       "/api/v2/event/age/0",
+		HTTPMethod: "DELETE",
+	}
+}
+
+func TestValidate(t *testing.T) {
+	noName := testOneShot()
+	noName.Name = " "
+	noHost := testOneShot()
+	noHost.Host = ""
+	noPort := testOneShot()
+	noPort.Port = 0
+	unknownProtocol := testOneShot()
+	unknownProtocol.Protocol = "ftp"
+	unknownMethod := testOneShot()
+	unknownMethod.HTTPMethod = "PATCH"
+	past := testOneShot()
+	past.RunAt = now - 1
+	rightNow := testOneShot()
+	rightNow.RunAt = now
+
+	tests := []struct {
+		name          string
+		oneShot       OneShot
+		errorExpected bool
+	}{
+		{"Valid", testOneShot(), false},
+		{"Valid - now", rightNow, false},
+		{"Invalid - no name", noName, true},
+		{"Invalid - no host", noHost, true},
+		{"Invalid - no port", noPort, true},
+		{"Invalid - unsupported protocol", unknownProtocol, true},
+		{"Invalid - unsupported method", unknownMethod, true},
+		{"Invalid - in the past", past, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.oneShot.Validate(now)
+			if testCase.errorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIntervalAction(t *testing.T) {
+	o := testOneShot()
+	action := o.IntervalAction()
+	assert.Equal(t, o.Name, action.Name)
+	assert.Equal(t, o.Host, action.Address)
+	assert.Equal(t, o.Port, action.Port)
+	assert.Equal(t, o.Path, action.Path)
+	assert.Equal(t, o.HTTPMethod, action.HTTPMethod)
+
+	o.Protocol = ""
+	assert.Equal(t, "http", o.IntervalAction().Protocol, "the protocol must default to http")
+}
@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +build ignore
+
+// gen generates the Go files embedding the OpenAPI documents of openapi/v2 in the service binaries. Run it with
+// go generate from this directory whenever a document changes.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+const specsDir = "../../../openapi/v2"
+
+func main() {
+	files, err := filepath.Glob(filepath.Join(specsDir, "*.yaml"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, file := range files {
+		spec, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if bytes.ContainsRune(spec, '`') {
+			log.Fatalf("%s can't be embedded in a raw string literal, it contains a backquote", file)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(file), ".yaml")
+		var source bytes.Buffer
+		fmt.Fprintf(&source, "// Code generated by gen.go from openapi/v2/%s.yaml; DO NOT EDIT.\n\n", name)
+		fmt.Fprintf(&source, "package openapi\n\n")
+		fmt.Fprintf(&source, "// %s is the OpenAPI document of the %s service\n", ConstName(name), name)
+		fmt.Fprintf(&source, "const %s = `%s`\n", ConstName(name), spec)
+		formatted, err := format.Source(source.Bytes())
+		if err != nil {
+			log.Fatal(err)
+		}
+		output := strings.ReplaceAll(name, "-", "") + "_spec.go"
+		if err := ioutil.WriteFile(output, formatted, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// ConstName returns the name of the constant holding the document of the service name, e.g. CoreDataSpec
+func ConstName(name string) string {
+	var constName strings.Builder
+	for _, word := range strings.Split(name, "-") {
+		constName.WriteString(strings.Title(word))
+	}
+	return constName.String() + "Spec"
+}
@@ -0,0 +1,240 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package openapi serves from each service the OpenAPI document of its v2 API, embedded in the binary and adjusted
+// at runtime to the routes the service actually enabled and to the security mode, along with a minimal UI to try the
+// API out.
+package openapi
+
+//go:generate go run gen.go
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ApiOpenAPIRoute returns the OpenAPI document of the service as JSON
+	ApiOpenAPIRoute = v2.ApiBase + "/openapi"
+	// ApiOpenAPIUIRoute returns the page trying the API out from the OpenAPI document
+	ApiOpenAPIUIRoute = ApiOpenAPIRoute + "/ui"
+)
+
+const (
+	// forwardedPrefixHeader holds the path prefix of the service behind the API gateway
+	forwardedPrefixHeader = "X-Forwarded-Prefix"
+	// secureModeEnv is set to false when EdgeX runs in non-secure mode
+	secureModeEnv = "EDGEX_SECURITY_SECRET_STORE"
+	// bearerAuth is the security scheme of the JWTs verified by the API gateway or the service
+	bearerAuth = "bearerAuth"
+)
+
+// uiPage loads Swagger UI from a CDN, so the browser needs to reach the internet but not the service. The document
+// URL is relative so that the page also works through the API gateway.
+const uiPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>EdgeX Foundry API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@3.38.0/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@3.38.0/swagger-ui-bundle.js"></script>
+  <script>
+    SwaggerUIBundle({url: "../openapi", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
+
+// operations are the keys of the operations of an OpenAPI path item
+var operations = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var pathParameter = regexp.MustCompile(`{[^}]*}`)
+
+type handler struct {
+	lc      logger.LoggingClient
+	router  *mux.Router
+	spec    string
+	jwtAuth bool
+	secure  bool
+
+	once     sync.Once
+	document map[string]interface{}
+	err      error
+}
+
+// LoadRestRoutes adds to router the routes serving spec, the OpenAPI document of the service in YAML, and its UI.
+// jwtAuth tells whether the service verifies JWTs itself. The document is built on the first request, once every
+// route of the service is loaded.
+func LoadRestRoutes(router *mux.Router, lc logger.LoggingClient, spec string, jwtAuth bool) {
+	h := &handler{
+		lc:      lc,
+		router:  router,
+		spec:    spec,
+		jwtAuth: jwtAuth,
+		secure:  os.Getenv(secureModeEnv) != "false",
+	}
+	router.HandleFunc(ApiOpenAPIRoute, h.Document).Methods(http.MethodGet)
+	router.HandleFunc(ApiOpenAPIUIRoute, h.UI).Methods(http.MethodGet)
+}
+
+// Document handles the request returning the OpenAPI document of the service
+func (h *handler) Document(w http.ResponseWriter, r *http.Request) {
+	h.once.Do(func() {
+		h.document, h.err = BuildDocument(h.spec, Routes(h.router), h.jwtAuth, h.secure)
+	})
+	if h.err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindServerError, "failed to build the OpenAPI document", h.err)
+		h.lc.Error(edgexErr.Error(), clients.CorrelationHeader, correlation.FromContext(r.Context()))
+		utils.WriteHttpHeader(w, r.Context(), edgexErr.Code())
+		pkg.Encode(commonDTO.NewBaseResponse("", edgexErr.Message(), edgexErr.Code()), w, h.lc)
+		return
+	}
+
+	// The document is shared, only its servers are set for the request
+	document := make(map[string]interface{}, len(h.document))
+	for key, value := range h.document {
+		document[key] = value
+	}
+	document["servers"] = []interface{}{
+		map[string]interface{}{
+			"url":         r.Header.Get(forwardedPrefixHeader) + v2.ApiBase,
+			"description": "This service",
+		},
+	}
+	utils.WriteHttpHeader(w, r.Context(), http.StatusOK)
+	pkg.Encode(document, w, h.lc)
+}
+
+// UI handles the request returning the page trying the API out
+func (h *handler) UI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set(clients.ContentType, "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(uiPage))
+}
+
+// Routes returns the routes of router, keyed by method and path template with unnamed parameters
+func Routes(router *mux.Router) map[string]bool {
+	routes := make(map[string]bool)
+	_ = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			routes[routeKey(method, template)] = true
+		}
+		return nil
+	})
+	return routes
+}
+
+func routeKey(method string, template string) string {
+	return strings.ToUpper(method) + " " + pathParameter.ReplaceAllString(template, "{}")
+}
+
+// BuildDocument returns the OpenAPI document spec, in YAML, as a JSON object keeping only the operations of the
+// routes, and requiring JWTs when the service verifies them itself. In secure mode, the JWTs required by the API
+// gateway are optional for the service.
+func BuildDocument(spec string, routes map[string]bool, jwtAuth bool, secure bool) (map[string]interface{}, error) {
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(spec), &parsed); err != nil {
+		return nil, err
+	}
+	document, ok := toJSON(parsed).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("OpenAPI document is not an object")
+	}
+
+	paths, _ := document["paths"].(map[string]interface{})
+	for path, item := range paths {
+		pathItem, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		enabled := 0
+		for _, operation := range operations {
+			if _, ok := pathItem[operation]; !ok {
+				continue
+			}
+			if routes[routeKey(operation, v2.ApiBase+path)] {
+				enabled++
+			} else {
+				delete(pathItem, operation)
+			}
+		}
+		if enabled == 0 {
+			delete(paths, path)
+		}
+	}
+
+	if jwtAuth || secure {
+		components, ok := document["components"].(map[string]interface{})
+		if !ok {
+			components = make(map[string]interface{})
+			document["components"] = components
+		}
+		schemes, ok := components["securitySchemes"].(map[string]interface{})
+		if !ok {
+			schemes = make(map[string]interface{})
+			components["securitySchemes"] = schemes
+		}
+		schemes[bearerAuth] = map[string]interface{}{
+			"type":         "http",
+			"scheme":       "bearer",
+			"bearerFormat": "JWT",
+			"description":  "JWT issued for the API gateway",
+		}
+		security := []interface{}{map[string]interface{}{bearerAuth: []interface{}{}}}
+		if !jwtAuth {
+			// An empty requirement makes the JWT optional
+			security = append(security, map[string]interface{}{})
+		}
+		document["security"] = security
+	}
+	return document, nil
+}
+
+// toJSON converts the maps decoded from YAML, keyed by interface{}, to maps keyed by string
+func toJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			object[fmt.Sprint(key)] = toJSON(child)
+		}
+		return object
+	case []interface{}:
+		for i, child := range v {
+			v[i] = toJSON(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,165 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 2.0.0
+paths:
+  /device/name/{name}:
+    get:
+      summary: "Device by name"
+    delete:
+      summary: "Delete device by name"
+  /device/all:
+    get:
+      summary: "All devices"
+  /ping:
+    get:
+      summary: "Ping"
+components:
+  schemas:
+    BaseResponse:
+      type: object
+`
+
+func testRoutes() map[string]bool {
+	return map[string]bool{
+		routeKey(http.MethodGet, v2.ApiBase+"/device/name/{deviceName}"): true,
+		routeKey(http.MethodGet, v2.ApiPingRoute):                        true,
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc(v2.ApiDeviceByNameRoute, func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodGet, http.MethodDelete)
+	router.HandleFunc(v2.ApiPingRoute, func(http.ResponseWriter, *http.Request) {})
+
+	routes := Routes(router)
+	assert.Equal(t, map[string]bool{
+		"GET " + v2.ApiBase + "/device/name/{}":    true,
+		"DELETE " + v2.ApiBase + "/device/name/{}": true,
+	}, routes, "the routes without methods aren't documented")
+}
+
+func TestBuildDocument(t *testing.T) {
+	tests := []struct {
+		name             string
+		jwtAuth          bool
+		secure           bool
+		expectedSecurity []interface{}
+	}{
+		{"Non-secure", false, false, nil},
+		{"Secure - JWTs verified by the API gateway", false, true, []interface{}{map[string]interface{}{bearerAuth: []interface{}{}}, map[string]interface{}{}}},
+		{"JWTs verified by the service", true, false, []interface{}{map[string]interface{}{bearerAuth: []interface{}{}}}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			document, err := BuildDocument(testSpec, testRoutes(), testCase.jwtAuth, testCase.secure)
+			require.NoError(t, err)
+
+			paths := document["paths"].(map[string]interface{})
+			require.Len(t, paths, 2, "the paths without enabled operations must be removed")
+			require.Contains(t, paths, "/device/name/{name}")
+			assert.Equal(t, []string{"get"}, keys(paths["/device/name/{name}"]))
+			assert.Contains(t, paths, "/ping")
+
+			components := document["components"].(map[string]interface{})
+			assert.Contains(t, components, "schemas")
+			if testCase.expectedSecurity == nil {
+				assert.NotContains(t, document, "security")
+				assert.NotContains(t, components, "securitySchemes")
+				return
+			}
+			assert.Equal(t, testCase.expectedSecurity, document["security"])
+			assert.Contains(t, components["securitySchemes"], bearerAuth)
+		})
+	}
+}
+
+func TestBuildDocumentInvalid(t *testing.T) {
+	_, err := BuildDocument("- not an object", testRoutes(), false, false)
+	assert.Error(t, err)
+}
+
+func TestDocument(t *testing.T) {
+	router := mux.NewRouter()
+	LoadRestRoutes(router, logger.NewMockClient(), testSpec, false)
+	router.HandleFunc(v2.ApiPingRoute, func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodGet)
+
+	req := httptest.NewRequest(http.MethodGet, ApiOpenAPIRoute, nil)
+	req.Header.Set(forwardedPrefixHeader, "/core-data")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+	assert.Equal(t, "3.0.0", document["openapi"])
+	assert.Equal(t, []string{"/ping"}, keys(document["paths"]), "the routes loaded after the document must be documented")
+	servers := document["servers"].([]interface{})
+	require.Len(t, servers, 1)
+	assert.Equal(t, "/core-data"+v2.ApiBase, servers[0].(map[string]interface{})["url"])
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ApiOpenAPIUIRoute, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "swagger-ui")
+}
+
+func TestDocumentInvalid(t *testing.T) {
+	router := mux.NewRouter()
+	LoadRestRoutes(router, logger.NewMockClient(), "- not an object", false)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ApiOpenAPIRoute, nil))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+// TestGeneratedSpecs fails when the documents embedded in the binaries are out of date
+func TestGeneratedSpecs(t *testing.T) {
+	specs := map[string]string{
+		"core-command":          CoreCommandSpec,
+		"core-data":             CoreDataSpec,
+		"core-metadata":         CoreMetadataSpec,
+		"support-notifications": SupportNotificationsSpec,
+		"support-scheduler":     SupportSchedulerSpec,
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			expected, err := ioutil.ReadFile("../../../openapi/v2/" + name + ".yaml")
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), spec, "run go generate ./internal/pkg/openapi")
+
+			_, err = BuildDocument(spec, nil, true, true)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func keys(object interface{}) []string {
+	var keys []string
+	for key := range object.(map[string]interface{}) {
+		keys = append(keys, key)
+	}
+	return keys
+}
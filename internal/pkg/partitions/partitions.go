@@ -0,0 +1,89 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package partitions holds the configuration of the partitioning of the event indexes of the busy devices by
+// core-data, and the statistics of their partitions.
+//
+// The events of a device are indexed by a single sorted set until the device has more than HotThreshold of them.
+// The device then becomes hot: its next events are indexed by partitions spanning Span of time each, so that no
+// index grows without bound and the old partitions are dropped whole once their events are deleted. The events
+// indexed before are left where they are, so neither the cold devices nor the promotion of a device pay for moving
+// index entries around.
+package partitions
+
+import (
+	"fmt"
+	"time"
+)
+
+// PartitionInfo configures the partitioning of the event indexes of the hot devices
+type PartitionInfo struct {
+	// HotThreshold is the number of events of a device from which its next events are indexed by partitions. The
+	// indexes of the devices which aren't hot yet aren't partitioned when 0.
+	HotThreshold int
+	// Span is the time range of the events of a partition, e.g. "1h". Changing it only applies to the next
+	// partitions.
+	Span string
+}
+
+// SpanDuration returns the Span of the partitions
+func (info PartitionInfo) SpanDuration() (time.Duration, error) {
+	span, err := time.ParseDuration(info.Span)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Span '%s': %s", info.Span, err.Error())
+	}
+	if span < time.Millisecond {
+		return 0, fmt.Errorf("Span '%s' must be at least 1ms", info.Span)
+	}
+	return span, nil
+}
+
+// Validate checks the HotThreshold and the Span
+func (info PartitionInfo) Validate() error {
+	if info.HotThreshold < 0 {
+		return fmt.Errorf("HotThreshold %d must not be negative", info.HotThreshold)
+	}
+	_, err := info.SpanDuration()
+	return err
+}
+
+// Partition is an index of the events of a hot device created from Start, in milliseconds, until the Start of the
+// next partition of the device
+type Partition struct {
+	Start  int64  `json:"start"`
+	Events uint32 `json:"events"`
+}
+
+// DevicePartitions describes how the events of a device are indexed
+type DevicePartitions struct {
+	DeviceName string `json:"deviceName"`
+	// Hot tells whether the device had more than the HotThreshold events, and its events since PromotedAt are
+	// indexed by Partitions
+	Hot        bool  `json:"hot"`
+	PromotedAt int64 `json:"promotedAt,omitempty"`
+	// ColdEvents is the number of events of the unpartitioned index, those created before PromotedAt when the
+	// device is hot
+	ColdEvents uint32      `json:"coldEvents"`
+	Partitions []Partition `json:"partitions,omitempty"`
+}
+
+// Events returns the number of events of the device
+func (d DevicePartitions) Events() uint32 {
+	count := d.ColdEvents
+	for _, p := range d.Partitions {
+		count += p.Events
+	}
+	return count
+}
+
+// Start returns the start of the partition of the span holding created, aligned on the span, but not before
+// promotedAt, both in milliseconds
+func Start(created int64, promotedAt int64, span int64) int64 {
+	start := created - created%span
+	if start < promotedAt {
+		return promotedAt
+	}
+	return start
+}
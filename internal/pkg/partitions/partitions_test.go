@@ -0,0 +1,48 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package partitions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionInfoValidate(t *testing.T) {
+	info := PartitionInfo{HotThreshold: 1000, Span: "30m"}
+	require.NoError(t, info.Validate())
+	span, err := info.SpanDuration()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, span)
+
+	tests := []struct {
+		name string
+		info PartitionInfo
+	}{
+		{"negative threshold", PartitionInfo{HotThreshold: -1, Span: "1h"}},
+		{"invalid span", PartitionInfo{Span: "hourly"}},
+		{"span below 1ms", PartitionInfo{Span: "100us"}},
+		{"zero span", PartitionInfo{Span: "0s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.info.Validate())
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	assert.Equal(t, int64(3000), Start(3500, 1500, 1000))
+	assert.Equal(t, int64(1500), Start(1700, 1500, 1000), "The first partition should not start before the promotion")
+	assert.Equal(t, int64(2000), Start(2000, 1500, 1000))
+}
+
+func TestDevicePartitionsEvents(t *testing.T) {
+	d := DevicePartitions{ColdEvents: 10, Partitions: []Partition{{Start: 2000, Events: 5}, {Start: 1000, Events: 7}}}
+	assert.Equal(t, uint32(22), d.Events())
+}
@@ -0,0 +1,196 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package problem provides the middleware shared by the services to respond to the failed requests with the problem
+// details of RFC 7807, whatever the format of the error written by the handler, so that the clients can handle the
+// errors of all the services the same way.
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+const (
+	// ContentType is the media type of the problem details
+	ContentType = "application/problem+json"
+	// DefaultType is the type of the problems which have no semantics beyond their HTTP status code
+	DefaultType = "about:blank"
+
+	retryAfterHeader = "Retry-After"
+)
+
+// ProblemDetailsInfo configures the problem details responses
+type ProblemDetailsInfo struct {
+	// Enabled responds to the failed requests with problem details rather than with the error body written by the
+	// handler, which the existing clients may rely on
+	Enabled bool
+}
+
+// Configuration is implemented by the service configurations that define the problem details responses.
+type Configuration interface {
+	// GetProblemDetails returns the service's current problem details configuration.
+	GetProblemDetails() ProblemDetailsInfo
+}
+
+// Details is the problem+json body of a failed request
+type Details struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Service is the key of the service which failed the request
+	Service string `json:"service"`
+	// ErrorCode is the EdgeX error kind of the status, e.g. NotFound, or its reason phrase without spaces
+	ErrorCode     string `json:"errorCode"`
+	CorrelationId string `json:"correlationId,omitempty"`
+	// Retryable tells whether the same request may succeed later
+	Retryable bool `json:"retryable"`
+	// RetryAfter is the number of seconds to wait before retrying, when the service tells it
+	RetryAfter int `json:"retryAfter,omitempty"`
+}
+
+// NewDetails returns the problem details of the request r failed with statusCode and the message detail
+func NewDetails(service string, statusCode int, detail string, header http.Header, r *http.Request) Details {
+	correlationId := correlation.FromContext(r.Context())
+	if correlationId == "" {
+		correlationId = header.Get(clients.CorrelationHeader)
+	}
+	retryAfter, _ := strconv.Atoi(header.Get(retryAfterHeader))
+	return Details{
+		Type:          DefaultType,
+		Title:         http.StatusText(statusCode),
+		Status:        statusCode,
+		Detail:        detail,
+		Instance:      r.URL.Path,
+		Service:       service,
+		ErrorCode:     ErrorCode(statusCode),
+		CorrelationId: correlationId,
+		Retryable:     Retryable(statusCode),
+		RetryAfter:    retryAfter,
+	}
+}
+
+// ErrorCode returns the EdgeX error kind of statusCode, or its reason phrase without spaces when no kind has it
+func ErrorCode(statusCode int) string {
+	if kind := errors.KindMapping(statusCode); kind != errors.KindUnknown {
+		return string(kind)
+	}
+	return strings.ReplaceAll(http.StatusText(statusCode), " ", "")
+}
+
+// Retryable tells whether a request failed with statusCode may succeed when sent again unchanged
+func Retryable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// problemWriter holds back the body of the failed responses, the other responses being written through
+type problemWriter struct {
+	http.ResponseWriter
+	statusCode int
+	failed     bool
+	body       bytes.Buffer
+}
+
+func (w *problemWriter) WriteHeader(statusCode int) {
+	if w.statusCode != 0 {
+		return
+	}
+	w.statusCode = statusCode
+	if statusCode >= http.StatusBadRequest {
+		w.failed = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *problemWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.failed {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends the response written through to the client, the held back body of a failed response being left for
+// the middleware to rewrite
+func (w *problemWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok && w.statusCode != 0 && !w.failed {
+		flusher.Flush()
+	}
+}
+
+// detail returns the message of the error body, either the message of a JSON response or the plain text written by
+// http.Error. It returns false for the other JSON bodies, such as the arrays of the batch requests, which carry more
+// than a message.
+func detail(body []byte) (string, bool) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return "", true
+	}
+	if trimmed[0] != '{' && trimmed[0] != '[' {
+		return string(trimmed), true
+	}
+	var response struct {
+		Message string `json:"message"`
+	}
+	if trimmed[0] == '[' || json.Unmarshal(trimmed, &response) != nil {
+		return "", false
+	}
+	return response.Message, true
+}
+
+// NewMiddleware returns a middleware that rewrites the responses of the failed requests, those with a status code of
+// 400 or more, into the problem details of service when enabled by configuration. The configuration is read on every
+// request so that it can be changed without a restart.
+func NewMiddleware(service string, configuration Configuration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !configuration.GetProblemDetails().Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			pw := &problemWriter{ResponseWriter: w}
+			next.ServeHTTP(pw, r)
+			if !pw.failed {
+				return
+			}
+
+			message, ok := detail(pw.body.Bytes())
+			if !ok {
+				w.WriteHeader(pw.statusCode)
+				_, _ = w.Write(pw.body.Bytes())
+				return
+			}
+			body, err := json.Marshal(NewDetails(service, pw.statusCode, message, w.Header(), r))
+			if err != nil {
+				w.WriteHeader(pw.statusCode)
+				_, _ = w.Write(pw.body.Bytes())
+				return
+			}
+			w.Header().Set(clients.ContentType, ContentType)
+			w.Header().Del("Content-Length")
+			w.WriteHeader(pw.statusCode)
+			_, _ = w.Write(body)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testService = "edgex-core-data"
+
+type problemConfig ProblemDetailsInfo
+
+func (c problemConfig) GetProblemDetails() ProblemDetailsInfo {
+	return ProblemDetailsInfo(c)
+}
+
+func serve(info ProblemDetailsInfo, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/device/name/d1", http.NoBody)
+	req.Header.Set(clients.CorrelationHeader, "correlation-1")
+	recorder := httptest.NewRecorder()
+	NewMiddleware(testService, problemConfig(info))(handler).ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestMiddleware(t *testing.T) {
+	jsonError := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(clients.ContentType, clients.ContentTypeJSON)
+		w.Header().Set(clients.CorrelationHeader, "correlation-1")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"apiVersion":"v2","message":"device d1 not found","statusCode":404}`))
+	}
+	textError := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		http.Error(w, "too many events", http.StatusTooManyRequests)
+	}
+
+	tests := []struct {
+		name              string
+		handler           http.HandlerFunc
+		expectedStatus    int
+		expectedDetail    string
+		expectedCode      string
+		expectedRetryable bool
+		expectedRetry     int
+	}{
+		{"JSON error", jsonError, http.StatusNotFound, "device d1 not found", "NotFound", false, 0},
+		{"Plain text error", textError, http.StatusTooManyRequests, "too many events", "TooManyRequests", true, 3},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := serve(ProblemDetailsInfo{Enabled: true}, testCase.handler)
+
+			assert.Equal(t, testCase.expectedStatus, recorder.Code)
+			assert.Equal(t, ContentType, recorder.Header().Get(clients.ContentType))
+			var details Details
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &details))
+			assert.Equal(t, DefaultType, details.Type)
+			assert.Equal(t, http.StatusText(testCase.expectedStatus), details.Title)
+			assert.Equal(t, testCase.expectedStatus, details.Status)
+			assert.Equal(t, testCase.expectedDetail, details.Detail)
+			assert.Equal(t, "/api/v2/device/name/d1", details.Instance)
+			assert.Equal(t, testService, details.Service)
+			assert.Equal(t, testCase.expectedCode, details.ErrorCode)
+			assert.Equal(t, testCase.expectedRetryable, details.Retryable)
+			assert.Equal(t, testCase.expectedRetry, details.RetryAfter)
+		})
+	}
+}
+
+func TestMiddlewareCorrelationId(t *testing.T) {
+	recorder := serve(ProblemDetailsInfo{Enabled: true}, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(clients.CorrelationHeader, "correlation-1")
+		http.Error(w, "invalid request", http.StatusBadRequest)
+	})
+
+	var details Details
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &details))
+	assert.Equal(t, "correlation-1", details.CorrelationId)
+	assert.Equal(t, "ContractInvalid", details.ErrorCode)
+}
+
+func TestMiddlewarePassThrough(t *testing.T) {
+	batch := `[{"apiVersion":"v2","statusCode":400,"message":"invalid"}]`
+	tests := []struct {
+		name           string
+		info           ProblemDetailsInfo
+		handler        http.HandlerFunc
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"Disabled", ProblemDetailsInfo{}, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+		}, http.StatusBadRequest, "invalid request\n"},
+		{"Success", ProblemDetailsInfo{Enabled: true}, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("pong"))
+		}, http.StatusOK, "pong"},
+		{"Created", ProblemDetailsInfo{Enabled: true}, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+		}, http.StatusCreated, `{"id":"1"}`},
+		{"Batch response", ProblemDetailsInfo{Enabled: true}, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(batch))
+		}, http.StatusBadRequest, batch},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := serve(testCase.info, testCase.handler)
+
+			assert.Equal(t, testCase.expectedStatus, recorder.Code)
+			assert.Equal(t, testCase.expectedBody, recorder.Body.String())
+			assert.NotEqual(t, ContentType, recorder.Header().Get(clients.ContentType))
+		})
+	}
+}
+
+func TestMiddlewareFlush(t *testing.T) {
+	recorder := serve(ProblemDetailsInfo{Enabled: true}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}\n"))
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok, "The streamed responses should be flushed through the middleware")
+		flusher.Flush()
+	})
+	assert.True(t, recorder.Flushed)
+
+	recorder = serve(ProblemDetailsInfo{Enabled: true}, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		w.(http.Flusher).Flush()
+	})
+	assert.Equal(t, ContentType, recorder.Header().Get(clients.ContentType), "A failed response should still be rewritten once flushed")
+}
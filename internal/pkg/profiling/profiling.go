@@ -0,0 +1,174 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package profiling exposes the pprof endpoints and a summary of the memory statistics of the Go runtime, so that the
+// memory growth of a service running for weeks on a gateway can be diagnosed without rebuilding it with debug flags.
+// The endpoints are served while the Profiling configuration of the service's Writable section is enabled, so they
+// can be switched on and off at runtime, and only to the requests carrying the token read from the secret store.
+package profiling
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiPprofRoute is the prefix of the pprof endpoints, which net/http/pprof expects to be served under
+	ApiPprofRoute = "/debug/pprof/"
+	// ApiMemStatsRoute serves the MemStats summary
+	ApiMemStatsRoute = "/debug/memstats"
+	// TokenHeader carries the profiling token. It isn't the Authorization header, which carries the JWT verified by
+	// the services whose JWTAuth is enabled.
+	TokenHeader = "X-Profiling-Token"
+	// TokenKey is the key of the token in the secret at ProfilingInfo.SecretPath
+	TokenKey = "token"
+)
+
+// ProfilingInfo configures the profiling endpoints of a service
+type ProfilingInfo struct {
+	// Enabled serves the profiling endpoints, which respond 404 (Not Found) otherwise
+	Enabled bool
+	// SecretPath is the path of the secret whose token entry the requests must carry in the X-Profiling-Token header.
+	// The endpoints respond 503 (Service Unavailable) while the secret or its token is missing.
+	SecretPath string
+}
+
+// Configuration is implemented by the service configurations that define the profiling endpoints
+type Configuration interface {
+	// GetProfiling returns the service's current profiling configuration
+	GetProfiling() ProfilingInfo
+}
+
+// SecretProvider reads the secrets of the service's secret store, e.g. a bootstrap interfaces.SecretProvider
+type SecretProvider interface {
+	GetSecrets(path string, keys ...string) (map[string]string, error)
+}
+
+// MemStats summarizes the runtime.MemStats used to diagnose a memory growth
+type MemStats struct {
+	// Alloc is the number of bytes of the allocated heap objects
+	Alloc uint64 `json:"alloc"`
+	// TotalAlloc is the cumulative number of bytes allocated for the heap objects
+	TotalAlloc uint64 `json:"totalAlloc"`
+	// Sys is the number of bytes of memory obtained from the OS
+	Sys uint64 `json:"sys"`
+	// HeapObjects is the number of allocated heap objects
+	HeapObjects uint64 `json:"heapObjects"`
+	// HeapInuse is the number of bytes in the in-use spans of the heap
+	HeapInuse uint64 `json:"heapInuse"`
+	// HeapIdle is the number of bytes in the idle spans of the heap
+	HeapIdle uint64 `json:"heapIdle"`
+	// HeapReleased is the number of bytes of the idle spans returned to the OS
+	HeapReleased uint64 `json:"heapReleased"`
+	// StackInuse is the number of bytes of the goroutine stacks
+	StackInuse uint64 `json:"stackInuse"`
+	// Goroutines is the number of goroutines, whose leaks often cause the memory growth
+	Goroutines int `json:"goroutines"`
+	// NumGC is the number of completed GC cycles
+	NumGC uint32 `json:"numGC"`
+	// LastGC is the time the last GC cycle completed, zero when there was none
+	LastGC time.Time `json:"lastGC"`
+	// PauseTotal is the cumulative duration of the GC pauses, in nanoseconds
+	PauseTotal uint64 `json:"pauseTotal"`
+	// GCCPUFraction is the fraction of the CPU time used by the GC since the service started
+	GCCPUFraction float64 `json:"gcCPUFraction"`
+}
+
+// ReadMemStats reads the MemStats of the running service
+func ReadMemStats() MemStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	stats := MemStats{
+		Alloc:         m.Alloc,
+		TotalAlloc:    m.TotalAlloc,
+		Sys:           m.Sys,
+		HeapObjects:   m.HeapObjects,
+		HeapInuse:     m.HeapInuse,
+		HeapIdle:      m.HeapIdle,
+		HeapReleased:  m.HeapReleased,
+		StackInuse:    m.StackInuse,
+		Goroutines:    runtime.NumGoroutine(),
+		NumGC:         m.NumGC,
+		PauseTotal:    m.PauseTotalNs,
+		GCCPUFraction: m.GCCPUFraction,
+	}
+	if m.LastGC > 0 {
+		stats.LastGC = time.Unix(0, int64(m.LastGC)).UTC()
+	}
+	return stats
+}
+
+// LoadRoutes adds the profiling endpoints to router, guarded by the configuration and the token read from secrets on
+// each request, so that a rotated token or a disabled configuration applies at once
+func LoadRoutes(router *mux.Router, lc logger.LoggingClient, configuration Configuration, secrets SecretProvider) {
+	g := guard{lc: lc, configuration: configuration, secrets: secrets}
+
+	router.Handle(ApiMemStatsRoute, g.handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		pkg.Encode(ReadMemStats(), w, lc)
+	}))).Methods(http.MethodGet)
+
+	router.Handle(ApiPprofRoute+"cmdline", g.handler(http.HandlerFunc(pprof.Cmdline))).Methods(http.MethodGet)
+	router.Handle(ApiPprofRoute+"profile", g.handler(http.HandlerFunc(pprof.Profile))).Methods(http.MethodGet)
+	router.Handle(ApiPprofRoute+"symbol", g.handler(http.HandlerFunc(pprof.Symbol))).Methods(http.MethodGet, http.MethodPost)
+	router.Handle(ApiPprofRoute+"trace", g.handler(http.HandlerFunc(pprof.Trace))).Methods(http.MethodGet)
+	// the index serves the named profiles too, such as heap, goroutine and allocs
+	router.PathPrefix(ApiPprofRoute).Handler(g.handler(http.HandlerFunc(pprof.Index))).Methods(http.MethodGet)
+}
+
+// guard serves the profiling endpoints while they are enabled, to the requests carrying the token
+type guard struct {
+	lc            logger.LoggingClient
+	configuration Configuration
+	secrets       SecretProvider
+}
+
+func (g guard) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info := g.configuration.GetProfiling()
+		if !info.Enabled {
+			http.NotFound(w, r)
+			return
+		}
+
+		token, err := g.token(info)
+		if err != nil {
+			g.lc.Error(fmt.Sprintf("unable to serve %s: %s", r.URL.Path, err.Error()))
+			http.Error(w, "profiling token unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(TokenHeader)), []byte(token)) != 1 {
+			g.lc.Warn(fmt.Sprintf("rejecting %s %s from %s: invalid profiling token", r.Method, r.URL.Path, r.RemoteAddr))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		g.lc.Info(fmt.Sprintf("serving %s to %s", r.URL.Path, r.RemoteAddr))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// token reads the token of the secret at info.SecretPath, which must not be empty
+func (g guard) token(info ProfilingInfo) (string, error) {
+	if info.SecretPath == "" {
+		return "", fmt.Errorf("Profiling.SecretPath is not set")
+	}
+	secrets, err := g.secrets.GetSecrets(info.SecretPath, TokenKey)
+	if err != nil {
+		return "", fmt.Errorf("could not read the secret %s: %s", info.SecretPath, err.Error())
+	}
+	if secrets[TokenKey] == "" {
+		return "", fmt.Errorf("the %s of the secret %s is empty", TokenKey, info.SecretPath)
+	}
+	return secrets[TokenKey], nil
+}
@@ -0,0 +1,98 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiling
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSecretPath = "profiling"
+	testToken      = "s3cr3t"
+)
+
+type profilingConfig ProfilingInfo
+
+func (c profilingConfig) GetProfiling() ProfilingInfo {
+	return ProfilingInfo(c)
+}
+
+type secretProvider map[string]map[string]string
+
+func (p secretProvider) GetSecrets(path string, _ ...string) (map[string]string, error) {
+	secrets, ok := p[path]
+	if !ok {
+		return nil, errors.New("secret not found")
+	}
+	return secrets, nil
+}
+
+func TestLoadRoutes(t *testing.T) {
+	enabled := ProfilingInfo{Enabled: true, SecretPath: testSecretPath}
+	secrets := secretProvider{testSecretPath: {TokenKey: testToken}}
+
+	tests := []struct {
+		name           string
+		info           ProfilingInfo
+		secrets        secretProvider
+		path           string
+		token          string
+		expectedStatus int
+	}{
+		{"Valid - memstats", enabled, secrets, ApiMemStatsRoute, testToken, http.StatusOK},
+		{"Valid - pprof index", enabled, secrets, ApiPprofRoute, testToken, http.StatusOK},
+		{"Valid - heap profile", enabled, secrets, ApiPprofRoute + "heap", testToken, http.StatusOK},
+		{"Valid - cmdline", enabled, secrets, ApiPprofRoute + "cmdline", testToken, http.StatusOK},
+		{"Disabled", ProfilingInfo{SecretPath: testSecretPath}, secrets, ApiMemStatsRoute, testToken, http.StatusNotFound},
+		{"Invalid - no token", enabled, secrets, ApiMemStatsRoute, "", http.StatusUnauthorized},
+		{"Invalid - wrong token", enabled, secrets, ApiPprofRoute + "heap", "wrong", http.StatusUnauthorized},
+		{"Invalid - no secret path", ProfilingInfo{Enabled: true}, secrets, ApiMemStatsRoute, testToken, http.StatusServiceUnavailable},
+		{"Invalid - secret not found", enabled, secretProvider{}, ApiMemStatsRoute, testToken, http.StatusServiceUnavailable},
+		{"Invalid - empty token secret", enabled, secretProvider{testSecretPath: {TokenKey: ""}}, ApiMemStatsRoute, "", http.StatusServiceUnavailable},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			LoadRoutes(router, logger.NewMockClient(), profilingConfig(testCase.info), testCase.secrets)
+
+			req := httptest.NewRequest(http.MethodGet, testCase.path, http.NoBody)
+			if testCase.token != "" {
+				req.Header.Set(TokenHeader, testCase.token)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, testCase.expectedStatus, recorder.Code, recorder.Body.String())
+		})
+	}
+}
+
+func TestMemStats(t *testing.T) {
+	router := mux.NewRouter()
+	LoadRoutes(router, logger.NewMockClient(), profilingConfig{Enabled: true, SecretPath: testSecretPath}, secretProvider{testSecretPath: {TokenKey: testToken}})
+
+	req := httptest.NewRequest(http.MethodGet, ApiMemStatsRoute, http.NoBody)
+	req.Header.Set(TokenHeader, testToken)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var stats MemStats
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &stats))
+	assert.NotZero(t, stats.Sys)
+	assert.NotZero(t, stats.HeapInuse)
+	assert.NotZero(t, stats.Goroutines)
+}
@@ -0,0 +1,117 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package quality carries the data quality of the readings stored by core-data, following the severities of the OPC UA
+// status codes, and whether their value is null rather than an empty string.
+package quality
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/schema"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Qualities of a reading
+const (
+	// Good is the quality of a reading whose value can be used, and of the readings reported without a quality
+	Good = "good"
+	// Uncertain is the quality of a reading whose value may be inaccurate, e.g. a sensor out of calibration
+	Uncertain = "uncertain"
+	// Bad is the quality of a reading whose value can't be used, e.g. a failed sensor or a communication error
+	Bad = "bad"
+	// Stale is the quality of a reading whose value is the last known one, no longer updated by the device
+	Stale = "stale"
+)
+
+// ExcludeQuality is the query parameter listing, comma separated, the qualities of the readings left out of a query
+const ExcludeQuality = "excludeQuality"
+
+// Qualities lists the qualities in decreasing order of usability
+var Qualities = []string{Good, Uncertain, Bad, Stale}
+
+// Annotation is the quality of a reading and whether its value is null. The zero value annotates a good reading with
+// a value.
+type Annotation struct {
+	Quality string `json:"quality,omitempty"`
+	Null    bool   `json:"null,omitempty"`
+}
+
+// IsZero returns whether the annotation describes a good reading with a value, which needs not be stored
+func (a Annotation) IsZero() bool {
+	return (a.Quality == "" || a.Quality == Good) && !a.Null
+}
+
+// Validate returns an error when quality is not one of Qualities. An empty quality is a good one.
+func Validate(quality string) error {
+	if quality == "" {
+		return nil
+	}
+	for _, q := range Qualities {
+		if quality == q {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown quality %s, expected one of %s", quality, strings.Join(Qualities, ", "))
+}
+
+// ParseExcluded returns the qualities listed by the value of the ExcludeQuality query parameter. Good readings are
+// the default and can't be excluded.
+func ParseExcluded(value string) ([]string, error) {
+	var excluded []string
+	for _, quality := range strings.Split(value, ",") {
+		quality = strings.ToLower(strings.TrimSpace(quality))
+		if quality == "" {
+			continue
+		}
+		if err := Validate(quality); err != nil {
+			return nil, err
+		}
+		if quality == Good {
+			return nil, fmt.Errorf("good readings can't be excluded")
+		}
+		excluded = append(excluded, quality)
+	}
+	return excluded, nil
+}
+
+// Reading is a reading along with its annotation, as returned by the reading queries of core-data
+type Reading struct {
+	dtos.BaseReading `json:",inline"`
+	Annotation       `json:",inline"`
+}
+
+// MultiReadingsResponse is the response of the reading queries of core-data
+type MultiReadingsResponse struct {
+	common.BaseResponse `json:",inline"`
+	Readings            []Reading `json:"readings"`
+}
+
+// NewMultiReadingsResponse creates the response of a reading query
+func NewMultiReadingsResponse(requestId string, message string, statusCode int, readings []Reading) MultiReadingsResponse {
+	return MultiReadingsResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		Readings:     readings,
+	}
+}
+
+// ExtendEventSchema adds the quality of the readings to the schema of the add event requests, and allows their null
+// values, both of which core-data accepts on top of the AddEventRequest DTO
+func ExtendEventSchema(s *schema.Schema) {
+	reading := s.Property("event", "readings")
+	if reading == nil {
+		return
+	}
+	reading.Properties["quality"] = &schema.Schema{
+		Type:        schema.String,
+		Description: fmt.Sprintf("Quality of the reading, one of %s ignoring case, %s by default", strings.Join(Qualities, ", "), Good),
+	}
+	if value, ok := reading.Properties["value"]; ok {
+		value.Nullable = true
+	}
+}
@@ -0,0 +1,60 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package quality
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/schema"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExcluded(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      []string
+		errorExpected bool
+	}{
+		{"Valid - no quality", "", nil, false},
+		{"Valid - one quality", "bad", []string{Bad}, false},
+		{"Valid - several qualities", " Bad, stale ,", []string{Bad, Stale}, false},
+		{"Invalid - unknown quality", "bad,excellent", nil, true},
+		{"Invalid - good quality", "good", nil, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			excluded, err := ParseExcluded(testCase.value)
+			if testCase.errorExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, excluded)
+		})
+	}
+}
+
+func TestAnnotationIsZero(t *testing.T) {
+	assert.True(t, Annotation{}.IsZero())
+	assert.True(t, Annotation{Quality: Good}.IsZero())
+	assert.False(t, Annotation{Quality: Uncertain}.IsZero())
+	assert.False(t, Annotation{Null: true}.IsZero())
+}
+
+func TestExtendEventSchema(t *testing.T) {
+	s := schema.Generate(requests.AddEventRequest{})
+	ExtendEventSchema(s)
+
+	reading := s.Property("event", "readings")
+	require.NotNil(t, reading)
+	assert.Equal(t, schema.String, reading.Properties["quality"].Type)
+	assert.True(t, reading.Properties["value"].Nullable)
+}
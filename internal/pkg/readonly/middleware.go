@@ -0,0 +1,79 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package readonly provides the middleware shared by the services to reject the mutating requests while the
+// service is in read-only mode, e.g. during a backup or a migration, or while the gateway is quarantined.
+package readonly
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// ReadOnlyInfo holds the read-only mode of a service.
+type ReadOnlyInfo struct {
+	// Enabled rejects the POST, PUT, PATCH and DELETE requests with 503 (Service Unavailable).
+	Enabled bool
+	// Reason is reported to the clients of the rejected requests, e.g. "backup in progress".
+	Reason string
+	// ExemptPaths are served even in read-only mode.
+	ExemptPaths []string
+}
+
+// Configuration is implemented by the service configurations that define a read-only mode.
+type Configuration interface {
+	// GetReadOnly returns the service's current read-only mode.
+	GetReadOnly() ReadOnlyInfo
+}
+
+// NewMiddleware returns a middleware that responds 503 (Service Unavailable), with the reason, to the mutating
+// requests while configuration is in read-only mode. The mode is read on every request so that the service can be
+// put in and out of read-only mode through its Writable configuration without a restart.
+func NewMiddleware(lc logger.LoggingClient, configuration Configuration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			info := configuration.GetReadOnly()
+			if !info.Enabled || !isMutating(r.Method) || contains(info.ExemptPaths, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			message := "service is in read-only mode"
+			if info.Reason != "" {
+				message += ": " + info.Reason
+			}
+			lc.Debug(fmt.Sprintf("rejecting %s %s: %s", r.Method, r.URL.Path, message))
+			http.Error(w, message, http.StatusServiceUnavailable)
+		})
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
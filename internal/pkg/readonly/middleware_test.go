@@ -0,0 +1,69 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package readonly
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type readOnlyConfig ReadOnlyInfo
+
+func (c readOnlyConfig) GetReadOnly() ReadOnlyInfo {
+	return ReadOnlyInfo(c)
+}
+
+func TestMiddleware(t *testing.T) {
+	readOnly := ReadOnlyInfo{Enabled: true, Reason: "backup in progress", ExemptPaths: []string{"/api/v2/ping"}}
+
+	tests := []struct {
+		name           string
+		info           ReadOnlyInfo
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{"Disabled", ReadOnlyInfo{Reason: "backup in progress"}, http.MethodPost, "/api/v2/device", http.StatusOK},
+		{"GET served", readOnly, http.MethodGet, "/api/v2/device/all", http.StatusOK},
+		{"OPTIONS served", readOnly, http.MethodOptions, "/api/v2/device", http.StatusOK},
+		{"POST rejected", readOnly, http.MethodPost, "/api/v2/device", http.StatusServiceUnavailable},
+		{"PUT rejected", readOnly, http.MethodPut, "/api/v2/device", http.StatusServiceUnavailable},
+		{"PATCH rejected", readOnly, http.MethodPatch, "/api/v2/device", http.StatusServiceUnavailable},
+		{"DELETE rejected", readOnly, http.MethodDelete, "/api/v2/device/name/d1", http.StatusServiceUnavailable},
+		{"Exempt path served", readOnly, http.MethodPost, "/api/v2/ping", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewMiddleware(logger.MockLogger{}, readOnlyConfig(tt.info))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				assert.Contains(t, recorder.Body.String(), tt.info.Reason, "the response should report the reason")
+			}
+		})
+	}
+}
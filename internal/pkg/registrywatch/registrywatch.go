@@ -0,0 +1,247 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package registrywatch keeps a service registered with the registry while it runs: the registration is checked
+// periodically and made again once lost, e.g. when Consul is restarted without its data, and Consul is told to
+// deregister the service whose health check has been failing for a while, e.g. after the service crashed without
+// deregistering. The state of the registration is returned by the /ping route.
+package registrywatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	registryTypes "github.com/edgexfoundry/go-mod-registry/v2/pkg/types"
+)
+
+const (
+	// DefaultInterval is the interval of the checks of the registration when RegistryWatchInfo doesn't set one
+	DefaultInterval = 30 * time.Second
+	// minDeregisterCriticalAfter is the shortest DeregisterCriticalAfter Consul accepts
+	minDeregisterCriticalAfter = time.Minute
+)
+
+// RegistryWatchInfo configures the watch of the registration of the service, when the registry is used
+type RegistryWatchInfo struct {
+	// Enabled checks the registration every Interval and registers the service again once lost
+	Enabled bool
+	// Interval is the time between the checks of the registration, e.g. "30s", DefaultInterval when empty
+	Interval string
+	// DeregisterCriticalAfter has Consul deregister the service once its health check has been failing that long, e.g.
+	// "5m", at least a minute; the service is only deregistered on shutdown when empty
+	DeregisterCriticalAfter string
+}
+
+// Validate checks the durations
+func (info RegistryWatchInfo) Validate() error {
+	_, _, err := info.Durations()
+	return err
+}
+
+// Durations returns the interval of the checks and how long the health check fails before the service is
+// deregistered, 0 when never
+func (info RegistryWatchInfo) Durations() (interval time.Duration, deregisterAfter time.Duration, err error) {
+	interval = DefaultInterval
+	if info.Interval != "" {
+		interval, err = time.ParseDuration(info.Interval)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid Interval %s: %s", info.Interval, err.Error())
+		}
+		if interval <= 0 {
+			return 0, 0, fmt.Errorf("Interval %s must be positive", info.Interval)
+		}
+	}
+	if info.DeregisterCriticalAfter != "" {
+		deregisterAfter, err = time.ParseDuration(info.DeregisterCriticalAfter)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid DeregisterCriticalAfter %s: %s", info.DeregisterCriticalAfter, err.Error())
+		}
+		if deregisterAfter < minDeregisterCriticalAfter {
+			return 0, 0, fmt.Errorf("DeregisterCriticalAfter %s must be at least %s", info.DeregisterCriticalAfter, minDeregisterCriticalAfter)
+		}
+	}
+	return interval, deregisterAfter, nil
+}
+
+// Registry is the client of the registry the service is registered with, e.g. a registry.Client
+type Registry interface {
+	IsAlive() bool
+	Register() error
+	GetServiceEndpoint(serviceId string) (registryTypes.ServiceEndpoint, error)
+}
+
+// Status is the state of the registration of the service as of the last check
+type Status struct {
+	// Reachable is whether the registry responded
+	Reachable bool `json:"reachable"`
+	// Registered is whether the service is registered, possibly again by the last check
+	Registered bool `json:"registered"`
+	// LastCheck is the time of the last check, in milliseconds since the epoch
+	LastCheck int64 `json:"lastCheck"`
+	// Reregistrations is the number of times the service was registered again since started
+	Reregistrations int `json:"reregistrations"`
+	// LastError is the error of the last check, if it failed
+	LastError string `json:"lastError,omitempty"`
+}
+
+// PingResponse is the response of the /ping route along with the state of the registration of the service
+type PingResponse struct {
+	commonDTO.PingResponse `json:",inline"`
+	Registry               *Status `json:"registry,omitempty"`
+}
+
+// Deregistration is the Consul health check of a service deregistering it once failing for a while
+type Deregistration struct {
+	// Url is the base URL of the Consul agent, e.g. http://localhost:8500
+	Url string
+	// ServiceKey is the id of the service in Consul
+	ServiceKey string
+	// CheckUrl is the URL Consul requests to check the health of the service
+	CheckUrl string
+	// Interval is the interval of the health check, e.g. "10s"
+	Interval string
+	// After is how long the health check fails before the service is deregistered
+	After time.Duration
+}
+
+// checkRegistration is the body of the Consul request registering a health check
+type checkRegistration struct {
+	ID                             string
+	Name                           string
+	ServiceID                      string
+	HTTP                           string
+	Interval                       string
+	DeregisterCriticalServiceAfter string
+}
+
+// register registers the health check with the Consul agent, replacing the previous one of the service. The check
+// is removed along with the service when the service is deregistered.
+func (d Deregistration) register(client *http.Client) error {
+	body, err := json.Marshal(checkRegistration{
+		ID:                             d.ServiceKey + "-deregistration",
+		Name:                           "Deregistration Check: " + d.ServiceKey,
+		ServiceID:                      d.ServiceKey,
+		HTTP:                           d.CheckUrl,
+		Interval:                       d.Interval,
+		DeregisterCriticalServiceAfter: d.After.String(),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, d.Url+"/v1/agent/check/register", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(clients.ContentType, clients.ContentTypeJSON)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Consul responded %s to the registration of the deregistration check", resp.Status)
+	}
+	return nil
+}
+
+// Watcher checks the registration of a service and registers it again once lost
+type Watcher struct {
+	lc             logger.LoggingClient
+	registry       Registry
+	serviceKey     string
+	deregistration *Deregistration
+	httpClient     *http.Client
+
+	mutex  sync.RWMutex
+	status Status
+}
+
+// NewWatcher returns the Watcher of the registration of serviceKey with registry, which also registers
+// deregistration, if not nil, each time it registers the service
+func NewWatcher(lc logger.LoggingClient, registry Registry, serviceKey string, deregistration *Deregistration) *Watcher {
+	return &Watcher{
+		lc:             lc,
+		registry:       registry,
+		serviceKey:     serviceKey,
+		deregistration: deregistration,
+		httpClient:     &http.Client{Timeout: 10 * time.Second},
+		status:         Status{Reachable: true, Registered: true},
+	}
+}
+
+// Status returns the state of the registration as of the last check
+func (w *Watcher) Status() Status {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.status
+}
+
+// Check checks that the service is still registered, registering it again if the registry lost its registration
+func (w *Watcher) Check() {
+	status := w.Status()
+	status.LastCheck = common.MakeTimestamp()
+	status.LastError = ""
+
+	if !w.registry.IsAlive() {
+		if status.Reachable {
+			w.lc.Warn("the registry is unreachable, the registration of the service will be checked once it is back")
+		}
+		status.Reachable = false
+		status.LastError = "registry unreachable"
+		w.setStatus(status)
+		return
+	}
+	status.Reachable = true
+
+	if _, err := w.registry.GetServiceEndpoint(w.serviceKey); err == nil {
+		status.Registered = true
+		w.setStatus(status)
+		return
+	}
+
+	w.lc.Warn(fmt.Sprintf("%s is no longer registered with the registry, registering it again", w.serviceKey))
+	if err := w.registry.Register(); err != nil {
+		status.Registered = false
+		status.LastError = fmt.Sprintf("failed to register again: %s", err.Error())
+		w.lc.Error(status.LastError)
+		w.setStatus(status)
+		return
+	}
+	status.Registered = true
+	status.Reregistrations++
+	w.lc.Info(fmt.Sprintf("%s registered again with the registry", w.serviceKey))
+	if err := w.RegisterDeregistration(); err != nil {
+		status.LastError = err.Error()
+	}
+	w.setStatus(status)
+}
+
+// RegisterDeregistration registers the deregistration check of the service, if any
+func (w *Watcher) RegisterDeregistration() error {
+	if w.deregistration == nil {
+		return nil
+	}
+	if err := w.deregistration.register(w.httpClient); err != nil {
+		err = fmt.Errorf("failed to register the deregistration check: %s", err.Error())
+		w.lc.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+func (w *Watcher) setStatus(status Status) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.status = status
+}
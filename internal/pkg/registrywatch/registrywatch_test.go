@@ -0,0 +1,177 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registrywatch
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	registryTypes "github.com/edgexfoundry/go-mod-registry/v2/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type registry struct {
+	alive       bool
+	registered  bool
+	registerErr error
+	registers   int
+}
+
+func (r *registry) IsAlive() bool {
+	return r.alive
+}
+
+func (r *registry) Register() error {
+	r.registers++
+	if r.registerErr != nil {
+		return r.registerErr
+	}
+	r.registered = true
+	return nil
+}
+
+func (r *registry) GetServiceEndpoint(serviceId string) (registryTypes.ServiceEndpoint, error) {
+	if !r.registered {
+		return registryTypes.ServiceEndpoint{}, errors.New(serviceId + " service endpoint not found")
+	}
+	return registryTypes.ServiceEndpoint{ServiceId: serviceId}, nil
+}
+
+func TestRegistryWatchInfoValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		info          RegistryWatchInfo
+		expectedError bool
+	}{
+		{"defaults", RegistryWatchInfo{Enabled: true}, false},
+		{"valid", RegistryWatchInfo{Enabled: true, Interval: "10s", DeregisterCriticalAfter: "5m"}, false},
+		{"invalid interval", RegistryWatchInfo{Interval: "often"}, true},
+		{"zero interval", RegistryWatchInfo{Interval: "0s"}, true},
+		{"invalid deregistration", RegistryWatchInfo{DeregisterCriticalAfter: "soon"}, true},
+		{"deregistration under a minute", RegistryWatchInfo{DeregisterCriticalAfter: "30s"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.info.Validate()
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	interval, deregisterAfter, err := RegistryWatchInfo{}.Durations()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultInterval, interval)
+	assert.Zero(t, deregisterAfter)
+}
+
+func TestCheck(t *testing.T) {
+	r := &registry{alive: true, registered: true}
+	w := NewWatcher(logger.NewMockClient(), r, "edgex-core-data", nil)
+
+	w.Check()
+	status := w.Status()
+	assert.True(t, status.Reachable)
+	assert.True(t, status.Registered)
+	assert.NotZero(t, status.LastCheck)
+	assert.Zero(t, r.registers)
+
+	// the registry restarted without its data
+	r.registered = false
+	w.Check()
+	status = w.Status()
+	assert.True(t, status.Registered)
+	assert.Equal(t, 1, status.Reregistrations)
+	assert.Equal(t, 1, r.registers)
+
+	r.alive = false
+	w.Check()
+	status = w.Status()
+	assert.False(t, status.Reachable)
+	assert.NotEmpty(t, status.LastError)
+	assert.Equal(t, 1, r.registers, "no registration while the registry is unreachable")
+
+	r.alive = true
+	r.registered = false
+	r.registerErr = errors.New("ACL not found")
+	w.Check()
+	status = w.Status()
+	assert.True(t, status.Reachable)
+	assert.False(t, status.Registered)
+	assert.Contains(t, status.LastError, "ACL not found")
+	assert.Equal(t, 1, status.Reregistrations)
+
+	r.registerErr = nil
+	w.Check()
+	status = w.Status()
+	assert.True(t, status.Registered)
+	assert.Empty(t, status.LastError)
+	assert.Equal(t, 2, status.Reregistrations)
+}
+
+func TestDeregistration(t *testing.T) {
+	var received []checkRegistration
+	responseCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v1/agent/check/register", r.URL.Path)
+		var check checkRegistration
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&check))
+		received = append(received, check)
+		w.WriteHeader(responseCode)
+	}))
+	defer server.Close()
+
+	r := &registry{alive: true, registered: true}
+	w := NewWatcher(logger.NewMockClient(), r, "edgex-core-data", &Deregistration{
+		Url:        server.URL,
+		ServiceKey: "edgex-core-data",
+		CheckUrl:   "http://edgex-core-data:48080/api/v1/ping",
+		Interval:   "10s",
+		After:      10 * time.Minute,
+	})
+	require.NoError(t, w.RegisterDeregistration())
+	require.Len(t, received, 1)
+	assert.Equal(t, checkRegistration{
+		ID:                             "edgex-core-data-deregistration",
+		Name:                           "Deregistration Check: edgex-core-data",
+		ServiceID:                      "edgex-core-data",
+		HTTP:                           "http://edgex-core-data:48080/api/v1/ping",
+		Interval:                       "10s",
+		DeregisterCriticalServiceAfter: "10m0s",
+	}, received[0])
+
+	// registered again along with the service
+	r.registered = false
+	w.Check()
+	assert.Len(t, received, 2)
+	assert.Empty(t, w.Status().LastError)
+
+	responseCode = http.StatusInternalServerError
+	assert.Error(t, w.RegisterDeregistration())
+}
+
+func TestPingResponse(t *testing.T) {
+	response := PingResponse{PingResponse: common.NewPingResponse(), Registry: &Status{Reachable: true, Registered: true}}
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Contains(t, decoded, "timestamp", "the fields of the ping response are kept at the top level")
+	assert.Contains(t, decoded, "apiVersion")
+	assert.Equal(t, true, decoded["registry"].(map[string]interface{})["registered"])
+}
@@ -0,0 +1,167 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package requestlimits provides the middleware shared by the services to reject oversized requests
+// before they are decoded by the handlers.
+package requestlimits
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	readingsField = "readings"
+	labelsField   = "labels"
+)
+
+// RequestLimitsInfo holds the request limits. A zero value disables the corresponding limit.
+type RequestLimitsInfo struct {
+	// MaxRequestSize is the maximum size of a request body in KB.
+	MaxRequestSize int64
+	// MaxReadingsPerEvent is the maximum number of entries in any "readings" array of a JSON or CBOR request.
+	MaxReadingsPerEvent int
+	// MaxLabels is the maximum number of entries in any "labels" array of a JSON or CBOR request.
+	MaxLabels int
+}
+
+// Configuration is implemented by the service configurations that define request limits.
+type Configuration interface {
+	// GetRequestLimits returns the service's current request limits.
+	GetRequestLimits() RequestLimitsInfo
+}
+
+// NewMiddleware returns a middleware that responds 413 (Request Entity Too Large) to requests that exceed
+// the limits returned by configuration. The limits are read on every request so that changes to the
+// service's Writable configuration are applied without a restart.
+func NewMiddleware(lc logger.LoggingClient, configuration Configuration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limits := configuration.GetRequestLimits()
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if limits.MaxRequestSize > 0 {
+				maxBytes := limits.MaxRequestSize * 1024
+				if r.ContentLength > maxBytes {
+					reject(lc, w, r, fmt.Sprintf("request body of %d bytes exceeds the limit of %d KB", r.ContentLength, limits.MaxRequestSize))
+					return
+				}
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+
+			unmarshal := unmarshalFunc(r)
+			if (limits.MaxReadingsPerEvent <= 0 && limits.MaxLabels <= 0) || unmarshal == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// The body has to be buffered to be counted; it is bounded by MaxRequestSize when that is set.
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				if limits.MaxRequestSize > 0 {
+					reject(lc, w, r, fmt.Sprintf("request body exceeds the limit of %d KB", limits.MaxRequestSize))
+					return
+				}
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			var document interface{}
+			if err := unmarshal(body, &document); err != nil {
+				// Leave malformed bodies to the handler so the client gets the usual 400 response
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if limits.MaxReadingsPerEvent > 0 {
+				if count := maxArrayLength(document, readingsField); count > limits.MaxReadingsPerEvent {
+					reject(lc, w, r, fmt.Sprintf("event with %d readings exceeds the limit of %d", count, limits.MaxReadingsPerEvent))
+					return
+				}
+			}
+			if limits.MaxLabels > 0 {
+				if count := maxArrayLength(document, labelsField); count > limits.MaxLabels {
+					reject(lc, w, r, fmt.Sprintf("%d labels exceeds the limit of %d", count, limits.MaxLabels))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// unmarshalFunc returns the function decoding the body of r according to its content type, or nil if the body is
+// neither JSON nor CBOR
+func unmarshalFunc(r *http.Request) func([]byte, interface{}) error {
+	contentType := r.Header.Get(clients.ContentType)
+	switch {
+	case contentType == "" || strings.HasPrefix(contentType, clients.ContentTypeJSON):
+		return json.Unmarshal
+	case strings.HasPrefix(contentType, clients.ContentTypeCBOR):
+		return cbor.Unmarshal
+	default:
+		return nil
+	}
+}
+
+// maxArrayLength returns the length of the longest array found under field anywhere in document, decoded from JSON
+// or from CBOR, whose maps have interface{} keys.
+func maxArrayLength(document interface{}, field string) int {
+	longest := 0
+	visit := func(key string, child interface{}) {
+		if array, ok := child.([]interface{}); ok && strings.EqualFold(key, field) && len(array) > longest {
+			longest = len(array)
+		}
+		if length := maxArrayLength(child, field); length > longest {
+			longest = length
+		}
+	}
+	switch value := document.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			visit(key, child)
+		}
+	case map[interface{}]interface{}:
+		for key, child := range value {
+			name, _ := key.(string)
+			visit(name, child)
+		}
+	case []interface{}:
+		for _, child := range value {
+			if length := maxArrayLength(child, field); length > longest {
+				longest = length
+			}
+		}
+	}
+	return longest
+}
+
+func reject(lc logger.LoggingClient, w http.ResponseWriter, r *http.Request, message string) {
+	lc.Error(fmt.Sprintf("rejecting %s %s: %s", r.Method, r.URL.Path, message))
+	http.Error(w, message, http.StatusRequestEntityTooLarge)
+}
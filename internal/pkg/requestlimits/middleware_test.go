@@ -0,0 +1,93 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package requestlimits
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type limitsConfig RequestLimitsInfo
+
+func (c limitsConfig) GetRequestLimits() RequestLimitsInfo {
+	return RequestLimitsInfo(c)
+}
+
+func TestMiddleware(t *testing.T) {
+	largeBody := `{"device":"d1","readings":[` + strings.Repeat(`{"value":"0123456789"},`, 100) + `{}]}`
+	validEvent := `{"apiVersion":"v2","event":{"deviceName":"d1","readings":[{"value":"1"},{"value":"2"}]}}`
+	bulkDevices := `[{"device":{"name":"d1","labels":["a","b","c"]}},{"device":{"name":"d2","labels":["a"]}}]`
+	cborEvent := toCBOR(t, validEvent)
+
+	tests := []struct {
+		name           string
+		limits         RequestLimitsInfo
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{"No limits", RequestLimitsInfo{}, clients.ContentTypeJSON, largeBody, http.StatusOK},
+		{"Within limits", RequestLimitsInfo{MaxRequestSize: 1, MaxReadingsPerEvent: 2, MaxLabels: 3}, clients.ContentTypeJSON, validEvent, http.StatusOK},
+		{"Body too large", RequestLimitsInfo{MaxRequestSize: 1}, clients.ContentTypeJSON, largeBody, http.StatusRequestEntityTooLarge},
+		{"Too many readings", RequestLimitsInfo{MaxReadingsPerEvent: 1}, clients.ContentTypeJSON, validEvent, http.StatusRequestEntityTooLarge},
+		{"Too many labels in bulk request", RequestLimitsInfo{MaxLabels: 2}, clients.ContentTypeJSON, bulkDevices, http.StatusRequestEntityTooLarge},
+		{"CBOR within limits", RequestLimitsInfo{MaxReadingsPerEvent: 2}, clients.ContentTypeCBOR, cborEvent, http.StatusOK},
+		{"Too many readings in CBOR", RequestLimitsInfo{MaxReadingsPerEvent: 1}, clients.ContentTypeCBOR, cborEvent, http.StatusRequestEntityTooLarge},
+		{"Malformed CBOR left to handler", RequestLimitsInfo{MaxReadingsPerEvent: 1}, clients.ContentTypeCBOR, "\xbf", http.StatusOK},
+		{"Readings not counted for other content", RequestLimitsInfo{MaxReadingsPerEvent: 1}, "text/plain", validEvent, http.StatusOK},
+		{"Malformed JSON left to handler", RequestLimitsInfo{MaxReadingsPerEvent: 1}, clients.ContentTypeJSON, `{"readings":[`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			handler := NewMiddleware(logger.MockLogger{}, limitsConfig(tt.limits))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				received = string(body)
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/api/v2/event", strings.NewReader(tt.body))
+			req.Header.Set(clients.ContentType, tt.contentType)
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.body, received, "handler should receive the complete body")
+			}
+		})
+	}
+}
+
+// toCBOR encodes the JSON document in CBOR, the way the device services encode the events
+func toCBOR(t *testing.T, document string) string {
+	var decoded interface{}
+	require.NoError(t, json.Unmarshal([]byte(document), &decoded))
+	encoded, err := cbor.Marshal(decoded)
+	require.NoError(t, err)
+	return string(encoded)
+}
@@ -0,0 +1,178 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiSchemaRoute lists the names of the schemas published by the service
+	ApiSchemaRoute = v2.ApiBase + "/schema"
+	// ApiSchemaByNameRoute returns the JSON Schema of a DTO by name
+	ApiSchemaByNameRoute = ApiSchemaRoute + "/{" + v2.Name + "}"
+)
+
+// MultiSchemaNamesResponse is the response listing the names of the schemas published by a service
+type MultiSchemaNamesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Names                  []string `json:"names"`
+}
+
+// Registry holds the schemas of the DTOs published by a service, which validate its request bodies
+type Registry struct {
+	lc      logger.LoggingClient
+	schemas map[string]*Schema
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry(lc logger.LoggingClient) *Registry {
+	return &Registry{
+		lc:      lc,
+		schemas: make(map[string]*Schema),
+	}
+}
+
+// Add publishes the schemas of dtos, those of their elements for slices of DTOs
+func (r *Registry) Add(dtos ...interface{}) {
+	for _, dto := range dtos {
+		r.add(dto)
+	}
+}
+
+func (r *Registry) add(dto interface{}) *Schema {
+	name := Name(dto)
+	if s, ok := r.schemas[name]; ok {
+		return s
+	}
+	t := reflect.TypeOf(dto)
+	if t.Kind() == reflect.Slice {
+		dto = reflect.Zero(t.Elem()).Interface()
+	}
+	s := Generate(dto)
+	r.schemas[name] = s
+	return s
+}
+
+// Schema returns the published schema named name, or nil when there is none. Changes to the returned schema apply
+// to the validation of the requests.
+func (r *Registry) Schema(name string) *Schema {
+	return r.schemas[name]
+}
+
+// ValidateRequest publishes the schema of dto and returns a handler validating the JSON request bodies against it
+// before calling next. dto is a slice for the bulk requests, whose elements are each validated. The bodies that
+// aren't JSON are left to next, as are those that can't be decoded so that the client gets the usual response.
+func (r *Registry) ValidateRequest(dto interface{}, next http.HandlerFunc) http.HandlerFunc {
+	s := r.add(dto)
+	if reflect.TypeOf(dto).Kind() == reflect.Slice {
+		s = &Schema{Title: s.Title, Type: Array, Items: s}
+	}
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Body == nil || req.Body == http.NoBody || !isJSON(req) {
+			next(w, req)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			r.reject(w, req, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to read request body", err))
+			return
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		document, err := Decode(body)
+		if err != nil {
+			next(w, req)
+			return
+		}
+		if err := s.Validate(document); err != nil {
+			r.reject(w, req, errors.NewCommonEdgeX(errors.KindContractInvalid, err.Error(), nil))
+			return
+		}
+		next(w, req)
+	}
+}
+
+// Decode decodes the JSON document data into an interface{} to be validated, keeping the numbers as json.Number
+func Decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// AllSchemas handles the request listing the names of the published schemas
+func (r *Registry) AllSchemas(w http.ResponseWriter, req *http.Request) {
+	names := make([]string, 0, len(r.schemas))
+	for name := range r.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	response := MultiSchemaNamesResponse{
+		BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+		Names:        names,
+	}
+	utils.WriteHttpHeader(w, req.Context(), http.StatusOK)
+	pkg.Encode(response, w, r.lc)
+}
+
+// SchemaByName handles the request returning the JSON Schema named by the path, as is so that it can be fed to the
+// client generators and validators
+func (r *Registry) SchemaByName(w http.ResponseWriter, req *http.Request) {
+	name := mux.Vars(req)[v2.Name]
+	s, ok := r.schemas[name]
+	if !ok {
+		r.reject(w, req, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("schema %s does not exist", name), nil))
+		return
+	}
+	utils.WriteHttpHeader(w, req.Context(), http.StatusOK)
+	pkg.Encode(s, w, r.lc)
+}
+
+func (r *Registry) reject(w http.ResponseWriter, req *http.Request, err errors.EdgeX) {
+	correlationId := correlation.FromContext(req.Context())
+	r.lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+	r.lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+	response := commonDTO.NewBaseResponse("", err.Message(), err.Code())
+	utils.WriteHttpHeader(w, req.Context(), err.Code())
+	pkg.Encode(response, w, r.lc)
+}
+
+func isJSON(req *http.Request) bool {
+	contentType := req.Header.Get(clients.ContentType)
+	return contentType == "" || strings.HasPrefix(contentType, clients.ContentTypeJSON)
+}
+
+// LoadRestRoutes adds the routes serving the schemas of registry to router
+func LoadRestRoutes(router *mux.Router, registry *Registry) {
+	router.HandleFunc(ApiSchemaRoute, registry.AllSchemas).Methods(http.MethodGet)
+	router.HandleFunc(ApiSchemaByNameRoute, registry.SchemaByName).Methods(http.MethodGet)
+}
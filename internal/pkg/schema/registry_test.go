@@ -0,0 +1,94 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequest(t *testing.T) {
+	registry := NewRegistry(logger.NewMockClient())
+	var received string
+	handler := registry.ValidateRequest([]testUpdateRequest{}, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusMultiStatus)
+	})
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{"Valid", clients.ContentTypeJSON, `[{"name":"d1"}]`, http.StatusMultiStatus},
+		{"Valid - no content type", "", `[{"name":"d1"}]`, http.StatusMultiStatus},
+		{"Invalid", clients.ContentTypeJSON, `[{"name":1}]`, http.StatusBadRequest},
+		{"Malformed JSON left to the handler", clients.ContentTypeJSON, `[{"name":`, http.StatusMultiStatus},
+		{"Not JSON", clients.ContentTypeCBOR, `[{"name":1}]`, http.StatusMultiStatus},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			received = ""
+			req := httptest.NewRequest(http.MethodPatch, v2.ApiDeviceRoute, strings.NewReader(testCase.body))
+			if testCase.contentType != "" {
+				req.Header.Set(clients.ContentType, testCase.contentType)
+			}
+			recorder := httptest.NewRecorder()
+			handler(recorder, req)
+
+			require.Equal(t, testCase.expectedStatus, recorder.Code)
+			if testCase.expectedStatus == http.StatusBadRequest {
+				var res commonDTO.BaseResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.Equal(t, http.StatusBadRequest, int(res.StatusCode))
+				assert.Contains(t, res.Message, "[0].name")
+				return
+			}
+			assert.Equal(t, testCase.body, received, "the handler must receive the whole body")
+		})
+	}
+}
+
+func TestSchemas(t *testing.T) {
+	registry := NewRegistry(logger.NewMockClient())
+	registry.Add(testAddEventRequest{}, []testUpdateRequest{})
+	router := mux.NewRouter()
+	LoadRestRoutes(router, registry)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ApiSchemaRoute, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var names MultiSchemaNamesResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &names))
+	assert.Equal(t, []string{"testAddEventRequest", "testUpdateRequest"}, names.Names)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ApiSchemaRoute+"/testUpdateRequest", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &schema))
+	assert.Equal(t, Draft, schema["$schema"])
+	assert.Equal(t, Object, schema["type"], "the schema of a DTO is published rather than that of the bulk request")
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ApiSchemaRoute+"/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,345 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema generates the JSON Schemas of the v2 DTOs from their Go structs, serves them from each service,
+// and validates the request bodies against them, so that the published schemas and the requests accepted by the
+// services can't drift apart.
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Draft is the JSON Schema version of the generated schemas
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Types of the JSON values
+const (
+	Object  = "object"
+	Array   = "array"
+	String  = "string"
+	Integer = "integer"
+	Number  = "number"
+	Boolean = "boolean"
+	Null    = "null"
+)
+
+// Validation rules of the validate struct tags mapped to the schemas; the other rules are only enforced by the DTO
+// validation in the services
+const (
+	ruleRequired       = "required"
+	ruleOmitEmpty      = "omitempty"
+	ruleDive           = "dive"
+	ruleSkip           = "-"
+	ruleOneOf          = "oneof"
+	ruleUUID           = "uuid"
+	ruleNoneEmptyValue = "edgex-dto-none-empty-string"
+)
+
+// Schema is a JSON Schema, limited to the keywords describing the v2 DTOs
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	// Nullable allows null along with Type, as the JSON decoding does for pointers, slices and maps
+	Nullable             bool               `json:"-"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// MarshalJSON encodes the type of a nullable schema as the list of Type and null
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	out := struct {
+		Type interface{} `json:"type,omitempty"`
+		plain
+	}{plain: plain(s)}
+	if s.Type != "" {
+		out.Type = s.Type
+		if s.Nullable {
+			out.Type = []string{s.Type, Null}
+		}
+	}
+	return json.Marshal(out)
+}
+
+// Property returns the schema of the property at path, or of its items when it is an array, descending into the
+// items of the arrays along the path; nil when there is none
+func (s *Schema) Property(path ...string) *Schema {
+	current := items(s)
+	for _, name := range path {
+		if current == nil {
+			return nil
+		}
+		current = items(current.Properties[name])
+	}
+	return current
+}
+
+func items(s *Schema) *Schema {
+	for s != nil && s.Type == Array {
+		s = s.Items
+	}
+	return s
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Generate returns the schema of the JSON encoding of dto, following its json struct tags, along with the rules of
+// its validate struct tags that JSON Schema can express. The schema of a slice of DTOs is the array of their schemas.
+func Generate(dto interface{}) *Schema {
+	t := reflect.TypeOf(dto)
+	g := generator{visiting: make(map[reflect.Type]bool)}
+	var s *Schema
+	if t.Kind() == reflect.Slice {
+		// The DTOs of a bulk request are each validated
+		s = &Schema{Type: Array, Items: g.dtoOf(t.Elem())}
+	} else {
+		s = g.dtoOf(t)
+	}
+	s.Schema = Draft
+	s.Title = Name(dto)
+	return s
+}
+
+// Name returns the name of the schema of dto, which is the name of its type or of the type of its elements
+func Name(dto interface{}) string {
+	t := reflect.TypeOf(dto)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+type generator struct {
+	// visiting holds the struct types being generated, whose recursive references are left unconstrained
+	visiting map[reflect.Type]bool
+}
+
+// dtoOf returns the schema of the DTO type t, generated from its fields even though it implements json.Unmarshaler:
+// the request DTOs unmarshal their own fields, only to validate them once decoded
+func (g generator) dtoOf(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Struct {
+		return g.structOf(t, true)
+	}
+	return g.schemaOf(t, true)
+}
+
+// schemaOf returns the schema of t; the validate rules of its fields are applied when constrained is set
+func (g generator) schemaOf(t reflect.Type, constrained bool) *Schema {
+	if t.Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) ||
+		t.Implements(textUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		// The JSON encoding of the type is its own
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := g.schemaOf(t.Elem(), constrained)
+		s.Nullable = s.Type != ""
+		return s
+	case reflect.Struct:
+		return g.structOf(t, constrained)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: String, ContentEncoding: "base64", Nullable: t.Kind() == reflect.Slice}
+		}
+		return &Schema{Type: Array, Items: g.schemaOf(t.Elem(), false), Nullable: t.Kind() == reflect.Slice}
+	case reflect.Map:
+		return &Schema{Type: Object, AdditionalProperties: g.schemaOf(t.Elem(), false), Nullable: true}
+	case reflect.String:
+		return &Schema{Type: String}
+	case reflect.Bool:
+		return &Schema{Type: Boolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: Integer}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Integer, Minimum: float64Ptr(0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Number}
+	default:
+		// interface{} holds any value
+		return &Schema{}
+	}
+}
+
+// structOf returns the schema of the struct type t, whose properties are its fields
+func (g generator) structOf(t reflect.Type, constrained bool) *Schema {
+	if g.visiting[t] {
+		return &Schema{Type: Object}
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+	s := &Schema{Type: Object, Properties: make(map[string]*Schema)}
+	g.addFields(s, t, constrained)
+	return s
+}
+
+// addFields adds the properties encoding the fields of the struct type t to s, flattening the embedded structs as the
+// JSON encoding does
+func (g generator) addFields(s *Schema, t reflect.Type, constrained bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		rules := strings.Split(field.Tag.Get("validate"), ",")
+		fieldConstrained := constrained && !(len(rules) == 1 && rules[0] == ruleSkip)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			g.addFields(s, fieldType, fieldConstrained)
+			continue
+		}
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaOf(field.Type, fieldConstrained)
+		if fieldConstrained {
+			required, elementRules := applyRules(property, field.Type, rules)
+			if required {
+				s.Required = append(s.Required, name)
+			}
+			if elementRules != nil && property.Type == Array {
+				// Only the elements of the fields diving into them are validated
+				property.Items = g.schemaOf(fieldType.Elem(), true)
+				applyRules(property.Items, fieldType.Elem(), elementRules)
+			}
+		}
+		s.Properties[name] = property
+	}
+}
+
+// applyRules applies to s the validate rules of a field of type t up to the dive into its elements, and returns
+// whether the field is required along with the rules of the elements, which are nil without a dive
+func applyRules(s *Schema, t reflect.Type, rules []string) (bool, []string) {
+	required := false
+	applicable := true
+	for i, rule := range rules {
+		switch {
+		case rule == ruleDive:
+			return required, rules[i+1:]
+		case rule == ruleRequired:
+			required = true
+		case rule == ruleOmitEmpty:
+			// Unless t is a pointer, the other rules don't apply to the zero value, which JSON Schema can't express
+			applicable = t.Kind() == reflect.Ptr
+		case applicable && !strings.Contains(rule, "|"):
+			applyRule(s, rule)
+		}
+	}
+	return required, nil
+}
+
+func applyRule(s *Schema, rule string) {
+	name, param := rule, ""
+	if i := strings.Index(rule, "="); i >= 0 {
+		name, param = rule[:i], rule[i+1:]
+	}
+	switch name {
+	case ruleUUID:
+		if s.Type == String {
+			s.Format = "uuid"
+		}
+	case ruleNoneEmptyValue:
+		if s.Type == String {
+			s.Pattern = `\S`
+		}
+	case ruleOneOf:
+		if s.Type == String && !strings.Contains(param, "'") {
+			s.Enum = strings.Fields(param)
+		}
+	case "len", "min", "max", "gt", "gte", "lt", "lte":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		applyBound(s, name, n)
+	}
+}
+
+// applyBound applies the bound n of the rule named name to the length of a string or an array, or to a number
+func applyBound(s *Schema, name string, n int) {
+	switch s.Type {
+	case String:
+		setLengthBound(&s.MinLength, &s.MaxLength, name, n)
+	case Array:
+		setLengthBound(&s.MinItems, &s.MaxItems, name, n)
+	case Integer, Number:
+		f := float64(n)
+		switch name {
+		case "len":
+			s.Minimum, s.Maximum = float64Ptr(f), float64Ptr(f)
+		case "min", "gte":
+			s.Minimum = float64Ptr(f)
+		case "max", "lte":
+			s.Maximum = float64Ptr(f)
+		case "gt":
+			s.ExclusiveMinimum = float64Ptr(f)
+		case "lt":
+			s.ExclusiveMaximum = float64Ptr(f)
+		}
+	}
+}
+
+func setLengthBound(min **int, max **int, name string, n int) {
+	switch name {
+	case "len":
+		*min, *max = intPtr(n), intPtr(n)
+	case "min", "gte":
+		*min = intPtr(n)
+	case "max", "lte":
+		*max = intPtr(n)
+	case "gt":
+		*min = intPtr(n + 1)
+	case "lt":
+		*max = intPtr(n - 1)
+	}
+}
+
+// jsonName returns the name of the field in the JSON encoding, empty when it is the field name or the field is
+// embedded
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,153 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBaseRequest struct {
+	RequestId string `json:"requestId" validate:"len=0|uuid"`
+}
+
+type testReading struct {
+	Id           string `json:"id,omitempty"`
+	ResourceName string `json:"resourceName" validate:"required,edgex-dto-none-empty-string"`
+	ValueType    string `json:"valueType" validate:"required,oneof='Bool' 'String'"`
+	testValue    `json:",inline" validate:"-"`
+}
+
+type testValue struct {
+	Value string `json:"value,omitempty" validate:"required"`
+}
+
+type testEvent struct {
+	Id       string            `json:"id" validate:"required,uuid"`
+	Origin   int64             `json:"origin" validate:"required"`
+	Readings []testReading     `json:"readings" validate:"gt=0,dive,required"`
+	Labels   []testReading     `json:"labels,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Priority uint8             `json:"priority" validate:"omitempty,max=9"`
+}
+
+type testAddEventRequest struct {
+	testBaseRequest `json:",inline"`
+	Event           testEvent `json:"event" validate:"required"`
+}
+
+type testUpdateRequest struct {
+	Name       *string     `json:"name" validate:"omitempty,edgex-dto-none-empty-string"`
+	AdminState *string     `json:"adminState" validate:"omitempty,oneof=LOCKED UNLOCKED"`
+	Payload    []byte      `json:"payload"`
+	Any        interface{} `json:"any"`
+	Ignored    string      `json:"-"`
+	internal   string
+}
+
+// testUnmarshalerRequest unmarshals its own fields, as the AddEventRequest DTO does
+type testUnmarshalerRequest struct {
+	testBaseRequest `json:",inline"`
+	Name            string `json:"name" validate:"required"`
+}
+
+func (r *testUnmarshalerRequest) UnmarshalJSON(b []byte) error {
+	type alias testUnmarshalerRequest
+	return json.Unmarshal(b, (*alias)(r))
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(testAddEventRequest{})
+	assert.Equal(t, Draft, s.Schema)
+	assert.Equal(t, "testAddEventRequest", s.Title)
+	assert.Equal(t, Object, s.Type)
+	assert.Equal(t, []string{"event"}, s.Required)
+	assert.Equal(t, &Schema{Type: String}, s.Properties["requestId"], "rules with alternatives aren't mapped")
+
+	event := s.Property("event")
+	require.NotNil(t, event)
+	assert.Equal(t, []string{"id", "origin"}, event.Required)
+	assert.Equal(t, "uuid", event.Properties["id"].Format)
+	assert.Equal(t, 1, *event.Properties["readings"].MinItems)
+	assert.Nil(t, event.Properties["priority"].Maximum, "rules of omitempty values aren't mapped")
+	assert.Equal(t, &Schema{Type: Object, AdditionalProperties: &Schema{Type: String}, Nullable: true}, event.Properties["tags"])
+
+	reading := s.Property("event", "readings")
+	require.NotNil(t, reading)
+	assert.Equal(t, []string{"resourceName", "valueType"}, reading.Required, "the rules of skipped embedded structs aren't mapped")
+	assert.Equal(t, `\S`, reading.Properties["resourceName"].Pattern)
+	assert.Nil(t, reading.Properties["valueType"].Enum, "quoted oneof values aren't mapped")
+	assert.Equal(t, &Schema{Type: String}, reading.Properties["value"])
+	assert.Nil(t, s.Property("event", "labels").Required, "the elements of the arrays without dive aren't validated")
+
+	update := Generate([]testUpdateRequest{})
+	assert.Equal(t, Array, update.Type)
+	assert.Equal(t, "testUpdateRequest", update.Title)
+	assert.Equal(t, []string{"LOCKED", "UNLOCKED"}, update.Property("adminState").Enum)
+	assert.True(t, update.Property("name").Nullable)
+	assert.Equal(t, `\S`, update.Property("name").Pattern)
+	assert.Equal(t, "base64", update.Property("payload").ContentEncoding)
+	assert.Equal(t, &Schema{}, update.Property("any"))
+	assert.Len(t, update.Items.Properties, 4)
+
+	unmarshaler := Generate(testUnmarshalerRequest{})
+	assert.Equal(t, Object, unmarshaler.Type)
+	assert.Equal(t, []string{"name"}, unmarshaler.Required, "the DTOs implementing json.Unmarshaler are generated from their fields")
+	assert.Equal(t, []string{"name"}, Generate([]testUnmarshalerRequest{}).Items.Required)
+}
+
+func TestMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Schema{Type: String, Nullable: true, MinLength: intPtr(1)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":["string","null"],"minLength":1}`, string(data))
+
+	data, err = json.Marshal(&Schema{Type: Array, Items: &Schema{Type: Integer}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"array","items":{"type":"integer"}}`, string(data))
+}
+
+func TestValidate(t *testing.T) {
+	addEvent := Generate(testAddEventRequest{})
+	update := Generate([]testUpdateRequest{})
+
+	tests := []struct {
+		name          string
+		schema        *Schema
+		document      string
+		errorExpected bool
+	}{
+		{"Valid", addEvent, `{"event":{"id":"a","origin":1,"readings":[{"resourceName":"r","valueType":"Bool"}]}}`, false},
+		{"Valid - property names ignoring case", addEvent, `{"Event":{"ID":"a","origin":1,"readings":[{"resourceName":"r","valueType":"Bool"}]}}`, false},
+		{"Valid - unknown properties", addEvent, `{"event":{"id":"a","origin":1,"readings":[{"resourceName":"r","valueType":"Bool","quality":"bad"}]},"extra":[]}`, false},
+		{"Valid - null slice and map", addEvent, `{"event":{"id":"a","origin":1,"readings":[{"resourceName":"r","valueType":"Bool"}],"labels":null,"tags":null}}`, false},
+		{"Valid - bulk", update, `[{"name":"d1","adminState":"LOCKED"},{"name":null,"payload":"AQI=","any":[1]}]`, false},
+		{"Invalid - missing required property", addEvent, `{"event":{"id":"a","readings":[{"resourceName":"r","valueType":"Bool"}]}}`, true},
+		{"Invalid - wrong type", addEvent, `{"event":{"id":"a","origin":"1","readings":[{"resourceName":"r","valueType":"Bool"}]}}`, true},
+		{"Invalid - not an integer", addEvent, `{"event":{"id":"a","origin":1.5,"readings":[{"resourceName":"r","valueType":"Bool"}]}}`, true},
+		{"Invalid - negative unsigned integer", addEvent, `{"event":{"id":"a","origin":1,"priority":-1,"readings":[{"resourceName":"r","valueType":"Bool"}]}}`, true},
+		{"Invalid - no readings", addEvent, `{"event":{"id":"a","origin":1,"readings":[]}}`, true},
+		{"Invalid - blank resource name", addEvent, `{"event":{"id":"a","origin":1,"readings":[{"resourceName":" ","valueType":"Bool"}]}}`, true},
+		{"Invalid - null string", addEvent, `{"event":{"id":null,"origin":1,"readings":[{"resourceName":"r","valueType":"Bool"}]}}`, true},
+		{"Invalid - tag value", addEvent, `{"event":{"id":"a","origin":1,"readings":[{"resourceName":"r","valueType":"Bool"}],"tags":{"site":1}}}`, true},
+		{"Invalid - enum", update, `[{"adminState":"DISABLED"}]`, true},
+		{"Invalid - not an array", update, `{"name":"d1"}`, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			document, err := Decode([]byte(testCase.document))
+			require.NoError(t, err)
+			err = testCase.schema.Validate(document)
+			if testCase.errorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
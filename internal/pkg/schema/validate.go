@@ -0,0 +1,206 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxViolations bounds the number of violations reported for a document
+const maxViolations = 10
+
+// Validate returns an error listing the violations of s by document, a JSON value decoded into an interface{}
+// with the numbers decoded as json.Number. The format keyword is an annotation and isn't validated.
+func (s *Schema) Validate(document interface{}) error {
+	var violations []string
+	s.validate(document, "", &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+	if len(violations) > maxViolations {
+		violations = append(violations[:maxViolations], "and more")
+	}
+	return fmt.Errorf("request doesn't match schema %s: %s", s.Title, strings.Join(violations, "; "))
+}
+
+func (s *Schema) validate(value interface{}, path string, violations *[]string) {
+	if len(*violations) > maxViolations || s.Type == "" {
+		return
+	}
+	location := path
+	if location == "" {
+		location = "request"
+	}
+	violate := func(format string, args ...interface{}) {
+		*violations = append(*violations, location+" "+fmt.Sprintf(format, args...))
+	}
+
+	if value == nil {
+		if !s.Nullable {
+			violate("must be %s, not null", s.Type)
+		}
+		return
+	}
+
+	switch s.Type {
+	case Object:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			violate("must be %s", Object)
+			return
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, required := range s.Required {
+			if lookup(names, required) == "" {
+				violate("must have property %s", required)
+			}
+		}
+		for _, name := range names {
+			if property := s.property(name); property != nil {
+				property.validate(object[name], join(path, name), violations)
+			} else if s.AdditionalProperties != nil {
+				s.AdditionalProperties.validate(object[name], join(path, name), violations)
+			}
+		}
+	case Array:
+		array, ok := value.([]interface{})
+		if !ok {
+			violate("must be %s", Array)
+			return
+		}
+		if s.MinItems != nil && len(array) < *s.MinItems {
+			violate("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(array) > *s.MaxItems {
+			violate("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range array {
+				s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), violations)
+			}
+		}
+	case String:
+		str, ok := value.(string)
+		if !ok {
+			violate("must be %s", String)
+			return
+		}
+		s.validateString(str, violate)
+	case Integer:
+		number, ok := value.(json.Number)
+		if !ok {
+			violate("must be %s", Integer)
+			return
+		}
+		if _, err := strconv.ParseInt(number.String(), 10, 64); err != nil {
+			if _, err := strconv.ParseUint(number.String(), 10, 64); err != nil {
+				violate("must be %s", Integer)
+				return
+			}
+		}
+		s.validateNumber(number, violate)
+	case Number:
+		number, ok := value.(json.Number)
+		if !ok {
+			violate("must be %s", Number)
+			return
+		}
+		s.validateNumber(number, violate)
+	case Boolean:
+		if _, ok := value.(bool); !ok {
+			violate("must be %s", Boolean)
+		}
+	}
+}
+
+func (s *Schema) validateString(str string, violate func(format string, args ...interface{})) {
+	length := utf8.RuneCountInString(str)
+	if s.MinLength != nil && length < *s.MinLength {
+		violate("must be at least %d characters long", *s.MinLength)
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		violate("must be at most %d characters long", *s.MaxLength)
+	}
+	if s.Pattern != "" {
+		if matched, err := regexp.MatchString(s.Pattern, str); err == nil && !matched {
+			violate("must match %s", s.Pattern)
+		}
+	}
+	if len(s.Enum) > 0 {
+		for _, value := range s.Enum {
+			if str == value {
+				return
+			}
+		}
+		violate("must be one of %s", strings.Join(s.Enum, ", "))
+	}
+}
+
+func (s *Schema) validateNumber(number json.Number, violate func(format string, args ...interface{})) {
+	f, err := number.Float64()
+	if err != nil {
+		violate("must be a number")
+		return
+	}
+	if s.Minimum != nil && f < *s.Minimum {
+		violate("must be at least %v", *s.Minimum)
+	}
+	if s.Maximum != nil && f > *s.Maximum {
+		violate("must be at most %v", *s.Maximum)
+	}
+	if s.ExclusiveMinimum != nil && f <= *s.ExclusiveMinimum {
+		violate("must be greater than %v", *s.ExclusiveMinimum)
+	}
+	if s.ExclusiveMaximum != nil && f >= *s.ExclusiveMaximum {
+		violate("must be less than %v", *s.ExclusiveMaximum)
+	}
+}
+
+// property returns the schema of the property name of an object, matched as the JSON decoding matches the struct
+// fields: exactly or else ignoring case
+func (s *Schema) property(name string) *Schema {
+	if property, ok := s.Properties[name]; ok {
+		return property
+	}
+	for propertyName, property := range s.Properties {
+		if strings.EqualFold(propertyName, name) {
+			return property
+		}
+	}
+	return nil
+}
+
+// lookup returns the name among names matching the property name, or an empty string
+func lookup(names []string, name string) string {
+	for _, n := range names {
+		if n == name {
+			return n
+		}
+	}
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return n
+		}
+	}
+	return ""
+}
+
+func join(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,180 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package secretcache provides the read-through cache of the secrets the services read from the secret store, so that
+// the services reading their database or message bus credentials on every reconnect don't load the secret store when
+// the network is flaky. The secrets are cached for their lease TTL and invalidated on the rotation signals published
+// on the message bus.
+package secretcache
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TTLKey is the entry of a secret setting how long it may be cached, e.g. "10m" or "600" seconds, as the ttl of the
+// secrets of the Vault KV secrets engine sets their lease duration
+const TTLKey = "ttl"
+
+// SecretCacheInfo configures the secret cache
+type SecretCacheInfo struct {
+	// Enabled caches the secrets read from the secret store. The secrets are never cached in insecure mode, where
+	// they are read from the Writable.InsecureSecrets configuration.
+	Enabled bool
+	// DefaultTTL is how long a secret without ttl entry is cached, e.g. "15m"
+	DefaultTTL string
+	// Rotation subscribes to the rotation signals when its Topic is set
+	Rotation RotationInfo
+}
+
+// Validate checks DefaultTTL
+func (info SecretCacheInfo) Validate() error {
+	if _, err := parseTTL(info.DefaultTTL); err != nil {
+		return fmt.Errorf("invalid SecretCache.DefaultTTL: %s", err.Error())
+	}
+	return nil
+}
+
+// SecretProvider reads and stores the secrets of the service's secret store, e.g. a bootstrap
+// interfaces.SecretProvider
+type SecretProvider interface {
+	GetSecrets(path string, keys ...string) (map[string]string, error)
+	StoreSecrets(path string, secrets map[string]string) error
+}
+
+// entry is a cached secret
+type entry struct {
+	secrets   map[string]string
+	expiresAt time.Time
+}
+
+// Cache reads the secrets through the provider, keeping them until their TTL has elapsed or they are invalidated. It
+// is safe for concurrent use.
+type Cache struct {
+	provider   SecretProvider
+	defaultTTL time.Duration
+	mutex      sync.Mutex
+	entries    map[string]entry
+	// generation is incremented by each invalidation, so that a secret read before it isn't cached after it
+	generation uint64
+	now        func() time.Time
+}
+
+// NewCache is a factory function that returns a Cache of the secrets of provider. It returns an error when info isn't
+// valid.
+func NewCache(provider SecretProvider, info SecretCacheInfo) (*Cache, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	defaultTTL, _ := parseTTL(info.DefaultTTL)
+	return &Cache{
+		provider:   provider,
+		defaultTTL: defaultTTL,
+		entries:    make(map[string]entry),
+		now:        time.Now,
+	}, nil
+}
+
+// parseTTL parses a duration, e.g. "10m", or a number of seconds
+func parseTTL(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		value = strconv.Itoa(seconds) + "s"
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("'%s' must not be negative", value)
+	}
+	return ttl, nil
+}
+
+// ttl returns how long secrets may be cached, their ttl entry or else the default TTL
+func (c *Cache) ttl(secrets map[string]string) time.Duration {
+	if value, ok := secrets[TTLKey]; ok {
+		if ttl, err := parseTTL(value); err == nil {
+			return ttl
+		}
+	}
+	return c.defaultTTL
+}
+
+// GetSecrets returns the keys of the secret at path, or all its keys when none is given, reading the whole secret
+// from the secret store when it isn't cached
+func (c *Cache) GetSecrets(path string, keys ...string) (map[string]string, error) {
+	c.mutex.Lock()
+	cached, ok := c.entries[path]
+	generation := c.generation
+	c.mutex.Unlock()
+
+	secrets := cached.secrets
+	if !ok || !c.now().Before(cached.expiresAt) {
+		var err error
+		if secrets, err = c.provider.GetSecrets(path); err != nil {
+			return nil, err
+		}
+		if ttl := c.ttl(secrets); ttl > 0 {
+			c.mutex.Lock()
+			if c.generation == generation {
+				c.entries[path] = entry{secrets: secrets, expiresAt: c.now().Add(ttl)}
+			}
+			c.mutex.Unlock()
+		}
+	}
+	return selectKeys(path, secrets, keys)
+}
+
+// selectKeys returns a copy of the keys of secrets, all of them when keys is empty
+func selectKeys(path string, secrets map[string]string, keys []string) (map[string]string, error) {
+	if len(keys) == 0 {
+		selected := make(map[string]string, len(secrets))
+		for key, value := range secrets {
+			selected[key] = value
+		}
+		return selected, nil
+	}
+
+	selected := make(map[string]string, len(keys))
+	var missing []string
+	for _, key := range keys {
+		value, ok := secrets[key]
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+		selected[key] = value
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("no value for the keys %v of the secret at %s", missing, path)
+	}
+	return selected, nil
+}
+
+// StoreSecrets stores the secrets at path in the secret store, invalidating the cached secret
+func (c *Cache) StoreSecrets(path string, secrets map[string]string) error {
+	err := c.provider.StoreSecrets(path, secrets)
+	c.Invalidate(path)
+	return err
+}
+
+// Invalidate removes the secret at path from the cache, or all the secrets when path is empty
+func (c *Cache) Invalidate(path string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	if path == "" {
+		c.entries = make(map[string]entry)
+		return
+	}
+	delete(c.entries, path)
+}
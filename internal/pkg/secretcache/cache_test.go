@@ -0,0 +1,190 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package secretcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider holds the secrets by path, counting the reads
+type fakeProvider struct {
+	mutex   sync.Mutex
+	secrets map[string]map[string]string
+	reads   int
+	err     error
+}
+
+func (p *fakeProvider) GetSecrets(path string, keys ...string) (map[string]string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.reads++
+	if p.err != nil {
+		return nil, p.err
+	}
+	secrets := make(map[string]string)
+	for key, value := range p.secrets[path] {
+		secrets[key] = value
+	}
+	return secrets, nil
+}
+
+func (p *fakeProvider) StoreSecrets(path string, secrets map[string]string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.secrets[path] = secrets
+	return nil
+}
+
+func newTestCache(t *testing.T, provider SecretProvider, defaultTTL string, now *time.Time) *Cache {
+	cache, err := NewCache(provider, SecretCacheInfo{Enabled: true, DefaultTTL: defaultTTL})
+	require.NoError(t, err)
+	cache.now = func() time.Time { return *now }
+	return cache
+}
+
+func TestGetSecrets(t *testing.T) {
+	now := time.Unix(1000, 0)
+	provider := &fakeProvider{secrets: map[string]map[string]string{
+		"redisdb": {"username": "redis5", "password": "secret"},
+		"mqtt":    {"username": "edgex", "password": "other", TTLKey: "60"},
+	}}
+	cache := newTestCache(t, provider, "10m", &now)
+
+	secrets, err := cache.GetSecrets("redisdb", "password")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"password": "secret"}, secrets)
+	secrets, err = cache.GetSecrets("redisdb")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"username": "redis5", "password": "secret"}, secrets)
+	assert.Equal(t, 1, provider.reads, "the secret should be read once")
+
+	secrets["password"] = "changed"
+	secrets, err = cache.GetSecrets("redisdb", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", secrets["password"], "the cached secret should not be changed by the callers")
+
+	_, err = cache.GetSecrets("redisdb", "password", "token")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[token]")
+
+	now = now.Add(10 * time.Minute)
+	_, err = cache.GetSecrets("redisdb")
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.reads, "the secret should be read again once DefaultTTL has elapsed")
+
+	_, err = cache.GetSecrets("mqtt")
+	require.NoError(t, err)
+	now = now.Add(time.Minute)
+	_, err = cache.GetSecrets("mqtt")
+	require.NoError(t, err)
+	assert.Equal(t, 4, provider.reads, "the ttl of the secret should apply")
+}
+
+func TestGetSecretsNotCached(t *testing.T) {
+	now := time.Unix(1000, 0)
+	provider := &fakeProvider{secrets: map[string]map[string]string{"redisdb": {"password": "secret"}}}
+
+	cache := newTestCache(t, provider, "", &now)
+	_, _ = cache.GetSecrets("redisdb")
+	_, _ = cache.GetSecrets("redisdb")
+	assert.Equal(t, 2, provider.reads, "the secrets should not be cached without TTL")
+
+	provider.reads = 0
+	provider.err = errors.New("secret store unavailable")
+	cache = newTestCache(t, provider, "10m", &now)
+	_, err := cache.GetSecrets("redisdb")
+	require.Error(t, err)
+	provider.err = nil
+	secrets, err := cache.GetSecrets("redisdb")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", secrets["password"])
+	assert.Equal(t, 2, provider.reads, "the errors should not be cached")
+}
+
+func TestInvalidate(t *testing.T) {
+	now := time.Unix(1000, 0)
+	provider := &fakeProvider{secrets: map[string]map[string]string{
+		"redisdb": {"password": "secret"},
+		"mqtt":    {"password": "other"},
+	}}
+	cache := newTestCache(t, provider, "10m", &now)
+	_, _ = cache.GetSecrets("redisdb")
+	_, _ = cache.GetSecrets("mqtt")
+
+	require.NoError(t, cache.StoreSecrets("redisdb", map[string]string{"password": "rotated"}))
+	secrets, err := cache.GetSecrets("redisdb")
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", secrets["password"], "the stored secret should be read again")
+	assert.Equal(t, 3, provider.reads)
+
+	cache.Invalidate("")
+	_, _ = cache.GetSecrets("redisdb")
+	_, _ = cache.GetSecrets("mqtt")
+	assert.Equal(t, 5, provider.reads, "all the secrets should be read again")
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, SecretCacheInfo{}.Validate())
+	assert.NoError(t, SecretCacheInfo{DefaultTTL: "15m"}.Validate())
+	assert.NoError(t, SecretCacheInfo{DefaultTTL: "900"}.Validate())
+	assert.Error(t, SecretCacheInfo{DefaultTTL: "fifteen minutes"}.Validate())
+	assert.Error(t, SecretCacheInfo{DefaultTTL: "-1m"}.Validate())
+}
+
+type mockSubscriber struct {
+	topics []msgTypes.TopicChannel
+}
+
+func (s *mockSubscriber) Subscribe(topics []msgTypes.TopicChannel, _ chan error) error {
+	s.topics = topics
+	return nil
+}
+
+func TestWatch(t *testing.T) {
+	now := time.Unix(1000, 0)
+	provider := &fakeProvider{secrets: map[string]map[string]string{
+		"redisdb": {"password": "secret"},
+		"mqtt":    {"password": "other"},
+	}}
+	cache := newTestCache(t, provider, "10m", &now)
+	_, _ = cache.GetSecrets("redisdb")
+	_, _ = cache.GetSecrets("mqtt")
+
+	subscriber := &mockSubscriber{}
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	require.NoError(t, Watch(ctx, wg, logger.NewMockClient(), subscriber, "edgex/secrets/rotated", cache))
+	require.Len(t, subscriber.topics, 1)
+	assert.Equal(t, "edgex/secrets/rotated", subscriber.topics[0].Topic)
+
+	payload, err := json.Marshal(RotationSignal{Path: "redisdb"})
+	require.NoError(t, err)
+	subscriber.topics[0].Messages <- msgTypes.MessageEnvelope{ContentType: clients.ContentTypeJSON, Payload: payload}
+	// the second signal is received once the first one is handled
+	subscriber.topics[0].Messages <- msgTypes.MessageEnvelope{ContentType: clients.ContentTypeJSON, Payload: []byte(`{"path":"kong"}`)}
+	_, _ = cache.GetSecrets("redisdb")
+	_, _ = cache.GetSecrets("mqtt")
+	assert.Equal(t, 3, provider.reads, "only the rotated secret should be read again")
+
+	subscriber.topics[0].Messages <- msgTypes.MessageEnvelope{ContentType: clients.ContentTypeJSON, Payload: []byte(`{}`)}
+	cancel()
+	wg.Wait()
+	_, _ = cache.GetSecrets("redisdb")
+	_, _ = cache.GetSecrets("mqtt")
+	assert.Equal(t, 5, provider.reads, "all the secrets should be read again")
+}
@@ -0,0 +1,149 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package secretcache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-messaging/v2/messaging"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+)
+
+// Configuration is implemented by the service configurations that define the secret cache.
+type Configuration interface {
+	// GetSecretCache returns the service's secret cache configuration.
+	GetSecretCache() SecretCacheInfo
+}
+
+// cachedProvider is the secret provider of the service reading the secrets through the cache
+type cachedProvider struct {
+	interfaces.SecretProvider
+	cache *Cache
+}
+
+func (p cachedProvider) GetSecrets(path string, keys ...string) (map[string]string, error) {
+	return p.cache.GetSecrets(path, keys...)
+}
+
+func (p cachedProvider) StoreSecrets(path string, secrets map[string]string) error {
+	return p.cache.StoreSecrets(path, secrets)
+}
+
+// Handler contains references to dependencies required by the secret cache bootstrap implementation.
+type Handler struct {
+	configuration Configuration
+}
+
+// NewHandler is a factory method that returns an initialized Handler receiver struct.
+func NewHandler(configuration Configuration) Handler {
+	return Handler{configuration: configuration}
+}
+
+// BootstrapHandler fulfills the BootstrapHandler contract. It replaces the secret provider of the service with one
+// reading the secrets through a cache when enabled in secure mode, and subscribes to the rotation signals. It must
+// follow the handler creating the secret provider.
+func (h Handler) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	lc := container.LoggingClientFrom(dic.Get)
+	info := h.configuration.GetSecretCache()
+	if !info.Enabled || os.Getenv("EDGEX_SECURITY_SECRET_STORE") == "false" {
+		return true
+	}
+
+	provider := container.SecretProviderFrom(dic.Get)
+	cache, err := NewCache(provider, info)
+	if err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+	dic.Update(di.ServiceConstructorMap{
+		container.SecretProviderName: func(get di.Get) interface{} {
+			return cachedProvider{SecretProvider: provider, cache: cache}
+		},
+	})
+	lc.Info("secrets cached")
+
+	if info.Rotation.Topic == "" {
+		return true
+	}
+	return subscribe(ctx, wg, startupTimer, dic, info.Rotation, provider, cache)
+}
+
+// subscribe connects to the message bus of rotation and invalidates the rotated secrets of cache
+func subscribe(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	startupTimer startup.Timer,
+	dic *di.Container,
+	rotation RotationInfo,
+	provider interfaces.SecretProvider,
+	cache *Cache) bool {
+
+	lc := container.LoggingClientFrom(dic.Get)
+	optional := make(map[string]string, len(rotation.Optional)+1)
+	for key, value := range rotation.Optional {
+		optional[key] = value
+	}
+	if rotation.CredentialsPath != "" {
+		credentials, err := provider.GetSecrets(rotation.CredentialsPath)
+		if err != nil {
+			lc.Error(fmt.Sprintf("failed to get the credentials of the secret rotation message bus: %s", err.Error()))
+			return false
+		}
+		optional["Password"] = credentials[secret.PasswordKey]
+	}
+
+	msgClient, err := messaging.NewMessageClient(
+		msgTypes.MessageBusConfig{
+			SubscribeHost: msgTypes.HostInfo{
+				Host:     rotation.Host,
+				Port:     rotation.Port,
+				Protocol: rotation.Protocol,
+			},
+			Type:     rotation.Type,
+			Optional: optional,
+		})
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to create the secret rotation messaging client: %s", err.Error()))
+		return false
+	}
+
+	for startupTimer.HasNotElapsed() {
+		err = msgClient.Connect()
+		if err == nil {
+			break
+		}
+
+		lc.Warn(fmt.Sprintf("couldn't connect to the secret rotation message bus: %s", err.Error()))
+		startupTimer.SleepForInterval()
+	}
+	if err != nil {
+		lc.Error("failed to connect to the secret rotation message bus in allotted time")
+		return false
+	}
+
+	if err := Watch(ctx, wg, lc, msgClient, rotation.Topic, cache); err != nil {
+		lc.Error(fmt.Sprintf("failed to subscribe to the secret rotation signals: %s", err.Error()))
+		_ = msgClient.Disconnect()
+		return false
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		_ = msgClient.Disconnect()
+	}()
+	lc.Info(fmt.Sprintf("secret cache invalidated by the rotation signals of topic %s", rotation.Topic))
+	return true
+}
@@ -0,0 +1,95 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package secretcache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+)
+
+// RotationInfo configures the message bus the rotation signals are published on
+type RotationInfo struct {
+	// Topic is the topic of the rotation signals. No signal is subscribed to when empty.
+	Topic    string
+	Host     string
+	Port     int
+	Protocol string
+	Type     string
+	// CredentialsPath is the secret holding the password of the message bus, e.g. "redisdb" for the Redis Streams
+	// message bus reusing the database, read when set
+	CredentialsPath string
+	// Optional holds the additional properties of the message bus client
+	Optional map[string]string
+}
+
+// RotationSignal is the message published on the rotation topic once a secret is rotated
+type RotationSignal struct {
+	// Path is the path of the rotated secret, all the secrets being rotated when empty
+	Path string `json:"path"`
+}
+
+// Subscriber subscribes to topics of the message bus, e.g. a messaging.MessageClient
+type Subscriber interface {
+	Subscribe(topics []msgTypes.TopicChannel, messageErrors chan error) error
+}
+
+// Decode decodes the rotation signal carried by a message envelope
+func Decode(envelope msgTypes.MessageEnvelope) (RotationSignal, error) {
+	var signal RotationSignal
+	if envelope.ContentType != "" && envelope.ContentType != clients.ContentTypeJSON {
+		return signal, fmt.Errorf("unsupported content type '%s'", envelope.ContentType)
+	}
+	err := json.Unmarshal(envelope.Payload, &signal)
+	return signal, err
+}
+
+// Watch subscribes to the rotation signals published on topic and invalidates the rotated secrets of cache, until ctx
+// is done
+func Watch(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	lc logger.LoggingClient,
+	subscriber Subscriber,
+	topic string,
+	cache *Cache) error {
+
+	messages := make(chan msgTypes.MessageEnvelope)
+	messageErrors := make(chan error)
+	if err := subscriber.Subscribe([]msgTypes.TopicChannel{{Topic: topic, Messages: messages}}, messageErrors); err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-messageErrors:
+				lc.Error(fmt.Sprintf("failed to receive secret rotation signals: %s", err.Error()))
+			case envelope := <-messages:
+				signal, err := Decode(envelope)
+				if err != nil {
+					// the rotated secret is unknown, so none is kept
+					lc.Error(fmt.Sprintf("failed to decode secret rotation signal, clearing the secret cache: %s", err.Error()))
+				} else if signal.Path == "" {
+					lc.Info("all secrets rotated, clearing the secret cache")
+				} else {
+					lc.Info(fmt.Sprintf("secret %s rotated, removing it from the secret cache", signal.Path))
+				}
+				cache.Invalidate(signal.Path)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,116 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tags parses the tag expressions filtering the events and readings queried from core-data, and holds the
+// configuration of the tags indexed to evaluate them.
+package tags
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operators of a tag expression term
+const (
+	// Equal matches the events carrying the tag with the value, e.g. site=plant-1
+	Equal = "="
+	// NotEqual matches the events not carrying the tag with the value, including those without the tag, e.g. line!=2
+	NotEqual = "!="
+	// Exists matches the events carrying the tag whatever its value, e.g. site
+	Exists = ""
+)
+
+// Expression is the query parameter holding the tag expression of a query
+const Expression = "expression"
+
+// nameSeparator can't appear in the name of an indexed tag, since it separates the name from the value in the index
+const nameSeparator = ":"
+
+// Term is a condition on one tag of a tag expression
+type Term struct {
+	Name     string
+	Operator string
+	Value    string
+}
+
+// ParseExpression returns the terms of expression, a comma separated list of terms that all must match. A term is
+// either name=value, name!=value or name alone.
+func ParseExpression(expression string) ([]Term, error) {
+	var terms []Term
+	for _, term := range strings.Split(expression, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		t := Term{Name: term, Operator: Exists}
+		if i := strings.Index(term, NotEqual); i >= 0 {
+			t = Term{Name: term[:i], Operator: NotEqual, Value: term[i+len(NotEqual):]}
+		} else if i := strings.Index(term, Equal); i >= 0 {
+			t = Term{Name: term[:i], Operator: Equal, Value: term[i+len(Equal):]}
+		}
+		t.Name = strings.TrimSpace(t.Name)
+		t.Value = strings.TrimSpace(t.Value)
+		if t.Name == "" {
+			return nil, fmt.Errorf("tag expression term %s has no tag name", term)
+		}
+		terms = append(terms, t)
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("tag expression must have at least one term")
+	}
+	return terms, nil
+}
+
+// IndexInfo configures the event tags indexed by core-data
+type IndexInfo struct {
+	// Tags lists the names of the tags indexed, which only can be used in tag expressions. The other tags are stored
+	// but not indexed, which bounds the growth of the index.
+	Tags []string
+}
+
+// Validate returns an error when a tag name can't be indexed
+func (info IndexInfo) Validate() error {
+	for _, name := range info.Tags {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("indexed tag name is empty")
+		}
+		if strings.Contains(name, nameSeparator) || strings.Contains(name, Equal) || strings.Contains(name, ",") {
+			return fmt.Errorf("indexed tag name %s can't contain '%s', '%s' or ','", name, nameSeparator, Equal)
+		}
+	}
+	return nil
+}
+
+// Indexed returns whether the tag is indexed
+func (info IndexInfo) Indexed(name string) bool {
+	for _, tag := range info.Tags {
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the tags to index among tags
+func (info IndexInfo) Filter(tags map[string]string) map[string]string {
+	indexed := make(map[string]string)
+	for name, value := range tags {
+		if info.Indexed(name) {
+			indexed[name] = value
+		}
+	}
+	return indexed
+}
+
+// ValidateTerms returns an error when a term of a tag expression is on a tag that isn't indexed
+func (info IndexInfo) ValidateTerms(terms []Term) error {
+	for _, t := range terms {
+		if !info.Indexed(t.Name) {
+			return fmt.Errorf("tag %s is not indexed, it must be listed in TagIndex.Tags to be queried", t.Name)
+		}
+	}
+	return nil
+}
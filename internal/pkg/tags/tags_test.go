@@ -0,0 +1,56 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		name          string
+		expression    string
+		expected      []Term
+		errorExpected bool
+	}{
+		{"Valid - equal", "site=plant-1", []Term{{"site", Equal, "plant-1"}}, false},
+		{"Valid - not equal", "line != 2", []Term{{"line", NotEqual, "2"}}, false},
+		{"Valid - exists", "site", []Term{{"site", Exists, ""}}, false},
+		{"Valid - several terms", "site=plant-1, line!=2,shift,", []Term{
+			{"site", Equal, "plant-1"},
+			{"line", NotEqual, "2"},
+			{"shift", Exists, ""},
+		}, false},
+		{"Valid - empty value", "site=", []Term{{"site", Equal, ""}}, false},
+		{"Invalid - empty expression", " , ", nil, true},
+		{"Invalid - no tag name", "=plant-1", nil, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			terms, err := ParseExpression(testCase.expression)
+			if testCase.errorExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, terms)
+		})
+	}
+}
+
+func TestIndexInfo(t *testing.T) {
+	info := IndexInfo{Tags: []string{"site", "line"}}
+	require.NoError(t, info.Validate())
+	assert.Error(t, IndexInfo{Tags: []string{"site:name"}}.Validate())
+	assert.Error(t, IndexInfo{Tags: []string{" "}}.Validate())
+
+	assert.Equal(t, map[string]string{"site": "plant-1"}, info.Filter(map[string]string{"site": "plant-1", "batch": "42"}))
+	assert.NoError(t, info.ValidateTerms([]Term{{"site", Equal, "plant-1"}, {"line", Exists, ""}}))
+	assert.Error(t, info.ValidateTerms([]Term{{"batch", Equal, "42"}}))
+}
@@ -0,0 +1,58 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package templates
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Source provides the stored templates and the locales of the subscriptions
+type Source interface {
+	AllTemplates(offset int, limit int) ([]Template, errors.EdgeX)
+	// SubscriptionLocale returns the locale of the subscription named name, empty when it has none
+	SubscriptionLocale(name string) (string, errors.EdgeX)
+}
+
+// AddTemplateRequest defines the request content of a template added through ApiTemplateRoute
+type AddTemplateRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	Template              Template `json:"template" validate:"required"`
+}
+
+// UpdateTemplateRequest defines the request content of a template changed through ApiTemplateRoute
+type UpdateTemplateRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	Template              UpdateTemplate `json:"template" validate:"required"`
+}
+
+// TemplateResponse defines the response content of ApiTemplateByNameRoute
+type TemplateResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Template               Template `json:"template"`
+}
+
+// MultiTemplatesResponse defines the response content of ApiAllTemplateRoute
+type MultiTemplatesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Templates              []Template `json:"templates"`
+}
+
+// SetSubscriptionLocaleRequest defines the request content of the locale of a subscription set through
+// ApiSubscriptionLocaleRoute
+type SetSubscriptionLocaleRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	// Locale is the locale of the notifications sent to the subscriber, e.g. "fr-CA"
+	Locale string `json:"locale" validate:"required,edgex-dto-none-empty-string"`
+}
+
+// SubscriptionLocaleResponse defines the response content of ApiSubscriptionLocaleRoute
+type SubscriptionLocaleResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	// Locale is empty when the subscription has none, the notifications being rendered in the default locale of the
+	// templates
+	Locale string `json:"locale"`
+}
@@ -0,0 +1,249 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package templates renders the content of the notifications sent to the subscribers with templates, so that the
+// messages can be branded and localized without changing the services publishing the notifications.
+package templates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+const (
+	// ApiTemplateRoute accepts the templates to add, and the changes of existing templates
+	ApiTemplateRoute = v2.ApiBase + "/template"
+	// ApiAllTemplateRoute returns the templates, the latest created first
+	ApiAllTemplateRoute = ApiTemplateRoute + "/" + v2.All
+	// ApiTemplateByNameRoute returns or deletes a template
+	ApiTemplateByNameRoute = ApiTemplateRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+	// ApiSubscriptionLocaleRoute returns, sets or resets the locale of the notifications sent to the subscriber of a
+	// subscription
+	ApiSubscriptionLocaleRoute = v2.ApiSubscriptionByNameRoute + "/locale"
+)
+
+// Template renders the notifications of some categories, in the locale of each subscriber
+type Template struct {
+	Id          string `json:"id,omitempty"`
+	Created     int64  `json:"created,omitempty"`
+	Modified    int64  `json:"modified,omitempty"`
+	Name        string `json:"name" validate:"required,edgex-dto-none-empty-string"`
+	Description string `json:"description,omitempty"`
+	// Categories are the categories of the notifications rendered with the template
+	Categories []string `json:"categories" validate:"required,gt=0,dive,edgex-dto-none-empty-string"`
+	// Labels restrict the template to the notifications carrying all of them
+	Labels []string `json:"labels,omitempty"`
+	// DefaultLocale is the locale of the contents used when the subscriber has no locale, or one without content
+	DefaultLocale string `json:"defaultLocale" validate:"required,edgex-dto-none-empty-string"`
+	// Contents are the contents of the template keyed by locale, e.g. "en" or "fr-CA"
+	Contents map[string]Content `json:"contents" validate:"required,gt=0,dive"`
+}
+
+// Content is the content of a template in a locale, each field being a text/template executed with the notification
+type Content struct {
+	// Subject is the subject of the emails, the one configured in Smtp.Subject when empty
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body" validate:"required,edgex-dto-none-empty-string"`
+	// ContentType is the content type of Body, the one of the notification when empty
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// UpdateTemplate carries the fields of a template to change, the template being identified by Id or Name
+type UpdateTemplate struct {
+	Id            *string            `json:"id" validate:"required_without=Name,omitempty,uuid"`
+	Name          *string            `json:"name" validate:"required_without=Id,omitempty,edgex-dto-none-empty-string"`
+	Description   *string            `json:"description"`
+	Categories    []string           `json:"categories" validate:"omitempty,gt=0,dive,edgex-dto-none-empty-string"`
+	Labels        []string           `json:"labels"`
+	DefaultLocale *string            `json:"defaultLocale" validate:"omitempty,edgex-dto-none-empty-string"`
+	Contents      map[string]Content `json:"contents" validate:"omitempty,gt=0,dive"`
+}
+
+// Apply replaces the fields of t with those set in update
+func (update UpdateTemplate) Apply(t *Template) {
+	if update.Description != nil {
+		t.Description = *update.Description
+	}
+	if update.Categories != nil {
+		t.Categories = update.Categories
+	}
+	if update.Labels != nil {
+		t.Labels = update.Labels
+	}
+	if update.DefaultLocale != nil {
+		t.DefaultLocale = *update.DefaultLocale
+	}
+	if update.Contents != nil {
+		t.Contents = update.Contents
+	}
+}
+
+// Message is a notification rendered for a subscriber
+type Message struct {
+	Subject     string
+	Body        string
+	ContentType string
+}
+
+// Data is what the contents of a template are executed with: the fields of the notification, e.g. {{.Slug}} or
+// {{.Severity}}, along with the subscriber and its locale
+type Data struct {
+	models.Notification
+	Receiver string
+	Locale   string
+}
+
+// funcs are the functions available to the templates along with the text/template ones
+var funcs = template.FuncMap{
+	// formatTime formats a timestamp in milliseconds, e.g. {{formatTime .Created "2006-01-02 15:04"}}
+	"formatTime": func(millis int64, layout string) string {
+		return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(layout)
+	},
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// Validate checks that the template is complete and that each of its contents parses
+func (t Template) Validate() errors.EdgeX {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "template name is empty", nil)
+	}
+	if len(t.Categories) == 0 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("template %s has no categories", t.Name), nil)
+	}
+	if _, ok := t.Contents[t.DefaultLocale]; !ok {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("template %s has no content in its default locale '%s'", t.Name, t.DefaultLocale), nil)
+	}
+	for locale, c := range t.Contents {
+		if strings.TrimSpace(c.Body) == "" {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid,
+				fmt.Sprintf("template %s has an empty body in locale %s", t.Name, locale), nil)
+		}
+		if _, err := parse(t.Name, locale, c); err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid,
+				fmt.Sprintf("template %s doesn't parse in locale %s", t.Name, locale), err)
+		}
+	}
+	return nil
+}
+
+// parsed is a content whose fields are parsed, Subject being nil when empty
+type parsed struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func parse(name string, locale string, c Content) (parsed, error) {
+	var p parsed
+	var err error
+	if c.Subject != "" {
+		p.subject, err = template.New(name + "/" + locale + "/subject").Funcs(funcs).Parse(c.Subject)
+		if err != nil {
+			return p, err
+		}
+	}
+	p.body, err = template.New(name + "/" + locale + "/body").Funcs(funcs).Parse(c.Body)
+	return p, err
+}
+
+// Applies returns whether the template renders the notifications of category carrying labels
+func (t Template) Applies(category string, labels []string) bool {
+	if !contains(t.Categories, category) {
+		return false
+	}
+	for _, label := range t.Labels {
+		if !contains(labels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// Select returns the template rendering a notification: among the templates applying to it, the one with the most
+// labels, then the first by name. ok is false when none applies.
+func Select(templates []Template, n models.Notification) (selected Template, ok bool) {
+	var candidates []Template
+	for _, t := range templates {
+		if t.Applies(string(n.Category), n.Labels) {
+			candidates = append(candidates, t)
+		}
+	}
+	if len(candidates) == 0 {
+		return Template{}, false
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i].Labels) != len(candidates[j].Labels) {
+			return len(candidates[i].Labels) > len(candidates[j].Labels)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates[0], true
+}
+
+// Localize returns the locale of the contents matching locale, ignoring the case and whether the subtags are
+// separated by '-' or '_': the same locale, else its language, e.g. "fr" for "fr-CA", else the default locale
+func (t Template) Localize(locale string) string {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	subtags := strings.FieldsFunc(locale, func(r rune) bool { return r == '-' })
+	if len(subtags) == 0 {
+		return t.DefaultLocale
+	}
+	for _, candidate := range []string{locale, subtags[0]} {
+		for l := range t.Contents {
+			if strings.EqualFold(strings.ReplaceAll(l, "_", "-"), candidate) {
+				return l
+			}
+		}
+	}
+	return t.DefaultLocale
+}
+
+// Render renders the notification for receiver in its locale, or the closest one Localize finds
+func (t Template) Render(n models.Notification, receiver string, locale string) (Message, error) {
+	locale = t.Localize(locale)
+	c := t.Contents[locale]
+	p, err := parse(t.Name, locale, c)
+	if err != nil {
+		return Message{}, err
+	}
+
+	data := Data{Notification: n, Receiver: receiver, Locale: locale}
+	message := Message{ContentType: c.ContentType}
+	if message.ContentType == "" {
+		message.ContentType = n.ContentType
+	}
+	if p.subject != nil {
+		var subject strings.Builder
+		if err := p.subject.Execute(&subject, data); err != nil {
+			return Message{}, err
+		}
+		// A line break would end the Subject header of the email
+		message.Subject = strings.Join(strings.Fields(subject.String()), " ")
+	}
+	var body strings.Builder
+	if err := p.body.Execute(&body, data); err != nil {
+		return Message{}, err
+	}
+	message.Body = body.String()
+	return message, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package templates
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTemplate() Template {
+	return Template{
+		Name:          "alert",
+		Categories:    []string{"HW_HEALTH"},
+		DefaultLocale: "en",
+		Contents: map[string]Content{
+			"en":    {Subject: "[{{.Severity}}] {{.Slug}}", Body: "Hello {{.Receiver}}, {{.Content}} at {{formatTime .Created \"15:04\"}}"},
+			"fr":    {Subject: "[{{.Severity}}] {{.Slug}}", Body: "Bonjour {{.Receiver}}, {{.Content}}"},
+			"fr-CA": {Body: "Allô {{.Receiver}}, {{.Content}}", ContentType: "text/html"},
+		},
+	}
+}
+
+func testNotification() models.Notification {
+	n := models.Notification{
+		Slug:        "fan-failure",
+		Category:    models.Hwhealth,
+		Severity:    models.Critical,
+		Content:     "the fan stopped",
+		ContentType: "text/plain",
+		Labels:      []string{"building-1"},
+	}
+	n.Created = 1609459200000 + 90*60*1000
+	return n
+}
+
+func TestValidate(t *testing.T) {
+	valid := testTemplate()
+	noName := testTemplate()
+	noName.Name = " "
+	noCategories := testTemplate()
+	noCategories.Categories = nil
+	noDefaultContent := testTemplate()
+	noDefaultContent.DefaultLocale = "de"
+	emptyBody := testTemplate()
+	emptyBody.Contents["fr"] = Content{Subject: "sujet"}
+	unparsableSubject := testTemplate()
+	unparsableSubject.Contents["fr"] = Content{Subject: "{{.Slug", Body: "corps"}
+	unknownFunction := testTemplate()
+	unknownFunction.Contents["en"] = Content{Body: "{{translate .Content}}"}
+
+	tests := []struct {
+		name          string
+		template      Template
+		errorExpected bool
+	}{
+		{"Valid", valid, false},
+		{"Invalid - no name", noName, true},
+		{"Invalid - no categories", noCategories, true},
+		{"Invalid - no content in the default locale", noDefaultContent, true},
+		{"Invalid - empty body", emptyBody, true},
+		{"Invalid - subject doesn't parse", unparsableSubject, true},
+		{"Invalid - unknown function", unknownFunction, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.template.Validate()
+			if testCase.errorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	general := Template{Name: "b-general", Categories: []string{"HW_HEALTH", "SW_HEALTH"}}
+	generalToo := Template{Name: "a-general", Categories: []string{"HW_HEALTH"}}
+	building := Template{Name: "building", Categories: []string{"HW_HEALTH"}, Labels: []string{"building-1"}}
+	otherBuilding := Template{Name: "other-building", Categories: []string{"HW_HEALTH"}, Labels: []string{"building-2"}}
+	security := Template{Name: "security", Categories: []string{"SECURITY"}}
+
+	n := testNotification()
+	unlabelled := testNotification()
+	unlabelled.Labels = nil
+	software := testNotification()
+	software.Category = models.Swhealth
+	unknown := testNotification()
+	unknown.Category = "OTHER"
+
+	all := []Template{general, generalToo, building, otherBuilding, security}
+	tests := []struct {
+		name         string
+		notification models.Notification
+		expected     string
+	}{
+		{"Most labels", n, building.Name},
+		{"First by name", unlabelled, generalToo.Name},
+		{"Other category", software, general.Name},
+		{"None", unknown, ""},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			selected, ok := Select(all, testCase.notification)
+			assert.Equal(t, testCase.expected != "", ok)
+			assert.Equal(t, testCase.expected, selected.Name)
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	template := testTemplate()
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"fr-CA", "fr-CA"},
+		{"fr_ca", "fr-CA"},
+		{"fr-BE", "fr"},
+		{"FR", "fr"},
+		{"de-DE", "en"},
+		{"", "en"},
+		{"-", "en"},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.locale, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, template.Localize(testCase.locale))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	template := testTemplate()
+	n := testNotification()
+
+	m, err := template.Render(n, "ops", "en-US")
+	require.NoError(t, err)
+	assert.Equal(t, "[CRITICAL] fan-failure", m.Subject)
+	assert.Equal(t, "Hello ops, the fan stopped at 01:30", m.Body)
+	assert.Equal(t, "text/plain", m.ContentType, "the content type of the notification must be kept")
+
+	m, err = template.Render(n, "ops", "fr-CA")
+	require.NoError(t, err)
+	assert.Empty(t, m.Subject)
+	assert.Equal(t, "Allô ops, the fan stopped", m.Body)
+	assert.Equal(t, "text/html", m.ContentType)
+
+	template.Contents["en"] = Content{Subject: "{{.Slug}}\r\nBcc: someone@example.com", Body: "{{.Content}}"}
+	m, err = template.Render(n, "ops", "")
+	require.NoError(t, err)
+	assert.Equal(t, "fan-failure Bcc: someone@example.com", m.Subject, "the subject must be a single line")
+
+	template.Contents["en"] = Content{Body: "{{.Unknown}}"}
+	_, err = template.Render(n, "ops", "")
+	assert.Error(t, err)
+}
+
+func TestApply(t *testing.T) {
+	template := testTemplate()
+	description := "alerts"
+	UpdateTemplate{Description: &description, Labels: []string{"building-1"}}.Apply(&template)
+	assert.Equal(t, description, template.Description)
+	assert.Equal(t, []string{"building-1"}, template.Labels)
+	assert.Equal(t, []string{"HW_HEALTH"}, template.Categories, "the fields not set must be kept")
+	assert.Len(t, template.Contents, 3)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/infrastructure/memory"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/infrastructure/redis"
 	v2Interface "github.com/edgexfoundry/edgex-go/internal/pkg/v2/interfaces"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -49,7 +50,11 @@ func NewDatabase(httpServer httpServer, database interfaces.Database, dBClientIn
 func (d Database) newDBClient(
 	lc logger.LoggingClient,
 	credentials bootstrapConfig.Credentials) (v2Interface.DBClient, error) {
-	databaseInfo := d.database.GetDatabaseInfo()["Primary"]
+	return NewDBClient(lc, d.database.GetDatabaseInfo()["Primary"])
+}
+
+// NewDBClient returns a client of the database described by databaseInfo
+func NewDBClient(lc logger.LoggingClient, databaseInfo bootstrapConfig.Database) (v2Interface.DBClient, error) {
 	switch databaseInfo.Type {
 	case "redisdb":
 		return redis.NewClient(
@@ -58,6 +63,8 @@ func (d Database) newDBClient(
 				Port: databaseInfo.Port,
 			},
 			lc)
+	case db.MemoryDB:
+		return memory.NewClient(), nil
 	default:
 		return nil, db.ErrUnsupportedDatabase
 	}
@@ -73,9 +80,9 @@ func (d Database) BootstrapHandler(
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	secretProvider := bootstrapContainer.SecretProviderFrom(dic.Get)
 
-	// get database credentials.
+	// get database credentials, which the in-memory database has none of.
 	var credentials bootstrapConfig.Credentials
-	for startupTimer.HasNotElapsed() {
+	for d.database.GetDatabaseInfo()["Primary"].Type != db.MemoryDB && startupTimer.HasNotElapsed() {
 		var err error
 
 		secrets, err := secretProvider.GetSecrets(d.database.GetDatabaseInfo()["Primary"].Type)
@@ -11,6 +11,11 @@ import (
 	"net/http"
 
 	"github.com/edgexfoundry/edgex-go"
+	bootstrapContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/registrywatch"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/telemetry"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -20,6 +25,8 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
 )
 
 // V2CommonController controller for V2 REST APIs
@@ -37,7 +44,12 @@ func NewV2CommonController(dic *di.Container) *V2CommonController {
 // Ping handles the request to /ping endpoint. Is used to test if the service is working
 // It returns a response as specified by the V2 API swagger in openapi/v2
 func (c *V2CommonController) Ping(writer http.ResponseWriter, request *http.Request) {
-	response := common.NewPingResponse()
+	response := registrywatch.PingResponse{PingResponse: common.NewPingResponse()}
+	// the state of the registration is returned when watched, the service responding all the same
+	if watcher := bootstrapContainer.RegistryWatcherFrom(c.dic.Get); watcher != nil {
+		status := watcher.Status()
+		response.Registry = &status
+	}
 	c.sendResponse(writer, request, contractsV2.ApiPingRoute, response, http.StatusOK)
 }
 
@@ -73,6 +85,44 @@ func (c *V2CommonController) Metrics(writer http.ResponseWriter, request *http.R
 	c.sendResponse(writer, request, contractsV2.ApiMetricsRoute, response, http.StatusOK)
 }
 
+// Trace handles the request to the /trace/{correlationId} endpoint, the recent requests of the service carrying the
+// correlation id. It is used to follow a request through the services.
+func (c *V2CommonController) Trace(writer http.ResponseWriter, request *http.Request) {
+	correlationId := mux.Vars(request)[correlation.Id]
+	response := correlation.TraceResponse{
+		BaseResponse:  common.NewBaseResponse("", "", http.StatusOK),
+		CorrelationId: correlationId,
+		Requests:      correlation.RecentRequests.Find(correlationId),
+	}
+	c.sendResponse(writer, request, correlation.ApiTraceRoute, response, http.StatusOK)
+}
+
+// OutboundMetrics handles the request to the /outbound/metrics endpoint, the counters and the circuit state of the
+// destinations of the outbound requests of the service.
+func (c *V2CommonController) OutboundMetrics(writer http.ResponseWriter, request *http.Request) {
+	client := bootstrapContainer.HTTPClientFrom(c.dic.Get)
+	if client == nil {
+		err := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "the service sends no outbound requests", nil)
+		c.sendResponse(writer, request, httpclient.ApiOutboundMetricsRoute, common.NewBaseResponse("", err.Message(), err.Code()), err.Code())
+		return
+	}
+	response := httpclient.MetricsResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Destinations: client.Metrics(),
+	}
+	c.sendResponse(writer, request, httpclient.ApiOutboundMetricsRoute, response, http.StatusOK)
+}
+
+// Capabilities handles the request to the /capabilities endpoint, the optional subsystems of the service, whether they
+// are compiled and enabled. It is used by the clients to adapt to the subsystems of the service.
+func (c *V2CommonController) Capabilities(writer http.ResponseWriter, request *http.Request) {
+	response := features.CapabilitiesResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Features:     bootstrapContainer.FeaturesFrom(c.dic.Get).Features(),
+	}
+	c.sendResponse(writer, request, features.ApiCapabilitiesRoute, response, http.StatusOK)
+}
+
 // sendResponse puts together the response packet for the V2 API
 func (c *V2CommonController) sendResponse(
 	writer http.ResponseWriter,
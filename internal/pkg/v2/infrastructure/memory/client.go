@@ -0,0 +1,241 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory implements the V2 DBClient interfaces over in-memory tables, mirroring the behavior of the Redis
+// implementation so that the services can run in development and in integration tests without a database.
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// Client is a V2 DBClient keeping its data in memory, which is lost once the client is garbage collected
+type Client struct {
+	mutex sync.RWMutex
+
+	deviceProfiles    *table
+	deviceServices    *table
+	devices           *table
+	provisionWatchers *table
+	deprecations      *table
+	discoveryRecords  *table
+	adminSchedules    *table
+	claimCodes        map[string]claimcodes.ClaimCode
+	callbacks         *table
+
+	events        *table
+	readings      *table
+	eventReadings map[string][]string
+	annotations   map[string]quality.Annotation
+	eventTags     map[string]map[string]string
+	eventExpiries map[string]int64
+	systemEvents  *table
+
+	subscriptions     *table
+	templates         *table
+	locales           map[string]string
+	muteWindows       *table
+	heldNotifications map[string]int64
+
+	intervals    *table
+	oneShots     *table
+	deviceScopes *table
+	calendars    *table
+}
+
+// NewClient returns an empty Client
+func NewClient() *Client {
+	return &Client{
+		deviceProfiles:    newTable(),
+		deviceServices:    newTable(),
+		devices:           newTable(),
+		provisionWatchers: newTable(),
+		deprecations:      newTable(),
+		discoveryRecords:  newTable(),
+		adminSchedules:    newTable(),
+		claimCodes:        make(map[string]claimcodes.ClaimCode),
+		callbacks:         newTable(),
+
+		events:        newTable(),
+		readings:      newTable(),
+		eventReadings: make(map[string][]string),
+		annotations:   make(map[string]quality.Annotation),
+		eventTags:     make(map[string]map[string]string),
+		eventExpiries: make(map[string]int64),
+		systemEvents:  newTable(),
+
+		subscriptions:     newTable(),
+		templates:         newTable(),
+		locales:           make(map[string]string),
+		muteWindows:       newTable(),
+		heldNotifications: make(map[string]int64),
+
+		intervals:    newTable(),
+		oneShots:     newTable(),
+		deviceScopes: newTable(),
+		calendars:    newTable(),
+	}
+}
+
+// CloseSession has nothing to release, the data being kept until the Client is garbage collected
+func (c *Client) CloseSession() {
+}
+
+// row is an object of a table along with its unique name, if any, and the sequence number it was added with
+type row struct {
+	seq    uint64
+	name   string
+	object interface{}
+}
+
+// table keeps the objects of a kind by id and indexes the named ones by name
+type table struct {
+	seq   uint64
+	rows  map[string]*row
+	names map[string]string
+}
+
+func newTable() *table {
+	return &table{
+		rows:  make(map[string]*row),
+		names: make(map[string]string),
+	}
+}
+
+// put adds the object, or replaces the object of the same id while keeping its sequence number
+func (t *table) put(id string, name string, object interface{}) {
+	r, ok := t.rows[id]
+	if ok {
+		if r.name != "" {
+			delete(t.names, r.name)
+		}
+	} else {
+		t.seq++
+		r = &row{seq: t.seq}
+		t.rows[id] = r
+	}
+	r.name = name
+	r.object = object
+	if name != "" {
+		t.names[name] = id
+	}
+}
+
+func (t *table) get(id string) (interface{}, bool) {
+	r, ok := t.rows[id]
+	if !ok {
+		return nil, false
+	}
+	return r.object, true
+}
+
+func (t *table) getByName(name string) (interface{}, bool) {
+	id, ok := t.names[name]
+	if !ok {
+		return nil, false
+	}
+	return t.get(id)
+}
+
+func (t *table) hasId(id string) bool {
+	_, ok := t.rows[id]
+	return ok
+}
+
+func (t *table) hasName(name string) bool {
+	_, ok := t.names[name]
+	return ok
+}
+
+// delete removes the object of the given id, returning whether it existed
+func (t *table) delete(id string) bool {
+	r, ok := t.rows[id]
+	if !ok {
+		return false
+	}
+	delete(t.rows, id)
+	if r.name != "" {
+		delete(t.names, r.name)
+	}
+	return true
+}
+
+// sorted returns the objects matching match, or all of them when match is nil, by descending score or ascending
+// score when ascending is set. Objects of the same score come in the reverse order they were added, or in that order
+// when ascending.
+func (t *table) sorted(score func(interface{}) int64, ascending bool, match func(interface{}) bool) []interface{} {
+	rows := make([]*row, 0, len(t.rows))
+	for _, r := range t.rows {
+		if match == nil || match(r.object) {
+			rows = append(rows, r)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		si, sj := score(rows[i].object), score(rows[j].object)
+		if si != sj {
+			return (si < sj) == ascending
+		}
+		return (rows[i].seq < rows[j].seq) == ascending
+	})
+
+	objects := make([]interface{}, len(rows))
+	for i, r := range rows {
+		objects[i] = r.object
+	}
+	return objects
+}
+
+// page returns the objects starting at offset with up to limit of them, all of them when limit is negative. As with
+// Redis, an offset past the objects is out of range unless there are none.
+func page(objects []interface{}, offset int, limit int) ([]interface{}, errors.EdgeX) {
+	if len(objects) == 0 {
+		return nil, nil
+	} else if offset > len(objects) {
+		return nil, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("query objects bounds out of range. length:%v", len(objects)), nil)
+	}
+	objects = objects[offset:]
+	if limit >= 0 && limit < len(objects) {
+		objects = objects[:limit]
+	}
+	return objects, nil
+}
+
+// pageInRange is page for the queries by score range, for which an offset at the end of the objects is out of range
+func pageInRange(objects []interface{}, offset int, limit int) ([]interface{}, errors.EdgeX) {
+	if len(objects) > 0 && offset >= len(objects) {
+		return nil, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("query objects bounds out of range. length:%v offset:%v", len(objects), offset), nil)
+	}
+	return page(objects, offset, limit)
+}
+
+// hasLabels returns whether every label is among the object labels
+func hasLabels(objectLabels []string, labels []string) bool {
+	for _, label := range labels {
+		if !contains(objectLabels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func notFound(kind string, key string) errors.EdgeX {
+	return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("%s %s doesn't exist in the database", kind, key), nil)
+}
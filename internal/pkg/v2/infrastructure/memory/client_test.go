@@ -0,0 +1,222 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"testing"
+
+	dataInterfaces "github.com/edgexfoundry/edgex-go/internal/core/data/v2/infrastructure/interfaces"
+	metadataInterfaces "github.com/edgexfoundry/edgex-go/internal/core/metadata/v2/infrastructure/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
+	notificationsInterfaces "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/infrastructure/interfaces"
+	schedulerInterfaces "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/infrastructure/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	model "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ dataInterfaces.DBClient          = &Client{}
+	_ metadataInterfaces.DBClient      = &Client{}
+	_ notificationsInterfaces.DBClient = &Client{}
+	_ schedulerInterfaces.DBClient     = &Client{}
+)
+
+func addEvent(t *testing.T, c *Client, deviceName string, created int64, values ...string) model.Event {
+	e := model.Event{DeviceName: deviceName, Created: created}
+	for _, value := range values {
+		e.Readings = append(e.Readings, model.SimpleReading{
+			BaseReading: model.BaseReading{DeviceName: deviceName, ResourceName: "temperature", Created: created},
+			Value:       value,
+		})
+	}
+	added, err := c.AddEvent(e)
+	require.NoError(t, err)
+	return added
+}
+
+func TestEvents(t *testing.T) {
+	c := NewClient()
+	first := addEvent(t, c, "device-1", 1, "20", "21")
+	second := addEvent(t, c, "device-2", 2, "22")
+	third := addEvent(t, c, "device-1", 3)
+
+	_, err := c.AddEvent(model.Event{Id: first.Id})
+	assert.Equal(t, errors.KindDuplicateName, errors.Kind(err))
+	_, err = c.AddEvent(model.Event{Id: "not-a-uuid"})
+	assert.Equal(t, errors.KindInvalidId, errors.Kind(err))
+
+	e, err := c.EventById(first.Id)
+	require.NoError(t, err)
+	require.Len(t, e.Readings, 2)
+	assert.Equal(t, "21", e.Readings[1].(model.SimpleReading).Value, "Readings should keep the order of the event")
+
+	tests := []struct {
+		name     string
+		offset   int
+		limit    int
+		expected []string
+		kind     errors.ErrKind
+	}{
+		{"all", 0, -1, []string{third.Id, second.Id, first.Id}, ""},
+		{"page", 1, 1, []string{second.Id}, ""},
+		{"past the end", 3, 1, []string{}, ""},
+		{"out of range", 4, 1, nil, errors.KindRangeNotSatisfiable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := c.AllEvents(tt.offset, tt.limit)
+			if tt.kind != "" {
+				assert.Equal(t, tt.kind, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			ids := []string{}
+			for _, e := range events {
+				ids = append(ids, e.Id)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	count, err := c.EventCountByDeviceNameAndTimeRange("device-1", 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), count)
+
+	require.NoError(t, c.DeleteEventById(first.Id))
+	count, err = c.ReadingTotalCount(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), count, "The readings should be deleted with their event")
+	assert.Equal(t, errors.KindEntityDoesNotExist, errors.Kind(c.DeleteEventById(first.Id)))
+}
+
+func TestReadingsExcludingQualities(t *testing.T) {
+	c := NewClient()
+	e := addEvent(t, c, "device-1", 1, "20", "", "22")
+	require.NoError(t, c.AddReadingAnnotations(map[string]quality.Annotation{
+		e.Readings[0].GetBaseReading().Id: {Quality: quality.Good},
+		e.Readings[1].GetBaseReading().Id: {Quality: quality.Bad, Null: true},
+	}))
+
+	annotations, err := c.ReadingAnnotations([]string{e.Readings[0].GetBaseReading().Id, e.Readings[1].GetBaseReading().Id})
+	require.NoError(t, err)
+	assert.Len(t, annotations, 1, "Good readings with a value should not be annotated")
+
+	readings, err := c.ReadingsByDeviceName(0, -1, "device-1", []string{quality.Bad})
+	require.NoError(t, err)
+	assert.Len(t, readings, 2)
+	count, err := c.ReadingCountByDeviceName("device-1", []string{quality.Bad})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), count)
+
+	_, err = c.AllReadings(3, 1, []string{quality.Bad})
+	assert.Equal(t, errors.KindRangeNotSatisfiable, errors.Kind(err))
+}
+
+func TestEventsByTags(t *testing.T) {
+	c := NewClient()
+	first := addEvent(t, c, "device-1", 1)
+	second := addEvent(t, c, "device-1", 2)
+	require.NoError(t, c.AddEventTagIndex(first.Id, first.Created, map[string]string{"site": "plant-1", "line": "1"}))
+	require.NoError(t, c.AddEventTagIndex(second.Id, second.Created, map[string]string{"site": "plant-1", "line": "2"}))
+
+	tests := []struct {
+		name     string
+		terms    []tags.Term
+		expected []string
+	}{
+		{"equal", []tags.Term{{Name: "site", Operator: tags.Equal, Value: "plant-1"}}, []string{second.Id, first.Id}},
+		{"not equal", []tags.Term{{Name: "line", Operator: tags.NotEqual, Value: "2"}}, []string{first.Id}},
+		{"exists", []tags.Term{{Name: "shift", Operator: tags.Exists}}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := c.EventsByTags(tt.terms, 0, -1)
+			require.NoError(t, err)
+			ids := []string{}
+			for _, e := range events {
+				ids = append(ids, e.Id)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func TestDeleteExpiredEvents(t *testing.T) {
+	c := NewClient()
+	_, err := c.AddExpiringEvent(model.Event{DeviceName: "device-1", Created: 1}, 9)
+	require.NoError(t, err)
+	_, err = c.AddExpiringEvent(model.Event{DeviceName: "device-1", Created: 2}, 18)
+	require.NoError(t, err)
+	addEvent(t, c, "device-1", 3, "22")
+
+	deleted, err := c.DeleteExpiredEvents(15, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), deleted)
+	count, err := c.EventTotalCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), count)
+
+	deleted, err = c.DeleteExpiredEvents(100, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), deleted, "the events added without a TTL never expire")
+}
+
+func TestDevicesBySearch(t *testing.T) {
+	c := NewClient()
+	_, err := c.AddDevice(model.Device{Name: "boiler-temperature", Labels: []string{"hvac"}})
+	require.NoError(t, err)
+	_, err = c.AddDevice(model.Device{Name: "pump", Description: "Water Pump", Labels: []string{"plumbing"}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		query    string
+		labels   []string
+		expected []string
+	}{
+		{"word prefix", "temp", nil, []string{"boiler-temperature"}},
+		{"case insensitive", "WATER", nil, []string{"pump"}},
+		{"every word", "boiler pump", nil, []string{}},
+		{"labels", "pu", []string{"hvac"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devices, err := c.DevicesBySearch(0, -1, tt.query, tt.labels)
+			require.NoError(t, err)
+			names := []string{}
+			for _, d := range devices {
+				names = append(names, d.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	_, err = c.DevicesBySearch(0, -1, "a", nil)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+}
+
+func TestDeviceProfileUpdate(t *testing.T) {
+	c := NewClient()
+	added, err := c.AddDeviceProfile(model.DeviceProfile{Name: "profile", Model: "m1"})
+	require.NoError(t, err)
+	_, err = c.AddDeviceProfile(model.DeviceProfile{Name: "profile"})
+	assert.Equal(t, errors.KindDuplicateName, errors.Kind(err))
+
+	err = c.UpdateDeviceProfile(model.DeviceProfile{Id: added.Id, Name: "renamed"})
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+
+	require.NoError(t, c.UpdateDeviceProfile(model.DeviceProfile{Name: "profile", Model: "m2"}))
+	updated, err := c.DeviceProfileByName("profile")
+	require.NoError(t, err)
+	assert.Equal(t, added.Id, updated.Id)
+	assert.Equal(t, added.Created, updated.Created)
+	assert.Equal(t, "m2", updated.Model)
+}
@@ -0,0 +1,631 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/partitions"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	model "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/google/uuid"
+)
+
+/* ----------------------------- Event ---------------------------------- */
+
+// AddEvent adds the event along with its readings, whose binary values are not kept
+func (c *Client) AddEvent(e model.Event) (model.Event, errors.EdgeX) {
+	return c.AddExpiringEvent(e, 0)
+}
+
+// AddExpiringEvent adds the event along with its readings, the event expiring ttl milliseconds after its creation,
+// or never when ttl is zero
+func (c *Client) AddExpiringEvent(e model.Event, ttl int64) (model.Event, errors.EdgeX) {
+	if e.Id != "" {
+		if _, err := uuid.Parse(e.Id); err != nil {
+			return model.Event{}, errors.NewCommonEdgeX(errors.KindInvalidId, "uuid parsing failed", err)
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e.Id == "" {
+		e.Id = uuid.New().String()
+	} else if c.events.hasId(e.Id) {
+		return model.Event{}, errors.NewCommonEdgeX(errors.KindDuplicateName, "Event Id exists", nil)
+	}
+	if e.Created == 0 {
+		e.Created = common.MakeTimestamp()
+	}
+
+	var readings []model.Reading
+	stored := make([]model.SimpleReading, len(e.Readings))
+	for i, r := range e.Readings {
+		switch newReading := r.(type) {
+		case model.BinaryReading:
+			newReading.BinaryValue = []byte{}
+			if edgeXerr := checkReadingValue(&newReading.BaseReading); edgeXerr != nil {
+				return model.Event{}, edgeXerr
+			}
+			stored[i] = model.SimpleReading{BaseReading: newReading.BaseReading}
+			readings = append(readings, newReading)
+		case model.SimpleReading:
+			if edgeXerr := checkReadingValue(&newReading.BaseReading); edgeXerr != nil {
+				return model.Event{}, edgeXerr
+			}
+			stored[i] = newReading
+			readings = append(readings, newReading)
+		default:
+			return model.Event{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "unsupported reading type", nil)
+		}
+	}
+
+	event := e
+	event.Readings = nil
+	c.events.put(e.Id, "", event)
+	ids := make([]string, len(stored))
+	for i, r := range stored {
+		c.readings.put(r.Id, "", r)
+		ids[i] = r.Id
+	}
+	c.eventReadings[e.Id] = ids
+	if ttl > 0 {
+		c.eventExpiries[e.Id] = e.Created + ttl
+	}
+	e.Readings = readings
+	return e, nil
+}
+
+// EventById returns the event of the given id along with its readings
+func (c *Client) EventById(id string) (model.Event, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.events.get(id)
+	if !ok {
+		return model.Event{}, notFound("event", id)
+	}
+	return c.eventOf(o), nil
+}
+
+// DeleteEventById deletes the event of the given id along with its readings
+func (c *Client) DeleteEventById(id string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.deleteEvent(id) {
+		return notFound("event", id)
+	}
+	return nil
+}
+
+// EventTotalCount returns the number of events
+func (c *Client) EventTotalCount() (uint32, errors.EdgeX) {
+	return c.eventCount(func(model.Event) bool { return true }), nil
+}
+
+// EventCountByDeviceName returns the number of events of the device of the given name
+func (c *Client) EventCountByDeviceName(deviceName string) (uint32, errors.EdgeX) {
+	return c.eventCount(func(e model.Event) bool { return e.DeviceName == deviceName }), nil
+}
+
+// EventCountByTimeRange returns the number of events created in the time range
+func (c *Client) EventCountByTimeRange(start int, end int) (uint32, errors.EdgeX) {
+	return c.eventCount(func(e model.Event) bool { return createdIn(e.Created, start, end) }), nil
+}
+
+// EventCountByDeviceNameAndTimeRange returns the number of events of the device of the given name created in the time
+// range
+func (c *Client) EventCountByDeviceNameAndTimeRange(deviceName string, start int, end int) (uint32, errors.EdgeX) {
+	return c.eventCount(func(e model.Event) bool {
+		return e.DeviceName == deviceName && createdIn(e.Created, start, end)
+	}), nil
+}
+
+// DeviceHasEventsSince returns whether the device of the given name has events created since the given timestamp
+func (c *Client) DeviceHasEventsSince(deviceName string, since int) (bool, errors.EdgeX) {
+	count := c.eventCount(func(e model.Event) bool { return e.DeviceName == deviceName && e.Created >= int64(since) })
+	return count > 0, nil
+}
+
+// SetEventPartitioning does nothing, the events being kept in memory without indexes
+func (c *Client) SetEventPartitioning(hotThreshold int, span time.Duration) {
+}
+
+// EventPartitions returns no hot devices, the events being kept in memory without indexes
+func (c *Client) EventPartitions(offset int, limit int) ([]partitions.DevicePartitions, errors.EdgeX) {
+	return []partitions.DevicePartitions{}, nil
+}
+
+// EventPartitionsByDeviceName returns the number of events of the device of the given name, which is never hot
+func (c *Client) EventPartitionsByDeviceName(deviceName string) (partitions.DevicePartitions, errors.EdgeX) {
+	count, _ := c.EventCountByDeviceName(deviceName)
+	return partitions.DevicePartitions{DeviceName: deviceName, ColdEvents: count}, nil
+}
+
+// AllEvents returns the events by offset and limit, the latest created first
+func (c *Client) AllEvents(offset int, limit int) ([]model.Event, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.eventsOf(page(c.events.sorted(byCreated, false, nil), offset, limit))
+}
+
+// EventsByDeviceName returns the events of the device of the given name by offset and limit, the latest created first
+func (c *Client) EventsByDeviceName(offset int, limit int, name string) ([]model.Event, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.eventsOf(page(c.events.sorted(byCreated, false, func(o interface{}) bool {
+		return o.(model.Event).DeviceName == name
+	}), offset, limit))
+}
+
+// DeleteEventsByDeviceName deletes the events of the device of the given name along with their readings
+func (c *Client) DeleteEventsByDeviceName(deviceName string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, o := range c.events.sorted(byCreated, false, func(o interface{}) bool {
+		return o.(model.Event).DeviceName == deviceName
+	}) {
+		c.deleteEvent(o.(model.Event).Id)
+	}
+	return nil
+}
+
+// EventsByTimeRange returns the events created in the time range by offset and limit, the latest created first
+func (c *Client) EventsByTimeRange(start int, end int, offset int, limit int) ([]model.Event, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.eventsOf(pageInRange(c.events.sorted(byCreated, false, func(o interface{}) bool {
+		return createdIn(o.(model.Event).Created, start, end)
+	}), offset, limit))
+}
+
+// DeleteEventsByAge deletes the events older than age milliseconds along with their readings
+func (c *Client) DeleteEventsByAge(age int64) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expired := common.MakeTimestamp() - age
+	for _, o := range c.events.sorted(byCreated, false, func(o interface{}) bool {
+		return o.(model.Event).Created <= expired
+	}) {
+		c.deleteEvent(o.(model.Event).Id)
+	}
+	return nil
+}
+
+// AddEventTagIndex indexes the event with the given id by the given tags
+func (c *Client) AddEventTagIndex(id string, created int64, eventTags map[string]string) errors.EdgeX {
+	if len(eventTags) == 0 {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	indexed, ok := c.eventTags[id]
+	if !ok {
+		indexed = make(map[string]string, len(eventTags))
+		c.eventTags[id] = indexed
+	}
+	for name, value := range eventTags {
+		indexed[name] = value
+	}
+	return nil
+}
+
+// DeleteExpiredEvents deletes, with their readings, up to limit events expired at the now timestamp, the earliest
+// expired first, and returns the number of events deleted
+func (c *Client) DeleteExpiredEvents(now int64, limit int) (uint32, errors.EdgeX) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var ids []string
+	for id, expireAt := range c.eventExpiries {
+		if expireAt <= now {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if c.eventExpiries[ids[i]] != c.eventExpiries[ids[j]] {
+			return c.eventExpiries[ids[i]] < c.eventExpiries[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if limit >= 0 && limit < len(ids) {
+		ids = ids[:limit]
+	}
+
+	var deleted uint32
+	for _, id := range ids {
+		if c.deleteEvent(id) {
+			deleted++
+		} else {
+			// the event is gone already, only its expiry is left to remove
+			delete(c.eventExpiries, id)
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteEventsByTimeRange deletes, with their readings, up to limit events created in the time range, the earliest
+// first, and returns the number of events deleted
+func (c *Client) DeleteEventsByTimeRange(start int, end int, limit int) (uint32, errors.EdgeX) {
+	return c.deleteEventsWhere(limit, func(e model.Event) bool { return createdIn(e.Created, start, end) }), nil
+}
+
+// DeleteEventsByDeviceNameAndTimeRange deletes, with their readings, up to limit events of the device of the given
+// name created in the time range, the earliest first, and returns the number of events deleted
+func (c *Client) DeleteEventsByDeviceNameAndTimeRange(deviceName string, start int, end int, limit int) (uint32, errors.EdgeX) {
+	return c.deleteEventsWhere(limit, func(e model.Event) bool {
+		return e.DeviceName == deviceName && createdIn(e.Created, start, end)
+	}), nil
+}
+
+// CompressReadings compresses nothing since the readings are not serialized in memory, and returns 0
+func (c *Client) CompressReadings(before int64, chunkSize int) (uint32, errors.EdgeX) {
+	return 0, nil
+}
+
+// EventsByTags returns the events matching every term of a tag expression by offset and limit, the latest created
+// first
+func (c *Client) EventsByTags(terms []tags.Term, offset int, limit int) ([]model.Event, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.eventsOf(page(c.eventsMatching(terms), offset, limit))
+}
+
+// EventCountByTags returns the number of events matching every term of a tag expression
+func (c *Client) EventCountByTags(terms []tags.Term) (uint32, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return uint32(len(c.eventsMatching(terms))), nil
+}
+
+// ReadingsByTags returns the readings of the events matching every term of a tag expression by offset and limit. The
+// readings are sorted by event, the latest created first, and then in the order of the event.
+func (c *Client) ReadingsByTags(terms []tags.Term, offset int, limit int) ([]model.Reading, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var objects []interface{}
+	for _, o := range c.eventsMatching(terms) {
+		for _, id := range c.eventReadings[o.(model.Event).Id] {
+			r, _ := c.readings.get(id)
+			objects = append(objects, r)
+		}
+	}
+	return readingsOf(page(objects, offset, limit))
+}
+
+// eventsMatching returns the events matching every term from their indexed tags, the latest created first
+func (c *Client) eventsMatching(terms []tags.Term) []interface{} {
+	return c.events.sorted(byCreated, false, func(o interface{}) bool {
+		indexed := c.eventTags[o.(model.Event).Id]
+		for _, t := range terms {
+			value, ok := indexed[t.Name]
+			switch t.Operator {
+			case tags.Equal:
+				if !ok || value != t.Value {
+					return false
+				}
+			case tags.NotEqual:
+				if ok && value == t.Value {
+					return false
+				}
+			default:
+				if !ok {
+					return false
+				}
+			}
+		}
+		return true
+	})
+}
+
+// deleteEvent deletes the event of the given id along with its readings, index and expiry, returning whether it
+// existed
+func (c *Client) deleteEvent(id string) bool {
+	if !c.events.delete(id) {
+		return false
+	}
+	for _, readingId := range c.eventReadings[id] {
+		c.readings.delete(readingId)
+		delete(c.annotations, readingId)
+	}
+	delete(c.eventReadings, id)
+	delete(c.eventTags, id)
+	delete(c.eventExpiries, id)
+	return true
+}
+
+// deleteEventsWhere deletes up to limit events matching, the earliest created first, and returns their number
+func (c *Client) deleteEventsWhere(limit int, match func(model.Event) bool) uint32 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var deleted uint32
+	for _, o := range c.events.sorted(byCreated, true, func(o interface{}) bool { return match(o.(model.Event)) }) {
+		if int(deleted) >= limit {
+			break
+		}
+		if c.deleteEvent(o.(model.Event).Id) {
+			deleted++
+		}
+	}
+	return deleted
+}
+
+func (c *Client) eventCount(match func(model.Event) bool) uint32 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var count uint32
+	for _, r := range c.events.rows {
+		if match(r.object.(model.Event)) {
+			count++
+		}
+	}
+	return count
+}
+
+// eventOf returns the stored event o along with its readings
+func (c *Client) eventOf(o interface{}) model.Event {
+	e := o.(model.Event)
+	ids := c.eventReadings[e.Id]
+	if len(ids) > 0 {
+		e.Readings = make([]model.Reading, len(ids))
+		for i, id := range ids {
+			r, _ := c.readings.get(id)
+			e.Readings[i] = r.(model.SimpleReading)
+		}
+	}
+	return e
+}
+
+func (c *Client) eventsOf(objects []interface{}, edgeXerr errors.EdgeX) ([]model.Event, errors.EdgeX) {
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	events := make([]model.Event, len(objects))
+	for i, o := range objects {
+		events[i] = c.eventOf(o)
+	}
+	return events, nil
+}
+
+/* ----------------------------- Reading ---------------------------------- */
+
+// ReadingTotalCount returns the number of readings, leaving out the readings of the excluded qualities
+func (c *Client) ReadingTotalCount(excludedQualities []string) (uint32, errors.EdgeX) {
+	return c.readingCount(func(model.SimpleReading) bool { return true }, excludedQualities), nil
+}
+
+// AllReadings returns the readings by offset and limit, the latest created first, leaving out the readings of the
+// excluded qualities
+func (c *Client) AllReadings(offset int, limit int, excludedQualities []string) ([]model.Reading, errors.EdgeX) {
+	return c.readingsWhere(offset, limit, false, func(model.SimpleReading) bool { return true }, excludedQualities)
+}
+
+// ReadingsByTimeRange returns the readings created in the time range by offset and limit, the latest created first,
+// leaving out the readings of the excluded qualities
+func (c *Client) ReadingsByTimeRange(start int, end int, offset int, limit int, excludedQualities []string) ([]model.Reading, errors.EdgeX) {
+	return c.readingsWhere(offset, limit, true, func(r model.SimpleReading) bool {
+		return createdIn(r.Created, start, end)
+	}, excludedQualities)
+}
+
+// ReadingsByResourceName returns the readings of the device resource of the given name by offset and limit, the
+// latest created first, leaving out the readings of the excluded qualities
+func (c *Client) ReadingsByResourceName(offset int, limit int, resourceName string, excludedQualities []string) ([]model.Reading, errors.EdgeX) {
+	return c.readingsWhere(offset, limit, false, func(r model.SimpleReading) bool {
+		return r.ResourceName == resourceName
+	}, excludedQualities)
+}
+
+// ReadingsByDeviceName returns the readings of the device of the given name by offset and limit, the latest created
+// first, leaving out the readings of the excluded qualities
+func (c *Client) ReadingsByDeviceName(offset int, limit int, name string, excludedQualities []string) ([]model.Reading, errors.EdgeX) {
+	return c.readingsWhere(offset, limit, false, func(r model.SimpleReading) bool {
+		return r.DeviceName == name
+	}, excludedQualities)
+}
+
+// ReadingCountByDeviceName returns the number of readings of the device of the given name, leaving out the readings
+// of the excluded qualities
+func (c *Client) ReadingCountByDeviceName(deviceName string, excludedQualities []string) (uint32, errors.EdgeX) {
+	return c.readingCount(func(r model.SimpleReading) bool { return r.DeviceName == deviceName }, excludedQualities), nil
+}
+
+// ReadingCountByDeviceNameAndTimeRange returns the number of readings of the device of the given name created in the
+// time range, leaving out the readings of the excluded qualities
+func (c *Client) ReadingCountByDeviceNameAndTimeRange(deviceName string, start int, end int, excludedQualities []string) (uint32, errors.EdgeX) {
+	return c.readingCount(func(r model.SimpleReading) bool {
+		return r.DeviceName == deviceName && createdIn(r.Created, start, end)
+	}, excludedQualities), nil
+}
+
+// AddReadingAnnotations sets the quality annotations of the readings of the given ids, the good readings with a value
+// needing none
+func (c *Client) AddReadingAnnotations(annotations map[string]quality.Annotation) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for id, a := range annotations {
+		if a.IsZero() {
+			continue
+		}
+		c.annotations[id] = a
+	}
+	return nil
+}
+
+// ReadingAnnotations returns the annotations of the readings of the given ids that have one
+func (c *Client) ReadingAnnotations(ids []string) (map[string]quality.Annotation, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	annotations := make(map[string]quality.Annotation)
+	for _, id := range ids {
+		if a, ok := c.annotations[id]; ok {
+			annotations[id] = a
+		}
+	}
+	return annotations, nil
+}
+
+// readingsWhere returns the readings matching match by offset and limit, the latest created first, leaving out those
+// of the excluded qualities. inRange is set for the queries by time range, whose offset is out of range at the end of
+// the readings.
+func (c *Client) readingsWhere(offset int, limit int, inRange bool, match func(model.SimpleReading) bool, excluded []string) ([]model.Reading, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if len(excluded) > 0 {
+		objects := c.readings.sorted(byCreated, false, func(o interface{}) bool {
+			r := o.(model.SimpleReading)
+			return match(r) && !c.hasExcludedQuality(r.Id, excluded)
+		})
+		if offset > len(objects) {
+			return nil, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("query objects bounds out of range. length:%v", len(objects)), nil)
+		}
+		objects = objects[offset:]
+		if limit >= 0 && limit < len(objects) {
+			objects = objects[:limit]
+		}
+		return readingsOf(objects, nil)
+	}
+
+	objects := c.readings.sorted(byCreated, false, func(o interface{}) bool { return match(o.(model.SimpleReading)) })
+	if inRange {
+		return readingsOf(pageInRange(objects, offset, limit))
+	}
+	return readingsOf(page(objects, offset, limit))
+}
+
+func (c *Client) readingCount(match func(model.SimpleReading) bool, excluded []string) uint32 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var count uint32
+	for id, r := range c.readings.rows {
+		if match(r.object.(model.SimpleReading)) && !c.hasExcludedQuality(id, excluded) {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *Client) hasExcludedQuality(id string, excluded []string) bool {
+	a, ok := c.annotations[id]
+	return ok && contains(excluded, a.Quality)
+}
+
+func readingsOf(objects []interface{}, edgeXerr errors.EdgeX) ([]model.Reading, errors.EdgeX) {
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	readings := make([]model.Reading, len(objects))
+	for i, o := range objects {
+		readings[i] = o.(model.SimpleReading)
+	}
+	return readings, nil
+}
+
+func checkReadingValue(b *model.BaseReading) errors.EdgeX {
+	if b.Created == 0 {
+		b.Created = common.MakeTimestamp()
+	}
+	if b.Id == "" {
+		b.Id = uuid.New().String()
+	} else if _, err := uuid.Parse(b.Id); err != nil {
+		return errors.NewCommonEdgeX(errors.KindInvalidId, "uuid parsing failed", err)
+	}
+	return nil
+}
+
+/* ----------------------------- System Event ---------------------------------- */
+
+// AddSystemEvents adds the system events, recorded now unless they have a timestamp
+func (c *Client) AddSystemEvents(events []audit.SystemEvent) ([]audit.SystemEvent, errors.EdgeX) {
+	for _, e := range events {
+		if e.Id == "" {
+			continue
+		}
+		if _, err := uuid.Parse(e.Id); err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindInvalidId, "uuid parsing failed", err)
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i := range events {
+		if events[i].Id == "" {
+			events[i].Id = uuid.New().String()
+		}
+		if events[i].Timestamp == 0 {
+			events[i].Timestamp = common.MakeTimestamp()
+		}
+		c.systemEvents.put(events[i].Id, "", events[i])
+	}
+	return events, nil
+}
+
+// SystemEventsByTimeRange returns the system events recorded in the time range, by the actor if it is not empty, by
+// offset and limit, the latest first
+func (c *Client) SystemEventsByTimeRange(start int, end int, actor string, offset int, limit int) ([]audit.SystemEvent, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := pageInRange(c.systemEvents.sorted(func(o interface{}) int64 {
+		return o.(audit.SystemEvent).Timestamp
+	}, false, func(o interface{}) bool {
+		e := o.(audit.SystemEvent)
+		return (actor == "" || e.Actor == actor) && createdIn(e.Timestamp, start, end)
+	}), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	events := make([]audit.SystemEvent, len(objects))
+	for i, o := range objects {
+		events[i] = o.(audit.SystemEvent)
+	}
+	return events, nil
+}
+
+/* ----------------------------- Helpers ---------------------------------- */
+
+// byCreated scores the events and readings by their creation timestamp
+func byCreated(o interface{}) int64 {
+	switch object := o.(type) {
+	case model.Event:
+		return object.Created
+	case model.SimpleReading:
+		return object.Created
+	}
+	return 0
+}
+
+// createdIn returns whether the timestamp is in the time range [start, end]
+func createdIn(created int64, start int, end int) bool {
+	return created >= int64(start) && created <= int64(end)
+}
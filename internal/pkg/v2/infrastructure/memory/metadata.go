@@ -0,0 +1,940 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	model "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/google/uuid"
+)
+
+/* ----------------------------- Device Profile ---------------------------------- */
+
+// AddDeviceProfile adds the device profile, whose id and name must be unique
+func (c *Client) AddDeviceProfile(dp model.DeviceProfile) (model.DeviceProfile, errors.EdgeX) {
+	if dp.Id != "" {
+		if _, err := uuid.Parse(dp.Id); err != nil {
+			return model.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindInvalidId, "ID failed UUID parsing", err)
+		}
+	} else {
+		dp.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.deviceProfiles.hasId(dp.Id) {
+		return dp, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device profile id %s exists", dp.Id), nil)
+	}
+	if c.deviceProfiles.hasName(dp.Name) {
+		return dp, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device profile name %s exists", dp.Name), nil)
+	}
+	dp.Timestamps = newTimestamps(dp.Timestamps)
+	c.deviceProfiles.put(dp.Id, dp.Name, dp)
+	return dp, nil
+}
+
+// UpdateDeviceProfile replaces the device profile of the same id, or of the same name when the id is unknown
+func (c *Client) UpdateDeviceProfile(dp model.DeviceProfile) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	o, ok := c.deviceProfiles.get(dp.Id)
+	if ok {
+		if old := o.(model.DeviceProfile); dp.Name != old.Name {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device profile name '%s' not match the exsting '%s' ", dp.Name, old.Name), nil)
+		}
+	} else if o, ok = c.deviceProfiles.getByName(dp.Name); !ok {
+		return notFound("device profile", dp.Name)
+	}
+	old := o.(model.DeviceProfile)
+	dp.Id = old.Id
+	dp.Created = old.Created
+	dp.Modified = common.MakeTimestamp()
+	c.deviceProfiles.put(dp.Id, dp.Name, dp)
+	return nil
+}
+
+// DeviceProfileByName returns the device profile of the given name
+func (c *Client) DeviceProfileByName(name string) (model.DeviceProfile, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.deviceProfiles.getByName(name)
+	if !ok {
+		return model.DeviceProfile{}, notFound("device profile", name)
+	}
+	return o.(model.DeviceProfile), nil
+}
+
+// DeleteDeviceProfileById deletes the device profile of the given id
+func (c *Client) DeleteDeviceProfileById(id string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.deviceProfiles.delete(id) {
+		return notFound("device profile", id)
+	}
+	return nil
+}
+
+// DeleteDeviceProfileByName deletes the device profile of the given name
+func (c *Client) DeleteDeviceProfileByName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.deviceProfiles.names[name]
+	if !ok {
+		return notFound("device profile", name)
+	}
+	c.deviceProfiles.delete(id)
+	return nil
+}
+
+// DeviceProfileNameExists returns whether a device profile has the given name
+func (c *Client) DeviceProfileNameExists(name string) (bool, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.deviceProfiles.hasName(name), nil
+}
+
+// AllDeviceProfiles returns the device profiles carrying every label by offset and limit, the latest modified first
+func (c *Client) AllDeviceProfiles(offset int, limit int, labels []string) ([]model.DeviceProfile, errors.EdgeX) {
+	return c.deviceProfilesWhere(offset, limit, func(dp model.DeviceProfile) bool {
+		return hasLabels(dp.Labels, labels)
+	})
+}
+
+// DeviceProfilesBySearch returns the device profiles matching every word of the search query and carrying every
+// label by offset and limit, the latest modified first. A query word matches the beginning of a word of the name,
+// description, labels, or of the names of the resources and commands.
+func (c *Client) DeviceProfilesBySearch(offset int, limit int, query string, labels []string) ([]model.DeviceProfile, errors.EdgeX) {
+	words, edgeXerr := queryWords(query)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	return c.deviceProfilesWhere(offset, limit, func(dp model.DeviceProfile) bool {
+		texts := append([]string{dp.Name, dp.Description}, dp.Labels...)
+		for _, r := range dp.DeviceResources {
+			texts = append(texts, r.Name)
+		}
+		for _, command := range dp.DeviceCommands {
+			texts = append(texts, command.Name)
+		}
+		return hasLabels(dp.Labels, labels) && matchesWords(words, texts...)
+	})
+}
+
+// DeviceProfilesByModel returns the device profiles of the given model by offset and limit
+func (c *Client) DeviceProfilesByModel(offset int, limit int, profileModel string) ([]model.DeviceProfile, errors.EdgeX) {
+	return c.deviceProfilesWhere(offset, limit, func(dp model.DeviceProfile) bool { return dp.Model == profileModel })
+}
+
+// DeviceProfilesByManufacturer returns the device profiles of the given manufacturer by offset and limit
+func (c *Client) DeviceProfilesByManufacturer(offset int, limit int, manufacturer string) ([]model.DeviceProfile, errors.EdgeX) {
+	return c.deviceProfilesWhere(offset, limit, func(dp model.DeviceProfile) bool { return dp.Manufacturer == manufacturer })
+}
+
+// DeviceProfilesByManufacturerAndModel returns the device profiles of the given manufacturer and model by offset and
+// limit
+func (c *Client) DeviceProfilesByManufacturerAndModel(offset int, limit int, manufacturer string, profileModel string) ([]model.DeviceProfile, errors.EdgeX) {
+	return c.deviceProfilesWhere(offset, limit, func(dp model.DeviceProfile) bool {
+		return dp.Manufacturer == manufacturer && dp.Model == profileModel
+	})
+}
+
+func (c *Client) deviceProfilesWhere(offset int, limit int, match func(model.DeviceProfile) bool) ([]model.DeviceProfile, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.deviceProfiles.sorted(byModified, false, func(o interface{}) bool {
+		return match(o.(model.DeviceProfile))
+	}), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	profiles := make([]model.DeviceProfile, len(objects))
+	for i, o := range objects {
+		profiles[i] = o.(model.DeviceProfile)
+	}
+	return profiles, nil
+}
+
+/* ----------------------------- Device Service ---------------------------------- */
+
+// AddDeviceService adds the device service, whose id and name must be unique
+func (c *Client) AddDeviceService(ds model.DeviceService) (model.DeviceService, errors.EdgeX) {
+	if ds.Id == "" {
+		ds.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.deviceServices.hasId(ds.Id) {
+		return model.DeviceService{}, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device service id %s already exists", ds.Id), nil)
+	}
+	if c.deviceServices.hasName(ds.Name) {
+		return model.DeviceService{}, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device service name %s already exists", ds.Name), nil)
+	}
+	if ds.Created == 0 {
+		ds.Created = common.MakeTimestamp()
+	}
+	ds.Modified = ds.Created
+	c.deviceServices.put(ds.Id, ds.Name, ds)
+	return ds, nil
+}
+
+// DeviceServiceById returns the device service of the given id
+func (c *Client) DeviceServiceById(id string) (model.DeviceService, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.deviceServices.get(id)
+	if !ok {
+		return model.DeviceService{}, notFound("device service", id)
+	}
+	return o.(model.DeviceService), nil
+}
+
+// DeviceServiceByName returns the device service of the given name
+func (c *Client) DeviceServiceByName(name string) (model.DeviceService, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.deviceServices.getByName(name)
+	if !ok {
+		return model.DeviceService{}, notFound("device service", name)
+	}
+	return o.(model.DeviceService), nil
+}
+
+// DeleteDeviceServiceById deletes the device service of the given id
+func (c *Client) DeleteDeviceServiceById(id string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.deviceServices.delete(id) {
+		return notFound("device service", id)
+	}
+	return nil
+}
+
+// DeleteDeviceServiceByName deletes the device service of the given name
+func (c *Client) DeleteDeviceServiceByName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.deviceServices.names[name]
+	if !ok {
+		return notFound("device service", name)
+	}
+	c.deviceServices.delete(id)
+	return nil
+}
+
+// DeviceServiceNameExists returns whether a device service has the given name
+func (c *Client) DeviceServiceNameExists(name string) (bool, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.deviceServices.hasName(name), nil
+}
+
+// AllDeviceServices returns the device services carrying every label by offset and limit, the latest modified first
+func (c *Client) AllDeviceServices(offset int, limit int, labels []string) ([]model.DeviceService, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.deviceServices.sorted(byModified, false, func(o interface{}) bool {
+		return hasLabels(o.(model.DeviceService).Labels, labels)
+	}), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	services := make([]model.DeviceService, len(objects))
+	for i, o := range objects {
+		services[i] = o.(model.DeviceService)
+	}
+	return services, nil
+}
+
+// UpdateDeviceService replaces the device service of the same name
+func (c *Client) UpdateDeviceService(ds model.DeviceService) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	o, ok := c.deviceServices.getByName(ds.Name)
+	if !ok {
+		return notFound("device service", ds.Name)
+	}
+	c.deviceServices.delete(o.(model.DeviceService).Id)
+	ds.Modified = common.MakeTimestamp()
+	c.deviceServices.put(ds.Id, ds.Name, ds)
+	return nil
+}
+
+/* ----------------------------- Device ---------------------------------- */
+
+// AddDevice adds the device, whose id and name must be unique
+func (c *Client) AddDevice(d model.Device) (model.Device, errors.EdgeX) {
+	if d.Id == "" {
+		d.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.devices.hasId(d.Id) {
+		return d, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device id %s already exists", d.Id), nil)
+	}
+	if c.devices.hasName(d.Name) {
+		return d, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device name %s already exists", d.Name), nil)
+	}
+	d.Timestamps = newTimestamps(d.Timestamps)
+	c.devices.put(d.Id, d.Name, d)
+	return d, nil
+}
+
+// DeleteDeviceById deletes the device of the given id
+func (c *Client) DeleteDeviceById(id string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.devices.delete(id) {
+		return notFound("device", id)
+	}
+	return nil
+}
+
+// DeleteDeviceByName deletes the device of the given name
+func (c *Client) DeleteDeviceByName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.devices.names[name]
+	if !ok {
+		return notFound("device", name)
+	}
+	c.devices.delete(id)
+	return nil
+}
+
+// DevicesByServiceName returns the devices of the device service of the given name by offset and limit
+func (c *Client) DevicesByServiceName(offset int, limit int, name string) ([]model.Device, errors.EdgeX) {
+	return c.devicesWhere(offset, limit, func(d model.Device) bool { return d.ServiceName == name })
+}
+
+// DeviceIdExists returns whether a device has the given id
+func (c *Client) DeviceIdExists(id string) (bool, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.devices.hasId(id), nil
+}
+
+// DeviceNameExists returns whether a device has the given name
+func (c *Client) DeviceNameExists(name string) (bool, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.devices.hasName(name), nil
+}
+
+// DeviceById returns the device of the given id
+func (c *Client) DeviceById(id string) (model.Device, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.devices.get(id)
+	if !ok {
+		return model.Device{}, notFound("device", id)
+	}
+	return o.(model.Device), nil
+}
+
+// DeviceByName returns the device of the given name
+func (c *Client) DeviceByName(name string) (model.Device, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.devices.getByName(name)
+	if !ok {
+		return model.Device{}, notFound("device", name)
+	}
+	return o.(model.Device), nil
+}
+
+// AllDevices returns the devices carrying every label by offset and limit, the latest modified first
+func (c *Client) AllDevices(offset int, limit int, labels []string) ([]model.Device, errors.EdgeX) {
+	return c.devicesWhere(offset, limit, func(d model.Device) bool { return hasLabels(d.Labels, labels) })
+}
+
+// DevicesBySearch returns the devices matching every word of the search query and carrying every label by offset
+// and limit, the latest modified first. A query word matches the beginning of a word of the name, description or
+// labels.
+func (c *Client) DevicesBySearch(offset int, limit int, query string, labels []string) ([]model.Device, errors.EdgeX) {
+	words, edgeXerr := queryWords(query)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	return c.devicesWhere(offset, limit, func(d model.Device) bool {
+		texts := append([]string{d.Name, d.Description}, d.Labels...)
+		return hasLabels(d.Labels, labels) && matchesWords(words, texts...)
+	})
+}
+
+// DevicesByProfileName returns the devices of the device profile of the given name by offset and limit
+func (c *Client) DevicesByProfileName(offset int, limit int, profileName string) ([]model.Device, errors.EdgeX) {
+	return c.devicesWhere(offset, limit, func(d model.Device) bool { return d.ProfileName == profileName })
+}
+
+// UpdateDevice replaces the device of the same name
+func (c *Client) UpdateDevice(d model.Device) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	o, ok := c.devices.getByName(d.Name)
+	if !ok {
+		return notFound("device", d.Name)
+	}
+	c.devices.delete(o.(model.Device).Id)
+	d.Modified = common.MakeTimestamp()
+	c.devices.put(d.Id, d.Name, d)
+	return nil
+}
+
+func (c *Client) devicesWhere(offset int, limit int, match func(model.Device) bool) ([]model.Device, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.devices.sorted(byModified, false, func(o interface{}) bool {
+		return match(o.(model.Device))
+	}), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	devices := make([]model.Device, len(objects))
+	for i, o := range objects {
+		devices[i] = o.(model.Device)
+	}
+	return devices, nil
+}
+
+/* ----------------------------- Provision Watcher ---------------------------------- */
+
+// AddProvisionWatcher adds the provision watcher, whose id and name must be unique
+func (c *Client) AddProvisionWatcher(pw model.ProvisionWatcher) (model.ProvisionWatcher, errors.EdgeX) {
+	if pw.Id == "" {
+		pw.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.provisionWatchers.hasId(pw.Id) {
+		return model.ProvisionWatcher{}, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("provision watcher id %s already exists", pw.Id), nil)
+	}
+	if c.provisionWatchers.hasName(pw.Name) {
+		return model.ProvisionWatcher{}, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("provision watcher name %s already exists", pw.Name), nil)
+	}
+	pw.Timestamps = newTimestamps(pw.Timestamps)
+	c.provisionWatchers.put(pw.Id, pw.Name, pw)
+	return pw, nil
+}
+
+// ProvisionWatcherById returns the provision watcher of the given id
+func (c *Client) ProvisionWatcherById(id string) (model.ProvisionWatcher, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.provisionWatchers.get(id)
+	if !ok {
+		return model.ProvisionWatcher{}, notFound("provision watcher", id)
+	}
+	return o.(model.ProvisionWatcher), nil
+}
+
+// ProvisionWatcherByName returns the provision watcher of the given name
+func (c *Client) ProvisionWatcherByName(name string) (model.ProvisionWatcher, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.provisionWatchers.getByName(name)
+	if !ok {
+		return model.ProvisionWatcher{}, notFound("provision watcher", name)
+	}
+	return o.(model.ProvisionWatcher), nil
+}
+
+// ProvisionWatchersByServiceName returns the provision watchers of the device service of the given name by offset
+// and limit
+func (c *Client) ProvisionWatchersByServiceName(offset int, limit int, name string) ([]model.ProvisionWatcher, errors.EdgeX) {
+	return c.provisionWatchersWhere(offset, limit, func(pw model.ProvisionWatcher) bool { return pw.ServiceName == name })
+}
+
+// ProvisionWatchersByProfileName returns the provision watchers of the device profile of the given name by offset
+// and limit
+func (c *Client) ProvisionWatchersByProfileName(offset int, limit int, name string) ([]model.ProvisionWatcher, errors.EdgeX) {
+	return c.provisionWatchersWhere(offset, limit, func(pw model.ProvisionWatcher) bool { return pw.ProfileName == name })
+}
+
+// AllProvisionWatchers returns the provision watchers carrying every label by offset and limit, the latest modified
+// first
+func (c *Client) AllProvisionWatchers(offset int, limit int, labels []string) ([]model.ProvisionWatcher, errors.EdgeX) {
+	return c.provisionWatchersWhere(offset, limit, func(pw model.ProvisionWatcher) bool { return hasLabels(pw.Labels, labels) })
+}
+
+// DeleteProvisionWatcherByName deletes the provision watcher of the given name
+func (c *Client) DeleteProvisionWatcherByName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.provisionWatchers.names[name]
+	if !ok {
+		return notFound("provision watcher", name)
+	}
+	c.provisionWatchers.delete(id)
+	return nil
+}
+
+// UpdateProvisionWatcher replaces the provision watcher of the same name
+func (c *Client) UpdateProvisionWatcher(pw model.ProvisionWatcher) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	o, ok := c.provisionWatchers.getByName(pw.Name)
+	if !ok {
+		return notFound("provision watcher", pw.Name)
+	}
+	c.provisionWatchers.delete(o.(model.ProvisionWatcher).Id)
+	pw.Modified = common.MakeTimestamp()
+	c.provisionWatchers.put(pw.Id, pw.Name, pw)
+	return nil
+}
+
+func (c *Client) provisionWatchersWhere(offset int, limit int, match func(model.ProvisionWatcher) bool) ([]model.ProvisionWatcher, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.provisionWatchers.sorted(byModified, false, func(o interface{}) bool {
+		return match(o.(model.ProvisionWatcher))
+	}), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	watchers := make([]model.ProvisionWatcher, len(objects))
+	for i, o := range objects {
+		watchers[i] = o.(model.ProvisionWatcher)
+	}
+	return watchers, nil
+}
+
+/* ----------------------------- Deprecation ---------------------------------- */
+
+// AddDeprecation adds the deprecation, a device resource being deprecated once at most
+func (c *Client) AddDeprecation(d deprecations.Deprecation) (deprecations.Deprecation, errors.EdgeX) {
+	if d.Id == "" {
+		d.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.deprecations.hasId(d.Id) {
+		return d, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("deprecation id %s already exists", d.Id), nil)
+	}
+	name := deprecationName(d.ProfileName, d.ResourceName)
+	if c.deprecations.hasName(name) {
+		return d, errors.NewCommonEdgeX(errors.KindDuplicateName,
+			fmt.Sprintf("%s of device profile %s is already deprecated", d.ResourceName, d.ProfileName), nil)
+	}
+	now := common.MakeTimestamp()
+	if d.Created == 0 {
+		d.Created = now
+	}
+	d.Modified = now
+	c.deprecations.put(d.Id, name, d)
+	return d, nil
+}
+
+// AllDeprecations returns the deprecations by offset and limit, the latest created first
+func (c *Client) AllDeprecations(offset int, limit int) ([]deprecations.Deprecation, errors.EdgeX) {
+	return c.deprecationsWhere(offset, limit, func(deprecations.Deprecation) bool { return true })
+}
+
+// DeprecationsByProfileName returns all the deprecations of the device profile of the given name, the latest created
+// first
+func (c *Client) DeprecationsByProfileName(profileName string) ([]deprecations.Deprecation, errors.EdgeX) {
+	return c.deprecationsWhere(0, -1, func(d deprecations.Deprecation) bool { return d.ProfileName == profileName })
+}
+
+// DeleteDeprecationByProfileNameAndResourceName deletes the deprecation of the resource of the device profile
+func (c *Client) DeleteDeprecationByProfileNameAndResourceName(profileName string, resourceName string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.deprecations.names[deprecationName(profileName, resourceName)]
+	if !ok {
+		return notFound("deprecation", fmt.Sprintf("of %s of device profile %s", resourceName, profileName))
+	}
+	c.deprecations.delete(id)
+	return nil
+}
+
+func (c *Client) deprecationsWhere(offset int, limit int, match func(deprecations.Deprecation) bool) ([]deprecations.Deprecation, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.deprecations.sorted(func(o interface{}) int64 {
+		return o.(deprecations.Deprecation).Created
+	}, false, func(o interface{}) bool {
+		return match(o.(deprecations.Deprecation))
+	}), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	result := make([]deprecations.Deprecation, len(objects))
+	for i, o := range objects {
+		result[i] = o.(deprecations.Deprecation)
+	}
+	return result, nil
+}
+
+// deprecationName is the unique name a deprecation is indexed by, that of its device profile and resource
+func deprecationName(profileName string, resourceName string) string {
+	return profileName + "/" + resourceName
+}
+
+/* ----------------------------- Discovery Record ---------------------------------- */
+
+// AddDiscoveryRecord adds the record of a discovered device
+func (c *Client) AddDiscoveryRecord(r discovery.Record) (discovery.Record, errors.EdgeX) {
+	if r.Id == "" {
+		r.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.discoveryRecords.hasId(r.Id) {
+		return r, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("discovery record id %s already exists", r.Id), nil)
+	}
+	if r.Created == 0 {
+		r.Created = common.MakeTimestamp()
+	}
+	c.discoveryRecords.put(r.Id, "", r)
+	return r, nil
+}
+
+// AllDiscoveryRecords returns the discovery records by offset and limit, the latest created first
+func (c *Client) AllDiscoveryRecords(offset int, limit int) ([]discovery.Record, errors.EdgeX) {
+	return c.discoveryRecordsWhere(offset, limit, func(discovery.Record) bool { return true })
+}
+
+// DiscoveryRecordsByProvisionWatcherName returns the records of the devices discovered by the provision watcher of
+// the given name by offset and limit, the latest created first
+func (c *Client) DiscoveryRecordsByProvisionWatcherName(offset int, limit int, name string) ([]discovery.Record, errors.EdgeX) {
+	return c.discoveryRecordsWhere(offset, limit, func(r discovery.Record) bool { return r.ProvisionWatcherName == name })
+}
+
+// DiscoveryRecordCountByProvisionWatcherName returns the number of devices discovered by the provision watcher of the
+// given name since the given timestamp
+func (c *Client) DiscoveryRecordCountByProvisionWatcherName(name string, since int64) (uint32, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var count uint32
+	for _, r := range c.discoveryRecords.rows {
+		if record := r.object.(discovery.Record); record.ProvisionWatcherName == name && record.Created >= since {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (c *Client) discoveryRecordsWhere(offset int, limit int, match func(discovery.Record) bool) ([]discovery.Record, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.discoveryRecords.sorted(func(o interface{}) int64 {
+		return o.(discovery.Record).Created
+	}, false, func(o interface{}) bool {
+		return match(o.(discovery.Record))
+	}), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	records := make([]discovery.Record, len(objects))
+	for i, o := range objects {
+		records[i] = o.(discovery.Record)
+	}
+	return records, nil
+}
+
+/* ----------------------------- Helpers ---------------------------------- */
+
+// newTimestamps returns the timestamps of an added object, which is modified now and created now unless it already was
+func newTimestamps(ts model.Timestamps) model.Timestamps {
+	now := common.MakeTimestamp()
+	if ts.Created == 0 {
+		ts.Created = now
+	}
+	ts.Modified = now
+	return ts
+}
+
+// byModified scores the metadata objects by their modification timestamp
+func byModified(o interface{}) int64 {
+	switch object := o.(type) {
+	case model.DeviceProfile:
+		return object.Modified
+	case model.DeviceService:
+		return object.Modified
+	case model.Device:
+		return object.Modified
+	case model.ProvisionWatcher:
+		return object.Modified
+	}
+	return 0
+}
+
+// queryWords splits the search query into lowercase words of letters and digits as the Redis search index does,
+// dropping the single characters and truncating the words past 32 characters
+func queryWords(query string) ([]string, errors.EdgeX) {
+	words := searchWords(query)
+	if len(words) == 0 {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "search query must contain at least one word of two or more letters or digits", nil)
+	}
+	return words, nil
+}
+
+func searchWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		runes := []rune(field)
+		if len(runes) < 2 {
+			continue
+		}
+		if len(runes) > 32 {
+			runes = runes[:32]
+		}
+		words = append(words, string(runes))
+	}
+	return words
+}
+
+// matchesWords returns whether each of words is the beginning of a word of the texts
+func matchesWords(words []string, texts ...string) bool {
+	var textWords []string
+	for _, text := range texts {
+		textWords = append(textWords, searchWords(text)...)
+	}
+	for _, word := range words {
+		found := false
+		for _, textWord := range textWords {
+			if strings.HasPrefix(textWord, word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+/* ----------------------------- Admin State Schedule ---------------------------------- */
+
+// AddAdminSchedule adds the admin state schedule, whose name must be unique
+func (c *Client) AddAdminSchedule(s adminschedules.AdminSchedule) (adminschedules.AdminSchedule, errors.EdgeX) {
+	if s.Id == "" {
+		s.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.adminSchedules.hasId(s.Id) {
+		return s, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("admin state schedule id %s already exists", s.Id), nil)
+	}
+	if c.adminSchedules.hasName(s.Name) {
+		return s, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("admin state schedule name %s already exists", s.Name), nil)
+	}
+	now := common.MakeTimestamp()
+	if s.Created == 0 {
+		s.Created = now
+	}
+	s.Modified = now
+	c.adminSchedules.put(s.Id, s.Name, s)
+	return s, nil
+}
+
+// AllAdminSchedules returns the admin state schedules by offset and limit, the latest created first
+func (c *Client) AllAdminSchedules(offset int, limit int) ([]adminschedules.AdminSchedule, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.adminSchedules.sorted(func(o interface{}) int64 {
+		return o.(adminschedules.AdminSchedule).Created
+	}, false, func(interface{}) bool { return true }), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	result := make([]adminschedules.AdminSchedule, len(objects))
+	for i, o := range objects {
+		result[i] = o.(adminschedules.AdminSchedule)
+	}
+	return result, nil
+}
+
+// AdminScheduleByName returns the admin state schedule of the given name
+func (c *Client) AdminScheduleByName(name string) (adminschedules.AdminSchedule, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.adminSchedules.getByName(name)
+	if !ok {
+		return adminschedules.AdminSchedule{}, notFound("admin state schedule", name)
+	}
+	return o.(adminschedules.AdminSchedule), nil
+}
+
+// DeleteAdminScheduleByName deletes the admin state schedule of the given name
+func (c *Client) DeleteAdminScheduleByName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.adminSchedules.names[name]
+	if !ok {
+		return notFound("admin state schedule", name)
+	}
+	c.adminSchedules.delete(id)
+	return nil
+}
+
+/* ----------------------------- Claim Code ---------------------------------- */
+
+// AddClaimCode adds the claim code of a device, replacing its previous one
+func (c *Client) AddClaimCode(code claimcodes.ClaimCode) errors.EdgeX {
+	if code.Expired(common.MakeTimestamp()) {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("claim code of device %s already expired", code.DeviceName), nil)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for hash, existing := range c.claimCodes {
+		if existing.DeviceName == code.DeviceName {
+			delete(c.claimCodes, hash)
+		}
+	}
+	c.claimCodes[code.Hash] = code
+	return nil
+}
+
+// TakeClaimCode deletes and returns the claim code of the hash, which can't be taken again
+func (c *Client) TakeClaimCode(hash string) (claimcodes.ClaimCode, errors.EdgeX) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	code, ok := c.claimCodes[hash]
+	// the expired codes are kept until taken or replaced, Redis expiring them
+	if !ok || code.Expired(common.MakeTimestamp()) {
+		delete(c.claimCodes, hash)
+		return claimcodes.ClaimCode{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "claim code doesn't exist in the database", nil)
+	}
+	delete(c.claimCodes, hash)
+	return code, nil
+}
+
+// DeleteClaimCodeByDeviceName revokes the claim code of a device
+func (c *Client) DeleteClaimCodeByDeviceName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := common.MakeTimestamp()
+	for hash, code := range c.claimCodes {
+		if code.DeviceName == name {
+			delete(c.claimCodes, hash)
+			if !code.Expired(now) {
+				return nil
+			}
+		}
+	}
+	return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("device %s has no claim code", name), nil)
+}
+
+/* ------------------------------- Callback ---------------------------------- */
+
+// AddCallbackMessage adds a callback to the outbox
+func (c *Client) AddCallbackMessage(m callbacks.Message) (callbacks.Message, errors.EdgeX) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.callbacks.hasId(m.Id) {
+		return m, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("callback id %s already exists", m.Id), nil)
+	}
+	c.callbacks.put(m.Id, "", m)
+	return m, nil
+}
+
+// AllCallbackMessages returns the callbacks of the outbox by offset and limit, the oldest first
+func (c *Client) AllCallbackMessages(offset int, limit int) ([]callbacks.Message, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, err := page(c.callbacks.sorted(func(o interface{}) int64 { return o.(callbacks.Message).Created }, true, nil), offset, limit)
+	if err != nil {
+		return []callbacks.Message{}, err
+	}
+	messages := make([]callbacks.Message, len(objects))
+	for i, o := range objects {
+		messages[i] = o.(callbacks.Message)
+	}
+	return messages, nil
+}
+
+// UpdateCallbackMessage replaces the attempts of a callback of the outbox
+func (c *Client) UpdateCallbackMessage(m callbacks.Message) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.callbacks.hasId(m.Id) {
+		return notFound("callback", m.Id)
+	}
+	c.callbacks.put(m.Id, "", m)
+	return nil
+}
+
+// DeleteCallbackMessageById removes a callback from the outbox
+func (c *Client) DeleteCallbackMessageById(id string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.callbacks.delete(id) {
+		return notFound("callback", id)
+	}
+	return nil
+}
@@ -0,0 +1,398 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/google/uuid"
+)
+
+/* ----------------------------- Subscription ---------------------------------- */
+
+// AddSubscription adds the subscription, whose id and name must be unique
+func (c *Client) AddSubscription(s models.Subscription) (models.Subscription, errors.EdgeX) {
+	if s.Id == "" {
+		s.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.subscriptions.hasId(s.Id) {
+		return s, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("subscription id %s already exists", s.Id), nil)
+	}
+	if c.subscriptions.hasName(s.Name) {
+		return s, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("subscription name %s already exists", s.Name), nil)
+	}
+	now := common.MakeTimestamp()
+	if s.Created == 0 {
+		s.Created = now
+	}
+	s.Modified = now
+	c.subscriptions.put(s.Id, s.Name, s)
+	return s, nil
+}
+
+// SubscriptionById returns the subscription of the given id
+func (c *Client) SubscriptionById(id string) (models.Subscription, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.subscriptions.get(id)
+	if !ok {
+		return models.Subscription{}, notFound("subscription", id)
+	}
+	return o.(models.Subscription), nil
+}
+
+// AllSubscriptions returns the subscriptions by offset and limit, the latest modified first
+func (c *Client) AllSubscriptions(offset int, limit int) ([]models.Subscription, errors.EdgeX) {
+	return c.subscriptionsWhere(offset, limit, func(models.Subscription) bool { return true })
+}
+
+// SubscriptionByName returns the subscription of the given name
+func (c *Client) SubscriptionByName(name string) (models.Subscription, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.subscriptions.getByName(name)
+	if !ok {
+		return models.Subscription{}, notFound("subscription", name)
+	}
+	return o.(models.Subscription), nil
+}
+
+// SubscriptionsByCategory returns the subscriptions to the given category by offset and limit
+func (c *Client) SubscriptionsByCategory(offset, limit int, category string) ([]models.Subscription, errors.EdgeX) {
+	return c.subscriptionsWhere(offset, limit, func(s models.Subscription) bool {
+		for _, sc := range s.Categories {
+			if string(sc) == category {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// SubscriptionsByLabel returns the subscriptions to the given label by offset and limit
+func (c *Client) SubscriptionsByLabel(offset, limit int, label string) ([]models.Subscription, errors.EdgeX) {
+	return c.subscriptionsWhere(offset, limit, func(s models.Subscription) bool { return contains(s.Labels, label) })
+}
+
+// SubscriptionsByReceiver returns the subscriptions of the given receiver by offset and limit
+func (c *Client) SubscriptionsByReceiver(offset, limit int, receiver string) ([]models.Subscription, errors.EdgeX) {
+	return c.subscriptionsWhere(offset, limit, func(s models.Subscription) bool { return s.Receiver == receiver })
+}
+
+// DeleteSubscriptionByName deletes the subscription of the given name
+func (c *Client) DeleteSubscriptionByName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.subscriptions.names[name]
+	if !ok {
+		return notFound("subscription", name)
+	}
+	c.subscriptions.delete(id)
+	return nil
+}
+
+func (c *Client) subscriptionsWhere(offset int, limit int, match func(models.Subscription) bool) ([]models.Subscription, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.subscriptions.sorted(func(o interface{}) int64 {
+		return o.(models.Subscription).Modified
+	}, false, func(o interface{}) bool {
+		return match(o.(models.Subscription))
+	}), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	subscriptions := make([]models.Subscription, len(objects))
+	for i, o := range objects {
+		subscriptions[i] = o.(models.Subscription)
+	}
+	return subscriptions, nil
+}
+
+/* ----------------------------- Template ---------------------------------- */
+
+// AddTemplate adds the notification template, whose id and name must be unique
+func (c *Client) AddTemplate(t templates.Template) (templates.Template, errors.EdgeX) {
+	if t.Id == "" {
+		t.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.templates.hasId(t.Id) {
+		return t, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("template id %s already exists", t.Id), nil)
+	}
+	if c.templates.hasName(t.Name) {
+		return t, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("template name %s already exists", t.Name), nil)
+	}
+	now := common.MakeTimestamp()
+	if t.Created == 0 {
+		t.Created = now
+	}
+	t.Modified = now
+	c.templates.put(t.Id, t.Name, t)
+	return t, nil
+}
+
+// AllTemplates returns the notification templates by offset and limit, the latest created first
+func (c *Client) AllTemplates(offset int, limit int) ([]templates.Template, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.templates.sorted(func(o interface{}) int64 {
+		return o.(templates.Template).Created
+	}, false, nil), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	result := make([]templates.Template, len(objects))
+	for i, o := range objects {
+		result[i] = o.(templates.Template)
+	}
+	return result, nil
+}
+
+// TemplateById returns the notification template of the given id
+func (c *Client) TemplateById(id string) (templates.Template, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.templates.get(id)
+	if !ok {
+		return templates.Template{}, notFound("template", id)
+	}
+	return o.(templates.Template), nil
+}
+
+// TemplateByName returns the notification template of the given name
+func (c *Client) TemplateByName(name string) (templates.Template, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.templates.getByName(name)
+	if !ok {
+		return templates.Template{}, notFound("template", name)
+	}
+	return o.(templates.Template), nil
+}
+
+// UpdateTemplate replaces the notification template of the same id, keeping its name
+func (c *Client) UpdateTemplate(t templates.Template) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	o, ok := c.templates.get(t.Id)
+	if !ok {
+		return notFound("template", t.Id)
+	}
+	stored := o.(templates.Template)
+	if stored.Name != t.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("template name '%s' not match the existing '%s'", t.Name, stored.Name), nil)
+	}
+	t.Created = stored.Created
+	t.Modified = common.MakeTimestamp()
+	c.templates.put(t.Id, t.Name, t)
+	return nil
+}
+
+// DeleteTemplateByName deletes the notification template of the given name
+func (c *Client) DeleteTemplateByName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.templates.names[name]
+	if !ok {
+		return notFound("template", name)
+	}
+	c.templates.delete(id)
+	return nil
+}
+
+// SubscriptionLocale returns the locale of the subscription of the given name, empty when it has none
+func (c *Client) SubscriptionLocale(name string) (string, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.locales[name], nil
+}
+
+// SetSubscriptionLocale sets the locale of the subscription of the given name, replacing the existing one
+func (c *Client) SetSubscriptionLocale(name string, locale string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.locales[name] = locale
+	return nil
+}
+
+// DeleteSubscriptionLocale deletes the locale of the subscription of the given name
+func (c *Client) DeleteSubscriptionLocale(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.locales, name)
+	return nil
+}
+
+/* ----------------------------- Mute Window ---------------------------------- */
+
+// AddMuteWindow adds the notification mute window, whose id and name must be unique
+func (c *Client) AddMuteWindow(w mutes.MuteWindow) (mutes.MuteWindow, errors.EdgeX) {
+	if w.Id == "" {
+		w.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.muteWindows.hasId(w.Id) {
+		return w, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("mute window id %s already exists", w.Id), nil)
+	}
+	if c.muteWindows.hasName(w.Name) {
+		return w, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("mute window name %s already exists", w.Name), nil)
+	}
+	now := common.MakeTimestamp()
+	if w.Created == 0 {
+		w.Created = now
+	}
+	w.Modified = now
+	c.muteWindows.put(w.Id, w.Name, w)
+	return w, nil
+}
+
+// AllMuteWindows returns the notification mute windows by offset and limit, the latest created first
+func (c *Client) AllMuteWindows(offset int, limit int) ([]mutes.MuteWindow, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.muteWindows.sorted(func(o interface{}) int64 {
+		return o.(mutes.MuteWindow).Created
+	}, false, nil), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	windows := make([]mutes.MuteWindow, len(objects))
+	for i, o := range objects {
+		windows[i] = o.(mutes.MuteWindow)
+	}
+	return windows, nil
+}
+
+// MuteWindowById returns the notification mute window of the given id
+func (c *Client) MuteWindowById(id string) (mutes.MuteWindow, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.muteWindows.get(id)
+	if !ok {
+		return mutes.MuteWindow{}, notFound("mute window", id)
+	}
+	return o.(mutes.MuteWindow), nil
+}
+
+// MuteWindowByName returns the notification mute window of the given name
+func (c *Client) MuteWindowByName(name string) (mutes.MuteWindow, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.muteWindows.getByName(name)
+	if !ok {
+		return mutes.MuteWindow{}, notFound("mute window", name)
+	}
+	return o.(mutes.MuteWindow), nil
+}
+
+// UpdateMuteWindow replaces the notification mute window of the same id, keeping its name
+func (c *Client) UpdateMuteWindow(w mutes.MuteWindow) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	o, ok := c.muteWindows.get(w.Id)
+	if !ok {
+		return notFound("mute window", w.Id)
+	}
+	stored := o.(mutes.MuteWindow)
+	if stored.Name != w.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("mute window name '%s' not match the existing '%s'", w.Name, stored.Name), nil)
+	}
+	w.Created = stored.Created
+	w.Modified = common.MakeTimestamp()
+	c.muteWindows.put(w.Id, w.Name, w)
+	return nil
+}
+
+// DeleteMuteWindowByName deletes the notification mute window of the given name
+func (c *Client) DeleteMuteWindowByName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.muteWindows.names[name]
+	if !ok {
+		return notFound("mute window", name)
+	}
+	c.muteWindows.delete(id)
+	return nil
+}
+
+// HoldNotification adds the id of a notification queued by a mute window
+func (c *Client) HoldNotification(id string, created int64) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.heldNotifications[id] = created
+	return nil
+}
+
+// HeldNotifications returns the ids of the queued notifications by offset and limit, the first created first
+func (c *Client) HeldNotifications(offset int, limit int) ([]string, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	ids := make([]string, 0, len(c.heldNotifications))
+	for id := range c.heldNotifications {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if c.heldNotifications[ids[i]] != c.heldNotifications[ids[j]] {
+			return c.heldNotifications[ids[i]] < c.heldNotifications[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if offset > len(ids) {
+		return []string{}, nil
+	}
+	ids = ids[offset:]
+	if limit >= 0 && limit < len(ids) {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+// ReleaseNotification removes the id of a queued notification
+func (c *Client) ReleaseNotification(id string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.heldNotifications, id)
+	return nil
+}
@@ -0,0 +1,264 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	model "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/google/uuid"
+)
+
+/* ----------------------------- Interval ---------------------------------- */
+
+// AddInterval adds the interval, whose id and name must be unique
+func (c *Client) AddInterval(interval model.Interval) (model.Interval, errors.EdgeX) {
+	if interval.Id == "" {
+		interval.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.intervals.hasId(interval.Id) {
+		return interval, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval id %s already exists", interval.Id), nil)
+	}
+	if c.intervals.hasName(interval.Name) {
+		return interval, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval name %s already exists", interval.Name), nil)
+	}
+	interval.Timestamps = newTimestamps(interval.Timestamps)
+	c.intervals.put(interval.Id, interval.Name, interval)
+	return interval, nil
+}
+
+/* ----------------------------- One-Shot Job ---------------------------------- */
+
+// AddOneShot adds the one-shot job, whose id and name must be unique
+func (c *Client) AddOneShot(o oneshots.OneShot) (oneshots.OneShot, errors.EdgeX) {
+	if o.Id == "" {
+		o.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.oneShots.hasId(o.Id) {
+		return o, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("one-shot job id %s already exists", o.Id), nil)
+	}
+	if c.oneShots.hasName(o.Name) {
+		return o, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("one-shot job name %s already exists", o.Name), nil)
+	}
+	now := common.MakeTimestamp()
+	if o.Created == 0 {
+		o.Created = now
+	}
+	o.Modified = now
+	c.oneShots.put(o.Id, o.Name, o)
+	return o, nil
+}
+
+// AllOneShots returns the pending one-shot jobs by offset and limit, the first to run first
+func (c *Client) AllOneShots(offset int, limit int) ([]oneshots.OneShot, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return oneShotsOf(page(c.oneShots.sorted(byRunAt, true, nil), offset, limit))
+}
+
+// DueOneShots returns the one-shot jobs to run at or before the timestamp at, the first to run first
+func (c *Client) DueOneShots(at int64) ([]oneshots.OneShot, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return oneShotsOf(c.oneShots.sorted(byRunAt, true, func(o interface{}) bool {
+		return o.(oneshots.OneShot).RunAt <= at
+	}), nil)
+}
+
+// OneShotByName returns the one-shot job of the given name
+func (c *Client) OneShotByName(name string) (oneshots.OneShot, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.oneShots.getByName(name)
+	if !ok {
+		return oneshots.OneShot{}, notFound("one-shot job", name)
+	}
+	return o.(oneshots.OneShot), nil
+}
+
+// DeleteOneShotByName deletes the one-shot job of the given name
+func (c *Client) DeleteOneShotByName(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.oneShots.names[name]
+	if !ok {
+		return notFound("one-shot job", name)
+	}
+	c.oneShots.delete(id)
+	return nil
+}
+
+func byRunAt(o interface{}) int64 {
+	return o.(oneshots.OneShot).RunAt
+}
+
+func oneShotsOf(objects []interface{}, edgeXerr errors.EdgeX) ([]oneshots.OneShot, errors.EdgeX) {
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	jobs := make([]oneshots.OneShot, len(objects))
+	for i, o := range objects {
+		jobs[i] = o.(oneshots.OneShot)
+	}
+	return jobs, nil
+}
+
+/* ----------------------------- Device Scope ---------------------------------- */
+
+// AddDeviceScope adds the device scope, an interval action having one device scope at most
+func (c *Client) AddDeviceScope(s devicescopes.DeviceScope) (devicescopes.DeviceScope, errors.EdgeX) {
+	if s.Id == "" {
+		s.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.deviceScopes.hasId(s.Id) {
+		return s, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device scope id %s already exists", s.Id), nil)
+	}
+	if c.deviceScopes.hasName(s.IntervalAction) {
+		return s, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval action %s already has a device scope", s.IntervalAction), nil)
+	}
+	now := common.MakeTimestamp()
+	if s.Created == 0 {
+		s.Created = now
+	}
+	s.Modified = now
+	c.deviceScopes.put(s.Id, s.IntervalAction, s)
+	return s, nil
+}
+
+// AllDeviceScopes returns the device scopes by offset and limit, the latest created first
+func (c *Client) AllDeviceScopes(offset int, limit int) ([]devicescopes.DeviceScope, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.deviceScopes.sorted(func(o interface{}) int64 {
+		return o.(devicescopes.DeviceScope).Created
+	}, false, nil), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	scopes := make([]devicescopes.DeviceScope, len(objects))
+	for i, o := range objects {
+		scopes[i] = o.(devicescopes.DeviceScope)
+	}
+	return scopes, nil
+}
+
+// DeviceScopeByIntervalAction returns the device scope of the interval action of the given name
+func (c *Client) DeviceScopeByIntervalAction(name string) (devicescopes.DeviceScope, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.deviceScopes.getByName(name)
+	if !ok {
+		return devicescopes.DeviceScope{}, notFound("device scope of interval action", name)
+	}
+	return o.(devicescopes.DeviceScope), nil
+}
+
+// DeleteDeviceScopeByIntervalAction deletes the device scope of the interval action of the given name
+func (c *Client) DeleteDeviceScopeByIntervalAction(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.deviceScopes.names[name]
+	if !ok {
+		return notFound("device scope of interval action", name)
+	}
+	c.deviceScopes.delete(id)
+	return nil
+}
+
+/* ----------------------------- Exclusion Calendar ---------------------------------- */
+
+// AddCalendar adds the exclusion calendar, an interval having one exclusion calendar at most
+func (c *Client) AddCalendar(calendar calendars.Calendar) (calendars.Calendar, errors.EdgeX) {
+	if calendar.Id == "" {
+		calendar.Id = uuid.New().String()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.calendars.hasId(calendar.Id) {
+		return calendar, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("exclusion calendar id %s already exists", calendar.Id), nil)
+	}
+	if c.calendars.hasName(calendar.Interval) {
+		return calendar, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval %s already has an exclusion calendar", calendar.Interval), nil)
+	}
+	now := common.MakeTimestamp()
+	if calendar.Created == 0 {
+		calendar.Created = now
+	}
+	calendar.Modified = now
+	c.calendars.put(calendar.Id, calendar.Interval, calendar)
+	return calendar, nil
+}
+
+// AllCalendars returns the exclusion calendars by offset and limit, the latest created first
+func (c *Client) AllCalendars(offset int, limit int) ([]calendars.Calendar, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	objects, edgeXerr := page(c.calendars.sorted(func(o interface{}) int64 {
+		return o.(calendars.Calendar).Created
+	}, false, nil), offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	result := make([]calendars.Calendar, len(objects))
+	for i, o := range objects {
+		result[i] = o.(calendars.Calendar)
+	}
+	return result, nil
+}
+
+// CalendarByInterval returns the exclusion calendar of the interval of the given name
+func (c *Client) CalendarByInterval(name string) (calendars.Calendar, errors.EdgeX) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	o, ok := c.calendars.getByName(name)
+	if !ok {
+		return calendars.Calendar{}, notFound("exclusion calendar of interval", name)
+	}
+	return o.(calendars.Calendar), nil
+}
+
+// DeleteCalendarByInterval deletes the exclusion calendar of the interval of the given name
+func (c *Client) DeleteCalendarByInterval(name string) errors.EdgeX {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id, ok := c.calendars.names[name]
+	if !ok {
+		return notFound("exclusion calendar of interval", name)
+	}
+	c.calendars.delete(id)
+	return nil
+}
@@ -0,0 +1,119 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// AdminScheduleCollection is the sorted set of the admin state schedules, scored by their creation
+	AdminScheduleCollection     = "md|as"
+	AdminScheduleCollectionName = AdminScheduleCollection + DBKeySeparator + v2.Name
+)
+
+// adminScheduleStoredKey return the admin state schedule's stored key which combines the collection name and object id
+func adminScheduleStoredKey(id string) string {
+	return CreateKey(AdminScheduleCollection, id)
+}
+
+// addAdminSchedule adds a new admin state schedule into DB
+func addAdminSchedule(conn redis.Conn, s adminschedules.AdminSchedule) (adminschedules.AdminSchedule, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, adminScheduleStoredKey(s.Id))
+	if edgeXerr != nil {
+		return s, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return s, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("admin state schedule id %s already exists", s.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, AdminScheduleCollectionName, s.Name)
+	if edgeXerr != nil {
+		return s, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return s, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("admin state schedule name %s already exists", s.Name), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	if s.Created == 0 {
+		s.Created = ts
+	}
+	s.Modified = ts
+
+	m, err := json.Marshal(s)
+	if err != nil {
+		return s, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal admin state schedule for Redis persistence", err)
+	}
+
+	storedKey := adminScheduleStoredKey(s.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, AdminScheduleCollection, s.Created, storedKey)
+	_ = conn.Send(HSET, AdminScheduleCollectionName, s.Name, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "admin state schedule creation failed", err)
+	}
+
+	return s, edgeXerr
+}
+
+// allAdminSchedules queries admin state schedules by offset and limit, the latest created first
+func allAdminSchedules(conn redis.Conn, offset, limit int) (result []adminschedules.AdminSchedule, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, AdminScheduleCollection, offset, end)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	result = make([]adminschedules.AdminSchedule, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &result[i])
+		if err != nil {
+			return []adminschedules.AdminSchedule{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "admin state schedule format parsing failed from the database", err)
+		}
+	}
+	return result, nil
+}
+
+// adminScheduleByName queries the admin state schedule by name
+func adminScheduleByName(conn redis.Conn, name string) (s adminschedules.AdminSchedule, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, AdminScheduleCollectionName, name, &s)
+	if edgeXerr != nil {
+		return s, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// deleteAdminScheduleByName deletes the admin state schedule by name
+func deleteAdminScheduleByName(conn redis.Conn, name string) errors.EdgeX {
+	s, edgeXerr := adminScheduleByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	storedKey := adminScheduleStoredKey(s.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, AdminScheduleCollection, storedKey)
+	_ = conn.Send(HDEL, AdminScheduleCollectionName, s.Name)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "admin state schedule deletion failed", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// CalendarCollection is the sorted set of the exclusion calendars of the intervals, scored by their creation
+	CalendarCollection = "ss|cal"
+	// CalendarCollectionInterval maps the names of the intervals to their exclusion calendar
+	CalendarCollectionInterval = CalendarCollection + DBKeySeparator + "interval"
+)
+
+// calendarStoredKey return the exclusion calendar's stored key which combines the collection name and object id
+func calendarStoredKey(id string) string {
+	return CreateKey(CalendarCollection, id)
+}
+
+// addCalendar adds a new exclusion calendar into DB
+func addCalendar(conn redis.Conn, calendar calendars.Calendar) (calendars.Calendar, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, calendarStoredKey(calendar.Id))
+	if edgeXerr != nil {
+		return calendar, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return calendar, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("exclusion calendar id %s already exists", calendar.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, CalendarCollectionInterval, calendar.Interval)
+	if edgeXerr != nil {
+		return calendar, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return calendar, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval %s already has an exclusion calendar", calendar.Interval), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	if calendar.Created == 0 {
+		calendar.Created = ts
+	}
+	calendar.Modified = ts
+
+	m, err := json.Marshal(calendar)
+	if err != nil {
+		return calendar, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal exclusion calendar for Redis persistence", err)
+	}
+
+	storedKey := calendarStoredKey(calendar.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, CalendarCollection, calendar.Created, storedKey)
+	_ = conn.Send(HSET, CalendarCollectionInterval, calendar.Interval, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "exclusion calendar creation failed", err)
+	}
+
+	return calendar, edgeXerr
+}
+
+// allCalendars queries exclusion calendars by offset and limit, the latest created first
+func allCalendars(conn redis.Conn, offset, limit int) (result []calendars.Calendar, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, CalendarCollection, offset, end)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	result = make([]calendars.Calendar, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &result[i])
+		if err != nil {
+			return []calendars.Calendar{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "exclusion calendar format parsing failed from the database", err)
+		}
+	}
+	return result, nil
+}
+
+// calendarByInterval queries the exclusion calendar of the named interval
+func calendarByInterval(conn redis.Conn, name string) (calendar calendars.Calendar, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, CalendarCollectionInterval, name, &calendar)
+	if edgeXerr != nil {
+		return calendar, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// deleteCalendarByInterval deletes the exclusion calendar of the named interval
+func deleteCalendarByInterval(conn redis.Conn, name string) errors.EdgeX {
+	calendar, edgeXerr := calendarByInterval(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	storedKey := calendarStoredKey(calendar.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, CalendarCollection, storedKey)
+	_ = conn.Send(HDEL, CalendarCollectionInterval, calendar.Interval)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "exclusion calendar deletion failed", err)
+	}
+	return nil
+}
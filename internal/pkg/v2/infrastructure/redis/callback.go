@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// CallbackCollection is the sorted set of the callbacks pending in the outbox, scored by their creation
+const CallbackCollection = "md|cb"
+
+// callbackStoredKey return the callback's stored key which combines the collection name and object id
+func callbackStoredKey(id string) string {
+	return CreateKey(CallbackCollection, id)
+}
+
+// addCallbackMessage adds a new callback into the outbox
+func addCallbackMessage(conn redis.Conn, m callbacks.Message) (callbacks.Message, errors.EdgeX) {
+	storedKey := callbackStoredKey(m.Id)
+	exists, edgeXerr := objectIdExists(conn, storedKey)
+	if edgeXerr != nil {
+		return m, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return m, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("callback id %s already exists", m.Id), edgeXerr)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return m, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal callback for Redis persistence", err)
+	}
+
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, data)
+	_ = conn.Send(ZADD, CallbackCollection, m.Created, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "callback creation failed", err)
+	}
+	return m, edgeXerr
+}
+
+// allCallbackMessages queries the callbacks by offset and limit, the oldest first
+func allCallbackMessages(conn redis.Conn, offset, limit int) (result []callbacks.Message, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRange(conn, CallbackCollection, offset, end)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	result = make([]callbacks.Message, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &result[i])
+		if err != nil {
+			return []callbacks.Message{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "callback format parsing failed from the database", err)
+		}
+	}
+	return result, nil
+}
+
+// updateCallbackMessage replaces the attempts of a callback still in the outbox, its place in the outbox unchanged
+func updateCallbackMessage(conn redis.Conn, m callbacks.Message) errors.EdgeX {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal callback for Redis persistence", err)
+	}
+
+	// XX only replaces the callback if it is still there, i.e. not acknowledged meanwhile
+	reply, err := conn.Do(SET, callbackStoredKey(m.Id), data, XX)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "callback update failed", err)
+	} else if reply == nil {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("callback %s doesn't exist in the database", m.Id), nil)
+	}
+	return nil
+}
+
+// deleteCallbackMessageById removes the callback from the outbox
+func deleteCallbackMessageById(conn redis.Conn, id string) errors.EdgeX {
+	storedKey := callbackStoredKey(id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, CallbackCollection, storedKey)
+	deleted, err := redis.Ints(conn.Do(EXEC))
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "callback deletion failed", err)
+	} else if deleted[0] == 0 {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("callback %s doesn't exist in the database", id), nil)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// ClaimCodeCollection prefixes the keys of the claim codes, which Redis expires along with the codes
+	ClaimCodeCollection = "md|cc"
+	// ClaimCodeCollectionDevice is the hash of the key of the claim code of each device
+	ClaimCodeCollectionDevice = ClaimCodeCollection + DBKeySeparator + "device"
+)
+
+// claimCodeStoredKey return the claim code's stored key which combines the collection name and the hash of the code
+func claimCodeStoredKey(hash string) string {
+	return CreateKey(ClaimCodeCollection, hash)
+}
+
+// addClaimCode adds the claim code, replacing the previous one of its device, until the code expires
+func addClaimCode(conn redis.Conn, c claimcodes.ClaimCode) errors.EdgeX {
+	ttl := c.Expires - common.MakeTimestamp()
+	if ttl <= 0 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("claim code of device %s already expired", c.DeviceName), nil)
+	}
+	previous, err := redis.String(conn.Do(HGET, ClaimCodeCollectionDevice, c.DeviceName))
+	if err != nil && err != redis.ErrNil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "claim code query failed", err)
+	}
+
+	m, err := json.Marshal(c)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal claim code for Redis persistence", err)
+	}
+
+	storedKey := claimCodeStoredKey(c.Hash)
+	_ = conn.Send(MULTI)
+	if previous != "" {
+		_ = conn.Send(DEL, previous)
+	}
+	_ = conn.Send(SET, storedKey, m, PX, ttl)
+	_ = conn.Send(HSET, ClaimCodeCollectionDevice, c.DeviceName, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "claim code creation failed", err)
+	}
+	return nil
+}
+
+// takeClaimCode deletes and returns the claim code of the hash, in a transaction so that a code is only taken once
+func takeClaimCode(conn redis.Conn, hash string) (c claimcodes.ClaimCode, edgeXerr errors.EdgeX) {
+	storedKey := claimCodeStoredKey(hash)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(GET, storedKey)
+	_ = conn.Send(DEL, storedKey)
+	replies, err := redis.Values(conn.Do(EXEC))
+	if err != nil {
+		return c, errors.NewCommonEdgeX(errors.KindDatabaseError, "claim code query failed", err)
+	}
+	object, err := redis.Bytes(replies[0], nil)
+	if err == redis.ErrNil {
+		return c, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "claim code doesn't exist in the database", nil)
+	} else if err != nil {
+		return c, errors.NewCommonEdgeX(errors.KindDatabaseError, "claim code query failed", err)
+	}
+	if err = json.Unmarshal(object, &c); err != nil {
+		return c, errors.NewCommonEdgeX(errors.KindDatabaseError, "claim code format parsing failed from the database", err)
+	}
+
+	// the device may already have a newer code
+	current, err := redis.String(conn.Do(HGET, ClaimCodeCollectionDevice, c.DeviceName))
+	if err == nil && current == storedKey {
+		_, _ = conn.Do(HDEL, ClaimCodeCollectionDevice, c.DeviceName)
+	}
+	return c, nil
+}
+
+// deleteClaimCodeByDeviceName revokes the claim code of the device
+func deleteClaimCodeByDeviceName(conn redis.Conn, name string) errors.EdgeX {
+	storedKey, err := redis.String(conn.Do(HGET, ClaimCodeCollectionDevice, name))
+	if err == redis.ErrNil {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("device %s has no claim code", name), nil)
+	} else if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "claim code query failed", err)
+	}
+
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(HDEL, ClaimCodeCollectionDevice, name)
+	replies, err := redis.Ints(conn.Do(EXEC))
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "claim code deletion failed", err)
+	}
+	if replies[0] == 0 {
+		// the code already expired
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("device %s has no claim code", name), nil)
+	}
+	return nil
+}
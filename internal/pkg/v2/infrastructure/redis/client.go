@@ -8,9 +8,24 @@ package redis
 import (
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/edgexfoundry/edgex-go/internal/pkg/adminschedules"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/callbacks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/claimcodes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
 	redisClient "github.com/edgexfoundry/edgex-go/internal/pkg/db/redis"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/partitions"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
@@ -24,7 +39,8 @@ var once sync.Once
 
 type Client struct {
 	*redisClient.Client
-	loggingClient logger.LoggingClient
+	loggingClient     logger.LoggingClient
+	eventPartitioning eventPartitioning
 }
 
 func NewClient(config db.Configuration, logger logger.LoggingClient) (*Client, errors.EdgeX) {
@@ -32,6 +48,7 @@ func NewClient(config db.Configuration, logger logger.LoggingClient) (*Client, e
 	dc := &Client{}
 	dc.Client, err = redisClient.NewClient(config, logger)
 	dc.loggingClient = logger
+	dc.eventPartitioning = eventPartitioning{span: defaultPartitionSpan.Milliseconds()}
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "redis client creation failed", err)
 	}
@@ -49,6 +66,11 @@ func (c *Client) CloseSession() {
 
 // AddEvent adds a new event
 func (c *Client) AddEvent(e model.Event) (model.Event, errors.EdgeX) {
+	return c.AddExpiringEvent(e, 0)
+}
+
+// AddExpiringEvent adds a new event which expires ttl milliseconds after its creation, or never when ttl is zero
+func (c *Client) AddExpiringEvent(e model.Event, ttl int64) (model.Event, errors.EdgeX) {
 	conn := c.Pool.Get()
 	defer conn.Close()
 
@@ -59,7 +81,13 @@ func (c *Client) AddEvent(e model.Event) (model.Event, errors.EdgeX) {
 		}
 	}
 
-	return addEvent(conn, e)
+	return addEvent(conn, e, ttl, c.eventPartitioning)
+}
+
+// SetEventPartitioning partitions the event index of each device from hotThreshold events on, by partitions of
+// span, unless hotThreshold is 0. It must be called before the events are added.
+func (c *Client) SetEventPartitioning(hotThreshold int, span time.Duration) {
+	c.eventPartitioning = eventPartitioning{hotThreshold: hotThreshold, span: span.Milliseconds()}
 }
 
 // EventById gets an event by id
@@ -248,6 +276,18 @@ func (c *Client) AllDeviceProfiles(offset int, limit int, labels []string) ([]mo
 	return deviceProfiles, nil
 }
 
+// DeviceProfilesBySearch query device profiles matching every word of the search query, with offset, limit and labels
+func (c *Client) DeviceProfilesBySearch(offset int, limit int, query string, labels []string) ([]model.DeviceProfile, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	deviceProfiles, edgeXerr := deviceProfilesBySearch(conn, offset, limit, query, labels)
+	if edgeXerr != nil {
+		return deviceProfiles, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return deviceProfiles, nil
+}
+
 // DeviceProfilesByModel query device profiles with offset, limit and model
 func (c *Client) DeviceProfilesByModel(offset int, limit int, model string) ([]model.DeviceProfile, errors.EdgeX) {
 	conn := c.Pool.Get()
@@ -302,7 +342,34 @@ func (c *Client) EventCountByDeviceName(deviceName string) (uint32, errors.EdgeX
 	conn := c.Pool.Get()
 	defer conn.Close()
 
-	count, edgeXerr := getMemberNumber(conn, ZCARD, CreateKey(EventsCollectionDeviceName, deviceName))
+	count, edgeXerr := deviceEventCountByScoreRange(conn, deviceName, InfiniteMin, InfiniteMax)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// EventCountByTimeRange returns the count of the events created within the time range [start, end]
+func (c *Client) EventCountByTimeRange(start int, end int) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := getMemberCountByScoreRange(conn, EventsCollectionCreated, start, end)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// EventCountByDeviceNameAndTimeRange returns the count of the events of a device created within the time range
+// [start, end]
+func (c *Client) EventCountByDeviceNameAndTimeRange(deviceName string, start int, end int) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := deviceEventCountByScoreRange(conn, deviceName, start, end)
 	if edgeXerr != nil {
 		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
 	}
@@ -310,6 +377,19 @@ func (c *Client) EventCountByDeviceName(deviceName string) (uint32, errors.EdgeX
 	return count, nil
 }
 
+// DeviceHasEventsSince returns whether a device has events created at or after the since timestamp
+func (c *Client) DeviceHasEventsSince(deviceName string, since int) (bool, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := deviceEventCountByScoreRange(conn, deviceName, since, InfiniteMax)
+	if edgeXerr != nil {
+		return false, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count > 0, nil
+}
+
 // AllDeviceServices returns multiple device services per query criteria, including
 // offset: the number of items to skip before starting to collect the result set
 // limit: The numbers of items to return
@@ -470,6 +550,18 @@ func (c *Client) AllDevices(offset int, limit int, labels []string) ([]model.Dev
 	return devices, nil
 }
 
+// DevicesBySearch query the devices matching every word of the search query, with offset, limit and labels
+func (c *Client) DevicesBySearch(offset int, limit int, query string, labels []string) ([]model.Device, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	devices, edgeXerr := devicesBySearch(conn, offset, limit, query, labels)
+	if edgeXerr != nil {
+		return devices, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return devices, nil
+}
+
 // EventsByDeviceName query events by offset, limit and device name
 func (c *Client) EventsByDeviceName(offset int, limit int, name string) (events []model.Event, edgeXerr errors.EdgeX) {
 	conn := c.Pool.Get()
@@ -496,12 +588,13 @@ func (c *Client) EventsByTimeRange(start int, end int, offset int, limit int) (e
 	return events, nil
 }
 
-// ReadingTotalCount returns the total count of Event from the database
-func (c *Client) ReadingTotalCount() (uint32, errors.EdgeX) {
+// ReadingTotalCount returns the total count of Reading from the database, leaving out the readings of the excluded
+// qualities
+func (c *Client) ReadingTotalCount(excludedQualities []string) (uint32, errors.EdgeX) {
 	conn := c.Pool.Get()
 	defer conn.Close()
 
-	count, edgeXerr := getMemberNumber(conn, ZCARD, ReadingsCollection)
+	count, edgeXerr := readingCountExcludingQualities(conn, ReadingsCollection, excludedQualities)
 	if edgeXerr != nil {
 		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
 	}
@@ -509,12 +602,12 @@ func (c *Client) ReadingTotalCount() (uint32, errors.EdgeX) {
 	return count, nil
 }
 
-// AllReadings query events by offset, limit, and labels
-func (c *Client) AllReadings(offset int, limit int) ([]model.Reading, errors.EdgeX) {
+// AllReadings query readings by offset and limit, leaving out the readings of the excluded qualities
+func (c *Client) AllReadings(offset int, limit int, excludedQualities []string) ([]model.Reading, errors.EdgeX) {
 	conn := c.Pool.Get()
 	defer conn.Close()
 
-	readings, edgeXerr := allReadings(conn, offset, limit)
+	readings, edgeXerr := allReadings(conn, offset, limit, excludedQualities)
 	if edgeXerr != nil {
 		return readings, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
 			fmt.Sprintf("fail to query readings by offset %d, and limit %d", offset, limit), edgeXerr)
@@ -522,12 +615,12 @@ func (c *Client) AllReadings(offset int, limit int) ([]model.Reading, errors.Edg
 	return readings, nil
 }
 
-// ReadingsByTimeRange query readings by time range, offset, and limit
-func (c *Client) ReadingsByTimeRange(start int, end int, offset int, limit int) (readings []model.Reading, edgeXerr errors.EdgeX) {
+// ReadingsByTimeRange query readings by time range, offset, and limit, leaving out the readings of the excluded qualities
+func (c *Client) ReadingsByTimeRange(start int, end int, offset int, limit int, excludedQualities []string) (readings []model.Reading, edgeXerr errors.EdgeX) {
 	conn := c.Pool.Get()
 	defer conn.Close()
 
-	readings, edgeXerr = readingsByTimeRange(conn, start, end, offset, limit)
+	readings, edgeXerr = readingsByTimeRange(conn, start, end, offset, limit, excludedQualities)
 	if edgeXerr != nil {
 		return readings, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
 			fmt.Sprintf("fail to query readings by time range %v ~ %v, offset %d, and limit %d", start, end, offset, limit), edgeXerr)
@@ -535,12 +628,13 @@ func (c *Client) ReadingsByTimeRange(start int, end int, offset int, limit int)
 	return readings, nil
 }
 
-// ReadingsByResourceName query readings by offset, limit and resource name
-func (c *Client) ReadingsByResourceName(offset int, limit int, resourceName string) (readings []model.Reading, edgeXerr errors.EdgeX) {
+// ReadingsByResourceName query readings by offset, limit and resource name, leaving out the readings of the excluded
+// qualities
+func (c *Client) ReadingsByResourceName(offset int, limit int, resourceName string, excludedQualities []string) (readings []model.Reading, edgeXerr errors.EdgeX) {
 	conn := c.Pool.Get()
 	defer conn.Close()
 
-	readings, edgeXerr = readingsByResourceName(conn, offset, limit, resourceName)
+	readings, edgeXerr = readingsByResourceName(conn, offset, limit, resourceName, excludedQualities)
 	if edgeXerr != nil {
 		return readings, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
 			fmt.Sprintf("fail to query readings by offset %d, limit %d and resourceName %s", offset, limit, resourceName), edgeXerr)
@@ -548,12 +642,13 @@ func (c *Client) ReadingsByResourceName(offset int, limit int, resourceName stri
 	return readings, nil
 }
 
-// ReadingsByDeviceName query readings by offset, limit and device name
-func (c *Client) ReadingsByDeviceName(offset int, limit int, name string) (readings []model.Reading, edgeXerr errors.EdgeX) {
+// ReadingsByDeviceName query readings by offset, limit and device name, leaving out the readings of the excluded
+// qualities
+func (c *Client) ReadingsByDeviceName(offset int, limit int, name string, excludedQualities []string) (readings []model.Reading, edgeXerr errors.EdgeX) {
 	conn := c.Pool.Get()
 	defer conn.Close()
 
-	readings, edgeXerr = readingsByDeviceName(conn, offset, limit, name)
+	readings, edgeXerr = readingsByDeviceName(conn, offset, limit, name, excludedQualities)
 	if edgeXerr != nil {
 		return readings, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
 			fmt.Sprintf("fail to query readings by offset %d, limit %d and name %s", offset, limit, name), edgeXerr)
@@ -561,12 +656,27 @@ func (c *Client) ReadingsByDeviceName(offset int, limit int, name string) (readi
 	return readings, nil
 }
 
-// ReadingCountByDeviceName returns the count of Readings associated a specific Device from the database
-func (c *Client) ReadingCountByDeviceName(deviceName string) (uint32, errors.EdgeX) {
+// ReadingCountByDeviceName returns the count of Readings associated a specific Device from the database, leaving out
+// the readings of the excluded qualities
+func (c *Client) ReadingCountByDeviceName(deviceName string, excludedQualities []string) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := readingCountExcludingQualities(conn, CreateKey(ReadingsCollectionDeviceName, deviceName), excludedQualities)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// ReadingCountByDeviceNameAndTimeRange returns the count of the readings of a device created within the time range
+// [start, end], leaving out the readings of the excluded qualities
+func (c *Client) ReadingCountByDeviceNameAndTimeRange(deviceName string, start int, end int, excludedQualities []string) (uint32, errors.EdgeX) {
 	conn := c.Pool.Get()
 	defer conn.Close()
 
-	count, edgeXerr := getMemberNumber(conn, ZCARD, CreateKey(ReadingsCollectionDeviceName, deviceName))
+	count, edgeXerr := readingCountByScoreRangeExcludingQualities(conn, CreateKey(ReadingsCollectionDeviceName, deviceName), start, end, excludedQualities)
 	if edgeXerr != nil {
 		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
 	}
@@ -574,6 +684,161 @@ func (c *Client) ReadingCountByDeviceName(deviceName string) (uint32, errors.Edg
 	return count, nil
 }
 
+// AddReadingAnnotations stores the quality and null flag of the readings with the given ids
+func (c *Client) AddReadingAnnotations(annotations map[string]quality.Annotation) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := addReadingAnnotations(conn, annotations)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
+
+// ReadingAnnotations returns the quality and null flag of the readings with the given ids that have some
+func (c *Client) ReadingAnnotations(ids []string) (map[string]quality.Annotation, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	annotations, edgeXerr := readingAnnotations(conn, ids)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return annotations, nil
+}
+
+// AddEventTagIndex indexes the event with the given id and created timestamp by the given tags
+func (c *Client) AddEventTagIndex(id string, created int64, eventTags map[string]string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := addEventTagIndex(conn, id, created, eventTags)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
+
+// EventPartitions returns how the events of the hot devices are indexed by offset and limit
+func (c *Client) EventPartitions(offset int, limit int) ([]partitions.DevicePartitions, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	stats, edgeXerr := hotDevicePartitionStats(conn, offset, limit)
+	if edgeXerr != nil {
+		return stats, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return stats, nil
+}
+
+// EventPartitionsByDeviceName returns how the events of the device are indexed
+func (c *Client) EventPartitionsByDeviceName(deviceName string) (partitions.DevicePartitions, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	stats, edgeXerr := devicePartitionStats(conn, deviceName)
+	if edgeXerr != nil {
+		return stats, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return stats, nil
+}
+
+// DeleteExpiredEvents deletes, with their readings, up to limit events expired at the now timestamp and returns the
+// number of events deleted
+func (c *Client) DeleteExpiredEvents(now int64, limit int) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	deleted, edgeXerr := deleteExpiredEvents(conn, now, limit)
+	if edgeXerr != nil {
+		return deleted, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return deleted, nil
+}
+
+// DeleteEventsByTimeRange deletes, with their readings, up to limit events created within the time range [start, end],
+// the earliest first, and returns the number of events deleted, lower than limit once none is left
+func (c *Client) DeleteEventsByTimeRange(start int, end int, limit int) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	deleted, edgeXerr := deleteEventsByKeyScoreRange(conn, []string{EventsCollectionCreated}, start, end, limit)
+	if edgeXerr != nil {
+		return deleted, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return deleted, nil
+}
+
+// DeleteEventsByDeviceNameAndTimeRange deletes, with their readings, up to limit events of a device created within the
+// time range [start, end], and returns the number of events deleted, lower than limit once none is left
+func (c *Client) DeleteEventsByDeviceNameAndTimeRange(deviceName string, start int, end int, limit int) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	d, edgeXerr := loadDevicePartitioning(conn, deviceName)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	deleted, edgeXerr := deleteEventsByKeyScoreRange(conn, d.indexKeys(), start, end, limit)
+	if edgeXerr != nil {
+		return deleted, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return deleted, nil
+}
+
+// CompressReadings compresses the numeric readings created up to the before timestamp, by chunks of up to chunkSize
+// readings of a same device and resource, and returns the number of readings compressed. The compressed readings are
+// decoded transparently by the queries.
+func (c *Client) CompressReadings(before int64, chunkSize int) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	compressed, edgeXerr := compressReadings(conn, before, chunkSize, c.BatchSize)
+	if edgeXerr != nil {
+		return compressed, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return compressed, nil
+}
+
+// EventsByTags query the events matching every term of a tag expression by offset and limit
+func (c *Client) EventsByTags(terms []tags.Term, offset int, limit int) (events []model.Event, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	events, edgeXerr = eventsByTags(conn, terms, offset, limit)
+	if edgeXerr != nil {
+		return events, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query events by tags, offset %d, and limit %d", offset, limit), edgeXerr)
+	}
+	return events, nil
+}
+
+// EventCountByTags returns the count of the events matching every term of a tag expression
+func (c *Client) EventCountByTags(terms []tags.Term) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := eventCountByTags(conn, terms)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return count, nil
+}
+
+// ReadingsByTags query the readings of the events matching every term of a tag expression by offset and limit
+func (c *Client) ReadingsByTags(terms []tags.Term, offset int, limit int) (readings []model.Reading, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	readings, edgeXerr = readingsByTags(conn, terms, offset, limit)
+	if edgeXerr != nil {
+		return readings, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query readings by tags, offset %d, and limit %d", offset, limit), edgeXerr)
+	}
+	return readings, nil
+}
+
 // AddProvisionWatcher adds a new provision watcher
 func (c *Client) AddProvisionWatcher(pw model.ProvisionWatcher) (model.ProvisionWatcher, errors.EdgeX) {
 	conn := c.Pool.Get()
@@ -674,6 +939,225 @@ func (c *Client) UpdateProvisionWatcher(pw model.ProvisionWatcher) errors.EdgeX
 	return updateProvisionWatcher(conn, pw)
 }
 
+// AddDeprecation adds a new deprecation of a device profile resource
+func (c *Client) AddDeprecation(d deprecations.Deprecation) (deprecations.Deprecation, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(d.Id) == 0 {
+		d.Id = uuid.New().String()
+	}
+
+	return addDeprecation(conn, d)
+}
+
+// AllDeprecations returns the deprecations by offset and limit
+func (c *Client) AllDeprecations(offset int, limit int) ([]deprecations.Deprecation, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := allDeprecations(conn, offset, limit)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query deprecations by offset %d and limit %d", offset, limit), edgeXerr)
+	}
+	return result, nil
+}
+
+// DeprecationsByProfileName returns the deprecations of a device profile
+func (c *Client) DeprecationsByProfileName(profileName string) ([]deprecations.Deprecation, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := deprecationsByProfileName(conn, profileName)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query deprecations by profile name %s", profileName), edgeXerr)
+	}
+	return result, nil
+}
+
+// DeleteDeprecationByProfileNameAndResourceName withdraws the deprecation of a device profile resource
+func (c *Client) DeleteDeprecationByProfileNameAndResourceName(profileName string, resourceName string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteDeprecationByProfileNameAndResourceName(conn, profileName, resourceName)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to delete the deprecation of %s of device profile %s", resourceName, profileName), edgeXerr)
+	}
+	return nil
+}
+
+// AddAdminSchedule adds a new admin state schedule
+func (c *Client) AddAdminSchedule(s adminschedules.AdminSchedule) (adminschedules.AdminSchedule, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(s.Id) == 0 {
+		s.Id = uuid.New().String()
+	}
+
+	return addAdminSchedule(conn, s)
+}
+
+// AllAdminSchedules returns the admin state schedules by offset and limit
+func (c *Client) AllAdminSchedules(offset int, limit int) ([]adminschedules.AdminSchedule, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := allAdminSchedules(conn, offset, limit)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query admin state schedules by offset %d and limit %d", offset, limit), edgeXerr)
+	}
+	return result, nil
+}
+
+// AdminScheduleByName gets an admin state schedule by name
+func (c *Client) AdminScheduleByName(name string) (s adminschedules.AdminSchedule, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	s, edgeXerr = adminScheduleByName(conn, name)
+	if edgeXerr != nil {
+		return s, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query admin state schedule by name %s", name), edgeXerr)
+	}
+	return s, nil
+}
+
+// DeleteAdminScheduleByName deletes an admin state schedule by name
+func (c *Client) DeleteAdminScheduleByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteAdminScheduleByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the admin state schedule with name %s", name), edgeXerr)
+	}
+	return nil
+}
+
+// AddClaimCode adds the claim code of a device, replacing its previous one
+func (c *Client) AddClaimCode(code claimcodes.ClaimCode) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return addClaimCode(conn, code)
+}
+
+// TakeClaimCode deletes and returns the claim code of the hash, which can't be taken again
+func (c *Client) TakeClaimCode(hash string) (claimcodes.ClaimCode, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return takeClaimCode(conn, hash)
+}
+
+// DeleteClaimCodeByDeviceName revokes the claim code of a device
+func (c *Client) DeleteClaimCodeByDeviceName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return deleteClaimCodeByDeviceName(conn, name)
+}
+
+// AddCallbackMessage adds a callback to the outbox
+func (c *Client) AddCallbackMessage(m callbacks.Message) (callbacks.Message, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	m, edgeXerr := addCallbackMessage(conn, m)
+	if edgeXerr != nil {
+		return m, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to add the callback %s", m.Id), edgeXerr)
+	}
+	return m, nil
+}
+
+// AllCallbackMessages returns the callbacks of the outbox by offset and limit, the oldest first
+func (c *Client) AllCallbackMessages(offset int, limit int) ([]callbacks.Message, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	messages, edgeXerr := allCallbackMessages(conn, offset, limit)
+	if edgeXerr != nil {
+		return messages, errors.NewCommonEdgeX(errors.Kind(edgeXerr), "fail to query all callbacks", edgeXerr)
+	}
+	return messages, nil
+}
+
+// UpdateCallbackMessage replaces the attempts of a callback of the outbox
+func (c *Client) UpdateCallbackMessage(m callbacks.Message) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := updateCallbackMessage(conn, m)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to update the callback %s", m.Id), edgeXerr)
+	}
+	return nil
+}
+
+// DeleteCallbackMessageById removes a callback from the outbox
+func (c *Client) DeleteCallbackMessageById(id string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteCallbackMessageById(conn, id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the callback %s", id), edgeXerr)
+	}
+	return nil
+}
+
+// AddDiscoveryRecord records a device added through a provision watcher
+func (c *Client) AddDiscoveryRecord(r discovery.Record) (discovery.Record, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(r.Id) == 0 {
+		r.Id = uuid.New().String()
+	}
+
+	return addDiscoveryRecord(conn, r)
+}
+
+// AllDiscoveryRecords returns the discovery records by offset and limit
+func (c *Client) AllDiscoveryRecords(offset int, limit int) ([]discovery.Record, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := allDiscoveryRecords(conn, offset, limit)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query discovery records by offset %d and limit %d", offset, limit), edgeXerr)
+	}
+	return result, nil
+}
+
+// DiscoveryRecordsByProvisionWatcherName returns the discovery records of a provision watcher by offset and limit
+func (c *Client) DiscoveryRecordsByProvisionWatcherName(offset int, limit int, name string) ([]discovery.Record, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := discoveryRecordsByProvisionWatcherName(conn, offset, limit, name)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query discovery records by offset %d, limit %d and provision watcher name %s", offset, limit, name), edgeXerr)
+	}
+	return result, nil
+}
+
+// DiscoveryRecordCountByProvisionWatcherName returns the number of discovery records of a provision watcher created
+// since the given timestamp
+func (c *Client) DiscoveryRecordCountByProvisionWatcherName(name string, since int64) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := discoveryRecordCountByProvisionWatcherName(conn, name, since)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return count, nil
+}
+
 // AddInterval adds a new interval
 func (c *Client) AddInterval(interval model.Interval) (model.Interval, errors.EdgeX) {
 	conn := c.Pool.Get()
@@ -686,6 +1170,162 @@ func (c *Client) AddInterval(interval model.Interval) (model.Interval, errors.Ed
 	return addInterval(conn, interval)
 }
 
+// AddOneShot adds a new one-shot job
+func (c *Client) AddOneShot(oneShot oneshots.OneShot) (oneshots.OneShot, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(oneShot.Id) == 0 {
+		oneShot.Id = uuid.New().String()
+	}
+
+	return addOneShot(conn, oneShot)
+}
+
+// AllOneShots returns the pending one-shot jobs by offset and limit
+func (c *Client) AllOneShots(offset int, limit int) ([]oneshots.OneShot, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := allOneShots(conn, offset, limit)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return result, nil
+}
+
+// DueOneShots returns the one-shot jobs to run at or before the timestamp at
+func (c *Client) DueOneShots(at int64) ([]oneshots.OneShot, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := dueOneShots(conn, at)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return result, nil
+}
+
+// OneShotByName gets a one-shot job by name
+func (c *Client) OneShotByName(name string) (oneShot oneshots.OneShot, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	oneShot, edgeXerr = oneShotByName(conn, name)
+	if edgeXerr != nil {
+		return oneShot, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query one-shot job by name %s", name), edgeXerr)
+	}
+	return oneShot, nil
+}
+
+// DeleteOneShotByName deletes a one-shot job by name
+func (c *Client) DeleteOneShotByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteOneShotByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the one-shot job with name %s", name), edgeXerr)
+	}
+	return nil
+}
+
+// AddDeviceScope adds a new device scope of an interval action
+func (c *Client) AddDeviceScope(scope devicescopes.DeviceScope) (devicescopes.DeviceScope, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(scope.Id) == 0 {
+		scope.Id = uuid.New().String()
+	}
+
+	return addDeviceScope(conn, scope)
+}
+
+// AllDeviceScopes returns the device scopes by offset and limit
+func (c *Client) AllDeviceScopes(offset int, limit int) ([]devicescopes.DeviceScope, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := allDeviceScopes(conn, offset, limit)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return result, nil
+}
+
+// DeviceScopeByIntervalAction gets the device scope of the named interval action
+func (c *Client) DeviceScopeByIntervalAction(name string) (scope devicescopes.DeviceScope, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	scope, edgeXerr = deviceScopeByIntervalAction(conn, name)
+	if edgeXerr != nil {
+		return scope, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query the device scope of interval action %s", name), edgeXerr)
+	}
+	return scope, nil
+}
+
+// DeleteDeviceScopeByIntervalAction deletes the device scope of the named interval action
+func (c *Client) DeleteDeviceScopeByIntervalAction(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteDeviceScopeByIntervalAction(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the device scope of interval action %s", name), edgeXerr)
+	}
+	return nil
+}
+
+// AddCalendar adds a new exclusion calendar of an interval
+func (c *Client) AddCalendar(calendar calendars.Calendar) (calendars.Calendar, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(calendar.Id) == 0 {
+		calendar.Id = uuid.New().String()
+	}
+
+	return addCalendar(conn, calendar)
+}
+
+// AllCalendars returns the exclusion calendars by offset and limit
+func (c *Client) AllCalendars(offset int, limit int) ([]calendars.Calendar, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := allCalendars(conn, offset, limit)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return result, nil
+}
+
+// CalendarByInterval gets the exclusion calendar of the named interval
+func (c *Client) CalendarByInterval(name string) (calendar calendars.Calendar, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	calendar, edgeXerr = calendarByInterval(conn, name)
+	if edgeXerr != nil {
+		return calendar, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query the exclusion calendar of interval %s", name), edgeXerr)
+	}
+	return calendar, nil
+}
+
+// DeleteCalendarByInterval deletes the exclusion calendar of the named interval
+func (c *Client) DeleteCalendarByInterval(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteCalendarByInterval(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the exclusion calendar of interval %s", name), edgeXerr)
+	}
+	return nil
+}
+
 // AddSubscription adds a new subscription
 func (c *Client) AddSubscription(subscription model.Subscription) (model.Subscription, errors.EdgeX) {
 	conn := c.Pool.Get()
@@ -789,3 +1429,202 @@ func (c *Client) DeleteSubscriptionByName(name string) errors.EdgeX {
 
 	return nil
 }
+
+// AddSystemEvents adds the system events recorded by the services
+func (c *Client) AddSystemEvents(events []audit.SystemEvent) ([]audit.SystemEvent, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	for _, event := range events {
+		if event.Id != "" {
+			_, err := uuid.Parse(event.Id)
+			if err != nil {
+				return nil, errors.NewCommonEdgeX(errors.KindInvalidId, "uuid parsing failed", err)
+			}
+		}
+	}
+
+	return addSystemEvents(conn, events)
+}
+
+// SystemEventsByTimeRange query system events by time range, actor, offset, and limit. All actors are
+// matched when actor is empty.
+func (c *Client) SystemEventsByTimeRange(start int, end int, actor string, offset int, limit int) (events []audit.SystemEvent, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	events, edgeXerr = systemEventsByTimeRange(conn, start, end, actor, offset, limit)
+	if edgeXerr != nil {
+		return events, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query system events by time range %v ~ %v, actor %s, offset %d, and limit %d", start, end, actor, offset, limit), edgeXerr)
+	}
+	return events, nil
+}
+
+// AddTemplate adds a new notification template
+func (c *Client) AddTemplate(template templates.Template) (templates.Template, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(template.Id) == 0 {
+		template.Id = uuid.New().String()
+	}
+
+	return addTemplate(conn, template)
+}
+
+// AllTemplates returns the notification templates by offset and limit
+func (c *Client) AllTemplates(offset int, limit int) ([]templates.Template, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := allTemplates(conn, offset, limit)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return result, nil
+}
+
+// TemplateById gets a notification template by id
+func (c *Client) TemplateById(id string) (template templates.Template, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	template, edgeXerr = templateById(conn, id)
+	if edgeXerr != nil {
+		return template, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("failed to query template by id %s", id), edgeXerr)
+	}
+	return template, nil
+}
+
+// TemplateByName gets a notification template by name
+func (c *Client) TemplateByName(name string) (template templates.Template, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	template, edgeXerr = templateByName(conn, name)
+	if edgeXerr != nil {
+		return template, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query template by name %s", name), edgeXerr)
+	}
+	return template, nil
+}
+
+// UpdateTemplate replaces a notification template
+func (c *Client) UpdateTemplate(template templates.Template) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := updateTemplate(conn, template)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to update the template with name %s", template.Name), edgeXerr)
+	}
+	return nil
+}
+
+// DeleteTemplateByName deletes a notification template by name
+func (c *Client) DeleteTemplateByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteTemplateByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the template with name %s", name), edgeXerr)
+	}
+	return nil
+}
+
+// AddMuteWindow adds a new notification mute window
+func (c *Client) AddMuteWindow(window mutes.MuteWindow) (mutes.MuteWindow, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(window.Id) == 0 {
+		window.Id = uuid.New().String()
+	}
+
+	return addMuteWindow(conn, window)
+}
+
+// AllMuteWindows returns the notification mute windows by offset and limit
+func (c *Client) AllMuteWindows(offset int, limit int) ([]mutes.MuteWindow, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	result, edgeXerr := allMuteWindows(conn, offset, limit)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return result, nil
+}
+
+// MuteWindowById gets a notification mute window by id
+func (c *Client) MuteWindowById(id string) (window mutes.MuteWindow, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	window, edgeXerr = muteWindowById(conn, id)
+	if edgeXerr != nil {
+		return window, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("failed to query mute window by id %s", id), edgeXerr)
+	}
+	return window, nil
+}
+
+// MuteWindowByName gets a notification mute window by name
+func (c *Client) MuteWindowByName(name string) (window mutes.MuteWindow, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	window, edgeXerr = muteWindowByName(conn, name)
+	if edgeXerr != nil {
+		return window, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query mute window by name %s", name), edgeXerr)
+	}
+	return window, nil
+}
+
+// UpdateMuteWindow replaces a notification mute window
+func (c *Client) UpdateMuteWindow(window mutes.MuteWindow) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := updateMuteWindow(conn, window)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to update the mute window with name %s", window.Name), edgeXerr)
+	}
+	return nil
+}
+
+// DeleteMuteWindowByName deletes a notification mute window by name
+func (c *Client) DeleteMuteWindowByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteMuteWindowByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the mute window with name %s", name), edgeXerr)
+	}
+	return nil
+}
+
+// HoldNotification keeps the id of a notification queued by a mute window
+func (c *Client) HoldNotification(id string, created int64) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return holdNotification(conn, id, created)
+}
+
+// HeldNotifications returns the ids of the notifications queued by the mute windows by offset and limit
+func (c *Client) HeldNotifications(offset int, limit int) ([]string, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return heldNotifications(conn, offset, limit)
+}
+
+// ReleaseNotification removes the id of a notification queued by a mute window
+func (c *Client) ReleaseNotification(id string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return releaseNotification(conn, id)
+}
@@ -0,0 +1,558 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/gorilla"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+)
+
+const (
+	// ReadingsCollectionChunk is the sorted set of the chunks of compressed readings, scored by the creation timestamp
+	// of their last reading. Each chunk is a hash, keyed by ReadingsCollectionChunk:<id>, holding the compressed
+	// readings of a series.
+	ReadingsCollectionChunk = ReadingsCollection + DBKeySeparator + "chunk"
+	// ReadingsCollectionCompressed holds the creation timestamp up to which the readings were compressed
+	ReadingsCollectionCompressed = ReadingsCollection + DBKeySeparator + "compressed"
+
+	// compressedReadingPrefix starts the value stored for a compressed reading, a reference to the reading in its chunk
+	// in place of the JSON encoding of the reading
+	compressedReadingPrefix = 0
+
+	// the formats of the values of the numeric readings, the decimal or E notation, or the base64 encoding of the
+	// big-endian bits of the floats
+	decimalFormat = "decimal"
+	base64Format  = "base64"
+)
+
+// the fields of the chunk hashes
+const (
+	chunkDeviceName   = "deviceName"
+	chunkResourceName = "resourceName"
+	chunkProfileName  = "profileName"
+	chunkValueType    = "valueType"
+	chunkFormat       = "format"
+	chunkCount        = "count"
+	// chunkLive is the number of readings of the chunk not deleted yet, the chunk being deleted with the last one
+	chunkLive    = "live"
+	chunkIds     = "ids"
+	chunkCreated = "created"
+	chunkOrigins = "origins"
+	chunkValues  = "values"
+)
+
+var chunkFields = []interface{}{chunkDeviceName, chunkResourceName, chunkProfileName, chunkValueType, chunkFormat,
+	chunkCount, chunkIds, chunkCreated, chunkOrigins, chunkValues}
+
+// readingSeries identifies the readings compressed together, which differ only by their id, timestamps and value
+type readingSeries struct {
+	deviceName   string
+	resourceName string
+	profileName  string
+	valueType    string
+	format       string
+}
+
+// readingChunk holds the readings of a series in the order they were created. The creation and origin timestamps
+// are compressed by their delta-of-delta and the values by their XOR with the previous value.
+type readingChunk struct {
+	series  readingSeries
+	ids     []uuid.UUID
+	created []int64
+	origins []int64
+	values  []uint64
+}
+
+func (c *readingChunk) add(id uuid.UUID, reading models.SimpleReading, value uint64) {
+	c.ids = append(c.ids, id)
+	c.created = append(c.created, reading.Created)
+	c.origins = append(c.origins, reading.Origin)
+	c.values = append(c.values, value)
+}
+
+// hsetArgs returns the arguments of the HSET command storing the chunk in the hash key
+func (c readingChunk) hsetArgs(key string) []interface{} {
+	ids := make([]byte, 0, len(c.ids)*16)
+	for _, id := range c.ids {
+		ids = append(ids, id[:]...)
+	}
+	return []interface{}{key,
+		chunkDeviceName, c.series.deviceName,
+		chunkResourceName, c.series.resourceName,
+		chunkProfileName, c.series.profileName,
+		chunkValueType, c.series.valueType,
+		chunkFormat, c.series.format,
+		chunkCount, len(c.ids),
+		chunkLive, len(c.ids),
+		chunkIds, ids,
+		chunkCreated, gorilla.EncodeTimestamps(c.created),
+		chunkOrigins, gorilla.EncodeTimestamps(c.origins),
+		chunkValues, gorilla.EncodeValues(c.values),
+	}
+}
+
+// decodeChunk decodes the values of the chunkFields of a chunk hash
+func decodeChunk(fields [][]byte) (readingChunk, error) {
+	c := readingChunk{series: readingSeries{
+		deviceName:   string(fields[0]),
+		resourceName: string(fields[1]),
+		profileName:  string(fields[2]),
+		valueType:    string(fields[3]),
+		format:       string(fields[4]),
+	}}
+	count, err := strconv.Atoi(string(fields[5]))
+	if err != nil || count < 0 || len(fields[6]) != count*16 {
+		return c, fmt.Errorf("invalid count of readings %s", fields[5])
+	}
+	c.ids = make([]uuid.UUID, count)
+	for i := range c.ids {
+		copy(c.ids[i][:], fields[6][i*16:])
+	}
+	if c.created, err = gorilla.DecodeTimestamps(fields[7], count); err != nil {
+		return c, err
+	}
+	if c.origins, err = gorilla.DecodeTimestamps(fields[8], count); err != nil {
+		return c, err
+	}
+	if c.values, err = gorilla.DecodeValues(fields[9], count); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// reading returns the i-th reading of the chunk
+func (c readingChunk) reading(i int) models.SimpleReading {
+	return models.SimpleReading{
+		BaseReading: models.BaseReading{
+			Id:           c.ids[i].String(),
+			Created:      c.created[i],
+			Origin:       c.origins[i],
+			DeviceName:   c.series.deviceName,
+			ResourceName: c.series.resourceName,
+			ProfileName:  c.series.profileName,
+			ValueType:    c.series.valueType,
+		},
+		Value: formatValue(c.series.valueType, c.series.format, c.values[i]),
+	}
+}
+
+func chunkKey(id uuid.UUID) string {
+	return CreateKey(ReadingsCollectionChunk, id.String())
+}
+
+// compressedReadingRef returns the value stored for the reading at index in the chunk id
+func compressedReadingRef(id uuid.UUID, index int) []byte {
+	ref := append([]byte{compressedReadingPrefix}, id[:]...)
+	buf := make([]byte, binary.MaxVarintLen64)
+	return append(ref, buf[:binary.PutUvarint(buf, uint64(index))]...)
+}
+
+// parseCompressedReadingRef returns the key of the chunk and the index of the reading referenced by a stored value,
+// and false when the value is the JSON encoding of a reading
+func parseCompressedReadingRef(object []byte) (string, int, bool) {
+	if len(object) < 18 || object[0] != compressedReadingPrefix {
+		return "", 0, false
+	}
+	var id uuid.UUID
+	copy(id[:], object[1:17])
+	index, n := binary.Uvarint(object[17:])
+	if n <= 0 {
+		return "", 0, false
+	}
+	return chunkKey(id), int(index), true
+}
+
+// valueFormats returns the formats of the values of a numeric value type, none for the other types
+func valueFormats(valueType string) []string {
+	switch valueType {
+	case v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64,
+		v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64:
+		return []string{decimalFormat}
+	case v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+		return []string{decimalFormat, base64Format}
+	}
+	return nil
+}
+
+// parseValue returns the 64 bits compressed for the value of a numeric reading
+func parseValue(valueType string, format string, value string) (uint64, error) {
+	switch valueType {
+	case v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64:
+		i, err := strconv.ParseInt(value, 10, 64)
+		return uint64(i), err
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64:
+		return strconv.ParseUint(value, 10, 64)
+	case v2.ValueTypeFloat32:
+		if format == base64Format {
+			b, err := base64.StdEncoding.DecodeString(value)
+			if err != nil || len(b) != 4 {
+				return 0, fmt.Errorf("invalid base64 %s value %s", valueType, value)
+			}
+			return uint64(binary.BigEndian.Uint32(b)), nil
+		}
+		f, err := strconv.ParseFloat(value, 32)
+		return uint64(math.Float32bits(float32(f))), err
+	case v2.ValueTypeFloat64:
+		if format == base64Format {
+			b, err := base64.StdEncoding.DecodeString(value)
+			if err != nil || len(b) != 8 {
+				return 0, fmt.Errorf("invalid base64 %s value %s", valueType, value)
+			}
+			return binary.BigEndian.Uint64(b), nil
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		return math.Float64bits(f), err
+	}
+	return 0, fmt.Errorf("%s values can't be compressed", valueType)
+}
+
+// formatValue returns the value of a numeric reading from its compressed bits
+func formatValue(valueType string, format string, bits uint64) string {
+	switch valueType {
+	case v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64:
+		return strconv.FormatInt(int64(bits), 10)
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64:
+		return strconv.FormatUint(bits, 10)
+	case v2.ValueTypeFloat32:
+		if format == base64Format {
+			b := make([]byte, 4)
+			binary.BigEndian.PutUint32(b, uint32(bits))
+			return base64.StdEncoding.EncodeToString(b)
+		}
+		return strconv.FormatFloat(float64(math.Float32frombits(uint32(bits))), 'e', -1, 32)
+	case v2.ValueTypeFloat64:
+		if format == base64Format {
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, bits)
+			return base64.StdEncoding.EncodeToString(b)
+		}
+		return strconv.FormatFloat(math.Float64frombits(bits), 'e', -1, 64)
+	}
+	return ""
+}
+
+// compressibleReading returns the series and the compressed id and value of a reading, and false when the reading
+// isn't numeric or can't be restored exactly from its compressed form, e.g. a float value not written in the shortest
+// E notation
+func compressibleReading(reading models.SimpleReading) (readingSeries, uuid.UUID, uint64, bool) {
+	id, err := uuid.Parse(reading.Id)
+	if err != nil || id.String() != reading.Id {
+		return readingSeries{}, id, 0, false
+	}
+	for _, format := range valueFormats(reading.ValueType) {
+		value, err := parseValue(reading.ValueType, format, reading.Value)
+		if err == nil && formatValue(reading.ValueType, format, value) == reading.Value {
+			return readingSeries{
+				deviceName:   reading.DeviceName,
+				resourceName: reading.ResourceName,
+				profileName:  reading.ProfileName,
+				valueType:    reading.ValueType,
+				format:       format,
+			}, id, value, true
+		}
+	}
+	return readingSeries{}, id, 0, false
+}
+
+// chunkByKey reads and decodes the chunk hash key, returning a KindEntityDoesNotExist error when it doesn't exist
+func chunkByKey(conn redis.Conn, key string) (readingChunk, errors.EdgeX) {
+	fields, err := redis.ByteSlices(conn.Do(HMGET, append([]interface{}{key}, chunkFields...)...))
+	if err != nil {
+		return readingChunk{}, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to read the reading chunk %s", key), err)
+	}
+	if fields[0] == nil {
+		return readingChunk{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("reading chunk %s doesn't exist", key), nil)
+	}
+	chunk, err := decodeChunk(fields)
+	if err != nil {
+		return readingChunk{}, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to decode the reading chunk %s", key), err)
+	}
+	return chunk, nil
+}
+
+// expandReadings returns the stored readings of objects as JSON, decoding the compressed ones from their chunk, each
+// chunk being read once. The readings whose chunk no longer exists are returned nil.
+func expandReadings(conn redis.Conn, objects [][]byte) ([][]byte, errors.EdgeX) {
+	expanded := make([][]byte, len(objects))
+	chunks := make(map[string]*readingChunk)
+	for i, object := range objects {
+		key, index, ok := parseCompressedReadingRef(object)
+		if !ok {
+			expanded[i] = object
+			continue
+		}
+		chunk, read := chunks[key]
+		if !read {
+			c, edgeXerr := chunkByKey(conn, key)
+			if edgeXerr != nil && errors.Kind(edgeXerr) != errors.KindEntityDoesNotExist {
+				return nil, edgeXerr
+			}
+			if edgeXerr == nil {
+				chunk = &c
+			}
+			chunks[key] = chunk
+		}
+		if chunk == nil {
+			continue
+		}
+		if index >= len(chunk.ids) {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("reading %d out of the reading chunk %s", index, key), nil)
+		}
+		m, err := json.Marshal(chunk.reading(index))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "reading parsing failed", err)
+		}
+		expanded[i] = m
+	}
+	return expanded, nil
+}
+
+// sendDeleteCompressedReadingCmd sends the command counting the deletion of the stored reading object from its chunk,
+// within the transaction deleting the reading, and returns the key of the chunk, empty when the reading isn't
+// compressed
+func sendDeleteCompressedReadingCmd(conn redis.Conn, object []byte) string {
+	key, _, ok := parseCompressedReadingRef(object)
+	if !ok {
+		return ""
+	}
+	_ = conn.Send(HINCRBY, key, chunkLive, -1)
+	return key
+}
+
+// deleteEmptyChunks deletes the chunks of keys whose readings are all deleted
+func deleteEmptyChunks(conn redis.Conn, keys []string) errors.EdgeX {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		live, err := redis.Int(conn.Do(HGET, key, chunkLive))
+		if err == redis.ErrNil {
+			continue
+		} else if err != nil {
+			return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to read the reading chunk %s", key), err)
+		}
+		if live > 0 {
+			continue
+		}
+		if edgeXerr := deleteChunks(conn, []string{key}); edgeXerr != nil {
+			return edgeXerr
+		}
+	}
+	return nil
+}
+
+func deleteChunks(conn redis.Conn, keys []string) errors.EdgeX {
+	if len(keys) == 0 {
+		return nil
+	}
+	_ = conn.Send(MULTI)
+	for _, key := range keys {
+		_ = conn.Send(UNLINK, key)
+		_ = conn.Send(ZREM, ReadingsCollectionChunk, key)
+	}
+	if _, err := conn.Do(EXEC); err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to delete the reading chunks", err)
+	}
+	return nil
+}
+
+// deleteStaleChunks deletes the chunks whose last reading is older than the oldest stored reading, left when their
+// readings were deleted while being compressed
+func deleteStaleChunks(conn redis.Conn) errors.EdgeX {
+	upTo := InfiniteMax
+	oldest, err := redis.Strings(conn.Do(ZRANGE, ReadingsCollectionCreated, 0, 0, WITHSCORES))
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to read the oldest reading", err)
+	}
+	if len(oldest) == 2 {
+		upTo = "(" + oldest[1]
+	}
+	keys, err := redis.Strings(conn.Do(ZRANGEBYSCORE, ReadingsCollectionChunk, InfiniteMin, upTo))
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to query the stale reading chunks", err)
+	}
+	return deleteChunks(conn, keys)
+}
+
+// compressReadings compresses the numeric readings created up to the before timestamp since the last compression,
+// by chunks of up to chunkSize readings of a same series, reading batchSize readings at a time, and returns the
+// number of readings compressed. The readings are left uncompressed when they are the only reading of their series
+// in a batch.
+func compressReadings(conn redis.Conn, before int64, chunkSize int, batchSize int) (uint32, errors.EdgeX) {
+	watermark, err := redis.Int64(conn.Do(GET, ReadingsCollectionCompressed))
+	if err != nil && err != redis.ErrNil {
+		return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to read the timestamp of the last compression", err)
+	}
+
+	var compressed uint32
+	for watermark < before {
+		keys, upTo, edgeXerr := nextReadingKeys(conn, watermark, before, batchSize)
+		if edgeXerr != nil {
+			return compressed, edgeXerr
+		}
+		count, edgeXerr := compressReadingKeys(conn, keys, chunkSize)
+		compressed += count
+		if edgeXerr != nil {
+			return compressed, edgeXerr
+		}
+		if _, err := conn.Do(SET, ReadingsCollectionCompressed, upTo); err != nil {
+			return compressed, errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to save the timestamp of the last compression", err)
+		}
+		watermark = upTo
+	}
+
+	return compressed, deleteStaleChunks(conn)
+}
+
+// nextReadingKeys returns the keys of up to batchSize readings created after the after timestamp and up to the before
+// timestamp, the oldest first, and the timestamp up to which they were all returned. The readings created at the same
+// timestamp are returned together, even beyond batchSize.
+func nextReadingKeys(conn redis.Conn, after int64, before int64, batchSize int) ([]string, int64, errors.EdgeX) {
+	values, err := redis.Strings(conn.Do(ZRANGEBYSCORE, ReadingsCollectionCreated, fmt.Sprintf("(%d", after), before,
+		WITHSCORES, LIMIT, 0, batchSize))
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to query the readings to compress", err)
+	}
+	keys := make([]string, 0, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		keys = append(keys, values[i])
+	}
+	if len(keys) < batchSize {
+		return keys, before, nil
+	}
+
+	last, err := strconv.ParseFloat(values[len(values)-1], 64)
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "invalid reading creation timestamp", err)
+	}
+	lastCreated := int64(last)
+	// the readings created at the last timestamp may go beyond the batch, they are left to the next one
+	for i := len(values) - 1; i >= 0; i -= 2 {
+		if values[i] != values[len(values)-1] {
+			return keys[:(i+1)/2], lastCreated - 1, nil
+		}
+	}
+	// all of the batch is created at the same timestamp
+	keys, err = redis.Strings(conn.Do(ZRANGEBYSCORE, ReadingsCollectionCreated, lastCreated, lastCreated))
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to query the readings to compress", err)
+	}
+	return keys, lastCreated, nil
+}
+
+// compressReadingKeys compresses the numeric readings stored by keys, in the order of keys, and returns the number
+// of readings compressed
+func compressReadingKeys(conn redis.Conn, keys []string, chunkSize int) (uint32, errors.EdgeX) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = key
+	}
+	objects, err := redis.ByteSlices(conn.Do(MGET, args...))
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "query objects from database failed", err)
+	}
+
+	var series []readingSeries
+	chunks := make(map[readingSeries]*readingChunk)
+	chunkKeys := make(map[readingSeries][]string)
+	for i, object := range objects {
+		if len(object) == 0 || object[0] != '{' {
+			// deleted or compressed already
+			continue
+		}
+		var reading models.SimpleReading
+		if err := json.Unmarshal(object, &reading); err != nil {
+			continue
+		}
+		s, id, value, ok := compressibleReading(reading)
+		if !ok {
+			continue
+		}
+		chunk, found := chunks[s]
+		if !found {
+			chunk = &readingChunk{series: s}
+			chunks[s] = chunk
+			series = append(series, s)
+		}
+		chunk.add(id, reading, value)
+		chunkKeys[s] = append(chunkKeys[s], keys[i])
+	}
+
+	var compressed uint32
+	for _, s := range series {
+		chunk, storedKeys := chunks[s], chunkKeys[s]
+		if len(storedKeys) < 2 {
+			continue
+		}
+		for start := 0; start < len(storedKeys); start += chunkSize {
+			end := start + chunkSize
+			if end > len(storedKeys) {
+				end = len(storedKeys)
+			}
+			count, edgeXerr := writeChunk(conn, readingChunk{
+				series:  s,
+				ids:     chunk.ids[start:end],
+				created: chunk.created[start:end],
+				origins: chunk.origins[start:end],
+				values:  chunk.values[start:end],
+			}, storedKeys[start:end])
+			compressed += count
+			if edgeXerr != nil {
+				return compressed, edgeXerr
+			}
+		}
+	}
+	return compressed, nil
+}
+
+// writeChunk stores the chunk and replaces its readings, stored by storedKeys, by their reference in the chunk, and
+// returns the number of readings replaced. The readings deleted since they were read aren't stored again.
+func writeChunk(conn redis.Conn, chunk readingChunk, storedKeys []string) (uint32, errors.EdgeX) {
+	id := uuid.New()
+	key := chunkKey(id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(HSET, chunk.hsetArgs(key)...)
+	_ = conn.Send(ZADD, ReadingsCollectionChunk, chunk.created[len(chunk.created)-1], key)
+	for i, storedKey := range storedKeys {
+		_ = conn.Send(SET, storedKey, compressedReadingRef(id, i), XX)
+	}
+	replies, err := redis.Values(conn.Do(EXEC))
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to store the reading chunk", err)
+	}
+
+	deleted := 0
+	for _, reply := range replies[2:] {
+		if reply == nil {
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		if _, err := conn.Do(HINCRBY, key, chunkLive, -deleted); err != nil {
+			return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to count the readings of the reading chunk", err)
+		}
+		if edgeXerr := deleteEmptyChunks(conn, []string{key}); edgeXerr != nil {
+			return 0, edgeXerr
+		}
+	}
+	return uint32(len(storedKeys) - deleted), nil
+}
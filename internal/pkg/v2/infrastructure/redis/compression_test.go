@@ -0,0 +1,127 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func simpleReading(valueType string, value string) models.SimpleReading {
+	return models.SimpleReading{
+		BaseReading: models.BaseReading{
+			Id:           uuid.New().String(),
+			Created:      1600000000000,
+			Origin:       1600000000000000000,
+			DeviceName:   "thermostat",
+			ResourceName: "temperature",
+			ProfileName:  "thermostat-profile",
+			ValueType:    valueType,
+		},
+		Value: value,
+	}
+}
+
+func TestCompressibleReading(t *testing.T) {
+	tests := []struct {
+		name         string
+		reading      models.SimpleReading
+		compressible bool
+		format       string
+	}{
+		{"int", simpleReading(v2.ValueTypeInt16, "-42"), true, decimalFormat},
+		{"uint", simpleReading(v2.ValueTypeUint64, "18446744073709551615"), true, decimalFormat},
+		{"float E notation", simpleReading(v2.ValueTypeFloat64, "2.15e+01"), true, decimalFormat},
+		{"float32 E notation", simpleReading(v2.ValueTypeFloat32, "1.1e-01"), true, decimalFormat},
+		{"float base64", simpleReading(v2.ValueTypeFloat64, "QDWAAAAAAAA="), true, base64Format},
+		{"float32 base64", simpleReading(v2.ValueTypeFloat32, "QawAAA=="), true, base64Format},
+		{"float not in E notation", simpleReading(v2.ValueTypeFloat64, "21.5"), false, ""},
+		{"int with a sign", simpleReading(v2.ValueTypeInt32, "+7"), false, ""},
+		{"string", simpleReading(v2.ValueTypeString, "on"), false, ""},
+		{"bool", simpleReading(v2.ValueTypeBool, "true"), false, ""},
+		{"invalid id", func() models.SimpleReading {
+			r := simpleReading(v2.ValueTypeInt64, "1")
+			r.Id = "reading-1"
+			return r
+		}(), false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, id, value, ok := compressibleReading(tt.reading)
+			require.Equal(t, tt.compressible, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.reading.Id, id.String())
+			assert.Equal(t, tt.format, series.format)
+			assert.Equal(t, tt.reading.Value, formatValue(series.valueType, series.format, value))
+		})
+	}
+}
+
+func TestCompressedReadingRef(t *testing.T) {
+	id := uuid.New()
+	for _, index := range []int{0, 127, 128, 100000} {
+		key, i, ok := parseCompressedReadingRef(compressedReadingRef(id, index))
+		require.True(t, ok)
+		assert.Equal(t, chunkKey(id), key)
+		assert.Equal(t, index, i)
+	}
+
+	_, _, ok := parseCompressedReadingRef([]byte(`{"id":"1b9d2e3a-6e4f-4a5b-8c7d-9e0f1a2b3c4d"}`))
+	assert.False(t, ok, "a JSON reading should not be taken for a reference")
+	_, _, ok = parseCompressedReadingRef(compressedReadingRef(id, 1)[:17])
+	assert.False(t, ok, "a truncated reference should be rejected")
+}
+
+func TestReadingChunk(t *testing.T) {
+	var chunk readingChunk
+	var readings []models.SimpleReading
+	for i := 0; i < 10; i++ {
+		r := simpleReading(v2.ValueTypeFloat64, fmt.Sprintf("2.1%de+01", i%3+1))
+		r.Created += int64(i) * 1000
+		r.Origin += int64(i) * 1000000000
+		series, id, value, ok := compressibleReading(r)
+		require.True(t, ok)
+		chunk.series = series
+		chunk.add(id, r, value)
+		readings = append(readings, r)
+	}
+
+	// the chunkFields values as returned by HMGET
+	args := chunk.hsetArgs(chunkKey(uuid.New()))
+	values := make(map[interface{}][]byte)
+	for i := 1; i < len(args); i += 2 {
+		switch v := args[i+1].(type) {
+		case []byte:
+			values[args[i]] = v
+		default:
+			values[args[i]] = []byte(fmt.Sprint(v))
+		}
+	}
+	assert.Equal(t, "10", string(values[chunkLive]))
+	var fields [][]byte
+	for _, field := range chunkFields {
+		fields = append(fields, values[field])
+	}
+
+	decoded, err := decodeChunk(fields)
+	require.NoError(t, err)
+	for i, r := range readings {
+		assert.Equal(t, r, decoded.reading(i))
+	}
+
+	fields[5] = []byte("11")
+	_, err = decodeChunk(fields)
+	assert.Error(t, err, "a chunk with missing ids should be rejected")
+}
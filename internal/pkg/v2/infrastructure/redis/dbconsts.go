@@ -17,8 +17,13 @@ const (
 	HGET             = "HGET"
 	HEXISTS          = "HEXISTS"
 	HDEL             = "HDEL"
+	HMGET            = "HMGET"
+	HINCRBY          = "HINCRBY"
 	SADD             = "SADD"
 	SREM             = "SREM"
+	SCARD            = "SCARD"
+	SMEMBERS         = "SMEMBERS"
+	ZSCORE           = "ZSCORE"
 	ZADD             = "ZADD"
 	ZREM             = "ZREM"
 	EXEC             = "EXEC"
@@ -31,6 +36,12 @@ const (
 	ZRANGEBYSCORE    = "ZRANGEBYSCORE"
 	ZREVRANGEBYSCORE = "ZREVRANGEBYSCORE"
 	LIMIT            = "LIMIT"
+	WITHSCORES       = "WITHSCORES"
+	XX               = "XX"
+	NX               = "NX"
+	PX               = "PX"
+	WATCH            = "WATCH"
+	UNWATCH          = "UNWATCH"
 )
 
 const (
@@ -0,0 +1,130 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/deprecations"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	DeprecationCollection            = "md|dpr"
+	DeprecationCollectionProfileName = DeprecationCollection + DBKeySeparator + v2.Profile + DBKeySeparator + v2.Name
+	// DeprecationCollectionResource maps "<profile name>:<resource name>" to the stored key of the deprecation
+	DeprecationCollectionResource = DeprecationCollection + DBKeySeparator + "resource"
+)
+
+// deprecationStoredKey return the deprecation's stored key which combines the collection name and object id
+func deprecationStoredKey(id string) string {
+	return CreateKey(DeprecationCollection, id)
+}
+
+// addDeprecation adds a new deprecation into DB, a resource of a profile being deprecated only once
+func addDeprecation(conn redis.Conn, d deprecations.Deprecation) (deprecations.Deprecation, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, deprecationStoredKey(d.Id))
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return d, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("deprecation id %s already exists", d.Id), edgeXerr)
+	}
+
+	resourceKey := CreateKey(d.ProfileName, d.ResourceName)
+	exists, edgeXerr = objectNameExists(conn, DeprecationCollectionResource, resourceKey)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return d, errors.NewCommonEdgeX(errors.KindDuplicateName,
+			fmt.Sprintf("%s of device profile %s is already deprecated", d.ResourceName, d.ProfileName), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	if d.Created == 0 {
+		d.Created = ts
+	}
+	d.Modified = ts
+
+	m, err := json.Marshal(d)
+	if err != nil {
+		return d, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal deprecation for Redis persistence", err)
+	}
+
+	redisKey := deprecationStoredKey(d.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, redisKey, m)
+	_ = conn.Send(ZADD, DeprecationCollection, d.Created, redisKey)
+	_ = conn.Send(ZADD, CreateKey(DeprecationCollectionProfileName, d.ProfileName), d.Created, redisKey)
+	_ = conn.Send(HSET, DeprecationCollectionResource, resourceKey, redisKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "deprecation creation failed", err)
+	}
+
+	return d, edgeXerr
+}
+
+// unmarshalDeprecations converts the stored objects into deprecations
+func unmarshalDeprecations(objects [][]byte) ([]deprecations.Deprecation, errors.EdgeX) {
+	result := make([]deprecations.Deprecation, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &result[i])
+		if err != nil {
+			return []deprecations.Deprecation{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "deprecation format parsing failed from the database", err)
+		}
+	}
+	return result, nil
+}
+
+// allDeprecations queries deprecations by offset and limit, the latest created first
+func allDeprecations(conn redis.Conn, offset, limit int) ([]deprecations.Deprecation, errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, DeprecationCollection, offset, end)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return unmarshalDeprecations(objects)
+}
+
+// deprecationsByProfileName queries the deprecations of a device profile, the latest created first
+func deprecationsByProfileName(conn redis.Conn, profileName string) ([]deprecations.Deprecation, errors.EdgeX) {
+	objects, edgeXerr := getObjectsByRevRange(conn, CreateKey(DeprecationCollectionProfileName, profileName), 0, -1)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return unmarshalDeprecations(objects)
+}
+
+// deleteDeprecationByProfileNameAndResourceName withdraws the deprecation of a resource of a device profile
+func deleteDeprecationByProfileNameAndResourceName(conn redis.Conn, profileName string, resourceName string) errors.EdgeX {
+	resourceKey := CreateKey(profileName, resourceName)
+	var d deprecations.Deprecation
+	edgeXerr := getObjectByHash(conn, DeprecationCollectionResource, resourceKey, &d)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	storedKey := deprecationStoredKey(d.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, DeprecationCollection, storedKey)
+	_ = conn.Send(ZREM, CreateKey(DeprecationCollectionProfileName, d.ProfileName), storedKey)
+	_ = conn.Send(HDEL, DeprecationCollectionResource, resourceKey)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "deprecation deletion failed", err)
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ const (
 	DeviceCollectionLabel       = DeviceCollection + DBKeySeparator + v2.Label
 	DeviceCollectionServiceName = DeviceCollection + DBKeySeparator + v2.Service + DBKeySeparator + v2.Name
 	DeviceCollectionProfileName = DeviceCollection + DBKeySeparator + v2.Profile + DBKeySeparator + v2.Name
+	DeviceCollectionSearch      = DeviceCollection + DBKeySeparator + "search"
 )
 
 // deviceStoredKey return the device's stored key which combines the collection name and object id
@@ -31,6 +32,11 @@ func deviceStoredKey(id string) string {
 	return CreateKey(DeviceCollection, id)
 }
 
+// deviceSearchTexts returns the device fields covered by the free-text search index
+func deviceSearchTexts(d models.Device) []string {
+	return append([]string{d.Name, d.Description}, d.Labels...)
+}
+
 // deviceNameExists whether the device exists by name
 func deviceNameExists(conn redis.Conn, name string) (bool, errors.EdgeX) {
 	exists, err := objectNameExists(conn, DeviceCollectionName, name)
@@ -63,6 +69,7 @@ func sendAddDeviceCmd(conn redis.Conn, storedKey string, d models.Device) errors
 	for _, label := range d.Labels {
 		_ = conn.Send(ZADD, CreateKey(DeviceCollectionLabel, label), d.Modified, storedKey)
 	}
+	sendAddSearchIndexCmd(conn, DeviceCollectionSearch, storedKey, d.Modified, deviceSearchTexts(d)...)
 	return nil
 }
 
@@ -156,6 +163,7 @@ func sendDeleteDeviceCmd(conn redis.Conn, storedKey string, device models.Device
 	for _, label := range device.Labels {
 		_ = conn.Send(ZREM, CreateKey(DeviceCollectionLabel, label), storedKey)
 	}
+	sendDeleteSearchIndexCmd(conn, DeviceCollectionSearch, storedKey, deviceSearchTexts(device)...)
 }
 
 // deleteDevice deletes a device
@@ -216,6 +224,33 @@ func devicesByLabels(conn redis.Conn, offset int, limit int, labels []string) (d
 	return devices, nil
 }
 
+// devicesBySearch query devices whose name, description or labels contain every word of the search query, with offset, limit and labels
+func devicesBySearch(conn redis.Conn, offset int, limit int, query string, labels []string) (devices []models.Device, edgeXerr errors.EdgeX) {
+	indexKeys, edgeXerr := searchIndexKeys(DeviceCollectionSearch, DeviceCollectionLabel, query, labels)
+	if edgeXerr != nil {
+		return devices, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByIndexKeysAndSomeRange(conn, ZREVRANGE, indexKeys, offset, end)
+	if edgeXerr != nil {
+		return devices, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	devices = make([]models.Device, len(objects))
+	for i, in := range objects {
+		d := models.Device{}
+		err := json.Unmarshal(in, &d)
+		if err != nil {
+			return []models.Device{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "device format parsing failed from the database", err)
+		}
+		devices[i] = d
+	}
+	return devices, nil
+}
+
 // devicesByProfileName query devices by offset, limit and profile name
 func devicesByProfileName(conn redis.Conn, offset int, limit int, profileName string) (devices []models.Device, edgeXerr errors.EdgeX) {
 	end := offset + limit - 1
@@ -24,6 +24,7 @@ const (
 	DeviceProfileCollectionLabel        = DeviceProfileCollection + DBKeySeparator + v2.Label
 	DeviceProfileCollectionModel        = DeviceProfileCollection + DBKeySeparator + v2.Model
 	DeviceProfileCollectionManufacturer = DeviceProfileCollection + DBKeySeparator + v2.Manufacturer
+	DeviceProfileCollectionSearch       = DeviceProfileCollection + DBKeySeparator + "search"
 )
 
 // deviceProfileStoredKey return the device profile's stored key which combines the collection name and object id
@@ -31,6 +32,18 @@ func deviceProfileStoredKey(id string) string {
 	return CreateKey(DeviceProfileCollection, id)
 }
 
+// deviceProfileSearchTexts returns the device profile fields covered by the free-text search index
+func deviceProfileSearchTexts(dp models.DeviceProfile) []string {
+	texts := append([]string{dp.Name, dp.Description}, dp.Labels...)
+	for _, resource := range dp.DeviceResources {
+		texts = append(texts, resource.Name)
+	}
+	for _, command := range dp.DeviceCommands {
+		texts = append(texts, command.Name)
+	}
+	return texts
+}
+
 // deviceProfileNameExists whether the device profile exists by name
 func deviceProfileNameExists(conn redis.Conn, name string) (bool, errors.EdgeX) {
 	exists, err := objectNameExists(conn, DeviceProfileCollectionName, name)
@@ -63,6 +76,7 @@ func sendAddDeviceProfileCmd(conn redis.Conn, storedKey string, dp models.Device
 	for _, label := range dp.Labels {
 		_ = conn.Send(ZADD, CreateKey(DeviceProfileCollectionLabel, label), dp.Modified, storedKey)
 	}
+	sendAddSearchIndexCmd(conn, DeviceProfileCollectionSearch, storedKey, dp.Modified, deviceProfileSearchTexts(dp)...)
 	return nil
 }
 
@@ -133,6 +147,7 @@ func sendDeleteDeviceProfileCmd(conn redis.Conn, storedKey string, dp models.Dev
 	for _, label := range dp.Labels {
 		_ = conn.Send(ZREM, CreateKey(DeviceProfileCollectionLabel, label), storedKey)
 	}
+	sendDeleteSearchIndexCmd(conn, DeviceProfileCollectionSearch, storedKey, deviceProfileSearchTexts(dp)...)
 }
 
 func deleteDeviceProfile(conn redis.Conn, dp models.DeviceProfile) errors.EdgeX {
@@ -229,6 +244,34 @@ func deviceProfilesByLabels(conn redis.Conn, offset int, limit int, labels []str
 	return deviceProfiles, nil
 }
 
+// deviceProfilesBySearch query device profiles whose name, description, labels or resource names contain every word
+// of the search query, with offset, limit and labels
+func deviceProfilesBySearch(conn redis.Conn, offset int, limit int, query string, labels []string) (deviceProfiles []models.DeviceProfile, edgeXerr errors.EdgeX) {
+	indexKeys, edgeXerr := searchIndexKeys(DeviceProfileCollectionSearch, DeviceProfileCollectionLabel, query, labels)
+	if edgeXerr != nil {
+		return deviceProfiles, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByIndexKeysAndSomeRange(conn, ZREVRANGE, indexKeys, offset, end)
+	if edgeXerr != nil {
+		return deviceProfiles, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	deviceProfiles = make([]models.DeviceProfile, len(objects))
+	for i, in := range objects {
+		dp := models.DeviceProfile{}
+		err := json.Unmarshal(in, &dp)
+		if err != nil {
+			return []models.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "device profile format parsing failed from the database", err)
+		}
+		deviceProfiles[i] = dp
+	}
+	return deviceProfiles, nil
+}
+
 // deviceProfilesByModel query device profiles by offset, limit and model
 func deviceProfilesByModel(conn redis.Conn, offset int, limit int, model string) (deviceProfiles []models.DeviceProfile, edgeXerr errors.EdgeX) {
 	end := offset + limit - 1
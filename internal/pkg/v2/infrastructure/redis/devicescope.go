@@ -0,0 +1,119 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// DeviceScopeCollection is the sorted set of the device scopes of the interval actions, scored by their creation
+	DeviceScopeCollection = "ss|ds"
+	// DeviceScopeCollectionIntervalAction maps the names of the interval actions to their device scope
+	DeviceScopeCollectionIntervalAction = DeviceScopeCollection + DBKeySeparator + "intervalaction"
+)
+
+// deviceScopeStoredKey return the device scope's stored key which combines the collection name and object id
+func deviceScopeStoredKey(id string) string {
+	return CreateKey(DeviceScopeCollection, id)
+}
+
+// addDeviceScope adds a new device scope into DB
+func addDeviceScope(conn redis.Conn, scope devicescopes.DeviceScope) (devicescopes.DeviceScope, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, deviceScopeStoredKey(scope.Id))
+	if edgeXerr != nil {
+		return scope, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return scope, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device scope id %s already exists", scope.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, DeviceScopeCollectionIntervalAction, scope.IntervalAction)
+	if edgeXerr != nil {
+		return scope, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return scope, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval action %s already has a device scope", scope.IntervalAction), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	if scope.Created == 0 {
+		scope.Created = ts
+	}
+	scope.Modified = ts
+
+	m, err := json.Marshal(scope)
+	if err != nil {
+		return scope, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal device scope for Redis persistence", err)
+	}
+
+	storedKey := deviceScopeStoredKey(scope.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, DeviceScopeCollection, scope.Created, storedKey)
+	_ = conn.Send(HSET, DeviceScopeCollectionIntervalAction, scope.IntervalAction, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "device scope creation failed", err)
+	}
+
+	return scope, edgeXerr
+}
+
+// allDeviceScopes queries device scopes by offset and limit, the latest created first
+func allDeviceScopes(conn redis.Conn, offset, limit int) (result []devicescopes.DeviceScope, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, DeviceScopeCollection, offset, end)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	result = make([]devicescopes.DeviceScope, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &result[i])
+		if err != nil {
+			return []devicescopes.DeviceScope{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "device scope format parsing failed from the database", err)
+		}
+	}
+	return result, nil
+}
+
+// deviceScopeByIntervalAction queries the device scope of the named interval action
+func deviceScopeByIntervalAction(conn redis.Conn, name string) (scope devicescopes.DeviceScope, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, DeviceScopeCollectionIntervalAction, name, &scope)
+	if edgeXerr != nil {
+		return scope, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// deleteDeviceScopeByIntervalAction deletes the device scope of the named interval action
+func deleteDeviceScopeByIntervalAction(conn redis.Conn, name string) errors.EdgeX {
+	scope, edgeXerr := deviceScopeByIntervalAction(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	storedKey := deviceScopeStoredKey(scope.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, DeviceScopeCollection, storedKey)
+	_ = conn.Send(HDEL, DeviceScopeCollectionIntervalAction, scope.IntervalAction)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device scope deletion failed", err)
+	}
+	return nil
+}
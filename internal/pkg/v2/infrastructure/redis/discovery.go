@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/discovery"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	DiscoveryRecordCollection                     = "md|dsc"
+	DiscoveryRecordCollectionProvisionWatcherName = DiscoveryRecordCollection + DBKeySeparator + "provisionwatcher" + DBKeySeparator + v2.Name
+)
+
+// discoveryRecordStoredKey return the discovery record's stored key which combines the collection name and object id
+func discoveryRecordStoredKey(id string) string {
+	return CreateKey(DiscoveryRecordCollection, id)
+}
+
+// addDiscoveryRecord adds a new discovery record into DB, scored by its creation time
+func addDiscoveryRecord(conn redis.Conn, r discovery.Record) (discovery.Record, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, discoveryRecordStoredKey(r.Id))
+	if edgeXerr != nil {
+		return r, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return r, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("discovery record id %s already exists", r.Id), edgeXerr)
+	}
+
+	if r.Created == 0 {
+		r.Created = common.MakeTimestamp()
+	}
+
+	m, err := json.Marshal(r)
+	if err != nil {
+		return r, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal discovery record for Redis persistence", err)
+	}
+
+	redisKey := discoveryRecordStoredKey(r.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, redisKey, m)
+	_ = conn.Send(ZADD, DiscoveryRecordCollection, r.Created, redisKey)
+	_ = conn.Send(ZADD, CreateKey(DiscoveryRecordCollectionProvisionWatcherName, r.ProvisionWatcherName), r.Created, redisKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "discovery record creation failed", err)
+	}
+
+	return r, edgeXerr
+}
+
+// unmarshalDiscoveryRecords converts the stored objects into discovery records
+func unmarshalDiscoveryRecords(objects [][]byte) ([]discovery.Record, errors.EdgeX) {
+	result := make([]discovery.Record, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &result[i])
+		if err != nil {
+			return []discovery.Record{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "discovery record format parsing failed from the database", err)
+		}
+	}
+	return result, nil
+}
+
+// discoveryRecordsByRevRange queries the discovery records of the key sorted set by offset and limit, the latest created first
+func discoveryRecordsByRevRange(conn redis.Conn, key string, offset int, limit int) ([]discovery.Record, errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, key, offset, end)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return unmarshalDiscoveryRecords(objects)
+}
+
+// allDiscoveryRecords queries discovery records by offset and limit, the latest created first
+func allDiscoveryRecords(conn redis.Conn, offset int, limit int) ([]discovery.Record, errors.EdgeX) {
+	return discoveryRecordsByRevRange(conn, DiscoveryRecordCollection, offset, limit)
+}
+
+// discoveryRecordsByProvisionWatcherName queries the discovery records of a provision watcher by offset and limit,
+// the latest created first
+func discoveryRecordsByProvisionWatcherName(conn redis.Conn, offset int, limit int, name string) ([]discovery.Record, errors.EdgeX) {
+	return discoveryRecordsByRevRange(conn, CreateKey(DiscoveryRecordCollectionProvisionWatcherName, name), offset, limit)
+}
+
+// discoveryRecordCountByProvisionWatcherName counts the discovery records of a provision watcher created since the
+// given timestamp
+func discoveryRecordCountByProvisionWatcherName(conn redis.Conn, name string, since int64) (uint32, errors.EdgeX) {
+	key := CreateKey(DiscoveryRecordCollectionProvisionWatcherName, name)
+	count, err := redis.Int(conn.Do(ZCOUNT, key, since, InfiniteMax))
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to count the discovery records of %s", key), err)
+	}
+	return uint32(count), nil
+}
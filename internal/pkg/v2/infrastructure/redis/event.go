@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
@@ -40,26 +41,52 @@ func (c *Client) asyncDeleteEventsByIds(eventIds []string) {
 		return
 	}
 
-	// iterate each events for deletion in batch
-	queriesInQueue := 0
-	e := models.Event{}
-	_ = conn.Send(MULTI)
-	for i, event := range events {
+	// the partitions of the devices are looked up before the transactions, which can't read
+	devices := make(map[string]devicePartitioning)
+	touched := make(map[partitionRef]bool)
+	var storedEvents []models.Event
+	var eventPartitions []string
+	for _, event := range events {
+		e := models.Event{}
 		err := json.Unmarshal(event, &e)
 		if err != nil {
 			c.loggingClient.Error(fmt.Sprintf("unable to marshal event.  Err: %s", err.Error()))
 			continue
 		}
+		d, ok := devices[e.DeviceName]
+		if !ok {
+			d, edgeXerr = loadDevicePartitioning(conn, e.DeviceName)
+			if edgeXerr != nil {
+				c.loggingClient.Error(fmt.Sprintf("unable to load the partitions of device %s.  Err: %s", e.DeviceName, edgeXerr.DebugMessages()))
+				continue
+			}
+			devices[e.DeviceName] = d
+		}
+		partition := ""
+		if start, ok := d.partitionOf(e.Created); ok {
+			partition = partitionKey(e.DeviceName, start)
+			touched[partitionRef{deviceName: e.DeviceName, start: start}] = true
+		}
+		storedEvents = append(storedEvents, e)
+		eventPartitions = append(eventPartitions, partition)
+	}
+
+	// iterate each events for deletion in batch
+	queriesInQueue := 0
+	_ = conn.Send(MULTI)
+	for i, e := range storedEvents {
 		storedKey := eventStoredKey(e.Id)
 		_ = conn.Send(UNLINK, storedKey)
 		_ = conn.Send(UNLINK, CreateKey(EventsCollectionReadings, e.Id))
 		_ = conn.Send(ZREM, EventsCollection, storedKey)
 		_ = conn.Send(ZREM, EventsCollectionCreated, storedKey)
-		_ = conn.Send(ZREM, CreateKey(EventsCollectionDeviceName, e.DeviceName), storedKey)
+		sendDeleteDeviceEventIndexCmd(conn, storedKey, e.DeviceName, eventPartitions[i])
+		sendDeleteEventTagIndexCmd(conn, storedKey, e.Tags)
+		sendDeleteEventExpiryCmd(conn, storedKey)
 		queriesInQueue++
 
 		if queriesInQueue >= c.BatchSize {
-			_, err = conn.Do(EXEC)
+			_, err := conn.Do(EXEC)
 			if err != nil {
 				c.loggingClient.Error(fmt.Sprintf("unable to execute batch event deletion.  Err: %s", err.Error()))
 				continue
@@ -67,7 +94,7 @@ func (c *Client) asyncDeleteEventsByIds(eventIds []string) {
 			// reset queriesInQueue to zero if EXEC is successfully executed without error
 			queriesInQueue = 0
 			// rerun another transaction when event iteration is not finished
-			if i < len(events)-1 {
+			if i < len(storedEvents)-1 {
 				_ = conn.Send(MULTI)
 			}
 		}
@@ -79,6 +106,12 @@ func (c *Client) asyncDeleteEventsByIds(eventIds []string) {
 			c.loggingClient.Error(fmt.Sprintf("unable to execute batch event deletion.  Err: %s", err.Error()))
 		}
 	}
+
+	for p := range touched {
+		if edgeXerr := pruneEventPartition(conn, p.deviceName, p.start); edgeXerr != nil {
+			c.loggingClient.Error(fmt.Sprintf("unable to prune partition %s.  Err: %s", partitionKey(p.deviceName, p.start), edgeXerr.DebugMessages()))
+		}
+	}
 }
 
 // DeleteEventsByDeviceName deletes specific device's events and corresponding readings.  This function is implemented to starts up
@@ -87,9 +120,18 @@ func (c *Client) DeleteEventsByDeviceName(deviceName string) (edgeXerr errors.Ed
 	conn := c.Pool.Get()
 	defer conn.Close()
 
-	eventIds, readingIds, err := getEventReadingIdsByKeyScoreRange(conn, CreateKey(EventsCollectionDeviceName, deviceName), GreaterThanZero, InfiniteMax)
-	if err != nil {
-		return errors.NewCommonEdgeXWrapper(err)
+	d, edgeXerr := loadDevicePartitioning(conn, deviceName)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	var eventIds, readingIds []string
+	for _, key := range d.indexKeys() {
+		keyEventIds, keyReadingIds, err := getEventReadingIdsByKeyScoreRange(conn, key, GreaterThanZero, InfiniteMax)
+		if err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+		eventIds = append(eventIds, keyEventIds...)
+		readingIds = append(readingIds, keyReadingIds...)
 	}
 	c.loggingClient.Debug(fmt.Sprintf("Prepare to delete %v readings", len(readingIds)))
 	go c.asyncDeleteReadingsByIds(readingIds)
@@ -125,7 +167,7 @@ func eventStoredKey(id string) string {
 	return CreateKey(EventsCollection, id)
 }
 
-func addEvent(conn redis.Conn, e models.Event) (addedEvent models.Event, edgeXerr errors.EdgeX) {
+func addEvent(conn redis.Conn, e models.Event, ttl int64, p eventPartitioning) (addedEvent models.Event, edgeXerr errors.EdgeX) {
 	// query Event by Id first to avoid the Id conflict
 	_, edgeXerr = eventById(conn, e.Id)
 	if errors.Kind(edgeXerr) != errors.KindEntityDoesNotExist {
@@ -151,13 +193,24 @@ func addEvent(conn redis.Conn, e models.Event) (addedEvent models.Event, edgeXer
 		return addedEvent, errors.NewCommonEdgeX(errors.KindContractInvalid, "event parsing failed", err)
 	}
 
+	deviceIndex, start, partitioned, edgeXerr := routeNewEvent(conn, p, e.DeviceName, e.Created)
+	if edgeXerr != nil {
+		return addedEvent, edgeXerr
+	}
+
 	storedKey := eventStoredKey(e.Id)
 	_ = conn.Send(MULTI)
 	// use the SET command to save event as blob
 	_ = conn.Send(SET, storedKey, m)
 	_ = conn.Send(ZADD, EventsCollection, e.Created, storedKey)
 	_ = conn.Send(ZADD, EventsCollectionCreated, e.Created, storedKey)
-	_ = conn.Send(ZADD, CreateKey(EventsCollectionDeviceName, e.DeviceName), e.Created, storedKey)
+	_ = conn.Send(ZADD, deviceIndex, e.Created, storedKey)
+	if partitioned {
+		sendAddPartitionCmd(conn, e.DeviceName, start)
+	}
+	if ttl > 0 {
+		sendAddEventExpiryCmd(conn, storedKey, e.Created+ttl)
+	}
 
 	// add reading ids as sorted set under each event id
 	// sort by the order provided by device service
@@ -203,13 +256,25 @@ func deleteEventById(conn redis.Conn, id string) (edgeXerr errors.EdgeX) {
 		}
 	}
 
+	d, edgeXerr := loadDeviceRouting(conn, e.DeviceName, e.Created)
+	if edgeXerr != nil {
+		return edgeXerr
+	}
+	start, partitioned := d.partitionOf(e.Created)
+	partition := ""
+	if partitioned {
+		partition = partitionKey(e.DeviceName, start)
+	}
+
 	storedKey := eventStoredKey(e.Id)
 	_ = conn.Send(MULTI)
 	_ = conn.Send(UNLINK, storedKey)
 	_ = conn.Send(UNLINK, CreateKey(EventsCollectionReadings, e.Id))
 	_ = conn.Send(ZREM, EventsCollection, storedKey)
 	_ = conn.Send(ZREM, EventsCollectionCreated, storedKey)
-	_ = conn.Send(ZREM, CreateKey(EventsCollectionDeviceName, e.DeviceName), storedKey)
+	sendDeleteDeviceEventIndexCmd(conn, storedKey, e.DeviceName, partition)
+	sendDeleteEventTagIndexCmd(conn, storedKey, e.Tags)
+	sendDeleteEventExpiryCmd(conn, storedKey)
 
 	res, err := redis.Values(conn.Do(EXEC))
 	if err != nil {
@@ -220,7 +285,41 @@ func deleteEventById(conn redis.Conn, id string) (edgeXerr errors.EdgeX) {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, "event delete failed", redis.ErrNil)
 	}
 
-	return edgeXerr
+	if partitioned {
+		return pruneEventPartition(conn, e.DeviceName, start)
+	}
+	return nil
+}
+
+// deleteEventsByKeyScoreRange deletes, with their readings, up to limit events of the index keys whose scores are in
+// the range [min, max], the earliest first in each key, and returns the number of events deleted. The stale entries of
+// the events deleted already are removed and counted as well, so that the number is lower than limit once no event of
+// the range is left.
+func deleteEventsByKeyScoreRange(conn redis.Conn, keys []string, min interface{}, max interface{}, limit int) (uint32, errors.EdgeX) {
+	var deleted uint32
+	for _, key := range keys {
+		if int(deleted) >= limit {
+			break
+		}
+		// ZRANGEBYSCORE key min max LIMIT offset count
+		storedKeys, err := redis.Strings(conn.Do(ZRANGEBYSCORE, key, min, max, LIMIT, 0, limit-int(deleted)))
+		if err != nil {
+			return deleted, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("retrieve event ids by key %s failed", key), err)
+		}
+		for _, storedKey := range storedKeys {
+			id := strings.TrimPrefix(storedKey, EventsCollection+DBKeySeparator)
+			edgeXerr := deleteEventById(conn, id)
+			if errors.Kind(edgeXerr) == errors.KindEntityDoesNotExist {
+				if _, err := conn.Do(ZREM, key, storedKey); err != nil {
+					return deleted, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to remove the stale index of event %s", id), err)
+				}
+			} else if edgeXerr != nil {
+				return deleted, edgeXerr
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
 }
 
 func getEventReadingIdsByKeyScoreRange(conn redis.Conn, key string, min string, max string) (eventIds []string, readingIds []string, edgeXerr errors.EdgeX) {
@@ -273,13 +372,9 @@ func (c *Client) allEvents(conn redis.Conn, offset int, limit int) (events []mod
 	return convertObjectsToEvents(conn, objects)
 }
 
-// eventsByDeviceName query events by offset, limit and device name
+// eventsByDeviceName query events by offset, limit and device name, across the partitions of the device
 func eventsByDeviceName(conn redis.Conn, offset int, limit int, name string) (events []models.Event, edgeXerr errors.EdgeX) {
-	end := offset + limit - 1
-	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
-		end = limit
-	}
-	objects, err := getObjectsByRevRange(conn, CreateKey(EventsCollectionDeviceName, name), offset, end)
+	objects, err := deviceEventObjects(conn, name, offset, limit)
 	if err != nil {
 		return events, errors.NewCommonEdgeXWrapper(err)
 	}
@@ -0,0 +1,58 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// EventsCollectionExpiry is the sorted set of the stored keys of the events that expire, scored by their expiry
+// timestamp. The expiry is set when the event is written, so changing the TTL only applies to the next events.
+const EventsCollectionExpiry = EventsCollection + DBKeySeparator + "expiry"
+
+// sendAddEventExpiryCmd sends the command setting the expiry timestamp of the event of storedKey, within the
+// transaction adding the event so that no event is stored without its expiry
+func sendAddEventExpiryCmd(conn redis.Conn, storedKey string, expireAt int64) {
+	_ = conn.Send(ZADD, EventsCollectionExpiry, expireAt, storedKey)
+}
+
+// sendDeleteEventExpiryCmd sends the command removing the expiry of the event of storedKey, within the transaction
+// deleting the event
+func sendDeleteEventExpiryCmd(conn redis.Conn, storedKey string) {
+	_ = conn.Send(ZREM, EventsCollectionExpiry, storedKey)
+}
+
+// deleteExpiredEvents deletes, with their readings, up to limit events expired at the now timestamp, the earliest
+// expired first, and returns the number of events deleted
+func deleteExpiredEvents(conn redis.Conn, now int64, limit int) (uint32, errors.EdgeX) {
+	// ZRANGEBYSCORE key min max LIMIT offset count
+	storedKeys, err := redis.Strings(conn.Do(ZRANGEBYSCORE, EventsCollectionExpiry, InfiniteMin, now, LIMIT, 0, limit))
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "query expired events from database failed", err)
+	}
+
+	var deleted uint32
+	for _, storedKey := range storedKeys {
+		id := strings.TrimPrefix(storedKey, EventsCollection+DBKeySeparator)
+		edgeXerr := deleteEventById(conn, id)
+		if errors.Kind(edgeXerr) == errors.KindEntityDoesNotExist {
+			// the event is gone already, only its expiry is left to remove
+			if _, err := conn.Do(ZREM, EventsCollectionExpiry, storedKey); err != nil {
+				return deleted, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to remove the expiry of event %s", id), err)
+			}
+			continue
+		} else if edgeXerr != nil {
+			return deleted, edgeXerr
+		}
+		deleted++
+	}
+	return deleted, nil
+}
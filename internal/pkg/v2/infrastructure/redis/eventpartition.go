@@ -0,0 +1,393 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/partitions"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// EventsCollectionPartition prefixes the sorted sets of the stored keys of the events of a hot device created
+	// within a partition, keyed by "<start>:<device name>" so that the device name comes last whatever it holds
+	EventsCollectionPartition = EventsCollection + DBKeySeparator + "partition"
+	// EventsCollectionPartitionList prefixes the sorted set of the starts of the partitions of each hot device
+	EventsCollectionPartitionList = EventsCollectionPartition + DBKeySeparator + "list"
+	// EventsCollectionHotDevices is the sorted set of the hot devices, scored by the timestamp of their first
+	// partitioned event. A device stays hot once promoted.
+	EventsCollectionHotDevices = EventsCollectionPartition + DBKeySeparator + "devices"
+)
+
+// defaultPartitionSpan is the span of the partitions of the devices promoted before the partitioning was disabled
+const defaultPartitionSpan = time.Hour
+
+// eventPartitioning is how the event indexes of the devices are partitioned
+type eventPartitioning struct {
+	hotThreshold int
+	// span is in milliseconds
+	span int64
+}
+
+// devicePartitioning is the partitioning of the event index of a device. The partitions are listed by their
+// starts, ascending, the last one being the newest.
+type devicePartitioning struct {
+	deviceName string
+	hot        bool
+	promotedAt int64
+	starts     []int64
+}
+
+// partitionRef identifies a partition of a device
+type partitionRef struct {
+	deviceName string
+	start      int64
+}
+
+func partitionKey(deviceName string, start int64) string {
+	return CreateKey(EventsCollectionPartition, strconv.FormatInt(start, 10), deviceName)
+}
+
+// coldKey returns the key of the unpartitioned index of the device
+func (d devicePartitioning) coldKey() string {
+	return CreateKey(EventsCollectionDeviceName, d.deviceName)
+}
+
+// partitionOf returns the start of the listed partition holding the events created at created, if any
+func (d devicePartitioning) partitionOf(created int64) (int64, bool) {
+	if !d.hot || created < d.promotedAt {
+		return 0, false
+	}
+	i := sort.Search(len(d.starts), func(i int) bool { return d.starts[i] > created })
+	if i == 0 {
+		return 0, false
+	}
+	return d.starts[i-1], true
+}
+
+// route returns the start of the partition indexing a new event created at created, which is either the listed
+// partition holding it or a new one when it is past the span of the newest partition, and false when the event is
+// indexed by the unpartitioned index
+func (d devicePartitioning) route(created int64, span int64) (int64, bool) {
+	if !d.hot || created < d.promotedAt {
+		return 0, false
+	}
+	start, ok := d.partitionOf(created)
+	if ok && (start != d.starts[len(d.starts)-1] || created < start+span) {
+		return start, true
+	}
+	return partitions.Start(created, d.promotedAt, span), true
+}
+
+// indexKeys returns the keys of the indexes of the events of the device, the newest partition first and the
+// unpartitioned index last
+func (d devicePartitioning) indexKeys() []string {
+	keys := make([]string, 0, len(d.starts)+1)
+	for i := len(d.starts) - 1; i >= 0; i-- {
+		keys = append(keys, partitionKey(d.deviceName, d.starts[i]))
+	}
+	return append(keys, d.coldKey())
+}
+
+// loadDevicePartitioning returns the partitioning of the event index of the device with all its partitions
+func loadDevicePartitioning(conn redis.Conn, deviceName string) (devicePartitioning, errors.EdgeX) {
+	d, edgeXerr := loadHotDevice(conn, deviceName)
+	if edgeXerr != nil || !d.hot {
+		return d, edgeXerr
+	}
+	d.starts, edgeXerr = partitionStarts(conn, deviceName, ZRANGE, 0, -1)
+	return d, edgeXerr
+}
+
+// loadDeviceRouting returns the partitioning of the event index of the device with only the partitions route needs
+// for an event created at created: the one which may hold it and the newest one
+func loadDeviceRouting(conn redis.Conn, deviceName string, created int64) (devicePartitioning, errors.EdgeX) {
+	d, edgeXerr := loadHotDevice(conn, deviceName)
+	if edgeXerr != nil || !d.hot || created < d.promotedAt {
+		return d, edgeXerr
+	}
+	// ZREVRANGEBYSCORE key max min LIMIT offset count
+	previous, edgeXerr := partitionStarts(conn, deviceName, ZREVRANGEBYSCORE, created, InfiniteMin, LIMIT, 0, 1)
+	if edgeXerr != nil {
+		return d, edgeXerr
+	}
+	newest, edgeXerr := partitionStarts(conn, deviceName, ZREVRANGE, 0, 0)
+	if edgeXerr != nil {
+		return d, edgeXerr
+	}
+	d.starts = previous
+	if len(newest) > 0 && (len(previous) == 0 || newest[0] != previous[0]) {
+		d.starts = append(d.starts, newest[0])
+	}
+	return d, nil
+}
+
+func loadHotDevice(conn redis.Conn, deviceName string) (devicePartitioning, errors.EdgeX) {
+	d := devicePartitioning{deviceName: deviceName}
+	promotedAt, err := redis.Int64(conn.Do(ZSCORE, EventsCollectionHotDevices, deviceName))
+	if err == redis.ErrNil {
+		return d, nil
+	} else if err != nil {
+		return d, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to check whether device %s is hot", deviceName), err)
+	}
+	d.hot = true
+	d.promotedAt = promotedAt
+	return d, nil
+}
+
+func partitionStarts(conn redis.Conn, deviceName string, command string, args ...interface{}) ([]int64, errors.EdgeX) {
+	values, err := redis.Int64s(conn.Do(command, append([]interface{}{CreateKey(EventsCollectionPartitionList, deviceName)}, args...)...))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to list the partitions of device %s", deviceName), err)
+	}
+	return values, nil
+}
+
+// routeNewEvent returns the key of the index of a new event of the device, and the start of its partition when
+// partitioned. A device with more than the hot threshold of events is promoted first, its events created from then
+// on being partitioned.
+func routeNewEvent(conn redis.Conn, p eventPartitioning, deviceName string, created int64) (string, int64, bool, errors.EdgeX) {
+	d, edgeXerr := loadDeviceRouting(conn, deviceName, created)
+	if edgeXerr != nil {
+		return "", 0, false, edgeXerr
+	}
+	if !d.hot && p.hotThreshold > 0 {
+		if d, edgeXerr = promoteDevice(conn, p, d); edgeXerr != nil {
+			return "", 0, false, edgeXerr
+		}
+	}
+	if start, ok := d.route(created, p.span); ok {
+		return partitionKey(deviceName, start), start, true, nil
+	}
+	return d.coldKey(), 0, false, nil
+}
+
+// promoteDevice makes the device hot when it has more than the hot threshold of events, its events created after
+// the latest one indexed being partitioned, so that the partitions never overlap the unpartitioned index
+func promoteDevice(conn redis.Conn, p eventPartitioning, d devicePartitioning) (devicePartitioning, errors.EdgeX) {
+	count, edgeXerr := getMemberNumber(conn, ZCARD, d.coldKey())
+	if edgeXerr != nil || int(count) < p.hotThreshold {
+		return d, edgeXerr
+	}
+	latest, err := redis.Strings(conn.Do(ZREVRANGE, d.coldKey(), 0, 0, WITHSCORES))
+	if err != nil {
+		return d, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to get the latest event of device %s", d.deviceName), err)
+	} else if len(latest) < 2 {
+		// the events of the device were deleted meanwhile
+		return d, nil
+	}
+	latestCreated, err := strconv.ParseInt(latest[1], 10, 64)
+	if err != nil {
+		return d, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("invalid score of the latest event of device %s", d.deviceName), err)
+	}
+	// the first promotion wins when the device is promoted concurrently
+	if _, err := conn.Do(ZADD, EventsCollectionHotDevices, NX, latestCreated+1, d.deviceName); err != nil {
+		return d, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to promote device %s", d.deviceName), err)
+	}
+	return loadHotDevice(conn, d.deviceName)
+}
+
+// sendAddPartitionCmd sends the command listing the partition of start, within the transaction adding an event to
+// it, so that the partition is listed again if pruned meanwhile
+func sendAddPartitionCmd(conn redis.Conn, deviceName string, start int64) {
+	_ = conn.Send(ZADD, CreateKey(EventsCollectionPartitionList, deviceName), start, start)
+}
+
+// sendDeleteDeviceEventIndexCmd sends the commands removing the event of storedKey from the index of its device,
+// which is the unpartitioned index unless a partition key is given. The event is removed from the unpartitioned
+// index anyway, as a concurrent promotion of the device may leave a recent event there.
+func sendDeleteDeviceEventIndexCmd(conn redis.Conn, storedKey string, deviceName string, partition string) {
+	_ = conn.Send(ZREM, CreateKey(EventsCollectionDeviceName, deviceName), storedKey)
+	if partition != "" {
+		_ = conn.Send(ZREM, partition, storedKey)
+	}
+}
+
+// pruneEventPartition unlists the partition of the device starting at start once it is empty. The partition is
+// watched so that an event added to it concurrently, which lists it again, isn't lost.
+func pruneEventPartition(conn redis.Conn, deviceName string, start int64) errors.EdgeX {
+	key := partitionKey(deviceName, start)
+	if _, err := conn.Do(WATCH, key); err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to watch partition %s", key), err)
+	}
+	count, edgeXerr := getMemberNumber(conn, ZCARD, key)
+	if edgeXerr != nil || count > 0 {
+		_, _ = conn.Do(UNWATCH)
+		return edgeXerr
+	}
+	_ = conn.Send(MULTI)
+	_ = conn.Send(ZREM, CreateKey(EventsCollectionPartitionList, deviceName), start)
+	// a nil reply tells that the partition was written meanwhile and is left listed
+	if _, err := conn.Do(EXEC); err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to prune partition %s", key), err)
+	}
+	return nil
+}
+
+// indexRange is a range of positions, the most recent first, of the index of position index in a list of indexes
+type indexRange struct {
+	index int
+	start int
+	end   int
+}
+
+// pageIndexes returns the ranges of the indexes, of counts members and read in turn, holding the page starting at
+// offset with up to limit members, or all of them when limit is -1
+func pageIndexes(counts []int, offset int, limit int) ([]indexRange, errors.EdgeX) {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	if total == 0 {
+		return nil, nil
+	} else if offset > total {
+		return nil, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("query objects bounds out of range. length:%v", total), nil)
+	}
+
+	var ranges []indexRange
+	for i, count := range counts {
+		if limit == 0 {
+			break
+		}
+		if offset >= count {
+			offset -= count
+			continue
+		}
+		end := count - 1
+		if limit > 0 && offset+limit-1 < end {
+			end = offset + limit - 1
+		}
+		ranges = append(ranges, indexRange{index: i, start: offset, end: end})
+		if limit > 0 {
+			limit -= end - offset + 1
+		}
+		offset = 0
+	}
+	return ranges, nil
+}
+
+// countMembers returns the number of members of each key, counted in a pipeline
+func countMembers(conn redis.Conn, keys []string) ([]int, errors.EdgeX) {
+	for _, key := range keys {
+		_ = conn.Send(ZCARD, key)
+	}
+	if err := conn.Flush(); err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to count the indexed events", err)
+	}
+	counts := make([]int, len(keys))
+	for i, key := range keys {
+		count, err := redis.Int(conn.Receive())
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to count the members of %s", key), err)
+		}
+		counts[i] = count
+	}
+	return counts, nil
+}
+
+// deviceEventObjects returns the stored events of the device by offset and limit, the most recent first
+func deviceEventObjects(conn redis.Conn, deviceName string, offset int, limit int) ([][]byte, errors.EdgeX) {
+	d, edgeXerr := loadDevicePartitioning(conn, deviceName)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	keys := d.indexKeys()
+	counts, edgeXerr := countMembers(conn, keys)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	ranges, edgeXerr := pageIndexes(counts, offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+
+	var objects [][]byte
+	for _, r := range ranges {
+		ids, err := redis.Values(conn.Do(ZREVRANGE, keys[r.index], r.start, r.end))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query object ids from database failed", err)
+		}
+		rangeObjects, edgeXerr := getObjectsByIds(conn, ids)
+		if edgeXerr != nil {
+			return nil, edgeXerr
+		}
+		objects = append(objects, rangeObjects...)
+	}
+	return objects, nil
+}
+
+// deviceEventCountByScoreRange returns the number of events of the device created within [min, max]
+func deviceEventCountByScoreRange(conn redis.Conn, deviceName string, min interface{}, max interface{}) (uint32, errors.EdgeX) {
+	d, edgeXerr := loadDevicePartitioning(conn, deviceName)
+	if edgeXerr != nil {
+		return 0, edgeXerr
+	}
+	var total uint32
+	for _, key := range d.indexKeys() {
+		count, edgeXerr := getMemberCountByScoreRange(conn, key, min, max)
+		if edgeXerr != nil {
+			return 0, edgeXerr
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// devicePartitionStats returns how the events of the device are indexed
+func devicePartitionStats(conn redis.Conn, deviceName string) (partitions.DevicePartitions, errors.EdgeX) {
+	d, edgeXerr := loadDevicePartitioning(conn, deviceName)
+	if edgeXerr != nil {
+		return partitions.DevicePartitions{}, edgeXerr
+	}
+	keys := d.indexKeys()
+	counts, edgeXerr := countMembers(conn, keys)
+	if edgeXerr != nil {
+		return partitions.DevicePartitions{}, edgeXerr
+	}
+
+	stats := partitions.DevicePartitions{
+		DeviceName: deviceName,
+		Hot:        d.hot,
+		PromotedAt: d.promotedAt,
+		ColdEvents: uint32(counts[len(counts)-1]),
+	}
+	for i := len(d.starts) - 1; i >= 0; i-- {
+		stats.Partitions = append(stats.Partitions, partitions.Partition{
+			Start:  d.starts[i],
+			Events: uint32(counts[len(d.starts)-1-i]),
+		})
+	}
+	return stats, nil
+}
+
+// hotDevicePartitionStats returns how the events of the hot devices are indexed by offset and limit, the devices
+// promoted first first
+func hotDevicePartitionStats(conn redis.Conn, offset int, limit int) ([]partitions.DevicePartitions, errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	deviceNames, err := redis.Strings(conn.Do(ZRANGE, EventsCollectionHotDevices, offset, end))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "failed to list the hot devices", err)
+	}
+	stats := make([]partitions.DevicePartitions, 0, len(deviceNames))
+	for _, deviceName := range deviceNames {
+		deviceStats, edgeXerr := devicePartitionStats(conn, deviceName)
+		if edgeXerr != nil {
+			return nil, edgeXerr
+		}
+		stats = append(stats, deviceStats)
+	}
+	return stats, nil
+}
@@ -0,0 +1,100 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevicePartitioningRoute(t *testing.T) {
+	d := devicePartitioning{deviceName: "device", hot: true, promotedAt: 1500, starts: []int64{1500, 2000, 3000}}
+
+	tests := []struct {
+		name        string
+		created     int64
+		start       int64
+		partitioned bool
+	}{
+		{"before promotion", 1499, 0, false},
+		{"first partition", 1999, 1500, true},
+		{"partition before a gap", 2500, 2000, true},
+		{"newest partition", 3999, 3000, true},
+		{"past the newest partition", 4200, 4000, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, partitioned := d.route(tt.created, 1000)
+			assert.Equal(t, tt.partitioned, partitioned)
+			assert.Equal(t, tt.start, start)
+		})
+	}
+
+	_, partitioned := devicePartitioning{deviceName: "device"}.route(4200, 1000)
+	assert.False(t, partitioned, "The events of a cold device should not be partitioned")
+	start, partitioned := devicePartitioning{deviceName: "device", hot: true, promotedAt: 1500}.route(1700, 1000)
+	assert.True(t, partitioned)
+	assert.Equal(t, int64(1500), start, "The first partition should start at the promotion")
+}
+
+func TestDevicePartitioningPartitionOf(t *testing.T) {
+	d := devicePartitioning{deviceName: "device", hot: true, promotedAt: 1500, starts: []int64{2000, 3000}}
+
+	_, ok := d.partitionOf(1700)
+	assert.False(t, ok, "An event created before the first listed partition should not be in a partition")
+	start, ok := d.partitionOf(2000)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2000), start)
+	start, ok = d.partitionOf(9000)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3000), start)
+}
+
+func TestDevicePartitioningIndexKeys(t *testing.T) {
+	d := devicePartitioning{deviceName: "device", hot: true, starts: []int64{1000, 2000}}
+	assert.Equal(t, []string{
+		EventsCollectionPartition + ":2000:device",
+		EventsCollectionPartition + ":1000:device",
+		EventsCollectionDeviceName + ":device",
+	}, d.indexKeys())
+	assert.Equal(t, []string{EventsCollectionDeviceName + ":device"}, devicePartitioning{deviceName: "device"}.indexKeys())
+}
+
+func TestPageIndexes(t *testing.T) {
+	counts := []int{3, 0, 2, 4}
+
+	tests := []struct {
+		name     string
+		offset   int
+		limit    int
+		expected []indexRange
+	}{
+		{"first index", 0, 2, []indexRange{{index: 0, start: 0, end: 1}}},
+		{"across indexes", 2, 3, []indexRange{{index: 0, start: 2, end: 2}, {index: 2, start: 0, end: 1}}},
+		{"skipped indexes", 5, 2, []indexRange{{index: 3, start: 0, end: 1}}},
+		{"all", 1, -1, []indexRange{{index: 0, start: 1, end: 2}, {index: 2, start: 0, end: 1}, {index: 3, start: 0, end: 3}}},
+		{"past the last member", 9, 5, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, err := pageIndexes(counts, tt.offset, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ranges)
+		})
+	}
+
+	ranges, err := pageIndexes([]int{0, 0}, 5, 1)
+	require.NoError(t, err)
+	assert.Empty(t, ranges)
+
+	_, err = pageIndexes(counts, 10, 1)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindRangeNotSatisfiable, errors.Kind(err))
+}
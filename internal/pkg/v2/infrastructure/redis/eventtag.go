@@ -0,0 +1,183 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// EventsCollectionTagName prefixes the sorted sets of the stored keys of the events carrying each indexed tag
+	EventsCollectionTagName = EventsCollection + DBKeySeparator + "tag" + DBKeySeparator + "name"
+	// EventsCollectionTagValue prefixes the sorted sets of the stored keys of the events carrying each value of each
+	// indexed tag
+	EventsCollectionTagValue = EventsCollection + DBKeySeparator + "tag" + DBKeySeparator + "value"
+)
+
+// sendAddEventTagIndexCmd sends redis commands adding the event with storedKey to the index of each of its tags
+func sendAddEventTagIndexCmd(conn redis.Conn, storedKey string, created int64, eventTags map[string]string) {
+	for name, value := range eventTags {
+		_ = conn.Send(ZADD, CreateKey(EventsCollectionTagName, name), created, storedKey)
+		_ = conn.Send(ZADD, CreateKey(EventsCollectionTagValue, name, value), created, storedKey)
+	}
+}
+
+// sendDeleteEventTagIndexCmd sends redis commands removing the event with storedKey from the index of each of its
+// tags, whether the tag is indexed or not
+func sendDeleteEventTagIndexCmd(conn redis.Conn, storedKey string, eventTags map[string]string) {
+	for name, value := range eventTags {
+		_ = conn.Send(ZREM, CreateKey(EventsCollectionTagName, name), storedKey)
+		_ = conn.Send(ZREM, CreateKey(EventsCollectionTagValue, name, value), storedKey)
+	}
+}
+
+// addEventTagIndex indexes the event with the given id by the given tags
+func addEventTagIndex(conn redis.Conn, id string, created int64, eventTags map[string]string) errors.EdgeX {
+	if len(eventTags) == 0 {
+		return nil
+	}
+
+	_ = conn.Send(MULTI)
+	sendAddEventTagIndexCmd(conn, eventStoredKey(id), created, eventTags)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "event tag index creation failed", err)
+	}
+	return nil
+}
+
+// eventTagIndexKeys returns the index keys of the events matching the terms, and those of the events that must not
+func eventTagIndexKeys(terms []tags.Term) (included []string, excluded []string) {
+	for _, t := range terms {
+		switch t.Operator {
+		case tags.Equal:
+			included = append(included, CreateKey(EventsCollectionTagValue, t.Name, t.Value))
+		case tags.NotEqual:
+			excluded = append(excluded, CreateKey(EventsCollectionTagValue, t.Name, t.Value))
+		default:
+			included = append(included, CreateKey(EventsCollectionTagName, t.Name))
+		}
+	}
+	if len(included) == 0 {
+		included = append(included, EventsCollection)
+	}
+	return included, excluded
+}
+
+// eventKeysByTags returns the stored keys of the events matching every term, most recent first
+func eventKeysByTags(conn redis.Conn, terms []tags.Term) ([]string, errors.EdgeX) {
+	included, excluded := eventTagIndexKeys(terms)
+
+	keysSlice := make([][]string, len(included))
+	for i, indexKey := range included {
+		keys, err := redis.Strings(conn.Do(ZREVRANGE, indexKey, 0, -1))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query event ids by %s from database failed", indexKey), err)
+		}
+		keysSlice[i] = keys
+	}
+	keys := common.FindCommonStrings(keysSlice...)
+	if len(excluded) == 0 {
+		return keys, nil
+	}
+
+	excludedKeys := make(map[string]bool)
+	for _, indexKey := range excluded {
+		members, err := redis.Strings(conn.Do(ZRANGE, indexKey, 0, -1))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query event ids by %s from database failed", indexKey), err)
+		}
+		for _, member := range members {
+			excludedKeys[member] = true
+		}
+	}
+	matching := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !excludedKeys[key] {
+			matching = append(matching, key)
+		}
+	}
+	return matching, nil
+}
+
+// pageStrings returns the page of values starting at offset with up to limit of them, or all of them when limit is -1
+func pageStrings(values []string, offset int, limit int) ([]string, errors.EdgeX) {
+	if len(values) == 0 {
+		return nil, nil
+	} else if offset > len(values) {
+		return nil, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("query objects bounds out of range. length:%v", len(values)), nil)
+	}
+	values = values[offset:]
+	if limit >= 0 && limit < len(values) {
+		values = values[:limit]
+	}
+	return values, nil
+}
+
+// eventsByTags query the events matching every term by offset and limit
+func eventsByTags(conn redis.Conn, terms []tags.Term, offset int, limit int) ([]models.Event, errors.EdgeX) {
+	keys, edgeXerr := eventKeysByTags(conn, terms)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	keys, edgeXerr = pageStrings(keys, offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	objects, edgeXerr := getObjectsByIds(conn, common.ConvertStringsToInterfaces(keys))
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	return convertObjectsToEvents(conn, objects)
+}
+
+// eventCountByTags returns the number of events matching every term
+func eventCountByTags(conn redis.Conn, terms []tags.Term) (uint32, errors.EdgeX) {
+	keys, edgeXerr := eventKeysByTags(conn, terms)
+	if edgeXerr != nil {
+		return 0, edgeXerr
+	}
+	return uint32(len(keys)), nil
+}
+
+// readingsByTags query the readings of the events matching every term by offset and limit. The readings are sorted
+// by event, most recent first, and then in the order of the event.
+func readingsByTags(conn redis.Conn, terms []tags.Term, offset int, limit int) ([]models.Reading, errors.EdgeX) {
+	keys, edgeXerr := eventKeysByTags(conn, terms)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+
+	var readingKeys []string
+	for _, key := range keys {
+		if limit >= 0 && len(readingKeys) >= offset+limit {
+			break
+		}
+		id := key[len(EventsCollection)+len(DBKeySeparator):]
+		eventReadingKeys, err := redis.Strings(conn.Do(ZRANGE, CreateKey(EventsCollectionReadings, id), 0, -1))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("retrieve all reading Ids of event %s failed", id), err)
+		}
+		readingKeys = append(readingKeys, eventReadingKeys...)
+	}
+	readingKeys, edgeXerr = pageStrings(readingKeys, offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	objects, edgeXerr := getObjectsByIds(conn, common.ConvertStringsToInterfaces(readingKeys))
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	return convertObjectsToReadings(conn, objects)
+}
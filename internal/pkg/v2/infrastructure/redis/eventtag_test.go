@@ -0,0 +1,51 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/tags"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventTagIndexKeys(t *testing.T) {
+	included, excluded := eventTagIndexKeys([]tags.Term{
+		{Name: "site", Operator: tags.Equal, Value: "plant-1"},
+		{Name: "line", Operator: tags.NotEqual, Value: "2"},
+		{Name: "shift", Operator: tags.Exists},
+	})
+	assert.Equal(t, []string{EventsCollectionTagValue + ":site:plant-1", EventsCollectionTagName + ":shift"}, included)
+	assert.Equal(t, []string{EventsCollectionTagValue + ":line:2"}, excluded)
+
+	included, excluded = eventTagIndexKeys([]tags.Term{{Name: "line", Operator: tags.NotEqual, Value: "2"}})
+	assert.Equal(t, []string{EventsCollection}, included, "Events without a matching term should be looked up among all events")
+	assert.Len(t, excluded, 1)
+}
+
+func TestPageStrings(t *testing.T) {
+	values := []string{"a", "b", "c"}
+
+	page, err := pageStrings(values, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, page)
+
+	page, err = pageStrings(values, 1, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, page)
+
+	page, err = pageStrings(nil, 5, 1)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+
+	_, err = pageStrings(values, 4, 1)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindRangeNotSatisfiable, errors.Kind(err))
+}
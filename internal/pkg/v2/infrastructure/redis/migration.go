@@ -0,0 +1,139 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	redisClient "github.com/edgexfoundry/edgex-go/internal/pkg/db/redis"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// CoreDataSchemaKeyPrefix prefixes the keys holding the schema version of the core-data keys and the progress of
+// their migrations
+const CoreDataSchemaKeyPrefix = "cd"
+
+// CoreDataMigrations are the changes to the layout of the core-data keys, by increasing version. A release changing
+// the layout appends its migration here, e.g. built with redisClient.ScanMigration, rather than shipping a script to
+// run on upgrade.
+var CoreDataMigrations []redisClient.Migration
+
+// MigrateCoreData runs the pending migrations of the core-data keys, only logging what they would change in a dry run
+func (c *Client) MigrateCoreData(dryRun bool) error {
+	migrator, err := redisClient.NewMigrator(c.loggingClient, c.Pool, c.Client, CoreDataSchemaKeyPrefix, CoreDataMigrations)
+	if err != nil {
+		return err
+	}
+	return migrator.Migrate(dryRun)
+}
+
+// CoreMetadataSchemaKeyPrefix prefixes the keys holding the schema version of the core-metadata keys and the progress
+// of their migrations
+const CoreMetadataSchemaKeyPrefix = "md"
+
+// searchBackfillBatchSize is the number of objects indexed for search by each step of a backfill migration
+const searchBackfillBatchSize = 500
+
+// CoreMetadataMigrations are the changes to the layout of the core-metadata keys, by increasing version
+var CoreMetadataMigrations = []redisClient.Migration{
+	searchBackfillMigration(1, "index the devices for search", DeviceCollection, DeviceCollectionSearch,
+		func(stored []byte) ([]string, int64, error) {
+			var d models.Device
+			if err := json.Unmarshal(stored, &d); err != nil {
+				return nil, 0, err
+			}
+			return deviceSearchTexts(d), d.Modified, nil
+		}),
+	searchBackfillMigration(2, "index the device profiles for search", DeviceProfileCollection, DeviceProfileCollectionSearch,
+		func(stored []byte) ([]string, int64, error) {
+			var dp models.DeviceProfile
+			if err := json.Unmarshal(stored, &dp); err != nil {
+				return nil, 0, err
+			}
+			return deviceProfileSearchTexts(dp), dp.Modified, nil
+		}),
+}
+
+// MigrateCoreMetadata runs the pending migrations of the core-metadata keys, only logging what they would change in a
+// dry run
+func (c *Client) MigrateCoreMetadata(dryRun bool) error {
+	migrator, err := redisClient.NewMigrator(c.loggingClient, c.Pool, c.Client, CoreMetadataSchemaKeyPrefix, CoreMetadataMigrations)
+	if err != nil {
+		return err
+	}
+	return migrator.Migrate(dryRun)
+}
+
+// searchBackfillMigration returns the migration adding the objects of collection stored before the search index to
+// the index of searchKey. searchTexts returns the texts and the score to index a stored object with.
+// The cursor of the migration is the rank of the next object of collection to index, indexing an object twice being
+// harmless.
+func searchBackfillMigration(
+	version int,
+	description string,
+	collection string,
+	searchKey string,
+	searchTexts func(stored []byte) ([]string, int64, error)) redisClient.Migration {
+
+	return redisClient.Migration{
+		Version:     version,
+		Description: description,
+		Step: func(conn redis.Conn, cursor string, dryRun bool) (string, int, error) {
+			start, err := strconv.Atoi(cursor)
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid cursor %s: %s", cursor, err.Error())
+			}
+			storedKeys, err := redis.Strings(conn.Do(ZRANGE, collection, start, start+searchBackfillBatchSize-1))
+			if err != nil {
+				return "", 0, err
+			}
+			if len(storedKeys) == 0 {
+				return "0", 0, nil
+			}
+			values, err := redis.ByteSlices(conn.Do(MGET, redis.Args{}.AddFlat(storedKeys)...))
+			if err != nil {
+				return "", 0, err
+			}
+
+			migrated := 0
+			if !dryRun {
+				_ = conn.Send(MULTI)
+			}
+			for i, stored := range values {
+				if stored == nil {
+					// deleted since the collection was read
+					continue
+				}
+				texts, score, err := searchTexts(stored)
+				if err != nil {
+					if !dryRun {
+						_, _ = conn.Do("DISCARD")
+					}
+					return "", 0, fmt.Errorf("failed to decode %s: %s", storedKeys[i], err.Error())
+				}
+				if !dryRun {
+					sendAddSearchIndexCmd(conn, searchKey, storedKeys[i], score, texts...)
+				}
+				migrated++
+			}
+			if !dryRun {
+				if _, err := conn.Do(EXEC); err != nil {
+					return "", 0, err
+				}
+			}
+
+			if len(storedKeys) < searchBackfillBatchSize {
+				return "0", migrated, nil
+			}
+			return strconv.Itoa(start + len(storedKeys)), migrated, nil
+		},
+	}
+}
@@ -0,0 +1,140 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backfillConn is a redis connection serving ZRANGE and MGET from a collection, and recording the ZADD commands
+// sent in a transaction
+type backfillConn struct {
+	keys   []string
+	values map[string][]byte
+	added  []string
+	multi  bool
+}
+
+func (c *backfillConn) Close() error                  { return nil }
+func (c *backfillConn) Err() error                    { return nil }
+func (c *backfillConn) Flush() error                  { return nil }
+func (c *backfillConn) Receive() (interface{}, error) { return nil, errors.New("not supported") }
+
+func (c *backfillConn) Send(command string, args ...interface{}) error {
+	switch command {
+	case MULTI:
+		c.multi = true
+	case ZADD:
+		if !c.multi {
+			return errors.New("ZADD outside of a transaction")
+		}
+		c.added = append(c.added, fmt.Sprintf("%v %v", args[0], args[2]))
+	default:
+		return fmt.Errorf("unexpected command %s", command)
+	}
+	return nil
+}
+
+func (c *backfillConn) Do(command string, args ...interface{}) (interface{}, error) {
+	switch command {
+	case ZRANGE:
+		start, end := args[1].(int), args[2].(int)
+		var keys []interface{}
+		for i := start; i <= end && i < len(c.keys); i++ {
+			keys = append(keys, []byte(c.keys[i]))
+		}
+		return keys, nil
+	case MGET:
+		var values []interface{}
+		for _, key := range args {
+			if value, ok := c.values[key.(string)]; ok {
+				values = append(values, value)
+			} else {
+				values = append(values, nil)
+			}
+		}
+		return values, nil
+	case EXEC:
+		c.multi = false
+		return []interface{}{}, nil
+	case "DISCARD":
+		c.multi = false
+		c.added = nil
+		return "OK", nil
+	}
+	return nil, fmt.Errorf("unexpected command %s", command)
+}
+
+func TestSearchBackfillMigration(t *testing.T) {
+	texts := func(stored []byte) ([]string, int64, error) {
+		if string(stored) == "invalid" {
+			return nil, 0, errors.New("invalid")
+		}
+		return []string{string(stored)}, 1, nil
+	}
+	migration := searchBackfillMigration(1, "index", DeviceCollection, DeviceCollectionSearch, texts)
+
+	t.Run("index", func(t *testing.T) {
+		conn := &backfillConn{
+			keys:   []string{"md|dv:1", "md|dv:2", "md|dv:3"},
+			values: map[string][]byte{"md|dv:1": []byte("pump"), "md|dv:3": []byte("fan")},
+		}
+		next, migrated, err := migration.Step(conn, "0", false)
+		require.NoError(t, err)
+		assert.Equal(t, "0", next, "a partial batch must end the migration")
+		assert.Equal(t, 2, migrated, "the deleted device must be skipped")
+		assert.Equal(t, []string{
+			CreateKey(DeviceCollectionSearch, "pu") + " md|dv:1",
+			CreateKey(DeviceCollectionSearch, "pum") + " md|dv:1",
+			CreateKey(DeviceCollectionSearch, "pump") + " md|dv:1",
+			CreateKey(DeviceCollectionSearch, "fa") + " md|dv:3",
+			CreateKey(DeviceCollectionSearch, "fan") + " md|dv:3",
+		}, conn.added)
+	})
+
+	t.Run("full batch", func(t *testing.T) {
+		conn := &backfillConn{values: map[string][]byte{}}
+		for i := 0; i < searchBackfillBatchSize+1; i++ {
+			key := CreateKey(DeviceCollection, fmt.Sprint(i))
+			conn.keys = append(conn.keys, key)
+			conn.values[key] = []byte("pump")
+		}
+		next, migrated, err := migration.Step(conn, "0", false)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprint(searchBackfillBatchSize), next)
+		assert.Equal(t, searchBackfillBatchSize, migrated)
+
+		next, migrated, err = migration.Step(conn, next, false)
+		require.NoError(t, err)
+		assert.Equal(t, "0", next)
+		assert.Equal(t, 1, migrated)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		conn := &backfillConn{keys: []string{"md|dv:1"}, values: map[string][]byte{"md|dv:1": []byte("pump")}}
+		next, migrated, err := migration.Step(conn, "0", true)
+		require.NoError(t, err)
+		assert.Equal(t, "0", next)
+		assert.Equal(t, 1, migrated)
+		assert.Empty(t, conn.added)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		conn := &backfillConn{
+			keys:   []string{"md|dv:1", "md|dv:2"},
+			values: map[string][]byte{"md|dv:1": []byte("pump"), "md|dv:2": []byte("invalid")},
+		}
+		_, _, err := migration.Step(conn, "0", false)
+		require.Error(t, err)
+		assert.Empty(t, conn.added, "the transaction must be discarded")
+		assert.False(t, conn.multi)
+	})
+}
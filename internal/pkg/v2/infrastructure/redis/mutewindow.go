@@ -0,0 +1,184 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	MuteWindowCollection     = "sn|mute"
+	MuteWindowCollectionName = MuteWindowCollection + DBKeySeparator + v2.Name
+	// MuteWindowCollectionHeld is the sorted set of the ids of the notifications queued by the mute windows, scored
+	// by their creation
+	MuteWindowCollectionHeld = MuteWindowCollection + DBKeySeparator + "held"
+)
+
+// muteWindowStoredKey return the mute window's stored key which combines the collection name and object id
+func muteWindowStoredKey(id string) string {
+	return CreateKey(MuteWindowCollection, id)
+}
+
+// addMuteWindow adds a new mute window into DB
+func addMuteWindow(conn redis.Conn, window mutes.MuteWindow) (mutes.MuteWindow, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, muteWindowStoredKey(window.Id))
+	if edgeXerr != nil {
+		return window, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return window, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("mute window id %s already exists", window.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, MuteWindowCollectionName, window.Name)
+	if edgeXerr != nil {
+		return window, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return window, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("mute window name %s already exists", window.Name), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	if window.Created == 0 {
+		window.Created = ts
+	}
+	window.Modified = ts
+
+	m, err := json.Marshal(window)
+	if err != nil {
+		return window, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal mute window for Redis persistence", err)
+	}
+
+	redisKey := muteWindowStoredKey(window.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, redisKey, m)
+	_ = conn.Send(ZADD, MuteWindowCollection, window.Created, redisKey)
+	_ = conn.Send(HSET, MuteWindowCollectionName, window.Name, redisKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "mute window creation failed", err)
+	}
+
+	return window, edgeXerr
+}
+
+// allMuteWindows queries mute windows by offset and limit, the latest created first
+func allMuteWindows(conn redis.Conn, offset, limit int) (result []mutes.MuteWindow, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, MuteWindowCollection, offset, end)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	result = make([]mutes.MuteWindow, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &result[i])
+		if err != nil {
+			return []mutes.MuteWindow{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "mute window format parsing failed from the database", err)
+		}
+	}
+	return result, nil
+}
+
+// muteWindowById query mute window by id from DB
+func muteWindowById(conn redis.Conn, id string) (window mutes.MuteWindow, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, muteWindowStoredKey(id), &window)
+	if edgeXerr != nil {
+		return window, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// muteWindowByName queries mute window by name
+func muteWindowByName(conn redis.Conn, name string) (window mutes.MuteWindow, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, MuteWindowCollectionName, name, &window)
+	if edgeXerr != nil {
+		return window, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// updateMuteWindow replaces the stored mute window having the same id, keeping its name
+func updateMuteWindow(conn redis.Conn, window mutes.MuteWindow) errors.EdgeX {
+	stored, edgeXerr := muteWindowById(conn, window.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if stored.Name != window.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("mute window name '%s' not match the existing '%s'", window.Name, stored.Name), nil)
+	}
+
+	window.Created = stored.Created
+	window.Modified = common.MakeTimestamp()
+	m, err := json.Marshal(window)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal mute window for Redis persistence", err)
+	}
+	_, err = conn.Do(SET, muteWindowStoredKey(window.Id), m)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "mute window update failed", err)
+	}
+	return nil
+}
+
+// deleteMuteWindowByName deletes the mute window by name
+func deleteMuteWindowByName(conn redis.Conn, name string) errors.EdgeX {
+	window, edgeXerr := muteWindowByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	storedKey := muteWindowStoredKey(window.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, MuteWindowCollection, storedKey)
+	_ = conn.Send(HDEL, MuteWindowCollectionName, window.Name)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "mute window deletion failed", err)
+	}
+	return nil
+}
+
+// holdNotification adds the id of a notification queued by a mute window
+func holdNotification(conn redis.Conn, id string, created int64) errors.EdgeX {
+	_, err := conn.Do(ZADD, MuteWindowCollectionHeld, created, id)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("holding notification %s failed", id), err)
+	}
+	return nil
+}
+
+// heldNotifications queries the ids of the queued notifications by offset and limit, the first created first
+func heldNotifications(conn redis.Conn, offset, limit int) ([]string, errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	ids, err := redis.Strings(conn.Do(ZRANGE, MuteWindowCollectionHeld, offset, end))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query held notification ids from database failed", err)
+	}
+	return ids, nil
+}
+
+// releaseNotification removes the id of a queued notification
+func releaseNotification(conn redis.Conn, id string) errors.EdgeX {
+	_, err := conn.Do(ZREM, MuteWindowCollectionHeld, id)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("releasing notification %s failed", id), err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// OneShotCollection is the sorted set of the pending one-shot jobs, scored by when they run
+	OneShotCollection     = "ss|os"
+	OneShotCollectionName = OneShotCollection + DBKeySeparator + v2.Name
+)
+
+// oneShotStoredKey return the one-shot job's stored key which combines the collection name and object id
+func oneShotStoredKey(id string) string {
+	return CreateKey(OneShotCollection, id)
+}
+
+// addOneShot adds a new one-shot job into DB
+func addOneShot(conn redis.Conn, oneShot oneshots.OneShot) (oneshots.OneShot, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, oneShotStoredKey(oneShot.Id))
+	if edgeXerr != nil {
+		return oneShot, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return oneShot, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("one-shot job id %s already exists", oneShot.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, OneShotCollectionName, oneShot.Name)
+	if edgeXerr != nil {
+		return oneShot, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return oneShot, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("one-shot job name %s already exists", oneShot.Name), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	if oneShot.Created == 0 {
+		oneShot.Created = ts
+	}
+	oneShot.Modified = ts
+
+	m, err := json.Marshal(oneShot)
+	if err != nil {
+		return oneShot, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal one-shot job for Redis persistence", err)
+	}
+
+	storedKey := oneShotStoredKey(oneShot.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, OneShotCollection, oneShot.RunAt, storedKey)
+	_ = conn.Send(HSET, OneShotCollectionName, oneShot.Name, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "one-shot job creation failed", err)
+	}
+
+	return oneShot, edgeXerr
+}
+
+// allOneShots queries the pending one-shot jobs by offset and limit, the first to run first
+func allOneShots(conn redis.Conn, offset, limit int) (result []oneshots.OneShot, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRange(conn, OneShotCollection, offset, end)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return unmarshalOneShots(objects)
+}
+
+// dueOneShots queries the one-shot jobs to run at or before the timestamp at, the first to run first
+func dueOneShots(conn redis.Conn, at int64) ([]oneshots.OneShot, errors.EdgeX) {
+	storedKeys, err := redis.Strings(conn.Do(ZRANGEBYSCORE, OneShotCollection, InfiniteMin, at))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query due one-shot jobs from database failed", err)
+	}
+	objects, edgeXerr := getObjectsByIds(conn, common.ConvertStringsToInterfaces(storedKeys))
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return unmarshalOneShots(objects)
+}
+
+func unmarshalOneShots(objects [][]byte) ([]oneshots.OneShot, errors.EdgeX) {
+	result := make([]oneshots.OneShot, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &result[i])
+		if err != nil {
+			return []oneshots.OneShot{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "one-shot job format parsing failed from the database", err)
+		}
+	}
+	return result, nil
+}
+
+// oneShotByName queries one-shot job by name
+func oneShotByName(conn redis.Conn, name string) (oneShot oneshots.OneShot, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, OneShotCollectionName, name, &oneShot)
+	if edgeXerr != nil {
+		return oneShot, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// deleteOneShotByName deletes the one-shot job by name
+func deleteOneShotByName(conn redis.Conn, name string) errors.EdgeX {
+	oneShot, edgeXerr := oneShotByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	storedKey := oneShotStoredKey(oneShot.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, OneShotCollection, storedKey)
+	_ = conn.Send(HDEL, OneShotCollectionName, oneShot.Name)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "one-shot job deletion failed", err)
+	}
+	return nil
+}
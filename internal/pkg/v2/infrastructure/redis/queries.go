@@ -99,21 +99,31 @@ func getObjectsByLabelsAndSomeRange(conn redis.Conn, command string, key string,
 		return getObjectsBySomeRange(conn, command, key, start, end)
 	}
 
-	idsSlice := make([][]string, len(labels))
-	for i, label := range labels { //iterate each labels to retrieve Ids associated with labels
-		idsWithLabel, err := redis.Strings(conn.Do(command, CreateKey(key, v2.Label, label), 0, -1))
+	indexKeys := make([]string, len(labels))
+	for i, label := range labels {
+		indexKeys[i] = CreateKey(key, v2.Label, label)
+	}
+	return getObjectsByIndexKeysAndSomeRange(conn, command, indexKeys, start, end)
+}
+
+// getObjectsByIndexKeysAndSomeRange retrieves the entries whose keys are enumerated in every one of the indexKeys
+// sorted sets, using the specified Redis range command (i.e. RANGE, REVRANGE) to read each sorted set.
+func getObjectsByIndexKeysAndSomeRange(conn redis.Conn, command string, indexKeys []string, start int, end int) ([][]byte, errors.EdgeX) {
+	idsSlice := make([][]string, len(indexKeys))
+	for i, indexKey := range indexKeys { //iterate each index to retrieve Ids associated with it
+		ids, err := redis.Strings(conn.Do(command, indexKey, 0, -1))
 		if err != nil {
-			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query object ids by label %s from database failed", label), err)
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query object ids by %s from database failed", indexKey), err)
 		}
-		idsSlice[i] = idsWithLabel
+		idsSlice[i] = ids
 	}
 
-	//find common Ids among two-dimension Ids slice associated with labels
+	//find common Ids among two-dimension Ids slice associated with the indexes
 	commonIds := common.FindCommonStrings(idsSlice...)
 	if start > len(commonIds) {
 		return nil, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("query objects bounds out of range. length:%v", len(commonIds)), nil)
 	}
-	if end >= len(commonIds) {
+	if end < 0 || end >= len(commonIds) {
 		commonIds = commonIds[start:]
 	} else { // as end index in golang re-slice is exclusive, increment the end index to ensure the end could be inclusive
 		commonIds = commonIds[start : end+1]
@@ -161,6 +171,17 @@ func objectIdExists(conn redis.Conn, id string) (bool, errors.EdgeX) {
 	return exists, nil
 }
 
+// getMemberCountByScoreRange returns the number of members of the sorted set key within the score range [min, max],
+// counted by Redis with ZCOUNT without reading the members
+func getMemberCountByScoreRange(conn redis.Conn, key string, min interface{}, max interface{}) (uint32, errors.EdgeX) {
+	count, err := redis.Int(conn.Do(ZCOUNT, key, min, max))
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to count the members of %s by score range", key), err)
+	}
+
+	return uint32(count), nil
+}
+
 func getMemberNumber(conn redis.Conn, command string, key string) (uint32, errors.EdgeX) {
 	count, err := redis.Int(conn.Do(command, key))
 	if err != nil {
@@ -41,12 +41,22 @@ func (c *Client) asyncDeleteReadingsByIds(readingIds []string) {
 		c.loggingClient.Error(fmt.Sprintf("Deleted readings failed while retrieving objects by Ids.  Err: %s", edgeXerr.DebugMessages()))
 		return
 	}
+	expanded, edgeXerr := expandReadings(conn, readings)
+	if edgeXerr != nil {
+		c.loggingClient.Error(fmt.Sprintf("Deleted readings failed while decoding compressed readings.  Err: %s", edgeXerr.DebugMessages()))
+		return
+	}
 
 	// iterate each readings for deletion in batch
 	queriesInQueue := 0
+	var chunkKeys []string
 	r := models.BaseReading{}
 	_ = conn.Send(MULTI)
-	for i, reading := range readings {
+	for i, reading := range expanded {
+		if reading == nil {
+			c.loggingClient.Error("unable to delete a compressed reading whose chunk no longer exists")
+			continue
+		}
 		err := json.Unmarshal(reading, &r)
 		if err != nil {
 			c.loggingClient.Error(fmt.Sprintf("unable to marshal reading.  Err: %s", err.Error()))
@@ -58,6 +68,8 @@ func (c *Client) asyncDeleteReadingsByIds(readingIds []string) {
 		_ = conn.Send(ZREM, ReadingsCollectionCreated, storedKey)
 		_ = conn.Send(ZREM, CreateKey(ReadingsCollectionDeviceName, r.DeviceName), storedKey)
 		_ = conn.Send(ZREM, CreateKey(ReadingsCollectionResourceName, r.ResourceName), storedKey)
+		sendDeleteReadingAnnotationCmd(conn, storedKey)
+		chunkKeys = append(chunkKeys, sendDeleteCompressedReadingCmd(conn, readings[i]))
 		queriesInQueue++
 
 		if queriesInQueue >= c.BatchSize {
@@ -81,6 +93,10 @@ func (c *Client) asyncDeleteReadingsByIds(readingIds []string) {
 			c.loggingClient.Error(fmt.Sprintf("unable to execute batch reading deletion.  Err: %s", err.Error()))
 		}
 	}
+
+	if edgeXerr = deleteEmptyChunks(conn, chunkKeys); edgeXerr != nil {
+		c.loggingClient.Error(fmt.Sprintf("unable to delete the emptied reading chunks.  Err: %s", edgeXerr.DebugMessages()))
+	}
 }
 
 // readingStoredKey return the reading's stored key which combines the collection name and object id
@@ -133,7 +149,7 @@ func addReading(conn redis.Conn, r models.Reading) (reading models.Reading, edge
 func deleteReadingById(conn redis.Conn, id string) (edgeXerr errors.EdgeX) {
 	r := models.BaseReading{}
 	storedKey := readingStoredKey(id)
-	edgeXerr = getObjectById(conn, storedKey, &r)
+	object, edgeXerr := readingObjectById(conn, storedKey, &r)
 	if edgeXerr != nil {
 		return edgeXerr
 	}
@@ -144,12 +160,36 @@ func deleteReadingById(conn redis.Conn, id string) (edgeXerr errors.EdgeX) {
 	_ = conn.Send(ZREM, ReadingsCollectionCreated, storedKey)
 	_ = conn.Send(ZREM, CreateKey(ReadingsCollectionDeviceName, r.DeviceName), storedKey)
 	_ = conn.Send(ZREM, CreateKey(ReadingsCollectionResourceName, r.ResourceName), storedKey)
+	sendDeleteReadingAnnotationCmd(conn, storedKey)
+	chunkKey := sendDeleteCompressedReadingCmd(conn, object)
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("reading[id:%s] delete failed", id), err)
 	}
 
-	return nil
+	return deleteEmptyChunks(conn, []string{chunkKey})
+}
+
+// readingObjectById reads the reading stored by storedKey into out, decoding it from its chunk when compressed, and
+// returns the stored value
+func readingObjectById(conn redis.Conn, storedKey string, out interface{}) ([]byte, errors.EdgeX) {
+	object, err := redis.Bytes(conn.Do(GET, storedKey))
+	if err == redis.ErrNil {
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("fail to query object %T, because id: %s doesn't exist in the database", out, storedKey), err)
+	} else if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query object %T by id from the database failed", out), err)
+	}
+	expanded, edgeXerr := expandReadings(conn, [][]byte{object})
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+	if expanded[0] == nil {
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("the chunk of the compressed reading %s doesn't exist", storedKey), nil)
+	}
+	if err = json.Unmarshal(expanded[0], out); err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("object %T format parsing failed from the database", out), err)
+	}
+	return object, nil
 }
 
 func checkReadingValue(b *models.BaseReading) errors.EdgeX {
@@ -176,10 +216,14 @@ func readingsByEventId(conn redis.Conn, eventId string) (readings []models.Readi
 		return readings, errors.NewCommonEdgeXWrapper(err)
 	}
 
-	return convertObjectsToReadings(objects)
+	return convertObjectsToReadings(conn, objects)
 }
 
-func allReadings(conn redis.Conn, offset int, limit int) (readings []models.Reading, edgeXerr errors.EdgeX) {
+// allReadings query readings by offset and limit, leaving out the readings of the excluded qualities
+func allReadings(conn redis.Conn, offset int, limit int, excluded []string) (readings []models.Reading, edgeXerr errors.EdgeX) {
+	if len(excluded) > 0 {
+		return readingsExcludingQualities(conn, ReadingsCollectionCreated, InfiniteMin, InfiniteMax, excluded, offset, limit)
+	}
 	end := offset + limit - 1
 	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
 		end = limit
@@ -189,11 +233,15 @@ func allReadings(conn redis.Conn, offset int, limit int) (readings []models.Read
 		return readings, errors.NewCommonEdgeXWrapper(err)
 	}
 
-	return convertObjectsToReadings(objects)
+	return convertObjectsToReadings(conn, objects)
 }
 
-// readingsByResourceName query readings by offset, limit, and resource name
-func readingsByResourceName(conn redis.Conn, offset int, limit int, resourceName string) (readings []models.Reading, edgeXerr errors.EdgeX) {
+// readingsByResourceName query readings by offset, limit, and resource name, leaving out the readings of the excluded
+// qualities
+func readingsByResourceName(conn redis.Conn, offset int, limit int, resourceName string, excluded []string) (readings []models.Reading, edgeXerr errors.EdgeX) {
+	if len(excluded) > 0 {
+		return readingsExcludingQualities(conn, CreateKey(ReadingsCollectionResourceName, resourceName), InfiniteMin, InfiniteMax, excluded, offset, limit)
+	}
 	end := offset + limit - 1
 	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
 		end = limit
@@ -203,11 +251,15 @@ func readingsByResourceName(conn redis.Conn, offset int, limit int, resourceName
 		return readings, errors.NewCommonEdgeXWrapper(err)
 	}
 
-	return convertObjectsToReadings(objects)
+	return convertObjectsToReadings(conn, objects)
 }
 
-// readingsByDeviceName query readings by offset, limit, and device name
-func readingsByDeviceName(conn redis.Conn, offset int, limit int, name string) (readings []models.Reading, edgeXerr errors.EdgeX) {
+// readingsByDeviceName query readings by offset, limit, and device name, leaving out the readings of the excluded
+// qualities
+func readingsByDeviceName(conn redis.Conn, offset int, limit int, name string, excluded []string) (readings []models.Reading, edgeXerr errors.EdgeX) {
+	if len(excluded) > 0 {
+		return readingsExcludingQualities(conn, CreateKey(ReadingsCollectionDeviceName, name), InfiniteMin, InfiniteMax, excluded, offset, limit)
+	}
 	end := offset + limit - 1
 	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
 		end = limit
@@ -217,21 +269,44 @@ func readingsByDeviceName(conn redis.Conn, offset int, limit int, name string) (
 		return readings, errors.NewCommonEdgeXWrapper(err)
 	}
 
-	return convertObjectsToReadings(objects)
+	return convertObjectsToReadings(conn, objects)
 }
 
-// readingsByTimeRange query readings by time range, offset, and limit
-func readingsByTimeRange(conn redis.Conn, start int, end int, offset int, limit int) (readings []models.Reading, edgeXerr errors.EdgeX) {
+// readingsByTimeRange query readings by time range, offset, and limit, leaving out the readings of the excluded
+// qualities
+func readingsByTimeRange(conn redis.Conn, start int, end int, offset int, limit int, excluded []string) (readings []models.Reading, edgeXerr errors.EdgeX) {
+	if len(excluded) > 0 {
+		return readingsExcludingQualities(conn, ReadingsCollectionCreated, start, end, excluded, offset, limit)
+	}
 	objects, edgeXerr := getObjectsByScoreRange(conn, ReadingsCollectionCreated, start, end, offset, limit)
 	if edgeXerr != nil {
 		return readings, edgeXerr
 	}
-	return convertObjectsToReadings(objects)
+	return convertObjectsToReadings(conn, objects)
+}
+
+// readingsExcludingQualities query the readings enumerated in the sorted set key within a score range, leaving out the
+// readings of the excluded qualities
+func readingsExcludingQualities(conn redis.Conn, key string, min interface{}, max interface{}, excluded []string, offset int, limit int) (readings []models.Reading, edgeXerr errors.EdgeX) {
+	objects, edgeXerr := getReadingsExcludingQualities(conn, key, min, max, excluded, offset, limit)
+	if edgeXerr != nil {
+		return readings, edgeXerr
+	}
+	return convertObjectsToReadings(conn, objects)
 }
 
-func convertObjectsToReadings(objects [][]byte) (readings []models.Reading, edgeXerr errors.EdgeX) {
-	readings = make([]models.Reading, len(objects))
-	for i, in := range objects {
+// convertObjectsToReadings converts the stored readings of objects, decoding the compressed ones from their chunk
+func convertObjectsToReadings(conn redis.Conn, objects [][]byte) (readings []models.Reading, edgeXerr errors.EdgeX) {
+	objects, edgeXerr = expandReadings(conn, objects)
+	if edgeXerr != nil {
+		return []models.Reading{}, edgeXerr
+	}
+	readings = make([]models.Reading, 0, len(objects))
+	for _, in := range objects {
+		if in == nil {
+			// the chunk of the compressed reading was deleted with its last readings
+			continue
+		}
 		// as V2 APi doesn't deal with BinaryReading at this moment, convert to SimpleReading here
 		// Shall update the logic here when working on BinaryReading in the future
 		sr := models.SimpleReading{}
@@ -239,7 +314,7 @@ func convertObjectsToReadings(objects [][]byte) (readings []models.Reading, edge
 		if err != nil {
 			return []models.Reading{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "reading format parsing failed from the database", err)
 		}
-		readings[i] = sr
+		readings = append(readings, sr)
 	}
 	return readings, nil
 }
@@ -0,0 +1,226 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/quality"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// ReadingsCollectionAnnotation is the hash of the annotations of the readings that aren't good or have a null
+	// value, by reading stored key
+	ReadingsCollectionAnnotation = ReadingsCollection + DBKeySeparator + "annotation"
+	// ReadingsCollectionQuality prefixes the sets of the stored keys of the readings of each quality but good, which
+	// is the quality of most readings and is not indexed
+	ReadingsCollectionQuality = ReadingsCollection + DBKeySeparator + "quality"
+)
+
+// excludedQueryBatchSize is the number of ids read at once while skipping the readings of excluded qualities
+const excludedQueryBatchSize = 1000
+
+// sendDeleteReadingAnnotationCmd sends redis commands removing the annotation of the reading with storedKey, if any
+func sendDeleteReadingAnnotationCmd(conn redis.Conn, storedKey string) {
+	_ = conn.Send(HDEL, ReadingsCollectionAnnotation, storedKey)
+	for _, q := range quality.Qualities {
+		if q != quality.Good {
+			_ = conn.Send(SREM, CreateKey(ReadingsCollectionQuality, q), storedKey)
+		}
+	}
+}
+
+// addReadingAnnotations stores the annotations of the readings with the given ids, skipping the zero annotations, and
+// indexes the readings by quality
+func addReadingAnnotations(conn redis.Conn, annotations map[string]quality.Annotation) errors.EdgeX {
+	objects := make(map[string][]byte, len(annotations))
+	for id, annotation := range annotations {
+		if annotation.IsZero() {
+			continue
+		}
+		m, err := json.Marshal(annotation)
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal reading annotation for Redis persistence", err)
+		}
+		objects[id] = m
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	_ = conn.Send(MULTI)
+	for id, m := range objects {
+		storedKey := readingStoredKey(id)
+		_ = conn.Send(HSET, ReadingsCollectionAnnotation, storedKey, m)
+		if q := annotations[id].Quality; q != "" && q != quality.Good {
+			_ = conn.Send(SADD, CreateKey(ReadingsCollectionQuality, q), storedKey)
+		}
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "reading annotation creation failed", err)
+	}
+	return nil
+}
+
+// readingAnnotations returns the annotations of the readings with the given ids; readings without an annotation are
+// left out of the returned map
+func readingAnnotations(conn redis.Conn, ids []string) (map[string]quality.Annotation, errors.EdgeX) {
+	annotations := make(map[string]quality.Annotation)
+	if len(ids) == 0 {
+		return annotations, nil
+	}
+
+	args := make([]interface{}, len(ids)+1)
+	args[0] = ReadingsCollectionAnnotation
+	for i, id := range ids {
+		args[i+1] = readingStoredKey(id)
+	}
+	objects, err := redis.ByteSlices(conn.Do(HMGET, args...))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query reading annotations from database failed", err)
+	}
+	for i, object := range objects {
+		if object == nil {
+			continue
+		}
+		var annotation quality.Annotation
+		if err := json.Unmarshal(object, &annotation); err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "reading annotation format parsing failed from the database", err)
+		}
+		annotations[ids[i]] = annotation
+	}
+	return annotations, nil
+}
+
+// excludedReadingKeys returns the stored keys of the readings of the excluded qualities
+func excludedReadingKeys(conn redis.Conn, excluded []string) (map[string]bool, errors.EdgeX) {
+	keys := make(map[string]bool)
+	for _, q := range excluded {
+		members, err := redis.Strings(conn.Do(SMEMBERS, CreateKey(ReadingsCollectionQuality, q)))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query %s readings from database failed", q), err)
+		}
+		for _, member := range members {
+			keys[member] = true
+		}
+	}
+	return keys, nil
+}
+
+// getReadingsExcludingQualities retrieves, most recent first, the readings enumerated in the sorted set key within
+// the score range [min, max] whose quality is not excluded, skipping offset of them and returning up to limit of them,
+// or all of them when limit is -1.
+func getReadingsExcludingQualities(conn redis.Conn, key string, min interface{}, max interface{}, excluded []string, offset int, limit int) ([][]byte, errors.EdgeX) {
+	excludedKeys, edgeXerr := excludedReadingKeys(conn, excluded)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+
+	var ids []string
+	skipped := 0
+	for position := 0; limit < 0 || len(ids) < limit; position += excludedQueryBatchSize {
+		// ZREVRANGEBYSCORE key max min LIMIT offset count
+		batch, err := redis.Strings(conn.Do(ZREVRANGEBYSCORE, key, max, min, LIMIT, position, excludedQueryBatchSize))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query object ids from database failed", err)
+		}
+		for _, id := range batch {
+			if excludedKeys[id] {
+				continue
+			}
+			if skipped < offset {
+				skipped++
+				continue
+			}
+			ids = append(ids, id)
+			if limit >= 0 && len(ids) == limit {
+				break
+			}
+		}
+		if len(batch) < excludedQueryBatchSize {
+			break
+		}
+	}
+	if len(ids) == 0 && offset > 0 && skipped < offset {
+		return nil, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("query objects bounds out of range. length:%v", skipped), nil)
+	}
+
+	return getObjectsByIds(conn, common.ConvertStringsToInterfaces(ids))
+}
+
+// readingCountExcludingQualities returns the number of readings enumerated in the sorted set key whose quality is not
+// excluded
+func readingCountExcludingQualities(conn redis.Conn, key string, excluded []string) (uint32, errors.EdgeX) {
+	count, edgeXerr := getMemberNumber(conn, ZCARD, key)
+	if edgeXerr != nil {
+		return 0, edgeXerr
+	}
+	scores, edgeXerr := excludedReadingScores(conn, key, excluded)
+	if edgeXerr != nil {
+		return 0, edgeXerr
+	}
+
+	for range scores {
+		if count > 0 {
+			count--
+		}
+	}
+	return count, nil
+}
+
+// readingCountByScoreRangeExcludingQualities returns the number of readings enumerated in the sorted set key within
+// the score range [start, end] whose quality is not excluded
+func readingCountByScoreRangeExcludingQualities(conn redis.Conn, key string, start int, end int, excluded []string) (uint32, errors.EdgeX) {
+	count, edgeXerr := getMemberCountByScoreRange(conn, key, start, end)
+	if edgeXerr != nil {
+		return 0, edgeXerr
+	}
+	scores, edgeXerr := excludedReadingScores(conn, key, excluded)
+	if edgeXerr != nil {
+		return 0, edgeXerr
+	}
+
+	for _, score := range scores {
+		if score >= float64(start) && score <= float64(end) && count > 0 {
+			count--
+		}
+	}
+	return count, nil
+}
+
+// excludedReadingScores returns the scores in the sorted set key of the readings it enumerates whose quality is
+// excluded, read with ZSCORE rather than by going through the sorted set
+func excludedReadingScores(conn redis.Conn, key string, excluded []string) ([]float64, errors.EdgeX) {
+	excludedKeys, edgeXerr := excludedReadingKeys(conn, excluded)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+
+	for storedKey := range excludedKeys {
+		_ = conn.Send(ZSCORE, key, storedKey)
+	}
+	if err := conn.Flush(); err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query reading scores from database failed", err)
+	}
+	var scores []float64
+	for range excludedKeys {
+		score, err := redis.Float64(conn.Receive())
+		if err == redis.ErrNil {
+			continue
+		} else if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query reading scores from database failed", err)
+		}
+		scores = append(scores, score)
+	}
+	return scores, nil
+}
@@ -0,0 +1,94 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// minSearchTokenLength is the shortest word prefix that is indexed; single characters would match nearly everything
+	minSearchTokenLength = 2
+	// maxSearchTokenLength bounds the number of prefixes indexed per word
+	maxSearchTokenLength = 32
+)
+
+// searchWords splits text into lowercase words made of letters and digits, dropping words too short to be indexed
+func searchWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		runes := []rune(field)
+		if len(runes) < minSearchTokenLength {
+			continue
+		}
+		if len(runes) > maxSearchTokenLength {
+			runes = runes[:maxSearchTokenLength]
+		}
+		words = append(words, string(runes))
+	}
+	return words
+}
+
+// searchTokens returns the distinct tokens to index for the texts. Every prefix of each word is a token so that a
+// partially typed word matches, e.g. "temperature" is reachable by "te", "tem", ... "temperature".
+func searchTokens(texts ...string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	for _, text := range texts {
+		for _, word := range searchWords(text) {
+			runes := []rune(word)
+			for i := minSearchTokenLength; i <= len(runes); i++ {
+				token := string(runes[:i])
+				if !seen[token] {
+					seen[token] = true
+					tokens = append(tokens, token)
+				}
+			}
+		}
+	}
+	return tokens
+}
+
+// sendAddSearchIndexCmd sends redis commands adding storedKey to the search index for each token of the texts
+func sendAddSearchIndexCmd(conn redis.Conn, searchKey string, storedKey string, score int64, texts ...string) {
+	for _, token := range searchTokens(texts...) {
+		_ = conn.Send(ZADD, CreateKey(searchKey, token), score, storedKey)
+	}
+}
+
+// sendDeleteSearchIndexCmd sends redis commands removing storedKey from the search index for each token of the texts
+func sendDeleteSearchIndexCmd(conn redis.Conn, searchKey string, storedKey string, texts ...string) {
+	for _, token := range searchTokens(texts...) {
+		_ = conn.Send(ZREM, CreateKey(searchKey, token), storedKey)
+	}
+}
+
+// searchIndexKeys returns the index keys to intersect for the search query and labels, so that an object must match
+// every word of the query and carry every label
+func searchIndexKeys(searchKey string, labelKey string, query string, labels []string) ([]string, errors.EdgeX) {
+	words := searchWords(query)
+	if len(words) == 0 {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "search query must contain at least one word of two or more letters or digits", nil)
+	}
+
+	keys := make([]string, 0, len(words)+len(labels))
+	for _, word := range words {
+		keys = append(keys, CreateKey(searchKey, word))
+	}
+	for _, label := range labels {
+		keys = append(keys, CreateKey(labelKey, label))
+	}
+	return keys, nil
+}
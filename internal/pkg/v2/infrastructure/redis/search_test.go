@@ -0,0 +1,40 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchWords(t *testing.T) {
+	assert.Equal(t, []string{"room", "thermostat", "v2"}, searchWords("Room-1 Thermostat, v2"))
+	assert.Empty(t, searchWords("a b -"))
+}
+
+func TestSearchTokens(t *testing.T) {
+	tokens := searchTokens("Temp", "temp-sensor")
+
+	assert.Equal(t, []string{"te", "tem", "temp", "se", "sen", "sens", "senso", "sensor"}, tokens)
+}
+
+func TestSearchIndexKeys(t *testing.T) {
+	keys, err := searchIndexKeys(DeviceCollectionSearch, DeviceCollectionLabel, "Therm room", []string{"HVAC"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		CreateKey(DeviceCollectionSearch, "therm"),
+		CreateKey(DeviceCollectionSearch, "room"),
+		CreateKey(DeviceCollectionLabel, "HVAC"),
+	}, keys)
+
+	_, err = searchIndexKeys(DeviceCollectionSearch, DeviceCollectionLabel, " x ", nil)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+}
@@ -0,0 +1,81 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+)
+
+const (
+	SystemEventCollection      = "sys|evt"
+	SystemEventCollectionActor = SystemEventCollection + DBKeySeparator + audit.Actor
+)
+
+// systemEventStoredKey return the system event's stored key which combines the collection name and object id
+func systemEventStoredKey(id string) string {
+	return CreateKey(SystemEventCollection, id)
+}
+
+// addSystemEvents adds the system events into DB, indexed by timestamp and by actor
+func addSystemEvents(conn redis.Conn, events []audit.SystemEvent) ([]audit.SystemEvent, errors.EdgeX) {
+	objects := make([][]byte, len(events))
+	for i := range events {
+		if events[i].Id == "" {
+			events[i].Id = uuid.New().String()
+		}
+		if events[i].Timestamp == 0 {
+			events[i].Timestamp = common.MakeTimestamp()
+		}
+
+		m, err := json.Marshal(events[i])
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal system event for Redis persistence", err)
+		}
+		objects[i] = m
+	}
+
+	_ = conn.Send(MULTI)
+	for i, event := range events {
+		storedKey := systemEventStoredKey(event.Id)
+		_ = conn.Send(SET, storedKey, objects[i])
+		_ = conn.Send(ZADD, SystemEventCollection, event.Timestamp, storedKey)
+		_ = conn.Send(ZADD, CreateKey(SystemEventCollectionActor, event.Actor), event.Timestamp, storedKey)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "system event creation failed", err)
+	}
+	return events, nil
+}
+
+// systemEventsByTimeRange queries the system events recorded in the time range, by the actor if it is not empty
+func systemEventsByTimeRange(conn redis.Conn, start int, end int, actor string, offset int, limit int) ([]audit.SystemEvent, errors.EdgeX) {
+	key := SystemEventCollection
+	if actor != "" {
+		key = CreateKey(SystemEventCollectionActor, actor)
+	}
+	objects, edgeXerr := getObjectsByScoreRange(conn, key, start, end, offset, limit)
+	if edgeXerr != nil {
+		return nil, edgeXerr
+	}
+
+	events := make([]audit.SystemEvent, len(objects))
+	for i, in := range objects {
+		err := json.Unmarshal(in, &events[i])
+		if err != nil {
+			return []audit.SystemEvent{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "system event format parsing failed from the database", err)
+		}
+	}
+	return events, nil
+}
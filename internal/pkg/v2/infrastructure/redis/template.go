@@ -0,0 +1,217 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	TemplateCollection     = "sn|tpl"
+	TemplateCollectionName = TemplateCollection + DBKeySeparator + v2.Name
+	// SubscriptionLocaleCollection is the hash of the locales of the subscriptions, keyed by subscription name
+	SubscriptionLocaleCollection = SubscriptionCollection + DBKeySeparator + "locale"
+)
+
+// templateStoredKey return the template's stored key which combines the collection name and object id
+func templateStoredKey(id string) string {
+	return CreateKey(TemplateCollection, id)
+}
+
+// addTemplate adds a new template into DB
+func addTemplate(conn redis.Conn, template templates.Template) (templates.Template, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, templateStoredKey(template.Id))
+	if edgeXerr != nil {
+		return template, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return template, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("template id %s already exists", template.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, TemplateCollectionName, template.Name)
+	if edgeXerr != nil {
+		return template, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return template, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("template name %s already exists", template.Name), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	if template.Created == 0 {
+		template.Created = ts
+	}
+	template.Modified = ts
+
+	m, err := json.Marshal(template)
+	if err != nil {
+		return template, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal template for Redis persistence", err)
+	}
+
+	redisKey := templateStoredKey(template.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, redisKey, m)
+	_ = conn.Send(ZADD, TemplateCollection, template.Created, redisKey)
+	_ = conn.Send(HSET, TemplateCollectionName, template.Name, redisKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "template creation failed", err)
+	}
+
+	return template, edgeXerr
+}
+
+// allTemplates queries templates by offset and limit, the latest created first
+func allTemplates(conn redis.Conn, offset, limit int) (result []templates.Template, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, TemplateCollection, offset, end)
+	if edgeXerr != nil {
+		return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	result = make([]templates.Template, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &result[i])
+		if err != nil {
+			return []templates.Template{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "template format parsing failed from the database", err)
+		}
+	}
+	return result, nil
+}
+
+// templateById query template by id from DB
+func templateById(conn redis.Conn, id string) (template templates.Template, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, templateStoredKey(id), &template)
+	if edgeXerr != nil {
+		return template, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// templateByName queries template by name
+func templateByName(conn redis.Conn, name string) (template templates.Template, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, TemplateCollectionName, name, &template)
+	if edgeXerr != nil {
+		return template, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// updateTemplate replaces the stored template having the same id, keeping its name
+func updateTemplate(conn redis.Conn, template templates.Template) errors.EdgeX {
+	stored, edgeXerr := templateById(conn, template.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if stored.Name != template.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("template name '%s' not match the existing '%s'", template.Name, stored.Name), nil)
+	}
+
+	template.Created = stored.Created
+	template.Modified = common.MakeTimestamp()
+	m, err := json.Marshal(template)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal template for Redis persistence", err)
+	}
+	_, err = conn.Do(SET, templateStoredKey(template.Id), m)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "template update failed", err)
+	}
+	return nil
+}
+
+// deleteTemplateByName deletes the template by name
+func deleteTemplateByName(conn redis.Conn, name string) errors.EdgeX {
+	template, edgeXerr := templateByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	storedKey := templateStoredKey(template.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, TemplateCollection, storedKey)
+	_ = conn.Send(HDEL, TemplateCollectionName, template.Name)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "template deletion failed", err)
+	}
+	return nil
+}
+
+// subscriptionLocale returns the locale of the subscription, empty when it has none
+func subscriptionLocale(conn redis.Conn, name string) (string, errors.EdgeX) {
+	locale, err := redis.String(conn.Do(HGET, SubscriptionLocaleCollection, name))
+	if err == redis.ErrNil {
+		return "", nil
+	} else if err != nil {
+		return "", errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to query the locale of subscription %s", name), err)
+	}
+	return locale, nil
+}
+
+// setSubscriptionLocale sets the locale of the subscription, replacing the existing one
+func setSubscriptionLocale(conn redis.Conn, name string, locale string) errors.EdgeX {
+	_, err := conn.Do(HSET, SubscriptionLocaleCollection, name, locale)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to set the locale of subscription %s", name), err)
+	}
+	return nil
+}
+
+// deleteSubscriptionLocale deletes the locale of the subscription
+func deleteSubscriptionLocale(conn redis.Conn, name string) errors.EdgeX {
+	_, err := conn.Do(HDEL, SubscriptionLocaleCollection, name)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("failed to delete the locale of subscription %s", name), err)
+	}
+	return nil
+}
+
+// SubscriptionLocale gets the locale of a subscription by name, empty when it has none
+func (c *Client) SubscriptionLocale(name string) (string, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	locale, edgeXerr := subscriptionLocale(conn, name)
+	if edgeXerr != nil {
+		return "", errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return locale, nil
+}
+
+// SetSubscriptionLocale sets the locale of a subscription by name
+func (c *Client) SetSubscriptionLocale(name string, locale string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := setSubscriptionLocale(conn, name, locale)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
+
+// DeleteSubscriptionLocale deletes the locale of a subscription by name
+func (c *Client) DeleteSubscriptionLocale(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteSubscriptionLocale(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
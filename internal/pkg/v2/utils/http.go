@@ -19,6 +19,9 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// Search is the query string key of the free-text search supported by some of the "all" queries
+const Search = "search"
+
 func WriteHttpHeader(w http.ResponseWriter, ctx context.Context, statusCode int) {
 	w.Header().Set(clients.CorrelationHeader, correlation.FromContext(ctx))
 	w.Header().Set(clients.ContentType, clients.ContentTypeJSON)
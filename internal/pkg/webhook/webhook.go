@@ -0,0 +1,174 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook signs the payloads posted by the gateway to the webhooks with HMAC-SHA256, so that the receivers
+// can authenticate the gateway and reject the replayed requests. The signature is computed with a secret shared with
+// the receivers over the timestamp of the request and its body, "<timestamp>.<body>", and sent with the timestamp in
+// the SignatureHeader and TimestampHeader headers. The receivers check it with Verify.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
+)
+
+const (
+	// TimestampHeader holds the time the request was signed at, in seconds since the epoch
+	TimestampHeader = "X-EdgeX-Timestamp"
+	// SignatureHeader holds the signature of the request, "sha256=" followed by the hex encoded HMAC-SHA256
+	SignatureHeader = "X-EdgeX-Signature"
+	// SecretKey is the key of the shared secret in the secret of WebhookSigningInfo.SecretPath
+	SecretKey = "secret"
+	// DefaultReplayWindow is how far from the time of the receiver the timestamps of the requests should be accepted
+	DefaultReplayWindow = 5 * time.Minute
+
+	signaturePrefix = "sha256="
+)
+
+// WebhookSigningInfo configures the signing of the payloads posted to the webhooks
+type WebhookSigningInfo struct {
+	// Enabled signs the payloads, failing their requests when the secret can't be read
+	Enabled bool
+	// SecretPath is the path of the secret holding the shared secret in the service's secret store
+	SecretPath string
+}
+
+// Validate checks that the enabled signing has the SecretPath of its secret
+func (info WebhookSigningInfo) Validate() error {
+	if info.Enabled && info.SecretPath == "" {
+		return errors.New("the signing of the webhooks requires the SecretPath of the shared secret")
+	}
+	return nil
+}
+
+// Signature returns the value of the SignatureHeader of body signed with secret at timestamp, in seconds since the
+// epoch
+func Signature(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(timestamp))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of body against secret, and that the timestamp it was signed at is within window of
+// now, for the receivers of the signed requests. The signature is compared in constant time.
+func Verify(secret []byte, timestamp string, signature string, body []byte, now time.Time, window time.Duration) error {
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("the request isn't signed: the %s or %s header is missing", TimestampHeader, SignatureHeader)
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s'", TimestampHeader, timestamp)
+	}
+	signedAt := time.Unix(seconds, 0)
+	if signedAt.Before(now.Add(-window)) || signedAt.After(now.Add(window)) {
+		return fmt.Errorf("the request was signed at %s, outside of the replay window of %s", signedAt.UTC().Format(time.RFC3339), window)
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return fmt.Errorf("unsupported %s, expected %s followed by the HMAC-SHA256", SignatureHeader, signaturePrefix)
+	}
+	if !hmac.Equal([]byte(signature), []byte(Signature(secret, timestamp, body))) {
+		return errors.New("the signature doesn't match the request")
+	}
+	return nil
+}
+
+// Signer signs the requests with the shared secret of the secret store. The secret is read for every request, so a
+// rotated secret is used as soon as the secret store, or its cache, returns it. It is safe for concurrent use.
+type Signer struct {
+	secrets secretcache.SecretProvider
+	path    string
+	now     func() time.Time
+}
+
+// NewSigner returns the Signer of info reading the shared secret from secrets, or nil when the signing isn't enabled
+func NewSigner(secrets secretcache.SecretProvider, info WebhookSigningInfo) *Signer {
+	if !info.Enabled {
+		return nil
+	}
+	return &Signer{secrets: secrets, path: info.SecretPath, now: time.Now}
+}
+
+// Sign sets the TimestampHeader and SignatureHeader of req, whose body is read and restored. A nil Signer leaves
+// req unsigned.
+func (s *Signer) Sign(req *http.Request) error {
+	if s == nil {
+		return nil
+	}
+	body, err := readBody(req)
+	if err != nil {
+		return fmt.Errorf("unable to read the body to sign: %s", err.Error())
+	}
+	if s.secrets == nil {
+		return errors.New("the secret store isn't available to read the webhook signing secret")
+	}
+	secrets, err := s.secrets.GetSecrets(s.path, SecretKey)
+	if err != nil {
+		return fmt.Errorf("unable to read the webhook signing secret at %s: %s", s.path, err.Error())
+	}
+	if secrets[SecretKey] == "" {
+		return fmt.Errorf("the webhook signing secret at %s has no '%s'", s.path, SecretKey)
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Signature([]byte(secrets[SecretKey]), timestamp, body))
+	return nil
+}
+
+// Caller returns a caller signing the requests before sending them with client, or client itself when s is nil. A
+// request which can't be signed fails without being sent.
+func (s *Signer) Caller(client internal.HttpCaller) internal.HttpCaller {
+	if s == nil {
+		return client
+	}
+	return &signingCaller{signer: s, client: client}
+}
+
+type signingCaller struct {
+	signer *Signer
+	client internal.HttpCaller
+}
+
+func (c *signingCaller) Do(req *http.Request) (*http.Response, error) {
+	if err := c.signer.Sign(req); err != nil {
+		return nil, err
+	}
+	return c.client.Do(req)
+}
+
+// readBody returns the body of req, replacing it with an unread copy
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	var reader io.ReadCloser = req.Body
+	if req.GetBody != nil {
+		var err error
+		if reader, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	body, err := ioutil.ReadAll(reader)
+	_ = reader.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,163 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type secretStore map[string]map[string]string
+
+func (s secretStore) GetSecrets(path string, _ ...string) (map[string]string, error) {
+	secrets, ok := s[path]
+	if !ok {
+		return nil, fmt.Errorf("no secret at %s", path)
+	}
+	return secrets, nil
+}
+
+func (s secretStore) StoreSecrets(path string, secrets map[string]string) error {
+	s[path] = secrets
+	return nil
+}
+
+type recordingCaller struct {
+	requests []*http.Request
+	bodies   []string
+}
+
+func (c *recordingCaller) Do(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.requests = append(c.requests, req)
+	c.bodies = append(c.bodies, string(body))
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, WebhookSigningInfo{}.Validate())
+	assert.NoError(t, WebhookSigningInfo{Enabled: true, SecretPath: "webhook"}.Validate())
+	assert.Error(t, WebhookSigningInfo{Enabled: true}.Validate())
+}
+
+func TestSignature(t *testing.T) {
+	// the HMAC-SHA256 of "1600000000.hello" with the key "secret"
+	assert.Equal(t, "sha256=8467897bfe066c439626cf886f905f0f1330dc715bc527f82a724835f911d943",
+		Signature([]byte("secret"), "1600000000", []byte("hello")))
+	assert.NotEqual(t, Signature([]byte("secret"), "1600000000", []byte("hello")),
+		Signature([]byte("secret"), "1600000001", []byte("hello")))
+}
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Unix(1600000000, 0)
+	body := []byte(`{"content":"hello"}`)
+	signature := Signature(secret, "1600000000", body)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		timestamp string
+		signature string
+		body      []byte
+		valid     bool
+	}{
+		{"valid", secret, "1600000000", signature, body, true},
+		{"valid within the window", secret, "1599999800", Signature(secret, "1599999800", body), body, true},
+		{"unsigned", secret, "", "", body, false},
+		{"invalid timestamp", secret, "yesterday", signature, body, false},
+		{"stale", secret, "1599999000", Signature(secret, "1599999000", body), body, false},
+		{"future", secret, "1600001000", Signature(secret, "1600001000", body), body, false},
+		{"replayed with a new timestamp", secret, "1600000010", signature, body, false},
+		{"tampered body", secret, "1600000000", signature, []byte(`{"content":"bye"}`), false},
+		{"other secret", []byte("other"), "1600000000", signature, body, false},
+		{"unsupported algorithm", secret, "1600000000", "sha1=0123", body, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.timestamp, tt.signature, tt.body, now, DefaultReplayWindow)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNewSignerDisabled(t *testing.T) {
+	signer := NewSigner(secretStore{}, WebhookSigningInfo{SecretPath: "webhook"})
+	assert.Nil(t, signer)
+
+	client := &recordingCaller{}
+	assert.Same(t, client, signer.Caller(client))
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/hook", bytes.NewBufferString("hello"))
+	require.NoError(t, err)
+	require.NoError(t, signer.Sign(req))
+	assert.Empty(t, req.Header.Get(SignatureHeader))
+}
+
+func TestCaller(t *testing.T) {
+	secrets := secretStore{"webhook": {SecretKey: "secret"}}
+	signer := NewSigner(secrets, WebhookSigningInfo{Enabled: true, SecretPath: "webhook"})
+	require.NotNil(t, signer)
+	now := time.Unix(1600000000, 0)
+	signer.now = func() time.Time { return now }
+	client := &recordingCaller{}
+	caller := signer.Caller(client)
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/hook", bytes.NewBufferString("hello"))
+	require.NoError(t, err)
+	_, err = caller.Do(req)
+	require.NoError(t, err)
+	require.Len(t, client.requests, 1)
+	assert.Equal(t, "hello", client.bodies[0], "the body should be sent once read to be signed")
+	assert.Equal(t, "1600000000", req.Header.Get(TimestampHeader))
+	assert.NoError(t, Verify([]byte("secret"), req.Header.Get(TimestampHeader), req.Header.Get(SignatureHeader),
+		[]byte("hello"), now, DefaultReplayWindow))
+
+	// each request is signed with its own timestamp and the current secret
+	now = now.Add(time.Minute)
+	secrets["webhook"] = map[string]string{SecretKey: "rotated"}
+	req, err = http.NewRequest(http.MethodPost, "http://localhost/hook", bytes.NewBufferString("hello"))
+	require.NoError(t, err)
+	_, err = caller.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, "1600000060", req.Header.Get(TimestampHeader))
+	assert.NoError(t, Verify([]byte("rotated"), req.Header.Get(TimestampHeader), req.Header.Get(SignatureHeader),
+		[]byte("hello"), now, DefaultReplayWindow))
+}
+
+func TestCallerWithoutSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets secretStore
+	}{
+		{"no secret", secretStore{}},
+		{"empty secret", secretStore{"webhook": {"other": "value"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &recordingCaller{}
+			caller := NewSigner(tt.secrets, WebhookSigningInfo{Enabled: true, SecretPath: "webhook"}).Caller(client)
+			req, err := http.NewRequest(http.MethodPost, "http://localhost/hook", bytes.NewBufferString("hello"))
+			require.NoError(t, err)
+			_, err = caller.Do(req)
+			assert.Error(t, err)
+			assert.Empty(t, client.requests, "an unsigned request should not be sent")
+		})
+	}
+}
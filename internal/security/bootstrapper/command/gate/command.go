@@ -22,7 +22,9 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/edgexfoundry/edgex-go/internal/security/bootstrapper/condition"
 	"github.com/edgexfoundry/edgex-go/internal/security/bootstrapper/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/bootstrapper/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/security/bootstrapper/tcp"
@@ -33,6 +35,14 @@ import (
 const (
 	// the command name for gating the stages of bootstrapping on other services for security
 	CommandName string = "gate"
+
+	registryStage = "Registry"
+	kongDBStage   = "KongDB"
+	databaseStage = "Database"
+
+	// conditionTimeout bounds each check of the readiness conditions of a stage
+	conditionTimeout     = 5 * time.Second
+	defaultRetryInterval = time.Second
 )
 
 type cmd struct {
@@ -40,6 +50,10 @@ type cmd struct {
 	waitGroup     *sync.WaitGroup
 	loggingClient logger.LoggingClient
 	config        *config.ConfigurationStruct
+
+	// stageConditions are the readiness conditions of the stages, waited for once their ready port is open
+	stageConditions map[string][]condition.Condition
+	retryInterval   time.Duration
 }
 
 // NewCommand creates a new cmd and parses through options if any
@@ -66,6 +80,24 @@ func NewCommand(
 		return nil, fmt.Errorf("Unable to parse command: %s: %w", strings.Join(args, " "), err)
 	}
 
+	cmd.retryInterval = defaultRetryInterval
+	if conf.StageGate.WaitFor.RetryInterval != "" {
+		cmd.retryInterval, err = time.ParseDuration(conf.StageGate.WaitFor.RetryInterval)
+		if err != nil || cmd.retryInterval <= 0 {
+			return nil, fmt.Errorf("Expect positive time duration (> 0) for StageGate.WaitFor.RetryInterval: %s",
+				conf.StageGate.WaitFor.RetryInterval)
+		}
+	}
+
+	cmd.stageConditions = make(map[string][]condition.Condition)
+	for _, stage := range []string{registryStage, kongDBStage, databaseStage} {
+		conditions, err := condition.ParseAll(conf.StageGate.WaitFor.Stages[stage], conditionTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse StageGate.WaitFor.Stages.%s: %w", stage, err)
+		}
+		cmd.stageConditions[stage] = conditions
+	}
+
 	return &cmd, nil
 }
 
@@ -92,6 +124,9 @@ func (c *cmd) Execute() (statusCode int, err error) {
 			c.config.StageGate.Registry.Host, c.config.StageGate.Registry.ReadyPort, err)
 		return interfaces.StatusCodeExitWithError, retErr
 	}
+	if err := c.waitForStage(registryStage); err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
 	c.loggingClient.Info("Registry is ready")
 
 	if err := tcp.DialTcp(
@@ -102,6 +137,9 @@ func (c *cmd) Execute() (statusCode int, err error) {
 			c.config.StageGate.KongDB.Host, c.config.StageGate.KongDB.ReadyPort, err)
 		return interfaces.StatusCodeExitWithError, retErr
 	}
+	if err := c.waitForStage(kongDBStage); err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
 	c.loggingClient.Info("KongDB is ready")
 
 	if err := tcp.DialTcp(
@@ -112,6 +150,9 @@ func (c *cmd) Execute() (statusCode int, err error) {
 			c.config.StageGate.Database.Host, c.config.StageGate.Database.ReadyPort, err)
 		return interfaces.StatusCodeExitWithError, retErr
 	}
+	if err := c.waitForStage(databaseStage); err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
 	c.loggingClient.Info("Database is ready")
 
 	// Reached ready-to-run phase
@@ -140,6 +181,20 @@ func (c *cmd) GetCommandName() string {
 	return CommandName
 }
 
+// waitForStage waits for the readiness conditions configured for stage, for as long as it takes like for the
+// ready port of the stage
+func (c *cmd) waitForStage(stage string) error {
+	conditions := c.stageConditions[stage]
+	if len(conditions) == 0 {
+		return nil
+	}
+	c.loggingClient.Infof("Waiting for the readiness conditions of %s: %v", stage, conditions)
+	if err := condition.Wait(conditions, 0, c.retryInterval, c.loggingClient); err != nil {
+		return fmt.Errorf("found error while waiting for readiness conditions of %s, err: %v", stage, err)
+	}
+	return nil
+}
+
 func openGatingSemaphorePort(tcpServer *tcp.TcpServer, portNum int, lc logger.LoggingClient, raisingMsg string) {
 	lc.Info(raisingMsg)
 	if err := tcpServer.StartListener(portNum, lc, ""); err != nil {
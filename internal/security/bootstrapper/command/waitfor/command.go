@@ -19,14 +19,12 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"net"
-	"net/http"
-	"net/url"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/edgexfoundry/edgex-go/internal/security/bootstrapper/condition"
 	"github.com/edgexfoundry/edgex-go/internal/security/bootstrapper/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/bootstrapper/interfaces"
 
@@ -38,17 +36,14 @@ const (
 )
 
 type cmd struct {
-	waitGroup     *sync.WaitGroup
 	loggingClient logger.LoggingClient
 	configuration *config.ConfigurationStruct
 
 	// options
 	uris          uriFlagsVar
+	stage         string
 	timeout       time.Duration
 	retryInterval time.Duration
-
-	// internal states
-	parsedURIs []url.URL
 }
 
 // NewCommand creates a new cmd and parses through options if any
@@ -79,7 +74,6 @@ func NewCommand(
 	}
 
 	cmd := cmd{
-		waitGroup:     &sync.WaitGroup{},
 		loggingClient: lc,
 		configuration: configuration,
 	}
@@ -89,8 +83,11 @@ func NewCommand(
 	flagSet := flag.NewFlagSet(CommandName, flag.ContinueOnError)
 	flagSet.StringVar(&dummy, "confdir", "", "") // handled by bootstrap; duplicated here to prevent arg parsing errors
 
-	flagSet.Var(&cmd.uris, "uri", "Service (tcp/tcp4/tcp6/http/https/unix/file) to wait for before this one starts. "+
-		"Can be passed multiple times. e.g. tcp://db:5432")
+	flagSet.Var(&cmd.uris, "uri", "Service (tcp/tcp4/tcp6/http/https/unix/file/dns/redis) to wait for before this one "+
+		"starts. Can be passed multiple times. e.g. tcp://db:5432")
+
+	flagSet.StringVar(&cmd.stage, "stage", "", "Stage of StageGate.WaitFor.Stages whose conditions to wait for, "+
+		"in addition to the --uri ones")
 
 	flagSet.DurationVar(&cmd.timeout, "timeout", defaultTimeout, "Timeout duration of waiting for services")
 
@@ -101,8 +98,17 @@ func NewCommand(
 		return nil, fmt.Errorf("Unable to parse command: %s: %w", strings.Join(args, " "), err)
 	}
 
+	if cmd.stage != "" {
+		stageURIs, ok := configuration.StageGate.WaitFor.Stages[cmd.stage]
+		if !ok {
+			return nil, fmt.Errorf("%s %s: stage %s is not defined in StageGate.WaitFor.Stages",
+				os.Args[0], CommandName, cmd.stage)
+		}
+		cmd.uris = append(cmd.uris, stageURIs...)
+	}
+
 	if len(cmd.uris) == 0 {
-		return nil, fmt.Errorf("%s %s: argument --uri is required", os.Args[0], CommandName)
+		return nil, fmt.Errorf("%s %s: argument --uri or --stage is required", os.Args[0], CommandName)
 	}
 
 	return &cmd, nil
@@ -118,135 +124,15 @@ func (c *cmd) GetCommandName() string {
 func (c *cmd) Execute() (int, error) {
 	c.loggingClient.Infof("Security bootstrapper running %s", CommandName)
 
-	for _, rawURI := range c.uris {
-		uri, err := url.Parse(rawURI)
-		if err != nil {
-			return interfaces.StatusCodeExitWithError, err
-		}
-
-		c.parsedURIs = append(c.parsedURIs, *uri)
+	conditions, err := condition.ParseAll(c.uris, c.timeout)
+	if err != nil {
+		return interfaces.StatusCodeExitWithError, err
 	}
 
-	if err := c.waitForDependencies(); err != nil {
+	c.loggingClient.Infof("Waiting for: %v with timeout: [%s]", conditions, c.timeout.String())
+	if err := condition.Wait(conditions, c.timeout, c.retryInterval, c.loggingClient); err != nil {
 		return interfaces.StatusCodeExitWithError, err
 	}
 
 	return interfaces.StatusCodeExitNormal, nil
 }
-
-// waitForDependencies implements very similar ideas from dockerize
-func (c *cmd) waitForDependencies() error {
-	dependencyChan := make(chan struct{})
-	waitErr := make(chan error, 1)
-
-	go func() {
-		for _, uri := range c.parsedURIs {
-			c.loggingClient.Infof("Waiting for: [%s] with timeout: [%s]", uri.String(), c.timeout.String())
-
-			switch uri.Scheme {
-			case "file":
-				c.waitForFile(uri)
-			case "tcp", "tcp4", "tcp6":
-				c.waitForSocket(uri.Scheme, uri.Host)
-			case "unix":
-				c.waitForSocket(uri.Scheme, uri.Path)
-			case "http", "https":
-				c.waitForHTTP(uri)
-			default:
-				waitErr <- fmt.Errorf("invalid host protocol provided: %s. supported protocols are: file, tcp, tcp4, "+
-					"tcp6, unix and http", uri.Scheme)
-				return
-			}
-		}
-
-		c.waitGroup.Wait()
-		close(dependencyChan)
-	}()
-
-	select {
-	case err := <-waitErr:
-		return err
-	case <-dependencyChan:
-		break
-	case <-time.After(c.timeout):
-		return fmt.Errorf("Timeout after %s waiting on dependencies to become available: %v", c.timeout, c.uris)
-	}
-
-	return nil
-}
-
-func (c *cmd) waitForFile(fileUri url.URL) {
-	c.waitGroup.Add(1)
-	go func(uri url.URL) {
-		defer c.waitGroup.Done()
-		ticker := time.NewTicker(c.retryInterval)
-		defer ticker.Stop()
-		var err error
-		for range ticker.C {
-			if _, err = os.Stat(uri.Path); err == nil {
-				c.loggingClient.Infof("File %s had been generated", uri.String())
-				return
-			} else if os.IsNotExist(err) {
-				// file not exists at this moment
-				c.loggingClient.Infof("File %s not exists: %v. Sleeping %s",
-					uri.String(), err, c.retryInterval)
-			} else {
-				// file may or may not exist: see err for details
-				c.loggingClient.Infof("Problem with check file %s exist: %v. Sleeping %s",
-					uri.String(), err, c.retryInterval)
-			}
-		}
-	}(fileUri)
-}
-
-func (c *cmd) waitForSocket(scheme, addr string) {
-	c.waitGroup.Add(1)
-	go func(schm, adr string) {
-		defer c.waitGroup.Done()
-		for {
-			conn, err := net.DialTimeout(schm, adr, c.timeout)
-			if err != nil {
-				c.loggingClient.Infof("Problem with dial: %v. Sleeping %s", err.Error(), c.retryInterval)
-				time.Sleep(c.retryInterval)
-			}
-
-			if conn != nil {
-				c.loggingClient.Infof("Connected to %s://%s", schm, adr)
-				return
-			}
-		}
-	}(scheme, addr)
-}
-
-func (c *cmd) waitForHTTP(uri url.URL) {
-	c.waitGroup.Add(1)
-	go func(httpURL url.URL) {
-		client := &http.Client{
-			Timeout: c.timeout,
-		}
-
-		defer c.waitGroup.Done()
-		for {
-			req, err := http.NewRequest(http.MethodGet, httpURL.String(), nil)
-			if err != nil {
-				c.loggingClient.Infof("Problem with dial: %v. Sleeping %s", err.Error(), c.retryInterval)
-				time.Sleep(c.retryInterval)
-				continue
-			}
-
-			resp, err := client.Do(req)
-			if err != nil {
-				c.loggingClient.Infof("Problem with request: %s. Sleeping %s", err.Error(), c.retryInterval)
-				time.Sleep(c.retryInterval)
-			} else if err == nil && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
-				// dependency is treated as ok if http status code is between 200 and 300
-				c.loggingClient.Infof("Received %d from %s", resp.StatusCode, httpURL.String())
-				return
-			} else {
-				c.loggingClient.Infof("Received %d from %s. Sleeping %s", resp.StatusCode,
-					httpURL.String(), c.retryInterval)
-				time.Sleep(c.retryInterval)
-			}
-		}
-	}(uri)
-}
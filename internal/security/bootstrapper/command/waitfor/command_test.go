@@ -69,6 +69,11 @@ func TestNewCommand(t *testing.T) {
 		{"Good: waitFor --uri --timeout --retryInterval options",
 			[]string{"--uri=http://:11120", "--timeout=1s", "--retryInterval=5s"}, testDefaultTimeout,
 			testDefaultRetryInterval, false},
+		{"Good: waitFor --stage option", []string{"--stage=Vault"}, testDefaultTimeout, testDefaultRetryInterval, false},
+		{"Good: waitFor --stage with --uri options", []string{"--stage=Vault", "--uri=dns://localhost"},
+			testDefaultTimeout, testDefaultRetryInterval, false},
+		{"Bad: waitFor undefined --stage option", []string{"--stage=Kong"}, testDefaultTimeout,
+			testDefaultRetryInterval, true},
 		{"Bad: waitFor invalid option", []string{"--invalid=http://localhost:123"}, testDefaultTimeout,
 			testDefaultRetryInterval, true},
 		{"Bad: waitFor empty option", []string{""}, testDefaultTimeout, testDefaultRetryInterval, true},
@@ -148,6 +153,7 @@ func TestExecute(t *testing.T) {
 		{"Good: waitFor with existing tcp server and testFile and retryInterval",
 			[]string{"--uri=tcp://" + testSrv, "--uri=file://" + waitFile, "--retryInterval=2s"}, defaultWaitTimeout, false},
 		{"Good: waitFor with existing http server", []string{"--uri=" + testHttpSrv.URL}, defaultWaitTimeout, false},
+		{"Good: waitFor with resolved host", []string{"--uri=dns://localhost"}, defaultWaitTimeout, false},
 		{"Bad: waitFor with malformed URL", []string{"--uri=_http!@xxxxxx:1111"}, time.Duration(2 * time.Second), true},
 		{"Bad: waitFor with no tcp server response", []string{"--uri=tcp://non-existing:1111", "--timeout=3s"},
 			time.Duration(3 * time.Second), true},
@@ -208,6 +214,7 @@ func getTestConfig(timeout, retryInterval string) *config.ConfigurationStruct {
 			WaitFor: config.WaitForInfo{
 				Timeout:       timeout,
 				RetryInterval: retryInterval,
+				Stages:        map[string][]string{"Vault": {"http://localhost:11120/v1/sys/health"}},
 			},
 		},
 	}
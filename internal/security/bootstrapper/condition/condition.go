@@ -0,0 +1,261 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+// Package condition implements the readiness conditions the security bootstrapper waits for before a stage runs.
+// A condition is written as a URI whose scheme selects the check:
+//
+//	tcp://host:port, tcp4://, tcp6://   a connection to the address is accepted
+//	unix:///path                        a connection to the socket is accepted
+//	http://host:port/path, https://     a GET request returns a 2xx status code
+//	file:///path                        the file exists
+//	dns://host                          the host name resolves
+//	redis://[:password@]host:port       Redis answers PING with PONG
+package condition
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// Condition is a readiness signal a stage of the bootstrapping waits for
+type Condition interface {
+	// Check returns nil once the condition is met
+	Check() error
+	// String returns the URI the condition was parsed from
+	String() string
+}
+
+// Parse returns the Condition described by rawURI; timeout bounds each check
+func Parse(rawURI string, timeout time.Duration) (Condition, error) {
+	uri, err := url.Parse(rawURI)
+	if err != nil {
+		return nil, err
+	}
+
+	switch uri.Scheme {
+	case "file":
+		return fileCondition{uri: *uri}, nil
+	case "tcp", "tcp4", "tcp6":
+		return socketCondition{uri: *uri, address: uri.Host, timeout: timeout}, nil
+	case "unix":
+		return socketCondition{uri: *uri, address: uri.Path, timeout: timeout}, nil
+	case "http", "https":
+		return httpCondition{uri: *uri, client: &http.Client{Timeout: timeout}}, nil
+	case "dns":
+		if uri.Hostname() == "" {
+			return nil, fmt.Errorf("dns condition %s has no host name", rawURI)
+		}
+		return dnsCondition{uri: *uri}, nil
+	case "redis":
+		if uri.Host == "" {
+			return nil, fmt.Errorf("redis condition %s has no address", rawURI)
+		}
+		return redisCondition{uri: *uri, timeout: timeout}, nil
+	default:
+		return nil, fmt.Errorf("invalid host protocol provided: %s. supported protocols are: file, tcp, tcp4, "+
+			"tcp6, unix, http, https, dns and redis", uri.Scheme)
+	}
+}
+
+// ParseAll returns the Conditions described by rawURIs
+func ParseAll(rawURIs []string, timeout time.Duration) ([]Condition, error) {
+	conditions := make([]Condition, 0, len(rawURIs))
+	for _, rawURI := range rawURIs {
+		condition, err := Parse(rawURI, timeout)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// Wait checks every condition each retryInterval until they are all met. It fails once timeout has elapsed, unless
+// timeout isn't positive, in which case it keeps waiting.
+func Wait(conditions []Condition, timeout time.Duration, retryInterval time.Duration, lc logger.LoggingClient) error {
+	done := make(chan struct{})
+	stop := make(chan struct{})
+	defer close(stop)
+
+	var wg sync.WaitGroup
+	for _, condition := range conditions {
+		wg.Add(1)
+		go func(condition Condition) {
+			defer wg.Done()
+			for {
+				err := condition.Check()
+				if err == nil {
+					lc.Infof("Condition %s is met", condition.String())
+					return
+				}
+				lc.Infof("Condition %s is not met yet: %v. Sleeping %s", condition.String(), err, retryInterval)
+				select {
+				case <-stop:
+					return
+				case <-time.After(retryInterval):
+				}
+			}
+		}(condition)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		expired = time.After(timeout)
+	}
+	select {
+	case <-done:
+		return nil
+	case <-expired:
+		return fmt.Errorf("Timeout after %s waiting on dependencies to become available: %v", timeout, conditions)
+	}
+}
+
+type fileCondition struct {
+	uri url.URL
+}
+
+func (c fileCondition) Check() error {
+	_, err := os.Stat(c.uri.Path)
+	return err
+}
+
+func (c fileCondition) String() string {
+	return c.uri.String()
+}
+
+type socketCondition struct {
+	uri     url.URL
+	address string
+	timeout time.Duration
+}
+
+func (c socketCondition) Check() error {
+	conn, err := net.DialTimeout(c.uri.Scheme, c.address, c.timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (c socketCondition) String() string {
+	return c.uri.String()
+}
+
+type httpCondition struct {
+	uri    url.URL
+	client *http.Client
+}
+
+func (c httpCondition) Check() error {
+	resp, err := c.client.Get(c.uri.String())
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	// dependency is treated as ok if http status code is between 200 and 300
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("received status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c httpCondition) String() string {
+	return c.uri.String()
+}
+
+type dnsCondition struct {
+	uri url.URL
+}
+
+func (c dnsCondition) Check() error {
+	_, err := net.LookupHost(c.uri.Hostname())
+	return err
+}
+
+func (c dnsCondition) String() string {
+	return c.uri.String()
+}
+
+type redisCondition struct {
+	uri     url.URL
+	timeout time.Duration
+}
+
+func (c redisCondition) Check() error {
+	conn, err := net.DialTimeout("tcp", c.uri.Host, c.timeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if c.timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+			return err
+		}
+	}
+
+	reader := bufio.NewReader(conn)
+	if password, ok := c.uri.User.Password(); ok {
+		if err := redisCommand(conn, reader, "+OK", "AUTH", password); err != nil {
+			return err
+		}
+	}
+	return redisCommand(conn, reader, "+PONG", "PING")
+}
+
+// String hides the password of the condition
+func (c redisCondition) String() string {
+	return c.uri.Redacted()
+}
+
+// redisCommand sends a command in the Redis serialization protocol and checks that the reply is expected
+func redisCommand(conn net.Conn, reader *bufio.Reader, expected string, args ...string) error {
+	var command strings.Builder
+	command.WriteString(fmt.Sprintf("*%d\r\n", len(args)))
+	for _, arg := range args {
+		command.WriteString(fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg))
+	}
+	if _, err := conn.Write([]byte(command.String())); err != nil {
+		return err
+	}
+
+	reply, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	reply = strings.TrimRight(reply, "\r\n")
+	if reply != expected {
+		if strings.HasPrefix(reply, "-") {
+			return errors.New(strings.TrimPrefix(reply, "-"))
+		}
+		return fmt.Errorf("unexpected reply %s to %s", reply, args[0])
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package condition
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTimeout = time.Second
+
+// startRedis starts a fake Redis server answering PING, and AUTH with password, and returns its address
+func startRedis(t *testing.T, password string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				authenticated := password == ""
+				for {
+					// read the array header, then each bulk string length and value
+					header, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					var args []string
+					for i := 0; i < int(header[1]-'0'); i++ {
+						_, _ = reader.ReadString('\n')
+						arg, _ := reader.ReadString('\n')
+						args = append(args, strings.TrimRight(arg, "\r\n"))
+					}
+					switch {
+					case args[0] == "AUTH" && args[1] == password:
+						authenticated = true
+						_, _ = conn.Write([]byte("+OK\r\n"))
+					case args[0] == "AUTH":
+						_, _ = conn.Write([]byte("-WRONGPASS invalid password\r\n"))
+					case !authenticated:
+						_, _ = conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+					default:
+						_, _ = conn.Write([]byte("+PONG\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		expectedErr bool
+	}{
+		{"tcp", "tcp://localhost:1234", false},
+		{"unix", "unix:///var/run/socket", false},
+		{"http", "http://localhost:8200/v1/sys/health", false},
+		{"file", "file:///tmp/file", false},
+		{"dns", "dns://edgex-vault", false},
+		{"redis", "redis://:password@edgex-redis:6379", false},
+		{"dns without host", "dns://", true},
+		{"redis without address", "redis://", true},
+		{"unsupported protocol", "chrome://settings", true},
+		{"malformed", "_http!@xxxxxx:1111", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := Parse(tt.uri, testTimeout)
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, condition)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer okServer.Close()
+	sealedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer sealedServer.Close()
+
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	redis := startRedis(t, "")
+	securedRedis := startRedis(t, "secret")
+
+	tests := []struct {
+		name string
+		uri  string
+		met  bool
+	}{
+		{"tcp open", "tcp://" + listener.Addr().String(), true},
+		{"http 200", okServer.URL, true},
+		{"http 503", sealedServer.URL, false},
+		{"existing file", "file://" + workingDir + "/condition_test.go", true},
+		{"missing file", "file://" + workingDir + "/missing", false},
+		{"resolved host", "dns://localhost", true},
+		{"redis", "redis://" + redis, true},
+		{"redis with password", "redis://:secret@" + securedRedis, true},
+		{"redis without password", "redis://" + securedRedis, false},
+		{"redis with wrong password", "redis://:wrong@" + securedRedis, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := Parse(tt.uri, testTimeout)
+			require.NoError(t, err)
+			if tt.met {
+				assert.NoError(t, condition.Check())
+			} else {
+				assert.Error(t, condition.Check())
+			}
+		})
+	}
+}
+
+func TestRedisConditionHidesPassword(t *testing.T) {
+	condition, err := Parse("redis://:secret@edgex-redis:6379", testTimeout)
+	require.NoError(t, err)
+	assert.NotContains(t, condition.String(), "secret")
+}
+
+func TestWait(t *testing.T) {
+	lc := logger.MockLogger{}
+	dir := t.TempDir()
+	file := dir + "/ready"
+
+	conditions, err := ParseAll([]string{"file://" + file}, testTimeout)
+	require.NoError(t, err)
+
+	err = Wait(conditions, 300*time.Millisecond, 100*time.Millisecond, lc)
+	require.Error(t, err, "the file doesn't exist yet")
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = ioutil.WriteFile(file, []byte{}, 0600)
+	}()
+	require.NoError(t, Wait(conditions, 0, 100*time.Millisecond, lc))
+}
@@ -20,4 +20,8 @@ package config
 type WaitForInfo struct {
 	Timeout       string
 	RetryInterval string
+	// Stages maps the name of a stage to the readiness conditions it waits for, written as URIs
+	// (tcp, unix, http, file, dns or redis). The gate command waits for the conditions of the
+	// Registry, KongDB and Database stages once their ready port is open.
+	Stages map[string][]string
 }
@@ -14,12 +14,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 )
 
 const (
@@ -33,6 +35,7 @@ type cmd struct {
 	privateKeyPath string
 	jwtID          string
 	expiration     string
+	roles          string
 }
 
 func NewCommand(
@@ -53,6 +56,7 @@ func NewCommand(
 	flagSet.StringVar(&cmd.privateKeyPath, "private_key", "", "Private key used to sign the JWT (PEM-encoded)")
 	flagSet.StringVar(&cmd.jwtID, "id", "", "The 'key' field (ID) from the 'adduser' command")
 	flagSet.StringVar(&cmd.expiration, "expiration", "1h", "Duration of generated jwt expressed as a golang-parseable duration value (default: 1h)")
+	flagSet.StringVar(&cmd.roles, "roles", "", "Comma-separated roles checked by services that enforce JWTAuth policies")
 
 	err := flagSet.Parse(args)
 	if err != nil {
@@ -73,10 +77,14 @@ func NewCommand(
 
 func (c *cmd) Execute() (int, error) {
 	now := time.Now().Unix()
-	claims := &jwt.StandardClaims{
-		Issuer:    c.jwtID,
-		IssuedAt:  now,
-		NotBefore: now,
+	claims := &jwtauth.Claims{
+		StandardClaims: jwt.StandardClaims{
+			// the id of the token revokes it alone
+			Id:        uuid.New().String(),
+			Issuer:    c.jwtID,
+			IssuedAt:  now,
+			NotBefore: now,
+		},
 	}
 	if len(c.expiration) > 0 {
 		duration, err := time.ParseDuration(c.expiration)
@@ -85,6 +93,11 @@ func (c *cmd) Execute() (int, error) {
 		}
 		claims.ExpiresAt = now + int64(duration.Seconds())
 	}
+	for _, role := range strings.Split(c.roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			claims.Roles = append(claims.Roles, role)
+		}
+	}
 
 	bytes, err := ioutil.ReadFile(c.privateKeyPath)
 	if err != nil {
@@ -66,6 +66,16 @@ func TestJWTGenerateRSA(t *testing.T) {
 	})
 }
 
+// TestJWTGenerateWithRoles tests JWT generation with a roles claim
+func TestJWTGenerateWithRoles(t *testing.T) {
+	generateWithArgs(t, []string{
+		"--algorithm", "RS256",
+		"--private_key", "testdata/rsa.key",
+		"--id", "7f3ab74c-3bc2-4635-bc28-161f7f7ef246",
+		"--roles", "admin, device",
+	})
+}
+
 // TestJWTGenerateECDSA tests ECDSA JWT generation
 func TestJWTGenerateECDSA(t *testing.T) {
 	generateWithArgs(t, []string{
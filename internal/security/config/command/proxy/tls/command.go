@@ -8,6 +8,8 @@ package tls
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -20,6 +22,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/common"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
 	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
@@ -97,6 +100,19 @@ func (c *cmd) readCertKeyPairFromFiles() (*bootstrapConfig.CertKeyPair, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Failed to read private key from file %s: %w", c.privateKeyPath, err)
 	}
+	if fips.Enabled {
+		pair, err := tls.X509KeyPair(certPem, prvKey)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse TLS certificate and private key: %w", err)
+		}
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse TLS certificate: %w", err)
+		}
+		if err := fips.CheckPublicKey(cert.PublicKey); err != nil {
+			return nil, fmt.Errorf("TLS certificate from file %s rejected: %w", c.certificatePath, err)
+		}
+	}
 	return &bootstrapConfig.CertKeyPair{Cert: string(certPem), Key: string(prvKey)}, nil
 }
 
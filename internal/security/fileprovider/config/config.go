@@ -37,6 +37,24 @@ type TokenFileProviderInfo struct {
 	OutputDir string
 	// File name for token file (default: secrets-token.json)
 	OutputFilename string
+	// Output backend for generated tokens: "file" (default) or "kubernetes"
+	OutputBackend string
+	// Settings for the "kubernetes" output backend
+	Kubernetes KubernetesInfo
+}
+
+// KubernetesInfo configures writing tokens to Kubernetes Secrets via the API server
+type KubernetesInfo struct {
+	// Base URL of the API server (default: https://kubernetes.default.svc)
+	APIServer string
+	// Namespace of the generated secrets; if empty the pod's own namespace is used
+	Namespace string
+	// Prefix prepended to the service name to form the secret name
+	SecretPrefix string
+	// Labels applied to each generated secret
+	Labels map[string]string
+	// Directory holding the pod's service account token, ca.crt and namespace files
+	ServiceAccountDir string
 }
 
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
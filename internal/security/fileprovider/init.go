@@ -19,10 +19,14 @@ package fileprovider
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/container"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
 
@@ -30,6 +34,8 @@ import (
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
 	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/authtokenloader"
 	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
 )
@@ -74,7 +80,19 @@ func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ sta
 	vaultHost := fmt.Sprintf("%s:%v", cfg.SecretService.Server, cfg.SecretService.Port)
 	vaultClient := secretstoreclient.NewSecretStoreClient(lc, req, vaultProtocol, vaultHost)
 
-	fileProvider := NewTokenProvider(lc, fileOpener, tokenProvider, vaultClient)
+	var fileProvider TokenProvider
+	if cfg.TokenFileProvider.OutputBackend == KubernetesOutputBackend {
+		lc.Info("writing tokens to kubernetes secrets")
+		tokenWriter, err := makeKubernetesSecretWriter(lc, fileOpener, cfg.TokenFileProvider)
+		if err != nil {
+			lc.Error(fmt.Sprintf("failed to configure kubernetes output backend: %s", err.Error()))
+			b.exitCode = 1
+			return false
+		}
+		fileProvider = NewTokenProviderWithWriter(lc, fileOpener, tokenProvider, vaultClient, tokenWriter)
+	} else {
+		fileProvider = NewTokenProvider(lc, fileOpener, tokenProvider, vaultClient)
+	}
 
 	fileProvider.SetConfiguration(cfg.SecretService, cfg.TokenFileProvider)
 	err := fileProvider.Run()
@@ -86,3 +104,53 @@ func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ sta
 
 	return false // Tell bootstrap.Run() to exit wait loop and terminate
 }
+
+// makeKubernetesSecretWriter creates the kubernetes output backend using the pod's service account credentials
+func makeKubernetesSecretWriter(lc logger.LoggingClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	tokenConfig config.TokenFileProviderInfo) (TokenWriter, error) {
+
+	serviceAccountDir := tokenConfig.Kubernetes.ServiceAccountDir
+	if serviceAccountDir == "" {
+		serviceAccountDir = DefaultServiceAccountDir
+	}
+
+	bearerToken, err := readTrimmedFile(fileOpener, filepath.Join(serviceAccountDir, "token"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %s", err.Error())
+	}
+
+	namespace := tokenConfig.Kubernetes.Namespace
+	if namespace == "" {
+		namespace, err = readTrimmedFile(fileOpener, filepath.Join(serviceAccountDir, "namespace"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account namespace: %s", err.Error())
+		}
+	}
+
+	caReader, err := fileOpener.OpenFileReader(filepath.Join(serviceAccountDir, "ca.crt"), os.O_RDONLY, 0400)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API server CA certificate: %s", err.Error())
+	}
+	client := secretstoreclient.NewRequestor(lc).WithTLS(caReader, "")
+	if client == nil {
+		return nil, fmt.Errorf("failed to create API server client")
+	}
+
+	return NewKubernetesSecretWriter(lc, client, tokenConfig.Kubernetes, bearerToken, namespace, tokenConfig.OutputFilename), nil
+}
+
+func readTrimmedFile(fileOpener fileioperformer.FileIoPerformer, path string) (string, error) {
+	reader, err := fileOpener.OpenFileReader(path, os.O_RDONLY, 0400)
+	if err != nil {
+		return "", err
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	defer readCloser.Close()
+
+	contents, err := ioutil.ReadAll(readCloser)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(contents)), nil
+}
@@ -29,3 +29,10 @@ type TokenProvider interface {
 	// Generate tokens
 	Run() error
 }
+
+// TokenWriter is the interface for output backends other than
+// the default file system output
+type TokenWriter interface {
+	// Persist the token created for the named service
+	WriteToken(serviceName string, serviceConfig ServiceKey, token interface{}) error
+}
@@ -0,0 +1,195 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package fileprovider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	KubernetesOutputBackend     = "kubernetes"
+	DefaultKubernetesAPIServer  = "https://kubernetes.default.svc"
+	DefaultServiceAccountDir    = "/var/run/secrets/kubernetes.io/serviceaccount"
+	kubernetesSecretsPath       = "/api/v1/namespaces/%s/secrets"
+	kubernetesSecretPath        = "/api/v1/namespaces/%s/secrets/%s"
+	kubernetesManagedByLabel    = "app.kubernetes.io/managed-by"
+	kubernetesManagedByValue    = "edgex-security-file-token-provider"
+	kubernetesServiceLabel      = "edgex.io/service-name"
+	kubernetesMaxNameLength     = 253
+	kubernetesMaxLabelLength    = 63
+	kubernetesInvalidNameRegexp = `[^a-z0-9.-]+`
+)
+
+// kubernetesSecret is the subset of the core/v1 Secret resource used by the token writer
+type kubernetesSecret struct {
+	APIVersion string                   `json:"apiVersion"`
+	Kind       string                   `json:"kind"`
+	Metadata   kubernetesObjectMetadata `json:"metadata"`
+	Type       string                   `json:"type"`
+	Data       map[string]string        `json:"data"`
+}
+
+type kubernetesObjectMetadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// kubernetesSecretWriter writes service tokens to Kubernetes Secrets via the API server
+type kubernetesSecretWriter struct {
+	logger      logger.LoggingClient
+	client      internal.HttpCaller
+	apiServer   string
+	bearerToken string
+	namespace   string
+	prefix      string
+	labels      map[string]string
+	dataKey     string
+}
+
+// NewKubernetesSecretWriter creates a TokenWriter that stores each token under dataKey
+// in a Secret named {SecretPrefix}{serviceName} in the configured namespace
+func NewKubernetesSecretWriter(logger logger.LoggingClient,
+	client internal.HttpCaller,
+	info config.KubernetesInfo,
+	bearerToken string,
+	namespace string,
+	dataKey string) TokenWriter {
+
+	apiServer := info.APIServer
+	if apiServer == "" {
+		apiServer = DefaultKubernetesAPIServer
+	}
+
+	return &kubernetesSecretWriter{
+		logger:      logger,
+		client:      client,
+		apiServer:   strings.TrimSuffix(apiServer, "/"),
+		bearerToken: bearerToken,
+		namespace:   namespace,
+		prefix:      info.SecretPrefix,
+		labels:      info.Labels,
+		dataKey:     dataKey,
+	}
+}
+
+// WriteToken creates the service's secret, or replaces it if it already exists
+func (w *kubernetesSecretWriter) WriteToken(serviceName string, _ ServiceKey, token interface{}) error {
+	tokenBytes, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]string{
+		kubernetesManagedByLabel: kubernetesManagedByValue,
+		kubernetesServiceLabel:   labelValue(serviceName),
+	}
+	for k, v := range w.labels {
+		labels[k] = v
+	}
+
+	secret := kubernetesSecret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata: kubernetesObjectMetadata{
+			Name:      secretName(w.prefix, serviceName),
+			Namespace: w.namespace,
+			Labels:    labels,
+		},
+		Type: "Opaque",
+		Data: map[string]string{
+			// json.Encoder output used by the file backend ends in a newline; keep the payloads identical
+			w.dataKey: base64.StdEncoding.EncodeToString(append(tokenBytes, '\n')),
+		},
+	}
+
+	body, err := json.Marshal(secret)
+	if err != nil {
+		return err
+	}
+
+	w.logger.Info(fmt.Sprintf("creating kubernetes secret %s/%s", w.namespace, secret.Metadata.Name))
+	code, err := w.do(http.MethodPost, fmt.Sprintf(kubernetesSecretsPath, w.namespace), body)
+	if err != nil {
+		return err
+	}
+
+	switch code {
+	case http.StatusCreated, http.StatusOK:
+		return nil
+	case http.StatusConflict:
+		w.logger.Info(fmt.Sprintf("kubernetes secret %s/%s exists, replacing it", w.namespace, secret.Metadata.Name))
+		code, err = w.do(http.MethodPut, fmt.Sprintf(kubernetesSecretPath, w.namespace, secret.Metadata.Name), body)
+		if err != nil {
+			return err
+		}
+		if code != http.StatusOK {
+			return fmt.Errorf("failed to replace kubernetes secret %s: status %d", secret.Metadata.Name, code)
+		}
+		return nil
+	default:
+		return fmt.Errorf("failed to create kubernetes secret %s: status %d", secret.Metadata.Name, code)
+	}
+}
+
+func (w *kubernetesSecretWriter) do(method string, path string, body []byte) (int, error) {
+	req, err := http.NewRequest(method, w.apiServer+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+w.bearerToken)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// secretName converts prefix+serviceName into a valid Kubernetes object name (RFC 1123 subdomain)
+func secretName(prefix string, serviceName string) string {
+	name := strings.ToLower(prefix + serviceName)
+	name = regexp.MustCompile(kubernetesInvalidNameRegexp).ReplaceAllString(name, "-")
+	name = strings.Trim(name, "-.")
+	if len(name) > kubernetesMaxNameLength {
+		name = strings.Trim(name[:kubernetesMaxNameLength], "-.")
+	}
+	return name
+}
+
+// labelValue converts serviceName into a valid Kubernetes label value
+func labelValue(serviceName string) string {
+	value := secretName("", serviceName)
+	if len(value) > kubernetesMaxLabelLength {
+		value = strings.Trim(value[:kubernetesMaxLabelLength], "-.")
+	}
+	return value
+}
@@ -0,0 +1,121 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package fileprovider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubernetesSecretWriterCreate(t *testing.T) {
+	// Arrange
+	mockLogger := logger.MockLogger{}
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/namespaces/edgex/secrets", r.URL.EscapedPath())
+		assert.Equal(t, "Bearer sa-token", r.Header.Get("Authorization"))
+
+		var secret kubernetesSecret
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&secret))
+		assert.Equal(t, "edgex-token-edgex-core-data", secret.Metadata.Name)
+		assert.Equal(t, "edgex", secret.Metadata.Namespace)
+		assert.Equal(t, "edgex-core-data", secret.Metadata.Labels[kubernetesServiceLabel])
+		assert.Equal(t, "custom", secret.Metadata.Labels["tier"])
+		token, err := base64.StdEncoding.DecodeString(secret.Data[outputFilename])
+		require.NoError(t, err)
+		assert.Equal(t, "{\"auth\":{\"client_token\":\"s.token\"}}\n", string(token))
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	info := config.KubernetesInfo{
+		APIServer:    ts.URL,
+		SecretPrefix: "edgex-token-",
+		Labels:       map[string]string{"tier": "custom"},
+	}
+	writer := NewKubernetesSecretWriter(mockLogger, secretstoreclient.NewRequestor(mockLogger).Insecure(), info, "sa-token", "edgex", outputFilename)
+	token := map[string]interface{}{"auth": map[string]interface{}{"client_token": "s.token"}}
+
+	// Act
+	err := writer.WriteToken("edgex-core-data", ServiceKey{}, token)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestKubernetesSecretWriterReplace(t *testing.T) {
+	// Arrange
+	mockLogger := logger.MockLogger{}
+	var methods []string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+		case http.MethodPut:
+			assert.Equal(t, "/api/v1/namespaces/edgex/secrets/myservice", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	info := config.KubernetesInfo{APIServer: ts.URL}
+	writer := NewKubernetesSecretWriter(mockLogger, secretstoreclient.NewRequestor(mockLogger).Insecure(), info, "sa-token", "edgex", outputFilename)
+
+	// Act
+	err := writer.WriteToken("myservice", ServiceKey{}, map[string]interface{}{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{http.MethodPost, http.MethodPut}, methods)
+}
+
+func TestKubernetesSecretWriterForbidden(t *testing.T) {
+	// Arrange
+	mockLogger := logger.MockLogger{}
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	info := config.KubernetesInfo{APIServer: ts.URL}
+	writer := NewKubernetesSecretWriter(mockLogger, secretstoreclient.NewRequestor(mockLogger).Insecure(), info, "sa-token", "edgex", outputFilename)
+
+	// Act
+	err := writer.WriteToken("myservice", ServiceKey{}, map[string]interface{}{})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestSecretName(t *testing.T) {
+	assert.Equal(t, "edgex-token-myservice", secretName("edgex-token-", "myservice"))
+	assert.Equal(t, "test.service-name", secretName("", "Test.Service~Name"))
+	assert.Equal(t, "app", secretName("", "-app-"))
+}
@@ -17,9 +17,11 @@ package fileprovider
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configflags"
 	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/container"
 
@@ -45,8 +47,14 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 	//      flags.Parse(os.Args[1:])
 	//
 
-	f := flags.New()
+	var configFlags configflags.Flags
+	f := flags.NewWithUsage(configflags.Usage)
+	configFlags.Register(f.FlagSet)
 	f.Parse(os.Args[1:])
+	if err := configFlags.Apply(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 
 	configuration := &config.ConfigurationStruct{}
 	dic := di.NewContainer(di.ServiceConstructorMap{
@@ -67,6 +75,7 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 		startupTimer,
 		dic,
 		[]interfaces.BootstrapHandler{
+			configflags.NewHandler(&configFlags, configuration).BootstrapHandler,
 			bootStrapper.BootstrapHandler,
 		},
 	)
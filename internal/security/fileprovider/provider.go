@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 
 	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/config"
@@ -46,6 +47,7 @@ type fileTokenProvider struct {
 	vaultClient   secretstoreclient.SecretStoreClient
 	secretConfig  secretstoreclient.SecretServiceInfo
 	tokenConfig   config.TokenFileProviderInfo
+	tokenWriter   TokenWriter
 }
 
 // NewTokenProvider creates a new TokenProvider
@@ -61,6 +63,22 @@ func NewTokenProvider(logger logger.LoggingClient,
 	}
 }
 
+// NewTokenProviderWithWriter creates a new TokenProvider that hands generated tokens
+// to tokenWriter instead of writing them to the file system
+func NewTokenProviderWithWriter(logger logger.LoggingClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	tokenProvider authtokenloader.AuthTokenLoader,
+	vaultClient secretstoreclient.SecretStoreClient,
+	tokenWriter TokenWriter) TokenProvider {
+	return &fileTokenProvider{
+		logger:        logger,
+		fileOpener:    fileOpener,
+		tokenProvider: tokenProvider,
+		vaultClient:   vaultClient,
+		tokenWriter:   tokenWriter,
+	}
+}
+
 // Set configuration
 func (p *fileTokenProvider) SetConfiguration(secretConfig secretstoreclient.SecretServiceInfo, tokenConfig config.TokenFileProviderInfo) {
 	p.secretConfig = secretConfig
@@ -93,7 +111,14 @@ func (p *fileTokenProvider) Run() error {
 	// note that the configuration file takes precedence, as the tokenConf will override
 	// the tokenConfEnv with same duplicate keys
 	// The tokenConfEnv only uses default settings.
-	tokenConf = tokenConfEnv.mergeWith(tokenConf)
+	// Services from the environment variable pick up the settings of a matching
+	// wildcard entry in the configuration file, if there is one
+	tokenConfEnv, err = tokenConfEnv.applyWildcards(tokenConf)
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("failed to apply wildcard service entries: %s", err.Error()))
+		return err
+	}
+	tokenConf = tokenConfEnv.mergeWith(tokenConf.withoutWildcards())
 
 	for serviceName, serviceConfig := range tokenConf {
 		p.logger.Info(fmt.Sprintf("generating policy/token defaults for service %s", serviceName))
@@ -108,7 +133,11 @@ func (p *fileTokenProvider) Run() error {
 		}
 
 		if serviceConfig.CustomPolicy != nil {
-			customPolicy := serviceConfig.CustomPolicy
+			customPolicy, err := expandPolicyTemplate(serviceConfig.CustomPolicy, serviceName)
+			if err != nil {
+				p.logger.Error(fmt.Sprintf("failed to expand custom policy for %s: %s", serviceName, err.Error()))
+				return err
+			}
 			if customPolicy["path"] != nil {
 				customPaths := customPolicy["path"].(map[string]interface{})
 				if servicePolicy["path"] == nil {
@@ -125,6 +154,14 @@ func (p *fileTokenProvider) Run() error {
 			createTokenParameters = mergeMaps(createTokenParameters, serviceConfig.CustomTokenParameters)
 		}
 
+		// Explicit TTL/period settings take precedence over custom token parameters
+		if serviceConfig.TokenTTL != "" {
+			createTokenParameters["ttl"] = serviceConfig.TokenTTL
+		}
+		if serviceConfig.TokenPeriod != "" {
+			createTokenParameters["period"] = serviceConfig.TokenPeriod
+		}
+
 		// Set a meta property that consuming serices can use to automatically scope secret queries
 		createTokenParameters["meta"] = map[string]interface{}{
 			"edgex-service-name": serviceName,
@@ -139,11 +176,20 @@ func (p *fileTokenProvider) Run() error {
 			return err
 		}
 
+		// Installing a policy overwrites any previous version, so policies are regenerated on each run
 		if _, err := p.vaultClient.InstallPolicy(privilegedToken, policyName, string(policyBytes)); err != nil {
 			p.logger.Error(fmt.Sprintf("failed to install policy %s: %s", policyName, err.Error()))
 			return err
 		}
 
+		additionalPolicyNames, err := p.installAdditionalPolicies(privilegedToken, serviceName, policyName, serviceConfig.AdditionalPolicies)
+		if err != nil {
+			return err
+		}
+		if len(additionalPolicyNames) > 0 {
+			createTokenParameters["policies"] = appendPolicies(createTokenParameters["policies"], additionalPolicyNames)
+		}
+
 		var createTokenResponse interface{}
 
 		if _, err = p.vaultClient.CreateToken(privilegedToken, createTokenParameters, &createTokenResponse); err != nil {
@@ -151,68 +197,121 @@ func (p *fileTokenProvider) Run() error {
 			return err
 		}
 
-		outputTokenDir := filepath.Join(p.tokenConfig.OutputDir, serviceName)
-		outputTokenFilename := filepath.Join(outputTokenDir, p.tokenConfig.OutputFilename)
-		if err := p.fileOpener.MkdirAll(outputTokenDir, os.FileMode(0700)); err != nil {
-			p.logger.Error(fmt.Sprintf("failed to create base directory path(s) %s: %s", outputTokenDir, err.Error()))
+		if p.tokenWriter != nil {
+			if err := p.tokenWriter.WriteToken(serviceName, serviceConfig, createTokenResponse); err != nil {
+				p.logger.Error(fmt.Sprintf("failed to write token for service %s: %s", serviceName, err.Error()))
+				return err
+			}
+		} else if err := p.writeTokenFile(serviceName, serviceConfig, createTokenResponse); err != nil {
 			return err
 		}
+	}
 
-		p.logger.Info(fmt.Sprintf("creating token file %s", outputTokenFilename))
-		writeCloser, err := p.fileOpener.OpenFileWriter(outputTokenFilename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0600))
+	return nil
+}
+
+// installAdditionalPolicies installs each of the service's additional policy documents
+// as <policyName>-<key> and returns the names of the installed policies
+func (p *fileTokenProvider) installAdditionalPolicies(privilegedToken string,
+	serviceName string,
+	policyName string,
+	additionalPolicies map[string]map[string]interface{}) ([]string, error) {
+
+	keys := make([]string, 0, len(additionalPolicies))
+	for key := range additionalPolicies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	installed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		additionalPolicyName := policyName + "-" + key
+
+		policy, err := expandPolicyTemplate(additionalPolicies[key], serviceName)
 		if err != nil {
-			p.logger.Error(fmt.Sprintf("failed open token file for writing %s: %s", outputTokenFilename, err.Error()))
-			return err
-		}
-		// writeCloser is writable file -- explicitly close() to ensure we catch errors writing to it
-
-		permissionable, ok := writeCloser.(permissionable)
-		if ok {
-			if serviceConfig.FilePermissions != nil &&
-				(serviceConfig.FilePermissions).ModeOctal != nil {
-				mode, err := strconv.ParseInt(*(serviceConfig.FilePermissions).ModeOctal, 8, 32)
-				if err != nil {
-					_ = writeCloser.Close()
-					p.logger.Error(fmt.Sprintf("invalid file mode %s: %s", *(serviceConfig.FilePermissions).ModeOctal, err.Error()))
-					return err
-				}
-				if err := permissionable.Chmod(os.FileMode(mode)); err != nil {
-					_ = writeCloser.Close()
-					p.logger.Error(fmt.Sprintf("failed to set file mode on %s: %s", outputTokenFilename, err.Error()))
-					return err
-				}
-			}
-			if serviceConfig.FilePermissions != nil &&
-				(serviceConfig.FilePermissions).Uid != nil &&
-				(serviceConfig.FilePermissions).Gid != nil {
-				err := permissionable.Chown(*(serviceConfig.FilePermissions).Uid, *(serviceConfig.FilePermissions).Gid)
-				if err != nil {
-					_ = writeCloser.Close()
-					p.logger.Error(fmt.Sprintf("failed to set file user/group on %s: %s", outputTokenFilename, err.Error()))
-					return err
-				}
-			}
+			p.logger.Error(fmt.Sprintf("failed to expand policy %s: %s", additionalPolicyName, err.Error()))
+			return nil, err
 		}
 
-		encoder := json.NewEncoder(writeCloser)
-		if encoder == nil {
-			_ = writeCloser.Close()
-			err = fmt.Errorf("unable to create JSON output encoder")
-			return err
+		policyBytes, err := json.Marshal(policy)
+		if err != nil {
+			p.logger.Error(fmt.Sprintf("failed encode policy %s: %s", additionalPolicyName, err.Error()))
+			return nil, err
 		}
 
-		// Write resulting token
-		if err := encoder.Encode(createTokenResponse); err != nil {
-			_ = writeCloser.Close()
-			p.logger.Error(fmt.Sprintf("failed to write token file: %s", err.Error()))
-			return err
+		if _, err := p.vaultClient.InstallPolicy(privilegedToken, additionalPolicyName, string(policyBytes)); err != nil {
+			p.logger.Error(fmt.Sprintf("failed to install policy %s: %s", additionalPolicyName, err.Error()))
+			return nil, err
 		}
+		installed = append(installed, additionalPolicyName)
+	}
 
-		if err := writeCloser.Close(); err != nil {
-			p.logger.Error(fmt.Sprintf("failed to close %s: %s", outputTokenFilename, err.Error()))
-			return err
+	return installed, nil
+}
+
+// writeTokenFile writes the token to {OutputDir}/{serviceName}/{OutputFilename}
+func (p *fileTokenProvider) writeTokenFile(serviceName string, serviceConfig ServiceKey, createTokenResponse interface{}) error {
+	outputTokenDir := filepath.Join(p.tokenConfig.OutputDir, serviceName)
+	outputTokenFilename := filepath.Join(outputTokenDir, p.tokenConfig.OutputFilename)
+	if err := p.fileOpener.MkdirAll(outputTokenDir, os.FileMode(0700)); err != nil {
+		p.logger.Error(fmt.Sprintf("failed to create base directory path(s) %s: %s", outputTokenDir, err.Error()))
+		return err
+	}
+
+	p.logger.Info(fmt.Sprintf("creating token file %s", outputTokenFilename))
+	writeCloser, err := p.fileOpener.OpenFileWriter(outputTokenFilename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0600))
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("failed open token file for writing %s: %s", outputTokenFilename, err.Error()))
+		return err
+	}
+	// writeCloser is writable file -- explicitly close() to ensure we catch errors writing to it
+
+	permissionable, ok := writeCloser.(permissionable)
+	if ok {
+		if serviceConfig.FilePermissions != nil &&
+			(serviceConfig.FilePermissions).ModeOctal != nil {
+			mode, err := strconv.ParseInt(*(serviceConfig.FilePermissions).ModeOctal, 8, 32)
+			if err != nil {
+				_ = writeCloser.Close()
+				p.logger.Error(fmt.Sprintf("invalid file mode %s: %s", *(serviceConfig.FilePermissions).ModeOctal, err.Error()))
+				return err
+			}
+			if err := permissionable.Chmod(os.FileMode(mode)); err != nil {
+				_ = writeCloser.Close()
+				p.logger.Error(fmt.Sprintf("failed to set file mode on %s: %s", outputTokenFilename, err.Error()))
+				return err
+			}
+		}
+		if serviceConfig.FilePermissions != nil &&
+			(serviceConfig.FilePermissions).Uid != nil &&
+			(serviceConfig.FilePermissions).Gid != nil {
+			err := permissionable.Chown(*(serviceConfig.FilePermissions).Uid, *(serviceConfig.FilePermissions).Gid)
+			if err != nil {
+				_ = writeCloser.Close()
+				p.logger.Error(fmt.Sprintf("failed to set file user/group on %s: %s", outputTokenFilename, err.Error()))
+				return err
+			}
 		}
 	}
 
+	encoder := json.NewEncoder(writeCloser)
+	if encoder == nil {
+		_ = writeCloser.Close()
+		err = fmt.Errorf("unable to create JSON output encoder")
+		return err
+	}
+
+	// Write resulting token
+	if err := encoder.Encode(createTokenResponse); err != nil {
+		_ = writeCloser.Close()
+		p.logger.Error(fmt.Sprintf("failed to write token file: %s", err.Error()))
+		return err
+	}
+
+	if err := writeCloser.Close(); err != nil {
+		p.logger.Error(fmt.Sprintf("failed to close %s: %s", outputTokenFilename, err.Error()))
+		return err
+	}
+
 	return nil
 }
@@ -326,6 +326,62 @@ func TestTokenFilePermissions(t *testing.T) {
 	mockSecretStoreClient.AssertExpectations(t)
 	assert.Equal(t, expectedTokenFile("myservice"), service1Buffer.Bytes())
 }
+
+// TestAdditionalPoliciesAndTokenOverrides
+func TestAdditionalPoliciesAndTokenOverrides(t *testing.T) {
+	// Arrange
+	mockLogger := logger.MockLogger{}
+
+	mockFileIoPerformer := &fileMock.FileIoPerformer{}
+	expectedService1Dir := filepath.Join(outputDir, "myservice")
+	expectedService1File := filepath.Join(expectedService1Dir, outputFilename)
+	service1Buffer := new(bytes.Buffer)
+	mockFileIoPerformer.On("MkdirAll", expectedService1Dir, os.FileMode(0700)).Return(nil)
+	mockFileIoPerformer.On("OpenFileReader", configFile, os.O_RDONLY, os.FileMode(0400)).Return(strings.NewReader(
+		`{"myservice":{"edgex_use_defaults":true,"token_ttl":"4h","token_period":"2h",`+
+			`"additional_policies":{"appdata":{"path":{"secret/appdata/{{.ServiceName}}":{"capabilities":["read"]}}}}}}`), nil)
+	mockFileIoPerformer.On("OpenFileWriter", expectedService1File, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0600)).Return(&writeCloserBuffer{service1Buffer}, nil)
+
+	mockAuthTokenLoader := &loaderMock.AuthTokenLoader{}
+	mockAuthTokenLoader.On("Load", privilegedTokenPath).Return("fake-priv-token", nil)
+
+	expectedService1Policy := `{"path":{"secret/edgex/myservice/*":{"capabilities":["create","update","delete","list","read"]}}}`
+	expectedAdditionalPolicy := `{"path":{"secret/appdata/myservice":{"capabilities":["read"]}}}`
+	expectedService1Parameters := makeDefaultTokenParameters("myservice")
+	expectedService1Parameters["ttl"] = "4h"
+	expectedService1Parameters["period"] = "2h"
+	expectedService1Parameters["policies"] = []string{"edgex-service-myservice", "edgex-service-myservice-appdata"}
+	expectedService1Parameters["meta"] = makeMetaServiceName("myservice")["meta"]
+	mockSecretStoreClient := &MockSecretStoreClient{}
+	mockSecretStoreClient.On("InstallPolicy", "fake-priv-token", "edgex-service-myservice", expectedService1Policy).Return(http.StatusNoContent, nil)
+	mockSecretStoreClient.On("InstallPolicy", "fake-priv-token", "edgex-service-myservice-appdata", expectedAdditionalPolicy).Return(http.StatusNoContent, nil)
+	mockSecretStoreClient.On("CreateToken", "fake-priv-token", expectedService1Parameters, mock.Anything).
+		Run(func(args mock.Arguments) {
+			setCreateTokenResponse(args.Get(2).(*interface{}))
+		}).
+		Return(http.StatusOK, nil)
+
+	p := NewTokenProvider(mockLogger, mockFileIoPerformer, mockAuthTokenLoader, mockSecretStoreClient)
+	p.SetConfiguration(secretstoreclient.SecretServiceInfo{}, config.TokenFileProviderInfo{
+		PrivilegedTokenPath: privilegedTokenPath,
+		ConfigFile:          configFile,
+		OutputDir:           outputDir,
+		OutputFilename:      outputFilename,
+	})
+
+	// Act
+	err := p.Run()
+
+	// Assert
+	// - Both the service policy and the templated additional policy are installed
+	// - Token is created with both policies and the TTL/period overrides
+	assert.NoError(t, err)
+	mockFileIoPerformer.AssertExpectations(t)
+	mockAuthTokenLoader.AssertExpectations(t)
+	mockSecretStoreClient.AssertExpectations(t)
+	assert.Equal(t, expectedTokenFile("myservice"), service1Buffer.Bytes())
+}
+
 func TestErrorLoading1(t *testing.T) {
 	// Arrange
 	mockLogger := logger.MockLogger{}
@@ -38,17 +38,42 @@ Example config file
       "gid": 0,
       "mode_octal": "0600"
 	}
+  },
+  "app-*": {
+    "edgex_use_defaults": true,
+    "additional_policies": {
+      "appdata": {
+        "path": {
+          "secret/edgex/appdata/{{.ServiceName}}/*": {
+            "capabilities": [ "list", "read" ]
+          }
+        }
+      }
+    },
+    "token_ttl": "4h",
+    "token_period": "4h"
   }
 }
 
+Keys containing wildcard characters (see path.Match) do not generate a token
+themselves; instead they supply the configuration for any service named in
+the ADD_SECRETSTORE_TOKENS environment variable that matches the pattern.
+
+Policy documents in custom_policy and additional_policies are Go templates;
+{{.ServiceName}} expands to the name of the service being processed.
+
 */
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"regexp"
+	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
 )
@@ -70,6 +95,15 @@ type ServiceKey struct {
 	CustomPolicy          map[string]interface{} `json:"custom_policy"` // JSON serialization of HCL
 	CustomTokenParameters map[string]interface{} `json:"custom_token_parameters"`
 	FilePermissions       *FilePermissions       `json:"file_permissions,omitempty"`
+	// AdditionalPolicies are installed as edgex-service-<service>-<key> and attached to the token
+	AdditionalPolicies map[string]map[string]interface{} `json:"additional_policies,omitempty"` // JSON serialization of HCL
+	TokenTTL           string                            `json:"token_ttl,omitempty"`
+	TokenPeriod        string                            `json:"token_period,omitempty"`
+}
+
+// policyTemplateData is the data available to policy document templates
+type policyTemplateData struct {
+	ServiceName string
 }
 
 func LoadTokenConfig(fileOpener fileioperformer.FileIoPerformer, path string, tokenConf *TokenConfFile) error {
@@ -174,3 +208,77 @@ func (tf TokenConfFile) keyExists(key string) bool {
 	_, exists := tf[key]
 	return exists
 }
+
+// isWildcard returns true if the key is a wildcard pattern rather than a service name
+func isWildcard(key string) bool {
+	return strings.ContainsAny(key, "*?[")
+}
+
+// applyWildcards function replaces the configuration of each service in tf
+// with that of the first wildcard entry of patterns (in lexical order) matching the service name.
+// Services not matching any wildcard are left unchanged.
+func (tf TokenConfFile) applyWildcards(patterns TokenConfFile) (TokenConfFile, error) {
+	wildcards := make([]string, 0)
+	for key := range patterns {
+		if isWildcard(key) {
+			wildcards = append(wildcards, key)
+		}
+	}
+	if len(wildcards) == 0 {
+		return tf, nil
+	}
+	sort.Strings(wildcards)
+
+	applied := make(TokenConfFile)
+	for serviceName, serviceConfig := range tf {
+		applied[serviceName] = serviceConfig
+		for _, pattern := range wildcards {
+			matched, err := path.Match(pattern, serviceName)
+			if err != nil {
+				return nil, fmt.Errorf("invalid wildcard service entry %s: %s", pattern, err.Error())
+			}
+			if matched {
+				applied[serviceName] = patterns[pattern]
+				break
+			}
+		}
+	}
+
+	return applied, nil
+}
+
+// withoutWildcards function returns a copy of tf with the wildcard entries removed
+func (tf TokenConfFile) withoutWildcards() TokenConfFile {
+	concrete := make(TokenConfFile)
+	for key, value := range tf {
+		if !isWildcard(key) {
+			concrete[key] = value
+		}
+	}
+	return concrete
+}
+
+// expandPolicyTemplate function renders a policy document as a Go template
+// with the service name available as {{.ServiceName}}
+func expandPolicyTemplate(policy map[string]interface{}, serviceName string) (map[string]interface{}, error) {
+	raw, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(serviceName).Option("missingkey=error").Parse(string(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, policyTemplateData{ServiceName: serviceName}); err != nil {
+		return nil, err
+	}
+
+	expanded := make(map[string]interface{})
+	if err := json.Unmarshal(rendered.Bytes(), &expanded); err != nil {
+		return nil, err
+	}
+	return expanded, nil
+}
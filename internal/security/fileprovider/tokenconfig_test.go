@@ -243,3 +243,49 @@ func TestGetTokenConfigFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyWildcards(t *testing.T) {
+	ttl := ServiceKey{UseDefaults: true, TokenTTL: "4h"}
+	fileConf := TokenConfFile{
+		"app-*":     ttl,
+		"other-svc": ServiceKey{},
+	}
+	envConf := TokenConfFile{
+		"app-rules": ServiceKey{UseDefaults: true},
+		"device-x":  ServiceKey{UseDefaults: true},
+	}
+
+	applied, err := envConf.applyWildcards(fileConf)
+	require.NoError(t, err)
+	assert.Equal(t, ttl, applied["app-rules"])
+	assert.Equal(t, ServiceKey{UseDefaults: true}, applied["device-x"])
+
+	concrete := fileConf.withoutWildcards()
+	assert.False(t, concrete.keyExists("app-*"))
+	assert.True(t, concrete.keyExists("other-svc"))
+}
+
+func TestApplyWildcardsBadPattern(t *testing.T) {
+	fileConf := TokenConfFile{"app-[": ServiceKey{}}
+	envConf := TokenConfFile{"app-rules": ServiceKey{UseDefaults: true}}
+
+	_, err := envConf.applyWildcards(fileConf)
+	assert.Error(t, err)
+}
+
+func TestExpandPolicyTemplate(t *testing.T) {
+	policy := map[string]interface{}{
+		"path": map[string]interface{}{
+			"secret/edgex/appdata/{{.ServiceName}}/*": map[string]interface{}{
+				"capabilities": []interface{}{"read"},
+			},
+		},
+	}
+
+	expanded, err := expandPolicyTemplate(policy, "app-rules")
+	require.NoError(t, err)
+	assert.Contains(t, expanded["path"], "secret/edgex/appdata/app-rules/*")
+
+	_, err = expandPolicyTemplate(map[string]interface{}{"path": "{{.Unknown}}"}, "app-rules")
+	assert.Error(t, err)
+}
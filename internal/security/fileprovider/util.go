@@ -23,3 +23,20 @@ func mergeMaps(defaults map[string]interface{}, other map[string]interface{}) ma
 	}
 	return defaults
 }
+
+// appendPolicies appends policy names to a "policies" token parameter,
+// which may be a []string from the defaults or a []interface{} from JSON custom token parameters
+func appendPolicies(existing interface{}, policies []string) []string {
+	merged := make([]string, 0)
+	switch current := existing.(type) {
+	case []string:
+		merged = append(merged, current...)
+	case []interface{}:
+		for _, policy := range current {
+			if name, ok := policy.(string); ok {
+				merged = append(merged, name)
+			}
+		}
+	}
+	return append(merged, policies...)
+}
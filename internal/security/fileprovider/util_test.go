@@ -34,3 +34,17 @@ func TestMergeMaps(t *testing.T) {
 	assert.True(t, merged["overridden"].(bool))
 	assert.True(t, merged["newkey"].(bool))
 }
+
+func TestAppendPolicies(t *testing.T) {
+	// Policies from defaults
+	merged := appendPolicies([]string{"edgex-service-a"}, []string{"edgex-service-a-extra"})
+	assert.Equal(t, []string{"edgex-service-a", "edgex-service-a-extra"}, merged)
+
+	// Policies decoded from JSON custom token parameters
+	merged = appendPolicies([]interface{}{"custom"}, []string{"edgex-service-a-extra"})
+	assert.Equal(t, []string{"custom", "edgex-service-a-extra"}, merged)
+
+	// No existing policies
+	merged = appendPolicies(nil, []string{"edgex-service-a-extra"})
+	assert.Equal(t, []string{"edgex-service-a-extra"}, merged)
+}
@@ -0,0 +1,206 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+// Package fips restricts the cryptography of the security services to the algorithms and key sizes approved for
+// FIPS 140-2 by SP 800-131A when they are built with the fips build tag, e.g. with
+//
+//	make build GOTAGS=fips
+//
+// The checks fail with an error naming the non-approved algorithm or key size, so that a service configured with one
+// fails at startup instead of using it; they always pass without the tag. The tag doesn't turn the Go cryptography
+// into a validated module, which takes a Go toolchain built with one, e.g. BoringCrypto.
+package fips
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+const (
+	// MinIKMLength is the minimum length in bytes of the input key material of the KDF, that of the AES-256 keys
+	// derived from it
+	MinIKMLength = 32
+	// MinDerivedKeyLength is the minimum length in bytes of the keys derived by the KDF, that of the AES-128 keys
+	MinDerivedKeyLength = 16
+	// MinHashSize is the minimum size in bytes of the digest of the hash of the KDF, that of SHA-256
+	MinHashSize = sha256.Size
+	// MinPasswordLength is the minimum length of the generated passwords, 112 bits of security strength in base64
+	MinPasswordLength = 19
+	// MinRSABits is the minimum size of the RSA keys
+	MinRSABits = 2048
+)
+
+// ErrNotApproved is wrapped by the errors of the checks
+var ErrNotApproved = errors.New("not FIPS approved")
+
+// approvedTransitKeyTypes are the approved types of the keys of the Vault transit engine
+var approvedTransitKeyTypes = map[string]bool{
+	"aes128-gcm96": true,
+	"aes256-gcm96": true,
+	"ecdsa-p256":   true,
+	"ecdsa-p384":   true,
+	"ecdsa-p521":   true,
+	"rsa-2048":     true,
+	"rsa-3072":     true,
+	"rsa-4096":     true,
+	"hmac":         true,
+}
+
+// approvedCipherSuites are the TLS 1.2 cipher suites with approved key exchanges and AEADs
+var approvedCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+func notApproved(format string, args ...interface{}) error {
+	return fmt.Errorf("%s is %w", fmt.Sprintf(format, args...), ErrNotApproved)
+}
+
+// CheckKDF returns an error if deriving keys of keyLen bytes from ikm with HKDF and the hash of newHash isn't approved
+func CheckKDF(newHash func() hash.Hash, ikm []byte, keyLen uint) error {
+	if !Enabled {
+		return nil
+	}
+	return checkKDF(newHash, ikm, keyLen)
+}
+
+func checkKDF(newHash func() hash.Hash, ikm []byte, keyLen uint) error {
+	if size := newHash().Size(); size < MinHashSize {
+		return notApproved("HKDF with a %d-bit hash", size*8)
+	}
+	if err := checkIKM(ikm); err != nil {
+		return err
+	}
+	if keyLen < MinDerivedKeyLength {
+		return notApproved("a derived key of %d bits", keyLen*8)
+	}
+	return nil
+}
+
+// CheckKDFAlgorithm returns an error if the KDF deriving the keys wrapping the Vault master key shares isn't HKDF;
+// the memory-hard Argon2id and scrypt aren't approved
+func CheckKDFAlgorithm(algorithm string) error {
+	if !Enabled {
+		return nil
+	}
+	return checkKDFAlgorithm(algorithm)
+}
+
+func checkKDFAlgorithm(algorithm string) error {
+	if algorithm != "hkdf" {
+		return notApproved("the %s KDF", algorithm)
+	}
+	return nil
+}
+
+// CheckIKM returns an error if the input key material of the KDF is too short
+func CheckIKM(ikm []byte) error {
+	if !Enabled {
+		return nil
+	}
+	return checkIKM(ikm)
+}
+
+func checkIKM(ikm []byte) error {
+	if len(ikm) < MinIKMLength {
+		return notApproved("input key material of %d bytes, at least %d being required,", len(ikm), MinIKMLength)
+	}
+	return nil
+}
+
+// CheckPassword returns an error if the generated password is too short
+func CheckPassword(password string) error {
+	if !Enabled {
+		return nil
+	}
+	return checkPassword(password)
+}
+
+func checkPassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return notApproved("a password of %d characters, at least %d being required,", len(password),
+			MinPasswordLength)
+	}
+	return nil
+}
+
+// CheckTransitKeyType returns an error if the type of the keys of the Vault transit engine isn't approved
+func CheckTransitKeyType(keyType string) error {
+	if !Enabled {
+		return nil
+	}
+	return checkTransitKeyType(keyType)
+}
+
+func checkTransitKeyType(keyType string) error {
+	if !approvedTransitKeyTypes[keyType] {
+		return notApproved("transit key type %q", keyType)
+	}
+	return nil
+}
+
+// CheckPublicKey returns an error if the public key of a certificate isn't approved, i.e. isn't an RSA key of at
+// least MinRSABits or an ECDSA key on the P-256, P-384 or P-521 curves
+func CheckPublicKey(key crypto.PublicKey) error {
+	if !Enabled {
+		return nil
+	}
+	return checkPublicKey(key)
+}
+
+func checkPublicKey(key crypto.PublicKey) error {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < MinRSABits {
+			return notApproved("an RSA key of %d bits", k.N.BitLen())
+		}
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+		default:
+			return notApproved("an ECDSA key on curve %s", k.Curve.Params().Name)
+		}
+	default:
+		return notApproved("a %T key", key)
+	}
+	return nil
+}
+
+// Restrict restricts config to TLS 1.2 with the approved cipher suites and curves, and returns it. TLS 1.3 is
+// excluded as its cipher suites can't be restricted.
+func Restrict(config *tls.Config) *tls.Config {
+	if !Enabled {
+		return config
+	}
+	return restrict(config)
+}
+
+func restrict(config *tls.Config) *tls.Config {
+	config.MinVersion = tls.VersionTLS12
+	config.MaxVersion = tls.VersionTLS12
+	config.CipherSuites = approvedCipherSuites
+	config.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+	return config
+}
@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package fips
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckKDF(t *testing.T) {
+	ikm := make([]byte, MinIKMLength)
+	tests := []struct {
+		name     string
+		err      error
+		approved bool
+	}{
+		{"approved", checkKDF(sha256.New, ikm, 32), true},
+		{"SHA-1", checkKDF(sha1.New, ikm, 32), false},
+		{"short input key material", checkKDF(sha256.New, ikm[:16], 32), false},
+		{"short derived key", checkKDF(sha256.New, ikm, 8), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.approved {
+				assert.NoError(t, tt.err)
+			} else {
+				assert.True(t, errors.Is(tt.err, ErrNotApproved), "unexpected error %v", tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckKDFAlgorithm(t *testing.T) {
+	assert.NoError(t, checkKDFAlgorithm("hkdf"))
+	assert.Error(t, checkKDFAlgorithm("argon2id"))
+	assert.Error(t, checkKDFAlgorithm("scrypt"))
+}
+
+func TestCheckPassword(t *testing.T) {
+	assert.NoError(t, checkPassword(strings.Repeat("a", MinPasswordLength)))
+	assert.Error(t, checkPassword("password"))
+}
+
+func TestCheckTransitKeyType(t *testing.T) {
+	assert.NoError(t, checkTransitKeyType("ecdsa-p256"))
+	assert.NoError(t, checkTransitKeyType("aes256-gcm96"))
+	assert.Error(t, checkTransitKeyType("ed25519"))
+	assert.Error(t, checkTransitKeyType("chacha20-poly1305"))
+}
+
+func TestCheckPublicKey(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+	rsa1024, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	assert.NoError(t, checkPublicKey(&p256.PublicKey))
+	assert.Error(t, checkPublicKey(&p224.PublicKey))
+	assert.Error(t, checkPublicKey(&rsa1024.PublicKey))
+	assert.Error(t, checkPublicKey(edPublic))
+}
+
+func TestRestrict(t *testing.T) {
+	config := restrict(&tls.Config{ServerName: "edgex-vault"})
+	assert.Equal(t, "edgex-vault", config.ServerName)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MaxVersion)
+	assert.NotContains(t, config.CipherSuites, tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305)
+	assert.NotContains(t, config.CurvePreferences, tls.X25519)
+}
+
+func TestChecksFollowTheBuildTag(t *testing.T) {
+	err := CheckIKM(make([]byte, 16))
+	unrestricted := &tls.Config{}
+	if Enabled {
+		assert.Error(t, err)
+		assert.NotEmpty(t, Restrict(unrestricted).CipherSuites)
+	} else {
+		assert.NoError(t, err)
+		assert.Empty(t, Restrict(unrestricted).CipherSuites)
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"os"
 	"path"
 
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
+
 	"golang.org/x/crypto/hkdf"
 
 	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
@@ -27,7 +29,8 @@ import (
 // and provides a mechanism to force generation of unique keys
 // in the event that the KDF inputKeyMaterial is less than random.
 const (
-	saltFile   string = "kdf-salt.dat"
+	// SaltFile is the name of the file holding the salt in the persistence path
+	SaltFile   string = "kdf-salt.dat"
 	saltLength        = 32
 )
 
@@ -49,6 +52,9 @@ func NewKdf(fileIoPerformer fileioperformer.FileIoPerformer, persistencePath str
 
 // DeriveKey returns derived key material of specified length
 func (kdf *kdfObject) DeriveKey(inputKeyingMaterial []byte, keyLen uint, info string) ([]byte, error) {
+	if err := fips.CheckKDF(kdf.hashConstructor, inputKeyingMaterial, keyLen); err != nil {
+		return nil, err
+	}
 	salt, err := kdf.initializeSalt()
 	if err != nil {
 		return nil, err
@@ -64,7 +70,7 @@ func (kdf *kdfObject) DeriveKey(inputKeyingMaterial []byte, keyLen uint, info st
 // or installs a new salt
 func (kdf *kdfObject) initializeSalt() ([]byte, error) {
 	salt := make([]byte, saltLength)
-	saltPath := path.Join(kdf.persistencePath, saltFile)
+	saltPath := path.Join(kdf.persistencePath, SaltFile)
 
 	_, err := osStat(saltPath)
 	if err == nil {
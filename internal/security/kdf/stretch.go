@@ -0,0 +1,111 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package kdf
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+// Names of the key derivation algorithms
+const (
+	HKDF     = "hkdf"
+	Argon2id = "argon2id"
+	Scrypt   = "scrypt"
+)
+
+// stretchedKeyLength is the length of the key stretched from the input key material, which is then expanded
+const stretchedKeyLength = 32
+
+// stretchingKdf derives keys from input key material of low entropy, e.g. a PIN, by stretching it with a
+// memory-hard function and the salt, then expanding the stretched key with HKDF-SHA256 and info so that different
+// infos still derive different keys
+type stretchingKdf struct {
+	salt    *kdfObject
+	stretch func(inputKeyingMaterial []byte, salt []byte) ([]byte, error)
+}
+
+// NewArgon2idKdf creates a new KeyDeriver stretching the input key material with Argon2id, using memory KiB of
+// memory, iterations passes over it and parallelism threads
+func NewArgon2idKdf(fileIoPerformer fileioperformer.FileIoPerformer, persistencePath string,
+	memory int, iterations int, parallelism int) (KeyDeriver, error) {
+	if err := fips.CheckKDFAlgorithm(Argon2id); err != nil {
+		return nil, err
+	}
+	if iterations < 1 {
+		return nil, fmt.Errorf("argon2id iterations must be at least 1, not %d", iterations)
+	}
+	if parallelism < 1 || parallelism > 255 {
+		return nil, fmt.Errorf("argon2id parallelism must be between 1 and 255, not %d", parallelism)
+	}
+	if memory < 8*parallelism {
+		return nil, fmt.Errorf("argon2id memory must be at least 8 KiB per thread, not %d KiB", memory)
+	}
+
+	return &stretchingKdf{
+		salt: &kdfObject{fileIoPerformer: fileIoPerformer, persistencePath: persistencePath},
+		stretch: func(inputKeyingMaterial []byte, salt []byte) ([]byte, error) {
+			return argon2.IDKey(inputKeyingMaterial, salt, uint32(iterations), uint32(memory), uint8(parallelism),
+				stretchedKeyLength), nil
+		},
+	}, nil
+}
+
+// NewScryptKdf creates a new KeyDeriver stretching the input key material with scrypt, of CPU/memory cost n, a power
+// of two, block size r and parallelization p
+func NewScryptKdf(fileIoPerformer fileioperformer.FileIoPerformer, persistencePath string,
+	n int, r int, p int) (KeyDeriver, error) {
+	if err := fips.CheckKDFAlgorithm(Scrypt); err != nil {
+		return nil, err
+	}
+	if n <= 1 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("scrypt N must be a power of two greater than 1, not %d", n)
+	}
+	if r < 1 || p < 1 || uint64(r)*uint64(p) >= 1<<30 {
+		return nil, fmt.Errorf("scrypt r and p must be positive with r*p < 2^30, not r=%d and p=%d", r, p)
+	}
+
+	return &stretchingKdf{
+		salt: &kdfObject{fileIoPerformer: fileIoPerformer, persistencePath: persistencePath},
+		stretch: func(inputKeyingMaterial []byte, salt []byte) ([]byte, error) {
+			return scrypt.Key(inputKeyingMaterial, salt, n, r, p, stretchedKeyLength)
+		},
+	}, nil
+}
+
+// DeriveKey returns derived key material of specified length
+func (kdf *stretchingKdf) DeriveKey(inputKeyingMaterial []byte, keyLen uint, info string) ([]byte, error) {
+	salt, err := kdf.salt.initializeSalt()
+	if err != nil {
+		return nil, err
+	}
+	stretched, err := kdf.stretch(inputKeyingMaterial, salt)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for i := range stretched {
+			stretched[i] = 0
+		}
+	}()
+
+	kdfReader := hkdf.New(sha256.New, stretched, nil, []byte(info))
+	key := make([]byte, keyLen)
+	if _, err := io.ReadFull(kdfReader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
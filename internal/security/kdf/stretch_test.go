@@ -0,0 +1,122 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package kdf
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// zeroSaltFileOpener returns a FileIoPerformer reading an all-zero salt
+func zeroSaltFileOpener() *mocks.FileIoPerformer {
+	mockSeedFile := &mockSeedFile{}
+	mockSeedFile.On("Read", mock.Anything).Run(func(args mock.Arguments) {
+		b := args.Get(0).([]byte)
+		for i := range b {
+			b[i] = 0
+		}
+	}).Return(32, nil)
+	mockSeedFile.On("Close").Return(nil)
+	mockFileOpener := &mocks.FileIoPerformer{}
+	mockFileOpener.On("OpenFileReader", "/target/kdf-salt.dat", os.O_RDONLY, os.FileMode(0400)).Return(mockSeedFile, nil)
+	return mockFileOpener
+}
+
+func newStretchingKdfs(t *testing.T) map[string]KeyDeriver {
+	if fips.Enabled {
+		t.Skip("argon2id and scrypt aren't FIPS approved")
+	}
+	argon2id, err := NewArgon2idKdf(zeroSaltFileOpener(), "/target", 64, 1, 1)
+	require.NoError(t, err)
+	scrypt, err := NewScryptKdf(zeroSaltFileOpener(), "/target", 16, 1, 1)
+	require.NoError(t, err)
+	return map[string]KeyDeriver{Argon2id: argon2id, Scrypt: scrypt}
+}
+
+// TestStretchingKdfs tests that the keys stretched from a PIN depend on the info only
+func TestStretchingKdfs(t *testing.T) {
+	defer mockOsStat(func(string) (os.FileInfo, error) { return &mockFileInfo{}, nil })()
+	keys := map[string]bool{}
+
+	for name, keyDeriver := range newStretchingKdfs(t) {
+		t.Run(name, func(t *testing.T) {
+			key, err := keyDeriver.DeriveKey([]byte("123456"), 32, "info")
+			require.NoError(t, err)
+			assert.Len(t, key, 32)
+			again, err := keyDeriver.DeriveKey([]byte("123456"), 32, "info")
+			require.NoError(t, err)
+			assert.Equal(t, key, again)
+			other, err := keyDeriver.DeriveKey([]byte("123456"), 32, "other")
+			require.NoError(t, err)
+			assert.NotEqual(t, key, other)
+			pin, err := keyDeriver.DeriveKey([]byte("654321"), 32, "info")
+			require.NoError(t, err)
+			assert.NotEqual(t, key, pin)
+
+			assert.False(t, keys[string(key)], "the algorithms derive different keys")
+			keys[string(key)] = true
+		})
+	}
+}
+
+func TestStretchingKdfFailedStat(t *testing.T) {
+	defer mockOsStat(func(string) (os.FileInfo, error) { return &mockFileInfo{}, os.ErrPermission })()
+
+	for name, keyDeriver := range newStretchingKdfs(t) {
+		t.Run(name, func(t *testing.T) {
+			key, err := keyDeriver.DeriveKey([]byte("123456"), 32, "info")
+			require.Error(t, err)
+			require.Nil(t, key)
+		})
+	}
+}
+
+func TestInvalidStretchingKdfParameters(t *testing.T) {
+	if fips.Enabled {
+		t.Skip("argon2id and scrypt aren't FIPS approved")
+	}
+	fileOpener := &mocks.FileIoPerformer{}
+
+	tests := []struct {
+		name string
+		new  func() (KeyDeriver, error)
+	}{
+		{"argon2id no iterations", func() (KeyDeriver, error) { return NewArgon2idKdf(fileOpener, "/target", 64, 0, 1) }},
+		{"argon2id no threads", func() (KeyDeriver, error) { return NewArgon2idKdf(fileOpener, "/target", 64, 1, 0) }},
+		{"argon2id too many threads", func() (KeyDeriver, error) { return NewArgon2idKdf(fileOpener, "/target", 4096, 1, 256) }},
+		{"argon2id too little memory", func() (KeyDeriver, error) { return NewArgon2idKdf(fileOpener, "/target", 15, 1, 2) }},
+		{"scrypt N not a power of two", func() (KeyDeriver, error) { return NewScryptKdf(fileOpener, "/target", 1000, 8, 1) }},
+		{"scrypt N of 1", func() (KeyDeriver, error) { return NewScryptKdf(fileOpener, "/target", 1, 8, 1) }},
+		{"scrypt no r", func() (KeyDeriver, error) { return NewScryptKdf(fileOpener, "/target", 16, 0, 1) }},
+		{"scrypt r*p too large", func() (KeyDeriver, error) { return NewScryptKdf(fileOpener, "/target", 16, 1<<15, 1<<15) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.new()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStretchingKdfsNotApproved(t *testing.T) {
+	if !fips.Enabled {
+		t.Skip("argon2id and scrypt are only rejected in FIPS builds")
+	}
+	_, err := NewArgon2idKdf(&mocks.FileIoPerformer{}, "/target", 65536, 3, 2)
+	assert.True(t, errors.Is(err, fips.ErrNotApproved))
+	_, err = NewScryptKdf(&mocks.FileIoPerformer{}, "/target", 32768, 8, 1)
+	assert.True(t, errors.Is(err, fips.ErrNotApproved))
+}
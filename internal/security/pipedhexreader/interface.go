@@ -10,6 +10,10 @@ package pipedhexreader
 // standard output stream of an executable into a byte array
 type PipedHexReader interface {
 	// ReadHexBytesFromExe invokes executable
-	// and reads hex bytes from stdout and returns an array
+	// and reads hex bytes from stdout and returns an array.
+	// executablePath may instead be unix:// followed by the path
+	// of a Unix domain socket to read the hex bytes from, or an
+	// https:// URL to GET them from, e.g. to retrieve them from
+	// a local attestation agent
 	ReadHexBytesFromExe(executablePath string) ([]byte, error)
 }
@@ -8,46 +8,167 @@ package pipedhexreader
 
 import (
 	"bufio"
+	"context"
+	"crypto/tls"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os/exec"
 	"strings"
+	"time"
 )
 
+const (
+	// DefaultTimeout is the time the input key material is waited for if Options.Timeout isn't set
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxLength is the maximum number of hex characters read if Options.MaxLength isn't set
+	DefaultMaxLength = 1024
+
+	unixScheme  = "unix://"
+	httpsScheme = "https://"
+	httpScheme  = "http://"
+)
+
+// Options configure how the hex bytes are read
+type Options struct {
+	// Timeout bounds the time to run the executable or to fetch the hex bytes from the socket or the endpoint
+	Timeout time.Duration
+	// MaxLength is the maximum number of hex characters read
+	MaxLength int
+	// TLSConfig authenticates the HTTPS endpoint, and the reader to it with a client certificate;
+	// the system roots are trusted if nil
+	TLSConfig *tls.Config
+}
+
 // pipedHexReader stores instance data for the pipedhexreader
-type pipedHexReader struct{}
+type pipedHexReader struct {
+	options Options
+}
 
 // NewPipedHexReader creates a new PipedHexReader
 func NewPipedHexReader() PipedHexReader {
-	return &pipedHexReader{}
+	return NewPipedHexReaderWithOptions(Options{})
+}
+
+// NewPipedHexReaderWithOptions creates a new PipedHexReader with the specified options
+func NewPipedHexReaderWithOptions(options Options) PipedHexReader {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.MaxLength <= 0 {
+		options.MaxLength = DefaultMaxLength
+	}
+	return &pipedHexReader{options: options}
 }
 
 // ReadHexBytesFromExe see interface.go
 func (phr *pipedHexReader) ReadHexBytesFromExe(executablePath string) ([]byte, error) {
-	sanitizedExecutable, err := exec.LookPath(executablePath)
+	var hexbytes string
+	var err error
+	switch {
+	case strings.HasPrefix(executablePath, unixScheme):
+		hexbytes, err = phr.readFromSocket(strings.TrimPrefix(executablePath, unixScheme))
+	case strings.HasPrefix(executablePath, httpsScheme):
+		hexbytes, err = phr.readFromURL(executablePath)
+	case strings.HasPrefix(executablePath, httpScheme):
+		err = errors.New("input key material can't be fetched over unauthenticated http")
+	default:
+		hexbytes, err = phr.readFromExe(executablePath)
+	}
 	if err != nil {
 		return nil, err
 	}
-	cmd := exec.Command(sanitizedExecutable)
-	stdout, err := cmd.StdoutPipe()
+
+	bytes, err := hex.DecodeString(hexbytes)
 	if err != nil {
 		return nil, err
 	}
+	return bytes, nil
+}
+
+// readFromExe invokes the executable and reads the hex bytes from its stdout
+func (phr *pipedHexReader) readFromExe(executablePath string) (string, error) {
+	sanitizedExecutable, err := exec.LookPath(executablePath)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), phr.options.Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, sanitizedExecutable)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", err
+	}
 	if err := cmd.Start(); err != nil {
-		return nil, err
+		return "", err
+	}
+	hexbytes, err := phr.readLine(stdout)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return "", err
 	}
-	reader := bufio.NewReader(stdout)
-	// We don't WANT a newline, but code defensively
-	hexbytes, _ := reader.ReadString('\n')
-	// Readstring returns non-nil error if delim is not present: ignore this
 	// StdoutPipe usage is to Wait at the end of the reading logic
 	// because it closes the readers automatically
 	if err := cmd.Wait(); err != nil {
-		return nil, err
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("%s timed out after %s", executablePath, phr.options.Timeout)
+		}
+		return "", err
 	}
-	hexbytes = strings.TrimSuffix(hexbytes, "\n")
-	bytes, err := hex.DecodeString(hexbytes)
+	return hexbytes, nil
+}
+
+// readFromSocket connects to the Unix domain socket and reads the hex bytes written by the agent listening on it
+func (phr *pipedHexReader) readFromSocket(socketPath string) (string, error) {
+	conn, err := net.DialTimeout("unix", socketPath, phr.options.Timeout)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	return bytes, nil
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(phr.options.Timeout)); err != nil {
+		return "", err
+	}
+	return phr.readLine(conn)
+}
+
+// readFromURL reads the hex bytes from the body of the response to a GET of the HTTPS endpoint
+func (phr *pipedHexReader) readFromURL(url string) (string, error) {
+	client := &http.Client{
+		Timeout:   phr.options.Timeout,
+		Transport: &http.Transport{TLSClientConfig: phr.options.TLSConfig},
+		// A redirect could downgrade the request to http
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch input key material: %s", resp.Status)
+	}
+	return phr.readLine(resp.Body)
+}
+
+// readLine reads the hex bytes up to the first newline or the end of the stream,
+// failing if there are more than MaxLength of them
+func (phr *pipedHexReader) readLine(stream io.Reader) (string, error) {
+	reader := bufio.NewReader(io.LimitReader(stream, int64(phr.options.MaxLength)+1))
+	// We don't WANT a newline, but code defensively
+	hexbytes, err := reader.ReadString('\n')
+	// Readstring returns io.EOF if delim is not present: ignore this
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	hexbytes = strings.TrimSuffix(strings.TrimSuffix(hexbytes, "\n"), "\r")
+	if len(hexbytes) > phr.options.MaxLength {
+		return "", fmt.Errorf("input key material is longer than %d hex characters", phr.options.MaxLength)
+	}
+	return hexbytes, nil
 }
@@ -10,7 +10,12 @@ package pipedhexreader
 
 import (
 	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -40,3 +45,108 @@ func TestPipedHexReaderNoNewline(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, key)
 }
+
+func TestPipedHexReaderTooLong(t *testing.T) {
+	// Arrange
+	phr := NewPipedHexReaderWithOptions(Options{MaxLength: 4})
+
+	// Act
+	_, err := phr.ReadHexBytesFromExe("./testdata/echowithnewline")
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestPipedHexReaderTimeout(t *testing.T) {
+	// Arrange
+	phr := NewPipedHexReaderWithOptions(Options{Timeout: 100 * time.Millisecond})
+
+	// Act
+	start := time.Now()
+	_, err := phr.ReadHexBytesFromExe("./testdata/sleep")
+
+	// Assert
+	require.Error(t, err)
+	require.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
+
+// serveSocket listens on a Unix domain socket writing response to each connection,
+// returning its path and a function removing it
+func serveSocket(t *testing.T, response string) (string, func()) {
+	dir, err := ioutil.TempDir("", "pipedhexreader")
+	require.NoError(t, err)
+	socketPath := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte(response))
+			_ = conn.Close()
+		}
+	}()
+	return socketPath, func() {
+		_ = listener.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestPipedHexReaderSocket(t *testing.T) {
+	// Arrange
+	socketPath, cleanup := serveSocket(t, "12345678\n")
+	defer cleanup()
+	phr := NewPipedHexReader()
+	expected, _ := hex.DecodeString("12345678")
+
+	// Act
+	key, err := phr.ReadHexBytesFromExe("unix://" + socketPath)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, expected, key)
+}
+
+func TestPipedHexReaderSocketTooLong(t *testing.T) {
+	// Arrange
+	socketPath, cleanup := serveSocket(t, "1234567890")
+	defer cleanup()
+	phr := NewPipedHexReaderWithOptions(Options{MaxLength: 8})
+
+	// Act
+	_, err := phr.ReadHexBytesFromExe("unix://" + socketPath)
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestPipedHexReaderSocketTimeout(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "pipedhexreader")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer listener.Close()
+	phr := NewPipedHexReaderWithOptions(Options{Timeout: 100 * time.Millisecond})
+
+	// Act: the connection is accepted by the kernel but nothing is ever written to it
+	_, err = phr.ReadHexBytesFromExe("unix://" + socketPath)
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestPipedHexReaderMissingSocket(t *testing.T) {
+	// Arrange
+	phr := NewPipedHexReader()
+
+	// Act
+	_, err := phr.ReadHexBytesFromExe("unix:///nonexistent/agent.sock")
+
+	// Assert
+	require.Error(t, err)
+}
@@ -0,0 +1,80 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package pipedhexreader
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newAgent starts an HTTPS server calling handler, returning it along with the options trusting it
+func newAgent(handler http.HandlerFunc) (*httptest.Server, Options) {
+	server := httptest.NewTLSServer(handler)
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	return server, Options{TLSConfig: &tls.Config{RootCAs: roots}}
+}
+
+func TestPipedHexReaderHTTPS(t *testing.T) {
+	// Arrange
+	server, options := newAgent(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ikm" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("12345678\n"))
+	})
+	defer server.Close()
+	phr := NewPipedHexReaderWithOptions(options)
+	expected, _ := hex.DecodeString("12345678")
+
+	// Act
+	key, err := phr.ReadHexBytesFromExe(server.URL + "/ikm")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, expected, key)
+}
+
+func TestPipedHexReaderHTTPSErrors(t *testing.T) {
+	server, options := newAgent(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/denied":
+			w.WriteHeader(http.StatusForbidden)
+		case "/redirect":
+			http.Redirect(w, r, "http://example.com/ikm", http.StatusFound)
+		case "/toolong":
+			_, _ = w.Write([]byte(strings.Repeat("12", DefaultMaxLength)))
+		}
+	})
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		options Options
+	}{
+		{"attestation denied", server.URL + "/denied", options},
+		{"redirect", server.URL + "/redirect", options},
+		{"too long", server.URL + "/toolong", options},
+		{"untrusted server", server.URL + "/ikm", Options{}},
+		{"plain http", strings.Replace(server.URL, "https://", "http://", 1) + "/ikm", options},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPipedHexReaderWithOptions(tt.options).ReadHexBytesFromExe(tt.url)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+)
+
+const (
+	// HttpLogPlugin is the Kong plugin posting the access log entries of a route
+	HttpLogPlugin = "http-log"
+	// CorrelationIdPlugin is the Kong plugin giving each request a correlation id, unless it already has one
+	CorrelationIdPlugin = "correlation-id"
+
+	defaultAccessLogTimeout = 10000
+)
+
+// accessLogPlugins returns the plugins of a route whose requests are logged. The correlation id is passed to the
+// service and returned to the client in the clients.CorrelationHeader, and recorded with the request headers in the
+// access log entries.
+func (s *Service) accessLogPlugins() []KongDeclarativePlugin {
+	timeout := s.configuration.KongAccessLog.Timeout
+	if timeout == 0 {
+		timeout = defaultAccessLogTimeout
+	}
+	return []KongDeclarativePlugin{
+		{
+			Name: CorrelationIdPlugin,
+			Config: map[string]interface{}{
+				"header_name":     clients.CorrelationHeader,
+				"generator":       "uuid",
+				"echo_downstream": true,
+			},
+		},
+		{
+			Name: HttpLogPlugin,
+			Config: map[string]interface{}{
+				"http_endpoint": s.configuration.KongAccessLog.HttpEndpoint,
+				"method":        http.MethodPost,
+				"content_type":  clients.ContentTypeJSON,
+				"timeout":       timeout,
+			},
+		},
+	}
+}
+
+// initAccessLog enables the access logging of the route through the admin API
+func (s *Service) initAccessLog(routeName string) error {
+	for _, plugin := range s.accessLogPlugins() {
+		formVals := url.Values{
+			"name": {plugin.Name},
+		}
+		var keys []string
+		for key := range plugin.Config {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			formVals.Set("config."+key, fmt.Sprint(plugin.Config[key]))
+		}
+		tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), RoutesPath, routeName, PluginsPath}
+		req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), strings.NewReader(formVals.Encode()))
+		if err != nil {
+			return fmt.Errorf("failed to create %s request for route %s -- %s", plugin.Name, routeName, err.Error())
+		}
+		req.Header.Add(clients.ContentType, "application/x-www-form-urlencoded")
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			e := fmt.Sprintf("failed to set up %s for route %s -- %s", plugin.Name, routeName, err.Error())
+			s.loggingClient.Error(e)
+			return errors.New(e)
+		}
+		resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK, http.StatusCreated, http.StatusConflict:
+			s.loggingClient.Info(fmt.Sprintf("successful to set up %s for route %s", plugin.Name, routeName))
+		default:
+			e := fmt.Sprintf("failed to set up %s for route %s with errorcode %d", plugin.Name, routeName, resp.StatusCode)
+			s.loggingClient.Error(e)
+			return errors.New(e)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKongAccessLogInfo(t *testing.T) {
+	accessLog := config.KongAccessLogInfo{Routes: []string{"CoreData"}, HttpEndpoint: "http://edgex-logstash:8080/kong"}
+	assert.NoError(t, accessLog.Validate())
+	assert.True(t, accessLog.Logged("coredata"))
+	assert.False(t, accessLog.Logged("metadata"))
+	accessLog.Routes = []string{"*"}
+	assert.True(t, accessLog.Logged("metadata"))
+
+	accessLog.HttpEndpoint = "edgex-logstash:8080"
+	assert.Error(t, accessLog.Validate())
+	accessLog.Routes = nil
+	assert.NoError(t, accessLog.Validate(), "the endpoint should only be required when routes are logged")
+}
+
+func TestInitAccessLog(t *testing.T) {
+	plugins := make(map[string]url.Values)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/"+RoutesPath+"/coredata/"+PluginsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		plugins[r.PostForm.Get("name")] = r.PostForm
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	host, port, err := parseHostAndPort(ts, t)
+	require.NoError(t, err)
+	configuration := loadTestConfiguration(t)
+	configuration.KongURL = config.KongUrlInfo{Server: host, AdminPort: port}
+	configuration.KongAccessLog = config.KongAccessLogInfo{Routes: []string{"coredata"}, HttpEndpoint: "https://edgex-logstash/kong"}
+	mockLogger := logger.MockLogger{}
+	service := NewService(NewRequestor(true, 10, "", mockLogger), mockLogger, configuration)
+
+	require.NoError(t, service.initAccessLog("coredata"))
+	require.Contains(t, plugins, CorrelationIdPlugin)
+	assert.Equal(t, clients.CorrelationHeader, plugins[CorrelationIdPlugin].Get("config.header_name"))
+	assert.Equal(t, "true", plugins[CorrelationIdPlugin].Get("config.echo_downstream"))
+	require.Contains(t, plugins, HttpLogPlugin)
+	assert.Equal(t, "https://edgex-logstash/kong", plugins[HttpLogPlugin].Get("config.http_endpoint"))
+	assert.Equal(t, "10000", plugins[HttpLogPlugin].Get("config.timeout"))
+
+	assert.Error(t, service.initAccessLog("metadata"))
+}
@@ -17,22 +17,30 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/types"
 )
 
 type ConfigurationStruct struct {
-	LogLevel       string
-	RequestTimeout int
-	KongURL        KongUrlInfo
-	KongAuth       KongAuthInfo
-	KongACL        KongAclInfo
-	SecretStore    bootstrapConfig.SecretStoreInfo
-	SecretService  SecretServiceInfo
-	Clients        map[string]bootstrapConfig.ClientInfo
+	LogLevel        string
+	RequestTimeout  int
+	KongURL         KongUrlInfo
+	KongAuth        KongAuthInfo
+	KongACL         KongAclInfo
+	KongDeclarative KongDeclarativeInfo
+	KongAccessLog   KongAccessLogInfo
+	KongMTLS        KongMTLSInfo
+	SecretStore     bootstrapConfig.SecretStoreInfo
+	SecretService   SecretServiceInfo
+	Clients         map[string]bootstrapConfig.ClientInfo
+	// Registry is the registry whose registered services are proxied along with the Clients by the declarative
+	// configuration. It isn't used when Host is empty.
+	Registry bootstrapConfig.RegistryInfo
 }
 
 type KongUrlInfo struct {
@@ -71,6 +79,125 @@ type KongAclInfo struct {
 	WhiteList string
 }
 
+// KongDeclarativeInfo configures the declarative configuration generated for a DB-less Kong
+type KongDeclarativeInfo struct {
+	// OutputPath is the file the declarative configuration is written to by --init, in place of the admin API calls
+	// configuring Kong one entity at a time. Kong is configured through its admin API when empty.
+	OutputPath string
+	// Load posts the written configuration to the /config endpoint of the running DB-less Kong, which applies it
+	// entirely or not at all
+	Load bool
+	// IncludeCertificate adds the TLS certificate and key of the secret at SecretService.CertPath, served for the
+	// SecretService.SNIS
+	IncludeCertificate bool
+}
+
+// KongAccessLogInfo configures the structured access logging of the selected routes, each request and its response
+// being posted as a JSON entry along with its correlation id
+type KongAccessLogInfo struct {
+	// Routes are the names of the routes whose requests are logged, i.e. the lowercase names of the proxied services,
+	// or "*" for all the routes. The access logging is disabled when empty.
+	Routes []string
+	// HttpEndpoint is the URL the access log entries are posted to
+	HttpEndpoint string
+	// Timeout is the timeout of the posts, in milliseconds
+	Timeout int
+}
+
+// Logged returns whether the requests of the route are logged
+func (k KongAccessLogInfo) Logged(route string) bool {
+	for _, name := range k.Routes {
+		if name == "*" || strings.EqualFold(name, route) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the endpoint is configured when routes are logged
+func (k KongAccessLogInfo) Validate() error {
+	if len(k.Routes) == 0 {
+		return nil
+	}
+	endpoint, err := url.Parse(k.HttpEndpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("invalid KongAccessLog HttpEndpoint %q, an http or https URL is required", k.HttpEndpoint)
+	}
+	if k.Timeout < 0 {
+		return fmt.Errorf("invalid KongAccessLog Timeout %d", k.Timeout)
+	}
+	return nil
+}
+
+// KongMTLSInfo configures the client certificate authentication of the machine-to-machine API consumers, as an
+// alternative to the JWTs or OAuth2 tokens of KongAuth
+type KongMTLSInfo struct {
+	Enabled bool
+	// CASecretPath is the path of the secret holding the PEM bundle of the CAs issuing the client certificates, in its
+	// "cabundle" key, relative to SecretService.CertPath
+	CASecretPath string
+	// RevocationCheckMode is how the revocation of the client certificates is checked through the OCSP responder, or
+	// else the CRL, named by the certificates: SKIP, IGNORE_CA_ERROR, letting the certificates through when neither
+	// can be reached, or STRICT
+	RevocationCheckMode string
+	// HttpTimeout is the timeout of the OCSP and CRL requests, in milliseconds
+	HttpTimeout int
+	// CertCacheTTL is how long the result of the revocation check of a certificate is cached, in milliseconds
+	CertCacheTTL int
+	// Consumers map the subjects of the client certificates to the KongACL groups, i.e. their roles
+	Consumers []KongMTLSConsumer
+}
+
+// KongMTLSConsumer is the consumer authenticated by the client certificates of a subject
+type KongMTLSConsumer struct {
+	// Subject is matched against the common name, or else the subject alternative names, of the certificates
+	Subject string
+	Roles   []string
+}
+
+// Validate checks the enabled client certificate authentication, whose roles should all be whitelisted by acl
+func (k KongMTLSInfo) Validate(acl KongAclInfo) error {
+	if !k.Enabled {
+		return nil
+	}
+	if k.CASecretPath == "" {
+		return errors.New("KongMTLS CASecretPath is required")
+	}
+	switch k.RevocationCheckMode {
+	case "SKIP", "IGNORE_CA_ERROR", "STRICT":
+	default:
+		return fmt.Errorf("invalid KongMTLS RevocationCheckMode %q, expected SKIP, IGNORE_CA_ERROR or STRICT",
+			k.RevocationCheckMode)
+	}
+	if k.HttpTimeout < 0 || k.CertCacheTTL < 0 {
+		return errors.New("KongMTLS HttpTimeout and CertCacheTTL can't be negative")
+	}
+
+	whitelisted := make(map[string]bool)
+	for _, group := range strings.Split(acl.WhiteList, ",") {
+		whitelisted[strings.TrimSpace(group)] = true
+	}
+	subjects := make(map[string]bool)
+	for _, consumer := range k.Consumers {
+		if consumer.Subject == "" {
+			return errors.New("KongMTLS Consumers Subject is required")
+		}
+		if subjects[consumer.Subject] {
+			return fmt.Errorf("KongMTLS Consumers Subject %q is duplicated", consumer.Subject)
+		}
+		subjects[consumer.Subject] = true
+		if len(consumer.Roles) == 0 {
+			return fmt.Errorf("KongMTLS Consumers %q have no Roles", consumer.Subject)
+		}
+		for _, role := range consumer.Roles {
+			if !whitelisted[role] {
+				return fmt.Errorf("role %q of KongMTLS Consumers %q isn't in the KongACL WhiteList", role, consumer.Subject)
+			}
+		}
+	}
+	return nil
+}
+
 type SecretServiceInfo struct {
 	Protocol        string
 	Server          string
@@ -0,0 +1,529 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// DeclarativeFormatVersion is the version of the Kong declarative configuration format generated
+	DeclarativeFormatVersion = "2.1"
+	// ConfigPath is the admin API endpoint loading a declarative configuration into a DB-less Kong
+	ConfigPath = "config"
+
+	registryServicesPath = "v1/agent/services"
+	registryProtocolMeta = "protocol"
+)
+
+var (
+	// kongNamePattern is the pattern of the names of the Kong entities
+	kongNamePattern = regexp.MustCompile(`^[0-9A-Za-z.\-_~]+$`)
+	kongProtocols   = map[string]bool{"http": true, "https": true, "grpc": true, "grpcs": true}
+	kongPlugins     = map[string]bool{"jwt": true, "oauth2": true, "acl": true, MTLSAuthPlugin: true}
+	// kongRoutePlugins are the plugins applied to selected routes
+	kongRoutePlugins = map[string]bool{HttpLogPlugin: true, CorrelationIdPlugin: true}
+)
+
+// KongDeclarativeConfig is the declarative configuration of a DB-less Kong, replacing the entities created through
+// the admin API
+type KongDeclarativeConfig struct {
+	FormatVersion string                       `yaml:"_format_version"`
+	Services      []KongDeclarativeService     `yaml:"services,omitempty"`
+	Plugins       []KongDeclarativePlugin      `yaml:"plugins,omitempty"`
+	Certificates  []KongDeclarativeCertificate `yaml:"certificates,omitempty"`
+	// CACertificates and Consumers are the CAs and consumers of the client certificate authentication
+	CACertificates []KongDeclarativeCACertificate `yaml:"ca_certificates,omitempty"`
+	Consumers      []KongDeclarativeConsumer      `yaml:"consumers,omitempty"`
+}
+
+type KongDeclarativeService struct {
+	Name     string                 `yaml:"name"`
+	Protocol string                 `yaml:"protocol"`
+	Host     string                 `yaml:"host"`
+	Port     int                    `yaml:"port"`
+	Routes   []KongDeclarativeRoute `yaml:"routes"`
+}
+
+type KongDeclarativeRoute struct {
+	Name    string                  `yaml:"name"`
+	Paths   []string                `yaml:"paths"`
+	Plugins []KongDeclarativePlugin `yaml:"plugins,omitempty"`
+}
+
+type KongDeclarativePlugin struct {
+	Name   string                 `yaml:"name"`
+	Config map[string]interface{} `yaml:"config,omitempty"`
+}
+
+type KongDeclarativeCertificate struct {
+	Cert string                   `yaml:"cert"`
+	Key  string                   `yaml:"key"`
+	Snis []KongDeclarativeSniInfo `yaml:"snis,omitempty"`
+}
+
+type KongDeclarativeSniInfo struct {
+	Name string `yaml:"name"`
+}
+
+type KongDeclarativeCACertificate struct {
+	ID   string `yaml:"id"`
+	Cert string `yaml:"cert"`
+}
+
+type KongDeclarativeConsumer struct {
+	ID       string                       `yaml:"id,omitempty"`
+	Username string                       `yaml:"username"`
+	ACLs     []KongDeclarativeConsumerACL `yaml:"acls,omitempty"`
+}
+
+type KongDeclarativeConsumerACL struct {
+	Group string `yaml:"group"`
+}
+
+// registeredService is a service of the Consul agent services
+type registeredService struct {
+	Service string
+	Address string
+	Port    int
+	Meta    map[string]string
+}
+
+// Validate checks the configuration against the constraints of the Kong schema, so that an invalid configuration is
+// reported before it is handed to Kong, which rejects it as a whole
+func (c KongDeclarativeConfig) Validate() error {
+	var violations []string
+	violate := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if c.FormatVersion == "" {
+		violate("_format_version is required")
+	}
+	serviceNames := make(map[string]bool)
+	routeNames := make(map[string]bool)
+	for i, service := range c.Services {
+		if !kongNamePattern.MatchString(service.Name) {
+			violate("services[%d].name %q is invalid", i, service.Name)
+		} else if serviceNames[service.Name] {
+			violate("services[%d].name %q is duplicated", i, service.Name)
+		}
+		serviceNames[service.Name] = true
+		if !kongProtocols[service.Protocol] {
+			violate("services[%d].protocol %q is not supported", i, service.Protocol)
+		}
+		if service.Host == "" {
+			violate("services[%d].host is required", i)
+		}
+		if service.Port < 0 || service.Port > 65535 {
+			violate("services[%d].port %d is out of range", i, service.Port)
+		}
+		for j, route := range service.Routes {
+			if !kongNamePattern.MatchString(route.Name) {
+				violate("services[%d].routes[%d].name %q is invalid", i, j, route.Name)
+			} else if routeNames[route.Name] {
+				violate("services[%d].routes[%d].name %q is duplicated", i, j, route.Name)
+			}
+			routeNames[route.Name] = true
+			if len(route.Paths) == 0 {
+				violate("services[%d].routes[%d].paths is required", i, j)
+			}
+			for _, path := range route.Paths {
+				if !strings.HasPrefix(path, "/") {
+					violate("services[%d].routes[%d].paths %q should start with /", i, j, path)
+				}
+			}
+			routePluginNames := make(map[string]bool)
+			for k, plugin := range route.Plugins {
+				if !kongRoutePlugins[plugin.Name] {
+					violate("services[%d].routes[%d].plugins[%d].name %q is not supported", i, j, k, plugin.Name)
+				} else if routePluginNames[plugin.Name] {
+					violate("services[%d].routes[%d].plugins[%d].name %q is duplicated", i, j, k, plugin.Name)
+				}
+				routePluginNames[plugin.Name] = true
+			}
+		}
+	}
+
+	pluginNames := make(map[string]bool)
+	for i, plugin := range c.Plugins {
+		if !kongPlugins[plugin.Name] {
+			violate("plugins[%d].name %q is not supported", i, plugin.Name)
+		} else if pluginNames[plugin.Name] {
+			violate("plugins[%d].name %q is duplicated", i, plugin.Name)
+		}
+		pluginNames[plugin.Name] = true
+	}
+
+	sniNames := make(map[string]bool)
+	for i, certificate := range c.Certificates {
+		if _, err := tls.X509KeyPair([]byte(certificate.Cert), []byte(certificate.Key)); err != nil {
+			violate("certificates[%d] is invalid: %s", i, err.Error())
+		}
+		for j, sni := range certificate.Snis {
+			if sni.Name == "" {
+				violate("certificates[%d].snis[%d].name is required", i, j)
+			} else if sniNames[sni.Name] {
+				violate("certificates[%d].snis[%d].name %q is duplicated", i, j, sni.Name)
+			}
+			sniNames[sni.Name] = true
+		}
+	}
+
+	caIDs := make(map[string]bool)
+	for i, ca := range c.CACertificates {
+		block, _ := pem.Decode([]byte(ca.Cert))
+		if block == nil {
+			violate("ca_certificates[%d].cert is invalid", i)
+		} else if cert, err := x509.ParseCertificate(block.Bytes); err != nil {
+			violate("ca_certificates[%d].cert is invalid: %s", i, err.Error())
+		} else if !cert.IsCA {
+			violate("ca_certificates[%d].cert isn't a CA", i)
+		}
+		if ca.ID == "" {
+			violate("ca_certificates[%d].id is required", i)
+		} else if caIDs[ca.ID] {
+			violate("ca_certificates[%d].id %q is duplicated", i, ca.ID)
+		}
+		caIDs[ca.ID] = true
+	}
+	usernames := make(map[string]bool)
+	for i, consumer := range c.Consumers {
+		if consumer.Username == "" {
+			violate("consumers[%d].username is required", i)
+		} else if usernames[consumer.Username] {
+			violate("consumers[%d].username %q is duplicated", i, consumer.Username)
+		}
+		usernames[consumer.Username] = true
+		for j, acl := range consumer.ACLs {
+			if acl.Group == "" {
+				violate("consumers[%d].acls[%d].group is required", i, j)
+			}
+		}
+	}
+	for i, plugin := range c.Plugins {
+		if plugin.Name != MTLSAuthPlugin {
+			continue
+		}
+		ids, _ := plugin.Config["ca_certificates"].([]string)
+		if len(ids) == 0 {
+			violate("plugins[%d].config.ca_certificates is required", i)
+		}
+		for _, id := range ids {
+			if !caIDs[id] {
+				violate("plugins[%d].config.ca_certificates %q doesn't exist", i, id)
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return errors.New(strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// RegisteredServices returns the services registered to the configured registry, or nil when no registry is
+// configured. The protocol of a service is given by its "protocol" metadata, http by default.
+func (s *Service) RegisteredServices() (map[string]bootstrapConfig.ClientInfo, error) {
+	registry := s.configuration.Registry
+	if registry.Host == "" {
+		return nil, nil
+	}
+	if registry.Type != "" && registry.Type != "consul" {
+		return nil, fmt.Errorf("unsupported registry type: %s", registry.Type)
+	}
+
+	path := fmt.Sprintf("http://%s:%d/%s", registry.Host, registry.Port, registryServicesPath)
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registered services request -- %s", err.Error())
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the registered services from %s -- %s", path, err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get the registered services from %s with errorcode %d", path, resp.StatusCode)
+	}
+	var registered map[string]registeredService
+	if err = json.NewDecoder(resp.Body).Decode(&registered); err != nil {
+		return nil, fmt.Errorf("failed to decode the registered services -- %s", err.Error())
+	}
+
+	services := make(map[string]bootstrapConfig.ClientInfo)
+	for _, service := range registered {
+		if service.Address == "" {
+			s.loggingClient.Warn(fmt.Sprintf("ignoring registered service %s without address", service.Service))
+			continue
+		}
+		protocol := service.Meta[registryProtocolMeta]
+		if protocol == "" {
+			protocol = "http"
+		}
+		services[service.Service] = bootstrapConfig.ClientInfo{
+			Protocol: protocol,
+			Host:     service.Address,
+			Port:     service.Port,
+		}
+	}
+	return services, nil
+}
+
+// DeclarativeConfig generates the declarative configuration proxying the Clients, the routes of AddProxyRoutesEnv and
+// the registered services, protected by the authentication and ACL plugins, and serving cert when not nil. The
+// registered services already proxied under another name are skipped, and the routes selected by KongAccessLog are
+// logged. When KongMTLS is enabled, the client certificates issued by the client CA bundle authenticate their consumers
+// as an alternative to the tokens.
+func (s *Service) DeclarativeConfig(
+	registered map[string]bootstrapConfig.ClientInfo,
+	cert *bootstrapConfig.CertKeyPair) (KongDeclarativeConfig, error) {
+
+	kongConfig := KongDeclarativeConfig{FormatVersion: DeclarativeFormatVersion}
+
+	addRoutesFromEnv, parseErr := s.parseAdditionalProxyRoutes()
+	if parseErr != nil {
+		s.loggingClient.Error(fmt.Sprintf(
+			"failed to parse additional proxy Kong routes from env %s: %s",
+			s.additionalRoutes, parseErr.Error()))
+	}
+
+	services := make(map[string]bootstrapConfig.ClientInfo)
+	endpoints := make(map[string]bool)
+	for clientName, client := range s.mergeRoutesWith(addRoutesFromEnv) {
+		services[strings.ToLower(clientName)] = client
+		endpoints[net.JoinHostPort(client.Host, strconv.Itoa(client.Port))] = true
+	}
+	for serviceName, client := range registered {
+		name := strings.ToLower(serviceName)
+		if _, exists := services[name]; exists || endpoints[net.JoinHostPort(client.Host, strconv.Itoa(client.Port))] {
+			s.loggingClient.Debug(fmt.Sprintf("registered service %s is already proxied", serviceName))
+			continue
+		}
+		services[name] = client
+	}
+
+	if err := s.configuration.KongAccessLog.Validate(); err != nil {
+		return kongConfig, err
+	}
+	if err := s.configuration.KongMTLS.Validate(s.configuration.KongACL); err != nil {
+		return kongConfig, err
+	}
+
+	var names []string
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		client := services[name]
+		route := KongDeclarativeRoute{Name: name, Paths: []string{"/" + name}}
+		if s.configuration.KongAccessLog.Logged(name) {
+			route.Plugins = s.accessLogPlugins()
+		}
+		kongConfig.Services = append(kongConfig.Services, KongDeclarativeService{
+			Name:     name,
+			Protocol: client.Protocol,
+			Host:     client.Host,
+			Port:     client.Port,
+			Routes:   []KongDeclarativeRoute{route},
+		})
+	}
+
+	var authPlugin KongDeclarativePlugin
+	switch s.configuration.KongAuth.Name {
+	case "jwt":
+		authPlugin = KongDeclarativePlugin{Name: "jwt"}
+	case "oauth2":
+		authPlugin = KongDeclarativePlugin{
+			Name: "oauth2",
+			Config: map[string]interface{}{
+				"scopes":                    []string{OAuth2Scopes},
+				"mandatory_scope":           true,
+				"enable_client_credentials": true,
+				"global_credentials":        true,
+				"refresh_token_ttl":         s.configuration.KongAuth.TokenTTL,
+			},
+		}
+	default:
+		return kongConfig, fmt.Errorf("unsupported authentication method: %s", s.configuration.KongAuth.Name)
+	}
+	if s.configuration.KongMTLS.Enabled {
+		if len(s.clientCAs) == 0 {
+			return kongConfig, errors.New("the client CA bundle isn't set")
+		}
+		if authPlugin.Config == nil {
+			authPlugin.Config = make(map[string]interface{})
+		}
+		authPlugin.Config["anonymous"] = AnonymousConsumerID()
+		kongConfig.Plugins = append(kongConfig.Plugins, authPlugin, KongDeclarativePlugin{
+			Name:   MTLSAuthPlugin,
+			Config: s.mtlsPluginConfig(),
+		})
+		for _, ca := range s.clientCAs {
+			kongConfig.CACertificates = append(kongConfig.CACertificates, KongDeclarativeCACertificate{
+				ID:   caCertificateID(ca),
+				Cert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})),
+			})
+		}
+		kongConfig.Consumers = append(kongConfig.Consumers, KongDeclarativeConsumer{
+			ID:       AnonymousConsumerID(),
+			Username: AnonymousConsumer,
+		})
+		for _, consumer := range s.configuration.KongMTLS.Consumers {
+			declarative := KongDeclarativeConsumer{Username: consumer.Subject}
+			for _, role := range consumer.Roles {
+				declarative.ACLs = append(declarative.ACLs, KongDeclarativeConsumerACL{Group: role})
+			}
+			kongConfig.Consumers = append(kongConfig.Consumers, declarative)
+		}
+	} else {
+		kongConfig.Plugins = append(kongConfig.Plugins, authPlugin)
+	}
+
+	var whitelist []string
+	for _, group := range strings.Split(s.configuration.KongACL.WhiteList, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			whitelist = append(whitelist, group)
+		}
+	}
+	kongConfig.Plugins = append(kongConfig.Plugins, KongDeclarativePlugin{
+		Name:   s.configuration.KongACL.Name,
+		Config: map[string]interface{}{"whitelist": whitelist},
+	})
+
+	if cert != nil {
+		certificate := KongDeclarativeCertificate{Cert: cert.Cert, Key: cert.Key}
+		for _, sni := range s.configuration.SecretService.SNIS {
+			if sni = strings.TrimSpace(sni); sni != "" {
+				certificate.Snis = append(certificate.Snis, KongDeclarativeSniInfo{Name: sni})
+			}
+		}
+		kongConfig.Certificates = append(kongConfig.Certificates, certificate)
+	}
+
+	return kongConfig, nil
+}
+
+// InitDeclarative writes the validated declarative configuration to KongDeclarative.OutputPath, and loads it into the
+// running Kong when KongDeclarative.Load is set. Either the whole configuration is applied or none of it.
+func (s *Service) InitDeclarative(cert *bootstrapConfig.CertKeyPair) error {
+	registered, err := s.RegisteredServices()
+	if err != nil {
+		return err
+	}
+	kongConfig, err := s.DeclarativeConfig(registered, cert)
+	if err != nil {
+		return err
+	}
+	if err = kongConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid Kong declarative configuration: %s", err.Error())
+	}
+	data, err := yaml.Marshal(kongConfig)
+	if err != nil {
+		return fmt.Errorf("failed to encode Kong declarative configuration: %s", err.Error())
+	}
+
+	outputPath := s.configuration.KongDeclarative.OutputPath
+	if err = writeFile(outputPath, data); err != nil {
+		return fmt.Errorf("failed to write Kong declarative configuration to %s: %s", outputPath, err.Error())
+	}
+	s.loggingClient.Info(fmt.Sprintf(
+		"Kong declarative configuration of %d services written to %s", len(kongConfig.Services), outputPath))
+
+	if !s.configuration.KongDeclarative.Load {
+		return nil
+	}
+	if err = s.CheckProxyServiceStatus(); err != nil {
+		return err
+	}
+	return s.loadDeclarativeConfig(data)
+}
+
+func (s *Service) loadDeclarativeConfig(data []byte) error {
+	formVals := url.Values{
+		"config": {string(data)},
+	}
+	tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), ConfigPath}
+	req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), strings.NewReader(formVals.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create declarative configuration request -- %s", err.Error())
+	}
+	req.Header.Add(clients.ContentType, "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		e := fmt.Sprintf("failed to load declarative configuration -- %s", err.Error())
+		s.loggingClient.Error(e)
+		return errors.New(e)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		s.loggingClient.Info("declarative configuration loaded into the reverse proxy")
+	default:
+		b, _ := ioutil.ReadAll(resp.Body)
+		e := fmt.Sprintf("failed to load declarative configuration with errorcode %d, error %s", resp.StatusCode, string(b))
+		s.loggingClient.Error(e)
+		return errors.New(e)
+	}
+	return nil
+}
+
+// writeFile replaces the file at path with data readable by the owner only, so that a failed write doesn't leave a
+// truncated configuration behind
+func writeFile(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
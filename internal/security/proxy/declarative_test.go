@@ -0,0 +1,288 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func loadTestConfiguration(t *testing.T) *config.ConfigurationStruct {
+	contents, err := ioutil.ReadFile("./testdata/configuration.toml")
+	require.NoError(t, err)
+	configuration := &config.ConfigurationStruct{}
+	require.NoError(t, toml.Unmarshal(contents, configuration))
+	return configuration
+}
+
+func newTestCertKeyPair(t *testing.T) *bootstrapConfig.CertKeyPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "edgex-kong"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &bootstrapConfig.CertKeyPair{
+		Cert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		Key:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})),
+	}
+}
+
+func serviceNames(kongConfig KongDeclarativeConfig) []string {
+	var names []string
+	for _, service := range kongConfig.Services {
+		names = append(names, service.Name)
+	}
+	return names
+}
+
+func TestDeclarativeConfig(t *testing.T) {
+	configuration := loadTestConfiguration(t)
+	configuration.Clients = map[string]bootstrapConfig.ClientInfo{
+		"CoreData": {Protocol: "http", Host: "edgex-core-data", Port: 48080},
+		"Metadata": {Protocol: "http", Host: "edgex-core-metadata", Port: 48081},
+	}
+	mockLogger := logger.MockLogger{}
+	service := NewService(NewRequestor(true, 10, "", mockLogger), mockLogger, configuration)
+	service.additionalRoutes = "rules.http://edgex-app-rules:48100"
+	registered := map[string]bootstrapConfig.ClientInfo{
+		"edgex-core-data": {Protocol: "http", Host: "edgex-core-data", Port: 48080},
+		"metadata":        {Protocol: "http", Host: "edgex-core-metadata", Port: 48999},
+		"device-virtual":  {Protocol: "http", Host: "edgex-device-virtual", Port: 49990},
+	}
+	cert := newTestCertKeyPair(t)
+
+	kongConfig, err := service.DeclarativeConfig(registered, cert)
+	require.NoError(t, err)
+	require.NoError(t, kongConfig.Validate())
+	assert.Equal(t, DeclarativeFormatVersion, kongConfig.FormatVersion)
+	assert.Equal(t, []string{"coredata", "device-virtual", "metadata", "rules"}, serviceNames(kongConfig),
+		"the registered services already proxied should be skipped")
+	assert.Equal(t, KongDeclarativeService{
+		Name:     "rules",
+		Protocol: "http",
+		Host:     "edgex-app-rules",
+		Port:     48100,
+		Routes:   []KongDeclarativeRoute{{Name: "rules", Paths: []string{"/rules"}}},
+	}, kongConfig.Services[3])
+
+	assert.Empty(t, kongConfig.Services[0].Routes[0].Plugins, "the routes should not be logged by default")
+
+	require.Len(t, kongConfig.Plugins, 2)
+	assert.Equal(t, "oauth2", kongConfig.Plugins[0].Name)
+	assert.Equal(t, []string{OAuth2Scopes}, kongConfig.Plugins[0].Config["scopes"])
+	assert.Equal(t, "acl", kongConfig.Plugins[1].Name)
+	assert.Equal(t, []string{"admin"}, kongConfig.Plugins[1].Config["whitelist"])
+
+	require.Len(t, kongConfig.Certificates, 1)
+	assert.Equal(t, cert.Cert, kongConfig.Certificates[0].Cert)
+	assert.Equal(t, []KongDeclarativeSniInfo{{Name: "edgex-kong"}}, kongConfig.Certificates[0].Snis)
+
+	configuration.KongAccessLog = config.KongAccessLogInfo{Routes: []string{"Rules"}, HttpEndpoint: "http://edgex-logstash/kong"}
+	kongConfig, err = service.DeclarativeConfig(registered, nil)
+	require.NoError(t, err)
+	require.NoError(t, kongConfig.Validate())
+	assert.Empty(t, kongConfig.Services[0].Routes[0].Plugins)
+	require.Len(t, kongConfig.Services[3].Routes[0].Plugins, 2)
+	assert.Equal(t, CorrelationIdPlugin, kongConfig.Services[3].Routes[0].Plugins[0].Name)
+	assert.Equal(t, HttpLogPlugin, kongConfig.Services[3].Routes[0].Plugins[1].Name)
+
+	configuration.KongAccessLog.HttpEndpoint = ""
+	_, err = service.DeclarativeConfig(registered, nil)
+	assert.Error(t, err, "the access log endpoint should be required")
+
+	configuration.KongAccessLog = config.KongAccessLogInfo{}
+	configuration.KongAuth.Name = "basic"
+	_, err = service.DeclarativeConfig(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestKongDeclarativeConfig_Validate(t *testing.T) {
+	validService := func() KongDeclarativeService {
+		return KongDeclarativeService{
+			Name:     "coredata",
+			Protocol: "http",
+			Host:     "edgex-core-data",
+			Port:     48080,
+			Routes:   []KongDeclarativeRoute{{Name: "coredata", Paths: []string{"/coredata"}}},
+		}
+	}
+	cert := newTestCertKeyPair(t)
+
+	tests := []struct {
+		name   string
+		modify func(c *KongDeclarativeConfig)
+		valid  bool
+	}{
+		{"valid", func(c *KongDeclarativeConfig) {}, true},
+		{"missing format version", func(c *KongDeclarativeConfig) { c.FormatVersion = "" }, false},
+		{"invalid service name", func(c *KongDeclarativeConfig) { c.Services[0].Name = "core data" }, false},
+		{"duplicated service name", func(c *KongDeclarativeConfig) {
+			service := validService()
+			service.Routes[0].Name = "other"
+			c.Services = append(c.Services, service)
+		}, false},
+		{"unsupported protocol", func(c *KongDeclarativeConfig) { c.Services[0].Protocol = "ftp" }, false},
+		{"missing host", func(c *KongDeclarativeConfig) { c.Services[0].Host = "" }, false},
+		{"port out of range", func(c *KongDeclarativeConfig) { c.Services[0].Port = 70000 }, false},
+		{"relative route path", func(c *KongDeclarativeConfig) { c.Services[0].Routes[0].Paths = []string{"coredata"} }, false},
+		{"missing route paths", func(c *KongDeclarativeConfig) { c.Services[0].Routes[0].Paths = nil }, false},
+		{"unsupported plugin", func(c *KongDeclarativeConfig) { c.Plugins[0].Name = "basic-auth" }, false},
+		{"duplicated plugin", func(c *KongDeclarativeConfig) { c.Plugins = append(c.Plugins, c.Plugins[0]) }, false},
+		{"mismatched certificate key", func(c *KongDeclarativeConfig) { c.Certificates[0].Key = newTestCertKeyPair(t).Key }, false},
+		{"unsupported route plugin", func(c *KongDeclarativeConfig) {
+			c.Services[0].Routes[0].Plugins = []KongDeclarativePlugin{{Name: "jwt"}}
+		}, false},
+		{"duplicated route plugin", func(c *KongDeclarativeConfig) {
+			c.Services[0].Routes[0].Plugins = []KongDeclarativePlugin{{Name: HttpLogPlugin}, {Name: HttpLogPlugin}}
+		}, false},
+		{"empty sni", func(c *KongDeclarativeConfig) { c.Certificates[0].Snis = []KongDeclarativeSniInfo{{}} }, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			kongConfig := KongDeclarativeConfig{
+				FormatVersion: DeclarativeFormatVersion,
+				Services:      []KongDeclarativeService{validService()},
+				Plugins:       []KongDeclarativePlugin{{Name: "jwt"}, {Name: "acl"}},
+				Certificates: []KongDeclarativeCertificate{
+					{Cert: cert.Cert, Key: cert.Key, Snis: []KongDeclarativeSniInfo{{Name: "edgex-kong"}}},
+				},
+			}
+			testCase.modify(&kongConfig)
+			err := kongConfig.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRegisteredServices(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+registryServicesPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"edgex-core-data": {"ID": "edgex-core-data", "Service": "edgex-core-data", "Address": "edgex-core-data", "Port": 48080},
+			"app-rules": {"ID": "app-rules", "Service": "app-rules", "Address": "edgex-app-rules", "Port": 48100, "Meta": {"protocol": "https"}},
+			"no-address": {"ID": "no-address", "Service": "no-address", "Port": 1234}
+		}`))
+	}))
+	defer ts.Close()
+
+	host, port, err := parseHostAndPort(ts, t)
+	require.NoError(t, err)
+	configuration := loadTestConfiguration(t)
+	mockLogger := logger.MockLogger{}
+	service := NewService(NewRequestor(true, 10, "", mockLogger), mockLogger, configuration)
+
+	registered, err := service.RegisteredServices()
+	require.NoError(t, err)
+	assert.Nil(t, registered, "no registry should be queried when not configured")
+
+	configuration.Registry = bootstrapConfig.RegistryInfo{Host: host, Port: port, Type: "consul"}
+	registered, err = service.RegisteredServices()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bootstrapConfig.ClientInfo{
+		"edgex-core-data": {Protocol: "http", Host: "edgex-core-data", Port: 48080},
+		"app-rules":       {Protocol: "https", Host: "edgex-app-rules", Port: 48100},
+	}, registered)
+
+	configuration.Registry.Type = "etcd"
+	_, err = service.RegisteredServices()
+	assert.Error(t, err)
+}
+
+func TestInitDeclarative(t *testing.T) {
+	var loaded string
+	status := http.StatusCreated
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && r.URL.Path == "/"+ConfigPath:
+			loaded = r.FormValue("config")
+			w.WriteHeader(status)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	host, port, err := parseHostAndPort(ts, t)
+	require.NoError(t, err)
+	configuration := loadTestConfiguration(t)
+	configuration.KongURL = config.KongUrlInfo{Server: host, AdminPort: port}
+	outputPath := filepath.Join(t.TempDir(), "kong.yml")
+	configuration.KongDeclarative = config.KongDeclarativeInfo{OutputPath: outputPath}
+	mockLogger := logger.MockLogger{}
+	service := NewService(NewRequestor(true, 10, "", mockLogger), mockLogger, configuration)
+
+	require.NoError(t, service.InitDeclarative(nil))
+	assert.Empty(t, loaded, "the configuration should only be loaded when enabled")
+	info, err := os.Stat(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	contents, err := ioutil.ReadFile(outputPath)
+	require.NoError(t, err)
+	var written KongDeclarativeConfig
+	require.NoError(t, yaml.Unmarshal(contents, &written))
+	assert.Equal(t, DeclarativeFormatVersion, written.FormatVersion)
+	assert.Len(t, written.Services, len(configuration.Clients))
+
+	configuration.KongDeclarative.Load = true
+	require.NoError(t, service.InitDeclarative(nil))
+	assert.Equal(t, string(contents), loaded)
+
+	status = http.StatusBadRequest
+	assert.Error(t, service.InitDeclarative(nil))
+
+	configuration.Clients["Invalid"] = bootstrapConfig.ClientInfo{Protocol: "http", Port: 80}
+	loaded = ""
+	assert.Error(t, service.InitDeclarative(nil), "an invalid configuration should be rejected")
+	assert.Empty(t, loaded)
+}
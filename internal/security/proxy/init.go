@@ -18,14 +18,17 @@ package proxy
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sync"
 
 	"github.com/edgexfoundry/edgex-go/internal"
 	"github.com/edgexfoundry/edgex-go/internal/security/proxy/container"
+	"github.com/edgexfoundry/edgex-go/internal/security/securebuffer"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
@@ -65,6 +68,11 @@ func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ sta
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	configuration := container.ConfigurationFrom(dic.Get)
 
+	// The certificates' private keys and the tokens are held in strings, which mustn't end up in a core dump
+	if err := securebuffer.DisableCoreDumps(); err != nil {
+		lc.Warn(fmt.Sprintf("failed to disable core dumps: %s", err.Error()))
+	}
+
 	var req internal.HttpCaller
 	if len(configuration.SecretService.CACertPath) > 0 {
 		req = NewRequestor(
@@ -85,6 +93,26 @@ func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ sta
 	}
 
 	s := NewService(req, lc, configuration)
+
+	if b.initNeeded && configuration.KongMTLS.Enabled {
+		secrets, err := bootstrapContainer.SecretProviderFrom(dic.Get).GetSecrets(
+			configuration.KongMTLS.CASecretPath, CABundleSecretKey)
+		b.haltIfError(lc, err)
+		b.haltIfError(lc, s.SetClientCABundle(secrets[CABundleSecretKey]))
+	}
+
+	// the declarative configuration replaces the admin API calls initializing Kong
+	if b.initNeeded && !b.resetNeeded && configuration.KongDeclarative.OutputPath != "" {
+		var cert *bootstrapConfig.CertKeyPair
+		if configuration.KongDeclarative.IncludeCertificate {
+			secrets, err := bootstrapContainer.SecretProviderFrom(dic.Get).GetSecrets("", "cert", "key")
+			b.haltIfError(lc, err)
+			cert = &bootstrapConfig.CertKeyPair{Cert: secrets["cert"], Key: secrets["key"]}
+		}
+		b.haltIfError(lc, s.InitDeclarative(cert))
+		return false
+	}
+
 	b.haltIfError(lc, s.CheckProxyServiceStatus())
 
 	if b.initNeeded {
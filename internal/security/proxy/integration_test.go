@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/securitytest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupIntegration(t *testing.T) {
+	contents, err := ioutil.ReadFile("./testdata/configuration.toml")
+	require.NoError(t, err)
+	configuration := &config.ConfigurationStruct{}
+	require.NoError(t, toml.Unmarshal(contents, configuration))
+	configuration.KongAccessLog = config.KongAccessLogInfo{Routes: []string{"coredata"}, HttpEndpoint: "http://edgex-access-log:8080"}
+
+	h := securitytest.NewHarness(t)
+	host, port := h.Kong.HostAndPort()
+	configuration.KongURL = config.KongUrlInfo{Server: host, AdminPort: port}
+
+	origProxyRoutEnv := os.Getenv(AddProxyRoutesEnv)
+	defer func() {
+		_ = os.Setenv(AddProxyRoutesEnv, origProxyRoutEnv)
+	}()
+	require.NoError(t, os.Setenv(AddProxyRoutesEnv, "AppServiceRules.http://edgex-app-service-configurable-rules:48100"))
+
+	lc := logger.MockLogger{}
+	s := NewService(NewRequestor(true, 10, "", lc), lc, configuration)
+	require.NoError(t, s.CheckProxyServiceStatus())
+
+	expected := []string{"appservicerules", "command", "coredata", "metadata", "notifications", "rulesengine",
+		"scheduler", "virtualdevice"}
+	// initializing again succeeds, the existing entities being kept
+	for run := 0; run < 2; run++ {
+		require.NoError(t, s.Init())
+		assert.Equal(t, expected, h.Kong.Names(ServicesPath))
+		assert.Equal(t, expected, h.Kong.Names(RoutesPath))
+		for _, route := range h.Kong.Entities(RoutesPath) {
+			assert.Equal(t, ServicesPath+"/"+route.Name, route.Parent)
+			assert.Equal(t, []interface{}{"/" + route.Name}, route.Fields["paths"])
+		}
+		assert.Equal(t, []string{"acl", CorrelationIdPlugin, HttpLogPlugin, "oauth2"}, h.Kong.Names(PluginsPath))
+	}
+	for _, plugin := range h.Kong.Entities(PluginsPath) {
+		switch plugin.Name {
+		case CorrelationIdPlugin, HttpLogPlugin:
+			assert.Equal(t, RoutesPath+"/coredata", plugin.Parent)
+		case "acl":
+			assert.Equal(t, "admin", plugin.Fields["config.whitelist"])
+		}
+	}
+
+	require.NoError(t, s.ResetProxy())
+	for _, path := range []string{RoutesPath, ServicesPath, ConsumersPath, PluginsPath, CertificatesPath} {
+		assert.Empty(t, h.Kong.Entities(path), path)
+	}
+}
@@ -18,9 +18,11 @@ package proxy
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configflags"
 	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/proxy/container"
 
@@ -41,12 +43,14 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 	var initNeeded bool
 	var insecureSkipVerify bool
 	var resetNeeded bool
+	var configFlags configflags.Flags
 
 	// All common command-line flags have been moved to bootstrap. Service specific flags are added below.
 	f := flags.NewWithUsage(
 		"    --insecureSkipVerify=true/false Indicates if skipping the server side SSL cert verification, similar to -k of curl\n" +
 			"    --init=true/false               Indicates if security service should be initialized\n" +
-			"    --reset=true/false              Indicate if security service should be reset to initialization status\n",
+			"    --reset=true/false              Indicate if security service should be reset to initialization status\n" +
+			configflags.Usage,
 	)
 
 	if len(os.Args) < 2 {
@@ -56,7 +60,12 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 	f.FlagSet.BoolVar(&insecureSkipVerify, "insecureSkipVerify", false, "")
 	f.FlagSet.BoolVar(&initNeeded, "init", false, "")
 	f.FlagSet.BoolVar(&resetNeeded, "reset", false, "")
+	configFlags.Register(f.FlagSet)
 	f.Parse(os.Args[1:])
+	if err := configFlags.Apply(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 
 	configuration := &config.ConfigurationStruct{}
 	dic := di.NewContainer(di.ServiceConstructorMap{
@@ -75,6 +84,7 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 		startupTimer,
 		dic,
 		[]interfaces.BootstrapHandler{
+			configflags.NewHandler(&configFlags, configuration).BootstrapHandler,
 			handlers.SecureProviderBootstrapHandler,
 			NewBootstrap(
 				insecureSkipVerify,
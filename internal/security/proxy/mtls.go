@@ -0,0 +1,201 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MTLSAuthPlugin is the Kong plugin authenticating the consumers by their client certificates
+	MTLSAuthPlugin = "mtls-auth"
+	// CACertificatesPath is the admin API endpoint of the CA certificates the client certificates are verified against
+	CACertificatesPath = "ca_certificates"
+	// CABundleSecretKey is the key of the PEM bundle of the CAs in the secret at KongMTLS.CASecretPath
+	CABundleSecretKey = "cabundle"
+	// AnonymousConsumer is the consumer of the requests authenticated neither by a client certificate nor a token,
+	// which has no ACL group and so is denied by the ACL plugin
+	AnonymousConsumer = "anonymous"
+
+	defaultMTLSHttpTimeout  = 30000
+	defaultMTLSCertCacheTTL = 60000
+)
+
+// kongEntityNamespace derives the ids of the entities referenced by the plugins, which are the same on every --init
+// so that the admin API upserts them and the declarative configuration can reference them
+var kongEntityNamespace = uuid.MustParse("6f3c8a4e-2d0b-4f51-9a57-3c1e7b9d2a10")
+
+// AnonymousConsumerID returns the id of the AnonymousConsumer
+func AnonymousConsumerID() string {
+	return uuid.NewSHA1(kongEntityNamespace, []byte(ConsumersPath+"/"+AnonymousConsumer)).String()
+}
+
+// caCertificateID returns the id of the Kong CA certificate of cert
+func caCertificateID(cert *x509.Certificate) string {
+	return uuid.NewSHA1(kongEntityNamespace, cert.Raw).String()
+}
+
+// SetClientCABundle sets the CAs the client certificates are verified against from their PEM bundle, which should
+// only hold valid CA certificates
+func (s *Service) SetClientCABundle(bundle string) error {
+	var cas []*x509.Certificate
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected %s in the client CA bundle", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse the client CA bundle -- %s", err.Error())
+		}
+		if !cert.BasicConstraintsValid || !cert.IsCA {
+			return fmt.Errorf("certificate %q of the client CA bundle isn't a CA", cert.Subject.String())
+		}
+		if time.Now().After(cert.NotAfter) {
+			return fmt.Errorf("CA %q of the client CA bundle expired on %s", cert.Subject.String(),
+				cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+		cas = append(cas, cert)
+	}
+	if len(cas) == 0 {
+		return errors.New("the client CA bundle holds no certificate")
+	}
+	s.clientCAs = cas
+	return nil
+}
+
+// mtlsPluginConfig returns the configuration of the MTLSAuthPlugin. The consumers are looked up by the username
+// matching the common name, or else a subject alternative name, of the certificates.
+func (s *Service) mtlsPluginConfig() map[string]interface{} {
+	mtls := s.configuration.KongMTLS
+	httpTimeout := mtls.HttpTimeout
+	if httpTimeout == 0 {
+		httpTimeout = defaultMTLSHttpTimeout
+	}
+	certCacheTTL := mtls.CertCacheTTL
+	if certCacheTTL == 0 {
+		certCacheTTL = defaultMTLSCertCacheTTL
+	}
+	var ids []string
+	for _, ca := range s.clientCAs {
+		ids = append(ids, caCertificateID(ca))
+	}
+	return map[string]interface{}{
+		"ca_certificates":       ids,
+		"consumer_by":           []string{"username"},
+		"revocation_check_mode": mtls.RevocationCheckMode,
+		"http_timeout":          httpTimeout,
+		"cert_cache_ttl":        certCacheTTL,
+		"anonymous":             AnonymousConsumerID(),
+	}
+}
+
+// initMTLS creates, or updates, the CA certificates, the anonymous consumer and the consumers of the client
+// certificates with their ACL groups, and enables the MTLSAuthPlugin through the admin API
+func (s *Service) initMTLS() error {
+	if len(s.clientCAs) == 0 {
+		return errors.New("the client CA bundle isn't set")
+	}
+	for _, ca := range s.clientCAs {
+		formVals := url.Values{
+			"cert": {string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}))},
+		}
+		what := fmt.Sprintf("CA certificate %s", ca.Subject.String())
+		if err := s.sendKongForm(http.MethodPut, formVals, what, CACertificatesPath, caCertificateID(ca)); err != nil {
+			return err
+		}
+	}
+
+	formVals := url.Values{"username": {AnonymousConsumer}}
+	err := s.sendKongForm(http.MethodPut, formVals, "anonymous consumer", ConsumersPath, AnonymousConsumerID())
+	if err != nil {
+		return err
+	}
+	for _, consumer := range s.configuration.KongMTLS.Consumers {
+		what := fmt.Sprintf("consumer %s", consumer.Subject)
+		formVals = url.Values{"username": {consumer.Subject}}
+		if err := s.sendKongForm(http.MethodPut, formVals, what, ConsumersPath, consumer.Subject); err != nil {
+			return err
+		}
+		for _, role := range consumer.Roles {
+			formVals = url.Values{"group": {role}}
+			what = fmt.Sprintf("group %s of consumer %s", role, consumer.Subject)
+			if err := s.sendKongForm(http.MethodPost, formVals, what, ConsumersPath, consumer.Subject, "acls"); err != nil {
+				return err
+			}
+		}
+	}
+
+	formVals = url.Values{"name": {MTLSAuthPlugin}}
+	for key, value := range s.mtlsPluginConfig() {
+		switch v := value.(type) {
+		case []string:
+			formVals["config."+key] = v
+		case int:
+			formVals.Set("config."+key, strconv.Itoa(v))
+		default:
+			formVals.Set("config."+key, fmt.Sprint(v))
+		}
+	}
+	return s.sendKongForm(http.MethodPost, formVals, "client certificate authentication", PluginsPath)
+}
+
+// sendKongForm sends formVals to the admin API endpoint made of the path elements, the entity already existing being
+// no error
+func (s *Service) sendKongForm(method string, formVals url.Values, what string, path ...string) error {
+	tokens := append([]string{s.configuration.KongURL.GetProxyBaseURL()}, path...)
+	req, err := http.NewRequest(method, strings.Join(tokens, "/"), strings.NewReader(formVals.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request -- %s", what, err.Error())
+	}
+	req.Header.Add(clients.ContentType, "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		e := fmt.Sprintf("failed to set up %s -- %s", what, err.Error())
+		s.loggingClient.Error(e)
+		return errors.New(e)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusConflict:
+		s.loggingClient.Info(fmt.Sprintf("successful to set up %s", what))
+	default:
+		b, _ := ioutil.ReadAll(resp.Body)
+		e := fmt.Sprintf("failed to set up %s with errorcode %d, error %s", what, resp.StatusCode, string(b))
+		s.loggingClient.Error(e)
+		return errors.New(e)
+	}
+	return nil
+}
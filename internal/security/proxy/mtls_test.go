@@ -0,0 +1,229 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/securitytest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func newTestCA(t *testing.T, commonName string, isCA bool, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-2 * time.Hour),
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testMTLSInfo() config.KongMTLSInfo {
+	return config.KongMTLSInfo{
+		Enabled:             true,
+		CASecretPath:        "clientca",
+		RevocationCheckMode: "STRICT",
+		Consumers: []config.KongMTLSConsumer{
+			{Subject: "edgex-rules-engine", Roles: []string{"admin"}},
+		},
+	}
+}
+
+func TestKongMTLSInfoValidate(t *testing.T) {
+	acl := config.KongAclInfo{Name: "acl", WhiteList: "admin, operator"}
+	valid := testMTLSInfo()
+	noPath := testMTLSInfo()
+	noPath.CASecretPath = ""
+	invalidMode := testMTLSInfo()
+	invalidMode.RevocationCheckMode = "OCSP"
+	negativeTimeout := testMTLSInfo()
+	negativeTimeout.HttpTimeout = -1
+	noSubject := testMTLSInfo()
+	noSubject.Consumers[0].Subject = ""
+	duplicated := testMTLSInfo()
+	duplicated.Consumers = append(duplicated.Consumers, duplicated.Consumers[0])
+	noRoles := testMTLSInfo()
+	noRoles.Consumers[0].Roles = nil
+	notWhitelisted := testMTLSInfo()
+	notWhitelisted.Consumers[0].Roles = []string{"operator", "reader"}
+
+	tests := []struct {
+		name  string
+		mtls  config.KongMTLSInfo
+		valid bool
+	}{
+		{"valid", valid, true},
+		{"disabled", config.KongMTLSInfo{}, true},
+		{"no CA secret path", noPath, false},
+		{"invalid revocation check mode", invalidMode, false},
+		{"negative timeout", negativeTimeout, false},
+		{"no subject", noSubject, false},
+		{"duplicated subject", duplicated, false},
+		{"no roles", noRoles, false},
+		{"role not whitelisted", notWhitelisted, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mtls.Validate(acl)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSetClientCABundle(t *testing.T) {
+	ca := newTestCA(t, "EdgeX Client CA", true, time.Now().Add(time.Hour))
+	otherCA := newTestCA(t, "Partner Client CA", true, time.Now().Add(time.Hour))
+	leaf := newTestCA(t, "edgex-rules-engine", false, time.Now().Add(time.Hour))
+	expired := newTestCA(t, "Expired Client CA", true, time.Now().Add(-time.Hour))
+	key := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}))
+
+	tests := []struct {
+		name   string
+		bundle string
+		cas    int
+	}{
+		{"one CA", ca, 1},
+		{"two CAs", ca + otherCA, 2},
+		{"not a CA", ca + leaf, 0},
+		{"expired CA", expired, 0},
+		{"private key", ca + key, 0},
+		{"empty", "", 0},
+		{"not PEM", "EdgeX Client CA", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(nil, logger.MockLogger{}, &config.ConfigurationStruct{})
+			err := service.SetClientCABundle(tt.bundle)
+			if tt.cas == 0 {
+				assert.Error(t, err)
+				assert.Empty(t, service.clientCAs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, service.clientCAs, tt.cas)
+		})
+	}
+}
+
+func TestInitMTLS(t *testing.T) {
+	configuration := loadTestConfiguration(t)
+	configuration.KongMTLS = testMTLSInfo()
+	h := securitytest.NewHarness(t)
+	host, port := h.Kong.HostAndPort()
+	configuration.KongURL = config.KongUrlInfo{Server: host, AdminPort: port}
+
+	lc := logger.MockLogger{}
+	s := NewService(NewRequestor(true, 10, "", lc), lc, configuration)
+	require.Error(t, s.Init(), "the client CA bundle should be required")
+	require.NoError(t, s.SetClientCABundle(newTestCA(t, "EdgeX Client CA", true, time.Now().Add(time.Hour))))
+	caID := caCertificateID(s.clientCAs[0])
+
+	// initializing again succeeds, the entities being upserted
+	for run := 0; run < 2; run++ {
+		require.NoError(t, s.Init())
+		require.Len(t, h.Kong.Entities(CACertificatesPath), 1)
+		assert.Equal(t, caID, h.Kong.Entities(CACertificatesPath)[0].ID)
+		assert.Equal(t, []string{AnonymousConsumer, "edgex-rules-engine"}, h.Kong.Names(ConsumersPath))
+		acls := h.Kong.Entities("acls")
+		require.Len(t, acls, 1)
+		assert.Equal(t, ConsumersPath+"/edgex-rules-engine", acls[0].Parent)
+		assert.Equal(t, "admin", acls[0].Fields["group"])
+		assert.Equal(t, []string{"acl", MTLSAuthPlugin, "oauth2"}, h.Kong.Names(PluginsPath))
+	}
+	for _, consumer := range h.Kong.Entities(ConsumersPath) {
+		if consumer.Name == AnonymousConsumer {
+			assert.Equal(t, AnonymousConsumerID(), consumer.ID)
+		}
+	}
+	for _, plugin := range h.Kong.Entities(PluginsPath) {
+		switch plugin.Name {
+		case MTLSAuthPlugin:
+			assert.Equal(t, caID, plugin.Fields["config.ca_certificates"])
+			assert.Equal(t, "STRICT", plugin.Fields["config.revocation_check_mode"])
+			assert.Equal(t, "30000", plugin.Fields["config.http_timeout"])
+			assert.Equal(t, AnonymousConsumerID(), plugin.Fields["config.anonymous"])
+		case "oauth2":
+			assert.Equal(t, AnonymousConsumerID(), plugin.Fields["config.anonymous"])
+		}
+	}
+
+	require.NoError(t, s.ResetProxy())
+	assert.Empty(t, h.Kong.Entities(CACertificatesPath))
+	assert.Empty(t, h.Kong.Entities(ConsumersPath))
+}
+
+func TestDeclarativeConfigMTLS(t *testing.T) {
+	configuration := loadTestConfiguration(t)
+	configuration.KongAuth.Name = "jwt"
+	configuration.KongMTLS = testMTLSInfo()
+	mockLogger := logger.MockLogger{}
+	service := NewService(NewRequestor(true, 10, "", mockLogger), mockLogger, configuration)
+
+	_, err := service.DeclarativeConfig(nil, nil)
+	require.Error(t, err, "the client CA bundle should be required")
+
+	ca := newTestCA(t, "EdgeX Client CA", true, time.Now().Add(time.Hour))
+	require.NoError(t, service.SetClientCABundle(ca))
+	kongConfig, err := service.DeclarativeConfig(nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, kongConfig.Validate())
+
+	caID := caCertificateID(service.clientCAs[0])
+	assert.Equal(t, []KongDeclarativeCACertificate{{ID: caID, Cert: ca}}, kongConfig.CACertificates)
+	assert.Equal(t, []KongDeclarativeConsumer{
+		{ID: AnonymousConsumerID(), Username: AnonymousConsumer},
+		{Username: "edgex-rules-engine", ACLs: []KongDeclarativeConsumerACL{{Group: "admin"}}},
+	}, kongConfig.Consumers)
+	require.Len(t, kongConfig.Plugins, 3)
+	assert.Equal(t, "jwt", kongConfig.Plugins[0].Name)
+	assert.Equal(t, AnonymousConsumerID(), kongConfig.Plugins[0].Config["anonymous"])
+	assert.Equal(t, MTLSAuthPlugin, kongConfig.Plugins[1].Name)
+	assert.Equal(t, []string{caID}, kongConfig.Plugins[1].Config["ca_certificates"])
+	assert.Equal(t, []string{"username"}, kongConfig.Plugins[1].Config["consumer_by"])
+	assert.Equal(t, "acl", kongConfig.Plugins[2].Name)
+
+	data, err := yaml.Marshal(kongConfig)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "ca_certificates:")
+	assert.Contains(t, string(data), "consumers:")
+
+	kongConfig.Plugins[1].Config["ca_certificates"] = []string{AnonymousConsumerID()}
+	assert.Error(t, kongConfig.Validate(), "the plugin should only reference the CA certificates of the configuration")
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 )
@@ -36,7 +37,7 @@ func NewRequestor(
 	var tr *http.Transport
 	if skipVerify {
 		tr = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			TLSClientConfig: fips.Restrict(&tls.Config{InsecureSkipVerify: true}),
 		}
 	} else {
 		caCert, err := ioutil.ReadFile(caCertPath)
@@ -49,10 +50,10 @@ func NewRequestor(
 		caCertPool.AppendCertsFromPEM(caCert)
 
 		tr = &http.Transport{
-			TLSClientConfig: &tls.Config{
+			TLSClientConfig: fips.Restrict(&tls.Config{
 				RootCAs:            caCertPool,
 				InsecureSkipVerify: false,
-			},
+			}),
 			TLSHandshakeTimeout: 10 * time.Second,
 		}
 	}
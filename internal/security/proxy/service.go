@@ -17,6 +17,8 @@
 package proxy
 
 import (
+	"bytes"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +31,7 @@ import (
 	"strings"
 
 	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/securebuffer"
 
 	"github.com/edgexfoundry/edgex-go/internal"
 
@@ -63,6 +66,8 @@ type Service struct {
 	configuration    *config.ConfigurationStruct
 	additionalRoutes string
 	routes           map[string]*KongRoute
+	// clientCAs are the CAs of the client certificates authenticated when KongMTLS is enabled
+	clientCAs []*x509.Certificate
 }
 
 func NewService(
@@ -112,7 +117,7 @@ func (s *Service) checkServiceStatus(path string) error {
 }
 
 func (s *Service) ResetProxy() error {
-	paths := []string{RoutesPath, ServicesPath, ConsumersPath, PluginsPath, CertificatesPath}
+	paths := []string{RoutesPath, ServicesPath, ConsumersPath, PluginsPath, CertificatesPath, CACertificatesPath}
 	for _, path := range paths {
 		d, err := s.getSvcIDs(path)
 		if err != nil {
@@ -132,6 +137,13 @@ func (s *Service) ResetProxy() error {
 func (s *Service) Init() error {
 	// no cert pair to post internally any more
 
+	if err := s.configuration.KongAccessLog.Validate(); err != nil {
+		return err
+	}
+	if err := s.configuration.KongMTLS.Validate(s.configuration.KongACL); err != nil {
+		return err
+	}
+
 	addRoutesFromEnv, parseErr := s.parseAdditionalProxyRoutes()
 
 	if parseErr != nil {
@@ -164,6 +176,19 @@ func (s *Service) Init() error {
 		if err != nil {
 			return err
 		}
+
+		if s.configuration.KongAccessLog.Logged(routeParams.Name) {
+			if err = s.initAccessLog(routeParams.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the anonymous consumer of the auth plugins is created along with the client certificate authentication
+	if s.configuration.KongMTLS.Enabled {
+		if err := s.initMTLS(); err != nil {
+			return err
+		}
 	}
 
 	err := s.initAuthMethod(s.configuration.KongAuth.Name, s.configuration.KongAuth.TokenTTL)
@@ -290,8 +315,9 @@ func (s *Service) postCert(cp bootstrapConfig.CertKeyPair) *CertError {
 		s.loggingClient.Error(err.Error())
 		return &CertError{err.Error(), InternalError}
 	}
+	defer securebuffer.Wipe(data) // the body holds the private key
 	tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), CertificatesPath}
-	req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), strings.NewReader(string(data)))
+	req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), bytes.NewReader(data))
 	req.Header.Add(clients.ContentType, clients.ContentTypeJSON)
 	if err != nil {
 		s.loggingClient.Error("failed to create upload cert request -- %s", err.Error())
@@ -457,6 +483,7 @@ func (s *Service) initJWTAuth() error {
 	formVals := url.Values{
 		"name": {"jwt"},
 	}
+	s.allowAnonymous(formVals)
 	tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), PluginsPath}
 	req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), strings.NewReader(formVals.Encode()))
 	if err != nil {
@@ -504,6 +531,7 @@ func (s *Service) initOAuth2(ttl int) error {
 		"config.global_credentials":        {oauth2Params.EnableGlobalCredentials},
 		"config.refresh_token_ttl":         {strconv.Itoa(oauth2Params.TokenTTL)},
 	}
+	s.allowAnonymous(formVals)
 	tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), PluginsPath}
 	req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), strings.NewReader(formVals.Encode()))
 	if err != nil {
@@ -533,6 +561,15 @@ func (s *Service) initOAuth2(ttl int) error {
 	return nil
 }
 
+// allowAnonymous lets the requests without token through the auth plugin as the AnonymousConsumer when the client
+// certificates are authenticated too, so that a request is authenticated by either of them. The ACL plugin denies the
+// requests authenticated by neither.
+func (s *Service) allowAnonymous(formVals url.Values) {
+	if s.configuration.KongMTLS.Enabled {
+		formVals.Set("config.anonymous", AnonymousConsumerID())
+	}
+}
+
 func (s *Service) getSvcIDs(path string) (DataCollect, error) {
 	collection := DataCollect{}
 
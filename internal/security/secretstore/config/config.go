@@ -16,6 +16,7 @@
 package config
 
 import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
 
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
@@ -25,6 +26,26 @@ type ConfigurationStruct struct {
 	LogLevel      string
 	SecretService secretstoreclient.SecretServiceInfo
 	Databases     map[string]Database
+	Transit       TransitInfo
+	Watchdog      WatchdogInfo
+	Snapshot      SnapshotInfo
+	Entropy       EntropyInfo
+	Decommission  DecommissionInfo
+	KDF           KDFInfo
+	TPM           TPMInfo
+	IKMHook       IKMHookInfo
+	OutboundHTTP  httpclient.OutboundHTTPInfo
+}
+
+// TransitInfo controls optional enablement of the Vault transit engine
+// used by services to sign or HMAC outbound payloads
+type TransitInfo struct {
+	// Enabled mounts the transit engine and creates the configured keys
+	Enabled bool
+	// KeyType is the Vault transit key type (e.g. ed25519, ecdsa-p256)
+	KeyType string
+	// ServiceKeys is the list of service names for which a named key is created
+	ServiceKeys []string
 }
 
 type Database struct {
@@ -32,6 +53,106 @@ type Database struct {
 	Service  string
 }
 
+// WatchdogInfo configures the long-running --watchdog mode that re-unseals Vault if it restarts sealed
+type WatchdogInfo struct {
+	// Interval between Vault health checks, e.g. "30s"
+	Interval string
+	// Base URL of support-notifications used for alerts; alerts are disabled if empty
+	NotificationsURL string
+	// Directory containing the per-service token directories written by the token provider
+	ServiceTokenDir string
+	// Name of the token file within each service directory
+	ServiceTokenFile string
+	// Properties used in the assembly of alert notifications
+	Sender string
+	Slug   string
+	Label  string
+}
+
+// SnapshotInfo configures the snapshot subcommand that backs up Vault's integrated raft storage
+type SnapshotInfo struct {
+	// Interval between snapshots, e.g. "24h"
+	Interval string
+	// Directory the encrypted snapshots are written to
+	Directory string
+	// MaxSnapshots is the number of most recent snapshots kept, the older ones being pruned; 0 keeps them all
+	MaxSnapshots int
+}
+
+// EntropyInfo configures the check that the host has enough entropy before Vault is initialized and credentials
+// are generated
+type EntropyInfo struct {
+	// MinimumEntropy is the number of bits the kernel entropy pool must hold; 0 disables the check
+	MinimumEntropy int
+	// HardwareRNGDevice is an optional hardware RNG device, e.g. /dev/hwrng, seeding the kernel entropy pool
+	HardwareRNGDevice string
+	// SeedBytes is the number of bytes read from HardwareRNGDevice, 64 if not set
+	SeedBytes int
+}
+
+// DecommissionInfo configures the decommission subcommand that wipes the secrets of the gateway before it is
+// retired or handed over
+type DecommissionInfo struct {
+	// SecretPaths are the paths of the KV secrets engine under which every secret is deleted, e.g. "/v1/secret/edgex/"
+	SecretPaths []string
+	// ShredFiles are the files shredded besides the init response, the KDF salt, the token provider admin token, the
+	// service tokens and the snapshots, e.g. the private keys of the TLS certificates of the services
+	ShredFiles []string
+	// ShredPasses is the number of times the files are overwritten with random bytes before being removed, 3 if not set
+	ShredPasses int
+	// SigningKey is the transit key, of type Transit.KeyType, signing the report; it is created if missing
+	SigningKey string
+	// ReportFile is the file the signed report is written to
+	ReportFile string
+}
+
+// KDFInfo selects the key derivation function deriving the keys wrapping the Vault master key shares from the input
+// key material of IKM_HOOK
+type KDFInfo struct {
+	// Algorithm is hkdf, the default, or argon2id or scrypt for input key material of low entropy, e.g. a PIN
+	Algorithm string
+	// Memory is the memory used by argon2id in KiB
+	Memory int
+	// Iterations is the number of passes of argon2id over the memory
+	Iterations int
+	// Parallelism is the number of threads of argon2id
+	Parallelism int
+	// ScryptN is the CPU/memory cost of scrypt, a power of two
+	ScryptN int
+	// ScryptR is the block size of scrypt
+	ScryptR int
+	// ScryptP is the parallelization of scrypt
+	ScryptP int
+}
+
+// TPMInfo configures sealing the input key material to the PCRs of a TPM 2.0 as an alternative to IKM_HOOK
+type TPMInfo struct {
+	// Enabled seals random IKM to the PCRs on first use, then unseals it; IKM_HOOK must not be set
+	Enabled bool
+	// Device is the TPM resource manager device, e.g. /dev/tpmrm0
+	Device string
+	// PCRs are the indexes of the SHA-256 PCRs the IKM is sealed to, e.g. 0, 2, 4 and 7 for the firmware, boot
+	// loader and secure boot state
+	PCRs []int
+	// SealedIKMFile is the name of the file holding the sealed IKM in SecretService.TokenFolderPath
+	SealedIKMFile string
+}
+
+// IKMHookInfo configures how the input key material is read from IKM_HOOK, which is an executable printing it, the
+// path of a Unix domain socket prefixed with unix:// or an https:// URL, e.g. of a local attestation agent
+type IKMHookInfo struct {
+	// Timeout bounds the time to read the IKM, e.g. "30s"
+	Timeout string
+	// MaxLength is the maximum number of hex characters of the IKM
+	MaxLength int
+	// CaFilePath is the CA certificate authenticating the HTTPS endpoint; the system roots are trusted if empty
+	CaFilePath string
+	// CertFilePath and KeyFilePath are the client certificate and its private key authenticating to the HTTPS
+	// endpoint, if set
+	CertFilePath string
+	KeyFilePath  string
+}
+
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
 // then used to overwrite the service's existing configuration struct.
 func (c *ConfigurationStruct) UpdateFromRaw(rawConfig interface{}) bool {
@@ -10,6 +10,8 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/securebuffer"
 )
 
 const randomBytesLength = 33 // 264 bits of entropy
@@ -28,11 +30,12 @@ func NewDefaultCredentialGenerator() CredentialGenerator {
 
 // Generate implementation returns base64-encoded randomBytesLength random bytes
 func (cg *defaultCredentialGenerator) Generate(ctx context.Context) (string, error) {
-	randomBytes := make([]byte, randomBytesLength)
-	_, err := rand.Read(randomBytes) // all of salt guaranteed to be filled if err==nil
+	randomBytes := securebuffer.New(randomBytesLength)
+	defer randomBytes.Destroy()
+	_, err := rand.Read(randomBytes.Bytes()) // all of salt guaranteed to be filled if err==nil
 	if err != nil {
 		return "", err
 	}
-	newCredential := base64.StdEncoding.EncodeToString(randomBytes)
+	newCredential := base64.StdEncoding.EncodeToString(randomBytes.Bytes())
 	return newCredential, nil
 }
@@ -0,0 +1,358 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstore
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/kdf"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+/*
+
+Decommission flow, run once with the decommission subcommand before the
+gateway is retired or handed over:
+
+1. Load the IKM from IKM_HOOK or the TPM if set, then the init response, and
+   regenerate a transient root token from the key shares
+2. Create the transit key signing the report if missing, and read its
+   public key
+3. Delete every secret under Decommission.SecretPaths, which hold the
+   credentials and the certificates of the services
+4. Revoke every other token, the service and admin tokens as well as the
+   root tokens
+5. Shred the init response, the KDF salt, the IKM sealed by the TPM, the
+   token provider admin token, the service tokens, the snapshots and
+   Decommission.ShredFiles
+6. Sign the report with the transit key, write it to ReportFile and seal
+   Vault
+
+Steps 2 to 4 stop the decommission at the first failure, before anything
+is shredded, so that it can be run again. Vault can't be unsealed anymore
+once the init response is shredded, which is why the report holds the
+public key verifying its signature.
+
+*/
+
+const defaultShredPasses = 3
+
+// DecommissionReport records what the decommission of the gateway did
+type DecommissionReport struct {
+	Host           string    `json:"host"`
+	Vault          string    `json:"vault"`
+	Started        time.Time `json:"started"`
+	Completed      time.Time `json:"completed"`
+	SecretsDeleted []string  `json:"secretsDeleted"`
+	TokensRevoked  int       `json:"tokensRevoked"`
+	FilesShredded  []string  `json:"filesShredded"`
+	// Failures are the files that couldn't be shredded
+	Failures []string `json:"failures,omitempty"`
+	// SigningKey is the transit key that signed the report, and PublicKey the public key verifying the signature
+	SigningKey string `json:"signingKey"`
+	PublicKey  string `json:"publicKey"`
+}
+
+// SignedDecommissionReport is the content of the report file, Signature being the transit signature of the exact
+// bytes of Report
+type SignedDecommissionReport struct {
+	Report    json.RawMessage `json:"report"`
+	Signature string          `json:"signature"`
+}
+
+// Decommissioner wipes the secrets of the gateway and seals Vault for good
+type Decommissioner struct {
+	lc            logger.LoggingClient
+	vc            secretstoreclient.SecretStoreClient
+	transit       secretstoreclient.TransitClient
+	fileOpener    fileioperformer.FileIoPerformer
+	vmkEncryption *VMKEncryption
+	ikmHook       string
+	configuration *config.ConfigurationStruct
+	now           func() time.Time
+}
+
+// NewDecommissioner creates a new Decommissioner; the init response is decrypted with the IKM if ikmHook isn't empty
+func NewDecommissioner(lc logger.LoggingClient,
+	vc secretstoreclient.SecretStoreClient,
+	transit secretstoreclient.TransitClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	vmkEncryption *VMKEncryption,
+	ikmHook string,
+	configuration *config.ConfigurationStruct) *Decommissioner {
+	return &Decommissioner{
+		lc:            lc,
+		vc:            vc,
+		transit:       transit,
+		fileOpener:    fileOpener,
+		vmkEncryption: vmkEncryption,
+		ikmHook:       ikmHook,
+		configuration: configuration,
+		now:           time.Now,
+	}
+}
+
+// Decommission wipes the secrets of the gateway, writes the signed report and seals Vault. Vault is sealed as soon as
+// anything is shredded, even if the report can't be written; an error is returned if any file couldn't be shredded.
+func (d *Decommissioner) Decommission() (*DecommissionReport, error) {
+	secretConfig := d.configuration.SecretService
+	decommission := d.configuration.Decommission
+	report := &DecommissionReport{
+		Vault:      fmt.Sprintf("%s:%d", secretConfig.Server, secretConfig.Port),
+		Started:    d.now().UTC(),
+		SigningKey: decommission.SigningKey,
+	}
+	report.Host, _ = os.Hostname()
+
+	if d.ikmHook != "" {
+		if err := d.vmkEncryption.LoadIKM(d.ikmHook); err != nil {
+			return nil, fmt.Errorf("failed to setup vault master key encryption: %s", err.Error())
+		}
+		defer d.vmkEncryption.WipeIKM() // Ensure IKM is wiped from memory
+	}
+
+	rootToken, revoke, err := transientRootToken(d.lc, d.vc, d.fileOpener, d.vmkEncryption, secretConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not regenerate root token: %s", err.Error())
+	}
+	sealed := false
+	defer func() {
+		// a sealed Vault can't revoke the token, which is lost with the init response anyway
+		if !sealed {
+			revoke()
+		}
+	}()
+
+	transit := config.TransitInfo{KeyType: d.configuration.Transit.KeyType, ServiceKeys: []string{decommission.SigningKey}}
+	if err := enableTransitSecretsEngine(d.lc, d.vc, rootToken, transit); err != nil {
+		return nil, fmt.Errorf("failed to create the signing key %s: %s", decommission.SigningKey, err.Error())
+	}
+	if report.PublicKey, err = d.transit.PublicKey(rootToken, decommission.SigningKey); err != nil {
+		return nil, err
+	}
+
+	for _, path := range decommission.SecretPaths {
+		deleted, err := d.deleteSecrets(rootToken, path)
+		report.SecretsDeleted = append(report.SecretsDeleted, deleted...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete the secrets under %s: %s", path, err.Error())
+		}
+	}
+	d.lc.Info(fmt.Sprintf("deleted %d secret(s)", len(report.SecretsDeleted)))
+
+	if report.TokensRevoked, err = d.revokeTokens(rootToken); err != nil {
+		return nil, fmt.Errorf("failed to revoke the tokens: %s", err.Error())
+	}
+	d.lc.Info(fmt.Sprintf("revoked %d token(s)", report.TokensRevoked))
+
+	passes := decommission.ShredPasses
+	if passes <= 0 {
+		passes = defaultShredPasses
+	}
+	for _, path := range d.shreddedFiles() {
+		shredded, err := shredFile(path, passes)
+		if err != nil {
+			report.Failures = append(report.Failures, fmt.Sprintf("failed to shred %s: %s", path, err.Error()))
+			continue
+		}
+		if shredded {
+			report.FilesShredded = append(report.FilesShredded, path)
+		}
+	}
+	report.Completed = d.now().UTC()
+
+	reportErr := d.writeReport(rootToken, report)
+	if _, err := d.vc.Seal(rootToken); err != nil {
+		return report, fmt.Errorf("failed to seal vault: %s", err.Error())
+	}
+	sealed = true
+	d.lc.Info("vault sealed")
+
+	if reportErr != nil {
+		return report, fmt.Errorf("failed to write the decommission report: %s", reportErr.Error())
+	}
+	if len(report.Failures) > 0 {
+		return report, fmt.Errorf("decommission completed with %d failure(s)", len(report.Failures))
+	}
+	return report, nil
+}
+
+// deleteSecrets deletes every secret under path, descending into its sub-paths, and returns the paths of the
+// deleted secrets
+func (d *Decommissioner) deleteSecrets(rootToken string, path string) ([]string, error) {
+	path = strings.TrimSuffix(path, "/")
+	var keys []string
+	code, err := d.vc.ListSecrets(rootToken, path, &keys)
+	if code == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, key := range keys {
+		keyPath := path + "/" + key
+		if strings.HasSuffix(key, "/") {
+			subDeleted, err := d.deleteSecrets(rootToken, keyPath)
+			deleted = append(deleted, subDeleted...)
+			if err != nil {
+				return deleted, err
+			}
+			continue
+		}
+		if _, err := d.vc.DeleteSecret(rootToken, keyPath); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, keyPath)
+	}
+	return deleted, nil
+}
+
+// revokeTokens revokes every token but rootToken, and returns how many were revoked
+func (d *Decommissioner) revokeTokens(rootToken string) (int, error) {
+	var before, after []string
+	if _, err := d.vc.ListAccessors(rootToken, &before); err != nil {
+		return 0, err
+	}
+
+	tokenMaintenance := NewTokenMaintenance(d.lc, d.vc)
+	if err := tokenMaintenance.RevokeNonRootTokens(rootToken); err != nil {
+		return 0, err
+	}
+	if err := tokenMaintenance.RevokeRootTokens(rootToken); err != nil {
+		return 0, err
+	}
+
+	if _, err := d.vc.ListAccessors(rootToken, &after); err != nil {
+		return 0, err
+	}
+	if len(after) > 1 {
+		return 0, fmt.Errorf("%d token(s) still not revoked", len(after)-1)
+	}
+	return len(before) - len(after), nil
+}
+
+// shreddedFiles returns the paths of the files to shred, some of which may not exist
+func (d *Decommissioner) shreddedFiles() []string {
+	secretConfig := d.configuration.SecretService
+	files := []string{
+		filepath.Join(secretConfig.TokenFolderPath, secretConfig.TokenFile),
+		filepath.Join(secretConfig.TokenFolderPath, kdf.SaltFile),
+	}
+	if tpm := d.configuration.TPM; tpm.Enabled {
+		files = append(files, filepath.Join(secretConfig.TokenFolderPath, tpm.SealedIKMFile))
+	}
+	if secretConfig.TokenProviderAdminTokenPath != "" {
+		files = append(files, secretConfig.TokenProviderAdminTokenPath)
+	}
+
+	// The patterns are valid, so the only errors are ignored I/O errors
+	if watchdog := d.configuration.Watchdog; watchdog.ServiceTokenDir != "" {
+		serviceTokens, _ := filepath.Glob(filepath.Join(watchdog.ServiceTokenDir, "*", watchdog.ServiceTokenFile))
+		files = append(files, serviceTokens...)
+	}
+	if snapshotDir := d.configuration.Snapshot.Directory; snapshotDir != "" {
+		snapshots, _ := filepath.Glob(filepath.Join(snapshotDir, snapshotFilePrefix+"*"+snapshotFileSuffix))
+		files = append(files, snapshots...)
+	}
+	return append(files, d.configuration.Decommission.ShredFiles...)
+}
+
+// writeReport signs the report with the signing key and writes it to the report file
+func (d *Decommissioner) writeReport(rootToken string, report *DecommissionReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	signature, err := d.transit.Sign(rootToken, report.SigningKey, data)
+	if err != nil {
+		return err
+	}
+	// json.Marshal keeps the compact report as is, so that the signature can be verified on its bytes
+	signed, err := json.Marshal(SignedDecommissionReport{Report: data, Signature: signature})
+	if err != nil {
+		return err
+	}
+
+	path := d.configuration.Decommission.ReportFile
+	writer, err := d.fileOpener.OpenFileWriter(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(signed); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	d.lc.Info(fmt.Sprintf("decommission report written to %s", path))
+	return nil
+}
+
+// shredFile overwrites the file at path with random bytes passes times, syncing each pass to the disk, then removes
+// it. It returns false if there is no file at path. Overwriting doesn't reach the copies kept by copy-on-write file
+// systems or by the wear leveling of flash storage, which is why Vault is sealed once its key shares are shredded.
+func shredFile(path string, passes int) (bool, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !info.Mode().IsRegular() {
+		return false, fmt.Errorf("%s is not a regular file", path)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return false, err
+	}
+	for pass := 0; pass < passes; pass++ {
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			break
+		}
+		if _, err = io.CopyN(file, rand.Reader, info.Size()); err != nil {
+			break
+		}
+		if err = file.Sync(); err != nil {
+			break
+		}
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, os.Remove(path)
+}
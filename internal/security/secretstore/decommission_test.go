@@ -0,0 +1,261 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstore
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
+	"github.com/edgexfoundry/edgex-go/internal/security/kdf"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	ssMocks "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ed25519Transit is a TransitClient signing with an ed25519 key as the Vault transit engine does
+type ed25519Transit struct {
+	secretstoreclient.TransitClient
+	privateKey ed25519.PrivateKey
+}
+
+func (e *ed25519Transit) Sign(_ string, _ string, payload []byte) (string, error) {
+	return "vault:v1:" + base64.StdEncoding.EncodeToString(ed25519.Sign(e.privateKey, payload)), nil
+}
+
+func (e *ed25519Transit) PublicKey(_ string, _ string) (string, error) {
+	return base64.StdEncoding.EncodeToString(e.privateKey.Public().(ed25519.PublicKey)), nil
+}
+
+// newTestDecommissioner returns a Decommissioner of the files in a temporary directory, which the caller removes,
+// along with the paths of the files to shred
+func newTestDecommissioner(t *testing.T, vc secretstoreclient.SecretStoreClient) (*Decommissioner, string, []string) {
+	if fips.Enabled {
+		t.Skip("the ed25519 signing key isn't FIPS approved")
+	}
+
+	dir, err := ioutil.TempDir("", "decommission")
+	require.NoError(t, err)
+
+	files := []string{
+		filepath.Join(dir, "assets", "resp-init.json"),
+		filepath.Join(dir, "assets", kdf.SaltFile),
+		filepath.Join(dir, "tokenprovider", "secrets-token.json"),
+		filepath.Join(dir, "secrets", "edgex-core-data", "secrets-token.json"),
+		filepath.Join(dir, "snapshots", "vault-20210601T120000Z.snap.enc"),
+		filepath.Join(dir, "kong", "server.key"),
+	}
+	for _, file := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0700))
+		require.NoError(t, ioutil.WriteFile(file, []byte(sampleJSON), 0600))
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "snapshots", "unrelated.txt"), []byte("kept"), 0600))
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	fileOpener := fileioperformer.NewDefaultFileIoPerformer()
+	configuration := &config.ConfigurationStruct{
+		SecretService: secretstoreclient.SecretServiceInfo{
+			Server:                      "edgex-vault",
+			Port:                        8200,
+			TokenFolderPath:             filepath.Join(dir, "assets"),
+			TokenFile:                   "resp-init.json",
+			TokenProviderAdminTokenPath: filepath.Join(dir, "tokenprovider", "secrets-token.json"),
+		},
+		Transit:  config.TransitInfo{KeyType: "ed25519"},
+		Watchdog: config.WatchdogInfo{ServiceTokenDir: filepath.Join(dir, "secrets"), ServiceTokenFile: "secrets-token.json"},
+		Snapshot: config.SnapshotInfo{Directory: filepath.Join(dir, "snapshots")},
+		Decommission: config.DecommissionInfo{
+			SecretPaths: []string{"/v1/secret/edgex/"},
+			ShredFiles:  []string{filepath.Join(dir, "kong", "server.key"), filepath.Join(dir, "kong", "missing.key")},
+			SigningKey:  "edgex-decommission",
+			ReportFile:  filepath.Join(dir, "report.json"),
+		},
+	}
+	d := NewDecommissioner(logger.MockLogger{}, vc, &ed25519Transit{privateKey: privateKey}, fileOpener,
+		NewVMKEncryption(fileOpener, nil, nil), "", configuration)
+	return d, dir, files
+}
+
+func expectSigningKey(vc *ssMocks.MockSecretStoreClient) {
+	vc.On("CheckSecretEngineInstalled", "root-token", "transit/", "transit").Return(true, nil)
+	vc.On("CreateTransitKey", "root-token", "transit", "edgex-decommission", "ed25519").Return(http.StatusNoContent, nil)
+}
+
+func expectAccessors(vc *ssMocks.MockSecretStoreClient, accessors ...string) {
+	vc.On("ListAccessors", "root-token", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args.Get(1)).(*[]string) = accessors
+		}).
+		Return(http.StatusOK, nil).Once()
+}
+
+func TestDecommission(t *testing.T) {
+	// Arrange
+	vc := &ssMocks.MockSecretStoreClient{}
+	expectRootToken(vc)
+	expectSigningKey(vc)
+	vc.On("ListSecrets", "root-token", "/v1/secret/edgex", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args.Get(2)).(*[]string) = []string{"coredata/", "bootstrap-redis"}
+		}).
+		Return(http.StatusOK, nil)
+	vc.On("ListSecrets", "root-token", "/v1/secret/edgex/coredata", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args.Get(2)).(*[]string) = []string{"redisdb"}
+		}).
+		Return(http.StatusOK, nil)
+	vc.On("DeleteSecret", "root-token", "/v1/secret/edgex/coredata/redisdb").Return(http.StatusNoContent, nil)
+	vc.On("DeleteSecret", "root-token", "/v1/secret/edgex/bootstrap-redis").Return(http.StatusNoContent, nil)
+	// before the revocations, for each of them and after them
+	expectAccessors(vc, "service", "admin-root", "self")
+	expectAccessors(vc, "service", "admin-root", "self")
+	expectAccessors(vc, "admin-root", "self")
+	expectAccessors(vc, "self")
+	vc.On("LookupSelf", "root-token", mock.Anything).
+		Run(func(args mock.Arguments) {
+			(args.Get(1)).(*secretstoreclient.TokenMetadata).Accessor = "self"
+		}).
+		Return(http.StatusOK, nil)
+	vc.On("LookupAccessor", "root-token", "service", mock.Anything).
+		Run(func(args mock.Arguments) {
+			(args.Get(2)).(*secretstoreclient.TokenMetadata).Policies = []string{"default", "edgex-service-edgex-core-data"}
+		}).
+		Return(http.StatusOK, nil)
+	vc.On("LookupAccessor", "root-token", "admin-root", mock.Anything).
+		Run(func(args mock.Arguments) {
+			(args.Get(2)).(*secretstoreclient.TokenMetadata).Policies = []string{"root"}
+		}).
+		Return(http.StatusOK, nil)
+	vc.On("RevokeAccessor", "root-token", "service").Return(http.StatusNoContent, nil)
+	vc.On("RevokeAccessor", "root-token", "admin-root").Return(http.StatusNoContent, nil)
+	vc.On("Seal", "root-token").Return(http.StatusNoContent, nil)
+	d, dir, files := newTestDecommissioner(t, vc)
+	defer os.RemoveAll(dir)
+
+	// Act
+	report, err := d.Decommission()
+
+	// Assert
+	require.NoError(t, err)
+	vc.AssertExpectations(t)
+	vc.AssertNotCalled(t, "RevokeSelf", mock.Anything)
+	assert.Equal(t, []string{"/v1/secret/edgex/coredata/redisdb", "/v1/secret/edgex/bootstrap-redis"}, report.SecretsDeleted)
+	assert.Equal(t, 2, report.TokensRevoked)
+	assert.Equal(t, files, report.FilesShredded)
+	assert.Empty(t, report.Failures)
+	for _, file := range files {
+		assert.NoFileExists(t, file)
+	}
+	assert.FileExists(t, filepath.Join(dir, "snapshots", "unrelated.txt"))
+
+	data, err := ioutil.ReadFile(d.configuration.Decommission.ReportFile)
+	require.NoError(t, err)
+	var signed SignedDecommissionReport
+	require.NoError(t, json.Unmarshal(data, &signed))
+	var written DecommissionReport
+	require.NoError(t, json.Unmarshal(signed.Report, &written))
+	assert.Equal(t, report.SecretsDeleted, written.SecretsDeleted)
+	assert.Equal(t, "edgex-vault:8200", written.Vault)
+	assert.Equal(t, "edgex-decommission", written.SigningKey)
+
+	// the report is verified offline with its public key
+	publicKey, err := base64.StdEncoding.DecodeString(written.PublicKey)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(signed.Signature, "vault:v1:"))
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(signed.Signature, "vault:v1:"))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, signed.Report, signature))
+}
+
+func TestDecommissionStopsBeforeShredding(t *testing.T) {
+	// Arrange
+	vc := &ssMocks.MockSecretStoreClient{}
+	expectRootToken(vc)
+	expectSigningKey(vc)
+	vc.On("ListSecrets", "root-token", "/v1/secret/edgex", mock.Anything).
+		Return(http.StatusForbidden, errors.New("permission denied"))
+	vc.On("RevokeSelf", "root-token").Return(http.StatusNoContent, nil)
+	d, dir, files := newTestDecommissioner(t, vc)
+	defer os.RemoveAll(dir)
+
+	// Act
+	_, err := d.Decommission()
+
+	// Assert
+	require.Error(t, err)
+	vc.AssertExpectations(t)
+	vc.AssertNotCalled(t, "Seal", mock.Anything)
+	vc.AssertNotCalled(t, "ListAccessors", mock.Anything, mock.Anything)
+	for _, file := range files {
+		assert.FileExists(t, file, "nothing should be shredded so that the decommission can be run again")
+	}
+	assert.NoFileExists(t, d.configuration.Decommission.ReportFile)
+}
+
+func TestDecommissionWithoutSecrets(t *testing.T) {
+	// Arrange
+	vc := &ssMocks.MockSecretStoreClient{}
+	vc.On("ListSecrets", "root-token", "/v1/secret/edgex", mock.Anything).
+		Return(http.StatusNotFound, errors.New("not found"))
+	d, dir, _ := newTestDecommissioner(t, vc)
+	defer os.RemoveAll(dir)
+
+	// Act
+	deleted, err := d.deleteSecrets("root-token", "/v1/secret/edgex/")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+}
+
+func TestShredFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "shred")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "resp-init.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(sampleJSON), 0600))
+
+	shredded, err := shredFile(path, 2)
+	require.NoError(t, err)
+	assert.True(t, shredded)
+	assert.NoFileExists(t, path)
+
+	shredded, err = shredFile(path, 2)
+	assert.NoError(t, err)
+	assert.False(t, shredded, "a missing file is not shredded")
+
+	_, err = shredFile(dir, 2)
+	assert.Error(t, err, "a directory is not shredded")
+}
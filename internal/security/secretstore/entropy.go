@@ -0,0 +1,150 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+/*
+
+Entropy check flow, run before Vault is initialized and any credential is generated:
+
+1. Optionally read SeedBytes from the HardwareRNGDevice and add them to the kernel
+   entropy pool, so that boards with a hardware RNG but no rngd get seeded
+2. Draw random bytes without blocking, which fails until the kernel RNG is initialized
+3. Check that the kernel entropy pool holds at least MinimumEntropy bits
+
+The checks that the platform doesn't support are skipped.
+
+*/
+
+const (
+	defaultSeedBytes = 64
+	// entropyCheckBytes is the size of a 256-bit key, the largest one generated during bootstrapping
+	entropyCheckBytes = 32
+)
+
+// errEntropyUnsupported is returned by the platform functions when the platform can't perform a check
+var errEntropyUnsupported = errors.New("not supported on this platform")
+
+// EntropyCheck verifies that the host can generate strong random numbers before credentials are generated
+type EntropyCheck struct {
+	lc         logger.LoggingClient
+	fileOpener fileioperformer.FileIoPerformer
+	entropy    config.EntropyInfo
+	// platform functions, overridden in tests
+	getRandomNonBlocking func(buf []byte) error
+	availableEntropy     func() (int, error)
+	addEntropy           func(seed []byte) (credited bool, err error)
+}
+
+// NewEntropyCheck creates a new EntropyCheck
+func NewEntropyCheck(lc logger.LoggingClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	entropy config.EntropyInfo) *EntropyCheck {
+	return &EntropyCheck{
+		lc:                   lc,
+		fileOpener:           fileOpener,
+		entropy:              entropy,
+		getRandomNonBlocking: getRandomNonBlocking,
+		availableEntropy:     availableEntropy,
+		addEntropy:           addEntropy,
+	}
+}
+
+// Verify seeds the kernel entropy pool from the hardware RNG device if one is configured, and returns an error
+// if the host doesn't have enough entropy to generate credentials
+func (e *EntropyCheck) Verify() error {
+	if e.entropy.HardwareRNGDevice != "" {
+		e.seed()
+	}
+
+	buf := make([]byte, entropyCheckBytes)
+	defer wipeKey(buf)
+	if err := e.getRandomNonBlocking(buf); errors.Is(err, errEntropyUnsupported) {
+		e.lc.Debug(fmt.Sprintf("skipping non-blocking random number generator check: %s", err.Error()))
+	} else if err != nil {
+		return fmt.Errorf("refusing to generate credentials, the kernel random number generator is not ready "+
+			"(%s); configure Entropy.HardwareRNGDevice or run an entropy daemon such as rngd on this host", err.Error())
+	}
+
+	if e.entropy.MinimumEntropy <= 0 {
+		return nil
+	}
+	available, err := e.availableEntropy()
+	if errors.Is(err, errEntropyUnsupported) {
+		e.lc.Debug(fmt.Sprintf("skipping entropy pool check: %s", err.Error()))
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read the available entropy: %s", err.Error())
+	}
+	if available < e.entropy.MinimumEntropy {
+		return fmt.Errorf("refusing to generate credentials, the kernel entropy pool holds %d bits where at least "+
+			"%d are required; configure Entropy.HardwareRNGDevice or run an entropy daemon such as rngd on this host",
+			available, e.entropy.MinimumEntropy)
+	}
+	e.lc.Info(fmt.Sprintf("entropy check passed, %d bits of entropy available", available))
+	return nil
+}
+
+// seed adds bytes read from the hardware RNG device to the kernel entropy pool. Seeding is best effort, the checks
+// that follow tell whether there is enough entropy.
+func (e *EntropyCheck) seed() {
+	seedBytes := e.entropy.SeedBytes
+	if seedBytes <= 0 {
+		seedBytes = defaultSeedBytes
+	}
+
+	reader, err := e.fileOpener.OpenFileReader(e.entropy.HardwareRNGDevice, os.O_RDONLY, 0400)
+	if err != nil {
+		e.lc.Warn(fmt.Sprintf("failed to open hardware RNG device %s: %s", e.entropy.HardwareRNGDevice, err.Error()))
+		return
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	defer func() {
+		_ = readCloser.Close()
+	}()
+
+	seed := make([]byte, seedBytes)
+	defer wipeKey(seed)
+	if _, err := io.ReadFull(readCloser, seed); err != nil {
+		e.lc.Warn(fmt.Sprintf("failed to read hardware RNG device %s: %s", e.entropy.HardwareRNGDevice, err.Error()))
+		return
+	}
+
+	credited, err := e.addEntropy(seed)
+	switch {
+	case err != nil:
+		e.lc.Warn(fmt.Sprintf("failed to seed the kernel entropy pool: %s", err.Error()))
+	case credited:
+		e.lc.Info(fmt.Sprintf("seeded the kernel entropy pool with %d bytes from %s", seedBytes, e.entropy.HardwareRNGDevice))
+	default:
+		// Crediting entropy requires CAP_SYS_ADMIN, without which the seed is mixed in but not accounted for
+		e.lc.Info(fmt.Sprintf("mixed %d bytes from %s into the kernel entropy pool without crediting them",
+			seedBytes, e.entropy.HardwareRNGDevice))
+	}
+}
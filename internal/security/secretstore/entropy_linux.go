@@ -0,0 +1,115 @@
+// +build linux
+
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstore
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	entropyAvailPath = "/proc/sys/kernel/random/entropy_avail"
+	randomDevicePath = "/dev/random"
+
+	grndNonBlock = 0x1
+	// rndAddEntropy is the RNDADDENTROPY ioctl request, _IOW('R', 0x03, int[2])
+	rndAddEntropy = 0x40085203
+)
+
+// getrandomTrap is the getrandom system call number, which the syscall package only defines for a few architectures
+var getrandomTrap = map[string]uintptr{
+	"386":     355,
+	"amd64":   318,
+	"arm":     384,
+	"arm64":   278,
+	"ppc64le": 359,
+	"riscv64": 278,
+	"s390x":   349,
+}
+
+// getRandomNonBlocking fills buf using getrandom(2) with GRND_NONBLOCK, which fails with EAGAIN until the kernel
+// random number generator is initialized
+func getRandomNonBlocking(buf []byte) error {
+	trap, ok := getrandomTrap[runtime.GOARCH]
+	if !ok {
+		return fmt.Errorf("getrandom on %s: %w", runtime.GOARCH, errEntropyUnsupported)
+	}
+	for read := 0; read < len(buf); {
+		n, _, errno := syscall.Syscall(trap, uintptr(unsafe.Pointer(&buf[read])), uintptr(len(buf)-read), grndNonBlock)
+		switch errno {
+		case 0:
+			read += int(n)
+		case syscall.EINTR:
+		case syscall.ENOSYS:
+			return fmt.Errorf("getrandom: %w", errEntropyUnsupported)
+		default:
+			return fmt.Errorf("getrandom: %w", errno)
+		}
+	}
+	return nil
+}
+
+// availableEntropy returns the number of bits of entropy the kernel estimates its pool holds
+func availableEntropy() (int, error) {
+	contents, err := ioutil.ReadFile(entropyAvailPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, fmt.Errorf("%s: %w", entropyAvailPath, errEntropyUnsupported)
+	} else if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(contents)))
+}
+
+// addEntropy adds seed to the kernel entropy pool and credits it as entropy, which requires CAP_SYS_ADMIN.
+// Without it, seed is only mixed into the pool and credited is false.
+func addEntropy(seed []byte) (credited bool, err error) {
+	device, err := os.OpenFile(randomDevicePath, os.O_WRONLY, 0)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = device.Close()
+	}()
+
+	// struct rand_pool_info { int entropy_count; int buf_size; __u32 buf[0]; }
+	poolInfo := make([]byte, 8+len(seed))
+	defer wipeKey(poolInfo)
+	*(*int32)(unsafe.Pointer(&poolInfo[0])) = int32(len(seed) * 8)
+	*(*int32)(unsafe.Pointer(&poolInfo[4])) = int32(len(seed))
+	copy(poolInfo[8:], seed)
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, device.Fd(), rndAddEntropy, uintptr(unsafe.Pointer(&poolInfo[0])))
+	if errno == 0 {
+		return true, nil
+	}
+	if errno != syscall.EPERM {
+		return false, fmt.Errorf("RNDADDENTROPY: %w", errno)
+	}
+	if _, err := device.Write(seed); err != nil {
+		return false, err
+	}
+	return false, nil
+}
@@ -0,0 +1,98 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package secretstore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestEntropyCheck returns an EntropyCheck whose platform functions report the given state
+func newTestEntropyCheck(entropy config.EntropyInfo, randomErr error, available int, availableErr error) *EntropyCheck {
+	e := NewEntropyCheck(logger.MockLogger{}, fileioperformer.NewDefaultFileIoPerformer(), entropy)
+	e.getRandomNonBlocking = func([]byte) error { return randomErr }
+	e.availableEntropy = func() (int, error) { return available, availableErr }
+	e.addEntropy = func([]byte) (bool, error) { return false, errors.New("unexpected seeding") }
+	return e
+}
+
+func TestEntropyCheckVerify(t *testing.T) {
+	tests := []struct {
+		name         string
+		minimum      int
+		randomErr    error
+		available    int
+		availableErr error
+		expectedErr  bool
+	}{
+		{"enough entropy", 128, nil, 256, nil, false},
+		{"pool check disabled", 0, nil, 0, errors.New("must not be read"), false},
+		{"RNG not initialized", 128, syscall.EAGAIN, 256, nil, true},
+		{"entropy starved", 128, nil, 20, nil, true},
+		{"unreadable pool", 128, nil, 0, errors.New("permission denied"), true},
+		{"unsupported platform", 128, errEntropyUnsupported, 0, errEntropyUnsupported, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEntropyCheck(config.EntropyInfo{MinimumEntropy: tt.minimum}, tt.randomErr, tt.available,
+				tt.availableErr)
+
+			err := e.Verify()
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEntropyCheckSeedsFromHardwareRNG(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "entropy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	device := filepath.Join(dir, "hwrng")
+	require.NoError(t, ioutil.WriteFile(device, []byte("0123456789abcdef"), 0600))
+
+	var seeded []byte
+	e := newTestEntropyCheck(config.EntropyInfo{MinimumEntropy: 128, HardwareRNGDevice: device, SeedBytes: 8},
+		nil, 256, nil)
+	e.addEntropy = func(seed []byte) (bool, error) {
+		seeded = append(seeded, seed...)
+		return true, nil
+	}
+
+	// Act
+	err = e.Verify()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte("01234567"), seeded)
+}
+
+func TestEntropyCheckMissingHardwareRNG(t *testing.T) {
+	// Seeding is best effort, a missing device only fails the check if the entropy is insufficient
+	e := newTestEntropyCheck(config.EntropyInfo{MinimumEntropy: 128, HardwareRNGDevice: "/nonexistent/hwrng"},
+		nil, 256, nil)
+
+	assert.NoError(t, e.Verify())
+}
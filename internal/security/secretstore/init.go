@@ -18,9 +18,12 @@ package secretstore
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -29,17 +32,24 @@ import (
 	"time"
 
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
 	"github.com/edgexfoundry/edgex-go/internal/security/kdf"
 	"github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/container"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	"github.com/edgexfoundry/edgex-go/internal/security/securebuffer"
+	"github.com/edgexfoundry/edgex-go/internal/security/tpmsealer"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/notifications"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/urlclient/local"
 
 	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
 )
@@ -47,20 +57,39 @@ import (
 type Bootstrap struct {
 	insecureSkipVerify bool
 	vaultInterval      int
+	watchdog           bool
+	// args are the positional command-line arguments, the first of which selects a subcommand to run
+	// instead of bootstrapping
+	args []string
 }
 
-func NewBootstrap(insecureSkipVerify bool, vaultInterval int) *Bootstrap {
+func NewBootstrap(insecureSkipVerify bool, vaultInterval int, watchdog bool, args []string) *Bootstrap {
 	return &Bootstrap{
 		insecureSkipVerify: insecureSkipVerify,
 		vaultInterval:      vaultInterval,
+		watchdog:           watchdog,
+		args:               args,
 	}
 }
 
+// arg returns the i-th positional command-line argument, or an empty string if there are fewer
+func (b *Bootstrap) arg(i int) string {
+	if i < len(b.args) {
+		return b.args[i]
+	}
+	return ""
+}
+
 // BootstrapHandler fulfills the BootstrapHandler contract and performs initialization needed by the data service.
 func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
 	configuration := container.ConfigurationFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	// The key shares, the root tokens and the passwords are held in strings, which mustn't end up in a core dump
+	if err := securebuffer.DisableCoreDumps(); err != nil {
+		lc.Warn(fmt.Sprintf("failed to disable core dumps: %s", err.Error()))
+	}
+
 	//step 1: boot up secretstore general steps same as other EdgeX microservice
 
 	//step 2: initialize the communications
@@ -79,16 +108,77 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 		lc.Info("bypassing certificate verification for secret store connection")
 		req = secretstoreclient.NewRequestor(lc).Insecure()
 	}
+	req, err := httpclient.NewClient(lc, configuration.OutboundHTTP, req)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid outbound HTTP configuration: %s", err.Error()))
+		return false
+	}
 
 	vaultProtocol := configuration.SecretService.Protocol
 	vaultHost := fmt.Sprintf("%s:%v", configuration.SecretService.Server, configuration.SecretService.Port)
-	intervalDuration := time.Duration(b.vaultInterval) * time.Second
 	vc := secretstoreclient.NewSecretStoreClient(lc, req, vaultProtocol, vaultHost)
-	pipedHexReader := pipedhexreader.NewPipedHexReader()
-	kdf := kdf.NewKdf(fileOpener, configuration.SecretService.TokenFolderPath, sha256.New)
-	vmkEncryption := NewVMKEncryption(fileOpener, pipedHexReader, kdf)
+	pipedHexReader, err := newPipedHexReader(configuration.IKMHook)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid IKM hook configuration: %s", err.Error()))
+		return false
+	}
+	kdf, err := newKeyDeriver(fileOpener, configuration.SecretService.TokenFolderPath, configuration.KDF)
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid KDF configuration: %s", err.Error()))
+		return false
+	}
 
 	hook := os.Getenv("IKM_HOOK")
+	if tpm := configuration.TPM; tpm.Enabled {
+		// The IKM is unsealed by the TPM instead, the hook being the path of the sealed IKM
+		if hook != "" {
+			lc.Error("IKM_HOOK must not be set when the IKM is sealed by the TPM")
+			return false
+		}
+		sealer, err := tpmsealer.NewTPMSealer(tpm.Device, tpm.PCRs)
+		if err != nil {
+			lc.Error(fmt.Sprintf("invalid TPM configuration: %s", err.Error()))
+			return false
+		}
+		pipedHexReader = NewTPMIKMReader(fileOpener, sealer)
+		hook = filepath.Join(configuration.SecretService.TokenFolderPath, tpm.SealedIKMFile)
+	}
+	vmkEncryption := NewVMKEncryption(fileOpener, pipedHexReader, kdf)
+
+	if b.watchdog {
+		return b.runWatchdog(ctx, lc, configuration, vc, fileOpener, vmkEncryption, hook)
+	}
+
+	switch b.arg(0) {
+	case lintPoliciesSubcommandName:
+		if !b.runPolicyLint(lc, configuration, vc, fileOpener, vmkEncryption, hook) {
+			os.Exit(1)
+		}
+		return false
+	case snapshotSubcommandName:
+		return b.runSnapshots(ctx, lc, configuration, vc, fileOpener, vmkEncryption, hook)
+	case restoreSnapshotSubcommandName:
+		snapshotter := NewSnapshotter(lc, vc, fileOpener, vmkEncryption, hook, configuration.SecretService, configuration.Snapshot)
+		if err := snapshotter.Restore(b.arg(1)); err != nil {
+			lc.Error(fmt.Sprintf("failed to restore vault snapshot: %s", err.Error()))
+			os.Exit(1)
+		}
+		return false
+	case decommissionSubcommandName:
+		transit := secretstoreclient.NewTransitClient(lc, req, vaultProtocol, vaultHost, secretstoreclient.TransitMountPoint)
+		decommissioner := NewDecommissioner(lc, vc, transit, fileOpener, vmkEncryption, hook, configuration)
+		if _, err := decommissioner.Decommission(); err != nil {
+			lc.Error(fmt.Sprintf("failed to decommission: %s", err.Error()))
+			os.Exit(1)
+		}
+		return false
+	}
+
+	if err := NewEntropyCheck(lc, fileOpener, configuration.Entropy).Verify(); err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+
 	if len(hook) > 0 {
 		err := vmkEncryption.LoadIKM(hook)
 		defer vmkEncryption.WipeIKM() // Ensure IKM is wiped from memory
@@ -104,107 +194,10 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 	var initResponse secretstoreclient.InitResponse // reused many places in below flow
 
 	//step 3: initialize and unseal Vault
-	for shouldContinue := true; shouldContinue; {
-		// Anonymous function used to prevent file handles from accumulating
-		successful := func() bool {
-			sCode, _ := vc.HealthCheck()
-
-			switch sCode {
-			case http.StatusOK:
-				// Load the init response from disk since we need it to regenerate root token later
-				if err := loadInitResponse(lc, fileOpener, configuration.SecretService, &initResponse); err != nil {
-					lc.Error(fmt.Sprintf("unable to load init response: %s", err.Error()))
-					return false
-				}
-				lc.Info(fmt.Sprintf("vault is initialized and unsealed (status code: %d)", sCode))
-				shouldContinue = false
-			case http.StatusTooManyRequests:
-				lc.Error(fmt.Sprintf("vault is unsealed and in standby mode (Status Code: %d)", sCode))
-				shouldContinue = false
-			case http.StatusNotImplemented:
-				lc.Info(fmt.Sprintf("vault is not initialized (status code: %d). Starting initialization and unseal phases", sCode))
-				_, err := vc.Init(configuration.SecretService.VaultSecretThreshold,
-					configuration.SecretService.VaultSecretShares, &initResponse)
-				if configuration.SecretService.RevokeRootTokens {
-					// Never persist the root token to disk on secret store initialization if we intend to revoke it later
-					initResponse.RootToken = ""
-					lc.Info("Root token stripped from init response for security reasons")
-				}
-				_, err = vc.Unseal(&initResponse)
-				if err == nil {
-					shouldContinue = false
-				}
-				// We need the unencrypted initResponse in order to generate a temporary root token later
-				// Make a copy and save the copy, possibly encrypted
-				encryptedInitResponse := initResponse
-				// Optionally encrypt the vault init response based on whether encryption was enabled
-				if vmkEncryption.IsEncrypting() {
-					if err := vmkEncryption.EncryptInitResponse(&encryptedInitResponse); err != nil {
-						lc.Error(fmt.Sprintf("failed to encrypt init response from secret store: %s", err.Error()))
-						return false
-					}
-				}
-				if err := saveInitResponse(lc, fileOpener, configuration.SecretService, &encryptedInitResponse); err != nil {
-					lc.Error(fmt.Sprintf("unable to save init response: %s", err.Error()))
-					return false
-				}
-			case http.StatusServiceUnavailable:
-				lc.Info(fmt.Sprintf("vault is sealed (status code: %d). Starting unseal phase", sCode))
-				if err := loadInitResponse(lc, fileOpener, configuration.SecretService, &initResponse); err != nil {
-					lc.Error(fmt.Sprintf("unable to load init response: %s", err.Error()))
-					return false
-				}
-				// Optionally decrypt the vault init response based on whether encryption was enabled
-				if vmkEncryption.IsEncrypting() {
-					if err := vmkEncryption.DecryptInitResponse(&initResponse); err != nil {
-						lc.Error(fmt.Sprintf("failed to decrypt key shares for sercret store unsealing: %s", err.Error()))
-						return false
-					}
-				}
-				_, err := vc.Unseal(&initResponse)
-				if err == nil {
-					shouldContinue = false
-				}
-			default:
-				if sCode == 0 {
-					lc.Error(fmt.Sprintf("vault is in an unknown state. No Status code available"))
-				} else {
-					lc.Error(fmt.Sprintf("vault is in an unknown state. Status code: %d", sCode))
-				}
-			}
-			return true
-		}()
-		if !successful {
-			return false
-		}
-
-		if shouldContinue {
-			lc.Info(fmt.Sprintf("trying Vault init/unseal again in %d seconds", b.vaultInterval))
-			time.Sleep(intervalDuration)
-		}
+	if !b.initializeAndUnseal(lc, configuration.SecretService, vc, fileOpener, vmkEncryption, &initResponse) {
+		return false
 	}
 
-	/* After vault is init'd and unsealed, it takes a while to get ready to accept any request. During which period any request will get http 500 error.
-	We need to check the status constantly until it return http StatusOK.
-	*/
-	ticker := time.NewTicker(time.Second)
-	healthOkCh := make(chan struct{})
-	go func() {
-		for {
-			select {
-			case <-ticker.C:
-				if sCode, _ := vc.HealthCheck(); sCode == http.StatusOK {
-					close(healthOkCh)
-					ticker.Stop()
-					return
-				}
-			}
-		}
-	}()
-
-	// Wait on a StatusOK response from vc.HealthCheck()
-	<-healthOkCh
-
 	// create new root token
 	// defer revoke token
 	// optional: revoke other root token
@@ -290,6 +283,16 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 		os.Exit(1)
 	}
 
+	// Optionally enable transit secret engine for payload signing
+	if configuration.Transit.Enabled {
+		if err := enableTransitSecretsEngine(lc, vc, rootToken, configuration.Transit); err != nil {
+			lc.Error(fmt.Sprintf("failed to enable transit secrets engine: %s", err.Error()))
+			os.Exit(1)
+		}
+	} else {
+		lc.Info("transit secrets engine not enabled")
+	}
+
 	// credential creation
 	gen := NewPasswordGenerator(lc, configuration.SecretService.PasswordProvider, configuration.SecretService.PasswordProviderArgs)
 	cred := NewCred(req, rootToken, gen, configuration.SecretService.GetSecretSvcBaseURL(), lc)
@@ -386,6 +389,244 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 
 }
 
+// runWatchdog blocks, re-unsealing Vault whenever it is found sealed, until ctx is cancelled
+func (b *Bootstrap) runWatchdog(
+	ctx context.Context,
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	vc secretstoreclient.SecretStoreClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	vmkEncryption *VMKEncryption,
+	hook string) bool {
+
+	interval, err := time.ParseDuration(configuration.Watchdog.Interval)
+	if err != nil || interval <= 0 {
+		lc.Error(fmt.Sprintf("invalid watchdog interval '%s'", configuration.Watchdog.Interval))
+		return false
+	}
+
+	var notifier notifications.NotificationsClient
+	if configuration.Watchdog.NotificationsURL != "" {
+		notifier = notifications.NewNotificationsClient(
+			local.New(configuration.Watchdog.NotificationsURL + clients.ApiNotificationRoute))
+	} else {
+		lc.Info("vault watchdog alerts disabled: Watchdog.NotificationsURL not set")
+	}
+
+	watchdog := NewWatchdog(lc, vc, fileOpener, vmkEncryption, hook, configuration.SecretService, configuration.Watchdog, notifier)
+	watchdog.Run(ctx, interval)
+	return false
+}
+
+// runPolicyLint reviews the EdgeX policies and tokens with a transient root token, printing the findings to
+// stdout, and returns whether there were none
+func (b *Bootstrap) runPolicyLint(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	vc secretstoreclient.SecretStoreClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	vmkEncryption *VMKEncryption,
+	hook string) bool {
+
+	if len(hook) > 0 {
+		if err := vmkEncryption.LoadIKM(hook); err != nil {
+			lc.Error(fmt.Sprintf("failed to setup vault master key encryption: %s", err.Error()))
+			return false
+		}
+		defer vmkEncryption.WipeIKM() // Ensure IKM is wiped from memory
+	}
+
+	rootToken, revoke, err := transientRootToken(lc, vc, fileOpener, vmkEncryption, configuration.SecretService)
+	if err != nil {
+		lc.Error(fmt.Sprintf("could not regenerate root token %s", err.Error()))
+		return false
+	}
+	defer revoke()
+
+	findings, err := NewPolicyLinter(lc, vc).Lint(rootToken)
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to lint policies: %s", err.Error()))
+		return false
+	}
+	for _, finding := range findings {
+		fmt.Println(finding.String())
+	}
+	lc.Info(fmt.Sprintf("policy lint completed with %d finding(s)", len(findings)))
+	return len(findings) == 0
+}
+
+// runSnapshots blocks, taking an encrypted snapshot of the raft storage every Snapshot.Interval, until ctx is
+// cancelled
+func (b *Bootstrap) runSnapshots(
+	ctx context.Context,
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	vc secretstoreclient.SecretStoreClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	vmkEncryption *VMKEncryption,
+	hook string) bool {
+
+	interval, err := time.ParseDuration(configuration.Snapshot.Interval)
+	if err != nil || interval <= 0 {
+		lc.Error(fmt.Sprintf("invalid snapshot interval '%s'", configuration.Snapshot.Interval))
+		return false
+	}
+	if len(hook) == 0 {
+		lc.Error("vault snapshots require IKM_HOOK to be set, snapshots are encrypted with a key derived from it")
+		return false
+	}
+
+	NewSnapshotter(lc, vc, fileOpener, vmkEncryption, hook, configuration.SecretService, configuration.Snapshot).
+		Run(ctx, interval)
+	return false
+}
+
+// initializeAndUnseal initializes Vault if needed, saving the init response, then unseals it with the key shares of
+// the init response, retrying every vaultInterval until Vault is unsealed, and waits for Vault to be ready. It returns
+// false if the init response can't be loaded or saved.
+func (b *Bootstrap) initializeAndUnseal(
+	lc logger.LoggingClient,
+	secretConfig secretstoreclient.SecretServiceInfo,
+	vc secretstoreclient.SecretStoreClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	vmkEncryption *VMKEncryption,
+	initResponse *secretstoreclient.InitResponse) bool {
+
+	intervalDuration := time.Duration(b.vaultInterval) * time.Second
+	for shouldContinue := true; shouldContinue; {
+		// Anonymous function used to prevent file handles from accumulating
+		successful := func() bool {
+			sCode, _ := vc.HealthCheck()
+
+			switch sCode {
+			case http.StatusOK:
+				// Load the init response from disk since we need it to regenerate root token later
+				if err := loadInitResponse(lc, fileOpener, secretConfig, initResponse); err != nil {
+					lc.Error(fmt.Sprintf("unable to load init response: %s", err.Error()))
+					return false
+				}
+				lc.Info(fmt.Sprintf("vault is initialized and unsealed (status code: %d)", sCode))
+				shouldContinue = false
+			case http.StatusTooManyRequests:
+				lc.Error(fmt.Sprintf("vault is unsealed and in standby mode (Status Code: %d)", sCode))
+				shouldContinue = false
+			case http.StatusNotImplemented:
+				lc.Info(fmt.Sprintf("vault is not initialized (status code: %d). Starting initialization and unseal phases", sCode))
+				_, err := vc.Init(secretConfig.VaultSecretThreshold, secretConfig.VaultSecretShares, initResponse)
+				if secretConfig.RevokeRootTokens {
+					// Never persist the root token to disk on secret store initialization if we intend to revoke it later
+					initResponse.RootToken = ""
+					lc.Info("Root token stripped from init response for security reasons")
+				}
+				_, err = vc.Unseal(initResponse)
+				if err == nil {
+					shouldContinue = false
+				}
+				// We need the unencrypted initResponse in order to generate a temporary root token later
+				// Make a copy and save the copy, possibly encrypted
+				encryptedInitResponse := *initResponse
+				// Optionally encrypt the vault init response based on whether encryption was enabled
+				if vmkEncryption.IsEncrypting() {
+					if err := vmkEncryption.EncryptInitResponse(&encryptedInitResponse); err != nil {
+						lc.Error(fmt.Sprintf("failed to encrypt init response from secret store: %s", err.Error()))
+						return false
+					}
+				}
+				if err := saveInitResponse(lc, fileOpener, secretConfig, &encryptedInitResponse); err != nil {
+					lc.Error(fmt.Sprintf("unable to save init response: %s", err.Error()))
+					return false
+				}
+			case http.StatusServiceUnavailable:
+				lc.Info(fmt.Sprintf("vault is sealed (status code: %d). Starting unseal phase", sCode))
+				if err := loadInitResponse(lc, fileOpener, secretConfig, initResponse); err != nil {
+					lc.Error(fmt.Sprintf("unable to load init response: %s", err.Error()))
+					return false
+				}
+				// Optionally decrypt the vault init response based on whether encryption was enabled
+				if vmkEncryption.IsEncrypting() {
+					if err := vmkEncryption.DecryptInitResponse(initResponse); err != nil {
+						lc.Error(fmt.Sprintf("failed to decrypt key shares for sercret store unsealing: %s", err.Error()))
+						return false
+					}
+				}
+				_, err := vc.Unseal(initResponse)
+				if err == nil {
+					shouldContinue = false
+				}
+			default:
+				if sCode == 0 {
+					lc.Error(fmt.Sprintf("vault is in an unknown state. No Status code available"))
+				} else {
+					lc.Error(fmt.Sprintf("vault is in an unknown state. Status code: %d", sCode))
+				}
+			}
+			return true
+		}()
+		if !successful {
+			return false
+		}
+
+		if shouldContinue {
+			lc.Info(fmt.Sprintf("trying Vault init/unseal again in %d seconds", b.vaultInterval))
+			time.Sleep(intervalDuration)
+		}
+	}
+
+	/* After vault is init'd and unsealed, it takes a while to get ready to accept any request. During which period any request will get http 500 error.
+	We need to check the status constantly until it return http StatusOK.
+	*/
+	ticker := time.NewTicker(time.Second)
+	healthOkCh := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if sCode, _ := vc.HealthCheck(); sCode == http.StatusOK {
+					close(healthOkCh)
+					ticker.Stop()
+					return
+				}
+			}
+		}
+	}()
+
+	// Wait on a StatusOK response from vc.HealthCheck()
+	<-healthOkCh
+	return true
+}
+
+// transientRootToken regenerates a root token from the key shares of the init response, decrypting them if the
+// IKM is loaded, and returns it along with the function revoking it
+func transientRootToken(
+	lc logger.LoggingClient,
+	vc secretstoreclient.SecretStoreClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	vmkEncryption *VMKEncryption,
+	secretConfig secretstoreclient.SecretServiceInfo) (string, RevokeFunc, error) {
+
+	var initResponse secretstoreclient.InitResponse
+	if err := loadInitResponse(lc, fileOpener, secretConfig, &initResponse); err != nil {
+		return "", nil, err
+	}
+	if vmkEncryption.IsEncrypting() {
+		if err := vmkEncryption.DecryptInitResponse(&initResponse); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var rootToken string
+	if err := vc.RegenRootToken(&initResponse, &rootToken); err != nil {
+		return "", nil, err
+	}
+	revoke := func() {
+		lc.Info("revoking temporary root token")
+		if _, err := vc.RevokeSelf(rootToken); err != nil {
+			lc.Error(fmt.Sprintf("could not revoke temporary root token %s", err.Error()))
+		}
+	}
+	return rootToken, revoke, nil
+}
+
 // XXX Collapse addServiceCredential and addDBCredential together by passing in the path or using
 // variadic functions
 
@@ -520,6 +761,97 @@ func enableKVSecretsEngine(
 	return nil
 }
 
+func enableTransitSecretsEngine(
+	lc logger.LoggingClient,
+	vc secretstoreclient.SecretStoreClient,
+	rootToken string,
+	transit config.TransitInfo) error {
+
+	if err := fips.CheckTransitKeyType(transit.KeyType); err != nil {
+		lc.Error(fmt.Sprintf("invalid transit key type: %s", err.Error()))
+		return err
+	}
+
+	mountPoint := secretstoreclient.TransitMountPoint
+	installed, err := vc.CheckSecretEngineInstalled(rootToken, mountPoint+"/", "transit")
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed call to check if transit secrets engine is installed: %s", err.Error()))
+		return err
+	}
+	if !installed {
+		lc.Info("enabling transit secrets engine for the first time...")
+		if _, err := vc.EnableTransitSecretEngine(rootToken, mountPoint); err != nil {
+			lc.Error(fmt.Sprintf("failed call to enable transit secrets engine: %s", err.Error()))
+			return err
+		}
+	} else {
+		lc.Info("transit secrets engine already enabled...")
+	}
+
+	// Creating a key that already exists is a no-op in Vault, so keys are safe to re-create each run
+	for _, serviceName := range transit.ServiceKeys {
+		if _, err := vc.CreateTransitKey(rootToken, mountPoint, serviceName, transit.KeyType); err != nil {
+			lc.Error(fmt.Sprintf("failed to create transit key for service %s: %s", serviceName, err.Error()))
+			return err
+		}
+		lc.Info(fmt.Sprintf("transit key %s is present", serviceName))
+	}
+	return nil
+}
+
+// newKeyDeriver returns the KDF of the configured algorithm deriving the keys wrapping the Vault master key shares
+func newKeyDeriver(
+	fileOpener fileioperformer.FileIoPerformer,
+	persistencePath string,
+	kdfInfo config.KDFInfo) (kdf.KeyDeriver, error) {
+
+	switch kdfInfo.Algorithm {
+	case "", kdf.HKDF:
+		return kdf.NewKdf(fileOpener, persistencePath, sha256.New), nil
+	case kdf.Argon2id:
+		return kdf.NewArgon2idKdf(fileOpener, persistencePath, kdfInfo.Memory, kdfInfo.Iterations, kdfInfo.Parallelism)
+	case kdf.Scrypt:
+		return kdf.NewScryptKdf(fileOpener, persistencePath, kdfInfo.ScryptN, kdfInfo.ScryptR, kdfInfo.ScryptP)
+	default:
+		return nil, fmt.Errorf("unknown KDF algorithm %q", kdfInfo.Algorithm)
+	}
+}
+
+// newPipedHexReader returns the PipedHexReader reading the input key material from IKM_HOOK
+func newPipedHexReader(ikmHook config.IKMHookInfo) (pipedhexreader.PipedHexReader, error) {
+	options := pipedhexreader.Options{MaxLength: ikmHook.MaxLength}
+	if ikmHook.Timeout != "" {
+		timeout, err := time.ParseDuration(ikmHook.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %s: %w", ikmHook.Timeout, err)
+		}
+		options.Timeout = timeout
+	}
+
+	tlsConfig := &tls.Config{}
+	if ikmHook.CaFilePath != "" {
+		caCert, err := ioutil.ReadFile(ikmHook.CaFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no CA certificate found in %s", ikmHook.CaFilePath)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if ikmHook.CertFilePath != "" {
+		cert, err := tls.LoadX509KeyPair(ikmHook.CertFilePath, ikmHook.KeyFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	options.TLSConfig = fips.Restrict(tlsConfig)
+
+	return pipedhexreader.NewPipedHexReaderWithOptions(options), nil
+}
+
 func loadInitResponse(
 	lc logger.LoggingClient,
 	fileOpener fileioperformer.FileIoPerformer,
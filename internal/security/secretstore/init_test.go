@@ -7,17 +7,23 @@
 package secretstore
 
 import (
+	"errors"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer/mocks"
 
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	ssMocks "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 const sampleJSON = `
@@ -75,6 +81,136 @@ func TestSaveInitResponse(t *testing.T) {
 	fileOpener.AssertExpectations(t)
 }
 
+func TestEnableTransitSecretsEngine(t *testing.T) {
+	if fips.Enabled {
+		t.Skip("ed25519 transit keys aren't FIPS approved")
+	}
+
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+	vc := &ssMocks.MockSecretStoreClient{}
+	vc.On("CheckSecretEngineInstalled", "fake-token", "transit/", "transit").Return(false, nil)
+	vc.On("EnableTransitSecretEngine", "fake-token", "transit").Return(http.StatusNoContent, nil)
+	vc.On("CreateTransitKey", "fake-token", "transit", "edgex-core-data", "ed25519").Return(http.StatusNoContent, nil)
+	transit := config.TransitInfo{
+		Enabled:     true,
+		KeyType:     "ed25519",
+		ServiceKeys: []string{"edgex-core-data"},
+	}
+
+	// Act
+	err := enableTransitSecretsEngine(mockLogger, vc, "fake-token", transit)
+
+	// Assert
+	assert.NoError(err)
+	vc.AssertExpectations(t)
+}
+
+func TestEnableTransitSecretsEngineAlreadyInstalled(t *testing.T) {
+	if fips.Enabled {
+		t.Skip("ed25519 transit keys aren't FIPS approved")
+	}
+
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+	vc := &ssMocks.MockSecretStoreClient{}
+	vc.On("CheckSecretEngineInstalled", "fake-token", "transit/", "transit").Return(true, nil)
+	vc.On("CreateTransitKey", "fake-token", "transit", "edgex-core-data", "ed25519").Return(http.StatusNoContent, nil)
+	transit := config.TransitInfo{
+		Enabled:     true,
+		KeyType:     "ed25519",
+		ServiceKeys: []string{"edgex-core-data"},
+	}
+
+	// Act
+	err := enableTransitSecretsEngine(mockLogger, vc, "fake-token", transit)
+
+	// Assert
+	assert.NoError(err)
+	vc.AssertNotCalled(t, "EnableTransitSecretEngine", "fake-token", "transit")
+	vc.AssertExpectations(t)
+}
+
+func TestEnableTransitSecretsEngineNotApproved(t *testing.T) {
+	if !fips.Enabled {
+		t.Skip("all transit key types are allowed without the fips build tag")
+	}
+
+	// Arrange
+	vc := &ssMocks.MockSecretStoreClient{}
+	transit := config.TransitInfo{
+		Enabled:     true,
+		KeyType:     "ed25519",
+		ServiceKeys: []string{"edgex-core-data"},
+	}
+
+	// Act
+	err := enableTransitSecretsEngine(logger.MockLogger{}, vc, "fake-token", transit)
+
+	// Assert
+	assert.True(t, errors.Is(err, fips.ErrNotApproved))
+	vc.AssertNotCalled(t, "CheckSecretEngineInstalled", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNewKeyDeriver(t *testing.T) {
+	fileOpener := &mocks.FileIoPerformer{}
+
+	tests := []struct {
+		name        string
+		kdfInfo     config.KDFInfo
+		approved    bool
+		expectError bool
+	}{
+		{"default", config.KDFInfo{}, true, false},
+		{"hkdf", config.KDFInfo{Algorithm: "hkdf"}, true, false},
+		{"argon2id", config.KDFInfo{Algorithm: "argon2id", Memory: 65536, Iterations: 3, Parallelism: 2}, false, false},
+		{"argon2id without memory", config.KDFInfo{Algorithm: "argon2id", Iterations: 3, Parallelism: 2}, false, true},
+		{"scrypt", config.KDFInfo{Algorithm: "scrypt", ScryptN: 32768, ScryptR: 8, ScryptP: 1}, false, false},
+		{"pbkdf2", config.KDFInfo{Algorithm: "pbkdf2"}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyDeriver, err := newKeyDeriver(fileOpener, "/target", tt.kdfInfo)
+			if tt.expectError || (fips.Enabled && !tt.approved) {
+				assert.Error(t, err)
+				assert.Nil(t, keyDeriver)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, keyDeriver)
+			}
+		})
+	}
+}
+
+func TestNewPipedHexReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		ikmHook     config.IKMHookInfo
+		expectError bool
+	}{
+		{"default", config.IKMHookInfo{}, false},
+		{"limits", config.IKMHookInfo{Timeout: "5s", MaxLength: 128}, false},
+		{"invalid timeout", config.IKMHookInfo{Timeout: "5"}, true},
+		{"missing CA", config.IKMHookInfo{CaFilePath: "/nonexistent/ca.pem"}, true},
+		{"CA without certificates", config.IKMHookInfo{CaFilePath: "testdata/test-resp-init.json"}, true},
+		{"missing client certificate", config.IKMHookInfo{CertFilePath: "/nonexistent/cert.pem", KeyFilePath: "/nonexistent/key.pem"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := newPipedHexReader(tt.ikmHook)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, reader)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, reader)
+			}
+		})
+	}
+}
+
 //
 // mocks
 //
@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package secretstore
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	"github.com/edgexfoundry/edgex-go/internal/security/securitytest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// integration runs the steps of the secret store setup against a FakeVault, saving the init response in a
+// temporary directory
+type integration struct {
+	vault         *securitytest.FakeVault
+	vc            secretstoreclient.SecretStoreClient
+	fileOpener    fileioperformer.FileIoPerformer
+	vmkEncryption *VMKEncryption
+	secretConfig  secretstoreclient.SecretServiceInfo
+	bootstrap     *Bootstrap
+}
+
+func newIntegration(t *testing.T) *integration {
+	h := securitytest.NewHarness(t)
+	lc := logger.MockLogger{}
+	fileOpener := fileioperformer.NewDefaultFileIoPerformer()
+	return &integration{
+		vault:         h.Vault,
+		vc:            secretstoreclient.NewSecretStoreClient(lc, &http.Client{Timeout: 5 * time.Second}, "http", h.Vault.Host()),
+		fileOpener:    fileOpener,
+		vmkEncryption: NewVMKEncryption(fileOpener, nil, nil),
+		secretConfig: secretstoreclient.SecretServiceInfo{
+			TokenFolderPath:      t.TempDir(),
+			TokenFile:            "resp-init.json",
+			VaultSecretShares:    5,
+			VaultSecretThreshold: 3,
+			RevokeRootTokens:     true,
+		},
+		bootstrap: &Bootstrap{},
+	}
+}
+
+func (i *integration) initializeAndUnseal(initResponse *secretstoreclient.InitResponse) bool {
+	return i.bootstrap.initializeAndUnseal(logger.MockLogger{}, i.secretConfig, i.vc, i.fileOpener,
+		i.vmkEncryption, initResponse)
+}
+
+func (i *integration) savedInitResponse(t *testing.T) secretstoreclient.InitResponse {
+	var initResponse secretstoreclient.InitResponse
+	require.NoError(t, loadInitResponse(logger.MockLogger{}, i.fileOpener, i.secretConfig, &initResponse))
+	return initResponse
+}
+
+func TestSetupIntegration(t *testing.T) {
+	i := newIntegration(t)
+	lc := logger.MockLogger{}
+
+	// the first run initializes and unseals Vault, and waits for it to be ready
+	i.vault.DelayReady(1)
+	var initResponse secretstoreclient.InitResponse
+	require.True(t, i.initializeAndUnseal(&initResponse))
+	assert.True(t, i.vault.Initialized())
+	assert.False(t, i.vault.Sealed())
+	saved := i.savedInitResponse(t)
+	assert.Len(t, saved.KeysBase64, 5)
+	assert.Empty(t, saved.RootToken, "the root token must not be saved when it is revoked")
+	initialRootTokens := i.vault.RootTokens()
+	require.Len(t, initialRootTokens, 1)
+
+	// a transient root token revokes the tokens of the previous runs
+	serviceToken := i.vault.IssueToken("edgex-core-data", "edgex-service-core-data")
+	var rootToken string
+	require.NoError(t, i.vc.RegenRootToken(&initResponse, &rootToken))
+	assert.Contains(t, i.vault.RootTokens(), rootToken)
+	tokenMaintenance := NewTokenMaintenance(lc, i.vc)
+	require.NoError(t, tokenMaintenance.RevokeRootTokens(rootToken))
+	require.NoError(t, tokenMaintenance.RevokeNonRootTokens(rootToken))
+	assert.Equal(t, []string{rootToken}, i.vault.RootTokens(), "the other root tokens must be revoked")
+	assert.NotContains(t, i.vault.Tokens(), serviceToken, "the non-root tokens must be revoked")
+
+	// the token issuing token
+	response, revokeIssuingToken, err := tokenMaintenance.CreateTokenIssuingToken(rootToken)
+	require.NoError(t, err)
+	issuingToken := response["auth"].(map[string]interface{})["client_token"].(string)
+	_, ok := i.vault.Policy(TokenCreatorPolicyName)
+	assert.True(t, ok)
+	assert.Equal(t, []string{TokenCreatorPolicyName}, i.vault.Tokens()[issuingToken].Policies)
+	revokeIssuingToken()
+	assert.NotContains(t, i.vault.Tokens(), issuingToken)
+
+	// the transient root token is revoked at the end of the run
+	_, err = i.vc.RevokeSelf(rootToken)
+	require.NoError(t, err)
+	assert.Empty(t, i.vault.RootTokens())
+
+	// the next run unseals Vault with the saved key shares and regenerates a root token from them
+	i.vault.Seal()
+	var restartResponse secretstoreclient.InitResponse
+	require.True(t, i.initializeAndUnseal(&restartResponse))
+	assert.False(t, i.vault.Sealed())
+	assert.Equal(t, saved.KeysBase64, restartResponse.KeysBase64, "vault must not be initialized again")
+	rootToken, revokeRootToken, err := transientRootToken(lc, i.vc, i.fileOpener, i.vmkEncryption, i.secretConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []string{rootToken}, i.vault.RootTokens())
+	revokeRootToken()
+	assert.Empty(t, i.vault.RootTokens())
+}
+
+func TestSetupIntegrationKeepRootToken(t *testing.T) {
+	i := newIntegration(t)
+	i.secretConfig.RevokeRootTokens = false
+	lc := logger.MockLogger{}
+
+	var initResponse secretstoreclient.InitResponse
+	require.True(t, i.initializeAndUnseal(&initResponse))
+	saved := i.savedInitResponse(t)
+	assert.Equal(t, []string{saved.RootToken}, i.vault.RootTokens())
+
+	var rootToken string
+	require.NoError(t, i.vc.RegenRootToken(&initResponse, &rootToken))
+	require.NoError(t, NewTokenMaintenance(lc, i.vc).RevokeNonRootTokens(rootToken))
+	assert.Contains(t, i.vault.RootTokens(), saved.RootToken)
+	assert.Equal(t, saved.RootToken, i.savedInitResponse(t).RootToken)
+}
@@ -21,9 +21,11 @@ package secretstore
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configflags"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/container"
 
@@ -38,18 +40,35 @@ import (
 	"github.com/gorilla/mux"
 )
 
+const (
+	lintPoliciesSubcommandName    = "lintPolicies"
+	snapshotSubcommandName        = "snapshot"
+	restoreSnapshotSubcommandName = "restoreSnapshot"
+	decommissionSubcommandName    = "decommission"
+)
+
 func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<- bool) {
 	startupTimer := startup.NewStartUpTimer(clients.SecuritySecretStoreSetupServiceKey)
 
 	var insecureSkipVerify bool
 	var vaultInterval int
+	var watchdog bool
+	var configFlags configflags.Flags
 
 	// All common command-line flags have been moved to bootstrap. Service specific flags are add here,
 	// but DO NOT call flag.Parse() as it is called by bootstrap.Run() below
 	// Service specific used is passed below.
 	f := flags.NewWithUsage(
 		"    --insecureSkipVerify=true/false Indicates if skipping the server side SSL cert verification, similar to -k of curl\n" +
-			"    --vaultInterval=<seconds>       Indicates how long the program will pause between vault initialization attempts until it succeeds",
+			"    --vaultInterval=<seconds>       Indicates how long the program will pause between vault initialization attempts until it succeeds\n" +
+			"    --watchdog=true/false           Run as a long-lived watchdog that re-unseals Vault if it restarts sealed, instead of bootstrapping\n" +
+			configflags.Usage + "\n" +
+			"Subcommands:\n" +
+			"    lintPolicies                    Report over-broad grants of the EdgeX policies and expired or unlabeled EdgeX tokens, instead of bootstrapping\n" +
+			"    snapshot                        Take an encrypted snapshot of the Vault raft storage every Snapshot.Interval, instead of bootstrapping\n" +
+			"    restoreSnapshot [<file>]        Restore the given encrypted snapshot, or the most recent one, instead of bootstrapping\n" +
+			"    decommission                    Delete the EdgeX secrets, revoke the tokens, shred the key shares and tokens on disk\n" +
+			"                                    and seal Vault for good, writing a signed report, instead of bootstrapping",
 	)
 
 	if len(os.Args) < 2 {
@@ -58,7 +77,13 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 
 	f.FlagSet.BoolVar(&insecureSkipVerify, "insecureSkipVerify", false, "")
 	f.FlagSet.IntVar(&vaultInterval, "vaultInterval", 30, "")
+	f.FlagSet.BoolVar(&watchdog, "watchdog", false, "")
+	configFlags.Register(f.FlagSet)
 	f.Parse(os.Args[1:])
+	if err := configFlags.Apply(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 
 	configuration := &config.ConfigurationStruct{}
 	dic := di.NewContainer(di.ServiceConstructorMap{
@@ -77,7 +102,8 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 		startupTimer,
 		dic,
 		[]interfaces.BootstrapHandler{
-			NewBootstrap(insecureSkipVerify, vaultInterval).BootstrapHandler,
+			configflags.NewHandler(&configFlags, configuration).BootstrapHandler,
+			NewBootstrap(insecureSkipVerify, vaultInterval, watchdog, f.FlagSet.Args()).BootstrapHandler,
 		},
 	)
 }
@@ -25,6 +25,7 @@ import (
 	"net/url"
 
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 )
@@ -54,7 +55,14 @@ func NewPasswordGenerator(lc logger.LoggingClient, passwordProvider string, pass
 
 // Generate delegates password generation to underlying implementation
 func (gk *passwordGenerator) Generate(ctx context.Context) (string, error) {
-	return gk.generatorImplementation.Generate(ctx)
+	password, err := gk.generatorImplementation.Generate(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := fips.CheckPassword(password); err != nil {
+		return "", err
+	}
+	return password, nil
 }
 
 type CredCollect struct {
@@ -0,0 +1,249 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+/*
+
+Policy lint flow, run on demand with the lintPolicies subcommand:
+
+1. Enumerate the token accessors and look up each EdgeX token, that is
+   one holding an edgex-service-* or the token-issuing policy
+2. Report the tokens that have expired or carry no metadata
+3. Read each edgex-service-* policy and report the paths granted with
+   a wildcard anywhere but below edgex/<service>/, <service> being the
+   service owning the policy
+
+*/
+
+const (
+	// ServicePolicyPrefix prefixes the name of the policies installed for the EdgeX services
+	ServicePolicyPrefix = "edgex-service-"
+	// serviceNameMetadata is the token metadata the token provider records the service name in
+	serviceNameMetadata = "edgex-service-name"
+)
+
+// FindingKind classifies a policy lint finding
+type FindingKind string
+
+const (
+	OverBroadGrant       FindingKind = "over-broad grant"
+	ExpiredToken         FindingKind = "expired token"
+	TokenWithoutMetadata FindingKind = "token without metadata"
+)
+
+// Finding is an issue found by the PolicyLinter
+type Finding struct {
+	Kind FindingKind
+	// Subject is the policy name or the token accessor the finding is about
+	Subject string
+	Detail  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Kind, f.Subject, f.Detail)
+}
+
+// hclPathPattern matches the path stanzas of a policy written in HCL rather than JSON
+var hclPathPattern = regexp.MustCompile(`path\s+"([^"]+)"`)
+
+// PolicyLinter reviews the policies and tokens EdgeX created in the secret store
+type PolicyLinter struct {
+	logging      logger.LoggingClient
+	secretClient secretstoreclient.SecretStoreClient
+	now          func() time.Time
+}
+
+// NewPolicyLinter creates a new PolicyLinter
+func NewPolicyLinter(logging logger.LoggingClient, secretClient secretstoreclient.SecretStoreClient) *PolicyLinter {
+	return &PolicyLinter{
+		logging:      logging,
+		secretClient: secretClient,
+		now:          time.Now,
+	}
+}
+
+// Lint returns the findings on the EdgeX policies and tokens, sorted by kind and subject.
+// Should be called with a high-privileged token, which is itself left out of the review.
+func (pl *PolicyLinter) Lint(privilegedToken string) ([]Finding, error) {
+	findings := make([]Finding, 0)
+
+	owners, tokenFindings, err := pl.lintTokens(privilegedToken)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, tokenFindings...)
+
+	policyFindings, err := pl.lintPolicies(privilegedToken, owners)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, policyFindings...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Kind != findings[j].Kind {
+			return findings[i].Kind < findings[j].Kind
+		}
+		return findings[i].Subject < findings[j].Subject
+	})
+	return findings, nil
+}
+
+// lintTokens reports the expired EdgeX tokens and those without metadata, and returns the service owning each
+// policy according to the metadata of the tokens holding it
+func (pl *PolicyLinter) lintTokens(privilegedToken string) (map[string]string, []Finding, error) {
+	allAccessors := make([]string, 0)
+	if _, err := pl.secretClient.ListAccessors(privilegedToken, &allAccessors); err != nil {
+		return nil, nil, err // secretclient already logged failure
+	}
+
+	var selfMetadata secretstoreclient.TokenMetadata
+	if _, err := pl.secretClient.LookupSelf(privilegedToken, &selfMetadata); err != nil {
+		return nil, nil, err // secretclient already logged failure
+	}
+
+	owners := make(map[string]string)
+	findings := make([]Finding, 0)
+	for _, accessor := range allAccessors {
+		if accessor == selfMetadata.Accessor {
+			continue // the transient token running the review
+		}
+		tokenMetadata := secretstoreclient.TokenMetadata{}
+		if _, err := pl.secretClient.LookupAccessor(privilegedToken, accessor, &tokenMetadata); err != nil {
+			return nil, nil, err // secretclient already logged failure
+		}
+		if !isEdgeXToken(tokenMetadata) {
+			continue
+		}
+
+		if tokenMetadata.ExpireTime != "" {
+			expireTime, err := time.Parse(time.RFC3339, tokenMetadata.ExpireTime)
+			if err != nil {
+				pl.logging.Warn(fmt.Sprintf("token %s has an unparseable expire time %s", accessor, tokenMetadata.ExpireTime))
+			} else if expireTime.Before(pl.now()) {
+				findings = append(findings, Finding{
+					Kind:    ExpiredToken,
+					Subject: accessor,
+					Detail:  fmt.Sprintf("%s expired at %s", tokenMetadata.DisplayName, tokenMetadata.ExpireTime),
+				})
+			}
+		}
+
+		serviceName := tokenMetadata.Meta[serviceNameMetadata]
+		if len(tokenMetadata.Meta) == 0 {
+			findings = append(findings, Finding{
+				Kind:    TokenWithoutMetadata,
+				Subject: accessor,
+				Detail:  fmt.Sprintf("%s holding policies %v has no metadata", tokenMetadata.DisplayName, tokenMetadata.Policies),
+			})
+		}
+		if serviceName == "" {
+			continue
+		}
+		for _, policy := range tokenMetadata.Policies {
+			if strings.HasPrefix(policy, ServicePolicyPrefix) {
+				owners[policy] = serviceName
+			}
+		}
+	}
+	return owners, findings, nil
+}
+
+// lintPolicies reports the wildcard paths of the edgex-service-* policies granted outside of edgex/<service>/
+func (pl *PolicyLinter) lintPolicies(privilegedToken string, owners map[string]string) ([]Finding, error) {
+	policyNames := make([]string, 0)
+	if _, err := pl.secretClient.ListPolicies(privilegedToken, &policyNames); err != nil {
+		return nil, err // secretclient already logged failure
+	}
+
+	findings := make([]Finding, 0)
+	for _, policyName := range policyNames {
+		if !strings.HasPrefix(policyName, ServicePolicyPrefix) {
+			continue
+		}
+		var policyDocument string
+		if _, err := pl.secretClient.ReadPolicy(privilegedToken, policyName, &policyDocument); err != nil {
+			return nil, err // secretclient already logged failure
+		}
+
+		serviceName, ok := owners[policyName]
+		if !ok {
+			// No token holds the policy: assume it is the main policy of the service
+			serviceName = strings.TrimPrefix(policyName, ServicePolicyPrefix)
+		}
+		for _, path := range policyPaths(policyDocument) {
+			if isOverBroad(path, serviceName) {
+				findings = append(findings, Finding{
+					Kind:    OverBroadGrant,
+					Subject: policyName,
+					Detail:  fmt.Sprintf("path %s grants a wildcard outside of edgex/%s/", path, serviceName),
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+// isEdgeXToken tells whether the token was created by EdgeX, for a service or to issue the service tokens
+func isEdgeXToken(tokenMetadata secretstoreclient.TokenMetadata) bool {
+	for _, policy := range tokenMetadata.Policies {
+		if strings.HasPrefix(policy, ServicePolicyPrefix) || policy == TokenCreatorPolicyName {
+			return true
+		}
+	}
+	return false
+}
+
+// policyPaths returns the paths of policyDocument, which the token provider writes in JSON
+func policyPaths(policyDocument string) []string {
+	paths := make([]string, 0)
+	var policy struct {
+		Path map[string]interface{} `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(policyDocument), &policy); err == nil {
+		for path := range policy.Path {
+			paths = append(paths, path)
+		}
+	} else {
+		for _, match := range hclPathPattern.FindAllStringSubmatch(policyDocument, -1) {
+			paths = append(paths, match[1])
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// isOverBroad tells whether path holds a glob (*) or segment (+) wildcard before reaching edgex/<serviceName>/
+func isOverBroad(path string, serviceName string) bool {
+	wildcard := strings.IndexAny(path, "*+")
+	if wildcard < 0 {
+		return false
+	}
+	return !strings.Contains(path[:wildcard], "edgex/"+serviceName+"/")
+}
@@ -0,0 +1,126 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstore
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintPolicies(t *testing.T) {
+	// Arrange
+	logging := logger.MockLogger{}
+	secretClient := &MockSecretStoreClient{}
+	pl := NewPolicyLinter(logging, secretClient)
+	pl.now = func() time.Time { return time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	secretClient.On("ListAccessors", "root-token", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args.Get(1)).(*[]string) = []string{"self", "root", "core-data", "expired", "unlabeled"}
+		}).
+		Return(http.StatusOK, nil)
+	secretClient.On("LookupSelf", "root-token", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args.Get(1)).(*secretstoreclient.TokenMetadata) = secretstoreclient.TokenMetadata{Accessor: "self"}
+		}).
+		Return(http.StatusOK, nil)
+	tokens := map[string]secretstoreclient.TokenMetadata{
+		"root": {Policies: []string{"root"}},
+		"core-data": {
+			ExpireTime: "2021-06-02T00:00:00Z",
+			Meta:       map[string]string{"edgex-service-name": "core-data"},
+			Policies:   []string{"default", "edgex-service-core-data", "edgex-service-core-data-transit"},
+		},
+		"expired": {
+			DisplayName: "token-app-rules",
+			ExpireTime:  "2021-05-01T00:00:00Z",
+			Meta:        map[string]string{"edgex-service-name": "app-rules"},
+			Policies:    []string{"edgex-service-app-rules"},
+		},
+		"unlabeled": {DisplayName: "token", Policies: []string{TokenCreatorPolicyName}},
+	}
+	for accessor, metadata := range tokens {
+		metadata := metadata
+		secretClient.On("LookupAccessor", "root-token", accessor, mock.Anything).
+			Run(func(args mock.Arguments) {
+				*(args.Get(2)).(*secretstoreclient.TokenMetadata) = metadata
+			}).
+			Return(http.StatusOK, nil)
+	}
+	secretClient.On("ListPolicies", "root-token", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args.Get(1)).(*[]string) = []string{"default", "root", "edgex-service-core-data",
+				"edgex-service-core-data-transit", "edgex-service-app-rules"}
+		}).
+		Return(http.StatusOK, nil)
+	policies := map[string]string{
+		"edgex-service-core-data": `{"path":{"secret/edgex/core-data/*":{"capabilities":["read"]},` +
+			`"secret/edgex/+/redisdb":{"capabilities":["read"]}}}`,
+		"edgex-service-core-data-transit": `{"path":{"transit/sign/core-data":{"capabilities":["update"]}}}`,
+		"edgex-service-app-rules":         `path "secret/edgex/app-rules/*" { capabilities = ["read"] } path "secret/*" { capabilities = ["read"] }`,
+	}
+	for name, document := range policies {
+		document := document
+		secretClient.On("ReadPolicy", "root-token", name, mock.Anything).
+			Run(func(args mock.Arguments) {
+				*(args.Get(2)).(*string) = document
+			}).
+			Return(http.StatusOK, nil)
+	}
+
+	// Act
+	findings, err := pl.Lint("root-token")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Kind: ExpiredToken, Subject: "expired", Detail: "token-app-rules expired at 2021-05-01T00:00:00Z"},
+		{Kind: OverBroadGrant, Subject: "edgex-service-app-rules", Detail: "path secret/* grants a wildcard outside of edgex/app-rules/"},
+		{Kind: OverBroadGrant, Subject: "edgex-service-core-data", Detail: "path secret/edgex/+/redisdb grants a wildcard outside of edgex/core-data/"},
+		{Kind: TokenWithoutMetadata, Subject: "unlabeled", Detail: "token holding policies [privileged-token-creator] has no metadata"},
+	}, findings)
+	secretClient.AssertExpectations(t)
+}
+
+func TestIsOverBroad(t *testing.T) {
+	tests := []struct {
+		path      string
+		overBroad bool
+	}{
+		{"secret/edgex/core-data/*", false},
+		{"secret/edgex/core-data/+/password", false},
+		{"transit/sign/core-data", false},
+		{"secret/edgex/core-data*", true},
+		{"secret/edgex/core-metadata/*", true},
+		{"secret/edgex/*", true},
+		{"sys/*", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.overBroad, isOverBroad(tt.path, "core-data"))
+		})
+	}
+}
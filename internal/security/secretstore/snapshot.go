@@ -0,0 +1,269 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+/*
+
+Snapshot flow, run once per interval with the snapshot subcommand:
+
+1. Load the IKM from IKM_HOOK, then the init response, and regenerate
+   a transient root token from the key shares
+2. Check that Vault uses the integrated raft storage, stopping otherwise
+3. Take a snapshot of the raft storage, encrypt it with a key derived
+   from the IKM and write it to the snapshot directory
+4. Prune the oldest snapshots beyond MaxSnapshots
+
+The restoreSnapshot subcommand decrypts the given snapshot, or else the
+most recent one, and restores it into the unsealed Vault it was taken from.
+
+*/
+
+const (
+	snapshotFilePrefix     = "vault-"
+	snapshotFileSuffix     = ".snap.enc"
+	snapshotTimestampStyle = "20060102T150405Z"
+)
+
+// errNotRaftStorage is returned when Vault uses a storage backend without snapshot support
+var errNotRaftStorage = errors.New("vault does not use integrated raft storage")
+
+// Snapshotter backs up Vault's raft storage to encrypted snapshot files and restores them
+type Snapshotter struct {
+	lc            logger.LoggingClient
+	vc            secretstoreclient.SecretStoreClient
+	fileOpener    fileioperformer.FileIoPerformer
+	vmkEncryption *VMKEncryption
+	ikmHook       string
+	secretConfig  secretstoreclient.SecretServiceInfo
+	snapshot      config.SnapshotInfo
+	now           func() time.Time
+}
+
+// NewSnapshotter creates a new Snapshotter; snapshots can't be taken nor restored if ikmHook is empty
+func NewSnapshotter(lc logger.LoggingClient,
+	vc secretstoreclient.SecretStoreClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	vmkEncryption *VMKEncryption,
+	ikmHook string,
+	secretConfig secretstoreclient.SecretServiceInfo,
+	snapshot config.SnapshotInfo) *Snapshotter {
+	return &Snapshotter{
+		lc:            lc,
+		vc:            vc,
+		fileOpener:    fileOpener,
+		vmkEncryption: vmkEncryption,
+		ikmHook:       ikmHook,
+		secretConfig:  secretConfig,
+		snapshot:      snapshot,
+		now:           time.Now,
+	}
+}
+
+// Run takes a snapshot now and then every interval until ctx is cancelled, or until it finds that Vault doesn't
+// use raft storage
+func (s *Snapshotter) Run(ctx context.Context, interval time.Duration) {
+	s.lc.Info(fmt.Sprintf("vault snapshots started, taking one every %s", interval.String()))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Take(); errors.Is(err, errNotRaftStorage) {
+			s.lc.Warn(fmt.Sprintf("vault snapshots stopped: %s", err.Error()))
+			return
+		} else if err != nil {
+			s.lc.Error(fmt.Sprintf("failed to take vault snapshot: %s", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			s.lc.Info("vault snapshots stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Take writes an encrypted snapshot of the raft storage to the snapshot directory, prunes the oldest ones and
+// returns the path of the new snapshot
+func (s *Snapshotter) Take() (string, error) {
+	if err := s.loadIKM(); err != nil {
+		return "", err
+	}
+	defer s.vmkEncryption.WipeIKM() // Only hold the IKM in memory for the duration of the snapshot
+
+	rootToken, revoke, err := transientRootToken(s.lc, s.vc, s.fileOpener, s.vmkEncryption, s.secretConfig)
+	if err != nil {
+		return "", err
+	}
+	defer revoke()
+
+	isRaft, err := s.vc.IsRaftStorage(rootToken)
+	if err != nil {
+		return "", err
+	}
+	if !isRaft {
+		return "", errNotRaftStorage
+	}
+
+	var snapshot bytes.Buffer
+	if _, err := s.vc.TakeSnapshot(rootToken, &snapshot); err != nil {
+		return "", err
+	}
+	encrypted, err := s.vmkEncryption.EncryptSnapshot(snapshot.Bytes())
+	wipeKey(snapshot.Bytes()) // the snapshot holds the secrets, encrypted by the barrier key only
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(s.snapshot.Directory, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(s.snapshot.Directory,
+		snapshotFilePrefix+s.now().UTC().Format(snapshotTimestampStyle)+snapshotFileSuffix)
+	writer, err := s.fileOpener.OpenFileWriter(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(encrypted); err != nil {
+		_ = writer.Close()
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	s.lc.Info(fmt.Sprintf("vault snapshot written to %s", path))
+
+	if err := s.prune(); err != nil {
+		s.lc.Warn(fmt.Sprintf("failed to prune old vault snapshots: %s", err.Error()))
+	}
+	return path, nil
+}
+
+// Restore restores the snapshot at path, or the most recent snapshot if path is empty, into the unsealed Vault
+// it was taken from
+func (s *Snapshotter) Restore(path string) error {
+	if path == "" {
+		snapshots, err := s.list()
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			return fmt.Errorf("no vault snapshot found in %s", s.snapshot.Directory)
+		}
+		path = snapshots[len(snapshots)-1]
+	}
+
+	reader, err := s.fileOpener.OpenFileReader(path, os.O_RDONLY, 0400)
+	if err != nil {
+		return err
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	encrypted, err := ioutil.ReadAll(readCloser)
+	_ = readCloser.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := s.loadIKM(); err != nil {
+		return err
+	}
+	defer s.vmkEncryption.WipeIKM()
+
+	snapshot, err := s.vmkEncryption.DecryptSnapshot(encrypted)
+	if err != nil {
+		return err
+	}
+	defer wipeKey(snapshot)
+
+	rootToken, revoke, err := transientRootToken(s.lc, s.vc, s.fileOpener, s.vmkEncryption, s.secretConfig)
+	if err != nil {
+		return err
+	}
+	if _, err := s.vc.RestoreSnapshot(rootToken, bytes.NewReader(snapshot)); err != nil {
+		revoke()
+		return err
+	}
+	// The transient root token isn't part of the restored storage, so there is nothing left to revoke
+	s.lc.Info(fmt.Sprintf("vault snapshot %s restored", path))
+	return nil
+}
+
+func (s *Snapshotter) loadIKM() error {
+	if s.ikmHook == "" {
+		return errors.New("vault snapshots are encrypted with a key derived from IKM_HOOK, which is not set")
+	}
+	return s.vmkEncryption.LoadIKM(s.ikmHook)
+}
+
+// list returns the paths of the snapshots in the snapshot directory, the oldest first
+func (s *Snapshotter) list() ([]string, error) {
+	entries, err := ioutil.ReadDir(s.snapshot.Directory)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, snapshotFilePrefix) && strings.HasSuffix(name, snapshotFileSuffix) {
+			snapshots = append(snapshots, filepath.Join(s.snapshot.Directory, name))
+		}
+	}
+	// The timestamp format sorts lexically
+	sort.Strings(snapshots)
+	return snapshots, nil
+}
+
+// prune removes the oldest snapshots beyond MaxSnapshots
+func (s *Snapshotter) prune() error {
+	if s.snapshot.MaxSnapshots <= 0 {
+		return nil
+	}
+	snapshots, err := s.list()
+	if err != nil {
+		return err
+	}
+	var lastErr error
+	for i := 0; i < len(snapshots)-s.snapshot.MaxSnapshots; i++ {
+		// Remove as many as we can despite errors
+		if err := os.Remove(snapshots[i]); err != nil {
+			lastErr = err
+			continue
+		}
+		s.lc.Info(fmt.Sprintf("pruned vault snapshot %s", snapshots[i]))
+	}
+	return lastErr
+}
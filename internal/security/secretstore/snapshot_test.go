@@ -0,0 +1,146 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package secretstore
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kdfMocks "github.com/edgexfoundry/edgex-go/internal/security/kdf/mocks"
+	hexMocks "github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	ssMocks "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSnapshotter returns a Snapshotter writing to a temporary directory, which the caller removes
+func newTestSnapshotter(t *testing.T, vc secretstoreclient.SecretStoreClient, maxSnapshots int) (*Snapshotter, string) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "resp-init.json"), []byte(sampleJSON), 0600))
+
+	pipedHexReader := &hexMocks.MockPipedHexReader{}
+	pipedHexReader.On("ReadHexBytesFromExe", "/bin/myikm").Return(make([]byte, 32), nil)
+	kdf := &kdfMocks.MockKeyDeriver{}
+	kdf.On("DeriveKey", mock.Anything, uint(32), mock.Anything).Return(make([]byte, 32), nil)
+	fileOpener := fileioperformer.NewDefaultFileIoPerformer()
+
+	s := NewSnapshotter(logger.MockLogger{}, vc, fileOpener, NewVMKEncryption(fileOpener, pipedHexReader, kdf),
+		"/bin/myikm",
+		secretstoreclient.SecretServiceInfo{TokenFolderPath: dir, TokenFile: "resp-init.json"},
+		config.SnapshotInfo{Directory: filepath.Join(dir, "snapshots"), MaxSnapshots: maxSnapshots})
+	s.now = func() time.Time { return time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC) }
+	return s, dir
+}
+
+func expectRootToken(vc *ssMocks.MockSecretStoreClient) {
+	vc.On("RegenRootToken", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args.Get(1)).(*string) = "root-token"
+		}).
+		Return(nil)
+}
+
+func TestSnapshotTakeAndRestore(t *testing.T) {
+	// Arrange
+	vc := &ssMocks.MockSecretStoreClient{}
+	expectRootToken(vc)
+	vc.On("IsRaftStorage", "root-token").Return(true, nil)
+	vc.On("TakeSnapshot", "root-token", mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = (args.Get(1)).(io.Writer).Write([]byte("raft snapshot"))
+		}).
+		Return(http.StatusOK, nil)
+	vc.On("RevokeSelf", "root-token").Return(http.StatusNoContent, nil)
+	vc.On("RestoreSnapshot", "root-token", mock.Anything).
+		Run(func(args mock.Arguments) {
+			restored, err := ioutil.ReadAll((args.Get(1)).(io.Reader))
+			require.NoError(t, err)
+			assert.Equal(t, "raft snapshot", string(restored))
+		}).
+		Return(http.StatusNoContent, nil)
+	s, dir := newTestSnapshotter(t, vc, 2)
+	defer os.RemoveAll(dir)
+
+	snapshotDir := filepath.Join(dir, "snapshots")
+	require.NoError(t, os.MkdirAll(snapshotDir, 0700))
+	for _, name := range []string{"vault-20210530T120000Z.snap.enc", "vault-20210531T120000Z.snap.enc", "unrelated.txt"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, name), []byte("old"), 0600))
+	}
+
+	// Act
+	path, err := s.Take()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(snapshotDir, "vault-20210601T120000Z.snap.enc"), path)
+	encrypted, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(encrypted, []byte("raft snapshot")), "the snapshot must be encrypted")
+
+	remaining, err := s.list()
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(snapshotDir, "vault-20210531T120000Z.snap.enc"), path}, remaining,
+		"the oldest snapshot must be pruned")
+	assert.FileExists(t, filepath.Join(snapshotDir, "unrelated.txt"))
+
+	// Act: restore the most recent snapshot
+	require.NoError(t, s.Restore(""))
+
+	// Assert
+	vc.AssertExpectations(t)
+	vc.AssertNumberOfCalls(t, "RevokeSelf", 1)
+}
+
+func TestSnapshotNotRaftStorage(t *testing.T) {
+	// Arrange
+	vc := &ssMocks.MockSecretStoreClient{}
+	expectRootToken(vc)
+	vc.On("IsRaftStorage", "root-token").Return(false, nil)
+	vc.On("RevokeSelf", "root-token").Return(http.StatusNoContent, nil)
+	s, dir := newTestSnapshotter(t, vc, 0)
+	defer os.RemoveAll(dir)
+
+	// Act
+	_, err := s.Take()
+
+	// Assert
+	assert.Equal(t, errNotRaftStorage, err)
+	vc.AssertExpectations(t)
+	vc.AssertNotCalled(t, "TakeSnapshot", mock.Anything, mock.Anything)
+}
+
+func TestSnapshotWithoutIKM(t *testing.T) {
+	// Arrange
+	vc := &ssMocks.MockSecretStoreClient{}
+	s, dir := newTestSnapshotter(t, vc, 0)
+	defer os.RemoveAll(dir)
+	s.ikmHook = ""
+
+	// Act
+	_, takeErr := s.Take()
+	restoreErr := s.Restore(filepath.Join(dir, "resp-init.json"))
+
+	// Assert
+	assert.Error(t, takeErr)
+	assert.Error(t, restoreErr)
+	vc.AssertExpectations(t)
+}
@@ -0,0 +1,96 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// US Export Control Classification Number (ECCN): 5D002TSU
+//
+
+package secretstore
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader"
+	"github.com/edgexfoundry/edgex-go/internal/security/tpmsealer"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+// tpmIKMLength is the length of the input key material sealed by the TPM
+const tpmIKMLength = 32
+
+// tpmIKMReader provides the input key material in place of the IKM_HOOK executable: it is random, sealed by the TPM
+// to its PCRs and saved on first use, then unsealed from the saved file, so that the Vault master key shares are only
+// decrypted on the same platform booted with unmodified firmware and OS
+type tpmIKMReader struct {
+	fileOpener fileioperformer.FileIoPerformer
+	sealer     tpmsealer.TPMSealer
+}
+
+// NewTPMIKMReader creates a PipedHexReader for VMKEncryption whose executable path is that of the sealed IKM file
+func NewTPMIKMReader(fileOpener fileioperformer.FileIoPerformer, sealer tpmsealer.TPMSealer) pipedhexreader.PipedHexReader {
+	return &tpmIKMReader{fileOpener: fileOpener, sealer: sealer}
+}
+
+// ReadHexBytesFromExe returns the input key material unsealed from sealedIKMPath, sealing new IKM to it if missing
+func (r *tpmIKMReader) ReadHexBytesFromExe(sealedIKMPath string) ([]byte, error) {
+	_, err := os.Stat(sealedIKMPath)
+	if os.IsNotExist(err) {
+		return r.sealNewIKM(sealedIKMPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := r.fileOpener.OpenFileReader(sealedIKMPath, os.O_RDONLY, 0400)
+	if err != nil {
+		return nil, err
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	defer readCloser.Close()
+
+	sealed, err := ioutil.ReadAll(readCloser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sealed IKM %s: %w", sealedIKMPath, err)
+	}
+	ikm, err := r.sealer.Unseal(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unseal IKM %s: %w", sealedIKMPath, err)
+	}
+	return ikm, nil
+}
+
+// sealNewIKM seals random input key material to sealedIKMPath and returns it
+func (r *tpmIKMReader) sealNewIKM(sealedIKMPath string) ([]byte, error) {
+	ikm := make([]byte, tpmIKMLength)
+	if _, err := rand.Read(ikm); err != nil {
+		return nil, fmt.Errorf("failed to generate IKM: %w", err)
+	}
+	sealed, err := r.sealer.Seal(ikm)
+	if err != nil {
+		wipeKey(ikm)
+		return nil, fmt.Errorf("failed to seal IKM: %w", err)
+	}
+
+	// os.O_EXCL never overwrites a sealed IKM, which would make the init response unrecoverable
+	writer, err := r.fileOpener.OpenFileWriter(sealedIKMPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		wipeKey(ikm)
+		return nil, err
+	}
+	_, err = writer.Write(sealed)
+	closeErr := writer.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		wipeKey(ikm)
+		_ = os.Remove(sealedIKMPath)
+		return nil, fmt.Errorf("failed to write sealed IKM %s: %w", sealedIKMPath, err)
+	}
+	return ikm, nil
+}
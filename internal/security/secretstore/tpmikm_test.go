@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package secretstore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/tpmsealer/mocks"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTPMIKMReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tpmikm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sealedIKMPath := filepath.Join(dir, "tpm-ikm.sealed")
+
+	var ikm []byte
+	sealer := &mocks.MockTPMSealer{}
+	sealer.On("Seal", mock.Anything).
+		Run(func(args mock.Arguments) {
+			ikm = append([]byte(nil), args.Get(0).([]byte)...)
+		}).
+		Return([]byte("sealed"), nil).Once()
+	reader := NewTPMIKMReader(fileioperformer.NewDefaultFileIoPerformer(), sealer)
+
+	// First use seals new random IKM
+	first, err := reader.ReadHexBytesFromExe(sealedIKMPath)
+	require.NoError(t, err)
+	assert.Len(t, first, tpmIKMLength)
+	assert.Equal(t, ikm, first)
+	sealed, err := ioutil.ReadFile(sealedIKMPath)
+	require.NoError(t, err)
+	assert.Equal(t, "sealed", string(sealed))
+
+	// Next uses unseal it
+	sealer.On("Unseal", []byte("sealed")).Return(ikm, nil).Once()
+	second, err := reader.ReadHexBytesFromExe(sealedIKMPath)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	sealer.AssertExpectations(t)
+}
+
+func TestTPMIKMReaderUnsealFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tpmikm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sealedIKMPath := filepath.Join(dir, "tpm-ikm.sealed")
+	require.NoError(t, ioutil.WriteFile(sealedIKMPath, []byte("sealed"), 0600))
+
+	sealer := &mocks.MockTPMSealer{}
+	sealer.On("Unseal", []byte("sealed")).Return([]byte(nil), errors.New("PCR mismatch"))
+	reader := NewTPMIKMReader(fileioperformer.NewDefaultFileIoPerformer(), sealer)
+
+	_, err = reader.ReadHexBytesFromExe(sealedIKMPath)
+	assert.Error(t, err)
+	sealer.AssertNotCalled(t, "Seal", mock.Anything)
+	assert.FileExists(t, sealedIKMPath, "the sealed IKM must not be replaced when it can't be unsealed")
+}
+
+func TestTPMIKMReaderSealFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tpmikm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sealedIKMPath := filepath.Join(dir, "tpm-ikm.sealed")
+
+	sealer := &mocks.MockTPMSealer{}
+	sealer.On("Seal", mock.Anything).Return([]byte(nil), errors.New("no TPM"))
+	reader := NewTPMIKMReader(fileioperformer.NewDefaultFileIoPerformer(), sealer)
+
+	_, err = reader.ReadHexBytesFromExe(sealedIKMPath)
+	assert.Error(t, err)
+	assert.NoFileExists(t, sealedIKMPath)
+}
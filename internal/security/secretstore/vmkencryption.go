@@ -16,9 +16,11 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
 	"github.com/edgexfoundry/edgex-go/internal/security/kdf"
 	"github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	"github.com/edgexfoundry/edgex-go/internal/security/securebuffer"
 	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
 )
 
@@ -51,14 +53,17 @@ and other measures.
 
 */
 
-const aesKeyLength = 32 // for AES-256
+const (
+	aesKeyLength = 32 // for AES-256
+	snapshotInfo = "vault-snapshot"
+)
 
 type VMKEncryption struct {
 	fileOpener     fileioperformer.FileIoPerformer
 	pipedHexReader pipedhexreader.PipedHexReader
 	kdf            kdf.KeyDeriver
 	encrypting     bool
-	ikm            []byte
+	ikm            *securebuffer.SecureBuffer
 }
 
 // NewVMKEncryption - constructor
@@ -83,15 +88,19 @@ func (v *VMKEncryption) LoadIKM(ikmBinPath string) error {
 	if err != nil {
 		return fmt.Errorf("Error reading input key material from IKM_HOOK - encryption not enabled: %w", err)
 	}
-	v.ikm = ikm
+	if err := fips.CheckIKM(ikm); err != nil {
+		wipeKey(ikm)
+		return fmt.Errorf("Input key material from IKM_HOOK rejected - encryption not enabled: %w", err)
+	}
+	v.ikm = securebuffer.FromBytes(ikm) // locked in memory, the source being zeroed
 	v.encrypting = true
 	return nil
 }
 
 // WipeIKM scrubs the input key material from memory
 func (v *VMKEncryption) WipeIKM() {
-	// Note: make() is defined to zero-fill the array
-	copy(v.ikm, make([]byte, len(v.ikm)))
+	v.ikm.Destroy()
+	v.ikm = nil
 	v.encrypting = false
 }
 
@@ -174,6 +183,7 @@ func (v *VMKEncryption) DecryptInitResponse(initResp *secretstoreclient.InitResp
 
 		newKeys[i] = hex.EncodeToString(keyShare)
 		newKeysBase64[i] = base64.StdEncoding.EncodeToString(keyShare)
+		wipeKey(keyShare) // the strings can't be zeroed, but the binary key share can
 	}
 
 	initResp.Keys = newKeys
@@ -183,10 +193,78 @@ func (v *VMKEncryption) DecryptInitResponse(initResp *secretstoreclient.InitResp
 	return nil
 }
 
+// EncryptSnapshot encrypts a raft storage snapshot with a key derived
+// from the input key material, returning the nonce followed by the ciphertext
+func (v *VMKEncryption) EncryptSnapshot(snapshot []byte) ([]byte, error) {
+
+	// Check prerequisite (key has been loaded)
+	if !v.encrypting {
+		return nil, fmt.Errorf("Cannot encrypt snapshot as key has not been loaded")
+	}
+
+	aesgcm, err := v.snapshotCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to initialize random nonce: %w", err)
+	}
+
+	return aesgcm.Seal(nonce, nonce, snapshot, nil), nil
+}
+
+// DecryptSnapshot decrypts a raft storage snapshot encrypted by EncryptSnapshot
+func (v *VMKEncryption) DecryptSnapshot(encryptedSnapshot []byte) ([]byte, error) {
+
+	// Check prerequisite (key has been loaded)
+	if !v.encrypting {
+		return nil, fmt.Errorf("Cannot decrypt snapshot as key has not been loaded")
+	}
+
+	aesgcm, err := v.snapshotCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encryptedSnapshot) < aesgcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted snapshot is too short")
+	}
+	nonce, cipherText := encryptedSnapshot[:aesgcm.NonceSize()], encryptedSnapshot[aesgcm.NonceSize():]
+	snapshot, err := aesgcm.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
 //
 // Internal methods
 //
 
+// snapshotCipher returns the AES-GCM cipher of the key derived
+// from the input key material by passing the info string vault-snapshot to the KDF.
+func (v *VMKEncryption) snapshotCipher() (cipher.AEAD, error) {
+
+	key, err := v.kdf.DeriveKey(v.ikm.Bytes(), aesKeyLength, snapshotInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key for vault snapshot %w", err)
+	}
+	defer wipeKey(key) // wipe encryption key on exit
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize block cipher: %w", err)
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AES cipher: %w", err)
+	}
+	return aesgcm, nil
+}
+
 // gcmEncryptKeyshare encrypts each key share with a unique key
 // from the key derivation function based on passing the info
 // string vault0, vault1, ... et cetera to the KDF.
@@ -196,7 +274,7 @@ func (v *VMKEncryption) gcmEncryptKeyshare(keyshare []byte, counter int) ([]byte
 
 	info := fmt.Sprintf("vault%d", counter)
 
-	key, err := v.kdf.DeriveKey(v.ikm, aesKeyLength, info)
+	key, err := v.kdf.DeriveKey(v.ikm.Bytes(), aesKeyLength, info)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to derive encryption key for vault master key share %w", err)
 	}
@@ -232,7 +310,7 @@ func (v *VMKEncryption) gcmDecryptKeyshare(keyshare []byte, nonce []byte, counte
 
 	info := fmt.Sprintf("vault%d", counter)
 
-	key, err := v.kdf.DeriveKey(v.ikm, aesKeyLength, info)
+	key, err := v.kdf.DeriveKey(v.ikm.Bytes(), aesKeyLength, info)
 	if err != nil {
 		return nil, fmt.Errorf("failed to derive encryption key for vault master key share %w", err)
 	}
@@ -261,6 +339,5 @@ func (v *VMKEncryption) gcmDecryptKeyshare(keyshare []byte, nonce []byte, counte
 }
 
 func wipeKey(key []byte) {
-	blank := make([]byte, len(key)) // zero-filled
-	copy(key, blank)
+	securebuffer.Wipe(key)
 }
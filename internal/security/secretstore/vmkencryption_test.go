@@ -105,3 +105,41 @@ func TestVMKEncryptionFailPath(t *testing.T) {
 	pipedHexReader.AssertExpectations(t)
 	kdf.AssertExpectations(t)
 }
+
+// TestVMKEncryptionSnapshot tests the encryption of raft snapshots
+func TestVMKEncryptionSnapshot(t *testing.T) {
+	// Arrange
+	fakeIkm := make([]byte, 512)
+	fileOpener := &mocks.FileIoPerformer{}
+	pipedHexReader := &MockPipedHexReader{}
+	pipedHexReader.On("ReadHexBytesFromExe", "/bin/myikm").Return(fakeIkm, nil)
+	kdf := &MockKeyDeriver{}
+	kdf.On("DeriveKey", make([]byte, 512), uint(32), "vault-snapshot").Return(make([]byte, 32), nil)
+	snapshot := []byte("raft snapshot")
+
+	// Act & Assert
+	vmkEncryption := NewVMKEncryption(fileOpener, pipedHexReader, kdf)
+	_, err := vmkEncryption.EncryptSnapshot(snapshot)
+	require.Error(t, err, "encryption must fail until the key is loaded")
+
+	err = vmkEncryption.LoadIKM("/bin/myikm")
+	require.NoError(t, err)
+
+	encrypted, err := vmkEncryption.EncryptSnapshot(snapshot)
+	require.NoError(t, err)
+	require.NotContains(t, string(encrypted), string(snapshot))
+
+	decrypted, err := vmkEncryption.DecryptSnapshot(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, decrypted)
+
+	encrypted[len(encrypted)-1] ^= 0xff
+	_, err = vmkEncryption.DecryptSnapshot(encrypted)
+	require.Error(t, err, "tampered snapshots must be rejected")
+
+	vmkEncryption.WipeIKM()
+
+	fileOpener.AssertExpectations(t)
+	pipedHexReader.AssertExpectations(t)
+	kdf.AssertExpectations(t)
+}
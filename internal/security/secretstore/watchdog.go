@@ -0,0 +1,220 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/notifications"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+/*
+
+Watchdog flow, run once per interval after the initial bootstrap:
+
+1. Query Vault health
+2. If Vault is sealed (e.g. it was restarted), load the init response,
+   decrypt it if IKM_HOOK is set, and re-apply the key shares
+3. After a re-unseal, look up each service token found under the token
+   output directory to verify it is still valid
+4. Post a notification describing the outcome, success or failure
+
+Vault configured for auto-unseal comes back unsealed by itself;
+the watchdog then never sees the sealed state and does nothing.
+
+*/
+
+// tokenFileContents is the subset of the token provider's output file used to verify a service token
+type tokenFileContents struct {
+	Auth struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+// Watchdog monitors Vault after bootstrap and re-unseals it if it comes back sealed
+type Watchdog struct {
+	lc            logger.LoggingClient
+	vc            secretstoreclient.SecretStoreClient
+	fileOpener    fileioperformer.FileIoPerformer
+	vmkEncryption *VMKEncryption
+	ikmHook       string
+	secretConfig  secretstoreclient.SecretServiceInfo
+	watchdog      config.WatchdogInfo
+	notifier      notifications.NotificationsClient
+}
+
+// NewWatchdog creates a new Watchdog; notifier may be nil if alerts are not wanted
+func NewWatchdog(lc logger.LoggingClient,
+	vc secretstoreclient.SecretStoreClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	vmkEncryption *VMKEncryption,
+	ikmHook string,
+	secretConfig secretstoreclient.SecretServiceInfo,
+	watchdog config.WatchdogInfo,
+	notifier notifications.NotificationsClient) *Watchdog {
+	return &Watchdog{
+		lc:            lc,
+		vc:            vc,
+		fileOpener:    fileOpener,
+		vmkEncryption: vmkEncryption,
+		ikmHook:       ikmHook,
+		secretConfig:  secretConfig,
+		watchdog:      watchdog,
+		notifier:      notifier,
+	}
+}
+
+// Run checks Vault health every interval until ctx is cancelled
+func (w *Watchdog) Run(ctx context.Context, interval time.Duration) {
+	w.lc.Info(fmt.Sprintf("vault watchdog started, checking every %s", interval.String()))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.lc.Info("vault watchdog stopped")
+			return
+		case <-ticker.C:
+			w.Check(ctx)
+		}
+	}
+}
+
+// Check performs a single health check and re-unseals Vault if it is sealed
+func (w *Watchdog) Check(ctx context.Context) {
+	sCode, _ := w.vc.HealthCheck()
+	if sCode != http.StatusServiceUnavailable {
+		return
+	}
+
+	w.lc.Warn(fmt.Sprintf("vault watchdog found vault sealed (status code: %d). Starting unseal phase", sCode))
+	if err := w.unseal(); err != nil {
+		w.lc.Error(fmt.Sprintf("vault watchdog failed to unseal vault: %s", err.Error()))
+		w.alert(ctx, notifications.CRITICAL, fmt.Sprintf("Vault was found sealed and could not be unsealed: %s", err.Error()))
+		return
+	}
+	w.lc.Info("vault watchdog unsealed vault")
+
+	invalid := w.verifyServiceTokens()
+	if len(invalid) > 0 {
+		w.alert(ctx, notifications.CRITICAL,
+			fmt.Sprintf("Vault was found sealed and has been unsealed, but service tokens failed verification: %v", invalid))
+		return
+	}
+	w.alert(ctx, notifications.NORMAL, "Vault was found sealed and has been unsealed; service tokens verified")
+}
+
+func (w *Watchdog) unseal() error {
+	var initResponse secretstoreclient.InitResponse
+	if err := loadInitResponse(w.lc, w.fileOpener, w.secretConfig, &initResponse); err != nil {
+		return err
+	}
+
+	if w.ikmHook != "" {
+		// Only hold the IKM in memory for the duration of the unseal
+		if err := w.vmkEncryption.LoadIKM(w.ikmHook); err != nil {
+			return err
+		}
+		defer w.vmkEncryption.WipeIKM()
+		if err := w.vmkEncryption.DecryptInitResponse(&initResponse); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.vc.Unseal(&initResponse)
+	return err
+}
+
+// verifyServiceTokens looks up every {ServiceTokenDir}/{service}/{ServiceTokenFile}
+// and returns the names of the services whose token is no longer valid
+func (w *Watchdog) verifyServiceTokens() []string {
+	invalid := make([]string, 0)
+	if w.watchdog.ServiceTokenDir == "" {
+		return invalid
+	}
+
+	entries, err := ioutil.ReadDir(w.watchdog.ServiceTokenDir)
+	if err != nil {
+		w.lc.Error(fmt.Sprintf("vault watchdog failed to list %s: %s", w.watchdog.ServiceTokenDir, err.Error()))
+		return append(invalid, w.watchdog.ServiceTokenDir)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		serviceName := entry.Name()
+		tokenPath := filepath.Join(w.watchdog.ServiceTokenDir, serviceName, w.watchdog.ServiceTokenFile)
+		if err := w.verifyTokenFile(tokenPath); err != nil {
+			w.lc.Error(fmt.Sprintf("vault watchdog failed to verify token for %s: %s", serviceName, err.Error()))
+			invalid = append(invalid, serviceName)
+		}
+	}
+	return invalid
+}
+
+func (w *Watchdog) verifyTokenFile(tokenPath string) error {
+	reader, err := w.fileOpener.OpenFileReader(tokenPath, os.O_RDONLY, 0400)
+	if err != nil {
+		return err
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	defer readCloser.Close()
+
+	var contents tokenFileContents
+	if err := json.NewDecoder(readCloser).Decode(&contents); err != nil {
+		return err
+	}
+
+	var metadata secretstoreclient.TokenMetadata
+	_, err = w.vc.LookupSelf(contents.Auth.ClientToken, &metadata)
+	return err
+}
+
+func (w *Watchdog) alert(ctx context.Context, severity notifications.SeverityEnum, content string) {
+	if w.notifier == nil {
+		return
+	}
+
+	notification := notifications.Notification{
+		Slug:        w.watchdog.Slug + strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10),
+		Content:     content,
+		Category:    notifications.SECURITY,
+		Description: "secret store watchdog alert",
+		Labels:      []string{w.watchdog.Label},
+		Sender:      w.watchdog.Sender,
+		Severity:    severity,
+	}
+	if err := w.notifier.SendNotification(ctx, notification); err != nil {
+		w.lc.Error(fmt.Sprintf("vault watchdog failed to send notification: %s", err.Error()))
+	}
+}
@@ -0,0 +1,108 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	ssMocks "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/notifications"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchdogHealthy(t *testing.T) {
+	// Arrange
+	vc := &ssMocks.MockSecretStoreClient{}
+	vc.On("HealthCheck").Return(http.StatusOK, nil)
+	notifier := &recordingNotifier{}
+	w := NewWatchdog(logger.MockLogger{}, vc, fileioperformer.NewDefaultFileIoPerformer(), nil, "",
+		secretstoreclient.SecretServiceInfo{}, config.WatchdogInfo{}, notifier)
+
+	// Act
+	w.Check(context.Background())
+
+	// Assert
+	vc.AssertExpectations(t)
+	assert.Empty(t, notifier.sent)
+}
+
+func TestWatchdogUnsealsAndVerifiesTokens(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "watchdog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "resp-init.json"), []byte(sampleJSON), 0600))
+	tokenDir := filepath.Join(dir, "secrets")
+	require.NoError(t, os.MkdirAll(filepath.Join(tokenDir, "edgex-core-data"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tokenDir, "edgex-core-data", "secrets-token.json"),
+		[]byte(`{"auth":{"client_token":"s.coredata"}}`), 0600))
+
+	vc := &ssMocks.MockSecretStoreClient{}
+	vc.On("HealthCheck").Return(http.StatusServiceUnavailable, nil)
+	vc.On("Unseal", mock.Anything).Return(http.StatusOK, nil)
+	vc.On("LookupSelf", "s.coredata", mock.Anything).Return(http.StatusOK, nil)
+	notifier := &recordingNotifier{}
+	w := NewWatchdog(logger.MockLogger{}, vc, fileioperformer.NewDefaultFileIoPerformer(), nil, "",
+		secretstoreclient.SecretServiceInfo{TokenFolderPath: dir, TokenFile: "resp-init.json"},
+		config.WatchdogInfo{ServiceTokenDir: tokenDir, ServiceTokenFile: "secrets-token.json"}, notifier)
+
+	// Act
+	w.Check(context.Background())
+
+	// Assert
+	vc.AssertExpectations(t)
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, notifications.NORMAL, notifier.sent[0].Severity)
+}
+
+func TestWatchdogUnsealFailure(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "watchdog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "resp-init.json"), []byte(sampleJSON), 0600))
+
+	vc := &ssMocks.MockSecretStoreClient{}
+	vc.On("HealthCheck").Return(http.StatusServiceUnavailable, nil)
+	vc.On("Unseal", mock.Anything).Return(0, errors.New("unseal failed"))
+	notifier := &recordingNotifier{}
+	w := NewWatchdog(logger.MockLogger{}, vc, fileioperformer.NewDefaultFileIoPerformer(), nil, "",
+		secretstoreclient.SecretServiceInfo{TokenFolderPath: dir, TokenFile: "resp-init.json"},
+		config.WatchdogInfo{}, notifier)
+
+	// Act
+	w.Check(context.Background())
+
+	// Assert
+	vc.AssertExpectations(t)
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, notifications.CRITICAL, notifier.sent[0].Severity)
+}
+
+type recordingNotifier struct {
+	sent []notifications.Notification
+}
+
+func (n *recordingNotifier) SendNotification(_ context.Context, notification notifications.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
@@ -44,6 +44,8 @@ type commonRequestArgs struct {
 	ExpectedStatusCode int
 	// If non-nil and request succeeded, response body will be serialized here (must be a pointer)
 	ResponseObject interface{}
+	// If non-nil and request succeeded, raw response body will be copied here
+	ResponseWriter io.Writer
 }
 
 func (vc *vaultClient) doRequest(params commonRequestArgs) (int, error) {
@@ -94,6 +96,13 @@ func (vc *vaultClient) doRequest(params commonRequestArgs) (int, error) {
 		}
 	}
 
+	if params.ResponseWriter != nil {
+		if _, err := io.Copy(params.ResponseWriter, resp.Body); err != nil {
+			vc.logger.Error(fmt.Sprintf("failed to read response body: %s", err.Error()))
+			return resp.StatusCode, err
+		}
+	}
+
 	vc.logger.Info(fmt.Sprintf("successfully made request to %s", params.OperationDescription))
 	return resp.StatusCode, nil
 }
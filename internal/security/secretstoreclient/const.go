@@ -21,8 +21,10 @@ const (
 	VaultHealthAPI        = "/v1/sys/health"
 	VaultInitAPI          = "/v1/sys/init"
 	VaultUnsealAPI        = "/v1/sys/unseal"
+	VaultSealAPI          = "/v1/sys/seal"
 	JSONContentType       = "application/json"
 	CreatePolicyPath      = "/v1/sys/policies/acl/%s"
+	ListPoliciesAPI       = "/v1/sys/policies/acl"
 	CreateTokenAPI        = "/v1/auth/token/create"
 	ListAccessorsAPI      = "/v1/auth/token/accessors"
 	RevokeAccessorAPI     = "/v1/auth/token/revoke-accessor"
@@ -32,4 +34,11 @@ const (
 	RootTokenControlAPI   = "/v1/sys/generate-root/attempt"
 	RootTokenRetrievalAPI = "/v1/sys/generate-root/update"
 	VaultMountsAPI        = "/v1/sys/mounts"
+	RaftConfigurationAPI  = "/v1/sys/storage/raft/configuration"
+	RaftSnapshotAPI       = "/v1/sys/storage/raft/snapshot"
+	TransitMountPoint     = "transit"
+	TransitKeysPath       = "/v1/%s/keys/%s"
+	TransitSignPath       = "/v1/%s/sign/%s"
+	TransitVerifyPath     = "/v1/%s/verify/%s"
+	TransitHMACPath       = "/v1/%s/hmac/%s"
 )
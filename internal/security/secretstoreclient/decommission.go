@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstoreclient
+
+import (
+	"net/http"
+)
+
+// ListSecrets lists the keys under path of the KV secrets engine, e.g. "/v1/secret/edgex", the keys of the
+// sub-paths ending with a slash. Vault answers 404 when there is no secret under path.
+func (vc *vaultClient) ListSecrets(token string, path string, keys *[]string) (statusCode int, err error) {
+	var response ListSecretsResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               "LIST",
+		Path:                 path,
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "list secrets",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*keys = response.Data.Keys
+	return code, err
+}
+
+// DeleteSecret deletes the secret at path of the KV secrets engine
+func (vc *vaultClient) DeleteSecret(token string, path string) (statusCode int, err error) {
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodDelete,
+		Path:                 path,
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "delete secret",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+}
+
+// Seal seals Vault, which then needs the key shares to be unsealed again. token must be a root token.
+func (vc *vaultClient) Seal(token string) (statusCode int, err error) {
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPut,
+		Path:                 VaultSealAPI,
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "seal vault",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+}
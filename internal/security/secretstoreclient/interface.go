@@ -16,6 +16,8 @@
 
 package secretstoreclient
 
+import "io"
+
 // SecretStoreClient is interface to Vault
 type SecretStoreClient interface {
 	HealthCheck() (statusCode int, err error)
@@ -23,6 +25,8 @@ type SecretStoreClient interface {
 	Unseal(initResponse *InitResponse) (statusCode int, err error)
 	InstallPolicy(token string,
 		policyName string, policyDocument string) (statusCode int, err error)
+	ListPolicies(token string, policyNames *[]string) (statusCode int, err error)
+	ReadPolicy(token string, policyName string, policyDocument *string) (statusCode int, err error)
 	CreateToken(token string,
 		parameters map[string]interface{}, response interface{}) (statusCode int, err error)
 	ListAccessors(token string, accessors *[]string) (statusCode int, err error)
@@ -31,6 +35,14 @@ type SecretStoreClient interface {
 	LookupSelf(token string, tokenMetadata *TokenMetadata) (statusCode int, err error)
 	RevokeSelf(token string) (statusCode int, err error)
 	RegenRootToken(initResponse *InitResponse, rootToken *string) (err error)
+	IsRaftStorage(token string) (isRaft bool, err error)
+	TakeSnapshot(token string, snapshot io.Writer) (statusCode int, err error)
+	RestoreSnapshot(token string, snapshot io.Reader) (statusCode int, err error)
+	ListSecrets(token string, path string, keys *[]string) (statusCode int, err error)
+	DeleteSecret(token string, path string) (statusCode int, err error)
+	Seal(token string) (statusCode int, err error)
 	CheckSecretEngineInstalled(token string, mountPoint string, engine string) (isInstalled bool, err error)
 	EnableKVSecretEngine(token string, mountPoint string, kvVersion string) (statusCode int, err error)
+	EnableTransitSecretEngine(token string, mountPoint string) (statusCode int, err error)
+	CreateTransitKey(token string, mountPoint string, keyName string, keyType string) (statusCode int, err error)
 }
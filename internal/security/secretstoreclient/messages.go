@@ -58,6 +58,21 @@ type ListTokenAccessorsResponse struct {
 	} `json:"data"`
 }
 
+// ListPoliciesResponse is the response to the list ACL policies API
+type ListPoliciesResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+// ReadPolicyResponse is the response to the read ACL policy API
+type ReadPolicyResponse struct {
+	Data struct {
+		Name   string `json:"name"`
+		Policy string `json:"policy"`
+	} `json:"data"`
+}
+
 // RevokeTokenAccessorRequest is the input to the revoke token by accessor API
 type RevokeTokenAccessorRequest struct {
 	Accessor string `json:"accessor"`
@@ -65,10 +80,12 @@ type RevokeTokenAccessorRequest struct {
 
 // TokenMetadata has introspection data about a token
 type TokenMetadata struct {
-	Accessor   string   `json:"accessor"`
-	ExpireTime string   `json:"expire_time"`
-	Path       string   `json:"path"`
-	Policies   []string `json:"policies"`
+	Accessor    string            `json:"accessor"`
+	DisplayName string            `json:"display_name"`
+	ExpireTime  string            `json:"expire_time"`
+	Meta        map[string]string `json:"meta"`
+	Path        string            `json:"path"`
+	Policies    []string          `json:"policies"`
 }
 
 // LookupAccessorRequest is used by accessor lookup API
@@ -112,6 +129,68 @@ type EnableSecretsEngineRequest struct {
 	Type        string `json:"type"`
 	Description string `json:"description"`
 	Options     struct {
-		Version string `json:"version"`
+		Version string `json:"version,omitempty"`
 	} `json:"options"`
 }
+
+// CreateTransitKeyRequest is the POST request to /v1/transit/keys/:name
+type CreateTransitKeyRequest struct {
+	Type string `json:"type"`
+}
+
+// TransitSignRequest is the POST request to /v1/transit/sign/:name
+type TransitSignRequest struct {
+	Input string `json:"input"`
+}
+
+// TransitSignResponse is the response to /v1/transit/sign/:name
+type TransitSignResponse struct {
+	Data struct {
+		Signature string `json:"signature"`
+	} `json:"data"`
+}
+
+// ReadTransitKeyResponse is the response to GET /v1/transit/keys/:name, the public keys of the asymmetric keys
+// being held by version
+type ReadTransitKeyResponse struct {
+	Data struct {
+		Keys map[string]struct {
+			PublicKey string `json:"public_key"`
+		} `json:"keys"`
+		LatestVersion int `json:"latest_version"`
+	} `json:"data"`
+}
+
+// ListSecretsResponse is the response to LIST on a path of the KV secrets engine
+type ListSecretsResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+// TransitHMACRequest is the POST request to /v1/transit/hmac/:name
+type TransitHMACRequest struct {
+	Input string `json:"input"`
+}
+
+// TransitHMACResponse is the response to /v1/transit/hmac/:name
+type TransitHMACResponse struct {
+	Data struct {
+		HMAC string `json:"hmac"`
+	} `json:"data"`
+}
+
+// TransitVerifyRequest is the POST request to /v1/transit/verify/:name
+// Only one of Signature or HMAC should be set
+type TransitVerifyRequest struct {
+	Input     string `json:"input"`
+	Signature string `json:"signature,omitempty"`
+	HMAC      string `json:"hmac,omitempty"`
+}
+
+// TransitVerifyResponse is the response to /v1/transit/verify/:name
+type TransitVerifyResponse struct {
+	Data struct {
+		Valid bool `json:"valid"`
+	} `json:"data"`
+}
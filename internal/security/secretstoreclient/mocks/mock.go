@@ -17,6 +17,8 @@
 package mocks
 
 import (
+	"io"
+
 	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
 	"github.com/stretchr/testify/mock"
 )
@@ -55,6 +57,18 @@ func (m *MockSecretStoreClient) CreateToken(token string, parameters map[string]
 	return arguments.Int(0), arguments.Error(1)
 }
 
+func (m *MockSecretStoreClient) ListPolicies(token string, policyNames *[]string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, policyNames)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) ReadPolicy(token string, policyName string, policyDocument *string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, policyName, policyDocument)
+	return arguments.Int(0), arguments.Error(1)
+}
+
 func (m *MockSecretStoreClient) ListAccessors(token string, accessors *[]string) (statusCode int, err error) {
 	// Boilerplate that returns whatever Mock.On().Returns() is configured for
 	arguments := m.Called(token, accessors)
@@ -91,6 +105,42 @@ func (m *MockSecretStoreClient) RegenRootToken(initResponse *InitResponse, rootT
 	return arguments.Error(0)
 }
 
+func (m *MockSecretStoreClient) IsRaftStorage(token string) (isRaft bool, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token)
+	return arguments.Bool(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) TakeSnapshot(token string, snapshot io.Writer) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, snapshot)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) RestoreSnapshot(token string, snapshot io.Reader) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, snapshot)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) ListSecrets(token string, path string, keys *[]string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, path, keys)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) DeleteSecret(token string, path string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, path)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) Seal(token string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token)
+	return arguments.Int(0), arguments.Error(1)
+}
+
 func (m *MockSecretStoreClient) CheckSecretEngineInstalled(token string, mountPoint string, engine string) (isInstalled bool, err error) {
 	// Boilerplate that returns whatever Mock.On().Returns() is configured for
 	arguments := m.Called(token, mountPoint, engine)
@@ -102,3 +152,15 @@ func (m *MockSecretStoreClient) EnableKVSecretEngine(token string, mountPoint st
 	arguments := m.Called(token, mountPoint, kvVersion)
 	return arguments.Int(0), arguments.Error(1)
 }
+
+func (m *MockSecretStoreClient) EnableTransitSecretEngine(token string, mountPoint string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) CreateTransitKey(token string, mountPoint string, keyName string, keyType string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint, keyName, keyType)
+	return arguments.Int(0), arguments.Error(1)
+}
@@ -17,6 +17,7 @@
 package mocks
 
 import (
+	"bytes"
 	"net/http"
 	"testing"
 
@@ -96,6 +97,66 @@ func TestMockListAccessors(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestMockListPolicies(t *testing.T) {
+	var response []string
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("ListPolicies", "fake-token", mock.Anything).Return(http.StatusOK, nil)
+
+	rc, err := mockClient.ListPolicies("fake-token", &response)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMockReadPolicy(t *testing.T) {
+	var response string
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("ReadPolicy", "fake-token", "policy-name", mock.Anything).Return(http.StatusOK, nil)
+
+	rc, err := mockClient.ReadPolicy("fake-token", "policy-name", &response)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMockSnapshots(t *testing.T) {
+	var snapshot bytes.Buffer
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("IsRaftStorage", "fake-token").Return(true, nil)
+	mockClient.On("TakeSnapshot", "fake-token", &snapshot).Return(http.StatusOK, nil)
+	mockClient.On("RestoreSnapshot", "fake-token", &snapshot).Return(http.StatusNoContent, nil)
+
+	isRaft, err := mockClient.IsRaftStorage("fake-token")
+	assert.NoError(t, err)
+	assert.True(t, isRaft)
+	rc, err := mockClient.TakeSnapshot("fake-token", &snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	rc, err = mockClient.RestoreSnapshot("fake-token", &snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rc)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMockDecommission(t *testing.T) {
+	var keys []string
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("ListSecrets", "fake-token", "/v1/secret/edgex", &keys).Return(http.StatusOK, nil)
+	mockClient.On("DeleteSecret", "fake-token", "/v1/secret/edgex/redisdb").Return(http.StatusNoContent, nil)
+	mockClient.On("Seal", "fake-token").Return(http.StatusNoContent, nil)
+
+	rc, err := mockClient.ListSecrets("fake-token", "/v1/secret/edgex", &keys)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	rc, err = mockClient.DeleteSecret("fake-token", "/v1/secret/edgex/redisdb")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rc)
+	rc, err = mockClient.Seal("fake-token")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rc)
+	mockClient.AssertExpectations(t)
+}
+
 func TestMockRevokeAccessor(t *testing.T) {
 	mockClient := &MockSecretStoreClient{}
 	mockClient.On("RevokeAccessor", "fake-token", "someaccessor").Return(http.StatusNoContent, nil)
@@ -168,3 +229,23 @@ func TestMockEnableKVSecretEngine(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rc)
 	mockClient.AssertExpectations(t)
 }
+
+func TestMockEnableTransitSecretEngine(t *testing.T) {
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("EnableTransitSecretEngine", "fake-token", "transit").Return(http.StatusNoContent, nil)
+
+	rc, err := mockClient.EnableTransitSecretEngine("fake-token", "transit")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rc)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMockCreateTransitKey(t *testing.T) {
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("CreateTransitKey", "fake-token", "transit", "edgex-core-data", "ed25519").Return(http.StatusNoContent, nil)
+
+	rc, err := mockClient.CreateTransitKey("fake-token", "transit", "edgex-core-data", "ed25519")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rc)
+	mockClient.AssertExpectations(t)
+}
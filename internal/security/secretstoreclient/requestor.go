@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/security/fips"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 
@@ -47,7 +48,7 @@ func NewRequestor(logger logger.LoggingClient) HTTPSRequestor {
 
 func (r *fluentRequestor) Insecure() internal.HttpCaller {
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		TLSClientConfig: fips.Restrict(&tls.Config{InsecureSkipVerify: true}),
 	}
 	return &http.Client{Timeout: 10 * time.Second, Transport: tr}
 }
@@ -65,11 +66,11 @@ func (r *fluentRequestor) WithTLS(caReader io.Reader, serverName string) interna
 	caCertPool.AppendCertsFromPEM(caCert)
 
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{
+		TLSClientConfig: fips.Restrict(&tls.Config{
 			RootCAs:            caCertPool,
 			InsecureSkipVerify: false,
 			ServerName:         serverName,
-		},
+		}),
 		TLSHandshakeTimeout: 10 * time.Second,
 	}
 	return &http.Client{Timeout: 10 * time.Second, Transport: tr}
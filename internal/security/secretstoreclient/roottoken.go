@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/securebuffer"
 )
 
 func (vc *vaultClient) RegenRootToken(initResp *InitResponse, rootToken *string) (err error) {
@@ -138,18 +140,22 @@ func (vc *vaultClient) rootTokenDecodeToken(encodedToken string, otp string, roo
 		return err
 	}
 
+	defer securebuffer.Wipe(encodedBytes)
 	otpBytes := []byte(otp)
+	defer securebuffer.Wipe(otpBytes)
 
 	if len(encodedBytes) != len(otpBytes) {
 		return fmt.Errorf("Invalid input to rootTokenDecodeToken - array length mismatch: %d != %d", len(encodedBytes), len(otpBytes))
 	}
 
-	decodedBytes := make([]byte, len(encodedBytes))
+	decodedBytes := securebuffer.New(len(encodedBytes))
+	defer decodedBytes.Destroy()
 
-	for i := range decodedBytes {
-		decodedBytes[i] = encodedBytes[i] ^ otpBytes[i]
+	decoded := decodedBytes.Bytes()
+	for i := range decoded {
+		decoded[i] = encodedBytes[i] ^ otpBytes[i]
 	}
 
-	*rootToken = string(decodedBytes)
+	*rootToken = decodedBytes.String()
 	return nil
 }
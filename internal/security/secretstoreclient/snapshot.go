@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstoreclient
+
+import (
+	"io"
+	"net/http"
+)
+
+// IsRaftStorage tells whether Vault uses the integrated raft storage, the only one supporting snapshots.
+// Vault fails reading the raft configuration of other storage backends, which is logged but not an error.
+func (vc *vaultClient) IsRaftStorage(token string) (isRaft bool, err error) {
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodGet,
+		Path:                 RaftConfigurationAPI,
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "read raft configuration",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       nil,
+	})
+	switch code {
+	case http.StatusOK:
+		return true, nil
+	case 0, http.StatusForbidden, http.StatusServiceUnavailable:
+		return false, err
+	default:
+		return false, nil
+	}
+}
+
+// TakeSnapshot writes a snapshot of the raft storage to snapshot
+func (vc *vaultClient) TakeSnapshot(token string, snapshot io.Writer) (statusCode int, err error) {
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodGet,
+		Path:                 RaftSnapshotAPI,
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "take raft snapshot",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       nil,
+		ResponseWriter:       snapshot,
+	})
+}
+
+// RestoreSnapshot replaces the raft storage with snapshot, which must have been taken from the same cluster
+// for its key shares to remain valid
+func (vc *vaultClient) RestoreSnapshot(token string, snapshot io.Reader) (statusCode int, err error) {
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 RaftSnapshotAPI,
+		JSONObject:           nil,
+		BodyReader:           snapshot,
+		OperationDescription: "restore raft snapshot",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+}
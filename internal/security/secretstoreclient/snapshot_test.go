@@ -0,0 +1,125 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstoreclient
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRaftStorage(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		expected    bool
+		expectError bool
+	}{
+		{"raft", http.StatusOK, true, false},
+		{"other storage", http.StatusInternalServerError, false, false},
+		{"permission denied", http.StatusForbidden, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "GET", r.Method)
+				assert.Equal(t, RaftConfigurationAPI, r.URL.EscapedPath())
+				assert.Equal(t, "fake-token", r.Header.Get("X-Vault-Token"))
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer ts.Close()
+
+			mockLogger := logger.MockLogger{}
+			host := strings.Replace(ts.URL, "https://", "", -1)
+			vc := NewSecretStoreClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host)
+
+			isRaft, err := vc.IsRaftStorage("fake-token")
+
+			assert.Equal(t, tt.expected, isRaft)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal(RaftSnapshotAPI, r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get("X-Vault-Token"))
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("snapshot-bytes"))
+		assert.NoError(err)
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	vc := NewSecretStoreClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host)
+
+	// Act
+	var snapshot bytes.Buffer
+	code, err := vc.TakeSnapshot("fake-token", &snapshot)
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal("snapshot-bytes", snapshot.String())
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal(RaftSnapshotAPI, r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get("X-Vault-Token"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(err)
+		assert.Equal("snapshot-bytes", string(body))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	vc := NewSecretStoreClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host)
+
+	// Act
+	code, err := vc.RestoreSnapshot("fake-token", strings.NewReader("snapshot-bytes"))
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, code)
+}
@@ -0,0 +1,143 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstoreclient
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// TransitClient signs and HMACs payloads using named keys held by the
+// Vault transit engine so that callers never handle raw key material
+type TransitClient interface {
+	Sign(token string, keyName string, payload []byte) (signature string, err error)
+	HMAC(token string, keyName string, payload []byte) (hmac string, err error)
+	VerifySignature(token string, keyName string, payload []byte, signature string) (valid bool, err error)
+	VerifyHMAC(token string, keyName string, payload []byte, hmac string) (valid bool, err error)
+	PublicKey(token string, keyName string) (publicKey string, err error)
+}
+
+type transitClient struct {
+	vc         *vaultClient
+	mountPoint string
+}
+
+// NewTransitClient creates a TransitClient for the transit engine mounted at mountPoint
+func NewTransitClient(logger logger.LoggingClient, r internal.HttpCaller, s string, h string, mountPoint string) TransitClient {
+	return &transitClient{
+		vc: &vaultClient{
+			logger: logger,
+			client: r,
+			scheme: s,
+			host:   h,
+		},
+		mountPoint: mountPoint,
+	}
+}
+
+func (tc *transitClient) Sign(token string, keyName string, payload []byte) (string, error) {
+	var response TransitSignResponse
+	_, err := tc.vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(TransitSignPath, tc.mountPoint, url.PathEscape(keyName)),
+		JSONObject:           TransitSignRequest{Input: base64.StdEncoding.EncodeToString(payload)},
+		BodyReader:           nil,
+		OperationDescription: "sign payload",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	return response.Data.Signature, err
+}
+
+func (tc *transitClient) HMAC(token string, keyName string, payload []byte) (string, error) {
+	var response TransitHMACResponse
+	_, err := tc.vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(TransitHMACPath, tc.mountPoint, url.PathEscape(keyName)),
+		JSONObject:           TransitHMACRequest{Input: base64.StdEncoding.EncodeToString(payload)},
+		BodyReader:           nil,
+		OperationDescription: "hmac payload",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	return response.Data.HMAC, err
+}
+
+func (tc *transitClient) VerifySignature(token string, keyName string, payload []byte, signature string) (bool, error) {
+	return tc.verify(token, keyName, TransitVerifyRequest{
+		Input:     base64.StdEncoding.EncodeToString(payload),
+		Signature: signature,
+	})
+}
+
+func (tc *transitClient) VerifyHMAC(token string, keyName string, payload []byte, hmac string) (bool, error) {
+	return tc.verify(token, keyName, TransitVerifyRequest{
+		Input: base64.StdEncoding.EncodeToString(payload),
+		HMAC:  hmac,
+	})
+}
+
+// PublicKey returns the public key of the latest version of the asymmetric key, which verifies its signatures
+// without Vault
+func (tc *transitClient) PublicKey(token string, keyName string) (string, error) {
+	var response ReadTransitKeyResponse
+	_, err := tc.vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodGet,
+		Path:                 fmt.Sprintf(TransitKeysPath, tc.mountPoint, url.PathEscape(keyName)),
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "read transit key",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	if err != nil {
+		return "", err
+	}
+	key, found := response.Data.Keys[strconv.Itoa(response.Data.LatestVersion)]
+	if !found || key.PublicKey == "" {
+		return "", fmt.Errorf("transit key %s has no public key", keyName)
+	}
+	return key.PublicKey, nil
+}
+
+func (tc *transitClient) verify(token string, keyName string, request TransitVerifyRequest) (bool, error) {
+	var response TransitVerifyResponse
+	_, err := tc.vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(TransitVerifyPath, tc.mountPoint, url.PathEscape(keyName)),
+		JSONObject:           request,
+		BodyReader:           nil,
+		OperationDescription: "verify payload",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	if err != nil {
+		return false, err
+	}
+	return response.Data.Valid, nil
+}
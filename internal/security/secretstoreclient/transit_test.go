@@ -0,0 +1,171 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstoreclient
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitSign(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/v1/transit/sign/edgex-core-data", r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get("X-Vault-Token"))
+
+		var body TransitSignRequest
+		err := json.NewDecoder(r.Body).Decode(&body)
+		assert.NoError(err)
+		assert.Equal(base64.StdEncoding.EncodeToString([]byte("payload")), body.Input)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"signature": "vault:v1:c2lnbmF0dXJl"}}`))
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	tc := NewTransitClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host, TransitMountPoint)
+
+	// Act
+	signature, err := tc.Sign("fake-token", "edgex-core-data", []byte("payload"))
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal("vault:v1:c2lnbmF0dXJl", signature)
+}
+
+func TestTransitHMAC(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/v1/transit/hmac/edgex-core-data", r.URL.EscapedPath())
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"hmac": "vault:v1:aG1hYw=="}}`))
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	tc := NewTransitClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host, TransitMountPoint)
+
+	// Act
+	hmac, err := tc.HMAC("fake-token", "edgex-core-data", []byte("payload"))
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal("vault:v1:aG1hYw==", hmac)
+}
+
+func TestTransitVerifySignature(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/v1/transit/verify/edgex-core-data", r.URL.EscapedPath())
+
+		var body TransitVerifyRequest
+		err := json.NewDecoder(r.Body).Decode(&body)
+		assert.NoError(err)
+		assert.Equal("vault:v1:c2lnbmF0dXJl", body.Signature)
+		assert.Empty(body.HMAC)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"valid": true}}`))
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	tc := NewTransitClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host, TransitMountPoint)
+
+	// Act
+	valid, err := tc.VerifySignature("fake-token", "edgex-core-data", []byte("payload"), "vault:v1:c2lnbmF0dXJl")
+
+	// Assert
+	assert.NoError(err)
+	assert.True(valid)
+}
+
+func TestTransitVerifyFailure(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	tc := NewTransitClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host, TransitMountPoint)
+
+	// Act
+	valid, err := tc.VerifyHMAC("fake-token", "edgex-core-data", []byte("payload"), "vault:v1:aG1hYw==")
+
+	// Assert
+	assert.Error(err)
+	assert.False(valid)
+}
+
+func TestTransitPublicKey(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("fake-token", r.Header.Get("X-Vault-Token"))
+
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.EscapedPath() {
+		case "/v1/transit/keys/edgex-decommission":
+			_, _ = w.Write([]byte(`{"data": {"keys": {"1": {"public_key": "old"}, "2": {"public_key": "cHVibGlj"}},
+				"latest_version": 2}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data": {"keys": {"1": 1622548800}, "latest_version": 1}}`))
+		}
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	tc := NewTransitClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host, TransitMountPoint)
+
+	// Act
+	publicKey, err := tc.PublicKey("fake-token", "edgex-decommission")
+	_, symmetricErr := tc.PublicKey("fake-token", "edgex-core-data")
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal("cHVibGlj", publicKey)
+	assert.Error(symmetricErr, "the symmetric keys have no public key")
+}
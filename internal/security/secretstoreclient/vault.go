@@ -134,6 +134,38 @@ func (vc *vaultClient) InstallPolicy(token string, policyName string, policyDocu
 	})
 }
 
+func (vc *vaultClient) ListPolicies(token string, policyNames *[]string) (statusCode int, err error) {
+	var response ListPoliciesResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               "LIST",
+		Path:                 ListPoliciesAPI,
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "list policies",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*policyNames = response.Data.Keys
+	return code, err
+}
+
+func (vc *vaultClient) ReadPolicy(token string, policyName string, policyDocument *string) (statusCode int, err error) {
+	var response ReadPolicyResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodGet,
+		Path:                 fmt.Sprintf(CreatePolicyPath, url.PathEscape(policyName)),
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "read policy",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*policyDocument = response.Data.Policy
+	return code, err
+}
+
 func (vc *vaultClient) CreateToken(token string, parameters map[string]interface{}, response interface{}) (int, error) {
 	return vc.doRequest(commonRequestArgs{
 		AuthToken:            token,
@@ -257,3 +289,33 @@ func (vc *vaultClient) EnableKVSecretEngine(token string, mountPoint string, kvV
 	})
 	return rc, err
 }
+
+func (vc *vaultClient) EnableTransitSecretEngine(token string, mountPoint string) (statusCode int, err error) {
+	urlPath := path.Join(VaultMountsAPI, mountPoint)
+	parameters := EnableSecretsEngineRequest{Type: "transit", Description: "encryption as a service"}
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 urlPath,
+		JSONObject:           parameters,
+		BodyReader:           nil,
+		OperationDescription: "enable transit secrets engine",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+}
+
+// CreateTransitKey creates a named key in the transit engine.  Vault treats
+// creation of an already existing key as a no-op, so this is safe to call on every run.
+func (vc *vaultClient) CreateTransitKey(token string, mountPoint string, keyName string, keyType string) (statusCode int, err error) {
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(TransitKeysPath, mountPoint, url.PathEscape(keyName)),
+		JSONObject:           CreateTransitKeyRequest{Type: keyType},
+		BodyReader:           nil,
+		OperationDescription: "create transit key",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+}
@@ -274,6 +274,73 @@ func TestListAccessors(t *testing.T) {
 	assert.Equal("accessor2", response[1])
 }
 
+func TestListPolicies(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("LIST", r.Method)
+		assert.Equal(ListPoliciesAPI, r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get("X-Vault-Token"))
+
+		// No body for this request
+
+		w.WriteHeader(http.StatusOK)
+
+		response := ListPoliciesResponse{}
+		response.Data.Keys = []string{"default", "edgex-service-core-data"}
+		err := json.NewEncoder(w).Encode(response)
+		assert.NoError(err)
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	vc := NewSecretStoreClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host)
+
+	// Act
+	var response []string
+	code, err := vc.ListPolicies("fake-token", &response)
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal([]string{"default", "edgex-service-core-data"}, response)
+}
+
+func TestReadPolicy(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/v1/sys/policies/acl/edgex-service-core-data", r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get("X-Vault-Token"))
+
+		w.WriteHeader(http.StatusOK)
+
+		response := ReadPolicyResponse{}
+		response.Data.Name = "edgex-service-core-data"
+		response.Data.Policy = `path "secret/edgex/core-data/*" { capabilities = ["read"] }`
+		err := json.NewEncoder(w).Encode(response)
+		assert.NoError(err)
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	vc := NewSecretStoreClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host)
+
+	// Act
+	var document string
+	code, err := vc.ReadPolicy("fake-token", "edgex-service-core-data", &document)
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(`path "secret/edgex/core-data/*" { capabilities = ["read"] }`, document)
+}
+
 func TestRevokeAccessor(t *testing.T) {
 	// Arrange
 	assert := assert.New(t)
@@ -619,3 +686,63 @@ func TestEnableKVSecretEngine(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(http.StatusNoContent, code)
 }
+
+func TestEnableTransitSecretEngine(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal(VaultMountsAPI+"/transit", r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get("X-Vault-Token"))
+
+		var body EnableSecretsEngineRequest
+		err := json.NewDecoder(r.Body).Decode(&body)
+		assert.NoError(err)
+		assert.Equal("transit", body.Type)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	vc := NewSecretStoreClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host)
+
+	// Act
+	code, err := vc.EnableTransitSecretEngine("fake-token", TransitMountPoint)
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, code)
+}
+
+func TestCreateTransitKey(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/v1/transit/keys/edgex-core-data", r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get("X-Vault-Token"))
+
+		var body CreateTransitKeyRequest
+		err := json.NewDecoder(r.Body).Decode(&body)
+		assert.NoError(err)
+		assert.Equal("ed25519", body.Type)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	vc := NewSecretStoreClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host)
+
+	// Act
+	code, err := vc.CreateTransitKey("fake-token", TransitMountPoint, "edgex-core-data", "ed25519")
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, code)
+}
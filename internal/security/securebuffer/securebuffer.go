@@ -0,0 +1,84 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package securebuffer holds secrets, such as key material, key shares and
+// generated passwords, in buffers that are locked in memory so that they are
+// never swapped out, excluded from core dumps and zeroed when released, instead
+// of in strings lingering in the garbage collected heap until it is reused.
+//
+// On Linux the buffers span whole pages of their own; elsewhere they are
+// plain byte slices that are only zeroed. Locking memory may fail without
+// CAP_IPC_LOCK once RLIMIT_MEMLOCK is reached, in which case the buffer is
+// still usable and zeroed, only unlocked.
+package securebuffer
+
+// SecureBuffer is a fixed-size buffer holding a secret
+type SecureBuffer struct {
+	data    []byte
+	locked  bool
+	release func(data []byte)
+}
+
+// New returns a zero-filled SecureBuffer of size bytes, which the caller
+// releases with Destroy
+func New(size int) *SecureBuffer {
+	if size <= 0 {
+		return &SecureBuffer{data: []byte{}, release: func([]byte) {}}
+	}
+	return allocate(size)
+}
+
+// FromBytes returns a SecureBuffer holding a copy of secret, which is zeroed
+func FromBytes(secret []byte) *SecureBuffer {
+	b := New(len(secret))
+	copy(b.data, secret)
+	Wipe(secret)
+	return b
+}
+
+// Bytes returns the secret, which is only valid until Destroy is called;
+// nil is returned once it has been
+func (b *SecureBuffer) Bytes() []byte {
+	if b == nil {
+		return nil
+	}
+	return b.data
+}
+
+// Len returns the size of the secret
+func (b *SecureBuffer) Len() int {
+	return len(b.Bytes())
+}
+
+// Locked returns whether the buffer is locked in memory
+func (b *SecureBuffer) Locked() bool {
+	return b != nil && b.locked
+}
+
+// String returns a copy of the secret as a string for the APIs requiring one,
+// e.g. HTTP headers and JSON bodies; the copy isn't protected
+func (b *SecureBuffer) String() string {
+	return string(b.Bytes())
+}
+
+// Destroy zeroes the secret and releases the buffer; it can be called more
+// than once, and on a nil SecureBuffer
+func (b *SecureBuffer) Destroy() {
+	if b == nil || b.data == nil {
+		return
+	}
+	Wipe(b.data)
+	b.release(b.data)
+	b.data = nil
+	b.locked = false
+}
+
+// Wipe zeroes secret
+func Wipe(secret []byte) {
+	for i := range secret {
+		secret[i] = 0
+	}
+}
@@ -0,0 +1,59 @@
+// +build linux
+
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package securebuffer
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// madvDontDump and madvDoDump exclude pages from and include them back in core dumps; they aren't defined by syscall
+const (
+	madvDontDump = 0x10
+	madvDoDump   = 0x11
+)
+
+// allocate returns a buffer spanning whole pages of its own, so that locking and unlocking them doesn't affect other
+// objects, which are locked in memory and excluded from core dumps. The pages are part of the Go heap, whose objects
+// don't move, so that a slice of the buffer retained after Destroy only holds zeros instead of faulting.
+func allocate(size int) *SecureBuffer {
+	pageSize := os.Getpagesize()
+	length := (size + pageSize - 1) / pageSize * pageSize
+	backing := make([]byte, length+pageSize)
+	offset := (pageSize - int(uintptr(unsafe.Pointer(&backing[0]))%uintptr(pageSize))) % pageSize
+	pages := backing[offset : offset+length]
+
+	_ = syscall.Madvise(pages, madvDontDump)
+	locked := syscall.Mlock(pages) == nil
+
+	return &SecureBuffer{
+		data:   pages[:size:size],
+		locked: locked,
+		release: func([]byte) {
+			if locked {
+				_ = syscall.Munlock(pages)
+			}
+			_ = syscall.Madvise(pages, madvDoDump)
+		},
+	}
+}
+
+// DisableCoreDumps prevents the process from dumping core, which would write the
+// secrets held in strings to disk, and other processes of the same user from
+// attaching to it with ptrace
+func DisableCoreDumps() error {
+	if err := syscall.Setrlimit(syscall.RLIMIT_CORE, &syscall.Rlimit{Cur: 0, Max: 0}); err != nil {
+		return err
+	}
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_SET_DUMPABLE, 0, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// +build linux
+
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package securebuffer
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferIsPageAligned(t *testing.T) {
+	for _, size := range []int{1, 32, os.Getpagesize(), 3*os.Getpagesize() + 1} {
+		b := New(size)
+		data := b.Bytes()
+		assert.Len(t, data, size)
+		assert.Equal(t, uintptr(0), uintptr(unsafe.Pointer(&data[0]))%uintptr(os.Getpagesize()))
+		data[size-1] = 1
+
+		b.Destroy()
+		assert.Equal(t, byte(0), data[size-1], "a slice retained after Destroy holds zeros")
+	}
+}
+
+func TestDisableCoreDumps(t *testing.T) {
+	assert.NoError(t, DisableCoreDumps())
+
+	var limit syscall.Rlimit
+	assert.NoError(t, syscall.Getrlimit(syscall.RLIMIT_CORE, &limit))
+	assert.Equal(t, uint64(0), limit.Cur)
+}
@@ -0,0 +1,19 @@
+// +build !linux
+
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package securebuffer
+
+// allocate returns a buffer on the heap, which is only zeroed
+func allocate(size int) *SecureBuffer {
+	return &SecureBuffer{data: make([]byte, size), release: func([]byte) {}}
+}
+
+// DisableCoreDumps is a no-op outside of Linux
+func DisableCoreDumps() error {
+	return nil
+}
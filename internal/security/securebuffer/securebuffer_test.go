@@ -0,0 +1,66 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package securebuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromBytes(t *testing.T) {
+	secret := []byte("s.root-token")
+
+	b := FromBytes(secret)
+
+	assert.Equal(t, make([]byte, len(secret)), secret, "the source is zeroed")
+	assert.Equal(t, "s.root-token", string(b.Bytes()))
+	assert.Equal(t, "s.root-token", b.String())
+	assert.Equal(t, len(secret), b.Len())
+}
+
+func TestDestroy(t *testing.T) {
+	b := New(32)
+	require.Equal(t, make([]byte, 32), b.Bytes())
+	data := b.Bytes()
+	copy(data, "key share")
+
+	b.Destroy()
+
+	assert.Nil(t, b.Bytes())
+	assert.Equal(t, 0, b.Len())
+	assert.False(t, b.Locked())
+	assert.NotPanics(t, b.Destroy, "Destroy can be called again")
+}
+
+func TestDestroyWipesHeapBuffer(t *testing.T) {
+	data := []byte("key share")
+	b := &SecureBuffer{data: data, release: func([]byte) {}}
+
+	b.Destroy()
+
+	assert.Equal(t, make([]byte, len("key share")), data)
+}
+
+func TestEmptyAndNil(t *testing.T) {
+	b := New(0)
+	assert.Equal(t, 0, b.Len())
+	b.Destroy()
+
+	var nilBuffer *SecureBuffer
+	assert.Nil(t, nilBuffer.Bytes())
+	assert.Equal(t, "", nilBuffer.String())
+	assert.False(t, nilBuffer.Locked())
+	assert.NotPanics(t, nilBuffer.Destroy)
+}
+
+func TestWipe(t *testing.T) {
+	secret := []byte("password")
+	Wipe(secret)
+	assert.Equal(t, make([]byte, len("password")), secret)
+}
@@ -0,0 +1,24 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package securitytest
+
+import "testing"
+
+// Harness runs a FakeVault and a FakeKong for the duration of a test
+type Harness struct {
+	Vault *FakeVault
+	Kong  *FakeKong
+}
+
+// NewHarness starts an uninitialized FakeVault and an empty FakeKong, closed when the test ends
+func NewHarness(t testing.TB) *Harness {
+	h := &Harness{Vault: NewFakeVault(), Kong: NewFakeKong()}
+	t.Cleanup(func() {
+		h.Vault.Close()
+		h.Kong.Close()
+	})
+	return h
+}
@@ -0,0 +1,279 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package securitytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// the entities of the Kong admin API, by the path of their collection
+var kongCollections = []string{"services", "routes", "plugins", "consumers", "acls", "certificates", "ca_certificates"}
+
+// KongEntity is an entity created through the Kong admin API, with the fields it was posted with, as form values or
+// JSON. Parent is the collection and name of the entity it was created under, such as routes/coredata, if any. The
+// consumers are named by their username.
+type KongEntity struct {
+	ID     string
+	Name   string
+	Parent string
+	Fields map[string]interface{}
+}
+
+// FakeKong is a Kong admin API keeping the services, routes, plugins, consumers with their ACL groups, certificates
+// and CA certificates in memory. They are created by posting to their collection, or to the collection of the entity
+// they belong to, such as /services/{name}/routes, or upserted by putting to their id or name, listed by getting the
+// collection and deleted by id or name. As Kong, it rejects the entities whose name is taken with a 409 status.
+type FakeKong struct {
+	Server *httptest.Server
+
+	mutex    sync.Mutex
+	nextID   int
+	entities map[string][]*KongEntity
+}
+
+// NewFakeKong starts an empty FakeKong, which the caller closes
+func NewFakeKong() *FakeKong {
+	k := &FakeKong{entities: make(map[string][]*KongEntity)}
+	k.Server = httptest.NewServer(http.HandlerFunc(k.serveHTTP))
+	return k
+}
+
+// Close shuts the server down
+func (k *FakeKong) Close() {
+	k.Server.Close()
+}
+
+// HostAndPort returns the host and port of the admin API, as configured for the proxy setup
+func (k *FakeKong) HostAndPort() (string, int) {
+	parsed, err := url.Parse(k.Server.URL)
+	if err != nil {
+		panic(err)
+	}
+	port, err := strconv.Atoi(parsed.Port())
+	if err != nil {
+		panic(err)
+	}
+	return parsed.Hostname(), port
+}
+
+// Entities returns the entities of the collection, such as "services", in their order of creation
+func (k *FakeKong) Entities(collection string) []KongEntity {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	var entities []KongEntity
+	for _, e := range k.entities[collection] {
+		entities = append(entities, *e)
+	}
+	return entities
+}
+
+// Names returns the sorted names of the entities of the collection
+func (k *FakeKong) Names(collection string) []string {
+	var names []string
+	for _, e := range k.Entities(collection) {
+		names = append(names, e.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (k *FakeKong) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "" && r.Method == http.MethodGet:
+		writeKongJSON(w, http.StatusOK, map[string]string{"tagline": "Welcome to kong"})
+	case len(segments) == 1 && isKongCollection(segments[0]) && r.Method == http.MethodGet:
+		k.list(w, segments[0])
+	case len(segments) == 1 && isKongCollection(segments[0]) && r.Method == http.MethodPost:
+		k.create(w, r, segments[0], "")
+	case len(segments) == 2 && isKongCollection(segments[0]) && r.Method == http.MethodGet:
+		if e := k.find(segments[0], segments[1]); e != nil {
+			writeKongJSON(w, http.StatusOK, e.Fields)
+			return
+		}
+		writeKongError(w, http.StatusNotFound, "Not found")
+	case len(segments) == 2 && isKongCollection(segments[0]) && r.Method == http.MethodPut:
+		k.upsert(w, r, segments[0], segments[1])
+	case len(segments) == 2 && isKongCollection(segments[0]) && r.Method == http.MethodDelete:
+		k.delete(segments[0], segments[1])
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 3 && isKongCollection(segments[0]) && isKongCollection(segments[2]) && r.Method == http.MethodPost:
+		parent := k.find(segments[0], segments[1])
+		if parent == nil {
+			writeKongError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		k.create(w, r, segments[2], segments[0]+"/"+parent.Name)
+	default:
+		writeKongError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (k *FakeKong) list(w http.ResponseWriter, collection string) {
+	data := make([]map[string]interface{}, 0, len(k.entities[collection]))
+	for _, e := range k.entities[collection] {
+		data = append(data, e.Fields)
+	}
+	writeKongJSON(w, http.StatusOK, map[string]interface{}{"data": data, "next": nil})
+}
+
+func (k *FakeKong) create(w http.ResponseWriter, r *http.Request, collection string, parent string) {
+	fields, ok := readKongFields(w, r)
+	if !ok {
+		return
+	}
+
+	name := entityName(fields)
+	// the names of the plugins are their types, of which there is one per service, route or consumer
+	if name != "" && collection != "plugins" && k.find(collection, name) != nil {
+		writeKongError(w, http.StatusConflict, fmt.Sprintf("UNIQUE violation detected on '{name=\"%s\"}'", name))
+		return
+	}
+	for _, e := range k.entities[collection] {
+		if collection == "plugins" && e.Name == name && e.Parent == parent {
+			writeKongError(w, http.StatusConflict, fmt.Sprintf("UNIQUE violation detected on '{name=\"%s\"}'", name))
+			return
+		}
+		if collection == "acls" && e.Fields["group"] == fields["group"] && e.Parent == parent {
+			writeKongError(w, http.StatusConflict,
+				fmt.Sprintf("UNIQUE violation detected on '{group=\"%v\"}'", fields["group"]))
+			return
+		}
+	}
+
+	k.nextID++
+	fields["id"] = fmt.Sprintf("%08d-0000-4000-8000-000000000000", k.nextID)
+	e := &KongEntity{ID: fields["id"].(string), Name: name, Parent: parent, Fields: fields}
+	k.entities[collection] = append(k.entities[collection], e)
+	writeKongJSON(w, http.StatusCreated, fields)
+}
+
+// upsert replaces the fields of the entity, or creates it with the id, or else the name, it was put to
+func (k *FakeKong) upsert(w http.ResponseWriter, r *http.Request, collection string, idOrName string) {
+	fields, ok := readKongFields(w, r)
+	if !ok {
+		return
+	}
+
+	e := k.find(collection, idOrName)
+	if e == nil {
+		e = &KongEntity{}
+		if _, err := uuid.Parse(idOrName); err == nil {
+			e.ID = idOrName
+		} else {
+			k.nextID++
+			e.ID = fmt.Sprintf("%08d-0000-4000-8000-000000000000", k.nextID)
+			if entityName(fields) == "" {
+				fields["name"] = idOrName
+			}
+		}
+		k.entities[collection] = append(k.entities[collection], e)
+	}
+	fields["id"] = e.ID
+	e.Name = entityName(fields)
+	e.Fields = fields
+	writeKongJSON(w, http.StatusOK, fields)
+}
+
+func (k *FakeKong) find(collection string, idOrName string) *KongEntity {
+	for _, e := range k.entities[collection] {
+		if e.ID == idOrName || (e.Name != "" && e.Name == idOrName) {
+			return e
+		}
+	}
+	return nil
+}
+
+// delete removes the entity, and the entities it holds, as Kong cascades the deletes. Deleting a missing entity
+// succeeds.
+func (k *FakeKong) delete(collection string, idOrName string) {
+	e := k.find(collection, idOrName)
+	if e == nil {
+		return
+	}
+	var kept []*KongEntity
+	for _, other := range k.entities[collection] {
+		if other != e {
+			kept = append(kept, other)
+		}
+	}
+	k.entities[collection] = kept
+	if e.Name == "" {
+		return
+	}
+	for _, held := range kongCollections {
+		for _, other := range k.entities[held] {
+			if other.Parent == collection+"/"+e.Name {
+				k.delete(held, other.ID)
+			}
+		}
+	}
+}
+
+// readKongFields reads the fields of an entity from the form values, the repeated ones being arrays, or JSON of the
+// request, writing the error response when it can't be read
+func readKongFields(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	fields := make(map[string]interface{})
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			writeKongError(w, http.StatusBadRequest, fmt.Sprintf("Cannot parse JSON body: %s", err.Error()))
+			return nil, false
+		}
+		return fields, true
+	}
+	if err := r.ParseForm(); err != nil {
+		writeKongError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	for key, values := range r.PostForm {
+		if len(values) > 1 {
+			fields[key] = values
+		} else {
+			fields[key] = values[0]
+		}
+	}
+	return fields, true
+}
+
+func entityName(fields map[string]interface{}) string {
+	if name, ok := fields["name"].(string); ok {
+		return name
+	}
+	username, _ := fields["username"].(string)
+	return username
+}
+
+func isKongCollection(path string) bool {
+	for _, collection := range kongCollections {
+		if path == collection {
+			return true
+		}
+	}
+	return false
+}
+
+func writeKongJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeKongError(w http.ResponseWriter, status int, message string) {
+	writeKongJSON(w, status, map[string]string{"message": message})
+}
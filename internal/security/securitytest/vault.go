@@ -0,0 +1,554 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package securitytest provides fakes of the Vault and Kong admin APIs, served by httptest servers, so the flows of
+// security-secretstore-setup and security-proxy-setup can be tested end to end without the real servers.
+package securitytest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+)
+
+const (
+	// the length of the tokens, which the one-time passwords of the root token generation must match
+	tokenLength = 26
+	tokenChars  = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// VaultToken describes a token issued by a FakeVault
+type VaultToken struct {
+	Accessor    string
+	DisplayName string
+	Policies    []string
+}
+
+// FakeVault is a Vault server keeping its state in memory. It serves the health, init, unseal and root token
+// generation APIs, and the token, policy and mount APIs authenticated by the tokens it issued. Any threshold of the
+// distinct key shares it generated unseals it, and it is sealed again by Seal, as when Vault restarts.
+type FakeVault struct {
+	Server *httptest.Server
+
+	mutex       sync.Mutex
+	initialized bool
+	sealed      bool
+	threshold   int
+	keys        [][]byte
+	unsealKeys  map[string]bool
+	unready     int
+	generation  *rootGeneration
+	tokens      map[string]*VaultToken
+	policies    map[string]string
+	mounts      map[string]string
+}
+
+type rootGeneration struct {
+	nonce string
+	otp   string
+	keys  map[string]bool
+}
+
+// NewFakeVault starts an uninitialized FakeVault, which the caller closes
+func NewFakeVault() *FakeVault {
+	v := &FakeVault{
+		sealed:   true,
+		tokens:   make(map[string]*VaultToken),
+		policies: make(map[string]string),
+		mounts:   map[string]string{"sys/": "system", "cubbyhole/": "cubbyhole", "identity/": "identity"},
+	}
+	v.Server = httptest.NewServer(http.HandlerFunc(v.serveHTTP))
+	return v
+}
+
+// Close shuts the server down
+func (v *FakeVault) Close() {
+	v.Server.Close()
+}
+
+// Host returns the host and port of the server, as configured for the Vault clients
+func (v *FakeVault) Host() string {
+	return strings.TrimPrefix(v.Server.URL, "http://")
+}
+
+// Seal seals the vault, discarding the progress of the unsealing and of the root token generation
+func (v *FakeVault) Seal() {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.sealed = true
+	v.unsealKeys = nil
+	v.generation = nil
+}
+
+// Sealed returns whether the vault is sealed
+func (v *FakeVault) Sealed() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.sealed
+}
+
+// Initialized returns whether the vault is initialized
+func (v *FakeVault) Initialized() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.initialized
+}
+
+// DelayReady makes the next health checks of the unsealed vault fail with a 500 status, as Vault does while it gets
+// ready once unsealed
+func (v *FakeVault) DelayReady(healthChecks int) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.unready = healthChecks
+}
+
+// Tokens returns the valid tokens by their id
+func (v *FakeVault) Tokens() map[string]VaultToken {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	tokens := make(map[string]VaultToken, len(v.tokens))
+	for id, token := range v.tokens {
+		tokens[id] = *token
+	}
+	return tokens
+}
+
+// RootTokens returns the ids of the valid tokens with the root policy, sorted
+func (v *FakeVault) RootTokens() []string {
+	var ids []string
+	for id, token := range v.Tokens() {
+		if hasPolicy(token.Policies, "root") {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// IssueToken issues a token with the policies, as the previous runs of the setup or the services would
+func (v *FakeVault) IssueToken(displayName string, policies ...string) string {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.issue(displayName, policies)
+}
+
+// Policy returns the ACL policy document installed under the name, if any
+func (v *FakeVault) Policy(name string) (string, bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	policy, ok := v.policies[name]
+	return policy, ok
+}
+
+// Mounts returns the types of the secrets engines by their mount path, ending with a slash
+func (v *FakeVault) Mounts() map[string]string {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	mounts := make(map[string]string, len(v.mounts))
+	for path, engine := range v.mounts {
+		mounts[path] = engine
+	}
+	return mounts
+}
+
+func (v *FakeVault) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case path == secretstoreclient.VaultHealthAPI && r.Method == http.MethodGet:
+		v.health(w)
+		return
+	case path == secretstoreclient.VaultInitAPI && r.Method == http.MethodPost:
+		v.init(w, r)
+		return
+	case path == secretstoreclient.VaultUnsealAPI && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+		v.unseal(w, r)
+		return
+	}
+
+	if !v.initialized {
+		writeVaultError(w, http.StatusBadRequest, "Vault is not initialized")
+		return
+	}
+	if v.sealed {
+		writeVaultError(w, http.StatusServiceUnavailable, "Vault is sealed")
+		return
+	}
+
+	switch {
+	case path == secretstoreclient.RootTokenControlAPI && r.Method == http.MethodDelete:
+		v.generation = nil
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case path == secretstoreclient.RootTokenControlAPI && (r.Method == http.MethodPut || r.Method == http.MethodPost):
+		v.startGeneration(w)
+		return
+	case path == secretstoreclient.RootTokenRetrievalAPI && (r.Method == http.MethodPut || r.Method == http.MethodPost):
+		v.updateGeneration(w, r)
+		return
+	}
+
+	id := r.Header.Get(secretstoreclient.VaultToken)
+	token, ok := v.tokens[id]
+	if !ok {
+		writeVaultError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	switch {
+	case path == secretstoreclient.LookupSelfAPI && r.Method == http.MethodGet:
+		writeVaultJSON(w, http.StatusOK, secretstoreclient.TokenLookupResponse{Data: metadata(token)})
+	case path == secretstoreclient.RevokeSelfAPI && r.Method == http.MethodPost:
+		delete(v.tokens, id)
+		w.WriteHeader(http.StatusNoContent)
+	case path == secretstoreclient.ListAccessorsAPI && (r.Method == "LIST" || r.Method == http.MethodGet):
+		var response secretstoreclient.ListTokenAccessorsResponse
+		for _, t := range v.tokens {
+			response.Data.Keys = append(response.Data.Keys, t.Accessor)
+		}
+		sort.Strings(response.Data.Keys)
+		writeVaultJSON(w, http.StatusOK, response)
+	case path == secretstoreclient.LookupAccessorAPI && r.Method == http.MethodPost:
+		var request secretstoreclient.LookupAccessorRequest
+		if !decodeVaultRequest(w, r, &request) {
+			return
+		}
+		if _, t := v.byAccessor(request.Accessor); t != nil {
+			writeVaultJSON(w, http.StatusOK, secretstoreclient.TokenLookupResponse{Data: metadata(t)})
+			return
+		}
+		writeVaultError(w, http.StatusBadRequest, "invalid accessor")
+	case path == secretstoreclient.RevokeAccessorAPI && r.Method == http.MethodPost:
+		var request secretstoreclient.RevokeTokenAccessorRequest
+		if !decodeVaultRequest(w, r, &request) {
+			return
+		}
+		if revoked, t := v.byAccessor(request.Accessor); t != nil {
+			delete(v.tokens, revoked)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeVaultError(w, http.StatusBadRequest, "invalid accessor")
+	case path == secretstoreclient.CreateTokenAPI && r.Method == http.MethodPost:
+		v.createToken(w, r, token)
+	case strings.HasPrefix(path, secretstoreclient.ListPoliciesAPI):
+		v.policy(w, r, strings.TrimPrefix(strings.TrimPrefix(path, secretstoreclient.ListPoliciesAPI), "/"))
+	case strings.HasPrefix(path, secretstoreclient.VaultMountsAPI):
+		v.mount(w, r, strings.TrimPrefix(strings.TrimPrefix(path, secretstoreclient.VaultMountsAPI), "/"))
+	default:
+		writeVaultError(w, http.StatusNotFound, fmt.Sprintf("no handler for %s %s", r.Method, path))
+	}
+}
+
+// health answers with the status codes of /v1/sys/health
+func (v *FakeVault) health(w http.ResponseWriter) {
+	status := http.StatusOK
+	switch {
+	case !v.initialized:
+		status = http.StatusNotImplemented
+	case v.sealed:
+		status = http.StatusServiceUnavailable
+	case v.unready > 0:
+		v.unready--
+		status = http.StatusInternalServerError
+	}
+	writeVaultJSON(w, status, map[string]bool{"initialized": v.initialized, "sealed": v.sealed})
+}
+
+func (v *FakeVault) init(w http.ResponseWriter, r *http.Request) {
+	var request secretstoreclient.InitRequest
+	if !decodeVaultRequest(w, r, &request) {
+		return
+	}
+	if v.initialized {
+		writeVaultError(w, http.StatusBadRequest, "Vault is already initialized")
+		return
+	}
+	if request.SecretShares < 1 || request.SecretThreshold < 1 || request.SecretThreshold > request.SecretShares {
+		writeVaultError(w, http.StatusBadRequest, "invalid seal configuration")
+		return
+	}
+
+	response := secretstoreclient.InitResponse{}
+	for i := 0; i < request.SecretShares; i++ {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			writeVaultError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		v.keys = append(v.keys, key)
+		response.Keys = append(response.Keys, hex.EncodeToString(key))
+		response.KeysBase64 = append(response.KeysBase64, base64.StdEncoding.EncodeToString(key))
+	}
+	v.initialized = true
+	v.threshold = request.SecretThreshold
+	response.RootToken = v.issue("root", []string{"root"})
+	writeVaultJSON(w, http.StatusOK, response)
+}
+
+func (v *FakeVault) unseal(w http.ResponseWriter, r *http.Request) {
+	var request secretstoreclient.UnsealRequest
+	if !decodeVaultRequest(w, r, &request) {
+		return
+	}
+	if !v.initialized {
+		writeVaultError(w, http.StatusBadRequest, "Vault is not initialized")
+		return
+	}
+	if request.Reset {
+		v.unsealKeys = nil
+	} else if v.sealed {
+		key, ok := v.keyShare(request.Key)
+		if !ok {
+			writeVaultError(w, http.StatusBadRequest, "invalid key share")
+			return
+		}
+		if v.unsealKeys == nil {
+			v.unsealKeys = make(map[string]bool)
+		}
+		v.unsealKeys[key] = true
+		if len(v.unsealKeys) >= v.threshold {
+			v.sealed = false
+			v.unsealKeys = nil
+		}
+	}
+	writeVaultJSON(w, http.StatusOK, secretstoreclient.UnsealResponse{
+		Sealed:   v.sealed,
+		T:        v.threshold,
+		N:        len(v.keys),
+		Progress: len(v.unsealKeys),
+	})
+}
+
+func (v *FakeVault) startGeneration(w http.ResponseWriter) {
+	if v.generation != nil {
+		writeVaultError(w, http.StatusBadRequest, "root generation already in progress")
+		return
+	}
+	v.generation = &rootGeneration{
+		nonce: randomString(tokenLength),
+		otp:   randomString(tokenLength),
+		keys:  make(map[string]bool),
+	}
+	writeVaultJSON(w, http.StatusOK, secretstoreclient.RootTokenControlResponse{
+		Nonce: v.generation.nonce,
+		Otp:   v.generation.otp,
+	})
+}
+
+func (v *FakeVault) updateGeneration(w http.ResponseWriter, r *http.Request) {
+	var request secretstoreclient.RootTokenRetrievalRequest
+	if !decodeVaultRequest(w, r, &request) {
+		return
+	}
+	if v.generation == nil {
+		writeVaultError(w, http.StatusBadRequest, "no root generation in progress")
+		return
+	}
+	if request.Nonce != v.generation.nonce {
+		writeVaultError(w, http.StatusBadRequest, "incorrect nonce")
+		return
+	}
+	key, ok := v.keyShare(request.Key)
+	if !ok {
+		writeVaultError(w, http.StatusBadRequest, "invalid key share")
+		return
+	}
+	v.generation.keys[key] = true
+	if len(v.generation.keys) < v.threshold {
+		writeVaultJSON(w, http.StatusOK, secretstoreclient.RootTokenRetrievalResponse{})
+		return
+	}
+
+	// the encoded token is the token XORed with the one-time password, in unpadded base64
+	token := v.issue("root", []string{"root"})
+	encoded := make([]byte, tokenLength)
+	for i := range encoded {
+		encoded[i] = token[i] ^ v.generation.otp[i]
+	}
+	v.generation = nil
+	writeVaultJSON(w, http.StatusOK, secretstoreclient.RootTokenRetrievalResponse{
+		Complete:     true,
+		EncodedToken: base64.RawStdEncoding.EncodeToString(encoded),
+	})
+}
+
+func (v *FakeVault) createToken(w http.ResponseWriter, r *http.Request, parent *VaultToken) {
+	var request struct {
+		DisplayName string   `json:"display_name"`
+		Policies    []string `json:"policies"`
+	}
+	if !decodeVaultRequest(w, r, &request) {
+		return
+	}
+	if !hasPolicy(parent.Policies, "root") && !hasPolicy(parent.Policies, "privileged-token-creator") {
+		writeVaultError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+	if len(request.Policies) == 0 {
+		request.Policies = parent.Policies
+	}
+	id := v.issue(request.DisplayName, request.Policies)
+	writeVaultJSON(w, http.StatusOK, map[string]interface{}{
+		"auth": map[string]interface{}{
+			"client_token": id,
+			"accessor":     v.tokens[id].Accessor,
+			"policies":     request.Policies,
+		},
+	})
+}
+
+func (v *FakeVault) policy(w http.ResponseWriter, r *http.Request, name string) {
+	switch {
+	case name == "" && (r.Method == "LIST" || r.Method == http.MethodGet):
+		var response secretstoreclient.ListPoliciesResponse
+		for policyName := range v.policies {
+			response.Data.Keys = append(response.Data.Keys, policyName)
+		}
+		sort.Strings(response.Data.Keys)
+		writeVaultJSON(w, http.StatusOK, response)
+	case name != "" && (r.Method == http.MethodPut || r.Method == http.MethodPost):
+		var request secretstoreclient.UpdateACLPolicyRequest
+		if !decodeVaultRequest(w, r, &request) {
+			return
+		}
+		v.policies[name] = request.Policy
+		w.WriteHeader(http.StatusNoContent)
+	case name != "" && r.Method == http.MethodGet:
+		policy, ok := v.policies[name]
+		if !ok {
+			writeVaultError(w, http.StatusNotFound, fmt.Sprintf("policy %s not found", name))
+			return
+		}
+		var response secretstoreclient.ReadPolicyResponse
+		response.Data.Name = name
+		response.Data.Policy = policy
+		writeVaultJSON(w, http.StatusOK, response)
+	default:
+		writeVaultError(w, http.StatusMethodNotAllowed, "unsupported operation")
+	}
+}
+
+func (v *FakeVault) mount(w http.ResponseWriter, r *http.Request, path string) {
+	switch {
+	case path == "" && r.Method == http.MethodGet:
+		var response secretstoreclient.ListSecretEnginesResponse
+		response.Data = make(map[string]struct {
+			Type string `json:"type"`
+		})
+		for mountPath, engine := range v.mounts {
+			response.Data[mountPath] = struct {
+				Type string `json:"type"`
+			}{Type: engine}
+		}
+		writeVaultJSON(w, http.StatusOK, response)
+	case path != "" && r.Method == http.MethodPost:
+		var request secretstoreclient.EnableSecretsEngineRequest
+		if !decodeVaultRequest(w, r, &request) {
+			return
+		}
+		if _, ok := v.mounts[path+"/"]; ok {
+			writeVaultError(w, http.StatusBadRequest, fmt.Sprintf("path is already in use at %s/", path))
+			return
+		}
+		v.mounts[path+"/"] = request.Type
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeVaultError(w, http.StatusMethodNotAllowed, "unsupported operation")
+	}
+}
+
+// keyShare returns the hex encoding of the key share given in base64 or hex if it is one of the vault's
+func (v *FakeVault) keyShare(encoded string) (string, bool) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		if key, err = hex.DecodeString(encoded); err != nil {
+			return "", false
+		}
+	}
+	for _, k := range v.keys {
+		if string(k) == string(key) {
+			return hex.EncodeToString(key), true
+		}
+	}
+	return "", false
+}
+
+func (v *FakeVault) issue(displayName string, policies []string) string {
+	id := "s." + randomString(tokenLength-2)
+	v.tokens[id] = &VaultToken{
+		Accessor:    randomString(24),
+		DisplayName: displayName,
+		Policies:    append([]string(nil), policies...),
+	}
+	return id
+}
+
+func (v *FakeVault) byAccessor(accessor string) (string, *VaultToken) {
+	for id, token := range v.tokens {
+		if token.Accessor == accessor {
+			return id, token
+		}
+	}
+	return "", nil
+}
+
+func metadata(token *VaultToken) secretstoreclient.TokenMetadata {
+	return secretstoreclient.TokenMetadata{
+		Accessor:    token.Accessor,
+		DisplayName: token.DisplayName,
+		Path:        "auth/token/create",
+		Policies:    token.Policies,
+	}
+}
+
+func hasPolicy(policies []string, name string) bool {
+	for _, policy := range policies {
+		if policy == name {
+			return true
+		}
+	}
+	return false
+}
+
+func randomString(length int) string {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	for i := range b {
+		b[i] = tokenChars[int(b[i])%len(tokenChars)]
+	}
+	return string(b)
+}
+
+func decodeVaultRequest(w http.ResponseWriter, r *http.Request, request interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeVaultError(w, http.StatusBadRequest, fmt.Sprintf("failed to parse JSON input: %s", err.Error()))
+		return false
+	}
+	return true
+}
+
+func writeVaultJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeVaultError(w http.ResponseWriter, status int, message string) {
+	writeVaultJSON(w, status, map[string][]string{"errors": {message}})
+}
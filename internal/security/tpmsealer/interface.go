@@ -0,0 +1,19 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package tpmsealer
+
+// TPMSealer is an interface to seal secrets to the state of the platform
+// recorded in the platform configuration registers (PCRs) of a TPM 2.0
+type TPMSealer interface {
+	// Seal seals data to the current values of the PCRs and returns
+	// the sealed blob, which is only usable on the same TPM
+	Seal(data []byte) ([]byte, error)
+	// Unseal returns the data of a blob returned by Seal; it fails
+	// if the values of the PCRs differ from those it was sealed to,
+	// e.g. after the firmware, boot loader or kernel was modified
+	Unseal(sealed []byte) ([]byte, error)
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTPMSealer struct {
+	mock.Mock
+}
+
+func (m *MockTPMSealer) Seal(data []byte) ([]byte, error) {
+	arguments := m.Called(data)
+	return arguments.Get(0).([]byte), arguments.Error(1)
+}
+
+func (m *MockTPMSealer) Unseal(sealed []byte) ([]byte, error) {
+	arguments := m.Called(sealed)
+	return arguments.Get(0).([]byte), arguments.Error(1)
+}
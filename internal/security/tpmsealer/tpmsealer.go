@@ -0,0 +1,196 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// US Export Control Classification Number (ECCN): 5D002TSU
+//
+
+// Package tpmsealer seals secrets to the platform configuration
+// registers (PCRs) of a TPM 2.0, so that they are only unsealed
+// on a platform booted with unmodified firmware and OS
+package tpmsealer
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+)
+
+const (
+	// maxPCR is the highest PCR index of the PC client platforms
+	maxPCR = 23
+	// maxSealedDataLength is the maximum size of a sealed data object
+	maxSealedDataLength = 128
+)
+
+// srkTemplate is the template of the storage root key (SRK) the secrets are
+// sealed under. It is recreated from the owner hierarchy on each use, which
+// yields the same key on the same TPM, so that no key is persisted in the TPM.
+var srkTemplate = tpm2.Public{
+	Type:    tpm2.AlgRSA,
+	NameAlg: tpm2.AlgSHA256,
+	Attributes: tpm2.FlagFixedTPM | tpm2.FlagFixedParent | tpm2.FlagSensitiveDataOrigin |
+		tpm2.FlagUserWithAuth | tpm2.FlagRestricted | tpm2.FlagDecrypt | tpm2.FlagNoDA,
+	RSAParameters: &tpm2.RSAParams{
+		Symmetric: &tpm2.SymScheme{
+			Alg:     tpm2.AlgAES,
+			KeyBits: 128,
+			Mode:    tpm2.AlgCFB,
+		},
+		KeyBits:    2048,
+		ModulusRaw: make([]byte, 256),
+	},
+}
+
+// sealedBlob is the JSON encoding of a sealed data object
+type sealedBlob struct {
+	PCRs    []int  `json:"pcrs"`
+	Public  []byte `json:"public"`
+	Private []byte `json:"private"`
+}
+
+// tpmSealer stores instance data for the TPMSealer
+type tpmSealer struct {
+	device string
+	pcrs   []int
+}
+
+// NewTPMSealer creates a new TPMSealer sealing to the SHA-256 bank of
+// the pcrs of the TPM at device, e.g. /dev/tpmrm0
+func NewTPMSealer(device string, pcrs []int) (TPMSealer, error) {
+	if device == "" {
+		return nil, fmt.Errorf("TPM device is required")
+	}
+	if len(pcrs) == 0 {
+		return nil, fmt.Errorf("at least one PCR is required to seal to")
+	}
+	for _, pcr := range pcrs {
+		if pcr < 0 || pcr > maxPCR {
+			return nil, fmt.Errorf("PCR %d is out of range 0-%d", pcr, maxPCR)
+		}
+	}
+	return &tpmSealer{device: device, pcrs: pcrs}, nil
+}
+
+// Seal see interface.go
+func (s *tpmSealer) Seal(data []byte) ([]byte, error) {
+	if len(data) > maxSealedDataLength {
+		return nil, fmt.Errorf("cannot seal more than %d bytes", maxSealedDataLength)
+	}
+
+	rw, err := tpm2.OpenTPM(s.device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open TPM %s: %w", s.device, err)
+	}
+	defer rw.Close()
+
+	srk, err := createSRK(rw)
+	if err != nil {
+		return nil, err
+	}
+	defer tpm2.FlushContext(rw, srk)
+
+	// A trial session computes the policy digest of the current PCR values
+	session, policy, err := pcrPolicySession(rw, tpm2.SessionTrial, s.pcrs)
+	if err != nil {
+		return nil, err
+	}
+	_ = tpm2.FlushContext(rw, session)
+
+	private, public, err := tpm2.Seal(rw, srk, "", "", policy, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal data: %w", err)
+	}
+
+	return json.Marshal(sealedBlob{PCRs: s.pcrs, Public: public, Private: private})
+}
+
+// Unseal see interface.go
+func (s *tpmSealer) Unseal(sealed []byte) ([]byte, error) {
+	var blob sealedBlob
+	if err := json.Unmarshal(sealed, &blob); err != nil {
+		return nil, fmt.Errorf("failed to decode sealed blob: %w", err)
+	}
+	if len(blob.PCRs) == 0 || len(blob.Public) == 0 || len(blob.Private) == 0 {
+		return nil, fmt.Errorf("sealed blob is incomplete")
+	}
+
+	rw, err := tpm2.OpenTPM(s.device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open TPM %s: %w", s.device, err)
+	}
+	defer rw.Close()
+
+	srk, err := createSRK(rw)
+	if err != nil {
+		return nil, err
+	}
+	defer tpm2.FlushContext(rw, srk)
+
+	object, _, err := tpm2.Load(rw, srk, "", blob.Public, blob.Private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sealed blob: %w", err)
+	}
+	defer tpm2.FlushContext(rw, object)
+
+	// The blob records the PCRs it was sealed to, so that changing the
+	// configured PCRs doesn't prevent unsealing the existing blobs
+	session, _, err := pcrPolicySession(rw, tpm2.SessionPolicy, blob.PCRs)
+	if err != nil {
+		return nil, err
+	}
+	defer tpm2.FlushContext(rw, session)
+
+	data, err := tpm2.UnsealWithSession(rw, session, object, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to unseal data, the PCR values may have changed since it was sealed: %w", err)
+	}
+	return data, nil
+}
+
+//
+// Internal methods
+//
+
+// createSRK creates the storage root key under the owner hierarchy
+func createSRK(rw io.ReadWriter) (tpmutil.Handle, error) {
+	srk, _, err := tpm2.CreatePrimary(rw, tpm2.HandleOwner, tpm2.PCRSelection{}, "", "", srkTemplate)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create storage root key: %w", err)
+	}
+	return srk, nil
+}
+
+// pcrPolicySession starts a session of sessionType satisfying the policy
+// of the current values of the SHA-256 bank of pcrs, and returns it along
+// with the digest of the policy
+func pcrPolicySession(rw io.ReadWriter, sessionType tpm2.SessionType, pcrs []int) (tpmutil.Handle, []byte, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return 0, nil, fmt.Errorf("failed to initialize random nonce: %w", err)
+	}
+
+	session, _, err := tpm2.StartAuthSession(rw, tpm2.HandleNull, tpm2.HandleNull, nonce, nil, sessionType,
+		tpm2.AlgNull, tpm2.AlgSHA256)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to start policy session: %w", err)
+	}
+
+	selection := tpm2.PCRSelection{Hash: tpm2.AlgSHA256, PCRs: pcrs}
+	if err := tpm2.PolicyPCR(rw, session, nil, selection); err != nil {
+		_ = tpm2.FlushContext(rw, session)
+		return 0, nil, fmt.Errorf("failed to set PCR policy: %w", err)
+	}
+
+	policy, err := tpm2.PolicyGetDigest(rw, session)
+	if err != nil {
+		_ = tpm2.FlushContext(rw, session)
+		return 0, nil, fmt.Errorf("failed to get policy digest: %w", err)
+	}
+	return session, policy, nil
+}
@@ -0,0 +1,69 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package tpmsealer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTPMSealer(t *testing.T) {
+	tests := []struct {
+		name        string
+		device      string
+		pcrs        []int
+		expectError bool
+	}{
+		{"valid", "/dev/tpmrm0", []int{0, 2, 4, 7}, false},
+		{"no device", "", []int{7}, true},
+		{"no PCRs", "/dev/tpmrm0", nil, true},
+		{"negative PCR", "/dev/tpmrm0", []int{-1}, true},
+		{"PCR out of range", "/dev/tpmrm0", []int{0, 24}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealer, err := NewTPMSealer(tt.device, tt.pcrs)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, sealer)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, sealer)
+			}
+		})
+	}
+}
+
+func TestSealTooLong(t *testing.T) {
+	sealer, err := NewTPMSealer("/nonexistent/tpm", []int{7})
+	require.NoError(t, err)
+
+	_, err = sealer.Seal(make([]byte, maxSealedDataLength+1))
+	assert.Error(t, err)
+}
+
+func TestMissingDevice(t *testing.T) {
+	sealer, err := NewTPMSealer("/nonexistent/tpm", []int{7})
+	require.NoError(t, err)
+
+	_, err = sealer.Seal(make([]byte, 32))
+	assert.Error(t, err)
+	_, err = sealer.Unseal([]byte(`{"pcrs":[7],"public":"AQI=","private":"AwQ="}`))
+	assert.Error(t, err)
+}
+
+func TestUnsealInvalidBlob(t *testing.T) {
+	sealer, err := NewTPMSealer("/nonexistent/tpm", []int{7})
+	require.NoError(t, err)
+
+	for _, sealed := range []string{"", "not json", `{"pcrs":[7]}`, `{"public":"AQI=","private":"AwQ="}`} {
+		_, err = sealer.Unseal([]byte(sealed))
+		assert.Error(t, err, sealed)
+	}
+}
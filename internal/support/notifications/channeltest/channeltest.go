@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package channeltest sends test messages through a channel configuration, recording each step of the transport, so
+// that the SMTP settings and the webhooks of the subscriptions can be checked before a notification is missed.
+package channeltest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+const (
+	// ApiChannelTestRoute sends a test message through a channel configuration
+	ApiChannelTestRoute = v2.ApiBase + "/notification/channel/test"
+
+	// DefaultTimeout bounds a test when the request sets no deadline
+	DefaultTimeout = 10 * time.Second
+	// DefaultContent is the content of the test messages when the request doesn't set one
+	DefaultContent = "Test message of the EdgeX support-notifications channel configuration"
+	// maxResponseExcerpt is the number of bytes of the response of a webhook reported
+	maxResponseExcerpt = 512
+)
+
+// TestChannelRequest defines the request content of ApiChannelTestRoute
+type TestChannelRequest struct {
+	commonDTO.BaseRequest `json:",inline"`
+	Channel               dtos.Channel `json:"channel" validate:"required"`
+	// Subject is the subject of the test email, the configured Smtp.Subject when empty
+	Subject     string `json:"subject,omitempty"`
+	Content     string `json:"content,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// TestChannelResponse defines the response content of ApiChannelTestRoute. The diagnostics report a failed
+// transmission with a 200 status code, the test having been run.
+type TestChannelResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	Diagnostics            Diagnostics `json:"diagnostics"`
+}
+
+// Step is one step of the transport of a test message, e.g. the connection to the server or the authentication
+type Step struct {
+	Name string `json:"name"`
+	// Detail describes the outcome of the step, e.g. the TLS version negotiated or the status of the response
+	Detail   string `json:"detail,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Diagnostics reports the steps of the transport of a test message, up to the first failed one
+type Diagnostics struct {
+	Type string `json:"type"`
+	// Target is the address of the SMTP server, or the URL of the webhook
+	Target    string `json:"target"`
+	Succeeded bool   `json:"succeeded"`
+	Steps     []Step `json:"steps"`
+	Duration  string `json:"duration"`
+}
+
+// recorder records the steps of a test, which may be traced concurrently
+type recorder struct {
+	mutex       sync.Mutex
+	diagnostics Diagnostics
+	start       time.Time
+	started     map[string]time.Time
+	now         func() time.Time
+}
+
+func newRecorder(channelType string, target string) *recorder {
+	r := &recorder{
+		diagnostics: Diagnostics{Type: channelType, Target: target, Steps: []Step{}},
+		started:     make(map[string]time.Time),
+		now:         time.Now,
+	}
+	r.start = r.now()
+	return r
+}
+
+// step runs f as the step named name, recording its detail or its error, and returns whether it succeeded
+func (r *recorder) step(name string, f func() (string, error)) bool {
+	r.begin(name)
+	detail, err := f()
+	r.end(name, name, detail, err)
+	return err == nil
+}
+
+// begin records the start of the step identified by key
+func (r *recorder) begin(key string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.started[key] = r.now()
+}
+
+// end records the step named name, whose start was recorded under key, with its detail or its error. A step whose
+// start wasn't recorded has a zero duration.
+func (r *recorder) end(name string, key string, detail string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	s := Step{Name: name, Detail: detail, Duration: "0s"}
+	if start, ok := r.started[key]; ok {
+		s.Duration = r.now().Sub(start).String()
+	}
+	if err != nil {
+		s.Detail = ""
+		s.Error = err.Error()
+	}
+	r.diagnostics.Steps = append(r.diagnostics.Steps, s)
+}
+
+// lastFailed tells whether the last recorded step failed
+func (r *recorder) lastFailed() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	steps := r.diagnostics.Steps
+	return len(steps) > 0 && steps[len(steps)-1].Error != ""
+}
+
+// finish returns the diagnostics, which succeeded when all the steps did
+func (r *recorder) finish(succeeded bool) Diagnostics {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.diagnostics.Succeeded = succeeded
+	r.diagnostics.Duration = r.now().Sub(r.start).String()
+	return r.diagnostics
+}
@@ -0,0 +1,240 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package channeltest
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/webhook"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSmtpServer serves one SMTP session on a local port, accepting the credentials username and password, or the
+// access token password of XOAUTH2, and returns the address of the server and the channel receiving the message
+func fakeSmtpServer(t *testing.T, username string, password string) (string, int, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	messages := make(chan string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		credentials := base64.StdEncoding.EncodeToString([]byte("\x00" + username + "\x00" + password))
+		bearer := base64.StdEncoding.EncodeToString([]byte("user=" + username + "\x01auth=Bearer " + password + "\x01\x01"))
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250-localhost")
+				reply("250 AUTH PLAIN XOAUTH2")
+			case strings.HasPrefix(line, "AUTH PLAIN"):
+				if strings.TrimPrefix(line, "AUTH PLAIN ") == credentials {
+					reply("235 2.7.0 Authentication successful")
+				} else {
+					reply("535 5.7.8 Authentication credentials invalid")
+				}
+			case strings.HasPrefix(line, "AUTH XOAUTH2"):
+				if strings.TrimPrefix(line, "AUTH XOAUTH2 ") == bearer {
+					reply("235 2.7.0 Accepted")
+				} else {
+					reply("535 5.7.8 Username and Password not accepted")
+				}
+			case strings.HasPrefix(line, "MAIL"), strings.HasPrefix(line, "RCPT"):
+				reply("250 OK")
+			case line == "DATA":
+				reply("354 Go ahead")
+				var message strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil || dataLine == ".\r\n" {
+						break
+					}
+					message.WriteString(dataLine)
+				}
+				messages <- message.String()
+				reply("250 OK queued")
+			case line == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return host, portNumber, messages
+}
+
+func stepNames(d Diagnostics) []string {
+	names := make([]string, len(d.Steps))
+	for i, s := range d.Steps {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func TestSmtp(t *testing.T) {
+	host, port, messages := fakeSmtpServer(t, "edgex", "secret")
+	s := notificationsConfig.SmtpInfo{Host: host, Port: port, Username: "edgex", Password: "secret", Sender: "edgex@example.com"}
+
+	d := Smtp(context.Background(), s, nil, []string{"ops@example.com"}, "Test", "", "Hello")
+
+	assert.True(t, d.Succeeded, "%+v", d)
+	assert.Equal(t, models.Email, d.Type)
+	assert.Equal(t, []string{"connect", "greeting", "hello", "auth", "mail", "rcpt", "data", "quit"}, stepNames(d))
+	message := <-messages
+	assert.Contains(t, message, "Subject: Test\r\n")
+	assert.Contains(t, message, "To: ops@example.com\r\n")
+	assert.Contains(t, message, "\r\n\r\nHello\r\n")
+}
+
+func TestSmtp_InvalidCredentials(t *testing.T) {
+	host, port, _ := fakeSmtpServer(t, "edgex", "secret")
+	s := notificationsConfig.SmtpInfo{Host: host, Port: port, Username: "edgex", Password: "wrong", Sender: "edgex@example.com"}
+
+	d := Smtp(context.Background(), s, nil, []string{"ops@example.com"}, "Test", "", "Hello")
+
+	assert.False(t, d.Succeeded)
+	require.Equal(t, []string{"connect", "greeting", "hello", "auth"}, stepNames(d))
+	assert.Contains(t, d.Steps[3].Error, "535")
+	for _, step := range d.Steps {
+		assert.NotContains(t, step.Detail+step.Error, "wrong", "the password should not be reported")
+	}
+}
+
+// secretStore holds the secret of the SMTP credentials or of the webhook signing
+type secretStore map[string]string
+
+func (s secretStore) GetSecrets(string, ...string) (map[string]string, error) {
+	return s, nil
+}
+
+func (s secretStore) StoreSecrets(string, map[string]string) error {
+	return nil
+}
+
+func TestSmtp_XOAuth2(t *testing.T) {
+	host, port, _ := fakeSmtpServer(t, "edgex", "access-token")
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"access-token","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+	s := notificationsConfig.SmtpInfo{Host: host, Port: port, Username: "edgex", Sender: "edgex@example.com",
+		Authentication: smtpauth.AuthInfo{Mechanism: smtpauth.XOAuth2, SecretPath: "smtp", OAuth2: smtpauth.OAuth2Info{TokenURL: tokenServer.URL}}}
+	authenticator := smtpauth.NewAuthenticator(logger.NewMockClient(), secretStore{smtpauth.RefreshTokenKey: "refresh-token"}, tokenServer.Client())
+
+	d := Smtp(context.Background(), s, authenticator, []string{"ops@example.com"}, "Test", "", "Hello")
+
+	assert.True(t, d.Succeeded, "%+v", d)
+	require.Len(t, d.Steps, 8)
+	assert.Equal(t, "authenticated as edgex with XOAUTH2, the server offers PLAIN XOAUTH2", d.Steps[3].Detail)
+	for _, step := range d.Steps {
+		assert.NotContains(t, step.Detail+step.Error, "token", "the tokens should not be reported")
+	}
+}
+
+func TestSmtp_Unreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().(*net.TCPAddr)
+	_ = listener.Close()
+
+	d := Smtp(context.Background(), notificationsConfig.SmtpInfo{Host: "127.0.0.1", Port: addr.Port}, nil, nil, "", "", "")
+
+	assert.False(t, d.Succeeded)
+	require.Len(t, d.Steps, 1)
+	assert.Equal(t, "connect", d.Steps[0].Name)
+	assert.NotEmpty(t, d.Steps[0].Error)
+}
+
+func TestRest(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("invalid token"))
+		}
+	}))
+	defer server.Close()
+
+	d := Rest(context.Background(), server.URL+"/hook", "", "Hello", nil)
+	assert.True(t, d.Succeeded, "%+v", d)
+	assert.Equal(t, models.Rest, d.Type)
+	assert.Equal(t, []string{"request", "connect", "response"}, stepNames(d))
+	assert.Equal(t, "status 200 OK", d.Steps[2].Detail)
+	assert.Equal(t, "Hello", received)
+
+	d = Rest(context.Background(), server.URL+"/fail", "", "Hello", nil)
+	assert.False(t, d.Succeeded)
+	require.Len(t, d.Steps, 3)
+	assert.Contains(t, d.Steps[2].Error, "401")
+	assert.Contains(t, d.Steps[2].Error, "invalid token")
+
+	server.Close()
+	d = Rest(context.Background(), server.URL+"/hook", "", "Hello", nil)
+	assert.False(t, d.Succeeded)
+	require.Len(t, d.Steps, 2)
+	assert.Equal(t, "connect", d.Steps[1].Name)
+	assert.NotEmpty(t, d.Steps[1].Error)
+}
+
+func TestRestSigned(t *testing.T) {
+	var verifyErr error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		verifyErr = webhook.Verify([]byte("shared"), r.Header.Get(webhook.TimestampHeader), r.Header.Get(webhook.SignatureHeader),
+			body, time.Now(), webhook.DefaultReplayWindow)
+	}))
+	defer server.Close()
+
+	signer := webhook.NewSigner(secretStore{webhook.SecretKey: "shared"}, webhook.WebhookSigningInfo{Enabled: true, SecretPath: "webhook"})
+	d := Rest(context.Background(), server.URL+"/hook", "", "Hello", signer)
+	assert.True(t, d.Succeeded, "%+v", d)
+	assert.Contains(t, d.Steps[0].Detail, "signed")
+	assert.NoError(t, verifyErr)
+
+	// the test fails before sending the message when it can't be signed
+	signer = webhook.NewSigner(secretStore{}, webhook.WebhookSigningInfo{Enabled: true, SecretPath: "webhook"})
+	d = Rest(context.Background(), server.URL+"/hook", "", "Hello", signer)
+	assert.False(t, d.Succeeded)
+	require.Len(t, d.Steps, 1)
+	assert.Equal(t, "request", d.Steps[0].Name)
+	assert.NotEmpty(t, d.Steps[0].Error)
+}
@@ -0,0 +1,117 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package channeltest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/webhook"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// Rest posts a test message to the webhook at url, the way the notifications are sent but without retry, and reports
+// the resolution of its host, the connection, the TLS handshake and the response. The message is signed by signer,
+// unless nil. The test succeeds when the webhook responds with a 2xx status code.
+func Rest(ctx context.Context, url string, contentType string, content string, signer *webhook.Signer) Diagnostics {
+	r := newRecorder(models.Rest, url)
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+
+	var req *http.Request
+	if !r.step("request", func() (string, error) {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(content))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", contentType)
+		correlation.Propagate(ctx, req)
+		if signer != nil {
+			if err := signer.Sign(req); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("POST %d bytes of %s, signed", len(content), contentType), nil
+		}
+		return fmt.Sprintf("POST %d bytes of %s", len(content), contentType), nil
+	}) {
+		return r.finish(false)
+	}
+
+	// the steps of the transport are recorded as they are traced, up to the one the request failed at
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			r.begin("dns")
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			r.end("dns", "dns", describeAddresses(req.URL.Hostname(), info), info.Err)
+		},
+		ConnectStart: func(_, addr string) {
+			r.begin("connect " + addr)
+		},
+		ConnectDone: func(_, addr string, err error) {
+			r.end("connect", "connect "+addr, "connected to "+addr, err)
+		},
+		TLSHandshakeStart: func() {
+			r.begin("tls")
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			r.end("tls", "tls", describeTLS(state, false), err)
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			r.begin("response")
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(ctx, trace))
+
+	// a transport of its own so that the connection is traced rather than reused from the pool
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, DisableKeepAlives: true}}
+	rs, err := client.Do(req)
+	if err != nil {
+		// the error is reported by the failed step of the transport, if any
+		if !r.lastFailed() {
+			r.end("response", "response", "", err)
+		}
+		return r.finish(false)
+	}
+	defer func() { _ = rs.Body.Close() }()
+
+	excerpt, _ := ioutil.ReadAll(io.LimitReader(rs.Body, maxResponseExcerpt))
+	detail := "status " + rs.Status
+	if body := strings.TrimSpace(string(excerpt)); body != "" {
+		detail += ", body: " + body
+	}
+	var statusErr error
+	if rs.StatusCode < 200 || rs.StatusCode >= 300 {
+		statusErr = fmt.Errorf("the webhook responded with %s", detail)
+	}
+	r.end("response", "response", detail, statusErr)
+	return r.finish(statusErr == nil)
+}
+
+// describeAddresses describes the addresses host resolved to
+func describeAddresses(host string, info httptrace.DNSDoneInfo) string {
+	addresses := make([]string, len(info.Addrs))
+	for i, addr := range info.Addrs {
+		addresses[i] = addr.String()
+	}
+	return fmt.Sprintf("%s resolved to %s", host, strings.Join(addresses, ", "))
+}
@@ -0,0 +1,219 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package channeltest
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	mail "net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// Smtp sends a test email to the addresses to through the SMTP server of s, authenticating with smtpAuth the way the
+// notifications are sent, and reports the steps of the SMTP exchange. The password and the tokens are never reported.
+func Smtp(
+	ctx context.Context,
+	s notificationsConfig.SmtpInfo,
+	smtpAuth *smtpauth.Authenticator,
+	to []string,
+	subject string,
+	contentType string,
+	content string) Diagnostics {
+
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	r := newRecorder(models.Email, addr)
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(DefaultTimeout)
+	}
+
+	var conn net.Conn
+	if !r.step("connect", func() (string, error) {
+		var err error
+		dialer := &net.Dialer{Deadline: deadline}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return "", err
+		}
+		return "connected to " + conn.RemoteAddr().String(), conn.SetDeadline(deadline)
+	}) {
+		return r.finish(false)
+	}
+	defer func() { _ = conn.Close() }()
+
+	var c *mail.Client
+	if !r.step("greeting", func() (string, error) {
+		var err error
+		c, err = mail.NewClient(conn, s.Host)
+		return "", err
+	}) {
+		return r.finish(false)
+	}
+	defer func() { _ = c.Close() }()
+
+	if !r.step("hello", func() (string, error) {
+		if err := c.Hello(addr); err != nil {
+			return "", err
+		}
+		return "extensions: " + strings.Join(extensions(c), ", "), nil
+	}) {
+		return r.finish(false)
+	}
+
+	tlsUsed := false
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if !r.step("starttls", func() (string, error) {
+			if err := c.StartTLS(&tls.Config{ServerName: s.Host, InsecureSkipVerify: s.EnableSelfSignedCert}); err != nil {
+				return "", err
+			}
+			tlsUsed = true
+			state, _ := c.TLSConnectionState()
+			return describeTLS(state, s.EnableSelfSignedCert), nil
+		}) {
+			return r.finish(false)
+		}
+	}
+
+	if !r.step("auth", func() (string, error) {
+		auth, username, err := smtpAuth.Auth(s.Authentication, s.Host, s.CheckUsername(), s.Password)
+		if err != nil {
+			return "", err
+		}
+		if auth == nil {
+			return "no password configured, not authenticating", nil
+		}
+		ok, mechanisms := c.Extension("AUTH")
+		if !ok {
+			return "", errors.New("the server doesn't support AUTH")
+		}
+		mechanism := s.Authentication.Mechanism
+		if mechanism == "" {
+			mechanism = smtpauth.Plain
+		}
+		if !contains(strings.Fields(mechanisms), mechanism) {
+			return "", fmt.Errorf("the server doesn't offer %s, only %s", mechanism, mechanisms)
+		}
+		if err := c.Auth(auth); err != nil {
+			if !tlsUsed {
+				return "", fmt.Errorf("%s (without TLS)", err.Error())
+			}
+			return "", err
+		}
+		return fmt.Sprintf("authenticated as %s with %s, the server offers %s", username, mechanism, mechanisms), nil
+	}) {
+		return r.finish(false)
+	}
+
+	if !r.step("mail", func() (string, error) {
+		return "from " + s.Sender, c.Mail(s.Sender)
+	}) {
+		return r.finish(false)
+	}
+	for _, address := range to {
+		address := address
+		if !r.step("rcpt", func() (string, error) {
+			return "to " + address, c.Rcpt(address)
+		}) {
+			return r.finish(false)
+		}
+	}
+
+	if !r.step("data", func() (string, error) {
+		w, err := c.Data()
+		if err != nil {
+			return "", err
+		}
+		message := buildMessage(s.Sender, subject, to, contentType, content)
+		if _, err = w.Write(message); err != nil {
+			return "", err
+		}
+		if err = w.Close(); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d bytes accepted", len(message)), nil
+	}) {
+		return r.finish(false)
+	}
+
+	return r.finish(r.step("quit", func() (string, error) {
+		return "", c.Quit()
+	}))
+}
+
+// extensions returns the SMTP extensions the server announced
+func extensions(c *mail.Client) []string {
+	var names []string
+	for _, name := range []string{"STARTTLS", "AUTH", "SIZE", "8BITMIME", "PIPELINING", "SMTPUTF8"} {
+		if ok, _ := c.Extension(name); ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return []string{"none"}
+	}
+	return names
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeTLS describes the TLS version, the cipher suite and the certificate of the server of state
+func describeTLS(state tls.ConnectionState, selfSignedAllowed bool) string {
+	detail := fmt.Sprintf("%s, %s", tlsVersion(state.Version), tls.CipherSuiteName(state.CipherSuite))
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		detail += fmt.Sprintf(", certificate of %s issued by %s expiring %s", cert.Subject.CommonName,
+			cert.Issuer.CommonName, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if selfSignedAllowed {
+		detail += ", certificate not verified"
+	}
+	return detail
+}
+
+func tlsVersion(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	}
+	return fmt.Sprintf("TLS 0x%04x", version)
+}
+
+// buildMessage builds the RFC 822 message of the test email
+func buildMessage(sender string, subject string, to []string, contentType string, content string) []byte {
+	var b strings.Builder
+	b.WriteString("Subject: " + subject + "\r\n")
+	b.WriteString("From: " + sender + "\r\n")
+	b.WriteString("To: " + strings.Join(to, ",") + "\r\n")
+	if contentType != "" {
+		b.WriteString(fmt.Sprintf("MIME-version: 1.0;\r\nContent-Type: %s; charset=\"UTF-8\";\r\n", contentType))
+	}
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\n", "\r\n") + "\r\n")
+	return []byte(b.String())
+}
@@ -16,22 +16,53 @@
 package config
 
 import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/registrywatch"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/webhook"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/systemevents"
+
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 )
 
 type ConfigurationStruct struct {
-	Writable    WritableInfo
-	Clients     map[string]bootstrapConfig.ClientInfo
-	Databases   map[string]bootstrapConfig.Database
-	Registry    bootstrapConfig.RegistryInfo
-	Service     bootstrapConfig.ServiceInfo
-	Smtp        SmtpInfo
-	SecretStore bootstrapConfig.SecretStoreInfo
+	Writable       WritableInfo
+	Clients        map[string]bootstrapConfig.ClientInfo
+	Databases      map[string]bootstrapConfig.Database
+	Registry       bootstrapConfig.RegistryInfo
+	Service        bootstrapConfig.ServiceInfo
+	Smtp           SmtpInfo
+	SecretStore    bootstrapConfig.SecretStoreInfo
+	JWTAuth        jwtauth.JWTAuthInfo
+	Audit          audit.AuditInfo
+	Mutes          MutesInfo
+	SystemEvents   systemevents.SystemEventsInfo
+	MessageQueue   MessageQueueInfo
+	OutboundHTTP   httpclient.OutboundHTTPInfo
+	WebhookSigning webhook.WebhookSigningInfo
+	SecretCache    secretcache.SecretCacheInfo
+	RegistryWatch  registrywatch.RegistryWatchInfo
+	Delivery       delivery.DeliveryInfo
+	Jobs           jobs.JobsInfo
 }
 
 type WritableInfo struct {
 	ResendLimit     int
 	LogLevel        string
+	ShutdownTimeout string
+	RequestLimits   requestlimits.RequestLimitsInfo
+	ProblemDetails  problem.ProblemDetailsInfo
+	CORS            cors.CORSInfo
 	InsecureSecrets bootstrapConfig.InsecureSecrets
 }
 
@@ -43,6 +74,36 @@ type SmtpInfo struct {
 	Sender               string
 	EnableSelfSignedCert bool
 	Subject              string
+	// Authentication selects the PLAIN or XOAUTH2 authentication, and the secret of the credentials overriding
+	// Username and Password
+	Authentication smtpauth.AuthInfo
+}
+
+// MutesInfo configures the release of the notifications queued by the mute windows
+type MutesInfo struct {
+	// ReleaseInterval is how often the queued notifications are checked, and sent once no mute window mutes them
+	ReleaseInterval string
+}
+
+// MessageQueueInfo provides parameters related to connecting to the message bus the system events are received from
+type MessageQueueInfo struct {
+	// Host is the hostname or IP address of the broker, if applicable.
+	Host string
+	// Port defines the port on which to access the message queue.
+	Port int
+	// Protocol indicates the protocol to use when accessing the message queue.
+	Protocol string
+	// Indicates the message queue platform being used.
+	Type string
+	// Provides additional configuration properties which do not fit within the existing field.
+	// Typically the key is the name of the configuration property and the value is a string representation of the
+	// desired value for the configuration property.
+	Optional map[string]string
+}
+
+// URL constructs a URL from the protocol, host and port and returns that as a string.
+func (m MessageQueueInfo) URL() string {
+	return fmt.Sprintf("%s://%s:%v", m.Protocol, m.Host, m.Port)
 }
 
 // The earlier releases do not have Username field and are using Sender field where Usename will
@@ -114,6 +175,36 @@ func (c *ConfigurationStruct) GetDatabaseInfo() map[string]bootstrapConfig.Datab
 	return c.Databases
 }
 
+// GetRequestLimits returns the limits applied to incoming requests.
+func (c *ConfigurationStruct) GetRequestLimits() requestlimits.RequestLimitsInfo {
+	return c.Writable.RequestLimits
+}
+
+// GetSecretCache returns the configuration of the cache of the secrets read from the secret store.
+func (c *ConfigurationStruct) GetSecretCache() secretcache.SecretCacheInfo {
+	return c.SecretCache
+}
+
+// GetRegistryWatch returns the configuration of the watch of the registration of the service with the registry.
+func (c *ConfigurationStruct) GetRegistryWatch() registrywatch.RegistryWatchInfo {
+	return c.RegistryWatch
+}
+
+// GetProblemDetails returns whether the failed requests are responded to with problem details.
+func (c *ConfigurationStruct) GetProblemDetails() problem.ProblemDetailsInfo {
+	return c.Writable.ProblemDetails
+}
+
+// GetCORS returns the CORS policy applied to incoming requests.
+func (c *ConfigurationStruct) GetCORS() cors.CORSInfo {
+	return c.Writable.CORS
+}
+
+// GetShutdownTimeout returns how long in-flight requests are given to complete when the service is stopped.
+func (c *ConfigurationStruct) GetShutdownTimeout() string {
+	return c.Writable.ShutdownTimeout
+}
+
 // GetInsecureSecrets returns the service's InsecureSecrets.
 func (c *ConfigurationStruct) GetInsecureSecrets() bootstrapConfig.InsecureSecrets {
 	return c.Writable.InsecureSecrets
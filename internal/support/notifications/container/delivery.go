@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// DeliveryPoolName contains the name of the delivery.Pool instance in the DIC.
+var DeliveryPoolName = di.TypeInstanceToName(delivery.Pool{})
+
+// DeliveryPoolFrom helper function queries the DIC and returns the delivery.Pool instance, or nil if none is
+// registered, the notifications then being delivered as they are sent.
+func DeliveryPoolFrom(get di.Get) *delivery.Pool {
+	pool, ok := get(DeliveryPoolName).(*delivery.Pool)
+	if !ok {
+		return nil
+	}
+	return pool
+}
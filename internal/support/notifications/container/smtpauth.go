@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// SmtpAuthenticatorName contains the name of the smtpauth.Authenticator instance in the DIC.
+var SmtpAuthenticatorName = di.TypeInstanceToName(smtpauth.Authenticator{})
+
+// SmtpAuthenticatorFrom helper function queries the DIC and returns the smtpauth.Authenticator instance, or nil if
+// none is registered, authenticating with the configured credentials only.
+func SmtpAuthenticatorFrom(get di.Get) *smtpauth.Authenticator {
+	authenticator, ok := get(SmtpAuthenticatorName).(*smtpauth.Authenticator)
+	if !ok {
+		return nil
+	}
+	return authenticator
+}
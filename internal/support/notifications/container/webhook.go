@@ -0,0 +1,33 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal"
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/webhook"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// WebhookSignerName contains the name of the webhook.Signer instance in the DIC.
+var WebhookSignerName = di.TypeInstanceToName(webhook.Signer{})
+
+// WebhookSignerFrom helper function queries the DIC and returns the webhook.Signer instance, or nil if none is
+// registered, the notifications sent to the REST channels not being signed.
+func WebhookSignerFrom(get di.Get) *webhook.Signer {
+	signer, ok := get(WebhookSignerName).(*webhook.Signer)
+	if !ok {
+		return nil
+	}
+	return signer
+}
+
+// RestClientFrom helper function returns the client of the notifications sent to the REST channels: the outbound
+// HTTP client of the DIC, signing the notifications when a webhook.Signer is registered.
+func RestClientFrom(get di.Get) internal.HttpCaller {
+	return WebhookSignerFrom(get).Caller(pkgContainer.HTTPClientFrom(get))
+}
@@ -0,0 +1,197 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package delivery delivers the notifications through their channels with a bounded pool of workers. Each channel
+// type has its own queue, workers and rate limit, so that a slow SMTP server delays the emails only, while the
+// notifications sent to the REST channels keep flowing.
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// The defaults of DeliveryInfo
+const (
+	DefaultQueueSize    = 1000
+	DefaultEmailWorkers = 5
+	DefaultRESTWorkers  = 50
+)
+
+// ErrQueueFull is returned when a delivery is submitted while the queue of its channel type is full
+var ErrQueueFull = errors.New("the delivery queue is full")
+
+// ChannelLimits limits the deliveries through the channels of a type
+type ChannelLimits struct {
+	// Workers is the number of deliveries running at once
+	Workers int
+	// RatePerSecond is the number of deliveries started per second at most, unlimited when 0
+	RatePerSecond float64
+}
+
+// DeliveryInfo configures the pool of the workers delivering the notifications
+type DeliveryInfo struct {
+	// QueueSize is the number of deliveries waiting for a worker of each channel type, DefaultQueueSize when 0. A
+	// delivery submitted to a full queue fails, and is resent as any failed transmission of a critical notification.
+	QueueSize int
+	// Email limits the deliveries to the SMTP server, DefaultEmailWorkers at once when Workers is 0
+	Email ChannelLimits
+	// REST limits the deliveries to the REST endpoints, DefaultRESTWorkers at once when Workers is 0
+	REST ChannelLimits
+}
+
+// Validate checks that the sizes and rates aren't negative
+func (info DeliveryInfo) Validate() error {
+	if info.QueueSize < 0 {
+		return fmt.Errorf("QueueSize %d must not be negative", info.QueueSize)
+	}
+	for name, limits := range map[string]ChannelLimits{"Email": info.Email, "REST": info.REST} {
+		if limits.Workers < 0 {
+			return fmt.Errorf("%s.Workers %d must not be negative", name, limits.Workers)
+		}
+		if limits.RatePerSecond < 0 {
+			return fmt.Errorf("%s.RatePerSecond %v must not be negative", name, limits.RatePerSecond)
+		}
+	}
+	return nil
+}
+
+// Pool runs the deliveries submitted, once started. A nil Pool runs them as they are submitted.
+type Pool struct {
+	email *lane
+	rest  *lane
+	lc    logger.LoggingClient
+}
+
+// NewPool creates the pool of the workers configured by info
+func NewPool(info DeliveryInfo, lc logger.LoggingClient) (*Pool, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	queueSize := info.QueueSize
+	if queueSize == 0 {
+		queueSize = DefaultQueueSize
+	}
+	return &Pool{
+		email: newLane(models.Email, info.Email, DefaultEmailWorkers, queueSize),
+		rest:  newLane(models.Rest, info.REST, DefaultRESTWorkers, queueSize),
+		lc:    lc,
+	}, nil
+}
+
+// Start starts the workers, which stop when ctx is done. The deliveries still queued then are dropped.
+func (p *Pool) Start(ctx context.Context, wg *sync.WaitGroup) {
+	for _, l := range []*lane{p.email, p.rest} {
+		p.lc.Info(fmt.Sprintf("Starting %d workers delivering the %s notifications", l.workers, l.channelType))
+		for i := 0; i < l.workers; i++ {
+			wg.Add(1)
+			go func(l *lane) {
+				defer wg.Done()
+				l.work(ctx)
+			}(l)
+		}
+	}
+}
+
+// Submit queues deliver for a worker of channelType, the REST workers delivering to the channels of the unknown types
+// as the notifications are. It returns ErrQueueFull, without running deliver, when the queue is full.
+func (p *Pool) Submit(channelType models.ChannelType, deliver func()) error {
+	if p == nil {
+		deliver()
+		return nil
+	}
+	l := p.rest
+	if channelType == models.ChannelType(models.Email) {
+		l = p.email
+	}
+	select {
+	case l.queue <- deliver:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// lane is the queue, workers and rate limit of a channel type
+type lane struct {
+	channelType string
+	workers     int
+	queue       chan func()
+	limiter     *limiter
+}
+
+func newLane(channelType string, limits ChannelLimits, defaultWorkers int, queueSize int) *lane {
+	workers := limits.Workers
+	if workers == 0 {
+		workers = defaultWorkers
+	}
+	return &lane{
+		channelType: channelType,
+		workers:     workers,
+		queue:       make(chan func(), queueSize),
+		limiter:     newLimiter(limits.RatePerSecond),
+	}
+}
+
+func (l *lane) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case deliver := <-l.queue:
+			if !l.limiter.wait(ctx) {
+				return
+			}
+			deliver()
+		}
+	}
+}
+
+// limiter spaces the starts of the deliveries evenly, to rate per second. A nil limiter doesn't wait.
+type limiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newLimiter(rate float64) *limiter {
+	if rate == 0 {
+		return nil
+	}
+	return &limiter{interval: time.Duration(float64(time.Second) / rate)}
+}
+
+// wait waits for the next start, and returns false when ctx is done before
+func (l *limiter) wait(ctx context.Context) bool {
+	if l == nil {
+		return true
+	}
+	l.mutex.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mutex.Unlock()
+
+	if delay == 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
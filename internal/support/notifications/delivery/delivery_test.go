@@ -0,0 +1,131 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package delivery
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startPool(t *testing.T, info DeliveryInfo) (*Pool, func()) {
+	pool, err := NewPool(info, logger.NewMockClient())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	pool.Start(ctx, &wg)
+	return pool, func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func TestDeliveryInfoValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		info  DeliveryInfo
+		valid bool
+	}{
+		{"Valid - defaults", DeliveryInfo{}, true},
+		{"Valid", DeliveryInfo{QueueSize: 10, Email: ChannelLimits{Workers: 5, RatePerSecond: 0.5}, REST: ChannelLimits{Workers: 50}}, true},
+		{"Invalid - queue size", DeliveryInfo{QueueSize: -1}, false},
+		{"Invalid - workers", DeliveryInfo{Email: ChannelLimits{Workers: -1}}, false},
+		{"Invalid - rate", DeliveryInfo{REST: ChannelLimits{RatePerSecond: -1}}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.info.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNilPool(t *testing.T) {
+	var pool *Pool
+	delivered := false
+	require.NoError(t, pool.Submit(models.ChannelType(models.Email), func() { delivered = true }))
+	assert.True(t, delivered, "a nil pool should deliver at once")
+}
+
+func TestPoolConcurrency(t *testing.T) {
+	pool, stop := startPool(t, DeliveryInfo{Email: ChannelLimits{Workers: 2}, REST: ChannelLimits{Workers: 3}})
+	defer stop()
+
+	// the emails are blocked by a slow SMTP server
+	release := make(chan struct{})
+	var running, maxRunning int32
+	for i := 0; i < 5; i++ {
+		require.NoError(t, pool.Submit(models.ChannelType(models.Email), func() {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt32(&running, -1)
+		}))
+	}
+
+	var delivered sync.WaitGroup
+	delivered.Add(10)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, pool.Submit(models.ChannelType(models.Rest), delivered.Done))
+	}
+	done := make(chan struct{})
+	go func() {
+		delivered.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the REST deliveries should not wait for the emails")
+	}
+
+	close(release)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&running) == 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning), "the emails should be delivered by 2 workers at most")
+}
+
+func TestPoolQueueFull(t *testing.T) {
+	// the pool isn't started, so its queues fill up
+	pool, err := NewPool(DeliveryInfo{QueueSize: 2}, logger.NewMockClient())
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, pool.Submit(models.ChannelType(models.Email), func() {}))
+	}
+	assert.Equal(t, ErrQueueFull, pool.Submit(models.ChannelType(models.Email), func() {}))
+	assert.NoError(t, pool.Submit(models.ChannelType(models.Rest), func() {}), "the REST queue should not be full")
+}
+
+func TestPoolRate(t *testing.T) {
+	pool, stop := startPool(t, DeliveryInfo{REST: ChannelLimits{Workers: 10, RatePerSecond: 20}})
+	defer stop()
+
+	var delivered sync.WaitGroup
+	delivered.Add(5)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, pool.Submit(models.ChannelType(models.Rest), delivered.Done))
+	}
+	delivered.Wait()
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(200*time.Millisecond), "5 deliveries at 20 per second should take 200ms")
+}
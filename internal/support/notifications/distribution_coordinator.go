@@ -16,17 +16,28 @@
 package notifications
 
 import (
+	"context"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
 	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 )
 
 func distribute(
+	ctx context.Context,
 	n models.Notification,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
 	config notificationsConfig.ConfigurationStruct) error {
 
 	lc.Debug("DistributionCoordinator start distributing notification: " + n.Slug)
@@ -38,7 +49,7 @@ func distribute(
 		return err
 	}
 	for _, sub := range subs {
-		send(n, sub, lc, dbClient, config)
+		send(ctx, n, sub, lc, dbClient, client, pool, smtpAuth, templateSource, config)
 	}
 	return nil
 }
@@ -47,21 +58,31 @@ func resend(
 	t models.Transmission,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
 	config notificationsConfig.ConfigurationStruct) {
 
 	lc.Debug("Resending transmission: " + t.ID + " for: " + t.Notification.Slug)
-	resendViaChannel(t, lc, dbClient, config)
+	resendViaChannel(t, lc, dbClient, client, pool, smtpAuth, templateSource, config)
 }
 
 func send(
+	ctx context.Context,
 	n models.Notification,
 	s models.Subscription,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
 	config notificationsConfig.ConfigurationStruct) {
 
+	locale := subscriptionLocale(s.Slug, lc, templateSource)
 	for _, ch := range s.Channels {
-		sendViaChannel(n, ch, s.Receiver, lc, dbClient, config)
+		sendViaChannel(ctx, n, ch, s.Receiver, locale, lc, dbClient, client, pool, smtpAuth, templateSource, config)
 	}
 }
 
@@ -69,8 +90,12 @@ func criticalSeverityResend(
 	t models.Transmission,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
 	config notificationsConfig.ConfigurationStruct) {
 
 	lc.Info("Critical severity resend scheduler is triggered.")
-	resend(t, lc, dbClient, config)
+	resend(t, lc, dbClient, client, pool, smtpAuth, templateSource, config)
 }
@@ -16,8 +16,15 @@
 package notifications
 
 import (
+	"context"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
 	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
@@ -27,6 +34,10 @@ func escalate(
 	t models.Transmission,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
 	config notificationsConfig.ConfigurationStruct) {
 
 	lc.Warn("Escalating transmission: " + t.ID + ", for: " + t.Notification.Slug)
@@ -44,7 +55,8 @@ func escalate(
 		return
 	}
 
-	send(n, s, lc, dbClient, config)
+	// The escalation follows a failed transmission, not a request, so it starts a new correlation id
+	send(correlation.NewContext(context.Background()), n, s, lc, dbClient, client, pool, smtpAuth, templateSource, config)
 }
 
 func createEscalatedNotification(
@@ -17,11 +17,26 @@ package notifications
 
 import (
 	"context"
+	"net/http"
 	"sync"
 
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/webhook"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2"
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 
 	"github.com/gorilla/mux"
 )
@@ -39,8 +54,81 @@ func NewBootstrap(router *mux.Router) *Bootstrap {
 }
 
 // BootstrapHandler fulfills the BootstrapHandler contract and performs initialization for the notifications service.
-func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	// the client of the notifications sent to the REST channels
+	httpClient, err := httpclient.NewClient(
+		bootstrapContainer.LoggingClientFrom(dic.Get),
+		container.ConfigurationFrom(dic.Get).OutboundHTTP,
+		&http.Client{})
+	if err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("invalid outbound HTTP configuration: " + err.Error())
+		return false
+	}
+	// the notifications sent to the REST channels are signed with the shared secret of the secret store, when enabled
+	if err := container.ConfigurationFrom(dic.Get).WebhookSigning.Validate(); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("invalid WebhookSigning configuration: " + err.Error())
+		return false
+	}
+	signer := webhook.NewSigner(bootstrapContainer.SecretProviderFrom(dic.Get), container.ConfigurationFrom(dic.Get).WebhookSigning)
+	// the authentication of the notifications sent to the EMAIL channels, with the credentials of the secret store or
+	// the OAuth2 access tokens refreshed with the same client
+	if err := container.ConfigurationFrom(dic.Get).Smtp.Authentication.Validate(); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("invalid Smtp.Authentication configuration: " + err.Error())
+		return false
+	}
+	smtpAuthenticator := smtpauth.NewAuthenticator(
+		bootstrapContainer.LoggingClientFrom(dic.Get),
+		bootstrapContainer.SecretProviderFrom(dic.Get),
+		httpClient)
+	// the notifications are delivered by a bounded pool of workers, with concurrency and rate limits per channel type
+	pool, err := delivery.NewPool(container.ConfigurationFrom(dic.Get).Delivery, bootstrapContainer.LoggingClientFrom(dic.Get))
+	if err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("invalid Delivery configuration: " + err.Error())
+		return false
+	}
+	pool.Start(ctx, wg)
+	// the bulk deletions run in background jobs, whose routes are loaded with the v2 ones
+	jobManager, err := jobs.NewManager(container.ConfigurationFrom(dic.Get).Jobs, bootstrapContainer.LoggingClientFrom(dic.Get))
+	if err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("invalid Jobs configuration: " + err.Error())
+		return false
+	}
+	jobManager.Start(ctx, wg)
+	dic.Update(di.ServiceConstructorMap{
+		pkgContainer.HTTPClientName: func(get di.Get) interface{} {
+			return httpClient
+		},
+		pkgContainer.JobManagerName: func(get di.Get) interface{} {
+			return jobManager
+		},
+		container.DeliveryPoolName: func(get di.Get) interface{} {
+			return pool
+		},
+		container.WebhookSignerName: func(get di.Get) interface{} {
+			return signer
+		},
+		container.SmtpAuthenticatorName: func(get di.Get) interface{} {
+			return smtpAuthenticator
+		},
+	})
+
 	loadRestRoutes(b.router, dic)
 	v2.LoadRestRoutes(b.router, dic)
+	b.router.Use(problem.NewMiddleware(clients.SupportNotificationsServiceKey, container.ConfigurationFrom(dic.Get)))
+	cors.UseMiddleware(b.router, container.ConfigurationFrom(dic.Get))
+	b.router.Use(requestlimits.NewMiddleware(bootstrapContainer.LoggingClientFrom(dic.Get), container.ConfigurationFrom(dic.Get)))
+	if err := jwtauth.UseMiddleware(b.router, dic, container.ConfigurationFrom(dic.Get).JWTAuth); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("failed to enable JWT verification: " + err.Error())
+		return false
+	}
+	audit.UseMiddleware(ctx, wg, b.router, dic, container.ConfigurationFrom(dic.Get).Audit, clients.SupportNotificationsServiceKey,
+		container.ConfigurationFrom(dic.Get).Clients["CoreData"].Url(), audit.ClassifyWrite)
+	if err := startReleaser(ctx, wg, dic); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("failed to release the notifications queued by the mute windows: " + err.Error())
+		return false
+	}
+	if container.ConfigurationFrom(dic.Get).SystemEvents.Enabled && !startSystemEventConsumer(ctx, wg, startupTimer, dic) {
+		return false
+	}
 	return true
 }
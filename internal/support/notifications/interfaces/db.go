@@ -39,6 +39,7 @@ type DBClient interface {
 	DeleteNotificationById(id string) error
 	DeleteNotificationBySlug(id string) error
 	DeleteNotificationsOld(age int) error
+	DeleteProcessedNotificationsBefore(end int64, offset int, limit int) (int, int, error)
 
 	// Subscriptions
 	GetSubscriptions() ([]contract.Subscription, error)
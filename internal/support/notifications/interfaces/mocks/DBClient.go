@@ -148,6 +148,34 @@ func (_m *DBClient) DeleteNotificationsOld(age int) error {
 	return r0
 }
 
+// DeleteProcessedNotificationsBefore provides a mock function with given fields: end, offset, limit
+func (_m *DBClient) DeleteProcessedNotificationsBefore(end int64, offset int, limit int) (int, int, error) {
+	ret := _m.Called(end, offset, limit)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int64, int, int) int); ok {
+		r0 = rf(end, offset, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int64, int, int) int); ok {
+		r1 = rf(end, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, int, int) error); ok {
+		r2 = rf(end, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // DeleteSubscriptionById provides a mock function with given fields: id
 func (_m *DBClient) DeleteSubscriptionById(id string) error {
 	ret := _m.Called(id)
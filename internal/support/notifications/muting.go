@@ -0,0 +1,156 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+const defaultReleaseInterval = time.Minute
+
+// mute applies the mute window muting the notification, if any: a suppressed notification is marked processed without
+// being sent, a queued one is held until releaseHeld sends it. It returns whether the notification is muted. The
+// notifications are sent when the mute windows can't be read, paging the on-call being better than losing them.
+func mute(
+	n models.Notification,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	muteStore mutes.Store) bool {
+
+	if muteStore == nil {
+		return false
+	}
+	windows, err := muteStore.AllMuteWindows(0, -1)
+	if err != nil {
+		lc.Error("Unable to get the mute windows of notification: " + n.Slug + ", issue: " + err.Error())
+		return false
+	}
+	w, muted := mutes.Muting(windows, n, db.MakeTimestamp())
+	if !muted {
+		return false
+	}
+
+	if w.Action == mutes.Suppress {
+		lc.Info("Notification: " + n.Slug + " suppressed by mute window: " + w.Name)
+		if err := dbClient.MarkNotificationProcessed(n); err != nil {
+			lc.Error("Trouble updating notification to Processed for: " + n.Slug)
+		}
+		return true
+	}
+	if err := muteStore.HoldNotification(n.ID, n.Created); err != nil {
+		lc.Error("Unable to queue notification: " + n.Slug + ", issue: " + err.Error())
+		return false
+	}
+	lc.Info("Notification: " + n.Slug + " queued by mute window: " + w.Name)
+	return true
+}
+
+// releaseHeld distributes the queued notifications that no mute window mutes anymore
+func releaseHeld(
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	muteStore mutes.Store,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
+	config notificationsConfig.ConfigurationStruct) {
+
+	ids, err := muteStore.HeldNotifications(0, -1)
+	if err != nil {
+		lc.Error("Unable to get the queued notifications: " + err.Error())
+		return
+	}
+	if len(ids) == 0 {
+		return
+	}
+	windows, err := muteStore.AllMuteWindows(0, -1)
+	if err != nil {
+		lc.Error("Unable to get the mute windows of the queued notifications: " + err.Error())
+		return
+	}
+
+	now := db.MakeTimestamp()
+	for _, id := range ids {
+		n, err := dbClient.GetNotificationById(id)
+		if err == db.ErrNotFound {
+			// Deleted while queued
+			_ = muteStore.ReleaseNotification(id)
+			continue
+		} else if err != nil {
+			lc.Error("Unable to get queued notification: " + id + ", issue: " + err.Error())
+			continue
+		}
+		if _, muted := mutes.Muting(windows, n, now); muted {
+			continue
+		}
+
+		lc.Info("Releasing queued notification: " + n.Slug)
+		if n.Status == models.NotificationsStatus(models.New) {
+			// Released even if it can't be marked processed, as it is being sent
+			_ = distributeAndMark(correlation.NewContext(context.Background()), n, lc, dbClient, client, pool, smtpAuth, templateSource, config)
+		}
+		if err := muteStore.ReleaseNotification(id); err != nil {
+			lc.Error("Unable to release queued notification: " + n.Slug + ", issue: " + err.Error())
+		}
+	}
+}
+
+// startReleaser calls releaseHeld every Mutes.ReleaseInterval, a minute when not configured, until ctx is done
+func startReleaser(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) error {
+	interval := defaultReleaseInterval
+	if configured := notificationsContainer.ConfigurationFrom(dic.Get).Mutes.ReleaseInterval; configured != "" {
+		var err error
+		interval, err = time.ParseDuration(configured)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("invalid Mutes.ReleaseInterval '%s'", configured)
+		}
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dbClient := container.DBClientFrom(dic.Get)
+	client := notificationsContainer.RestClientFrom(dic.Get)
+	pool := notificationsContainer.DeliveryPoolFrom(dic.Get)
+	smtpAuth := notificationsContainer.SmtpAuthenticatorFrom(dic.Get)
+	v2DBClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				releaseHeld(lc, dbClient, v2DBClient, client, pool, smtpAuth, v2DBClient, *notificationsContainer.ConfigurationFrom(dic.Get))
+			}
+		}
+	}()
+	return nil
+}
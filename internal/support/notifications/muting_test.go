@@ -0,0 +1,114 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces/mocks"
+	v2Mocks "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func activeMuteWindow(action string) mutes.MuteWindow {
+	now := db.MakeTimestamp()
+	return mutes.MuteWindow{
+		Name:       "maintenance-" + action,
+		Categories: []string{contract.Hwhealth},
+		Start:      now - 60*60*1000,
+		End:        now + 60*60*1000,
+		Action:     action,
+	}
+}
+
+func mutedNotification(id string) contract.Notification {
+	n := contract.Notification{Slug: "fan-failure-" + id, Category: contract.Hwhealth, Severity: contract.Critical}
+	n.ID = id
+	n.Created = db.MakeTimestamp()
+	n.Status = contract.NotificationsStatus(contract.New)
+	return n
+}
+
+func TestMute(t *testing.T) {
+	n := mutedNotification(notificationId)
+	other := mutedNotification(notificationId)
+	other.Category = contract.Security
+
+	tests := []struct {
+		name       string
+		windows    []mutes.MuteWindow
+		windowsErr errors.EdgeX
+		n          contract.Notification
+		held       bool
+		marked     bool
+		expected   bool
+	}{
+		{"Not muted", []mutes.MuteWindow{activeMuteWindow(mutes.Suppress)}, nil, other, false, false, false},
+		{"Suppressed", []mutes.MuteWindow{activeMuteWindow(mutes.Queue), activeMuteWindow(mutes.Suppress)}, nil, n, false, true, true},
+		{"Queued", []mutes.MuteWindow{activeMuteWindow(mutes.Queue)}, nil, n, true, false, true},
+		{"Mute windows not readable", nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "unavailable", nil), n, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := &mocks.DBClient{}
+			dbMock.On("MarkNotificationProcessed", mock.Anything).Return(nil)
+			storeMock := &v2Mocks.DBClient{}
+			storeMock.On("AllMuteWindows", 0, -1).Return(tt.windows, tt.windowsErr)
+			storeMock.On("HoldNotification", tt.n.ID, tt.n.Created).Return(nil)
+
+			assert.Equal(t, tt.expected, mute(tt.n, logger.NewMockClient(), dbMock, storeMock))
+			if tt.held {
+				storeMock.AssertCalled(t, "HoldNotification", tt.n.ID, tt.n.Created)
+			} else {
+				storeMock.AssertNotCalled(t, "HoldNotification", mock.Anything, mock.Anything)
+			}
+			if tt.marked {
+				dbMock.AssertCalled(t, "MarkNotificationProcessed", mock.Anything)
+			} else {
+				dbMock.AssertNotCalled(t, "MarkNotificationProcessed", mock.Anything)
+			}
+		})
+	}
+
+	assert.False(t, mute(n, logger.NewMockClient(), &mocks.DBClient{}, nil), "nothing is muted without mute windows")
+}
+
+func TestReleaseHeld(t *testing.T) {
+	stillMuted := mutedNotification("still-muted")
+	stillMuted.Labels = []string{mutes.DeviceGroupLabelPrefix + "building-1"}
+	released := mutedNotification("released")
+	deleted := "deleted"
+
+	queueing := activeMuteWindow(mutes.Queue)
+	queueing.DeviceGroups = []string{"building-1"}
+
+	dbMock := &mocks.DBClient{}
+	dbMock.On("GetNotificationById", stillMuted.ID).Return(stillMuted, nil)
+	dbMock.On("GetNotificationById", released.ID).Return(released, nil)
+	dbMock.On("GetNotificationById", deleted).Return(contract.Notification{}, db.ErrNotFound)
+	dbMock.On("GetSubscriptionByCategoriesLabels", mock.Anything, mock.Anything).Return([]contract.Subscription{}, nil)
+	dbMock.On("MarkNotificationProcessed", mock.Anything).Return(nil)
+	storeMock := &v2Mocks.DBClient{}
+	storeMock.On("HeldNotifications", 0, -1).Return([]string{stillMuted.ID, released.ID, deleted}, nil)
+	storeMock.On("AllMuteWindows", 0, -1).Return([]mutes.MuteWindow{queueing}, nil)
+	storeMock.On("ReleaseNotification", mock.Anything).Return(nil)
+
+	releaseHeld(logger.NewMockClient(), dbMock, storeMock, nil, nil, nil, nil, notificationsConfig.ConfigurationStruct{})
+
+	dbMock.AssertCalled(t, "MarkNotificationProcessed", mock.Anything)
+	storeMock.AssertCalled(t, "ReleaseNotification", released.ID)
+	storeMock.AssertCalled(t, "ReleaseNotification", deleted)
+	storeMock.AssertNotCalled(t, "ReleaseNotification", stillMuted.ID)
+}
@@ -16,20 +16,31 @@
 package notifications
 
 import (
+	"context"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
 	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 )
 
 func distributeAndMark(
+	ctx context.Context,
 	n models.Notification,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
 	config notificationsConfig.ConfigurationStruct) error {
 
-	go distribute(n, lc, dbClient, config)
+	go distribute(ctx, n, lc, dbClient, client, pool, smtpAuth, templateSource, config)
 
 	err := dbClient.MarkNotificationProcessed(n)
 	if err != nil {
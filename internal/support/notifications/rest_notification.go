@@ -20,12 +20,17 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/edgexfoundry/edgex-go/internal"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
 	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/errors"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/operators/notification"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
@@ -39,6 +44,11 @@ func notificationHandler(
 	r *http.Request,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
+	muteStore mutes.Store,
 	config notificationsConfig.ConfigurationStruct) {
 
 	if r.Body != nil {
@@ -72,9 +82,11 @@ func notificationHandler(
 		return
 	}
 
-	err = distributeAndMark(n, lc, dbClient, config)
-	if err != nil {
-		return
+	if !mute(n, lc, dbClient, muteStore) {
+		err = distributeAndMark(r.Context(), n, lc, dbClient, client, pool, smtpAuth, templateSource, config)
+		if err != nil {
+			return
+		}
 	}
 	lc.Debug("The scheduler has completed for: " + n.Slug)
 
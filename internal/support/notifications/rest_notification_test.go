@@ -1005,6 +1005,11 @@ func TestNotificationHandler(t *testing.T) {
 				tt.request,
 				logger.NewMockClient(),
 				tt.dbMock,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 				notificationsConfig.ConfigurationStruct{Service: bootstrapConfig.ServiceInfo{MaxResultCount: 5}})
 			response := rr.Result()
 			if response.StatusCode != tt.expectedStatus {
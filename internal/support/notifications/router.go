@@ -23,6 +23,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/telemetry"
 	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
@@ -69,6 +70,11 @@ func loadRestRoutes(r *mux.Router, dic *di.Container) {
 				r,
 				bootstrapContainer.LoggingClientFrom(dic.Get),
 				container.DBClientFrom(dic.Get),
+				notificationsContainer.RestClientFrom(dic.Get),
+				notificationsContainer.DeliveryPoolFrom(dic.Get),
+				notificationsContainer.SmtpAuthenticatorFrom(dic.Get),
+				v2NotificationsContainer.DBClientFrom(dic.Get),
+				v2NotificationsContainer.DBClientFrom(dic.Get),
 				*notificationsContainer.ConfigurationFrom(dic.Get))
 		}).Methods(http.MethodPost)
 	b.HandleFunc(
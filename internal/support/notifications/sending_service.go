@@ -17,6 +17,7 @@ package notifications
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -27,32 +28,52 @@ import (
 	"strings"
 	"time"
 
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
 	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 )
 
 func sendViaChannel(
+	ctx context.Context,
 	n models.Notification,
 	c models.Channel,
 	receiver string,
+	locale string,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
 	config notificationsConfig.ConfigurationStruct) {
 
 	lc.Debug("Sending notification: " + n.Slug + ", via channel: " + c.String())
-	var tr models.TransmissionRecord
-	if c.Type == models.ChannelType(models.Email) {
-		tr = sendMail(n.Content, c.MailAddresses, n.ContentType, lc, config.Smtp)
-	} else {
-		tr = restSend(n.Content, c.Url, n.ContentType, lc)
+	complete := func(tr models.TransmissionRecord) {
+		t, err := persistTransmission(tr, n, c, receiver, lc, dbClient)
+		if err == nil {
+			handleFailedTransmission(t, lc, dbClient, client, pool, smtpAuth, templateSource, config)
+		}
 	}
-	t, err := persistTransmission(tr, n, c, receiver, lc, dbClient)
-	if err == nil {
-		handleFailedTransmission(t, lc, dbClient, config)
+	err := pool.Submit(c.Type, func() {
+		m := render(n, receiver, locale, lc, templateSource, config)
+		if c.Type == models.ChannelType(models.Email) {
+			complete(sendMail(m.Subject, m.Body, c.MailAddresses, m.ContentType, lc, config.Smtp, smtpAuth))
+		} else {
+			complete(restSend(ctx, client, m.Body, c.Url, m.ContentType, lc))
+		}
+	})
+	if err != nil {
+		// the transmission fails, to be resent as the others
+		lc.Error("Unable to send notification: " + n.Slug + ", via channel: " + c.String() + ", issue: " + err.Error())
+		complete(getTransmissionRecord(err.Error(), models.Failed))
 	}
 }
 
@@ -60,21 +81,104 @@ func resendViaChannel(
 	t models.Transmission,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
 	config notificationsConfig.ConfigurationStruct) {
 
-	var tr models.TransmissionRecord
-	if t.Channel.Type == models.ChannelType(models.Email) {
-		tr = sendMail(t.Notification.Content, t.Channel.MailAddresses, t.Notification.ContentType, lc, config.Smtp)
-	} else {
-		tr = restSend(t.Notification.Content, t.Channel.Url, t.Notification.ContentType, lc)
+	complete := func(tr models.TransmissionRecord) {
+		t.ResendCount = t.ResendCount + 1
+		t.Status = tr.Status
+		t.Records = append(t.Records, tr)
+		err := dbClient.UpdateTransmission(t)
+		if err == nil {
+			handleFailedTransmission(t, lc, dbClient, client, pool, smtpAuth, templateSource, config)
+		}
 	}
-	t.ResendCount = t.ResendCount + 1
-	t.Status = tr.Status
-	t.Records = append(t.Records, tr)
-	err := dbClient.UpdateTransmission(t)
-	if err == nil {
-		handleFailedTransmission(t, lc, dbClient, config)
+	err := pool.Submit(t.Channel.Type, func() {
+		m := render(t.Notification, t.Receiver, receiverLocale(t.Receiver, lc, dbClient, templateSource), lc, templateSource, config)
+		if t.Channel.Type == models.ChannelType(models.Email) {
+			complete(sendMail(m.Subject, m.Body, t.Channel.MailAddresses, m.ContentType, lc, config.Smtp, smtpAuth))
+		} else {
+			// A resend isn't tied to the request posting the notification, so it starts a new correlation id
+			complete(restSend(correlation.NewContext(context.Background()), client, m.Body, t.Channel.Url, m.ContentType, lc))
+		}
+	})
+	if err != nil {
+		lc.Error("Unable to resend transmission: " + t.ID + ", issue: " + err.Error())
+		complete(getTransmissionRecord(err.Error(), models.Failed))
+	}
+}
+
+// render renders the notification for the receiver with the template applying to it, in locale. The notification is
+// sent as is, with the subject configured in Smtp.Subject, when no template applies or the rendering fails.
+func render(
+	n models.Notification,
+	receiver string,
+	locale string,
+	lc logger.LoggingClient,
+	templateSource templates.Source,
+	config notificationsConfig.ConfigurationStruct) templates.Message {
+
+	m := templates.Message{Subject: config.Smtp.Subject, Body: n.Content, ContentType: n.ContentType}
+	if templateSource == nil {
+		return m
+	}
+	all, err := templateSource.AllTemplates(0, -1)
+	if err != nil {
+		lc.Error("Unable to get the templates to render notification: " + n.Slug + ", issue: " + err.Error())
+		return m
+	}
+	t, ok := templates.Select(all, n)
+	if !ok {
+		return m
+	}
+	rendered, renderErr := t.Render(n, receiver, locale)
+	if renderErr != nil {
+		lc.Error("Unable to render notification: " + n.Slug + " with template: " + t.Name + ", issue: " + renderErr.Error())
+		return m
+	}
+	if rendered.Subject == "" {
+		rendered.Subject = config.Smtp.Subject
+	}
+	return rendered
+}
+
+// subscriptionLocale returns the locale set on the subscription, empty when it has none or it can't be queried
+func subscriptionLocale(slug string, lc logger.LoggingClient, templateSource templates.Source) string {
+	if templateSource == nil {
+		return ""
+	}
+	locale, err := templateSource.SubscriptionLocale(slug)
+	if err != nil {
+		lc.Error("Unable to get the locale of subscription: " + slug + ", issue: " + err.Error())
+		return ""
+	}
+	return locale
+}
+
+// receiverLocale returns the locale set on the subscriptions of receiver, as the transmissions only record the
+// receiver of their subscription
+func receiverLocale(
+	receiver string,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	templateSource templates.Source) string {
+
+	if templateSource == nil {
+		return ""
+	}
+	subs, err := dbClient.GetSubscriptionByReceiver(receiver)
+	if err != nil {
+		return ""
+	}
+	for _, s := range subs {
+		if locale := subscriptionLocale(s.Slug, lc, templateSource); locale != "" {
+			return locale
+		}
 	}
+	return ""
 }
 
 func getTransmissionRecord(msg string, st models.TransmissionStatus) models.TransmissionRecord {
@@ -111,17 +215,19 @@ func persistTransmission(
 }
 
 func sendMail(
+	subject string,
 	message string,
 	addressees []string,
 	contentType string,
 	lc logger.LoggingClient,
-	smtp notificationsConfig.SmtpInfo) models.TransmissionRecord {
+	smtp notificationsConfig.SmtpInfo,
+	smtpAuth *smtpauth.Authenticator) models.TransmissionRecord {
 
 	tr := getTransmissionRecord("SMTP server received", models.Sent)
 
-	smtpMessage := buildSmtpMessage(smtp.Sender, smtp.Subject, addressees, contentType, message)
+	smtpMessage := buildSmtpMessage(smtp.Sender, subject, addressees, contentType, message)
 
-	err := smtpSend(addressees, smtpMessage, smtp)
+	err := smtpSend(addressees, smtpMessage, smtp, smtpAuth)
 	if err != nil {
 		lc.Error("Problems sending message to: " + strings.Join(addressees, ",") + ", issue: " + err.Error())
 		tr.Status = models.Failed
@@ -164,14 +270,27 @@ func buildSmtpMessage(sender string, subject string, toAddresses []string, conte
 	return []byte(buf.String())
 }
 
-func restSend(message string, url string, contentType string, lc logger.LoggingClient) models.TransmissionRecord {
+func restSend(
+	ctx context.Context,
+	client internal.HttpCaller,
+	message string,
+	url string,
+	contentType string,
+	lc logger.LoggingClient) models.TransmissionRecord {
+
 	tr := getTransmissionRecord("", models.Sent)
 
 	if contentType == "" {
 		contentType = "text/plain"
 	}
 
-	rs, err := http.Post(url, contentType, bytes.NewBuffer([]byte(message)))
+	var rs *http.Response
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer([]byte(message)))
+	if err == nil {
+		req.Header.Set("Content-Type", contentType)
+		correlation.Propagate(ctx, req)
+		rs, err = client.Do(req)
+	}
 	if err != nil {
 		lc.Error("Problems sending message to: " + url)
 		lc.Error("Error indication was:  " + err.Error())
@@ -179,6 +298,7 @@ func restSend(message string, url string, contentType string, lc logger.LoggingC
 		tr.Response = err.Error()
 		return tr
 	}
+	_ = rs.Body.Close()
 	tr.Response = "Got response status code: " + rs.Status
 	return tr
 }
@@ -187,6 +307,10 @@ func handleFailedTransmission(
 	t models.Transmission,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
 	config notificationsConfig.ConfigurationStruct) {
 
 	n := t.Notification
@@ -198,10 +322,10 @@ func handleFailedTransmission(
 		if n.Severity == models.Critical {
 			if t.ResendCount < config.Writable.ResendLimit {
 				time.AfterFunc(time.Second*5, func() {
-					criticalSeverityResend(t, lc, dbClient, config)
+					criticalSeverityResend(t, lc, dbClient, client, pool, smtpAuth, templateSource, config)
 				})
 			} else {
-				escalate(t, lc, dbClient, config)
+				escalate(t, lc, dbClient, client, pool, smtpAuth, templateSource, config)
 				t.Status = models.Trxescalated
 				dbClient.UpdateTransmission(t)
 			}
@@ -209,17 +333,11 @@ func handleFailedTransmission(
 	}
 }
 
-func deduceAuth(s notificationsConfig.SmtpInfo) (mail.Auth, error) {
-	if s.CheckUsername() == "" && s.Password == "" {
-		return nil, errors.New("Notifications: Expecting username")
-	}
-	if s.CheckUsername() != "" && s.Password == "" {
-		return nil, nil
-	}
-	if s.CheckUsername() == "" && s.Password != "" {
-		return nil, errors.New("Notifications: Expecting username")
-	}
-	return mail.PlainAuth("", s.CheckUsername(), s.Password, s.Host), nil
+// deduceAuth returns the authentication of s, with the credentials of the secret store or the OAuth2 access token
+// of smtpAuth when configured
+func deduceAuth(s notificationsConfig.SmtpInfo, smtpAuth *smtpauth.Authenticator) (mail.Auth, error) {
+	auth, _, err := smtpAuth.Auth(s.Authentication, s.Host, s.CheckUsername(), s.Password)
+	return auth, err
 }
 
 // The function smtpSend replicates the functionality provided by the SendMail function
@@ -236,9 +354,9 @@ func deduceAuth(s notificationsConfig.SmtpInfo) (mail.Auth, error) {
 // interfaces, which makes it a little bit trickier to modify. Since, the intention for
 // this function is to use it as a support function for handling the low level SMTP
 // protocol mechanism, it is not exported.
-func smtpSend(to []string, msg []byte, s notificationsConfig.SmtpInfo) error {
+func smtpSend(to []string, msg []byte, s notificationsConfig.SmtpInfo, smtpAuth *smtpauth.Authenticator) error {
 	addr := s.Host + ":" + strconv.Itoa(s.Port)
-	auth, err := deduceAuth(s)
+	auth, err := deduceAuth(s, smtpAuth)
 	if err != nil {
 		return err
 	}
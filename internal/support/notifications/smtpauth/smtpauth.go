@@ -0,0 +1,348 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package smtpauth authenticates the SMTP client of the email channel, with the username and password of the
+// configuration or of the secret store, or with an OAuth2 access token (XOAUTH2) for the providers deprecating the basic
+// authentication, e.g. Office365 and Gmail. The access tokens are refreshed with the refresh token of the secret store.
+package smtpauth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	mail "net/smtp"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	// Plain authenticates with the username and password, the default mechanism
+	Plain = "PLAIN"
+	// XOAuth2 authenticates with an OAuth2 access token
+	XOAuth2 = "XOAUTH2"
+
+	// The keys of the secret of AuthInfo.SecretPath
+	UsernameKey     = "username"
+	PasswordKey     = "password"
+	ClientSecretKey = "clientSecret"
+	RefreshTokenKey = "refreshToken"
+	// AccessTokenKey holds an access token refreshed outside of the service, used when the secret holds no refresh token
+	AccessTokenKey = "accessToken"
+
+	// expiryMargin is how long before their expiry the access tokens are refreshed
+	expiryMargin = time.Minute
+	// maxErrorExcerpt is the number of bytes of the response of the token endpoint reported on failure
+	maxErrorExcerpt = 512
+)
+
+// OAuth2Info defines the OAuth2 client refreshing the access tokens of the XOAUTH2 mechanism
+type OAuth2Info struct {
+	// TokenURL is the token endpoint of the provider, e.g. https://login.microsoftonline.com/<tenant>/oauth2/v2.0/token
+	TokenURL string
+	ClientId string
+	// Scopes are the scopes requested when refreshing the access tokens, e.g. https://outlook.office.com/SMTP.Send
+	Scopes []string
+}
+
+// AuthInfo defines how the SMTP client authenticates
+type AuthInfo struct {
+	// Mechanism is PLAIN, the default, or XOAUTH2
+	Mechanism string
+	// SecretPath is the path of the secret holding the username and password, overriding the configured ones, or the
+	// clientSecret and refreshToken of the XOAUTH2 mechanism
+	SecretPath string
+	OAuth2     OAuth2Info
+}
+
+// Validate checks the mechanism, and that the XOAUTH2 mechanism reads its tokens from the secret store
+func (info AuthInfo) Validate() error {
+	switch info.Mechanism {
+	case "", Plain:
+	case XOAuth2:
+		if info.SecretPath == "" {
+			return errors.New("the XOAUTH2 mechanism requires the SecretPath of its tokens")
+		}
+	default:
+		return fmt.Errorf("unknown SMTP authentication mechanism '%s', expected %s or %s", info.Mechanism, Plain, XOAuth2)
+	}
+	if info.OAuth2.TokenURL != "" {
+		if u, err := url.Parse(info.OAuth2.TokenURL); err != nil || !u.IsAbs() {
+			return fmt.Errorf("invalid OAuth2 TokenURL '%s'", info.OAuth2.TokenURL)
+		}
+	}
+	return nil
+}
+
+// mechanism returns the mechanism of info, PLAIN when not set
+func (info AuthInfo) mechanism() string {
+	if info.Mechanism == "" {
+		return Plain
+	}
+	return info.Mechanism
+}
+
+// token is an access token, refreshed from the refresh token of the secret store
+type token struct {
+	accessToken string
+	expiresAt   time.Time
+	// storedRefreshToken is the refresh token of the secret store the token was refreshed from, so that a token
+	// refreshed before the secret was rotated isn't used
+	storedRefreshToken string
+	// refreshToken is the refresh token issued with the access token, when the provider rotates them
+	refreshToken string
+}
+
+// Authenticator resolves the credentials of the SMTP client and keeps the access tokens until they expire. It is safe
+// for concurrent use. A nil Authenticator authenticates with the configured username and password only.
+type Authenticator struct {
+	lc      logger.LoggingClient
+	secrets secretcache.SecretProvider
+	client  internal.HttpCaller
+	mutex   sync.Mutex
+	tokens  map[string]token
+	now     func() time.Time
+}
+
+// NewAuthenticator returns an Authenticator reading the secrets from secrets and refreshing the access tokens with
+// client
+func NewAuthenticator(lc logger.LoggingClient, secrets secretcache.SecretProvider, client internal.HttpCaller) *Authenticator {
+	return &Authenticator{
+		lc:      lc,
+		secrets: secrets,
+		client:  client,
+		tokens:  make(map[string]token),
+		now:     time.Now,
+	}
+}
+
+// Auth returns the authentication of the SMTP client of host with info, and the username it authenticates as. The
+// username and password are the configured ones, overridden by those of the secret of info.SecretPath. It returns a
+// nil authentication when the PLAIN mechanism has no password, the client not authenticating.
+func (a *Authenticator) Auth(info AuthInfo, host string, username string, password string) (mail.Auth, string, error) {
+	var secrets map[string]string
+	if info.SecretPath != "" {
+		if a == nil {
+			return nil, "", errors.New("Notifications: the secret store isn't available to read the SMTP credentials")
+		}
+		var err error
+		secrets, err = a.secrets.GetSecrets(info.SecretPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("Notifications: unable to read the SMTP credentials at %s: %s", info.SecretPath, err.Error())
+		}
+		if secrets[UsernameKey] != "" {
+			username = secrets[UsernameKey]
+		}
+		if secrets[PasswordKey] != "" {
+			password = secrets[PasswordKey]
+		}
+	}
+	if username == "" {
+		return nil, "", errors.New("Notifications: Expecting username")
+	}
+
+	switch info.mechanism() {
+	case Plain:
+		if password == "" {
+			return nil, username, nil
+		}
+		return mail.PlainAuth("", username, password, host), username, nil
+	case XOAuth2:
+		if a == nil {
+			return nil, "", errors.New("Notifications: the secret store isn't available to read the OAuth2 tokens")
+		}
+		accessToken, err := a.accessToken(info, secrets)
+		if err != nil {
+			return nil, "", fmt.Errorf("Notifications: unable to get the OAuth2 access token: %s", err.Error())
+		}
+		return &xoauth2Auth{
+			username:    username,
+			accessToken: accessToken,
+			host:        host,
+			invalidate:  func() { a.invalidate(info.SecretPath, accessToken) },
+		}, username, nil
+	}
+	return nil, "", fmt.Errorf("Notifications: unknown SMTP authentication mechanism '%s'", info.Mechanism)
+}
+
+// accessToken returns the cached access token of info, refreshing it when it's about to expire. The refresh is
+// serialized so that concurrent notifications don't refresh the token more than once.
+func (a *Authenticator) accessToken(info AuthInfo, secrets map[string]string) (string, error) {
+	storedRefreshToken := secrets[RefreshTokenKey]
+	if storedRefreshToken == "" {
+		if secrets[AccessTokenKey] != "" {
+			return secrets[AccessTokenKey], nil
+		}
+		return "", fmt.Errorf("the secret %s holds neither a %s nor an %s", info.SecretPath, RefreshTokenKey, AccessTokenKey)
+	}
+	if info.OAuth2.TokenURL == "" {
+		return "", errors.New("no OAuth2 TokenURL configured to refresh the access token")
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	cached, ok := a.tokens[info.SecretPath]
+	if ok && cached.storedRefreshToken != storedRefreshToken {
+		// the secret was rotated since, the refresh tokens issued before are discarded
+		cached, ok = token{}, false
+	}
+	if ok && cached.accessToken != "" && a.now().Before(cached.expiresAt) {
+		return cached.accessToken, nil
+	}
+
+	refreshToken := storedRefreshToken
+	if cached.refreshToken != "" {
+		refreshToken = cached.refreshToken
+	}
+	refreshed, err := a.refresh(info.OAuth2, secrets[ClientSecretKey], refreshToken)
+	if err != nil {
+		return "", err
+	}
+	refreshed.storedRefreshToken = storedRefreshToken
+	if refreshed.refreshToken != "" && refreshed.refreshToken != refreshToken {
+		refreshed = a.storeRefreshToken(info.SecretPath, secrets, refreshed)
+	} else {
+		refreshed.refreshToken = cached.refreshToken
+	}
+	a.tokens[info.SecretPath] = refreshed
+	a.lc.Debug(fmt.Sprintf("OAuth2 access token of %s refreshed, expiring %s", info.SecretPath, refreshed.expiresAt.UTC().Format(time.RFC3339)))
+	return refreshed.accessToken, nil
+}
+
+// storeRefreshToken stores the refresh token rotated by the provider in the secret store. The token is kept in memory
+// only when the secret store doesn't accept it, e.g. in insecure mode.
+func (a *Authenticator) storeRefreshToken(path string, secrets map[string]string, t token) token {
+	updated := make(map[string]string, len(secrets))
+	for key, value := range secrets {
+		updated[key] = value
+	}
+	updated[RefreshTokenKey] = t.refreshToken
+	if err := a.secrets.StoreSecrets(path, updated); err != nil {
+		a.lc.Warn(fmt.Sprintf("unable to store the OAuth2 refresh token rotated by the provider at %s, keeping it in memory: %s", path, err.Error()))
+		return t
+	}
+	t.storedRefreshToken = t.refreshToken
+	t.refreshToken = ""
+	return t
+}
+
+// invalidate discards the access token of path rejected by the server, so that the next authentication refreshes it
+func (a *Authenticator) invalidate(path string, accessToken string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if cached, ok := a.tokens[path]; ok && cached.accessToken == accessToken {
+		cached.accessToken = ""
+		a.tokens[path] = cached
+	}
+}
+
+// tokenResponse is the response of the token endpoint, RFC 6749 section 5
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// refresh requests an access token from the token endpoint of info with the refresh token grant
+func (a *Authenticator) refresh(info OAuth2Info, clientSecret string, refreshToken string) (token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	if info.ClientId != "" {
+		form.Set("client_id", info.ClientId)
+	}
+	if clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+	if len(info.Scopes) > 0 {
+		form.Set("scope", strings.Join(info.Scopes, " "))
+	}
+	req, err := http.NewRequest(http.MethodPost, info.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	rs, err := a.client.Do(req)
+	if err != nil {
+		return token{}, err
+	}
+	defer func() { _ = rs.Body.Close() }()
+	body, err := ioutil.ReadAll(io.LimitReader(rs.Body, 1<<20))
+	if err != nil {
+		return token{}, err
+	}
+
+	var response tokenResponse
+	decodeErr := json.Unmarshal(body, &response)
+	if rs.StatusCode < 200 || rs.StatusCode >= 300 || response.Error != "" {
+		if decodeErr == nil && response.Error != "" {
+			return token{}, fmt.Errorf("the token endpoint responded with status %s: %s %s", rs.Status, response.Error, response.ErrorDescription)
+		}
+		if len(body) > maxErrorExcerpt {
+			body = body[:maxErrorExcerpt]
+		}
+		return token{}, fmt.Errorf("the token endpoint responded with status %s: %s", rs.Status, strings.TrimSpace(string(body)))
+	}
+	if decodeErr != nil {
+		return token{}, fmt.Errorf("unable to decode the response of the token endpoint: %s", decodeErr.Error())
+	}
+	if response.AccessToken == "" {
+		return token{}, errors.New("the token endpoint responded without access_token")
+	}
+
+	t := token{accessToken: response.AccessToken, refreshToken: response.RefreshToken, expiresAt: a.now()}
+	// a token without expiry isn't cached
+	if response.ExpiresIn > 0 {
+		t.expiresAt = t.expiresAt.Add(time.Duration(response.ExpiresIn)*time.Second - expiryMargin)
+	}
+	return t, nil
+}
+
+// xoauth2Auth implements the XOAUTH2 SASL mechanism of Gmail and Office365
+type xoauth2Auth struct {
+	username    string
+	accessToken string
+	host        string
+	invalidate  func()
+}
+
+// Start sends the initial response of XOAUTH2, refusing to send the access token over an unencrypted connection to
+// another host than localhost, as PlainAuth does for the password
+func (x *xoauth2Auth) Start(server *mail.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != x.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return XOAuth2, []byte("user=" + x.username + "\x01auth=Bearer " + x.accessToken + "\x01\x01"), nil
+}
+
+// Next answers the challenge the server sends with the details of a rejected token with an empty response, after
+// which the server fails the authentication. The rejected token is discarded.
+func (x *xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		x.invalidate()
+		return []byte{}, nil
+	}
+	return nil, nil
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
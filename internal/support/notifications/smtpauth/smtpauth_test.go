@@ -0,0 +1,220 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package smtpauth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	mail "net/smtp"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// secretStore is an in-memory secret store, optionally refusing to store the secrets
+type secretStore struct {
+	mutex         sync.Mutex
+	secrets       map[string]map[string]string
+	storeDisabled bool
+}
+
+func (s *secretStore) GetSecrets(path string, _ ...string) (map[string]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	secrets, ok := s.secrets[path]
+	if !ok {
+		return nil, fmt.Errorf("no secret at %s", path)
+	}
+	copied := make(map[string]string, len(secrets))
+	for key, value := range secrets {
+		copied[key] = value
+	}
+	return copied, nil
+}
+
+func (s *secretStore) StoreSecrets(path string, secrets map[string]string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.storeDisabled {
+		return errors.New("storing secrets not supported")
+	}
+	s.secrets[path] = secrets
+	return nil
+}
+
+// tokenServer issues the access tokens access-1, access-2... for the refresh tokens, rotating them when rotate is set
+func tokenServer(t *testing.T, rotate bool) (*httptest.Server, *[]url.Values) {
+	var mutex sync.Mutex
+	var requests []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		mutex.Lock()
+		requests = append(requests, r.PostForm)
+		n := len(requests)
+		mutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("refresh_token") == "revoked" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"token revoked"}`))
+			return
+		}
+		refreshToken := ""
+		if rotate {
+			refreshToken = fmt.Sprintf(`,"refresh_token":"refresh-%d"`, n+1)
+		}
+		_, _ = fmt.Fprintf(w, `{"access_token":"access-%d","token_type":"Bearer","expires_in":3600%s}`, n, refreshToken)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func initialResponse(t *testing.T, auth mail.Auth, host string) string {
+	mechanism, response, err := auth.Start(&mail.ServerInfo{Name: host, TLS: true})
+	require.NoError(t, err)
+	require.Equal(t, XOAuth2, mechanism)
+	return string(response)
+}
+
+func TestAuthInfo_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		info  AuthInfo
+		valid bool
+	}{
+		{"Valid - default", AuthInfo{}, true},
+		{"Valid - PLAIN from the secret store", AuthInfo{Mechanism: Plain, SecretPath: "smtp"}, true},
+		{"Valid - XOAUTH2", AuthInfo{Mechanism: XOAuth2, SecretPath: "smtp", OAuth2: OAuth2Info{TokenURL: "https://login.example.com/token"}}, true},
+		{"Invalid - unknown mechanism", AuthInfo{Mechanism: "CRAM-MD5"}, false},
+		{"Invalid - XOAUTH2 without SecretPath", AuthInfo{Mechanism: XOAuth2}, false},
+		{"Invalid - relative TokenURL", AuthInfo{Mechanism: XOAuth2, SecretPath: "smtp", OAuth2: OAuth2Info{TokenURL: "/token"}}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.info.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestAuth_Plain(t *testing.T) {
+	var none *Authenticator
+	auth, username, err := none.Auth(AuthInfo{}, "smtp.example.com", "edgex", "secret")
+	require.NoError(t, err)
+	assert.NotNil(t, auth)
+	assert.Equal(t, "edgex", username)
+
+	auth, _, err = none.Auth(AuthInfo{}, "smtp.example.com", "edgex", "")
+	require.NoError(t, err)
+	assert.Nil(t, auth, "the client should not authenticate without password")
+
+	_, _, err = none.Auth(AuthInfo{}, "smtp.example.com", "", "secret")
+	assert.Error(t, err)
+	_, _, err = none.Auth(AuthInfo{SecretPath: "smtp"}, "smtp.example.com", "edgex", "secret")
+	assert.Error(t, err, "the secret store should be required")
+
+	store := &secretStore{secrets: map[string]map[string]string{"smtp": {UsernameKey: "stored", PasswordKey: "stored-secret"}}}
+	a := NewAuthenticator(logger.NewMockClient(), store, http.DefaultClient)
+	auth, username, err = a.Auth(AuthInfo{SecretPath: "smtp"}, "smtp.example.com", "edgex", "")
+	require.NoError(t, err)
+	assert.Equal(t, "stored", username)
+	_, response, err := auth.Start(&mail.ServerInfo{Name: "smtp.example.com", TLS: true})
+	require.NoError(t, err)
+	assert.Equal(t, "\x00stored\x00stored-secret", string(response))
+
+	_, _, err = a.Auth(AuthInfo{SecretPath: "missing"}, "smtp.example.com", "edgex", "")
+	assert.Error(t, err)
+}
+
+func TestAuth_XOAuth2(t *testing.T) {
+	server, requests := tokenServer(t, false)
+	store := &secretStore{secrets: map[string]map[string]string{"smtp": {ClientSecretKey: "client-secret", RefreshTokenKey: "refresh-1"}}}
+	a := NewAuthenticator(logger.NewMockClient(), store, server.Client())
+	now := time.Now()
+	a.now = func() time.Time { return now }
+	info := AuthInfo{Mechanism: XOAuth2, SecretPath: "smtp", OAuth2: OAuth2Info{
+		TokenURL: server.URL, ClientId: "edgex", Scopes: []string{"https://outlook.office.com/SMTP.Send", "offline_access"}}}
+
+	auth, username, err := a.Auth(info, "smtp.office365.com", "jdoe@example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe@example.com", username)
+	assert.Equal(t, "user=jdoe@example.com\x01auth=Bearer access-1\x01\x01", initialResponse(t, auth, "smtp.office365.com"))
+	require.Len(t, *requests, 1)
+	form := (*requests)[0]
+	assert.Equal(t, "refresh_token", form.Get("grant_type"))
+	assert.Equal(t, "refresh-1", form.Get("refresh_token"))
+	assert.Equal(t, "edgex", form.Get("client_id"))
+	assert.Equal(t, "client-secret", form.Get("client_secret"))
+	assert.Equal(t, "https://outlook.office.com/SMTP.Send offline_access", form.Get("scope"))
+
+	_, _, err = auth.Start(&mail.ServerInfo{Name: "smtp.office365.com"})
+	assert.Error(t, err, "the token should not be sent unencrypted")
+
+	auth, _, err = a.Auth(info, "smtp.office365.com", "jdoe@example.com", "")
+	require.NoError(t, err)
+	assert.Contains(t, initialResponse(t, auth, "smtp.office365.com"), "Bearer access-1\x01")
+	assert.Len(t, *requests, 1, "the access token should be cached")
+
+	// the server rejecting the token sends a challenge, the token is refreshed by the next authentication
+	response, err := auth.Next([]byte(`{"status":"401"}`), true)
+	require.NoError(t, err)
+	assert.Empty(t, response)
+	auth, _, err = a.Auth(info, "smtp.office365.com", "jdoe@example.com", "")
+	require.NoError(t, err)
+	assert.Contains(t, initialResponse(t, auth, "smtp.office365.com"), "Bearer access-2\x01")
+
+	now = now.Add(time.Hour)
+	auth, _, err = a.Auth(info, "smtp.office365.com", "jdoe@example.com", "")
+	require.NoError(t, err)
+	assert.Contains(t, initialResponse(t, auth, "smtp.office365.com"), "Bearer access-3\x01", "the expired token should be refreshed")
+
+	store.secrets["smtp"][RefreshTokenKey] = "revoked"
+	_, _, err = a.Auth(info, "smtp.office365.com", "jdoe@example.com", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid_grant token revoked")
+	assert.NotContains(t, err.Error(), "client-secret")
+
+	store.secrets["external"] = map[string]string{AccessTokenKey: "external-token"}
+	auth, _, err = a.Auth(AuthInfo{Mechanism: XOAuth2, SecretPath: "external"}, "smtp.gmail.com", "jdoe@example.com", "")
+	require.NoError(t, err)
+	assert.Contains(t, initialResponse(t, auth, "smtp.gmail.com"), "Bearer external-token\x01")
+}
+
+func TestAuth_XOAuth2RotatedRefreshToken(t *testing.T) {
+	for _, storeDisabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("store disabled %t", storeDisabled), func(t *testing.T) {
+			server, requests := tokenServer(t, true)
+			store := &secretStore{secrets: map[string]map[string]string{"smtp": {RefreshTokenKey: "refresh-1"}}, storeDisabled: storeDisabled}
+			a := NewAuthenticator(logger.NewMockClient(), store, server.Client())
+			info := AuthInfo{Mechanism: XOAuth2, SecretPath: "smtp", OAuth2: OAuth2Info{TokenURL: server.URL}}
+
+			auth, _, err := a.Auth(info, "smtp.gmail.com", "jdoe@example.com", "")
+			require.NoError(t, err)
+			_, _ = auth.Next(nil, true)
+			_, _, err = a.Auth(info, "smtp.gmail.com", "jdoe@example.com", "")
+			require.NoError(t, err)
+
+			require.Len(t, *requests, 2)
+			assert.Equal(t, "refresh-2", (*requests)[1].Get("refresh_token"), "the rotated refresh token should be used")
+			if storeDisabled {
+				assert.Equal(t, "refresh-1", store.secrets["smtp"][RefreshTokenKey])
+			} else {
+				assert.Equal(t, "refresh-3", store.secrets["smtp"][RefreshTokenKey], "the rotated refresh token should be stored")
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/delivery"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/smtpauth"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/systemevents"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-messaging/v2/messaging"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+)
+
+// notifySystemEvent adds the notification generated from a system event and distributes it, as if it was posted to
+// the notification route
+func notifySystemEvent(
+	ctx context.Context,
+	n models.Notification,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	client internal.HttpCaller,
+	pool *delivery.Pool,
+	smtpAuth *smtpauth.Authenticator,
+	templateSource templates.Source,
+	muteStore mutes.Store,
+	config notificationsConfig.ConfigurationStruct) {
+
+	correlationId := correlation.FromContext(ctx)
+	lc.Info("Posting Notification: "+n.String(), clients.CorrelationHeader, correlationId)
+	n.Status = models.NotificationsStatus(models.New)
+	id, err := dbClient.AddNotification(n)
+	if err != nil {
+		lc.Error("Unable to add the notification of a system event: "+err.Error(), clients.CorrelationHeader, correlationId)
+		return
+	}
+	n, err = dbClient.GetNotificationById(id)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		return
+	}
+	if !mute(n, lc, dbClient, muteStore) {
+		_ = distributeAndMark(ctx, n, lc, dbClient, client, pool, smtpAuth, templateSource, config)
+	}
+}
+
+// startSystemEventConsumer connects to the message bus and generates the notifications of the system events received
+func startSystemEventConsumer(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	configuration := notificationsContainer.ConfigurationFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	if err := configuration.SystemEvents.Validate(); err != nil {
+		lc.Error("invalid SystemEvents configuration: " + err.Error())
+		return false
+	}
+
+	// For Redis Streams MessageBus, we reuse the Redis instance running for the DB, which may have a password,
+	// so we need to get and use the DB credentials for the MessageBus connection.
+	if configuration.MessageQueue.Type == "redisstreams" {
+		secretProvider := bootstrapContainer.SecretProviderFrom(dic.Get)
+		credentials, err := secretProvider.GetSecrets(configuration.Databases["Primary"].Type)
+		if err != nil {
+			lc.Error(fmt.Sprintf("Error getting DB creds for RedisStreams: %s", err.Error()))
+			return false
+		}
+		configuration.MessageQueue.Optional["Password"] = credentials[secret.PasswordKey]
+	}
+
+	msgClient, err := messaging.NewMessageClient(
+		msgTypes.MessageBusConfig{
+			SubscribeHost: msgTypes.HostInfo{
+				Host:     configuration.MessageQueue.Host,
+				Port:     configuration.MessageQueue.Port,
+				Protocol: configuration.MessageQueue.Protocol,
+			},
+			Type:     configuration.MessageQueue.Type,
+			Optional: configuration.MessageQueue.Optional,
+		})
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to create messaging client: %s", err.Error()))
+		return false
+	}
+
+	for startupTimer.HasNotElapsed() {
+		err = msgClient.Connect()
+		if err == nil {
+			break
+		}
+
+		lc.Warn(fmt.Sprintf("couldn't connect to message bus: %s", err.Error()))
+		startupTimer.SleepForInterval()
+	}
+	if err != nil {
+		lc.Error("failed to connect to message bus in allotted time")
+		return false
+	}
+
+	dbClient := container.DBClientFrom(dic.Get)
+	client := notificationsContainer.RestClientFrom(dic.Get)
+	pool := notificationsContainer.DeliveryPoolFrom(dic.Get)
+	smtpAuth := notificationsContainer.SmtpAuthenticatorFrom(dic.Get)
+	v2DBClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	notify := func(ctx context.Context, n models.Notification) {
+		notifySystemEvent(ctx, n, lc, dbClient, client, pool, smtpAuth, v2DBClient, v2DBClient, *notificationsContainer.ConfigurationFrom(dic.Get))
+	}
+	err = systemevents.Run(ctx, wg, lc, msgClient, configuration.SystemEvents, db.MakeTimestamp, notify)
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to subscribe to the system events: %s", err.Error()))
+		_ = msgClient.Disconnect()
+		return false
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if err := msgClient.Disconnect(); err != nil {
+			lc.Error("failed to disconnect from the Message Bus")
+			return
+		}
+		lc.Info("Message Bus disconnected")
+	}()
+
+	lc.Info(fmt.Sprintf("generating notifications from the system events of topics %v of %s Message Bus @ %s",
+		configuration.SystemEvents.Topics, configuration.MessageQueue.Type, configuration.MessageQueue.URL()))
+	return true
+}
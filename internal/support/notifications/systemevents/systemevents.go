@@ -0,0 +1,270 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package systemevents turns the system events published on the message bus, like the devices added and removed by
+// core-metadata or reported down by their device service, into notifications, according to configured rules, so that
+// no application service is needed to be notified of them.
+package systemevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+)
+
+// Types of the objects the system events are about
+const (
+	Device = "device"
+)
+
+// Actions of the system events: the changes published by core-metadata, and the liveness changes published by the
+// device services
+const (
+	Add    = "add"
+	Update = "update"
+	Delete = "delete"
+	Down   = "down"
+	Up     = "up"
+)
+
+// SystemEvent is the message published on the system event and device liveness topics
+type SystemEvent struct {
+	// Type is the type of the object the event is about, e.g. "device"
+	Type string `json:"type"`
+	// Action is what happened to the object, e.g. "add", "delete" or "down"
+	Action string `json:"action"`
+	// Source is the service publishing the event
+	Source string `json:"source"`
+	// Owner is the service owning the object, e.g. the device service of a device
+	Owner string `json:"owner,omitempty"`
+	// Name is the name of the object
+	Name string `json:"name"`
+	// Details are the properties of the object, or the reason of the event
+	Details map[string]interface{} `json:"details,omitempty"`
+	// Timestamp is when the event happened, in milliseconds since the epoch
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Rule maps the matching system events to a notification. The events match when they are of Type and one of
+// Actions, and owned by one of Owners, the empty criteria matching any event.
+type Rule struct {
+	Name     string
+	Type     string
+	Actions  []string
+	Owners   []string
+	Category string
+	Severity string
+	Labels   []string
+	// Description is the description of the notifications
+	Description string
+	// Content is the text/template of the content of the notifications, executed with the SystemEvent, e.g.
+	// "Device {{.Name}} of {{.Owner}} is down"
+	Content string
+}
+
+// SystemEventsInfo configures the notifications generated from the system events
+type SystemEventsInfo struct {
+	// Enabled subscribes to Topics of the message bus configured in [MessageQueue]
+	Enabled bool
+	// Topics are the system event and device liveness topics subscribed to
+	Topics []string
+	// Sender is the sender of the notifications
+	Sender string
+	// Rules are checked in order, the first rule matching an event generating its notification. The events no rule
+	// matches are ignored.
+	Rules []Rule
+}
+
+// Subscriber subscribes to topics of the message bus, e.g. a messaging.MessageClient
+type Subscriber interface {
+	Subscribe(topics []msgTypes.TopicChannel, messageErrors chan error) error
+}
+
+// Validate checks the rules, parsing their content templates
+func (info SystemEventsInfo) Validate() error {
+	if len(info.Topics) == 0 {
+		return fmt.Errorf("no SystemEvents.Topics to subscribe to")
+	}
+	names := make(map[string]bool)
+	for _, r := range info.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if names[r.Name] {
+			return fmt.Errorf("duplicate system event rule '%s'", r.Name)
+		}
+		names[r.Name] = true
+	}
+	return nil
+}
+
+// Validate checks the category and severity of the notifications of the rule, and parses its content template
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("system event rule without name")
+	}
+	switch r.Category {
+	case models.Security, models.Hwhealth, models.Swhealth:
+	default:
+		return fmt.Errorf("system event rule '%s' has invalid category '%s'", r.Name, r.Category)
+	}
+	switch r.Severity {
+	case models.Critical, models.Normal:
+	default:
+		return fmt.Errorf("system event rule '%s' has invalid severity '%s'", r.Name, r.Severity)
+	}
+	if r.Content == "" {
+		return fmt.Errorf("system event rule '%s' has no content", r.Name)
+	}
+	if _, err := template.New(r.Name).Parse(r.Content); err != nil {
+		return fmt.Errorf("system event rule '%s' has invalid content: %s", r.Name, err.Error())
+	}
+	return nil
+}
+
+// Matches returns whether the rule applies to the system event
+func (r Rule) Matches(e SystemEvent) bool {
+	if r.Type != "" && !strings.EqualFold(r.Type, e.Type) {
+		return false
+	}
+	return matchesAny(r.Actions, e.Action) && matchesAny(r.Owners, e.Owner)
+}
+
+func matchesAny(criteria []string, value string) bool {
+	if len(criteria) == 0 {
+		return true
+	}
+	for _, c := range criteria {
+		if strings.EqualFold(c, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode decodes the system event carried by a message envelope
+func Decode(envelope msgTypes.MessageEnvelope) (SystemEvent, error) {
+	var e SystemEvent
+	if envelope.ContentType != "" && envelope.ContentType != clients.ContentTypeJSON {
+		return e, fmt.Errorf("unsupported content type '%s'", envelope.ContentType)
+	}
+	if err := json.Unmarshal(envelope.Payload, &e); err != nil {
+		return e, err
+	}
+	if e.Type == "" || e.Action == "" || e.Name == "" {
+		return e, fmt.Errorf("system event must have a type, an action and a name")
+	}
+	return e, nil
+}
+
+// ToNotification returns the notification of the system event generated by the first rule matching it, and false
+// when no rule matches it. now, in milliseconds, makes the slug unique.
+func ToNotification(e SystemEvent, rules []Rule, sender string, now int64) (models.Notification, bool, error) {
+	for _, r := range rules {
+		if !r.Matches(e) {
+			continue
+		}
+		t, err := template.New(r.Name).Option("missingkey=zero").Parse(r.Content)
+		if err != nil {
+			return models.Notification{}, false, err
+		}
+		var content bytes.Buffer
+		if err = t.Execute(&content, e); err != nil {
+			return models.Notification{}, false, fmt.Errorf("system event rule '%s' failed to render: %s", r.Name, err.Error())
+		}
+
+		return models.Notification{
+			Slug:        fmt.Sprintf("%s-%s-%d", r.Name, e.Name, now),
+			Sender:      sender,
+			Category:    models.NotificationsCategory(r.Category),
+			Severity:    models.NotificationsSeverity(r.Severity),
+			Content:     content.String(),
+			Description: r.Description,
+			Labels:      append([]string{}, r.Labels...),
+		}, true, nil
+	}
+	return models.Notification{}, false, nil
+}
+
+// Run subscribes to the topics of info and calls notify with the notification of each system event received, until
+// ctx is done
+func Run(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	lc logger.LoggingClient,
+	subscriber Subscriber,
+	info SystemEventsInfo,
+	now func() int64,
+	notify func(ctx context.Context, n models.Notification)) error {
+
+	messages := make(chan msgTypes.MessageEnvelope)
+	messageErrors := make(chan error)
+	topics := make([]msgTypes.TopicChannel, len(info.Topics))
+	for i, topic := range info.Topics {
+		topics[i] = msgTypes.TopicChannel{Topic: topic, Messages: messages}
+	}
+	if err := subscriber.Subscribe(topics, messageErrors); err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-messageErrors:
+				lc.Error(fmt.Sprintf("failed to receive system events: %s", err.Error()))
+			case envelope := <-messages:
+				handle(envelope, lc, info, now, notify)
+			}
+		}
+	}()
+	return nil
+}
+
+func handle(
+	envelope msgTypes.MessageEnvelope,
+	lc logger.LoggingClient,
+	info SystemEventsInfo,
+	now func() int64,
+	notify func(ctx context.Context, n models.Notification)) {
+
+	ctx := context.Background()
+	if envelope.CorrelationID != "" {
+		ctx = context.WithValue(ctx, clients.CorrelationHeader, envelope.CorrelationID)
+	}
+	ctx = correlation.NewContext(ctx)
+
+	e, err := Decode(envelope)
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to decode system event: %s", err.Error()),
+			clients.CorrelationHeader, correlation.FromContext(ctx))
+		return
+	}
+	n, ok, err := ToNotification(e, info.Rules, info.Sender, now())
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlation.FromContext(ctx))
+		return
+	}
+	if !ok {
+		lc.Debug(fmt.Sprintf("no rule matches system event %s %s %s", e.Type, e.Action, e.Name),
+			clients.CorrelationHeader, correlation.FromContext(ctx))
+		return
+	}
+	notify(ctx, n)
+}
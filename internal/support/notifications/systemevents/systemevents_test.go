@@ -0,0 +1,203 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package systemevents
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	deviceDown = Rule{
+		Name:        "device-down",
+		Type:        Device,
+		Actions:     []string{Down},
+		Category:    models.Hwhealth,
+		Severity:    models.Critical,
+		Labels:      []string{"device-liveness"},
+		Description: "Device down",
+		Content:     "Device {{.Name}} of device service {{.Owner}} is down: {{.Details.reason}}",
+	}
+	deviceAdded = Rule{
+		Name:     "device-added",
+		Type:     Device,
+		Actions:  []string{Add},
+		Owners:   []string{"device-virtual"},
+		Category: models.Swhealth,
+		Severity: models.Normal,
+		Content:  "Device {{.Name}} was added",
+	}
+	anyDevice = Rule{
+		Name:     "any-device",
+		Type:     Device,
+		Category: models.Swhealth,
+		Severity: models.Normal,
+		Content:  "Device {{.Name}}: {{.Action}}",
+	}
+)
+
+func TestRuleValidate(t *testing.T) {
+	invalidCategory := deviceDown
+	invalidCategory.Category = "HEALTH"
+	invalidSeverity := deviceDown
+	invalidSeverity.Severity = "HIGH"
+	noContent := deviceDown
+	noContent.Content = ""
+	invalidContent := deviceDown
+	invalidContent.Content = "Device {{.Name"
+	noName := deviceDown
+	noName.Name = ""
+
+	tests := []struct {
+		name          string
+		rule          Rule
+		expectedError bool
+	}{
+		{"valid", deviceDown, false},
+		{"invalid category", invalidCategory, true},
+		{"invalid severity", invalidSeverity, true},
+		{"no content", noContent, true},
+		{"invalid content", invalidContent, true},
+		{"no name", noName, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSystemEventsInfoValidate(t *testing.T) {
+	topics := []string{"edgex/system-events"}
+	assert.NoError(t, SystemEventsInfo{Topics: topics, Rules: []Rule{deviceDown, deviceAdded}}.Validate())
+	assert.Error(t, SystemEventsInfo{Rules: []Rule{deviceDown}}.Validate(), "topics are required")
+	assert.Error(t, SystemEventsInfo{Topics: topics, Rules: []Rule{deviceDown, deviceDown}}.Validate(), "rule names must be unique")
+}
+
+func TestRuleMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		event    SystemEvent
+		expected bool
+	}{
+		{"type and action", deviceDown, SystemEvent{Type: Device, Action: Down, Owner: "device-modbus"}, true},
+		{"case insensitive", deviceDown, SystemEvent{Type: "Device", Action: "DOWN"}, true},
+		{"other action", deviceDown, SystemEvent{Type: Device, Action: Up}, false},
+		{"other type", deviceDown, SystemEvent{Type: "deviceservice", Action: Down}, false},
+		{"owner", deviceAdded, SystemEvent{Type: Device, Action: Add, Owner: "device-virtual"}, true},
+		{"other owner", deviceAdded, SystemEvent{Type: Device, Action: Add, Owner: "device-modbus"}, false},
+		{"any action and owner", anyDevice, SystemEvent{Type: Device, Action: Update, Owner: "device-modbus"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Matches(tt.event))
+		})
+	}
+}
+
+func newEnvelope(t *testing.T, e SystemEvent) msgTypes.MessageEnvelope {
+	payload, err := json.Marshal(e)
+	require.NoError(t, err)
+	return msgTypes.MessageEnvelope{CorrelationID: "correlation-id", Payload: payload, ContentType: clients.ContentTypeJSON}
+}
+
+func TestDecode(t *testing.T) {
+	expected := SystemEvent{Type: Device, Action: Down, Source: "device-modbus", Owner: "device-modbus", Name: "Boiler",
+		Timestamp: 1600000000000}
+	e, err := Decode(newEnvelope(t, expected))
+	require.NoError(t, err)
+	assert.Equal(t, expected, e)
+
+	_, err = Decode(newEnvelope(t, SystemEvent{Type: Device, Action: Down}))
+	assert.Error(t, err, "the name is required")
+	_, err = Decode(msgTypes.MessageEnvelope{Payload: []byte("{"), ContentType: clients.ContentTypeJSON})
+	assert.Error(t, err, "the payload must be JSON")
+	_, err = Decode(msgTypes.MessageEnvelope{Payload: []byte("{}"), ContentType: clients.ContentTypeCBOR})
+	assert.Error(t, err, "CBOR isn't supported")
+}
+
+func TestToNotification(t *testing.T) {
+	rules := []Rule{deviceDown, deviceAdded, anyDevice}
+	down := SystemEvent{Type: Device, Action: Down, Owner: "device-modbus", Name: "Boiler",
+		Details: map[string]interface{}{"reason": "no response"}}
+
+	n, ok, err := ToNotification(down, rules, "support-notifications", 1600000000000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "device-down-Boiler-1600000000000", n.Slug)
+	assert.Equal(t, "support-notifications", n.Sender)
+	assert.Equal(t, models.NotificationsCategory(models.Hwhealth), n.Category)
+	assert.Equal(t, models.NotificationsSeverity(models.Critical), n.Severity)
+	assert.Equal(t, "Device Boiler of device service device-modbus is down: no response", n.Content)
+	assert.Equal(t, "Device down", n.Description)
+	assert.Equal(t, []string{"device-liveness"}, n.Labels)
+
+	// the first matching rule applies
+	n, ok, err = ToNotification(SystemEvent{Type: Device, Action: Add, Owner: "device-modbus", Name: "Boiler"}, rules, "", 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "Device Boiler: add", n.Content)
+
+	_, ok, err = ToNotification(SystemEvent{Type: "deviceservice", Action: Add, Name: "device-modbus"}, rules, "", 1)
+	require.NoError(t, err)
+	assert.False(t, ok, "no rule matches the event")
+}
+
+type mockSubscriber struct {
+	topics []msgTypes.TopicChannel
+}
+
+func (s *mockSubscriber) Subscribe(topics []msgTypes.TopicChannel, _ chan error) error {
+	s.topics = topics
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	subscriber := &mockSubscriber{}
+	info := SystemEventsInfo{Topics: []string{"edgex/system-events", "edgex/device-liveness"}, Rules: []Rule{deviceDown}}
+	notified := make(chan models.Notification, 1)
+	notify := func(ctx context.Context, n models.Notification) {
+		assert.Equal(t, "correlation-id", ctx.Value(clients.CorrelationHeader))
+		notified <- n
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	err := Run(ctx, wg, logger.NewMockClient(), subscriber, info, func() int64 { return 1 }, notify)
+	require.NoError(t, err)
+	require.Len(t, subscriber.topics, 2)
+	assert.Equal(t, "edgex/device-liveness", subscriber.topics[1].Topic)
+
+	subscriber.topics[0].Messages <- newEnvelope(t, SystemEvent{Type: Device, Action: Up, Name: "Boiler"})
+	subscriber.topics[1].Messages <- newEnvelope(t, SystemEvent{Type: Device, Action: Down, Name: "Boiler"})
+	select {
+	case n := <-notified:
+		assert.Equal(t, "device-down-Boiler-1", n.Slug)
+	case <-time.After(time.Second):
+		assert.Fail(t, "the device down event wasn't notified")
+	}
+	assert.Len(t, notified, 0, "the device up event matches no rule")
+
+	cancel()
+	wg.Wait()
+}
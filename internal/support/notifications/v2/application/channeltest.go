@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/channeltest"
+	notificationContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// TestChannel sends a test message through the channel of req and returns the diagnostics of its transport. The test
+// emails are sent through the configured Smtp server, authenticating as the notifications do, and the test messages
+// of the REST channels are signed as the notifications are.
+func TestChannel(req channeltest.TestChannelRequest, ctx context.Context, dic *di.Container) (channeltest.Diagnostics, errors.EdgeX) {
+	lc := container.LoggingClientFrom(dic.Get)
+	configuration := notificationContainer.ConfigurationFrom(dic.Get)
+
+	content := req.Content
+	if content == "" {
+		content = channeltest.DefaultContent
+	}
+	var diagnostics channeltest.Diagnostics
+	switch req.Channel.Type {
+	case models.Email:
+		if len(req.Channel.EmailAddresses) == 0 {
+			return diagnostics, errors.NewCommonEdgeX(errors.KindContractInvalid, "the email addresses of the EMAIL channel are empty", nil)
+		}
+		subject := req.Subject
+		if subject == "" {
+			subject = configuration.Smtp.Subject
+		}
+		smtpAuth := notificationContainer.SmtpAuthenticatorFrom(dic.Get)
+		diagnostics = channeltest.Smtp(ctx, configuration.Smtp, smtpAuth, req.Channel.EmailAddresses, subject, req.ContentType, content)
+	case models.Rest:
+		if req.Channel.Url == "" {
+			return diagnostics, errors.NewCommonEdgeX(errors.KindContractInvalid, "the url of the REST channel is empty", nil)
+		}
+		signer := notificationContainer.WebhookSignerFrom(dic.Get)
+		diagnostics = channeltest.Rest(ctx, req.Channel.Url, req.ContentType, content, signer)
+	default:
+		return diagnostics, errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("channel type '%s' is not supported, expected %s or %s", req.Channel.Type, models.Email, models.Rest), nil)
+	}
+
+	lc.Infof("Test message sent through the %s channel %s, succeeded: %t. Correlation-ID: %s",
+		diagnostics.Type,
+		diagnostics.Target,
+		diagnostics.Succeeded,
+		correlation.FromContext(ctx))
+	return diagnostics, nil
+}
@@ -0,0 +1,112 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// AddMuteWindow validates the new mute window and then adds it
+func AddMuteWindow(w mutes.MuteWindow, ctx context.Context, dic *di.Container) (id string, edgeXerr errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	if err := w.Validate(); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	addedWindow, err := dbClient.AddMuteWindow(w)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Mute window created on DB successfully. Mute window ID: %s, Correlation-ID: %s ",
+		addedWindow.Id,
+		correlation.FromContext(ctx))
+
+	return addedWindow.Id, nil
+}
+
+// AllMuteWindows queries mute windows by offset and limit
+func AllMuteWindows(offset, limit int, dic *di.Container) ([]mutes.MuteWindow, errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.AllMuteWindows(offset, limit)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
+
+// MuteWindowByName queries mute window by name
+func MuteWindowByName(name string, dic *di.Container) (window mutes.MuteWindow, err errors.EdgeX) {
+	if name == "" {
+		return window, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	window, err = dbClient.MuteWindowByName(name)
+	if err != nil {
+		return window, errors.NewCommonEdgeXWrapper(err)
+	}
+	return window, nil
+}
+
+// DeleteMuteWindowByName deletes the mute window by name
+func DeleteMuteWindowByName(name string, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteMuteWindowByName(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// PatchMuteWindow executes the PATCH operation with the mute window DTO to replace the old data, validating the result
+func PatchMuteWindow(ctx context.Context, dto mutes.UpdateMuteWindow, dic *di.Container) errors.EdgeX {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	var window mutes.MuteWindow
+	var edgexErr errors.EdgeX
+	switch {
+	case dto.Id != nil:
+		window, edgexErr = dbClient.MuteWindowById(*dto.Id)
+	case dto.Name != nil:
+		window, edgexErr = dbClient.MuteWindowByName(*dto.Name)
+	default:
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "the mute window id or name is required", nil)
+	}
+	if edgexErr != nil {
+		return errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+	if dto.Name != nil && *dto.Name != window.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("mute window name '%s' not match the existing '%s' ", *dto.Name, window.Name), nil)
+	}
+
+	dto.Apply(&window)
+	if edgexErr = window.Validate(); edgexErr != nil {
+		return errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+
+	edgexErr = dbClient.UpdateMuteWindow(window)
+	if edgexErr != nil {
+		return errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+
+	lc.Debugf("Mute window patched on DB successfully. Correlation-ID: %s ", correlation.FromContext(ctx))
+	return nil
+}
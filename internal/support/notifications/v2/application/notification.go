@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// JobDeleteProcessedNotificationsByAge is the type of the job deleting the processed notifications by age
+const JobDeleteProcessedNotificationsByAge = "DeleteProcessedNotificationsByAge"
+
+// SubmitDeleteProcessedNotificationsByAge submits the job deleting the processed notifications not modified for age
+// milliseconds, by batches run in the background. The notifications modified since the job was submitted are kept.
+func SubmitDeleteProcessedNotificationsByAge(age int, dic *di.Container) (jobs.Job, errors.EdgeX) {
+	if age < 0 {
+		return jobs.Job{}, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("age %d must not be negative", age), nil)
+	}
+	dbClient := pkgContainer.DBClientFrom(dic.Get)
+	end := common.MakeTimestamp() - int64(age)
+
+	// the notifications left unprocessed stay in the range scanned, so the next batch skips them
+	offset := 0
+	job, err := pkgContainer.JobManagerFrom(dic.Get).Submit(JobDeleteProcessedNotificationsByAge,
+		fmt.Sprintf("processed notifications modified before %d", end), 0,
+		func(limit int) (uint32, bool, errors.EdgeX) {
+			deleted, scanned, err := dbClient.DeleteProcessedNotificationsBefore(end, offset, limit)
+			if err != nil {
+				return uint32(deleted), false, errors.NewCommonEdgeX(errors.KindDatabaseError, "processed notifications deletion failed", err)
+			}
+			offset += scanned - deleted
+			return uint32(deleted), scanned < limit, nil
+		})
+	if err != nil {
+		return jobs.Job{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	return job, nil
+}
@@ -0,0 +1,160 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// AddTemplate validates the new template, parsing its contents, and then adds it
+func AddTemplate(t templates.Template, ctx context.Context, dic *di.Container) (id string, edgeXerr errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	if err := t.Validate(); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	addedTemplate, err := dbClient.AddTemplate(t)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Template created on DB successfully. Template ID: %s, Correlation-ID: %s ",
+		addedTemplate.Id,
+		correlation.FromContext(ctx))
+
+	return addedTemplate.Id, nil
+}
+
+// AllTemplates queries templates by offset and limit
+func AllTemplates(offset, limit int, dic *di.Container) ([]templates.Template, errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.AllTemplates(offset, limit)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
+
+// TemplateByName queries template by name
+func TemplateByName(name string, dic *di.Container) (template templates.Template, err errors.EdgeX) {
+	if name == "" {
+		return template, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	template, err = dbClient.TemplateByName(name)
+	if err != nil {
+		return template, errors.NewCommonEdgeXWrapper(err)
+	}
+	return template, nil
+}
+
+// DeleteTemplateByName deletes the template by name
+func DeleteTemplateByName(name string, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteTemplateByName(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// PatchTemplate executes the PATCH operation with the template DTO to replace the old data, validating the result
+func PatchTemplate(ctx context.Context, dto templates.UpdateTemplate, dic *di.Container) errors.EdgeX {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	var template templates.Template
+	var edgexErr errors.EdgeX
+	switch {
+	case dto.Id != nil:
+		template, edgexErr = dbClient.TemplateById(*dto.Id)
+	case dto.Name != nil:
+		template, edgexErr = dbClient.TemplateByName(*dto.Name)
+	default:
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "the template id or name is required", nil)
+	}
+	if edgexErr != nil {
+		return errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+	if dto.Name != nil && *dto.Name != template.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("template name '%s' not match the existing '%s' ", *dto.Name, template.Name), nil)
+	}
+
+	dto.Apply(&template)
+	if edgexErr = template.Validate(); edgexErr != nil {
+		return errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+
+	edgexErr = dbClient.UpdateTemplate(template)
+	if edgexErr != nil {
+		return errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+
+	lc.Debugf("Template patched on DB successfully. Correlation-ID: %s ", correlation.FromContext(ctx))
+	return nil
+}
+
+// SubscriptionLocale queries the locale of the subscription by name, empty when it has none
+func SubscriptionLocale(name string, dic *di.Container) (string, errors.EdgeX) {
+	if name == "" {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	locale, err := dbClient.SubscriptionLocale(name)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	return locale, nil
+}
+
+// SetSubscriptionLocale sets the locale of the notifications sent to the subscriber of the subscription by name
+func SetSubscriptionLocale(ctx context.Context, name string, locale string, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	if strings.TrimSpace(locale) == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "locale is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	err := dbClient.SetSubscriptionLocale(name, locale)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Locale of subscription %s set on DB successfully. Correlation-ID: %s ", name, correlation.FromContext(ctx))
+	return nil
+}
+
+// DeleteSubscriptionLocale deletes the locale of the subscription by name, whose notifications are then rendered in
+// the default locale of the templates
+func DeleteSubscriptionLocale(name string, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteSubscriptionLocale(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
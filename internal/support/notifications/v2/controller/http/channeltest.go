@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/channeltest"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/io"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+type ChannelTestController struct {
+	reader io.ChannelTestReader
+	dic    *di.Container
+}
+
+// NewChannelTestController creates and initializes a ChannelTestController
+func NewChannelTestController(dic *di.Container) *ChannelTestController {
+	return &ChannelTestController{
+		reader: io.NewChannelTestRequestReader(),
+		dic:    dic,
+	}
+}
+
+func (ctc *ChannelTestController) TestChannel(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(ctc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	request, err := ctc.reader.ReadTestChannelRequest(r.Body)
+	var diagnostics channeltest.Diagnostics
+	if err == nil {
+		diagnostics, err = application.TestChannel(request, ctx, ctc.dic)
+	}
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse(request.RequestId, err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = channeltest.TestChannelResponse{
+			BaseResponse: commonDTO.NewBaseResponse(request.RequestId, "", http.StatusOK),
+			Diagnostics:  diagnostics,
+		}
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
@@ -0,0 +1,78 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/channeltest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestChannel(t *testing.T) {
+	var received string
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+	}))
+	defer webhook.Close()
+
+	controller := NewChannelTestController(mockDic())
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		channel            dtos.Channel
+		expectedStatusCode int
+	}{
+		{"Valid - REST channel", dtos.Channel{Type: models.Rest, Url: webhook.URL}, http.StatusOK},
+		{"Invalid - REST channel without url", dtos.Channel{Type: models.Rest}, http.StatusBadRequest},
+		{"Invalid - EMAIL channel without addresses", dtos.Channel{Type: models.Email}, http.StatusBadRequest},
+		{"Invalid - SMS channel", dtos.Channel{Type: "SMS", Url: "tel:+15555550100"}, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := channeltest.TestChannelRequest{
+				BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+				Channel:     testCase.channel,
+			}
+			jsonData, err := json.Marshal(request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, channeltest.ApiChannelTestRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.TestChannel)
+			handler.ServeHTTP(recorder, req)
+			var res channeltest.TestChannelResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "BaseResponse status code not as expected")
+			assert.Equal(t, ExampleUUID, res.RequestId, "RequestID not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.True(t, res.Diagnostics.Succeeded)
+				assert.Equal(t, channeltest.DefaultContent, received)
+			} else {
+				assert.NotEmpty(t, res.Message, "Message is empty")
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testMuteWindowName = "muteWindowName"
+
+func muteWindowData() mutes.MuteWindow {
+	return mutes.MuteWindow{
+		Name:         testMuteWindowName,
+		Categories:   []string{"HW_HEALTH"},
+		DeviceGroups: []string{"building-1"},
+		Start:        1609538400000,
+		End:          1609545600000,
+		Recurrence:   mutes.Weekly,
+		Action:       mutes.Queue,
+	}
+}
+
+func addMuteWindowRequestData() mutes.AddMuteWindowRequest {
+	return mutes.AddMuteWindowRequest{
+		BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+		MuteWindow:  muteWindowData(),
+	}
+}
+
+func TestAddMuteWindow(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+
+	valid := addMuteWindowRequestData()
+	added := valid.MuteWindow
+	added.Id = ExampleUUID
+	dbClientMock.On("AddMuteWindow", valid.MuteWindow).Return(added, nil)
+
+	duplicatedName := addMuteWindowRequestData()
+	duplicatedName.MuteWindow.Name = "duplicatedName"
+	dbClientMock.On("AddMuteWindow", duplicatedName.MuteWindow).Return(duplicatedName.MuteWindow,
+		errors.NewCommonEdgeX(errors.KindDuplicateName, "mute window name duplicatedName already exists", nil))
+
+	endBeforeStart := addMuteWindowRequestData()
+	endBeforeStart.MuteWindow.End = endBeforeStart.MuteWindow.Start - 1
+	longerThanRecurrence := addMuteWindowRequestData()
+	longerThanRecurrence.MuteWindow.Recurrence = mutes.Daily
+	longerThanRecurrence.MuteWindow.End = longerThanRecurrence.MuteWindow.Start + 25*60*60*1000
+	unknownAction := addMuteWindowRequestData()
+	unknownAction.MuteWindow.Action = "DROP"
+
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewMuteWindowController(dic)
+
+	tests := []struct {
+		name               string
+		request            mutes.AddMuteWindowRequest
+		expectedStatusCode int
+	}{
+		{"Valid", valid, http.StatusCreated},
+		{"Invalid - duplicated name", duplicatedName, http.StatusConflict},
+		{"Invalid - ends before it starts", endBeforeStart, http.StatusBadRequest},
+		{"Invalid - longer than its recurrence", longerThanRecurrence, http.StatusBadRequest},
+		{"Invalid - unknown action", unknownAction, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal([]mutes.AddMuteWindowRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, mutes.ApiMuteWindowRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.AddMuteWindow).ServeHTTP(recorder, req)
+
+			var res []common.BaseWithIdResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "BaseResponse status code not as expected")
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res[0].Id)
+			} else {
+				assert.NotEmpty(t, res[0].Message, "Message is empty")
+			}
+		})
+	}
+}
+
+func TestMuteWindowByName(t *testing.T) {
+	window := muteWindowData()
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("MuteWindowByName", window.Name).Return(window, nil)
+	dbClientMock.On("MuteWindowByName", "notFound").Return(mutes.MuteWindow{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "mute window doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewMuteWindowController(dic)
+
+	tests := []struct {
+		name               string
+		windowName         string
+		expectedStatusCode int
+	}{
+		{"Valid - find mute window by name", window.Name, http.StatusOK},
+		{"Invalid - mute window not found by name", "notFound", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, mutes.ApiMuteWindowByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.windowName})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.MuteWindowByName).ServeHTTP(recorder, req)
+
+			var res mutes.MuteWindowResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, testCase.windowName, res.MuteWindow.Name, "Name not as expected")
+			}
+		})
+	}
+}
+
+func TestPatchMuteWindow(t *testing.T) {
+	stored := muteWindowData()
+	stored.Id = ExampleUUID
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("MuteWindowByName", stored.Name).Return(stored, nil)
+	dbClientMock.On("MuteWindowById", stored.Id).Return(stored, nil)
+	dbClientMock.On("UpdateMuteWindow", mock.Anything).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewMuteWindowController(dic)
+
+	name := stored.Name
+	id := stored.Id
+	otherName := "otherName"
+	suppress := mutes.Suppress
+	noRecurrence := ""
+	endBeforeStart := stored.Start - 1
+	tests := []struct {
+		name               string
+		update             mutes.UpdateMuteWindow
+		expectedStatusCode int
+	}{
+		{"Valid - by name", mutes.UpdateMuteWindow{Name: &name, Action: &suppress}, http.StatusOK},
+		{"Valid - by id", mutes.UpdateMuteWindow{Id: &id, Recurrence: &noRecurrence}, http.StatusOK},
+		{"Invalid - no id or name", mutes.UpdateMuteWindow{Action: &suppress}, http.StatusBadRequest},
+		{"Invalid - name mismatch", mutes.UpdateMuteWindow{Id: &id, Name: &otherName}, http.StatusBadRequest},
+		{"Invalid - ends before it starts", mutes.UpdateMuteWindow{Name: &name, End: &endBeforeStart}, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := mutes.UpdateMuteWindowRequest{
+				BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+				MuteWindow:  testCase.update,
+			}
+			jsonData, err := json.Marshal([]mutes.UpdateMuteWindowRequest{request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPatch, mutes.ApiMuteWindowRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.PatchMuteWindow).ServeHTTP(recorder, req)
+
+			var res []common.BaseResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "BaseResponse status code not as expected")
+		})
+	}
+}
@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/application"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+// ApiNotificationByAgeRoute deletes the processed notifications not modified for an age in milliseconds, in a
+// background job
+const ApiNotificationByAgeRoute = v2.ApiBase + "/notification/" + v2.Age + "/{" + v2.Age + "}"
+
+type NotificationController struct {
+	dic *di.Container
+}
+
+// NewNotificationController creates and initializes a NotificationController
+func NewNotificationController(dic *di.Container) *NotificationController {
+	return &NotificationController{
+		dic: dic,
+	}
+}
+
+func (nc *NotificationController) DeleteProcessedNotificationsByAge(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(nc.dic.Get)
+
+	var job jobs.Job
+	age, err := utils.ParsePathParamToInt(r, v2.Age)
+	if err == nil {
+		job, err = application.SubmitDeleteProcessedNotificationsByAge(age, nc.dic)
+	}
+	jobs.WriteSubmitted(w, r, lc, job, err)
+}
@@ -0,0 +1,112 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db/memory"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteProcessedNotificationsByAge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	manager, err := jobs.NewManager(jobs.JobsInfo{BatchSize: 2}, logger.NewMockClient())
+	require.NoError(t, err)
+	manager.Start(ctx, wg)
+
+	dbClient := memory.NewClient()
+	statuses := []contract.NotificationsStatus{contract.Processed, contract.New, contract.Processed, contract.Processed, contract.Escalated, contract.Processed}
+	for i, status := range statuses {
+		_, err := dbClient.AddNotification(contract.Notification{
+			Slug:       fmt.Sprintf("notification-%d", i),
+			Status:     status,
+			Timestamps: contract.Timestamps{Created: int64(1000 + i), Modified: int64(1000 + i)},
+		})
+		require.NoError(t, err)
+	}
+
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		pkgContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClient
+		},
+		pkgContainer.JobManagerName: func(get di.Get) interface{} {
+			return manager
+		},
+	})
+	controller := NewNotificationController(dic)
+
+	tests := []struct {
+		name               string
+		age                string
+		expectedStatusCode int
+	}{
+		{"Valid", "0", http.StatusAccepted},
+		{"Invalid - age not a number", "day", http.StatusBadRequest},
+		{"Invalid - negative age", "-1", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodDelete, ApiNotificationByAgeRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Age: testCase.age})
+
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DeleteProcessedNotificationsByAge)
+			handler.ServeHTTP(recorder, req)
+
+			var res common.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+			if testCase.expectedStatusCode != http.StatusAccepted {
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+				return
+			}
+			assert.Equal(t, jobs.ApiJobRoute+"/"+v2.Id+"/"+res.Id, recorder.Header().Get("Location"))
+
+			var job jobs.Job
+			require.Eventually(t, func() bool {
+				job, _ = manager.Job(res.Id)
+				return job.Status == jobs.StatusCompleted
+			}, time.Second, 10*time.Millisecond)
+			assert.Equal(t, uint32(4), job.Processed)
+
+			notifications, err := dbClient.GetNotifications()
+			require.NoError(t, err)
+			require.Len(t, notifications, 2, "the unprocessed notifications should be kept")
+			for _, n := range notifications {
+				assert.NotEqual(t, contract.Processed, n.Status)
+			}
+		})
+	}
+}
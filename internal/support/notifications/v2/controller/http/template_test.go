@@ -0,0 +1,357 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testTemplateName = "templateName"
+
+func templateData() templates.Template {
+	return templates.Template{
+		Name:          testTemplateName,
+		Categories:    []string{"HW_HEALTH"},
+		DefaultLocale: "en",
+		Contents: map[string]templates.Content{
+			"en": {Subject: "[{{.Severity}}] {{.Slug}}", Body: "Device alert: {{.Content}}"},
+			"fr": {Subject: "[{{.Severity}}] {{.Slug}}", Body: "Alerte équipement : {{.Content}}"},
+		},
+	}
+}
+
+func addTemplateRequestData() templates.AddTemplateRequest {
+	return templates.AddTemplateRequest{
+		BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+		Template:    templateData(),
+	}
+}
+
+func TestAddTemplate(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+
+	valid := addTemplateRequestData()
+	added := valid.Template
+	added.Id = ExampleUUID
+	dbClientMock.On("AddTemplate", valid.Template).Return(added, nil)
+
+	duplicatedName := addTemplateRequestData()
+	duplicatedName.Template.Name = "duplicatedName"
+	dbClientMock.On("AddTemplate", duplicatedName.Template).Return(duplicatedName.Template,
+		errors.NewCommonEdgeX(errors.KindDuplicateName, "template name duplicatedName already exists", nil))
+
+	noDefaultContent := addTemplateRequestData()
+	noDefaultContent.Template.DefaultLocale = "de"
+	unparsableBody := addTemplateRequestData()
+	unparsableBody.Template.Contents["fr"] = templates.Content{Body: "{{.Content"}
+	noCategories := addTemplateRequestData()
+	noCategories.Template.Categories = nil
+
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		request            templates.AddTemplateRequest
+		expectedStatusCode int
+	}{
+		{"Valid", valid, http.StatusCreated},
+		{"Invalid - duplicated name", duplicatedName, http.StatusConflict},
+		{"Invalid - no content in the default locale", noDefaultContent, http.StatusBadRequest},
+		{"Invalid - body doesn't parse", unparsableBody, http.StatusBadRequest},
+		{"Invalid - no categories", noCategories, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal([]templates.AddTemplateRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, templates.ApiTemplateRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.AddTemplate).ServeHTTP(recorder, req)
+
+			var res []common.BaseWithIdResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "BaseResponse status code not as expected")
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res[0].Id)
+			} else {
+				assert.NotEmpty(t, res[0].Message, "Message is empty")
+			}
+		})
+	}
+}
+
+func TestTemplateByName(t *testing.T) {
+	template := templateData()
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("TemplateByName", template.Name).Return(template, nil)
+	dbClientMock.On("TemplateByName", "notFound").Return(templates.Template{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "template doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		templateName       string
+		expectedStatusCode int
+	}{
+		{"Valid - find template by name", template.Name, http.StatusOK},
+		{"Invalid - template not found by name", "notFound", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, templates.ApiTemplateByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.templateName})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.TemplateByName).ServeHTTP(recorder, req)
+
+			var res templates.TemplateResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, testCase.templateName, res.Template.Name, "Name not as expected")
+			}
+		})
+	}
+}
+
+func TestPatchTemplate(t *testing.T) {
+	stored := templateData()
+	stored.Id = ExampleUUID
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("TemplateByName", stored.Name).Return(stored, nil)
+	dbClientMock.On("TemplateById", stored.Id).Return(stored, nil)
+	dbClientMock.On("UpdateTemplate", mock.Anything).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewTemplateController(dic)
+
+	name := stored.Name
+	id := stored.Id
+	otherName := "otherName"
+	unknownLocale := "de"
+	tests := []struct {
+		name               string
+		update             templates.UpdateTemplate
+		expectedStatusCode int
+	}{
+		{"Valid - by name", templates.UpdateTemplate{Name: &name, Labels: []string{"building-1"}}, http.StatusOK},
+		{"Valid - by id", templates.UpdateTemplate{Id: &id, Categories: []string{"SW_HEALTH"}}, http.StatusOK},
+		{"Invalid - no id or name", templates.UpdateTemplate{Labels: []string{"building-1"}}, http.StatusBadRequest},
+		{"Invalid - name mismatch", templates.UpdateTemplate{Id: &id, Name: &otherName}, http.StatusBadRequest},
+		{"Invalid - no content in the default locale", templates.UpdateTemplate{Name: &name, DefaultLocale: &unknownLocale}, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := templates.UpdateTemplateRequest{
+				BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+				Template:    testCase.update,
+			}
+			jsonData, err := json.Marshal([]templates.UpdateTemplateRequest{request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPatch, templates.ApiTemplateRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.PatchTemplate).ServeHTTP(recorder, req)
+
+			var res []common.BaseResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "BaseResponse status code not as expected")
+		})
+	}
+}
+
+func TestDeleteTemplateByName(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeleteTemplateByName", testTemplateName).Return(nil)
+	dbClientMock.On("DeleteTemplateByName", "notFound").Return(
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "template doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		templateName       string
+		expectedStatusCode int
+	}{
+		{"Valid - delete template by name", testTemplateName, http.StatusNoContent},
+		{"Invalid - template not found by name", "notFound", http.StatusNotFound},
+		{"Invalid - name parameter is empty", "", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodDelete, templates.ApiTemplateByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.templateName})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.DeleteTemplateByName).ServeHTTP(recorder, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+}
+
+func TestSubscriptionLocale(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("SubscriptionLocale", testSubscriptionName).Return("fr-CA", nil)
+	dbClientMock.On("SubscriptionLocale", "noLocale").Return("", nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		subscriptionName   string
+		expectedStatusCode int
+		expectedLocale     string
+	}{
+		{"Valid - subscription with a locale", testSubscriptionName, http.StatusOK, "fr-CA"},
+		{"Valid - subscription without locale", "noLocale", http.StatusOK, ""},
+		{"Invalid - name parameter is empty", "", http.StatusBadRequest, ""},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, templates.ApiSubscriptionLocaleRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.subscriptionName})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.SubscriptionLocale).ServeHTTP(recorder, req)
+
+			var res templates.SubscriptionLocaleResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedLocale, res.Locale, "Locale not as expected")
+		})
+	}
+}
+
+func TestSetSubscriptionLocale(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("SetSubscriptionLocale", testSubscriptionName, "fr-CA").Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		subscriptionName   string
+		body               string
+		expectedStatusCode int
+	}{
+		{"Valid - set locale", testSubscriptionName, `{"apiVersion":"v2","locale":"fr-CA"}`, http.StatusOK},
+		{"Invalid - empty locale", testSubscriptionName, `{"apiVersion":"v2","locale":" "}`, http.StatusBadRequest},
+		{"Invalid - name parameter is empty", "", `{"apiVersion":"v2","locale":"fr-CA"}`, http.StatusBadRequest},
+		{"Invalid - bad JSON", testSubscriptionName, `{"locale":`, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPut, templates.ApiSubscriptionLocaleRoute, strings.NewReader(testCase.body))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.subscriptionName})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.SetSubscriptionLocale).ServeHTTP(recorder, req)
+
+			var res common.BaseResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "BaseResponse status code not as expected")
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "SetSubscriptionLocale", 1)
+}
+
+func TestDeleteSubscriptionLocale(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeleteSubscriptionLocale", testSubscriptionName).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		subscriptionName   string
+		expectedStatusCode int
+	}{
+		{"Valid - reset locale", testSubscriptionName, http.StatusNoContent},
+		{"Invalid - name parameter is empty", "", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodDelete, templates.ApiSubscriptionLocaleRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.subscriptionName})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.DeleteSubscriptionLocale).ServeHTTP(recorder, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+}
@@ -6,6 +6,9 @@
 package interfaces
 
 import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
+
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 )
@@ -21,4 +24,24 @@ type DBClient interface {
 	SubscriptionsByLabel(offset, limit int, label string) ([]models.Subscription, errors.EdgeX)
 	SubscriptionsByReceiver(offset, limit int, receiver string) ([]models.Subscription, errors.EdgeX)
 	DeleteSubscriptionByName(name string) errors.EdgeX
+	SubscriptionLocale(name string) (string, errors.EdgeX)
+	SetSubscriptionLocale(name string, locale string) errors.EdgeX
+	DeleteSubscriptionLocale(name string) errors.EdgeX
+
+	AddTemplate(t templates.Template) (templates.Template, errors.EdgeX)
+	AllTemplates(offset int, limit int) ([]templates.Template, errors.EdgeX)
+	TemplateById(id string) (templates.Template, errors.EdgeX)
+	TemplateByName(name string) (templates.Template, errors.EdgeX)
+	UpdateTemplate(t templates.Template) errors.EdgeX
+	DeleteTemplateByName(name string) errors.EdgeX
+
+	AddMuteWindow(w mutes.MuteWindow) (mutes.MuteWindow, errors.EdgeX)
+	AllMuteWindows(offset int, limit int) ([]mutes.MuteWindow, errors.EdgeX)
+	MuteWindowById(id string) (mutes.MuteWindow, errors.EdgeX)
+	MuteWindowByName(name string) (mutes.MuteWindow, errors.EdgeX)
+	UpdateMuteWindow(w mutes.MuteWindow) errors.EdgeX
+	DeleteMuteWindowByName(name string) errors.EdgeX
+	HoldNotification(id string, created int64) errors.EdgeX
+	HeldNotifications(offset int, limit int) ([]string, errors.EdgeX)
+	ReleaseNotification(id string) errors.EdgeX
 }
@@ -8,6 +8,10 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	mutes "github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+
+	templates "github.com/edgexfoundry/edgex-go/internal/pkg/templates"
 )
 
 // DBClient is an autogenerated mock type for the DBClient type
@@ -15,6 +19,29 @@ type DBClient struct {
 	mock.Mock
 }
 
+// AddMuteWindow provides a mock function with given fields: w
+func (_m *DBClient) AddMuteWindow(w mutes.MuteWindow) (mutes.MuteWindow, errors.EdgeX) {
+	ret := _m.Called(w)
+
+	var r0 mutes.MuteWindow
+	if rf, ok := ret.Get(0).(func(mutes.MuteWindow) mutes.MuteWindow); ok {
+		r0 = rf(w)
+	} else {
+		r0 = ret.Get(0).(mutes.MuteWindow)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(mutes.MuteWindow) errors.EdgeX); ok {
+		r1 = rf(w)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AddSubscription provides a mock function with given fields: e
 func (_m *DBClient) AddSubscription(e models.Subscription) (models.Subscription, errors.EdgeX) {
 	ret := _m.Called(e)
@@ -38,6 +65,54 @@ func (_m *DBClient) AddSubscription(e models.Subscription) (models.Subscription,
 	return r0, r1
 }
 
+// AddTemplate provides a mock function with given fields: t
+func (_m *DBClient) AddTemplate(t templates.Template) (templates.Template, errors.EdgeX) {
+	ret := _m.Called(t)
+
+	var r0 templates.Template
+	if rf, ok := ret.Get(0).(func(templates.Template) templates.Template); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Get(0).(templates.Template)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(templates.Template) errors.EdgeX); ok {
+		r1 = rf(t)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AllMuteWindows provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllMuteWindows(offset int, limit int) ([]mutes.MuteWindow, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []mutes.MuteWindow
+	if rf, ok := ret.Get(0).(func(int, int) []mutes.MuteWindow); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]mutes.MuteWindow)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AllSubscriptions provides a mock function with given fields: offset, limit
 func (_m *DBClient) AllSubscriptions(offset int, limit int) ([]models.Subscription, errors.EdgeX) {
 	ret := _m.Called(offset, limit)
@@ -63,11 +138,52 @@ func (_m *DBClient) AllSubscriptions(offset int, limit int) ([]models.Subscripti
 	return r0, r1
 }
 
+// AllTemplates provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllTemplates(offset int, limit int) ([]templates.Template, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []templates.Template
+	if rf, ok := ret.Get(0).(func(int, int) []templates.Template); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]templates.Template)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // CloseSession provides a mock function with given fields:
 func (_m *DBClient) CloseSession() {
 	_m.Called()
 }
 
+// DeleteMuteWindowByName provides a mock function with given fields: name
+func (_m *DBClient) DeleteMuteWindowByName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
 // DeleteSubscriptionByName provides a mock function with given fields: name
 func (_m *DBClient) DeleteSubscriptionByName(name string) errors.EdgeX {
 	ret := _m.Called(name)
@@ -84,6 +200,157 @@ func (_m *DBClient) DeleteSubscriptionByName(name string) errors.EdgeX {
 	return r0
 }
 
+// DeleteSubscriptionLocale provides a mock function with given fields: name
+func (_m *DBClient) DeleteSubscriptionLocale(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// DeleteTemplateByName provides a mock function with given fields: name
+func (_m *DBClient) DeleteTemplateByName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// HeldNotifications provides a mock function with given fields: offset, limit
+func (_m *DBClient) HeldNotifications(offset int, limit int) ([]string, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int, int) []string); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// HoldNotification provides a mock function with given fields: id, created
+func (_m *DBClient) HoldNotification(id string, created int64) errors.EdgeX {
+	ret := _m.Called(id, created)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string, int64) errors.EdgeX); ok {
+		r0 = rf(id, created)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// MuteWindowById provides a mock function with given fields: id
+func (_m *DBClient) MuteWindowById(id string) (mutes.MuteWindow, errors.EdgeX) {
+	ret := _m.Called(id)
+
+	var r0 mutes.MuteWindow
+	if rf, ok := ret.Get(0).(func(string) mutes.MuteWindow); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(mutes.MuteWindow)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// MuteWindowByName provides a mock function with given fields: name
+func (_m *DBClient) MuteWindowByName(name string) (mutes.MuteWindow, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 mutes.MuteWindow
+	if rf, ok := ret.Get(0).(func(string) mutes.MuteWindow); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(mutes.MuteWindow)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ReleaseNotification provides a mock function with given fields: id
+func (_m *DBClient) ReleaseNotification(id string) errors.EdgeX {
+	ret := _m.Called(id)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// SetSubscriptionLocale provides a mock function with given fields: name, locale
+func (_m *DBClient) SetSubscriptionLocale(name string, locale string) errors.EdgeX {
+	ret := _m.Called(name, locale)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string, string) errors.EdgeX); ok {
+		r0 = rf(name, locale)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
 // SubscriptionById provides a mock function with given fields: id
 func (_m *DBClient) SubscriptionById(id string) (models.Subscription, errors.EdgeX) {
 	ret := _m.Called(id)
@@ -130,6 +397,29 @@ func (_m *DBClient) SubscriptionByName(name string) (models.Subscription, errors
 	return r0, r1
 }
 
+// SubscriptionLocale provides a mock function with given fields: name
+func (_m *DBClient) SubscriptionLocale(name string) (string, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // SubscriptionsByCategory provides a mock function with given fields: offset, limit, category
 func (_m *DBClient) SubscriptionsByCategory(offset int, limit int, category string) ([]models.Subscription, errors.EdgeX) {
 	ret := _m.Called(offset, limit, category)
@@ -204,3 +494,81 @@ func (_m *DBClient) SubscriptionsByReceiver(offset int, limit int, receiver stri
 
 	return r0, r1
 }
+
+// TemplateById provides a mock function with given fields: id
+func (_m *DBClient) TemplateById(id string) (templates.Template, errors.EdgeX) {
+	ret := _m.Called(id)
+
+	var r0 templates.Template
+	if rf, ok := ret.Get(0).(func(string) templates.Template); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(templates.Template)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// TemplateByName provides a mock function with given fields: name
+func (_m *DBClient) TemplateByName(name string) (templates.Template, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 templates.Template
+	if rf, ok := ret.Get(0).(func(string) templates.Template); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(templates.Template)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateMuteWindow provides a mock function with given fields: w
+func (_m *DBClient) UpdateMuteWindow(w mutes.MuteWindow) errors.EdgeX {
+	ret := _m.Called(w)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(mutes.MuteWindow) errors.EdgeX); ok {
+		r0 = rf(w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// UpdateTemplate provides a mock function with given fields: t
+func (_m *DBClient) UpdateTemplate(t templates.Template) errors.EdgeX {
+	ret := _m.Called(t)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(templates.Template) errors.EdgeX); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/channeltest"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// ChannelTestReader unmarshals a request body into a TestChannelRequest type
+type ChannelTestReader interface {
+	ReadTestChannelRequest(reader io.Reader) (channeltest.TestChannelRequest, errors.EdgeX)
+}
+
+// NewChannelTestRequestReader returns a BodyReader capable of processing the request body
+func NewChannelTestRequestReader() ChannelTestReader {
+	return NewJsonChannelTestReader()
+}
+
+// NewJsonChannelTestReader creates a new instance of jsonChannelTestReader
+func NewJsonChannelTestReader() jsonChannelTestReader {
+	return jsonChannelTestReader{}
+}
+
+// jsonChannelTestReader unmarshals the JSON request body payload
+type jsonChannelTestReader struct{}
+
+// ReadTestChannelRequest reads a request and then converts its JSON data into a TestChannelRequest struct
+func (jsonChannelTestReader) ReadTestChannelRequest(reader io.Reader) (channeltest.TestChannelRequest, errors.EdgeX) {
+	var request channeltest.TestChannelRequest
+	err := json.NewDecoder(reader).Decode(&request)
+	if err != nil {
+		return request, errors.NewCommonEdgeX(errors.KindContractInvalid, "channel test json decoding failed", err)
+	}
+	return request, nil
+}
@@ -0,0 +1,54 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// MuteWindowReader unmarshals a request body into an array of MuteWindow type
+type MuteWindowReader interface {
+	ReadAddMuteWindowRequest(reader io.Reader) ([]mutes.AddMuteWindowRequest, errors.EdgeX)
+	ReadUpdateMuteWindowRequest(reader io.Reader) ([]mutes.UpdateMuteWindowRequest, errors.EdgeX)
+}
+
+// NewMuteWindowRequestReader returns a BodyReader capable of processing the request body
+func NewMuteWindowRequestReader() MuteWindowReader {
+	return NewJsonMuteWindowReader()
+}
+
+// NewJsonMuteWindowReader creates a new instance of jsonMuteWindowReader
+func NewJsonMuteWindowReader() jsonMuteWindowReader {
+	return jsonMuteWindowReader{}
+}
+
+// jsonMuteWindowReader unmarshals the JSON request body payload
+type jsonMuteWindowReader struct{}
+
+// ReadAddMuteWindowRequest reads a request and then converts its JSON data into an array of AddMuteWindowRequest struct
+func (jsonMuteWindowReader) ReadAddMuteWindowRequest(reader io.Reader) ([]mutes.AddMuteWindowRequest, errors.EdgeX) {
+	var addMuteWindows []mutes.AddMuteWindowRequest
+	err := json.NewDecoder(reader).Decode(&addMuteWindows)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "mute window json decoding failed", err)
+	}
+	return addMuteWindows, nil
+}
+
+// ReadUpdateMuteWindowRequest reads a request and then converts its JSON data into an array of UpdateMuteWindowRequest struct
+func (jsonMuteWindowReader) ReadUpdateMuteWindowRequest(reader io.Reader) ([]mutes.UpdateMuteWindowRequest, errors.EdgeX) {
+	var updateMuteWindows []mutes.UpdateMuteWindowRequest
+	err := json.NewDecoder(reader).Decode(&updateMuteWindows)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "mute window json decoding failed", err)
+	}
+	return updateMuteWindows, nil
+}
@@ -0,0 +1,65 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// TemplateReader unmarshals a request body into an array of Template type
+type TemplateReader interface {
+	ReadAddTemplateRequest(reader io.Reader) ([]templates.AddTemplateRequest, errors.EdgeX)
+	ReadUpdateTemplateRequest(reader io.Reader) ([]templates.UpdateTemplateRequest, errors.EdgeX)
+	ReadSetSubscriptionLocaleRequest(reader io.Reader) (templates.SetSubscriptionLocaleRequest, errors.EdgeX)
+}
+
+// NewTemplateRequestReader returns a BodyReader capable of processing the request body
+func NewTemplateRequestReader() TemplateReader {
+	return NewJsonTemplateReader()
+}
+
+// NewJsonTemplateReader creates a new instance of jsonTemplateReader
+func NewJsonTemplateReader() jsonTemplateReader {
+	return jsonTemplateReader{}
+}
+
+// jsonTemplateReader unmarshals the JSON request body payload
+type jsonTemplateReader struct{}
+
+// ReadAddTemplateRequest reads a request and then converts its JSON data into an array of AddTemplateRequest struct
+func (jsonTemplateReader) ReadAddTemplateRequest(reader io.Reader) ([]templates.AddTemplateRequest, errors.EdgeX) {
+	var addTemplates []templates.AddTemplateRequest
+	err := json.NewDecoder(reader).Decode(&addTemplates)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "template json decoding failed", err)
+	}
+	return addTemplates, nil
+}
+
+// ReadUpdateTemplateRequest reads a request and then converts its JSON data into an array of UpdateTemplateRequest struct
+func (jsonTemplateReader) ReadUpdateTemplateRequest(reader io.Reader) ([]templates.UpdateTemplateRequest, errors.EdgeX) {
+	var updateTemplates []templates.UpdateTemplateRequest
+	err := json.NewDecoder(reader).Decode(&updateTemplates)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "template json decoding failed", err)
+	}
+	return updateTemplates, nil
+}
+
+// ReadSetSubscriptionLocaleRequest reads a request and then converts its JSON data into a SetSubscriptionLocaleRequest struct
+func (jsonTemplateReader) ReadSetSubscriptionLocaleRequest(reader io.Reader) (templates.SetSubscriptionLocaleRequest, errors.EdgeX) {
+	var setLocale templates.SetSubscriptionLocaleRequest
+	err := json.NewDecoder(reader).Decode(&setLocale)
+	if err != nil {
+		return setLocale, errors.NewCommonEdgeX(errors.KindContractInvalid, "subscription locale json decoding failed", err)
+	}
+	return setLocale, nil
+}
@@ -7,11 +7,25 @@ package v2
 import (
 	"net/http"
 
+	pkgContainer "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jobs"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/mutes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/openapi"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/schema"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/templates"
 	commonController "github.com/edgexfoundry/edgex-go/internal/pkg/v2/controller/http"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/channeltest"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
 	notificationsController "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/controller/http"
 
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	v2Constant "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
 
 	"github.com/gorilla/mux"
 )
@@ -24,15 +38,61 @@ func LoadRestRoutes(r *mux.Router, dic *di.Container) {
 	r.HandleFunc(v2Constant.ApiVersionRoute, cc.Version).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiConfigRoute, cc.Config).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiMetricsRoute, cc.Metrics).Methods(http.MethodGet)
+	r.HandleFunc(correlation.ApiTraceRoute, cc.Trace).Methods(http.MethodGet)
+	r.HandleFunc(features.ApiCapabilitiesRoute, cc.Capabilities).Methods(http.MethodGet)
+	r.HandleFunc(httpclient.ApiOutboundMetricsRoute, cc.OutboundMetrics).Methods(http.MethodGet)
+
+	// Schemas
+	schemas := schema.NewRegistry(bootstrapContainer.LoggingClientFrom(dic.Get))
+	schemas.Add(responseDTO.SubscriptionResponse{}, responseDTO.MultiSubscriptionsResponse{}, templates.SubscriptionLocaleResponse{},
+		templates.TemplateResponse{}, templates.MultiTemplatesResponse{},
+		mutes.MuteWindowResponse{}, mutes.MultiMuteWindowsResponse{},
+		channeltest.TestChannelResponse{},
+		jobs.JobResponse{}, jobs.MultiJobsResponse{})
+	schema.LoadRestRoutes(r, schemas)
+
+	// OpenAPI document
+	openapi.LoadRestRoutes(r, bootstrapContainer.LoggingClientFrom(dic.Get), openapi.SupportNotificationsSpec,
+		notificationsContainer.ConfigurationFrom(dic.Get).JWTAuth.Enabled)
 
 	// Subscription
 	nc := notificationsController.NewSubscriptionController(dic)
-	r.HandleFunc(v2Constant.ApiSubscriptionRoute, nc.AddSubscription).Methods(http.MethodPost)
+	r.HandleFunc(v2Constant.ApiSubscriptionRoute, schemas.ValidateRequest([]requests.AddSubscriptionRequest{}, nc.AddSubscription)).Methods(http.MethodPost)
 	r.HandleFunc(v2Constant.ApiAllSubscriptionRoute, nc.AllSubscriptions).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiSubscriptionByNameRoute, nc.SubscriptionByName).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiSubscriptionByCategoryRoute, nc.SubscriptionsByCategory).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiSubscriptionByLabelRoute, nc.SubscriptionsByLabel).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiSubscriptionByReceiverRoute, nc.SubscriptionsByReceiver).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiSubscriptionByNameRoute, nc.DeleteSubscriptionByName).Methods(http.MethodDelete)
-	r.HandleFunc(v2Constant.ApiSubscriptionRoute, nc.PatchSubscription).Methods(http.MethodPatch)
+	r.HandleFunc(v2Constant.ApiSubscriptionRoute, schemas.ValidateRequest([]requests.UpdateSubscriptionRequest{}, nc.PatchSubscription)).Methods(http.MethodPatch)
+
+	// Template
+	tc := notificationsController.NewTemplateController(dic)
+	r.HandleFunc(templates.ApiSubscriptionLocaleRoute, tc.SubscriptionLocale).Methods(http.MethodGet)
+	r.HandleFunc(templates.ApiSubscriptionLocaleRoute, schemas.ValidateRequest(templates.SetSubscriptionLocaleRequest{}, tc.SetSubscriptionLocale)).Methods(http.MethodPut)
+	r.HandleFunc(templates.ApiSubscriptionLocaleRoute, tc.DeleteSubscriptionLocale).Methods(http.MethodDelete)
+	r.HandleFunc(templates.ApiTemplateRoute, schemas.ValidateRequest([]templates.AddTemplateRequest{}, tc.AddTemplate)).Methods(http.MethodPost)
+	r.HandleFunc(templates.ApiAllTemplateRoute, tc.AllTemplates).Methods(http.MethodGet)
+	r.HandleFunc(templates.ApiTemplateByNameRoute, tc.TemplateByName).Methods(http.MethodGet)
+	r.HandleFunc(templates.ApiTemplateByNameRoute, tc.DeleteTemplateByName).Methods(http.MethodDelete)
+	r.HandleFunc(templates.ApiTemplateRoute, schemas.ValidateRequest([]templates.UpdateTemplateRequest{}, tc.PatchTemplate)).Methods(http.MethodPatch)
+
+	// Mute window
+	mc := notificationsController.NewMuteWindowController(dic)
+	r.HandleFunc(mutes.ApiMuteWindowRoute, schemas.ValidateRequest([]mutes.AddMuteWindowRequest{}, mc.AddMuteWindow)).Methods(http.MethodPost)
+	r.HandleFunc(mutes.ApiAllMuteWindowRoute, mc.AllMuteWindows).Methods(http.MethodGet)
+	r.HandleFunc(mutes.ApiMuteWindowByNameRoute, mc.MuteWindowByName).Methods(http.MethodGet)
+	r.HandleFunc(mutes.ApiMuteWindowByNameRoute, mc.DeleteMuteWindowByName).Methods(http.MethodDelete)
+	r.HandleFunc(mutes.ApiMuteWindowRoute, schemas.ValidateRequest([]mutes.UpdateMuteWindowRequest{}, mc.PatchMuteWindow)).Methods(http.MethodPatch)
+
+	// Channel test
+	ctc := notificationsController.NewChannelTestController(dic)
+	r.HandleFunc(channeltest.ApiChannelTestRoute, schemas.ValidateRequest(channeltest.TestChannelRequest{}, ctc.TestChannel)).Methods(http.MethodPost)
+
+	// Notification
+	ntc := notificationsController.NewNotificationController(dic)
+	r.HandleFunc(notificationsController.ApiNotificationByAgeRoute, ntc.DeleteProcessedNotificationsByAge).Methods(http.MethodDelete)
+
+	// Job
+	jobs.LoadRestRoutes(r, pkgContainer.JobManagerFrom(dic.Get))
 }
@@ -0,0 +1,53 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// exclusionCalendars skips the executions of the intervals on the days excluded by their calendar. A nil
+// exclusionCalendars lets every execution run.
+type exclusionCalendars struct {
+	store calendars.Store
+}
+
+func newExclusionCalendars(store calendars.Store) *exclusionCalendars {
+	return &exclusionCalendars{store: store}
+}
+
+// excluded tells whether the execution of the named interval at the given time is skipped by its calendar. The
+// execution runs when the calendar can't be read or evaluated, a missed execution being what the calendars are meant
+// to prevent on the days that aren't excluded.
+func (e *exclusionCalendars) excluded(interval string, at time.Time, lc logger.LoggingClient) bool {
+	if e == nil {
+		return false
+	}
+
+	calendar, err := e.store.CalendarByInterval(interval)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(fmt.Sprintf("unable to get the exclusion calendar of interval : %s, running it : %s", interval, err.Error()))
+		}
+		return false
+	}
+
+	status, err := calendar.Excludes(at)
+	if err != nil {
+		lc.Error(fmt.Sprintf("unable to check the exclusion calendar of interval : %s, running it : %s", interval, err.Error()))
+		return false
+	}
+	if status.Excluded {
+		lc.Info(fmt.Sprintf("interval : %s skipped at %s, %s", interval, at.String(), status.Reason))
+	}
+	return status.Excluded
+}
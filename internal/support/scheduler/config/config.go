@@ -17,6 +17,15 @@ package config
 import (
 	"fmt"
 
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/registrywatch"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
+
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 )
 
@@ -30,11 +39,21 @@ type ConfigurationStruct struct {
 	Intervals       map[string]IntervalInfo
 	IntervalActions map[string]IntervalActionInfo
 	SecretStore     bootstrapConfig.SecretStoreInfo
+	JWTAuth         jwtauth.JWTAuthInfo
+	Audit           audit.AuditInfo
+	Coordination    CoordinationInfo
+	OutboundHTTP    httpclient.OutboundHTTPInfo
+	SecretCache     secretcache.SecretCacheInfo
+	RegistryWatch   registrywatch.RegistryWatchInfo
 }
 
 type WritableInfo struct {
 	ScheduleIntervalTime int
 	LogLevel             string
+	ShutdownTimeout      string
+	RequestLimits        requestlimits.RequestLimitsInfo
+	ProblemDetails       problem.ProblemDetailsInfo
+	CORS                 cors.CORSInfo
 	InsecureSecrets      bootstrapConfig.InsecureSecrets
 }
 
@@ -75,6 +94,19 @@ type IntervalActionInfo struct {
 	Interval string
 }
 
+// CoordinationInfo configures the execution locks letting several scheduler instances share the same database
+type CoordinationInfo struct {
+	// Enabled turns on the execution locks. Leave disabled when running a single instance.
+	Enabled bool
+	// InstanceId identifies this instance as lock owner. Defaults to the host name when empty.
+	InstanceId string
+	// LockKeyPrefix prefixes the database keys holding the execution locks, followed by the interval or job name.
+	LockKeyPrefix string
+	// LockDuration is how long the lock of a run-once interval or one-shot job is held, e.g. "1m". The lock of a
+	// recurring interval is held for half its frequency.
+	LockDuration string
+}
+
 // URI constructs a URI from the protocol, host and port and returns that as a string.
 func (e IntervalActionInfo) URL() string {
 	return fmt.Sprintf("%s://%s:%v", e.Protocol, e.Host, e.Port)
@@ -139,6 +171,36 @@ func (c *ConfigurationStruct) GetDatabaseInfo() map[string]bootstrapConfig.Datab
 	return c.Databases
 }
 
+// GetRequestLimits returns the limits applied to incoming requests.
+func (c *ConfigurationStruct) GetRequestLimits() requestlimits.RequestLimitsInfo {
+	return c.Writable.RequestLimits
+}
+
+// GetSecretCache returns the configuration of the cache of the secrets read from the secret store.
+func (c *ConfigurationStruct) GetSecretCache() secretcache.SecretCacheInfo {
+	return c.SecretCache
+}
+
+// GetRegistryWatch returns the configuration of the watch of the registration of the service with the registry.
+func (c *ConfigurationStruct) GetRegistryWatch() registrywatch.RegistryWatchInfo {
+	return c.RegistryWatch
+}
+
+// GetProblemDetails returns whether the failed requests are responded to with problem details.
+func (c *ConfigurationStruct) GetProblemDetails() problem.ProblemDetailsInfo {
+	return c.Writable.ProblemDetails
+}
+
+// GetCORS returns the CORS policy applied to incoming requests.
+func (c *ConfigurationStruct) GetCORS() cors.CORSInfo {
+	return c.Writable.CORS
+}
+
+// GetShutdownTimeout returns how long in-flight requests are given to complete when the service is stopped.
+func (c *ConfigurationStruct) GetShutdownTimeout() string {
+	return c.Writable.ShutdownTimeout
+}
+
 // GetInsecureSecrets returns the service's InsecureSecrets.
 func (c *ConfigurationStruct) GetInsecureSecrets() bootstrapConfig.InsecureSecrets {
 	return c.Writable.InsecureSecrets
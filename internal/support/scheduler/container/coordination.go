@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/coordination"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// ExecutionLockName contains the name of the coordination.ExecutionLock instance in the DIC.
+var ExecutionLockName = di.TypeInstanceToName(coordination.ExecutionLock{})
+
+// ExecutionLockFrom helper function queries the DIC and returns the coordination.ExecutionLock, or nil if
+// coordination is disabled.
+func ExecutionLockFrom(get di.Get) *coordination.ExecutionLock {
+	lock, ok := get(ExecutionLockName).(*coordination.ExecutionLock)
+	if !ok {
+		return nil
+	}
+	return lock
+}
@@ -0,0 +1,88 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/coordination"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	defaultLockKeyPrefix = "edgex-support-scheduler:lock:"
+	defaultLockDuration  = time.Minute
+)
+
+// executionLock guards the dispatch of the executions, so that several instances sharing the database don't
+// run them twice. A nil lock lets every execution run, as when running a single instance.
+type executionLock struct {
+	lock         *coordination.ExecutionLock
+	lockDuration time.Duration
+}
+
+// newExecutionLock builds the execution lock from the Coordination configuration
+func newExecutionLock(dbClient interface{}, info config.CoordinationInfo) (*executionLock, error) {
+	lease, ok := dbClient.(coordination.Lease)
+	if !ok {
+		return nil, fmt.Errorf("database client %T does not support leases", dbClient)
+	}
+
+	lockDuration := defaultLockDuration
+	if info.LockDuration != "" {
+		var err error
+		lockDuration, err = time.ParseDuration(info.LockDuration)
+		if err != nil || lockDuration <= 0 {
+			return nil, fmt.Errorf("invalid Coordination.LockDuration '%s'", info.LockDuration)
+		}
+	}
+
+	instanceId := info.InstanceId
+	if instanceId == "" {
+		var err error
+		if instanceId, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("unable to default Coordination.InstanceId to the host name: %s", err.Error())
+		}
+	}
+
+	keyPrefix := info.LockKeyPrefix
+	if keyPrefix == "" {
+		keyPrefix = defaultLockKeyPrefix
+	}
+
+	return &executionLock{
+		lock:         coordination.NewExecutionLock(lease, keyPrefix, instanceId),
+		lockDuration: lockDuration,
+	}, nil
+}
+
+// owns takes the lock of an execution of the job recurring every frequency, zero when it runs once, and returns
+// whether this instance is to run it. The execution is run when the lock can't be checked, a duplicate execution
+// being better than a missed one.
+func (l *executionLock) owns(job string, frequency time.Duration, lc logger.LoggingClient) bool {
+	if l == nil {
+		return true
+	}
+
+	hold := l.lockDuration
+	if frequency > 0 {
+		// Held long enough for the other instances to schedule the same execution, yet released before the next one
+		hold = frequency / 2
+	}
+	owned, err := l.lock.TryLock(job, hold)
+	if err != nil {
+		lc.Error(fmt.Sprintf("unable to check the execution lock of %s, running it: %s", job, err.Error()))
+		return true
+	}
+	if !owned {
+		lc.Debug(fmt.Sprintf("the execution of %s is run by another instance, skipping it", job))
+	}
+	return owned
+}
@@ -0,0 +1,86 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLease records the locks taken, which never expire
+type testLease struct {
+	owners map[string]string
+	holds  map[string]time.Duration
+	err    error
+}
+
+func newTestLease() *testLease {
+	return &testLease{owners: make(map[string]string), holds: make(map[string]time.Duration)}
+}
+
+func (l *testLease) AcquireLease(key string, owner string, ttl time.Duration) (bool, error) {
+	if l.err != nil {
+		return false, l.err
+	}
+	if current, held := l.owners[key]; held && current != owner {
+		return false, nil
+	}
+	l.owners[key] = owner
+	l.holds[key] = ttl
+	return true, nil
+}
+
+func (l *testLease) ReleaseLease(key string, owner string) error {
+	if l.owners[key] == owner {
+		delete(l.owners, key)
+	}
+	return nil
+}
+
+func TestNewExecutionLock(t *testing.T) {
+	lock, err := newExecutionLock(newTestLease(), config.CoordinationInfo{InstanceId: "first"})
+	require.NoError(t, err)
+	assert.Equal(t, defaultLockDuration, lock.lockDuration)
+	assert.Equal(t, "first", lock.lock.InstanceId())
+
+	_, err = newExecutionLock(struct{}{}, config.CoordinationInfo{})
+	assert.Error(t, err, "the database client must support leases")
+	_, err = newExecutionLock(newTestLease(), config.CoordinationInfo{LockDuration: "soon"})
+	assert.Error(t, err)
+}
+
+func TestOwns(t *testing.T) {
+	lease := newTestLease()
+	info := config.CoordinationInfo{LockKeyPrefix: "lock:", LockDuration: "30s"}
+	info.InstanceId = "first"
+	first, err := newExecutionLock(lease, info)
+	require.NoError(t, err)
+	info.InstanceId = "second"
+	second, err := newExecutionLock(lease, info)
+	require.NoError(t, err)
+	lc := logger.NewMockClient()
+
+	assert.True(t, first.owns("interval:midnight", 24*time.Hour, lc))
+	assert.False(t, second.owns("interval:midnight", 24*time.Hour, lc), "the execution is run by the first instance")
+	assert.Equal(t, 12*time.Hour, lease.holds["lock:interval:midnight"], "a recurring execution is held for half its frequency")
+	assert.True(t, second.owns("oneshot:purge", 0, lc))
+	assert.Equal(t, 30*time.Second, lease.holds["lock:oneshot:purge"])
+
+	lease.err = errors.New("connection refused")
+	assert.True(t, second.owns("interval:midnight", 24*time.Hour, lc), "the execution runs when the lock can't be checked")
+	assert.Equal(t, uint64(1), second.lock.Metrics().Failed)
+
+	var disabled *executionLock
+	assert.True(t, disabled.owns("interval:midnight", 24*time.Hour, lc), "every execution runs without coordination")
+}
@@ -0,0 +1,58 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// deviceScopes suspends the executions of the interval actions bound to devices that are locked or deleted in
+// core-metadata. A nil deviceScopes lets every execution run.
+type deviceScopes struct {
+	store  devicescopes.Store
+	source devicescopes.DeviceSource
+}
+
+func newDeviceScopes(store devicescopes.Store, source devicescopes.DeviceSource) *deviceScopes {
+	return &deviceScopes{store: store, source: source}
+}
+
+// suspended tells whether the execution of the named interval action is suspended by its device scope. An action
+// without device scope runs even when the scopes can't be read, as before the scopes existed, but a scoped action is
+// suspended when the state of its devices can't be checked, actuating a locked device being worse than a missed
+// execution.
+func (d *deviceScopes) suspended(action string, lc logger.LoggingClient) bool {
+	if d == nil {
+		return false
+	}
+
+	scope, err := d.store.DeviceScopeByIntervalAction(action)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(fmt.Sprintf("unable to get the device scope of interval action : %s, running it : %s", action, err.Error()))
+		}
+		return false
+	}
+
+	ctx := correlation.NewContext(context.Background())
+	status, err := devicescopes.Evaluate(ctx, scope, d.source)
+	if err != nil {
+		lc.Error(fmt.Sprintf("unable to check the device scope of interval action : %s, suspending it : %s. Correlation-ID: %s",
+			action, err.Error(), correlation.FromContext(ctx)))
+		return true
+	}
+	if status.Suspended {
+		lc.Debug(fmt.Sprintf("interval action : %s suspended, %s", action, status.Reason))
+	}
+	return status.Suspended
+}
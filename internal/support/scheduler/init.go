@@ -18,16 +18,27 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
+	"github.com/edgexfoundry/edgex-go/internal/pkg/audit"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
 	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
 	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	V2Container "github.com/edgexfoundry/go-mod-bootstrap/v2/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	V2Clients "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/clients/http"
 
 	"github.com/gorilla/mux"
 )
@@ -48,26 +59,72 @@ func NewBootstrap(router *mux.Router) *Bootstrap {
 func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
 	loadRestRoutes(b.router, dic)
 	v2.LoadRestRoutes(b.router, dic)
+	b.router.Use(problem.NewMiddleware(clients.SupportSchedulerServiceKey, schedulerContainer.ConfigurationFrom(dic.Get)))
+	cors.UseMiddleware(b.router, schedulerContainer.ConfigurationFrom(dic.Get))
+	b.router.Use(requestlimits.NewMiddleware(bootstrapContainer.LoggingClientFrom(dic.Get), schedulerContainer.ConfigurationFrom(dic.Get)))
+	if err := jwtauth.UseMiddleware(b.router, dic, schedulerContainer.ConfigurationFrom(dic.Get).JWTAuth); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("failed to enable JWT verification: " + err.Error())
+		return false
+	}
+	audit.UseMiddleware(ctx, wg, b.router, dic, schedulerContainer.ConfigurationFrom(dic.Get).Audit, clients.SupportSchedulerServiceKey,
+		schedulerContainer.ConfigurationFrom(dic.Get).Clients["CoreData"].Url(), audit.ClassifyWrite)
 
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	configuration := schedulerContainer.ConfigurationFrom(dic.Get)
 
+	// the client of the requests of the interval actions and the one-shot jobs
+	httpClient, err := httpclient.NewClient(lc, configuration.OutboundHTTP, &http.Client{
+		Timeout: time.Duration(configuration.Service.Timeout) * time.Millisecond,
+	})
+	if err != nil {
+		lc.Error(fmt.Sprintf("invalid outbound HTTP configuration: %s", err.Error()))
+		return false
+	}
+
 	// add dependencies to bootstrapContainer
 	scClient := NewSchedulerQueueClient(lc)
 	dic.Update(di.ServiceConstructorMap{
 		schedulerContainer.QueueName: func(get di.Get) interface{} {
 			return scClient
 		},
+		container.HTTPClientName: func(get di.Get) interface{} {
+			return httpClient
+		},
 	})
 
-	err := LoadScheduler(lc, container.DBClientFrom(dic.Get), scClient, configuration)
+	err = LoadScheduler(lc, container.DBClientFrom(dic.Get), scClient, configuration)
 	if err != nil {
 		lc.Error(fmt.Sprintf("Failed to load schedules and events %s", err.Error()))
 		return false
 	}
 
+	var lock *executionLock
+	if configuration.Coordination.Enabled {
+		lock, err = newExecutionLock(container.DBClientFrom(dic.Get), configuration.Coordination)
+		if err != nil {
+			lc.Error(fmt.Sprintf("failed to set up instance coordination: %s", err.Error()))
+			return false
+		}
+		dic.Update(di.ServiceConstructorMap{
+			schedulerContainer.ExecutionLockName: func(get di.Get) interface{} {
+				return lock.lock
+			},
+		})
+	}
+
+	// the interval actions bound to devices are suspended while the devices are locked or deleted in core-metadata
+	deviceClient := V2Clients.NewDeviceClient(configuration.Clients["Metadata"].Url())
+	dic.Update(di.ServiceConstructorMap{
+		V2Container.MetadataDeviceClientName: func(get di.Get) interface{} {
+			return deviceClient
+		},
+	})
+	scopes := newDeviceScopes(v2SchedulerContainer.DBClientFrom(dic.Get), deviceClient)
+	// the intervals skip the days excluded by their calendar
+	exclusions := newExclusionCalendars(v2SchedulerContainer.DBClientFrom(dic.Get))
+
 	ticker := time.NewTicker(time.Duration(configuration.Writable.ScheduleIntervalTime) * time.Millisecond)
-	StartTicker(ticker, lc, configuration)
+	StartTicker(ticker, lc, httpClient, lock, scopes, exclusions)
 
 	wg.Add(1)
 	go func() {
@@ -77,5 +134,7 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _
 		StopTicker(ticker)
 	}()
 
+	startOneShotRunner(ctx, wg, dic, lock)
+
 	return true
 }
@@ -17,11 +17,17 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/edgexfoundry/edgex-go"
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configflags"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/configprovider"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/database"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/httpserver"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers/registry"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/secretcache"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/telemetry"
 	v2Handlers "github.com/edgexfoundry/edgex-go/internal/pkg/v2/bootstrap/handlers"
 	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/config"
@@ -49,8 +55,17 @@ func Main(ctx context.Context, cancel context.CancelFunc, router *mux.Router, re
 	//      ....
 	//      flags.Parse(os.Args[1:])
 	//
-	f := flags.New()
+	var inMemory bool
+	var configFlags configflags.Flags
+	f := flags.NewWithUsage(database.InMemoryUsage + configflags.Usage)
+	f.FlagSet.BoolVar(&inMemory, database.InMemoryFlag, false, "")
+	configFlags.Register(f.FlagSet)
 	f.Parse(os.Args[1:])
+	if err := configFlags.Apply(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	providerFlags := configprovider.NewFlags(f)
 
 	configuration := &config.ConfigurationStruct{}
 	dic := di.NewContainer(di.ServiceConstructorMap{
@@ -59,22 +74,27 @@ func Main(ctx context.Context, cancel context.CancelFunc, router *mux.Router, re
 		},
 	})
 
-	httpServer := handlers.NewHttpServer(router, true)
+	httpServer := httpserver.NewHttpServer(router, configuration, true)
 
 	bootstrap.Run(
 		ctx,
 		cancel,
-		f,
+		providerFlags,
 		clients.SupportSchedulerServiceKey,
 		internal.ConfigStemCore+internal.ConfigMajorVersion,
 		configuration,
 		startupTimer,
 		dic,
 		[]interfaces.BootstrapHandler{
+			configprovider.NewHandler(providerFlags, clients.SupportSchedulerServiceKey, internal.ConfigStemCore+internal.ConfigMajorVersion, configuration).BootstrapHandler,
+			configflags.NewHandler(&configFlags, configuration).BootstrapHandler,
 			handlers.SecureProviderBootstrapHandler,
+			secretcache.NewHandler(configuration).BootstrapHandler,
+			database.NewInMemory(configuration, inMemory).BootstrapHandler,
 			database.NewDatabase(httpServer, configuration).BootstrapHandler,
 			v2Handlers.NewDatabase(httpServer, configuration, v2SchedulerContainer.DBClientInterfaceName).BootstrapHandler, // add v2 db client bootstrap handler
 			NewBootstrap(router).BootstrapHandler,
+			registry.NewHandler(configuration, clients.SupportSchedulerServiceKey).BootstrapHandler,
 			telemetry.BootstrapHandler,
 			httpServer.BootstrapHandler,
 			handlers.NewStartMessage(clients.SupportSchedulerServiceKey, edgex.Version).BootstrapHandler,
@@ -0,0 +1,88 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// runDueOneShots runs the one-shot jobs due at the timestamp now, in milliseconds, and then deletes them. A job is
+// deleted even when its request fails, one-shot jobs not being retried. The jobs run by another instance are left
+// for it to delete.
+func runDueOneShots(
+	lc logger.LoggingClient,
+	client internal.HttpCaller,
+	store oneshots.Store,
+	lock *executionLock,
+	now int64) {
+
+	due, err := store.DueOneShots(now)
+	if err != nil {
+		lc.Error("Unable to get the due one-shot jobs: " + err.Error())
+		return
+	}
+
+	for _, o := range due {
+		if !lock.owns("oneshot:"+o.Name, 0, lc) {
+			continue
+		}
+		action := o.IntervalAction()
+		executingUrl := getUrlStr(action)
+		lc.Debug("the one-shot job : " + o.Name + " will request url : " + executingUrl)
+
+		req, err := getHttpRequest(o.HTTPMethod, executingUrl, action, lc)
+		if err == nil {
+			_, statusCode, err := sendRequestAndGetResponse(client, req)
+			if err != nil {
+				lc.Error("one-shot job : " + o.Name + " request failed : " + err.Error())
+			} else {
+				lc.Debug(fmt.Sprintf("one-shot job : %s returns status code : %d", o.Name, statusCode))
+			}
+		}
+
+		if err := store.DeleteOneShotByName(o.Name); err != nil {
+			lc.Error("Unable to delete the run one-shot job : " + o.Name + ", issue: " + err.Error())
+		}
+	}
+}
+
+// startOneShotRunner calls runDueOneShots every Writable.ScheduleIntervalTime, like the intervals are triggered,
+// until ctx is done
+func startOneShotRunner(ctx context.Context, wg *sync.WaitGroup, dic *di.Container, lock *executionLock) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	configuration := schedulerContainer.ConfigurationFrom(dic.Get)
+	store := v2SchedulerContainer.DBClientFrom(dic.Get)
+	client := container.HTTPClientFrom(dic.Get)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(time.Duration(configuration.Writable.ScheduleIntervalTime) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runDueOneShots(lc, client, store, lock, common.MakeTimestamp())
+			}
+		}
+	}()
+}
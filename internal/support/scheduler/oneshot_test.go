@@ -0,0 +1,69 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+	v2Mocks "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDueOneShots(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requested = append(requested, r.Method+" "+r.URL.Path+" "+string(body))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverUrl.Port())
+	require.NoError(t, err)
+
+	succeeding := oneshots.OneShot{
+		Name:       "succeeding",
+		Host:       serverUrl.Hostname(),
+		Port:       port,
+		Path:       "/api/v2/purge",
+		Parameters: `{"age":0}`,
+		HTTPMethod: http.MethodPost,
+	}
+	failing := succeeding
+	failing.Name = "failing"
+	failing.Port = 1
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	store := &v2Mocks.DBClient{}
+	store.On("DueOneShots", int64(1000)).Return([]oneshots.OneShot{succeeding, failing}, nil)
+	store.On("DeleteOneShotByName", mock.Anything).Return(nil)
+
+	runDueOneShots(logger.NewMockClient(), client, store, nil, 1000)
+
+	assert.Equal(t, []string{`POST /api/v2/purge {"age":0}`}, requested)
+	store.AssertCalled(t, "DeleteOneShotByName", succeeding.Name)
+	// a failing one-shot job must not be retried
+	store.AssertCalled(t, "DeleteOneShotByName", failing.Name)
+
+	unavailable := &v2Mocks.DBClient{}
+	unavailable.On("DueOneShots", mock.Anything).Return(nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "unavailable", nil))
+	runDueOneShots(logger.NewMockClient(), client, unavailable, nil, 1000)
+	unavailable.AssertNotCalled(t, "DeleteOneShotByName", mock.Anything)
+}
@@ -9,6 +9,7 @@ package scheduler
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -22,7 +23,8 @@ import (
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	queueV1 "gopkg.in/eapache/queue.v1"
 
-	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/config"
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 )
 
 // the interval specific shared variables
@@ -37,10 +39,16 @@ var (
 	intervalActionNameToIntervalActionIdMap = make(map[string]string)
 )
 
-func StartTicker(ticker *time.Ticker, lc logger.LoggingClient, configuration *config.ConfigurationStruct) {
+func StartTicker(
+	ticker *time.Ticker,
+	lc logger.LoggingClient,
+	client internal.HttpCaller,
+	lock *executionLock,
+	scopes *deviceScopes,
+	exclusions *exclusionCalendars) {
 	go func() {
 		for range ticker.C {
-			triggerInterval(lc, configuration)
+			triggerInterval(lc, client, lock, scopes, exclusions)
 		}
 	}()
 }
@@ -424,7 +432,12 @@ func (qc *QueueClient) RemoveIntervalActionQueue(intervalActionId string) error
 	return nil
 }
 
-func triggerInterval(lc logger.LoggingClient, configuration *config.ConfigurationStruct) {
+func triggerInterval(
+	lc logger.LoggingClient,
+	client internal.HttpCaller,
+	lock *executionLock,
+	scopes *deviceScopes,
+	exclusions *exclusionCalendars) {
 	nowEpoch := time.Now().Unix()
 
 	defer func() {
@@ -455,7 +468,7 @@ func triggerInterval(lc logger.LoggingClient, configuration *config.Configuratio
 					wg.Add(1)
 
 					// execute it in a individual go routine
-					go execute(intervalContext, &wg, lc, configuration)
+					go execute(intervalContext, &wg, lc, client, lock, scopes, exclusions)
 				} else {
 					intervalQueue.Add(intervalContext)
 				}
@@ -470,9 +483,18 @@ func execute(
 	context *IntervalContext,
 	wg *sync.WaitGroup,
 	lc logger.LoggingClient,
-	configuration *config.ConfigurationStruct) {
+	client internal.HttpCaller,
+	lock *executionLock,
+	scopes *deviceScopes,
+	exclusions *exclusionCalendars) {
 
 	intervalActionMap := context.IntervalActionsMap
+	if !lock.owns("interval:"+context.Interval.Name, context.Frequency, lc) {
+		intervalActionMap = nil
+	} else if exclusions.excluded(context.Interval.Name, context.NextTime, lc) {
+		// the interval is still rescheduled below, so that it runs again after the excluded days
+		intervalActionMap = nil
+	}
 
 	defer wg.Done()
 
@@ -490,6 +512,9 @@ func execute(
 			"the event with id : " + eventId +
 				" belongs to interval : " + context.Interval.ID + " will be executing!")
 		intervalAction, _ := intervalActionMap[eventId]
+		if scopes.suspended(intervalAction.Name, lc) {
+			continue
+		}
 
 		executingUrl := getUrlStr(intervalAction)
 		lc.Debug("the event with id : " + eventId + " will request url : " + executingUrl)
@@ -506,9 +531,6 @@ func execute(
 			lc.Error("create new request occurs error : " + err.Error())
 		}
 
-		client := &http.Client{
-			Timeout: time.Duration(configuration.Service.Timeout) * time.Millisecond,
-		}
 		responseBytes, statusCode, err := sendRequestAndGetResponse(client, req)
 		responseStr := string(responseBytes)
 
@@ -552,6 +574,8 @@ func getHttpRequest(
 	}
 
 	req.Header.Set(ContentTypeKey, ContentTypeJsonValue)
+	// Each execution starts a new correlation id, carried on by the service it calls
+	correlation.Propagate(correlation.NewContext(context.Background()), req)
 
 	if len(params) > 0 {
 		req.Header.Set(ContentLengthKey, strconv.Itoa(len(params)))
@@ -564,7 +588,7 @@ func getUrlStr(intervalAction contract.IntervalAction) string {
 	return intervalAction.GetBaseURL() + intervalAction.Path
 }
 
-func sendRequestAndGetResponse(client *http.Client, req *http.Request) ([]byte, int, error) {
+func sendRequestAndGetResponse(client internal.HttpCaller, req *http.Request) ([]byte, int, error) {
 	resp, err := client.Do(req)
 
 	if err != nil {
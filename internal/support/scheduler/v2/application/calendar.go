@@ -0,0 +1,98 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// AddCalendar validates the new exclusion calendar and checks that its interval exists before adding it
+func AddCalendar(c calendars.Calendar, ctx context.Context, dic *di.Container) (id string, edgeXerr errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	if err := c.Validate(); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	// the scheduler queue holds all the intervals being scheduled
+	if _, err := schedulerContainer.QueueFrom(dic.Get).QueryIntervalByName(c.Interval); err != nil {
+		return "", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("interval %s does not exist", c.Interval), err)
+	}
+	addedCalendar, err := dbClient.AddCalendar(c)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Exclusion calendar created on DB successfully. Exclusion calendar ID: %s, Correlation-ID: %s ",
+		addedCalendar.Id,
+		correlation.FromContext(ctx))
+
+	return addedCalendar.Id, nil
+}
+
+// ImportCalendar adds the exclusion calendar of the named interval from the events of an iCal file
+func ImportCalendar(name string, data []byte, ctx context.Context, dic *di.Container) (id string, edgeXerr errors.EdgeX) {
+	if name == "" {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	c, err := calendars.ParseICal(data)
+	if err != nil {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid iCal file", err)
+	}
+	c.Interval = name
+	return AddCalendar(c, ctx, dic)
+}
+
+// AllCalendars queries the exclusion calendars by offset and limit
+func AllCalendars(offset, limit int, dic *di.Container) ([]calendars.Calendar, errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.AllCalendars(offset, limit)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
+
+// CalendarByInterval queries the exclusion calendar of the named interval with its upcoming excluded days
+func CalendarByInterval(name string, dic *di.Container) (c calendars.Calendar, upcoming []calendars.ExcludedDay, err errors.EdgeX) {
+	if name == "" {
+		return c, upcoming, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	c, err = dbClient.CalendarByInterval(name)
+	if err != nil {
+		return c, upcoming, errors.NewCommonEdgeXWrapper(err)
+	}
+	upcoming, err = c.Upcoming(time.Now())
+	if err != nil {
+		return c, upcoming, errors.NewCommonEdgeXWrapper(err)
+	}
+	return c, upcoming, nil
+}
+
+// DeleteCalendarByInterval deletes the exclusion calendar of the named interval, which then runs every day
+func DeleteCalendarByInterval(name string, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteCalendarByInterval(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
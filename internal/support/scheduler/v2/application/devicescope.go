@@ -0,0 +1,87 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	V2Container "github.com/edgexfoundry/go-mod-bootstrap/v2/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// AddDeviceScope validates the new device scope and checks that its interval action exists before adding it
+func AddDeviceScope(s devicescopes.DeviceScope, ctx context.Context, dic *di.Container) (id string, edgeXerr errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	if err := s.Validate(); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	// the scheduler queue holds all the interval actions being scheduled
+	if _, err := schedulerContainer.QueueFrom(dic.Get).QueryIntervalActionByName(s.IntervalAction); err != nil {
+		return "", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("interval action %s does not exist", s.IntervalAction), err)
+	}
+	addedScope, err := dbClient.AddDeviceScope(s)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Device scope created on DB successfully. Device scope ID: %s, Correlation-ID: %s ",
+		addedScope.Id,
+		correlation.FromContext(ctx))
+
+	return addedScope.Id, nil
+}
+
+// AllDeviceScopes queries the device scopes by offset and limit
+func AllDeviceScopes(offset, limit int, dic *di.Container) ([]devicescopes.DeviceScope, errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.AllDeviceScopes(offset, limit)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
+
+// DeviceScopeByIntervalAction queries the device scope of the named interval action and evaluates its status from
+// core-metadata. The action is reported suspended when its devices can't be checked, as it is when executed.
+func DeviceScopeByIntervalAction(name string, ctx context.Context, dic *di.Container) (s devicescopes.DeviceScope, status devicescopes.Status, err errors.EdgeX) {
+	if name == "" {
+		return s, status, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	s, err = dbClient.DeviceScopeByIntervalAction(name)
+	if err != nil {
+		return s, status, errors.NewCommonEdgeXWrapper(err)
+	}
+	status, err = devicescopes.Evaluate(ctx, s, V2Container.MetadataDeviceClientFrom(dic.Get))
+	if err != nil {
+		container.LoggingClientFrom(dic.Get).Error(err.Error())
+		return s, devicescopes.Status{Suspended: true, Reason: err.Error()}, nil
+	}
+	return s, status, nil
+}
+
+// DeleteDeviceScopeByIntervalAction unbinds the named interval action from its devices
+func DeleteDeviceScopeByIntervalAction(name string, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteDeviceScopeByIntervalAction(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
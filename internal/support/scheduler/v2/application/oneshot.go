@@ -0,0 +1,75 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// AddOneShot validates the new one-shot job and then adds it, to be run at its RunAt timestamp
+func AddOneShot(o oneshots.OneShot, ctx context.Context, dic *di.Container) (id string, edgeXerr errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	if err := o.Validate(common.MakeTimestamp()); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	addedOneShot, err := dbClient.AddOneShot(o)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("One-shot job created on DB successfully. One-shot job ID: %s, Correlation-ID: %s ",
+		addedOneShot.Id,
+		correlation.FromContext(ctx))
+
+	return addedOneShot.Id, nil
+}
+
+// AllOneShots queries the pending one-shot jobs by offset and limit
+func AllOneShots(offset, limit int, dic *di.Container) ([]oneshots.OneShot, errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	result, err := dbClient.AllOneShots(offset, limit)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	return result, nil
+}
+
+// OneShotByName queries the pending one-shot job by name
+func OneShotByName(name string, dic *di.Container) (o oneshots.OneShot, err errors.EdgeX) {
+	if name == "" {
+		return o, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	o, err = dbClient.OneShotByName(name)
+	if err != nil {
+		return o, errors.NewCommonEdgeXWrapper(err)
+	}
+	return o, nil
+}
+
+// DeleteOneShotByName cancels the pending one-shot job by name
+func DeleteOneShotByName(name string, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteOneShotByName(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
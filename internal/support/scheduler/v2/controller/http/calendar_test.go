@@ -0,0 +1,209 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	queueMock "github.com/edgexfoundry/edgex-go/internal/support/scheduler/interfaces/mocks"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func calendarData() calendars.Calendar {
+	return calendars.Calendar{
+		Interval: TestIntervalName,
+		Weekdays: []string{"SA", "SU"},
+	}
+}
+
+func mockCalendarDic(dbClientMock *dbMock.DBClient) *di.Container {
+	dic := mockDic()
+	queueClientMock := &queueMock.SchedulerQueueClient{}
+	queueClientMock.On("QueryIntervalByName", TestIntervalName).Return(contract.Interval{Name: TestIntervalName}, nil)
+	queueClientMock.On("QueryIntervalByName", "unknown").Return(contract.Interval{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "scheduler could not find interval with name : unknown", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		schedulerContainer.QueueName: func(get di.Get) interface{} {
+			return queueClientMock
+		},
+	})
+	return dic
+}
+
+func TestAddCalendar(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+
+	valid := calendars.AddCalendarRequest{
+		BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+		Calendar:    calendarData(),
+	}
+	added := valid.Calendar
+	added.Id = ExampleUUID
+	dbClientMock.On("AddCalendar", valid.Calendar).Return(added, nil)
+
+	duplicated := valid
+	duplicated.Calendar.Weekdays = []string{"SU"}
+	dbClientMock.On("AddCalendar", duplicated.Calendar).Return(duplicated.Calendar,
+		errors.NewCommonEdgeX(errors.KindDuplicateName, "interval TestInterval already has an exclusion calendar", nil))
+
+	unknownInterval := valid
+	unknownInterval.Calendar.Interval = "unknown"
+
+	invalidRule := valid
+	invalidRule.Calendar.Rules = []calendars.Rule{{Start: "2021-12-25", RRule: "FREQ=HOURLY"}}
+
+	controller := NewCalendarController(mockCalendarDic(dbClientMock))
+
+	tests := []struct {
+		name               string
+		request            calendars.AddCalendarRequest
+		expectedStatusCode int
+	}{
+		{"Valid", valid, http.StatusCreated},
+		{"Invalid - interval already has a calendar", duplicated, http.StatusConflict},
+		{"Invalid - unknown interval", unknownInterval, http.StatusNotFound},
+		{"Invalid - unsupported rule", invalidRule, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal([]calendars.AddCalendarRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, calendars.ApiCalendarRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.AddCalendar).ServeHTTP(recorder, req)
+
+			var res []common.BaseWithIdResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "BaseResponse status code not as expected")
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res[0].Id)
+			} else {
+				assert.NotEmpty(t, res[0].Message, "Message is empty")
+			}
+		})
+	}
+}
+
+func TestImportCalendar(t *testing.T) {
+	ical := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Christmas shutdown\r\n" +
+		"DTSTART;VALUE=DATE:20211224\r\n" +
+		"DTEND;VALUE=DATE:20220102\r\n" +
+		"RRULE:FREQ=YEARLY\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	expected := calendars.Calendar{
+		Interval: TestIntervalName,
+		Rules:    []calendars.Rule{{Summary: "Christmas shutdown", Start: "2021-12-24", Days: 9, RRule: "FREQ=YEARLY"}},
+	}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddCalendar", expected).Return(calendars.Calendar{Id: ExampleUUID}, nil)
+	controller := NewCalendarController(mockCalendarDic(dbClientMock))
+
+	tests := []struct {
+		name               string
+		interval           string
+		ical               string
+		expectedStatusCode int
+	}{
+		{"Valid", TestIntervalName, ical, http.StatusCreated},
+		{"Invalid - unknown interval", "unknown", ical, http.StatusNotFound},
+		{"Invalid - no event", TestIntervalName, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", http.StatusBadRequest},
+		{"Invalid - unsupported rule", TestIntervalName, strings.Replace(ical, "FREQ=YEARLY", "FREQ=MINUTELY", 1), http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, calendars.ApiCalendarICalRoute, strings.NewReader(testCase.ical))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.interval})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.ImportCalendar).ServeHTTP(recorder, req)
+
+			var res common.BaseWithIdResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res.Id)
+			} else {
+				assert.NotEmpty(t, res.Message, "Message is empty")
+			}
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "AddCalendar", 1)
+}
+
+func TestCalendarByInterval(t *testing.T) {
+	c := calendarData()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CalendarByInterval", c.Interval).Return(c, nil)
+	dbClientMock.On("CalendarByInterval", "notFound").Return(calendars.Calendar{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "exclusion calendar doesn't exist in the database", nil))
+	dbClientMock.On("DeleteCalendarByInterval", mock.Anything).Return(nil)
+	controller := NewCalendarController(mockCalendarDic(dbClientMock))
+
+	tests := []struct {
+		name               string
+		interval           string
+		expectedStatusCode int
+	}{
+		{"Valid", c.Interval, http.StatusOK},
+		{"Invalid - calendar not found", "notFound", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, calendars.ApiCalendarByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.interval})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.CalendarByInterval).ServeHTTP(recorder, req)
+
+			var res calendars.CalendarResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, c, res.Calendar, "Calendar not as expected")
+				assert.Len(t, res.Upcoming, calendars.UpcomingLimit, "the weekends of the coming year should be upcoming")
+			}
+		})
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, calendars.ApiCalendarByNameRoute, http.NoBody)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{v2.Name: c.Interval})
+	recorder := httptest.NewRecorder()
+	http.HandlerFunc(controller.DeleteCalendarByInterval).ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNoContent, recorder.Result().StatusCode)
+}
@@ -0,0 +1,175 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	queueMock "github.com/edgexfoundry/edgex-go/internal/support/scheduler/interfaces/mocks"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	V2Container "github.com/edgexfoundry/go-mod-bootstrap/v2/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const TestIntervalActionName = "TestIntervalAction"
+
+func deviceScopeData() devicescopes.DeviceScope {
+	return devicescopes.DeviceScope{
+		IntervalAction: TestIntervalActionName,
+		DeviceName:     "TestDevice",
+	}
+}
+
+func TestAddDeviceScope(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	queueClientMock := &queueMock.SchedulerQueueClient{}
+
+	valid := devicescopes.AddDeviceScopeRequest{
+		BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+		DeviceScope: deviceScopeData(),
+	}
+	added := valid.DeviceScope
+	added.Id = ExampleUUID
+	queueClientMock.On("QueryIntervalActionByName", TestIntervalActionName).Return(contract.IntervalAction{Name: TestIntervalActionName}, nil)
+	dbClientMock.On("AddDeviceScope", valid.DeviceScope).Return(added, nil)
+
+	duplicated := valid
+	duplicated.DeviceScope.IntervalAction = "duplicated"
+	queueClientMock.On("QueryIntervalActionByName", "duplicated").Return(contract.IntervalAction{Name: "duplicated"}, nil)
+	dbClientMock.On("AddDeviceScope", duplicated.DeviceScope).Return(duplicated.DeviceScope,
+		errors.NewCommonEdgeX(errors.KindDuplicateName, "interval action duplicated already has a device scope", nil))
+
+	unknownAction := valid
+	unknownAction.DeviceScope.IntervalAction = "unknown"
+	queueClientMock.On("QueryIntervalActionByName", "unknown").Return(contract.IntervalAction{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "scheduler could not find interval id with intervalAction name : unknown", nil))
+
+	noDevice := valid
+	noDevice.DeviceScope.DeviceName = ""
+
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		schedulerContainer.QueueName: func(get di.Get) interface{} {
+			return queueClientMock
+		},
+	})
+	controller := NewDeviceScopeController(dic)
+
+	tests := []struct {
+		name               string
+		request            devicescopes.AddDeviceScopeRequest
+		expectedStatusCode int
+	}{
+		{"Valid", valid, http.StatusCreated},
+		{"Invalid - interval action already scoped", duplicated, http.StatusConflict},
+		{"Invalid - unknown interval action", unknownAction, http.StatusNotFound},
+		{"Invalid - no device nor labels", noDevice, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal([]devicescopes.AddDeviceScopeRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, devicescopes.ApiDeviceScopeRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.AddDeviceScope).ServeHTTP(recorder, req)
+
+			var res []common.BaseWithIdResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "BaseResponse status code not as expected")
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res[0].Id)
+			} else {
+				assert.NotEmpty(t, res[0].Message, "Message is empty")
+			}
+		})
+	}
+}
+
+func TestDeviceScopeByIntervalAction(t *testing.T) {
+	s := deviceScopeData()
+	unreachable := s
+	unreachable.IntervalAction = "unreachable"
+	unreachable.DeviceName = "unreachable"
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceScopeByIntervalAction", s.IntervalAction).Return(s, nil)
+	dbClientMock.On("DeviceScopeByIntervalAction", unreachable.IntervalAction).Return(unreachable, nil)
+	dbClientMock.On("DeviceScopeByIntervalAction", "notFound").Return(devicescopes.DeviceScope{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device scope doesn't exist in the database", nil))
+	deviceClientMock := &clientMocks.DeviceClient{}
+	deviceClientMock.On("DeviceByName", mock.Anything, s.DeviceName).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: s.DeviceName, AdminState: models.Locked}}, nil)
+	deviceClientMock.On("DeviceByName", mock.Anything, unreachable.DeviceName).Return(responses.DeviceResponse{},
+		errors.NewCommonEdgeX(errors.KindServiceUnavailable, "connection refused", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		V2Container.MetadataDeviceClientName: func(get di.Get) interface{} {
+			return deviceClientMock
+		},
+	})
+	controller := NewDeviceScopeController(dic)
+
+	tests := []struct {
+		name               string
+		intervalAction     string
+		expectedStatusCode int
+		expectedSuspended  bool
+	}{
+		{"Valid - locked device", s.IntervalAction, http.StatusOK, true},
+		{"Valid - unreachable core-metadata", unreachable.IntervalAction, http.StatusOK, true},
+		{"Invalid - device scope not found", "notFound", http.StatusNotFound, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, devicescopes.ApiDeviceScopeByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.intervalAction})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.DeviceScopeByIntervalAction).ServeHTTP(recorder, req)
+
+			var res devicescopes.DeviceScopeResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, testCase.intervalAction, res.DeviceScope.IntervalAction, "Interval action not as expected")
+				assert.Equal(t, testCase.expectedSuspended, res.Status.Suspended, "Status not as expected")
+				assert.NotEmpty(t, res.Status.Reason, "Reason is empty")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/coordination"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// ApiExecutionMetricsRoute reports the executions this instance took the lock of
+const ApiExecutionMetricsRoute = v2.ApiBase + "/execution/metrics"
+
+// ExecutionMetricsResponse defines the response content of ApiExecutionMetricsRoute
+type ExecutionMetricsResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	// Coordinated is false when the execution locks are disabled, every execution being run
+	Coordinated bool                     `json:"coordinated"`
+	Metrics     coordination.LockMetrics `json:"metrics"`
+}
+
+type ExecutionController struct {
+	dic *di.Container
+}
+
+// NewExecutionController creates and initializes an ExecutionController
+func NewExecutionController(dic *di.Container) *ExecutionController {
+	return &ExecutionController{
+		dic: dic,
+	}
+}
+
+func (ec *ExecutionController) ExecutionMetrics(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ec.dic.Get)
+
+	response := ExecutionMetricsResponse{
+		BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+	}
+	if lock := schedulerContainer.ExecutionLockFrom(ec.dic.Get); lock != nil {
+		response.Coordinated = true
+		response.Metrics = lock.Metrics()
+	}
+
+	utils.WriteHttpHeader(w, r.Context(), http.StatusOK)
+	pkg.Encode(response, w, lc)
+}
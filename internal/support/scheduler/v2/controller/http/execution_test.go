@@ -0,0 +1,72 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/coordination"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// freeLease grants every lock
+type freeLease struct{}
+
+func (freeLease) AcquireLease(string, string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (freeLease) ReleaseLease(string, string) error {
+	return nil
+}
+
+func TestExecutionMetrics(t *testing.T) {
+	lock := coordination.NewExecutionLock(freeLease{}, "lock:", "first")
+	_, _ = lock.TryLock("interval:midnight", time.Hour)
+
+	tests := []struct {
+		name                string
+		lock                *coordination.ExecutionLock
+		expectedCoordinated bool
+		expectedOwned       uint64
+	}{
+		{"Coordinated", lock, true, 1},
+		{"Not coordinated", nil, false, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dic := mockDic()
+			if testCase.lock != nil {
+				dic.Update(di.ServiceConstructorMap{
+					schedulerContainer.ExecutionLockName: func(get di.Get) interface{} {
+						return testCase.lock
+					},
+				})
+			}
+			controller := NewExecutionController(dic)
+
+			req, err := http.NewRequest(http.MethodGet, ApiExecutionMetricsRoute, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.ExecutionMetrics).ServeHTTP(recorder, req)
+
+			var res ExecutionMetricsResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedCoordinated, res.Coordinated)
+			assert.Equal(t, testCase.expectedOwned, res.Metrics.Owned)
+		})
+	}
+}
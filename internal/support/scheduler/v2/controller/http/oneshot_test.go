@@ -0,0 +1,187 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const TestOneShotName = "TestOneShot"
+
+func oneShotData() oneshots.OneShot {
+	return oneshots.OneShot{
+		Name:       TestOneShotName,
+		RunAt:      time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond),
+		Protocol:   "http",
+		Host:       "localhost",
+		Port:       48080,
+		Path:       "/api/v2/event/age/0",
+		HTTPMethod: http.MethodDelete,
+	}
+}
+
+func TestAddOneShot(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+
+	valid := oneshots.AddOneShotRequest{
+		BaseRequest: common.BaseRequest{RequestId: ExampleUUID, Versionable: common.NewVersionable()},
+		OneShot:     oneShotData(),
+	}
+	added := valid.OneShot
+	added.Id = ExampleUUID
+	dbClientMock.On("AddOneShot", valid.OneShot).Return(added, nil)
+
+	duplicatedName := valid
+	duplicatedName.OneShot.Name = "duplicatedName"
+	dbClientMock.On("AddOneShot", duplicatedName.OneShot).Return(duplicatedName.OneShot,
+		errors.NewCommonEdgeX(errors.KindDuplicateName, "one-shot job name duplicatedName already exists", nil))
+
+	past := valid
+	past.OneShot.RunAt = 1609459200000
+	unsupportedMethod := valid
+	unsupportedMethod.OneShot.HTTPMethod = http.MethodPatch
+
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewOneShotController(dic)
+
+	tests := []struct {
+		name               string
+		request            oneshots.AddOneShotRequest
+		expectedStatusCode int
+	}{
+		{"Valid", valid, http.StatusCreated},
+		{"Invalid - duplicated name", duplicatedName, http.StatusConflict},
+		{"Invalid - in the past", past, http.StatusBadRequest},
+		{"Invalid - unsupported method", unsupportedMethod, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal([]oneshots.AddOneShotRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, oneshots.ApiOneShotRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.AddOneShot).ServeHTTP(recorder, req)
+
+			var res []common.BaseWithIdResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode, "BaseResponse status code not as expected")
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res[0].Id)
+			} else {
+				assert.NotEmpty(t, res[0].Message, "Message is empty")
+			}
+		})
+	}
+}
+
+func TestAllOneShots(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AllOneShots", 0, 20).Return([]oneshots.OneShot{oneShotData()}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewOneShotController(dic)
+
+	tests := []struct {
+		name               string
+		limit              string
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid", "20", 1, http.StatusOK},
+		{"Invalid - limit over MaxResultCount", "31", 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, oneshots.ApiAllOneShotRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(v2.Limit, testCase.limit)
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.AllOneShots).ServeHTTP(recorder, req)
+
+			var res oneshots.MultiOneShotsResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			assert.Len(t, res.OneShots, testCase.expectedCount, "One-shot job count not as expected")
+		})
+	}
+}
+
+func TestOneShotByName(t *testing.T) {
+	o := oneShotData()
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("OneShotByName", o.Name).Return(o, nil)
+	dbClientMock.On("OneShotByName", "notFound").Return(oneshots.OneShot{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "one-shot job doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewOneShotController(dic)
+
+	tests := []struct {
+		name               string
+		oneShotName        string
+		expectedStatusCode int
+	}{
+		{"Valid - find one-shot job by name", o.Name, http.StatusOK},
+		{"Invalid - one-shot job not found by name", "notFound", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, oneshots.ApiOneShotByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{v2.Name: testCase.oneShotName})
+
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.OneShotByName).ServeHTTP(recorder, req)
+
+			var res oneshots.OneShotResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, testCase.oneShotName, res.OneShot.Name, "Name not as expected")
+			}
+		})
+	}
+}
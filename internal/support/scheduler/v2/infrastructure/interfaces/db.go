@@ -6,6 +6,10 @@
 package interfaces
 
 import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	model "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 )
@@ -14,4 +18,20 @@ type DBClient interface {
 	CloseSession()
 
 	AddInterval(e model.Interval) (model.Interval, errors.EdgeX)
+
+	AddOneShot(o oneshots.OneShot) (oneshots.OneShot, errors.EdgeX)
+	AllOneShots(offset int, limit int) ([]oneshots.OneShot, errors.EdgeX)
+	DueOneShots(at int64) ([]oneshots.OneShot, errors.EdgeX)
+	OneShotByName(name string) (oneshots.OneShot, errors.EdgeX)
+	DeleteOneShotByName(name string) errors.EdgeX
+
+	AddDeviceScope(s devicescopes.DeviceScope) (devicescopes.DeviceScope, errors.EdgeX)
+	AllDeviceScopes(offset int, limit int) ([]devicescopes.DeviceScope, errors.EdgeX)
+	DeviceScopeByIntervalAction(name string) (devicescopes.DeviceScope, errors.EdgeX)
+	DeleteDeviceScopeByIntervalAction(name string) errors.EdgeX
+
+	AddCalendar(c calendars.Calendar) (calendars.Calendar, errors.EdgeX)
+	AllCalendars(offset int, limit int) ([]calendars.Calendar, errors.EdgeX)
+	CalendarByInterval(name string) (calendars.Calendar, errors.EdgeX)
+	DeleteCalendarByInterval(name string) errors.EdgeX
 }
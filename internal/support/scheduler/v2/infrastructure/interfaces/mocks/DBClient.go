@@ -3,6 +3,12 @@
 package mocks
 
 import (
+	calendars "github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+
+	devicescopes "github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+
+	oneshots "github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+
 	errors "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 
 	mock "github.com/stretchr/testify/mock"
@@ -15,6 +21,52 @@ type DBClient struct {
 	mock.Mock
 }
 
+// AddCalendar provides a mock function with given fields: c
+func (_m *DBClient) AddCalendar(c calendars.Calendar) (calendars.Calendar, errors.EdgeX) {
+	ret := _m.Called(c)
+
+	var r0 calendars.Calendar
+	if rf, ok := ret.Get(0).(func(calendars.Calendar) calendars.Calendar); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Get(0).(calendars.Calendar)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(calendars.Calendar) errors.EdgeX); ok {
+		r1 = rf(c)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AddDeviceScope provides a mock function with given fields: s
+func (_m *DBClient) AddDeviceScope(s devicescopes.DeviceScope) (devicescopes.DeviceScope, errors.EdgeX) {
+	ret := _m.Called(s)
+
+	var r0 devicescopes.DeviceScope
+	if rf, ok := ret.Get(0).(func(devicescopes.DeviceScope) devicescopes.DeviceScope); ok {
+		r0 = rf(s)
+	} else {
+		r0 = ret.Get(0).(devicescopes.DeviceScope)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(devicescopes.DeviceScope) errors.EdgeX); ok {
+		r1 = rf(s)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AddInterval provides a mock function with given fields: e
 func (_m *DBClient) AddInterval(e models.Interval) (models.Interval, errors.EdgeX) {
 	ret := _m.Called(e)
@@ -38,7 +90,247 @@ func (_m *DBClient) AddInterval(e models.Interval) (models.Interval, errors.Edge
 	return r0, r1
 }
 
+// AddOneShot provides a mock function with given fields: o
+func (_m *DBClient) AddOneShot(o oneshots.OneShot) (oneshots.OneShot, errors.EdgeX) {
+	ret := _m.Called(o)
+
+	var r0 oneshots.OneShot
+	if rf, ok := ret.Get(0).(func(oneshots.OneShot) oneshots.OneShot); ok {
+		r0 = rf(o)
+	} else {
+		r0 = ret.Get(0).(oneshots.OneShot)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(oneshots.OneShot) errors.EdgeX); ok {
+		r1 = rf(o)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AllCalendars provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllCalendars(offset int, limit int) ([]calendars.Calendar, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []calendars.Calendar
+	if rf, ok := ret.Get(0).(func(int, int) []calendars.Calendar); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]calendars.Calendar)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AllDeviceScopes provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllDeviceScopes(offset int, limit int) ([]devicescopes.DeviceScope, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []devicescopes.DeviceScope
+	if rf, ok := ret.Get(0).(func(int, int) []devicescopes.DeviceScope); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]devicescopes.DeviceScope)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AllOneShots provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllOneShots(offset int, limit int) ([]oneshots.OneShot, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []oneshots.OneShot
+	if rf, ok := ret.Get(0).(func(int, int) []oneshots.OneShot); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]oneshots.OneShot)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// CalendarByInterval provides a mock function with given fields: name
+func (_m *DBClient) CalendarByInterval(name string) (calendars.Calendar, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 calendars.Calendar
+	if rf, ok := ret.Get(0).(func(string) calendars.Calendar); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(calendars.Calendar)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // CloseSession provides a mock function with given fields:
 func (_m *DBClient) CloseSession() {
 	_m.Called()
 }
+
+// DeleteCalendarByInterval provides a mock function with given fields: name
+func (_m *DBClient) DeleteCalendarByInterval(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// DeleteDeviceScopeByIntervalAction provides a mock function with given fields: name
+func (_m *DBClient) DeleteDeviceScopeByIntervalAction(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// DeleteOneShotByName provides a mock function with given fields: name
+func (_m *DBClient) DeleteOneShotByName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// DeviceScopeByIntervalAction provides a mock function with given fields: name
+func (_m *DBClient) DeviceScopeByIntervalAction(name string) (devicescopes.DeviceScope, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 devicescopes.DeviceScope
+	if rf, ok := ret.Get(0).(func(string) devicescopes.DeviceScope); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(devicescopes.DeviceScope)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DueOneShots provides a mock function with given fields: at
+func (_m *DBClient) DueOneShots(at int64) ([]oneshots.OneShot, errors.EdgeX) {
+	ret := _m.Called(at)
+
+	var r0 []oneshots.OneShot
+	if rf, ok := ret.Get(0).(func(int64) []oneshots.OneShot); ok {
+		r0 = rf(at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]oneshots.OneShot)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int64) errors.EdgeX); ok {
+		r1 = rf(at)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// OneShotByName provides a mock function with given fields: name
+func (_m *DBClient) OneShotByName(name string) (oneshots.OneShot, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 oneshots.OneShot
+	if rf, ok := ret.Get(0).(func(string) oneshots.OneShot); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(oneshots.OneShot)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,60 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// maxICalSize bounds the size of the iCal files, a holiday schedule taking a few kilobytes
+const maxICalSize = 1 << 20
+
+// CalendarReader unmarshals a request body into an array of exclusion calendar requests, or reads an iCal file
+type CalendarReader interface {
+	ReadAddCalendarRequest(reader io.Reader) ([]calendars.AddCalendarRequest, errors.EdgeX)
+	ReadICal(reader io.Reader) ([]byte, errors.EdgeX)
+}
+
+// NewCalendarRequestReader returns a CalendarReader capable of processing the request body
+func NewCalendarRequestReader() CalendarReader {
+	return NewJsonCalendarReader()
+}
+
+// NewJsonCalendarReader creates a new instance of jsonCalendarReader
+func NewJsonCalendarReader() jsonCalendarReader {
+	return jsonCalendarReader{}
+}
+
+// jsonCalendarReader unmarshals the JSON request body payload
+type jsonCalendarReader struct{}
+
+// ReadAddCalendarRequest reads a request and then converts its JSON data into an array of AddCalendarRequest struct
+func (jsonCalendarReader) ReadAddCalendarRequest(reader io.Reader) ([]calendars.AddCalendarRequest, errors.EdgeX) {
+	var addCalendars []calendars.AddCalendarRequest
+	err := json.NewDecoder(reader).Decode(&addCalendars)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "exclusion calendar json decoding failed", err)
+	}
+	return addCalendars, nil
+}
+
+// ReadICal reads the iCal file of a request, which is not JSON
+func (jsonCalendarReader) ReadICal(reader io.Reader) ([]byte, errors.EdgeX) {
+	data, err := ioutil.ReadAll(io.LimitReader(reader, maxICalSize+1))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to read the iCal file", err)
+	}
+	if len(data) > maxICalSize {
+		return nil, errors.NewCommonEdgeX(errors.KindLimitExceeded, "the iCal file exceeds 1MB", nil)
+	}
+	return data, nil
+}
@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// DeviceScopeReader unmarshals a request body into an array of device scope requests
+type DeviceScopeReader interface {
+	ReadAddDeviceScopeRequest(reader io.Reader) ([]devicescopes.AddDeviceScopeRequest, errors.EdgeX)
+}
+
+// NewDeviceScopeRequestReader returns a DeviceScopeReader capable of processing the request body
+func NewDeviceScopeRequestReader() DeviceScopeReader {
+	return NewJsonDeviceScopeReader()
+}
+
+// NewJsonDeviceScopeReader creates a new instance of jsonDeviceScopeReader
+func NewJsonDeviceScopeReader() jsonDeviceScopeReader {
+	return jsonDeviceScopeReader{}
+}
+
+// jsonDeviceScopeReader unmarshals the JSON request body payload
+type jsonDeviceScopeReader struct{}
+
+// ReadAddDeviceScopeRequest reads a request and then converts its JSON data into an array of AddDeviceScopeRequest struct
+func (jsonDeviceScopeReader) ReadAddDeviceScopeRequest(reader io.Reader) ([]devicescopes.AddDeviceScopeRequest, errors.EdgeX) {
+	var addDeviceScopes []devicescopes.AddDeviceScopeRequest
+	err := json.NewDecoder(reader).Decode(&addDeviceScopes)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "device scope json decoding failed", err)
+	}
+	return addDeviceScopes, nil
+}
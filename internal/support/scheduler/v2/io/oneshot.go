@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// OneShotReader unmarshals a request body into an array of one-shot job requests
+type OneShotReader interface {
+	ReadAddOneShotRequest(reader io.Reader) ([]oneshots.AddOneShotRequest, errors.EdgeX)
+}
+
+// NewOneShotRequestReader returns a OneShotReader capable of processing the request body
+func NewOneShotRequestReader() OneShotReader {
+	return NewJsonOneShotReader()
+}
+
+// NewJsonOneShotReader creates a new instance of jsonOneShotReader
+func NewJsonOneShotReader() jsonOneShotReader {
+	return jsonOneShotReader{}
+}
+
+// jsonOneShotReader unmarshals the JSON request body payload
+type jsonOneShotReader struct{}
+
+// ReadAddOneShotRequest reads a request and then converts its JSON data into an array of AddOneShotRequest struct
+func (jsonOneShotReader) ReadAddOneShotRequest(reader io.Reader) ([]oneshots.AddOneShotRequest, errors.EdgeX) {
+	var addOneShots []oneshots.AddOneShotRequest
+	err := json.NewDecoder(reader).Decode(&addOneShots)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "one-shot job json decoding failed", err)
+	}
+	return addOneShots, nil
+}
@@ -7,11 +7,22 @@ package v2
 import (
 	"net/http"
 
+	"github.com/edgexfoundry/edgex-go/internal/pkg/calendars"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/devicescopes"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/httpclient"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/oneshots"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/openapi"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/schema"
 	commonController "github.com/edgexfoundry/edgex-go/internal/pkg/v2/controller/http"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
 	schedulerController "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/controller/http"
 
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	v2Constant "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
 
 	"github.com/gorilla/mux"
 )
@@ -24,8 +35,48 @@ func LoadRestRoutes(r *mux.Router, dic *di.Container) {
 	r.HandleFunc(v2Constant.ApiVersionRoute, cc.Version).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiConfigRoute, cc.Config).Methods(http.MethodGet)
 	r.HandleFunc(v2Constant.ApiMetricsRoute, cc.Metrics).Methods(http.MethodGet)
+	r.HandleFunc(correlation.ApiTraceRoute, cc.Trace).Methods(http.MethodGet)
+	r.HandleFunc(features.ApiCapabilitiesRoute, cc.Capabilities).Methods(http.MethodGet)
+	r.HandleFunc(httpclient.ApiOutboundMetricsRoute, cc.OutboundMetrics).Methods(http.MethodGet)
+
+	// Schemas
+	schemas := schema.NewRegistry(bootstrapContainer.LoggingClientFrom(dic.Get))
+	schemas.Add(oneshots.OneShotResponse{}, oneshots.MultiOneShotsResponse{}, schedulerController.ExecutionMetricsResponse{},
+		devicescopes.DeviceScopeResponse{}, devicescopes.MultiDeviceScopesResponse{},
+		calendars.CalendarResponse{}, calendars.MultiCalendarsResponse{})
+	schema.LoadRestRoutes(r, schemas)
+
+	// OpenAPI document
+	openapi.LoadRestRoutes(r, bootstrapContainer.LoggingClientFrom(dic.Get), openapi.SupportSchedulerSpec,
+		schedulerContainer.ConfigurationFrom(dic.Get).JWTAuth.Enabled)
 
 	// Interval
 	interval := schedulerController.NewIntervalController(dic)
-	r.HandleFunc(v2Constant.ApiIntervalRoute, interval.AddInterval).Methods(http.MethodPost)
+	r.HandleFunc(v2Constant.ApiIntervalRoute, schemas.ValidateRequest([]requests.AddIntervalRequest{}, interval.AddInterval)).Methods(http.MethodPost)
+
+	// One-shot job
+	oc := schedulerController.NewOneShotController(dic)
+	r.HandleFunc(oneshots.ApiOneShotRoute, schemas.ValidateRequest([]oneshots.AddOneShotRequest{}, oc.AddOneShot)).Methods(http.MethodPost)
+	r.HandleFunc(oneshots.ApiAllOneShotRoute, oc.AllOneShots).Methods(http.MethodGet)
+	r.HandleFunc(oneshots.ApiOneShotByNameRoute, oc.OneShotByName).Methods(http.MethodGet)
+	r.HandleFunc(oneshots.ApiOneShotByNameRoute, oc.DeleteOneShotByName).Methods(http.MethodDelete)
+
+	// Device scope
+	dc := schedulerController.NewDeviceScopeController(dic)
+	r.HandleFunc(devicescopes.ApiDeviceScopeRoute, schemas.ValidateRequest([]devicescopes.AddDeviceScopeRequest{}, dc.AddDeviceScope)).Methods(http.MethodPost)
+	r.HandleFunc(devicescopes.ApiAllDeviceScopeRoute, dc.AllDeviceScopes).Methods(http.MethodGet)
+	r.HandleFunc(devicescopes.ApiDeviceScopeByNameRoute, dc.DeviceScopeByIntervalAction).Methods(http.MethodGet)
+	r.HandleFunc(devicescopes.ApiDeviceScopeByNameRoute, dc.DeleteDeviceScopeByIntervalAction).Methods(http.MethodDelete)
+
+	// Exclusion calendar
+	calendar := schedulerController.NewCalendarController(dic)
+	r.HandleFunc(calendars.ApiCalendarRoute, schemas.ValidateRequest([]calendars.AddCalendarRequest{}, calendar.AddCalendar)).Methods(http.MethodPost)
+	r.HandleFunc(calendars.ApiCalendarICalRoute, calendar.ImportCalendar).Methods(http.MethodPost)
+	r.HandleFunc(calendars.ApiAllCalendarRoute, calendar.AllCalendars).Methods(http.MethodGet)
+	r.HandleFunc(calendars.ApiCalendarByNameRoute, calendar.CalendarByInterval).Methods(http.MethodGet)
+	r.HandleFunc(calendars.ApiCalendarByNameRoute, calendar.DeleteCalendarByInterval).Methods(http.MethodDelete)
+
+	// Execution
+	ec := schedulerController.NewExecutionController(dic)
+	r.HandleFunc(schedulerController.ApiExecutionMetricsRoute, ec.ExecutionMetrics).Methods(http.MethodGet)
 }
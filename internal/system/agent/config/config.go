@@ -15,6 +15,13 @@
 package config
 
 import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/hosttelemetry"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/snapshot"
+
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 )
 
@@ -29,11 +36,18 @@ type ConfigurationStruct struct {
 	Registry         bootstrapConfig.RegistryInfo
 	FormatSpecifier  string
 	SecretStore      bootstrapConfig.SecretStoreInfo
+	JWTAuth          jwtauth.JWTAuthInfo
+	Snapshots        snapshot.SnapshotsInfo
+	HostTelemetry    hosttelemetry.HostTelemetryInfo
 }
 
 type WritableInfo struct {
 	ResendLimit     int
 	LogLevel        string
+	ShutdownTimeout string
+	RequestLimits   requestlimits.RequestLimitsInfo
+	ProblemDetails  problem.ProblemDetailsInfo
+	CORS            cors.CORSInfo
 	InsecureSecrets bootstrapConfig.InsecureSecrets
 }
 
@@ -91,6 +105,26 @@ func (c *ConfigurationStruct) GetRegistryInfo() bootstrapConfig.RegistryInfo {
 	return c.Registry
 }
 
+// GetRequestLimits returns the limits applied to incoming requests.
+func (c *ConfigurationStruct) GetRequestLimits() requestlimits.RequestLimitsInfo {
+	return c.Writable.RequestLimits
+}
+
+// GetProblemDetails returns whether the failed requests are responded to with problem details.
+func (c *ConfigurationStruct) GetProblemDetails() problem.ProblemDetailsInfo {
+	return c.Writable.ProblemDetails
+}
+
+// GetCORS returns the CORS policy applied to incoming requests.
+func (c *ConfigurationStruct) GetCORS() cors.CORSInfo {
+	return c.Writable.CORS
+}
+
+// GetShutdownTimeout returns how long in-flight requests are given to complete when the service is stopped.
+func (c *ConfigurationStruct) GetShutdownTimeout() string {
+	return c.Writable.ShutdownTimeout
+}
+
 // GetInsecureSecrets returns the service's InsecureSecrets of which this service doesn't have. I.e. service has no secrets
 func (c *ConfigurationStruct) GetInsecureSecrets() bootstrapConfig.InsecureSecrets {
 	return nil
@@ -0,0 +1,287 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package hosttelemetry samples the resources of the host the services run on and publishes them to core-data as
+// the events of a reserved device, so that the host health goes through the same pipelines as the sensor data.
+package hosttelemetry
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/telemetry"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+)
+
+const (
+	// DefaultDeviceName is the reserved device the host telemetry events are published for
+	DefaultDeviceName = "edgex-host"
+	// DefaultProfileName is the profile of the host telemetry events
+	DefaultProfileName = "edgex-host-telemetry"
+
+	defaultInterval = 30 * time.Second
+)
+
+// The resource names of the readings of the host telemetry events. The readings of the disks, thermal zones and
+// network interfaces are suffixed with "-" and the name of the disk, zone or interface, e.g. "DiskUsagePercent-root".
+const (
+	CpuUsagePercent      = "CpuUsagePercent"
+	MemoryTotalBytes     = "MemoryTotalBytes"
+	MemoryUsedBytes      = "MemoryUsedBytes"
+	MemoryUsagePercent   = "MemoryUsagePercent"
+	DiskTotalBytes       = "DiskTotalBytes"
+	DiskUsedBytes        = "DiskUsedBytes"
+	DiskUsagePercent     = "DiskUsagePercent"
+	Temperature          = "Temperature"
+	NetworkReceivedBytes = "NetworkReceivedBytes"
+	NetworkSentBytes     = "NetworkSentBytes"
+	NetworkReceiveErrors = "NetworkReceiveErrors"
+	NetworkSendErrors    = "NetworkSendErrors"
+)
+
+// resourceNamePattern matches the names allowed as reading resource names
+var resourceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9\-_.~]+$`)
+
+// HostTelemetryInfo configures the sampling of the host resources
+type HostTelemetryInfo struct {
+	// Enabled publishes the host telemetry events
+	Enabled bool
+	// Interval is how often the host resources are sampled and published, e.g. "30s"
+	Interval string
+	// DeviceName is the device the events are published for. It doesn't need to be provisioned in core-metadata,
+	// but must not be the name of another device.
+	DeviceName string
+	// ProfileName is the profile the events are published for
+	ProfileName string
+	// Disks maps the names the disk usage is reported under to a path on each volume to sample, e.g. root = "/"
+	Disks map[string]string
+	// NetworkInterfaces are the network interfaces whose counters are reported. All but the loopback interface
+	// are reported when empty.
+	NetworkInterfaces []string
+}
+
+// DiskUsage is the usage of the volume holding a path
+type DiskUsage struct {
+	TotalBytes uint64
+	UsedBytes  uint64
+}
+
+// NetworkCounters are the counters of a network interface since the host started
+type NetworkCounters struct {
+	ReceivedBytes uint64
+	SentBytes     uint64
+	ReceiveErrors uint64
+	SendErrors    uint64
+}
+
+// Sample is the usage of the host resources at a point in time. The resources which couldn't be sampled are left
+// empty.
+type Sample struct {
+	// CpuUsagePercent is the CPU usage since the previous sample
+	CpuUsagePercent  float64
+	MemoryTotalBytes uint64
+	MemoryUsedBytes  uint64
+	// Disks maps the configured disk names to the usage of their volume
+	Disks map[string]DiskUsage
+	// Temperatures maps the thermal zones of the host to their temperature, in degrees Celsius
+	Temperatures map[string]float64
+	// Networks maps the network interfaces to their counters
+	Networks map[string]NetworkCounters
+}
+
+// Sampler samples the host resources
+type Sampler struct {
+	info    HostTelemetryInfo
+	procDir string
+	sysDir  string
+	lastCpu telemetry.CpuUsage
+}
+
+// NewSampler is a factory function that returns an initialized Sampler.
+func NewSampler(info HostTelemetryInfo) *Sampler {
+	return &Sampler{
+		info:    info,
+		procDir: "/proc",
+		sysDir:  "/sys",
+	}
+}
+
+// Sample samples the host resources, returning an error describing the resources which couldn't be sampled
+func (s *Sampler) Sample() (Sample, error) {
+	cpu := telemetry.PollCpu()
+	sample := Sample{CpuUsagePercent: telemetry.AvgCpuUsage(s.lastCpu, cpu)}
+	s.lastCpu = cpu
+	return sample, s.readHost(&sample)
+}
+
+// EventAdder adds events to core-data, e.g. an interfaces.EventClient
+type EventAdder interface {
+	Add(ctx context.Context, req requests.AddEventRequest) (common.BaseWithIdResponse, errors.EdgeX)
+}
+
+// Validate checks the interval and that the configured names can be used in the events
+func (info HostTelemetryInfo) Validate() error {
+	if _, err := parseInterval(info.Interval); err != nil {
+		return err
+	}
+	for _, name := range []string{info.DeviceName, info.ProfileName} {
+		if name != "" && !resourceNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid HostTelemetry name '%s', only letters, digits and -_.~ are allowed", name)
+		}
+	}
+	for name := range info.Disks {
+		if !resourceNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid HostTelemetry disk name '%s', only letters, digits and -_.~ are allowed", name)
+		}
+	}
+	return nil
+}
+
+func parseInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultInterval, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid HostTelemetry.Interval '%s': %s", value, err.Error())
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("HostTelemetry.Interval must be positive, got '%s'", value)
+	}
+	return interval, nil
+}
+
+// NewEvent returns the event reporting sample, with a reading for each sampled resource
+func NewEvent(sample Sample, info HostTelemetryInfo) (dtos.Event, error) {
+	deviceName := info.DeviceName
+	if deviceName == "" {
+		deviceName = DefaultDeviceName
+	}
+	profileName := info.ProfileName
+	if profileName == "" {
+		profileName = DefaultProfileName
+	}
+	event := dtos.NewEvent(profileName, deviceName)
+
+	var err error
+	add := func(resourceName string, valueType string, value interface{}) {
+		if err == nil {
+			err = event.AddSimpleReading(resourceName, valueType, value)
+		}
+	}
+
+	add(CpuUsagePercent, v2.ValueTypeFloat64, sample.CpuUsagePercent)
+	if sample.MemoryTotalBytes > 0 {
+		add(MemoryTotalBytes, v2.ValueTypeUint64, sample.MemoryTotalBytes)
+		add(MemoryUsedBytes, v2.ValueTypeUint64, sample.MemoryUsedBytes)
+		add(MemoryUsagePercent, v2.ValueTypeFloat64, percent(sample.MemoryUsedBytes, sample.MemoryTotalBytes))
+	}
+
+	var names []string
+	for name := range sample.Disks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		disk := sample.Disks[name]
+		add(DiskTotalBytes+"-"+name, v2.ValueTypeUint64, disk.TotalBytes)
+		add(DiskUsedBytes+"-"+name, v2.ValueTypeUint64, disk.UsedBytes)
+		add(DiskUsagePercent+"-"+name, v2.ValueTypeFloat64, percent(disk.UsedBytes, disk.TotalBytes))
+	}
+
+	names = nil
+	for name := range sample.Temperatures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(Temperature+"-"+name, v2.ValueTypeFloat64, sample.Temperatures[name])
+	}
+
+	names = nil
+	for name := range sample.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		counters := sample.Networks[name]
+		add(NetworkReceivedBytes+"-"+name, v2.ValueTypeUint64, counters.ReceivedBytes)
+		add(NetworkSentBytes+"-"+name, v2.ValueTypeUint64, counters.SentBytes)
+		add(NetworkReceiveErrors+"-"+name, v2.ValueTypeUint64, counters.ReceiveErrors)
+		add(NetworkSendErrors+"-"+name, v2.ValueTypeUint64, counters.SendErrors)
+	}
+
+	return event, err
+}
+
+func percent(used uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(used) * 100 / float64(total)
+}
+
+// Publish samples the host resources and adds the event reporting them to core-data. The resources which couldn't
+// be sampled are logged and left out of the event.
+func Publish(ctx context.Context, sampler *Sampler, adder EventAdder, info HostTelemetryInfo, lc logger.LoggingClient) error {
+	sample, err := sampler.Sample()
+	if err != nil {
+		lc.Warn(fmt.Sprintf("failed to sample some host resources: %s", err.Error()))
+	}
+	event, err := NewEvent(sample, info)
+	if err != nil {
+		return fmt.Errorf("failed to create the host telemetry event: %s", err.Error())
+	}
+	if _, err := adder.Add(correlation.NewContext(ctx), requests.NewAddEventRequest(event)); err != nil {
+		return fmt.Errorf("failed to publish the host telemetry event: %s", err.Error())
+	}
+	return nil
+}
+
+// Run publishes the host telemetry every info.Interval until ctx is done
+func Run(ctx context.Context, wg *sync.WaitGroup, lc logger.LoggingClient, sampler *Sampler, adder EventAdder, info HostTelemetryInfo) error {
+	interval, err := parseInterval(info.Interval)
+	if err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := Publish(ctx, sampler, adder, info, lc); err != nil {
+					lc.Error(err.Error())
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,116 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hosttelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventAdderStub struct {
+	requests []requests.AddEventRequest
+	err      errors.EdgeX
+}
+
+func (s *eventAdderStub) Add(_ context.Context, req requests.AddEventRequest) (common.BaseWithIdResponse, errors.EdgeX) {
+	s.requests = append(s.requests, req)
+	return common.BaseWithIdResponse{}, s.err
+}
+
+func TestHostTelemetryInfo_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		info  HostTelemetryInfo
+		valid bool
+	}{
+		{"Valid - defaults", HostTelemetryInfo{}, true},
+		{"Valid - disks", HostTelemetryInfo{Interval: "1m", Disks: map[string]string{"root": "/", "data-1": "/data"}}, true},
+		{"Invalid - interval", HostTelemetryInfo{Interval: "often"}, false},
+		{"Invalid - negative interval", HostTelemetryInfo{Interval: "-1m"}, false},
+		{"Invalid - device name", HostTelemetryInfo{DeviceName: "edgex host"}, false},
+		{"Invalid - disk name", HostTelemetryInfo{Disks: map[string]string{"/data": "/data"}}, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.info.Validate()
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	sample := Sample{
+		CpuUsagePercent:  12.5,
+		MemoryTotalBytes: 4000,
+		MemoryUsedBytes:  1000,
+		Disks:            map[string]DiskUsage{"root": {TotalBytes: 200, UsedBytes: 50}},
+		Temperatures:     map[string]float64{"thermal_zone0": 45.5},
+		Networks:         map[string]NetworkCounters{"eth0": {ReceivedBytes: 10, SentBytes: 20, ReceiveErrors: 1}},
+	}
+
+	event, err := NewEvent(sample, HostTelemetryInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDeviceName, event.DeviceName)
+	assert.Equal(t, DefaultProfileName, event.ProfileName)
+
+	var names []string
+	for _, reading := range event.Readings {
+		names = append(names, reading.ResourceName)
+		assert.Equal(t, DefaultDeviceName, reading.DeviceName)
+	}
+	assert.Equal(t, []string{
+		CpuUsagePercent, MemoryTotalBytes, MemoryUsedBytes, MemoryUsagePercent,
+		"DiskTotalBytes-root", "DiskUsedBytes-root", "DiskUsagePercent-root",
+		"Temperature-thermal_zone0",
+		"NetworkReceivedBytes-eth0", "NetworkSentBytes-eth0", "NetworkReceiveErrors-eth0", "NetworkSendErrors-eth0",
+	}, names)
+	assert.Equal(t, v2.ValueTypeUint64, event.Readings[1].ValueType)
+	assert.Equal(t, "4000", event.Readings[1].Value)
+	assert.Equal(t, v2.ValueTypeFloat64, event.Readings[3].ValueType)
+
+	event, err = NewEvent(Sample{}, HostTelemetryInfo{DeviceName: "gateway-1", ProfileName: "gateway"})
+	require.NoError(t, err)
+	assert.Equal(t, "gateway-1", event.DeviceName)
+	assert.Equal(t, "gateway", event.ProfileName)
+	require.Len(t, event.Readings, 1, "only the CPU usage is reported when the other resources couldn't be sampled")
+}
+
+func TestPublish(t *testing.T) {
+	sampler := NewSampler(HostTelemetryInfo{})
+	sampler.procDir = "testdata/proc"
+	sampler.sysDir = "testdata/sys"
+	adder := &eventAdderStub{}
+
+	require.NoError(t, Publish(context.Background(), sampler, adder, HostTelemetryInfo{}, logger.NewMockClient()))
+	require.Len(t, adder.requests, 1)
+	assert.Equal(t, DefaultDeviceName, adder.requests[0].Event.DeviceName)
+	assert.NotEmpty(t, adder.requests[0].Event.Readings)
+
+	adder.err = errors.NewCommonEdgeX(errors.KindServiceUnavailable, "connection refused", nil)
+	assert.Error(t, Publish(context.Background(), sampler, adder, HostTelemetryInfo{}, logger.NewMockClient()))
+}
@@ -0,0 +1,204 @@
+// +build linux
+
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hosttelemetry
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const loopbackInterface = "lo"
+
+// readHost samples the memory, disks, temperatures and network of the host
+func (s *Sampler) readHost(sample *Sample) error {
+	var failures []string
+
+	total, used, err := s.readMemory()
+	if err != nil {
+		failures = append(failures, err.Error())
+	} else {
+		sample.MemoryTotalBytes = total
+		sample.MemoryUsedBytes = used
+	}
+
+	sample.Disks = make(map[string]DiskUsage)
+	for name, path := range s.info.Disks {
+		usage, err := readDisk(path)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		sample.Disks[name] = usage
+	}
+
+	sample.Temperatures, err = readTemperatures(filepath.Join(s.sysDir, "class", "thermal"))
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+
+	sample.Networks, err = s.readNetworks()
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func (s *Sampler) readMemory() (total uint64, used uint64, err error) {
+	file, err := os.Open(filepath.Join(s.procDir, "meminfo"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read the memory usage: %s", err.Error())
+	}
+	defer func() { _ = file.Close() }()
+	total, available, err := parseMemInfo(file)
+	if err != nil {
+		return 0, 0, err
+	}
+	return total, total - available, nil
+}
+
+// parseMemInfo returns the MemTotal and MemAvailable of /proc/meminfo, in bytes
+func parseMemInfo(r io.Reader) (total uint64, available uint64, err error) {
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// e.g. "MemAvailable:    8046572 kB"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		name := strings.TrimSuffix(fields[0], ":")
+		if name != "MemTotal" && name != "MemAvailable" {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s in meminfo: %s", name, err.Error())
+		}
+		if len(fields) > 2 && fields[2] == "kB" {
+			value *= 1024
+		}
+		values[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	total, hasTotal := values["MemTotal"]
+	available, hasAvailable := values["MemAvailable"]
+	if !hasTotal || !hasAvailable || available > total {
+		return 0, 0, errors.New("no valid MemTotal and MemAvailable in meminfo")
+	}
+	return total, available, nil
+}
+
+// readDisk returns the usage of the volume holding path, as reported by df
+func readDisk(path string) (DiskUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return DiskUsage{}, fmt.Errorf("failed to read the disk usage of %s: %s", path, err.Error())
+	}
+	return DiskUsage{
+		TotalBytes: stat.Blocks * uint64(stat.Bsize),
+		UsedBytes:  (stat.Blocks - stat.Bfree) * uint64(stat.Bsize),
+	}, nil
+}
+
+// readTemperatures returns the temperature of each thermal zone of the thermal directory, in degrees Celsius. The
+// zones whose temperature can't be read, like those of the sensors powered off, are left out.
+func readTemperatures(thermalDir string) (map[string]float64, error) {
+	temperatures := make(map[string]float64)
+	zones, err := filepath.Glob(filepath.Join(thermalDir, "thermal_zone*"))
+	if err != nil {
+		return temperatures, err
+	}
+	for _, zone := range zones {
+		// e.g. "45000", in millidegrees Celsius
+		data, err := ioutil.ReadFile(filepath.Join(zone, "temp"))
+		if err != nil {
+			continue
+		}
+		value, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return temperatures, fmt.Errorf("invalid temperature of %s: %s", filepath.Base(zone), err.Error())
+		}
+		temperatures[filepath.Base(zone)] = float64(value) / 1000
+	}
+	return temperatures, nil
+}
+
+func (s *Sampler) readNetworks() (map[string]NetworkCounters, error) {
+	file, err := os.Open(filepath.Join(s.procDir, "net", "dev"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the network counters: %s", err.Error())
+	}
+	defer func() { _ = file.Close() }()
+	return parseNetDev(file, s.info.NetworkInterfaces)
+}
+
+// parseNetDev returns the counters of /proc/net/dev of the interfaces, or of all but the loopback interface when
+// interfaces is empty
+func parseNetDev(r io.Reader, interfaces []string) (map[string]NetworkCounters, error) {
+	wanted := make(map[string]bool)
+	for _, name := range interfaces {
+		wanted[name] = true
+	}
+
+	networks := make(map[string]NetworkCounters)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// e.g. "  eth0: 1200 10 0 0 0 0 0 0 3400 20 1 0 0 0 0 0", the two header lines have no colon
+		line := strings.SplitN(scanner.Text(), ":", 2)
+		if len(line) != 2 {
+			continue
+		}
+		name := strings.TrimSpace(line[0])
+		if (len(wanted) > 0 && !wanted[name]) || (len(wanted) == 0 && name == loopbackInterface) ||
+			!resourceNamePattern.MatchString(name) {
+			continue
+		}
+		fields := strings.Fields(line[1])
+		if len(fields) < 16 {
+			return networks, fmt.Errorf("invalid network counters of %s", name)
+		}
+		var values [4]uint64
+		for i, field := range []int{0, 8, 2, 10} {
+			value, err := strconv.ParseUint(fields[field], 10, 64)
+			if err != nil {
+				return networks, fmt.Errorf("invalid network counters of %s: %s", name, err.Error())
+			}
+			values[i] = value
+		}
+		networks[name] = NetworkCounters{
+			ReceivedBytes: values[0],
+			SentBytes:     values[1],
+			ReceiveErrors: values[2],
+			SendErrors:    values[3],
+		}
+	}
+	return networks, scanner.Err()
+}
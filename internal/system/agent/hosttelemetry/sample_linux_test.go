@@ -0,0 +1,84 @@
+// +build linux
+
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hosttelemetry
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemInfo(t *testing.T) {
+	total, available, err := parseMemInfo(strings.NewReader("MemTotal: 16318420 kB\nMemFree: 602448 kB\nMemAvailable: 8046572 kB\n"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(16318420*1024), total)
+	assert.Equal(t, uint64(8046572*1024), available)
+
+	_, _, err = parseMemInfo(strings.NewReader("MemTotal: 16318420 kB\n"))
+	assert.Error(t, err, "MemAvailable is required")
+}
+
+func TestParseNetDev(t *testing.T) {
+	netDev := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:   52000     500    0    0    0     0          0         0    52000     500    0    0    0     0       0          0
+  eth0: 1200000    9000    3    0    0     0          0         0   340000    2000    1    0    0     0       0          0
+`
+	networks, err := parseNetDev(strings.NewReader(netDev), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]NetworkCounters{
+		"eth0": {ReceivedBytes: 1200000, SentBytes: 340000, ReceiveErrors: 3, SendErrors: 1},
+	}, networks, "the loopback interface should be left out")
+
+	networks, err = parseNetDev(strings.NewReader(netDev), []string{"lo"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(52000), networks["lo"].ReceivedBytes)
+	assert.NotContains(t, networks, "eth0")
+
+	_, err = parseNetDev(strings.NewReader("  eth0: 1200000 9000\n"), nil)
+	assert.Error(t, err)
+}
+
+func TestSampler_Sample(t *testing.T) {
+	sampler := NewSampler(HostTelemetryInfo{Disks: map[string]string{"temp": t.TempDir(), "missing": "/does/not/exist"}})
+	sampler.procDir = "testdata/proc"
+	sampler.sysDir = "testdata/sys"
+
+	sample, err := sampler.Sample()
+	require.Error(t, err, "the missing disk should be reported")
+	assert.Contains(t, err.Error(), "/does/not/exist")
+	assert.Equal(t, uint64(4000000*1024), sample.MemoryTotalBytes)
+	assert.Equal(t, uint64(3000000*1024), sample.MemoryUsedBytes)
+	require.Contains(t, sample.Disks, "temp")
+	assert.NotZero(t, sample.Disks["temp"].TotalBytes)
+	assert.LessOrEqual(t, sample.Disks["temp"].UsedBytes, sample.Disks["temp"].TotalBytes)
+	assert.NotContains(t, sample.Disks, "missing")
+	assert.Equal(t, map[string]float64{"thermal_zone0": 45.5}, sample.Temperatures, "the zone without temperature should be left out")
+	assert.Equal(t, []string{"eth0", "wlan0"}, keys(sample.Networks))
+}
+
+func keys(networks map[string]NetworkCounters) []string {
+	var names []string
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,27 @@
+// +build !linux
+
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hosttelemetry
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// readHost samples the memory, disks, temperatures and network of the host
+func (s *Sampler) readHost(_ *Sample) error {
+	return fmt.Errorf("the host memory, disks, temperatures and network can't be sampled on %s", runtime.GOOS)
+}
@@ -16,23 +16,35 @@ package agent
 
 import (
 	"context"
+	"net/http"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/urlclient/local"
 
+	"github.com/edgexfoundry/edgex-go/internal/pkg/cors"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/problem"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/requestlimits"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/clients"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/container"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/direct"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/executor"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/getconfig"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/hosttelemetry"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/setconfig"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/snapshot"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/tracing"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 
 	contracts "github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/general"
+	V2Clients "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/clients/http"
 	"github.com/gorilla/mux"
 )
 
@@ -49,8 +61,15 @@ func NewBootstrap(router *mux.Router) *Bootstrap {
 }
 
 // BootstrapHandler fulfills the BootstrapHandler contract.  It implements agent-specific initialization.
-func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
 	loadRestRoutes(b.router, dic)
+	b.router.Use(problem.NewMiddleware(contracts.SystemManagementAgentServiceKey, container.ConfigurationFrom(dic.Get)))
+	cors.UseMiddleware(b.router, container.ConfigurationFrom(dic.Get))
+	b.router.Use(requestlimits.NewMiddleware(bootstrapContainer.LoggingClientFrom(dic.Get), container.ConfigurationFrom(dic.Get)))
+	if err := jwtauth.UseMiddleware(b.router, dic, container.ConfigurationFrom(dic.Get).JWTAuth); err != nil {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Error("failed to enable JWT verification: " + err.Error())
+		return false
+	}
 
 	configuration := container.ConfigurationFrom(dic.Get)
 
@@ -115,9 +134,77 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 		)
 	}
 
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	registryClient := bootstrapContainer.RegistryFrom(dic.Get)
+	snapshots := snapshot.NewStore(
+		getconfig.NewExecutor(generalClients, registryClient, lc, configuration.Service.Protocol),
+		func() ([]string, error) {
+			return b.listConfiguredServices(configuration.Clients), nil
+		},
+		lc,
+		configuration.Snapshots)
+	if err := snapshots.Load(); err != nil {
+		lc.Error("failed to load the configuration snapshots: " + err.Error())
+		return false
+	}
+	dic.Update(di.ServiceConstructorMap{
+		container.SnapshotsInterfaceName: func(get di.Get) interface{} {
+			return snapshots
+		},
+	})
+
+	tracer := tracing.NewTracer(
+		&http.Client{Timeout: time.Duration(configuration.Service.Timeout) * time.Millisecond},
+		contracts.SystemManagementAgentServiceKey,
+		func() map[string]string {
+			services := make(map[string]string)
+			for serviceKey, serviceName := range b.listDefaultServices() {
+				if client, ok := configuration.Clients[serviceName]; ok {
+					services[serviceKey] = client.Url()
+				}
+			}
+			return services
+		})
+	dic.Update(di.ServiceConstructorMap{
+		container.TracerInterfaceName: func(get di.Get) interface{} {
+			return tracer
+		},
+	})
+
+	if configuration.HostTelemetry.Enabled {
+		if err := configuration.HostTelemetry.Validate(); err != nil {
+			lc.Error(err.Error())
+			return false
+		}
+		err := hosttelemetry.Run(
+			ctx,
+			wg,
+			lc,
+			hosttelemetry.NewSampler(configuration.HostTelemetry),
+			V2Clients.NewEventClient(configuration.Clients["CoreData"].Url()),
+			configuration.HostTelemetry)
+		if err != nil {
+			lc.Error(err.Error())
+			return false
+		}
+		lc.Info("publishing the host telemetry to core-data")
+	}
+
 	return true
 }
 
+// listConfiguredServices returns the sorted keys of the default services with a configured client.
+func (b Bootstrap) listConfiguredServices(clients map[string]bootstrapConfig.ClientInfo) []string {
+	var services []string
+	for serviceKey, serviceName := range b.listDefaultServices() {
+		if _, ok := clients[serviceName]; ok {
+			services = append(services, serviceKey)
+		}
+	}
+	sort.Strings(services)
+	return services
+}
+
 func (Bootstrap) listDefaultServices() map[string]string {
 	return map[string]string{
 		contracts.SupportNotificationsServiceKey: "Notifications",
@@ -0,0 +1,50 @@
+/*******************************************************************************
+ * Copyright 2021 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package agent
+
+import (
+	"testing"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+
+	contracts "github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListConfiguredServices(t *testing.T) {
+	sut := Bootstrap{}
+	clients := map[string]bootstrapConfig.ClientInfo{
+		"Metadata":  {Host: "localhost", Port: 48081, Protocol: "http"},
+		"CoreData":  {Host: "localhost", Port: 48080, Protocol: "http"},
+		"Scheduler": {Host: "localhost", Port: 48085, Protocol: "http"},
+		"Unknown":   {Host: "localhost", Port: 48099, Protocol: "http"},
+	}
+
+	result := sut.listConfiguredServices(clients)
+
+	assert.Equal(t, []string{
+		contracts.CoreDataServiceKey,
+		contracts.CoreMetaDataServiceKey,
+		contracts.SupportSchedulerServiceKey,
+	}, result)
+}
+
+func TestListConfiguredServicesWithoutClients(t *testing.T) {
+	sut := Bootstrap{}
+
+	result := sut.listConfiguredServices(nil)
+
+	assert.Empty(t, result)
+}
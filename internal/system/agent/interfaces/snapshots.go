@@ -0,0 +1,29 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/snapshot"
+)
+
+// Snapshots defines a configuration snapshot abstraction.
+type Snapshots interface {
+	Capture(ctx context.Context) (snapshot.Snapshot, error)
+	List() []snapshot.Summary
+	Get(id string) (snapshot.Snapshot, error)
+	Diff(id string, base string) (snapshot.Diff, error)
+}
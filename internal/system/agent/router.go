@@ -16,6 +16,7 @@
 package agent
 
 import (
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -24,6 +25,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/container"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/snapshot"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
@@ -59,6 +61,36 @@ func loadRestRoutes(r *mux.Router, dic *di.Container) {
 			setConfigHandler(w, r, bootstrapContainer.LoggingClientFrom(dic.Get), container.SetConfigFrom(dic.Get))
 		}).Methods(http.MethodPut)
 
+	b.HandleFunc(
+		"/snapshot",
+		func(w http.ResponseWriter, r *http.Request) {
+			captureSnapshotHandler(w, r, bootstrapContainer.LoggingClientFrom(dic.Get), container.SnapshotsFrom(dic.Get))
+		}).Methods(http.MethodPost)
+
+	b.HandleFunc(
+		"/snapshot",
+		func(w http.ResponseWriter, r *http.Request) {
+			listSnapshotsHandler(w, bootstrapContainer.LoggingClientFrom(dic.Get), container.SnapshotsFrom(dic.Get))
+		}).Methods(http.MethodGet)
+
+	b.HandleFunc(
+		"/snapshot/{id}",
+		func(w http.ResponseWriter, r *http.Request) {
+			getSnapshotHandler(w, r, bootstrapContainer.LoggingClientFrom(dic.Get), container.SnapshotsFrom(dic.Get))
+		}).Methods(http.MethodGet)
+
+	b.HandleFunc(
+		"/snapshot/{id}/diff/{base}",
+		func(w http.ResponseWriter, r *http.Request) {
+			diffSnapshotHandler(w, r, bootstrapContainer.LoggingClientFrom(dic.Get), container.SnapshotsFrom(dic.Get))
+		}).Methods(http.MethodGet)
+
+	b.HandleFunc(
+		"/trace/{"+correlation.Id+"}",
+		func(w http.ResponseWriter, r *http.Request) {
+			traceHandler(w, r, bootstrapContainer.LoggingClientFrom(dic.Get), container.TracerFrom(dic.Get))
+		}).Methods(http.MethodGet)
+
 	b.HandleFunc(
 		"/metrics/{services}",
 		func(w http.ResponseWriter, r *http.Request) {
@@ -84,6 +116,17 @@ func loadRestRoutes(r *mux.Router, dic *di.Container) {
 	r.Use(correlation.OnRequestBegin)
 }
 
+// traceHandler implements a controller to follow a correlation id through the recent requests of the services.
+func traceHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	tracer interfaces.Tracer) {
+
+	vars := mux.Vars(r)
+	pkg.Encode(tracer.Trace(r.Context(), vars[correlation.Id]), w, lc)
+}
+
 // metricsHandler implements a controller to execute a metrics request.
 func metricsHandler(
 	w http.ResponseWriter,
@@ -184,3 +227,70 @@ func healthHandler(
 
 	pkg.Encode(getHealth(strings.Split(vars["services"], ","), registryClient), w, lc)
 }
+
+// captureSnapshotHandler implements a controller to capture the configuration of all the services as a snapshot.
+func captureSnapshotHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	snapshots interfaces.Snapshots) {
+
+	s, err := snapshots.Capture(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		lc.Error(err.Error())
+		return
+	}
+	pkg.Encode(s, w, lc)
+}
+
+// listSnapshotsHandler implements a controller to list the configuration snapshots.
+func listSnapshotsHandler(
+	w http.ResponseWriter,
+	lc logger.LoggingClient,
+	snapshots interfaces.Snapshots) {
+
+	pkg.Encode(snapshots.List(), w, lc)
+}
+
+// getSnapshotHandler implements a controller to retrieve a configuration snapshot.
+func getSnapshotHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	snapshots interfaces.Snapshots) {
+
+	vars := mux.Vars(r)
+	s, err := snapshots.Get(vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), snapshotErrorStatus(err))
+		lc.Error(err.Error())
+		return
+	}
+	pkg.Encode(s, w, lc)
+}
+
+// diffSnapshotHandler implements a controller to compare a configuration snapshot to another one or to the
+// shipped defaults.
+func diffSnapshotHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	snapshots interfaces.Snapshots) {
+
+	vars := mux.Vars(r)
+	diff, err := snapshots.Diff(vars["id"], vars["base"])
+	if err != nil {
+		http.Error(w, err.Error(), snapshotErrorStatus(err))
+		lc.Error(err.Error())
+		return
+	}
+	pkg.Encode(diff, w, lc)
+}
+
+func snapshotErrorStatus(err error) int {
+	if errors.Is(err, snapshot.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
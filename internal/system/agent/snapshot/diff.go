@@ -0,0 +1,211 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Kinds of Change
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Change is a configuration setting that differs between two snapshots
+type Change struct {
+	// Path is the dotted path of the setting, e.g. Writable.LogLevel
+	Path string      `json:"path"`
+	Kind string      `json:"kind"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Diff lists the configuration changes made between two snapshots
+type Diff struct {
+	// From is the id of the base snapshot, or Defaults
+	From string `json:"from"`
+	// To is the id of the compared snapshot
+	To string `json:"to"`
+	// Services maps the key of each service whose configuration changed to its changes, ordered by path
+	Services map[string][]Change `json:"services"`
+	// AddedServices and RemovedServices are the services only found in one of the snapshots
+	AddedServices   []string `json:"addedServices,omitempty"`
+	RemovedServices []string `json:"removedServices,omitempty"`
+	// Errors maps the key of each service that couldn't be compared to the reason
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// compare returns the changes from base to snapshot. When ignoreZeroAdditions is set, settings missing from base
+// but holding their zero value in snapshot are not reported, as a setting left out of a configuration file is
+// loaded as its zero value.
+func compare(base Snapshot, snapshot Snapshot, ignoreZeroAdditions bool) Diff {
+	diff := Diff{
+		From:     base.Id,
+		To:       snapshot.Id,
+		Services: make(map[string][]Change),
+	}
+
+	for service, configuration := range snapshot.Services {
+		baseConfiguration, ok := base.Services[service]
+		if !ok {
+			if _, failed := base.Errors[service]; !failed {
+				diff.AddedServices = append(diff.AddedServices, service)
+			}
+			continue
+		}
+		if changes := compareSettings(flatten(baseConfiguration), flatten(configuration), ignoreZeroAdditions); len(changes) > 0 {
+			diff.Services[service] = changes
+		}
+	}
+	for service := range base.Services {
+		if _, ok := snapshot.Services[service]; !ok {
+			if _, failed := snapshot.Errors[service]; !failed {
+				diff.RemovedServices = append(diff.RemovedServices, service)
+			}
+		}
+	}
+	sort.Strings(diff.AddedServices)
+	sort.Strings(diff.RemovedServices)
+
+	for _, errs := range []map[string]string{base.Errors, snapshot.Errors} {
+		for service, reason := range errs {
+			if diff.Errors == nil {
+				diff.Errors = make(map[string]string)
+			}
+			diff.Errors[service] = reason
+		}
+	}
+	return diff
+}
+
+func compareSettings(base map[string]interface{}, settings map[string]interface{}, ignoreZeroAdditions bool) []Change {
+	var changes []Change
+	for path, value := range settings {
+		baseValue, ok := base[path]
+		switch {
+		case !ok:
+			if !ignoreZeroAdditions || !isZero(value) {
+				changes = append(changes, Change{Path: path, Kind: Added, To: value})
+			}
+		case !reflect.DeepEqual(baseValue, value):
+			changes = append(changes, Change{Path: path, Kind: Changed, From: baseValue, To: value})
+		}
+	}
+	for path, baseValue := range base {
+		if _, ok := settings[path]; !ok {
+			changes = append(changes, Change{Path: path, Kind: Removed, From: baseValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// flatten returns the leaf settings of configuration keyed by their dotted path. Arrays are compared as a whole.
+func flatten(configuration map[string]interface{}) map[string]interface{} {
+	settings := make(map[string]interface{})
+	var walk func(prefix string, m map[string]interface{})
+	walk = func(prefix string, m map[string]interface{}) {
+		for key, value := range m {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+				walk(path, nested)
+				continue
+			}
+			settings[path] = value
+		}
+	}
+	walk("", configuration)
+	return settings
+}
+
+func isZero(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// loadDefaults reads the shipped configuration of the given services from directory as a snapshot. Services
+// without a <service key>.toml file are reported in the snapshot's Errors.
+func loadDefaults(directory string, services map[string]map[string]interface{}) (Snapshot, error) {
+	if directory == "" {
+		return Snapshot{}, errors.New("no Snapshots.DefaultsDirectory configured to read the shipped configuration from")
+	}
+
+	defaults := Snapshot{
+		Id:       Defaults,
+		Services: make(map[string]map[string]interface{}),
+		Errors:   make(map[string]string),
+	}
+	for service := range services {
+		configuration, err := loadDefaultConfiguration(filepath.Join(directory, service+".toml"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				defaults.Errors[service] = "no shipped configuration found"
+			} else {
+				defaults.Errors[service] = err.Error()
+			}
+			continue
+		}
+		defaults.Services[service] = configuration
+	}
+	return defaults, nil
+}
+
+// loadDefaultConfiguration reads a configuration.toml file, converting its values to the types they have once
+// decoded from the JSON returned by the services so that they compare equal.
+func loadDefaultConfiguration(fileName string) (map[string]interface{}, error) {
+	var configuration map[string]interface{}
+	if _, err := toml.DecodeFile(fileName, &configuration); err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read %s: %s", fileName, err.Error())
+	}
+
+	contents, err := json.Marshal(configuration)
+	if err != nil {
+		return nil, err
+	}
+	configuration = nil
+	if err := json.Unmarshal(contents, &configuration); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
@@ -0,0 +1,265 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/getconfig"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Defaults is the snapshot id standing for the shipped default configuration of the services
+	Defaults = "defaults"
+
+	defaultMaxSnapshots = 20
+	snapshotFileSuffix  = ".json"
+)
+
+// ErrNotFound is returned when the requested snapshot doesn't exist
+var ErrNotFound = errors.New("snapshot not found")
+
+// SnapshotsInfo configures where configuration snapshots are kept
+type SnapshotsInfo struct {
+	// Directory is where the snapshots are persisted so they survive a restart. Snapshots are only kept in
+	// memory when empty.
+	Directory string
+	// MaxSnapshots is the number of snapshots kept, the oldest ones being dropped first
+	MaxSnapshots int
+	// DefaultsDirectory holds the shipped configuration.toml of each service, named <service key>.toml, that
+	// snapshots are compared to when diffed against "defaults"
+	DefaultsDirectory string
+}
+
+// Snapshot is the effective configuration of all the known services at a point in time
+type Snapshot struct {
+	Id string `json:"id"`
+	// Timestamp is when the snapshot was taken, in milliseconds since the epoch
+	Timestamp int64 `json:"timestamp"`
+	// Services maps each service key to its configuration
+	Services map[string]map[string]interface{} `json:"services"`
+	// Errors maps the key of each service whose configuration couldn't be fetched to the reason
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Summary describes a snapshot without its configuration
+type Summary struct {
+	Id        string            `json:"id"`
+	Timestamp int64             `json:"timestamp"`
+	Services  []string          `json:"services"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// ServiceLister returns the keys of the services whose configuration is captured
+type ServiceLister func() ([]string, error)
+
+// Store captures, keeps and compares configuration snapshots.
+type Store struct {
+	executor     getconfig.GetExecutor
+	listServices ServiceLister
+	lc           logger.LoggingClient
+	info         SnapshotsInfo
+	now          func() time.Time
+	mutex        sync.RWMutex
+	// snapshots is ordered from the oldest to the most recent
+	snapshots []Snapshot
+}
+
+// NewStore is a factory function that returns an initialized Store.
+func NewStore(executor getconfig.GetExecutor, listServices ServiceLister, lc logger.LoggingClient, info SnapshotsInfo) *Store {
+	if info.MaxSnapshots <= 0 {
+		info.MaxSnapshots = defaultMaxSnapshots
+	}
+	return &Store{
+		executor:     executor,
+		listServices: listServices,
+		lc:           lc,
+		info:         info,
+		now:          time.Now,
+	}
+}
+
+// Load reads the snapshots persisted in the configured directory, creating the directory when needed.
+func (s *Store) Load() error {
+	if s.info.Directory == "" {
+		return nil
+	}
+	if err := os.MkdirAll(s.info.Directory, 0700); err != nil {
+		return err
+	}
+	files, err := ioutil.ReadDir(s.info.Directory)
+	if err != nil {
+		return err
+	}
+
+	var snapshots []Snapshot
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), snapshotFileSuffix) {
+			continue
+		}
+		contents, err := ioutil.ReadFile(filepath.Join(s.info.Directory, file.Name()))
+		if err != nil {
+			return err
+		}
+		var snapshot Snapshot
+		if err := json.Unmarshal(contents, &snapshot); err != nil {
+			s.lc.Warn(fmt.Sprintf("skipping unreadable configuration snapshot %s: %s", file.Name(), err.Error()))
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Timestamp < snapshots[j].Timestamp })
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.snapshots = snapshots
+	s.prune()
+	return nil
+}
+
+// Capture fetches the configuration of every service and keeps it as a new snapshot. Services whose configuration
+// can't be fetched are reported in the snapshot's Errors rather than failing the capture.
+func (s *Store) Capture(ctx context.Context) (Snapshot, error) {
+	services, err := s.listServices()
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to list the services: %s", err.Error())
+	}
+
+	snapshot := Snapshot{
+		Id:        uuid.New().String(),
+		Timestamp: s.now().UnixNano() / int64(time.Millisecond),
+		Services:  make(map[string]map[string]interface{}),
+	}
+	for _, service := range services {
+		c, err := s.executor.Do(ctx, service)
+		if err == nil {
+			var configuration map[string]interface{}
+			if err = json.Unmarshal([]byte(c), &configuration); err == nil {
+				snapshot.Services[service] = configuration
+				continue
+			}
+		}
+		if snapshot.Errors == nil {
+			snapshot.Errors = make(map[string]string)
+		}
+		snapshot.Errors[service] = err.Error()
+		s.lc.Error(fmt.Sprintf("failed to capture the configuration of %s: %s", service, err.Error()))
+	}
+
+	if s.info.Directory != "" {
+		contents, err := json.Marshal(snapshot)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		if err := ioutil.WriteFile(s.fileName(snapshot.Id), contents, 0600); err != nil {
+			return Snapshot{}, fmt.Errorf("failed to persist the snapshot: %s", err.Error())
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.snapshots = append(s.snapshots, snapshot)
+	s.prune()
+	return snapshot, nil
+}
+
+// List returns the summary of every snapshot, the most recent first.
+func (s *Store) List() []Summary {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	summaries := make([]Summary, 0, len(s.snapshots))
+	for i := len(s.snapshots) - 1; i >= 0; i-- {
+		snapshot := s.snapshots[i]
+		services := make([]string, 0, len(snapshot.Services))
+		for service := range snapshot.Services {
+			services = append(services, service)
+		}
+		sort.Strings(services)
+		summaries = append(summaries, Summary{
+			Id:        snapshot.Id,
+			Timestamp: snapshot.Timestamp,
+			Services:  services,
+			Errors:    snapshot.Errors,
+		})
+	}
+	return summaries
+}
+
+// Get returns the snapshot with the given id, or ErrNotFound.
+func (s *Store) Get(id string) (Snapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, snapshot := range s.snapshots {
+		if snapshot.Id == id {
+			return snapshot, nil
+		}
+	}
+	return Snapshot{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// Diff returns the changes made to the configuration from the base snapshot to the snapshot with the given id.
+// base may be Defaults to compare the snapshot to the shipped default configuration.
+func (s *Store) Diff(id string, base string) (Diff, error) {
+	snapshot, err := s.Get(id)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	if base == Defaults {
+		defaults, err := loadDefaults(s.info.DefaultsDirectory, snapshot.Services)
+		if err != nil {
+			return Diff{}, err
+		}
+		return compare(defaults, snapshot, true), nil
+	}
+
+	baseSnapshot, err := s.Get(base)
+	if err != nil {
+		return Diff{}, err
+	}
+	return compare(baseSnapshot, snapshot, false), nil
+}
+
+// prune drops the oldest snapshots exceeding the configured maximum. The caller must hold the write lock.
+func (s *Store) prune() {
+	for len(s.snapshots) > s.info.MaxSnapshots {
+		if s.info.Directory != "" {
+			if err := os.Remove(s.fileName(s.snapshots[0].Id)); err != nil && !os.IsNotExist(err) {
+				s.lc.Warn(fmt.Sprintf("failed to remove configuration snapshot %s: %s", s.snapshots[0].Id, err.Error()))
+			}
+		}
+		s.snapshots = s.snapshots[1:]
+	}
+}
+
+func (s *Store) fileName(id string) string {
+	return filepath.Join(s.info.Directory, id+snapshotFileSuffix)
+}
@@ -0,0 +1,187 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	coreData = "edgex-core-data"
+	metadata = "edgex-core-metadata"
+)
+
+// stubExecutor returns the configured JSON configuration of each service, or an error for unknown services.
+type stubExecutor struct {
+	configurations map[string]string
+}
+
+func (e *stubExecutor) Do(_ context.Context, service string) (string, error) {
+	c, ok := e.configurations[service]
+	if !ok {
+		return "", errors.New("service " + service + " is not available")
+	}
+	return c, nil
+}
+
+func newTestStore(executor *stubExecutor, info SnapshotsInfo) *Store {
+	services := func() ([]string, error) { return []string{coreData, metadata}, nil }
+	store := NewStore(executor, services, logger.NewMockClient(), info)
+	var now int64
+	store.now = func() time.Time {
+		now++
+		return time.Unix(now, 0)
+	}
+	return store
+}
+
+func TestCapture(t *testing.T) {
+	executor := &stubExecutor{configurations: map[string]string{
+		coreData: `{"Writable":{"LogLevel":"INFO"},"Service":{"Port":48080}}`,
+	}}
+	store := newTestStore(executor, SnapshotsInfo{})
+
+	s, err := store.Capture(context.Background())
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, s.Id)
+	assert.Equal(t, map[string]interface{}{"LogLevel": "INFO"}, s.Services[coreData]["Writable"])
+	assert.Contains(t, s.Errors, metadata)
+
+	stored, err := store.Get(s.Id)
+	require.NoError(t, err)
+	assert.Equal(t, s, stored)
+
+	_, err = store.Get("unknown")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestListAndPrune(t *testing.T) {
+	executor := &stubExecutor{configurations: map[string]string{coreData: `{}`, metadata: `{}`}}
+	store := newTestStore(executor, SnapshotsInfo{MaxSnapshots: 2})
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		s, err := store.Capture(context.Background())
+		require.NoError(t, err)
+		ids = append(ids, s.Id)
+	}
+
+	summaries := store.List()
+	require.Len(t, summaries, 2)
+	assert.Equal(t, ids[2], summaries[0].Id, "the most recent snapshot should be listed first")
+	assert.Equal(t, ids[1], summaries[1].Id)
+	assert.Equal(t, []string{coreData, metadata}, summaries[0].Services)
+	_, err := store.Get(ids[0])
+	assert.Error(t, err, "the oldest snapshot should have been dropped")
+}
+
+func TestPersistence(t *testing.T) {
+	directory, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(directory) }()
+
+	executor := &stubExecutor{configurations: map[string]string{coreData: `{"Writable":{"LogLevel":"INFO"}}`}}
+	info := SnapshotsInfo{Directory: directory, MaxSnapshots: 1}
+	first, err := newTestStore(executor, info).Capture(context.Background())
+	require.NoError(t, err)
+
+	store := newTestStore(executor, info)
+	require.NoError(t, store.Load())
+	loaded, err := store.Get(first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, first, loaded)
+
+	second, err := store.Capture(context.Background())
+	require.NoError(t, err)
+	files, err := filepath.Glob(filepath.Join(directory, "*"+snapshotFileSuffix))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(directory, second.Id+snapshotFileSuffix)}, files)
+}
+
+func TestDiff(t *testing.T) {
+	executor := &stubExecutor{configurations: map[string]string{
+		coreData: `{"Writable":{"LogLevel":"INFO","PersistData":true},"Service":{"Port":48080}}`,
+	}}
+	store := newTestStore(executor, SnapshotsInfo{})
+	before, err := store.Capture(context.Background())
+	require.NoError(t, err)
+
+	executor.configurations = map[string]string{
+		coreData: `{"Writable":{"LogLevel":"DEBUG","MaxEvents":100},"Service":{"Port":48080}}`,
+		metadata: `{"Service":{"Port":48081}}`,
+	}
+	after, err := store.Capture(context.Background())
+	require.NoError(t, err)
+
+	diff, err := store.Diff(after.Id, before.Id)
+
+	require.NoError(t, err)
+	assert.Equal(t, before.Id, diff.From)
+	assert.Equal(t, after.Id, diff.To)
+	assert.Equal(t, []Change{
+		{Path: "Writable.LogLevel", Kind: Changed, From: "INFO", To: "DEBUG"},
+		{Path: "Writable.MaxEvents", Kind: Added, To: float64(100)},
+		{Path: "Writable.PersistData", Kind: Removed, From: true},
+	}, diff.Services[coreData])
+	assert.Empty(t, diff.AddedServices, "metadata failed in the base snapshot so it isn't reported as added")
+	assert.Contains(t, diff.Errors, metadata)
+
+	_, err = store.Diff(after.Id, "unknown")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestDiffDefaults(t *testing.T) {
+	directory, err := ioutil.TempDir("", "defaults")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(directory) }()
+	defaults := "[Writable]\nLogLevel = 'INFO'\n[Service]\nPort = 48080\nTimeout = 5000\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(directory, coreData+".toml"), []byte(defaults), 0600))
+
+	executor := &stubExecutor{configurations: map[string]string{
+		coreData: `{"Writable":{"LogLevel":"DEBUG","Title":""},"Service":{"Port":48080,"Timeout":5000}}`,
+		metadata: `{"Service":{"Port":48081}}`,
+	}}
+	store := newTestStore(executor, SnapshotsInfo{DefaultsDirectory: directory})
+	s, err := store.Capture(context.Background())
+	require.NoError(t, err)
+
+	diff, err := store.Diff(s.Id, Defaults)
+
+	require.NoError(t, err)
+	assert.Equal(t, Defaults, diff.From)
+	assert.Equal(t, []Change{
+		{Path: "Writable.LogLevel", Kind: Changed, From: "INFO", To: "DEBUG"},
+	}, diff.Services[coreData], "zero valued settings missing from the defaults should be ignored")
+	assert.NotContains(t, diff.Services, metadata)
+	assert.Contains(t, diff.Errors, metadata, "services without shipped configuration should be reported")
+
+	store = newTestStore(executor, SnapshotsInfo{})
+	s, err = store.Capture(context.Background())
+	require.NoError(t, err)
+	_, err = store.Diff(s.Id, Defaults)
+	assert.Error(t, err, "diffing against the defaults requires a defaults directory")
+}
@@ -0,0 +1,113 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+)
+
+// Step is a request of the traced correlation id handled by a service
+type Step struct {
+	Service string `json:"service"`
+	correlation.Request
+}
+
+// Trace is the path of a correlation id through the services, as found in their recent requests
+type Trace struct {
+	CorrelationId string `json:"correlationId"`
+	// Timeline holds the requests of all the services, the oldest first
+	Timeline []Step `json:"timeline"`
+	// Errors maps the key of each service whose requests couldn't be fetched to the reason
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// ServiceLister returns the base URL of each traced service, by service key
+type ServiceLister func() map[string]string
+
+// Tracer follows a correlation id through the recent requests of the services, which they return through
+// correlation.ApiTraceRoute, and of the agent itself.
+type Tracer struct {
+	client       *http.Client
+	serviceKey   string
+	listServices ServiceLister
+}
+
+// NewTracer is a factory function that returns an initialized Tracer. serviceKey is the key the requests of the
+// agent are listed under.
+func NewTracer(client *http.Client, serviceKey string, listServices ServiceLister) *Tracer {
+	return &Tracer{
+		client:       client,
+		serviceKey:   serviceKey,
+		listServices: listServices,
+	}
+}
+
+// Trace returns the recent requests carrying correlationId. The services which can't be reached are reported in
+// the errors of the trace rather than failing it.
+func (t *Tracer) Trace(ctx context.Context, correlationId string) Trace {
+	trace := Trace{CorrelationId: correlationId, Timeline: []Step{}, Errors: map[string]string{}}
+	for _, request := range correlation.RecentRequests.Find(correlationId) {
+		trace.Timeline = append(trace.Timeline, Step{Service: t.serviceKey, Request: request})
+	}
+
+	for service, baseUrl := range t.listServices() {
+		requests, err := t.fetch(ctx, baseUrl, correlationId)
+		if err != nil {
+			trace.Errors[service] = err.Error()
+			continue
+		}
+		for _, request := range requests {
+			trace.Timeline = append(trace.Timeline, Step{Service: service, Request: request})
+		}
+	}
+
+	sort.SliceStable(trace.Timeline, func(i, j int) bool {
+		return trace.Timeline[i].Timestamp < trace.Timeline[j].Timestamp
+	})
+	return trace
+}
+
+// fetch returns the recent requests carrying correlationId of the service at baseUrl
+func (t *Tracer) fetch(ctx context.Context, baseUrl string, correlationId string) ([]correlation.Request, error) {
+	route := strings.Replace(correlation.ApiTraceRoute, "{"+correlation.Id+"}", url.PathEscape(correlationId), 1)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseUrl, "/")+route, nil)
+	if err != nil {
+		return nil, err
+	}
+	correlation.Propagate(ctx, req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("trace request failed with status %d", resp.StatusCode)
+	}
+
+	var response correlation.TraceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	return response.Requests, nil
+}
@@ -0,0 +1,82 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	agent    = "edgex-sys-mgmt-agent"
+	coreData = "edgex-core-data"
+	metadata = "edgex-core-metadata"
+	traced   = "0b1f7e48-8c7c-4a3d-9b0a-3d4e0a1a9c11"
+)
+
+// traceServer answers the trace requests of the agent with requests, remembering the correlation id the
+// agent called it with.
+func traceServer(t *testing.T, requests []correlation.Request, received *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/trace/"+traced, r.URL.Path)
+		*received = r.Header.Get(clients.CorrelationHeader)
+		_ = json.NewEncoder(w).Encode(correlation.TraceResponse{CorrelationId: traced, Requests: requests})
+	}))
+}
+
+func TestTrace(t *testing.T) {
+	var received string
+	data := traceServer(t, []correlation.Request{
+		{CorrelationId: traced, Method: http.MethodPost, Path: "/api/v2/event", StatusCode: http.StatusCreated, Timestamp: 30},
+	}, &received)
+	defer data.Close()
+	meta := traceServer(t, []correlation.Request{
+		{CorrelationId: traced, Method: http.MethodGet, Path: "/api/v2/device/name/d1", StatusCode: http.StatusOK, Timestamp: 20},
+	}, &received)
+	defer meta.Close()
+	correlation.RecentRequests.Record(correlation.Request{CorrelationId: traced, Path: "/api/v1/operation", Timestamp: 10})
+
+	tracer := NewTracer(http.DefaultClient, agent, func() map[string]string {
+		return map[string]string{coreData: data.URL, metadata: meta.URL, "edgex-support-scheduler": "http://127.0.0.1:0"}
+	})
+	ctx := context.WithValue(context.Background(), clients.CorrelationHeader, "agent-request")
+	trace := tracer.Trace(ctx, traced)
+
+	assert.Equal(t, traced, trace.CorrelationId)
+	require.Len(t, trace.Timeline, 3)
+	assert.Equal(t, []string{agent, metadata, coreData},
+		[]string{trace.Timeline[0].Service, trace.Timeline[1].Service, trace.Timeline[2].Service},
+		"the requests must be ordered by time across the services")
+	assert.Equal(t, "/api/v2/event", trace.Timeline[2].Path)
+	assert.Contains(t, trace.Errors, "edgex-support-scheduler")
+	assert.Equal(t, "agent-request", received, "the trace requests must carry the correlation id of the agent request")
+}
+
+func TestTraceUnknown(t *testing.T) {
+	tracer := NewTracer(http.DefaultClient, agent, func() map[string]string { return nil })
+	trace := tracer.Trace(context.Background(), "unknown")
+	assert.Empty(t, trace.Timeline)
+	assert.Empty(t, trace.Errors)
+}
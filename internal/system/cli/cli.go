@@ -0,0 +1,361 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cli implements the edgex administration command line, which lists, adds, updates and deletes the devices,
+// device profiles, events, notifications and intervals through the REST APIs of the services, either directly or
+// through the API gateway with a JWT in secure mode, and imports them in bulk from JSON or YAML files.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+// TokenEnv holds the JWT sent to the services, which is kept off the command line
+const TokenEnv = "EDGEX_TOKEN"
+
+// Exit status codes
+const (
+	ExitNormal = 0
+	ExitError  = 1
+	ExitUsage  = 2
+)
+
+// options are the global options of the command line
+type options struct {
+	ClientOptions
+	json bool
+}
+
+// importSections are the sections of an import file, in the order they are imported, and their resources
+var importSections = []struct {
+	section  string
+	resource string
+}{
+	{"profiles", "profile"},
+	{"devices", "device"},
+	{"intervals", "interval"},
+	{"notifications", "notification"},
+}
+
+func usage(flagSet *flag.FlagSet) func() {
+	return func() {
+		w := flagSet.Output()
+		fmt.Fprintf(w, "Usage: edgex [options] <resource> <action> [arguments]\n")
+		fmt.Fprintf(w, "       edgex [options] import -f <file>\n")
+		fmt.Fprintf(w, "       edgex [options] quickstart [-dir d] [-bin d] [-device name] [-no-start] [-yes]\n\n")
+		fmt.Fprintf(w, "Resources and their actions:\n")
+		for _, name := range resourceOrder {
+			fmt.Fprintf(w, "  %-13s %s\n", name, strings.Join(actionsOf(resources[name]), ", "))
+		}
+		fmt.Fprintf(w, "\n  list [-offset n] [-limit n] [-labels a,b] [-device name]\n")
+		fmt.Fprintf(w, "  get <key>\n")
+		fmt.Fprintf(w, "  add -f <file>, update -f <file>: the objects of a JSON or YAML file, an object or an array\n")
+		fmt.Fprintf(w, "  delete <key>...; event delete -device <name> or -age <duration>\n")
+		fmt.Fprintf(w, "  count [-device name]\n")
+		fmt.Fprintf(w, "  tail [-limit n] [-f]: the newest events, then with -f those added, until interrupted\n\n")
+		fmt.Fprintf(w, "import adds the profiles, devices, intervals and notifications sections of a file, in this order.\n")
+		fmt.Fprintf(w, "quickstart starts core-metadata, core-data and core-command on the in-memory database, verifying a\n")
+		fmt.Fprintf(w, "generated JWT, registers a virtual device with a first reading and prints example API calls.\n")
+		fmt.Fprintf(w, "In secure mode the requests go through the API gateway with the JWT read from %s, or signed\n", TokenEnv)
+		fmt.Fprintf(w, "with -jwt-key for the API gateway user -jwt-id.\n\nOptions:\n")
+		flagSet.PrintDefaults()
+	}
+}
+
+func actionsOf(r *resource) []string {
+	actions := []string{"list", "get"}
+	if r.addPath != "" {
+		actions = append(actions, "add")
+	}
+	if r.updatePath != "" {
+		actions = append(actions, "update")
+	}
+	if r.name == "event" {
+		actions = append(actions, "count", "tail")
+	}
+	if r.deletePath != "" {
+		actions = append(actions, "delete")
+	}
+	return actions
+}
+
+// Main runs the command line of args, without the program name, and returns the exit status
+func Main(args []string, stdout io.Writer, stderr io.Writer) int {
+	var opts options
+	flagSet := flag.NewFlagSet("edgex", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.StringVar(&opts.Host, "host", "localhost", "Host of the services, or of the API gateway in secure mode")
+	flagSet.BoolVar(&opts.Secure, "secure", false, "Call the services through the API gateway over HTTPS")
+	flagSet.IntVar(&opts.GatewayPort, "gateway-port", 8443, "HTTPS port of the API gateway")
+	flagSet.StringVar(&opts.CACert, "ca-cert", "", "PEM file of the CA certificate of the API gateway")
+	flagSet.BoolVar(&opts.InsecureSkipVerify, "insecure-skip-verify", false, "Don't verify the certificate of the API gateway")
+	flagSet.StringVar(&opts.JWTKey, "jwt-key", "", "PEM file of the private key signing the JWT, instead of "+TokenEnv)
+	flagSet.StringVar(&opts.JWTId, "jwt-id", "", "The 'key' (ID) of the API gateway user, from 'secrets-config proxy adduser'")
+	flagSet.StringVar(&opts.JWTRoles, "jwt-roles", "", "Comma-separated roles of the signed JWT")
+	flagSet.DurationVar(&opts.JWTExpiration, "jwt-expiration", 5*time.Minute, "Validity of the signed JWT")
+	flagSet.DurationVar(&opts.Timeout, "timeout", 30*time.Second, "Timeout of the requests")
+	flagSet.BoolVar(&opts.json, "json", false, "Write the results as JSON rather than as tables")
+	flagSet.Usage = usage(flagSet)
+	if err := flagSet.Parse(args); err != nil {
+		return ExitUsage
+	}
+	opts.Token = os.Getenv(TokenEnv)
+
+	args = flagSet.Args()
+	if len(args) == 0 {
+		flagSet.Usage()
+		return ExitUsage
+	}
+	if args[0] == "quickstart" {
+		return runQuickstart(opts.ClientOptions, args[1:], stdout, stderr)
+	}
+	client, err := NewClient(opts.ClientOptions)
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return ExitError
+	}
+	out := output{writer: stdout, json: opts.json}
+
+	if args[0] == "import" {
+		return runImport(client, args[1:], out, stderr)
+	}
+	r, ok := resources[args[0]]
+	if !ok || len(args) < 2 {
+		flagSet.Usage()
+		return ExitUsage
+	}
+	return runAction(client, r, args[1], args[2:], out, stderr)
+}
+
+// runAction runs the action of the resource r with its arguments
+func runAction(c *Client, r *resource, action string, args []string, out output, stderr io.Writer) int {
+	flagSet := flag.NewFlagSet(r.name+" "+action, flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	var opts listOptions
+	var file string
+	var age time.Duration
+	var follow bool
+	switch action {
+	case "list":
+		flagSet.IntVar(&opts.offset, v2.Offset, 0, "Number of objects skipped")
+		flagSet.IntVar(&opts.limit, v2.Limit, 20, "Maximum number of objects listed")
+		if r.supports(v2.Labels) {
+			flagSet.StringVar(&opts.labels, v2.Labels, "", "Comma-separated labels of the objects listed")
+		}
+		if r.supports(v2.Device) {
+			flagSet.StringVar(&opts.device, v2.Device, "", "Name of the device of the events listed")
+		}
+	case "add", "update":
+		flagSet.StringVar(&file, "f", "", "JSON or YAML file of an object or an array of objects")
+	case "count":
+		flagSet.StringVar(&opts.device, v2.Device, "", "Name of the device of the events counted")
+	case "tail":
+		flagSet.IntVar(&opts.limit, v2.Limit, 20, "Maximum number of the newest events written")
+		flagSet.BoolVar(&follow, "f", false, "Keep writing the events as they are added")
+	case "delete":
+		if r.name == "event" {
+			flagSet.StringVar(&opts.device, v2.Device, "", "Name of the device whose events are deleted")
+			flagSet.DurationVar(&age, v2.Age, 0, "Delete the events older than this duration, e.g. 24h")
+		}
+	}
+	if err := flagSet.Parse(args); err != nil {
+		return ExitUsage
+	}
+	args = flagSet.Args()
+	supported := false
+	for _, a := range actionsOf(r) {
+		supported = supported || a == action
+	}
+	if !supported {
+		fmt.Fprintf(stderr, "%s doesn't support %s, only %s\n", r.name, action, strings.Join(actionsOf(r), ", "))
+		return ExitUsage
+	}
+
+	var results []result
+	var err error
+	switch action {
+	case "list":
+		var objects []object
+		if objects, err = r.list(c, opts); err == nil {
+			err = out.writeObjects(objects, r.columns)
+		}
+	case "get":
+		if len(args) != 1 {
+			fmt.Fprintf(stderr, "%s get requires the %s of the %s\n", r.name, r.key, r.name)
+			return ExitUsage
+		}
+		var o object
+		if o, err = r.get(c, args[0]); err == nil {
+			if out.json {
+				err = out.writeJSON(o)
+			} else {
+				err = out.writeObjects([]object{o}, r.columns)
+			}
+		}
+	case "add", "update":
+		if file == "" {
+			fmt.Fprintf(stderr, "%s %s requires -f <file>\n", r.name, action)
+			return ExitUsage
+		}
+		var objects []object
+		if objects, err = readObjects(file); err == nil {
+			if action == "add" {
+				results, err = r.add(c, objects)
+			} else {
+				results, err = r.update(c, objects)
+			}
+		}
+	case "count":
+		var count uint64
+		if count, err = countEvents(c, opts.device); err == nil {
+			if out.json {
+				err = out.writeJSON(map[string]uint64{"count": count})
+			} else {
+				_, err = fmt.Fprintln(out.writer, count)
+			}
+		}
+	case "tail":
+		err = tailEvents(c, opts.limit, follow, out, stderr)
+	case "delete":
+		switch {
+		case r.name == "event" && opts.device != "":
+			results, err = deleteEvents(c, withParam(v2.ApiEventByDeviceNameRoute, v2.Name, opts.device), opts.device)
+		case r.name == "event" && age > 0:
+			results, err = deleteEvents(c, withParam(v2.ApiEventByAgeRoute, v2.Age, fmt.Sprint(age.Milliseconds())), age.String())
+		case len(args) > 0:
+			results, err = r.delete(c, args)
+		default:
+			fmt.Fprintf(stderr, "%s delete requires the %s of the %s\n", r.name, r.key, r.name)
+			return ExitUsage
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return ExitError
+	}
+	return writeResults(results, out, stderr)
+}
+
+// writeResults writes the results, if any, and returns ExitError if any failed
+func writeResults(results []result, out output, stderr io.Writer) int {
+	if results == nil {
+		return ExitNormal
+	}
+	if err := out.writeResults(results); err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return ExitError
+	}
+	for _, r := range results {
+		if r.failed() {
+			return ExitError
+		}
+	}
+	return ExitNormal
+}
+
+// countEvents returns the number of events, of the device if deviceName isn't empty
+func countEvents(c *Client, deviceName string) (uint64, error) {
+	path := v2.ApiEventCountRoute
+	if deviceName != "" {
+		path = withParam(v2.ApiEventCountByDeviceNameRoute, v2.Name, deviceName)
+	}
+	var response struct {
+		Count uint64
+	}
+	_, err := c.do(http.MethodGet, CoreData, path, nil, &response)
+	return response.Count, err
+}
+
+// deleteEvents deletes the events of path, described by key in the result
+func deleteEvents(c *Client, path string, key string) ([]result, error) {
+	res := result{Resource: "event", Key: key}
+	statusCode, err := c.do(http.MethodDelete, CoreData, path, nil, nil)
+	res.StatusCode = statusCode
+	if e, ok := err.(statusError); ok {
+		res.Message = e.message
+	} else if err != nil {
+		return nil, err
+	}
+	return []result{res}, nil
+}
+
+// runImport adds the objects of the sections of an import file
+func runImport(c *Client, args []string, out output, stderr io.Writer) int {
+	flagSet := flag.NewFlagSet("import", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	file := flagSet.String("f", "", "JSON or YAML file with profiles, devices, intervals and notifications sections")
+	if err := flagSet.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if *file == "" {
+		fmt.Fprintln(stderr, "import requires -f <file>")
+		return ExitUsage
+	}
+
+	// the results of the sections imported before an error are written all the same
+	results, err := importFile(c, *file)
+	status := writeResults(results, out, stderr)
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return ExitError
+	}
+	return status
+}
+
+// importFile adds the objects of the sections of the file, the sections in the order of importSections
+func importFile(c *Client, path string) ([]result, error) {
+	document, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sections, ok := document.(object)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object with sections", path)
+	}
+	known := make(map[string]bool)
+	for _, s := range importSections {
+		known[s.section] = true
+	}
+	var unknown []string
+	for section := range sections {
+		if !known[section] {
+			unknown = append(unknown, section)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown sections %s in %s", strings.Join(unknown, ", "), path)
+	}
+
+	results := []result{}
+	for _, s := range importSections {
+		section, ok := sections[s.section]
+		if !ok {
+			continue
+		}
+		objects, err := toObjects(section)
+		if err != nil {
+			return results, fmt.Errorf("section %s of %s: %s", s.section, path, err.Error())
+		}
+		if len(objects) == 0 {
+			continue
+		}
+		added, err := resources[s.resource].add(c, objects)
+		if err != nil {
+			return results, fmt.Errorf("failed to import the %s: %s", s.section, err.Error())
+		}
+		results = append(results, added...)
+	}
+	return results, nil
+}
@@ -0,0 +1,387 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "test-token"
+
+// request is a request received by the test API gateway
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+}
+
+// newTestClient returns a Client calling the test API gateway handler in secure mode, and the requests it received
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *[]request) {
+	var requests []request
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+testToken, r.Header.Get("Authorization"))
+		req := request{method: r.Method, path: r.URL.Path, query: r.URL.Query()}
+		if r.Body != nil {
+			content, _ := ioutil.ReadAll(r.Body)
+			r.Body = ioutil.NopCloser(bytes.NewReader(content))
+			if len(content) > 0 {
+				require.NoError(t, json.Unmarshal(content, &req.body))
+			}
+		}
+		requests = append(requests, req)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	require.NoError(t, err)
+	client := &Client{
+		host:        serverURL.Hostname(),
+		secure:      true,
+		gatewayPort: port,
+		token:       testToken,
+		http:        server.Client(),
+	}
+	return client, &requests
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestList(t *testing.T) {
+	response := `{"apiVersion":"v2","statusCode":200,"devices":[
+		{"name":"Thermostat-1","serviceName":"device-modbus","profileName":"Thermostat","adminState":"UNLOCKED",
+		 "operatingState":"UP","labels":["hvac","floor-1"]}]}`
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(response))
+	})
+
+	var stdout, stderr bytes.Buffer
+	status := runAction(client, resources["device"], "list", []string{"-offset", "5", "-labels", "hvac"},
+		output{writer: &stdout}, &stderr)
+	require.Equal(t, ExitNormal, status, stderr.String())
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "/metadata/api/v2/device/all", (*requests)[0].path)
+	assert.Equal(t, "5", (*requests)[0].query.Get("offset"))
+	assert.Equal(t, "20", (*requests)[0].query.Get("limit"))
+	assert.Equal(t, "hvac", (*requests)[0].query.Get("labels"))
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"NAME", "SERVICE", "PROFILE", "ADMIN", "OPERATING", "LABELS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"Thermostat-1", "device-modbus", "Thermostat", "UNLOCKED", "UP", "hvac,floor-1"},
+		strings.Fields(lines[1]))
+
+	stdout.Reset()
+	status = runAction(client, resources["device"], "list", nil, output{writer: &stdout, json: true}, &stderr)
+	require.Equal(t, ExitNormal, status, stderr.String())
+	var devices []object
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &devices))
+	require.Len(t, devices, 1)
+	assert.Equal(t, "Thermostat-1", devices[0]["name"])
+}
+
+func TestListEventsOfDevice(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"apiVersion":"v2","statusCode":200,"events":[]}`))
+	})
+
+	var stdout, stderr bytes.Buffer
+	status := runAction(client, resources["event"], "list", []string{"-device", "Thermostat 1"},
+		output{writer: &stdout}, &stderr)
+	require.Equal(t, ExitNormal, status, stderr.String())
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "/coredata/api/v2/event/device/name/Thermostat 1", (*requests)[0].path)
+}
+
+func TestAdd(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = w.Write([]byte(`[{"apiVersion":"v2","statusCode":201,"id":"1"},
+			{"apiVersion":"v2","statusCode":409,"message":"device name Thermostat-2 exists"}]`))
+	})
+	file := writeFile(t, "devices.yaml", `
+- name: Thermostat-1
+  profileName: Thermostat
+  protocols:
+    modbus-tcp:
+      Address: 10.0.0.1
+- name: Thermostat-2
+  profileName: Thermostat
+`)
+
+	var stdout, stderr bytes.Buffer
+	status := runAction(client, resources["device"], "add", []string{"-f", file}, output{writer: &stdout, json: true},
+		&stderr)
+	assert.Equal(t, ExitError, status, "a device failed to be added")
+	require.Len(t, *requests, 1)
+	assert.Equal(t, http.MethodPost, (*requests)[0].method)
+	assert.Equal(t, "/metadata/api/v2/device", (*requests)[0].path)
+	body, ok := (*requests)[0].body.([]interface{})
+	require.True(t, ok)
+	require.Len(t, body, 2)
+	first := body[0].(map[string]interface{})
+	assert.Equal(t, "v2", first["apiVersion"])
+	assert.Equal(t, "10.0.0.1", first["device"].(map[string]interface{})["protocols"].(map[string]interface{})["modbus-tcp"].(map[string]interface{})["Address"])
+
+	var results []result
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	assert.Equal(t, []result{
+		{Resource: "device", Key: "Thermostat-1", StatusCode: http.StatusCreated},
+		{Resource: "device", Key: "Thermostat-2", StatusCode: http.StatusConflict, Message: "device name Thermostat-2 exists"},
+	}, results)
+}
+
+func TestAddNotifications(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("b6f4e5d0-3c2a-4f1e-9a8b-7c6d5e4f3a2b"))
+	})
+	file := writeFile(t, "notifications.json", `{"slug":"maintenance","sender":"admin","category":"SW_HEALTH"}`)
+
+	var stdout, stderr bytes.Buffer
+	status := runAction(client, resources["notification"], "add", []string{"-f", file}, output{writer: &stdout},
+		&stderr)
+	require.Equal(t, ExitNormal, status, stderr.String())
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "/notifications/api/v1/notification", (*requests)[0].path)
+	assert.Equal(t, "maintenance", (*requests)[0].body.(map[string]interface{})["slug"])
+}
+
+func TestDelete(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/unknown") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v2","statusCode":404,"message":"device unknown does not exist"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"apiVersion":"v2","statusCode":200}`))
+	})
+
+	var stdout, stderr bytes.Buffer
+	status := runAction(client, resources["device"], "delete", []string{"Thermostat-1", "unknown"},
+		output{writer: &stdout}, &stderr)
+	assert.Equal(t, ExitError, status)
+	require.Len(t, *requests, 2)
+	assert.Equal(t, http.MethodDelete, (*requests)[0].method)
+	assert.Equal(t, "/metadata/api/v2/device/name/Thermostat-1", (*requests)[0].path)
+	assert.Contains(t, stdout.String(), "device unknown does not exist")
+
+	*requests = nil
+	status = runAction(client, resources["event"], "delete", []string{"-age", "1h"}, output{writer: &stdout}, &stderr)
+	assert.Equal(t, ExitNormal, status)
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "/coredata/api/v2/event/age/3600000", (*requests)[0].path)
+}
+
+func TestCountEvents(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"apiVersion":"v2","statusCode":200,"Count":42}`))
+	})
+
+	var stdout, stderr bytes.Buffer
+	status := runAction(client, resources["event"], "count", []string{"-device", "Thermostat-1"},
+		output{writer: &stdout}, &stderr)
+	require.Equal(t, ExitNormal, status, stderr.String())
+	assert.Equal(t, "42\n", stdout.String())
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "/coredata/api/v2/event/count/device/name/Thermostat-1", (*requests)[0].path)
+}
+
+func TestTailEvents(t *testing.T) {
+	responses := []string{
+		`{"statusCode":200,"cursor":"e-1","events":[{"id":"1","deviceName":"Thermostat-1","readings":[{}]}]}`,
+		`{"statusCode":200,"cursor":"e-1","events":[]}`,
+		`{"statusCode":200,"cursor":"e-4","missed":1,"events":[{"id":"3"},{"id":"4"}]}`,
+	}
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(responses[0]))
+		responses = responses[1:]
+	})
+
+	var stdout, stderr bytes.Buffer
+	status := runAction(client, resources["event"], "tail", []string{"-limit", "5", "-f"},
+		output{writer: &stdout, json: true}, &stderr)
+	assert.Equal(t, ExitError, status, "following ends once a request fails")
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 3, "an event per line")
+	assert.JSONEq(t, `{"id":"4"}`, lines[2])
+	assert.Contains(t, stderr.String(), "1 event(s) missed")
+
+	require.Len(t, *requests, 4)
+	assert.Equal(t, "/coredata/api/v2/event/tail", (*requests)[0].path)
+	assert.Equal(t, "5", (*requests)[0].query.Get("limit"))
+	assert.Empty(t, (*requests)[0].query.Get("since"))
+	assert.Equal(t, "e-1", (*requests)[1].query.Get("since"))
+	assert.Equal(t, "e-1", (*requests)[2].query.Get("since"))
+	assert.Equal(t, "e-4", (*requests)[3].query.Get("since"))
+
+	responses = []string{`{"statusCode":200,"cursor":"e-1","events":[{"id":"1","deviceName":"Thermostat-1"}]}`}
+	stdout.Reset()
+	status = runAction(client, resources["event"], "tail", nil, output{writer: &stdout}, &stderr)
+	require.Equal(t, ExitNormal, status, stderr.String())
+	assert.Contains(t, stdout.String(), "Thermostat-1")
+	assert.Len(t, *requests, 5, "without -f only the newest events are written")
+}
+
+func TestUnsupportedAction(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	var stdout, stderr bytes.Buffer
+	status := runAction(client, resources["notification"], "update", []string{"-f", "notifications.json"},
+		output{writer: &stdout}, &stderr)
+	assert.Equal(t, ExitUsage, status)
+	assert.Empty(t, *requests)
+	assert.Contains(t, stderr.String(), "notification doesn't support update")
+}
+
+func TestImport(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body []interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			// the single v1 requests
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		responses := make([]object, len(body))
+		for i := range body {
+			responses[i] = object{"apiVersion": "v2", "statusCode": http.StatusCreated}
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		_ = json.NewEncoder(w).Encode(responses)
+	})
+	file := writeFile(t, "import.yaml", `
+notifications:
+  - slug: maintenance
+devices:
+  - name: Thermostat-1
+    profileName: Thermostat
+intervals:
+  name: hourly
+  frequency: 1h
+profiles:
+  - name: Thermostat
+`)
+
+	var stdout, stderr bytes.Buffer
+	status := runImport(client, []string{"-f", file}, output{writer: &stdout, json: true}, &stderr)
+	require.Equal(t, ExitNormal, status, stderr.String())
+	var paths []string
+	for _, r := range *requests {
+		paths = append(paths, r.path)
+	}
+	assert.Equal(t, []string{
+		"/metadata/api/v2/deviceprofile",
+		"/metadata/api/v2/device",
+		"/scheduler/api/v2/interval",
+		"/notifications/api/v1/notification",
+	}, paths)
+
+	var results []result
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	var keys []string
+	for _, r := range results {
+		keys = append(keys, r.Resource+" "+r.Key)
+	}
+	assert.Equal(t, []string{"profile Thermostat", "device Thermostat-1", "interval hourly", "notification maintenance"},
+		keys)
+}
+
+func TestImportUnknownSection(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	file := writeFile(t, "import.json", `{"devices":[],"readings":[]}`)
+
+	var stdout, stderr bytes.Buffer
+	status := runImport(client, []string{"-f", file}, output{writer: &stdout}, &stderr)
+	assert.Equal(t, ExitError, status)
+	assert.Empty(t, *requests)
+	assert.Contains(t, stderr.String(), "unknown sections readings")
+}
+
+func TestSignJWT(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p384DER, err := x509.MarshalECPrivateKey(p384Key)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		pemType   string
+		der       []byte
+		publicKey interface{}
+		method    jwt.SigningMethod
+		expectErr bool
+	}{
+		{"ES256", "EC PRIVATE KEY", ecDER, &ecKey.PublicKey, jwt.SigningMethodES256, false},
+		{"RS256", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey), &rsaKey.PublicKey, jwt.SigningMethodRS256, false},
+		{"P-384 key", "EC PRIVATE KEY", p384DER, nil, nil, true},
+		{"not a key", "CERTIFICATE", []byte("certificate"), nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyPath := writeFile(t, "key.pem", string(pem.EncodeToMemory(&pem.Block{Type: tt.pemType, Bytes: tt.der})))
+			signed, err := SignJWT(keyPath, "edgex-admin", "admin, reader", time.Minute)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			claims := &jwtauth.Claims{}
+			token, err := jwt.ParseWithClaims(signed, claims, func(token *jwt.Token) (interface{}, error) {
+				return tt.publicKey, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.method, token.Method)
+			assert.Equal(t, "edgex-admin", claims.Issuer)
+			assert.Equal(t, []string{"admin", "reader"}, claims.Roles)
+			assert.InDelta(t, time.Now().Add(time.Minute).Unix(), claims.ExpiresAt, 5)
+		})
+	}
+}
+
+func TestSignJWTRequiresId(t *testing.T) {
+	_, err := SignJWT("key.pem", "", "", time.Minute)
+	require.Error(t, err)
+}
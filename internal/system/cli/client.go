@@ -0,0 +1,224 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Service locates a service, on its own port or behind the API gateway
+type Service struct {
+	Name string
+	// GatewayPrefix is the path of the API gateway route to the service
+	GatewayPrefix string
+	Port          int
+}
+
+// The services, on the ports of their default configuration and the routes of the security-proxy-setup
+var (
+	CoreData      = Service{Name: "core-data", GatewayPrefix: "coredata", Port: 48080}
+	CoreMetadata  = Service{Name: "core-metadata", GatewayPrefix: "metadata", Port: 48081}
+	CoreCommand   = Service{Name: "core-command", GatewayPrefix: "command", Port: 48082}
+	Notifications = Service{Name: "support-notifications", GatewayPrefix: "notifications", Port: 48060}
+	Scheduler     = Service{Name: "support-scheduler", GatewayPrefix: "scheduler", Port: 48085}
+)
+
+// ClientOptions are the options of a Client, given on the command line
+type ClientOptions struct {
+	Host               string
+	Secure             bool
+	GatewayPort        int
+	CACert             string
+	InsecureSkipVerify bool
+	// Token is the JWT sent to the services, unless signed with JWTKey
+	Token         string
+	JWTKey        string
+	JWTId         string
+	JWTRoles      string
+	JWTExpiration time.Duration
+	Timeout       time.Duration
+}
+
+// Client sends the requests of the command line, or of the contract tests, to the services
+type Client struct {
+	host        string
+	secure      bool
+	gatewayPort int
+	token       string
+	http        *http.Client
+}
+
+// NewClient returns a Client calling the services on opts.Host, through the API gateway when opts.Secure is set
+func NewClient(opts ClientOptions) (*Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+	if opts.CACert != "" {
+		pem, err := ioutil.ReadFile(opts.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA certificate: %s", err.Error())
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate in %s", opts.CACert)
+		}
+	}
+
+	c := &Client{
+		host:        opts.Host,
+		secure:      opts.Secure,
+		gatewayPort: opts.GatewayPort,
+		token:       opts.Token,
+		http: &http.Client{
+			Timeout:   opts.Timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}
+	if opts.JWTKey != "" {
+		token, err := SignJWT(opts.JWTKey, opts.JWTId, opts.JWTRoles, opts.JWTExpiration)
+		if err != nil {
+			return nil, err
+		}
+		c.token = token
+	}
+	return c, nil
+}
+
+// SignJWT returns a JWT for the API gateway user jwtId, as added by 'secrets-config proxy adduser', signed with the
+// PEM encoded private key of keyPath: ES256 for a P-256 key, RS256 for an RSA key
+func SignJWT(keyPath string, jwtId string, roles string, expiration time.Duration) (string, error) {
+	if jwtId == "" {
+		return "", fmt.Errorf("the id of the API gateway user is required to sign a JWT")
+	}
+	key, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the private key: %s", err.Error())
+	}
+
+	now := time.Now().Unix()
+	claims := &jwtauth.Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    jwtId,
+			IssuedAt:  now,
+			NotBefore: now,
+			ExpiresAt: now + int64(expiration.Seconds()),
+		},
+	}
+	for _, role := range strings.Split(roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			claims.Roles = append(claims.Roles, role)
+		}
+	}
+
+	if ecKey, err := jwt.ParseECPrivateKeyFromPEM(key); err == nil {
+		if ecKey.Params().BitSize != 256 {
+			return "", fmt.Errorf("the ECDSA key must be a P-256 key, not %s", ecKey.Params().Name)
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(ecKey)
+	}
+	if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(key); err == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(rsaKey)
+	}
+	return "", fmt.Errorf("the private key must be a PEM encoded ECDSA P-256 or RSA key")
+}
+
+func (c *Client) url(s Service, path string) string {
+	if c.secure {
+		return fmt.Sprintf("https://%s:%d/%s%s", c.host, c.gatewayPort, s.GatewayPrefix, path)
+	}
+	return fmt.Sprintf("http://%s:%d%s", c.host, s.Port, path)
+}
+
+// Do sends body, if any, as JSON and returns the status code and the content of the response, whatever its status
+// code. Only the failures to send the request or to read the response are returned as errors.
+func (c *Client) Do(method string, s Service, path string, body interface{}) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, c.url(s, path), reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if body != nil {
+		req.Header.Set(clients.ContentType, clients.ContentTypeJSON)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s %s failed: %s", method, path, err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("failed to read the response of %s %s: %s", method, path, err.Error())
+	}
+	return resp.StatusCode, content, nil
+}
+
+// statusError is the error response of a service
+type statusError struct {
+	statusCode int
+	message    string
+}
+
+func (e statusError) Error() string {
+	if e.message == "" {
+		return fmt.Sprintf("request failed with status %d %s", e.statusCode, http.StatusText(e.statusCode))
+	}
+	return fmt.Sprintf("request failed with status %d: %s", e.statusCode, e.message)
+}
+
+// do sends body, if any, as JSON and decodes the JSON response into out, if any. The responses other than 2xx are
+// returned as a statusError, with the message of the v2 error responses or the body of the v1 ones.
+func (c *Client) do(method string, s Service, path string, body interface{}, out interface{}) (int, error) {
+	statusCode, content, err := c.Do(method, s, path, body)
+	if err != nil {
+		return statusCode, err
+	}
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return statusCode, statusError{statusCode: statusCode, message: errorMessage(content)}
+	}
+	if out == nil || len(content) == 0 {
+		return statusCode, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err = decoder.Decode(out); err != nil {
+		return statusCode, fmt.Errorf("failed to decode the response: %s", err.Error())
+	}
+	return statusCode, nil
+}
+
+func errorMessage(content []byte) string {
+	var response struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(content, &response); err == nil && response.Message != "" {
+		return response.Message
+	}
+	return strings.TrimSpace(string(content))
+}
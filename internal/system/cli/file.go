@@ -0,0 +1,108 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// object is a JSON object, as sent to and received from the services
+type object = map[string]interface{}
+
+// readFile decodes the JSON or YAML file at path, YAML when its extension is .yaml or .yml
+func readFile(path string) (interface{}, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err = yaml.Unmarshal(content, &document); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %s", path, err.Error())
+		}
+		return fromYAML(document)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		if err = decoder.Decode(&document); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %s", path, err.Error())
+		}
+		return document, nil
+	}
+}
+
+// fromYAML converts the maps decoded from YAML, keyed by interface{}, to JSON objects
+func fromYAML(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		o := make(object, len(v))
+		for key, element := range v {
+			name, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("key %v isn't a string", key)
+			}
+			converted, err := fromYAML(element)
+			if err != nil {
+				return nil, err
+			}
+			o[name] = converted
+		}
+		return o, nil
+	case []interface{}:
+		for i, element := range v {
+			converted, err := fromYAML(element)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// toObjects returns the objects of document, either a single object or an array of objects
+func toObjects(document interface{}) ([]object, error) {
+	switch d := document.(type) {
+	case object:
+		return []object{d}, nil
+	case []interface{}:
+		objects := make([]object, len(d))
+		for i, element := range d {
+			o, ok := element.(object)
+			if !ok {
+				return nil, fmt.Errorf("element %d isn't an object", i)
+			}
+			objects[i] = o
+		}
+		return objects, nil
+	default:
+		return nil, fmt.Errorf("expected an object or an array of objects")
+	}
+}
+
+// readObjects returns the objects of the JSON or YAML file at path, either a single object or an array of objects
+func readObjects(path string) ([]object, error) {
+	document, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := toObjects(document)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err.Error())
+	}
+	return objects, nil
+}
@@ -0,0 +1,125 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// column is a column of the table of a resource
+type column struct {
+	header string
+	value  func(o object) string
+}
+
+// field returns the column of the JSON field name
+func field(header string, name string) column {
+	return column{header: header, value: func(o object) string { return format(o[name]) }}
+}
+
+// count returns the column of the number of elements of the JSON array field name
+func count(header string, name string) column {
+	return column{header: header, value: func(o object) string {
+		elements, _ := o[name].([]interface{})
+		return fmt.Sprint(len(elements))
+	}}
+}
+
+// format formats a JSON value for a table cell: the arrays as comma separated values and the objects as their keys
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		elements := make([]string, len(v))
+		for i, element := range v {
+			elements[i] = format(element)
+		}
+		return strings.Join(elements, ",")
+	case object:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return strings.Join(keys, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// output writes the results of the commands as tables, or as JSON
+type output struct {
+	writer io.Writer
+	json   bool
+}
+
+// writeJSON writes value as indented JSON
+func (out output) writeJSON(value interface{}) error {
+	encoder := json.NewEncoder(out.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// writeObjects writes the objects as a table of columns, or as a JSON array
+func (out output) writeObjects(objects []object, columns []column) error {
+	if out.json {
+		if objects == nil {
+			objects = []object{}
+		}
+		return out.writeJSON(objects)
+	}
+	w := tabwriter.NewWriter(out.writer, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.header
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, o := range objects {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = c.value(o)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// result is the outcome of adding, updating or deleting an object
+type result struct {
+	Resource   string `json:"resource"`
+	Key        string `json:"key"`
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message,omitempty"`
+}
+
+// failed returns whether the operation failed
+func (r result) failed() bool {
+	return r.StatusCode < 200 || r.StatusCode >= 300
+}
+
+// writeResults writes the results as a table, or as a JSON array
+func (out output) writeResults(results []result) error {
+	if out.json {
+		if results == nil {
+			results = []result{}
+		}
+		return out.writeJSON(results)
+	}
+	w := tabwriter.NewWriter(out.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE\tKEY\tSTATUS\tMESSAGE")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", r.Resource, r.Key, r.StatusCode, r.Message)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,428 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+)
+
+const (
+	// QuickstartIssuer is the issuer of the JWT signed by the quickstart, the name of its public key in the keys
+	// directory of the services
+	QuickstartIssuer = "edgex-quickstart"
+
+	quickstartKeyFile   = "quickstart-key.pem"
+	quickstartKeysDir   = "keys"
+	quickstartEnvFile   = "quickstart.env"
+	quickstartLogsDir   = "logs"
+	quickstartService   = "device-virtual"
+	quickstartProfile   = "Quickstart-Thermostat"
+	quickstartResource  = "Temperature"
+	quickstartInMemory  = "--in-memory"
+	quickstartPingRoute = v2.ApiBase + "/ping"
+	quickstartStopWait  = 10 * time.Second
+
+	// secretStoreEnv runs the services in non-secure mode, without Vault, when false
+	secretStoreEnv = "EDGEX_SECURITY_SECRET_STORE"
+)
+
+// stdin is the input the quickstart prompts are read from
+var stdin io.Reader = os.Stdin
+
+// quickstartServices are the services started by the quickstart, core-metadata first as the others call it
+var quickstartServices = []Service{CoreMetadata, CoreData, CoreCommand}
+
+// quickstartOptions are the options of the quickstart command
+type quickstartOptions struct {
+	dir        string
+	bin        string
+	device     string
+	noStart    bool
+	yes        bool
+	wait       time.Duration
+	expiration time.Duration
+}
+
+// runQuickstart generates the configuration of a non-secure deployment verifying a JWT signed by a generated key,
+// starts core-metadata, core-data and core-command on the in-memory database, registers a virtual device with a
+// first reading, and prints example calls of the APIs with the JWT
+func runQuickstart(clientOpts ClientOptions, args []string, stdout io.Writer, stderr io.Writer) int {
+	flagSet := flag.NewFlagSet("quickstart", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	var opts quickstartOptions
+	flagSet.StringVar(&opts.dir, "dir", "edgex-quickstart", "Directory of the generated key, configuration and logs")
+	flagSet.StringVar(&opts.bin, "bin", "cmd", "Directory of the service binaries, laid out as by 'make build'")
+	flagSet.StringVar(&opts.device, "device", "Quickstart-Thermostat-1", "Name of the virtual device registered")
+	flagSet.BoolVar(&opts.noStart, "no-start", false, "Use the services already started with the generated configuration")
+	flagSet.BoolVar(&opts.yes, "yes", false, "Don't prompt for the settings, for scripts")
+	flagSet.DurationVar(&opts.wait, "wait", time.Minute, "How long to wait for the services to start")
+	flagSet.DurationVar(&opts.expiration, "jwt-expiration", 24*time.Hour, "Validity of the generated JWT")
+	if err := flagSet.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if clientOpts.Secure {
+		fmt.Fprintln(stderr, "quickstart sets up a non-secure deployment, without -secure")
+		return ExitUsage
+	}
+
+	if !opts.yes {
+		p := prompter{in: bufio.NewReader(stdin), out: stdout}
+		p.ask("Directory of the generated key, configuration and logs", &opts.dir)
+		p.ask("Directory of the service binaries, empty to use the services already started", &opts.bin)
+		p.ask("Name of the virtual device", &opts.device)
+		opts.noStart = opts.noStart || opts.bin == ""
+	}
+
+	token, env, err := generateQuickstart(opts.dir, opts.expiration)
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return ExitError
+	}
+	fmt.Fprintf(stdout, "Wrote the JWT signing key, the public key verifying it and %s to %s\n", quickstartEnvFile, opts.dir)
+
+	clientOpts.Token = token
+	c, err := NewClient(clientOpts)
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return ExitError
+	}
+
+	var processes []*process
+	if !opts.noStart {
+		processes, err = startServices(opts.bin, opts.dir, env, stdout)
+		defer stopServices(processes)
+		if err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return ExitError
+		}
+	}
+	if err = waitForServices(c, processes, opts.wait); err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		if !opts.noStart {
+			fmt.Fprintf(stderr, "The logs of the services are in %s\n", filepath.Join(opts.dir, quickstartLogsDir))
+		}
+		return ExitError
+	}
+
+	results, err := registerQuickstartDevice(c, opts.device)
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return ExitError
+	}
+	for _, r := range results {
+		// the objects registered by a previous run are kept
+		if r.failed() && r.StatusCode != http.StatusConflict {
+			fmt.Fprintf(stderr, "failed to add the %s %s: %s\n", r.Resource, r.Key, r.Message)
+			return ExitError
+		}
+	}
+	if err = addQuickstartReading(c, opts.device); err != nil {
+		fmt.Fprintf(stderr, "failed to add the first reading: %s\n", err.Error())
+		return ExitError
+	}
+
+	writeQuickstartExamples(stdout, clientOpts.Host, opts.device, token, opts.expiration)
+	if opts.noStart {
+		return ExitNormal
+	}
+
+	fmt.Fprintln(stdout, "\nThe services keep running until interrupted with Ctrl-C.")
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	return ExitNormal
+}
+
+// prompter asks for the settings, keeping their value on an empty answer or once the input is exhausted, so that
+// the quickstart can be scripted all the same
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func (p prompter) ask(question string, value *string) {
+	fmt.Fprintf(p.out, "%s [%s]: ", question, *value)
+	answer, err := p.in.ReadString('\n')
+	if err != nil {
+		fmt.Fprintln(p.out)
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		*value = answer
+	}
+}
+
+// generateQuickstart writes to dir the ECDSA P-256 key signing the JWT, unless it exists from a previous run, its
+// public key in the keys directory of the services and the environment variables configuring them, and returns the
+// signed JWT and the environment variables
+func generateQuickstart(dir string, expiration time.Duration) (string, []string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	keysDir := filepath.Join(dir, quickstartKeysDir)
+	if err = os.MkdirAll(keysDir, 0700); err != nil {
+		return "", nil, fmt.Errorf("failed to create %s: %s", keysDir, err.Error())
+	}
+
+	keyPath := filepath.Join(dir, quickstartKeyFile)
+	content, err := ioutil.ReadFile(keyPath)
+	if os.IsNotExist(err) {
+		content, err = generateKey(keyPath)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read the signing key: %s", err.Error())
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return "", nil, fmt.Errorf("no PEM encoded key in %s", keyPath)
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid signing key %s: %s", keyPath, err.Error())
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", nil, err
+	}
+	publicPath := filepath.Join(keysDir, QuickstartIssuer+".pem")
+	if err = ioutil.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0644); err != nil {
+		return "", nil, fmt.Errorf("failed to write the public key: %s", err.Error())
+	}
+
+	token, err := SignJWT(keyPath, QuickstartIssuer, "admin", expiration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	env := []string{
+		secretStoreEnv + "=false",
+		"JWTAUTH_ENABLED=true",
+		"JWTAUTH_KEYSOURCE=" + jwtauth.FileKeySource,
+		"JWTAUTH_KEYSDIRECTORY=" + keysDir,
+	}
+	envFile := "# Generated by 'edgex quickstart': the services verify the JWT signed with " + quickstartKeyFile + ".\n" +
+		"# Start them with " + quickstartInMemory + " and these variables, e.g. set -a; . ./" + quickstartEnvFile + "; set +a\n" +
+		strings.Join(env, "\n") + "\n"
+	if err = ioutil.WriteFile(filepath.Join(dir, quickstartEnvFile), []byte(envFile), 0644); err != nil {
+		return "", nil, fmt.Errorf("failed to write %s: %s", quickstartEnvFile, err.Error())
+	}
+	return token, env, nil
+}
+
+// generateKey writes a new PEM encoded ECDSA P-256 key to path and returns it
+func generateKey(path string) ([]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	content := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	return content, ioutil.WriteFile(path, content, 0600)
+}
+
+// process is a service started by the quickstart, done being closed once it exited
+type process struct {
+	name string
+	cmd  *exec.Cmd
+	log  *os.File
+	done chan struct{}
+}
+
+// startServices starts the quickstartServices of the bin directory on the in-memory database, in the directory of
+// their binary so that they find their configuration, with their logs in the logs directory of dir
+func startServices(bin string, dir string, env []string, stdout io.Writer) ([]*process, error) {
+	logsDir := filepath.Join(dir, quickstartLogsDir)
+	if err := os.MkdirAll(logsDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %s", logsDir, err.Error())
+	}
+
+	var processes []*process
+	for _, s := range quickstartServices {
+		serviceDir, err := filepath.Abs(filepath.Join(bin, s.Name))
+		if err != nil {
+			return processes, err
+		}
+		binary := filepath.Join(serviceDir, s.Name)
+		if _, err = os.Stat(binary); err != nil {
+			return processes, fmt.Errorf("no %s binary in %s, build the services with 'make build' or give their directory with -bin", s.Name, bin)
+		}
+		logFile, err := os.Create(filepath.Join(logsDir, s.Name+".log"))
+		if err != nil {
+			return processes, fmt.Errorf("failed to create the log of %s: %s", s.Name, err.Error())
+		}
+
+		p := &process{name: s.Name, cmd: exec.Command(binary, quickstartInMemory), log: logFile, done: make(chan struct{})}
+		p.cmd.Dir = serviceDir
+		p.cmd.Env = append(os.Environ(), env...)
+		p.cmd.Stdout = logFile
+		p.cmd.Stderr = logFile
+		if err = p.cmd.Start(); err != nil {
+			_ = logFile.Close()
+			return processes, fmt.Errorf("failed to start %s: %s", s.Name, err.Error())
+		}
+		go func() {
+			_ = p.cmd.Wait()
+			close(p.done)
+		}()
+		processes = append(processes, p)
+		fmt.Fprintf(stdout, "Started %s on the in-memory database, port %d\n", s.Name, s.Port)
+	}
+	return processes, nil
+}
+
+// stopServices interrupts the started services and waits for them to stop, killing those still running after
+// quickstartStopWait, e.g. while retrying to connect at startup
+func stopServices(processes []*process) {
+	for _, p := range processes {
+		_ = p.cmd.Process.Signal(os.Interrupt)
+	}
+	deadline := time.After(quickstartStopWait)
+	for _, p := range processes {
+		select {
+		case <-p.done:
+		case <-deadline:
+			_ = p.cmd.Process.Kill()
+			<-p.done
+		}
+		_ = p.log.Close()
+	}
+}
+
+// waitForServices waits until the quickstartServices respond to their ping, failing as soon as one of the started
+// processes exited
+func waitForServices(c *Client, processes []*process, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for _, s := range quickstartServices {
+		for {
+			statusCode, _, err := c.Do(http.MethodGet, s, quickstartPingRoute, nil)
+			if err == nil && statusCode == http.StatusOK {
+				break
+			}
+			for _, p := range processes {
+				select {
+				case <-p.done:
+					return fmt.Errorf("%s exited", p.name)
+				default:
+				}
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("%s isn't responding after %s", s.Name, timeout)
+			}
+			time.Sleep(time.Second)
+		}
+	}
+	return nil
+}
+
+// deviceServiceResource adds the device service the virtual device belongs to, the device services registering
+// themselves otherwise
+var deviceServiceResource = &resource{
+	name:     "deviceservice",
+	service:  CoreMetadata,
+	key:      "name",
+	addPath:  v2.ApiDeviceServiceRoute,
+	addField: "service",
+}
+
+// registerQuickstartDevice adds the virtual device, along with its device service and device profile
+func registerQuickstartDevice(c *Client, device string) ([]result, error) {
+	var results []result
+	for _, add := range []struct {
+		r *resource
+		o object
+	}{
+		{deviceServiceResource, object{
+			"apiVersion":  v2.ApiVersion,
+			"name":        quickstartService,
+			"description": "Device service of the devices registered by 'edgex quickstart'",
+			"baseAddress": "http://localhost:49990",
+			"adminState":  "UNLOCKED",
+			"labels":      []interface{}{"quickstart"},
+		}},
+		{resources["profile"], object{
+			"apiVersion":   v2.ApiVersion,
+			"name":         quickstartProfile,
+			"manufacturer": "EdgeX",
+			"model":        "Quickstart",
+			"labels":       []interface{}{"quickstart"},
+			"deviceResources": []interface{}{object{
+				"name":        quickstartResource,
+				"description": "Room temperature",
+				"properties":  object{"valueType": v2.ValueTypeFloat32, "readWrite": "R", "units": "C"},
+			}},
+		}},
+		{resources["device"], object{
+			"apiVersion":     v2.ApiVersion,
+			"name":           device,
+			"description":    "Virtual thermostat registered by 'edgex quickstart'",
+			"serviceName":    quickstartService,
+			"profileName":    quickstartProfile,
+			"adminState":     "UNLOCKED",
+			"operatingState": "UP",
+			"labels":         []interface{}{"quickstart"},
+			"protocols":      object{"other": object{"Address": "virtual"}},
+		}},
+	} {
+		added, err := add.r.add(c, []object{add.o})
+		if err != nil {
+			return results, fmt.Errorf("failed to add the %s: %s", add.r.name, err.Error())
+		}
+		results = append(results, added...)
+	}
+	return results, nil
+}
+
+// addQuickstartReading adds an event of the virtual device with a reading, as its device service would
+func addQuickstartReading(c *Client, device string) error {
+	event := dtos.NewEvent(quickstartProfile, device)
+	if err := event.AddSimpleReading(quickstartResource, v2.ValueTypeFloat32, float32(21.5)); err != nil {
+		return err
+	}
+	path := withParam(withParam(v2.ApiEventProfileNameDeviceNameRoute, v2.ProfileName, quickstartProfile), v2.DeviceName, device)
+	_, err := c.do(http.MethodPost, CoreData, path, requests.NewAddEventRequest(event), nil)
+	return err
+}
+
+// writeQuickstartExamples writes example calls of the APIs with the JWT
+func writeQuickstartExamples(w io.Writer, host string, device string, token string, expiration time.Duration) {
+	url := func(s Service, path string) string {
+		return fmt.Sprintf("http://%s:%d%s", host, s.Port, path)
+	}
+	fmt.Fprintf(w, "\nThe virtual device %s is registered with a first reading. The services require the JWT,\n", device)
+	fmt.Fprintf(w, "valid for %s, which the edgex command line reads from %s:\n\n", expiration, TokenEnv)
+	fmt.Fprintf(w, "  export %s=%s\n\n", TokenEnv, token)
+	fmt.Fprintf(w, "  curl -H \"Authorization: Bearer $%s\" %s\n", TokenEnv, url(CoreData, withParam(v2.ApiEventByDeviceNameRoute, v2.Name, device)))
+	fmt.Fprintf(w, "  curl -H \"Authorization: Bearer $%s\" %s\n", TokenEnv, url(CoreMetadata, withParam(v2.ApiDeviceByNameRoute, v2.Name, device)))
+	fmt.Fprintf(w, "  curl -H \"Authorization: Bearer $%s\" %s\n", TokenEnv, url(CoreData, v2.ApiEventCountRoute))
+	fmt.Fprintf(w, "  edgex event list -device %s\n", device)
+	fmt.Fprintf(w, "  edgex device list\n")
+}
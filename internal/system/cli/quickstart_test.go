@@ -0,0 +1,116 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/jwtauth"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateQuickstart(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "quickstart")
+	token, env, err := generateQuickstart(dir, time.Hour)
+	require.NoError(t, err)
+
+	keysDir := filepath.Join(dir, quickstartKeysDir)
+	assert.Contains(t, env, "JWTAUTH_KEYSOURCE=file")
+	assert.Contains(t, env, "JWTAUTH_KEYSDIRECTORY="+keysDir)
+	envFile, err := ioutil.ReadFile(filepath.Join(dir, quickstartEnvFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(envFile), "JWTAUTH_ENABLED=true\n")
+
+	// the services verify the token with the public key of the keys directory
+	keys, err := jwtauth.NewFileKeySource(keysDir).FetchKeys()
+	require.NoError(t, err)
+	publicKey, err := jwt.ParseECPublicKeyFromPEM([]byte(keys[QuickstartIssuer]))
+	require.NoError(t, err)
+	claims := &jwtauth.Claims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return publicKey, nil
+	})
+	require.NoError(t, err)
+	assert.True(t, parsed.Valid)
+	assert.Equal(t, QuickstartIssuer, claims.Issuer)
+	assert.Equal(t, []string{"admin"}, claims.Roles)
+
+	key, err := ioutil.ReadFile(filepath.Join(dir, quickstartKeyFile))
+	require.NoError(t, err)
+	_, _, err = generateQuickstart(dir, time.Hour)
+	require.NoError(t, err)
+	again, err := ioutil.ReadFile(filepath.Join(dir, quickstartKeyFile))
+	require.NoError(t, err)
+	assert.Equal(t, key, again, "the key of a previous run is kept, so are the tokens signed with it")
+}
+
+func TestRegisterQuickstartDevice(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/coredata/") {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"apiVersion":"v2","statusCode":201,"id":"1"}`))
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = w.Write([]byte(`[{"apiVersion":"v2","statusCode":201,"id":"1"}]`))
+	})
+
+	results, err := registerQuickstartDevice(client, "Thermostat-1")
+	require.NoError(t, err)
+	require.NoError(t, addQuickstartReading(client, "Thermostat-1"))
+
+	assert.Equal(t, []result{
+		{Resource: "deviceservice", Key: quickstartService, StatusCode: http.StatusCreated},
+		{Resource: "profile", Key: quickstartProfile, StatusCode: http.StatusCreated},
+		{Resource: "device", Key: "Thermostat-1", StatusCode: http.StatusCreated},
+	}, results)
+	require.Len(t, *requests, 4)
+	assert.Equal(t, "/metadata/api/v2/deviceservice", (*requests)[0].path)
+	assert.Equal(t, "/metadata/api/v2/deviceprofile", (*requests)[1].path)
+	device := (*requests)[2].body.([]interface{})[0].(map[string]interface{})["device"].(map[string]interface{})
+	assert.Equal(t, quickstartService, device["serviceName"])
+	assert.Equal(t, quickstartProfile, device["profileName"])
+
+	assert.Equal(t, "/coredata/api/v2/event/"+quickstartProfile+"/Thermostat-1", (*requests)[3].path)
+	event := (*requests)[3].body.(map[string]interface{})["event"].(map[string]interface{})
+	reading := event["readings"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, quickstartResource, reading["resourceName"])
+	assert.Equal(t, "Float32", reading["valueType"])
+}
+
+func TestPrompter(t *testing.T) {
+	var out bytes.Buffer
+	p := prompter{in: bufio.NewReader(strings.NewReader("my-dir\n\n")), out: &out}
+	dir, bin, device := "edgex-quickstart", "cmd", "Thermostat-1"
+
+	p.ask("Directory", &dir)
+	p.ask("Binaries", &bin)
+	p.ask("Device", &device)
+
+	assert.Equal(t, "my-dir", dir)
+	assert.Equal(t, "cmd", bin, "an empty answer keeps the default")
+	assert.Equal(t, "Thermostat-1", device, "the default is kept once the input is exhausted")
+	assert.Contains(t, out.String(), "Directory [edgex-quickstart]: ")
+}
+
+func TestWriteQuickstartExamples(t *testing.T) {
+	var out bytes.Buffer
+	writeQuickstartExamples(&out, "localhost", "Thermostat-1", "signed-token", time.Hour)
+
+	assert.Contains(t, out.String(), "export EDGEX_TOKEN=signed-token\n")
+	assert.Contains(t, out.String(), `curl -H "Authorization: Bearer $EDGEX_TOKEN" http://localhost:48080/api/v2/event/device/name/Thermostat-1`)
+	assert.Contains(t, out.String(), "edgex event list -device Thermostat-1\n")
+}
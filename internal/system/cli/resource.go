@@ -0,0 +1,296 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+// listOptions are the options of the list action
+type listOptions struct {
+	offset int
+	limit  int
+	labels string
+	device string
+}
+
+// resource describes the routes of a kind of objects managed by the command line. The v2 routes are used where the
+// service has them, the v1 routes otherwise.
+type resource struct {
+	name    string
+	service Service
+	// key is the JSON field identifying the objects, and param its route parameter
+	key     string
+	param   string
+	columns []column
+	// filters are the list options supported besides the offset and limit, "labels" or "device"
+	filters []string
+
+	// listPath returns the path listing the objects, held by the listField field of the response or, when empty, the
+	// v1 response being the array itself
+	listPath  func(opts listOptions) string
+	listField string
+	// getPath returns an object, held by itemField as for listField
+	getPath   string
+	itemField string
+	// addPath adds the objects, wrapped in addField by v2 bulk requests or, when empty, one v1 request per object
+	addPath  string
+	addField string
+	// updatePath updates the objects with updateMethod, updateField as for addField
+	updatePath   string
+	updateMethod string
+	updateField  string
+	deletePath   string
+}
+
+// withParam returns route with its param parameter replaced by value
+func withParam(route string, param string, value string) string {
+	return strings.Replace(route, "{"+param+"}", url.PathEscape(value), 1)
+}
+
+func pagedPath(route string, opts listOptions, filters ...string) string {
+	query := url.Values{}
+	query.Set(v2.Offset, strconv.Itoa(opts.offset))
+	query.Set(v2.Limit, strconv.Itoa(opts.limit))
+	for _, filter := range filters {
+		if filter == v2.Labels && opts.labels != "" {
+			query.Set(v2.Labels, opts.labels)
+		}
+	}
+	return route + "?" + query.Encode()
+}
+
+// resources are the objects managed by the command line, by name
+var resources = map[string]*resource{
+	"device": {
+		name:    "device",
+		service: CoreMetadata,
+		key:     "name",
+		param:   v2.Name,
+		columns: []column{
+			field("NAME", "name"), field("SERVICE", "serviceName"), field("PROFILE", "profileName"),
+			field("ADMIN", "adminState"), field("OPERATING", "operatingState"), field("LABELS", "labels"),
+		},
+		filters:      []string{v2.Labels},
+		listPath:     func(opts listOptions) string { return pagedPath(v2.ApiAllDeviceRoute, opts, v2.Labels) },
+		listField:    "devices",
+		getPath:      v2.ApiDeviceByNameRoute,
+		itemField:    "device",
+		addPath:      v2.ApiDeviceRoute,
+		addField:     "device",
+		updatePath:   v2.ApiDeviceRoute,
+		updateMethod: http.MethodPatch,
+		updateField:  "device",
+		deletePath:   v2.ApiDeviceByNameRoute,
+	},
+	"profile": {
+		name:    "profile",
+		service: CoreMetadata,
+		key:     "name",
+		param:   v2.Name,
+		columns: []column{
+			field("NAME", "name"), field("MANUFACTURER", "manufacturer"), field("MODEL", "model"),
+			count("RESOURCES", "deviceResources"), field("LABELS", "labels"),
+		},
+		filters:      []string{v2.Labels},
+		listPath:     func(opts listOptions) string { return pagedPath(v2.ApiAllDeviceProfileRoute, opts, v2.Labels) },
+		listField:    "profiles",
+		getPath:      v2.ApiDeviceProfileByNameRoute,
+		itemField:    "profile",
+		addPath:      v2.ApiDeviceProfileRoute,
+		addField:     "profile",
+		updatePath:   v2.ApiDeviceProfileRoute,
+		updateMethod: http.MethodPut,
+		updateField:  "profile",
+		deletePath:   v2.ApiDeviceProfileByNameRoute,
+	},
+	"event": {
+		name:    "event",
+		service: CoreData,
+		key:     "id",
+		param:   v2.Id,
+		columns: []column{
+			field("ID", "id"), field("DEVICE", "deviceName"), field("PROFILE", "profileName"),
+			field("ORIGIN", "origin"), count("READINGS", "readings"),
+		},
+		filters: []string{v2.Device},
+		listPath: func(opts listOptions) string {
+			if opts.device != "" {
+				return pagedPath(withParam(v2.ApiEventByDeviceNameRoute, v2.Name, opts.device), opts)
+			}
+			return pagedPath(v2.ApiAllEventRoute, opts)
+		},
+		listField:  "events",
+		getPath:    v2.ApiEventIdRoute,
+		itemField:  "event",
+		deletePath: v2.ApiEventIdRoute,
+		// the events are added by the device services
+	},
+	"notification": {
+		name:    "notification",
+		service: Notifications,
+		key:     "slug",
+		param:   v2.Name,
+		columns: []column{
+			field("SLUG", "slug"), field("SENDER", "sender"), field("CATEGORY", "category"),
+			field("SEVERITY", "severity"), field("STATUS", "status"), field("LABELS", "labels"),
+		},
+		// support-notifications serves the notifications on its v1 API only
+		listPath: func(opts listOptions) string {
+			end := time.Now().UnixNano() / int64(time.Millisecond)
+			return fmt.Sprintf("%s/start/0/end/%d/%d", clients.ApiNotificationRoute, end, opts.limit)
+		},
+		getPath:    clients.ApiNotificationRoute + "/slug/{" + v2.Name + "}",
+		addPath:    clients.ApiNotificationRoute,
+		deletePath: clients.ApiNotificationRoute + "/slug/{" + v2.Name + "}",
+	},
+	"interval": {
+		name:    "interval",
+		service: Scheduler,
+		key:     "name",
+		param:   v2.Name,
+		columns: []column{
+			field("NAME", "name"), field("START", "start"), field("END", "end"),
+			field("FREQUENCY", "frequency"), field("CRON", "cron"), field("RUNONCE", "runOnce"),
+		},
+		// support-scheduler only adds the intervals on its v2 API
+		listPath:     func(opts listOptions) string { return clients.ApiIntervalRoute },
+		getPath:      clients.ApiIntervalRoute + "/name/{" + v2.Name + "}",
+		addPath:      v2.ApiIntervalRoute,
+		addField:     "interval",
+		updatePath:   clients.ApiIntervalRoute,
+		updateMethod: http.MethodPut,
+		deletePath:   clients.ApiIntervalRoute + "/name/{" + v2.Name + "}",
+	},
+}
+
+// resourceOrder is the order the resources are listed in the usage and imported in, the device profiles before the
+// devices conforming to them
+var resourceOrder = []string{"profile", "device", "event", "notification", "interval"}
+
+func (r *resource) supports(filter string) bool {
+	for _, f := range r.filters {
+		if f == filter {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resource) keyOf(o object) string {
+	return format(o[r.key])
+}
+
+// list returns the objects of a page
+func (r *resource) list(c *Client, opts listOptions) ([]object, error) {
+	path := r.listPath(opts)
+	if r.listField == "" {
+		var objects []object
+		_, err := c.do(http.MethodGet, r.service, path, nil, &objects)
+		return objects, err
+	}
+	response := make(object)
+	if _, err := c.do(http.MethodGet, r.service, path, nil, &response); err != nil {
+		return nil, err
+	}
+	return toObjects(response[r.listField])
+}
+
+// get returns the object identified by key
+func (r *resource) get(c *Client, key string) (object, error) {
+	path := withParam(r.getPath, r.param, key)
+	response := make(object)
+	if _, err := c.do(http.MethodGet, r.service, path, nil, &response); err != nil {
+		return nil, err
+	}
+	if r.itemField == "" {
+		return response, nil
+	}
+	o, ok := response[r.itemField].(object)
+	if !ok {
+		return nil, fmt.Errorf("no %s in the response", r.name)
+	}
+	return o, nil
+}
+
+// send sends the objects to path with method, in a v2 bulk request wrapping each object in field or, when field is
+// empty, in one v1 request per object, and returns the result of each object
+func (r *resource) send(c *Client, method string, path string, field string, objects []object) ([]result, error) {
+	results := make([]result, len(objects))
+	if field == "" {
+		for i, o := range objects {
+			results[i] = r.result(o, nil)
+			statusCode, err := c.do(method, r.service, path, o, nil)
+			results[i].StatusCode = statusCode
+			if e, ok := err.(statusError); ok {
+				results[i].Message = e.message
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		return results, nil
+	}
+
+	requests := make([]object, len(objects))
+	for i, o := range objects {
+		requests[i] = object{"apiVersion": v2.ApiVersion, field: o}
+	}
+	var responses []object
+	if _, err := c.do(method, r.service, path, requests, &responses); err != nil {
+		return nil, err
+	}
+	if len(responses) != len(objects) {
+		return nil, fmt.Errorf("expected %d responses, got %d", len(objects), len(responses))
+	}
+	for i, o := range objects {
+		results[i] = r.result(o, responses[i])
+	}
+	return results, nil
+}
+
+// result returns the result of the object o from its v2 response, if any
+func (r *resource) result(o object, response object) result {
+	res := result{Resource: r.name, Key: r.keyOf(o)}
+	if response != nil {
+		res.StatusCode, _ = strconv.Atoi(format(response["statusCode"]))
+		res.Message = format(response["message"])
+	}
+	return res
+}
+
+// add adds the objects
+func (r *resource) add(c *Client, objects []object) ([]result, error) {
+	return r.send(c, http.MethodPost, r.addPath, r.addField, objects)
+}
+
+// update updates the objects
+func (r *resource) update(c *Client, objects []object) ([]result, error) {
+	return r.send(c, r.updateMethod, r.updatePath, r.updateField, objects)
+}
+
+// delete deletes the objects identified by keys
+func (r *resource) delete(c *Client, keys []string) ([]result, error) {
+	results := make([]result, len(keys))
+	for i, key := range keys {
+		results[i] = result{Resource: r.name, Key: key}
+		statusCode, err := c.do(http.MethodDelete, r.service, withParam(r.deletePath, r.param, key), nil, nil)
+		results[i].StatusCode = statusCode
+		if e, ok := err.(statusError); ok {
+			results[i].Message = e.message
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,100 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+// eventTailRoute is the route of core-data returning the newest events, or those added after the since cursor, which
+// requires EventTail.Enabled
+const eventTailRoute = v2.ApiBase + "/event/tail"
+
+// tailResponse is the response of eventTailRoute
+type tailResponse struct {
+	Cursor string   `json:"cursor"`
+	Missed uint64   `json:"missed"`
+	Events []object `json:"events"`
+}
+
+// tailEvents writes the limit newest events and, if follow is set, the events added since, until a request fails.
+// When following, the events are written as a row, or as a JSON line, as soon as they are added.
+func tailEvents(c *Client, limit int, follow bool, out output, stderr io.Writer) error {
+	query := url.Values{}
+	query.Set(v2.Limit, strconv.Itoa(limit))
+	var response tailResponse
+	if _, err := c.do(http.MethodGet, CoreData, eventTailRoute+"?"+query.Encode(), nil, &response); err != nil {
+		return err
+	}
+	if !follow {
+		return out.writeObjects(response.Events, resources["event"].columns)
+	}
+
+	rows := newRowWriter(out, resources["event"].columns)
+	for {
+		if response.Missed > 0 {
+			fmt.Fprintf(stderr, "%d event(s) missed, no longer kept by core-data\n", response.Missed)
+		}
+		if err := rows.write(response.Events); err != nil {
+			return err
+		}
+		query.Set("since", response.Cursor)
+		response = tailResponse{}
+		if _, err := c.do(http.MethodGet, CoreData, eventTailRoute+"?"+query.Encode(), nil, &response); err != nil {
+			return err
+		}
+	}
+}
+
+// rowWriter writes the objects followed as they come, as the rows of a table whose header is written first, or as
+// JSON lines
+type rowWriter struct {
+	out     output
+	columns []column
+	header  bool
+}
+
+func newRowWriter(out output, columns []column) *rowWriter {
+	return &rowWriter{out: out, columns: columns}
+}
+
+func (r *rowWriter) write(objects []object) error {
+	if r.out.json {
+		encoder := json.NewEncoder(r.out.writer)
+		for _, o := range objects {
+			if err := encoder.Encode(o); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	// the columns of the rows written together are aligned, and padded to the width of the headers
+	w := tabwriter.NewWriter(r.out.writer, 0, 0, 2, ' ', 0)
+	cells := make([]string, len(r.columns))
+	if !r.header {
+		for i, c := range r.columns {
+			cells[i] = c.header
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+		r.header = true
+	}
+	for _, o := range objects {
+		for i, c := range r.columns {
+			cells[i] = c.value(o)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,169 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package contract implements edgex-contract-tests, which runs the contract tests of the published v2 APIs of the
+// core and support services against a running EdgeX stack, either directly or through the API gateway with a JWT in
+// secure mode, and reports them as JUnit XML so that the distributions of EdgeX can check the API compatibility of
+// their builds in CI.
+package contract
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system/cli"
+)
+
+// Exit status codes
+const (
+	ExitNormal = 0
+	ExitFailed = 1
+	ExitUsage  = 2
+)
+
+// options are the options of the command line
+type options struct {
+	cli.ClientOptions
+	suites string
+	run    string
+	junit  string
+}
+
+func usage(flagSet *flag.FlagSet) func() {
+	return func() {
+		w := flagSet.Output()
+		fmt.Fprintf(w, "Usage: edgex-contract-tests [options]\n\n")
+		fmt.Fprintf(w, "Runs the contract tests of the v2 APIs against a running EdgeX stack and writes them as JUnit XML.\n")
+		fmt.Fprintf(w, "The objects added by the tests are named after the run and deleted at the end of each test.\n\n")
+		fmt.Fprintf(w, "Suites: %s\n\n", strings.Join(suiteNames(), ", "))
+		fmt.Fprintf(w, "In secure mode the requests go through the API gateway with the JWT read from %s, or signed\n", cli.TokenEnv)
+		fmt.Fprintf(w, "with -jwt-key for the API gateway user -jwt-id.\n\nOptions:\n")
+		flagSet.PrintDefaults()
+	}
+}
+
+// Main runs the contract tests with the options of args, without the program name, and returns the exit status
+func Main(args []string, stdout io.Writer, stderr io.Writer) int {
+	var opts options
+	flagSet := flag.NewFlagSet("edgex-contract-tests", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.StringVar(&opts.Host, "host", "localhost", "Host of the services, or of the API gateway in secure mode")
+	flagSet.BoolVar(&opts.Secure, "secure", false, "Call the services through the API gateway over HTTPS")
+	flagSet.IntVar(&opts.GatewayPort, "gateway-port", 8443, "HTTPS port of the API gateway")
+	flagSet.StringVar(&opts.CACert, "ca-cert", "", "PEM file of the CA certificate of the API gateway")
+	flagSet.BoolVar(&opts.InsecureSkipVerify, "insecure-skip-verify", false, "Don't verify the certificate of the API gateway")
+	flagSet.StringVar(&opts.JWTKey, "jwt-key", "", "PEM file of the private key signing the JWT, instead of "+cli.TokenEnv)
+	flagSet.StringVar(&opts.JWTId, "jwt-id", "", "The 'key' (ID) of the API gateway user, from 'secrets-config proxy adduser'")
+	flagSet.StringVar(&opts.JWTRoles, "jwt-roles", "", "Comma-separated roles of the signed JWT")
+	flagSet.DurationVar(&opts.JWTExpiration, "jwt-expiration", 30*time.Minute, "Validity of the signed JWT, which must outlast the run")
+	flagSet.DurationVar(&opts.Timeout, "timeout", 30*time.Second, "Timeout of the requests")
+	flagSet.StringVar(&opts.suites, "suites", "", "Comma-separated suites to run, all by default")
+	flagSet.StringVar(&opts.run, "run", "", "Regular expression of the <suite>/<test> names to run")
+	flagSet.StringVar(&opts.junit, "junit", "", "JUnit XML file of the results, written to the standard output by default")
+	flagSet.Usage = usage(flagSet)
+	if err := flagSet.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if flagSet.NArg() > 0 {
+		flagSet.Usage()
+		return ExitUsage
+	}
+	opts.Token = os.Getenv(cli.TokenEnv)
+
+	selected, err := selectSuites(opts.suites, opts.run)
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return ExitUsage
+	}
+	client, err := cli.NewClient(opts.ClientOptions)
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return ExitFailed
+	}
+
+	results := newRunner(client, stderr).run(selected)
+
+	out := stdout
+	if opts.junit != "" {
+		file, err := os.Create(opts.junit)
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to create the JUnit report: %s\n", err.Error())
+			return ExitFailed
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+	if err := writeJUnit(out, results); err != nil {
+		fmt.Fprintf(stderr, "failed to write the JUnit report: %s\n", err.Error())
+		return ExitFailed
+	}
+
+	for _, r := range results {
+		if r.Failure != "" {
+			return ExitFailed
+		}
+	}
+	return ExitNormal
+}
+
+// selectSuites returns the suites of the comma-separated names, all if empty, with the tests whose <suite>/<test>
+// name matches the regular expression run, if any
+func selectSuites(names string, run string) ([]suite, error) {
+	var pattern *regexp.Regexp
+	if run != "" {
+		var err error
+		if pattern, err = regexp.Compile(run); err != nil {
+			return nil, fmt.Errorf("invalid -run expression: %s", err.Error())
+		}
+	}
+
+	all := suites()
+	wanted := make(map[string]bool)
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted[name] = true
+		}
+	}
+	for name := range wanted {
+		found := false
+		for _, s := range all {
+			found = found || s.name == name
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown suite %s, the suites are %s", name, strings.Join(suiteNames(), ", "))
+		}
+	}
+
+	var selected []suite
+	for _, s := range all {
+		if len(wanted) > 0 && !wanted[s.name] {
+			continue
+		}
+		var cases []testCase
+		for _, c := range s.cases {
+			if pattern == nil || pattern.MatchString(s.name+"/"+c.name) {
+				cases = append(cases, c)
+			}
+		}
+		if len(cases) > 0 {
+			s.cases = cases
+			selected = append(selected, s)
+		}
+	}
+	return selected, nil
+}
+
+func suiteNames() []string {
+	var names []string
+	for _, s := range suites() {
+		names = append(names, s.name)
+	}
+	return names
+}
@@ -0,0 +1,169 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package contract
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/system/cli"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGateway starts a test API gateway serving handler and returns its host and port
+func newTestGateway(t *testing.T, handler http.HandlerFunc) (string, string) {
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return serverURL.Hostname(), serverURL.Port()
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	require.NoError(t, json.NewEncoder(w).Encode(body))
+}
+
+func TestMain_JUnit(t *testing.T) {
+	host, port := newTestGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		switch {
+		case strings.HasSuffix(r.URL.Path, v2.ApiPingRoute):
+			writeJSON(t, w, http.StatusOK, common.NewPingResponse())
+		case r.URL.Path == "/scheduler"+v2.ApiVersionRoute:
+			writeJSON(t, w, http.StatusOK, common.VersionResponse{Versionable: common.Versionable{ApiVersion: "v1"}, Version: "2.0.0"})
+		case strings.HasSuffix(r.URL.Path, v2.ApiVersionRoute):
+			writeJSON(t, w, http.StatusOK, common.NewVersionResponse("2.0.0"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	require.NoError(t, os.Setenv(cli.TokenEnv, "test-token"))
+	defer func() { _ = os.Unsetenv(cli.TokenEnv) }()
+	report := filepath.Join(t.TempDir(), "report.xml")
+
+	var stdout, stderr strings.Builder
+	status := Main([]string{"-secure", "-insecure-skip-verify", "-host", host, "-gateway-port", port,
+		"-run", "/(ping|version)$", "-junit", report}, &stdout, &stderr)
+	assert.Equal(t, ExitFailed, status)
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "--- PASS: core-data/ping")
+	assert.Contains(t, stderr.String(), "--- FAIL: support-scheduler/version")
+
+	content, err := ioutil.ReadFile(report)
+	require.NoError(t, err)
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(content, &suites))
+	assert.Equal(t, 10, suites.Tests)
+	assert.Equal(t, 1, suites.Failures)
+	require.Len(t, suites.Suites, 5)
+	scheduler := suites.Suites[4]
+	assert.Equal(t, "support-scheduler", scheduler.Name)
+	assert.Equal(t, 1, scheduler.Failures)
+	require.Len(t, scheduler.Cases, 2)
+	assert.Nil(t, scheduler.Cases[0].Failure)
+	require.NotNil(t, scheduler.Cases[1].Failure)
+	assert.Contains(t, scheduler.Cases[1].Failure.Message, `apiVersion is "v1" rather than "v2"`)
+}
+
+func TestMain_Usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"unknown suite", []string{"-suites", "core-data,app-service"}},
+		{"invalid run expression", []string{"-run", "("}},
+		{"arguments", []string{"core-data"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			assert.Equal(t, ExitUsage, Main(tt.args, &stdout, &stderr))
+		})
+	}
+}
+
+func TestSelectSuites(t *testing.T) {
+	selected, err := selectSuites("core-command, support-notifications", "lifecycle")
+	require.NoError(t, err)
+	require.Len(t, selected, 1, "Only the suites with tests matching -run should be selected")
+	assert.Equal(t, "support-notifications", selected[0].name)
+	require.Len(t, selected[0].cases, 1)
+	assert.Equal(t, "subscription lifecycle", selected[0].cases[0].name)
+
+	selected, err = selectSuites("", "")
+	require.NoError(t, err)
+	assert.Len(t, selected, 5)
+}
+
+func TestSubscriptionLifecycle(t *testing.T) {
+	subscriptions := make(map[string]dtos.Subscription)
+	var deletes []string
+	host, port := newTestGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/notifications")
+		name := path[strings.LastIndex(path, "/")+1:]
+		switch {
+		case r.Method == http.MethodPost && path == v2.ApiSubscriptionRoute:
+			var added []requests.AddSubscriptionRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&added))
+			require.Len(t, added, 1)
+			s := added[0].Subscription
+			s.Id = "0b6a5b2e-7d3c-4b1a-8e9f-2a1b3c4d5e6f"
+			subscriptions[s.Name] = s
+			writeJSON(t, w, http.StatusMultiStatus, []common.BaseWithIdResponse{common.NewBaseWithIdResponse("", "", http.StatusCreated, s.Id)})
+		case r.Method == http.MethodDelete:
+			deletes = append(deletes, name)
+			if _, ok := subscriptions[name]; !ok {
+				writeJSON(t, w, http.StatusNotFound, common.NewBaseResponse("", "not found", http.StatusNotFound))
+				return
+			}
+			delete(subscriptions, name)
+			writeJSON(t, w, http.StatusOK, common.NewBaseResponse("", "", http.StatusOK))
+		case strings.HasPrefix(path, v2.ApiSubscriptionRoute+"/"+v2.Receiver+"/"):
+			var found []dtos.Subscription
+			for _, s := range subscriptions {
+				if s.Receiver == name {
+					found = append(found, s)
+				}
+			}
+			writeJSON(t, w, http.StatusOK, responses.NewMultiSubscriptionsResponse("", "", http.StatusOK, found))
+		default:
+			s, ok := subscriptions[name]
+			if !ok {
+				writeJSON(t, w, http.StatusNotFound, common.NewBaseResponse("", "not found", http.StatusNotFound))
+				return
+			}
+			writeJSON(t, w, http.StatusOK, responses.NewSubscriptionResponse("", "", http.StatusOK, s))
+		}
+	})
+
+	var stdout, stderr strings.Builder
+	status := Main([]string{"-secure", "-insecure-skip-verify", "-host", host, "-gateway-port", port,
+		"-suites", "support-notifications", "-run", "lifecycle"}, &stdout, &stderr)
+	assert.Equal(t, ExitNormal, status, stderr.String())
+	assert.Contains(t, stdout.String(), `<testcase name="subscription lifecycle" classname="support-notifications"`)
+	assert.Empty(t, subscriptions)
+	require.Len(t, deletes, 2, "The subscription should be deleted by the test and then by its cleanup")
+	assert.Equal(t, deletes[0], deletes[1])
+	assert.True(t, strings.HasPrefix(deletes[0], "contract-"))
+}
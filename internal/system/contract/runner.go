@@ -0,0 +1,204 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system/cli"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// testCase is a contract test, which fails with the error it returns
+type testCase struct {
+	name string
+	run  func(s *session) error
+}
+
+// suite is the contract tests of a service
+type suite struct {
+	name    string
+	service cli.Service
+	cases   []testCase
+}
+
+// Result is the result of a contract test
+type Result struct {
+	Suite    string
+	Name     string
+	Duration time.Duration
+	// Failure is the reason of the failure of the test, empty if it passed
+	Failure string
+}
+
+// runner runs the contract tests and logs their progress
+type runner struct {
+	client *cli.Client
+	log    io.Writer
+	// runId names the objects added by the tests of the run, so they don't collide with those of the stack
+	runId string
+}
+
+func newRunner(client *cli.Client, log io.Writer) *runner {
+	return &runner{client: client, log: log, runId: fmt.Sprintf("contract-%d", time.Now().Unix())}
+}
+
+// run runs the tests of the suites in order, each test after the cleanup of the previous one
+func (r *runner) run(suites []suite) []Result {
+	var results []Result
+	for _, s := range suites {
+		for _, c := range s.cases {
+			result := r.runCase(s, c)
+			status := "PASS"
+			if result.Failure != "" {
+				status = "FAIL"
+			}
+			fmt.Fprintf(r.log, "--- %s: %s/%s (%.2fs)\n", status, s.name, c.name, result.Duration.Seconds())
+			if result.Failure != "" {
+				fmt.Fprintf(r.log, "    %s\n", result.Failure)
+			}
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+func (r *runner) runCase(s suite, c testCase) (result Result) {
+	sess := &session{client: r.client, service: s.service, runId: r.runId, log: r.log}
+	result = Result{Suite: s.name, Name: c.name}
+	start := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Failure = fmt.Sprintf("panic: %v", recovered)
+		}
+		sess.close()
+		result.Duration = time.Since(start)
+	}()
+	if err := c.run(sess); err != nil {
+		result.Failure = err.Error()
+	}
+	return result
+}
+
+// session is the state of a running test: the service of its suite and the objects to delete at its end
+type session struct {
+	client  *cli.Client
+	service cli.Service
+	runId   string
+	log     io.Writer
+	// cleanups are the deletes run at the end of the test, in reverse order
+	cleanups []cleanup
+}
+
+type cleanup struct {
+	service cli.Service
+	path    string
+}
+
+// name returns the name of an object of kind added by the test
+func (s *session) name(kind string) string {
+	return s.runId + "-" + kind
+}
+
+// deleteAtEnd deletes path of the service at the end of the test, whether it passes or not
+func (s *session) deleteAtEnd(svc cli.Service, path string) {
+	s.cleanups = append(s.cleanups, cleanup{service: svc, path: path})
+}
+
+// close runs the cleanups, whose failures are only logged as the test may have deleted the objects already
+func (s *session) close() {
+	for i := len(s.cleanups) - 1; i >= 0; i-- {
+		c := s.cleanups[i]
+		statusCode, _, err := s.client.Do(http.MethodDelete, c.service, c.path, nil)
+		if err != nil {
+			fmt.Fprintf(s.log, "    cleanup: %s\n", err.Error())
+		} else if statusCode != http.StatusOK && statusCode != http.StatusAccepted && statusCode != http.StatusNotFound {
+			fmt.Fprintf(s.log, "    cleanup: DELETE %s returned %d\n", c.path, statusCode)
+		}
+	}
+	s.cleanups = nil
+}
+
+// expect sends the request to svc, checks that it returns statusCode and decodes the JSON response into out, if any
+func (s *session) expect(method string, svc cli.Service, path string, body interface{}, statusCode int, out interface{}) error {
+	actual, content, err := s.client.Do(method, svc, path, body)
+	if err != nil {
+		return err
+	}
+	if actual != statusCode {
+		message := abbreviate(content)
+		var response common.BaseResponse
+		if json.Unmarshal(content, &response) == nil && response.Message != nil {
+			message = fmt.Sprint(response.Message)
+		}
+		return fmt.Errorf("%s %s of %s returned %d rather than %d: %s", method, path, svc.Name, actual, statusCode, message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("the response of %s %s of %s isn't the expected JSON: %s: %s",
+			method, path, svc.Name, err.Error(), abbreviate(content))
+	}
+	return nil
+}
+
+// add sends the batch add request to svc, checks that the object is added and returns its id
+func (s *session) add(svc cli.Service, path string, request interface{}) (string, error) {
+	var responses []common.BaseWithIdResponse
+	if err := s.expect(http.MethodPost, svc, path, []interface{}{request}, http.StatusMultiStatus, &responses); err != nil {
+		return "", err
+	}
+	if len(responses) != 1 {
+		return "", fmt.Errorf("POST %s of %s returned %d responses rather than 1", path, svc.Name, len(responses))
+	}
+	if err := checkResponse(responses[0].BaseResponse, http.StatusCreated); err != nil {
+		return "", fmt.Errorf("POST %s of %s: %s", path, svc.Name, err.Error())
+	}
+	if responses[0].Id == "" {
+		return "", fmt.Errorf("POST %s of %s returned no id", path, svc.Name)
+	}
+	return responses[0].Id, nil
+}
+
+// checkVersion checks the API version of a response
+func checkVersion(v common.Versionable) error {
+	if v.ApiVersion != v2.ApiVersion {
+		return fmt.Errorf("apiVersion is %q rather than %q", v.ApiVersion, v2.ApiVersion)
+	}
+	return nil
+}
+
+// checkResponse checks the API version and the status code of a response
+func checkResponse(r common.BaseResponse, statusCode int) error {
+	if err := checkVersion(r.Versionable); err != nil {
+		return err
+	}
+	if r.StatusCode != statusCode {
+		if r.Message != nil {
+			return fmt.Errorf("statusCode is %d rather than %d: %v", r.StatusCode, statusCode, r.Message)
+		}
+		return fmt.Errorf("statusCode is %d rather than %d", r.StatusCode, statusCode)
+	}
+	return nil
+}
+
+// abbreviate returns the beginning of the content of a response for the failure messages
+func abbreviate(content []byte) string {
+	const maxLength = 200
+	s := strings.TrimSpace(string(content))
+	if len(s) > maxLength {
+		return s[:maxLength] + "..."
+	}
+	return s
+}
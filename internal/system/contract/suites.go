@@ -0,0 +1,491 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package contract
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/features"
+	"github.com/edgexfoundry/edgex-go/internal/system/cli"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+)
+
+// resourceName is the device resource and core command of the profile added by the tests
+const resourceName = "temperature"
+
+// suites returns the contract tests of the services, each suite starting with the endpoints common to all services
+func suites() []suite {
+	return []suite{
+		{name: cli.CoreMetadata.Name, service: cli.CoreMetadata, cases: append(commonCases(),
+			testCase{"device service lifecycle", testDeviceServiceLifecycle},
+			testCase{"device profile lifecycle", testDeviceProfileLifecycle},
+			testCase{"device lifecycle", testDeviceLifecycle},
+			testCase{"invalid device", testInvalidDevice},
+		)},
+		{name: cli.CoreData.Name, service: cli.CoreData, cases: append(commonCases(),
+			testCase{"event lifecycle", testEventLifecycle},
+			testCase{"invalid event", testInvalidEvent},
+		)},
+		{name: cli.CoreCommand.Name, service: cli.CoreCommand, cases: append(commonCases(),
+			testCase{"device commands", testDeviceCommands},
+			testCase{"unknown device commands", testUnknownDeviceCommands},
+		)},
+		{name: cli.Notifications.Name, service: cli.Notifications, cases: append(commonCases(),
+			testCase{"subscription lifecycle", testSubscriptionLifecycle},
+		)},
+		{name: cli.Scheduler.Name, service: cli.Scheduler, cases: append(commonCases(),
+			testCase{"invalid interval", testInvalidInterval},
+		)},
+	}
+}
+
+// commonCases returns the tests of the endpoints common to all services
+func commonCases() []testCase {
+	return []testCase{
+		{"ping", testPing},
+		{"version", testVersion},
+		{"config", testConfig},
+		{"metrics", testMetrics},
+		{"capabilities", testCapabilities},
+	}
+}
+
+func withParam(route string, param string, value string) string {
+	return strings.Replace(route, "{"+param+"}", url.PathEscape(value), 1)
+}
+
+/* ----------------------------- Common ---------------------------------- */
+
+func testPing(s *session) error {
+	var response common.PingResponse
+	if err := s.expect(http.MethodGet, s.service, v2.ApiPingRoute, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err := checkVersion(response.Versionable); err != nil {
+		return err
+	}
+	if response.Timestamp == "" {
+		return fmt.Errorf("the ping response has no timestamp")
+	}
+	return nil
+}
+
+func testVersion(s *session) error {
+	var response common.VersionResponse
+	if err := s.expect(http.MethodGet, s.service, v2.ApiVersionRoute, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err := checkVersion(response.Versionable); err != nil {
+		return err
+	}
+	if response.Version == "" {
+		return fmt.Errorf("the version response has no version")
+	}
+	return nil
+}
+
+func testConfig(s *session) error {
+	var response common.ConfigResponse
+	if err := s.expect(http.MethodGet, s.service, v2.ApiConfigRoute, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err := checkVersion(response.Versionable); err != nil {
+		return err
+	}
+	if response.Config == nil {
+		return fmt.Errorf("the config response has no config")
+	}
+	return nil
+}
+
+func testMetrics(s *session) error {
+	var response common.MetricsResponse
+	if err := s.expect(http.MethodGet, s.service, v2.ApiMetricsRoute, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err := checkVersion(response.Versionable); err != nil {
+		return err
+	}
+	if response.Metrics.MemSys == 0 {
+		return fmt.Errorf("the metrics response has no memory metrics")
+	}
+	return nil
+}
+
+func testCapabilities(s *session) error {
+	var response features.CapabilitiesResponse
+	if err := s.expect(http.MethodGet, s.service, features.ApiCapabilitiesRoute, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err := checkVersion(response.Versionable); err != nil {
+		return err
+	}
+	for _, feature := range response.Features {
+		if feature.Enabled && !feature.Compiled {
+			return fmt.Errorf("%s is reported enabled but not compiled", feature.Name)
+		}
+	}
+	return nil
+}
+
+/* ----------------------------- Core Metadata ---------------------------------- */
+
+func deviceServiceDTO(name string) dtos.DeviceService {
+	return dtos.DeviceService{
+		Name:        name,
+		Description: "Added by the contract tests",
+		BaseAddress: "http://localhost:59999",
+		AdminState:  models.Unlocked,
+		Labels:      []string{"contract-tests"},
+	}
+}
+
+func deviceProfileDTO(name string) dtos.DeviceProfile {
+	return dtos.DeviceProfile{
+		Name:         name,
+		Manufacturer: "EdgeX",
+		Model:        "contract-tests",
+		Labels:       []string{"contract-tests"},
+		DeviceResources: []dtos.DeviceResource{{
+			Name:       resourceName,
+			Properties: dtos.PropertyValue{ValueType: v2.ValueTypeInt64, ReadWrite: "R"},
+		}},
+		DeviceCommands: []dtos.DeviceCommand{{
+			Name: resourceName,
+			Get:  []dtos.ResourceOperation{{DeviceResource: resourceName}},
+		}},
+		CoreCommands: []dtos.Command{{Name: resourceName, Get: true}},
+	}
+}
+
+func deviceDTO(name string, serviceName string, profileName string) dtos.Device {
+	return dtos.Device{
+		Name:           name,
+		Description:    "Added by the contract tests",
+		AdminState:     models.Unlocked,
+		OperatingState: models.Up,
+		Labels:         []string{"contract-tests"},
+		ServiceName:    serviceName,
+		ProfileName:    profileName,
+		Protocols:      map[string]dtos.ProtocolProperties{"other": {"address": "contract-tests"}},
+	}
+}
+
+// addDevice adds a device, with its device service and profile, deleted at the end of the test, and returns the
+// names of the profile and the device
+func addDevice(s *session) (string, string, error) {
+	serviceName, profileName, deviceName := s.name("service"), s.name("profile"), s.name("device")
+	s.deleteAtEnd(cli.CoreMetadata, withParam(v2.ApiDeviceServiceByNameRoute, v2.Name, serviceName))
+	if _, err := s.add(cli.CoreMetadata, v2.ApiDeviceServiceRoute, requests.NewAddDeviceServiceRequest(deviceServiceDTO(serviceName))); err != nil {
+		return "", "", err
+	}
+	s.deleteAtEnd(cli.CoreMetadata, withParam(v2.ApiDeviceProfileByNameRoute, v2.Name, profileName))
+	if _, err := s.add(cli.CoreMetadata, v2.ApiDeviceProfileRoute, requests.NewDeviceProfileRequest(deviceProfileDTO(profileName))); err != nil {
+		return "", "", err
+	}
+	s.deleteAtEnd(cli.CoreMetadata, withParam(v2.ApiDeviceByNameRoute, v2.Name, deviceName))
+	if _, err := s.add(cli.CoreMetadata, v2.ApiDeviceRoute, requests.NewAddDeviceRequest(deviceDTO(deviceName, serviceName, profileName))); err != nil {
+		return "", "", err
+	}
+	return profileName, deviceName, nil
+}
+
+// deleteAndCheck deletes path of svc and checks that getPath isn't found anymore
+func deleteAndCheck(s *session, svc cli.Service, path string, getPath string) error {
+	var response common.BaseResponse
+	if err := s.expect(http.MethodDelete, svc, path, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err := checkResponse(response, http.StatusOK); err != nil {
+		return fmt.Errorf("DELETE %s: %s", path, err.Error())
+	}
+	return s.expect(http.MethodGet, svc, getPath, nil, http.StatusNotFound, nil)
+}
+
+func testDeviceServiceLifecycle(s *session) error {
+	name := s.name("service")
+	byName := withParam(v2.ApiDeviceServiceByNameRoute, v2.Name, name)
+	s.deleteAtEnd(cli.CoreMetadata, byName)
+	id, err := s.add(cli.CoreMetadata, v2.ApiDeviceServiceRoute, requests.NewAddDeviceServiceRequest(deviceServiceDTO(name)))
+	if err != nil {
+		return err
+	}
+
+	var duplicates []common.BaseWithIdResponse
+	err = s.expect(http.MethodPost, cli.CoreMetadata, v2.ApiDeviceServiceRoute,
+		[]interface{}{requests.NewAddDeviceServiceRequest(deviceServiceDTO(name))}, http.StatusMultiStatus, &duplicates)
+	if err != nil {
+		return err
+	}
+	if len(duplicates) != 1 || duplicates[0].StatusCode != http.StatusConflict {
+		return fmt.Errorf("adding a device service of the same name should be a conflict: %+v", duplicates)
+	}
+
+	var response responses.DeviceServiceResponse
+	if err = s.expect(http.MethodGet, cli.CoreMetadata, byName, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err = checkResponse(response.BaseResponse, http.StatusOK); err != nil {
+		return err
+	}
+	if response.Service.Id != id || response.Service.BaseAddress != "http://localhost:59999" {
+		return fmt.Errorf("the device service %s isn't the one added: %+v", name, response.Service)
+	}
+
+	locked := models.Locked
+	var patched []common.BaseResponse
+	err = s.expect(http.MethodPatch, cli.CoreMetadata, v2.ApiDeviceServiceRoute,
+		[]interface{}{requests.NewUpdateDeviceServiceRequest(dtos.UpdateDeviceService{Name: &name, AdminState: &locked})},
+		http.StatusMultiStatus, &patched)
+	if err != nil {
+		return err
+	}
+	if len(patched) != 1 {
+		return fmt.Errorf("PATCH %s returned %d responses rather than 1", v2.ApiDeviceServiceRoute, len(patched))
+	}
+	if err = checkResponse(patched[0], http.StatusOK); err != nil {
+		return fmt.Errorf("PATCH %s: %s", v2.ApiDeviceServiceRoute, err.Error())
+	}
+	if err = s.expect(http.MethodGet, cli.CoreMetadata, byName, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if response.Service.AdminState != models.Locked {
+		return fmt.Errorf("the admin state of the device service is %s rather than %s after the patch", response.Service.AdminState, models.Locked)
+	}
+
+	return deleteAndCheck(s, cli.CoreMetadata, byName, byName)
+}
+
+func testDeviceProfileLifecycle(s *session) error {
+	name := s.name("profile")
+	byName := withParam(v2.ApiDeviceProfileByNameRoute, v2.Name, name)
+	s.deleteAtEnd(cli.CoreMetadata, byName)
+	id, err := s.add(cli.CoreMetadata, v2.ApiDeviceProfileRoute, requests.NewDeviceProfileRequest(deviceProfileDTO(name)))
+	if err != nil {
+		return err
+	}
+
+	var response responses.DeviceProfileResponse
+	if err = s.expect(http.MethodGet, cli.CoreMetadata, byName, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err = checkResponse(response.BaseResponse, http.StatusOK); err != nil {
+		return err
+	}
+	if response.Profile.Id != id || len(response.Profile.DeviceResources) != 1 || response.Profile.DeviceResources[0].Name != resourceName {
+		return fmt.Errorf("the device profile %s isn't the one added: %+v", name, response.Profile)
+	}
+
+	var byModel responses.MultiDeviceProfilesResponse
+	path := withParam(v2.ApiDeviceProfileByModelRoute, v2.Model, "contract-tests") + "?" + v2.Limit + "=-1"
+	if err = s.expect(http.MethodGet, cli.CoreMetadata, path, nil, http.StatusOK, &byModel); err != nil {
+		return err
+	}
+	found := false
+	for _, p := range byModel.Profiles {
+		found = found || p.Name == name
+	}
+	if !found {
+		return fmt.Errorf("the device profile %s isn't listed by its model", name)
+	}
+
+	return deleteAndCheck(s, cli.CoreMetadata, byName, byName)
+}
+
+func testDeviceLifecycle(s *session) error {
+	profileName, deviceName, err := addDevice(s)
+	if err != nil {
+		return err
+	}
+	byName := withParam(v2.ApiDeviceByNameRoute, v2.Name, deviceName)
+
+	var response responses.DeviceResponse
+	if err = s.expect(http.MethodGet, cli.CoreMetadata, byName, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err = checkResponse(response.BaseResponse, http.StatusOK); err != nil {
+		return err
+	}
+	if response.Device.ProfileName != profileName || response.Device.AdminState != models.Unlocked {
+		return fmt.Errorf("the device %s isn't the one added: %+v", deviceName, response.Device)
+	}
+
+	exists := withParam(v2.ApiDeviceNameExistsRoute, v2.Name, deviceName)
+	if err = s.expect(http.MethodGet, cli.CoreMetadata, exists, nil, http.StatusOK, nil); err != nil {
+		return err
+	}
+
+	var byProfile responses.MultiDevicesResponse
+	if err = s.expect(http.MethodGet, cli.CoreMetadata, withParam(v2.ApiDeviceByProfileNameRoute, v2.Name, profileName), nil, http.StatusOK, &byProfile); err != nil {
+		return err
+	}
+	if len(byProfile.Devices) != 1 || byProfile.Devices[0].Name != deviceName {
+		return fmt.Errorf("the device %s isn't the only device of its profile: %+v", deviceName, byProfile.Devices)
+	}
+
+	if err = deleteAndCheck(s, cli.CoreMetadata, byName, byName); err != nil {
+		return err
+	}
+	return s.expect(http.MethodGet, cli.CoreMetadata, exists, nil, http.StatusNotFound, nil)
+}
+
+func testInvalidDevice(s *session) error {
+	// a device without service, profile nor protocols
+	device := requests.NewAddDeviceRequest(dtos.Device{Name: s.name("device"), AdminState: models.Unlocked, OperatingState: models.Up})
+	s.deleteAtEnd(cli.CoreMetadata, withParam(v2.ApiDeviceByNameRoute, v2.Name, device.Device.Name))
+	return s.expect(http.MethodPost, cli.CoreMetadata, v2.ApiDeviceRoute, []interface{}{device}, http.StatusBadRequest, nil)
+}
+
+/* ----------------------------- Core Data ---------------------------------- */
+
+func testEventLifecycle(s *session) error {
+	profileName, deviceName, err := addDevice(s)
+	if err != nil {
+		return err
+	}
+	event := dtos.NewEvent(profileName, deviceName)
+	reading, err := dtos.NewSimpleReading(profileName, deviceName, resourceName, v2.ValueTypeInt64, int64(21))
+	if err != nil {
+		return err
+	}
+	event.Readings = append(event.Readings, reading)
+	byId := withParam(v2.ApiEventIdRoute, v2.Id, event.Id)
+	s.deleteAtEnd(cli.CoreData, withParam(v2.ApiEventByDeviceNameRoute, v2.Name, deviceName))
+
+	var added common.BaseWithIdResponse
+	path := withParam(withParam(v2.ApiEventProfileNameDeviceNameRoute, v2.ProfileName, profileName), v2.DeviceName, deviceName)
+	if err = s.expect(http.MethodPost, cli.CoreData, path, requests.NewAddEventRequest(event), http.StatusCreated, &added); err != nil {
+		return err
+	}
+	if err = checkResponse(added.BaseResponse, http.StatusCreated); err != nil {
+		return err
+	}
+	if added.Id != event.Id {
+		return fmt.Errorf("the id of the event added is %s rather than %s", added.Id, event.Id)
+	}
+
+	var response responses.EventResponse
+	if err = s.expect(http.MethodGet, cli.CoreData, byId, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err = checkResponse(response.BaseResponse, http.StatusOK); err != nil {
+		return err
+	}
+	if len(response.Event.Readings) != 1 || response.Event.Readings[0].Value != "21" {
+		return fmt.Errorf("the event %s isn't the one added: %+v", event.Id, response.Event)
+	}
+
+	var count common.CountResponse
+	if err = s.expect(http.MethodGet, cli.CoreData, withParam(v2.ApiEventCountByDeviceNameRoute, v2.Name, deviceName), nil, http.StatusOK, &count); err != nil {
+		return err
+	}
+	if count.Count != 1 {
+		return fmt.Errorf("the device %s has %d events rather than 1", deviceName, count.Count)
+	}
+
+	var readings responses.MultiReadingsResponse
+	if err = s.expect(http.MethodGet, cli.CoreData, withParam(v2.ApiReadingByDeviceNameRoute, v2.Name, deviceName), nil, http.StatusOK, &readings); err != nil {
+		return err
+	}
+	if len(readings.Readings) != 1 || readings.Readings[0].ResourceName != resourceName {
+		return fmt.Errorf("the readings of the device %s aren't those of the event added: %+v", deviceName, readings.Readings)
+	}
+
+	return deleteAndCheck(s, cli.CoreData, byId, byId)
+}
+
+func testInvalidEvent(s *session) error {
+	// an event without readings
+	event := dtos.NewEvent(s.name("profile"), s.name("device"))
+	path := withParam(withParam(v2.ApiEventProfileNameDeviceNameRoute, v2.ProfileName, event.ProfileName), v2.DeviceName, event.DeviceName)
+	return s.expect(http.MethodPost, cli.CoreData, path, requests.NewAddEventRequest(event), http.StatusBadRequest, nil)
+}
+
+/* ----------------------------- Core Command ---------------------------------- */
+
+func testDeviceCommands(s *session) error {
+	profileName, deviceName, err := addDevice(s)
+	if err != nil {
+		return err
+	}
+
+	var response responses.DeviceCoreCommandResponse
+	if err = s.expect(http.MethodGet, cli.CoreCommand, withParam(v2.ApiDeviceByNameRoute, v2.Name, deviceName), nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err = checkResponse(response.BaseResponse, http.StatusOK); err != nil {
+		return err
+	}
+	commands := response.DeviceCoreCommand
+	if commands.DeviceName != deviceName || commands.ProfileName != profileName {
+		return fmt.Errorf("the commands aren't those of the device %s: %+v", deviceName, commands)
+	}
+	if len(commands.CoreCommands) != 1 || commands.CoreCommands[0].Name != resourceName || !commands.CoreCommands[0].Get {
+		return fmt.Errorf("the commands of the device %s aren't those of its profile: %+v", deviceName, commands.CoreCommands)
+	}
+	return nil
+}
+
+func testUnknownDeviceCommands(s *session) error {
+	return s.expect(http.MethodGet, cli.CoreCommand, withParam(v2.ApiDeviceByNameRoute, v2.Name, s.name("unknown")), nil, http.StatusNotFound, nil)
+}
+
+/* ----------------------------- Support Notifications ---------------------------------- */
+
+func testSubscriptionLifecycle(s *session) error {
+	name := s.name("subscription")
+	byName := withParam(v2.ApiSubscriptionByNameRoute, v2.Name, name)
+	s.deleteAtEnd(cli.Notifications, byName)
+	subscription := dtos.Subscription{
+		Name:       name,
+		Channels:   []dtos.Channel{{Type: models.Rest, Url: "http://localhost:59999/contract-tests"}},
+		Receiver:   s.name("receiver"),
+		Categories: []string{models.SoftwareHealth},
+		Labels:     []string{"contract-tests"},
+	}
+	id, err := s.add(cli.Notifications, v2.ApiSubscriptionRoute, requests.NewAddSubscriptionRequest(subscription))
+	if err != nil {
+		return err
+	}
+
+	var response responses.SubscriptionResponse
+	if err = s.expect(http.MethodGet, cli.Notifications, byName, nil, http.StatusOK, &response); err != nil {
+		return err
+	}
+	if err = checkResponse(response.BaseResponse, http.StatusOK); err != nil {
+		return err
+	}
+	if response.Subscription.Id != id || response.Subscription.Receiver != subscription.Receiver {
+		return fmt.Errorf("the subscription %s isn't the one added: %+v", name, response.Subscription)
+	}
+
+	var byReceiver responses.MultiSubscriptionsResponse
+	path := withParam(v2.ApiSubscriptionByReceiverRoute, v2.Receiver, subscription.Receiver)
+	if err = s.expect(http.MethodGet, cli.Notifications, path, nil, http.StatusOK, &byReceiver); err != nil {
+		return err
+	}
+	if len(byReceiver.Subscriptions) != 1 || byReceiver.Subscriptions[0].Name != name {
+		return fmt.Errorf("the subscription %s isn't the only subscription of its receiver: %+v", name, byReceiver.Subscriptions)
+	}
+
+	return deleteAndCheck(s, cli.Notifications, byName, byName)
+}
+
+/* ----------------------------- Support Scheduler ---------------------------------- */
+
+func testInvalidInterval(s *session) error {
+	interval := requests.NewAddIntervalRequest(dtos.Interval{Name: s.name("interval"), Frequency: "every minute"})
+	return s.expect(http.MethodPost, cli.Scheduler, v2.ApiIntervalRoute, []interface{}{interval}, http.StatusBadRequest, nil)
+}
@@ -0,0 +1,297 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package keyspace analyzes the EdgeX Redis keyspace to guide the retention settings of memory-constrained
+// deployments: the keys and memory used by each collection and index, the orphaned keys, and the devices with the
+// most readings.
+package keyspace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	redisClient "github.com/edgexfoundry/edgex-go/internal/pkg/v2/infrastructure/redis"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+)
+
+// scanCount is the number of keys scanned per batch, each batch being pipelined
+const scanCount = 1000
+
+// Collections are the collections of the v2 layout. Their objects are stored by id under the collection prefix and
+// are members of the sorted set named after the collection.
+var Collections = []string{
+	redisClient.EventsCollection,
+	redisClient.ReadingsCollection,
+	redisClient.DeviceCollection,
+	redisClient.DeviceProfileCollection,
+	redisClient.DeviceServiceCollection,
+	redisClient.ProvisionWatcherCollection,
+	redisClient.DeprecationCollection,
+	redisClient.IntervalCollection,
+	redisClient.SubscriptionCollection,
+	redisClient.TemplateCollection,
+	redisClient.MuteWindowCollection,
+	redisClient.OneShotCollection,
+	redisClient.DeviceScopeCollection,
+	redisClient.SystemEventCollection,
+}
+
+// objectIndexes are the indexes specific to an object, keyed by "<index>:<id>", with the collection of the object
+var objectIndexes = map[string]string{
+	redisClient.EventsCollectionReadings: redisClient.EventsCollection,
+}
+
+// Kinds of orphaned keys
+const (
+	// OrphanUnlisted objects aren't members of the sorted set of their collection, so no query returns them
+	OrphanUnlisted = "object missing from its collection"
+	// OrphanDangling members of the sorted set of a collection have no object
+	OrphanDangling = "collection member without object"
+	// OrphanIndex keys index an object that no longer exists, e.g. the readings of a deleted event
+	OrphanIndex = "index of a deleted object"
+)
+
+// Options tune the analysis
+type Options struct {
+	// Memory reports the memory used by the keys, which costs a MEMORY USAGE command per key
+	Memory bool
+	// Top is the number of devices listed by reading volume
+	Top int
+	// Samples is the number of keys listed for each kind of orphaned keys
+	Samples int
+}
+
+// Analyzer analyzes the keyspace of a Redis server
+type Analyzer struct {
+	conn    redis.Conn
+	options Options
+	report  *Report
+	orphans map[string]*Orphans
+	devices []DeviceVolume
+}
+
+// NewAnalyzer is a factory method that returns an Analyzer running its commands on conn
+func NewAnalyzer(conn redis.Conn, options Options) *Analyzer {
+	return &Analyzer{
+		conn:    conn,
+		options: options,
+	}
+}
+
+// key is a key of the keyspace as classified by classify
+type key struct {
+	name string
+	// collection is the v2 collection of the key, empty for the other keys
+	collection string
+	// group is the index of the collection the key belongs to, empty for objects, or the prefix of the other keys
+	group string
+	// object is the object key of the collection that an index key is specific to, if any
+	object string
+}
+
+// classify returns the collection and group of a key name. The objects of a collection are keyed by
+// "<collection>:<id>" and its indexes by "<collection>:<index>[:<value>...]". The other keys, e.g. those of the v1
+// layout, are grouped by their prefix.
+func classify(name string) key {
+	for _, collection := range Collections {
+		if name == collection {
+			return key{name: name, collection: collection, group: collection}
+		}
+		if !strings.HasPrefix(name, collection+redisClient.DBKeySeparator) {
+			continue
+		}
+
+		segments := strings.Split(name[len(collection)+len(redisClient.DBKeySeparator):], redisClient.DBKeySeparator)
+		if len(segments) == 1 && isId(segments[0]) {
+			return key{name: name, collection: collection}
+		}
+		k := key{name: name, collection: collection, group: redisClient.CreateKey(collection, segments[0])}
+		if objectCollection, ok := objectIndexes[k.group]; ok && len(segments) == 2 {
+			k.object = redisClient.CreateKey(objectCollection, segments[1])
+		}
+		return k
+	}
+
+	if isId(name) {
+		return key{name: name, group: "(v1 objects)"}
+	}
+	if i := strings.IndexAny(name, ":|"); i > 0 {
+		return key{name: name, group: name[:i]}
+	}
+	return key{name: name, group: name}
+}
+
+func isId(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil && len(value) == 36
+}
+
+// isDeviceReadings tells whether a key indexes the readings of a device
+func isDeviceReadings(name string) bool {
+	return strings.HasPrefix(name, redisClient.ReadingsCollectionDeviceName+redisClient.DBKeySeparator)
+}
+
+// Analyze scans the whole keyspace and returns the report. The keys written during the analysis may be reported as
+// orphaned, so the services writing to Redis are best stopped.
+func (a *Analyzer) Analyze() (*Report, error) {
+	a.report = newReport(a.options.Memory)
+	a.orphans = make(map[string]*Orphans)
+	a.devices = nil
+
+	cursor := "0"
+	for {
+		values, err := redis.Values(a.conn.Do("SCAN", cursor, "COUNT", scanCount))
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan the keyspace: %s", err.Error())
+		}
+		var names []string
+		if _, err := redis.Scan(values, &cursor, &names); err != nil {
+			return nil, fmt.Errorf("failed to scan the keyspace: %s", err.Error())
+		}
+		if err := a.analyzeKeys(names); err != nil {
+			return nil, err
+		}
+		if cursor == "0" {
+			break
+		}
+	}
+
+	for _, collection := range Collections {
+		if err := a.findDanglingMembers(collection); err != nil {
+			return nil, err
+		}
+	}
+
+	a.report.finish(a.orphans, a.devices, a.options.Top)
+	return a.report, nil
+}
+
+// analyzeKeys runs the commands analyzing a batch of keys in a pipeline, in the order the replies are then read
+func (a *Analyzer) analyzeKeys(names []string) error {
+	keys := make([]key, len(names))
+	for i, name := range names {
+		k := classify(name)
+		keys[i] = k
+		if a.options.Memory {
+			_ = a.conn.Send("MEMORY", "USAGE", name)
+		}
+		switch {
+		case k.collection != "" && k.group == "":
+			_ = a.conn.Send("ZSCORE", k.collection, name)
+		case k.object != "":
+			_ = a.conn.Send("EXISTS", k.object)
+		}
+		if isDeviceReadings(name) {
+			_ = a.conn.Send("ZCARD", name)
+		}
+	}
+	if err := a.conn.Flush(); err != nil {
+		return fmt.Errorf("failed to analyze keys: %s", err.Error())
+	}
+
+	for _, k := range keys {
+		var memory int64
+		if a.options.Memory {
+			var err error
+			// The keys deleted since they were scanned have no memory usage
+			if memory, err = redis.Int64(a.conn.Receive()); err != nil && err != redis.ErrNil {
+				return fmt.Errorf("failed to get the memory usage of %s: %s", k.name, err.Error())
+			}
+		}
+		a.report.add(k, memory)
+
+		switch {
+		case k.collection != "" && k.group == "":
+			_, err := redis.String(a.conn.Receive())
+			if err == redis.ErrNil {
+				a.addOrphan(k.collection, OrphanUnlisted, k.name)
+			} else if err != nil {
+				return fmt.Errorf("failed to check that %s is listed: %s", k.name, err.Error())
+			}
+		case k.object != "":
+			exists, err := redis.Bool(a.conn.Receive())
+			if err != nil {
+				return fmt.Errorf("failed to check that %s exists: %s", k.object, err.Error())
+			}
+			if !exists {
+				a.addOrphan(k.collection, OrphanIndex, k.name)
+			}
+		}
+		if isDeviceReadings(k.name) {
+			count, err := redis.Int64(a.conn.Receive())
+			if err != nil {
+				return fmt.Errorf("failed to count the readings of %s: %s", k.name, err.Error())
+			}
+			a.devices = append(a.devices, DeviceVolume{
+				Name:     k.name[len(redisClient.ReadingsCollectionDeviceName)+len(redisClient.DBKeySeparator):],
+				Readings: count,
+			})
+		}
+	}
+	return nil
+}
+
+// findDanglingMembers finds the members of the sorted set of a collection whose object doesn't exist
+func (a *Analyzer) findDanglingMembers(collection string) error {
+	cursor := "0"
+	for {
+		values, err := redis.Values(a.conn.Do("ZSCAN", collection, cursor, "COUNT", scanCount))
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %s", collection, err.Error())
+		}
+		var membersAndScores []string
+		if _, err := redis.Scan(values, &cursor, &membersAndScores); err != nil {
+			return fmt.Errorf("failed to scan %s: %s", collection, err.Error())
+		}
+
+		var members []string
+		for i := 0; i < len(membersAndScores); i += 2 {
+			members = append(members, membersAndScores[i])
+			_ = a.conn.Send("EXISTS", membersAndScores[i])
+		}
+		if err := a.conn.Flush(); err != nil {
+			return fmt.Errorf("failed to check the members of %s: %s", collection, err.Error())
+		}
+		for _, member := range members {
+			exists, err := redis.Bool(a.conn.Receive())
+			if err != nil {
+				return fmt.Errorf("failed to check that %s exists: %s", member, err.Error())
+			}
+			if !exists {
+				a.addOrphan(collection, OrphanDangling, member)
+			}
+		}
+
+		if cursor == "0" {
+			return nil
+		}
+	}
+}
+
+func (a *Analyzer) addOrphan(collection string, kind string, name string) {
+	id := collection + " " + kind
+	orphans, ok := a.orphans[id]
+	if !ok {
+		orphans = &Orphans{Collection: collection, Kind: kind}
+		a.orphans[id] = orphans
+	}
+	orphans.Keys++
+	if len(orphans.Samples) < a.options.Samples {
+		orphans.Samples = append(orphans.Samples, name)
+	}
+}
+
+// sortDevices sorts the devices by decreasing reading volume, then by name
+func sortDevices(devices []DeviceVolume) {
+	sort.Slice(devices, func(i, j int) bool {
+		if devices[i].Readings != devices[j].Readings {
+			return devices[i].Readings > devices[j].Readings
+		}
+		return devices[i].Name < devices[j].Name
+	})
+}
@@ -0,0 +1,93 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keyspace
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEventId   = "9b9e1b2c-3c3a-4c1f-9d9a-1f8f2e6b5a10"
+	testReadingId = "0f6a5b2e-7d3c-4b1a-8e9f-2a1b3c4d5e6f"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		expected key
+	}{
+		{"object", "cd|evt:" + testEventId, key{collection: "cd|evt"}},
+		{"collection", "cd|rd", key{collection: "cd|rd", group: "cd|rd"}},
+		{"index", "cd|rd:created", key{collection: "cd|rd", group: "cd|rd:created"}},
+		{"index by value", "cd|rd:device:name:Random-Device", key{collection: "cd|rd", group: "cd|rd:device"}},
+		{"index of an object", "cd|evt:readings:" + testEventId, key{collection: "cd|evt", group: "cd|evt:readings", object: "cd|evt:" + testEventId}},
+		{"id not indexing an object", "md|dv:name:" + testEventId, key{collection: "md|dv", group: "md|dv:name"}},
+		{"v1 object", testReadingId, key{group: "(v1 objects)"}},
+		{"v1 index", "reading:device:Random-Device", key{group: "reading"}},
+		{"other", "edgex-core-data:leader", key{group: "edgex-core-data"}},
+		{"unknown collection", "xx|yy:zz", key{group: "xx"}},
+		{"no prefix", "lonely", key{group: "lonely"}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.expected.name = testCase.key
+			assert.Equal(t, testCase.expected, classify(testCase.key))
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	report := newReport(true)
+	report.add(classify("cd|evt:"+testEventId), 200)
+	report.add(classify("cd|evt"), 100)
+	report.add(classify("cd|rd:"+testReadingId), 150)
+	report.add(classify("cd|rd:device:name:d1"), 1000)
+	report.add(classify("cd|rd:device:name:d2"), 500)
+	report.add(classify("cd|rd:created"), 2000)
+	report.add(classify(testReadingId), 10)
+	orphans := map[string]*Orphans{
+		"cd|rd " + OrphanDangling: {Collection: "cd|rd", Kind: OrphanDangling, Keys: 2},
+		"cd|evt " + OrphanIndex:   {Collection: "cd|evt", Kind: OrphanIndex, Keys: 1},
+	}
+	devices := []DeviceVolume{{"d1", 10}, {"d3", 30}, {"d2", 30}}
+	report.finish(orphans, devices, 2)
+
+	assert.Equal(t, Stats{Keys: 7, Memory: 3960}, report.Total)
+	require.Len(t, report.Collections, 2)
+	rd := report.Collections[0]
+	assert.Equal(t, "cd|rd", rd.Name, "the collections must be sorted by decreasing memory")
+	assert.Equal(t, Stats{Keys: 4, Memory: 3650}, rd.Total)
+	assert.Equal(t, Stats{Keys: 1, Memory: 150}, rd.Objects)
+	assert.Equal(t, []Group{
+		{Name: "cd|rd:created", Stats: Stats{Keys: 1, Memory: 2000}},
+		{Name: "cd|rd:device", Stats: Stats{Keys: 2, Memory: 1500}},
+	}, rd.Indexes)
+	assert.Equal(t, []Group{{Name: "(v1 objects)", Stats: Stats{Keys: 1, Memory: 10}}}, report.Other)
+	assert.Equal(t, "cd|evt", report.Orphans[0].Collection)
+	assert.Equal(t, []DeviceVolume{{"d2", 30}, {"d3", 30}}, report.TopDevices)
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteText(&out))
+	assert.Contains(t, out.String(), "3.6 KiB")
+	assert.Contains(t, out.String(), "cd|rd:device")
+	assert.Contains(t, out.String(), OrphanDangling)
+}
+
+func TestMemory(t *testing.T) {
+	report := newReport(true)
+	assert.Equal(t, "512 B", report.memory(512))
+	assert.Equal(t, "1.5 KiB", report.memory(1536))
+	assert.Equal(t, "2.0 MiB", report.memory(2*1024*1024))
+	assert.Equal(t, "3072.0 GiB", report.memory(3*1024*1024*1024*1024))
+
+	report = newReport(false)
+	assert.Equal(t, "-", report.memory(512))
+}
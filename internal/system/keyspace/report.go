@@ -0,0 +1,221 @@
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keyspace
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Stats are the number of keys of a group of keys and the memory they use in bytes
+type Stats struct {
+	Keys   int64 `json:"keys"`
+	Memory int64 `json:"memory"`
+}
+
+func (s *Stats) add(memory int64) {
+	s.Keys++
+	s.Memory += memory
+}
+
+// Group is a group of keys, an index of a collection or the other keys sharing a prefix
+type Group struct {
+	Name  string `json:"name"`
+	Stats `json:",inline"`
+}
+
+// Collection reports the keys of a collection of the v2 layout
+type Collection struct {
+	Name    string  `json:"name"`
+	Total   Stats   `json:"total"`
+	Objects Stats   `json:"objects"`
+	Indexes []Group `json:"indexes"`
+}
+
+// Orphans are the orphaned keys of a kind in a collection, with some of them as samples
+type Orphans struct {
+	Collection string   `json:"collection"`
+	Kind       string   `json:"kind"`
+	Keys       int64    `json:"keys"`
+	Samples    []string `json:"samples,omitempty"`
+}
+
+// DeviceVolume is the number of readings stored for a device
+type DeviceVolume struct {
+	Name     string `json:"name"`
+	Readings int64  `json:"readings"`
+}
+
+// Report is the result of the analysis of a keyspace. The collections and groups are sorted by decreasing memory,
+// or number of keys when the memory isn't reported.
+type Report struct {
+	Total          Stats          `json:"total"`
+	MemoryReported bool           `json:"memoryReported"`
+	Collections    []Collection   `json:"collections"`
+	Other          []Group        `json:"other"`
+	Orphans        []Orphans      `json:"orphans"`
+	TopDevices     []DeviceVolume `json:"topDevices"`
+
+	collections map[string]*Collection
+	indexes     map[string]map[string]*Stats
+	other       map[string]*Stats
+}
+
+func newReport(memoryReported bool) *Report {
+	return &Report{
+		MemoryReported: memoryReported,
+		collections:    make(map[string]*Collection),
+		indexes:        make(map[string]map[string]*Stats),
+		other:          make(map[string]*Stats),
+	}
+}
+
+// add accounts for a key using memory bytes
+func (r *Report) add(k key, memory int64) {
+	r.Total.add(memory)
+
+	if k.collection == "" {
+		addToGroup(r.other, k.group, memory)
+		return
+	}
+	c, ok := r.collections[k.collection]
+	if !ok {
+		c = &Collection{Name: k.collection}
+		r.collections[k.collection] = c
+		r.indexes[k.collection] = make(map[string]*Stats)
+	}
+	c.Total.add(memory)
+	if k.group == "" {
+		c.Objects.add(memory)
+		return
+	}
+	addToGroup(r.indexes[k.collection], k.group, memory)
+}
+
+func addToGroup(groups map[string]*Stats, name string, memory int64) {
+	stats, ok := groups[name]
+	if !ok {
+		stats = &Stats{}
+		groups[name] = stats
+	}
+	stats.add(memory)
+}
+
+// finish sorts the report once every key is accounted for, keeping the top devices by reading volume
+func (r *Report) finish(orphans map[string]*Orphans, devices []DeviceVolume, top int) {
+	r.Collections = make([]Collection, 0, len(r.collections))
+	for name, c := range r.collections {
+		c.Indexes = r.sortedGroups(r.indexes[name])
+		r.Collections = append(r.Collections, *c)
+	}
+	sort.Slice(r.Collections, func(i, j int) bool {
+		return r.before(r.Collections[i].Total, r.Collections[j].Total, r.Collections[i].Name, r.Collections[j].Name)
+	})
+	r.Other = r.sortedGroups(r.other)
+
+	r.Orphans = make([]Orphans, 0, len(orphans))
+	for _, o := range orphans {
+		r.Orphans = append(r.Orphans, *o)
+	}
+	sort.Slice(r.Orphans, func(i, j int) bool {
+		if r.Orphans[i].Collection != r.Orphans[j].Collection {
+			return r.Orphans[i].Collection < r.Orphans[j].Collection
+		}
+		return r.Orphans[i].Kind < r.Orphans[j].Kind
+	})
+
+	sortDevices(devices)
+	if len(devices) > top {
+		devices = devices[:top]
+	}
+	r.TopDevices = devices
+}
+
+func (r *Report) sortedGroups(groups map[string]*Stats) []Group {
+	sorted := make([]Group, 0, len(groups))
+	for name, stats := range groups {
+		sorted = append(sorted, Group{Name: name, Stats: *stats})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return r.before(sorted[i].Stats, sorted[j].Stats, sorted[i].Name, sorted[j].Name)
+	})
+	return sorted
+}
+
+// before tells whether the stats a come before b: by decreasing memory when reported, then by decreasing number of
+// keys, then by name
+func (r *Report) before(a Stats, b Stats, nameA string, nameB string) bool {
+	if r.MemoryReported && a.Memory != b.Memory {
+		return a.Memory > b.Memory
+	}
+	if a.Keys != b.Keys {
+		return a.Keys > b.Keys
+	}
+	return nameA < nameB
+}
+
+// WriteText writes the report as tables
+func (r *Report) WriteText(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Keys: %d\tMemory: %s\n", r.Total.Keys, r.memory(r.Total.Memory))
+
+	fmt.Fprintf(w, "\nCOLLECTION\tKEYS\tMEMORY\tOBJECTS\tOBJECTS MEMORY\n")
+	for _, c := range r.Collections {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%s\n", c.Name, c.Total.Keys, r.memory(c.Total.Memory), c.Objects.Keys, r.memory(c.Objects.Memory))
+	}
+
+	fmt.Fprintf(w, "\nINDEX\tKEYS\tMEMORY\n")
+	for _, c := range r.Collections {
+		for _, index := range c.Indexes {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", index.Name, index.Keys, r.memory(index.Memory))
+		}
+	}
+
+	if len(r.Other) > 0 {
+		fmt.Fprintf(w, "\nOTHER KEYS\tKEYS\tMEMORY\n")
+		for _, group := range r.Other {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", group.Name, group.Keys, r.memory(group.Memory))
+		}
+	}
+
+	fmt.Fprintf(w, "\nORPHANED KEYS\tKIND\tKEYS\tSAMPLES\n")
+	if len(r.Orphans) == 0 {
+		fmt.Fprintf(w, "none\t\t\t\n")
+	}
+	for _, o := range r.Orphans {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", o.Collection, o.Kind, o.Keys, strings.Join(o.Samples, " "))
+	}
+
+	fmt.Fprintf(w, "\nDEVICE\tREADINGS\n")
+	for _, d := range r.TopDevices {
+		fmt.Fprintf(w, "%s\t%d\n", d.Name, d.Readings)
+	}
+
+	return w.Flush()
+}
+
+// memory formats bytes in binary units, or returns "-" when the memory isn't reported
+func (r *Report) memory(bytes int64) string {
+	if !r.MemoryReported {
+		return "-"
+	}
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes)
+	for _, prefix := range []string{"KiB", "MiB", "GiB"} {
+		value /= unit
+		if value < unit || prefix == "GiB" {
+			return fmt.Sprintf("%.1f %s", value, prefix)
+		}
+	}
+	return ""
+}